@@ -97,7 +97,7 @@ func main() {
 	// Create Amazon-aligned fulfillment activities
 	receivingActivities := activities.NewReceivingActivities()
 	stowActivities := activities.NewStowActivities()
-	slamActivities := activities.NewSLAMActivities()
+	slamActivities := activities.NewSLAMActivities(serviceClients)
 	sortationActivities := activities.NewSortationActivities()
 
 	// Create unit-level tracking activities
@@ -177,6 +177,7 @@ func main() {
 	// Register picking activities
 	w.RegisterActivity(pickingActivities.CreatePickTask)
 	w.RegisterActivity(pickingActivities.AssignPickerToTask)
+	w.RegisterActivity(pickingActivities.CheckWaveReplenished)
 
 	// Register consolidation activities
 	w.RegisterActivity(consolidationActivities.CreateConsolidationUnit)
@@ -268,6 +269,7 @@ func main() {
 	// Note: ScanPackage is not registered here to avoid conflict with ShippingActivities.ScanPackage
 	// SLAM's ScanPackage is called internally by ExecuteSLAM via Go method call
 	w.RegisterActivity(slamActivities.GenerateLabel)
+	w.RegisterActivity(slamActivities.VoidLabel)
 	w.RegisterActivity(slamActivities.ApplyLabel)
 	w.RegisterActivity(slamActivities.AddToManifest)
 	w.RegisterActivity(slamActivities.VerifyWeight)
@@ -313,6 +315,7 @@ func main() {
 		"CalculateMultiRoute",
 		"CreatePickTask",
 		"AssignPickerToTask",
+		"CheckWaveReplenished",
 		"CreateConsolidationUnit",
 		"ConsolidateItems",
 		"VerifyConsolidation",
@@ -383,6 +386,7 @@ func main() {
 		// SLAM activities
 		"ScanPackage",
 		"GenerateLabel",
+		"VoidLabel",
 		"ApplyLabel",
 		"AddToManifest",
 		"VerifyWeight",
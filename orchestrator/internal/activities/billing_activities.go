@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/wms-platform/orchestrator/internal/activities/clients"
 	"go.temporal.io/sdk/activity"
 )
 
@@ -154,6 +155,18 @@ func (a *BillingActivities) RecordFulfillmentFees(ctx context.Context, input map
 			"description": fmt.Sprintf("Shipping fee for order %s via %s", orderID, carrier),
 		}
 
+		// Shipping is billed by the billable weight shipping-service recorded on the shipment,
+		// passed as metadata since billing keeps it with the activity
+		trackingNumber, _ := input["trackingNumber"].(string)
+		if billable := a.shipmentBillableWeight(ctx, trackingNumber); billable != nil {
+			shippingFeeInput["metadata"] = map[string]interface{}{
+				"trackingNumber":     trackingNumber,
+				"billableWeightKg":   billable.BillableWeight,
+				"additionalHandling": billable.AdditionalHandling,
+				"oversize":           billable.Oversize,
+			}
+		}
+
 		shippingResult, err := a.recordActivity(ctx, shippingFeeInput)
 		if err != nil {
 			logger.Warn("Failed to record shipping fee", "error", err)
@@ -252,6 +265,24 @@ func (a *BillingActivities) RecordFulfillmentFees(ctx context.Context, input map
 	return result, nil
 }
 
+// shipmentBillableWeight looks up the billable weight of the shipment with the tracking
+// number. It returns nil when there is none, and the fee is then billed from actual weight.
+func (a *BillingActivities) shipmentBillableWeight(ctx context.Context, trackingNumber string) *clients.BillableWeight {
+	if trackingNumber == "" {
+		return nil
+	}
+
+	shipment, err := a.clients.GetShipmentByTracking(ctx, trackingNumber)
+	if err != nil {
+		activity.GetLogger(ctx).Warn("Failed to get shipment billable weight", "trackingNumber", trackingNumber, "error", err)
+		return nil
+	}
+	if shipment.BillableWeight == nil || shipment.BillableWeight.BillableWeight <= 0 {
+		return nil
+	}
+	return shipment.BillableWeight
+}
+
 // recordActivity calls the billing service to record a billable activity
 func (a *BillingActivities) recordActivity(ctx context.Context, input map[string]interface{}) (map[string]interface{}, error) {
 	// Call billing service API
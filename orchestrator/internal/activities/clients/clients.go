@@ -56,6 +56,21 @@ func NewServiceClients(config *Config) *ServiceClients {
 	}
 }
 
+// StatusError is returned when a service answers with an error status
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// IsClientError reports whether the service rejected the request, so repeating it won't help
+func (e *StatusError) IsClientError() bool {
+	return e.StatusCode >= 400 && e.StatusCode < 500 && e.StatusCode != http.StatusTooManyRequests
+}
+
 // doRequest performs an HTTP request and decodes the response
 func (c *ServiceClients) doRequest(ctx context.Context, method, url string, body interface{}, result interface{}) error {
 	var reqBody io.Reader
@@ -109,7 +124,7 @@ func (c *ServiceClients) doRequest(ctx context.Context, method, url string, body
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+		return &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	if result != nil && len(respBody) > 0 {
@@ -287,6 +302,16 @@ func (c *ServiceClients) GetInventoryBySKU(ctx context.Context, sku string) (*In
 	return &result, nil
 }
 
+// GetWaveReplenishments lists the replenishment tasks created to top off pick faces for a wave
+func (c *ServiceClients) GetWaveReplenishments(ctx context.Context, waveID string) ([]ReplenishmentTask, error) {
+	url := fmt.Sprintf("%s/api/v1/inventory/replenishments?waveId=%s", c.config.InventoryServiceURL, waveID)
+	var result []ReplenishmentTask
+	if err := c.doRequest(ctx, http.MethodGet, url, nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // RoutingService methods
 
 // CalculateRoute calculates the optimal pick route
@@ -481,6 +506,16 @@ func (c *ServiceClients) GetShipment(ctx context.Context, shipmentID string) (*S
 	return &result, nil
 }
 
+// GetShipmentByTracking retrieves a shipment by its tracking number
+func (c *ServiceClients) GetShipmentByTracking(ctx context.Context, trackingNumber string) (*Shipment, error) {
+	url := fmt.Sprintf("%s/api/v1/shipments/tracking/%s", c.config.ShippingServiceURL, trackingNumber)
+	var result Shipment
+	if err := c.doRequest(ctx, http.MethodGet, url, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // GenerateLabelRequest represents a request to generate a label
 type GenerateLabelRequest struct {
 	LabelFormat string `json:"labelFormat"`
@@ -505,6 +540,16 @@ func (c *ServiceClients) MarkShipped(ctx context.Context, shipmentID string) err
 	return c.doRequest(ctx, http.MethodPost, url, nil, nil)
 }
 
+// VoidShipmentLabel cancels a label with its carrier
+func (c *ServiceClients) VoidShipmentLabel(ctx context.Context, trackingNumber, reason string) (*Shipment, error) {
+	url := fmt.Sprintf("%s/api/v1/shipments/tracking/%s/void", c.config.ShippingServiceURL, trackingNumber)
+	var result Shipment
+	if err := c.doRequest(ctx, http.MethodPost, url, map[string]string{"reason": reason}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // LaborService methods
 
 // AssignWorker assigns a worker to a task
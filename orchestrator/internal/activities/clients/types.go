@@ -17,11 +17,14 @@ type Order struct {
 
 // OrderItem represents an item in an order
 type OrderItem struct {
-	SKU      string  `json:"sku"`
-	Name     string  `json:"name"`
-	Quantity int     `json:"quantity"`
-	Price    float64 `json:"price"`
-	Weight   float64 `json:"weight"`
+	SKU        string      `json:"sku"`
+	Name       string      `json:"name"`
+	Quantity   int         `json:"quantity"`
+	Price      float64     `json:"price"`
+	Weight     float64     `json:"weight"`
+	Dimensions *Dimensions `json:"dimensions,omitempty"`
+	IsFragile  bool        `json:"isFragile"`
+	IsHazmat   bool        `json:"isHazmat"`
 }
 
 // Address represents a shipping address
@@ -53,6 +56,20 @@ type InventoryItem struct {
 	Bin               string `json:"bin"`
 }
 
+// ReplenishmentTask represents a pick-face replenishment task in inventory-service
+type ReplenishmentTask struct {
+	TaskID       string `json:"taskId"`
+	SKU          string `json:"sku"`
+	ToLocationID string `json:"toLocationId"`
+	WaveID       string `json:"waveId,omitempty"`
+	Status       string `json:"status"`
+}
+
+// IsOpen reports whether the task still has to be worked
+func (t ReplenishmentTask) IsOpen() bool {
+	return t.Status == "pending"
+}
+
 // InventoryItemDetailed represents a detailed inventory item with reservations
 type InventoryItemDetailed struct {
 	SKU                   string               `json:"sku"`
@@ -327,8 +344,12 @@ type PackTask struct {
 
 // PackItem represents an item to be packed
 type PackItem struct {
-	SKU      string `json:"sku"`
-	Quantity int    `json:"quantity"`
+	SKU        string      `json:"sku"`
+	Quantity   int         `json:"quantity"`
+	Weight     float64     `json:"weight,omitempty"`
+	Dimensions *Dimensions `json:"dimensions,omitempty"`
+	Fragile    bool        `json:"fragile,omitempty"`
+	Hazmat     bool        `json:"hazmat,omitempty"`
 }
 
 // Dimensions represents package dimensions
@@ -375,10 +396,21 @@ type Shipment struct {
 	Recipient       ShipmentAddress     `json:"recipient,omitempty"`
 	Shipper         ShipmentAddress     `json:"shipper,omitempty"`
 	Label           *ShippingLabel      `json:"label,omitempty"`
+	BillableWeight  *BillableWeight     `json:"billableWeight,omitempty"`
 	CreatedAt       time.Time           `json:"createdAt"`
 	ShippedAt       *time.Time          `json:"shippedAt,omitempty"`
 }
 
+// BillableWeight is a package's billable weight under the carrier's DIM rules
+type BillableWeight struct {
+	CarrierCode        string  `json:"carrierCode"`
+	ActualWeight       float64 `json:"actualWeight"`
+	DimWeight          float64 `json:"dimWeight"`
+	BillableWeight     float64 `json:"billableWeight"`
+	AdditionalHandling bool    `json:"additionalHandling"`
+	Oversize           bool    `json:"oversize"`
+}
+
 // ShipmentCarrier represents a shipping carrier
 type ShipmentCarrier struct {
 	Code        string `json:"code"`
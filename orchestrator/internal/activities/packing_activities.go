@@ -29,8 +29,12 @@ func (a *PackingActivities) CreatePackTask(ctx context.Context, input map[string
 	items := make([]clients.PackItem, len(order.Items))
 	for i, item := range order.Items {
 		items[i] = clients.PackItem{
-			SKU:      item.SKU,
-			Quantity: item.Quantity,
+			SKU:        item.SKU,
+			Quantity:   item.Quantity,
+			Weight:     item.Weight,
+			Dimensions: item.Dimensions,
+			Fragile:    item.IsFragile,
+			Hazmat:     item.IsHazmat,
 		}
 	}
 
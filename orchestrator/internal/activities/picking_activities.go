@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/wms-platform/orchestrator/internal/activities/clients"
 	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
 )
 
 // CreatePickTask creates a pick task from route information
@@ -81,6 +82,33 @@ func (a *PickingActivities) CreatePickTask(ctx context.Context, input map[string
 	return task.TaskID, nil
 }
 
+// CheckWaveReplenished fails while replenishment tasks topping off the wave's pick faces are
+// still open, so the workflow retries it and pickers are not sent to empty pick faces
+func (a *PickingActivities) CheckWaveReplenished(ctx context.Context, waveID string) error {
+	logger := activity.GetLogger(ctx)
+
+	tasks, err := a.clients.GetWaveReplenishments(ctx, waveID)
+	if err != nil {
+		logger.Warn("Failed to get wave replenishments", "waveId", waveID, "error", err)
+		return fmt.Errorf("failed to get wave replenishments: %w", err)
+	}
+
+	open := 0
+	for _, task := range tasks {
+		if task.IsOpen() {
+			open++
+		}
+	}
+	if open > 0 {
+		logger.Info("Waiting for wave replenishment", "waveId", waveID, "openTasks", open)
+		return temporal.NewApplicationError(
+			fmt.Sprintf("wave %s has %d open replenishment tasks", waveID, open),
+			"WaveReplenishmentPending",
+		)
+	}
+	return nil
+}
+
 // AssignPickerToTask assigns an available worker to a pick task
 func (a *PickingActivities) AssignPickerToTask(ctx context.Context, input map[string]interface{}) (string, error) {
 	logger := activity.GetLogger(ctx)
@@ -2,23 +2,28 @@ package activities
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/wms-platform/orchestrator/internal/activities/clients"
 	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
 )
 
 // SLAMActivities contains activities for the SLAM process
 // SLAM = Scan, Label, Apply, Manifest
 type SLAMActivities struct {
-	// In a real implementation, these would be service clients
+	clients *ServiceClients
 }
 
 // NewSLAMActivities creates a new SLAMActivities instance
-func NewSLAMActivities() *SLAMActivities {
-	return &SLAMActivities{}
+func NewSLAMActivities(clients *ServiceClients) *SLAMActivities {
+	return &SLAMActivities{
+		clients: clients,
+	}
 }
 
 // ScanPackageInput represents input for package scanning
@@ -65,15 +70,17 @@ func (a *SLAMActivities) ScanPackage(ctx context.Context, input ScanPackageInput
 
 // GenerateLabelInput represents input for label generation
 type GenerateLabelInput struct {
-	OrderID       string  `json:"orderId"`
-	PackageID     string  `json:"packageId"`
-	CarrierCode   string  `json:"carrierCode"`
-	ServiceType   string  `json:"serviceType"`
-	Weight        float64 `json:"weight"`
-	RecipientName string  `json:"recipientName"`
-	RecipientAddr string  `json:"recipientAddress"`
-	RecipientCity string  `json:"recipientCity"`
-	RecipientZip  string  `json:"recipientZip"`
+	OrderID          string  `json:"orderId"`
+	PackageID        string  `json:"packageId"`
+	CarrierCode      string  `json:"carrierCode"`
+	ServiceType      string  `json:"serviceType"`
+	Weight           float64 `json:"weight"`
+	RecipientName    string  `json:"recipientName"`
+	RecipientAddr    string  `json:"recipientAddress"`
+	RecipientCity    string  `json:"recipientCity"`
+	RecipientState   string  `json:"recipientState"`
+	RecipientZip     string  `json:"recipientZip"`
+	RecipientCountry string  `json:"recipientCountry"`
 }
 
 // GenerateLabelResult represents the result of label generation
@@ -111,6 +118,57 @@ func (a *SLAMActivities) GenerateLabel(ctx context.Context, input GenerateLabelI
 	return result, nil
 }
 
+// VoidLabelInput represents input for voiding a shipping label
+type VoidLabelInput struct {
+	OrderID        string `json:"orderId"`
+	PackageID      string `json:"packageId"`
+	TrackingNumber string `json:"trackingNumber"`
+	CarrierCode    string `json:"carrierCode"`
+	Reason         string `json:"reason"`
+}
+
+// VoidLabelResult represents the result of voiding a label
+type VoidLabelResult struct {
+	TrackingNumber string    `json:"trackingNumber"`
+	Voided         bool      `json:"voided"`
+	VoidedAt       time.Time `json:"voidedAt"`
+}
+
+// VoidLabel cancels a generated shipping label with the carrier through shipping-service so
+// the carrier does not bill it. A void the service or carrier rejects fails without retrying;
+// outages are retried.
+func (a *SLAMActivities) VoidLabel(ctx context.Context, input VoidLabelInput) (*VoidLabelResult, error) {
+	logger := activity.GetLogger(ctx)
+	logger.Info("Voiding shipping label",
+		"orderId", input.OrderID,
+		"packageId", input.PackageID,
+		"trackingNumber", input.TrackingNumber,
+		"reason", input.Reason,
+	)
+
+	if _, err := a.clients.VoidShipmentLabel(ctx, input.TrackingNumber, input.Reason); err != nil {
+		var statusErr *clients.StatusError
+		if errors.As(err, &statusErr) && statusErr.IsClientError() {
+			logger.Error("Label void rejected", "trackingNumber", input.TrackingNumber, "error", err)
+			return nil, temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("void of label %s rejected: %v", input.TrackingNumber, err),
+				"LabelVoidRejected",
+				err,
+			)
+		}
+		logger.Error("Failed to void label", "trackingNumber", input.TrackingNumber, "error", err)
+		return nil, fmt.Errorf("failed to void label %s: %w", input.TrackingNumber, err)
+	}
+
+	result := &VoidLabelResult{
+		TrackingNumber: input.TrackingNumber,
+		Voided:         true,
+		VoidedAt:       time.Now(),
+	}
+
+	return result, nil
+}
+
 // ApplyLabelInput represents input for applying label
 type ApplyLabelInput struct {
 	PackageID      string `json:"packageId"`
@@ -256,18 +314,20 @@ func (a *SLAMActivities) VerifyWeight(ctx context.Context, input VerifyWeightInp
 
 // ExecuteSLAMInput represents input for the full SLAM process
 type ExecuteSLAMInput struct {
-	OrderID        string   `json:"orderId"`
-	PackageID      string   `json:"packageId"`
-	ExpectedWeight float64  `json:"expectedWeight"`
-	ExpectedSKUs   []string `json:"expectedSkus"`
-	CarrierCode    string   `json:"carrierCode"`
-	ServiceType    string   `json:"serviceType"`
-	RecipientName  string   `json:"recipientName"`
-	RecipientAddr  string   `json:"recipientAddress"`
-	RecipientCity  string   `json:"recipientCity"`
-	RecipientZip   string   `json:"recipientZip"`
-	StationID      string   `json:"stationId"`
-	WorkerID       string   `json:"workerId"`
+	OrderID          string   `json:"orderId"`
+	PackageID        string   `json:"packageId"`
+	ExpectedWeight   float64  `json:"expectedWeight"`
+	ExpectedSKUs     []string `json:"expectedSkus"`
+	CarrierCode      string   `json:"carrierCode"`
+	ServiceType      string   `json:"serviceType"`
+	RecipientName    string   `json:"recipientName"`
+	RecipientAddr    string   `json:"recipientAddress"`
+	RecipientCity    string   `json:"recipientCity"`
+	RecipientState   string   `json:"recipientState"`
+	RecipientZip     string   `json:"recipientZip"`
+	RecipientCountry string   `json:"recipientCountry"`
+	StationID        string   `json:"stationId"`
+	WorkerID         string   `json:"workerId"`
 }
 
 // ExecuteSLAMResult represents the full SLAM process result
@@ -326,15 +386,17 @@ func (a *SLAMActivities) ExecuteSLAM(ctx context.Context, input ExecuteSLAMInput
 
 	// Step 3: Generate label
 	labelResult, err := a.GenerateLabel(ctx, GenerateLabelInput{
-		OrderID:       input.OrderID,
-		PackageID:     input.PackageID,
-		CarrierCode:   input.CarrierCode,
-		ServiceType:   input.ServiceType,
-		Weight:        scanResult.ActualWeight,
-		RecipientName: input.RecipientName,
-		RecipientAddr: input.RecipientAddr,
-		RecipientCity: input.RecipientCity,
-		RecipientZip:  input.RecipientZip,
+		OrderID:          input.OrderID,
+		PackageID:        input.PackageID,
+		CarrierCode:      input.CarrierCode,
+		ServiceType:      input.ServiceType,
+		Weight:           scanResult.ActualWeight,
+		RecipientName:    input.RecipientName,
+		RecipientAddr:    input.RecipientAddr,
+		RecipientCity:    input.RecipientCity,
+		RecipientState:   input.RecipientState,
+		RecipientZip:     input.RecipientZip,
+		RecipientCountry: input.RecipientCountry,
 	})
 	if err != nil {
 		result.Success = false
@@ -388,6 +450,7 @@ func RegisterSLAMActivities(activities *SLAMActivities) map[string]interface{} {
 	return map[string]interface{}{
 		"ScanPackage":    activities.ScanPackage,
 		"GenerateLabel":  activities.GenerateLabel,
+		"VoidLabel":      activities.VoidLabel,
 		"ApplyLabel":     activities.ApplyLabel,
 		"AddToManifest":  activities.AddToManifest,
 		"VerifyWeight":   activities.VerifyWeight,
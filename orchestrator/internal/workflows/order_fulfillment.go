@@ -29,6 +29,7 @@ type OrderFulfillmentInput struct {
 	HazmatDetails    *HazmatDetailsInput    `json:"hazmatDetails,omitempty"`
 	ColdChainDetails *ColdChainDetailsInput `json:"coldChainDetails,omitempty"`
 	TotalValue       float64                `json:"totalValue"`
+	ShipTo           *ShippingAddress       `json:"shipTo,omitempty"`
 	// Unit-level tracking fields (now always enabled)
 	UnitIDs         []string `json:"unitIds,omitempty"`         // Pre-reserved unit IDs if any
 }
@@ -42,6 +43,7 @@ type WESExecutionInput struct {
 	ProcessPathID   string           `json:"processPathId,omitempty"`
 	SpecialHandling []string         `json:"specialHandling,omitempty"`
 	UnitIDs         []string         `json:"unitIds,omitempty"` // Unit IDs for unit-level tracking (always enabled)
+	Priority        string           `json:"priority,omitempty"`
 	// Multi-tenant context
 	TenantID    string `json:"tenantId"`
 	FacilityID  string `json:"facilityId"`
@@ -243,10 +245,14 @@ type OrderFulfillmentQueryStatus struct {
 	TotalStages      int     `json:"totalStages"`
 	CompletedStages  int     `json:"completedStages"`
 	Error            string  `json:"error,omitempty"`
+	// In-flight changes received through signals
+	OnHold        bool                `json:"onHold,omitempty"`
+	Modifications []OrderModification `json:"modifications,omitempty"`
 }
 
 // OrderFulfillmentWorkflow is the main saga that orchestrates the entire order fulfillment process
 // This workflow coordinates across all bounded contexts: Order -> Waving -> Routing -> Picking -> Consolidation -> Packing -> Shipping
+// Cancel, address change, priority change and hold/release signals are applied according to the stage the order is in
 func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput) (*OrderFulfillmentResult, error) {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("Starting order fulfillment workflow", "orderId", input.OrderID)
@@ -256,6 +262,10 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	version := workflow.GetVersion(ctx, "OrderFulfillmentWorkflow", workflow.DefaultVersion, OrderFulfillmentWorkflowVersion)
 	logger.Info("Workflow version", "version", version)
 
+	// In-flight changes: workflows started before signal handling existed ignore the signals
+	inFlightChangesVersion := workflow.GetVersion(ctx, OrderFulfillmentInFlightChanges, workflow.DefaultVersion, 1)
+	changes := newOrderChanges(input)
+
 	result := &OrderFulfillmentResult{
 		OrderID:         input.OrderID,
 		Status:          "in_progress",
@@ -286,7 +296,10 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 		CompletedStages: 0,
 	}
 	err := workflow.SetQueryHandler(ctx, "getStatus", func() (OrderFulfillmentQueryStatus, error) {
-		return queryStatus, nil
+		status := queryStatus
+		status.OnHold = changes.onHold
+		status.Modifications = changes.modifications
+		return status, nil
 	})
 	if err != nil {
 		logger.Error("Failed to set query handler", "error", err)
@@ -317,6 +330,13 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 		WorkflowExecutionTimeout: DefaultChildWorkflowTimeout,
 	}
 
+	// Steps run on a cancellable context so a cancel signal can stop the running step
+	if inFlightChangesVersion == 1 {
+		signalCtx := ctx
+		ctx, changes.cancelInFlight = workflow.WithCancel(ctx)
+		handleOrderChangeSignals(signalCtx, ctx, changes)
+	}
+
 	// ========================================
 	// Step 1: Validate Order
 	// ========================================
@@ -328,6 +348,9 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	var orderValidated bool
 	err = workflow.ExecuteActivity(ctx, "ValidateOrder", input).Get(ctx, &orderValidated)
 	if err != nil {
+		if changes.cancelRequested {
+			return compensateCancelledOrder(ctx, input, changes, &queryStatus, result, err)
+		}
 		queryStatus.Status = "failed"
 		queryStatus.Error = fmt.Sprintf("validation failed: %v", err)
 		result.Status = "validation_failed"
@@ -340,6 +363,10 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	// ========================================
 	// Step 2: Execute Planning Workflow (Child)
 	// ========================================
+	if err := changes.checkpoint(ctx, fulfillmentStagePlanning, nil); err != nil {
+		return compensateCancelledOrder(ctx, input, changes, &queryStatus, result, err)
+	}
+	changes.inventoryReserved = true
 	queryStatus.CurrentStage = "planning"
 	queryStatus.CompletionPercent = 20
 	logger.Info("Step 2: Executing planning workflow", "orderId", input.OrderID)
@@ -353,7 +380,7 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 		OrderID:            input.OrderID,
 		CustomerID:         input.CustomerID,
 		Items:              input.Items,
-		Priority:           input.Priority,
+		Priority:           changes.priority,
 		PromisedDeliveryAt: input.PromisedDeliveryAt,
 		IsMultiItem:        input.IsMultiItem,
 		GiftWrap:           input.GiftWrap,
@@ -372,6 +399,9 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	var planningResult *PlanningWorkflowResult
 	err = workflow.ExecuteChildWorkflow(planningChildCtx, PlanningWorkflow, planningInput).Get(ctx, &planningResult)
 	if err != nil {
+		if changes.cancelRequested {
+			return compensateCancelledOrder(ctx, input, changes, &queryStatus, result, err)
+		}
 		queryStatus.Status = "failed"
 		queryStatus.Error = fmt.Sprintf("planning failed: %v", err)
 		result.Status = "planning_failed"
@@ -389,6 +419,10 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	// Track unit IDs for downstream workflows (always enabled)
 	unitIDs := planningResult.ReservedUnitIDs
 
+	// Re-routes requested while WES executes start from the planned station
+	changes.station = planningResult.TargetStationID
+	changes.requirements = processPath.Requirements
+
 	waveAssignment := WaveAssignment{
 		WaveID:         planningResult.WaveID,
 		ScheduledStart: planningResult.WaveScheduledStart,
@@ -405,6 +439,9 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	// Step 3: WES Execution
 	// ========================================
 	// Delegate picking, walling, and packing to WES (Warehouse Execution System)
+	if err := changes.checkpoint(ctx, fulfillmentStageWES, nil); err != nil {
+		return compensateCancelledOrder(ctx, input, changes, &queryStatus, result, err)
+	}
 	queryStatus.CurrentStage = "wes_execution"
 	queryStatus.CompletionPercent = 40
 	logger.Info("Step 3: Delegating to WES for execution", "orderId", input.OrderID)
@@ -429,6 +466,7 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 		ProcessPathID:   processPath.PathID,
 		SpecialHandling: processPath.SpecialHandling,
 		UnitIDs:         unitIDs, // Pass unit IDs for unit-level tracking (always enabled)
+		Priority:        changes.priority,
 		// Multi-tenant context
 		TenantID:    input.TenantID,
 		FacilityID:  input.FacilityID,
@@ -445,6 +483,9 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	var wesResult WESExecutionResult
 	err = workflow.ExecuteChildWorkflow(wesChildCtx, "WESExecutionWorkflow", wesInput).Get(ctx, &wesResult)
 	if err != nil {
+		if changes.cancelRequested {
+			return compensateCancelledOrder(ctx, input, changes, &queryStatus, result, err)
+		}
 		result.Status = "wes_execution_failed"
 		result.Error = fmt.Sprintf("WES execution failed: %v", err)
 		// Release inventory on failure
@@ -474,21 +515,48 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	// ========================================
 	// Step 4: SLAM Process (Scan, Label, Apply, Manifest)
 	// ========================================
+	if err := changes.checkpoint(ctx, fulfillmentStageSLAM, nil); err != nil {
+		return compensateCancelledOrder(ctx, input, changes, &queryStatus, result, err)
+	}
+	queryStatus.CurrentStage = "slam"
 	logger.Info("Step 4: Starting SLAM process", "orderId", input.OrderID, "packageId", packResult.PackageID)
 
-	var slamResult SLAMResult
-	err = workflow.ExecuteActivity(ctx, "ExecuteSLAM", map[string]interface{}{
+	slamInput := map[string]interface{}{
 		"orderId":        input.OrderID,
 		"packageId":      packResult.PackageID,
 		"expectedWeight": packResult.Weight,
 		"carrier":        packResult.Carrier,
-	}).Get(ctx, &slamResult)
+	}
+	for key, value := range changes.recipient() {
+		slamInput[key] = value
+	}
+
+	var slamResult SLAMResult
+	err = workflow.ExecuteActivity(ctx, "ExecuteSLAM", slamInput).Get(ctx, &slamResult)
 	if err != nil {
+		if changes.cancelRequested {
+			return compensateCancelledOrder(ctx, input, changes, &queryStatus, result, err)
+		}
 		result.Status = "slam_failed"
 		result.Error = fmt.Sprintf("SLAM process failed: %v", err)
 		return result, err
 	}
 
+	changes.labelGenerated(packResult.PackageID, slamResult.TrackingNumber, packResult.Carrier)
+
+	// An address change that arrived while labeling voids the label and labels again
+	relabel := func(ctx workflow.Context) error {
+		return relabelPackage(ctx, changes, slamInput, &slamResult)
+	}
+	if err := changes.checkpoint(ctx, fulfillmentStageSortation, relabel); err != nil {
+		if changes.cancelRequested {
+			return compensateCancelledOrder(ctx, input, changes, &queryStatus, result, err)
+		}
+		result.Status = "slam_failed"
+		result.Error = fmt.Sprintf("relabel failed: %v", err)
+		return result, err
+	}
+
 	// Check weight verification - if out of tolerance, log warning but continue
 	if slamResult.WeightVariancePercent > WeightToleranceThreshold {
 		logger.Warn("Weight verification out of tolerance",
@@ -514,6 +582,7 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	// ========================================
 	// Step 5: Sortation (Route to Destination Chute)
 	// ========================================
+	queryStatus.CurrentStage = "sortation"
 	logger.Info("Step 5: Starting sortation workflow", "orderId", input.OrderID, "packageId", packResult.PackageID)
 
 	sortationChildCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
@@ -544,6 +613,9 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	var sortationResult *SortationWorkflowResult
 	err = workflow.ExecuteChildWorkflow(sortationChildCtx, SortationWorkflow, sortationInput).Get(ctx, &sortationResult)
 	if err != nil {
+		if changes.cancelRequested {
+			return compensateCancelledOrder(ctx, input, changes, &queryStatus, result, err)
+		}
 		result.Status = "sortation_failed"
 		result.Error = fmt.Sprintf("sortation workflow failed: %v", err)
 		return result, err
@@ -559,6 +631,11 @@ func OrderFulfillmentWorkflow(ctx workflow.Context, input OrderFulfillmentInput)
 	// ========================================
 	// Step 6: Shipping (Carrier Handoff)
 	// ========================================
+	// Once the carrier handoff starts the order can no longer be cancelled
+	if err := changes.checkpoint(ctx, fulfillmentStageShipping, nil); err != nil {
+		return compensateCancelledOrder(ctx, input, changes, &queryStatus, result, err)
+	}
+	queryStatus.CurrentStage = "shipping"
 	logger.Info("Step 6: Starting shipping workflow", "orderId", input.OrderID, "trackingNumber", result.TrackingNumber)
 
 	shippingChildCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
@@ -0,0 +1,453 @@
+package workflows
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Signals accepted by OrderFulfillmentWorkflow to change an order while it is in flight
+const (
+	CancelOrderSignal    = "cancelOrder"
+	ChangeAddressSignal  = "changeAddress"
+	ChangePrioritySignal = "changePriority"
+	HoldOrderSignal      = "holdOrder"
+	ReleaseOrderSignal   = "releaseOrder"
+)
+
+// Fulfillment stages an in-flight change can arrive in
+const (
+	fulfillmentStageValidation = "validation"
+	fulfillmentStagePlanning   = "planning"
+	fulfillmentStageWES        = "wes_execution"
+	fulfillmentStageSLAM       = "slam"
+	fulfillmentStageSortation  = "sortation"
+	fulfillmentStageShipping   = "shipping"
+)
+
+// Outcomes recorded for an in-flight change
+const (
+	ModificationPlanUpdated    = "plan_updated"
+	ModificationRerouted       = "rerouted"
+	ModificationRelabelPending = "relabel_pending"
+	ModificationRelabeled      = "relabeled"
+	ModificationCancelled      = "cancelled"
+	ModificationHeld           = "held"
+	ModificationReleased       = "released"
+	ModificationRejected       = "rejected"
+)
+
+// errOrderCancelled stops the fulfillment saga after a cancel signal
+var errOrderCancelled = errors.New("order cancelled")
+
+// ShippingAddress is the ship-to address of an order
+type ShippingAddress struct {
+	Name       string `json:"name"`
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country,omitempty"`
+}
+
+// CancelOrderRequest is the payload of the cancelOrder signal
+type CancelOrderRequest struct {
+	Reason      string `json:"reason"`
+	RequestedBy string `json:"requestedBy,omitempty"`
+}
+
+// AddressChangeRequest is the payload of the changeAddress signal
+type AddressChangeRequest struct {
+	ShipTo      ShippingAddress `json:"shipTo"`
+	RequestedBy string          `json:"requestedBy,omitempty"`
+}
+
+// PriorityChangeRequest is the payload of the changePriority signal
+type PriorityChangeRequest struct {
+	Priority    string `json:"priority"`
+	RequestedBy string `json:"requestedBy,omitempty"`
+}
+
+// HoldOrderRequest is the payload of the holdOrder signal
+type HoldOrderRequest struct {
+	Reason      string `json:"reason"`
+	RequestedBy string `json:"requestedBy,omitempty"`
+}
+
+// ReleaseOrderRequest is the payload of the releaseOrder signal
+type ReleaseOrderRequest struct {
+	RequestedBy string `json:"requestedBy,omitempty"`
+}
+
+// OrderModification records an in-flight change and how it was applied
+type OrderModification struct {
+	Type        string    `json:"type"`   // cancel, address, priority, hold, release
+	Stage       string    `json:"stage"`  // Fulfillment stage when the signal arrived
+	Action      string    `json:"action"` // plan_updated, rerouted, relabel_pending, relabeled, cancelled, held, released, rejected
+	Detail      string    `json:"detail,omitempty"`
+	RequestedBy string    `json:"requestedBy,omitempty"`
+	ReceivedAt  time.Time `json:"receivedAt"`
+}
+
+// rerouteDecision is the part of the RerouteOrder activity result the workflow uses
+type rerouteDecision struct {
+	NewStationID string `json:"newStationId"`
+	Success      bool   `json:"success"`
+}
+
+// orderChanges holds the state in-flight signals act on. The signal handler records
+// changes as they arrive; the saga applies the ones that cannot take effect
+// immediately at its checkpoints between steps.
+type orderChanges struct {
+	orderID      string
+	stage        string
+	priority     string
+	shipTo       *ShippingAddress
+	station      string
+	requirements []string
+
+	onHold          bool
+	cancelRequested bool
+	cancelReason    string
+	relabelRequired bool
+
+	// Progress used to pick compensations on cancel
+	inventoryReserved bool
+	packageID         string
+	trackingNumber    string
+	carrier           string
+
+	modifications  []OrderModification
+	cancelInFlight workflow.CancelFunc
+}
+
+func newOrderChanges(input OrderFulfillmentInput) *orderChanges {
+	return &orderChanges{
+		orderID:  input.OrderID,
+		stage:    fulfillmentStageValidation,
+		priority: input.Priority,
+		shipTo:   input.ShipTo,
+	}
+}
+
+// handleOrderChangeSignals starts the coroutine receiving in-flight change signals.
+// Re-routes run on activityCtx, which is cancelled together with the saga's steps.
+func handleOrderChangeSignals(ctx, activityCtx workflow.Context, changes *orderChanges) {
+	cancelCh := workflow.GetSignalChannel(ctx, CancelOrderSignal)
+	addressCh := workflow.GetSignalChannel(ctx, ChangeAddressSignal)
+	priorityCh := workflow.GetSignalChannel(ctx, ChangePrioritySignal)
+	holdCh := workflow.GetSignalChannel(ctx, HoldOrderSignal)
+	releaseCh := workflow.GetSignalChannel(ctx, ReleaseOrderSignal)
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(cancelCh, func(c workflow.ReceiveChannel, more bool) {
+			var req CancelOrderRequest
+			c.Receive(ctx, &req)
+			changes.cancel(ctx, req)
+		})
+		selector.AddReceive(addressCh, func(c workflow.ReceiveChannel, more bool) {
+			var req AddressChangeRequest
+			c.Receive(ctx, &req)
+			changes.changeAddress(ctx, activityCtx, req)
+		})
+		selector.AddReceive(priorityCh, func(c workflow.ReceiveChannel, more bool) {
+			var req PriorityChangeRequest
+			c.Receive(ctx, &req)
+			changes.changePriority(ctx, activityCtx, req)
+		})
+		selector.AddReceive(holdCh, func(c workflow.ReceiveChannel, more bool) {
+			var req HoldOrderRequest
+			c.Receive(ctx, &req)
+			changes.hold(ctx, req)
+		})
+		selector.AddReceive(releaseCh, func(c workflow.ReceiveChannel, more bool) {
+			var req ReleaseOrderRequest
+			c.Receive(ctx, &req)
+			changes.release(ctx, req)
+		})
+
+		for {
+			selector.Select(ctx)
+		}
+	})
+}
+
+// cancel stops the saga unless the package was already handed to the carrier.
+// Running steps are cancelled; compensations run once the saga unwinds.
+func (c *orderChanges) cancel(ctx workflow.Context, req CancelOrderRequest) {
+	if c.stage == fulfillmentStageShipping {
+		c.record(ctx, "cancel", ModificationRejected, "order already handed to carrier", req.RequestedBy)
+		return
+	}
+	if c.cancelRequested {
+		return
+	}
+
+	c.cancelRequested = true
+	c.cancelReason = req.Reason
+	if c.cancelReason == "" {
+		c.cancelReason = "cancelled while in fulfillment"
+	}
+	c.record(ctx, "cancel", ModificationCancelled, c.cancelReason, req.RequestedBy)
+
+	if c.cancelInFlight != nil {
+		c.cancelInFlight()
+	}
+}
+
+// changeAddress updates the plan before picking, re-routes while picking and packing
+// are in progress and voids and re-labels once the package is being labeled
+func (c *orderChanges) changeAddress(ctx, activityCtx workflow.Context, req AddressChangeRequest) {
+	switch c.stage {
+	case fulfillmentStageValidation, fulfillmentStagePlanning:
+		c.shipTo = &req.ShipTo
+		c.record(ctx, "address", ModificationPlanUpdated, req.ShipTo.PostalCode, req.RequestedBy)
+	case fulfillmentStageWES:
+		c.shipTo = &req.ShipTo
+		c.reroute(ctx, activityCtx, "address", "address_changed", req.RequestedBy)
+	case fulfillmentStageSLAM:
+		c.shipTo = &req.ShipTo
+		c.relabelRequired = true
+		c.record(ctx, "address", ModificationRelabelPending, req.ShipTo.PostalCode, req.RequestedBy)
+	default:
+		c.record(ctx, "address", ModificationRejected, "package already sorted", req.RequestedBy)
+	}
+}
+
+// changePriority updates the plan before picking and re-routes while picking and
+// packing are in progress. A packed order keeps its priority.
+func (c *orderChanges) changePriority(ctx, activityCtx workflow.Context, req PriorityChangeRequest) {
+	switch c.stage {
+	case fulfillmentStageValidation, fulfillmentStagePlanning:
+		c.priority = req.Priority
+		c.record(ctx, "priority", ModificationPlanUpdated, req.Priority, req.RequestedBy)
+	case fulfillmentStageWES:
+		c.priority = req.Priority
+		c.reroute(ctx, activityCtx, "priority", "priority_changed", req.RequestedBy)
+	default:
+		c.record(ctx, "priority", ModificationRejected, "order already packed", req.RequestedBy)
+	}
+}
+
+// hold parks the saga at its next checkpoint until the order is released
+func (c *orderChanges) hold(ctx workflow.Context, req HoldOrderRequest) {
+	if c.stage == fulfillmentStageShipping {
+		c.record(ctx, "hold", ModificationRejected, "order already handed to carrier", req.RequestedBy)
+		return
+	}
+	c.onHold = true
+	c.record(ctx, "hold", ModificationHeld, req.Reason, req.RequestedBy)
+}
+
+func (c *orderChanges) release(ctx workflow.Context, req ReleaseOrderRequest) {
+	if !c.onHold {
+		c.record(ctx, "release", ModificationRejected, "order is not on hold", req.RequestedBy)
+		return
+	}
+	c.onHold = false
+	c.record(ctx, "release", ModificationReleased, "", req.RequestedBy)
+}
+
+// reroute asks process-path-service for a new station while WES is executing the order
+func (c *orderChanges) reroute(ctx, activityCtx workflow.Context, changeType, reason, requestedBy string) {
+	logger := workflow.GetLogger(ctx)
+
+	var decision rerouteDecision
+	err := workflow.ExecuteActivity(activityCtx, "RerouteOrder", map[string]interface{}{
+		"orderId":      c.orderID,
+		"currentPath":  c.station,
+		"reason":       reason,
+		"requirements": c.requirements,
+		"priority":     c.priority,
+		"forceReroute": true,
+	}).Get(activityCtx, &decision)
+	if err != nil {
+		logger.Warn("Failed to reroute order", "orderId", c.orderID, "reason", reason, "error", err)
+		c.record(ctx, changeType, ModificationRejected, fmt.Sprintf("reroute failed: %v", err), requestedBy)
+		return
+	}
+
+	if decision.NewStationID != "" {
+		c.station = decision.NewStationID
+	}
+	c.record(ctx, changeType, ModificationRerouted, c.station, requestedBy)
+}
+
+func (c *orderChanges) record(ctx workflow.Context, changeType, action, detail, requestedBy string) {
+	workflow.GetLogger(ctx).Info("In-flight order change",
+		"orderId", c.orderID,
+		"type", changeType,
+		"stage", c.stage,
+		"action", action,
+		"detail", detail,
+	)
+	c.modifications = append(c.modifications, OrderModification{
+		Type:        changeType,
+		Stage:       c.stage,
+		Action:      action,
+		Detail:      detail,
+		RequestedBy: requestedBy,
+		ReceivedAt:  workflow.Now(ctx),
+	})
+}
+
+// checkpoint runs between saga steps. It waits while the order is on hold, stops on
+// cancellation and applies a pending relabel before the saga enters the next stage.
+func (c *orderChanges) checkpoint(ctx workflow.Context, next string, relabel func(workflow.Context) error) error {
+	for {
+		if c.onHold && !c.cancelRequested {
+			workflow.GetLogger(ctx).Info("Order on hold, waiting for release", "orderId", c.orderID, "stage", c.stage)
+		}
+		if err := workflow.Await(ctx, func() bool { return !c.onHold || c.cancelRequested }); err != nil {
+			return err
+		}
+		if c.cancelRequested {
+			return errOrderCancelled
+		}
+		if !c.relabelRequired || relabel == nil {
+			break
+		}
+
+		c.relabelRequired = false
+		if err := relabel(ctx); err != nil {
+			return err
+		}
+	}
+
+	c.stage = next
+	return nil
+}
+
+// labelGenerated records the label a cancellation or address change has to void
+func (c *orderChanges) labelGenerated(packageID, trackingNumber, carrier string) {
+	c.packageID = packageID
+	c.trackingNumber = trackingNumber
+	c.carrier = carrier
+}
+
+// recipient returns the ship-to fields passed to the SLAM activities
+func (c *orderChanges) recipient() map[string]interface{} {
+	if c.shipTo == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"recipientName":    c.shipTo.Name,
+		"recipientAddress": c.shipTo.Street,
+		"recipientCity":    c.shipTo.City,
+		"recipientState":   c.shipTo.State,
+		"recipientZip":     c.shipTo.PostalCode,
+		"recipientCountry": c.shipTo.Country,
+	}
+}
+
+// relabelPackage voids the package's label and runs SLAM again for the changed address
+func relabelPackage(ctx workflow.Context, changes *orderChanges, slamInput map[string]interface{}, slamResult *SLAMResult) error {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Relabeling package after address change",
+		"orderId", changes.orderID,
+		"packageId", changes.packageID,
+		"voidedTrackingNumber", changes.trackingNumber,
+	)
+
+	if changes.trackingNumber != "" {
+		err := workflow.ExecuteActivity(ctx, "VoidLabel", map[string]interface{}{
+			"orderId":        changes.orderID,
+			"packageId":      changes.packageID,
+			"trackingNumber": changes.trackingNumber,
+			"carrierCode":    changes.carrier,
+			"reason":         "address_changed",
+		}).Get(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to void label %s: %w", changes.trackingNumber, err)
+		}
+	}
+
+	for key, value := range changes.recipient() {
+		slamInput[key] = value
+	}
+
+	var relabeled SLAMResult
+	if err := workflow.ExecuteActivity(ctx, "ExecuteSLAM", slamInput).Get(ctx, &relabeled); err != nil {
+		return fmt.Errorf("failed to relabel package: %w", err)
+	}
+	*slamResult = relabeled
+	changes.labelGenerated(changes.packageID, relabeled.TrackingNumber, changes.carrier)
+	changes.record(ctx, "address", ModificationRelabeled, relabeled.TrackingNumber, "")
+	return nil
+}
+
+// compensateCancelledOrder unwinds a cancelled order: it cancels the order, releases the
+// inventory and units reserved during planning, voids a generated label and notifies
+// the customer. Failures other than a cancel signal are returned unchanged.
+func compensateCancelledOrder(ctx workflow.Context, input OrderFulfillmentInput, changes *orderChanges, queryStatus *OrderFulfillmentQueryStatus, result *OrderFulfillmentResult, cause error) (*OrderFulfillmentResult, error) {
+	if !changes.cancelRequested {
+		return result, cause
+	}
+
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Compensating cancelled order", "orderId", input.OrderID, "stage", changes.stage, "reason", changes.cancelReason)
+
+	// Compensations must run even though the saga's context was cancelled
+	compensationCtx, _ := workflow.NewDisconnectedContext(ctx)
+	compensationCtx = workflow.WithActivityOptions(compensationCtx, workflow.ActivityOptions{
+		StartToCloseTimeout: DefaultActivityTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    DefaultRetryInitialInterval,
+			BackoffCoefficient: DefaultRetryBackoffCoefficient,
+			MaximumInterval:    DefaultRetryMaxInterval,
+			MaximumAttempts:    DefaultMaxRetryAttempts,
+		},
+	})
+
+	if err := workflow.ExecuteActivity(compensationCtx, "CancelOrder", input.OrderID, changes.cancelReason).Get(compensationCtx, nil); err != nil {
+		logger.Error("Failed to cancel order", "orderId", input.OrderID, "error", err)
+	}
+
+	if changes.inventoryReserved {
+		if err := workflow.ExecuteActivity(compensationCtx, "ReleaseInventoryReservation", input.OrderID).Get(compensationCtx, nil); err != nil {
+			logger.Error("Failed to release inventory reservation", "orderId", input.OrderID, "error", err)
+		}
+
+		err := workflow.ExecuteActivity(compensationCtx, "ReleaseUnits", map[string]interface{}{
+			"orderId":     input.OrderID,
+			"reason":      "order_cancelled",
+			"tenantId":    input.TenantID,
+			"facilityId":  input.FacilityID,
+			"warehouseId": input.WarehouseID,
+			"sellerId":    input.SellerID,
+		}).Get(compensationCtx, nil)
+		if err != nil {
+			logger.Error("Failed to release units", "orderId", input.OrderID, "error", err)
+		}
+	}
+
+	if changes.trackingNumber != "" {
+		err := workflow.ExecuteActivity(compensationCtx, "VoidLabel", map[string]interface{}{
+			"orderId":        input.OrderID,
+			"packageId":      changes.packageID,
+			"trackingNumber": changes.trackingNumber,
+			"carrierCode":    changes.carrier,
+			"reason":         "order_cancelled",
+		}).Get(compensationCtx, nil)
+		if err != nil {
+			logger.Error("Failed to void label", "orderId", input.OrderID, "trackingNumber", changes.trackingNumber, "error", err)
+		}
+	}
+
+	if err := workflow.ExecuteActivity(compensationCtx, "NotifyCustomerCancellation", input.OrderID, changes.cancelReason).Get(compensationCtx, nil); err != nil {
+		logger.Warn("Failed to notify customer of cancellation", "orderId", input.OrderID, "error", err)
+	}
+
+	queryStatus.Status = "cancelled"
+	queryStatus.Error = ""
+	result.Status = "cancelled"
+	result.Error = changes.cancelReason
+	result.TrackingNumber = ""
+
+	logger.Info("Order cancelled in flight", "orderId", input.OrderID, "stage", changes.stage)
+	return result, nil
+}
@@ -0,0 +1,201 @@
+package workflows
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+func newModificationTestPlanning() *PlanningWorkflowResult {
+	return &PlanningWorkflowResult{
+		WaveID:          "WAVE-001",
+		PathID:          "PATH-001",
+		TargetStationID: "STATION-001",
+		ProcessPath: ProcessPathResult{
+			PathID:       "PATH-001",
+			Requirements: []string{"single_item"},
+		},
+		ReservedUnitIDs: []string{"UNIT-001"},
+	}
+}
+
+// newModificationTestEnv returns a test environment with the activities and child workflows the
+// order fulfillment workflow calls by name registered, so each test can mock the ones it expects
+func newModificationTestEnv() *testsuite.TestWorkflowEnvironment {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	orderActivity := func(ctx context.Context, orderID string) error { return nil }
+	reasonActivity := func(ctx context.Context, orderID, reason string) error { return nil }
+	mapActivity := func(ctx context.Context, input map[string]interface{}) error { return nil }
+	activities := map[string]interface{}{
+		"ValidateOrder":               func(ctx context.Context, input OrderFulfillmentInput) (bool, error) { return true, nil },
+		"MarkPacked":                  orderActivity,
+		"ReleaseInventoryReservation": orderActivity,
+		"CancelOrder":                 reasonActivity,
+		"NotifyCustomerCancellation":  reasonActivity,
+		"ExecuteSLAM":                 func(ctx context.Context, input map[string]interface{}) (SLAMResult, error) { return SLAMResult{}, nil },
+		"VoidLabel":                   mapActivity,
+		"ReleaseUnits":                mapActivity,
+		"RerouteOrder":                mapActivity,
+		"ReturnInventoryToShelf":      mapActivity,
+		"RecordFulfillmentFees":       mapActivity,
+		"SyncTrackingToChannel":       mapActivity,
+	}
+	for name, fn := range activities {
+		env.RegisterActivityWithOptions(fn, activity.RegisterOptions{Name: name})
+	}
+	env.RegisterWorkflowWithOptions(func(ctx workflow.Context, input WESExecutionInput) (WESExecutionResult, error) {
+		return WESExecutionResult{}, nil
+	}, workflow.RegisterOptions{Name: "WESExecutionWorkflow"})
+	return env
+}
+
+// TestOrderFulfillmentWorkflow_CancelDuringWES tests that a cancel signal stops WES and releases reservations
+func TestOrderFulfillmentWorkflow_CancelDuringWES(t *testing.T) {
+	env := newModificationTestEnv()
+
+	env.OnActivity("ValidateOrder", mock.Anything, mock.Anything).Return(true, nil)
+	env.OnWorkflow(PlanningWorkflow, mock.Anything, mock.Anything).Return(newModificationTestPlanning(), nil)
+	env.OnWorkflow("WESExecutionWorkflow", mock.Anything, mock.Anything).After(time.Hour).Return(WESExecutionResult{Status: "completed"}, nil)
+
+	// Compensations
+	env.OnActivity("CancelOrder", mock.Anything, "ORD-101", "customer request").Return(nil).Once()
+	env.OnActivity("ReleaseInventoryReservation", mock.Anything, "ORD-101").Return(nil).Once()
+	env.OnActivity("ReleaseUnits", mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnActivity("NotifyCustomerCancellation", mock.Anything, "ORD-101", "customer request").Return(nil).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(CancelOrderSignal, CancelOrderRequest{Reason: "customer request"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(OrderFulfillmentWorkflow, OrderFulfillmentInput{
+		OrderID: "ORD-101",
+		Items:   []Item{{SKU: "SKU-001", Quantity: 1}},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result OrderFulfillmentResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, "cancelled", result.Status)
+	require.Equal(t, "customer request", result.Error)
+	env.AssertExpectations(t)
+}
+
+// TestOrderFulfillmentWorkflow_PriorityChangeBeforePicking tests that a priority change during planning reaches WES
+func TestOrderFulfillmentWorkflow_PriorityChangeBeforePicking(t *testing.T) {
+	env := newModificationTestEnv()
+
+	env.OnActivity("ValidateOrder", mock.Anything, mock.Anything).Return(true, nil)
+	env.OnWorkflow(PlanningWorkflow, mock.Anything, mock.Anything).After(time.Hour).Return(newModificationTestPlanning(), nil)
+	env.OnWorkflow("WESExecutionWorkflow", mock.Anything, mock.MatchedBy(func(input WESExecutionInput) bool {
+		return input.Priority == "same_day"
+	})).Return(WESExecutionResult{Status: "completed"}, nil).Once()
+	env.OnActivity("MarkPacked", mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity("ExecuteSLAM", mock.Anything, mock.Anything).Return(SLAMResult{TrackingNumber: "TRK-001", Success: true}, nil)
+	env.OnWorkflow(SortationWorkflow, mock.Anything, mock.Anything).Return(&SortationWorkflowResult{BatchID: "BATCH-001"}, nil)
+	env.OnWorkflow(ShippingWorkflow, mock.Anything, mock.Anything).Return(nil)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(ChangePrioritySignal, PriorityChangeRequest{Priority: "same_day"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(OrderFulfillmentWorkflow, OrderFulfillmentInput{
+		OrderID:  "ORD-102",
+		Items:    []Item{{SKU: "SKU-001", Quantity: 1}},
+		Priority: "standard",
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	val, err := env.QueryWorkflow("getStatus")
+	require.NoError(t, err)
+	var status OrderFulfillmentQueryStatus
+	require.NoError(t, val.Get(&status))
+	require.Len(t, status.Modifications, 1)
+	require.Equal(t, ModificationPlanUpdated, status.Modifications[0].Action)
+}
+
+// TestOrderFulfillmentWorkflow_AddressChangeAfterLabeling tests that the label is voided and the package relabeled
+func TestOrderFulfillmentWorkflow_AddressChangeAfterLabeling(t *testing.T) {
+	env := newModificationTestEnv()
+
+	env.OnActivity("ValidateOrder", mock.Anything, mock.Anything).Return(true, nil)
+	env.OnWorkflow(PlanningWorkflow, mock.Anything, mock.Anything).Return(newModificationTestPlanning(), nil)
+	env.OnWorkflow("WESExecutionWorkflow", mock.Anything, mock.Anything).Return(WESExecutionResult{Status: "completed"}, nil)
+	env.OnActivity("MarkPacked", mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity("ExecuteSLAM", mock.Anything, mock.Anything).After(time.Hour).Return(SLAMResult{TrackingNumber: "TRK-OLD", Success: true}, nil).Once()
+	env.OnActivity("VoidLabel", mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnActivity("ExecuteSLAM", mock.Anything, mock.MatchedBy(func(input map[string]interface{}) bool {
+		return input["recipientZip"] == "94105"
+	})).Return(SLAMResult{TrackingNumber: "TRK-NEW", Success: true}, nil).Once()
+	env.OnWorkflow(SortationWorkflow, mock.Anything, mock.Anything).Return(&SortationWorkflowResult{BatchID: "BATCH-001"}, nil)
+	env.OnWorkflow(ShippingWorkflow, mock.Anything, mock.Anything).Return(nil)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(ChangeAddressSignal, AddressChangeRequest{
+			ShipTo: ShippingAddress{Name: "Jane Doe", Street: "1 Market St", City: "San Francisco", PostalCode: "94105"},
+		})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(OrderFulfillmentWorkflow, OrderFulfillmentInput{
+		OrderID: "ORD-103",
+		Items:   []Item{{SKU: "SKU-001", Quantity: 1}},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result OrderFulfillmentResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, "TRK-NEW", result.TrackingNumber)
+	env.AssertExpectations(t)
+}
+
+// TestOrderFulfillmentWorkflow_HoldAndRelease tests that a held order waits for release before the next step
+func TestOrderFulfillmentWorkflow_HoldAndRelease(t *testing.T) {
+	env := newModificationTestEnv()
+
+	env.OnActivity("ValidateOrder", mock.Anything, mock.Anything).After(time.Minute).Return(true, nil)
+	env.OnWorkflow(PlanningWorkflow, mock.Anything, mock.Anything).Return(newModificationTestPlanning(), nil)
+	env.OnWorkflow("WESExecutionWorkflow", mock.Anything, mock.Anything).Return(WESExecutionResult{Status: "completed"}, nil)
+	env.OnActivity("MarkPacked", mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity("ExecuteSLAM", mock.Anything, mock.Anything).Return(SLAMResult{TrackingNumber: "TRK-001", Success: true}, nil)
+	env.OnWorkflow(SortationWorkflow, mock.Anything, mock.Anything).Return(&SortationWorkflowResult{BatchID: "BATCH-001"}, nil)
+	env.OnWorkflow(ShippingWorkflow, mock.Anything, mock.Anything).Return(nil)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(HoldOrderSignal, HoldOrderRequest{Reason: "fraud review"})
+	}, 30*time.Second)
+	env.RegisterDelayedCallback(func() {
+		val, err := env.QueryWorkflow("getStatus")
+		require.NoError(t, err)
+		var status OrderFulfillmentQueryStatus
+		require.NoError(t, val.Get(&status))
+		require.True(t, status.OnHold)
+		require.Equal(t, "validation", status.CurrentStage)
+
+		env.SignalWorkflow(ReleaseOrderSignal, ReleaseOrderRequest{})
+	}, 2*time.Hour)
+
+	env.ExecuteWorkflow(OrderFulfillmentWorkflow, OrderFulfillmentInput{
+		OrderID: "ORD-104",
+		Items:   []Item{{SKU: "SKU-001", Quantity: 1}},
+	})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var result OrderFulfillmentResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	require.Equal(t, "completed", result.Status)
+}
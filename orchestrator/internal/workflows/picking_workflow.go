@@ -97,6 +97,23 @@ func OrchestratedPickingWorkflow(ctx workflow.Context, input map[string]interfac
 	}
 	result.TaskID = taskID
 
+	// Hold the pick task until replenishment topping off the wave's pick faces is done.
+	// Once the wait times out the task is released anyway; short picks are handled as exceptions.
+	if waveID != "" && workflow.GetVersion(ctx, PickingWaveReplenishmentHold, workflow.DefaultVersion, 1) == 1 {
+		holdCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+			StartToCloseTimeout:    30 * time.Second,
+			ScheduleToCloseTimeout: 30 * time.Minute,
+			RetryPolicy: &temporal.RetryPolicy{
+				InitialInterval:    15 * time.Second,
+				BackoffCoefficient: 1.5,
+				MaximumInterval:    2 * time.Minute,
+			},
+		})
+		if err := workflow.ExecuteActivity(holdCtx, "CheckWaveReplenished", waveID).Get(ctx, nil); err != nil {
+			logger.Warn("Wave replenishment not confirmed, releasing pick task", "waveId", waveID, "taskId", taskID, "error", err)
+		}
+	}
+
 	// Step 2: Assign worker to task
 	logger.Info("Assigning worker to pick task", "orderId", orderID, "taskId", taskID)
 	var workerID string
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	sharedDomain "github.com/wms-platform/shared/pkg/domain"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
@@ -824,17 +825,17 @@ func extractRequiredSkills(requirements []string, specialHandling []string) []st
 	for _, req := range requirements {
 		switch req {
 		case "hazmat":
-			skillsMap["hazmat_certification"] = true
+			skillsMap[sharedDomain.SkillHazmatCertification] = true
 		case "cold_chain":
-			skillsMap["cold_chain_handling"] = true
+			skillsMap[sharedDomain.SkillColdChainHandling] = true
 		case "high_value":
-			skillsMap["high_value_verification"] = true
+			skillsMap[sharedDomain.SkillHighValueVerification] = true
 		case "fragile":
-			skillsMap["fragile_handling"] = true
+			skillsMap[sharedDomain.SkillFragileHandling] = true
 		case "oversized":
-			skillsMap["heavy_lifting"] = true
+			skillsMap[sharedDomain.SkillHeavyLifting] = true
 		case "gift_wrap":
-			skillsMap["gift_wrapping"] = true
+			skillsMap[sharedDomain.SkillGiftWrapping] = true
 		}
 	}
 
@@ -842,15 +843,15 @@ func extractRequiredSkills(requirements []string, specialHandling []string) []st
 	for _, handling := range specialHandling {
 		switch handling {
 		case "hazmat_compliance":
-			skillsMap["hazmat_certification"] = true
+			skillsMap[sharedDomain.SkillHazmatCertification] = true
 		case "cold_chain_packaging":
-			skillsMap["cold_chain_handling"] = true
+			skillsMap[sharedDomain.SkillColdChainHandling] = true
 		case "high_value_verification":
-			skillsMap["high_value_verification"] = true
+			skillsMap[sharedDomain.SkillHighValueVerification] = true
 		case "fragile_packing":
-			skillsMap["fragile_handling"] = true
+			skillsMap[sharedDomain.SkillFragileHandling] = true
 		case "oversized_handling":
-			skillsMap["heavy_lifting"] = true
+			skillsMap[sharedDomain.SkillHeavyLifting] = true
 		}
 	}
 
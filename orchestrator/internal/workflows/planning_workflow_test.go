@@ -0,0 +1,27 @@
+package workflows
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	sharedDomain "github.com/wms-platform/shared/pkg/domain"
+)
+
+// TestExtractRequiredSkills tests that requirements and special handling map to the shared worker skills
+func TestExtractRequiredSkills(t *testing.T) {
+	skills := extractRequiredSkills(
+		[]string{"hazmat", "fragile", "gift_wrap", "multi_item"},
+		[]string{"hazmat_compliance", "cold_chain_packaging", "oversized_handling"},
+	)
+	sort.Strings(skills)
+
+	require.Equal(t, []string{
+		sharedDomain.SkillColdChainHandling,
+		sharedDomain.SkillFragileHandling,
+		sharedDomain.SkillGiftWrapping,
+		sharedDomain.SkillHazmatCertification,
+		sharedDomain.SkillHeavyLifting,
+	}, skills)
+	require.Empty(t, extractRequiredSkills(nil, nil))
+}
@@ -54,9 +54,11 @@ const (
 	// OrderFulfillment change IDs
 	OrderFulfillmentMultiRouteSupport = "multi-route-support"
 	OrderFulfillmentUnitTracking      = "unit-level-tracking"
+	OrderFulfillmentInFlightChanges   = "in-flight-changes"
 
 	// Picking change IDs
-	PickingPartialSuccess = "partial-success-handling"
+	PickingPartialSuccess        = "partial-success-handling"
+	PickingWaveReplenishmentHold = "wave-replenishment-hold"
 
 	// Consolidation change IDs
 	ConsolidationMultiRoute = "multi-route-consolidation"
@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/services/billing-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 )
 
 // BillingService handles billing-related use cases
@@ -313,20 +314,14 @@ func (s *BillingService) ListInvoices(ctx context.Context, query ListInvoicesQue
 
 // FinalizeInvoice finalizes an invoice
 func (s *BillingService) FinalizeInvoice(ctx context.Context, invoiceID string) (*InvoiceDTO, error) {
-	invoice, err := s.invoiceRepo.FindByID(ctx, invoiceID)
+	invoice, err := s.updateInvoice(ctx, invoiceID, func(invoice *domain.Invoice) error {
+		if err := invoice.Finalize(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get invoice: %w", err)
-	}
-	if invoice == nil {
-		return nil, errors.ErrNotFound("invoice not found")
-	}
-
-	if err := invoice.Finalize(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.invoiceRepo.Save(ctx, invoice); err != nil {
-		return nil, fmt.Errorf("failed to save invoice: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Invoice finalized", "invoiceId", invoiceID, "total", invoice.Total)
@@ -336,20 +331,14 @@ func (s *BillingService) FinalizeInvoice(ctx context.Context, invoiceID string)
 
 // MarkInvoicePaid marks an invoice as paid
 func (s *BillingService) MarkInvoicePaid(ctx context.Context, cmd MarkPaidCommand) (*InvoiceDTO, error) {
-	invoice, err := s.invoiceRepo.FindByID(ctx, cmd.InvoiceID)
+	invoice, err := s.updateInvoice(ctx, cmd.InvoiceID, func(invoice *domain.Invoice) error {
+		if err := invoice.MarkPaid(cmd.PaymentMethod, cmd.PaymentRef); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get invoice: %w", err)
-	}
-	if invoice == nil {
-		return nil, errors.ErrNotFound("invoice not found")
-	}
-
-	if err := invoice.MarkPaid(cmd.PaymentMethod, cmd.PaymentRef); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.invoiceRepo.Save(ctx, invoice); err != nil {
-		return nil, fmt.Errorf("failed to save invoice: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Invoice marked as paid",
@@ -362,20 +351,14 @@ func (s *BillingService) MarkInvoicePaid(ctx context.Context, cmd MarkPaidComman
 
 // VoidInvoice voids an invoice
 func (s *BillingService) VoidInvoice(ctx context.Context, invoiceID, reason string) (*InvoiceDTO, error) {
-	invoice, err := s.invoiceRepo.FindByID(ctx, invoiceID)
+	invoice, err := s.updateInvoice(ctx, invoiceID, func(invoice *domain.Invoice) error {
+		if err := invoice.Void(reason); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get invoice: %w", err)
-	}
-	if invoice == nil {
-		return nil, errors.ErrNotFound("invoice not found")
-	}
-
-	if err := invoice.Void(reason); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.invoiceRepo.Save(ctx, invoice); err != nil {
-		return nil, fmt.Errorf("failed to save invoice: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Invoice voided", "invoiceId", invoiceID, "reason", reason)
@@ -383,6 +366,42 @@ func (s *BillingService) VoidInvoice(ctx context.Context, invoiceID, reason stri
 	return ToInvoiceDTO(invoice), nil
 }
 
+// updateInvoice loads an invoice, applies change and saves it. A save that loses a race
+// with another writer reloads the invoice and applies change again.
+func (s *BillingService) updateInvoice(ctx context.Context, invoiceID string, change func(invoice *domain.Invoice) error) (*domain.Invoice, error) {
+	var invoice *domain.Invoice
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.invoiceRepo.FindByID(ctx, invoiceID)
+		if err != nil {
+			return fmt.Errorf("failed to get invoice: %w", err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("invoice not found")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.invoiceRepo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				s.logger.Debug("Invoice changed concurrently, retrying", "invoiceId", invoiceID)
+				return err
+			}
+			return fmt.Errorf("failed to save invoice: %w", err)
+		}
+		invoice = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("invoice %s was modified concurrently, please retry", invoiceID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
 // CalculateFees calculates fees for a request (preview without saving)
 func (s *BillingService) CalculateFees(ctx context.Context, cmd CalculateFeesCommand) (*FeeCalculationResultDTO, error) {
 	schedule := &domain.FeeSchedule{
@@ -397,6 +416,9 @@ func (s *BillingService) CalculateFees(ctx context.Context, cmd CalculateFeesCom
 		OversizedItemFee:           cmd.FeeSchedule.OversizedItemFee,
 		ColdChainFeePerUnit:        cmd.FeeSchedule.ColdChainFeePerUnit,
 		FragileHandlingFee:         cmd.FeeSchedule.FragileHandlingFee,
+		ShippingFeePerBillableKg:   cmd.FeeSchedule.ShippingFeePerBillableKg,
+		AdditionalHandlingFee:      cmd.FeeSchedule.AdditionalHandlingFee,
+		OversizePackageFee:         cmd.FeeSchedule.OversizePackageFee,
 	}
 
 	calculator := domain.NewFeeCalculator(schedule)
@@ -416,23 +438,27 @@ func (s *BillingService) CalculateFees(ctx context.Context, cmd CalculateFeesCom
 		OversizedItems:   cmd.OversizedItems,
 		ColdChainUnits:   cmd.ColdChainUnits,
 		FragileItems:     cmd.FragileItems,
+		Packages:         ToShippedPackages(cmd.Packages),
 	}
 
 	result := calculator.CalculateAllFees(req)
 
 	return &FeeCalculationResultDTO{
-		StorageFee:          result.StorageFee,
-		PickFee:             result.PickFee,
-		PackFee:             result.PackFee,
-		ReceivingFee:        result.ReceivingFee,
-		ShippingFee:         result.ShippingFee,
-		ReturnProcessingFee: result.ReturnProcessingFee,
-		GiftWrapFee:         result.GiftWrapFee,
-		HazmatFee:           result.HazmatFee,
-		OversizedFee:        result.OversizedFee,
-		ColdChainFee:        result.ColdChainFee,
-		FragileFee:          result.FragileFee,
-		TotalFees:           result.TotalFees,
+		StorageFee:            result.StorageFee,
+		PickFee:               result.PickFee,
+		PackFee:               result.PackFee,
+		ReceivingFee:          result.ReceivingFee,
+		ShippingFee:           result.ShippingFee,
+		ReturnProcessingFee:   result.ReturnProcessingFee,
+		GiftWrapFee:           result.GiftWrapFee,
+		HazmatFee:             result.HazmatFee,
+		OversizedFee:          result.OversizedFee,
+		ColdChainFee:          result.ColdChainFee,
+		FragileFee:            result.FragileFee,
+		AdditionalHandlingFee: result.AdditionalHandlingFee,
+		OversizePackageFee:    result.OversizePackageFee,
+		BillableWeightKg:      result.BillableWeightKg,
+		TotalFees:             result.TotalFees,
 	}, nil
 }
 
@@ -488,8 +514,10 @@ func (s *BillingService) CheckOverdueInvoices(ctx context.Context) (int, error)
 
 	count := 0
 	for _, inv := range overdueInvoices {
-		inv.MarkOverdue()
-		if err := s.invoiceRepo.Save(ctx, inv); err != nil {
+		if _, err := s.updateInvoice(ctx, inv.InvoiceID, func(invoice *domain.Invoice) error {
+			invoice.MarkOverdue()
+			return nil
+		}); err != nil {
 			s.logger.WithError(err).Warn("Failed to mark invoice as overdue", "invoiceId", inv.InvoiceID)
 			continue
 		}
@@ -437,6 +437,34 @@ func TestFinalizeInvoiceSuccess(t *testing.T) {
 	assert.Equal(t, inv.InvoiceID, dto.InvoiceID)
 }
 
+func TestFinalizeInvoiceRetriesConcurrentSave(t *testing.T) {
+	loads := 0
+	saveCalls := 0
+	invoiceRepo := &fakeInvoiceRepo{
+		findByIDFn: func(_ context.Context, invoiceID string) (*domain.Invoice, error) {
+			loads++
+			inv := domain.NewInvoice("TNT-001", "SLR-001", time.Now().Add(-24*time.Hour), time.Now(), "Acme", "billing@acme.com")
+			inv.InvoiceID = invoiceID
+			return inv, nil
+		},
+		saveFn: func(_ context.Context, invoice *domain.Invoice) error {
+			saveCalls++
+			if saveCalls == 1 {
+				return sharedErrors.NewConcurrencyConflictError("Invoice", invoice.InvoiceID, 0)
+			}
+			return nil
+		},
+	}
+
+	service := NewBillingService(&fakeActivityRepo{}, invoiceRepo, &fakeStorageRepo{}, testLogger())
+	dto, err := service.FinalizeInvoice(context.Background(), "INV-001")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, 2, saveCalls)
+	assert.Equal(t, string(domain.InvoiceStatusFinalized), dto.Status)
+}
+
 func TestMarkInvoicePaidSuccess(t *testing.T) {
 	inv := domain.NewInvoice("TNT-001", "SLR-001", time.Now().Add(-24*time.Hour), time.Now(), "Acme", "billing@acme.com")
 	_ = inv.Finalize()
@@ -918,6 +946,14 @@ func TestCheckOverdueInvoices(t *testing.T) {
 		findOverdueFn: func(_ context.Context) ([]*domain.Invoice, error) {
 			return []*domain.Invoice{inv1, inv2}, nil
 		},
+		findByIDFn: func(_ context.Context, invoiceID string) (*domain.Invoice, error) {
+			for _, inv := range []*domain.Invoice{inv1, inv2} {
+				if inv.InvoiceID == invoiceID {
+					return inv, nil
+				}
+			}
+			return nil, nil
+		},
 		saveFn: func(_ context.Context, _ *domain.Invoice) error {
 			saveCalls++
 			if saveCalls == 2 {
@@ -60,21 +60,36 @@ type MarkPaidCommand struct {
 
 // CalculateFeesCommand represents command to calculate fees
 type CalculateFeesCommand struct {
-	TenantID         string          `json:"tenantId" binding:"required"`
-	SellerID         string          `json:"sellerId" binding:"required"`
-	FacilityID       string          `json:"facilityId" binding:"required"`
-	FeeSchedule      FeeScheduleDTO  `json:"feeSchedule" binding:"required"`
-	StorageCubicFeet float64         `json:"storageCubicFeet"`
-	UnitsPicked      int             `json:"unitsPicked"`
-	OrdersPacked     int             `json:"ordersPacked"`
-	UnitsReceived    int             `json:"unitsReceived"`
-	ShippingBaseCost float64         `json:"shippingBaseCost"`
-	ReturnsProcessed int             `json:"returnsProcessed"`
-	GiftWrapItems    int             `json:"giftWrapItems"`
-	HazmatUnits      int             `json:"hazmatUnits"`
-	OversizedItems   int             `json:"oversizedItems"`
-	ColdChainUnits   int             `json:"coldChainUnits"`
-	FragileItems     int             `json:"fragileItems"`
+	TenantID         string              `json:"tenantId" binding:"required"`
+	SellerID         string              `json:"sellerId" binding:"required"`
+	FacilityID       string              `json:"facilityId" binding:"required"`
+	FeeSchedule      FeeScheduleDTO      `json:"feeSchedule" binding:"required"`
+	StorageCubicFeet float64             `json:"storageCubicFeet"`
+	UnitsPicked      int                 `json:"unitsPicked"`
+	OrdersPacked     int                 `json:"ordersPacked"`
+	UnitsReceived    int                 `json:"unitsReceived"`
+	ShippingBaseCost float64             `json:"shippingBaseCost"`
+	ReturnsProcessed int                 `json:"returnsProcessed"`
+	GiftWrapItems    int                 `json:"giftWrapItems"`
+	HazmatUnits      int                 `json:"hazmatUnits"`
+	OversizedItems   int                 `json:"oversizedItems"`
+	ColdChainUnits   int                 `json:"coldChainUnits"`
+	FragileItems     int                 `json:"fragileItems"`
+	Packages         []ShippedPackageDTO `json:"packages,omitempty"`
+}
+
+// ShippedPackageDTO represents a shipped package billed by billable weight.
+// BillableWeightKg comes from the shipment; when omitted it is calculated
+// from the weight and dimensions with the carrier's DIM rules.
+type ShippedPackageDTO struct {
+	CarrierCode        string  `json:"carrierCode"`
+	WeightKg           float64 `json:"weightKg"`
+	LengthCm           float64 `json:"lengthCm"`
+	WidthCm            float64 `json:"widthCm"`
+	HeightCm           float64 `json:"heightCm"`
+	BillableWeightKg   float64 `json:"billableWeightKg,omitempty"`
+	AdditionalHandling bool    `json:"additionalHandling,omitempty"`
+	Oversize           bool    `json:"oversize,omitempty"`
 }
 
 // RecordStorageCommand represents command to record storage calculation
@@ -181,22 +196,28 @@ type FeeScheduleDTO struct {
 	OversizedItemFee           float64 `json:"oversizedItemFee"`
 	ColdChainFeePerUnit        float64 `json:"coldChainFeePerUnit"`
 	FragileHandlingFee         float64 `json:"fragileHandlingFee"`
+	ShippingFeePerBillableKg   float64 `json:"shippingFeePerBillableKg"`
+	AdditionalHandlingFee      float64 `json:"additionalHandlingFee"`
+	OversizePackageFee         float64 `json:"oversizePackageFee"`
 }
 
 // FeeCalculationResultDTO represents fee calculation result
 type FeeCalculationResultDTO struct {
-	StorageFee          float64 `json:"storageFee"`
-	PickFee             float64 `json:"pickFee"`
-	PackFee             float64 `json:"packFee"`
-	ReceivingFee        float64 `json:"receivingFee"`
-	ShippingFee         float64 `json:"shippingFee"`
-	ReturnProcessingFee float64 `json:"returnProcessingFee"`
-	GiftWrapFee         float64 `json:"giftWrapFee"`
-	HazmatFee           float64 `json:"hazmatFee"`
-	OversizedFee        float64 `json:"oversizedFee"`
-	ColdChainFee        float64 `json:"coldChainFee"`
-	FragileFee          float64 `json:"fragileFee"`
-	TotalFees           float64 `json:"totalFees"`
+	StorageFee            float64 `json:"storageFee"`
+	PickFee               float64 `json:"pickFee"`
+	PackFee               float64 `json:"packFee"`
+	ReceivingFee          float64 `json:"receivingFee"`
+	ShippingFee           float64 `json:"shippingFee"`
+	ReturnProcessingFee   float64 `json:"returnProcessingFee"`
+	GiftWrapFee           float64 `json:"giftWrapFee"`
+	HazmatFee             float64 `json:"hazmatFee"`
+	OversizedFee          float64 `json:"oversizedFee"`
+	ColdChainFee          float64 `json:"coldChainFee"`
+	FragileFee            float64 `json:"fragileFee"`
+	AdditionalHandlingFee float64 `json:"additionalHandlingFee"`
+	OversizePackageFee    float64 `json:"oversizePackageFee"`
+	BillableWeightKg      float64 `json:"billableWeightKg"`
+	TotalFees             float64 `json:"totalFees"`
 }
 
 // Conversion functions
@@ -262,3 +283,21 @@ func ToInvoiceDTO(inv *domain.Invoice) *InvoiceDTO {
 		FinalizedAt:   inv.FinalizedAt,
 	}
 }
+
+// ToShippedPackages converts shipped package DTOs to domain packages
+func ToShippedPackages(dtos []ShippedPackageDTO) []domain.ShippedPackage {
+	packages := make([]domain.ShippedPackage, len(dtos))
+	for i, p := range dtos {
+		packages[i] = domain.ShippedPackage{
+			CarrierCode:        p.CarrierCode,
+			WeightKg:           p.WeightKg,
+			LengthCm:           p.LengthCm,
+			WidthCm:            p.WidthCm,
+			HeightCm:           p.HeightCm,
+			BillableWeightKg:   p.BillableWeightKg,
+			AdditionalHandling: p.AdditionalHandling,
+			Oversize:           p.Oversize,
+		}
+	}
+	return packages
+}
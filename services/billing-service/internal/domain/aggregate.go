@@ -178,6 +178,7 @@ type Invoice struct {
 	CreatedAt   time.Time  `bson:"createdAt" json:"createdAt"`
 	UpdatedAt   time.Time  `bson:"updatedAt" json:"updatedAt"`
 	FinalizedAt *time.Time `bson:"finalizedAt,omitempty" json:"finalizedAt,omitempty"`
+	Version     int        `bson:"version" json:"version"` // Incremented on every save for optimistic concurrency
 
 	// Domain events
 	domainEvents []DomainEvent `bson:"-" json:"-"`
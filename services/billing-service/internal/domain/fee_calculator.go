@@ -1,5 +1,7 @@
 package domain
 
+import "github.com/wms-platform/shared/pkg/dimweight"
+
 // FeeSchedule represents the fee structure for a seller
 // This is passed from seller-service when calculating fees
 type FeeSchedule struct {
@@ -14,6 +16,9 @@ type FeeSchedule struct {
 	OversizedItemFee           float64
 	ColdChainFeePerUnit        float64
 	FragileHandlingFee         float64
+	ShippingFeePerBillableKg   float64
+	AdditionalHandlingFee      float64
+	OversizePackageFee         float64
 	VolumeDiscounts            []VolumeDiscount
 }
 
@@ -61,6 +66,27 @@ func (c *FeeCalculator) CalculateShippingFee(baseCost float64) float64 {
 	return baseCost + markup
 }
 
+// CalculateBillableWeightFee calculates the weight-based shipping fee from billable weight
+func (c *FeeCalculator) CalculateBillableWeightFee(billableWeightKg float64) float64 {
+	return billableWeightKg * c.schedule.ShippingFeePerBillableKg
+}
+
+// billsByWeight returns true if shipping is billed from billable weight instead of
+// the marked up carrier base cost. The schedule charges one or the other, never both.
+func (s *FeeSchedule) billsByWeight(billableWeightKg float64) bool {
+	return s.ShippingFeePerBillableKg > 0 && billableWeightKg > 0
+}
+
+// CalculateAdditionalHandlingFee calculates the additional handling surcharge for packages
+func (c *FeeCalculator) CalculateAdditionalHandlingFee(packages int) float64 {
+	return float64(packages) * c.schedule.AdditionalHandlingFee
+}
+
+// CalculateOversizePackageFee calculates the oversize surcharge for packages
+func (c *FeeCalculator) CalculateOversizePackageFee(packages int) float64 {
+	return float64(packages) * c.schedule.OversizePackageFee
+}
+
 // CalculateReturnProcessingFee calculates return processing fee
 func (c *FeeCalculator) CalculateReturnProcessingFee(returns int) float64 {
 	return float64(returns) * c.schedule.ReturnProcessingFee
@@ -108,55 +134,119 @@ type FeeCalculationRequest struct {
 	FacilityID string
 
 	// Activity metrics
-	StorageCubicFeet  float64
-	UnitsPicked       int
-	OrdersPacked      int
-	UnitsReceived     int
-	ShippingBaseCost  float64
-	ReturnsProcessed  int
-	GiftWrapItems     int
-	HazmatUnits       int
-	OversizedItems    int
-	ColdChainUnits    int
-	FragileItems      int
+	StorageCubicFeet float64
+	UnitsPicked      int
+	OrdersPacked     int
+	UnitsReceived    int
+	ShippingBaseCost float64
+	ReturnsProcessed int
+	GiftWrapItems    int
+	HazmatUnits      int
+	OversizedItems   int
+	ColdChainUnits   int
+	FragileItems     int
+
+	// Shipped packages billed by billable weight. When the schedule has a per kg
+	// rate this replaces the marked up ShippingBaseCost rather than adding to it.
+	Packages []ShippedPackage
+}
+
+// ShippedPackage is a shipped package billed by its billable weight.
+// When the shipment's billable weight is not known it is calculated from the
+// package weight and dimensions with the carrier's DIM rules.
+type ShippedPackage struct {
+	CarrierCode        string
+	WeightKg           float64
+	LengthCm           float64
+	WidthCm            float64
+	HeightCm           float64
+	BillableWeightKg   float64
+	AdditionalHandling bool
+	Oversize           bool
+}
+
+// resolve fills in billable weight and surcharge flags from the carrier's DIM rules if missing
+func (p ShippedPackage) resolve() ShippedPackage {
+	if p.BillableWeightKg > 0 {
+		return p
+	}
+	result := dimweight.Calculate(p.CarrierCode, dimweight.Package{
+		WeightKg: p.WeightKg,
+		LengthCm: p.LengthCm,
+		WidthCm:  p.WidthCm,
+		HeightCm: p.HeightCm,
+	})
+	p.BillableWeightKg = result.BillableWeightKg
+	p.AdditionalHandling = p.AdditionalHandling || result.AdditionalHandling
+	p.Oversize = p.Oversize || result.Oversize
+	return p
+}
+
+// packageTotals sums billable weight and counts surcharged packages
+func packageTotals(packages []ShippedPackage) (billableWeightKg float64, additionalHandling, oversize int) {
+	for _, pkg := range packages {
+		pkg = pkg.resolve()
+		billableWeightKg += pkg.BillableWeightKg
+		if pkg.AdditionalHandling {
+			additionalHandling++
+		}
+		if pkg.Oversize {
+			oversize++
+		}
+	}
+	return billableWeightKg, additionalHandling, oversize
 }
 
 // FeeCalculationResult represents the result of fee calculation
 type FeeCalculationResult struct {
-	StorageFee          float64 `json:"storageFee"`
-	PickFee             float64 `json:"pickFee"`
-	PackFee             float64 `json:"packFee"`
-	ReceivingFee        float64 `json:"receivingFee"`
-	ShippingFee         float64 `json:"shippingFee"`
-	ReturnProcessingFee float64 `json:"returnProcessingFee"`
-	GiftWrapFee         float64 `json:"giftWrapFee"`
-	HazmatFee           float64 `json:"hazmatFee"`
-	OversizedFee        float64 `json:"oversizedFee"`
-	ColdChainFee        float64 `json:"coldChainFee"`
-	FragileFee          float64 `json:"fragileFee"`
-	TotalFees           float64 `json:"totalFees"`
+	StorageFee            float64 `json:"storageFee"`
+	PickFee               float64 `json:"pickFee"`
+	PackFee               float64 `json:"packFee"`
+	ReceivingFee          float64 `json:"receivingFee"`
+	ShippingFee           float64 `json:"shippingFee"`
+	ReturnProcessingFee   float64 `json:"returnProcessingFee"`
+	GiftWrapFee           float64 `json:"giftWrapFee"`
+	HazmatFee             float64 `json:"hazmatFee"`
+	OversizedFee          float64 `json:"oversizedFee"`
+	ColdChainFee          float64 `json:"coldChainFee"`
+	FragileFee            float64 `json:"fragileFee"`
+	AdditionalHandlingFee float64 `json:"additionalHandlingFee"`
+	OversizePackageFee    float64 `json:"oversizePackageFee"`
+	BillableWeightKg      float64 `json:"billableWeightKg"`
+	TotalFees             float64 `json:"totalFees"`
 }
 
 // CalculateAllFees calculates all fees for a request
 func (c *FeeCalculator) CalculateAllFees(req FeeCalculationRequest) *FeeCalculationResult {
+	billableWeightKg, additionalHandling, oversize := packageTotals(req.Packages)
+
+	shippingFee := c.CalculateShippingFee(req.ShippingBaseCost)
+	if c.schedule.billsByWeight(billableWeightKg) {
+		shippingFee = c.CalculateBillableWeightFee(billableWeightKg)
+	}
+
 	result := &FeeCalculationResult{
-		StorageFee:          c.CalculateStorageFee(req.StorageCubicFeet),
-		PickFee:             c.CalculatePickFee(req.UnitsPicked),
-		PackFee:             c.CalculatePackFee(req.OrdersPacked),
-		ReceivingFee:        c.CalculateReceivingFee(req.UnitsReceived),
-		ShippingFee:         c.CalculateShippingFee(req.ShippingBaseCost),
-		ReturnProcessingFee: c.CalculateReturnProcessingFee(req.ReturnsProcessed),
-		GiftWrapFee:         c.CalculateGiftWrapFee(req.GiftWrapItems),
-		HazmatFee:           c.CalculateHazmatFee(req.HazmatUnits),
-		OversizedFee:        c.CalculateOversizedFee(req.OversizedItems),
-		ColdChainFee:        c.CalculateColdChainFee(req.ColdChainUnits),
-		FragileFee:          c.CalculateFragileFee(req.FragileItems),
+		StorageFee:            c.CalculateStorageFee(req.StorageCubicFeet),
+		PickFee:               c.CalculatePickFee(req.UnitsPicked),
+		PackFee:               c.CalculatePackFee(req.OrdersPacked),
+		ReceivingFee:          c.CalculateReceivingFee(req.UnitsReceived),
+		ShippingFee:           shippingFee,
+		ReturnProcessingFee:   c.CalculateReturnProcessingFee(req.ReturnsProcessed),
+		GiftWrapFee:           c.CalculateGiftWrapFee(req.GiftWrapItems),
+		HazmatFee:             c.CalculateHazmatFee(req.HazmatUnits),
+		OversizedFee:          c.CalculateOversizedFee(req.OversizedItems),
+		ColdChainFee:          c.CalculateColdChainFee(req.ColdChainUnits),
+		FragileFee:            c.CalculateFragileFee(req.FragileItems),
+		AdditionalHandlingFee: c.CalculateAdditionalHandlingFee(additionalHandling),
+		OversizePackageFee:    c.CalculateOversizePackageFee(oversize),
+		BillableWeightKg:      billableWeightKg,
 	}
 
 	result.TotalFees = result.StorageFee + result.PickFee + result.PackFee +
 		result.ReceivingFee + result.ShippingFee + result.ReturnProcessingFee +
 		result.GiftWrapFee + result.HazmatFee + result.OversizedFee +
-		result.ColdChainFee + result.FragileFee
+		result.ColdChainFee + result.FragileFee +
+		result.AdditionalHandlingFee + result.OversizePackageFee
 
 	return result
 }
@@ -226,7 +316,21 @@ func CreateActivitiesFromResult(
 		}
 	}
 
-	if req.ShippingBaseCost > 0 {
+	billableWeightKg, additionalHandling, oversize := packageTotals(req.Packages)
+
+	if schedule.billsByWeight(billableWeightKg) {
+		activity, _ := NewBillableActivity(
+			tenantID, sellerID, facilityID,
+			ActivityTypeShipping,
+			"Shipping fee (billable weight)",
+			billableWeightKg,
+			schedule.ShippingFeePerBillableKg,
+			referenceType, referenceID,
+		)
+		if activity != nil {
+			activities = append(activities, activity)
+		}
+	} else if req.ShippingBaseCost > 0 {
 		shippingFee := req.ShippingBaseCost * (1 + schedule.ShippingMarkupPercent/100)
 		activity, _ := NewBillableActivity(
 			tenantID, sellerID, facilityID,
@@ -241,6 +345,34 @@ func CreateActivitiesFromResult(
 		}
 	}
 
+	if additionalHandling > 0 {
+		activity, _ := NewBillableActivity(
+			tenantID, sellerID, facilityID,
+			ActivityTypeSpecialHandling,
+			"Additional handling surcharge",
+			float64(additionalHandling),
+			schedule.AdditionalHandlingFee,
+			referenceType, referenceID,
+		)
+		if activity != nil {
+			activities = append(activities, activity)
+		}
+	}
+
+	if oversize > 0 {
+		activity, _ := NewBillableActivity(
+			tenantID, sellerID, facilityID,
+			ActivityTypeOversized,
+			"Oversize package surcharge",
+			float64(oversize),
+			schedule.OversizePackageFee,
+			referenceType, referenceID,
+		)
+		if activity != nil {
+			activities = append(activities, activity)
+		}
+	}
+
 	if req.ReturnsProcessed > 0 {
 		activity, _ := NewBillableActivity(
 			tenantID, sellerID, facilityID,
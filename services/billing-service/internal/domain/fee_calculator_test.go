@@ -174,3 +174,71 @@ func TestCreateActivitiesFromResultEmpty(t *testing.T) {
 
 	assert.Empty(t, activities)
 }
+
+func TestFeeCalculatorBillableWeightShipping(t *testing.T) {
+	schedule := &FeeSchedule{
+		ShippingMarkupPercent:    10,
+		ShippingFeePerBillableKg: 0.50,
+		AdditionalHandlingFee:    5.00,
+		OversizePackageFee:       12.00,
+	}
+
+	req := FeeCalculationRequest{
+		ShippingBaseCost: 10,
+		Packages: []ShippedPackage{
+			// Billable weight recorded on the shipment
+			{CarrierCode: "FEDEX", WeightKg: 2, BillableWeightKg: 7.711, AdditionalHandling: true},
+			// Calculated from dimensions: 40x30x30 cm / 5000 = 7.2, rounded up to 7.5 kg
+			{CarrierCode: "DHL", WeightKg: 2, LengthCm: 40, WidthCm: 30, HeightCm: 30},
+			// 130 cm exceeds DHL's longest side limit
+			{CarrierCode: "DHL", WeightKg: 10, LengthCm: 130, WidthCm: 30, HeightCm: 30},
+		},
+	}
+
+	result := NewFeeCalculator(schedule).CalculateAllFees(req)
+	assert.InDelta(t, 38.711, result.BillableWeightKg, 0.0001)
+	// Billable weight replaces the marked up base cost
+	assert.InDelta(t, 38.711*0.5, result.ShippingFee, 0.0001)
+	assert.Equal(t, 5.0, result.AdditionalHandlingFee)
+	assert.Equal(t, 12.0, result.OversizePackageFee)
+	assert.InDelta(t, result.ShippingFee+17, result.TotalFees, 0.0001)
+
+	activities := CreateActivitiesFromResult("TNT-001", "SLR-001", "FAC-001", schedule, req, "order", "ORD-123")
+	require.Len(t, activities, 3)
+
+	amounts := map[string]float64{}
+	for _, activity := range activities {
+		amounts[activity.Description] = activity.Amount
+	}
+	assert.NotContains(t, amounts, "Shipping fee")
+	assert.InDelta(t, 38.711*0.5, amounts["Shipping fee (billable weight)"], 0.0001)
+	assert.Equal(t, 5.0, amounts["Additional handling surcharge"])
+	assert.Equal(t, 12.0, amounts["Oversize package surcharge"])
+}
+
+func TestFeeCalculatorShippingFallsBackToMarkup(t *testing.T) {
+	req := FeeCalculationRequest{
+		ShippingBaseCost: 10,
+		Packages:         []ShippedPackage{{CarrierCode: "FEDEX", WeightKg: 2, BillableWeightKg: 7.711}},
+	}
+
+	t.Run("no per kg rate", func(t *testing.T) {
+		schedule := &FeeSchedule{ShippingMarkupPercent: 10}
+
+		result := NewFeeCalculator(schedule).CalculateAllFees(req)
+		assert.InDelta(t, 11.0, result.ShippingFee, 0.0001)
+
+		activities := CreateActivitiesFromResult("TNT-001", "SLR-001", "FAC-001", schedule, req, "order", "ORD-123")
+		require.Len(t, activities, 1)
+		assert.Equal(t, "Shipping fee", activities[0].Description)
+		assert.InDelta(t, 11.0, activities[0].Amount, 0.0001)
+	})
+
+	t.Run("no packages", func(t *testing.T) {
+		schedule := &FeeSchedule{ShippingMarkupPercent: 10, ShippingFeePerBillableKg: 0.50}
+
+		result := NewFeeCalculator(schedule).CalculateAllFees(FeeCalculationRequest{ShippingBaseCost: 10})
+		assert.InDelta(t, 11.0, result.ShippingFee, 0.0001)
+		assert.Zero(t, result.BillableWeightKg)
+	})
+}
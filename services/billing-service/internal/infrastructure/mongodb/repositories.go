@@ -13,6 +13,7 @@ import (
 	"github.com/wms-platform/services/billing-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -306,9 +307,12 @@ func NewInvoiceRepository(db *mongo.Database, eventFactory *cloudevents.EventFac
 	}
 }
 
-// Save persists an invoice with domain events
+// Save persists an invoice with domain events. The write only succeeds if the stored
+// invoice still has the version that was loaded.
 func (r *InvoiceRepository) Save(ctx context.Context, invoice *domain.Invoice) error {
 	invoice.UpdatedAt = time.Now().UTC()
+	expectedVersion := invoice.Version
+	invoice.Version = expectedVersion + 1
 
 	session, err := r.db.Client().StartSession()
 	if err != nil {
@@ -317,11 +321,12 @@ func (r *InvoiceRepository) Save(ctx context.Context, invoice *domain.Invoice) e
 	defer session.EndSession(ctx)
 
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"invoiceId": invoice.InvoiceID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"invoiceId": invoice.InvoiceID}, expectedVersion)
 		update := bson.M{"$set": invoice}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "Invoice", invoice.InvoiceID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save invoice: %w", err)
 		}
 
@@ -367,6 +372,9 @@ func (r *InvoiceRepository) Save(ctx context.Context, invoice *domain.Invoice) e
 		return nil, nil
 	})
 
+	if err != nil {
+		invoice.Version = expectedVersion
+	}
 	return err
 }
 
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,19 +14,23 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
 	"github.com/wms-platform/shared/pkg/metrics"
 	"github.com/wms-platform/shared/pkg/middleware"
 	"github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
+	"github.com/wms-platform/shared/pkg/secrets"
 	"github.com/wms-platform/shared/pkg/tracing"
 
 	"github.com/wms-platform/services/channel-service/internal/api/handlers"
 	"github.com/wms-platform/services/channel-service/internal/application"
 	"github.com/wms-platform/services/channel-service/internal/domain"
 	"github.com/wms-platform/services/channel-service/internal/infrastructure/adapters"
+	"github.com/wms-platform/services/channel-service/internal/infrastructure/clients"
 	mongoRepo "github.com/wms-platform/services/channel-service/internal/infrastructure/mongodb"
+	"github.com/wms-platform/services/channel-service/internal/infrastructure/x12"
 )
 
 const serviceName = "channel-service"
@@ -44,6 +50,12 @@ type outboxPublisher interface {
 	Stop() error
 }
 
+type eventConsumer interface {
+	Subscribe(topic string, eventType string, handler kafka.EventHandler, opts ...kafka.SubscribeOption)
+	Start(context.Context) error
+	Close() error
+}
+
 type server interface {
 	ListenAndServe() error
 	Shutdown(context.Context) error
@@ -60,11 +72,18 @@ var (
 	}
 	newKafkaProducer        func(*kafka.Config) *kafka.Producer                                                                = kafka.NewProducer
 	newInstrumentedProducer func(*kafka.Producer, *metrics.Metrics, *logging.Logger) *kafka.InstrumentedProducer               = kafka.NewInstrumentedProducer
+	newEventConsumer        func(*kafka.Config, *logging.Logger) eventConsumer                                                 = func(config *kafka.Config, logger *logging.Logger) eventConsumer {
+		return kafka.NewConsumer(config, logger.Logger)
+	}
 	newOutboxPublisher      func(outbox.Repository, *kafka.InstrumentedProducer, *logging.Logger, *metrics.Metrics, *outbox.PublisherConfig) outboxPublisher = func(repo outbox.Repository, producer *kafka.InstrumentedProducer, logger *logging.Logger, m *metrics.Metrics, config *outbox.PublisherConfig) outboxPublisher {
 		return outbox.NewPublisher(repo, producer, logger, m, config)
 	}
-	newChannelRepository    func(*mongo.Database) domain.ChannelRepository                                                     = func(db *mongo.Database) domain.ChannelRepository {
-		return mongoRepo.NewChannelRepository(db)
+	newChannelRepository    func(*mongo.Database, *secrets.Cipher) domain.ChannelRepository                                    = func(db *mongo.Database, cipher *secrets.Cipher) domain.ChannelRepository {
+		repo := mongoRepo.NewChannelRepository(db)
+		if cipher != nil {
+			repo.SetCredentialCipher(cipher)
+		}
+		return repo
 	}
 	newChannelOrderRepository func(*mongo.Database) domain.ChannelOrderRepository                                              = func(db *mongo.Database) domain.ChannelOrderRepository {
 		return mongoRepo.NewChannelOrderRepository(db)
@@ -72,6 +91,15 @@ var (
 	newSyncJobRepository    func(*mongo.Database) domain.SyncJobRepository                                                     = func(db *mongo.Database) domain.SyncJobRepository {
 		return mongoRepo.NewSyncJobRepository(db)
 	}
+	newTradingPartnerRepository func(*mongo.Database) domain.TradingPartnerRepository                                      = func(db *mongo.Database) domain.TradingPartnerRepository {
+		return mongoRepo.NewTradingPartnerRepository(db)
+	}
+	newEDIDocumentRepository func(*mongo.Database) domain.EDIDocumentRepository                                           = func(db *mongo.Database) domain.EDIDocumentRepository {
+		return mongoRepo.NewEDIDocumentRepository(db)
+	}
+	newRefreshLeaseRepository func(*mongo.Database) domain.RefreshLeaseStore                                              = func(db *mongo.Database) domain.RefreshLeaseStore {
+		return mongoRepo.NewRefreshLeaseRepository(db)
+	}
 	newOutboxRepository     func(*mongo.Database) outbox.Repository                                                             = func(db *mongo.Database) outbox.Repository {
 		return mongoRepo.NewOutboxRepository(db)
 	}
@@ -155,8 +183,18 @@ func run(ctx context.Context, quit <-chan os.Signal) error {
 	defer instrumentedMongo.Close(ctx)
 	logger.Info("Connected to MongoDB", "database", config.MongoDB.Database)
 
+	// Load the key used to encrypt channel credentials at rest
+	credentialCipher, err := loadCredentialCipher(config)
+	if err != nil {
+		logger.WithError(err).Error("Failed to load credential encryption key")
+		return err
+	}
+	if credentialCipher == nil {
+		logger.Warn("CREDENTIALS_KEY_FILE not set, channel credentials are stored unencrypted")
+	}
+
 	// Create repositories
-	channelRepo := newChannelRepository(instrumentedMongo.Database())
+	channelRepo := newChannelRepository(instrumentedMongo.Database(), credentialCipher)
 	orderRepo := newChannelOrderRepository(instrumentedMongo.Database())
 	syncJobRepo := newSyncJobRepository(instrumentedMongo.Database())
 	outboxRepo := newOutboxRepository(instrumentedMongo.Database())
@@ -198,6 +236,73 @@ func run(ctx context.Context, quit <-chan os.Signal) error {
 		adapterFactory,
 	)
 
+	// Create WMS orders and read WMS inventory for scheduled syncs
+	orderClient := clients.NewOrderServiceClient(config.OrderServiceURL)
+	channelService.SetOrderCreator(orderClient)
+	channelService.SetInventorySource(clients.NewInventoryServiceClient(config.InventoryServiceURL))
+
+	// Keep replicas from refreshing the same channel's tokens concurrently
+	channelService.SetRefreshLeases(newRefreshLeaseRepository(instrumentedMongo.Database()))
+
+	// Start sync scheduler (runs each active channel's automatic order imports and inventory pushes)
+	syncScheduler := application.NewSyncScheduler(channelService, channelRepo, config.SyncScheduler, logger)
+	if config.SyncSchedulerEnabled {
+		if err := syncScheduler.Start(ctx); err != nil {
+			logger.WithError(err).Error("Failed to start sync scheduler")
+			return err
+		}
+		defer syncScheduler.Stop()
+		logger.Info("Sync scheduler started",
+			"pollInterval", config.SyncScheduler.PollInterval,
+			"orderImport", config.SyncScheduler.OrderImportEnabled,
+			"inventoryPush", config.SyncScheduler.InventoryPushEnabled,
+		)
+	}
+
+	// EDI gateway for B2B trading partners: inbound orders and ship notices become WMS orders and
+	// inbound shipments, and ship confirmations are returned to partners as 945s and 856s
+	var ediService *application.EDIService
+	if config.EDIEnabled {
+		ediService = application.NewEDIService(
+			newTradingPartnerRepository(instrumentedMongo.Database()),
+			newEDIDocumentRepository(instrumentedMongo.Database()),
+			x12.NewCodec(),
+		)
+		ediService.SetOrderCreator(orderClient)
+		ediService.SetInboundShipmentCreator(clients.NewReceivingServiceClient(config.ReceivingServiceURL))
+	}
+
+	// Push carrier tracking milestones back to the order's channel and ship confirmations to EDI partners.
+	// Messages the consumer gives up on are dead-lettered and can be inspected and replayed via the DLQ admin.
+	var dlqAdmin *kafka.DLQAdmin
+	if config.TrackingSyncEnabled || config.EDIEnabled {
+		dlqAdmin = kafka.NewDLQAdmin(config.Kafka, logger.Logger)
+		defer dlqAdmin.Close()
+
+		shippingConsumer := newEventConsumer(config.Kafka, logger)
+		if config.TrackingSyncEnabled {
+			shippingConsumer.Subscribe(kafka.Topics.ShippingEvents, cloudevents.ShipmentTrackingUpdated, shipmentTrackingHandler(channelService))
+		}
+		if config.EDIEnabled {
+			shippingConsumer.Subscribe(kafka.Topics.ShippingEvents, cloudevents.ShipConfirmed, shipConfirmedHandler(ediService))
+		}
+		defer shippingConsumer.Close()
+
+		consumerCtx, cancelConsumer := context.WithCancel(ctx)
+		defer cancelConsumer()
+		go func() {
+			if err := shippingConsumer.Start(consumerCtx); err != nil && err != context.Canceled {
+				logger.WithError(err).Error("Shipping events consumer stopped")
+			}
+		}()
+		logger.Info("Shipping events consumer started",
+			"topic", kafka.Topics.ShippingEvents,
+			"group", config.Kafka.ConsumerGroup,
+			"trackingSync", config.TrackingSyncEnabled,
+			"edi", config.EDIEnabled,
+		)
+	}
+
 	// Create handler with observability
 	channelHandler := handlers.NewChannelHandler(channelService, logger, channelMetrics)
 
@@ -231,6 +336,12 @@ func run(ctx context.Context, quit <-chan os.Signal) error {
 	api := router.Group("/api/v1")
 	api.Use(middleware.RequireTenantAuth()) // All API routes require tenant headers
 	channelHandler.RegisterRoutes(api)
+	if ediService != nil {
+		handlers.NewEDIHandler(ediService, logger).RegisterRoutes(api)
+	}
+	if dlqAdmin != nil {
+		kafka.NewDLQAdminHandler(dlqAdmin, logger.Logger).RegisterRoutes(api.Group("/admin"))
+	}
 
 	// Start server
 	srv := newServer(config.ServerAddr, router)
@@ -261,18 +372,34 @@ func run(ctx context.Context, quit <-chan os.Signal) error {
 
 // Config holds application configuration
 type Config struct {
-	ServerAddr string
-	MongoDB    *mongodb.Config
-	Kafka      *kafka.Config
+	ServerAddr  string
+	Environment string
+	MongoDB     *mongodb.Config
+	Kafka       *kafka.Config
+
+	// CredentialsKeyFile is the key file for encrypting channel credentials, required in production
+	CredentialsKeyFile string
+
+	OrderServiceURL      string
+	InventoryServiceURL  string
+	SyncSchedulerEnabled bool
+	SyncScheduler        application.SyncSchedulerConfig
+	TrackingSyncEnabled  bool
+
+	// EDIEnabled turns on the X12 EDI gateway; inbound ship notices create receiving-service shipments
+	EDIEnabled          bool
+	ReceivingServiceURL string
 }
 
 func loadConfig() *Config {
 	kafkaConfig := kafka.DefaultConfig()
 	kafkaConfig.Brokers = strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ",")
 	kafkaConfig.ClientID = serviceName
+	kafkaConfig.ConsumerGroup = serviceName
 
 	return &Config{
-		ServerAddr: getEnv("SERVER_ADDR", ":8019"),
+		ServerAddr:  getEnv("SERVER_ADDR", ":8019"),
+		Environment: getEnv("ENVIRONMENT", "development"),
 		MongoDB: &mongodb.Config{
 			URI:            getEnv("MONGODB_URI", "mongodb://localhost:27017"),
 			Database:       getEnv("MONGODB_DATABASE", "channel_db"),
@@ -281,7 +408,89 @@ func loadConfig() *Config {
 			MinPoolSize:    10,
 		},
 		Kafka: kafkaConfig,
+
+		CredentialsKeyFile: getEnv("CREDENTIALS_KEY_FILE", ""),
+
+		OrderServiceURL:      getEnv("ORDER_SERVICE_URL", "http://localhost:8001"),
+		InventoryServiceURL:  getEnv("INVENTORY_SERVICE_URL", "http://localhost:8008"),
+		SyncSchedulerEnabled: getEnv("SYNC_SCHEDULER_ENABLED", "true") == "true",
+		SyncScheduler:        loadSyncSchedulerConfig(),
+		TrackingSyncEnabled:  getEnv("TRACKING_SYNC_ENABLED", "true") == "true",
+
+		EDIEnabled:          getEnv("EDI_ENABLED", "true") == "true",
+		ReceivingServiceURL: getEnv("RECEIVING_SERVICE_URL", "http://localhost:8010"),
+	}
+}
+
+func loadSyncSchedulerConfig() application.SyncSchedulerConfig {
+	config := application.DefaultSyncSchedulerConfig()
+	if interval, err := time.ParseDuration(getEnv("SYNC_SCHEDULER_POLL_INTERVAL", "")); err == nil && interval > 0 {
+		config.PollInterval = interval
+	}
+	config.OrderImportEnabled = getEnv("SYNC_SCHEDULER_ORDER_IMPORT_ENABLED", "true") == "true"
+	config.InventoryPushEnabled = getEnv("SYNC_SCHEDULER_INVENTORY_PUSH_ENABLED", "true") == "true"
+	return config
+}
+
+// shipmentTrackingHandler pushes shipping-service tracking updates to the channel the order came from
+func shipmentTrackingHandler(service *application.ChannelService) kafka.EventHandler {
+	return func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
+		payload, err := json.Marshal(event.Data)
+		if err != nil {
+			return fmt.Errorf("failed to read tracking event data: %w", err)
+		}
+		var data cloudevents.ShipmentTrackingUpdatedData
+		if err := json.Unmarshal(payload, &data); err != nil {
+			return fmt.Errorf("failed to decode tracking event data: %w", err)
+		}
+
+		return service.SyncShipmentTracking(ctx, application.ShipmentTrackingCommand{
+			WMSOrderID:     data.OrderID,
+			TrackingNumber: data.TrackingNumber,
+			Carrier:        data.Carrier,
+			Milestone:      data.Milestone,
+		})
+	}
+}
+
+// shipConfirmedHandler sends the ship confirmation documents EDI trading partners expect for their orders
+func shipConfirmedHandler(service *application.EDIService) kafka.EventHandler {
+	return func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
+		payload, err := json.Marshal(event.Data)
+		if err != nil {
+			return fmt.Errorf("failed to read ship confirmed event data: %w", err)
+		}
+		var data cloudevents.ShipConfirmedData
+		if err := json.Unmarshal(payload, &data); err != nil {
+			return fmt.Errorf("failed to decode ship confirmed event data: %w", err)
+		}
+
+		return service.HandleShipmentConfirmed(ctx, application.ShipmentConfirmedCommand{
+			WMSOrderID:     data.OrderID,
+			ShipmentID:     data.ShipmentID,
+			TrackingNumber: data.TrackingNumber,
+			Carrier:        data.Carrier,
+			ShippedAt:      data.ShippedAt,
+			WeightKg:       data.BillableWeight,
+		})
+	}
+}
+
+// loadCredentialCipher loads the credential encryption key. Outside production the key
+// file is optional so local setups keep working; a nil cipher stores credentials unencrypted.
+func loadCredentialCipher(config *Config) (*secrets.Cipher, error) {
+	if config.CredentialsKeyFile == "" {
+		if config.Environment == "production" {
+			return nil, fmt.Errorf("CREDENTIALS_KEY_FILE is required in production")
+		}
+		return nil, nil
+	}
+
+	provider, err := secrets.LoadLocalKeyProvider(config.CredentialsKeyFile)
+	if err != nil {
+		return nil, err
 	}
+	return secrets.NewCipher(provider), nil
 }
 
 func getEnv(key, defaultValue string) string {
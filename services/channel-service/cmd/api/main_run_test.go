@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 	"github.com/wms-platform/services/channel-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/kafka"
@@ -17,6 +18,7 @@ import (
 	"github.com/wms-platform/shared/pkg/metrics"
 	"github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
+	"github.com/wms-platform/shared/pkg/secrets"
 	"github.com/wms-platform/shared/pkg/tracing"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -90,6 +92,32 @@ func (f *fakeTracerProvider) Shutdown(context.Context) error {
 	return nil
 }
 
+type fakeConsumer struct {
+	mu         sync.Mutex
+	subscribed []string
+	started    bool
+	closed     bool
+}
+
+func (f *fakeConsumer) Subscribe(topic string, eventType string, _ kafka.EventHandler, _ ...kafka.SubscribeOption) {
+	f.subscribed = append(f.subscribed, topic+"/"+eventType)
+}
+
+func (f *fakeConsumer) Start(ctx context.Context) error {
+	f.mu.Lock()
+	f.started = true
+	f.mu.Unlock()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (f *fakeConsumer) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
 func stubRunDeps() (func(), *fakeMongo, *fakeServer) {
 	origNewMongoClient := newMongoClient
 	origNewInstrumentedMongo := newInstrumentedMongo
@@ -97,7 +125,11 @@ func stubRunDeps() (func(), *fakeMongo, *fakeServer) {
 	origNewChannelOrderRepository := newChannelOrderRepository
 	origNewSyncJobRepository := newSyncJobRepository
 	origNewOutboxRepository := newOutboxRepository
+	origNewRefreshLeaseRepository := newRefreshLeaseRepository
+	origNewTradingPartnerRepository := newTradingPartnerRepository
+	origNewEDIDocumentRepository := newEDIDocumentRepository
 	origNewServer := newServer
+	origNewEventConsumer := newEventConsumer
 
 	fakeMongoClient := &fakeMongo{}
 	fakeSrv := &fakeServer{}
@@ -108,7 +140,7 @@ func stubRunDeps() (func(), *fakeMongo, *fakeServer) {
 	newInstrumentedMongo = func(*mongodb.Client, *metrics.Metrics, *logging.Logger) mongoClient {
 		return fakeMongoClient
 	}
-	newChannelRepository = func(*mongo.Database) domain.ChannelRepository {
+	newChannelRepository = func(*mongo.Database, *secrets.Cipher) domain.ChannelRepository {
 		return &fakeChannelRepo{}
 	}
 	newChannelOrderRepository = func(*mongo.Database) domain.ChannelOrderRepository {
@@ -120,9 +152,21 @@ func stubRunDeps() (func(), *fakeMongo, *fakeServer) {
 	newOutboxRepository = func(*mongo.Database) outbox.Repository {
 		return &fakeOutboxRepo{}
 	}
+	newRefreshLeaseRepository = func(*mongo.Database) domain.RefreshLeaseStore {
+		return &fakeRefreshLeases{}
+	}
+	newTradingPartnerRepository = func(*mongo.Database) domain.TradingPartnerRepository {
+		return &fakeTradingPartnerRepo{}
+	}
+	newEDIDocumentRepository = func(*mongo.Database) domain.EDIDocumentRepository {
+		return &fakeEDIDocumentRepo{}
+	}
 	newServer = func(string, http.Handler) server {
 		return fakeSrv
 	}
+	newEventConsumer = func(*kafka.Config, *logging.Logger) eventConsumer {
+		return &fakeConsumer{}
+	}
 
 	return func() {
 		newMongoClient = origNewMongoClient
@@ -131,10 +175,21 @@ func stubRunDeps() (func(), *fakeMongo, *fakeServer) {
 		newChannelOrderRepository = origNewChannelOrderRepository
 		newSyncJobRepository = origNewSyncJobRepository
 		newOutboxRepository = origNewOutboxRepository
+		newRefreshLeaseRepository = origNewRefreshLeaseRepository
+		newTradingPartnerRepository = origNewTradingPartnerRepository
+		newEDIDocumentRepository = origNewEDIDocumentRepository
 		newServer = origNewServer
+		newEventConsumer = origNewEventConsumer
 	}, fakeMongoClient, fakeSrv
 }
 
+type fakeRefreshLeases struct{}
+
+func (f *fakeRefreshLeases) AcquireRefreshLease(context.Context, string, string, time.Duration) (bool, error) {
+	return true, nil
+}
+func (f *fakeRefreshLeases) ReleaseRefreshLease(context.Context, string, string) error { return nil }
+
 type fakeChannelRepo struct{}
 
 func (f *fakeChannelRepo) Save(context.Context, *domain.Channel) error                      { return nil }
@@ -162,15 +217,19 @@ func (f *fakeOrderRepo) FindByExternalID(context.Context, string, string) (*doma
 func (f *fakeOrderRepo) FindByChannelID(context.Context, string, domain.Pagination) ([]*domain.ChannelOrder, error) {
 	return nil, nil
 }
+func (f *fakeOrderRepo) FindByWMSOrderID(context.Context, string) (*domain.ChannelOrder, error) {
+	return nil, nil
+}
 func (f *fakeOrderRepo) FindUnimported(context.Context, string) ([]*domain.ChannelOrder, error) {
 	return nil, nil
 }
 func (f *fakeOrderRepo) FindWithoutTracking(context.Context, string) ([]*domain.ChannelOrder, error) {
 	return nil, nil
 }
-func (f *fakeOrderRepo) MarkImported(context.Context, string, string) error { return nil }
-func (f *fakeOrderRepo) MarkTrackingPushed(context.Context, string) error   { return nil }
-func (f *fakeOrderRepo) Count(context.Context, string) (int64, error)       { return 0, nil }
+func (f *fakeOrderRepo) MarkImported(context.Context, string, string) error          { return nil }
+func (f *fakeOrderRepo) MarkTrackingPushed(context.Context, string) error            { return nil }
+func (f *fakeOrderRepo) MarkTrackingMilestone(context.Context, string, string) error { return nil }
+func (f *fakeOrderRepo) Count(context.Context, string) (int64, error)                { return 0, nil }
 
 type fakeSyncJobRepo struct{}
 
@@ -199,6 +258,45 @@ func (f *fakeOutboxRepo) FindByAggregateID(context.Context, string) ([]*outbox.O
 	return nil, nil
 }
 
+type fakeTradingPartnerRepo struct{}
+
+func (f *fakeTradingPartnerRepo) Save(context.Context, *domain.TradingPartner) error { return nil }
+func (f *fakeTradingPartnerRepo) FindByID(context.Context, string) (*domain.TradingPartner, error) {
+	return nil, nil
+}
+func (f *fakeTradingPartnerRepo) FindByInterchangeIDs(context.Context, string, string, string, string) (*domain.TradingPartner, error) {
+	return nil, nil
+}
+func (f *fakeTradingPartnerRepo) FindByTenantID(context.Context, string) ([]*domain.TradingPartner, error) {
+	return nil, nil
+}
+func (f *fakeTradingPartnerRepo) Delete(context.Context, string) error { return nil }
+func (f *fakeTradingPartnerRepo) NextControlNumbers(context.Context, string) (domain.ControlNumbers, error) {
+	return domain.ControlNumbers{}, nil
+}
+
+type fakeEDIDocumentRepo struct{}
+
+func (f *fakeEDIDocumentRepo) Save(context.Context, *domain.EDIDocument) error { return nil }
+func (f *fakeEDIDocumentRepo) Insert(context.Context, ...*domain.EDIDocument) error {
+	return nil
+}
+func (f *fakeEDIDocumentRepo) FindByID(context.Context, string) (*domain.EDIDocument, error) {
+	return nil, nil
+}
+func (f *fakeEDIDocumentRepo) FindByPartnerID(context.Context, string, domain.EDIDirection, domain.Pagination) ([]*domain.EDIDocument, error) {
+	return nil, nil
+}
+func (f *fakeEDIDocumentRepo) FindByInterchangeControlNumber(context.Context, string, domain.EDIDirection, string) ([]*domain.EDIDocument, error) {
+	return nil, nil
+}
+func (f *fakeEDIDocumentRepo) FindByGroupControlNumber(context.Context, string, domain.EDIDirection, string) ([]*domain.EDIDocument, error) {
+	return nil, nil
+}
+func (f *fakeEDIDocumentRepo) FindByWMSOrderID(context.Context, string) ([]*domain.EDIDocument, error) {
+	return nil, nil
+}
+
 func TestRunSuccess(t *testing.T) {
 	restoreDeps, fakeMongoClient, fakeSrv := stubRunDeps()
 	origNewOutboxPublisher := newOutboxPublisher
@@ -220,11 +318,35 @@ func TestRunSuccess(t *testing.T) {
 		return fakeTracer, nil
 	}
 
+	consumer := &fakeConsumer{}
+	newEventConsumer = func(*kafka.Config, *logging.Logger) eventConsumer {
+		return consumer
+	}
+
+	var handler http.Handler
+	newServer = func(_ string, h http.Handler) server {
+		handler = h
+		return fakeSrv
+	}
+
 	quit := make(chan os.Signal, 1)
 	quit <- syscall.SIGTERM
 
 	err := run(context.Background(), quit)
 	require.NoError(t, err)
+	require.Equal(t, []string{
+		"wms.shipping.events/wms.shipping.tracking-updated",
+		"wms.shipping.events/wms.shipping.confirmed",
+	}, consumer.subscribed)
+
+	// Dead-lettered shipping events can be replayed through the admin API
+	paths := make([]string, 0)
+	for _, route := range handler.(*gin.Engine).Routes() {
+		paths = append(paths, route.Method+" "+route.Path)
+	}
+	require.Contains(t, paths, "GET /api/v1/admin/dlq/:topic/messages")
+	require.Contains(t, paths, "POST /api/v1/admin/dlq/:topic/replay-all")
+	require.True(t, consumer.closed)
 	require.True(t, fakePub.started)
 	require.True(t, fakePub.stopped)
 	require.True(t, fakeSrv.shutdown)
@@ -1,12 +1,19 @@
 package main
 
 import (
+	"context"
+	"encoding/base64"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/services/channel-service/internal/application"
+	"github.com/wms-platform/services/channel-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/metrics"
+	"github.com/wms-platform/shared/pkg/secrets"
 )
 
 func TestGetEnv(t *testing.T) {
@@ -34,6 +41,8 @@ func TestLoadConfig(t *testing.T) {
 	require.Equal(t, "channel_test", cfg.MongoDB.Database)
 	require.Equal(t, []string{"broker1:9092", "broker2:9092"}, cfg.Kafka.Brokers)
 	require.Equal(t, serviceName, cfg.Kafka.ClientID)
+	require.Equal(t, serviceName, cfg.Kafka.ConsumerGroup)
+	require.True(t, cfg.TrackingSyncEnabled)
 }
 
 func TestChannelMetrics(t *testing.T) {
@@ -47,3 +56,41 @@ func TestChannelMetrics(t *testing.T) {
 	cm.RecordWebhookReceived("ch-1", "orders/create", "success")
 	cm.RecordWebhookReceived("ch-1", "orders/create", "error")
 }
+
+func TestLoadCredentialCipher(t *testing.T) {
+	cipher, err := loadCredentialCipher(&Config{Environment: "development"})
+	require.NoError(t, err)
+	require.Nil(t, cipher)
+
+	_, err = loadCredentialCipher(&Config{Environment: "production"})
+	require.Error(t, err)
+
+	key, err := secrets.GenerateKey()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "keys.json")
+	content := `{"currentVersion": 1, "keys": {"1": "` + base64.StdEncoding.EncodeToString(key) + `"}}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	cipher, err = loadCredentialCipher(&Config{Environment: "production", CredentialsKeyFile: path})
+	require.NoError(t, err)
+	require.NotNil(t, cipher)
+}
+
+func TestShipmentTrackingHandler(t *testing.T) {
+	service := application.NewChannelService(&fakeChannelRepo{}, &fakeOrderRepo{}, &fakeSyncJobRepo{}, domain.NewAdapterFactory())
+	handler := shipmentTrackingHandler(service)
+
+	event := &cloudevents.WMSCloudEvent{
+		Type: cloudevents.ShipmentTrackingUpdated,
+		Data: map[string]interface{}{
+			"orderId":        "ORD-1",
+			"trackingNumber": "1Z999AA10123456784",
+			"carrier":        "UPS",
+			"milestone":      "in_transit",
+		},
+	}
+	require.NoError(t, handler(context.Background(), event))
+
+	event.Data = "not an object"
+	require.Error(t, handler(context.Background(), event))
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	mongoRepo "github.com/wms-platform/services/channel-service/internal/infrastructure/mongodb"
+	"github.com/wms-platform/shared/pkg/secrets"
+)
+
+// Rotation tool to re-encrypt channel credentials with the current key version.
+// Run it after adding a new key to the key file and making it current, and to
+// encrypt credentials written before encryption was enabled. Old key versions
+// can be removed from the key file once a run reports nothing left to rotate.
+
+var (
+	mongoURI  = flag.String("mongo-uri", "mongodb://localhost:27017", "MongoDB connection URI")
+	dbName    = flag.String("db", "channel_db", "Database name")
+	keyFile   = flag.String("key-file", "", "Credentials key file (same as CREDENTIALS_KEY_FILE)")
+	dryRun    = flag.Bool("dry-run", true, "Dry run mode (no actual writes)")
+	batchSize = flag.Int("batch-size", 100, "Batch size for processing")
+)
+
+func main() {
+	flag.Parse()
+
+	if *keyFile == "" {
+		log.Fatalf("-key-file is required")
+	}
+
+	log.Printf("Starting channel credential rotation...")
+	log.Printf("Database: %s", *dbName)
+	log.Printf("Dry Run: %v", *dryRun)
+	log.Printf("Batch Size: %d", *batchSize)
+
+	provider, err := secrets.LoadLocalKeyProvider(*keyFile)
+	if err != nil {
+		log.Fatalf("Failed to load key file: %v", err)
+	}
+	currentVersion, err := provider.CurrentKeyVersion(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to read current key version: %v", err)
+	}
+	log.Printf("Current Key Version: %d", currentVersion)
+
+	// Connect to MongoDB
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(*mongoURI))
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	defer client.Disconnect(context.Background())
+
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("Failed to ping MongoDB: %v", err)
+	}
+	log.Println("Connected to MongoDB successfully")
+
+	repo := mongoRepo.NewChannelRepository(client.Database(*dbName))
+	repo.SetCredentialCipher(secrets.NewCipher(provider))
+
+	result, err := repo.RotateCredentials(context.Background(), int32(*batchSize), *dryRun)
+	if err != nil {
+		log.Fatalf("Rotation failed: %v", err)
+	}
+
+	for _, msg := range result.Errors {
+		log.Printf("WARNING: %s", msg)
+	}
+	log.Printf("Channels scanned: %d", result.Scanned)
+	log.Printf("Channels rotated: %d", result.Rotated)
+	log.Printf("Channels skipped: %d", result.Skipped)
+	log.Printf("Channels failed: %d", result.Failed)
+
+	if result.Failed > 0 {
+		log.Fatalf("Rotation completed with %d failures", result.Failed)
+	}
+	if *dryRun {
+		log.Println("Dry run completed, no documents were changed")
+		return
+	}
+	log.Println("Rotation completed successfully!")
+}
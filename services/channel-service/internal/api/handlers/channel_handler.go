@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 	"github.com/wms-platform/shared/pkg/middleware"
 
 	"github.com/wms-platform/services/channel-service/internal/application"
+	"github.com/wms-platform/services/channel-service/internal/domain"
 )
 
 // ChannelMetrics interface for channel-specific metrics
@@ -46,6 +48,8 @@ func (h *ChannelHandler) RegisterRoutes(r *gin.RouterGroup) {
 		channels.GET("/:id", h.GetChannel)
 		channels.PUT("/:id", h.UpdateChannel)
 		channels.DELETE("/:id", h.DisconnectChannel)
+		channels.POST("/:id/pause", h.PauseChannel)
+		channels.POST("/:id/resume", h.ResumeChannel)
 		channels.GET("/:id/orders", h.GetChannelOrders)
 		channels.GET("/:id/orders/unimported", h.GetUnimportedOrders)
 		channels.GET("/:id/sync-jobs", h.GetSyncJobs)
@@ -186,6 +190,59 @@ func (h *ChannelHandler) DisconnectChannel(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Channel disconnected"})
 }
 
+// PauseChannel handles POST /channels/:id/pause
+func (h *ChannelHandler) PauseChannel(c *gin.Context) {
+	channelID := c.Param("id")
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"channel.id": channelID,
+		"operation":  "pause_channel",
+	})
+
+	channel, err := h.service.PauseChannel(c.Request.Context(), channelID)
+	if err != nil {
+		h.respondChannelStateError(c, "Failed to pause channel", channelID, err)
+		return
+	}
+
+	h.logger.Info("Channel paused", "channel_id", channelID)
+	c.JSON(http.StatusOK, channel)
+}
+
+// ResumeChannel handles POST /channels/:id/resume
+func (h *ChannelHandler) ResumeChannel(c *gin.Context) {
+	channelID := c.Param("id")
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"channel.id": channelID,
+		"operation":  "resume_channel",
+	})
+
+	channel, err := h.service.ResumeChannel(c.Request.Context(), channelID)
+	if err != nil {
+		h.respondChannelStateError(c, "Failed to resume channel", channelID, err)
+		return
+	}
+
+	h.logger.Info("Channel resumed", "channel_id", channelID)
+	c.JSON(http.StatusOK, channel)
+}
+
+// respondChannelStateError maps errors from channel status changes to HTTP responses
+func (h *ChannelHandler) respondChannelStateError(c *gin.Context, msg, channelID string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrChannelNotFound):
+		h.logger.Warn("Channel not found", "channel_id", channelID)
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrChannelDisconnected):
+		h.logger.Warn(msg, "channel_id", channelID, "error", err.Error())
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error(msg, "channel_id", channelID)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
 // GetChannelOrders handles GET /channels/:id/orders
 func (h *ChannelHandler) GetChannelOrders(c *gin.Context) {
 	channelID := c.Param("id")
@@ -109,6 +109,10 @@ func (f *fakeOrderRepo) FindUnimported(ctx context.Context, channelID string) ([
 	return f.findUnimportedFn(ctx, channelID)
 }
 
+func (f *fakeOrderRepo) FindByWMSOrderID(context.Context, string) (*domain.ChannelOrder, error) {
+	return nil, errUnexpected
+}
+
 func (f *fakeOrderRepo) FindWithoutTracking(context.Context, string) ([]*domain.ChannelOrder, error) {
 	return nil, errUnexpected
 }
@@ -127,6 +131,10 @@ func (f *fakeOrderRepo) MarkTrackingPushed(ctx context.Context, externalOrderID
 	return f.markTrackingFn(ctx, externalOrderID)
 }
 
+func (f *fakeOrderRepo) MarkTrackingMilestone(context.Context, string, string) error {
+	return errUnexpected
+}
+
 func (f *fakeOrderRepo) Count(context.Context, string) (int64, error) {
 	return 0, errUnexpected
 }
@@ -176,6 +184,7 @@ type fakeAdapter struct {
 	createFulfillmentFn  func(context.Context, *domain.Channel, domain.FulfillmentRequest) error
 	registerWebhooksFn   func(context.Context, *domain.Channel, string) error
 	validateWebhookFn    func(context.Context, *domain.Channel, string, []byte) bool
+	refreshFn            func(context.Context, domain.ChannelCredentials) (*domain.TokenRefresh, error)
 }
 
 func (f *fakeAdapter) GetType() domain.ChannelType {
@@ -228,6 +237,10 @@ func (f *fakeAdapter) CreateFulfillment(ctx context.Context, channel *domain.Cha
 	return f.createFulfillmentFn(ctx, channel, fulfillment)
 }
 
+func (f *fakeAdapter) PushShipmentStatus(context.Context, *domain.Channel, string, domain.ShipmentStatusUpdate) error {
+	return errUnexpected
+}
+
 func (f *fakeAdapter) RegisterWebhooks(ctx context.Context, channel *domain.Channel, webhookURL string) error {
 	if f.registerWebhooksFn == nil {
 		return errUnexpected
@@ -242,6 +255,13 @@ func (f *fakeAdapter) ValidateWebhook(ctx context.Context, channel *domain.Chann
 	return f.validateWebhookFn(ctx, channel, signature, body)
 }
 
+func (f *fakeAdapter) RefreshCredentials(ctx context.Context, creds domain.ChannelCredentials) (*domain.TokenRefresh, error) {
+	if f.refreshFn == nil {
+		return nil, domain.ErrTokenRefreshNotSupported
+	}
+	return f.refreshFn(ctx, creds)
+}
+
 type fakeMetrics struct {
 	syncStatus       string
 	ordersImported   int
@@ -786,6 +806,55 @@ func TestHandleWebhookBodyError(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+func TestPauseChannelNotFound(t *testing.T) {
+	env := newHandlerEnv(nil)
+	env.channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return nil, domain.ErrChannelNotFound
+	}
+
+	resp := performRequest(env.router, http.MethodPost, "/channels/ch-1/pause", nil, nil)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestPauseChannelSuccess(t *testing.T) {
+	env := newHandlerEnv(nil)
+	channel := newTestChannel(t, domain.ChannelTypeShopify)
+	env.channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	env.channelRepo.saveFn = func(context.Context, *domain.Channel) error { return nil }
+
+	resp := performRequest(env.router, http.MethodPost, "/channels/"+channel.ChannelID+"/pause", nil, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, domain.ChannelStatusPaused, channel.Status)
+}
+
+func TestResumeChannelSuccess(t *testing.T) {
+	env := newHandlerEnv(nil)
+	channel := newTestChannel(t, domain.ChannelTypeShopify)
+	channel.Pause()
+	env.channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	env.channelRepo.saveFn = func(context.Context, *domain.Channel) error { return nil }
+
+	resp := performRequest(env.router, http.MethodPost, "/channels/"+channel.ChannelID+"/resume", nil, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, domain.ChannelStatusActive, channel.Status)
+}
+
+func TestResumeChannelDisconnected(t *testing.T) {
+	env := newHandlerEnv(nil)
+	channel := newTestChannel(t, domain.ChannelTypeShopify)
+	channel.Disconnect()
+	env.channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+
+	resp := performRequest(env.router, http.MethodPost, "/channels/"+channel.ChannelID+"/resume", nil, nil)
+	require.Equal(t, http.StatusConflict, resp.Code)
+}
+
 func TestHandleWebhookUnauthorized(t *testing.T) {
 	adapter := &fakeAdapter{
 		channelType: domain.ChannelTypeShopify,
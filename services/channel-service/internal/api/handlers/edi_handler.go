@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/services/channel-service/internal/application"
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// x12ContentType is the media type outbound interchanges are served with
+const x12ContentType = "application/edi-x12"
+
+// EDIHandler handles EDI trading partner and document HTTP requests
+type EDIHandler struct {
+	service *application.EDIService
+	logger  *logging.Logger
+}
+
+// NewEDIHandler creates a new EDI handler
+func NewEDIHandler(service *application.EDIService, logger *logging.Logger) *EDIHandler {
+	return &EDIHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers the EDI routes
+func (h *EDIHandler) RegisterRoutes(r *gin.RouterGroup) {
+	edi := r.Group("/edi")
+	{
+		edi.POST("/inbound", h.ReceiveInterchange)
+
+		edi.POST("/partners", h.CreateTradingPartner)
+		edi.GET("/partners", h.GetTradingPartners)
+		edi.GET("/partners/:id", h.GetTradingPartner)
+		edi.PUT("/partners/:id", h.UpdateTradingPartner)
+		edi.DELETE("/partners/:id", h.DeleteTradingPartner)
+		edi.GET("/partners/:id/documents", h.GetDocuments)
+
+		edi.GET("/documents/:id", h.GetDocument)
+		edi.GET("/documents/:id/payload", h.GetDocumentPayload)
+		edi.POST("/documents/:id/retry", h.RetryDocument)
+		edi.POST("/documents/:id/sent", h.MarkDocumentSent)
+	}
+}
+
+// ReceiveInterchange handles POST /edi/inbound with a raw X12 interchange as the body
+func (h *EDIHandler) ReceiveInterchange(c *gin.Context) {
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation": "receive_edi_interchange",
+	})
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to read EDI interchange")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read body"})
+		return
+	}
+
+	result, err := h.service.ReceiveInterchange(c.Request.Context(), body)
+	if err != nil {
+		h.respondEDIError(c, "Failed to receive EDI interchange", err)
+		return
+	}
+
+	h.logger.Info("EDI interchange received",
+		"partner_id", result.PartnerID,
+		"interchange_control_number", result.InterchangeControlNumber,
+		"documents", len(result.Documents),
+	)
+	c.JSON(http.StatusAccepted, result)
+}
+
+// CreateTradingPartner handles POST /edi/partners
+func (h *EDIHandler) CreateTradingPartner(c *gin.Context) {
+	var cmd application.CreateTradingPartnerCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		h.logger.Warn("Invalid create trading partner request", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"seller.id": cmd.SellerID,
+		"operation": "create_trading_partner",
+	})
+
+	partner, err := h.service.CreateTradingPartner(c.Request.Context(), cmd)
+	if err != nil {
+		h.respondEDIError(c, "Failed to create trading partner", err)
+		return
+	}
+
+	h.logger.Info("Trading partner created", "partner_id", partner.ID, "seller_id", cmd.SellerID)
+	c.JSON(http.StatusCreated, partner)
+}
+
+// GetTradingPartners handles GET /edi/partners?tenantId=
+func (h *EDIHandler) GetTradingPartners(c *gin.Context) {
+	tenantID := c.Query("tenantId")
+	if tenantID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tenantId is required"})
+		return
+	}
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"tenant.id": tenantID,
+		"operation": "get_trading_partners",
+	})
+
+	partners, err := h.service.GetTradingPartnersByTenant(c.Request.Context(), tenantID)
+	if err != nil {
+		h.respondEDIError(c, "Failed to get trading partners", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"partners": partners})
+}
+
+// GetTradingPartner handles GET /edi/partners/:id
+func (h *EDIHandler) GetTradingPartner(c *gin.Context) {
+	partnerID := c.Param("id")
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"partner.id": partnerID,
+		"operation":  "get_trading_partner",
+	})
+
+	partner, err := h.service.GetTradingPartner(c.Request.Context(), partnerID)
+	if err != nil {
+		h.respondEDIError(c, "Failed to get trading partner", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, partner)
+}
+
+// UpdateTradingPartner handles PUT /edi/partners/:id
+func (h *EDIHandler) UpdateTradingPartner(c *gin.Context) {
+	partnerID := c.Param("id")
+
+	var cmd application.UpdateTradingPartnerCommand
+	if err := c.ShouldBindJSON(&cmd); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"partner.id": partnerID,
+		"operation":  "update_trading_partner",
+	})
+
+	partner, err := h.service.UpdateTradingPartner(c.Request.Context(), partnerID, cmd)
+	if err != nil {
+		h.respondEDIError(c, "Failed to update trading partner", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, partner)
+}
+
+// DeleteTradingPartner handles DELETE /edi/partners/:id
+func (h *EDIHandler) DeleteTradingPartner(c *gin.Context) {
+	partnerID := c.Param("id")
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"partner.id": partnerID,
+		"operation":  "delete_trading_partner",
+	})
+
+	if err := h.service.DeleteTradingPartner(c.Request.Context(), partnerID); err != nil {
+		h.respondEDIError(c, "Failed to delete trading partner", err)
+		return
+	}
+
+	h.logger.Info("Trading partner deleted", "partner_id", partnerID)
+	c.JSON(http.StatusOK, gin.H{"message": "Trading partner deleted"})
+}
+
+// GetDocuments handles GET /edi/partners/:id/documents?direction=
+func (h *EDIHandler) GetDocuments(c *gin.Context) {
+	partnerID := c.Param("id")
+	direction := c.Query("direction")
+	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	pageSize, _ := strconv.ParseInt(c.DefaultQuery("pageSize", "20"), 10, 64)
+
+	if direction != "" && direction != string(domain.EDIDirectionInbound) && direction != string(domain.EDIDirectionOutbound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "direction must be inbound or outbound"})
+		return
+	}
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"partner.id": partnerID,
+		"page":       page,
+		"page_size":  pageSize,
+		"operation":  "get_edi_documents",
+	})
+
+	docs, err := h.service.GetDocuments(c.Request.Context(), partnerID, direction, page, pageSize)
+	if err != nil {
+		h.respondEDIError(c, "Failed to get EDI documents", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"documents": docs,
+		"page":      page,
+		"size":      pageSize,
+	})
+}
+
+// GetDocument handles GET /edi/documents/:id
+func (h *EDIHandler) GetDocument(c *gin.Context) {
+	documentID := c.Param("id")
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"edi.document_id": documentID,
+		"operation":       "get_edi_document",
+	})
+
+	doc, err := h.service.GetDocument(c.Request.Context(), documentID)
+	if err != nil {
+		h.respondEDIError(c, "Failed to get EDI document", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, doc)
+}
+
+// GetDocumentPayload handles GET /edi/documents/:id/payload, returning the raw X12 interchange
+func (h *EDIHandler) GetDocumentPayload(c *gin.Context) {
+	documentID := c.Param("id")
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"edi.document_id": documentID,
+		"operation":       "get_edi_document_payload",
+	})
+
+	payload, err := h.service.GetDocumentPayload(c.Request.Context(), documentID)
+	if err != nil {
+		h.respondEDIError(c, "Failed to get EDI document payload", err)
+		return
+	}
+
+	c.Data(http.StatusOK, x12ContentType, []byte(payload))
+}
+
+// RetryDocument handles POST /edi/documents/:id/retry
+func (h *EDIHandler) RetryDocument(c *gin.Context) {
+	documentID := c.Param("id")
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"edi.document_id": documentID,
+		"operation":       "retry_edi_document",
+	})
+
+	doc, err := h.service.RetryDocument(c.Request.Context(), documentID)
+	if err != nil {
+		h.respondEDIError(c, "Failed to retry EDI document", err)
+		return
+	}
+
+	h.logger.Info("EDI document retried", "document_id", documentID, "status", doc.Status)
+	c.JSON(http.StatusOK, doc)
+}
+
+// MarkDocumentSent handles POST /edi/documents/:id/sent
+func (h *EDIHandler) MarkDocumentSent(c *gin.Context) {
+	documentID := c.Param("id")
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"edi.document_id": documentID,
+		"operation":       "mark_edi_document_sent",
+	})
+
+	doc, err := h.service.MarkDocumentSent(c.Request.Context(), documentID)
+	if err != nil {
+		h.respondEDIError(c, "Failed to mark EDI document sent", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, doc)
+}
+
+// respondEDIError maps EDI errors to HTTP responses
+func (h *EDIHandler) respondEDIError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTradingPartnerNotFound), errors.Is(err, domain.ErrEDIDocumentNotFound):
+		h.logger.Warn(msg, "error", err.Error())
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidTradingPartner), errors.Is(err, domain.ErrInvalidInterchange):
+		h.logger.Warn(msg, "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrTradingPartnerInactive):
+		h.logger.Warn(msg, "error", err.Error())
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrDuplicateInterchange),
+		errors.Is(err, domain.ErrEDIDocumentNotRetryable),
+		errors.Is(err, domain.ErrEDIDocumentNotTransmittable):
+		h.logger.Warn(msg, "error", err.Error())
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.WithError(err).Error(msg)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
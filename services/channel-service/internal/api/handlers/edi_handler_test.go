@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/services/channel-service/internal/application"
+	"github.com/wms-platform/services/channel-service/internal/domain"
+	"github.com/wms-platform/services/channel-service/internal/infrastructure/x12"
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+// memoryPartnerRepo is an in-memory domain.TradingPartnerRepository
+type memoryPartnerRepo struct {
+	partners map[string]*domain.TradingPartner
+	controls int64
+}
+
+func (m *memoryPartnerRepo) Save(_ context.Context, partner *domain.TradingPartner) error {
+	m.partners[partner.PartnerID] = partner
+	return nil
+}
+
+func (m *memoryPartnerRepo) FindByID(_ context.Context, partnerID string) (*domain.TradingPartner, error) {
+	return m.partners[partnerID], nil
+}
+
+func (m *memoryPartnerRepo) FindByInterchangeIDs(_ context.Context, senderQualifier, senderID, receiverQualifier, receiverID string) (*domain.TradingPartner, error) {
+	for _, p := range m.partners {
+		env := p.Envelope
+		if env.PartnerQualifier == senderQualifier && env.PartnerID == senderID &&
+			env.WarehouseQualifier == receiverQualifier && env.WarehouseID == receiverID {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryPartnerRepo) FindByTenantID(_ context.Context, tenantID string) ([]*domain.TradingPartner, error) {
+	var partners []*domain.TradingPartner
+	for _, p := range m.partners {
+		if p.TenantID == tenantID {
+			partners = append(partners, p)
+		}
+	}
+	return partners, nil
+}
+
+func (m *memoryPartnerRepo) Delete(_ context.Context, partnerID string) error {
+	if _, ok := m.partners[partnerID]; !ok {
+		return domain.ErrTradingPartnerNotFound
+	}
+	delete(m.partners, partnerID)
+	return nil
+}
+
+func (m *memoryPartnerRepo) NextControlNumbers(context.Context, string) (domain.ControlNumbers, error) {
+	m.controls++
+	return domain.ControlNumbers{Interchange: m.controls, Group: m.controls}, nil
+}
+
+// memoryEDIDocumentRepo is an in-memory domain.EDIDocumentRepository
+type memoryEDIDocumentRepo struct {
+	docs []*domain.EDIDocument
+}
+
+func (m *memoryEDIDocumentRepo) Save(_ context.Context, doc *domain.EDIDocument) error {
+	for i, d := range m.docs {
+		if d.DocumentID == doc.DocumentID {
+			m.docs[i] = doc
+			return nil
+		}
+	}
+	m.docs = append(m.docs, doc)
+	return nil
+}
+
+func (m *memoryEDIDocumentRepo) Insert(_ context.Context, docs ...*domain.EDIDocument) error {
+	for _, doc := range docs {
+		if len(m.filter(func(d *domain.EDIDocument) bool {
+			return d.PartnerID == doc.PartnerID && d.Direction == doc.Direction &&
+				d.InterchangeControlNumber == doc.InterchangeControlNumber &&
+				d.GroupControlNumber == doc.GroupControlNumber &&
+				d.TransactionControlNumber == doc.TransactionControlNumber
+		})) > 0 {
+			return domain.ErrDuplicateInterchange
+		}
+	}
+	m.docs = append(m.docs, docs...)
+	return nil
+}
+
+func (m *memoryEDIDocumentRepo) FindByID(_ context.Context, documentID string) (*domain.EDIDocument, error) {
+	for _, d := range m.docs {
+		if d.DocumentID == documentID {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryEDIDocumentRepo) FindByPartnerID(_ context.Context, partnerID string, direction domain.EDIDirection, _ domain.Pagination) ([]*domain.EDIDocument, error) {
+	return m.filter(func(d *domain.EDIDocument) bool {
+		return d.PartnerID == partnerID && (direction == "" || d.Direction == direction)
+	}), nil
+}
+
+func (m *memoryEDIDocumentRepo) FindByInterchangeControlNumber(_ context.Context, partnerID string, direction domain.EDIDirection, cn string) ([]*domain.EDIDocument, error) {
+	return m.filter(func(d *domain.EDIDocument) bool {
+		return d.PartnerID == partnerID && d.Direction == direction && d.InterchangeControlNumber == cn
+	}), nil
+}
+
+func (m *memoryEDIDocumentRepo) FindByGroupControlNumber(_ context.Context, partnerID string, direction domain.EDIDirection, cn string) ([]*domain.EDIDocument, error) {
+	return m.filter(func(d *domain.EDIDocument) bool {
+		return d.PartnerID == partnerID && d.Direction == direction && d.GroupControlNumber == cn
+	}), nil
+}
+
+func (m *memoryEDIDocumentRepo) FindByWMSOrderID(_ context.Context, wmsOrderID string) ([]*domain.EDIDocument, error) {
+	return m.filter(func(d *domain.EDIDocument) bool { return d.WMSOrderID == wmsOrderID }), nil
+}
+
+func (m *memoryEDIDocumentRepo) filter(keep func(*domain.EDIDocument) bool) []*domain.EDIDocument {
+	var docs []*domain.EDIDocument
+	for _, d := range m.docs {
+		if keep(d) {
+			docs = append(docs, d)
+		}
+	}
+	return docs
+}
+
+type stubEDIOrderCreator struct {
+	orderID string
+	err     error
+}
+
+func (s *stubEDIOrderCreator) CreateEDIOrder(context.Context, *domain.TradingPartner, *domain.EDIDocument) (string, error) {
+	return s.orderID, s.err
+}
+
+type ediHandlerEnv struct {
+	router    *gin.Engine
+	service   *application.EDIService
+	partners  *memoryPartnerRepo
+	documents *memoryEDIDocumentRepo
+}
+
+func newEDIHandlerEnv(t *testing.T) *ediHandlerEnv {
+	t.Helper()
+	partners := &memoryPartnerRepo{partners: map[string]*domain.TradingPartner{}}
+	documents := &memoryEDIDocumentRepo{}
+	service := application.NewEDIService(partners, documents, x12.NewCodec())
+	service.SetOrderCreator(&stubEDIOrderCreator{orderID: "ORD-1"})
+	logger := logging.New(&logging.Config{
+		Level:       logging.LevelInfo,
+		ServiceName: "test",
+		Environment: "test",
+		Version:     "test",
+		Output:      io.Discard,
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	NewEDIHandler(service, logger).RegisterRoutes(router.Group(""))
+
+	return &ediHandlerEnv{router: router, service: service, partners: partners, documents: documents}
+}
+
+func (env *ediHandlerEnv) createPartner(t *testing.T) *application.TradingPartnerDTO {
+	t.Helper()
+	body := []byte(`{
+		"tenantId": "tenant-1", "sellerId": "seller-1", "facilityId": "FAC-1", "warehouseId": "WH-1",
+		"name": "Acme B2B", "outboundDocuments": ["945"],
+		"envelope": {"partnerQualifier": "ZZ", "partnerId": "ACMEB2B", "warehouseQualifier": "ZZ", "warehouseId": "WMSWAREHOUSE", "sendFunctionalAcks": true}
+	}`)
+	resp := performRequest(env.router, http.MethodPost, "/edi/partners", body, nil)
+	require.Equal(t, http.StatusCreated, resp.Code, resp.Body.String())
+
+	var partner application.TradingPartnerDTO
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &partner))
+	return &partner
+}
+
+const inbound940 = `ISA*00*          *00*          *ZZ*ACMEB2B        *ZZ*WMSWAREHOUSE   *261012*1530*U*00401*000000101*0*P*>~
+GS*OW*ACMEB2B*WMSWAREHOUSE*20261012*1530*55*X*004010~
+ST*940*0001~
+W05*N*DO-7781*PO-4500012~
+N1*ST*Jane Doe~
+N3*1 Main St~
+N4*Springfield*IL*62701*US~
+LX*1~
+W01*12*EA**VN*WIDGET-1~
+SE*8*0001~
+GE*1*55~
+IEA*1*000000101~
+`
+
+func TestReceiveInterchangeCreatesOrderAndAcknowledges(t *testing.T) {
+	env := newEDIHandlerEnv(t)
+	partner := env.createPartner(t)
+
+	resp := performRequest(env.router, http.MethodPost, "/edi/inbound", []byte(inbound940), map[string]string{"Content-Type": x12ContentType})
+	require.Equal(t, http.StatusAccepted, resp.Code, resp.Body.String())
+
+	var result application.InterchangeResultDTO
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+	require.Equal(t, partner.ID, result.PartnerID)
+	require.Len(t, result.Documents, 1)
+	require.Equal(t, "processed", result.Documents[0].Status)
+	require.Equal(t, "ORD-1", result.Documents[0].WMSOrderID)
+	require.Len(t, result.Acknowledgements, 1)
+
+	payload := performRequest(env.router, http.MethodGet, "/edi/documents/"+result.Acknowledgements[0].ID+"/payload", nil, nil)
+	require.Equal(t, http.StatusOK, payload.Code)
+	require.Equal(t, x12ContentType, payload.Header().Get("Content-Type"))
+	require.Contains(t, payload.Body.String(), "AK1*OW*55~")
+
+	// A resent interchange is not applied twice
+	resp = performRequest(env.router, http.MethodPost, "/edi/inbound", []byte(inbound940), nil)
+	require.Equal(t, http.StatusConflict, resp.Code)
+
+	resp = performRequest(env.router, http.MethodGet, "/edi/partners/"+partner.ID+"/documents?direction=inbound", nil, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Contains(t, resp.Body.String(), "PO-4500012")
+}
+
+func TestReceiveInterchangeErrors(t *testing.T) {
+	env := newEDIHandlerEnv(t)
+
+	resp := performRequest(env.router, http.MethodPost, "/edi/inbound", []byte("not x12"), nil)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = performRequest(env.router, http.MethodPost, "/edi/inbound", []byte(inbound940), nil)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+
+	partner := env.createPartner(t)
+	inactive := []byte(`{"active": false}`)
+	resp = performRequest(env.router, http.MethodPut, "/edi/partners/"+partner.ID, inactive, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	resp = performRequest(env.router, http.MethodPost, "/edi/inbound", []byte(inbound940), nil)
+	require.Equal(t, http.StatusForbidden, resp.Code)
+}
+
+func TestCreateTradingPartnerValidation(t *testing.T) {
+	env := newEDIHandlerEnv(t)
+
+	body := `{"tenantId": "tenant-1", "sellerId": "seller-1", "facilityId": "FAC-1", "warehouseId": "WH-1", "name": "Acme",
+		"envelope": {"partnerQualifier": "ZZ", "partnerId": "ACMEB2B", "warehouseQualifier": "ZZ", "warehouseId": "WMSWAREHOUSE", "usageIndicator": "X"}}`
+	resp := performRequest(env.router, http.MethodPost, "/edi/partners", []byte(body), nil)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	require.Contains(t, resp.Body.String(), "usage indicator")
+}
+
+func TestEDIDocumentStateConflicts(t *testing.T) {
+	env := newEDIHandlerEnv(t)
+	env.createPartner(t)
+
+	resp := performRequest(env.router, http.MethodPost, "/edi/inbound", []byte(inbound940), nil)
+	require.Equal(t, http.StatusAccepted, resp.Code)
+	var result application.InterchangeResultDTO
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+
+	order := result.Documents[0].ID
+	resp = performRequest(env.router, http.MethodPost, "/edi/documents/"+order+"/retry", nil, nil)
+	require.Equal(t, http.StatusConflict, resp.Code)
+	resp = performRequest(env.router, http.MethodPost, "/edi/documents/"+order+"/sent", nil, nil)
+	require.Equal(t, http.StatusConflict, resp.Code)
+	resp = performRequest(env.router, http.MethodGet, "/edi/documents/"+order+"/payload", nil, nil)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+
+	ack := result.Acknowledgements[0].ID
+	resp = performRequest(env.router, http.MethodPost, "/edi/documents/"+ack+"/sent", nil, nil)
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.True(t, strings.Contains(resp.Body.String(), `"status":"sent"`))
+
+	resp = performRequest(env.router, http.MethodGet, "/edi/documents/EDI-missing", nil, nil)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+}
@@ -2,19 +2,41 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
+	sharedDomain "github.com/wms-platform/shared/pkg/domain"
+
 	"github.com/wms-platform/services/channel-service/internal/domain"
 )
 
+// Shipment tracking milestones published by shipping-service that are pushed to channels
+const (
+	milestoneInTransit      = "in_transit"
+	milestoneOutForDelivery = "out_for_delivery"
+	milestoneDelivered      = "delivered"
+)
+
+// milestoneRanks orders the pushed milestones so a repeated or late milestone is not pushed again
+var milestoneRanks = map[string]int{
+	milestoneInTransit:      1,
+	milestoneOutForDelivery: 2,
+	milestoneDelivered:      3,
+}
+
 // ChannelService handles channel operations
 type ChannelService struct {
 	channelRepo      domain.ChannelRepository
 	orderRepo        domain.ChannelOrderRepository
 	syncJobRepo      domain.SyncJobRepository
 	adapterFactory   *domain.AdapterFactory
+	orderCreator     domain.OrderCreator      // Optional: creates WMS orders during scheduled imports
+	inventorySource  domain.InventorySource   // Optional: provides WMS inventory for scheduled pushes
+	refreshLeases    domain.RefreshLeaseStore // Optional: serializes token refreshes across service instances
+	refreshLocks     sync.Map                 // channel ID -> *sync.Mutex serializing token refreshes
 }
 
 // NewChannelService creates a new channel service
@@ -32,6 +54,22 @@ func NewChannelService(
 	}
 }
 
+// SetOrderCreator sets the order creator used by scheduled order imports
+func (s *ChannelService) SetOrderCreator(creator domain.OrderCreator) {
+	s.orderCreator = creator
+}
+
+// SetInventorySource sets the inventory source used by scheduled inventory pushes
+func (s *ChannelService) SetInventorySource(source domain.InventorySource) {
+	s.inventorySource = source
+}
+
+// SetRefreshLeases sets the lease store that keeps service instances from refreshing
+// the same channel's tokens concurrently
+func (s *ChannelService) SetRefreshLeases(leases domain.RefreshLeaseStore) {
+	s.refreshLeases = leases
+}
+
 // ConnectChannel connects a new sales channel
 func (s *ChannelService) ConnectChannel(ctx context.Context, cmd ConnectChannelCommand) (*ChannelDTO, error) {
 	channelType := domain.ChannelType(cmd.Type)
@@ -62,6 +100,7 @@ func (s *ChannelService) ConnectChannel(ctx context.Context, cmd ConnectChannelC
 	if err != nil {
 		return nil, err
 	}
+	channel.FacilityID = cmd.FacilityID
 
 	// Register webhooks if URL provided
 	if cmd.WebhookURL != "" {
@@ -113,6 +152,10 @@ func (s *ChannelService) UpdateChannel(ctx context.Context, channelID string, cm
 		channel.Name = cmd.Name
 	}
 
+	if cmd.FacilityID != "" {
+		channel.FacilityID = cmd.FacilityID
+	}
+
 	if cmd.SyncSettings != nil {
 		channel.SyncSettings = *cmd.SyncSettings
 	}
@@ -142,6 +185,38 @@ func (s *ChannelService) DisconnectChannel(ctx context.Context, channelID string
 	return s.channelRepo.Save(ctx, channel)
 }
 
+// PauseChannel pauses a channel, which also stops its scheduled syncs
+func (s *ChannelService) PauseChannel(ctx context.Context, channelID string) (*ChannelDTO, error) {
+	channel, err := s.channelRepo.FindByID(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel.Pause()
+
+	if err := s.channelRepo.Save(ctx, channel); err != nil {
+		return nil, err
+	}
+	return ToChannelDTO(channel), nil
+}
+
+// ResumeChannel resumes a paused channel and its scheduled syncs
+func (s *ChannelService) ResumeChannel(ctx context.Context, channelID string) (*ChannelDTO, error) {
+	channel, err := s.channelRepo.FindByID(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := channel.Resume(); err != nil {
+		return nil, err
+	}
+
+	if err := s.channelRepo.Save(ctx, channel); err != nil {
+		return nil, err
+	}
+	return ToChannelDTO(channel), nil
+}
+
 // SyncOrders fetches and syncs orders from a channel
 func (s *ChannelService) SyncOrders(ctx context.Context, cmd SyncOrdersCommand) (*SyncJobDTO, error) {
 	channel, err := s.channelRepo.FindByID(ctx, cmd.ChannelID)
@@ -180,7 +255,11 @@ func (s *ChannelService) SyncOrders(ctx context.Context, cmd SyncOrdersCommand)
 	}
 
 	// Fetch orders
-	orders, err := adapter.FetchOrders(ctx, channel, since)
+	var orders []*domain.ChannelOrder
+	err = s.withFreshCredentials(ctx, channel, adapter, func() error {
+		orders, err = adapter.FetchOrders(ctx, channel, since)
+		return err
+	})
 	if err != nil {
 		job.Fail(err.Error())
 		s.syncJobRepo.Save(ctx, job)
@@ -213,8 +292,9 @@ func (s *ChannelService) SyncOrders(ctx context.Context, cmd SyncOrdersCommand)
 	s.syncJobRepo.Save(ctx, job)
 
 	// Update channel sync status
-	channel.UpdateLastSync(domain.SyncTypeOrders)
-	s.channelRepo.Save(ctx, channel)
+	s.saveChannelChanges(ctx, channel, func(channel *domain.Channel) {
+		channel.UpdateLastSync(domain.SyncTypeOrders)
+	})
 
 	return ToSyncJobDTO(job), nil
 }
@@ -239,7 +319,9 @@ func (s *ChannelService) SyncInventory(ctx context.Context, cmd SyncInventoryCom
 	}
 
 	// Sync inventory
-	err = adapter.SyncInventory(ctx, channel, cmd.Items)
+	err = s.withFreshCredentials(ctx, channel, adapter, func() error {
+		return adapter.SyncInventory(ctx, channel, cmd.Items)
+	})
 	if err != nil {
 		job.Fail(err.Error())
 		s.syncJobRepo.Save(ctx, job)
@@ -251,8 +333,9 @@ func (s *ChannelService) SyncInventory(ctx context.Context, cmd SyncInventoryCom
 	s.syncJobRepo.Save(ctx, job)
 
 	// Update channel sync status
-	channel.UpdateLastSync(domain.SyncTypeInventory)
-	s.channelRepo.Save(ctx, channel)
+	s.saveChannelChanges(ctx, channel, func(channel *domain.Channel) {
+		channel.UpdateLastSync(domain.SyncTypeInventory)
+	})
 
 	return ToSyncJobDTO(job), nil
 }
@@ -276,7 +359,9 @@ func (s *ChannelService) PushTracking(ctx context.Context, cmd PushTrackingComma
 		NotifyCustomer: cmd.NotifyCustomer,
 	}
 
-	err = adapter.PushTracking(ctx, channel, cmd.ExternalOrderID, tracking)
+	err = s.withFreshCredentials(ctx, channel, adapter, func() error {
+		return adapter.PushTracking(ctx, channel, cmd.ExternalOrderID, tracking)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to push tracking: %w", err)
 	}
@@ -311,7 +396,9 @@ func (s *ChannelService) CreateFulfillment(ctx context.Context, cmd CreateFulfil
 		NotifyCustomer: cmd.NotifyCustomer,
 	}
 
-	err = adapter.CreateFulfillment(ctx, channel, fulfillment)
+	err = s.withFreshCredentials(ctx, channel, adapter, func() error {
+		return adapter.CreateFulfillment(ctx, channel, fulfillment)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to create fulfillment: %w", err)
 	}
@@ -324,6 +411,111 @@ func (s *ChannelService) CreateFulfillment(ctx context.Context, cmd CreateFulfil
 	return nil
 }
 
+// SyncShipmentTracking pushes a shipment's tracking back to the channel the order came from once the
+// carrier has the parcel. The first milestone creates a channel fulfillment for orders with line items
+// and only pushes tracking for the rest; later milestones are pushed as status updates of that shipment.
+// A milestone at or before the last one pushed is skipped, as are orders that did not come from a
+// channel or whose channel has AutoPushTracking disabled.
+func (s *ChannelService) SyncShipmentTracking(ctx context.Context, cmd ShipmentTrackingCommand) error {
+	rank, ok := milestoneRanks[cmd.Milestone]
+	if !ok {
+		return nil
+	}
+
+	order, err := s.orderRepo.FindByWMSOrderID(ctx, cmd.WMSOrderID)
+	if err != nil {
+		return fmt.Errorf("failed to find channel order: %w", err)
+	}
+	if order == nil || milestoneRanks[order.TrackingMilestone] >= rank {
+		return nil
+	}
+
+	channel, err := s.channelRepo.FindByID(ctx, order.ChannelID)
+	if err != nil {
+		if errors.Is(err, domain.ErrChannelNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !channel.SyncSettings.AutoPushTracking {
+		return nil
+	}
+
+	if order.TrackingPushed {
+		err = s.pushShipmentStatus(ctx, channel, order, cmd)
+	} else {
+		err = s.fulfillShipment(ctx, order, cmd)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.orderRepo.MarkTrackingMilestone(ctx, order.ExternalOrderID, cmd.Milestone); err != nil {
+		log.Printf("Warning: failed to record tracking milestone: %v", err)
+	}
+
+	return nil
+}
+
+// fulfillShipment pushes a shipment's tracking to the channel for the first time
+func (s *ChannelService) fulfillShipment(ctx context.Context, order *domain.ChannelOrder, cmd ShipmentTrackingCommand) error {
+	trackingURL := ""
+	if trackingNumber, err := sharedDomain.NewTrackingNumberForCarrier(cmd.TrackingNumber, cmd.Carrier); err == nil {
+		trackingURL = trackingNumber.GetTrackingURL()
+	}
+
+	if len(order.LineItems) == 0 {
+		return s.PushTracking(ctx, PushTrackingCommand{
+			ChannelID:       order.ChannelID,
+			ExternalOrderID: order.ExternalOrderID,
+			TrackingNumber:  cmd.TrackingNumber,
+			Carrier:         cmd.Carrier,
+			TrackingURL:     trackingURL,
+			NotifyCustomer:  true,
+		})
+	}
+
+	lineItems := make([]domain.FulfillmentLineItem, 0, len(order.LineItems))
+	for _, item := range order.LineItems {
+		lineItems = append(lineItems, domain.FulfillmentLineItem{
+			LineItemID: item.ExternalID,
+			Quantity:   item.Quantity,
+		})
+	}
+	return s.CreateFulfillment(ctx, CreateFulfillmentCommand{
+		ChannelID:       order.ChannelID,
+		ExternalOrderID: order.ExternalOrderID,
+		TrackingNumber:  cmd.TrackingNumber,
+		TrackingURL:     trackingURL,
+		Carrier:         cmd.Carrier,
+		LineItems:       lineItems,
+		NotifyCustomer:  true,
+	})
+}
+
+// pushShipmentStatus pushes a later milestone of a shipment whose tracking the channel already has
+func (s *ChannelService) pushShipmentStatus(ctx context.Context, channel *domain.Channel, order *domain.ChannelOrder, cmd ShipmentTrackingCommand) error {
+	adapter, err := s.adapterFactory.GetAdapterForChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	update := domain.ShipmentStatusUpdate{
+		TrackingNumber: cmd.TrackingNumber,
+		Carrier:        cmd.Carrier,
+		Status:         cmd.Milestone,
+	}
+
+	err = s.withFreshCredentials(ctx, channel, adapter, func() error {
+		return adapter.PushShipmentStatus(ctx, channel, order.ExternalOrderID, update)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to push shipment status: %w", err)
+	}
+
+	return nil
+}
+
 // ImportOrder marks an order as imported to WMS
 func (s *ChannelService) ImportOrder(ctx context.Context, cmd ImportOrderCommand) error {
 	return s.orderRepo.MarkImported(ctx, cmd.ExternalOrderID, cmd.WMSOrderID)
@@ -425,5 +617,10 @@ func (s *ChannelService) GetInventoryLevels(ctx context.Context, channelID strin
 		return nil, err
 	}
 
-	return adapter.GetInventoryLevels(ctx, channel, skus)
+	var levels []domain.InventoryLevel
+	err = s.withFreshCredentials(ctx, channel, adapter, func() error {
+		levels, err = adapter.GetInventoryLevels(ctx, channel, skus)
+		return err
+	})
+	return levels, err
 }
@@ -85,10 +85,12 @@ type fakeOrderRepo struct {
 	saveAllFn          func(context.Context, []*domain.ChannelOrder) error
 	findByExternalIDFn func(context.Context, string, string) (*domain.ChannelOrder, error)
 	findByChannelIDFn  func(context.Context, string, domain.Pagination) ([]*domain.ChannelOrder, error)
+	findByWMSOrderFn   func(context.Context, string) (*domain.ChannelOrder, error)
 	findUnimportedFn   func(context.Context, string) ([]*domain.ChannelOrder, error)
 	findWithoutTrackFn func(context.Context, string) ([]*domain.ChannelOrder, error)
 	markImportedFn     func(context.Context, string, string) error
 	markTrackingFn     func(context.Context, string) error
+	markMilestoneFn    func(context.Context, string, string) error
 	countFn            func(context.Context, string) (int64, error)
 }
 
@@ -120,6 +122,13 @@ func (f *fakeOrderRepo) FindByChannelID(ctx context.Context, channelID string, p
 	return f.findByChannelIDFn(ctx, channelID, pagination)
 }
 
+func (f *fakeOrderRepo) FindByWMSOrderID(ctx context.Context, wmsOrderID string) (*domain.ChannelOrder, error) {
+	if f.findByWMSOrderFn == nil {
+		return nil, errUnexpected
+	}
+	return f.findByWMSOrderFn(ctx, wmsOrderID)
+}
+
 func (f *fakeOrderRepo) FindUnimported(ctx context.Context, channelID string) ([]*domain.ChannelOrder, error) {
 	if f.findUnimportedFn == nil {
 		return nil, errUnexpected
@@ -148,6 +157,13 @@ func (f *fakeOrderRepo) MarkTrackingPushed(ctx context.Context, externalOrderID
 	return f.markTrackingFn(ctx, externalOrderID)
 }
 
+func (f *fakeOrderRepo) MarkTrackingMilestone(ctx context.Context, externalOrderID, milestone string) error {
+	if f.markMilestoneFn == nil {
+		return errUnexpected
+	}
+	return f.markMilestoneFn(ctx, externalOrderID, milestone)
+}
+
 func (f *fakeOrderRepo) Count(ctx context.Context, channelID string) (int64, error) {
 	if f.countFn == nil {
 		return 0, errUnexpected
@@ -207,8 +223,10 @@ type fakeAdapter struct {
 	syncInventoryFn      func(context.Context, *domain.Channel, []domain.InventoryUpdate) error
 	getInventoryLevelsFn func(context.Context, *domain.Channel, []string) ([]domain.InventoryLevel, error)
 	createFulfillmentFn  func(context.Context, *domain.Channel, domain.FulfillmentRequest) error
+	pushShipmentStatusFn func(context.Context, *domain.Channel, string, domain.ShipmentStatusUpdate) error
 	registerWebhooksFn   func(context.Context, *domain.Channel, string) error
 	validateWebhookFn    func(context.Context, *domain.Channel, string, []byte) bool
+	refreshFn            func(context.Context, domain.ChannelCredentials) (*domain.TokenRefresh, error)
 }
 
 func (f *fakeAdapter) GetType() domain.ChannelType {
@@ -264,6 +282,13 @@ func (f *fakeAdapter) CreateFulfillment(ctx context.Context, channel *domain.Cha
 	return f.createFulfillmentFn(ctx, channel, fulfillment)
 }
 
+func (f *fakeAdapter) PushShipmentStatus(ctx context.Context, channel *domain.Channel, externalOrderID string, update domain.ShipmentStatusUpdate) error {
+	if f.pushShipmentStatusFn == nil {
+		return errUnexpected
+	}
+	return f.pushShipmentStatusFn(ctx, channel, externalOrderID, update)
+}
+
 func (f *fakeAdapter) RegisterWebhooks(ctx context.Context, channel *domain.Channel, webhookURL string) error {
 	if f.registerWebhooksFn == nil {
 		return errUnexpected
@@ -278,6 +303,13 @@ func (f *fakeAdapter) ValidateWebhook(ctx context.Context, channel *domain.Chann
 	return f.validateWebhookFn(ctx, channel, signature, body)
 }
 
+func (f *fakeAdapter) RefreshCredentials(ctx context.Context, creds domain.ChannelCredentials) (*domain.TokenRefresh, error) {
+	if f.refreshFn == nil {
+		return nil, domain.ErrTokenRefreshNotSupported
+	}
+	return f.refreshFn(ctx, creds)
+}
+
 func newServiceWithAdapter(adapter domain.ChannelAdapter) (*ChannelService, *fakeChannelRepo, *fakeOrderRepo, *fakeSyncJobRepo) {
 	channelRepo := &fakeChannelRepo{}
 	orderRepo := &fakeOrderRepo{}
@@ -820,6 +852,180 @@ func TestCreateFulfillmentMarkErrorIgnored(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSyncShipmentTrackingCreatesFulfillment(t *testing.T) {
+	var got domain.FulfillmentRequest
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeShopify,
+		createFulfillmentFn: func(_ context.Context, _ *domain.Channel, fulfillment domain.FulfillmentRequest) error {
+			got = fulfillment
+			return nil
+		},
+	}
+	service, channelRepo, orderRepo, _ := newServiceWithAdapter(adapter)
+	channel := newTestChannel(t, domain.ChannelTypeShopify)
+	channel.SyncSettings.AutoPushTracking = true
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	orderRepo.findByWMSOrderFn = func(_ context.Context, wmsOrderID string) (*domain.ChannelOrder, error) {
+		require.Equal(t, "wms-1", wmsOrderID)
+		return &domain.ChannelOrder{
+			ChannelID:       channel.ChannelID,
+			ExternalOrderID: "ext-1",
+			WMSOrderID:      "wms-1",
+			LineItems:       []domain.ChannelLineItem{{ExternalID: "li-1", Quantity: 2}},
+		}, nil
+	}
+	var marked, milestone string
+	orderRepo.markTrackingFn = func(_ context.Context, externalOrderID string) error {
+		marked = externalOrderID
+		return nil
+	}
+	orderRepo.markMilestoneFn = func(_ context.Context, _ string, pushed string) error {
+		milestone = pushed
+		return nil
+	}
+
+	err := service.SyncShipmentTracking(context.Background(), ShipmentTrackingCommand{
+		WMSOrderID:     "wms-1",
+		TrackingNumber: "1Z999AA10123456784",
+		Carrier:        "UPS",
+		Milestone:      "in_transit",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ext-1", got.OrderID)
+	require.Equal(t, "https://www.ups.com/track?tracknum=1Z999AA10123456784", got.TrackingURL)
+	require.Equal(t, []domain.FulfillmentLineItem{{LineItemID: "li-1", Quantity: 2}}, got.LineItems)
+	require.True(t, got.NotifyCustomer)
+	require.Equal(t, "ext-1", marked)
+	require.Equal(t, "in_transit", milestone)
+}
+
+func TestSyncShipmentTrackingPushesTrackingWithoutLineItems(t *testing.T) {
+	pushed := false
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeEbay,
+		pushTrackingFn: func(_ context.Context, _ *domain.Channel, externalOrderID string, tracking domain.TrackingInfo) error {
+			pushed = true
+			require.Equal(t, "ext-1", externalOrderID)
+			require.Equal(t, "track-1", tracking.TrackingNumber)
+			return nil
+		},
+	}
+	service, channelRepo, orderRepo, _ := newServiceWithAdapter(adapter)
+	channel := newTestChannel(t, domain.ChannelTypeEbay)
+	channel.SyncSettings.AutoPushTracking = true
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	orderRepo.findByWMSOrderFn = func(context.Context, string) (*domain.ChannelOrder, error) {
+		return &domain.ChannelOrder{ChannelID: channel.ChannelID, ExternalOrderID: "ext-1"}, nil
+	}
+	orderRepo.markTrackingFn = func(context.Context, string) error { return nil }
+
+	err := service.SyncShipmentTracking(context.Background(), ShipmentTrackingCommand{
+		WMSOrderID:     "wms-1",
+		TrackingNumber: "track-1",
+		Carrier:        "carrier",
+		Milestone:      "delivered",
+	})
+	require.NoError(t, err)
+	require.True(t, pushed)
+}
+
+func TestSyncShipmentTrackingPushesLaterMilestone(t *testing.T) {
+	var got domain.ShipmentStatusUpdate
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeShopify,
+		pushShipmentStatusFn: func(_ context.Context, _ *domain.Channel, externalOrderID string, update domain.ShipmentStatusUpdate) error {
+			require.Equal(t, "ext-1", externalOrderID)
+			got = update
+			return nil
+		},
+	}
+	service, channelRepo, orderRepo, _ := newServiceWithAdapter(adapter)
+	channel := newTestChannel(t, domain.ChannelTypeShopify)
+	channel.SyncSettings.AutoPushTracking = true
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	orderRepo.findByWMSOrderFn = func(context.Context, string) (*domain.ChannelOrder, error) {
+		return &domain.ChannelOrder{
+			ChannelID:         channel.ChannelID,
+			ExternalOrderID:   "ext-1",
+			LineItems:         []domain.ChannelLineItem{{ExternalID: "li-1", Quantity: 2}},
+			TrackingPushed:    true,
+			TrackingMilestone: "in_transit",
+		}, nil
+	}
+	var milestone string
+	orderRepo.markMilestoneFn = func(_ context.Context, _ string, pushed string) error {
+		milestone = pushed
+		return nil
+	}
+
+	err := service.SyncShipmentTracking(context.Background(), ShipmentTrackingCommand{
+		WMSOrderID:     "wms-1",
+		TrackingNumber: "track-1",
+		Carrier:        "UPS",
+		Milestone:      "delivered",
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.ShipmentStatusUpdate{TrackingNumber: "track-1", Carrier: "UPS", Status: "delivered"}, got)
+	require.Equal(t, "delivered", milestone)
+}
+
+func TestSyncShipmentTrackingSkips(t *testing.T) {
+	tests := []struct {
+		name      string
+		milestone string
+		order     *domain.ChannelOrder
+		autoPush  bool
+	}{
+		{name: "label created", milestone: "label_created", order: &domain.ChannelOrder{ExternalOrderID: "ext-1"}, autoPush: true},
+		{name: "exception", milestone: "exception", order: &domain.ChannelOrder{ExternalOrderID: "ext-1"}, autoPush: true},
+		{name: "not a channel order", milestone: "in_transit", autoPush: true},
+		{name: "already pushed", milestone: "delivered", order: &domain.ChannelOrder{ExternalOrderID: "ext-1", TrackingPushed: true, TrackingMilestone: "delivered"}, autoPush: true},
+		{name: "later milestone pushed", milestone: "in_transit", order: &domain.ChannelOrder{ExternalOrderID: "ext-1", TrackingPushed: true, TrackingMilestone: "out_for_delivery"}, autoPush: true},
+		{name: "auto push disabled", milestone: "in_transit", order: &domain.ChannelOrder{ExternalOrderID: "ext-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, channelRepo, orderRepo, _ := newServiceWithAdapter(&fakeAdapter{channelType: domain.ChannelTypeShopify})
+			channel := newTestChannel(t, domain.ChannelTypeShopify)
+			channel.SyncSettings.AutoPushTracking = tt.autoPush
+			channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+				return channel, nil
+			}
+			orderRepo.findByWMSOrderFn = func(context.Context, string) (*domain.ChannelOrder, error) {
+				return tt.order, nil
+			}
+
+			err := service.SyncShipmentTracking(context.Background(), ShipmentTrackingCommand{
+				WMSOrderID:     "wms-1",
+				TrackingNumber: "track-1",
+				Carrier:        "carrier",
+				Milestone:      tt.milestone,
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSyncShipmentTrackingChannelDeleted(t *testing.T) {
+	service, channelRepo, orderRepo, _ := newServiceWithAdapter(nil)
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return nil, domain.ErrChannelNotFound
+	}
+	orderRepo.findByWMSOrderFn = func(context.Context, string) (*domain.ChannelOrder, error) {
+		return &domain.ChannelOrder{ChannelID: "ch-gone", ExternalOrderID: "ext-1"}, nil
+	}
+
+	err := service.SyncShipmentTracking(context.Background(), ShipmentTrackingCommand{WMSOrderID: "wms-1", Milestone: "delivered"})
+	require.NoError(t, err)
+}
+
 func TestImportOrder(t *testing.T) {
 	service, _, orderRepo, _ := newServiceWithAdapter(nil)
 	var gotExternal string
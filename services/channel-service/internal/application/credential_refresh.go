@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+const (
+	// tokenRefreshWindow is how long before expiry an access token is refreshed proactively
+	tokenRefreshWindow = 5 * time.Minute
+
+	// refreshLeaseTTL bounds how long a crashed instance can block other instances'
+	// refreshes of a channel; it comfortably exceeds the adapters' HTTP timeouts
+	refreshLeaseTTL = time.Minute
+
+	// refreshLeasePollInterval is how often a caller waiting for another instance's
+	// refresh checks the lease again
+	refreshLeasePollInterval = 250 * time.Millisecond
+)
+
+// withFreshCredentials runs call against the channel after refreshing an access token
+// that is about to expire. When the channel answers 401 anyway the token is refreshed
+// once more and call is retried.
+func (s *ChannelService) withFreshCredentials(ctx context.Context, channel *domain.Channel, adapter domain.ChannelAdapter, call func() error) error {
+	if err := s.refreshCredentials(ctx, channel, adapter, false); err != nil {
+		return err
+	}
+
+	err := call()
+	if !errors.Is(err, domain.ErrChannelUnauthorized) {
+		return err
+	}
+
+	if refreshErr := s.refreshCredentials(ctx, channel, adapter, true); refreshErr != nil {
+		if errors.Is(refreshErr, domain.ErrTokenRefreshNotSupported) {
+			return err
+		}
+		return refreshErr
+	}
+	return call()
+}
+
+// refreshCredentials refreshes the channel's access token when it expires within
+// tokenRefreshWindow, or unconditionally with force set. Refreshes are serialized per
+// channel, within the instance by a mutex and across instances by the refresh lease; a
+// caller that waited for another refresh picks up the stored result instead of
+// refreshing again. Rotated tokens are persisted before the lease is released.
+func (s *ChannelService) refreshCredentials(ctx context.Context, channel *domain.Channel, adapter domain.ChannelAdapter, force bool) error {
+	if channel.Credentials.RefreshToken == "" {
+		if force {
+			return domain.ErrTokenRefreshNotSupported
+		}
+		return nil
+	}
+	if !force && !channel.Credentials.NeedsTokenRefresh(time.Now(), tokenRefreshWindow) {
+		return nil
+	}
+
+	lock := s.refreshLock(channel.ChannelID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	release, err := s.acquireRefreshLease(ctx, channel.ChannelID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Another caller may have refreshed the token while we were waiting. The refresh is
+	// applied to the stored channel, which the caller's copy then adopts, so the save
+	// neither conflicts with nor overwrites changes saved since the caller loaded it.
+	staleToken := channel.Credentials.AccessToken
+	latest, err := s.channelRepo.FindByID(ctx, channel.ChannelID)
+	if err != nil {
+		return fmt.Errorf("failed to reload channel credentials: %w", err)
+	}
+	if latest.Credentials.AccessToken != staleToken && !latest.Credentials.NeedsTokenRefresh(time.Now(), tokenRefreshWindow) {
+		*channel = *latest
+		return nil
+	}
+
+	refresh, err := adapter.RefreshCredentials(ctx, latest.Credentials)
+	if errors.Is(err, domain.ErrTokenRefreshNotSupported) {
+		return err
+	}
+	if errors.Is(err, domain.ErrRefreshTokenRejected) {
+		log.Printf("Credentials of channel %s expired: %v", channel.ChannelID, err)
+		latest.MarkCredentialsExpired("refresh token rejected by channel, reconnect required")
+		if saveErr := s.channelRepo.Save(ctx, latest); saveErr != nil {
+			return fmt.Errorf("%v (and failed to save channel: %w)", err, saveErr)
+		}
+		*channel = *latest
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to refresh credentials: %w", err)
+	}
+
+	latest.ApplyTokenRefresh(*refresh)
+	if err := s.channelRepo.Save(ctx, latest); err != nil {
+		return fmt.Errorf("failed to save refreshed credentials: %w", err)
+	}
+	*channel = *latest
+	return nil
+}
+
+// acquireRefreshLease waits until this instance holds the channel's refresh lease and
+// returns the function releasing it. Without a lease store only the in-process mutex
+// serializes refreshes.
+func (s *ChannelService) acquireRefreshLease(ctx context.Context, channelID string) (func(), error) {
+	if s.refreshLeases == nil {
+		return func() {}, nil
+	}
+
+	holder := uuid.NewString()
+	for {
+		acquired, err := s.refreshLeases.AcquireRefreshLease(ctx, channelID, holder, refreshLeaseTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire credential refresh lease: %w", err)
+		}
+		if acquired {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(refreshLeasePollInterval):
+		}
+	}
+
+	return func() {
+		if err := s.refreshLeases.ReleaseRefreshLease(ctx, channelID, holder); err != nil {
+			log.Printf("Failed to release credential refresh lease of channel %s: %v", channelID, err)
+		}
+	}, nil
+}
+
+// refreshLock returns the mutex serializing token refreshes of a channel
+func (s *ChannelService) refreshLock(channelID string) *sync.Mutex {
+	lock, _ := s.refreshLocks.LoadOrStore(channelID, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
@@ -0,0 +1,258 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+func newOAuthChannel(t *testing.T, accessToken string, expiresIn time.Duration) *domain.Channel {
+	t.Helper()
+	expiresAt := time.Now().Add(expiresIn)
+	channel, err := domain.NewChannel("tenant-1", "seller-1", domain.ChannelTypeEbay, "eBay", "", domain.ChannelCredentials{
+		ClientID:       "client",
+		ClientSecret:   "secret",
+		RefreshToken:   "refresh",
+		AccessToken:    accessToken,
+		TokenExpiresAt: &expiresAt,
+	}, domain.SyncSettings{})
+	require.NoError(t, err)
+	channel.ClearDomainEvents()
+	return channel
+}
+
+// storeChannel makes the fake repository behave like a store holding a single channel
+func storeChannel(channelRepo *fakeChannelRepo, channel *domain.Channel) *[]domain.DomainEvent {
+	var mu sync.Mutex
+	stored := *channel
+	var published []domain.DomainEvent
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		copied := stored
+		return &copied, nil
+	}
+	channelRepo.saveFn = func(_ context.Context, saved *domain.Channel) error {
+		mu.Lock()
+		defer mu.Unlock()
+		stored = *saved
+		published = append(published, saved.DomainEvents()...)
+		saved.ClearDomainEvents()
+		return nil
+	}
+	return &published
+}
+
+func freshToken(token string) func(context.Context, domain.ChannelCredentials) (*domain.TokenRefresh, error) {
+	return func(context.Context, domain.ChannelCredentials) (*domain.TokenRefresh, error) {
+		return &domain.TokenRefresh{AccessToken: token, ExpiresAt: time.Now().Add(2 * time.Hour)}, nil
+	}
+}
+
+func TestPushTrackingRefreshesExpiringToken(t *testing.T) {
+	var usedToken string
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeEbay,
+		refreshFn:   freshToken("fresh"),
+		pushTrackingFn: func(_ context.Context, channel *domain.Channel, _ string, _ domain.TrackingInfo) error {
+			usedToken = channel.Credentials.AccessToken
+			return nil
+		},
+	}
+	service, channelRepo, orderRepo, _ := newServiceWithAdapter(adapter)
+	channel := newOAuthChannel(t, "expiring", time.Minute)
+	published := storeChannel(channelRepo, channel)
+	orderRepo.markTrackingFn = func(context.Context, string) error { return nil }
+
+	err := service.PushTracking(context.Background(), PushTrackingCommand{ChannelID: channel.ChannelID, ExternalOrderID: "order-1"})
+	require.NoError(t, err)
+	require.Equal(t, "fresh", usedToken)
+
+	stored, _ := channelRepo.FindByID(context.Background(), channel.ChannelID)
+	require.Equal(t, "fresh", stored.Credentials.AccessToken)
+	require.Len(t, *published, 1)
+	require.Equal(t, "channel.credentials.refreshed", (*published)[0].EventType())
+}
+
+func TestPushTrackingRetriesAfterUnauthorized(t *testing.T) {
+	var calls int
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeEbay,
+		refreshFn:   freshToken("fresh"),
+		pushTrackingFn: func(_ context.Context, channel *domain.Channel, _ string, _ domain.TrackingInfo) error {
+			calls++
+			if channel.Credentials.AccessToken != "fresh" {
+				return fmt.Errorf("push failed: %w", domain.ErrChannelUnauthorized)
+			}
+			return nil
+		},
+	}
+	service, channelRepo, orderRepo, _ := newServiceWithAdapter(adapter)
+	channel := newOAuthChannel(t, "revoked", time.Hour)
+	storeChannel(channelRepo, channel)
+	orderRepo.markTrackingFn = func(context.Context, string) error { return nil }
+
+	err := service.PushTracking(context.Background(), PushTrackingCommand{ChannelID: channel.ChannelID, ExternalOrderID: "order-1"})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+}
+
+func TestUnauthorizedWithoutRefreshToken(t *testing.T) {
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeWooCommerce,
+		pushTrackingFn: func(context.Context, *domain.Channel, string, domain.TrackingInfo) error {
+			return domain.ErrChannelUnauthorized
+		},
+	}
+	service, channelRepo, _, _ := newServiceWithAdapter(adapter)
+	channel := newTestChannel(t, domain.ChannelTypeWooCommerce)
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) { return channel, nil }
+
+	err := service.PushTracking(context.Background(), PushTrackingCommand{ChannelID: channel.ChannelID})
+	require.ErrorIs(t, err, domain.ErrChannelUnauthorized)
+}
+
+func TestRefreshTokenRejectedExpiresCredentials(t *testing.T) {
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeEbay,
+		refreshFn: func(context.Context, domain.ChannelCredentials) (*domain.TokenRefresh, error) {
+			return nil, fmt.Errorf("%w: invalid_grant", domain.ErrRefreshTokenRejected)
+		},
+	}
+	service, channelRepo, _, _ := newServiceWithAdapter(adapter)
+	channel := newOAuthChannel(t, "expired", -time.Minute)
+	published := storeChannel(channelRepo, channel)
+
+	err := service.PushTracking(context.Background(), PushTrackingCommand{ChannelID: channel.ChannelID})
+	require.ErrorIs(t, err, domain.ErrRefreshTokenRejected)
+
+	stored, _ := channelRepo.FindByID(context.Background(), channel.ChannelID)
+	require.Equal(t, domain.ChannelStatusError, stored.Status)
+	require.Len(t, *published, 1)
+	require.Equal(t, "channel.credentials.expired", (*published)[0].EventType())
+}
+
+func TestRefreshSerializedPerChannel(t *testing.T) {
+	var refreshes atomic.Int32
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeEbay,
+		refreshFn: func(ctx context.Context, creds domain.ChannelCredentials) (*domain.TokenRefresh, error) {
+			refreshes.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return freshToken("fresh")(ctx, creds)
+		},
+		getInventoryLevelsFn: func(_ context.Context, channel *domain.Channel, _ []string) ([]domain.InventoryLevel, error) {
+			if channel.Credentials.AccessToken != "fresh" {
+				return nil, domain.ErrChannelUnauthorized
+			}
+			return nil, nil
+		},
+	}
+	service, channelRepo, _, _ := newServiceWithAdapter(adapter)
+	channel := newOAuthChannel(t, "expiring", time.Minute)
+	storeChannel(channelRepo, channel)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.GetInventoryLevels(context.Background(), channel.ChannelID, nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), refreshes.Load())
+}
+
+// fakeRefreshLeases is an in-memory lease store shared by several service instances
+type fakeRefreshLeases struct {
+	mu      sync.Mutex
+	holders map[string]string
+}
+
+func (f *fakeRefreshLeases) AcquireRefreshLease(_ context.Context, channelID, holder string, _ time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if current, ok := f.holders[channelID]; ok && current != holder {
+		return false, nil
+	}
+	if f.holders == nil {
+		f.holders = make(map[string]string)
+	}
+	f.holders[channelID] = holder
+	return true, nil
+}
+
+func (f *fakeRefreshLeases) ReleaseRefreshLease(_ context.Context, channelID, holder string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.holders[channelID] == holder {
+		delete(f.holders, channelID)
+	}
+	return nil
+}
+
+func TestRefreshSerializedAcrossInstances(t *testing.T) {
+	var refreshes atomic.Int32
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeEbay,
+		refreshFn: func(ctx context.Context, creds domain.ChannelCredentials) (*domain.TokenRefresh, error) {
+			refreshes.Add(1)
+			time.Sleep(10 * time.Millisecond)
+			return freshToken("fresh")(ctx, creds)
+		},
+		getInventoryLevelsFn: func(_ context.Context, channel *domain.Channel, _ []string) ([]domain.InventoryLevel, error) {
+			if channel.Credentials.AccessToken != "fresh" {
+				return nil, domain.ErrChannelUnauthorized
+			}
+			return nil, nil
+		},
+	}
+	first, channelRepo, orderRepo, syncJobRepo := newServiceWithAdapter(adapter)
+	factory := domain.NewAdapterFactory()
+	factory.Register(adapter)
+	second := NewChannelService(channelRepo, orderRepo, syncJobRepo, factory)
+
+	leases := &fakeRefreshLeases{}
+	first.SetRefreshLeases(leases)
+	second.SetRefreshLeases(leases)
+
+	channel := newOAuthChannel(t, "expiring", time.Minute)
+	storeChannel(channelRepo, channel)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 6)
+	for i := 0; i < 6; i++ {
+		service := first
+		if i%2 == 1 {
+			service = second
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.GetInventoryLevels(context.Background(), channel.ChannelID, nil)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), refreshes.Load())
+	require.Empty(t, leases.holders)
+}
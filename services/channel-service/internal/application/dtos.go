@@ -11,6 +11,7 @@ import (
 type ConnectChannelCommand struct {
 	TenantID    string                    `json:"tenantId" binding:"required"`
 	SellerID    string                    `json:"sellerId" binding:"required"`
+	FacilityID  string                    `json:"facilityId"`
 	Type        string                    `json:"type" binding:"required"`
 	Name        string                    `json:"name" binding:"required"`
 	Credentials domain.ChannelCredentials `json:"credentials" binding:"required"`
@@ -20,6 +21,7 @@ type ConnectChannelCommand struct {
 // UpdateChannelCommand represents a command to update channel settings
 type UpdateChannelCommand struct {
 	Name         string                 `json:"name"`
+	FacilityID   string                 `json:"facilityId"`
 	SyncSettings *domain.SyncSettings   `json:"syncSettings"`
 	Metadata     map[string]interface{} `json:"metadata"`
 }
@@ -73,6 +75,14 @@ type ImportOrderCommand struct {
 	WMSOrderID      string `json:"wmsOrderId" binding:"required"`
 }
 
+// ShipmentTrackingCommand represents a carrier tracking milestone for a WMS order's shipment
+type ShipmentTrackingCommand struct {
+	WMSOrderID     string `json:"wmsOrderId"`
+	TrackingNumber string `json:"trackingNumber"`
+	Carrier        string `json:"carrier"`
+	Milestone      string `json:"milestone"`
+}
+
 // WebhookCommand represents a webhook payload
 type WebhookCommand struct {
 	ChannelID string `json:"channelId"`
@@ -107,6 +117,7 @@ type SyncConfigDTO struct {
 	Enabled    bool       `json:"enabled"`
 	Interval   string     `json:"interval"`
 	LastSyncAt *time.Time `json:"lastSyncAt,omitempty"`
+	NextSyncAt *time.Time `json:"nextSyncAt,omitempty"`
 	LastError  string     `json:"lastError,omitempty"`
 }
 
@@ -204,12 +215,14 @@ func ToChannelDTO(channel *domain.Channel) *ChannelDTO {
 			Enabled:    channel.SyncSettings.AutoImportOrders,
 			Interval:   fmt.Sprintf("%dm", channel.SyncSettings.OrderSyncIntervalMin),
 			LastSyncAt: channel.LastOrderSync,
+			NextSyncAt: channel.NextSyncAt(domain.SyncTypeOrders),
 			LastError:  channel.LastError,
 		},
 		InventorySync: &SyncConfigDTO{
 			Enabled:    channel.SyncSettings.AutoSyncInventory,
 			Interval:   fmt.Sprintf("%dm", channel.SyncSettings.InventorySyncIntervalMin),
 			LastSyncAt: channel.LastInventorySync,
+			NextSyncAt: channel.NextSyncAt(domain.SyncTypeInventory),
 		},
 	}
 
@@ -0,0 +1,149 @@
+package application
+
+import (
+	"time"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// CreateTradingPartnerCommand represents a command to register an EDI trading partner
+type CreateTradingPartnerCommand struct {
+	TenantID          string                  `json:"tenantId" binding:"required"`
+	SellerID          string                  `json:"sellerId" binding:"required"`
+	FacilityID        string                  `json:"facilityId" binding:"required"`
+	WarehouseID       string                  `json:"warehouseId" binding:"required"`
+	Name              string                  `json:"name" binding:"required"`
+	Envelope          domain.EnvelopeSettings `json:"envelope" binding:"required"`
+	OutboundDocuments []string                `json:"outboundDocuments"`
+}
+
+// UpdateTradingPartnerCommand represents a command to update a trading partner. Omitted
+// fields keep their current value.
+type UpdateTradingPartnerCommand struct {
+	Name              string                   `json:"name"`
+	Envelope          *domain.EnvelopeSettings `json:"envelope"`
+	OutboundDocuments []string                 `json:"outboundDocuments"`
+	Active            *bool                    `json:"active"`
+}
+
+// ShipmentConfirmedCommand represents a shipping-service ship confirmation for a WMS order
+type ShipmentConfirmedCommand struct {
+	WMSOrderID     string    `json:"wmsOrderId"`
+	ShipmentID     string    `json:"shipmentId"`
+	TrackingNumber string    `json:"trackingNumber"`
+	Carrier        string    `json:"carrier"`
+	ShippedAt      time.Time `json:"shippedAt"`
+	WeightKg       float64   `json:"weightKg"`
+}
+
+// TradingPartnerDTO represents a trading partner response
+type TradingPartnerDTO struct {
+	ID                string                  `json:"id"`
+	TenantID          string                  `json:"tenantId"`
+	SellerID          string                  `json:"sellerId"`
+	FacilityID        string                  `json:"facilityId"`
+	WarehouseID       string                  `json:"warehouseId"`
+	Name              string                  `json:"name"`
+	Envelope          domain.EnvelopeSettings `json:"envelope"`
+	OutboundDocuments []string                `json:"outboundDocuments"`
+	Active            bool                    `json:"active"`
+	CreatedAt         time.Time               `json:"createdAt"`
+	UpdatedAt         time.Time               `json:"updatedAt"`
+}
+
+// EDIDocumentDTO represents an EDI document log entry response
+type EDIDocumentDTO struct {
+	ID                       string                          `json:"id"`
+	PartnerID                string                          `json:"partnerId"`
+	Direction                string                          `json:"direction"`
+	Type                     string                          `json:"type"`
+	Status                   string                          `json:"status"`
+	InterchangeControlNumber string                          `json:"interchangeControlNumber"`
+	GroupControlNumber       string                          `json:"groupControlNumber"`
+	TransactionControlNumber string                          `json:"transactionControlNumber"`
+	Reference                string                          `json:"reference,omitempty"`
+	WMSOrderID               string                          `json:"wmsOrderId,omitempty"`
+	InboundShipmentID        string                          `json:"inboundShipmentId,omitempty"`
+	Order                    *domain.EDIOrder                `json:"order,omitempty"`
+	ShipNotice               *domain.EDIShipNotice           `json:"shipNotice,omitempty"`
+	Acknowledgement          *domain.EDIAcknowledgement      `json:"acknowledgement,omitempty"`
+	Confirmation             *domain.EDIShipmentConfirmation `json:"confirmation,omitempty"`
+	AckStatus                string                          `json:"ackStatus,omitempty"`
+	AcknowledgedAt           *time.Time                      `json:"acknowledgedAt,omitempty"`
+	Errors                   []string                        `json:"errors,omitempty"`
+	SentAt                   *time.Time                      `json:"sentAt,omitempty"`
+	CreatedAt                time.Time                       `json:"createdAt"`
+	UpdatedAt                time.Time                       `json:"updatedAt"`
+}
+
+// InterchangeResultDTO represents the outcome of receiving an interchange
+type InterchangeResultDTO struct {
+	PartnerID                string            `json:"partnerId"`
+	InterchangeControlNumber string            `json:"interchangeControlNumber"`
+	Documents                []*EDIDocumentDTO `json:"documents"`
+	Acknowledgements         []*EDIDocumentDTO `json:"acknowledgements,omitempty"`
+}
+
+// ToTradingPartnerDTO converts a domain TradingPartner to DTO
+func ToTradingPartnerDTO(partner *domain.TradingPartner) *TradingPartnerDTO {
+	docs := make([]string, len(partner.OutboundDocuments))
+	for i, docType := range partner.OutboundDocuments {
+		docs[i] = string(docType)
+	}
+	return &TradingPartnerDTO{
+		ID:                partner.PartnerID,
+		TenantID:          partner.TenantID,
+		SellerID:          partner.SellerID,
+		FacilityID:        partner.FacilityID,
+		WarehouseID:       partner.WarehouseID,
+		Name:              partner.Name,
+		Envelope:          partner.Envelope,
+		OutboundDocuments: docs,
+		Active:            partner.Active,
+		CreatedAt:         partner.CreatedAt,
+		UpdatedAt:         partner.UpdatedAt,
+	}
+}
+
+// ToEDIDocumentDTO converts a domain EDIDocument to DTO
+func ToEDIDocumentDTO(doc *domain.EDIDocument) *EDIDocumentDTO {
+	return &EDIDocumentDTO{
+		ID:                       doc.DocumentID,
+		PartnerID:                doc.PartnerID,
+		Direction:                string(doc.Direction),
+		Type:                     string(doc.Type),
+		Status:                   string(doc.Status),
+		InterchangeControlNumber: doc.InterchangeControlNumber,
+		GroupControlNumber:       doc.GroupControlNumber,
+		TransactionControlNumber: doc.TransactionControlNumber,
+		Reference:                doc.Reference,
+		WMSOrderID:               doc.WMSOrderID,
+		InboundShipmentID:        doc.InboundShipmentID,
+		Order:                    doc.Order,
+		ShipNotice:               doc.ShipNotice,
+		Acknowledgement:          doc.Acknowledgement,
+		Confirmation:             doc.Confirmation,
+		AckStatus:                string(doc.AckStatus),
+		AcknowledgedAt:           doc.AcknowledgedAt,
+		Errors:                   doc.Errors,
+		SentAt:                   doc.SentAt,
+		CreatedAt:                doc.CreatedAt,
+		UpdatedAt:                doc.UpdatedAt,
+	}
+}
+
+func toEDIDocumentDTOs(docs []*domain.EDIDocument) []*EDIDocumentDTO {
+	dtos := make([]*EDIDocumentDTO, len(docs))
+	for i, doc := range docs {
+		dtos[i] = ToEDIDocumentDTO(doc)
+	}
+	return dtos
+}
+
+func toEDIDocumentTypes(types []string) []domain.EDIDocumentType {
+	docTypes := make([]domain.EDIDocumentType, len(types))
+	for i, t := range types {
+		docTypes[i] = domain.EDIDocumentType(t)
+	}
+	return docTypes
+}
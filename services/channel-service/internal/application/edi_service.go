@@ -0,0 +1,443 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// EDIService handles EDI trading partners and the X12 documents exchanged with them
+type EDIService struct {
+	partnerRepo     domain.TradingPartnerRepository
+	documentRepo    domain.EDIDocumentRepository
+	codec           domain.EDICodec
+	orderCreator    domain.EDIOrderCreator        // Optional: creates WMS orders for inbound 850 and 940
+	shipmentCreator domain.InboundShipmentCreator // Optional: creates inbound shipments for inbound 856
+}
+
+// NewEDIService creates a new EDI service
+func NewEDIService(
+	partnerRepo domain.TradingPartnerRepository,
+	documentRepo domain.EDIDocumentRepository,
+	codec domain.EDICodec,
+) *EDIService {
+	return &EDIService{
+		partnerRepo:  partnerRepo,
+		documentRepo: documentRepo,
+		codec:        codec,
+	}
+}
+
+// SetOrderCreator sets the order creator used for inbound purchase and warehouse shipping orders
+func (s *EDIService) SetOrderCreator(creator domain.EDIOrderCreator) {
+	s.orderCreator = creator
+}
+
+// SetInboundShipmentCreator sets the shipment creator used for inbound ship notices
+func (s *EDIService) SetInboundShipmentCreator(creator domain.InboundShipmentCreator) {
+	s.shipmentCreator = creator
+}
+
+// CreateTradingPartner registers a new trading partner
+func (s *EDIService) CreateTradingPartner(ctx context.Context, cmd CreateTradingPartnerCommand) (*TradingPartnerDTO, error) {
+	partner, err := domain.NewTradingPartner(
+		cmd.TenantID, cmd.SellerID, cmd.FacilityID, cmd.WarehouseID, cmd.Name,
+		cmd.Envelope, toEDIDocumentTypes(cmd.OutboundDocuments),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireUniqueEnvelope(ctx, partner); err != nil {
+		return nil, err
+	}
+
+	if err := s.partnerRepo.Save(ctx, partner); err != nil {
+		return nil, err
+	}
+	return ToTradingPartnerDTO(partner), nil
+}
+
+// GetTradingPartner retrieves a trading partner by ID
+func (s *EDIService) GetTradingPartner(ctx context.Context, partnerID string) (*TradingPartnerDTO, error) {
+	partner, err := s.findPartner(ctx, partnerID)
+	if err != nil {
+		return nil, err
+	}
+	return ToTradingPartnerDTO(partner), nil
+}
+
+// GetTradingPartnersByTenant retrieves all trading partners for a tenant
+func (s *EDIService) GetTradingPartnersByTenant(ctx context.Context, tenantID string) ([]*TradingPartnerDTO, error) {
+	partners, err := s.partnerRepo.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*TradingPartnerDTO, len(partners))
+	for i, partner := range partners {
+		dtos[i] = ToTradingPartnerDTO(partner)
+	}
+	return dtos, nil
+}
+
+// UpdateTradingPartner updates a trading partner's name, envelope settings, shipment documents or active flag
+func (s *EDIService) UpdateTradingPartner(ctx context.Context, partnerID string, cmd UpdateTradingPartnerCommand) (*TradingPartnerDTO, error) {
+	partner, err := s.findPartner(ctx, partnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, envelope, docs, active := partner.Name, partner.Envelope, partner.OutboundDocuments, partner.Active
+	if cmd.Name != "" {
+		name = cmd.Name
+	}
+	if cmd.Envelope != nil {
+		envelope = *cmd.Envelope
+	}
+	if cmd.OutboundDocuments != nil {
+		docs = toEDIDocumentTypes(cmd.OutboundDocuments)
+	}
+	if cmd.Active != nil {
+		active = *cmd.Active
+	}
+
+	if err := partner.Update(name, envelope, docs, active); err != nil {
+		return nil, err
+	}
+	if err := s.requireUniqueEnvelope(ctx, partner); err != nil {
+		return nil, err
+	}
+
+	if err := s.partnerRepo.Save(ctx, partner); err != nil {
+		return nil, err
+	}
+	return ToTradingPartnerDTO(partner), nil
+}
+
+// DeleteTradingPartner deletes a trading partner. Its document log is kept.
+func (s *EDIService) DeleteTradingPartner(ctx context.Context, partnerID string) error {
+	return s.partnerRepo.Delete(ctx, partnerID)
+}
+
+// ReceiveInterchange translates an interchange received from a trading partner. Orders become
+// WMS orders, ship notices become inbound shipments and acknowledgements are matched to the
+// documents they acknowledge. Each transaction set is logged; when the partner expects them a
+// 997 is generated for every functional group.
+func (s *EDIService) ReceiveInterchange(ctx context.Context, data []byte) (*InterchangeResultDTO, error) {
+	interchange, err := s.codec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	partner, err := s.partnerRepo.FindByInterchangeIDs(ctx,
+		interchange.SenderQualifier, interchange.SenderID,
+		interchange.ReceiverQualifier, interchange.ReceiverID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if partner == nil {
+		return nil, fmt.Errorf("%w: no partner for sender %s:%s and receiver %s:%s", domain.ErrTradingPartnerNotFound,
+			interchange.SenderQualifier, interchange.SenderID, interchange.ReceiverQualifier, interchange.ReceiverID)
+	}
+	if !partner.Active {
+		return nil, domain.ErrTradingPartnerInactive
+	}
+
+	// Partners resend interchanges they have no 997 for. Every transaction set is logged as
+	// received before any is applied; the log is unique per control number, so a resent or
+	// concurrently received copy fails here instead of creating orders a second time.
+	docs := make([][]*domain.EDIDocument, len(interchange.Groups))
+	all := make([]*domain.EDIDocument, 0)
+	for i, group := range interchange.Groups {
+		for _, txn := range group.Transactions {
+			doc := domain.NewInboundEDIDocument(partner, interchange, group, txn)
+			docs[i] = append(docs[i], doc)
+			all = append(all, doc)
+		}
+	}
+	if err := s.documentRepo.Insert(ctx, all...); err != nil {
+		if errors.Is(err, domain.ErrDuplicateInterchange) {
+			return nil, fmt.Errorf("%w: interchange %s from %s", domain.ErrDuplicateInterchange, interchange.ControlNumber, partner.PartnerID)
+		}
+		return nil, fmt.Errorf("failed to save EDI documents: %w", err)
+	}
+
+	result := &InterchangeResultDTO{
+		PartnerID:                partner.PartnerID,
+		InterchangeControlNumber: interchange.ControlNumber,
+	}
+	for i, group := range interchange.Groups {
+		for _, doc := range docs[i] {
+			if doc.Status == domain.EDIStatusReceived {
+				s.apply(ctx, partner, doc)
+				if err := s.documentRepo.Save(ctx, doc); err != nil {
+					return nil, fmt.Errorf("failed to save EDI document: %w", err)
+				}
+			}
+			result.Documents = append(result.Documents, ToEDIDocumentDTO(doc))
+		}
+
+		// Acknowledgements are never acknowledged
+		if !partner.Envelope.SendFunctionalAcks || group.FunctionalID == domain.X12FunctionalAck.FunctionalID() {
+			continue
+		}
+		ack, err := s.generate(ctx, partner, domain.EDITransaction{
+			Type:            domain.X12FunctionalAck,
+			Acknowledgement: domain.NewGroupAcknowledgement(group),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate 997 for group %s: %w", group.ControlNumber, err)
+		}
+		result.Acknowledgements = append(result.Acknowledgements, ToEDIDocumentDTO(ack))
+	}
+
+	return result, nil
+}
+
+// HandleShipmentConfirmed generates the 945 and/or 856 a trading partner expects when one of its
+// orders ships. Orders that did not arrive over EDI are skipped, as are documents already generated
+// for the order, so redelivered ship confirmations are safe.
+func (s *EDIService) HandleShipmentConfirmed(ctx context.Context, cmd ShipmentConfirmedCommand) error {
+	docs, err := s.documentRepo.FindByWMSOrderID(ctx, cmd.WMSOrderID)
+	if err != nil {
+		return fmt.Errorf("failed to find EDI documents: %w", err)
+	}
+
+	var orderDoc *domain.EDIDocument
+	generated := make(map[domain.EDIDocumentType]bool)
+	for _, doc := range docs {
+		switch {
+		case doc.Direction == domain.EDIDirectionInbound && doc.Order != nil:
+			orderDoc = doc
+		case doc.Direction == domain.EDIDirectionOutbound:
+			generated[doc.Type] = true
+		}
+	}
+	if orderDoc == nil {
+		return nil
+	}
+
+	partner, err := s.partnerRepo.FindByID(ctx, orderDoc.PartnerID)
+	if err != nil {
+		return err
+	}
+	if partner == nil || !partner.Active {
+		log.Printf("Skipping EDI confirmation of order %s: trading partner %s is not active", cmd.WMSOrderID, orderDoc.PartnerID)
+		return nil
+	}
+
+	confirmation := domain.NewShipmentConfirmation(orderDoc.Order, cmd.WMSOrderID, cmd.ShipmentID,
+		cmd.TrackingNumber, cmd.Carrier, cmd.ShippedAt, cmd.WeightKg)
+	for _, docType := range partner.OutboundDocuments {
+		if generated[docType] {
+			continue
+		}
+		if _, err := s.generate(ctx, partner, domain.EDITransaction{Type: docType, Confirmation: confirmation}); err != nil {
+			return fmt.Errorf("failed to generate %s for order %s: %w", docType, cmd.WMSOrderID, err)
+		}
+	}
+	return nil
+}
+
+// GetDocuments retrieves a trading partner's document log, optionally for one direction
+func (s *EDIService) GetDocuments(ctx context.Context, partnerID, direction string, page, pageSize int64) ([]*EDIDocumentDTO, error) {
+	pagination := domain.Pagination{Page: page, PageSize: pageSize}
+	if page <= 0 {
+		pagination.Page = 1
+	}
+	if pageSize <= 0 {
+		pagination.PageSize = 20
+	}
+
+	docs, err := s.documentRepo.FindByPartnerID(ctx, partnerID, domain.EDIDirection(direction), pagination)
+	if err != nil {
+		return nil, err
+	}
+	return toEDIDocumentDTOs(docs), nil
+}
+
+// GetDocument retrieves an EDI document by ID
+func (s *EDIService) GetDocument(ctx context.Context, documentID string) (*EDIDocumentDTO, error) {
+	doc, err := s.findDocument(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	return ToEDIDocumentDTO(doc), nil
+}
+
+// GetDocumentPayload retrieves the X12 interchange of an outbound document
+func (s *EDIService) GetDocumentPayload(ctx context.Context, documentID string) (string, error) {
+	doc, err := s.findDocument(ctx, documentID)
+	if err != nil {
+		return "", err
+	}
+	if doc.Payload == "" {
+		return "", fmt.Errorf("%w: %s has no X12 payload", domain.ErrEDIDocumentNotFound, documentID)
+	}
+	return doc.Payload, nil
+}
+
+// RetryDocument applies a failed inbound document again, e.g. once order-service is reachable
+func (s *EDIService) RetryDocument(ctx context.Context, documentID string) (*EDIDocumentDTO, error) {
+	doc, err := s.findDocument(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	if !doc.CanRetry() {
+		return nil, domain.ErrEDIDocumentNotRetryable
+	}
+
+	partner, err := s.findPartner(ctx, doc.PartnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.apply(ctx, partner, doc)
+	if err := s.documentRepo.Save(ctx, doc); err != nil {
+		return nil, err
+	}
+	return ToEDIDocumentDTO(doc), nil
+}
+
+// MarkDocumentSent records that an outbound document was transmitted to the partner
+func (s *EDIService) MarkDocumentSent(ctx context.Context, documentID string) (*EDIDocumentDTO, error) {
+	doc, err := s.findDocument(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.MarkSent(); err != nil {
+		return nil, err
+	}
+
+	if err := s.documentRepo.Save(ctx, doc); err != nil {
+		return nil, err
+	}
+	return ToEDIDocumentDTO(doc), nil
+}
+
+// apply hands an inbound document to the WMS service it is for. Failures are recorded on the
+// document so it can be retried; the partner's 997 only reports whether it could be translated.
+func (s *EDIService) apply(ctx context.Context, partner *domain.TradingPartner, doc *domain.EDIDocument) {
+	switch doc.Type {
+	case domain.X12PurchaseOrder, domain.X12WarehouseShippingOrder:
+		if s.orderCreator == nil {
+			doc.MarkFailed("order creation is not configured")
+			return
+		}
+		orderID, err := s.orderCreator.CreateEDIOrder(ctx, partner, doc)
+		if err != nil {
+			doc.MarkFailed(err.Error())
+			return
+		}
+		doc.MarkOrderCreated(orderID)
+
+	case domain.X12ShipNotice:
+		if s.shipmentCreator == nil {
+			doc.MarkFailed("inbound shipment creation is not configured")
+			return
+		}
+		shipmentID, err := s.shipmentCreator.CreateInboundShipment(ctx, partner, doc.ShipNotice)
+		if err != nil {
+			doc.MarkFailed(err.Error())
+			return
+		}
+		doc.MarkShipmentCreated(shipmentID)
+
+	case domain.X12FunctionalAck:
+		if err := s.applyAcknowledgement(ctx, partner, doc.Acknowledgement); err != nil {
+			doc.MarkFailed(err.Error())
+			return
+		}
+		doc.MarkAcknowledgementApplied()
+
+	default:
+		doc.MarkFailed(fmt.Sprintf("%s documents cannot be received", doc.Type))
+	}
+}
+
+// applyAcknowledgement records a partner's 997 on the outbound documents of the group it acknowledges.
+// AK2 loops are optional when a whole group is accepted, so documents without one take the group status.
+func (s *EDIService) applyAcknowledgement(ctx context.Context, partner *domain.TradingPartner, ack *domain.EDIAcknowledgement) error {
+	docs, err := s.documentRepo.FindByGroupControlNumber(ctx, partner.PartnerID, domain.EDIDirectionOutbound, ack.GroupControlNumber)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return fmt.Errorf("no outbound functional group %s", ack.GroupControlNumber)
+	}
+
+	for _, doc := range docs {
+		txnAck := domain.EDITransactionAck{Type: doc.Type, ControlNumber: doc.TransactionControlNumber, Status: ack.Status}
+		for _, t := range ack.Transactions {
+			if t.ControlNumber == doc.TransactionControlNumber {
+				txnAck = t
+				break
+			}
+		}
+		doc.ApplyAcknowledgement(txnAck)
+		if err := s.documentRepo.Save(ctx, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generate writes an outbound transaction set in its own interchange and logs it
+func (s *EDIService) generate(ctx context.Context, partner *domain.TradingPartner, txn domain.EDITransaction) (*domain.EDIDocument, error) {
+	controls, err := s.partnerRepo.NextControlNumbers(ctx, partner.PartnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve control numbers: %w", err)
+	}
+
+	payload, err := s.codec.Encode(partner, controls, txn, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	doc := domain.NewOutboundEDIDocument(partner, controls, txn, payload)
+	if err := s.documentRepo.Save(ctx, doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// requireUniqueEnvelope checks no other partner uses the same interchange sender and receiver IDs,
+// which identify the partner an inbound interchange belongs to
+func (s *EDIService) requireUniqueEnvelope(ctx context.Context, partner *domain.TradingPartner) error {
+	env := partner.Envelope
+	existing, err := s.partnerRepo.FindByInterchangeIDs(ctx, env.PartnerQualifier, env.PartnerID, env.WarehouseQualifier, env.WarehouseID)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.PartnerID != partner.PartnerID {
+		return fmt.Errorf("%w: interchange IDs are already used by trading partner %s", domain.ErrInvalidTradingPartner, existing.PartnerID)
+	}
+	return nil
+}
+
+func (s *EDIService) findPartner(ctx context.Context, partnerID string) (*domain.TradingPartner, error) {
+	partner, err := s.partnerRepo.FindByID(ctx, partnerID)
+	if err != nil {
+		return nil, err
+	}
+	if partner == nil {
+		return nil, domain.ErrTradingPartnerNotFound
+	}
+	return partner, nil
+}
+
+func (s *EDIService) findDocument(ctx context.Context, documentID string) (*domain.EDIDocument, error) {
+	doc, err := s.documentRepo.FindByID(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, domain.ErrEDIDocumentNotFound
+	}
+	return doc, nil
+}
@@ -0,0 +1,460 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+type fakePartnerRepo struct {
+	saveFn              func(context.Context, *domain.TradingPartner) error
+	findByIDFn          func(context.Context, string) (*domain.TradingPartner, error)
+	findByInterchangeFn func(context.Context, string, string, string, string) (*domain.TradingPartner, error)
+	findByTenantFn      func(context.Context, string) ([]*domain.TradingPartner, error)
+	deleteFn            func(context.Context, string) error
+	nextControlsFn      func(context.Context, string) (domain.ControlNumbers, error)
+}
+
+func (f *fakePartnerRepo) Save(ctx context.Context, partner *domain.TradingPartner) error {
+	if f.saveFn == nil {
+		return errUnexpected
+	}
+	return f.saveFn(ctx, partner)
+}
+
+func (f *fakePartnerRepo) FindByID(ctx context.Context, partnerID string) (*domain.TradingPartner, error) {
+	if f.findByIDFn == nil {
+		return nil, errUnexpected
+	}
+	return f.findByIDFn(ctx, partnerID)
+}
+
+func (f *fakePartnerRepo) FindByInterchangeIDs(ctx context.Context, senderQualifier, senderID, receiverQualifier, receiverID string) (*domain.TradingPartner, error) {
+	if f.findByInterchangeFn == nil {
+		return nil, errUnexpected
+	}
+	return f.findByInterchangeFn(ctx, senderQualifier, senderID, receiverQualifier, receiverID)
+}
+
+func (f *fakePartnerRepo) FindByTenantID(ctx context.Context, tenantID string) ([]*domain.TradingPartner, error) {
+	if f.findByTenantFn == nil {
+		return nil, errUnexpected
+	}
+	return f.findByTenantFn(ctx, tenantID)
+}
+
+func (f *fakePartnerRepo) Delete(ctx context.Context, partnerID string) error {
+	if f.deleteFn == nil {
+		return errUnexpected
+	}
+	return f.deleteFn(ctx, partnerID)
+}
+
+func (f *fakePartnerRepo) NextControlNumbers(ctx context.Context, partnerID string) (domain.ControlNumbers, error) {
+	if f.nextControlsFn == nil {
+		return domain.ControlNumbers{}, errUnexpected
+	}
+	return f.nextControlsFn(ctx, partnerID)
+}
+
+type fakeEDIDocumentRepo struct {
+	saveFn              func(context.Context, *domain.EDIDocument) error
+	insertFn            func(context.Context, ...*domain.EDIDocument) error
+	findByIDFn          func(context.Context, string) (*domain.EDIDocument, error)
+	findByPartnerFn     func(context.Context, string, domain.EDIDirection, domain.Pagination) ([]*domain.EDIDocument, error)
+	findByInterchangeFn func(context.Context, string, domain.EDIDirection, string) ([]*domain.EDIDocument, error)
+	findByGroupFn       func(context.Context, string, domain.EDIDirection, string) ([]*domain.EDIDocument, error)
+	findByWMSOrderFn    func(context.Context, string) ([]*domain.EDIDocument, error)
+}
+
+func (f *fakeEDIDocumentRepo) Save(ctx context.Context, doc *domain.EDIDocument) error {
+	if f.saveFn == nil {
+		return errUnexpected
+	}
+	return f.saveFn(ctx, doc)
+}
+
+func (f *fakeEDIDocumentRepo) Insert(ctx context.Context, docs ...*domain.EDIDocument) error {
+	if f.insertFn == nil {
+		return errUnexpected
+	}
+	return f.insertFn(ctx, docs...)
+}
+
+func (f *fakeEDIDocumentRepo) FindByID(ctx context.Context, documentID string) (*domain.EDIDocument, error) {
+	if f.findByIDFn == nil {
+		return nil, errUnexpected
+	}
+	return f.findByIDFn(ctx, documentID)
+}
+
+func (f *fakeEDIDocumentRepo) FindByPartnerID(ctx context.Context, partnerID string, direction domain.EDIDirection, pagination domain.Pagination) ([]*domain.EDIDocument, error) {
+	if f.findByPartnerFn == nil {
+		return nil, errUnexpected
+	}
+	return f.findByPartnerFn(ctx, partnerID, direction, pagination)
+}
+
+func (f *fakeEDIDocumentRepo) FindByInterchangeControlNumber(ctx context.Context, partnerID string, direction domain.EDIDirection, controlNumber string) ([]*domain.EDIDocument, error) {
+	if f.findByInterchangeFn == nil {
+		return nil, errUnexpected
+	}
+	return f.findByInterchangeFn(ctx, partnerID, direction, controlNumber)
+}
+
+func (f *fakeEDIDocumentRepo) FindByGroupControlNumber(ctx context.Context, partnerID string, direction domain.EDIDirection, controlNumber string) ([]*domain.EDIDocument, error) {
+	if f.findByGroupFn == nil {
+		return nil, errUnexpected
+	}
+	return f.findByGroupFn(ctx, partnerID, direction, controlNumber)
+}
+
+func (f *fakeEDIDocumentRepo) FindByWMSOrderID(ctx context.Context, wmsOrderID string) ([]*domain.EDIDocument, error) {
+	if f.findByWMSOrderFn == nil {
+		return nil, errUnexpected
+	}
+	return f.findByWMSOrderFn(ctx, wmsOrderID)
+}
+
+type fakeEDICodec struct {
+	decodeFn func([]byte) (*domain.EDIInterchange, error)
+	encodeFn func(*domain.TradingPartner, domain.ControlNumbers, domain.EDITransaction) ([]byte, error)
+}
+
+func (f *fakeEDICodec) Decode(data []byte) (*domain.EDIInterchange, error) {
+	if f.decodeFn == nil {
+		return nil, errUnexpected
+	}
+	return f.decodeFn(data)
+}
+
+func (f *fakeEDICodec) Encode(partner *domain.TradingPartner, controls domain.ControlNumbers, txn domain.EDITransaction, _ time.Time) ([]byte, error) {
+	if f.encodeFn == nil {
+		return nil, errUnexpected
+	}
+	return f.encodeFn(partner, controls, txn)
+}
+
+type fakeEDIOrderCreator struct {
+	createFn func(context.Context, *domain.TradingPartner, *domain.EDIDocument) (string, error)
+}
+
+func (f *fakeEDIOrderCreator) CreateEDIOrder(ctx context.Context, partner *domain.TradingPartner, doc *domain.EDIDocument) (string, error) {
+	if f.createFn == nil {
+		return "", errUnexpected
+	}
+	return f.createFn(ctx, partner, doc)
+}
+
+type fakeShipmentCreator struct {
+	createFn func(context.Context, *domain.TradingPartner, *domain.EDIShipNotice) (string, error)
+}
+
+func (f *fakeShipmentCreator) CreateInboundShipment(ctx context.Context, partner *domain.TradingPartner, notice *domain.EDIShipNotice) (string, error) {
+	if f.createFn == nil {
+		return "", errUnexpected
+	}
+	return f.createFn(ctx, partner, notice)
+}
+
+// memoryEDIDocuments records inserted and saved documents so tests can assert on the log
+type memoryEDIDocuments struct {
+	inserted []*domain.EDIDocument
+	saved    []*domain.EDIDocument
+}
+
+func (m *memoryEDIDocuments) insert(_ context.Context, docs ...*domain.EDIDocument) error {
+	m.inserted = append(m.inserted, docs...)
+	return nil
+}
+
+func (m *memoryEDIDocuments) save(_ context.Context, doc *domain.EDIDocument) error {
+	m.saved = append(m.saved, doc)
+	return nil
+}
+
+func newTestPartner(t *testing.T, docs ...domain.EDIDocumentType) *domain.TradingPartner {
+	t.Helper()
+	partner, err := domain.NewTradingPartner("tenant-1", "seller-1", "FAC-1", "WH-1", "Acme B2B", domain.EnvelopeSettings{
+		PartnerQualifier:   "ZZ",
+		PartnerID:          "ACMEB2B",
+		WarehouseQualifier: "ZZ",
+		WarehouseID:        "WMSWAREHOUSE",
+		SendFunctionalAcks: true,
+	}, docs)
+	require.NoError(t, err)
+	return partner
+}
+
+func newEDIServiceWithFakes(partner *domain.TradingPartner) (*EDIService, *fakePartnerRepo, *fakeEDIDocumentRepo, *fakeEDICodec, *memoryEDIDocuments) {
+	partnerRepo := &fakePartnerRepo{
+		findByIDFn: func(context.Context, string) (*domain.TradingPartner, error) { return partner, nil },
+		findByInterchangeFn: func(context.Context, string, string, string, string) (*domain.TradingPartner, error) {
+			return partner, nil
+		},
+	}
+	controls := int64(0)
+	partnerRepo.nextControlsFn = func(context.Context, string) (domain.ControlNumbers, error) {
+		controls++
+		return domain.ControlNumbers{Interchange: controls, Group: controls}, nil
+	}
+
+	memory := &memoryEDIDocuments{}
+	documentRepo := &fakeEDIDocumentRepo{saveFn: memory.save, insertFn: memory.insert}
+	codec := &fakeEDICodec{
+		encodeFn: func(_ *domain.TradingPartner, _ domain.ControlNumbers, txn domain.EDITransaction) ([]byte, error) {
+			return []byte("ISA*" + string(txn.Type)), nil
+		},
+	}
+	return NewEDIService(partnerRepo, documentRepo, codec), partnerRepo, documentRepo, codec, memory
+}
+
+func warehouseShippingOrderInterchange(txns ...domain.EDITransaction) *domain.EDIInterchange {
+	return &domain.EDIInterchange{
+		SenderQualifier:   "ZZ",
+		SenderID:          "ACMEB2B",
+		ReceiverQualifier: "ZZ",
+		ReceiverID:        "WMSWAREHOUSE",
+		ControlNumber:     "000000101",
+		Groups:            []domain.EDIGroup{{FunctionalID: "OW", ControlNumber: "55", Transactions: txns}},
+	}
+}
+
+func TestReceiveInterchangeCreatesOrdersAndAcknowledges(t *testing.T) {
+	partner := newTestPartner(t)
+	service, _, _, codec, memory := newEDIServiceWithFakes(partner)
+	codec.decodeFn = func([]byte) (*domain.EDIInterchange, error) {
+		return warehouseShippingOrderInterchange(
+			domain.EDITransaction{Type: domain.X12WarehouseShippingOrder, ControlNumber: "0001", Order: &domain.EDIOrder{PurchaseOrderNumber: "PO-1"}},
+			domain.EDITransaction{Type: domain.X12WarehouseShippingOrder, ControlNumber: "0002", Errors: []string{"no W01 line items"}},
+		), nil
+	}
+	var ack *domain.EDIAcknowledgement
+	codec.encodeFn = func(_ *domain.TradingPartner, controls domain.ControlNumbers, txn domain.EDITransaction) ([]byte, error) {
+		require.Equal(t, domain.X12FunctionalAck, txn.Type)
+		require.Equal(t, int64(1), controls.Interchange)
+		ack = txn.Acknowledgement
+		return []byte("ISA*997"), nil
+	}
+	service.SetOrderCreator(&fakeEDIOrderCreator{createFn: func(_ context.Context, p *domain.TradingPartner, doc *domain.EDIDocument) (string, error) {
+		require.Equal(t, partner, p)
+		require.Equal(t, "PO-1", doc.Order.PurchaseOrderNumber)
+		// Logged before it is applied, so a resent interchange is caught
+		require.Contains(t, memory.inserted, doc)
+		require.Equal(t, "edi-"+partner.PartnerID+"-000000101-55-0001", doc.IdempotencyKey())
+		return "ORD-1", nil
+	}})
+
+	result, err := service.ReceiveInterchange(context.Background(), []byte("ISA"))
+	require.NoError(t, err)
+	require.Equal(t, partner.PartnerID, result.PartnerID)
+	require.Len(t, result.Documents, 2)
+	require.Equal(t, "processed", result.Documents[0].Status)
+	require.Equal(t, "ORD-1", result.Documents[0].WMSOrderID)
+	require.Equal(t, "rejected", result.Documents[1].Status)
+
+	require.Len(t, result.Acknowledgements, 1)
+	require.Equal(t, domain.EDIAckAcceptedWithErrors, ack.Status)
+	require.Len(t, memory.inserted, 2)
+	require.Equal(t, domain.EDIStatusRejected, memory.inserted[1].Status)
+	require.Len(t, memory.saved, 2)
+	require.Equal(t, domain.EDIStatusProcessed, memory.saved[0].Status)
+	require.Equal(t, "ISA*997", memory.saved[1].Payload)
+}
+
+func TestReceiveInterchangeRecordsDownstreamFailures(t *testing.T) {
+	partner := newTestPartner(t)
+	partner.Envelope.SendFunctionalAcks = false
+	service, _, _, codec, memory := newEDIServiceWithFakes(partner)
+	codec.decodeFn = func([]byte) (*domain.EDIInterchange, error) {
+		return warehouseShippingOrderInterchange(
+			domain.EDITransaction{Type: domain.X12WarehouseShippingOrder, ControlNumber: "0001", Order: &domain.EDIOrder{PurchaseOrderNumber: "PO-1"}},
+		), nil
+	}
+	service.SetOrderCreator(&fakeEDIOrderCreator{createFn: func(context.Context, *domain.TradingPartner, *domain.EDIDocument) (string, error) {
+		return "", errors.New("order service returned status 503")
+	}})
+
+	result, err := service.ReceiveInterchange(context.Background(), []byte("ISA"))
+	require.NoError(t, err)
+	require.Empty(t, result.Acknowledgements)
+	require.Len(t, memory.saved, 1)
+	require.Equal(t, domain.EDIStatusFailed, memory.saved[0].Status)
+	require.True(t, memory.saved[0].CanRetry())
+}
+
+func TestReceiveInterchangeRejections(t *testing.T) {
+	tests := []struct {
+		name      string
+		partner   func(*domain.TradingPartner) *domain.TradingPartner
+		insertErr error
+		expected  error
+	}{
+		{"unknown partner", func(*domain.TradingPartner) *domain.TradingPartner { return nil }, nil, domain.ErrTradingPartnerNotFound},
+		{"inactive partner", func(p *domain.TradingPartner) *domain.TradingPartner { p.Active = false; return p }, nil, domain.ErrTradingPartnerInactive},
+		{"duplicate interchange", func(p *domain.TradingPartner) *domain.TradingPartner { return p }, domain.ErrDuplicateInterchange, domain.ErrDuplicateInterchange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partner := tt.partner(newTestPartner(t))
+			service, partnerRepo, documentRepo, codec, _ := newEDIServiceWithFakes(partner)
+			partnerRepo.findByInterchangeFn = func(context.Context, string, string, string, string) (*domain.TradingPartner, error) {
+				return partner, nil
+			}
+			documentRepo.insertFn = func(context.Context, ...*domain.EDIDocument) error {
+				return tt.insertErr
+			}
+			codec.decodeFn = func([]byte) (*domain.EDIInterchange, error) {
+				return warehouseShippingOrderInterchange(
+					domain.EDITransaction{Type: domain.X12WarehouseShippingOrder, ControlNumber: "0001", Order: &domain.EDIOrder{PurchaseOrderNumber: "PO-1"}},
+				), nil
+			}
+			// Nothing is applied for a rejected interchange
+			service.SetOrderCreator(&fakeEDIOrderCreator{})
+
+			_, err := service.ReceiveInterchange(context.Background(), []byte("ISA"))
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestReceiveInterchangeAppliesFunctionalAck(t *testing.T) {
+	partner := newTestPartner(t)
+	service, _, documentRepo, codec, memory := newEDIServiceWithFakes(partner)
+	advice := domain.NewOutboundEDIDocument(partner, domain.ControlNumbers{Interchange: 12, Group: 12}, domain.EDITransaction{
+		Type: domain.X12WarehouseShippingAdvice,
+	}, []byte("ISA"))
+	documentRepo.findByGroupFn = func(_ context.Context, _ string, direction domain.EDIDirection, cn string) ([]*domain.EDIDocument, error) {
+		require.Equal(t, domain.EDIDirectionOutbound, direction)
+		require.Equal(t, "12", cn)
+		return []*domain.EDIDocument{advice}, nil
+	}
+	codec.decodeFn = func([]byte) (*domain.EDIInterchange, error) {
+		interchange := warehouseShippingOrderInterchange(domain.EDITransaction{
+			Type:          domain.X12FunctionalAck,
+			ControlNumber: "0001",
+			Acknowledgement: &domain.EDIAcknowledgement{
+				FunctionalID:       "SW",
+				GroupControlNumber: "12",
+				Status:             domain.EDIAckRejected,
+				Transactions: []domain.EDITransactionAck{
+					{Type: domain.X12WarehouseShippingAdvice, ControlNumber: "0001", Status: domain.EDIAckRejected, Errors: []string{"W12 in error"}},
+				},
+			},
+		})
+		interchange.Groups[0].FunctionalID = "FA"
+		return interchange, nil
+	}
+
+	result, err := service.ReceiveInterchange(context.Background(), []byte("ISA"))
+	require.NoError(t, err)
+	require.Equal(t, "processed", result.Documents[0].Status)
+	// A 997 is never acknowledged
+	require.Empty(t, result.Acknowledgements)
+	require.Equal(t, domain.EDIAckRejected, advice.AckStatus)
+	require.Equal(t, []string{"W12 in error"}, advice.Errors)
+	require.Len(t, memory.saved, 2)
+}
+
+func TestHandleShipmentConfirmedGeneratesPartnerDocuments(t *testing.T) {
+	partner := newTestPartner(t, domain.X12WarehouseShippingAdvice, domain.X12ShipNotice)
+	service, _, documentRepo, codec, memory := newEDIServiceWithFakes(partner)
+
+	orderDoc := domain.NewInboundEDIDocument(partner, &domain.EDIInterchange{}, domain.EDIGroup{}, domain.EDITransaction{
+		Type:  domain.X12WarehouseShippingOrder,
+		Order: &domain.EDIOrder{PurchaseOrderNumber: "PO-1", Lines: []domain.EDIOrderLine{{SKU: "SKU-1", Quantity: 2}}},
+	})
+	orderDoc.MarkOrderCreated("ORD-1")
+	alreadySent := &domain.EDIDocument{Direction: domain.EDIDirectionOutbound, Type: domain.X12WarehouseShippingAdvice}
+	documentRepo.findByWMSOrderFn = func(_ context.Context, wmsOrderID string) ([]*domain.EDIDocument, error) {
+		require.Equal(t, "ORD-1", wmsOrderID)
+		return []*domain.EDIDocument{orderDoc, alreadySent}, nil
+	}
+	var encoded []domain.EDIDocumentType
+	codec.encodeFn = func(_ *domain.TradingPartner, _ domain.ControlNumbers, txn domain.EDITransaction) ([]byte, error) {
+		encoded = append(encoded, txn.Type)
+		require.Equal(t, "UPS", txn.Confirmation.CarrierSCAC)
+		require.Equal(t, "SHP-1", txn.Confirmation.ShipmentID)
+		return []byte("ISA"), nil
+	}
+
+	err := service.HandleShipmentConfirmed(context.Background(), ShipmentConfirmedCommand{
+		WMSOrderID:     "ORD-1",
+		ShipmentID:     "SHP-1",
+		TrackingNumber: "1Z999AA10123456784",
+		Carrier:        "ups",
+		ShippedAt:      time.Now(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []domain.EDIDocumentType{domain.X12ShipNotice}, encoded)
+	require.Len(t, memory.saved, 1)
+	require.Equal(t, "ORD-1", memory.saved[0].WMSOrderID)
+	require.Equal(t, domain.EDIAckPending, memory.saved[0].AckStatus)
+}
+
+func TestHandleShipmentConfirmedSkipsNonEDIOrders(t *testing.T) {
+	service, _, documentRepo, _, memory := newEDIServiceWithFakes(newTestPartner(t, domain.X12ShipNotice))
+	documentRepo.findByWMSOrderFn = func(context.Context, string) ([]*domain.EDIDocument, error) {
+		return nil, nil
+	}
+
+	require.NoError(t, service.HandleShipmentConfirmed(context.Background(), ShipmentConfirmedCommand{WMSOrderID: "ORD-9"}))
+	require.Empty(t, memory.saved)
+}
+
+func TestRetryDocument(t *testing.T) {
+	partner := newTestPartner(t)
+	service, _, documentRepo, _, memory := newEDIServiceWithFakes(partner)
+	doc := domain.NewInboundEDIDocument(partner, &domain.EDIInterchange{}, domain.EDIGroup{}, domain.EDITransaction{
+		Type:       domain.X12ShipNotice,
+		ShipNotice: &domain.EDIShipNotice{ShipmentID: "ASN-1"},
+	})
+	documentRepo.findByIDFn = func(context.Context, string) (*domain.EDIDocument, error) { return doc, nil }
+
+	doc.MarkShipmentCreated("TP-1-ASN-0")
+	_, err := service.RetryDocument(context.Background(), doc.DocumentID)
+	require.ErrorIs(t, err, domain.ErrEDIDocumentNotRetryable)
+
+	doc.MarkFailed("receiving service returned status 503")
+	service.SetInboundShipmentCreator(&fakeShipmentCreator{createFn: func(context.Context, *domain.TradingPartner, *domain.EDIShipNotice) (string, error) {
+		return "TP-1-ASN-1", nil
+	}})
+
+	dto, err := service.RetryDocument(context.Background(), doc.DocumentID)
+	require.NoError(t, err)
+	require.Equal(t, "processed", dto.Status)
+	require.Equal(t, "TP-1-ASN-1", dto.InboundShipmentID)
+	require.Len(t, memory.saved, 1)
+}
+
+func TestCreateTradingPartnerRejectsSharedInterchangeIDs(t *testing.T) {
+	other := newTestPartner(t)
+	service, _, _, _, _ := newEDIServiceWithFakes(other)
+
+	_, err := service.CreateTradingPartner(context.Background(), CreateTradingPartnerCommand{
+		TenantID:    "tenant-1",
+		SellerID:    "seller-2",
+		FacilityID:  "FAC-1",
+		WarehouseID: "WH-1",
+		Name:        "Acme Again",
+		Envelope:    other.Envelope,
+	})
+	require.ErrorIs(t, err, domain.ErrInvalidTradingPartner)
+}
+
+func TestUpdateTradingPartnerKeepsOmittedFields(t *testing.T) {
+	partner := newTestPartner(t, domain.X12ShipNotice)
+	service, partnerRepo, _, _, _ := newEDIServiceWithFakes(partner)
+	partnerRepo.saveFn = func(context.Context, *domain.TradingPartner) error { return nil }
+
+	inactive := false
+	dto, err := service.UpdateTradingPartner(context.Background(), partner.PartnerID, UpdateTradingPartnerCommand{Active: &inactive})
+	require.NoError(t, err)
+	require.False(t, dto.Active)
+	require.Equal(t, "Acme B2B", dto.Name)
+	require.Equal(t, []string{"856"}, dto.OutboundDocuments)
+}
@@ -0,0 +1,260 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/resilience"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+const (
+	// orderSyncOverlap re-fetches a short window before the last sync so orders
+	// created while the previous sync was running are not missed; duplicates are skipped
+	orderSyncOverlap = 5 * time.Minute
+
+	// initialOrderLookback is how far back the first import of a channel reaches
+	initialOrderLookback = 7 * 24 * time.Hour
+
+	// staleSyncJobTimeout is how long a running job may block new syncs before it is considered abandoned
+	staleSyncJobTimeout = time.Hour
+)
+
+// ErrOrderCreatorNotConfigured is returned when a scheduled import runs without an order creator
+var ErrOrderCreatorNotConfigured = errors.New("order creator not configured")
+
+// ErrInventorySourceNotConfigured is returned when a scheduled inventory push runs without an inventory source
+var ErrInventorySourceNotConfigured = errors.New("inventory source not configured")
+
+// RunOrderImport fetches new orders from a channel since its last order sync, stores them
+// as channel orders and creates WMS orders for every order not yet imported
+func (s *ChannelService) RunOrderImport(ctx context.Context, channelID string) (*SyncJobDTO, error) {
+	if s.orderCreator == nil {
+		return nil, ErrOrderCreatorNotConfigured
+	}
+
+	channel, adapter, err := s.loadSchedulableChannel(ctx, channelID, domain.SyncTypeOrders)
+	if err != nil {
+		return nil, err
+	}
+
+	job := domain.NewSyncJob(channel.TenantID, channel.SellerID, channel.ChannelID, domain.SyncTypeOrders, "inbound")
+	job.Start()
+	if err := s.syncJobRepo.Save(ctx, job); err != nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-initialOrderLookback)
+	if channel.LastOrderSync != nil {
+		since = channel.LastOrderSync.Add(-orderSyncOverlap)
+	}
+
+	var orders []*domain.ChannelOrder
+	err = s.withFreshCredentials(ctx, channel, adapter, func() error {
+		orders, err = adapter.FetchOrders(ctx, channel, since)
+		return err
+	})
+	if err != nil {
+		return s.failSyncJob(ctx, channel, job, fmt.Errorf("failed to fetch orders: %w", err))
+	}
+
+	// Dedupe against orders already stored for this channel
+	newOrders := make([]*domain.ChannelOrder, 0)
+	for _, order := range orders {
+		if !channel.SyncSettings.ShouldImport(order) {
+			continue
+		}
+		existing, err := s.orderRepo.FindByExternalID(ctx, channel.ChannelID, order.ExternalOrderID)
+		if err != nil {
+			return s.failSyncJob(ctx, channel, job, fmt.Errorf("failed to check order %s: %w", order.ExternalOrderID, err))
+		}
+		if existing == nil {
+			newOrders = append(newOrders, order)
+		}
+	}
+
+	if len(newOrders) > 0 {
+		if err := s.orderRepo.SaveAll(ctx, newOrders); err != nil {
+			return s.failSyncJob(ctx, channel, job, fmt.Errorf("failed to save orders: %w", err))
+		}
+	}
+
+	// Import everything still pending, including orders whose creation failed on an earlier run
+	pending, err := s.orderRepo.FindUnimported(ctx, channel.ChannelID)
+	if err != nil {
+		return s.failSyncJob(ctx, channel, job, fmt.Errorf("failed to find unimported orders: %w", err))
+	}
+
+	job.SetTotalItems(len(pending))
+	imported := make(map[string]string, len(pending)) // external order ID -> WMS order ID
+	for _, order := range pending {
+		wmsOrderID, err := s.orderCreator.CreateOrder(ctx, channel, order)
+		if err == nil {
+			err = s.orderRepo.MarkImported(ctx, order.ExternalOrderID, wmsOrderID)
+		}
+		if err != nil {
+			job.ProcessedItems++
+			job.AddError(order.ExternalOrderID, err.Error())
+			continue
+		}
+
+		imported[order.ExternalOrderID] = wmsOrderID
+		job.IncrementProgress(true)
+	}
+
+	job.Complete()
+	if err := s.syncJobRepo.Save(ctx, job); err != nil {
+		return nil, err
+	}
+
+	err = s.saveChannelChanges(ctx, channel, func(channel *domain.Channel) {
+		for externalOrderID, wmsOrderID := range imported {
+			channel.RecordOrderImported(externalOrderID, wmsOrderID)
+		}
+		channel.UpdateLastSync(domain.SyncTypeOrders)
+		channel.RecordSyncCompleted(job)
+		if channel.ErrorCount > 0 {
+			channel.ClearErrors()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ToSyncJobDTO(job), nil
+}
+
+// RunInventoryPush pushes WMS inventory levels that changed since the last push to a channel
+func (s *ChannelService) RunInventoryPush(ctx context.Context, channelID string) (*SyncJobDTO, error) {
+	if s.inventorySource == nil {
+		return nil, ErrInventorySourceNotConfigured
+	}
+
+	channel, adapter, err := s.loadSchedulableChannel(ctx, channelID, domain.SyncTypeInventory)
+	if err != nil {
+		return nil, err
+	}
+
+	job := domain.NewSyncJob(channel.TenantID, channel.SellerID, channel.ChannelID, domain.SyncTypeInventory, "outbound")
+	job.Start()
+	if err := s.syncJobRepo.Save(ctx, job); err != nil {
+		return nil, err
+	}
+
+	levels, err := s.inventorySource.GetInventoryLevels(ctx, channel)
+	if err != nil {
+		return s.failSyncJob(ctx, channel, job, fmt.Errorf("failed to get inventory levels: %w", err))
+	}
+
+	deltas := channel.InventoryDeltas(levels)
+	job.SetTotalItems(len(deltas))
+
+	if len(deltas) > 0 {
+		err := s.withFreshCredentials(ctx, channel, adapter, func() error {
+			return adapter.SyncInventory(ctx, channel, deltas)
+		})
+		if err != nil {
+			return s.failSyncJob(ctx, channel, job, fmt.Errorf("failed to sync inventory: %w", err))
+		}
+		job.ProcessedItems = len(deltas)
+		job.SuccessItems = len(deltas)
+	}
+
+	job.Complete()
+	if err := s.syncJobRepo.Save(ctx, job); err != nil {
+		return nil, err
+	}
+
+	err = s.saveChannelChanges(ctx, channel, func(channel *domain.Channel) {
+		channel.RecordInventoryPushed(deltas)
+		channel.UpdateLastSync(domain.SyncTypeInventory)
+		channel.RecordSyncCompleted(job)
+		if channel.ErrorCount > 0 {
+			channel.ClearErrors()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ToSyncJobDTO(job), nil
+}
+
+// loadSchedulableChannel loads an active channel and its adapter, making sure no other
+// sync of the same type is running. Abandoned running jobs are failed so they stop blocking.
+func (s *ChannelService) loadSchedulableChannel(ctx context.Context, channelID string, syncType domain.SyncType) (*domain.Channel, domain.ChannelAdapter, error) {
+	channel, err := s.channelRepo.FindByID(ctx, channelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !channel.IsActive() {
+		return nil, nil, domain.ErrChannelNotActive
+	}
+
+	running, err := s.syncJobRepo.FindRunning(ctx, channelID, syncType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if running != nil {
+		if !running.IsStale(time.Now(), staleSyncJobTimeout) {
+			return nil, nil, domain.ErrSyncInProgress
+		}
+		running.Fail("sync job abandoned")
+		if err := s.syncJobRepo.Save(ctx, running); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	adapter, err := s.adapterFactory.GetAdapterForChannel(channel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return channel, adapter, nil
+}
+
+// failSyncJob marks a scheduled sync as failed on both the job and the channel
+func (s *ChannelService) failSyncJob(ctx context.Context, channel *domain.Channel, job *domain.SyncJob, cause error) (*SyncJobDTO, error) {
+	job.Fail(cause.Error())
+	if err := s.syncJobRepo.Save(ctx, job); err != nil {
+		return nil, fmt.Errorf("%v (and failed to save sync job: %w)", cause, err)
+	}
+
+	err := s.saveChannelChanges(ctx, channel, func(channel *domain.Channel) {
+		channel.RecordError(cause.Error())
+		channel.RecordSyncCompleted(job)
+	})
+	if err != nil {
+		return ToSyncJobDTO(job), fmt.Errorf("%v (and failed to save channel: %w)", cause, err)
+	}
+
+	return ToSyncJobDTO(job), cause
+}
+
+// saveChannelChanges applies change to the channel and saves it. Syncs run for a while
+// after loading the channel, so when another writer saved it in the meantime the change
+// is applied again to the stored channel instead of overwriting that writer's update.
+// The caller's channel ends up as saved.
+func (s *ChannelService) saveChannelChanges(ctx context.Context, channel *domain.Channel, change func(*domain.Channel)) error {
+	current := channel
+	return resilience.RetryOnConflict(ctx, func() error {
+		if current == nil {
+			latest, err := s.channelRepo.FindByID(ctx, channel.ChannelID)
+			if err != nil {
+				return err
+			}
+			current = latest
+		}
+
+		change(current)
+		if err := s.channelRepo.Save(ctx, current); err != nil {
+			current = nil
+			return err
+		}
+		*channel = *current
+		return nil
+	})
+}
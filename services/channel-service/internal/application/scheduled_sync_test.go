@@ -0,0 +1,448 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/services/channel-service/internal/domain"
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+type fakeOrderCreator struct {
+	createFn func(context.Context, *domain.Channel, *domain.ChannelOrder) (string, error)
+}
+
+func (f *fakeOrderCreator) CreateOrder(ctx context.Context, channel *domain.Channel, order *domain.ChannelOrder) (string, error) {
+	if f.createFn == nil {
+		return "", errUnexpected
+	}
+	return f.createFn(ctx, channel, order)
+}
+
+type fakeInventorySource struct {
+	getLevelsFn func(context.Context, *domain.Channel) ([]domain.InventoryUpdate, error)
+}
+
+func (f *fakeInventorySource) GetInventoryLevels(ctx context.Context, channel *domain.Channel) ([]domain.InventoryUpdate, error) {
+	if f.getLevelsFn == nil {
+		return nil, errUnexpected
+	}
+	return f.getLevelsFn(ctx, channel)
+}
+
+func newScheduledChannel(t *testing.T) *domain.Channel {
+	t.Helper()
+	channel, err := domain.NewChannel("tenant-1", "seller-1", domain.ChannelTypeShopify, "Test", "", domain.ChannelCredentials{}, domain.SyncSettings{
+		AutoImportOrders:         true,
+		AutoSyncInventory:        true,
+		OrderSyncIntervalMin:     5,
+		InventorySyncIntervalMin: 15,
+	})
+	require.NoError(t, err)
+	return channel
+}
+
+func newTestLogger() *logging.Logger {
+	return logging.New(&logging.Config{
+		Level:       logging.LevelInfo,
+		ServiceName: "test",
+		Environment: "test",
+		Version:     "test",
+		Output:      io.Discard,
+	})
+}
+
+func TestRunOrderImportNotConfigured(t *testing.T) {
+	service, _, _, _ := newServiceWithAdapter(nil)
+
+	_, err := service.RunOrderImport(context.Background(), "ch-1")
+	require.ErrorIs(t, err, ErrOrderCreatorNotConfigured)
+}
+
+func TestRunOrderImportChannelNotActive(t *testing.T) {
+	service, channelRepo, _, _ := newServiceWithAdapter(nil)
+	service.SetOrderCreator(&fakeOrderCreator{})
+	channel := newScheduledChannel(t)
+	channel.Pause()
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+
+	_, err := service.RunOrderImport(context.Background(), channel.ChannelID)
+	require.ErrorIs(t, err, domain.ErrChannelNotActive)
+}
+
+func TestRunOrderImportAlreadyRunning(t *testing.T) {
+	service, channelRepo, _, syncRepo := newServiceWithAdapter(nil)
+	service.SetOrderCreator(&fakeOrderCreator{})
+	channel := newScheduledChannel(t)
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	running := domain.NewSyncJob(channel.TenantID, channel.SellerID, channel.ChannelID, domain.SyncTypeOrders, "inbound")
+	running.Start()
+	syncRepo.findRunningFn = func(context.Context, string, domain.SyncType) (*domain.SyncJob, error) {
+		return running, nil
+	}
+
+	_, err := service.RunOrderImport(context.Background(), channel.ChannelID)
+	require.ErrorIs(t, err, domain.ErrSyncInProgress)
+}
+
+func TestRunOrderImportSuccess(t *testing.T) {
+	var since time.Time
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeShopify,
+		fetchOrdersFn: func(_ context.Context, _ *domain.Channel, s time.Time) ([]*domain.ChannelOrder, error) {
+			since = s
+			return []*domain.ChannelOrder{
+				{ExternalOrderID: "ext-1"},
+				{ExternalOrderID: "ext-2"},
+				{ExternalOrderID: "ext-3", FulfillmentStatus: "fulfilled"},
+			}, nil
+		},
+	}
+	service, channelRepo, orderRepo, syncRepo := newServiceWithAdapter(adapter)
+	channel := newScheduledChannel(t)
+	lastSync := time.Now().Add(-time.Hour).UTC()
+	channel.LastOrderSync = &lastSync
+	channel.RecordError("previous failure")
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	channelRepo.saveFn = func(context.Context, *domain.Channel) error { return nil }
+	syncRepo.findRunningFn = func(context.Context, string, domain.SyncType) (*domain.SyncJob, error) {
+		return nil, nil
+	}
+	syncRepo.saveFn = func(context.Context, *domain.SyncJob) error { return nil }
+	orderRepo.findByExternalIDFn = func(_ context.Context, _, externalOrderID string) (*domain.ChannelOrder, error) {
+		if externalOrderID == "ext-1" {
+			return &domain.ChannelOrder{}, nil
+		}
+		return nil, nil
+	}
+	var saved []*domain.ChannelOrder
+	orderRepo.saveAllFn = func(_ context.Context, orders []*domain.ChannelOrder) error {
+		saved = orders
+		return nil
+	}
+	orderRepo.findUnimportedFn = func(context.Context, string) ([]*domain.ChannelOrder, error) {
+		return []*domain.ChannelOrder{{ExternalOrderID: "ext-0"}, {ExternalOrderID: "ext-2"}}, nil
+	}
+	imported := map[string]string{}
+	orderRepo.markImportedFn = func(_ context.Context, externalOrderID, wmsOrderID string) error {
+		imported[externalOrderID] = wmsOrderID
+		return nil
+	}
+	service.SetOrderCreator(&fakeOrderCreator{
+		createFn: func(_ context.Context, _ *domain.Channel, order *domain.ChannelOrder) (string, error) {
+			if order.ExternalOrderID == "ext-0" {
+				return "", errors.New("invalid address")
+			}
+			return "ORD-" + order.ExternalOrderID, nil
+		},
+	})
+
+	dto, err := service.RunOrderImport(context.Background(), channel.ChannelID)
+	require.NoError(t, err)
+	require.Equal(t, lastSync.Add(-orderSyncOverlap), since)
+	require.Len(t, saved, 1)
+	require.Equal(t, "ext-2", saved[0].ExternalOrderID)
+	require.Equal(t, map[string]string{"ext-2": "ORD-ext-2"}, imported)
+	require.Equal(t, string(domain.SyncStatusCompleted), dto.Status)
+	require.Equal(t, 2, dto.TotalItems)
+	require.Equal(t, 2, dto.ProcessedItems)
+	require.Equal(t, 1, dto.FailedItems)
+	require.True(t, channel.LastOrderSync.After(lastSync))
+	require.Zero(t, channel.ErrorCount)
+
+	var importedEvents, completedEvents int
+	for _, event := range channel.DomainEvents() {
+		switch event.(type) {
+		case *domain.OrderImportedEvent:
+			importedEvents++
+		case *domain.SyncCompletedEvent:
+			completedEvents++
+		}
+	}
+	require.Equal(t, 1, importedEvents)
+	require.Equal(t, 1, completedEvents)
+}
+
+func TestRunOrderImportFetchErrorRecordedOnChannel(t *testing.T) {
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeShopify,
+		fetchOrdersFn: func(context.Context, *domain.Channel, time.Time) ([]*domain.ChannelOrder, error) {
+			return nil, errors.New("rate limited")
+		},
+	}
+	service, channelRepo, _, syncRepo := newServiceWithAdapter(adapter)
+	service.SetOrderCreator(&fakeOrderCreator{})
+	channel := newScheduledChannel(t)
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	channelRepo.saveFn = func(context.Context, *domain.Channel) error { return nil }
+	syncRepo.findRunningFn = func(context.Context, string, domain.SyncType) (*domain.SyncJob, error) {
+		return nil, nil
+	}
+	syncRepo.saveFn = func(context.Context, *domain.SyncJob) error { return nil }
+
+	dto, err := service.RunOrderImport(context.Background(), channel.ChannelID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to fetch orders")
+	require.NotNil(t, dto)
+	require.Equal(t, string(domain.SyncStatusFailed), dto.Status)
+	require.Equal(t, 1, channel.ErrorCount)
+	require.Nil(t, channel.LastOrderSync)
+}
+
+func TestRunOrderImportFailsStaleJob(t *testing.T) {
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeShopify,
+		fetchOrdersFn: func(context.Context, *domain.Channel, time.Time) ([]*domain.ChannelOrder, error) {
+			return nil, nil
+		},
+	}
+	service, channelRepo, orderRepo, syncRepo := newServiceWithAdapter(adapter)
+	service.SetOrderCreator(&fakeOrderCreator{})
+	channel := newScheduledChannel(t)
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	channelRepo.saveFn = func(context.Context, *domain.Channel) error { return nil }
+	stale := domain.NewSyncJob(channel.TenantID, channel.SellerID, channel.ChannelID, domain.SyncTypeOrders, "inbound")
+	stale.Start()
+	startedAt := time.Now().Add(-2 * staleSyncJobTimeout)
+	stale.StartedAt = &startedAt
+	syncRepo.findRunningFn = func(context.Context, string, domain.SyncType) (*domain.SyncJob, error) {
+		return stale, nil
+	}
+	syncRepo.saveFn = func(context.Context, *domain.SyncJob) error { return nil }
+	orderRepo.findUnimportedFn = func(context.Context, string) ([]*domain.ChannelOrder, error) {
+		return nil, nil
+	}
+
+	dto, err := service.RunOrderImport(context.Background(), channel.ChannelID)
+	require.NoError(t, err)
+	require.Equal(t, string(domain.SyncStatusCompleted), dto.Status)
+	require.Equal(t, domain.SyncStatusFailed, stale.Status)
+}
+
+func TestRunInventoryPushOnlyPushesChanges(t *testing.T) {
+	var pushed []domain.InventoryUpdate
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeShopify,
+		syncInventoryFn: func(_ context.Context, _ *domain.Channel, items []domain.InventoryUpdate) error {
+			pushed = items
+			return nil
+		},
+	}
+	service, channelRepo, _, syncRepo := newServiceWithAdapter(adapter)
+	channel := newScheduledChannel(t)
+	channel.InventoryLevels = map[string]int{"sku-1": 4, "sku-2": 9}
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	channelRepo.saveFn = func(context.Context, *domain.Channel) error { return nil }
+	syncRepo.findRunningFn = func(context.Context, string, domain.SyncType) (*domain.SyncJob, error) {
+		return nil, nil
+	}
+	syncRepo.saveFn = func(context.Context, *domain.SyncJob) error { return nil }
+	service.SetInventorySource(&fakeInventorySource{
+		getLevelsFn: func(context.Context, *domain.Channel) ([]domain.InventoryUpdate, error) {
+			return []domain.InventoryUpdate{
+				{SKU: "sku-1", Quantity: 4, Available: 4},
+				{SKU: "sku-2", Quantity: 10, Available: 7},
+				{SKU: "sku-3", Quantity: 2, Available: 2},
+			}, nil
+		},
+	})
+
+	dto, err := service.RunInventoryPush(context.Background(), channel.ChannelID)
+	require.NoError(t, err)
+	require.Len(t, pushed, 2)
+	require.Equal(t, "sku-2", pushed[0].SKU)
+	require.Equal(t, "sku-3", pushed[1].SKU)
+	require.Equal(t, 2, dto.ProcessedItems)
+	require.Equal(t, map[string]int{"sku-1": 4, "sku-2": 7, "sku-3": 2}, channel.InventoryLevels)
+	require.NotNil(t, channel.LastInventorySync)
+}
+
+func TestRunInventoryPushReappliesChangesAfterConflict(t *testing.T) {
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeShopify,
+		syncInventoryFn: func(context.Context, *domain.Channel, []domain.InventoryUpdate) error {
+			return nil
+		},
+	}
+	service, channelRepo, _, syncRepo := newServiceWithAdapter(adapter)
+	loaded := newScheduledChannel(t)
+
+	// An order import saved the channel while the push was running
+	concurrent := *loaded
+	importedAt := time.Now().UTC()
+	concurrent.LastOrderSync = &importedAt
+	concurrent.Version = loaded.Version + 1
+
+	var loads int
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		loads++
+		if loads == 1 {
+			copied := *loaded
+			return &copied, nil
+		}
+		copied := concurrent
+		return &copied, nil
+	}
+	var saved *domain.Channel
+	channelRepo.saveFn = func(_ context.Context, channel *domain.Channel) error {
+		if channel.Version != concurrent.Version {
+			return sharedErrors.NewConcurrencyConflictError("Channel", channel.ChannelID, channel.Version)
+		}
+		copied := *channel
+		saved = &copied
+		return nil
+	}
+	syncRepo.findRunningFn = func(context.Context, string, domain.SyncType) (*domain.SyncJob, error) {
+		return nil, nil
+	}
+	syncRepo.saveFn = func(context.Context, *domain.SyncJob) error { return nil }
+	service.SetInventorySource(&fakeInventorySource{
+		getLevelsFn: func(context.Context, *domain.Channel) ([]domain.InventoryUpdate, error) {
+			return []domain.InventoryUpdate{{SKU: "sku-1", Available: 3}}, nil
+		},
+	})
+
+	_, err := service.RunInventoryPush(context.Background(), loaded.ChannelID)
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	require.Equal(t, map[string]int{"sku-1": 3}, saved.InventoryLevels)
+	require.NotNil(t, saved.LastInventorySync)
+	require.Equal(t, &importedAt, saved.LastOrderSync)
+}
+
+func TestRunInventoryPushAdapterError(t *testing.T) {
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeShopify,
+		syncInventoryFn: func(context.Context, *domain.Channel, []domain.InventoryUpdate) error {
+			return errors.New("push failed")
+		},
+	}
+	service, channelRepo, _, syncRepo := newServiceWithAdapter(adapter)
+	channel := newScheduledChannel(t)
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	channelRepo.saveFn = func(context.Context, *domain.Channel) error { return nil }
+	syncRepo.findRunningFn = func(context.Context, string, domain.SyncType) (*domain.SyncJob, error) {
+		return nil, nil
+	}
+	syncRepo.saveFn = func(context.Context, *domain.SyncJob) error { return nil }
+	service.SetInventorySource(&fakeInventorySource{
+		getLevelsFn: func(context.Context, *domain.Channel) ([]domain.InventoryUpdate, error) {
+			return []domain.InventoryUpdate{{SKU: "sku-1", Available: 1}}, nil
+		},
+	})
+
+	_, err := service.RunInventoryPush(context.Background(), channel.ChannelID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to sync inventory")
+	require.Empty(t, channel.InventoryLevels)
+	require.Equal(t, 1, channel.ErrorCount)
+}
+
+func TestSyncSchedulerRunDue(t *testing.T) {
+	adapter := &fakeAdapter{
+		channelType: domain.ChannelTypeShopify,
+		syncInventoryFn: func(context.Context, *domain.Channel, []domain.InventoryUpdate) error {
+			return nil
+		},
+	}
+	service, channelRepo, _, syncRepo := newServiceWithAdapter(adapter)
+
+	due := newScheduledChannel(t)
+	notDue := newScheduledChannel(t)
+	lastSync := time.Now().UTC()
+	notDue.LastInventorySync = &lastSync
+	paused := newScheduledChannel(t)
+	paused.Pause()
+
+	channels := map[string]*domain.Channel{due.ChannelID: due, notDue.ChannelID: notDue, paused.ChannelID: paused}
+	channelRepo.findNeedingSyncFn = func(_ context.Context, syncType domain.SyncType, _ time.Duration) ([]*domain.Channel, error) {
+		require.Equal(t, domain.SyncTypeInventory, syncType)
+		return []*domain.Channel{due, notDue, paused}, nil
+	}
+	channelRepo.findByIDFn = func(_ context.Context, channelID string) (*domain.Channel, error) {
+		return channels[channelID], nil
+	}
+	channelRepo.saveFn = func(context.Context, *domain.Channel) error { return nil }
+	syncRepo.findRunningFn = func(context.Context, string, domain.SyncType) (*domain.SyncJob, error) {
+		return nil, nil
+	}
+	syncRepo.saveFn = func(context.Context, *domain.SyncJob) error { return nil }
+	service.SetInventorySource(&fakeInventorySource{
+		getLevelsFn: func(context.Context, *domain.Channel) ([]domain.InventoryUpdate, error) {
+			return []domain.InventoryUpdate{{SKU: "sku-1", Available: 3}}, nil
+		},
+	})
+
+	config := DefaultSyncSchedulerConfig()
+	config.OrderImportEnabled = false
+	scheduler := NewSyncScheduler(service, channelRepo, config, newTestLogger())
+
+	results := scheduler.RunDue(context.Background(), time.Now())
+	require.Len(t, results, 1)
+	require.Equal(t, due.ChannelID, results[0].ChannelID)
+	require.Equal(t, domain.SyncTypeInventory, results[0].SyncType)
+	require.NoError(t, results[0].Err)
+	require.NotNil(t, due.LastInventorySync)
+}
+
+func TestSyncSchedulerStartStop(t *testing.T) {
+	service, channelRepo, _, _ := newServiceWithAdapter(nil)
+	scheduler := NewSyncScheduler(service, channelRepo, DefaultSyncSchedulerConfig(), newTestLogger())
+
+	require.NoError(t, scheduler.Start(context.Background()))
+	require.True(t, scheduler.IsRunning())
+	require.Error(t, scheduler.Start(context.Background()))
+
+	scheduler.Stop()
+	require.False(t, scheduler.IsRunning())
+}
+
+func TestPauseAndResumeChannel(t *testing.T) {
+	service, channelRepo, _, _ := newServiceWithAdapter(nil)
+	channel := newScheduledChannel(t)
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+	channelRepo.saveFn = func(context.Context, *domain.Channel) error { return nil }
+
+	dto, err := service.PauseChannel(context.Background(), channel.ChannelID)
+	require.NoError(t, err)
+	require.Equal(t, string(domain.ChannelStatusPaused), dto.Status)
+	require.Nil(t, dto.SyncSettings.OrderSync.NextSyncAt)
+
+	dto, err = service.ResumeChannel(context.Background(), channel.ChannelID)
+	require.NoError(t, err)
+	require.Equal(t, string(domain.ChannelStatusActive), dto.Status)
+	require.NotNil(t, dto.SyncSettings.OrderSync.NextSyncAt)
+}
+
+func TestResumeDisconnectedChannel(t *testing.T) {
+	service, channelRepo, _, _ := newServiceWithAdapter(nil)
+	channel := newScheduledChannel(t)
+	channel.Disconnect()
+	channelRepo.findByIDFn = func(context.Context, string) (*domain.Channel, error) {
+		return channel, nil
+	}
+
+	_, err := service.ResumeChannel(context.Background(), channel.ChannelID)
+	require.ErrorIs(t, err, domain.ErrChannelDisconnected)
+}
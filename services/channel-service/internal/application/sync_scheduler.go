@@ -0,0 +1,184 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/logging"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// SyncScheduler runs each channel's automatic order imports and inventory pushes
+// on the intervals configured in its SyncSettings. Only active channels are
+// scheduled, so pausing or disconnecting a channel stops its syncs and resuming
+// it picks them up again on the next poll.
+type SyncScheduler struct {
+	service     *ChannelService
+	channelRepo domain.ChannelRepository
+	config      SyncSchedulerConfig
+	logger      *logging.Logger
+	mu          sync.RWMutex
+	running     bool
+	stopChan    chan struct{}
+}
+
+// SyncSchedulerConfig configuration for the sync scheduler
+type SyncSchedulerConfig struct {
+	// PollInterval is how often channels are checked for due syncs
+	PollInterval time.Duration `json:"pollInterval"`
+
+	// OrderImportEnabled enables scheduled order imports
+	OrderImportEnabled bool `json:"orderImportEnabled"`
+
+	// InventoryPushEnabled enables scheduled inventory pushes
+	InventoryPushEnabled bool `json:"inventoryPushEnabled"`
+}
+
+// DefaultSyncSchedulerConfig returns default configuration
+func DefaultSyncSchedulerConfig() SyncSchedulerConfig {
+	return SyncSchedulerConfig{
+		PollInterval:         time.Minute,
+		OrderImportEnabled:   true,
+		InventoryPushEnabled: true,
+	}
+}
+
+// ScheduledSyncResult is the outcome of one scheduled sync
+type ScheduledSyncResult struct {
+	ChannelID string
+	SyncType  domain.SyncType
+	Job       *SyncJobDTO
+	Err       error
+}
+
+// NewSyncScheduler creates a new sync scheduler
+func NewSyncScheduler(
+	service *ChannelService,
+	channelRepo domain.ChannelRepository,
+	config SyncSchedulerConfig,
+	logger *logging.Logger,
+) *SyncScheduler {
+	return &SyncScheduler{
+		service:     service,
+		channelRepo: channelRepo,
+		config:      config,
+		logger:      logger,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins polling for due syncs
+func (s *SyncScheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return fmt.Errorf("sync scheduler is already running")
+	}
+	s.running = true
+	s.stopChan = make(chan struct{})
+	s.mu.Unlock()
+
+	go s.run(ctx)
+	return nil
+}
+
+// Stop stops polling for due syncs
+func (s *SyncScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		close(s.stopChan)
+		s.running = false
+	}
+}
+
+// IsRunning returns whether the scheduler is running
+func (s *SyncScheduler) IsRunning() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running
+}
+
+// run is the main loop for the sync scheduler
+func (s *SyncScheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			s.RunDue(ctx, time.Now())
+		}
+	}
+}
+
+// RunDue runs every order import and inventory push that is due at now
+func (s *SyncScheduler) RunDue(ctx context.Context, now time.Time) []ScheduledSyncResult {
+	results := make([]ScheduledSyncResult, 0)
+
+	if s.config.OrderImportEnabled {
+		results = append(results, s.runDue(ctx, domain.SyncTypeOrders, now, s.service.RunOrderImport)...)
+	}
+	if s.config.InventoryPushEnabled {
+		results = append(results, s.runDue(ctx, domain.SyncTypeInventory, now, s.service.RunInventoryPush)...)
+	}
+
+	return results
+}
+
+func (s *SyncScheduler) runDue(
+	ctx context.Context,
+	syncType domain.SyncType,
+	now time.Time,
+	runSync func(context.Context, string) (*SyncJobDTO, error),
+) []ScheduledSyncResult {
+	// Narrow down in the database, then apply each channel's own interval
+	channels, err := s.channelRepo.FindChannelsNeedingSync(ctx, syncType, s.config.PollInterval)
+	if err != nil {
+		s.logger.Error("Failed to find channels needing sync", "syncType", syncType, "error", err)
+		return nil
+	}
+
+	results := make([]ScheduledSyncResult, 0)
+	for _, channel := range channels {
+		if !channel.IsSyncDue(syncType, now) {
+			continue
+		}
+
+		job, err := runSync(ctx, channel.ChannelID)
+		results = append(results, ScheduledSyncResult{
+			ChannelID: channel.ChannelID,
+			SyncType:  syncType,
+			Job:       job,
+			Err:       err,
+		})
+
+		switch {
+		case errors.Is(err, domain.ErrSyncInProgress), errors.Is(err, domain.ErrChannelNotActive):
+			// Another sync is running or the channel was paused since it was loaded
+			s.logger.Debug("Skipped scheduled sync", "channelId", channel.ChannelID, "syncType", syncType, "reason", err.Error())
+		case err != nil:
+			// Log error but continue with other channels
+			s.logger.Error("Scheduled sync failed", "channelId", channel.ChannelID, "syncType", syncType, "error", err)
+		default:
+			s.logger.Info("Scheduled sync completed",
+				"channelId", channel.ChannelID,
+				"syncType", syncType,
+				"jobId", job.ID,
+				"status", job.Status,
+				"totalItems", job.TotalItems,
+				"failedItems", job.FailedItems,
+			)
+		}
+	}
+	return results
+}
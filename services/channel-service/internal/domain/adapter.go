@@ -2,9 +2,21 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// Errors returned by channel adapters
+var (
+	// ErrChannelUnauthorized is returned when the channel rejects the access token (HTTP 401)
+	ErrChannelUnauthorized = errors.New("channel rejected credentials")
+	// ErrRefreshTokenRejected is returned when the channel no longer accepts the refresh
+	// token; the seller has to reconnect the channel
+	ErrRefreshTokenRejected = errors.New("channel rejected refresh token")
+	// ErrTokenRefreshNotSupported is returned by adapters whose credentials do not expire
+	ErrTokenRefreshNotSupported = errors.New("token refresh not supported")
+)
+
 // ChannelAdapter defines the interface for channel integrations
 type ChannelAdapter interface {
 	// GetType returns the channel type this adapter handles
@@ -31,11 +43,27 @@ type ChannelAdapter interface {
 	// CreateFulfillment creates a fulfillment in the channel
 	CreateFulfillment(ctx context.Context, channel *Channel, fulfillment FulfillmentRequest) error
 
+	// PushShipmentStatus pushes a carrier milestone for a shipment already fulfilled in the channel
+	PushShipmentStatus(ctx context.Context, channel *Channel, externalOrderID string, update ShipmentStatusUpdate) error
+
 	// RegisterWebhooks registers webhooks with the channel
 	RegisterWebhooks(ctx context.Context, channel *Channel, webhookURL string) error
 
 	// ValidateWebhook validates an incoming webhook
 	ValidateWebhook(ctx context.Context, channel *Channel, signature string, body []byte) bool
+
+	// RefreshCredentials exchanges the refresh token for a new access token. It returns
+	// ErrTokenRefreshNotSupported when the channel's credentials do not expire.
+	RefreshCredentials(ctx context.Context, creds ChannelCredentials) (*TokenRefresh, error)
+}
+
+// TokenRefresh holds the tokens issued by a channel's OAuth token endpoint
+type TokenRefresh struct {
+	AccessToken string    `json:"-"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+
+	// RefreshToken is only set when the channel rotates refresh tokens
+	RefreshToken string `json:"-"`
 }
 
 // TrackingInfo represents tracking information to push
@@ -47,6 +75,13 @@ type TrackingInfo struct {
 	NotifyCustomer bool     `json:"notifyCustomer"`
 }
 
+// ShipmentStatusUpdate represents a carrier milestone for a shipment already fulfilled in the channel
+type ShipmentStatusUpdate struct {
+	TrackingNumber string `json:"trackingNumber"`
+	Carrier        string `json:"carrier"`
+	Status         string `json:"status"` // in_transit, out_for_delivery, delivered
+}
+
 // InventoryUpdate represents an inventory update to push
 type InventoryUpdate struct {
 	SKU           string `json:"sku"`
@@ -12,6 +12,7 @@ import (
 // Errors for Channel domain
 var (
 	ErrChannelNotActive     = errors.New("channel is not active")
+	ErrChannelDisconnected  = errors.New("cannot resume disconnected channel")
 	ErrChannelNotFound      = errors.New("channel not found")
 	ErrInvalidChannelType   = errors.New("invalid channel type")
 	ErrOrderAlreadyImported = errors.New("order already imported")
@@ -94,6 +95,9 @@ type Channel struct {
 	LastInventorySync *time.Time `bson:"lastInventorySync,omitempty" json:"lastInventorySync,omitempty"`
 	LastTrackingSync  *time.Time `bson:"lastTrackingSync,omitempty" json:"lastTrackingSync,omitempty"`
 
+	// Available quantity per SKU as last pushed to the channel, used to push only deltas
+	InventoryLevels map[string]int `bson:"inventoryLevels,omitempty" json:"-"`
+
 	// Error tracking
 	LastError     string     `bson:"lastError,omitempty" json:"lastError,omitempty"`
 	LastErrorAt   *time.Time `bson:"lastErrorAt,omitempty" json:"lastErrorAt,omitempty"`
@@ -106,6 +110,7 @@ type Channel struct {
 	// Timestamps
 	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
 	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+	Version   int       `bson:"version" json:"version"` // Incremented on every save for optimistic concurrency
 
 	// Domain events
 	domainEvents []DomainEvent `bson:"-" json:"-"`
@@ -140,6 +145,21 @@ type ChannelCredentials struct {
 	AdditionalConfig map[string]interface{} `bson:"additionalConfig,omitempty" json:"-"`
 }
 
+// SecretFields returns the credential fields that hold secrets, keyed by their stored
+// field name. These are encrypted at rest; store identifiers such as StoreDomain,
+// SellerID or ClientID are not secret and stay queryable.
+func (c *ChannelCredentials) SecretFields() map[string]*string {
+	return map[string]*string{
+		"apiKey":        &c.APIKey,
+		"apiSecret":     &c.APISecret,
+		"accessToken":   &c.AccessToken,
+		"mwsAuthToken":  &c.MWSAuthToken,
+		"clientSecret":  &c.ClientSecret,
+		"refreshToken":  &c.RefreshToken,
+		"webhookSecret": &c.WebhookSecret,
+	}
+}
+
 // SyncSettings defines how syncing works for a channel
 type SyncSettings struct {
 	AutoImportOrders     bool `bson:"autoImportOrders" json:"autoImportOrders"`
@@ -209,7 +229,7 @@ func (c *Channel) Pause() {
 // Resume resumes the channel
 func (c *Channel) Resume() error {
 	if c.Status == ChannelStatusDisconnected {
-		return errors.New("cannot resume disconnected channel")
+		return ErrChannelDisconnected
 	}
 	c.Status = ChannelStatusActive
 	c.UpdatedAt = time.Now().UTC()
@@ -272,6 +292,62 @@ func (c *Channel) UpdateCredentials(creds ChannelCredentials) {
 	c.UpdatedAt = time.Now().UTC()
 }
 
+// NeedsTokenRefresh reports whether the access token is missing or expires within window.
+// Channels without a refresh token, or whose token expiry is unknown, never need one.
+func (c *ChannelCredentials) NeedsTokenRefresh(now time.Time, window time.Duration) bool {
+	if c.RefreshToken == "" {
+		return false
+	}
+	if c.AccessToken == "" {
+		return true
+	}
+	return c.TokenExpiresAt != nil && !now.Add(window).Before(*c.TokenExpiresAt)
+}
+
+// ApplyTokenRefresh stores tokens issued by the channel's token endpoint
+func (c *Channel) ApplyTokenRefresh(refresh TokenRefresh) {
+	now := time.Now().UTC()
+	expiresAt := refresh.ExpiresAt.UTC()
+	rotated := refresh.RefreshToken != "" && refresh.RefreshToken != c.Credentials.RefreshToken
+
+	c.Credentials.AccessToken = refresh.AccessToken
+	c.Credentials.TokenExpiresAt = &expiresAt
+	if rotated {
+		c.Credentials.RefreshToken = refresh.RefreshToken
+	}
+	c.UpdatedAt = now
+
+	c.addDomainEvent(&CredentialsRefreshedEvent{
+		ChannelID:           c.ChannelID,
+		SellerID:            c.SellerID,
+		Type:                c.Type,
+		ExpiresAt:           expiresAt,
+		RefreshTokenRotated: rotated,
+		RefreshedAt:         now,
+	})
+}
+
+// MarkCredentialsExpired puts the channel in error state after the channel rejected its
+// refresh token. It stays there until the seller reconnects with new credentials.
+func (c *Channel) MarkCredentialsExpired(reason string) {
+	now := time.Now().UTC()
+	c.Credentials.AccessToken = ""
+	c.Credentials.TokenExpiresAt = nil
+	c.Status = ChannelStatusError
+	c.LastError = reason
+	c.LastErrorAt = &now
+	c.ErrorCount++
+	c.UpdatedAt = now
+
+	c.addDomainEvent(&CredentialsExpiredEvent{
+		ChannelID: c.ChannelID,
+		SellerID:  c.SellerID,
+		Type:      c.Type,
+		Reason:    reason,
+		ExpiredAt: now,
+	})
+}
+
 // UpdateSyncSettings updates sync settings
 func (c *Channel) UpdateSyncSettings(settings SyncSettings) {
 	c.SyncSettings = settings
@@ -332,8 +408,9 @@ type ChannelOrder struct {
 	FulfillmentStatus string `bson:"fulfillmentStatus" json:"fulfillmentStatus"` // unfulfilled, partial, fulfilled
 
 	// Fulfillment tracking
-	TrackingPushed   bool       `bson:"trackingPushed" json:"trackingPushed"`
-	TrackingPushedAt *time.Time `bson:"trackingPushedAt,omitempty" json:"trackingPushedAt,omitempty"`
+	TrackingPushed    bool       `bson:"trackingPushed" json:"trackingPushed"`
+	TrackingPushedAt  *time.Time `bson:"trackingPushedAt,omitempty" json:"trackingPushedAt,omitempty"`
+	TrackingMilestone string     `bson:"trackingMilestone,omitempty" json:"trackingMilestone,omitempty"` // Last carrier milestone pushed
 
 	// Metadata
 	Tags     []string               `bson:"tags,omitempty" json:"tags,omitempty"`
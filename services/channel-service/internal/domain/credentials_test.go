@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedsTokenRefresh(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	soon := now.Add(2 * time.Minute)
+	later := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		creds    ChannelCredentials
+		expected bool
+	}{
+		{"no refresh token", ChannelCredentials{AccessToken: "token", TokenExpiresAt: &soon}, false},
+		{"no access token yet", ChannelCredentials{RefreshToken: "refresh"}, true},
+		{"unknown expiry", ChannelCredentials{RefreshToken: "refresh", AccessToken: "token"}, false},
+		{"expires within window", ChannelCredentials{RefreshToken: "refresh", AccessToken: "token", TokenExpiresAt: &soon}, true},
+		{"valid beyond window", ChannelCredentials{RefreshToken: "refresh", AccessToken: "token", TokenExpiresAt: &later}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.creds.NeedsTokenRefresh(now, 5*time.Minute))
+		})
+	}
+}
+
+func TestChannel_ApplyTokenRefresh(t *testing.T) {
+	channel, err := NewChannel("TNT-001", "SLR-001", ChannelTypeEbay, "eBay", "",
+		ChannelCredentials{RefreshToken: "refresh-1", AccessToken: "old"}, SyncSettings{})
+	require.NoError(t, err)
+	channel.ClearDomainEvents()
+
+	expiresAt := time.Now().Add(2 * time.Hour)
+	channel.ApplyTokenRefresh(TokenRefresh{AccessToken: "new", ExpiresAt: expiresAt})
+
+	assert.Equal(t, "new", channel.Credentials.AccessToken)
+	assert.Equal(t, "refresh-1", channel.Credentials.RefreshToken)
+	require.NotNil(t, channel.Credentials.TokenExpiresAt)
+	assert.True(t, channel.Credentials.TokenExpiresAt.Equal(expiresAt))
+
+	require.Len(t, channel.DomainEvents(), 1)
+	event, ok := channel.DomainEvents()[0].(*CredentialsRefreshedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "channel.credentials.refreshed", event.EventType())
+	assert.False(t, event.RefreshTokenRotated)
+
+	channel.ApplyTokenRefresh(TokenRefresh{AccessToken: "newer", RefreshToken: "refresh-2", ExpiresAt: expiresAt})
+	assert.Equal(t, "refresh-2", channel.Credentials.RefreshToken)
+	assert.True(t, channel.DomainEvents()[1].(*CredentialsRefreshedEvent).RefreshTokenRotated)
+}
+
+func TestChannel_MarkCredentialsExpired(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	channel, err := NewChannel("TNT-001", "SLR-001", ChannelTypeAmazon, "Amazon", "",
+		ChannelCredentials{RefreshToken: "refresh", AccessToken: "token", TokenExpiresAt: &expiresAt}, SyncSettings{})
+	require.NoError(t, err)
+	channel.ClearDomainEvents()
+
+	channel.MarkCredentialsExpired("refresh token rejected")
+
+	assert.Equal(t, ChannelStatusError, channel.Status)
+	assert.Equal(t, "refresh token rejected", channel.LastError)
+	assert.Empty(t, channel.Credentials.AccessToken)
+	assert.Nil(t, channel.Credentials.TokenExpiresAt)
+
+	require.Len(t, channel.DomainEvents(), 1)
+	event, ok := channel.DomainEvents()[0].(*CredentialsExpiredEvent)
+	require.True(t, ok)
+	assert.Equal(t, "channel.credentials.expired", event.EventType())
+	assert.Equal(t, channel.ChannelID, event.ChannelID)
+}
@@ -45,6 +45,10 @@ func (f *fakeAdapter) CreateFulfillment(context.Context, *Channel, FulfillmentRe
 	return nil
 }
 
+func (f *fakeAdapter) PushShipmentStatus(context.Context, *Channel, string, ShipmentStatusUpdate) error {
+	return nil
+}
+
 func (f *fakeAdapter) RegisterWebhooks(context.Context, *Channel, string) error {
 	return nil
 }
@@ -53,6 +57,10 @@ func (f *fakeAdapter) ValidateWebhook(context.Context, *Channel, string, []byte)
 	return true
 }
 
+func (f *fakeAdapter) RefreshCredentials(context.Context, ChannelCredentials) (*TokenRefresh, error) {
+	return nil, ErrTokenRefreshNotSupported
+}
+
 func TestAdapterFactory(t *testing.T) {
 	factory := NewAdapterFactory()
 	adapter := &fakeAdapter{channelType: ChannelTypeShopify}
@@ -0,0 +1,689 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Errors for the EDI gateway
+var (
+	ErrTradingPartnerNotFound      = errors.New("trading partner not found")
+	ErrInvalidTradingPartner       = errors.New("invalid trading partner")
+	ErrTradingPartnerInactive      = errors.New("trading partner is not active")
+	ErrInvalidInterchange          = errors.New("invalid X12 interchange")
+	ErrDuplicateInterchange        = errors.New("interchange already received")
+	ErrUnsupportedEDIDocument      = errors.New("unsupported EDI document type")
+	ErrEDIDocumentNotFound         = errors.New("EDI document not found")
+	ErrEDIDocumentNotRetryable     = errors.New("only failed or unapplied inbound EDI documents can be retried")
+	ErrEDIDocumentNotTransmittable = errors.New("only generated outbound EDI documents can be marked sent")
+)
+
+// maxControlNumber is the largest value that fits the nine-digit ISA13 and GS06 control numbers
+const maxControlNumber = 999999999
+
+// OutboundTransactionControlNumber is ST02 of outbound transaction sets. Every outbound
+// interchange carries a single transaction set, so the interchange and group control
+// numbers identify the document.
+const OutboundTransactionControlNumber = "0001"
+
+// EDIDocumentType is the X12 transaction set identifier (ST01)
+type EDIDocumentType string
+
+const (
+	X12PurchaseOrder           EDIDocumentType = "850"
+	X12WarehouseShippingOrder  EDIDocumentType = "940"
+	X12ShipNotice              EDIDocumentType = "856"
+	X12WarehouseShippingAdvice EDIDocumentType = "945"
+	X12FunctionalAck           EDIDocumentType = "997"
+)
+
+// FunctionalID returns the GS01 functional identifier code for groups of this transaction set
+func (t EDIDocumentType) FunctionalID() string {
+	switch t {
+	case X12PurchaseOrder:
+		return "PO"
+	case X12WarehouseShippingOrder:
+		return "OW"
+	case X12ShipNotice:
+		return "SH"
+	case X12WarehouseShippingAdvice:
+		return "SW"
+	case X12FunctionalAck:
+		return "FA"
+	}
+	return ""
+}
+
+// IsShipmentDocument reports whether the type can be sent to a partner when an order ships
+func (t EDIDocumentType) IsShipmentDocument() bool {
+	return t == X12WarehouseShippingAdvice || t == X12ShipNotice
+}
+
+// EDIDirection is whether a document was received from or sent to a trading partner
+type EDIDirection string
+
+const (
+	EDIDirectionInbound  EDIDirection = "inbound"
+	EDIDirectionOutbound EDIDirection = "outbound"
+)
+
+// EDIDocumentStatus represents where a document is in its lifecycle
+type EDIDocumentStatus string
+
+const (
+	EDIStatusReceived  EDIDocumentStatus = "received"
+	EDIStatusProcessed EDIDocumentStatus = "processed"
+	EDIStatusFailed    EDIDocumentStatus = "failed"   // WMS could not apply the document; it can be retried
+	EDIStatusRejected  EDIDocumentStatus = "rejected" // the document could not be translated
+	EDIStatusGenerated EDIDocumentStatus = "generated"
+	EDIStatusSent      EDIDocumentStatus = "sent"
+)
+
+// EDIAckStatus is the functional acknowledgement state of an outbound document
+type EDIAckStatus string
+
+const (
+	EDIAckPending            EDIAckStatus = "pending"
+	EDIAckAccepted           EDIAckStatus = "accepted"
+	EDIAckAcceptedWithErrors EDIAckStatus = "accepted_with_errors"
+	EDIAckRejected           EDIAckStatus = "rejected"
+)
+
+// AckStatusFromCode maps an AK5/AK9 acknowledgement code to an EDIAckStatus
+func AckStatusFromCode(code string) EDIAckStatus {
+	switch code {
+	case "A":
+		return EDIAckAccepted
+	case "E", "P":
+		return EDIAckAcceptedWithErrors
+	case "R", "M", "W", "X":
+		return EDIAckRejected
+	}
+	return EDIAckPending
+}
+
+// Code returns the AK5/AK9 code for the acknowledgement status
+func (s EDIAckStatus) Code() string {
+	switch s {
+	case EDIAckAccepted:
+		return "A"
+	case EDIAckAcceptedWithErrors:
+		return "E"
+	case EDIAckRejected:
+		return "R"
+	}
+	return ""
+}
+
+// EnvelopeSettings are the ISA and GS envelope values agreed with a trading partner.
+// Partner values identify the partner as sender of inbound interchanges and as receiver
+// of outbound ones; warehouse values identify us.
+type EnvelopeSettings struct {
+	PartnerQualifier         string `bson:"partnerQualifier" json:"partnerQualifier"`
+	PartnerID                string `bson:"partnerId" json:"partnerId"`
+	WarehouseQualifier       string `bson:"warehouseQualifier" json:"warehouseQualifier"`
+	WarehouseID              string `bson:"warehouseId" json:"warehouseId"`
+	PartnerApplicationCode   string `bson:"partnerApplicationCode" json:"partnerApplicationCode"`
+	WarehouseApplicationCode string `bson:"warehouseApplicationCode" json:"warehouseApplicationCode"`
+
+	// InterchangeVersion is ISA12, GroupVersion is GS08
+	InterchangeVersion string `bson:"interchangeVersion" json:"interchangeVersion"`
+	GroupVersion       string `bson:"groupVersion" json:"groupVersion"`
+	// UsageIndicator is ISA15: P for production, T for test
+	UsageIndicator string `bson:"usageIndicator" json:"usageIndicator"`
+
+	ElementSeparator   string `bson:"elementSeparator" json:"elementSeparator"`
+	ComponentSeparator string `bson:"componentSeparator" json:"componentSeparator"`
+	SegmentTerminator  string `bson:"segmentTerminator" json:"segmentTerminator"`
+
+	// SendFunctionalAcks returns a 997 for every functional group received from the partner
+	SendFunctionalAcks bool `bson:"sendFunctionalAcks" json:"sendFunctionalAcks"`
+}
+
+// WithDefaults fills in the X12 4010 defaults for settings the partner did not specify
+func (e EnvelopeSettings) WithDefaults() EnvelopeSettings {
+	if e.PartnerApplicationCode == "" {
+		e.PartnerApplicationCode = e.PartnerID
+	}
+	if e.WarehouseApplicationCode == "" {
+		e.WarehouseApplicationCode = e.WarehouseID
+	}
+	if e.InterchangeVersion == "" {
+		e.InterchangeVersion = "00401"
+	}
+	if e.GroupVersion == "" {
+		e.GroupVersion = "004010"
+	}
+	if e.UsageIndicator == "" {
+		e.UsageIndicator = "P"
+	}
+	if e.ElementSeparator == "" {
+		e.ElementSeparator = "*"
+	}
+	if e.ComponentSeparator == "" {
+		e.ComponentSeparator = ">"
+	}
+	if e.SegmentTerminator == "" {
+		e.SegmentTerminator = "~"
+	}
+	return e
+}
+
+// Validate checks the envelope values fit their ISA and GS elements
+func (e EnvelopeSettings) Validate() error {
+	for name, qualifier := range map[string]string{"partner": e.PartnerQualifier, "warehouse": e.WarehouseQualifier} {
+		if len(qualifier) != 2 {
+			return fmt.Errorf("%w: %s qualifier must be 2 characters", ErrInvalidTradingPartner, name)
+		}
+	}
+	for name, id := range map[string]string{"partner": e.PartnerID, "warehouse": e.WarehouseID} {
+		if id == "" || len(id) > 15 {
+			return fmt.Errorf("%w: %s interchange ID must be 1 to 15 characters", ErrInvalidTradingPartner, name)
+		}
+	}
+	for name, code := range map[string]string{"partner": e.PartnerApplicationCode, "warehouse": e.WarehouseApplicationCode} {
+		if len(code) < 2 || len(code) > 15 {
+			return fmt.Errorf("%w: %s application code must be 2 to 15 characters", ErrInvalidTradingPartner, name)
+		}
+	}
+	if e.UsageIndicator != "P" && e.UsageIndicator != "T" {
+		return fmt.Errorf("%w: usage indicator must be P or T", ErrInvalidTradingPartner)
+	}
+	if len(e.InterchangeVersion) != 5 {
+		return fmt.Errorf("%w: interchange version must be 5 characters", ErrInvalidTradingPartner)
+	}
+
+	separators := []string{e.ElementSeparator, e.ComponentSeparator, e.SegmentTerminator}
+	for i, sep := range separators {
+		if len(sep) != 1 || strings.ContainsAny(sep, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 ") {
+			return fmt.Errorf("%w: separators must be a single non-alphanumeric character", ErrInvalidTradingPartner)
+		}
+		for _, other := range separators[i+1:] {
+			if sep == other {
+				return fmt.Errorf("%w: element, component and segment separators must differ", ErrInvalidTradingPartner)
+			}
+		}
+	}
+	return nil
+}
+
+// TradingPartner is a B2B seller or customer that exchanges X12 EDI with the warehouse
+type TradingPartner struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	PartnerID   string             `bson:"partnerId" json:"partnerId"`
+	TenantID    string             `bson:"tenantId" json:"tenantId"`
+	SellerID    string             `bson:"sellerId" json:"sellerId"`
+	FacilityID  string             `bson:"facilityId" json:"facilityId"`
+	WarehouseID string             `bson:"warehouseId" json:"warehouseId"`
+	Name        string             `bson:"name" json:"name"`
+
+	Envelope EnvelopeSettings `bson:"envelope" json:"envelope"`
+
+	// OutboundDocuments are the documents sent to the partner when one of its orders ships (945, 856)
+	OutboundDocuments []EDIDocumentType `bson:"outboundDocuments" json:"outboundDocuments"`
+
+	Active    bool      `bson:"active" json:"active"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// NewTradingPartner creates a new active TradingPartner
+func NewTradingPartner(
+	tenantID, sellerID, facilityID, warehouseID, name string,
+	envelope EnvelopeSettings,
+	outboundDocuments []EDIDocumentType,
+) (*TradingPartner, error) {
+	now := time.Now().UTC()
+	partner := &TradingPartner{
+		ID:                primitive.NewObjectID(),
+		PartnerID:         fmt.Sprintf("TP-%s", uuid.New().String()[:8]),
+		TenantID:          tenantID,
+		SellerID:          sellerID,
+		FacilityID:        facilityID,
+		WarehouseID:       warehouseID,
+		Name:              name,
+		Envelope:          envelope.WithDefaults(),
+		OutboundDocuments: outboundDocuments,
+		Active:            true,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := partner.Validate(); err != nil {
+		return nil, err
+	}
+	return partner, nil
+}
+
+// Validate checks the partner has the WMS scope and envelope settings EDI exchange needs
+func (p *TradingPartner) Validate() error {
+	if p.TenantID == "" || p.SellerID == "" {
+		return fmt.Errorf("%w: tenant and seller are required", ErrInvalidTradingPartner)
+	}
+	if p.FacilityID == "" || p.WarehouseID == "" {
+		return fmt.Errorf("%w: facility and warehouse are required", ErrInvalidTradingPartner)
+	}
+	if p.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTradingPartner)
+	}
+	for _, docType := range p.OutboundDocuments {
+		if !docType.IsShipmentDocument() {
+			return fmt.Errorf("%w: %s cannot be sent on shipment", ErrInvalidTradingPartner, docType)
+		}
+	}
+	return p.Envelope.Validate()
+}
+
+// Update replaces the partner's name, envelope settings and shipment documents
+func (p *TradingPartner) Update(name string, envelope EnvelopeSettings, outboundDocuments []EDIDocumentType, active bool) error {
+	updated := *p
+	updated.Name = name
+	updated.Envelope = envelope.WithDefaults()
+	updated.OutboundDocuments = outboundDocuments
+	updated.Active = active
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	updated.UpdatedAt = time.Now().UTC()
+	*p = updated
+	return nil
+}
+
+// ControlNumbers are the interchange (ISA13) and group (GS06) control numbers for one outbound interchange
+type ControlNumbers struct {
+	Interchange int64 `bson:"interchange" json:"interchange"`
+	Group       int64 `bson:"group" json:"group"`
+}
+
+// InterchangeControlNumber returns ISA13, zero padded to nine digits
+func (c ControlNumbers) InterchangeControlNumber() string {
+	return fmt.Sprintf("%09d", wrapControlNumber(c.Interchange))
+}
+
+// GroupControlNumber returns GS06
+func (c ControlNumbers) GroupControlNumber() string {
+	return fmt.Sprintf("%d", wrapControlNumber(c.Group))
+}
+
+// wrapControlNumber keeps an ever increasing sequence within 1..999999999
+func wrapControlNumber(n int64) int64 {
+	if n < 1 {
+		return 1
+	}
+	return (n-1)%maxControlNumber + 1
+}
+
+// EDIInterchange is a decoded ISA/IEA interchange
+type EDIInterchange struct {
+	SenderQualifier   string
+	SenderID          string
+	ReceiverQualifier string
+	ReceiverID        string
+	ControlNumber     string
+	UsageIndicator    string
+	Groups            []EDIGroup
+}
+
+// EDIGroup is a decoded GS/GE functional group
+type EDIGroup struct {
+	FunctionalID  string
+	SenderCode    string
+	ReceiverCode  string
+	ControlNumber string
+	Version       string
+	Transactions  []EDITransaction
+}
+
+// EDITransaction is one X12 transaction set translated to or from its WMS document.
+// Exactly one of the document fields is set; Errors holds translation errors for
+// inbound transactions that could not be read.
+type EDITransaction struct {
+	Type          EDIDocumentType
+	ControlNumber string
+
+	Order           *EDIOrder
+	ShipNotice      *EDIShipNotice
+	Acknowledgement *EDIAcknowledgement
+	Confirmation    *EDIShipmentConfirmation
+
+	Errors []string
+}
+
+// EDIParty is a name and address from an N1 loop
+type EDIParty struct {
+	Name       string `bson:"name" json:"name"`
+	Identifier string `bson:"identifier,omitempty" json:"identifier,omitempty"`
+	Address1   string `bson:"address1" json:"address1"`
+	Address2   string `bson:"address2,omitempty" json:"address2,omitempty"`
+	City       string `bson:"city" json:"city"`
+	State      string `bson:"state" json:"state"`
+	PostalCode string `bson:"postalCode" json:"postalCode"`
+	Country    string `bson:"country" json:"country"`
+}
+
+// EDIOrder is an order received as an 850 purchase order or a 940 warehouse shipping order
+type EDIOrder struct {
+	PurchaseOrderNumber  string         `bson:"purchaseOrderNumber" json:"purchaseOrderNumber"`
+	DepositorOrderNumber string         `bson:"depositorOrderNumber,omitempty" json:"depositorOrderNumber,omitempty"`
+	OrderDate            *time.Time     `bson:"orderDate,omitempty" json:"orderDate,omitempty"`
+	RequestedShipDate    *time.Time     `bson:"requestedShipDate,omitempty" json:"requestedShipDate,omitempty"`
+	CarrierSCAC          string         `bson:"carrierScac,omitempty" json:"carrierScac,omitempty"`
+	ShipTo               EDIParty       `bson:"shipTo" json:"shipTo"`
+	Lines                []EDIOrderLine `bson:"lines" json:"lines"`
+}
+
+// EDIOrderLine is a PO1 or W01 order line
+type EDIOrderLine struct {
+	LineNumber    string  `bson:"lineNumber" json:"lineNumber"`
+	SKU           string  `bson:"sku" json:"sku"`
+	UPC           string  `bson:"upc,omitempty" json:"upc,omitempty"`
+	Description   string  `bson:"description,omitempty" json:"description,omitempty"`
+	Quantity      int     `bson:"quantity" json:"quantity"`
+	UnitOfMeasure string  `bson:"unitOfMeasure" json:"unitOfMeasure"`
+	UnitPrice     float64 `bson:"unitPrice,omitempty" json:"unitPrice,omitempty"`
+}
+
+// EDIShipNotice is an inbound 856 advance ship notice for goods on their way to the warehouse
+type EDIShipNotice struct {
+	ShipmentID          string              `bson:"shipmentId" json:"shipmentId"`
+	ShipDate            *time.Time          `bson:"shipDate,omitempty" json:"shipDate,omitempty"`
+	ExpectedArrival     *time.Time          `bson:"expectedArrival,omitempty" json:"expectedArrival,omitempty"`
+	CarrierSCAC         string              `bson:"carrierScac,omitempty" json:"carrierScac,omitempty"`
+	CarrierName         string              `bson:"carrierName,omitempty" json:"carrierName,omitempty"`
+	TrackingNumber      string              `bson:"trackingNumber,omitempty" json:"trackingNumber,omitempty"`
+	BillOfLading        string              `bson:"billOfLading,omitempty" json:"billOfLading,omitempty"`
+	PurchaseOrderNumber string              `bson:"purchaseOrderNumber,omitempty" json:"purchaseOrderNumber,omitempty"`
+	ShipFrom            EDIParty            `bson:"shipFrom" json:"shipFrom"`
+	CartonCount         int                 `bson:"cartonCount,omitempty" json:"cartonCount,omitempty"`
+	TotalWeight         float64             `bson:"totalWeight,omitempty" json:"totalWeight,omitempty"`
+	Lines               []EDIShipNoticeLine `bson:"lines" json:"lines"`
+}
+
+// EDIShipNoticeLine is an item-level LIN/SN1 pair of an 856
+type EDIShipNoticeLine struct {
+	SKU           string `bson:"sku" json:"sku"`
+	UPC           string `bson:"upc,omitempty" json:"upc,omitempty"`
+	Description   string `bson:"description,omitempty" json:"description,omitempty"`
+	Quantity      int    `bson:"quantity" json:"quantity"`
+	UnitOfMeasure string `bson:"unitOfMeasure" json:"unitOfMeasure"`
+	LotNumber     string `bson:"lotNumber,omitempty" json:"lotNumber,omitempty"`
+}
+
+// EDIAcknowledgement is a 997 functional acknowledgement of one functional group
+type EDIAcknowledgement struct {
+	FunctionalID       string              `bson:"functionalId" json:"functionalId"`
+	GroupControlNumber string              `bson:"groupControlNumber" json:"groupControlNumber"`
+	Status             EDIAckStatus        `bson:"status" json:"status"`
+	Transactions       []EDITransactionAck `bson:"transactions" json:"transactions"`
+}
+
+// EDITransactionAck is the AK2/AK5 acknowledgement of one transaction set
+type EDITransactionAck struct {
+	Type          EDIDocumentType `bson:"type" json:"type"`
+	ControlNumber string          `bson:"controlNumber" json:"controlNumber"`
+	Status        EDIAckStatus    `bson:"status" json:"status"`
+	Errors        []string        `bson:"errors,omitempty" json:"errors,omitempty"`
+}
+
+// NewGroupAcknowledgement builds the 997 for a received functional group. Transactions
+// with translation errors are rejected; the group is rejected when none were accepted.
+func NewGroupAcknowledgement(group EDIGroup) *EDIAcknowledgement {
+	ack := &EDIAcknowledgement{
+		FunctionalID:       group.FunctionalID,
+		GroupControlNumber: group.ControlNumber,
+		Transactions:       make([]EDITransactionAck, 0, len(group.Transactions)),
+	}
+
+	accepted := 0
+	for _, txn := range group.Transactions {
+		status := EDIAckAccepted
+		if len(txn.Errors) > 0 {
+			status = EDIAckRejected
+		} else {
+			accepted++
+		}
+		ack.Transactions = append(ack.Transactions, EDITransactionAck{
+			Type:          txn.Type,
+			ControlNumber: txn.ControlNumber,
+			Status:        status,
+			Errors:        txn.Errors,
+		})
+	}
+
+	switch {
+	case accepted == len(group.Transactions):
+		ack.Status = EDIAckAccepted
+	case accepted == 0:
+		ack.Status = EDIAckRejected
+	default:
+		ack.Status = EDIAckAcceptedWithErrors
+	}
+	return ack
+}
+
+// EDIShipmentConfirmation is the content of an outbound 945 shipping advice or 856 ship notice
+type EDIShipmentConfirmation struct {
+	WMSOrderID           string         `bson:"wmsOrderId" json:"wmsOrderId"`
+	ShipmentID           string         `bson:"shipmentId" json:"shipmentId"`
+	PurchaseOrderNumber  string         `bson:"purchaseOrderNumber" json:"purchaseOrderNumber"`
+	DepositorOrderNumber string         `bson:"depositorOrderNumber,omitempty" json:"depositorOrderNumber,omitempty"`
+	TrackingNumber       string         `bson:"trackingNumber" json:"trackingNumber"`
+	CarrierSCAC          string         `bson:"carrierScac" json:"carrierScac"`
+	ShippedAt            time.Time      `bson:"shippedAt" json:"shippedAt"`
+	WeightKg             float64        `bson:"weightKg,omitempty" json:"weightKg,omitempty"`
+	ShipTo               EDIParty       `bson:"shipTo" json:"shipTo"`
+	Lines                []EDIOrderLine `bson:"lines" json:"lines"`
+}
+
+// EDIDocument is the log entry for one transaction set exchanged with a trading partner
+type EDIDocument struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	DocumentID string             `bson:"documentId" json:"documentId"`
+	TenantID   string             `bson:"tenantId" json:"tenantId"`
+	PartnerID  string             `bson:"partnerId" json:"partnerId"`
+
+	Direction EDIDirection      `bson:"direction" json:"direction"`
+	Type      EDIDocumentType   `bson:"type" json:"type"`
+	Status    EDIDocumentStatus `bson:"status" json:"status"`
+
+	// Envelope control numbers
+	InterchangeControlNumber string `bson:"interchangeControlNumber" json:"interchangeControlNumber"`
+	GroupControlNumber       string `bson:"groupControlNumber" json:"groupControlNumber"`
+	TransactionControlNumber string `bson:"transactionControlNumber" json:"transactionControlNumber"`
+
+	// Reference is the PO number, ship notice ID or acknowledged group control number
+	Reference         string `bson:"reference,omitempty" json:"reference,omitempty"`
+	WMSOrderID        string `bson:"wmsOrderId,omitempty" json:"wmsOrderId,omitempty"`
+	InboundShipmentID string `bson:"inboundShipmentId,omitempty" json:"inboundShipmentId,omitempty"`
+
+	// Translated content, one per document type
+	Order           *EDIOrder                `bson:"order,omitempty" json:"order,omitempty"`
+	ShipNotice      *EDIShipNotice           `bson:"shipNotice,omitempty" json:"shipNotice,omitempty"`
+	Acknowledgement *EDIAcknowledgement      `bson:"acknowledgement,omitempty" json:"acknowledgement,omitempty"`
+	Confirmation    *EDIShipmentConfirmation `bson:"confirmation,omitempty" json:"confirmation,omitempty"`
+
+	// Payload is the X12 interchange of an outbound document
+	Payload string `bson:"payload,omitempty" json:"-"`
+
+	// AckStatus tracks the partner's 997 for outbound 945 and 856 documents
+	AckStatus      EDIAckStatus `bson:"ackStatus,omitempty" json:"ackStatus,omitempty"`
+	AcknowledgedAt *time.Time   `bson:"acknowledgedAt,omitempty" json:"acknowledgedAt,omitempty"`
+
+	Errors    []string   `bson:"errors,omitempty" json:"errors,omitempty"`
+	SentAt    *time.Time `bson:"sentAt,omitempty" json:"sentAt,omitempty"`
+	CreatedAt time.Time  `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time  `bson:"updatedAt" json:"updatedAt"`
+}
+
+// NewInboundEDIDocument logs a transaction set received from a partner. Transactions that
+// failed translation are logged as rejected.
+func NewInboundEDIDocument(partner *TradingPartner, interchange *EDIInterchange, group EDIGroup, txn EDITransaction) *EDIDocument {
+	doc := newEDIDocument(partner, EDIDirectionInbound, txn)
+	doc.InterchangeControlNumber = interchange.ControlNumber
+	doc.GroupControlNumber = group.ControlNumber
+	doc.TransactionControlNumber = txn.ControlNumber
+	doc.Status = EDIStatusReceived
+	if len(txn.Errors) > 0 {
+		doc.Status = EDIStatusRejected
+		doc.Errors = txn.Errors
+	}
+	return doc
+}
+
+// NewOutboundEDIDocument logs a transaction set generated for a partner. 945 and 856
+// documents wait for the partner's 997; acknowledgements themselves are not acknowledged.
+func NewOutboundEDIDocument(partner *TradingPartner, controls ControlNumbers, txn EDITransaction, payload []byte) *EDIDocument {
+	doc := newEDIDocument(partner, EDIDirectionOutbound, txn)
+	doc.InterchangeControlNumber = controls.InterchangeControlNumber()
+	doc.GroupControlNumber = controls.GroupControlNumber()
+	doc.TransactionControlNumber = OutboundTransactionControlNumber
+	doc.Status = EDIStatusGenerated
+	doc.Payload = string(payload)
+	if txn.Type != X12FunctionalAck {
+		doc.AckStatus = EDIAckPending
+	}
+	return doc
+}
+
+func newEDIDocument(partner *TradingPartner, direction EDIDirection, txn EDITransaction) *EDIDocument {
+	now := time.Now().UTC()
+	doc := &EDIDocument{
+		ID:              primitive.NewObjectID(),
+		DocumentID:      fmt.Sprintf("EDI-%s", uuid.New().String()[:8]),
+		TenantID:        partner.TenantID,
+		PartnerID:       partner.PartnerID,
+		Direction:       direction,
+		Type:            txn.Type,
+		Order:           txn.Order,
+		ShipNotice:      txn.ShipNotice,
+		Acknowledgement: txn.Acknowledgement,
+		Confirmation:    txn.Confirmation,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	switch {
+	case txn.Order != nil:
+		doc.Reference = txn.Order.PurchaseOrderNumber
+	case txn.ShipNotice != nil:
+		doc.Reference = txn.ShipNotice.ShipmentID
+	case txn.Acknowledgement != nil:
+		doc.Reference = txn.Acknowledgement.GroupControlNumber
+	case txn.Confirmation != nil:
+		doc.Reference = txn.Confirmation.PurchaseOrderNumber
+		doc.WMSOrderID = txn.Confirmation.WMSOrderID
+	}
+	return doc
+}
+
+// MarkOrderCreated records the WMS order created from an inbound 850 or 940
+func (d *EDIDocument) MarkOrderCreated(wmsOrderID string) {
+	d.WMSOrderID = wmsOrderID
+	d.markProcessed()
+}
+
+// MarkShipmentCreated records the receiving-service inbound shipment created from an inbound 856
+func (d *EDIDocument) MarkShipmentCreated(shipmentID string) {
+	d.InboundShipmentID = shipmentID
+	d.markProcessed()
+}
+
+// MarkAcknowledgementApplied records that an inbound 997 was matched to the documents it acknowledges
+func (d *EDIDocument) MarkAcknowledgementApplied() {
+	d.markProcessed()
+}
+
+func (d *EDIDocument) markProcessed() {
+	d.Status = EDIStatusProcessed
+	d.Errors = nil
+	d.UpdatedAt = time.Now().UTC()
+}
+
+// MarkFailed records why WMS could not apply an inbound document
+func (d *EDIDocument) MarkFailed(reason string) {
+	d.Status = EDIStatusFailed
+	d.Errors = []string{reason}
+	d.UpdatedAt = time.Now().UTC()
+}
+
+// CanRetry reports whether the document can be applied again. Documents still received were
+// logged but never applied, e.g. because the service stopped mid-interchange.
+func (d *EDIDocument) CanRetry() bool {
+	return d.Direction == EDIDirectionInbound && (d.Status == EDIStatusFailed || d.Status == EDIStatusReceived)
+}
+
+// IdempotencyKey identifies the transaction set across resubmissions and retries, so the
+// WMS service it is applied to creates its order only once
+func (d *EDIDocument) IdempotencyKey() string {
+	return strings.Join([]string{"edi", d.PartnerID, d.InterchangeControlNumber, d.GroupControlNumber, d.TransactionControlNumber}, "-")
+}
+
+// MarkSent records that an outbound document was transmitted to the partner
+func (d *EDIDocument) MarkSent() error {
+	if d.Direction != EDIDirectionOutbound || d.Status != EDIStatusGenerated {
+		return ErrEDIDocumentNotTransmittable
+	}
+	now := time.Now().UTC()
+	d.Status = EDIStatusSent
+	d.SentAt = &now
+	d.UpdatedAt = now
+	return nil
+}
+
+// ApplyAcknowledgement records the partner's 997 response for this outbound document
+func (d *EDIDocument) ApplyAcknowledgement(ack EDITransactionAck) {
+	now := time.Now().UTC()
+	d.AckStatus = ack.Status
+	d.AcknowledgedAt = &now
+	d.Errors = ack.Errors
+	d.UpdatedAt = now
+}
+
+// NewShipmentConfirmation builds the 945/856 content for an EDI order that shipped.
+// Shipping confirms whole orders, so every ordered line is reported as shipped.
+func NewShipmentConfirmation(order *EDIOrder, wmsOrderID, shipmentID, trackingNumber, carrier string, shippedAt time.Time, weightKg float64) *EDIShipmentConfirmation {
+	scac := order.CarrierSCAC
+	if carrier != "" {
+		scac = strings.ToUpper(carrier)
+	}
+	return &EDIShipmentConfirmation{
+		WMSOrderID:           wmsOrderID,
+		ShipmentID:           shipmentID,
+		PurchaseOrderNumber:  order.PurchaseOrderNumber,
+		DepositorOrderNumber: order.DepositorOrderNumber,
+		TrackingNumber:       trackingNumber,
+		CarrierSCAC:          scac,
+		ShippedAt:            shippedAt.UTC(),
+		WeightKg:             weightKg,
+		ShipTo:               order.ShipTo,
+		Lines:                order.Lines,
+	}
+}
+
+// EDICodec reads and writes X12 interchanges
+type EDICodec interface {
+	// Decode reads an interchange and translates each transaction set it contains
+	Decode(data []byte) (*EDIInterchange, error)
+
+	// Encode writes one transaction set to the partner as a complete interchange
+	Encode(partner *TradingPartner, controls ControlNumbers, txn EDITransaction, at time.Time) ([]byte, error)
+}
+
+// EDIOrderCreator creates WMS orders for orders received over EDI
+type EDIOrderCreator interface {
+	// CreateEDIOrder creates the WMS order for an inbound 850 or 940 document and returns its ID.
+	// Creating the order again for the same document returns the order already created.
+	CreateEDIOrder(ctx context.Context, partner *TradingPartner, doc *EDIDocument) (string, error)
+}
+
+// InboundShipmentCreator creates receiving-service inbound shipments for EDI ship notices
+type InboundShipmentCreator interface {
+	// CreateInboundShipment creates the inbound shipment with its ASN and returns the shipment ID
+	CreateInboundShipment(ctx context.Context, partner *TradingPartner, notice *EDIShipNotice) (string, error)
+}
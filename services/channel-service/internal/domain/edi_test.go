@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validEnvelope() EnvelopeSettings {
+	return EnvelopeSettings{
+		PartnerQualifier:   "ZZ",
+		PartnerID:          "ACMEB2B",
+		WarehouseQualifier: "ZZ",
+		WarehouseID:        "WMSWAREHOUSE",
+	}
+}
+
+func TestNewTradingPartner(t *testing.T) {
+	partner, err := NewTradingPartner("TNT-001", "SLR-001", "FAC-1", "WH-1", "Acme B2B", validEnvelope(), []EDIDocumentType{X12WarehouseShippingAdvice})
+	require.NoError(t, err)
+
+	assert.Contains(t, partner.PartnerID, "TP-")
+	assert.True(t, partner.Active)
+	assert.Equal(t, "ACMEB2B", partner.Envelope.PartnerApplicationCode)
+	assert.Equal(t, "00401", partner.Envelope.InterchangeVersion)
+	assert.Equal(t, "~", partner.Envelope.SegmentTerminator)
+}
+
+func TestTradingPartnerValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutate   func(*EnvelopeSettings)
+		docs     []EDIDocumentType
+		facility string
+	}{
+		{"missing facility", func(*EnvelopeSettings) {}, nil, ""},
+		{"inbound document on shipment", func(*EnvelopeSettings) {}, []EDIDocumentType{X12PurchaseOrder}, "FAC-1"},
+		{"long qualifier", func(e *EnvelopeSettings) { e.PartnerQualifier = "ZZZ" }, nil, "FAC-1"},
+		{"long interchange ID", func(e *EnvelopeSettings) { e.WarehouseID = "WAREHOUSE-ID-TOO-LONG" }, nil, "FAC-1"},
+		{"bad usage indicator", func(e *EnvelopeSettings) { e.UsageIndicator = "X" }, nil, "FAC-1"},
+		{"alphanumeric separator", func(e *EnvelopeSettings) { e.ElementSeparator = "E" }, nil, "FAC-1"},
+		{"duplicate separators", func(e *EnvelopeSettings) { e.ComponentSeparator = "*" }, nil, "FAC-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope := validEnvelope()
+			tt.mutate(&envelope)
+			_, err := NewTradingPartner("TNT-001", "SLR-001", tt.facility, "WH-1", "Acme B2B", envelope, tt.docs)
+			assert.ErrorIs(t, err, ErrInvalidTradingPartner)
+		})
+	}
+}
+
+func TestTradingPartnerUpdateKeepsPartnerOnError(t *testing.T) {
+	partner, err := NewTradingPartner("TNT-001", "SLR-001", "FAC-1", "WH-1", "Acme B2B", validEnvelope(), nil)
+	require.NoError(t, err)
+
+	err = partner.Update("", validEnvelope(), nil, false)
+	assert.ErrorIs(t, err, ErrInvalidTradingPartner)
+	assert.Equal(t, "Acme B2B", partner.Name)
+	assert.True(t, partner.Active)
+
+	require.NoError(t, partner.Update("Acme Wholesale", validEnvelope(), []EDIDocumentType{X12ShipNotice}, false))
+	assert.Equal(t, "Acme Wholesale", partner.Name)
+	assert.False(t, partner.Active)
+}
+
+func TestControlNumbers(t *testing.T) {
+	tests := []struct {
+		controls    ControlNumbers
+		interchange string
+		group       string
+	}{
+		{ControlNumbers{Interchange: 1, Group: 1}, "000000001", "1"},
+		{ControlNumbers{Interchange: 42, Group: 7}, "000000042", "7"},
+		{ControlNumbers{Interchange: 999999999, Group: 999999999}, "999999999", "999999999"},
+		{ControlNumbers{Interchange: 1000000000, Group: 1000000001}, "000000001", "2"},
+		{ControlNumbers{}, "000000001", "1"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.interchange, tt.controls.InterchangeControlNumber())
+		assert.Equal(t, tt.group, tt.controls.GroupControlNumber())
+	}
+}
+
+func TestNewGroupAcknowledgement(t *testing.T) {
+	ok := EDITransaction{Type: X12WarehouseShippingOrder, ControlNumber: "0001"}
+	bad := EDITransaction{Type: X12WarehouseShippingOrder, ControlNumber: "0002", Errors: []string{"no W01 line items"}}
+
+	tests := []struct {
+		name         string
+		transactions []EDITransaction
+		expected     EDIAckStatus
+	}{
+		{"all accepted", []EDITransaction{ok}, EDIAckAccepted},
+		{"some rejected", []EDITransaction{ok, bad}, EDIAckAcceptedWithErrors},
+		{"all rejected", []EDITransaction{bad}, EDIAckRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ack := NewGroupAcknowledgement(EDIGroup{FunctionalID: "OW", ControlNumber: "55", Transactions: tt.transactions})
+			assert.Equal(t, tt.expected, ack.Status)
+			assert.Equal(t, "55", ack.GroupControlNumber)
+			assert.Len(t, ack.Transactions, len(tt.transactions))
+		})
+	}
+}
+
+func TestAckStatusCodes(t *testing.T) {
+	for _, status := range []EDIAckStatus{EDIAckAccepted, EDIAckAcceptedWithErrors, EDIAckRejected} {
+		assert.Equal(t, status, AckStatusFromCode(status.Code()))
+	}
+}
+
+func TestInboundEDIDocumentLifecycle(t *testing.T) {
+	partner, err := NewTradingPartner("TNT-001", "SLR-001", "FAC-1", "WH-1", "Acme B2B", validEnvelope(), nil)
+	require.NoError(t, err)
+	interchange := &EDIInterchange{ControlNumber: "000000101"}
+	group := EDIGroup{ControlNumber: "55"}
+
+	doc := NewInboundEDIDocument(partner, interchange, group, EDITransaction{
+		Type:          X12WarehouseShippingOrder,
+		ControlNumber: "0001",
+		Order:         &EDIOrder{PurchaseOrderNumber: "PO-1"},
+	})
+	assert.Equal(t, EDIStatusReceived, doc.Status)
+	assert.Equal(t, "PO-1", doc.Reference)
+	assert.Equal(t, partner.TenantID, doc.TenantID)
+	// Logged but not yet applied, e.g. after a crash mid-interchange
+	assert.True(t, doc.CanRetry())
+
+	doc.MarkFailed("order-service unavailable")
+	assert.True(t, doc.CanRetry())
+
+	doc.MarkOrderCreated("ORD-1")
+	assert.Equal(t, EDIStatusProcessed, doc.Status)
+	assert.Equal(t, "ORD-1", doc.WMSOrderID)
+	assert.Empty(t, doc.Errors)
+	assert.False(t, doc.CanRetry())
+
+	rejected := NewInboundEDIDocument(partner, interchange, group, EDITransaction{
+		Type:   X12WarehouseShippingOrder,
+		Errors: []string{"no W01 line items"},
+	})
+	assert.Equal(t, EDIStatusRejected, rejected.Status)
+	assert.False(t, rejected.CanRetry())
+}
+
+func TestOutboundEDIDocumentLifecycle(t *testing.T) {
+	partner, err := NewTradingPartner("TNT-001", "SLR-001", "FAC-1", "WH-1", "Acme B2B", validEnvelope(), nil)
+	require.NoError(t, err)
+
+	confirmation := NewShipmentConfirmation(
+		&EDIOrder{PurchaseOrderNumber: "PO-1", CarrierSCAC: "FDEG", Lines: []EDIOrderLine{{SKU: "SKU-1", Quantity: 2}}},
+		"ORD-1", "SHP-1", "1Z1", "upsn", time.Now(), 1.5,
+	)
+	assert.Equal(t, "UPSN", confirmation.CarrierSCAC)
+
+	doc := NewOutboundEDIDocument(partner, ControlNumbers{Interchange: 3, Group: 3}, EDITransaction{
+		Type:         X12WarehouseShippingAdvice,
+		Confirmation: confirmation,
+	}, []byte("ISA..."))
+	assert.Equal(t, EDIStatusGenerated, doc.Status)
+	assert.Equal(t, EDIAckPending, doc.AckStatus)
+	assert.Equal(t, "000000003", doc.InterchangeControlNumber)
+	assert.Equal(t, "ORD-1", doc.WMSOrderID)
+	assert.False(t, doc.CanRetry())
+
+	require.NoError(t, doc.MarkSent())
+	assert.NotNil(t, doc.SentAt)
+	assert.ErrorIs(t, doc.MarkSent(), ErrEDIDocumentNotTransmittable)
+
+	doc.ApplyAcknowledgement(EDITransactionAck{Status: EDIAckRejected, Errors: []string{"W12 in error"}})
+	assert.Equal(t, EDIAckRejected, doc.AckStatus)
+	assert.NotNil(t, doc.AcknowledgedAt)
+
+	ack := NewOutboundEDIDocument(partner, ControlNumbers{Interchange: 4, Group: 4}, EDITransaction{
+		Type:            X12FunctionalAck,
+		Acknowledgement: &EDIAcknowledgement{GroupControlNumber: "55"},
+	}, nil)
+	assert.Empty(t, ack.AckStatus)
+	assert.Equal(t, "55", ack.Reference)
+}
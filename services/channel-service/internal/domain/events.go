@@ -90,3 +90,29 @@ type WebhookReceivedEvent struct {
 
 func (e *WebhookReceivedEvent) EventType() string    { return "channel.webhook.received" }
 func (e *WebhookReceivedEvent) OccurredAt() time.Time { return e.ReceivedAt }
+
+// CredentialsRefreshedEvent is emitted when a channel's access token is refreshed
+type CredentialsRefreshedEvent struct {
+	ChannelID           string      `json:"channelId"`
+	SellerID            string      `json:"sellerId"`
+	Type                ChannelType `json:"type"`
+	ExpiresAt           time.Time   `json:"expiresAt"`
+	RefreshTokenRotated bool        `json:"refreshTokenRotated"`
+	RefreshedAt         time.Time   `json:"refreshedAt"`
+}
+
+func (e *CredentialsRefreshedEvent) EventType() string    { return "channel.credentials.refreshed" }
+func (e *CredentialsRefreshedEvent) OccurredAt() time.Time { return e.RefreshedAt }
+
+// CredentialsExpiredEvent is emitted when a channel rejects its refresh token and the
+// seller has to reconnect the channel
+type CredentialsExpiredEvent struct {
+	ChannelID string      `json:"channelId"`
+	SellerID  string      `json:"sellerId"`
+	Type      ChannelType `json:"type"`
+	Reason    string      `json:"reason"`
+	ExpiredAt time.Time   `json:"expiredAt"`
+}
+
+func (e *CredentialsExpiredEvent) EventType() string    { return "channel.credentials.expired" }
+func (e *CredentialsExpiredEvent) OccurredAt() time.Time { return e.ExpiredAt }
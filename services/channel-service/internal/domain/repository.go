@@ -32,6 +32,17 @@ type ChannelRepository interface {
 	Delete(ctx context.Context, channelID string) error
 }
 
+// RefreshLeaseStore hands out short-lived per-channel leases so that only one service
+// instance refreshes a channel's OAuth tokens at a time
+type RefreshLeaseStore interface {
+	// AcquireRefreshLease takes the channel's lease for holder until ttl elapses. It returns
+	// false while another holder's lease is still live.
+	AcquireRefreshLease(ctx context.Context, channelID, holder string, ttl time.Duration) (bool, error)
+
+	// ReleaseRefreshLease gives up the channel's lease if holder still owns it
+	ReleaseRefreshLease(ctx context.Context, channelID, holder string) error
+}
+
 // ChannelOrderRepository defines the interface for channel order persistence
 type ChannelOrderRepository interface {
 	// Save persists a channel order
@@ -46,6 +57,9 @@ type ChannelOrderRepository interface {
 	// FindByChannelID retrieves orders for a channel
 	FindByChannelID(ctx context.Context, channelID string, pagination Pagination) ([]*ChannelOrder, error)
 
+	// FindByWMSOrderID retrieves the order that was imported as the given WMS order
+	FindByWMSOrderID(ctx context.Context, wmsOrderID string) (*ChannelOrder, error)
+
 	// FindUnimported retrieves orders not yet imported to WMS
 	FindUnimported(ctx context.Context, channelID string) ([]*ChannelOrder, error)
 
@@ -58,6 +72,9 @@ type ChannelOrderRepository interface {
 	// MarkTrackingPushed marks tracking as pushed
 	MarkTrackingPushed(ctx context.Context, externalOrderID string) error
 
+	// MarkTrackingMilestone records the last carrier milestone pushed for an order
+	MarkTrackingMilestone(ctx context.Context, externalOrderID, milestone string) error
+
 	// Count returns count of orders for a channel
 	Count(ctx context.Context, channelID string) (int64, error)
 }
@@ -80,6 +97,52 @@ type SyncJobRepository interface {
 	FindLatest(ctx context.Context, channelID string, syncType SyncType) (*SyncJob, error)
 }
 
+// TradingPartnerRepository defines the interface for EDI trading partner persistence
+type TradingPartnerRepository interface {
+	// Save persists a trading partner
+	Save(ctx context.Context, partner *TradingPartner) error
+
+	// FindByID retrieves a trading partner by ID
+	FindByID(ctx context.Context, partnerID string) (*TradingPartner, error)
+
+	// FindByInterchangeIDs retrieves the partner whose envelope matches an interchange's sender and receiver
+	FindByInterchangeIDs(ctx context.Context, senderQualifier, senderID, receiverQualifier, receiverID string) (*TradingPartner, error)
+
+	// FindByTenantID retrieves the trading partners of a tenant
+	FindByTenantID(ctx context.Context, tenantID string) ([]*TradingPartner, error)
+
+	// Delete deletes a trading partner
+	Delete(ctx context.Context, partnerID string) error
+
+	// NextControlNumbers reserves the next interchange and group control numbers for the partner
+	NextControlNumbers(ctx context.Context, partnerID string) (ControlNumbers, error)
+}
+
+// EDIDocumentRepository defines the interface for EDI document log persistence
+type EDIDocumentRepository interface {
+	// Save persists an EDI document
+	Save(ctx context.Context, doc *EDIDocument) error
+
+	// Insert logs new documents. It fails with ErrDuplicateInterchange if one of their
+	// transaction sets was already logged for the partner.
+	Insert(ctx context.Context, docs ...*EDIDocument) error
+
+	// FindByID retrieves a document by ID
+	FindByID(ctx context.Context, documentID string) (*EDIDocument, error)
+
+	// FindByPartnerID retrieves a partner's documents, newest first
+	FindByPartnerID(ctx context.Context, partnerID string, direction EDIDirection, pagination Pagination) ([]*EDIDocument, error)
+
+	// FindByInterchangeControlNumber retrieves the documents of one interchange exchanged with a partner
+	FindByInterchangeControlNumber(ctx context.Context, partnerID string, direction EDIDirection, controlNumber string) ([]*EDIDocument, error)
+
+	// FindByGroupControlNumber retrieves the documents of one functional group exchanged with a partner
+	FindByGroupControlNumber(ctx context.Context, partnerID string, direction EDIDirection, controlNumber string) ([]*EDIDocument, error)
+
+	// FindByWMSOrderID retrieves the inbound order and outbound confirmations for a WMS order
+	FindByWMSOrderID(ctx context.Context, wmsOrderID string) ([]*EDIDocument, error)
+}
+
 // Pagination represents pagination options
 type Pagination struct {
 	Page     int64
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OrderCreator creates WMS orders for orders imported from a channel
+type OrderCreator interface {
+	// CreateOrder creates the WMS order and returns its ID
+	CreateOrder(ctx context.Context, channel *Channel, order *ChannelOrder) (string, error)
+}
+
+// InventorySource provides current WMS inventory levels to push to a channel
+type InventorySource interface {
+	// GetInventoryLevels returns the available quantity for every SKU the channel's seller stocks
+	GetInventoryLevels(ctx context.Context, channel *Channel) ([]InventoryUpdate, error)
+}
+
+// SyncInterval returns the configured automatic sync interval for a sync type, or 0 when it is not scheduled
+func (c *Channel) SyncInterval(syncType SyncType) time.Duration {
+	switch syncType {
+	case SyncTypeOrders:
+		if c.SyncSettings.AutoImportOrders && c.SyncSettings.OrderSyncIntervalMin > 0 {
+			return time.Duration(c.SyncSettings.OrderSyncIntervalMin) * time.Minute
+		}
+	case SyncTypeInventory:
+		if c.SyncSettings.AutoSyncInventory && c.SyncSettings.InventorySyncIntervalMin > 0 {
+			return time.Duration(c.SyncSettings.InventorySyncIntervalMin) * time.Minute
+		}
+	}
+	return 0
+}
+
+// LastSync returns when a sync type last completed
+func (c *Channel) LastSync(syncType SyncType) *time.Time {
+	switch syncType {
+	case SyncTypeOrders:
+		return c.LastOrderSync
+	case SyncTypeInventory:
+		return c.LastInventorySync
+	case SyncTypeTracking:
+		return c.LastTrackingSync
+	}
+	return nil
+}
+
+// NextSyncAt returns when the next automatic sync of a type is due, or nil when the
+// channel is not active or the sync type is not scheduled
+func (c *Channel) NextSyncAt(syncType SyncType) *time.Time {
+	interval := c.SyncInterval(syncType)
+	if !c.IsActive() || interval == 0 {
+		return nil
+	}
+
+	next := c.CreatedAt
+	if last := c.LastSync(syncType); last != nil {
+		next = last.Add(interval)
+	}
+	return &next
+}
+
+// IsSyncDue checks if an automatic sync of a type should run at the given time
+func (c *Channel) IsSyncDue(syncType SyncType, now time.Time) bool {
+	next := c.NextSyncAt(syncType)
+	return next != nil && !now.Before(*next)
+}
+
+// ShouldImport checks if an order passes the channel's import filters
+func (s SyncSettings) ShouldImport(order *ChannelOrder) bool {
+	if s.ImportPaidOnly && order.FinancialStatus != "paid" {
+		return false
+	}
+	if !s.ImportFulfilledOrders && order.FulfillmentStatus == "fulfilled" {
+		return false
+	}
+	for _, excluded := range s.ExcludeTags {
+		for _, tag := range order.Tags {
+			if tag == excluded {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// InventoryDeltas returns the levels whose available quantity changed since the last push
+func (c *Channel) InventoryDeltas(levels []InventoryUpdate) []InventoryUpdate {
+	deltas := make([]InventoryUpdate, 0)
+	for _, level := range levels {
+		if pushed, ok := c.InventoryLevels[level.SKU]; ok && pushed == level.Available {
+			continue
+		}
+		if level.LocationID == "" {
+			level.LocationID = c.SyncSettings.FulfillmentLocationID
+		}
+		deltas = append(deltas, level)
+	}
+	return deltas
+}
+
+// RecordInventoryPushed records the levels that were pushed to the channel
+func (c *Channel) RecordInventoryPushed(updates []InventoryUpdate) {
+	if c.InventoryLevels == nil {
+		c.InventoryLevels = make(map[string]int, len(updates))
+	}
+	for _, update := range updates {
+		c.InventoryLevels[update.SKU] = update.Available
+	}
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// RecordOrderImported records that a channel order was created as a WMS order
+func (c *Channel) RecordOrderImported(externalOrderID, wmsOrderID string) {
+	c.addDomainEvent(&OrderImportedEvent{
+		ChannelID:       c.ChannelID,
+		SellerID:        c.SellerID,
+		ExternalOrderID: externalOrderID,
+		WMSOrderID:      wmsOrderID,
+		ImportedAt:      time.Now().UTC(),
+	})
+}
+
+// RecordSyncCompleted records the outcome of a finished sync job
+func (c *Channel) RecordSyncCompleted(job *SyncJob) {
+	completedAt := time.Now().UTC()
+	if job.CompletedAt != nil {
+		completedAt = *job.CompletedAt
+	}
+
+	c.addDomainEvent(&SyncCompletedEvent{
+		JobID:        job.JobID,
+		ChannelID:    c.ChannelID,
+		Type:         job.Type,
+		Status:       job.Status,
+		TotalItems:   job.TotalItems,
+		SuccessItems: job.SuccessItems,
+		FailedItems:  job.FailedItems,
+		CompletedAt:  completedAt,
+	})
+}
+
+// IsStale checks if a running job has run longer than timeout, e.g. because the
+// instance running it stopped before the job finished
+func (j *SyncJob) IsStale(now time.Time, timeout time.Duration) bool {
+	if j.Status != SyncStatusRunning {
+		return false
+	}
+	started := j.CreatedAt
+	if j.StartedAt != nil {
+		started = *j.StartedAt
+	}
+	return now.Sub(started) > timeout
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScheduledChannel(t *testing.T) *Channel {
+	t.Helper()
+	channel, err := NewChannel("TNT-001", "SLR-001", ChannelTypeShopify, "Store", "", ChannelCredentials{}, SyncSettings{
+		AutoImportOrders:         true,
+		AutoSyncInventory:        true,
+		OrderSyncIntervalMin:     5,
+		InventorySyncIntervalMin: 15,
+		FulfillmentLocationID:    "LOC-1",
+	})
+	require.NoError(t, err)
+	return channel
+}
+
+// TestChannelNextSyncAt tests when automatic syncs are due
+func TestChannelNextSyncAt(t *testing.T) {
+	channel := newScheduledChannel(t)
+
+	// Never synced: due immediately
+	next := channel.NextSyncAt(SyncTypeOrders)
+	require.NotNil(t, next)
+	assert.Equal(t, channel.CreatedAt, *next)
+	assert.True(t, channel.IsSyncDue(SyncTypeOrders, time.Now()))
+
+	last := time.Now().UTC()
+	channel.LastOrderSync = &last
+	next = channel.NextSyncAt(SyncTypeOrders)
+	require.NotNil(t, next)
+	assert.Equal(t, last.Add(5*time.Minute), *next)
+	assert.False(t, channel.IsSyncDue(SyncTypeOrders, last.Add(4*time.Minute)))
+	assert.True(t, channel.IsSyncDue(SyncTypeOrders, last.Add(5*time.Minute)))
+
+	// Tracking has no automatic schedule
+	assert.Nil(t, channel.NextSyncAt(SyncTypeTracking))
+}
+
+// TestChannelNextSyncAtNotScheduled tests channels without an automatic schedule
+func TestChannelNextSyncAtNotScheduled(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Channel)
+	}{
+		{
+			name:   "Auto import disabled",
+			modify: func(c *Channel) { c.SyncSettings.AutoImportOrders = false },
+		},
+		{
+			name:   "Zero interval",
+			modify: func(c *Channel) { c.SyncSettings.OrderSyncIntervalMin = 0 },
+		},
+		{
+			name:   "Paused channel",
+			modify: func(c *Channel) { c.Pause() },
+		},
+		{
+			name:   "Disconnected channel",
+			modify: func(c *Channel) { c.Disconnect() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel := newScheduledChannel(t)
+			tt.modify(channel)
+
+			assert.Nil(t, channel.NextSyncAt(SyncTypeOrders))
+			assert.False(t, channel.IsSyncDue(SyncTypeOrders, time.Now().Add(24*time.Hour)))
+		})
+	}
+}
+
+// TestSyncSettingsShouldImport tests order import filters
+func TestSyncSettingsShouldImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings SyncSettings
+		order    ChannelOrder
+		expected bool
+	}{
+		{
+			name:     "No filters",
+			settings: SyncSettings{},
+			order:    ChannelOrder{FinancialStatus: "pending"},
+			expected: true,
+		},
+		{
+			name:     "Paid only rejects unpaid order",
+			settings: SyncSettings{ImportPaidOnly: true},
+			order:    ChannelOrder{FinancialStatus: "pending"},
+			expected: false,
+		},
+		{
+			name:     "Paid only accepts paid order",
+			settings: SyncSettings{ImportPaidOnly: true},
+			order:    ChannelOrder{FinancialStatus: "paid"},
+			expected: true,
+		},
+		{
+			name:     "Fulfilled orders skipped by default",
+			settings: SyncSettings{},
+			order:    ChannelOrder{FulfillmentStatus: "fulfilled"},
+			expected: false,
+		},
+		{
+			name:     "Fulfilled orders imported when enabled",
+			settings: SyncSettings{ImportFulfilledOrders: true},
+			order:    ChannelOrder{FulfillmentStatus: "fulfilled"},
+			expected: true,
+		},
+		{
+			name:     "Excluded tag",
+			settings: SyncSettings{ExcludeTags: []string{"test"}},
+			order:    ChannelOrder{Tags: []string{"vip", "test"}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.settings.ShouldImport(&tt.order))
+		})
+	}
+}
+
+// TestChannelInventoryDeltas tests that only changed levels are pushed
+func TestChannelInventoryDeltas(t *testing.T) {
+	channel := newScheduledChannel(t)
+	levels := []InventoryUpdate{
+		{SKU: "SKU-1", Quantity: 10, Available: 8},
+		{SKU: "SKU-2", Quantity: 5, Available: 5, LocationID: "LOC-2"},
+	}
+
+	deltas := channel.InventoryDeltas(levels)
+	require.Len(t, deltas, 2)
+	assert.Equal(t, "LOC-1", deltas[0].LocationID)
+	assert.Equal(t, "LOC-2", deltas[1].LocationID)
+
+	channel.RecordInventoryPushed(deltas)
+	assert.Equal(t, map[string]int{"SKU-1": 8, "SKU-2": 5}, channel.InventoryLevels)
+
+	levels[0].Available = 6
+	levels[1].Quantity = 7 // on-hand changed but available did not
+	deltas = channel.InventoryDeltas(levels)
+	require.Len(t, deltas, 1)
+	assert.Equal(t, "SKU-1", deltas[0].SKU)
+	assert.Equal(t, 6, deltas[0].Available)
+}
+
+// TestSyncJobIsStale tests abandoned job detection
+func TestSyncJobIsStale(t *testing.T) {
+	job := NewSyncJob("TNT-001", "SLR-001", "CH-001", SyncTypeOrders, "inbound")
+	now := time.Now()
+
+	assert.False(t, job.IsStale(now.Add(2*time.Hour), time.Hour), "pending job is never stale")
+
+	job.Start()
+	assert.False(t, job.IsStale(now.Add(30*time.Minute), time.Hour))
+	assert.True(t, job.IsStale(now.Add(2*time.Hour), time.Hour))
+
+	job.Complete()
+	assert.False(t, job.IsStale(now.Add(2*time.Hour), time.Hour), "completed job is never stale")
+}
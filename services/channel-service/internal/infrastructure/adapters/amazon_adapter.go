@@ -69,7 +69,21 @@ func (a *AmazonAdapter) ValidateCredentials(ctx context.Context, creds domain.Ch
 	return nil
 }
 
+// getAccessToken returns the stored LWA access token while it is valid and otherwise
+// exchanges the refresh token for a short-lived one
 func (a *AmazonAdapter) getAccessToken(ctx context.Context, creds domain.ChannelCredentials) (string, error) {
+	if token, ok := cachedAccessToken(creds); ok {
+		return token, nil
+	}
+	refresh, err := a.RefreshCredentials(ctx, creds)
+	if err != nil {
+		return "", err
+	}
+	return refresh.AccessToken, nil
+}
+
+// RefreshCredentials exchanges the LWA refresh token for a new access token
+func (a *AmazonAdapter) RefreshCredentials(ctx context.Context, creds domain.ChannelCredentials) (*domain.TokenRefresh, error) {
 	data := url.Values{}
 	data.Set("grant_type", "refresh_token")
 	data.Set("refresh_token", creds.RefreshToken)
@@ -78,30 +92,11 @@ func (a *AmazonAdapter) getAccessToken(ctx context.Context, creds domain.Channel
 
 	req, err := http.NewRequestWithContext(ctx, "POST", a.authURL, strings.NewReader(data.Encode()))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := a.httpClient.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("token request failed: %s", string(body))
-	}
-
-	var tokenResp struct {
-		AccessToken string `json:"access_token"`
-		ExpiresIn   int    `json:"expires_in"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return "", err
-	}
-
-	return tokenResp.AccessToken, nil
+	return requestToken(a.httpClient, req)
 }
 
 func (a *AmazonAdapter) FetchOrders(ctx context.Context, channel *domain.Channel, since time.Time) ([]*domain.ChannelOrder, error) {
@@ -126,7 +121,7 @@ func (a *AmazonAdapter) FetchOrders(ctx context.Context, channel *domain.Channel
 	req.Header.Set("x-amz-access-token", accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -251,7 +246,7 @@ func (a *AmazonAdapter) fetchOrderItems(ctx context.Context, channel *domain.Cha
 	}
 	req.Header.Set("x-amz-access-token", accessToken)
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -318,7 +313,7 @@ func (a *AmazonAdapter) FetchOrder(ctx context.Context, channel *domain.Channel,
 	}
 	req.Header.Set("x-amz-access-token", accessToken)
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -388,7 +383,7 @@ func (a *AmazonAdapter) PushTracking(ctx context.Context, channel *domain.Channe
 	req.Header.Set("x-amz-access-token", accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return err
 	}
@@ -405,6 +400,12 @@ func (a *AmazonAdapter) PushTracking(ctx context.Context, channel *domain.Channe
 	return nil
 }
 
+// PushShipmentStatus is a no-op: Amazon follows carrier milestones through the tracking number
+// submitted with the order fulfillment feed
+func (a *AmazonAdapter) PushShipmentStatus(ctx context.Context, channel *domain.Channel, externalOrderID string, update domain.ShipmentStatusUpdate) error {
+	return nil
+}
+
 func (a *AmazonAdapter) SyncInventory(ctx context.Context, channel *domain.Channel, items []domain.InventoryUpdate) error {
 	accessToken, err := a.getAccessToken(ctx, channel.Credentials)
 	if err != nil {
@@ -427,7 +428,7 @@ func (a *AmazonAdapter) SyncInventory(ctx context.Context, channel *domain.Chann
 	req.Header.Set("x-amz-access-token", accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return err
 	}
@@ -460,7 +461,7 @@ func (a *AmazonAdapter) GetInventoryLevels(ctx context.Context, channel *domain.
 	}
 	req.Header.Set("x-amz-access-token", accessToken)
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
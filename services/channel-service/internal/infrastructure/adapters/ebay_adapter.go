@@ -7,6 +7,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -57,7 +58,21 @@ func (a *EbayAdapter) ValidateCredentials(ctx context.Context, creds domain.Chan
 	return nil
 }
 
+// getAccessToken returns the stored access token while it is valid and otherwise
+// exchanges the refresh token for a short-lived one
 func (a *EbayAdapter) getAccessToken(ctx context.Context, creds domain.ChannelCredentials) (string, error) {
+	if token, ok := cachedAccessToken(creds); ok {
+		return token, nil
+	}
+	refresh, err := a.RefreshCredentials(ctx, creds)
+	if err != nil {
+		return "", err
+	}
+	return refresh.AccessToken, nil
+}
+
+// RefreshCredentials exchanges the refresh token for a new user access token
+func (a *EbayAdapter) RefreshCredentials(ctx context.Context, creds domain.ChannelCredentials) (*domain.TokenRefresh, error) {
 	data := url.Values{}
 	data.Set("grant_type", "refresh_token")
 	data.Set("refresh_token", creds.RefreshToken)
@@ -65,7 +80,7 @@ func (a *EbayAdapter) getAccessToken(ctx context.Context, creds domain.ChannelCr
 
 	req, err := http.NewRequestWithContext(ctx, "POST", a.authURL, strings.NewReader(data.Encode()))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// Basic auth with client credentials
@@ -73,26 +88,7 @@ func (a *EbayAdapter) getAccessToken(ctx context.Context, creds domain.ChannelCr
 	req.Header.Set("Authorization", "Basic "+auth)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := a.httpClient.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("token request failed: %s", string(body))
-	}
-
-	var tokenResp struct {
-		AccessToken string `json:"access_token"`
-		ExpiresIn   int    `json:"expires_in"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return "", err
-	}
-
-	return tokenResp.AccessToken, nil
+	return requestToken(a.httpClient, req)
 }
 
 func (a *EbayAdapter) FetchOrders(ctx context.Context, channel *domain.Channel, since time.Time) ([]*domain.ChannelOrder, error) {
@@ -114,7 +110,7 @@ func (a *EbayAdapter) FetchOrders(ctx context.Context, channel *domain.Channel,
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -266,7 +262,7 @@ func (a *EbayAdapter) FetchOrder(ctx context.Context, channel *domain.Channel, e
 	}
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -307,7 +303,7 @@ func (a *EbayAdapter) PushTracking(ctx context.Context, channel *domain.Channel,
 	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return err
 	}
@@ -321,6 +317,12 @@ func (a *EbayAdapter) PushTracking(ctx context.Context, channel *domain.Channel,
 	return nil
 }
 
+// PushShipmentStatus is a no-op: eBay follows carrier milestones through the tracking number
+// pushed with the shipping fulfillment
+func (a *EbayAdapter) PushShipmentStatus(ctx context.Context, channel *domain.Channel, externalOrderID string, update domain.ShipmentStatusUpdate) error {
+	return nil
+}
+
 func (a *EbayAdapter) mapCarrierCode(carrier string) string {
 	carrierMap := map[string]string{
 		"ups":   "UPS",
@@ -351,7 +353,10 @@ func (a *EbayAdapter) SyncInventory(ctx context.Context, channel *domain.Channel
 		}
 		getReq.Header.Set("Authorization", "Bearer "+accessToken)
 
-		getResp, err := a.httpClient.Do(getReq)
+		getResp, err := doRequest(a.httpClient, getReq)
+		if errors.Is(err, domain.ErrChannelUnauthorized) {
+			return err
+		}
 		if err != nil {
 			continue
 		}
@@ -377,7 +382,10 @@ func (a *EbayAdapter) SyncInventory(ctx context.Context, channel *domain.Channel
 			putReq.Header.Set("Authorization", "Bearer "+accessToken)
 			putReq.Header.Set("Content-Type", "application/json")
 
-			putResp, err := a.httpClient.Do(putReq)
+			putResp, err := doRequest(a.httpClient, putReq)
+			if errors.Is(err, domain.ErrChannelUnauthorized) {
+				return err
+			}
 			if err != nil {
 				continue
 			}
@@ -407,7 +415,10 @@ func (a *EbayAdapter) GetInventoryLevels(ctx context.Context, channel *domain.Ch
 		}
 		req.Header.Set("Authorization", "Bearer "+accessToken)
 
-		resp, err := a.httpClient.Do(req)
+		resp, err := doRequest(a.httpClient, req)
+		if errors.Is(err, domain.ErrChannelUnauthorized) {
+			return nil, err
+		}
 		if err != nil {
 			continue
 		}
@@ -477,7 +488,10 @@ func (a *EbayAdapter) RegisterWebhooks(ctx context.Context, channel *domain.Chan
 		req.Header.Set("Authorization", "Bearer "+accessToken)
 		req.Header.Set("Content-Type", "application/json")
 
-		resp, err := a.httpClient.Do(req)
+		resp, err := doRequest(a.httpClient, req)
+		if errors.Is(err, domain.ErrChannelUnauthorized) {
+			return err
+		}
 		if err != nil {
 			continue
 		}
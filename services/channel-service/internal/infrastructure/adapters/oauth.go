@@ -0,0 +1,72 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// doRequest sends a channel API request and turns a 401 response into
+// domain.ErrChannelUnauthorized so callers can refresh the token and retry
+func doRequest(client *http.Client, req *http.Request) (*http.Response, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s %s", domain.ErrChannelUnauthorized, req.Method, req.URL.Path)
+	}
+	return resp, nil
+}
+
+// requestToken sends an OAuth refresh_token grant to a token endpoint. A 400 or 401
+// answer means the refresh token was revoked or expired and is reported as
+// domain.ErrRefreshTokenRejected.
+func requestToken(client *http.Client, req *http.Request) (*domain.TokenRefresh, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: %s", domain.ErrRefreshTokenRejected, string(body))
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("token request failed: status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access token")
+	}
+
+	return &domain.TokenRefresh{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+		ExpiresAt:    time.Now().UTC().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// cachedAccessToken returns the stored access token if it is still valid
+func cachedAccessToken(creds domain.ChannelCredentials) (string, bool) {
+	if creds.AccessToken == "" || creds.NeedsTokenRefresh(time.Now(), 0) {
+		return "", false
+	}
+	return creds.AccessToken, true
+}
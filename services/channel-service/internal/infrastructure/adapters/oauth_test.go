@@ -0,0 +1,154 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+func TestDoRequestUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL+"/orders", nil)
+	require.NoError(t, err)
+	_, err = doRequest(server.Client(), req)
+	require.ErrorIs(t, err, domain.ErrChannelUnauthorized)
+}
+
+func TestEbayRefreshCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/auth/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("refresh_token") != "refresh" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "fresh",
+			"expires_in":   7200,
+		})
+	}))
+	defer server.Close()
+
+	adapter := newEbayTestAdapter(server)
+	refresh, err := adapter.RefreshCredentials(context.Background(), domain.ChannelCredentials{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RefreshToken: "refresh",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "fresh", refresh.AccessToken)
+	require.Empty(t, refresh.RefreshToken)
+	require.WithinDuration(t, time.Now().Add(2*time.Hour), refresh.ExpiresAt, time.Minute)
+
+	_, err = adapter.RefreshCredentials(context.Background(), domain.ChannelCredentials{RefreshToken: "revoked"})
+	require.ErrorIs(t, err, domain.ErrRefreshTokenRejected)
+}
+
+func TestEbayUsesStoredAccessToken(t *testing.T) {
+	var tokenRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/token" {
+			tokenRequests.Add(1)
+			_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "fresh", "expires_in": 7200})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer stored" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"orders": []any{}})
+	}))
+	defer server.Close()
+
+	expiresAt := time.Now().Add(time.Hour)
+	channel := &domain.Channel{
+		ChannelID: "ch-1",
+		Status:    domain.ChannelStatusActive,
+		Credentials: domain.ChannelCredentials{
+			RefreshToken:   "refresh",
+			AccessToken:    "stored",
+			TokenExpiresAt: &expiresAt,
+		},
+	}
+
+	adapter := newEbayTestAdapter(server)
+	_, err := adapter.FetchOrders(context.Background(), channel, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, tokenRequests.Load())
+
+	channel.Credentials.AccessToken = "revoked"
+	_, err = adapter.FetchOrders(context.Background(), channel, time.Now().Add(-time.Hour))
+	require.ErrorIs(t, err, domain.ErrChannelUnauthorized)
+}
+
+func TestAmazonRefreshCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client", r.PostForm.Get("client_id"))
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "Atza|fresh",
+			"refresh_token": "Atzr|rotated",
+			"expires_in":    3600,
+		})
+	}))
+	defer server.Close()
+
+	adapter := newAmazonTestAdapter(server)
+	refresh, err := adapter.RefreshCredentials(context.Background(), domain.ChannelCredentials{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RefreshToken: "Atzr|old",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Atza|fresh", refresh.AccessToken)
+	require.Equal(t, "Atzr|rotated", refresh.RefreshToken)
+}
+
+func TestShopifyRefreshCredentials(t *testing.T) {
+	adapter := NewShopifyAdapter()
+	_, err := adapter.RefreshCredentials(context.Background(), domain.ChannelCredentials{AccessToken: "shpat_offline"})
+	require.ErrorIs(t, err, domain.ErrTokenRefreshNotSupported)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/admin/oauth/access_token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "api-key", r.PostForm.Get("client_id"))
+		require.Equal(t, "shprt_old", r.PostForm.Get("refresh_token"))
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "shpat_new",
+			"refresh_token": "shprt_new",
+			"expires_in":    3600,
+		})
+	}))
+	defer server.Close()
+
+	adapter = newShopifyTestAdapter(server)
+	refresh, err := adapter.RefreshCredentials(context.Background(), domain.ChannelCredentials{
+		StoreDomain:  strings.TrimPrefix(server.URL, "https://"),
+		APIKey:       "api-key",
+		APISecret:    "api-secret",
+		RefreshToken: "shprt_old",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "shpat_new", refresh.AccessToken)
+	require.Equal(t, "shprt_new", refresh.RefreshToken)
+}
+
+func TestWooCommerceRefreshCredentialsNotSupported(t *testing.T) {
+	adapter := NewWooCommerceAdapter()
+	_, err := adapter.RefreshCredentials(context.Background(), domain.ChannelCredentials{})
+	require.ErrorIs(t, err, domain.ErrTokenRefreshNotSupported)
+}
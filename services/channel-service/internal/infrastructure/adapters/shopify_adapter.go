@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel"
@@ -103,7 +105,7 @@ func (a *ShopifyAdapter) FetchOrders(ctx context.Context, channel *domain.Channe
 	req.Header.Set("X-Shopify-Access-Token", channel.Credentials.AccessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		span.RecordError(err)
 		return nil, fmt.Errorf("failed to fetch orders: %w", err)
@@ -151,7 +153,7 @@ func (a *ShopifyAdapter) FetchOrder(ctx context.Context, channel *domain.Channel
 	req.Header.Set("X-Shopify-Access-Token", channel.Credentials.AccessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch order: %w", err)
 	}
@@ -188,7 +190,7 @@ func (a *ShopifyAdapter) PushTracking(ctx context.Context, channel *domain.Chann
 	req.Header.Set("X-Shopify-Access-Token", channel.Credentials.AccessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return fmt.Errorf("failed to get fulfillment orders: %w", err)
 	}
@@ -268,7 +270,7 @@ func (a *ShopifyAdapter) PushTracking(ctx context.Context, channel *domain.Chann
 	req.Header.Set("X-Shopify-Access-Token", channel.Credentials.AccessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err = a.httpClient.Do(req)
+	resp, err = doRequest(a.httpClient, req)
 	if err != nil {
 		return fmt.Errorf("failed to create fulfillment: %w", err)
 	}
@@ -320,7 +322,7 @@ func (a *ShopifyAdapter) SyncInventory(ctx context.Context, channel *domain.Chan
 		req.Header.Set("X-Shopify-Access-Token", channel.Credentials.AccessToken)
 		req.Header.Set("Content-Type", "application/json")
 
-		resp, err := a.httpClient.Do(req)
+		resp, err := doRequest(a.httpClient, req)
 		if err != nil {
 			return fmt.Errorf("failed to set inventory: %w", err)
 		}
@@ -351,6 +353,94 @@ func (a *ShopifyAdapter) CreateFulfillment(ctx context.Context, channel *domain.
 	})
 }
 
+// PushShipmentStatus records a carrier milestone as an event on the Shopify fulfillment
+// carrying the tracking number
+func (a *ShopifyAdapter) PushShipmentStatus(ctx context.Context, channel *domain.Channel, externalOrderID string, update domain.ShipmentStatusUpdate) error {
+	url := fmt.Sprintf(
+		"https://%s/admin/api/2024-01/orders/%s/fulfillments.json",
+		channel.Credentials.StoreDomain,
+		externalOrderID,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Shopify-Access-Token", channel.Credentials.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doRequest(a.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("failed to get fulfillments: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get fulfillments: status %d", resp.StatusCode)
+	}
+
+	var fResponse struct {
+		Fulfillments []struct {
+			ID             int64  `json:"id"`
+			TrackingNumber string `json:"tracking_number"`
+		} `json:"fulfillments"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&fResponse); err != nil {
+		return fmt.Errorf("failed to decode fulfillments: %w", err)
+	}
+
+	var fulfillmentID int64
+	for _, f := range fResponse.Fulfillments {
+		if f.TrackingNumber == update.TrackingNumber {
+			fulfillmentID = f.ID
+			break
+		}
+	}
+
+	if fulfillmentID == 0 {
+		return fmt.Errorf("no fulfillment found for tracking number %s", update.TrackingNumber)
+	}
+
+	// Create fulfillment event
+	eventURL := fmt.Sprintf(
+		"https://%s/admin/api/2024-01/orders/%s/fulfillments/%d/events.json",
+		channel.Credentials.StoreDomain,
+		externalOrderID,
+		fulfillmentID,
+	)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"event": map[string]interface{}{
+			"status": update.Status,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err = http.NewRequestWithContext(ctx, "POST", eventURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Shopify-Access-Token", channel.Credentials.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err = doRequest(a.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("failed to create fulfillment event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to create fulfillment event: status %d, body: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
 // RegisterWebhooks registers webhooks with Shopify
 func (a *ShopifyAdapter) RegisterWebhooks(ctx context.Context, channel *domain.Channel, webhookURL string) error {
 	topics := []string{
@@ -387,7 +477,7 @@ func (a *ShopifyAdapter) RegisterWebhooks(ctx context.Context, channel *domain.C
 		req.Header.Set("X-Shopify-Access-Token", channel.Credentials.AccessToken)
 		req.Header.Set("Content-Type", "application/json")
 
-		resp, err := a.httpClient.Do(req)
+		resp, err := doRequest(a.httpClient, req)
 		if err != nil {
 			return fmt.Errorf("failed to register webhook %s: %w", topic, err)
 		}
@@ -415,6 +505,36 @@ func (a *ShopifyAdapter) ValidateWebhook(ctx context.Context, channel *domain.Ch
 	return hmac.Equal([]byte(signature), []byte(expectedMAC))
 }
 
+// RefreshCredentials exchanges the refresh token of an expiring offline access token.
+// Non-expiring offline tokens come without a refresh token and cannot be refreshed.
+func (a *ShopifyAdapter) RefreshCredentials(ctx context.Context, creds domain.ChannelCredentials) (*domain.TokenRefresh, error) {
+	if creds.RefreshToken == "" {
+		return nil, domain.ErrTokenRefreshNotSupported
+	}
+
+	// The app's API key and secret double as OAuth client credentials
+	clientID, clientSecret := creds.ClientID, creds.ClientSecret
+	if clientID == "" {
+		clientID, clientSecret = creds.APIKey, creds.APISecret
+	}
+
+	data := url.Values{}
+	data.Set("grant_type", "refresh_token")
+	data.Set("refresh_token", creds.RefreshToken)
+	data.Set("client_id", clientID)
+	data.Set("client_secret", clientSecret)
+
+	endpoint := fmt.Sprintf("https://%s/admin/oauth/access_token", creds.StoreDomain)
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	return requestToken(a.httpClient, req)
+}
+
 // Helper methods
 
 func (a *ShopifyAdapter) getInventoryItemID(ctx context.Context, channel *domain.Channel, variantID string) (int64, error) {
@@ -432,7 +552,7 @@ func (a *ShopifyAdapter) getInventoryItemID(ctx context.Context, channel *domain
 	req.Header.Set("X-Shopify-Access-Token", channel.Credentials.AccessToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return 0, err
 	}
@@ -401,6 +401,55 @@ func TestShopifyPushTrackingNoOpenOrder(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestShopifyPushShipmentStatus(t *testing.T) {
+	var status string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/admin/api/2024-01/orders/10/fulfillments.json":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"fulfillments": []map[string]any{
+					{"id": 55, "tracking_number": "track-old"},
+					{"id": 56, "tracking_number": "track-1"},
+				},
+			})
+		case "/admin/api/2024-01/orders/10/fulfillments/56/events.json":
+			var body struct {
+				Event struct {
+					Status string `json:"status"`
+				} `json:"event"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			status = body.Event.Status
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	adapter := newShopifyTestAdapter(server)
+	channel := &domain.Channel{
+		Credentials: domain.ChannelCredentials{
+			StoreDomain: server.Listener.Addr().String(),
+			AccessToken: "token",
+		},
+	}
+
+	err := adapter.PushShipmentStatus(context.Background(), channel, "10", domain.ShipmentStatusUpdate{
+		TrackingNumber: "track-1",
+		Carrier:        "UPS",
+		Status:         "out_for_delivery",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "out_for_delivery", status)
+
+	err = adapter.PushShipmentStatus(context.Background(), channel, "10", domain.ShipmentStatusUpdate{
+		TrackingNumber: "track-unknown",
+		Status:         "delivered",
+	})
+	require.Error(t, err)
+}
+
 func TestShopifySyncInventory(t *testing.T) {
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
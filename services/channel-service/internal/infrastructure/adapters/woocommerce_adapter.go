@@ -7,6 +7,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -93,7 +94,7 @@ func (a *WooCommerceAdapter) FetchOrders(ctx context.Context, channel *domain.Ch
 	}
 	a.setAuth(req, channel.Credentials)
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -214,7 +215,7 @@ func (a *WooCommerceAdapter) FetchOrder(ctx context.Context, channel *domain.Cha
 	}
 	a.setAuth(req, channel.Credentials)
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return nil, err
 	}
@@ -279,7 +280,7 @@ func (a *WooCommerceAdapter) PushTracking(ctx context.Context, channel *domain.C
 	}
 	a.setAuth(req, channel.Credentials)
 
-	resp, err := a.httpClient.Do(req)
+	resp, err := doRequest(a.httpClient, req)
 	if err != nil {
 		return err
 	}
@@ -305,7 +306,7 @@ func (a *WooCommerceAdapter) PushTracking(ctx context.Context, channel *domain.C
 	}
 	a.setAuth(noteHttpReq, channel.Credentials)
 
-	noteResp, err := a.httpClient.Do(noteHttpReq)
+	noteResp, err := doRequest(a.httpClient, noteHttpReq)
 	if err != nil {
 		return err
 	}
@@ -319,6 +320,37 @@ func (a *WooCommerceAdapter) PushTracking(ctx context.Context, channel *domain.C
 	return nil
 }
 
+// PushShipmentStatus adds an order note with the carrier milestone, as WooCommerce has no
+// built-in shipment tracking
+func (a *WooCommerceAdapter) PushShipmentStatus(ctx context.Context, channel *domain.Channel, externalOrderID string, update domain.ShipmentStatusUpdate) error {
+	noteEndpoint := a.buildURL(channel.Credentials.ShopURL, "/wp-json/wc/v3/orders/"+externalOrderID+"/notes")
+
+	noteReq := map[string]interface{}{
+		"note":          fmt.Sprintf("Shipment %s via %s: %s", update.TrackingNumber, update.Carrier, strings.ReplaceAll(update.Status, "_", " ")),
+		"customer_note": true,
+	}
+
+	noteBody, _ := json.Marshal(noteReq)
+	req, err := http.NewRequestWithContext(ctx, "POST", noteEndpoint, bytes.NewReader(noteBody))
+	if err != nil {
+		return err
+	}
+	a.setAuth(req, channel.Credentials)
+
+	resp, err := doRequest(a.httpClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to add shipment status note: %s", string(respBody))
+	}
+
+	return nil
+}
+
 func (a *WooCommerceAdapter) SyncInventory(ctx context.Context, channel *domain.Channel, items []domain.InventoryUpdate) error {
 	for _, item := range items {
 		// Find product by SKU
@@ -331,7 +363,10 @@ func (a *WooCommerceAdapter) SyncInventory(ctx context.Context, channel *domain.
 		}
 		a.setAuth(searchReq, channel.Credentials)
 
-		searchResp, err := a.httpClient.Do(searchReq)
+		searchResp, err := doRequest(a.httpClient, searchReq)
+		if errors.Is(err, domain.ErrChannelUnauthorized) {
+			return err
+		}
 		if err != nil {
 			continue
 		}
@@ -366,7 +401,10 @@ func (a *WooCommerceAdapter) SyncInventory(ctx context.Context, channel *domain.
 		}
 		a.setAuth(req, channel.Credentials)
 
-		resp, err := a.httpClient.Do(req)
+		resp, err := doRequest(a.httpClient, req)
+		if errors.Is(err, domain.ErrChannelUnauthorized) {
+			return err
+		}
 		if err != nil {
 			continue
 		}
@@ -389,7 +427,10 @@ func (a *WooCommerceAdapter) GetInventoryLevels(ctx context.Context, channel *do
 		}
 		a.setAuth(req, channel.Credentials)
 
-		resp, err := a.httpClient.Do(req)
+		resp, err := doRequest(a.httpClient, req)
+		if errors.Is(err, domain.ErrChannelUnauthorized) {
+			return nil, err
+		}
 		if err != nil {
 			continue
 		}
@@ -452,7 +493,10 @@ func (a *WooCommerceAdapter) RegisterWebhooks(ctx context.Context, channel *doma
 		}
 		a.setAuth(req, channel.Credentials)
 
-		resp, err := a.httpClient.Do(req)
+		resp, err := doRequest(a.httpClient, req)
+		if errors.Is(err, domain.ErrChannelUnauthorized) {
+			return err
+		}
 		if err != nil {
 			continue
 		}
@@ -480,3 +524,8 @@ func (a *WooCommerceAdapter) ValidateWebhook(ctx context.Context, channel *domai
 
 	return hmac.Equal([]byte(signature), []byte(expectedSig))
 }
+
+// RefreshCredentials is not supported; WooCommerce consumer keys do not expire
+func (a *WooCommerceAdapter) RefreshCredentials(ctx context.Context, creds domain.ChannelCredentials) (*domain.TokenRefresh, error) {
+	return nil, domain.ErrTokenRefreshNotSupported
+}
@@ -284,6 +284,40 @@ func TestWooCommercePushTracking(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestWooCommercePushShipmentStatus(t *testing.T) {
+	var note string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wp-json/wc/v3/orders/10/notes" {
+			var body struct {
+				Note string `json:"note"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			note = body.Note
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	adapter := newWooTestAdapter(server)
+	channel := &domain.Channel{
+		Credentials: domain.ChannelCredentials{
+			ShopURL:   server.URL,
+			APIKey:    "key",
+			APISecret: "secret",
+		},
+	}
+
+	err := adapter.PushShipmentStatus(context.Background(), channel, "10", domain.ShipmentStatusUpdate{
+		TrackingNumber: "track-1",
+		Carrier:        "UPS",
+		Status:         "out_for_delivery",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Shipment track-1 via UPS: out for delivery", note)
+}
+
 func TestWooCommercePushTrackingError(t *testing.T) {
 	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
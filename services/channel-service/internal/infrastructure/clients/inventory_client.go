@@ -0,0 +1,94 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// inventoryPageSize is the number of inventory items requested per page
+const inventoryPageSize = 200
+
+// InventoryServiceClient handles communication with inventory-service
+// Implements domain.InventorySource interface
+type InventoryServiceClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewInventoryServiceClient creates a new InventoryServiceClient
+func NewInventoryServiceClient(baseURL string) *InventoryServiceClient {
+	return &InventoryServiceClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+type inventoryListItem struct {
+	SKU                 string `json:"sku"`
+	TotalQuantity       int    `json:"totalQuantity"`
+	AvailableQuantity   int    `json:"availableQuantity"`
+	NonSellableQuantity int    `json:"nonSellableQuantity"` // Damaged, quarantined, on hold, etc.
+	InTransitQuantity   int    `json:"inTransitQuantity"`   // On carts or totes mid-move
+}
+
+// GetInventoryLevels returns on-hand and available quantity for every SKU in the channel's
+// warehouse. Stock in non-sellable statuses is left out of both, so channels never list it,
+// and so is stock on a cart or tote until it is dropped at its new location.
+func (c *InventoryServiceClient) GetInventoryLevels(ctx context.Context, channel *domain.Channel) ([]domain.InventoryUpdate, error) {
+	if err := requireFulfillmentContext(channel); err != nil {
+		return nil, err
+	}
+
+	levels := make([]domain.InventoryUpdate, 0)
+	for offset := 0; ; offset += inventoryPageSize {
+		items, err := c.listInventory(ctx, channel, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			levels = append(levels, domain.InventoryUpdate{
+				SKU:       item.SKU,
+				Quantity:  item.TotalQuantity - item.NonSellableQuantity - item.InTransitQuantity,
+				Available: item.AvailableQuantity,
+			})
+		}
+
+		if len(items) < inventoryPageSize {
+			return levels, nil
+		}
+	}
+}
+
+func (c *InventoryServiceClient) listInventory(ctx context.Context, channel *domain.Channel, offset int) ([]inventoryListItem, error) {
+	url := fmt.Sprintf("%s/api/v1/inventory?limit=%d&offset=%d", c.baseURL, inventoryPageSize, offset)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	setTenantHeaders(req, channel)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("inventory service returned status %d", resp.StatusCode)
+	}
+
+	var items []inventoryListItem
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,44 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+func TestGetInventoryLevelsExcludesNonSellableStock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/api/v1/inventory", r.URL.Path)
+		require.Equal(t, "WH-1", r.Header.Get(middleware.HeaderWMSWarehouseID))
+
+		_, _ = w.Write([]byte(`[
+			{"sku":"WIDGET-1","totalQuantity":20,"availableQuantity":12,"nonSellableQuantity":5},
+			{"sku":"GADGET-2","totalQuantity":8,"availableQuantity":8},
+			{"sku":"GIZMO-3","totalQuantity":10,"availableQuantity":6,"inTransitQuantity":4}
+		]`))
+	}))
+	defer server.Close()
+
+	channel := &domain.Channel{
+		ChannelID:    "CH-1",
+		TenantID:     "tenant-1",
+		FacilityID:   "FAC-1",
+		SyncSettings: domain.SyncSettings{DefaultWarehouseID: "WH-1"},
+	}
+
+	client := NewInventoryServiceClient(server.URL)
+	levels, err := client.GetInventoryLevels(context.Background(), channel)
+	require.NoError(t, err)
+	require.Equal(t, []domain.InventoryUpdate{
+		{SKU: "WIDGET-1", Quantity: 15, Available: 12},
+		{SKU: "GADGET-2", Quantity: 8, Available: 8},
+		{SKU: "GIZMO-3", Quantity: 6, Available: 6},
+	}, levels)
+}
@@ -0,0 +1,226 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/idempotency"
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// defaultPromiseWindow is the delivery promise given to imported orders
+const defaultPromiseWindow = 5 * 24 * time.Hour
+
+// OrderServiceClient handles communication with order-service
+// Implements domain.OrderCreator interface
+type OrderServiceClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOrderServiceClient creates a new OrderServiceClient
+func NewOrderServiceClient(baseURL string) *OrderServiceClient {
+	return &OrderServiceClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+type createOrderItem struct {
+	SKU       string  `json:"sku"`
+	Name      string  `json:"name"`
+	Quantity  int     `json:"quantity"`
+	Weight    float64 `json:"weight"`
+	UnitPrice float64 `json:"unitPrice"`
+}
+
+type createOrderAddress struct {
+	Street        string `json:"street"`
+	City          string `json:"city"`
+	State         string `json:"state"`
+	ZipCode       string `json:"zipCode"`
+	Country       string `json:"country"`
+	Phone         string `json:"phone,omitempty"`
+	RecipientName string `json:"recipientName"`
+}
+
+type createOrderRequest struct {
+	CustomerID         string             `json:"customerId"`
+	Items              []createOrderItem  `json:"items"`
+	ShippingAddress    createOrderAddress `json:"shippingAddress"`
+	Priority           string             `json:"priority"`
+	PromisedDeliveryAt time.Time          `json:"promisedDeliveryAt"`
+	ExternalOrderID    string             `json:"externalOrderId"`
+}
+
+type createOrderResponse struct {
+	Order struct {
+		OrderID string `json:"orderId"`
+	} `json:"order"`
+}
+
+// CreateOrder creates a WMS order for a channel order and returns the WMS order ID
+func (c *OrderServiceClient) CreateOrder(ctx context.Context, channel *domain.Channel, order *domain.ChannelOrder) (string, error) {
+	if err := requireFulfillmentContext(channel); err != nil {
+		return "", err
+	}
+
+	return c.postOrder(ctx, toCreateOrderRequest(order, time.Now()), func(req *http.Request) {
+		setTenantHeaders(req, channel)
+	})
+}
+
+// CreateEDIOrder creates a WMS order for an 850 or 940 received from a trading partner
+// and returns the WMS order ID. Implements domain.EDIOrderCreator interface.
+// The document's idempotency key is sent as Idempotency-Key, and the request is built only
+// from the document, so order-service replays the original order for a repeated request.
+func (c *OrderServiceClient) CreateEDIOrder(ctx context.Context, partner *domain.TradingPartner, doc *domain.EDIDocument) (string, error) {
+	return c.postOrder(ctx, toCreateEDIOrderRequest(partner, doc.Order, doc.CreatedAt), func(req *http.Request) {
+		setPartnerHeaders(req, partner)
+		req.Header.Set(idempotency.HeaderIdempotencyKey, doc.IdempotencyKey())
+	})
+}
+
+func (c *OrderServiceClient) postOrder(ctx context.Context, request createOrderRequest, setHeaders func(*http.Request)) (string, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/orders", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create order: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("order service returned status %d", resp.StatusCode)
+	}
+
+	var result createOrderResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.Order.OrderID == "" {
+		return "", fmt.Errorf("order service returned no order ID")
+	}
+
+	return result.Order.OrderID, nil
+}
+
+func toCreateOrderRequest(order *domain.ChannelOrder, now time.Time) createOrderRequest {
+	items := make([]createOrderItem, 0, len(order.LineItems))
+	for _, item := range order.LineItems {
+		if !item.RequiresShipping {
+			continue
+		}
+		items = append(items, createOrderItem{
+			SKU:       item.SKU,
+			Name:      item.Title,
+			Quantity:  item.Quantity,
+			Weight:    float64(item.Grams) / 1000, // kg
+			UnitPrice: item.Price,
+		})
+	}
+
+	customerID := order.Customer.ExternalID
+	if customerID == "" {
+		customerID = order.Customer.Email
+	}
+
+	addr := order.ShippingAddr
+	return createOrderRequest{
+		CustomerID: customerID,
+		Items:      items,
+		ShippingAddress: createOrderAddress{
+			Street:        strings.TrimSpace(addr.Address1 + " " + addr.Address2),
+			City:          addr.City,
+			State:         addr.Province,
+			ZipCode:       addr.Zip,
+			Country:       addr.Country,
+			Phone:         addr.Phone,
+			RecipientName: strings.TrimSpace(addr.FirstName + " " + addr.LastName),
+		},
+		Priority:           "standard",
+		PromisedDeliveryAt: now.Add(defaultPromiseWindow).UTC(),
+		ExternalOrderID:    order.ExternalOrderID,
+	}
+}
+
+// toCreateEDIOrderRequest maps an EDI order to an order-service order. The trading partner is
+// the customer and the PO number is the external order ID. The promise is counted from when
+// the order was received.
+func toCreateEDIOrderRequest(partner *domain.TradingPartner, order *domain.EDIOrder, receivedAt time.Time) createOrderRequest {
+	items := make([]createOrderItem, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		items = append(items, createOrderItem{
+			SKU:       line.SKU,
+			Name:      line.Description,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+		})
+	}
+
+	promised := receivedAt.Add(defaultPromiseWindow)
+	if order.RequestedShipDate != nil && order.RequestedShipDate.After(promised) {
+		promised = *order.RequestedShipDate
+	}
+
+	shipTo := order.ShipTo
+	return createOrderRequest{
+		CustomerID: partner.PartnerID,
+		Items:      items,
+		ShippingAddress: createOrderAddress{
+			Street:        strings.TrimSpace(shipTo.Address1 + " " + shipTo.Address2),
+			City:          shipTo.City,
+			State:         shipTo.State,
+			ZipCode:       shipTo.PostalCode,
+			Country:       shipTo.Country,
+			RecipientName: shipTo.Name,
+		},
+		Priority:           "standard",
+		PromisedDeliveryAt: promised.UTC(),
+		ExternalOrderID:    order.PurchaseOrderNumber,
+	}
+}
+
+// requireFulfillmentContext checks the channel has the facility and warehouse WMS APIs are scoped to
+func requireFulfillmentContext(channel *domain.Channel) error {
+	if channel.FacilityID == "" || channel.SyncSettings.DefaultWarehouseID == "" {
+		return fmt.Errorf("channel %s has no facility or default warehouse configured", channel.ChannelID)
+	}
+	return nil
+}
+
+// setTenantHeaders scopes a request to the channel's tenant, facility, warehouse and seller
+func setTenantHeaders(req *http.Request, channel *domain.Channel) {
+	req.Header.Set(middleware.HeaderWMSTenantID, channel.TenantID)
+	req.Header.Set(middleware.HeaderWMSFacilityID, channel.FacilityID)
+	req.Header.Set(middleware.HeaderWMSWarehouseID, channel.SyncSettings.DefaultWarehouseID)
+	req.Header.Set(middleware.HeaderWMSSellerID, channel.SellerID)
+	req.Header.Set(middleware.HeaderWMSChannelID, channel.ChannelID)
+}
+
+// setPartnerHeaders scopes a request to the trading partner's tenant, facility, warehouse and seller
+func setPartnerHeaders(req *http.Request, partner *domain.TradingPartner) {
+	req.Header.Set(middleware.HeaderWMSTenantID, partner.TenantID)
+	req.Header.Set(middleware.HeaderWMSFacilityID, partner.FacilityID)
+	req.Header.Set(middleware.HeaderWMSWarehouseID, partner.WarehouseID)
+	req.Header.Set(middleware.HeaderWMSSellerID, partner.SellerID)
+}
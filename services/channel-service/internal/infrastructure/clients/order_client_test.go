@@ -0,0 +1,188 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+func newFulfillmentChannel(t *testing.T) *domain.Channel {
+	t.Helper()
+	channel, err := domain.NewChannel("tenant-1", "seller-1", domain.ChannelTypeShopify, "Test", "", domain.ChannelCredentials{}, domain.SyncSettings{
+		DefaultWarehouseID: "WH-1",
+	})
+	require.NoError(t, err)
+	channel.FacilityID = "FAC-1"
+	return channel
+}
+
+func TestCreateOrder(t *testing.T) {
+	channel := newFulfillmentChannel(t)
+	var received createOrderRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/orders", r.URL.Path)
+		require.Equal(t, "tenant-1", r.Header.Get(middleware.HeaderWMSTenantID))
+		require.Equal(t, "FAC-1", r.Header.Get(middleware.HeaderWMSFacilityID))
+		require.Equal(t, "WH-1", r.Header.Get(middleware.HeaderWMSWarehouseID))
+		require.Equal(t, "seller-1", r.Header.Get(middleware.HeaderWMSSellerID))
+		require.Equal(t, channel.ChannelID, r.Header.Get(middleware.HeaderWMSChannelID))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"order":{"orderId":"ORD-123"}}`))
+	}))
+	defer server.Close()
+
+	order := &domain.ChannelOrder{
+		ExternalOrderID: "1001",
+		Customer:        domain.ChannelCustomer{Email: "buyer@example.com"},
+		ShippingAddr: domain.ChannelAddress{
+			FirstName: "Jane",
+			LastName:  "Doe",
+			Address1:  "1 Main St",
+			Address2:  "Apt 2",
+			City:      "Springfield",
+			Province:  "IL",
+			Zip:       "62701",
+			Country:   "US",
+		},
+		LineItems: []domain.ChannelLineItem{
+			{SKU: "SKU-1", Title: "Widget", Quantity: 2, Price: 9.99, Grams: 500, RequiresShipping: true},
+			{SKU: "GIFT-CARD", Title: "Gift card", Quantity: 1, Price: 25},
+		},
+	}
+
+	client := NewOrderServiceClient(server.URL)
+	orderID, err := client.CreateOrder(context.Background(), channel, order)
+	require.NoError(t, err)
+	require.Equal(t, "ORD-123", orderID)
+
+	require.Equal(t, "buyer@example.com", received.CustomerID)
+	require.Equal(t, "1001", received.ExternalOrderID)
+	require.Equal(t, "1 Main St Apt 2", received.ShippingAddress.Street)
+	require.Equal(t, "Jane Doe", received.ShippingAddress.RecipientName)
+	require.Len(t, received.Items, 1)
+	require.Equal(t, "SKU-1", received.Items[0].SKU)
+	require.InDelta(t, 0.5, received.Items[0].Weight, 0.0001)
+}
+
+func TestCreateOrderErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := NewOrderServiceClient(server.URL)
+	_, err := client.CreateOrder(context.Background(), newFulfillmentChannel(t), &domain.ChannelOrder{ExternalOrderID: "1001"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 400")
+}
+
+func TestCreateOrderRequiresFulfillmentContext(t *testing.T) {
+	channel := newFulfillmentChannel(t)
+	channel.FacilityID = ""
+
+	client := NewOrderServiceClient("http://unused")
+	_, err := client.CreateOrder(context.Background(), channel, &domain.ChannelOrder{})
+	require.Error(t, err)
+}
+
+func newTestTradingPartner() *domain.TradingPartner {
+	return &domain.TradingPartner{
+		PartnerID:   "TP-1",
+		TenantID:    "tenant-1",
+		SellerID:    "seller-1",
+		FacilityID:  "FAC-1",
+		WarehouseID: "WH-1",
+		Name:        "Acme B2B",
+	}
+}
+
+func TestCreateEDIOrder(t *testing.T) {
+	var received createOrderRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/orders", r.URL.Path)
+		require.Equal(t, "tenant-1", r.Header.Get(middleware.HeaderWMSTenantID))
+		require.Equal(t, "FAC-1", r.Header.Get(middleware.HeaderWMSFacilityID))
+		require.Equal(t, "WH-1", r.Header.Get(middleware.HeaderWMSWarehouseID))
+		require.Equal(t, "seller-1", r.Header.Get(middleware.HeaderWMSSellerID))
+		require.Equal(t, "edi-TP-1-000000101-55-0001", r.Header.Get("Idempotency-Key"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"order":{"orderId":"ORD-940"}}`))
+	}))
+	defer server.Close()
+
+	order := &domain.EDIOrder{
+		PurchaseOrderNumber: "PO-4500012",
+		ShipTo: domain.EDIParty{
+			Name: "Acme Store 12", Address1: "500 Commerce Dr", City: "Dallas", State: "TX", PostalCode: "75201", Country: "US",
+		},
+		Lines: []domain.EDIOrderLine{{SKU: "WIDGET-1", Description: "Blue widget", Quantity: 12, UnitPrice: 2.5}},
+	}
+
+	receivedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	doc := &domain.EDIDocument{
+		PartnerID:                "TP-1",
+		InterchangeControlNumber: "000000101",
+		GroupControlNumber:       "55",
+		TransactionControlNumber: "0001",
+		Order:                    order,
+		CreatedAt:                receivedAt,
+	}
+
+	client := NewOrderServiceClient(server.URL)
+	orderID, err := client.CreateEDIOrder(context.Background(), newTestTradingPartner(), doc)
+	require.NoError(t, err)
+	require.Equal(t, "ORD-940", orderID)
+
+	// Retries send the same request so order-service can replay the created order
+	require.Equal(t, receivedAt.Add(defaultPromiseWindow), received.PromisedDeliveryAt)
+
+	require.Equal(t, "TP-1", received.CustomerID)
+	require.Equal(t, "PO-4500012", received.ExternalOrderID)
+	require.Equal(t, "Acme Store 12", received.ShippingAddress.RecipientName)
+	require.Equal(t, "75201", received.ShippingAddress.ZipCode)
+	require.Len(t, received.Items, 1)
+	require.Equal(t, 12, received.Items[0].Quantity)
+}
+
+func TestGetInventoryLevelsPages(t *testing.T) {
+	var offsets []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/inventory", r.URL.Path)
+		offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+		require.NoError(t, err)
+		offsets = append(offsets, offset)
+
+		items := make([]inventoryListItem, 0)
+		count := inventoryPageSize
+		if offset > 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			items = append(items, inventoryListItem{SKU: "SKU-" + strconv.Itoa(offset+i), TotalQuantity: 10, AvailableQuantity: 7})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(items))
+	}))
+	defer server.Close()
+
+	client := NewInventoryServiceClient(server.URL)
+	levels, err := client.GetInventoryLevels(context.Background(), newFulfillmentChannel(t))
+	require.NoError(t, err)
+	require.Equal(t, []int{0, inventoryPageSize}, offsets)
+	require.Len(t, levels, inventoryPageSize+1)
+	require.Equal(t, 10, levels[0].Quantity)
+	require.Equal(t, 7, levels[0].Available)
+}
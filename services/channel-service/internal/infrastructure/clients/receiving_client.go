@@ -0,0 +1,155 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// ReceivingServiceClient handles communication with receiving-service
+// Implements domain.InboundShipmentCreator interface
+type ReceivingServiceClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewReceivingServiceClient creates a new ReceivingServiceClient
+func NewReceivingServiceClient(baseURL string) *ReceivingServiceClient {
+	return &ReceivingServiceClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+type createShipmentASN struct {
+	ASNID           string    `json:"asnId"`
+	CarrierName     string    `json:"carrierName"`
+	TrackingNumber  string    `json:"trackingNumber,omitempty"`
+	ExpectedArrival time.Time `json:"expectedArrival"`
+	ContainerCount  int       `json:"containerCount"`
+	TotalWeight     float64   `json:"totalWeight"`
+}
+
+type createShipmentSupplier struct {
+	SupplierID   string `json:"supplierId"`
+	SupplierName string `json:"supplierName"`
+}
+
+type createShipmentItem struct {
+	SKU              string `json:"sku"`
+	ProductName      string `json:"productName"`
+	ExpectedQuantity int    `json:"expectedQuantity"`
+}
+
+type createShipmentRequest struct {
+	ShipmentID      string                 `json:"shipmentId"`
+	PurchaseOrderID string                 `json:"purchaseOrderId,omitempty"`
+	ASN             createShipmentASN      `json:"asn"`
+	Supplier        createShipmentSupplier `json:"supplier"`
+	ExpectedItems   []createShipmentItem   `json:"expectedItems"`
+}
+
+type createShipmentResponse struct {
+	ShipmentID string `json:"shipmentId"`
+}
+
+// CreateInboundShipment creates an inbound shipment with its ASN for an 856 received from a
+// trading partner and returns the shipment ID
+func (c *ReceivingServiceClient) CreateInboundShipment(ctx context.Context, partner *domain.TradingPartner, notice *domain.EDIShipNotice) (string, error) {
+	body, err := json.Marshal(toCreateShipmentRequest(partner, notice, time.Now()))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/shipments", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setPartnerHeaders(req, partner)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create inbound shipment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("receiving service returned status %d", resp.StatusCode)
+	}
+
+	var result createShipmentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.ShipmentID == "" {
+		return "", fmt.Errorf("receiving service returned no shipment ID")
+	}
+
+	return result.ShipmentID, nil
+}
+
+// toCreateShipmentRequest maps a ship notice to a receiving-service shipment. Partners number
+// their ship notices independently, so the shipment ID is prefixed with the partner ID.
+func toCreateShipmentRequest(partner *domain.TradingPartner, notice *domain.EDIShipNotice, now time.Time) createShipmentRequest {
+	carrier := notice.CarrierName
+	if carrier == "" {
+		carrier = notice.CarrierSCAC
+	}
+
+	expectedArrival := now
+	switch {
+	case notice.ExpectedArrival != nil:
+		expectedArrival = *notice.ExpectedArrival
+	case notice.ShipDate != nil:
+		expectedArrival = *notice.ShipDate
+	}
+
+	tracking := notice.TrackingNumber
+	if tracking == "" {
+		tracking = notice.BillOfLading
+	}
+
+	supplier := createShipmentSupplier{SupplierID: partner.PartnerID, SupplierName: partner.Name}
+	if notice.ShipFrom.Identifier != "" {
+		supplier.SupplierID = notice.ShipFrom.Identifier
+	}
+	if notice.ShipFrom.Name != "" {
+		supplier.SupplierName = notice.ShipFrom.Name
+	}
+
+	items := make([]createShipmentItem, 0, len(notice.Lines))
+	for _, line := range notice.Lines {
+		name := line.Description
+		if name == "" {
+			name = line.SKU
+		}
+		items = append(items, createShipmentItem{
+			SKU:              line.SKU,
+			ProductName:      name,
+			ExpectedQuantity: line.Quantity,
+		})
+	}
+
+	return createShipmentRequest{
+		ShipmentID:      fmt.Sprintf("%s-%s", partner.PartnerID, notice.ShipmentID),
+		PurchaseOrderID: notice.PurchaseOrderNumber,
+		ASN: createShipmentASN{
+			ASNID:           notice.ShipmentID,
+			CarrierName:     carrier,
+			TrackingNumber:  tracking,
+			ExpectedArrival: expectedArrival.UTC(),
+			ContainerCount:  notice.CartonCount,
+			TotalWeight:     notice.TotalWeight,
+		},
+		Supplier:      supplier,
+		ExpectedItems: items,
+	}
+}
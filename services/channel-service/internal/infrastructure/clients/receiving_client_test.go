@@ -0,0 +1,74 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+func TestCreateInboundShipment(t *testing.T) {
+	var received createShipmentRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/shipments", r.URL.Path)
+		require.Equal(t, "tenant-1", r.Header.Get(middleware.HeaderWMSTenantID))
+		require.Equal(t, "WH-1", r.Header.Get(middleware.HeaderWMSWarehouseID))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"shipmentId":"TP-1-ASN-3321"}`))
+	}))
+	defer server.Close()
+
+	arrival := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	notice := &domain.EDIShipNotice{
+		ShipmentID:          "ASN-3321",
+		ExpectedArrival:     &arrival,
+		CarrierSCAC:         "ODFL",
+		BillOfLading:        "BOL-99812",
+		PurchaseOrderNumber: "PO-4500099",
+		CartonCount:         4,
+		TotalWeight:         49.9,
+		Lines: []domain.EDIShipNoticeLine{
+			{SKU: "WIDGET-1", Quantity: 48},
+			{SKU: "GADGET-2", Description: "Gadget", Quantity: 24},
+		},
+	}
+
+	client := NewReceivingServiceClient(server.URL)
+	shipmentID, err := client.CreateInboundShipment(context.Background(), newTestTradingPartner(), notice)
+	require.NoError(t, err)
+	require.Equal(t, "TP-1-ASN-3321", shipmentID)
+
+	require.Equal(t, "TP-1-ASN-3321", received.ShipmentID)
+	require.Equal(t, "PO-4500099", received.PurchaseOrderID)
+	require.Equal(t, "ASN-3321", received.ASN.ASNID)
+	require.Equal(t, "ODFL", received.ASN.CarrierName)
+	require.Equal(t, "BOL-99812", received.ASN.TrackingNumber)
+	require.True(t, arrival.Equal(received.ASN.ExpectedArrival))
+	require.Equal(t, "TP-1", received.Supplier.SupplierID)
+	require.Equal(t, "Acme B2B", received.Supplier.SupplierName)
+	require.Len(t, received.ExpectedItems, 2)
+	require.Equal(t, "WIDGET-1", received.ExpectedItems[0].ProductName)
+	require.Equal(t, "Gadget", received.ExpectedItems[1].ProductName)
+}
+
+func TestCreateInboundShipmentErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	client := NewReceivingServiceClient(server.URL)
+	_, err := client.CreateInboundShipment(context.Background(), newTestTradingPartner(), &domain.EDIShipNotice{ShipmentID: "ASN-1"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "status 409")
+}
@@ -0,0 +1,158 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/secrets"
+)
+
+// CredentialRotationResult summarizes a credential re-encryption run
+type CredentialRotationResult struct {
+	Scanned int `json:"scanned"`
+	Rotated int `json:"rotated"`
+	Failed  int `json:"failed"`
+
+	// Skipped counts channels whose credentials changed between the read and the
+	// update; running the rotation again picks them up
+	Skipped int `json:"skipped"`
+
+	// Errors describes each failed or skipped channel; messages never include credential values
+	Errors []string `json:"errors,omitempty"`
+}
+
+// SetCredentialCipher enables encryption of channel credentials at rest.
+// Without a cipher credentials are stored as plaintext.
+func (r *ChannelRepository) SetCredentialCipher(cipher *secrets.Cipher) {
+	r.cipher = cipher
+}
+
+// encryptCredentials returns a copy of the channel with its secret credentials encrypted,
+// leaving the caller's channel untouched
+func (r *ChannelRepository) encryptCredentials(ctx context.Context, channel *domain.Channel) (*domain.Channel, error) {
+	if r.cipher == nil {
+		return channel, nil
+	}
+
+	stored := *channel
+	for field, value := range stored.Credentials.SecretFields() {
+		encrypted, err := r.cipher.Encrypt(ctx, *value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt credential %s: %w", field, err)
+		}
+		*value = encrypted
+	}
+	return &stored, nil
+}
+
+// decryptCredentials decrypts a loaded channel's secret credentials in place
+func (r *ChannelRepository) decryptCredentials(ctx context.Context, channel *domain.Channel) error {
+	if r.cipher == nil {
+		return nil
+	}
+
+	for field, value := range channel.Credentials.SecretFields() {
+		decrypted, err := r.cipher.Decrypt(ctx, *value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt credential %s of channel %s: %w", field, channel.ChannelID, err)
+		}
+		*value = decrypted
+	}
+	return nil
+}
+
+// RotateCredentials re-encrypts every channel's secret credentials that are stored in
+// plaintext or with an old key version. Each update only applies while the stored
+// credentials still hold the values that were read, so a channel whose credentials are
+// rewritten concurrently (a token refresh or reconnect) is skipped rather than overwritten
+// with the stale token. With dryRun set nothing is written.
+func (r *ChannelRepository) RotateCredentials(ctx context.Context, batchSize int32, dryRun bool) (*CredentialRotationResult, error) {
+	if r.cipher == nil {
+		return nil, fmt.Errorf("credential cipher not configured")
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetBatchSize(batchSize))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	result := &CredentialRotationResult{}
+	for cursor.Next(ctx) {
+		var channel domain.Channel
+		if err := cursor.Decode(&channel); err != nil {
+			return result, err
+		}
+		result.Scanned++
+
+		update, current, err := r.rotatedCredentialFields(ctx, &channel)
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		if len(update) == 0 {
+			continue
+		}
+
+		if !dryRun {
+			current["channelId"] = channel.ChannelID
+			res, err := r.collection.UpdateOne(ctx, current, bson.M{"$set": update})
+			if err != nil {
+				result.Failed++
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to update channel %s: %v", channel.ChannelID, err))
+				continue
+			}
+			if res.MatchedCount == 0 {
+				result.Skipped++
+				result.Errors = append(result.Errors, fmt.Sprintf("credentials of channel %s changed during rotation; run again to rotate them", channel.ChannelID))
+				continue
+			}
+		}
+		result.Rotated++
+	}
+
+	return result, cursor.Err()
+}
+
+// rotatedCredentialFields returns the $set update for every credential that needs
+// rotating, and a filter matching those credentials' currently stored values
+func (r *ChannelRepository) rotatedCredentialFields(ctx context.Context, channel *domain.Channel) (bson.M, bson.M, error) {
+	update := bson.M{}
+	current := bson.M{}
+	for field, value := range channel.Credentials.SecretFields() {
+		rotated, changed, err := r.cipher.Rotate(ctx, *value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to rotate credential %s of channel %s: %w", field, channel.ChannelID, err)
+		}
+		if changed {
+			update["credentials."+field] = rotated
+			current["credentials."+field] = *value
+		}
+	}
+	return update, current, nil
+}
+
+// findChannels decodes every channel matching filter and decrypts its credentials
+func (r *ChannelRepository) findChannels(ctx context.Context, filter bson.M) ([]*domain.Channel, error) {
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var channels []*domain.Channel
+	if err := cursor.All(ctx, &channels); err != nil {
+		return nil, err
+	}
+	for _, channel := range channels {
+		if err := r.decryptCredentials(ctx, channel); err != nil {
+			return nil, err
+		}
+	}
+	return channels, nil
+}
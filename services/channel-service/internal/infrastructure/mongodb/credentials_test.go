@@ -0,0 +1,213 @@
+package mongodb
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/cloudevents"
+	"github.com/wms-platform/shared/pkg/secrets"
+)
+
+func newTestCipher(t *testing.T, keys map[int][]byte, current int) *secrets.Cipher {
+	t.Helper()
+	provider, err := secrets.NewLocalKeyProvider(keys, current)
+	require.NoError(t, err)
+	return secrets.NewCipher(provider)
+}
+
+func newTestKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := secrets.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func newEncryptingChannelRepository(mt *mtest.T, cipher *secrets.Cipher) *ChannelRepository {
+	repo := &ChannelRepository{
+		collection:       mt.DB.Collection("channels"),
+		outboxCollection: mt.DB.Collection("outbox"),
+		eventFactory:     cloudevents.NewEventFactory(cloudevents.SourceChannel),
+	}
+	repo.SetCredentialCipher(cipher)
+	return repo
+}
+
+func TestChannelRepository_EncryptsCredentials(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("save and find", func(mt *mtest.T) {
+		cipher := newTestCipher(mt.T, map[int][]byte{1: newTestKey(mt.T)}, 1)
+		repo := newEncryptingChannelRepository(mt, cipher)
+		ctx := context.Background()
+		ns := repo.collection.Database().Name() + "." + repo.collection.Name()
+
+		channel, err := domain.NewChannel("tenant-1", "seller-1", domain.ChannelTypeShopify, "Shop", "",
+			domain.ChannelCredentials{
+				StoreDomain:   "shop.myshopify.com",
+				AccessToken:   "shpat_plaintext",
+				WebhookSecret: "whsec_plaintext",
+			},
+			domain.SyncSettings{},
+		)
+		require.NoError(mt, err)
+		channel.ClearDomainEvents()
+
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			mtest.CreateSuccessResponse(),
+		)
+		require.NoError(mt, repo.Save(ctx, channel))
+
+		// The caller's channel keeps plaintext credentials
+		assert.Equal(mt, "shpat_plaintext", channel.Credentials.AccessToken)
+
+		var replace *bson.Raw
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			if event.CommandName == "update" {
+				replace = &event.Command
+			}
+		}
+		require.NotNil(mt, replace)
+		stored := replace.Lookup("updates", "0", "u", "credentials").Document()
+		assert.Equal(mt, "shop.myshopify.com", stored.Lookup("storeDomain").StringValue())
+		storedToken := stored.Lookup("accessToken").StringValue()
+		assert.True(mt, secrets.IsEncrypted(storedToken))
+		assert.False(mt, strings.Contains(replace.String(), "plaintext"))
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
+			{Key: "channelId", Value: channel.ChannelID},
+			{Key: "credentials", Value: bson.D{
+				{Key: "storeDomain", Value: "shop.myshopify.com"},
+				{Key: "accessToken", Value: storedToken},
+				{Key: "refreshToken", Value: "legacy-plaintext"},
+			}},
+		}))
+		found, err := repo.FindByID(ctx, channel.ChannelID)
+		require.NoError(mt, err)
+		assert.Equal(mt, "shpat_plaintext", found.Credentials.AccessToken)
+		assert.Equal(mt, "legacy-plaintext", found.Credentials.RefreshToken)
+	})
+
+	mt.Run("find with unknown key", func(mt *mtest.T) {
+		otherCipher := newTestCipher(mt.T, map[int][]byte{2: newTestKey(mt.T)}, 2)
+		encrypted, err := otherCipher.Encrypt(context.Background(), "secret")
+		require.NoError(mt, err)
+
+		repo := newEncryptingChannelRepository(mt, newTestCipher(mt.T, map[int][]byte{1: newTestKey(mt.T)}, 1))
+		ns := repo.collection.Database().Name() + "." + repo.collection.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
+			{Key: "channelId", Value: "ch-1"},
+			{Key: "credentials", Value: bson.D{{Key: "accessToken", Value: encrypted}}},
+		}))
+
+		_, err = repo.FindBySellerID(context.Background(), "seller-1")
+		require.ErrorIs(mt, err, secrets.ErrUnknownKeyVersion)
+	})
+}
+
+func TestChannelRepository_RotateCredentials(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("rotates old and plaintext values", func(mt *mtest.T) {
+		key1, key2 := newTestKey(mt.T), newTestKey(mt.T)
+		oldCipher := newTestCipher(mt.T, map[int][]byte{1: key1}, 1)
+		newCipher := newTestCipher(mt.T, map[int][]byte{1: key1, 2: key2}, 2)
+		ctx := context.Background()
+
+		oldToken, err := oldCipher.Encrypt(ctx, "token-1")
+		require.NoError(mt, err)
+		currentToken, err := newCipher.Encrypt(ctx, "token-2")
+		require.NoError(mt, err)
+
+		repo := newEncryptingChannelRepository(mt, newCipher)
+		ns := repo.collection.Database().Name() + "." + repo.collection.Name()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+				bson.D{
+					{Key: "channelId", Value: "ch-old"},
+					{Key: "credentials", Value: bson.D{
+						{Key: "accessToken", Value: oldToken},
+						{Key: "apiSecret", Value: "plaintext-secret"},
+					}},
+				},
+				bson.D{
+					{Key: "channelId", Value: "ch-current"},
+					{Key: "credentials", Value: bson.D{{Key: "accessToken", Value: currentToken}}},
+				},
+			),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+		)
+
+		result, err := repo.RotateCredentials(ctx, 100, false)
+		require.NoError(mt, err)
+		assert.Equal(mt, 2, result.Scanned)
+		assert.Equal(mt, 1, result.Rotated)
+		assert.Zero(mt, result.Failed)
+
+		var update *bson.Raw
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			if event.CommandName == "update" {
+				update = &event.Command
+			}
+		}
+		require.NotNil(mt, update)
+		filter := update.Lookup("updates", "0", "q").Document()
+		assert.Equal(mt, "ch-old", filter.Lookup("channelId").StringValue())
+		assert.Equal(mt, oldToken, filter.Lookup("credentials.accessToken").StringValue())
+		assert.Equal(mt, "plaintext-secret", filter.Lookup("credentials.apiSecret").StringValue())
+		set := update.Lookup("updates", "0", "u", "$set").Document()
+		rotatedToken := set.Lookup("credentials.accessToken").StringValue()
+		version, err := secrets.KeyVersion(rotatedToken)
+		require.NoError(mt, err)
+		assert.Equal(mt, 2, version)
+		decrypted, err := newCipher.Decrypt(ctx, set.Lookup("credentials.apiSecret").StringValue())
+		require.NoError(mt, err)
+		assert.Equal(mt, "plaintext-secret", decrypted)
+	})
+
+	mt.Run("skips credentials changed concurrently", func(mt *mtest.T) {
+		repo := newEncryptingChannelRepository(mt, newTestCipher(mt.T, map[int][]byte{1: newTestKey(mt.T)}, 1))
+		ns := repo.collection.Database().Name() + "." + repo.collection.Name()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
+				{Key: "channelId", Value: "ch-1"},
+				{Key: "credentials", Value: bson.D{{Key: "accessToken", Value: "plaintext"}}},
+			}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}),
+		)
+
+		result, err := repo.RotateCredentials(context.Background(), 100, false)
+		require.NoError(mt, err)
+		assert.Zero(mt, result.Rotated)
+		assert.Zero(mt, result.Failed)
+		assert.Equal(mt, 1, result.Skipped)
+		require.Len(mt, result.Errors, 1)
+		assert.NotContains(mt, result.Errors[0], "plaintext")
+	})
+
+	mt.Run("dry run", func(mt *mtest.T) {
+		repo := newEncryptingChannelRepository(mt, newTestCipher(mt.T, map[int][]byte{1: newTestKey(mt.T)}, 1))
+		ns := repo.collection.Database().Name() + "." + repo.collection.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
+			{Key: "channelId", Value: "ch-1"},
+			{Key: "credentials", Value: bson.D{{Key: "accessToken", Value: "plaintext"}}},
+		}))
+
+		result, err := repo.RotateCredentials(context.Background(), 100, true)
+		require.NoError(mt, err)
+		assert.Equal(mt, 1, result.Rotated)
+	})
+
+	mt.Run("requires cipher", func(mt *mtest.T) {
+		repo := &ChannelRepository{collection: mt.DB.Collection("channels")}
+		_, err := repo.RotateCredentials(context.Background(), 100, false)
+		require.Error(mt, err)
+	})
+}
@@ -0,0 +1,271 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// TradingPartnerRepository implements domain.TradingPartnerRepository
+type TradingPartnerRepository struct {
+	collection     *mongo.Collection
+	controlNumbers *mongo.Collection
+}
+
+// NewTradingPartnerRepository creates a new trading partner repository
+func NewTradingPartnerRepository(db *mongo.Database) *TradingPartnerRepository {
+	collection := db.Collection("edi_trading_partners")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "partnerId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			// An interchange's sender and receiver IDs must identify exactly one partner
+			Keys: bson.D{
+				{Key: "envelope.partnerQualifier", Value: 1},
+				{Key: "envelope.partnerId", Value: 1},
+				{Key: "envelope.warehouseQualifier", Value: 1},
+				{Key: "envelope.warehouseId", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "tenantId", Value: 1}},
+		},
+	}
+
+	collection.Indexes().CreateMany(ctx, indexes)
+
+	return &TradingPartnerRepository{
+		collection:     collection,
+		controlNumbers: db.Collection("edi_control_numbers"),
+	}
+}
+
+func (r *TradingPartnerRepository) Save(ctx context.Context, partner *domain.TradingPartner) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"partnerId": partner.PartnerID}, partner, opts)
+	return err
+}
+
+func (r *TradingPartnerRepository) FindByID(ctx context.Context, partnerID string) (*domain.TradingPartner, error) {
+	return r.findOne(ctx, bson.M{"partnerId": partnerID})
+}
+
+func (r *TradingPartnerRepository) FindByInterchangeIDs(ctx context.Context, senderQualifier, senderID, receiverQualifier, receiverID string) (*domain.TradingPartner, error) {
+	return r.findOne(ctx, bson.M{
+		"envelope.partnerQualifier":   senderQualifier,
+		"envelope.partnerId":          senderID,
+		"envelope.warehouseQualifier": receiverQualifier,
+		"envelope.warehouseId":        receiverID,
+	})
+}
+
+func (r *TradingPartnerRepository) FindByTenantID(ctx context.Context, tenantID string) ([]*domain.TradingPartner, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"tenantId": tenantID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var partners []*domain.TradingPartner
+	if err := cursor.All(ctx, &partners); err != nil {
+		return nil, err
+	}
+	return partners, nil
+}
+
+func (r *TradingPartnerRepository) Delete(ctx context.Context, partnerID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"partnerId": partnerID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrTradingPartnerNotFound
+	}
+	return nil
+}
+
+// NextControlNumbers atomically increments the partner's control number sequences, so
+// concurrent shipments never reuse an ISA13 or GS06
+func (r *TradingPartnerRepository) NextControlNumbers(ctx context.Context, partnerID string) (domain.ControlNumbers, error) {
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var controls domain.ControlNumbers
+	err := r.controlNumbers.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": partnerID},
+		bson.M{"$inc": bson.M{"interchange": 1, "group": 1}},
+		opts,
+	).Decode(&controls)
+	if err != nil {
+		return domain.ControlNumbers{}, err
+	}
+	return controls, nil
+}
+
+func (r *TradingPartnerRepository) findOne(ctx context.Context, filter bson.M) (*domain.TradingPartner, error) {
+	var partner domain.TradingPartner
+	err := r.collection.FindOne(ctx, filter).Decode(&partner)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &partner, nil
+}
+
+// EDIDocumentRepository implements domain.EDIDocumentRepository
+type EDIDocumentRepository struct {
+	collection *mongo.Collection
+}
+
+// NewEDIDocumentRepository creates a new EDI document repository
+func NewEDIDocumentRepository(db *mongo.Database) *EDIDocumentRepository {
+	collection := db.Collection("edi_documents")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "documentId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "partnerId", Value: 1},
+				{Key: "direction", Value: 1},
+				{Key: "createdAt", Value: -1},
+			},
+		},
+		{
+			// One document per transaction set, so a resubmitted interchange cannot be logged twice
+			Keys: bson.D{
+				{Key: "partnerId", Value: 1},
+				{Key: "direction", Value: 1},
+				{Key: "interchangeControlNumber", Value: 1},
+				{Key: "groupControlNumber", Value: 1},
+				{Key: "transactionControlNumber", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "partnerId", Value: 1},
+				{Key: "direction", Value: 1},
+				{Key: "groupControlNumber", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{{Key: "wmsOrderId", Value: 1}},
+		},
+	}
+
+	collection.Indexes().CreateMany(ctx, indexes)
+
+	return &EDIDocumentRepository{collection: collection}
+}
+
+func (r *EDIDocumentRepository) Save(ctx context.Context, doc *domain.EDIDocument) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"documentId": doc.DocumentID}, doc, opts)
+	return err
+}
+
+// Insert logs new documents in order. A transaction set already logged for the partner
+// fails the insert with domain.ErrDuplicateInterchange.
+func (r *EDIDocumentRepository) Insert(ctx context.Context, docs ...*domain.EDIDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	records := make([]interface{}, len(docs))
+	for i, doc := range docs {
+		records[i] = doc
+	}
+	if _, err := r.collection.InsertMany(ctx, records); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("%w: %v", domain.ErrDuplicateInterchange, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *EDIDocumentRepository) FindByID(ctx context.Context, documentID string) (*domain.EDIDocument, error) {
+	var doc domain.EDIDocument
+	err := r.collection.FindOne(ctx, bson.M{"documentId": documentID}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// FindByPartnerID lists a partner's documents; an empty direction lists both directions
+func (r *EDIDocumentRepository) FindByPartnerID(ctx context.Context, partnerID string, direction domain.EDIDirection, pagination domain.Pagination) ([]*domain.EDIDocument, error) {
+	filter := bson.M{"partnerId": partnerID}
+	if direction != "" {
+		filter["direction"] = direction
+	}
+
+	opts := options.Find().
+		SetSkip(pagination.Skip()).
+		SetLimit(pagination.Limit()).
+		SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+func (r *EDIDocumentRepository) FindByInterchangeControlNumber(ctx context.Context, partnerID string, direction domain.EDIDirection, controlNumber string) ([]*domain.EDIDocument, error) {
+	return r.find(ctx, bson.M{
+		"partnerId":                partnerID,
+		"direction":                direction,
+		"interchangeControlNumber": controlNumber,
+	})
+}
+
+func (r *EDIDocumentRepository) FindByGroupControlNumber(ctx context.Context, partnerID string, direction domain.EDIDirection, controlNumber string) ([]*domain.EDIDocument, error) {
+	return r.find(ctx, bson.M{
+		"partnerId":          partnerID,
+		"direction":          direction,
+		"groupControlNumber": controlNumber,
+	})
+}
+
+func (r *EDIDocumentRepository) FindByWMSOrderID(ctx context.Context, wmsOrderID string) ([]*domain.EDIDocument, error) {
+	return r.find(ctx, bson.M{"wmsOrderId": wmsOrderID}, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+}
+
+func (r *EDIDocumentRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*domain.EDIDocument, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []*domain.EDIDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
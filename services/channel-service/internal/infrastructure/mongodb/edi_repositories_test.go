@@ -0,0 +1,111 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+func TestTradingPartnerRepository_MockOps(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("constructor", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		require.NotNil(t, NewTradingPartnerRepository(mt.DB))
+	})
+
+	mt.Run("operations", func(mt *mtest.T) {
+		coll := mt.DB.Collection("edi_trading_partners")
+		repo := &TradingPartnerRepository{collection: coll, controlNumbers: mt.DB.Collection("edi_control_numbers")}
+		ctx := context.Background()
+		ns := coll.Database().Name() + "." + coll.Name()
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		require.NoError(t, repo.Save(ctx, &domain.TradingPartner{PartnerID: "TP-1"}))
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
+			{Key: "partnerId", Value: "TP-1"},
+			{Key: "tenantId", Value: "tenant-1"},
+		}))
+		found, err := repo.FindByInterchangeIDs(ctx, "ZZ", "ACMEB2B", "ZZ", "WMSWAREHOUSE")
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "tenant-1", found.TenantID)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+		found, err = repo.FindByID(ctx, "missing")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{
+			{Key: "_id", Value: "TP-1"},
+			{Key: "interchange", Value: int64(12)},
+			{Key: "group", Value: int64(12)},
+		}}))
+		controls, err := repo.NextControlNumbers(ctx, "TP-1")
+		require.NoError(t, err)
+		assert.Equal(t, "000000012", controls.InterchangeControlNumber())
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}))
+		assert.ErrorIs(t, repo.Delete(ctx, "missing"), domain.ErrTradingPartnerNotFound)
+	})
+}
+
+func TestEDIDocumentRepository_MockOps(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("constructor", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		require.NotNil(t, NewEDIDocumentRepository(mt.DB))
+	})
+
+	mt.Run("operations", func(mt *mtest.T) {
+		coll := mt.DB.Collection("edi_documents")
+		repo := &EDIDocumentRepository{collection: coll}
+		ctx := context.Background()
+		ns := coll.Database().Name() + "." + coll.Name()
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		require.NoError(t, repo.Save(ctx, &domain.EDIDocument{DocumentID: "EDI-1"}))
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+		found, err := repo.FindByID(ctx, "missing")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			bson.D{{Key: "documentId", Value: "EDI-1"}, {Key: "direction", Value: "inbound"}},
+			bson.D{{Key: "documentId", Value: "EDI-2"}, {Key: "direction", Value: "outbound"}},
+		))
+		docs, err := repo.FindByWMSOrderID(ctx, "ORD-1")
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, domain.EDIDirectionOutbound, docs[1].Direction)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			bson.D{{Key: "documentId", Value: "EDI-2"}},
+		))
+		docs, err = repo.FindByPartnerID(ctx, "TP-1", "", domain.Pagination{Page: 1, PageSize: 20})
+		require.NoError(t, err)
+		assert.Len(t, docs, 1)
+
+		inbound := []*domain.EDIDocument{
+			{DocumentID: "EDI-3", PartnerID: "TP-1", InterchangeControlNumber: "000000101", TransactionControlNumber: "0001"},
+			{DocumentID: "EDI-4", PartnerID: "TP-1", InterchangeControlNumber: "000000101", TransactionControlNumber: "0002"},
+		}
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		require.NoError(t, repo.Insert(ctx, inbound...))
+
+		// A resubmitted interchange hits the unique transaction set index
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+			Index: 0, Code: 11000, Message: "E11000 duplicate key error",
+		}))
+		assert.ErrorIs(t, repo.Insert(ctx, inbound...), domain.ErrDuplicateInterchange)
+	})
+}
@@ -64,6 +64,16 @@ func TestDomainEventToCloudEvent(t *testing.T) {
 			event:    &domain.WebhookReceivedEvent{ReceivedAt: time.Now()},
 			expected: cloudevents.ChannelWebhookReceived,
 		},
+		{
+			name:     "credentials-refreshed",
+			event:    &domain.CredentialsRefreshedEvent{RefreshedAt: time.Now()},
+			expected: cloudevents.ChannelCredentialsRefreshed,
+		},
+		{
+			name:     "credentials-expired",
+			event:    &domain.CredentialsExpiredEvent{ExpiredAt: time.Now()},
+			expected: cloudevents.ChannelCredentialsExpired,
+		},
 		{
 			name:     "default",
 			event:    &customEvent{when: time.Now()},
@@ -0,0 +1,62 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RefreshLeaseRepository implements domain.RefreshLeaseStore with one document per
+// channel, keyed by channel ID
+type RefreshLeaseRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRefreshLeaseRepository creates a new refresh lease repository
+func NewRefreshLeaseRepository(db *mongo.Database) *RefreshLeaseRepository {
+	collection := db.Collection("channel_refresh_leases")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Expired leases are removed by MongoDB; acquiring never depends on it
+	collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+
+	return &RefreshLeaseRepository{collection: collection}
+}
+
+// AcquireRefreshLease takes the lease when none exists, it has expired or holder already
+// owns it. A live lease of another holder makes the upsert collide on _id, which is
+// reported as not acquired.
+func (r *RefreshLeaseRepository) AcquireRefreshLease(ctx context.Context, channelID, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	filter := bson.M{
+		"_id": channelID,
+		"$or": bson.A{
+			bson.M{"expiresAt": bson.M{"$lte": now}},
+			bson.M{"holder": holder},
+		},
+	}
+	update := bson.M{"$set": bson.M{"holder": holder, "expiresAt": now.Add(ttl)}}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ReleaseRefreshLease deletes the lease if holder still owns it
+func (r *RefreshLeaseRepository) ReleaseRefreshLease(ctx context.Context, channelID, holder string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": channelID, "holder": holder})
+	return err
+}
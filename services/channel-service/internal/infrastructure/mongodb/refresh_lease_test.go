@@ -0,0 +1,54 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestRefreshLeaseRepository_AcquireRefreshLease(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("acquires free or expired lease", func(mt *mtest.T) {
+		repo := &RefreshLeaseRepository{collection: mt.DB.Collection("channel_refresh_leases")}
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		acquired, err := repo.AcquireRefreshLease(context.Background(), "ch-1", "holder-1", time.Minute)
+		require.NoError(mt, err)
+		assert.True(mt, acquired)
+
+		event := mt.GetStartedEvent()
+		require.NotNil(mt, event)
+		update := event.Command.Lookup("updates", "0")
+		assert.Equal(mt, "ch-1", update.Document().Lookup("q", "_id").StringValue())
+		assert.True(mt, update.Document().Lookup("upsert").Boolean())
+	})
+
+	mt.Run("live lease of another holder", func(mt *mtest.T) {
+		repo := &RefreshLeaseRepository{collection: mt.DB.Collection("channel_refresh_leases")}
+		mt.AddMockResponses(mtest.CreateWriteErrorsResponse(mtest.WriteError{
+			Index:   0,
+			Code:    11000,
+			Message: "E11000 duplicate key error",
+		}))
+
+		acquired, err := repo.AcquireRefreshLease(context.Background(), "ch-1", "holder-2", time.Minute)
+		require.NoError(mt, err)
+		assert.False(mt, acquired)
+	})
+
+	mt.Run("other errors are returned", func(mt *mtest.T) {
+		repo := &RefreshLeaseRepository{collection: mt.DB.Collection("channel_refresh_leases")}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 2, Message: "bad value"}))
+
+		_, err := repo.AcquireRefreshLease(context.Background(), "ch-1", "holder-1", time.Minute)
+		var cmdErr mongo.CommandError
+		require.ErrorAs(mt, err, &cmdErr)
+	})
+}
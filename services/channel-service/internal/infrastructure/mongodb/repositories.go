@@ -10,7 +10,9 @@ import (
 
 	"github.com/wms-platform/services/channel-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
+	"github.com/wms-platform/shared/pkg/secrets"
 )
 
 const (
@@ -22,6 +24,7 @@ type ChannelRepository struct {
 	collection       *mongo.Collection
 	outboxCollection *mongo.Collection
 	eventFactory     *cloudevents.EventFactory
+	cipher           *secrets.Cipher
 }
 
 // NewChannelRepository creates a new channel repository
@@ -55,7 +58,13 @@ func NewChannelRepository(db *mongo.Database) *ChannelRepository {
 		{
 			Keys: bson.D{
 				{Key: "status", Value: 1},
-				{Key: "syncSettings.orderSync.lastSyncAt", Value: 1},
+				{Key: "lastOrderSync", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "lastInventorySync", Value: 1},
 			},
 		},
 	}
@@ -69,7 +78,18 @@ func NewChannelRepository(db *mongo.Database) *ChannelRepository {
 	}
 }
 
+// Save persists the channel and its events. The write only succeeds if the stored channel
+// still has the version that was loaded, otherwise it fails with ErrConcurrencyConflict.
 func (r *ChannelRepository) Save(ctx context.Context, channel *domain.Channel) error {
+	expectedVersion := channel.Version
+	channel.Version = expectedVersion + 1
+
+	stored, err := r.encryptCredentials(ctx, channel)
+	if err != nil {
+		channel.Version = expectedVersion
+		return err
+	}
+
 	session, err := r.collection.Database().Client().StartSession()
 	if err != nil {
 		return err
@@ -77,9 +97,10 @@ func (r *ChannelRepository) Save(ctx context.Context, channel *domain.Channel) e
 	defer session.EndSession(ctx)
 
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
-		opts := options.Replace().SetUpsert(true)
-		_, err := r.collection.ReplaceOne(sessCtx, bson.M{"channelId": channel.ChannelID}, channel, opts)
-		if err != nil {
+		opts := options.Replace().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"channelId": channel.ChannelID}, expectedVersion)
+		result, err := r.collection.ReplaceOne(sessCtx, filter, stored, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "Channel", channel.ChannelID, expectedVersion); err != nil {
 			return nil, err
 		}
 
@@ -105,11 +126,13 @@ func (r *ChannelRepository) Save(ctx context.Context, channel *domain.Channel) e
 		return nil, nil
 	})
 
-	if err == nil {
-		channel.ClearDomainEvents()
+	if err != nil {
+		channel.Version = expectedVersion
+		return err
 	}
 
-	return err
+	channel.ClearDomainEvents()
+	return nil
 }
 
 // domainEventToCloudEvent converts a domain event to a CloudEvent
@@ -130,6 +153,10 @@ func (r *ChannelRepository) domainEventToCloudEvent(ctx context.Context, channel
 		eventType = cloudevents.ChannelSyncCompleted
 	case "channel.webhook.received":
 		eventType = cloudevents.ChannelWebhookReceived
+	case "channel.credentials.refreshed":
+		eventType = cloudevents.ChannelCredentialsRefreshed
+	case "channel.credentials.expired":
+		eventType = cloudevents.ChannelCredentialsExpired
 	default:
 		eventType = "wms.channel." + event.EventType()
 	}
@@ -146,49 +173,22 @@ func (r *ChannelRepository) FindByID(ctx context.Context, channelID string) (*do
 		}
 		return nil, err
 	}
+	if err := r.decryptCredentials(ctx, &channel); err != nil {
+		return nil, err
+	}
 	return &channel, nil
 }
 
 func (r *ChannelRepository) FindBySellerID(ctx context.Context, sellerID string) ([]*domain.Channel, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{"sellerId": sellerID})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var channels []*domain.Channel
-	if err := cursor.All(ctx, &channels); err != nil {
-		return nil, err
-	}
-	return channels, nil
+	return r.findChannels(ctx, bson.M{"sellerId": sellerID})
 }
 
 func (r *ChannelRepository) FindByType(ctx context.Context, channelType domain.ChannelType) ([]*domain.Channel, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{"type": channelType})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var channels []*domain.Channel
-	if err := cursor.All(ctx, &channels); err != nil {
-		return nil, err
-	}
-	return channels, nil
+	return r.findChannels(ctx, bson.M{"type": channelType})
 }
 
 func (r *ChannelRepository) FindActiveChannels(ctx context.Context) ([]*domain.Channel, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{"status": domain.ChannelStatusActive})
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var channels []*domain.Channel
-	if err := cursor.All(ctx, &channels); err != nil {
-		return nil, err
-	}
-	return channels, nil
+	return r.findChannels(ctx, bson.M{"status": domain.ChannelStatusActive})
 }
 
 func (r *ChannelRepository) FindChannelsNeedingSync(ctx context.Context, syncType domain.SyncType, threshold time.Duration) ([]*domain.Channel, error) {
@@ -198,37 +198,27 @@ func (r *ChannelRepository) FindChannelsNeedingSync(ctx context.Context, syncTyp
 	switch syncType {
 	case domain.SyncTypeOrders:
 		filter = bson.M{
-			"status": domain.ChannelStatusActive,
-			"syncSettings.orderSync.enabled": true,
+			"status":                        domain.ChannelStatusActive,
+			"syncSettings.autoImportOrders": true,
 			"$or": []bson.M{
-				{"syncSettings.orderSync.lastSyncAt": bson.M{"$lt": cutoff}},
-				{"syncSettings.orderSync.lastSyncAt": bson.M{"$exists": false}},
+				{"lastOrderSync": bson.M{"$lt": cutoff}},
+				{"lastOrderSync": bson.M{"$exists": false}},
 			},
 		}
 	case domain.SyncTypeInventory:
 		filter = bson.M{
-			"status": domain.ChannelStatusActive,
-			"syncSettings.inventorySync.enabled": true,
+			"status":                         domain.ChannelStatusActive,
+			"syncSettings.autoSyncInventory": true,
 			"$or": []bson.M{
-				{"syncSettings.inventorySync.lastSyncAt": bson.M{"$lt": cutoff}},
-				{"syncSettings.inventorySync.lastSyncAt": bson.M{"$exists": false}},
+				{"lastInventorySync": bson.M{"$lt": cutoff}},
+				{"lastInventorySync": bson.M{"$exists": false}},
 			},
 		}
 	default:
 		filter = bson.M{"status": domain.ChannelStatusActive}
 	}
 
-	cursor, err := r.collection.Find(ctx, filter)
-	if err != nil {
-		return nil, err
-	}
-	defer cursor.Close(ctx)
-
-	var channels []*domain.Channel
-	if err := cursor.All(ctx, &channels); err != nil {
-		return nil, err
-	}
-	return channels, nil
+	return r.findChannels(ctx, filter)
 }
 
 func (r *ChannelRepository) UpdateStatus(ctx context.Context, channelID string, status domain.ChannelStatus) error {
@@ -304,6 +294,9 @@ func NewChannelOrderRepository(db *mongo.Database) *ChannelOrderRepository {
 		{
 			Keys: bson.D{{Key: "sellerId", Value: 1}},
 		},
+		{
+			Keys: bson.D{{Key: "wmsOrderId", Value: 1}},
+		},
 		{
 			Keys: bson.D{{Key: "externalCreatedAt", Value: -1}},
 		},
@@ -374,6 +367,18 @@ func (r *ChannelOrderRepository) FindByChannelID(ctx context.Context, channelID
 	return orders, nil
 }
 
+func (r *ChannelOrderRepository) FindByWMSOrderID(ctx context.Context, wmsOrderID string) (*domain.ChannelOrder, error) {
+	var order domain.ChannelOrder
+	err := r.collection.FindOne(ctx, bson.M{"wmsOrderId": wmsOrderID}).Decode(&order)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
 func (r *ChannelOrderRepository) FindUnimported(ctx context.Context, channelID string) ([]*domain.ChannelOrder, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{
 		"channelId": channelID,
@@ -441,6 +446,21 @@ func (r *ChannelOrderRepository) MarkTrackingPushed(ctx context.Context, externa
 	return err
 }
 
+func (r *ChannelOrderRepository) MarkTrackingMilestone(ctx context.Context, externalOrderID, milestone string) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"externalOrderId": externalOrderID},
+		bson.M{
+			"$set": bson.M{
+				"trackingPushed":    true,
+				"trackingPushedAt":  time.Now(),
+				"trackingMilestone": milestone,
+			},
+		},
+	)
+	return err
+}
+
 func (r *ChannelOrderRepository) Count(ctx context.Context, channelID string) (int64, error) {
 	return r.collection.CountDocuments(ctx, bson.M{"channelId": channelID})
 }
@@ -190,6 +190,20 @@ func TestChannelOrderRepository_MockOps(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, list, 1)
 
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
+			{Key: "externalOrderId", Value: "ext-1"},
+			{Key: "wmsOrderId", Value: "wms-1"},
+		}))
+		order, err = repo.FindByWMSOrderID(ctx, "wms-1")
+		require.NoError(t, err)
+		require.NotNil(t, order)
+		assert.Equal(t, "ext-1", order.ExternalOrderID)
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+		order, err = repo.FindByWMSOrderID(ctx, "wms-missing")
+		require.NoError(t, err)
+		require.Nil(t, order)
+
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
 			{Key: "externalOrderId", Value: "ext-3"},
 			{Key: "imported", Value: false},
@@ -214,6 +228,10 @@ func TestChannelOrderRepository_MockOps(t *testing.T) {
 		err = repo.MarkTrackingPushed(ctx, "ext-1")
 		require.NoError(t, err)
 
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		err = repo.MarkTrackingMilestone(ctx, "ext-1", "delivered")
+		require.NoError(t, err)
+
 		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{
 			{Key: "n", Value: int64(2)},
 		}))
@@ -0,0 +1,77 @@
+package x12
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// Codec translates X12 4010 interchanges to and from EDI gateway documents.
+// Implements domain.EDICodec interface
+type Codec struct{}
+
+// NewCodec creates a new X12 Codec
+func NewCodec() *Codec {
+	return &Codec{}
+}
+
+// Decode reads an interchange. Transaction sets that cannot be translated carry their
+// errors on the returned transaction rather than failing the interchange.
+func (c *Codec) Decode(data []byte) (*domain.EDIInterchange, error) {
+	parsed, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	isa := parsed.isa
+	result := &domain.EDIInterchange{
+		SenderQualifier:   isa.Element(5),
+		SenderID:          isa.Element(6),
+		ReceiverQualifier: isa.Element(7),
+		ReceiverID:        isa.Element(8),
+		ControlNumber:     isa.Element(13),
+		UsageIndicator:    isa.Element(15),
+		Groups:            make([]domain.EDIGroup, 0, len(parsed.groups)),
+	}
+
+	for _, g := range parsed.groups {
+		group := domain.EDIGroup{
+			FunctionalID:  g.gs.Element(1),
+			SenderCode:    g.gs.Element(2),
+			ReceiverCode:  g.gs.Element(3),
+			ControlNumber: g.gs.Element(6),
+			Version:       g.gs.Element(8),
+			Transactions:  make([]domain.EDITransaction, 0, len(g.transactions)),
+		}
+		for _, ts := range g.transactions {
+			group.Transactions = append(group.Transactions, translateTransaction(ts))
+		}
+		result.Groups = append(result.Groups, group)
+	}
+	return result, nil
+}
+
+// Encode writes a 945, 856 or 997 transaction set as a complete interchange addressed to the partner
+func (c *Codec) Encode(partner *domain.TradingPartner, controls domain.ControlNumbers, txn domain.EDITransaction, at time.Time) ([]byte, error) {
+	var body [][]string
+	var err error
+	switch txn.Type {
+	case domain.X12WarehouseShippingAdvice:
+		body, err = shippingAdviceBody(txn.Confirmation)
+	case domain.X12ShipNotice:
+		body, err = shipNoticeBody(txn.Confirmation)
+	case domain.X12FunctionalAck:
+		if txn.Acknowledgement == nil {
+			return nil, fmt.Errorf("997 requires an acknowledgement")
+		}
+		body = functionalAckBody(txn.Acknowledgement)
+	default:
+		return nil, fmt.Errorf("%w: cannot write %s", domain.ErrUnsupportedEDIDocument, txn.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newWriter(partner.Envelope.WithDefaults()).interchange(controls, txn.Type, body, at), nil
+}
@@ -0,0 +1,355 @@
+package x12
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+const testISA = "ISA*00*          *00*          *ZZ*ACMEB2B        *ZZ*WMSWAREHOUSE   *261012*1530*U*00401*000000101*0*P*>~"
+
+func interchangeOf(segments ...string) []byte {
+	return []byte(testISA + "\n" + strings.Join(segments, "~\n") + "~\n")
+}
+
+func testPartner() *domain.TradingPartner {
+	return &domain.TradingPartner{
+		PartnerID: "TP-1",
+		Envelope: domain.EnvelopeSettings{
+			PartnerQualifier:   "ZZ",
+			PartnerID:          "ACMEB2B",
+			WarehouseQualifier: "ZZ",
+			WarehouseID:        "WMSWAREHOUSE",
+		}.WithDefaults(),
+	}
+}
+
+func TestDecode_WarehouseShippingOrder(t *testing.T) {
+	data := interchangeOf(
+		"GS*OW*ACMEB2B*WMSWAREHOUSE*20261012*1530*55*X*004010",
+		"ST*940*0001",
+		"W05*N*DO-7781*PO-4500012",
+		"N1*ST*Jane Doe*92*STORE-12",
+		"N3*1 Main St*Suite 4",
+		"N4*Springfield*IL*62701*US",
+		"G62*10*20261014",
+		"W66*CC*M********UPSN",
+		"LX*1",
+		"W01*12*EA*012345678905*VN*WIDGET-1",
+		"G69*Blue widget",
+		"LX*2",
+		"W01*3*CA**VN*GADGET-2",
+		"W76*15",
+		"SE*14*0001",
+		"GE*1*55",
+		"IEA*1*000000101",
+	)
+
+	interchange, err := NewCodec().Decode(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ZZ", interchange.SenderQualifier)
+	assert.Equal(t, "ACMEB2B", interchange.SenderID)
+	assert.Equal(t, "WMSWAREHOUSE", interchange.ReceiverID)
+	assert.Equal(t, "000000101", interchange.ControlNumber)
+	require.Len(t, interchange.Groups, 1)
+	assert.Equal(t, "55", interchange.Groups[0].ControlNumber)
+	require.Len(t, interchange.Groups[0].Transactions, 1)
+
+	txn := interchange.Groups[0].Transactions[0]
+	assert.Empty(t, txn.Errors)
+	assert.Equal(t, domain.X12WarehouseShippingOrder, txn.Type)
+	require.NotNil(t, txn.Order)
+	assert.Equal(t, "PO-4500012", txn.Order.PurchaseOrderNumber)
+	assert.Equal(t, "DO-7781", txn.Order.DepositorOrderNumber)
+	assert.Equal(t, "UPSN", txn.Order.CarrierSCAC)
+	assert.Equal(t, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), *txn.Order.RequestedShipDate)
+	assert.Equal(t, domain.EDIParty{
+		Name: "Jane Doe", Identifier: "STORE-12", Address1: "1 Main St", Address2: "Suite 4",
+		City: "Springfield", State: "IL", PostalCode: "62701", Country: "US",
+	}, txn.Order.ShipTo)
+	require.Len(t, txn.Order.Lines, 2)
+	assert.Equal(t, domain.EDIOrderLine{LineNumber: "1", SKU: "WIDGET-1", UPC: "012345678905", Description: "Blue widget", Quantity: 12, UnitOfMeasure: "EA"}, txn.Order.Lines[0])
+	assert.Equal(t, "GADGET-2", txn.Order.Lines[1].SKU)
+	assert.Equal(t, 3, txn.Order.Lines[1].Quantity)
+}
+
+func TestDecode_PurchaseOrderWithPartnerDelimiters(t *testing.T) {
+	// Pipe element separators, colon component separators and newline segment terminators
+	isa := strings.NewReplacer("*", "|", "~", "\n", ">", ":").Replace(testISA)
+	data := isa + strings.Join([]string{
+		"GS|PO|ACMEB2B|WMSWAREHOUSE|20261012|1530|56|X|004010",
+		"ST|850|0001",
+		"BEG|00|SA|PO-88|  |20261011",
+		"DTM|010|20261015",
+		"TD5||2|FDEG",
+		"N1|ST|Acme Store 12",
+		"N3|500 Commerce Dr",
+		"N4|Dallas|TX|75201",
+		"PO1|1|4|EA|12.50||UP|012345678905|VN|WIDGET-1",
+		"PID|F||||Blue widget",
+		"PO1|2|1|EA|3||UP|098765432109",
+		"CTT|2",
+		"SE|12|0001",
+		"GE|1|56",
+		"IEA|1|000000101",
+	}, "\n") + "\n"
+
+	interchange, err := NewCodec().Decode([]byte(data))
+	require.NoError(t, err)
+
+	txn := interchange.Groups[0].Transactions[0]
+	assert.Empty(t, txn.Errors)
+	assert.Equal(t, domain.X12PurchaseOrder, txn.Type)
+	assert.Equal(t, "PO-88", txn.Order.PurchaseOrderNumber)
+	assert.Equal(t, "FDEG", txn.Order.CarrierSCAC)
+	assert.Equal(t, time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC), *txn.Order.OrderDate)
+	assert.Equal(t, "US", txn.Order.ShipTo.Country)
+	require.Len(t, txn.Order.Lines, 2)
+	assert.Equal(t, "WIDGET-1", txn.Order.Lines[0].SKU)
+	assert.Equal(t, 12.5, txn.Order.Lines[0].UnitPrice)
+	assert.Equal(t, "Blue widget", txn.Order.Lines[0].Description)
+	// Lines identified only by UPC use the UPC as the SKU
+	assert.Equal(t, "098765432109", txn.Order.Lines[1].SKU)
+}
+
+func TestDecode_ShipNotice(t *testing.T) {
+	data := interchangeOf(
+		"GS*SH*ACMEB2B*WMSWAREHOUSE*20261012*1530*57*X*004010",
+		"ST*856*0001",
+		"BSN*00*ASN-3321*20261012*1200",
+		"HL*1**S",
+		"TD1*CTN25*4****G*110*LB",
+		"TD5**2*ODFL**Old Dominion",
+		"REF*BM*BOL-99812",
+		"REF*CN*PRO-554433",
+		"DTM*017*20261016",
+		"N1*SF*Acme Supply",
+		"N4*Reno*NV*89502",
+		"HL*2*1*O",
+		"PRF*PO-4500099",
+		"HL*3*2*I",
+		"LIN**SK*WIDGET-1*UP*012345678905",
+		"SN1**48*EA",
+		"REF*LT*LOT-2026-10",
+		"HL*4*2*I",
+		"LIN**VN*GADGET-2",
+		"SN1**24*EA",
+		"CTT*4",
+		"SE*21*0001",
+		"GE*1*57",
+		"IEA*1*000000101",
+	)
+
+	interchange, err := NewCodec().Decode(data)
+	require.NoError(t, err)
+
+	txn := interchange.Groups[0].Transactions[0]
+	assert.Empty(t, txn.Errors)
+	notice := txn.ShipNotice
+	require.NotNil(t, notice)
+	assert.Equal(t, "ASN-3321", notice.ShipmentID)
+	assert.Equal(t, "ODFL", notice.CarrierSCAC)
+	assert.Equal(t, "Old Dominion", notice.CarrierName)
+	assert.Equal(t, "BOL-99812", notice.BillOfLading)
+	assert.Equal(t, "PRO-554433", notice.TrackingNumber)
+	assert.Equal(t, "PO-4500099", notice.PurchaseOrderNumber)
+	assert.Equal(t, "Acme Supply", notice.ShipFrom.Name)
+	assert.Equal(t, 4, notice.CartonCount)
+	assert.InDelta(t, 49.9, notice.TotalWeight, 0.01)
+	assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), *notice.ExpectedArrival)
+	require.Len(t, notice.Lines, 2)
+	assert.Equal(t, domain.EDIShipNoticeLine{SKU: "WIDGET-1", UPC: "012345678905", Quantity: 48, UnitOfMeasure: "EA", LotNumber: "LOT-2026-10"}, notice.Lines[0])
+	assert.Equal(t, "GADGET-2", notice.Lines[1].SKU)
+}
+
+func TestDecode_FunctionalAck(t *testing.T) {
+	data := interchangeOf(
+		"GS*FA*ACMEB2B*WMSWAREHOUSE*20261012*1530*58*X*004010",
+		"ST*997*0001",
+		"AK1*SW*12",
+		"AK2*945*0001",
+		"AK3*W12*8**8",
+		"AK5*R*5",
+		"AK9*R*1*1*0",
+		"SE*7*0001",
+		"GE*1*58",
+		"IEA*1*000000101",
+	)
+
+	interchange, err := NewCodec().Decode(data)
+	require.NoError(t, err)
+
+	ack := interchange.Groups[0].Transactions[0].Acknowledgement
+	require.NotNil(t, ack)
+	assert.Equal(t, "SW", ack.FunctionalID)
+	assert.Equal(t, "12", ack.GroupControlNumber)
+	assert.Equal(t, domain.EDIAckRejected, ack.Status)
+	require.Len(t, ack.Transactions, 1)
+	assert.Equal(t, domain.X12WarehouseShippingAdvice, ack.Transactions[0].Type)
+	assert.Equal(t, domain.EDIAckRejected, ack.Transactions[0].Status)
+	assert.Len(t, ack.Transactions[0].Errors, 2)
+}
+
+func TestDecode_TranslationErrorsStayOnTheTransaction(t *testing.T) {
+	data := interchangeOf(
+		"GS*OW*ACMEB2B*WMSWAREHOUSE*20261012*1530*59*X*004010",
+		"ST*940*0001",
+		"W05*N*DO-1",
+		"LX*1",
+		"W01*2.5*EA**VN*WIDGET-1",
+		"SE*9*0001",
+		"ST*810*0002",
+		"BIG*20261012*INV-1",
+		"SE*3*0002",
+		"GE*2*59",
+		"IEA*1*000000101",
+	)
+
+	interchange, err := NewCodec().Decode(data)
+	require.NoError(t, err)
+
+	transactions := interchange.Groups[0].Transactions
+	require.Len(t, transactions, 2)
+	assert.Contains(t, transactions[0].Errors, "SE01 segment count 9 does not match 5 segments")
+	assert.Contains(t, transactions[0].Errors, "N1*ST ship-to party is required")
+	assert.Contains(t, transactions[0].Errors, `W0101 quantity "2.5" is not a whole number`)
+	assert.Equal(t, []string{"unsupported transaction set 810"}, transactions[1].Errors)
+}
+
+func TestDecode_InvalidEnvelope(t *testing.T) {
+	group := []string{
+		"GS*OW*ACMEB2B*WMSWAREHOUSE*20261012*1530*60*X*004010",
+		"ST*997*0001",
+		"AK1*SW*1",
+		"AK9*A*1*1*1",
+		"SE*4*0001",
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"not an interchange", []byte("<order><po>1</po></order>")},
+		{"group count mismatch", interchangeOf(append(group, "GE*2*60", "IEA*1*000000101")...)},
+		{"group control mismatch", interchangeOf(append(group, "GE*1*61", "IEA*1*000000101")...)},
+		{"interchange control mismatch", interchangeOf(append(group, "GE*1*60", "IEA*1*000000102")...)},
+		{"missing IEA", interchangeOf(append(group, "GE*1*60")...)},
+		{"segment outside transaction", interchangeOf("GS*OW*A*B*20261012*1530*60*X*004010", "W05*N*1", "GE*0*60", "IEA*1*000000101")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCodec().Decode(tt.data)
+			assert.ErrorIs(t, err, domain.ErrInvalidInterchange)
+		})
+	}
+}
+
+func testConfirmation() *domain.EDIShipmentConfirmation {
+	return &domain.EDIShipmentConfirmation{
+		WMSOrderID:           "ORD-1",
+		ShipmentID:           "SHP-1",
+		PurchaseOrderNumber:  "PO-4500012",
+		DepositorOrderNumber: "DO-7781",
+		TrackingNumber:       "1Z999AA10123456784",
+		CarrierSCAC:          "UPSN",
+		ShippedAt:            time.Date(2026, 10, 13, 16, 45, 0, 0, time.UTC),
+		WeightKg:             7.256,
+		ShipTo:               domain.EDIParty{Name: "Jane Doe", Address1: "1 Main St", City: "Springfield", State: "IL", PostalCode: "62701", Country: "US"},
+		Lines: []domain.EDIOrderLine{
+			{LineNumber: "1", SKU: "WIDGET-1", UPC: "012345678905", Quantity: 12, UnitOfMeasure: "EA"},
+			{LineNumber: "2", SKU: "GADGET-2", Quantity: 3},
+		},
+	}
+}
+
+func TestEncode_ShippingAdvice(t *testing.T) {
+	controls := domain.ControlNumbers{Interchange: 42, Group: 7}
+	at := time.Date(2026, 10, 13, 17, 0, 0, 0, time.UTC)
+
+	data, err := NewCodec().Encode(testPartner(), controls, domain.EDITransaction{
+		Type:         domain.X12WarehouseShippingAdvice,
+		Confirmation: testConfirmation(),
+	}, at)
+	require.NoError(t, err)
+
+	text := string(data)
+	assert.True(t, strings.HasPrefix(text, "ISA*00*          *00*          *ZZ*WMSWAREHOUSE   *ZZ*ACMEB2B        *261013*1700*U*00401*000000042*0*P*>~\n"))
+	assert.Contains(t, text, "GS*SW*WMSWAREHOUSE*ACMEB2B*20261013*1700*7*X*004010~\n")
+	assert.Contains(t, text, "W06*N*DO-7781*20261013*SHP-1**PO-4500012~\n")
+	assert.Contains(t, text, "N9*CN*1Z999AA10123456784~\n")
+	assert.Contains(t, text, "W27*M*UPSN~\n")
+	assert.Contains(t, text, "W12*CC*12*12*0*EA*012345678905*VN*WIDGET-1~\n")
+	assert.Contains(t, text, "W12*CC*3*3*0*EA**VN*GADGET-2~\n")
+	assert.Contains(t, text, "W03*15*7.26*KG~\n")
+
+	// The envelope trailers must agree with what was written
+	parsed, err := parse(data)
+	require.NoError(t, err)
+	require.Len(t, parsed.groups, 1)
+	require.Len(t, parsed.groups[0].transactions, 1)
+	assert.Empty(t, parsed.groups[0].transactions[0].errors)
+}
+
+func TestEncode_ShipNoticeRoundTrip(t *testing.T) {
+	data, err := NewCodec().Encode(testPartner(), domain.ControlNumbers{Interchange: 1, Group: 1}, domain.EDITransaction{
+		Type:         domain.X12ShipNotice,
+		Confirmation: testConfirmation(),
+	}, time.Now())
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "HL*3*2*I~\nLIN*1*VN*WIDGET-1*UP*012345678905~\nSN1*1*12*EA~\n")
+
+	// Read it back as the partner would
+	interchange, err := NewCodec().Decode(data)
+	require.NoError(t, err)
+	txn := interchange.Groups[0].Transactions[0]
+	assert.Empty(t, txn.Errors)
+	assert.Equal(t, "SHP-1", txn.ShipNotice.ShipmentID)
+	assert.Equal(t, "PO-4500012", txn.ShipNotice.PurchaseOrderNumber)
+	assert.Equal(t, "1Z999AA10123456784", txn.ShipNotice.TrackingNumber)
+	assert.Len(t, txn.ShipNotice.Lines, 2)
+}
+
+func TestEncode_FunctionalAckRoundTrip(t *testing.T) {
+	ack := domain.NewGroupAcknowledgement(domain.EDIGroup{
+		FunctionalID:  "OW",
+		ControlNumber: "55",
+		Transactions: []domain.EDITransaction{
+			{Type: domain.X12WarehouseShippingOrder, ControlNumber: "0001"},
+			{Type: domain.X12WarehouseShippingOrder, ControlNumber: "0002", Errors: []string{"no W01 line items"}},
+		},
+	})
+
+	data, err := NewCodec().Encode(testPartner(), domain.ControlNumbers{Interchange: 9, Group: 9}, domain.EDITransaction{
+		Type:            domain.X12FunctionalAck,
+		Acknowledgement: ack,
+	}, time.Now())
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "AK1*OW*55~\nAK2*940*0001~\nAK5*A~\nAK2*940*0002~\nAK5*R*5~\nAK9*E*2*2*1~\n")
+
+	interchange, err := NewCodec().Decode(data)
+	require.NoError(t, err)
+	decoded := interchange.Groups[0].Transactions[0].Acknowledgement
+	assert.Equal(t, domain.EDIAckAcceptedWithErrors, decoded.Status)
+	assert.Equal(t, domain.EDIAckRejected, decoded.Transactions[1].Status)
+}
+
+func TestEncode_RejectsUnsupportedDocuments(t *testing.T) {
+	_, err := NewCodec().Encode(testPartner(), domain.ControlNumbers{Interchange: 1, Group: 1}, domain.EDITransaction{
+		Type: domain.X12PurchaseOrder,
+	}, time.Now())
+	assert.ErrorIs(t, err, domain.ErrUnsupportedEDIDocument)
+
+	confirmation := testConfirmation()
+	confirmation.CarrierSCAC = ""
+	_, err = NewCodec().Encode(testPartner(), domain.ControlNumbers{Interchange: 1, Group: 1}, domain.EDITransaction{
+		Type:         domain.X12WarehouseShippingAdvice,
+		Confirmation: confirmation,
+	}, time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,398 @@
+package x12
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// poundsToKg converts 856 TD1 weights reported in pounds
+const poundsToKg = 0.45359237
+
+// translateTransaction reads a transaction set into its WMS document
+func translateTransaction(ts transactionSet) domain.EDITransaction {
+	txn := domain.EDITransaction{
+		Type:          domain.EDIDocumentType(ts.code),
+		ControlNumber: ts.controlNumber,
+		Errors:        ts.errors,
+	}
+
+	var errs []string
+	switch txn.Type {
+	case domain.X12PurchaseOrder:
+		txn.Order, errs = readPurchaseOrder(ts.segments)
+	case domain.X12WarehouseShippingOrder:
+		txn.Order, errs = readWarehouseShippingOrder(ts.segments)
+	case domain.X12ShipNotice:
+		txn.ShipNotice, errs = readShipNotice(ts.segments)
+	case domain.X12FunctionalAck:
+		txn.Acknowledgement, errs = readFunctionalAck(ts.segments)
+	default:
+		errs = []string{fmt.Sprintf("unsupported transaction set %s", ts.code)}
+	}
+	txn.Errors = append(txn.Errors, errs...)
+	return txn
+}
+
+// readPurchaseOrder reads an 850 purchase order
+func readPurchaseOrder(segments []Segment) (*domain.EDIOrder, []string) {
+	order := &domain.EDIOrder{}
+	var errs []string
+	parties := newPartyReader()
+
+	for _, seg := range segments {
+		switch seg.ID {
+		case "BEG":
+			order.PurchaseOrderNumber = seg.Element(3)
+			order.OrderDate = readDate(seg.Element(5), "BEG05", &errs)
+		case "DTM":
+			if seg.Element(1) == "010" {
+				order.RequestedShipDate = readDate(seg.Element(2), "DTM02", &errs)
+			}
+		case "TD5":
+			order.CarrierSCAC = seg.Element(3)
+		case "PO1":
+			line := domain.EDIOrderLine{
+				LineNumber:    seg.Element(1),
+				UnitOfMeasure: seg.Element(3),
+			}
+			line.Quantity = readQuantity(seg.Element(2), "PO102", &errs)
+			if price := seg.Element(4); price != "" {
+				if value, err := strconv.ParseFloat(price, 64); err == nil {
+					line.UnitPrice = value
+				} else {
+					errs = append(errs, fmt.Sprintf("PO104 unit price %q is not a number", price))
+				}
+			}
+			line.SKU, line.UPC = readProductIDs(seg, 6)
+			if line.LineNumber == "" {
+				line.LineNumber = strconv.Itoa(len(order.Lines) + 1)
+			}
+			order.Lines = append(order.Lines, line)
+		case "PID":
+			if n := len(order.Lines); n > 0 && seg.Element(1) == "F" {
+				order.Lines[n-1].Description = seg.Element(5)
+			}
+		default:
+			parties.read(seg)
+		}
+	}
+
+	if order.PurchaseOrderNumber == "" {
+		errs = append(errs, "BEG03 purchase order number is required")
+	}
+	order.ShipTo = parties.shipTo(&errs)
+	errs = append(errs, validateOrderLines(order.Lines, "PO1")...)
+	return order, errs
+}
+
+// readWarehouseShippingOrder reads a 940 warehouse shipping order
+func readWarehouseShippingOrder(segments []Segment) (*domain.EDIOrder, []string) {
+	order := &domain.EDIOrder{}
+	var errs []string
+	parties := newPartyReader()
+	lineNumber := ""
+
+	for _, seg := range segments {
+		switch seg.ID {
+		case "W05":
+			order.DepositorOrderNumber = seg.Element(2)
+			order.PurchaseOrderNumber = seg.Element(3)
+			if order.PurchaseOrderNumber == "" {
+				order.PurchaseOrderNumber = order.DepositorOrderNumber
+			}
+		case "G62":
+			switch seg.Element(1) {
+			case "10":
+				order.RequestedShipDate = readDate(seg.Element(2), "G6202", &errs)
+			case "04":
+				order.OrderDate = readDate(seg.Element(2), "G6202", &errs)
+			}
+		case "W66":
+			order.CarrierSCAC = seg.Element(10)
+		case "LX":
+			lineNumber = seg.Element(1)
+		case "W01":
+			line := domain.EDIOrderLine{
+				LineNumber:    lineNumber,
+				UnitOfMeasure: seg.Element(2),
+				UPC:           seg.Element(3),
+			}
+			line.Quantity = readQuantity(seg.Element(1), "W0101", &errs)
+			line.SKU, _ = readProductIDs(seg, 4)
+			if line.SKU == "" {
+				line.SKU = line.UPC
+			}
+			if line.LineNumber == "" {
+				line.LineNumber = strconv.Itoa(len(order.Lines) + 1)
+			}
+			order.Lines = append(order.Lines, line)
+			lineNumber = ""
+		case "G69":
+			if n := len(order.Lines); n > 0 {
+				order.Lines[n-1].Description = seg.Element(1)
+			}
+		default:
+			parties.read(seg)
+		}
+	}
+
+	if order.PurchaseOrderNumber == "" {
+		errs = append(errs, "W0502 depositor order number is required")
+	}
+	order.ShipTo = parties.shipTo(&errs)
+	errs = append(errs, validateOrderLines(order.Lines, "W01")...)
+	return order, errs
+}
+
+// readShipNotice reads an inbound 856 ship notice
+func readShipNotice(segments []Segment) (*domain.EDIShipNotice, []string) {
+	notice := &domain.EDIShipNotice{}
+	var errs []string
+	parties := newPartyReader()
+
+	for _, seg := range segments {
+		switch seg.ID {
+		case "BSN":
+			notice.ShipmentID = seg.Element(2)
+			notice.ShipDate = readDate(seg.Element(3), "BSN03", &errs)
+		case "TD1":
+			if count, err := strconv.Atoi(seg.Element(2)); err == nil {
+				notice.CartonCount += count
+			}
+			if weight, err := strconv.ParseFloat(seg.Element(7), 64); err == nil {
+				if seg.Element(8) == "LB" {
+					weight *= poundsToKg
+				}
+				notice.TotalWeight += weight
+			}
+		case "TD5":
+			notice.CarrierSCAC = seg.Element(3)
+			notice.CarrierName = seg.Element(5)
+		case "REF":
+			switch seg.Element(1) {
+			case "BM":
+				notice.BillOfLading = seg.Element(2)
+			case "CN":
+				notice.TrackingNumber = seg.Element(2)
+			case "LT":
+				if n := len(notice.Lines); n > 0 {
+					notice.Lines[n-1].LotNumber = seg.Element(2)
+				}
+			}
+		case "DTM":
+			switch seg.Element(1) {
+			case "011":
+				notice.ShipDate = readDate(seg.Element(2), "DTM02", &errs)
+			case "017", "067":
+				notice.ExpectedArrival = readDate(seg.Element(2), "DTM02", &errs)
+			}
+		case "PRF":
+			notice.PurchaseOrderNumber = seg.Element(1)
+		case "LIN":
+			sku, upc := readProductIDs(seg, 2)
+			notice.Lines = append(notice.Lines, domain.EDIShipNoticeLine{SKU: sku, UPC: upc})
+		case "SN1":
+			if n := len(notice.Lines); n > 0 {
+				notice.Lines[n-1].Quantity = readQuantity(seg.Element(2), "SN102", &errs)
+				notice.Lines[n-1].UnitOfMeasure = seg.Element(3)
+			}
+		case "PID":
+			if n := len(notice.Lines); n > 0 && seg.Element(1) == "F" {
+				notice.Lines[n-1].Description = seg.Element(5)
+			}
+		default:
+			parties.read(seg)
+		}
+	}
+
+	if notice.ShipmentID == "" {
+		errs = append(errs, "BSN02 shipment identification is required")
+	}
+	notice.ShipFrom = parties.parties["SF"]
+	if len(notice.Lines) == 0 {
+		errs = append(errs, "no LIN item lines")
+	}
+	for i, line := range notice.Lines {
+		if line.SKU == "" {
+			errs = append(errs, fmt.Sprintf("LIN %d has no product identifier", i+1))
+		}
+		if line.Quantity <= 0 {
+			errs = append(errs, fmt.Sprintf("LIN %d has no SN1 shipped quantity", i+1))
+		}
+	}
+	return notice, errs
+}
+
+// readFunctionalAck reads a 997 functional acknowledgement
+func readFunctionalAck(segments []Segment) (*domain.EDIAcknowledgement, []string) {
+	ack := &domain.EDIAcknowledgement{}
+	var errs []string
+	var current *domain.EDITransactionAck
+
+	for _, seg := range segments {
+		switch seg.ID {
+		case "AK1":
+			ack.FunctionalID = seg.Element(1)
+			ack.GroupControlNumber = seg.Element(2)
+		case "AK2":
+			ack.Transactions = append(ack.Transactions, domain.EDITransactionAck{
+				Type:          domain.EDIDocumentType(seg.Element(1)),
+				ControlNumber: seg.Element(2),
+			})
+			current = &ack.Transactions[len(ack.Transactions)-1]
+		case "AK3":
+			if current != nil {
+				current.Errors = append(current.Errors, fmt.Sprintf("segment %s at position %s: error code %s", seg.Element(1), seg.Element(2), seg.Element(4)))
+			}
+		case "AK4":
+			if current != nil {
+				current.Errors = append(current.Errors, fmt.Sprintf("element %s: error code %s", seg.Element(1), seg.Element(3)))
+			}
+		case "AK5":
+			if current != nil {
+				current.Status = domain.AckStatusFromCode(seg.Element(1))
+				for i := 2; i <= 6; i++ {
+					if code := seg.Element(i); code != "" {
+						current.Errors = append(current.Errors, fmt.Sprintf("transaction set error code %s", code))
+					}
+				}
+			}
+		case "AK9":
+			ack.Status = domain.AckStatusFromCode(seg.Element(1))
+		}
+	}
+
+	if ack.GroupControlNumber == "" {
+		errs = append(errs, "AK102 group control number is required")
+	}
+	if ack.Status == "" {
+		errs = append(errs, "AK9 group acknowledgement is required")
+	}
+	return ack, errs
+}
+
+// partyReader collects N1 loops with their N3 and N4 address segments
+type partyReader struct {
+	parties map[string]domain.EDIParty
+	current string
+}
+
+func newPartyReader() *partyReader {
+	return &partyReader{parties: make(map[string]domain.EDIParty)}
+}
+
+func (r *partyReader) read(seg Segment) {
+	switch seg.ID {
+	case "N1":
+		r.current = seg.Element(1)
+		r.parties[r.current] = domain.EDIParty{Name: seg.Element(2), Identifier: seg.Element(4)}
+	case "N3":
+		if party, ok := r.parties[r.current]; ok {
+			party.Address1 = seg.Element(1)
+			party.Address2 = seg.Element(2)
+			r.parties[r.current] = party
+		}
+	case "N4":
+		if party, ok := r.parties[r.current]; ok {
+			party.City = seg.Element(1)
+			party.State = seg.Element(2)
+			party.PostalCode = seg.Element(3)
+			party.Country = seg.Element(4)
+			if party.Country == "" {
+				party.Country = "US"
+			}
+			r.parties[r.current] = party
+		}
+	}
+}
+
+// shipTo returns the N1*ST party, which orders need a complete address for
+func (r *partyReader) shipTo(errs *[]string) domain.EDIParty {
+	party, ok := r.parties["ST"]
+	if !ok {
+		*errs = append(*errs, "N1*ST ship-to party is required")
+		return party
+	}
+	if party.Address1 == "" || party.City == "" || party.PostalCode == "" {
+		*errs = append(*errs, "ship-to N3 and N4 address is incomplete")
+	}
+	return party
+}
+
+// skuQualifiers are the product ID qualifiers read as the seller's SKU, in order of preference
+var skuQualifiers = []string{"SK", "VN", "VP", "BP", "IN"}
+
+// upcQualifiers are the product ID qualifiers read as a UPC or GTIN
+var upcQualifiers = []string{"UP", "UK", "EN"}
+
+// readProductIDs reads the qualifier/ID pairs starting at element first (PO106, W0104, LIN02)
+func readProductIDs(seg Segment, first int) (sku, upc string) {
+	ids := make(map[string]string)
+	for i := first; i < len(seg.Elements); i += 2 {
+		if qualifier, id := seg.Element(i), seg.Element(i+1); qualifier != "" && id != "" {
+			if _, seen := ids[qualifier]; !seen {
+				ids[qualifier] = id
+			}
+		}
+	}
+	for _, qualifier := range upcQualifiers {
+		if id, ok := ids[qualifier]; ok {
+			upc = id
+			break
+		}
+	}
+	for _, qualifier := range skuQualifiers {
+		if id, ok := ids[qualifier]; ok {
+			return id, upc
+		}
+	}
+	return upc, upc
+}
+
+func validateOrderLines(lines []domain.EDIOrderLine, segmentID string) []string {
+	if len(lines) == 0 {
+		return []string{fmt.Sprintf("no %s line items", segmentID)}
+	}
+	var errs []string
+	for _, line := range lines {
+		if line.SKU == "" {
+			errs = append(errs, fmt.Sprintf("%s line %s has no product identifier", segmentID, line.LineNumber))
+		}
+		if line.Quantity <= 0 {
+			errs = append(errs, fmt.Sprintf("%s line %s has no quantity", segmentID, line.LineNumber))
+		}
+	}
+	return errs
+}
+
+// readQuantity reads a whole unit quantity; fractional quantities cannot be picked
+func readQuantity(value, element string, errs *[]string) int {
+	quantity, err := strconv.ParseFloat(value, 64)
+	if err != nil || quantity != math.Trunc(quantity) {
+		*errs = append(*errs, fmt.Sprintf("%s quantity %q is not a whole number", element, value))
+		return 0
+	}
+	return int(quantity)
+}
+
+// readDate reads a CCYYMMDD or YYMMDD date element
+func readDate(value, element string, errs *[]string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	layout := "20060102"
+	if len(value) == 6 {
+		layout = "060102"
+	}
+	date, err := time.Parse(layout, strings.TrimSpace(value))
+	if err != nil {
+		*errs = append(*errs, fmt.Sprintf("%s date %q is not CCYYMMDD", element, value))
+		return nil
+	}
+	return &date
+}
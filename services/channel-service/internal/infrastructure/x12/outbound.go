@@ -0,0 +1,132 @@
+package x12
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// shippingAdviceBody builds a 945 warehouse shipping advice
+func shippingAdviceBody(c *domain.EDIShipmentConfirmation) ([][]string, error) {
+	if err := requireConfirmation(c); err != nil {
+		return nil, err
+	}
+
+	depositorOrder := c.DepositorOrderNumber
+	if depositorOrder == "" {
+		depositorOrder = c.PurchaseOrderNumber
+	}
+	body := [][]string{
+		{"W06", "N", depositorOrder, c.ShippedAt.Format("20060102"), c.ShipmentID, "", c.PurchaseOrderNumber},
+	}
+	body = append(body, partySegments("ST", c.ShipTo)...)
+	if c.TrackingNumber != "" {
+		body = append(body, []string{"N9", "CN", c.TrackingNumber})
+	}
+	body = append(body,
+		[]string{"G62", "11", c.ShippedAt.Format("20060102"), "8", c.ShippedAt.Format("1504")},
+		[]string{"W27", "M", c.CarrierSCAC},
+	)
+
+	totalUnits := 0
+	for i, line := range c.Lines {
+		productQualifier := ""
+		if line.SKU != "" {
+			productQualifier = "VN"
+		}
+		body = append(body,
+			[]string{"LX", strconv.Itoa(i + 1)},
+			[]string{"W12", "CC", strconv.Itoa(line.Quantity), strconv.Itoa(line.Quantity), "0", unitOfMeasure(line.UnitOfMeasure), line.UPC, productQualifier, line.SKU},
+		)
+		totalUnits += line.Quantity
+	}
+
+	w03 := []string{"W03", strconv.Itoa(totalUnits)}
+	if c.WeightKg > 0 {
+		w03 = append(w03, formatWeight(c.WeightKg), "KG")
+	}
+	return append(body, w03), nil
+}
+
+// shipNoticeBody builds an outbound 856 ship notice with shipment, order and item levels
+func shipNoticeBody(c *domain.EDIShipmentConfirmation) ([][]string, error) {
+	if err := requireConfirmation(c); err != nil {
+		return nil, err
+	}
+
+	body := [][]string{
+		{"BSN", "00", c.ShipmentID, c.ShippedAt.Format("20060102"), c.ShippedAt.Format("1504"), "0001"},
+		{"HL", "1", "", "S"},
+	}
+	td1 := []string{"TD1", "CTN25", "1"}
+	if c.WeightKg > 0 {
+		td1 = append(td1, "", "", "", "G", formatWeight(c.WeightKg), "KG")
+	}
+	body = append(body, td1, []string{"TD5", "", "2", c.CarrierSCAC})
+	if c.TrackingNumber != "" {
+		body = append(body, []string{"REF", "CN", c.TrackingNumber})
+	}
+	body = append(body, []string{"DTM", "011", c.ShippedAt.Format("20060102")})
+	body = append(body, partySegments("ST", c.ShipTo)...)
+
+	body = append(body,
+		[]string{"HL", "2", "1", "O"},
+		[]string{"PRF", c.PurchaseOrderNumber},
+	)
+	for i, line := range c.Lines {
+		lin := []string{"LIN", line.LineNumber, "VN", line.SKU}
+		if line.UPC != "" {
+			lin = append(lin, "UP", line.UPC)
+		}
+		body = append(body,
+			[]string{"HL", strconv.Itoa(i + 3), "2", "I"},
+			lin,
+			[]string{"SN1", line.LineNumber, strconv.Itoa(line.Quantity), unitOfMeasure(line.UnitOfMeasure)},
+		)
+	}
+
+	return append(body, []string{"CTT", strconv.Itoa(len(c.Lines) + 2)}), nil
+}
+
+// functionalAckBody builds a 997 functional acknowledgement
+func functionalAckBody(ack *domain.EDIAcknowledgement) [][]string {
+	body := [][]string{{"AK1", ack.FunctionalID, ack.GroupControlNumber}}
+
+	accepted := 0
+	for _, txn := range ack.Transactions {
+		ak5 := []string{"AK5", txn.Status.Code()}
+		if txn.Status == domain.EDIAckRejected {
+			// 5: one or more segments in error
+			ak5 = append(ak5, "5")
+		} else {
+			accepted++
+		}
+		body = append(body, []string{"AK2", string(txn.Type), txn.ControlNumber}, ak5)
+	}
+
+	received := strconv.Itoa(len(ack.Transactions))
+	return append(body, []string{"AK9", ack.Status.Code(), received, received, strconv.Itoa(accepted)})
+}
+
+func requireConfirmation(c *domain.EDIShipmentConfirmation) error {
+	switch {
+	case c == nil:
+		return fmt.Errorf("shipment confirmation is required")
+	case c.PurchaseOrderNumber == "":
+		return fmt.Errorf("shipment confirmation requires a purchase order number")
+	case c.ShipmentID == "":
+		return fmt.Errorf("shipment confirmation requires a shipment ID")
+	case c.CarrierSCAC == "":
+		return fmt.Errorf("shipment confirmation requires a carrier")
+	}
+	return nil
+}
+
+// unitOfMeasure defaults order lines without a unit of measure to eaches
+func unitOfMeasure(uom string) string {
+	if uom == "" {
+		return "EA"
+	}
+	return uom
+}
@@ -0,0 +1,162 @@
+package x12
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// isaLength is the fixed length of an ISA segment including its terminator
+const isaLength = 106
+
+// Segment is one X12 segment
+type Segment struct {
+	ID       string
+	Elements []string
+}
+
+// Element returns the nth data element using X12 numbering (BEG03 is Element(3)), or "" when absent
+func (s Segment) Element(n int) string {
+	if n < 1 || n > len(s.Elements) {
+		return ""
+	}
+	return strings.TrimSpace(s.Elements[n-1])
+}
+
+// delimiters are the separators an interchange is written with
+type delimiters struct {
+	element string
+	segment string
+}
+
+// interchange is a parsed ISA/IEA envelope
+type interchange struct {
+	isa    Segment
+	groups []group
+}
+
+// group is a parsed GS/GE envelope
+type group struct {
+	gs           Segment
+	transactions []transactionSet
+}
+
+// transactionSet holds the segments between ST and SE
+type transactionSet struct {
+	code          string
+	controlNumber string
+	segments      []Segment
+	errors        []string
+}
+
+// parse splits an interchange into its groups and transaction sets. The delimiters are read
+// from the ISA segment. Envelope errors reject the whole interchange; a transaction set whose
+// SE trailer does not match is kept with an error so it can be rejected in the 997.
+func parse(data []byte) (*interchange, error) {
+	text := strings.TrimLeft(string(data), " \t\r\n\ufeff")
+	if len(text) < isaLength || !strings.HasPrefix(text, "ISA") {
+		return nil, fmt.Errorf("%w: interchange must start with a %d character ISA segment", domain.ErrInvalidInterchange, isaLength)
+	}
+
+	delims := delimiters{
+		element: text[3:4],
+		segment: text[105:106],
+	}
+	segments := splitSegments(text, delims)
+
+	isa := segments[0]
+	if len(isa.Elements) != 16 {
+		return nil, fmt.Errorf("%w: ISA segment has %d elements, expected 16", domain.ErrInvalidInterchange, len(isa.Elements))
+	}
+
+	result := &interchange{isa: isa}
+	var currentGroup *group
+	var currentTxn *transactionSet
+	closed := false
+
+	for _, seg := range segments[1:] {
+		if closed {
+			return nil, fmt.Errorf("%w: segment %s after IEA", domain.ErrInvalidInterchange, seg.ID)
+		}
+
+		switch seg.ID {
+		case "GS":
+			if currentGroup != nil {
+				return nil, fmt.Errorf("%w: GS %s started before GE", domain.ErrInvalidInterchange, seg.Element(6))
+			}
+			currentGroup = &group{gs: seg}
+
+		case "ST":
+			if currentGroup == nil || currentTxn != nil {
+				return nil, fmt.Errorf("%w: ST %s outside a functional group", domain.ErrInvalidInterchange, seg.Element(2))
+			}
+			currentTxn = &transactionSet{code: seg.Element(1), controlNumber: seg.Element(2)}
+
+		case "SE":
+			if currentTxn == nil {
+				return nil, fmt.Errorf("%w: SE without ST", domain.ErrInvalidInterchange)
+			}
+			if count, err := strconv.Atoi(seg.Element(1)); err != nil || count != len(currentTxn.segments)+2 {
+				currentTxn.errors = append(currentTxn.errors, fmt.Sprintf("SE01 segment count %s does not match %d segments", seg.Element(1), len(currentTxn.segments)+2))
+			}
+			if seg.Element(2) != currentTxn.controlNumber {
+				currentTxn.errors = append(currentTxn.errors, fmt.Sprintf("SE02 control number %s does not match ST02 %s", seg.Element(2), currentTxn.controlNumber))
+			}
+			currentGroup.transactions = append(currentGroup.transactions, *currentTxn)
+			currentTxn = nil
+
+		case "GE":
+			if currentGroup == nil || currentTxn != nil {
+				return nil, fmt.Errorf("%w: GE without a complete functional group", domain.ErrInvalidInterchange)
+			}
+			if count, err := strconv.Atoi(seg.Element(1)); err != nil || count != len(currentGroup.transactions) {
+				return nil, fmt.Errorf("%w: GE01 count %s does not match %d transaction sets", domain.ErrInvalidInterchange, seg.Element(1), len(currentGroup.transactions))
+			}
+			if seg.Element(2) != currentGroup.gs.Element(6) {
+				return nil, fmt.Errorf("%w: GE02 %s does not match GS06 %s", domain.ErrInvalidInterchange, seg.Element(2), currentGroup.gs.Element(6))
+			}
+			result.groups = append(result.groups, *currentGroup)
+			currentGroup = nil
+
+		case "IEA":
+			if currentGroup != nil {
+				return nil, fmt.Errorf("%w: IEA before GE", domain.ErrInvalidInterchange)
+			}
+			if count, err := strconv.Atoi(seg.Element(1)); err != nil || count != len(result.groups) {
+				return nil, fmt.Errorf("%w: IEA01 count %s does not match %d functional groups", domain.ErrInvalidInterchange, seg.Element(1), len(result.groups))
+			}
+			if seg.Element(2) != isa.Element(13) {
+				return nil, fmt.Errorf("%w: IEA02 %s does not match ISA13 %s", domain.ErrInvalidInterchange, seg.Element(2), isa.Element(13))
+			}
+			closed = true
+
+		default:
+			if currentTxn == nil {
+				return nil, fmt.Errorf("%w: segment %s outside a transaction set", domain.ErrInvalidInterchange, seg.ID)
+			}
+			currentTxn.segments = append(currentTxn.segments, seg)
+		}
+	}
+
+	if !closed {
+		return nil, fmt.Errorf("%w: missing IEA trailer", domain.ErrInvalidInterchange)
+	}
+	return result, nil
+}
+
+// splitSegments splits on the segment terminator, dropping the line breaks many partners add after it
+func splitSegments(text string, delims delimiters) []Segment {
+	raw := strings.Split(text, delims.segment)
+	segments := make([]Segment, 0, len(raw))
+	for _, r := range raw {
+		r = strings.Trim(r, "\r\n")
+		if strings.TrimSpace(r) == "" {
+			continue
+		}
+		elements := strings.Split(r, delims.element)
+		segments = append(segments, Segment{ID: strings.TrimSpace(elements[0]), Elements: elements[1:]})
+	}
+	return segments
+}
@@ -0,0 +1,110 @@
+package x12
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wms-platform/services/channel-service/internal/domain"
+)
+
+// writer builds an interchange using a trading partner's envelope settings
+type writer struct {
+	env domain.EnvelopeSettings
+	out strings.Builder
+}
+
+func newWriter(env domain.EnvelopeSettings) *writer {
+	return &writer{env: env}
+}
+
+// segment writes one segment, dropping trailing empty elements
+func (w *writer) segment(id string, elements ...string) {
+	for len(elements) > 0 && elements[len(elements)-1] == "" {
+		elements = elements[:len(elements)-1]
+	}
+	w.out.WriteString(id)
+	for _, element := range elements {
+		w.out.WriteString(w.env.ElementSeparator)
+		w.out.WriteString(w.clean(element))
+	}
+	w.out.WriteString(w.env.SegmentTerminator)
+	w.out.WriteString("\n")
+}
+
+// clean removes delimiter characters from free-form data, which would otherwise split the segment
+func (w *writer) clean(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch string(r) {
+		case w.env.ElementSeparator, w.env.ComponentSeparator, w.env.SegmentTerminator:
+			return ' '
+		}
+		return r
+	}, value)
+}
+
+// interchange wraps a single transaction set body in ST/SE, GS/GE and ISA/IEA envelopes
+func (w *writer) interchange(controls domain.ControlNumbers, docType domain.EDIDocumentType, body [][]string, at time.Time) []byte {
+	env := w.env
+	at = at.UTC()
+	icn := controls.InterchangeControlNumber()
+	gcn := controls.GroupControlNumber()
+
+	// ISA elements are fixed width, so it is written without trimming
+	repetition := "U"
+	if env.InterchangeVersion >= "00402" {
+		repetition = "^"
+	}
+	isa := []string{
+		"ISA", "00", pad("", 10), "00", pad("", 10),
+		env.WarehouseQualifier, pad(env.WarehouseID, 15),
+		env.PartnerQualifier, pad(env.PartnerID, 15),
+		at.Format("060102"), at.Format("1504"), repetition, env.InterchangeVersion,
+		icn, "0", env.UsageIndicator, env.ComponentSeparator,
+	}
+	w.out.WriteString(strings.Join(isa, env.ElementSeparator))
+	w.out.WriteString(env.SegmentTerminator)
+	w.out.WriteString("\n")
+
+	w.segment("GS", docType.FunctionalID(), env.WarehouseApplicationCode, env.PartnerApplicationCode,
+		at.Format("20060102"), at.Format("1504"), gcn, "X", env.GroupVersion)
+	w.segment("ST", string(docType), domain.OutboundTransactionControlNumber)
+	for _, seg := range body {
+		w.segment(seg[0], seg[1:]...)
+	}
+	w.segment("SE", strconv.Itoa(len(body)+2), domain.OutboundTransactionControlNumber)
+	w.segment("GE", "1", gcn)
+	w.segment("IEA", "1", icn)
+
+	return []byte(w.out.String())
+}
+
+// pad right-pads fixed-width ISA identifiers with spaces
+func pad(value string, width int) string {
+	if len(value) >= width {
+		return value[:width]
+	}
+	return value + strings.Repeat(" ", width-len(value))
+}
+
+// formatWeight writes a weight rounded to two decimals
+func formatWeight(weight float64) string {
+	return strconv.FormatFloat(math.Round(weight*100)/100, 'f', -1, 64)
+}
+
+// partySegments writes an N1 loop with its N3 and N4 address segments
+func partySegments(code string, party domain.EDIParty) [][]string {
+	n1 := []string{"N1", code, party.Name}
+	if party.Identifier != "" {
+		n1 = append(n1, "92", party.Identifier)
+	}
+	segments := [][]string{n1}
+	if party.Address1 != "" {
+		segments = append(segments, []string{"N3", party.Address1, party.Address2})
+	}
+	if party.City != "" {
+		segments = append(segments, []string{"N4", party.City, party.State, party.PostalCode, party.Country})
+	}
+	return segments
+}
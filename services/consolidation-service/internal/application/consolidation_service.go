@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 	"github.com/wms-platform/shared/pkg/tenant"
 
 	"github.com/wms-platform/consolidation-service/internal/domain"
@@ -93,23 +94,14 @@ func (s *ConsolidationApplicationService) GetConsolidation(ctx context.Context,
 
 // AssignStation assigns a station to a consolidation unit
 func (s *ConsolidationApplicationService) AssignStation(ctx context.Context, cmd AssignStationCommand) (*ConsolidationDTO, error) {
-	unit, err := s.repo.FindByID(ctx, cmd.ConsolidationID)
+	unit, err := s.updateUnit(ctx, cmd.ConsolidationID, func(unit *domain.ConsolidationUnit) error {
+		if err := unit.AssignStation(cmd.Station, cmd.WorkerID, cmd.DestinationBin); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get consolidation", "consolidationId", cmd.ConsolidationID)
-		return nil, fmt.Errorf("failed to get consolidation: %w", err)
-	}
-
-	if unit == nil {
-		return nil, errors.ErrNotFound("consolidation")
-	}
-
-	if err := unit.AssignStation(cmd.Station, cmd.WorkerID, cmd.DestinationBin); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, unit); err != nil {
-		s.logger.WithError(err).Error("Failed to save consolidation", "consolidationId", cmd.ConsolidationID)
-		return nil, fmt.Errorf("failed to save consolidation: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -120,23 +112,14 @@ func (s *ConsolidationApplicationService) AssignStation(ctx context.Context, cmd
 
 // ConsolidateItem consolidates an item into the unit
 func (s *ConsolidationApplicationService) ConsolidateItem(ctx context.Context, cmd ConsolidateItemCommand) (*ConsolidationDTO, error) {
-	unit, err := s.repo.FindByID(ctx, cmd.ConsolidationID)
+	unit, err := s.updateUnit(ctx, cmd.ConsolidationID, func(unit *domain.ConsolidationUnit) error {
+		if err := unit.ConsolidateItem(cmd.SKU, cmd.Quantity, cmd.SourceToteID, cmd.VerifiedBy); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get consolidation", "consolidationId", cmd.ConsolidationID)
-		return nil, fmt.Errorf("failed to get consolidation: %w", err)
-	}
-
-	if unit == nil {
-		return nil, errors.ErrNotFound("consolidation")
-	}
-
-	if err := unit.ConsolidateItem(cmd.SKU, cmd.Quantity, cmd.SourceToteID, cmd.VerifiedBy); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, unit); err != nil {
-		s.logger.WithError(err).Error("Failed to save consolidation", "consolidationId", cmd.ConsolidationID)
-		return nil, fmt.Errorf("failed to save consolidation: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -147,23 +130,14 @@ func (s *ConsolidationApplicationService) ConsolidateItem(ctx context.Context, c
 
 // CompleteConsolidation completes a consolidation unit
 func (s *ConsolidationApplicationService) CompleteConsolidation(ctx context.Context, cmd CompleteConsolidationCommand) (*ConsolidationDTO, error) {
-	unit, err := s.repo.FindByID(ctx, cmd.ConsolidationID)
+	unit, err := s.updateUnit(ctx, cmd.ConsolidationID, func(unit *domain.ConsolidationUnit) error {
+		if err := unit.Complete(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get consolidation", "consolidationId", cmd.ConsolidationID)
-		return nil, fmt.Errorf("failed to get consolidation: %w", err)
-	}
-
-	if unit == nil {
-		return nil, errors.ErrNotFound("consolidation")
-	}
-
-	if err := unit.Complete(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, unit); err != nil {
-		s.logger.WithError(err).Error("Failed to save consolidation", "consolidationId", cmd.ConsolidationID)
-		return nil, fmt.Errorf("failed to save consolidation: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -179,6 +153,45 @@ func (s *ConsolidationApplicationService) CompleteConsolidation(ctx context.Cont
 	return ToConsolidationDTO(unit), nil
 }
 
+// updateUnit loads a consolidation unit, applies change and saves it. A save that loses
+// a race with another writer reloads the unit and applies change again.
+func (s *ConsolidationApplicationService) updateUnit(ctx context.Context, consolidationID string, change func(unit *domain.ConsolidationUnit) error) (*domain.ConsolidationUnit, error) {
+	var unit *domain.ConsolidationUnit
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.repo.FindByID(ctx, consolidationID)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to get consolidation", "consolidationId", consolidationID)
+			return fmt.Errorf("failed to get consolidation: %w", err)
+		}
+
+		if loaded == nil {
+			return errors.ErrNotFound("consolidation")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				s.logger.Debug("Consolidation changed concurrently, retrying", "consolidationId", consolidationID)
+				return err
+			}
+			s.logger.WithError(err).Error("Failed to save consolidation", "consolidationId", consolidationID)
+			return fmt.Errorf("failed to save consolidation: %w", err)
+		}
+		unit = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("consolidation %s was modified concurrently, please retry", consolidationID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return unit, nil
+}
+
 // GetByOrder retrieves a consolidation unit by order ID
 func (s *ConsolidationApplicationService) GetByOrder(ctx context.Context, query GetByOrderQuery) (*ConsolidationDTO, error) {
 	unit, err := s.repo.FindByOrderID(ctx, query.OrderID)
@@ -58,6 +58,7 @@ type ConsolidationUnit struct {
 	UpdatedAt           time.Time             `bson:"updatedAt"`
 	StartedAt           *time.Time            `bson:"startedAt,omitempty"`
 	CompletedAt         *time.Time            `bson:"completedAt,omitempty"`
+	Version             int                   `bson:"version"` // Incremented on every save for optimistic concurrency
 	DomainEvents        []DomainEvent         `bson:"-"`
 
 	// Multi-route support fields
@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/consolidation-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -54,8 +55,12 @@ func (r *ConsolidationRepository) ensureIndexes(ctx context.Context) {
 	r.collection.Indexes().CreateMany(ctx, indexes)
 }
 
+// Save persists the consolidation unit and its events, failing with ErrConcurrencyConflict
+// when another writer saved the unit after it was loaded
 func (r *ConsolidationRepository) Save(ctx context.Context, unit *domain.ConsolidationUnit) error {
 	unit.UpdatedAt = time.Now()
+	expectedVersion := unit.Version
+	unit.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -67,11 +72,12 @@ func (r *ConsolidationRepository) Save(ctx context.Context, unit *domain.Consoli
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"consolidationId": unit.ConsolidationID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"consolidationId": unit.ConsolidationID}, expectedVersion)
 		update := bson.M{"$set": unit}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "ConsolidationUnit", unit.ConsolidationID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save consolidation unit: %w", err)
 		}
 
@@ -123,6 +129,7 @@ func (r *ConsolidationRepository) Save(ctx context.Context, unit *domain.Consoli
 	})
 
 	if err != nil {
+		unit.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
@@ -75,9 +75,11 @@ type appDependencies struct {
 	closeInstrumentedProd   func(p *kafka.InstrumentedProducer) error
 	newEventFactory         func(source string) *cloudevents.EventFactory
 	newStationRepository    func(db *mongo.Database, factory *cloudevents.EventFactory) stationRepository
+	newEquipmentRepository  func(db *mongo.Database, outboxRepo outbox.Repository, factory *cloudevents.EventFactory) application.EquipmentRepository
 	newIdempotencyKeyRepo   func(db *mongo.Database) idempotency.KeyRepository
 	newOutboxPublisher      func(repo outbox.Repository, producer *kafka.InstrumentedProducer, logger *logging.Logger, m *metrics.Metrics, cfg *outbox.PublisherConfig) outboxPublisher
 	newStationService       func(repo application.StationRepository, producer *kafka.InstrumentedProducer, factory *cloudevents.EventFactory, logger *logging.Logger) handlers.StationService
+	newEquipmentService     func(repo application.EquipmentRepository, stationRepo application.StationRepository, logger *logging.Logger, reservationDuration time.Duration) handlers.EquipmentService
 	newHTTPServer           func(addr string, handler http.Handler) httpServer
 }
 
@@ -104,6 +106,9 @@ func defaultDependencies() appDependencies {
 		newStationRepository: func(db *mongo.Database, factory *cloudevents.EventFactory) stationRepository {
 			return mongoRepo.NewStationRepository(db, factory)
 		},
+		newEquipmentRepository: func(db *mongo.Database, outboxRepo outbox.Repository, factory *cloudevents.EventFactory) application.EquipmentRepository {
+			return mongoRepo.NewEquipmentRepository(db, outboxRepo, factory)
+		},
 		newIdempotencyKeyRepo: func(db *mongo.Database) idempotency.KeyRepository {
 			return idempotency.NewMongoKeyRepository(db)
 		},
@@ -113,6 +118,9 @@ func defaultDependencies() appDependencies {
 		newStationService: func(repo application.StationRepository, producer *kafka.InstrumentedProducer, factory *cloudevents.EventFactory, logger *logging.Logger) handlers.StationService {
 			return application.NewStationApplicationService(repo, producer, factory, logger)
 		},
+		newEquipmentService: func(repo application.EquipmentRepository, stationRepo application.StationRepository, logger *logging.Logger, reservationDuration time.Duration) handlers.EquipmentService {
+			return application.NewEquipmentApplicationService(repo, stationRepo, logger, reservationDuration)
+		},
 		newHTTPServer: func(addr string, handler http.Handler) httpServer {
 			return &http.Server{
 				Addr:         addr,
@@ -159,6 +167,9 @@ func (d appDependencies) withDefaults() appDependencies {
 	if d.newStationRepository == nil {
 		d.newStationRepository = def.newStationRepository
 	}
+	if d.newEquipmentRepository == nil {
+		d.newEquipmentRepository = def.newEquipmentRepository
+	}
 	if d.newIdempotencyKeyRepo == nil {
 		d.newIdempotencyKeyRepo = def.newIdempotencyKeyRepo
 	}
@@ -168,6 +179,9 @@ func (d appDependencies) withDefaults() appDependencies {
 	if d.newStationService == nil {
 		d.newStationService = def.newStationService
 	}
+	if d.newEquipmentService == nil {
+		d.newEquipmentService = def.newEquipmentService
+	}
 	if d.newHTTPServer == nil {
 		d.newHTTPServer = def.newHTTPServer
 	}
@@ -257,6 +271,8 @@ func run(ctx context.Context, config *Config, deps appDependencies, signalCh <-c
 		db = instrumentedMongo.Database()
 	}
 	stationRepo := deps.newStationRepository(db, eventFactory)
+	// Equipment shares the station outbox so one publisher drains both aggregates
+	equipmentRepo := deps.newEquipmentRepository(db, stationRepo.GetOutboxRepository(), eventFactory)
 
 	// Initialize idempotency repository
 	idempotencyKeyRepo := deps.newIdempotencyKeyRepo(db)
@@ -284,6 +300,7 @@ func run(ctx context.Context, config *Config, deps appDependencies, signalCh <-c
 
 	// Initialize application services
 	stationService := deps.newStationService(stationRepo, instrumentedProducer, eventFactory, logger)
+	equipmentService := deps.newEquipmentService(equipmentRepo, stationRepo, logger, config.EquipmentReservationTTL)
 
 	// Setup Gin router with middleware
 	router := gin.New()
@@ -339,6 +356,10 @@ func run(ctx context.Context, config *Config, deps appDependencies, signalCh <-c
 	stationHandlers := handlers.NewStationHandlers(stationService, logger, businessMetrics)
 	stationHandlers.RegisterRoutes(apiV1)
 
+	// Equipment routes (registry and time-boxed reservations used by the orchestrator)
+	equipmentHandlers := handlers.NewEquipmentHandlers(equipmentService, logger)
+	equipmentHandlers.RegisterRoutes(apiV1)
+
 	// Start server
 	srv := deps.newHTTPServer(config.ServerAddr, router)
 
@@ -373,9 +394,10 @@ func run(ctx context.Context, config *Config, deps appDependencies, signalCh <-c
 
 // Config holds application configuration
 type Config struct {
-	ServerAddr string
-	MongoDB    *mongodb.Config
-	Kafka      *kafka.Config
+	ServerAddr              string
+	MongoDB                 *mongodb.Config
+	Kafka                   *kafka.Config
+	EquipmentReservationTTL time.Duration
 }
 
 func loadConfig() *Config {
@@ -396,6 +418,7 @@ func loadConfig() *Config {
 			BatchTimeout:  10 * time.Millisecond,
 			RequiredAcks:  -1,
 		},
+		EquipmentReservationTTL: getEnvDuration("EQUIPMENT_RESERVATION_TTL", 30*time.Minute),
 	}
 }
 
@@ -405,3 +428,12 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
@@ -56,6 +56,14 @@ func TestLoadConfig(t *testing.T) {
 	if len(cfg.Kafka.Brokers) != 1 || cfg.Kafka.Brokers[0] != "kafka:9092" {
 		t.Fatalf("Kafka brokers = %#v", cfg.Kafka.Brokers)
 	}
+	if cfg.EquipmentReservationTTL != 30*time.Minute {
+		t.Fatalf("EquipmentReservationTTL = %v", cfg.EquipmentReservationTTL)
+	}
+
+	t.Setenv("EQUIPMENT_RESERVATION_TTL", "45m")
+	if got := loadConfig().EquipmentReservationTTL; got != 45*time.Minute {
+		t.Fatalf("EquipmentReservationTTL override = %v", got)
+	}
 }
 
 type fakeTracerProvider struct {
@@ -106,10 +114,14 @@ type fakeServer struct {
 	listenCalls   int
 	shutdownCalls int
 	listenErr     error
+	listening     chan struct{} // Optional: lets Shutdown wait for the serve goroutine
 }
 
 func (f *fakeServer) ListenAndServe() error {
 	f.listenCalls++
+	if f.listening != nil {
+		close(f.listening)
+	}
 	if f.listenErr != nil {
 		return f.listenErr
 	}
@@ -117,6 +129,12 @@ func (f *fakeServer) ListenAndServe() error {
 }
 
 func (f *fakeServer) Shutdown(ctx context.Context) error {
+	if f.listening != nil {
+		select {
+		case <-f.listening:
+		case <-ctx.Done():
+		}
+	}
 	f.shutdownCalls++
 	return nil
 }
@@ -203,6 +221,40 @@ func (f *fakeStationRepo) GetOutboxRepository() outbox.Repository {
 	return f.outboxRepo
 }
 
+type fakeEquipmentRepo struct{}
+
+func (f fakeEquipmentRepo) Save(ctx context.Context, equipment *domain.Equipment) error {
+	return nil
+}
+
+func (f fakeEquipmentRepo) FindByID(ctx context.Context, equipmentID string) (*domain.Equipment, error) {
+	return nil, nil
+}
+
+func (f fakeEquipmentRepo) FindByType(ctx context.Context, equipmentType, zone string, status domain.EquipmentStatus) ([]*domain.Equipment, error) {
+	return nil, nil
+}
+
+func (f fakeEquipmentRepo) FindAvailable(ctx context.Context, equipmentType, zone string, asOf time.Time, limit int) ([]*domain.Equipment, error) {
+	return nil, nil
+}
+
+func (f fakeEquipmentRepo) FindByReservationID(ctx context.Context, reservationID string) ([]*domain.Equipment, error) {
+	return nil, nil
+}
+
+func (f fakeEquipmentRepo) FindByStationID(ctx context.Context, stationID string) ([]*domain.Equipment, error) {
+	return nil, nil
+}
+
+func (f fakeEquipmentRepo) FindAll(ctx context.Context, limit, offset int) ([]*domain.Equipment, error) {
+	return nil, nil
+}
+
+func (f fakeEquipmentRepo) Delete(ctx context.Context, equipmentID string) error {
+	return nil
+}
+
 type fakeKeyRepo struct{}
 
 func (f fakeKeyRepo) AcquireLock(ctx context.Context, key *idempotency.IdempotencyKey) (*idempotency.IdempotencyKey, bool, error) {
@@ -295,11 +347,12 @@ func TestRunSuccess(t *testing.T) {
 	tracer := &fakeTracerProvider{}
 	fakeMongo := &fakeInstrumentedMongo{}
 	publisher := &fakeOutboxPublisher{}
-	server := &fakeServer{}
+	server := &fakeServer{listening: make(chan struct{})}
 	repo := &fakeStationRepo{outboxRepo: &fakeOutboxRepo{}}
 
 	var idempotencyCalls int
 	var producerCloseCalls int
+	var equipmentOutbox outbox.Repository
 
 	deps := appDependencies{
 		initTracing: func(ctx context.Context, cfg *tracing.Config) (tracerProvider, error) {
@@ -337,6 +390,10 @@ func TestRunSuccess(t *testing.T) {
 		newStationRepository: func(db *mongo.Database, factory *cloudevents.EventFactory) stationRepository {
 			return repo
 		},
+		newEquipmentRepository: func(db *mongo.Database, outboxRepo outbox.Repository, factory *cloudevents.EventFactory) application.EquipmentRepository {
+			equipmentOutbox = outboxRepo
+			return fakeEquipmentRepo{}
+		},
 		newIdempotencyKeyRepo: func(db *mongo.Database) idempotency.KeyRepository {
 			return fakeKeyRepo{}
 		},
@@ -376,6 +433,9 @@ func TestRunSuccess(t *testing.T) {
 	if producerCloseCalls != 1 {
 		t.Fatalf("producer close calls = %d", producerCloseCalls)
 	}
+	if equipmentOutbox != repo.outboxRepo {
+		t.Fatalf("equipment repository should share the station outbox")
+	}
 }
 
 func TestRunOutboxStartError(t *testing.T) {
@@ -409,6 +469,9 @@ func TestRunOutboxStartError(t *testing.T) {
 		newStationRepository: func(db *mongo.Database, factory *cloudevents.EventFactory) stationRepository {
 			return &fakeStationRepo{outboxRepo: &fakeOutboxRepo{}}
 		},
+		newEquipmentRepository: func(db *mongo.Database, outboxRepo outbox.Repository, factory *cloudevents.EventFactory) application.EquipmentRepository {
+			return fakeEquipmentRepo{}
+		},
 		newIdempotencyKeyRepo: func(db *mongo.Database) idempotency.KeyRepository {
 			return fakeKeyRepo{}
 		},
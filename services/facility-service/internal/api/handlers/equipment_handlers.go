@@ -0,0 +1,395 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/facility-service/internal/application"
+)
+
+// EquipmentService defines the application service behavior needed by equipment handlers.
+type EquipmentService interface {
+	RegisterEquipment(ctx context.Context, cmd application.RegisterEquipmentCommand) (*application.EquipmentDTO, error)
+	GetEquipment(ctx context.Context, query application.GetEquipmentQuery) (*application.EquipmentDTO, error)
+	ListEquipment(ctx context.Context, query application.ListEquipmentQuery) ([]application.EquipmentDTO, error)
+	GetByType(ctx context.Context, query application.GetEquipmentByTypeQuery) ([]application.EquipmentDTO, error)
+	CheckAvailability(ctx context.Context, query application.CheckEquipmentAvailabilityQuery) (*application.EquipmentAvailabilityDTO, error)
+	ReserveEquipment(ctx context.Context, cmd application.ReserveEquipmentCommand) (*application.EquipmentReservationResultDTO, error)
+	ReleaseEquipment(ctx context.Context, cmd application.ReleaseEquipmentCommand) (*application.EquipmentReleaseResultDTO, error)
+	SetEquipmentStatus(ctx context.Context, cmd application.SetEquipmentStatusCommand) (*application.EquipmentDTO, error)
+	AssignToStation(ctx context.Context, cmd application.AssignEquipmentToStationCommand) (*application.EquipmentDTO, error)
+	DeleteEquipment(ctx context.Context, cmd application.DeleteEquipmentCommand) error
+}
+
+// EquipmentHandlers contains handlers for equipment operations
+type EquipmentHandlers struct {
+	service EquipmentService
+	logger  *logging.Logger
+}
+
+// NewEquipmentHandlers creates a new EquipmentHandlers
+func NewEquipmentHandlers(service EquipmentService, logger *logging.Logger) *EquipmentHandlers {
+	return &EquipmentHandlers{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers equipment routes on the router
+func (h *EquipmentHandlers) RegisterRoutes(router *gin.RouterGroup) {
+	equipment := router.Group("/equipment")
+	{
+		equipment.POST("", h.RegisterEquipment)
+		equipment.GET("", h.ListEquipment)
+		equipment.POST("/availability", h.CheckAvailability)
+		equipment.POST("/reserve", h.ReserveEquipment)
+		equipment.POST("/release", h.ReleaseEquipment)
+		equipment.GET("/type/:type", h.GetByType)
+		equipment.GET("/:equipmentId", h.GetEquipment)
+		equipment.DELETE("/:equipmentId", h.DeleteEquipment)
+		equipment.PUT("/:equipmentId/status", h.SetStatus)
+		equipment.PUT("/:equipmentId/station", h.AssignToStation)
+	}
+}
+
+// RegisterEquipment handles equipment registration
+func (h *EquipmentHandlers) RegisterEquipment(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger.Logger)
+
+	var req struct {
+		EquipmentID   string `json:"equipmentId" binding:"required"`
+		EquipmentType string `json:"equipmentType" binding:"required"`
+		Name          string `json:"name" binding:"required"`
+		Zone          string `json:"zone"`
+		StationID     string `json:"stationId"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation":      "create",
+		"equipment.id":   req.EquipmentID,
+		"equipment.type": req.EquipmentType,
+		"equipment.zone": req.Zone,
+	})
+
+	cmd := application.RegisterEquipmentCommand{
+		EquipmentID:   req.EquipmentID,
+		EquipmentType: req.EquipmentType,
+		Name:          req.Name,
+		Zone:          req.Zone,
+		StationID:     req.StationID,
+	}
+
+	equipment, err := h.service.RegisterEquipment(c.Request.Context(), cmd)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			responder.RespondWithAppError(appErr)
+		} else {
+			responder.RespondInternalError(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, equipment)
+}
+
+// GetEquipment handles getting equipment by ID
+func (h *EquipmentHandlers) GetEquipment(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger.Logger)
+
+	equipmentID := c.Param("equipmentId")
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation":    "read",
+		"equipment.id": equipmentID,
+	})
+
+	equipment, err := h.service.GetEquipment(c.Request.Context(), application.GetEquipmentQuery{EquipmentID: equipmentID})
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			responder.RespondWithAppError(appErr)
+		} else {
+			responder.RespondInternalError(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, equipment)
+}
+
+// ListEquipment handles listing all equipment
+func (h *EquipmentHandlers) ListEquipment(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger.Logger)
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation":    "read",
+		"query.limit":  limit,
+		"query.offset": offset,
+	})
+
+	equipment, err := h.service.ListEquipment(c.Request.Context(), application.ListEquipmentQuery{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		responder.RespondInternalError(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, equipment)
+}
+
+// GetByType handles getting equipment by type, optionally filtered by zone and status
+func (h *EquipmentHandlers) GetByType(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger.Logger)
+
+	query := application.GetEquipmentByTypeQuery{
+		EquipmentType: c.Param("type"),
+		Zone:          c.Query("zone"),
+		Status:        c.Query("status"),
+	}
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation":      "read",
+		"equipment.type": query.EquipmentType,
+		"equipment.zone": query.Zone,
+	})
+
+	equipment, err := h.service.GetByType(c.Request.Context(), query)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			responder.RespondWithAppError(appErr)
+		} else {
+			responder.RespondInternalError(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, equipment)
+}
+
+// CheckAvailability handles counting reservable equipment per type
+func (h *EquipmentHandlers) CheckAvailability(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger.Logger)
+
+	var req struct {
+		EquipmentTypes []string `json:"equipmentTypes" binding:"required"`
+		Zone           string   `json:"zone"`
+		RequiredCount  int      `json:"requiredCount"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation":       "read",
+		"equipment.types": len(req.EquipmentTypes),
+		"equipment.zone":  req.Zone,
+	})
+
+	availability, err := h.service.CheckAvailability(c.Request.Context(), application.CheckEquipmentAvailabilityQuery{
+		EquipmentTypes: req.EquipmentTypes,
+		Zone:           req.Zone,
+		RequiredCount:  req.RequiredCount,
+	})
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			responder.RespondWithAppError(appErr)
+		} else {
+			responder.RespondInternalError(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// ReserveEquipment handles reserving equipment for an order
+func (h *EquipmentHandlers) ReserveEquipment(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger.Logger)
+
+	var req struct {
+		ReservationID string `json:"reservationId" binding:"required"`
+		EquipmentType string `json:"equipmentType" binding:"required"`
+		OrderID       string `json:"orderId" binding:"required"`
+		Quantity      int    `json:"quantity"`
+		Zone          string `json:"zone"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation":      "reserve",
+		"reservation.id": req.ReservationID,
+		"order.id":       req.OrderID,
+		"equipment.type": req.EquipmentType,
+	})
+
+	result, err := h.service.ReserveEquipment(c.Request.Context(), application.ReserveEquipmentCommand{
+		ReservationID: req.ReservationID,
+		EquipmentType: req.EquipmentType,
+		OrderID:       req.OrderID,
+		Quantity:      req.Quantity,
+		Zone:          req.Zone,
+	})
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			responder.RespondWithAppError(appErr)
+		} else {
+			responder.RespondInternalError(err)
+		}
+		return
+	}
+
+	middleware.AddSpanEvent(c, "equipment_reserved", map[string]interface{}{
+		"reservation_id": result.ReservationID,
+		"count":          len(result.ReservedEquipmentIDs),
+	})
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ReleaseEquipment handles releasing a reservation
+func (h *EquipmentHandlers) ReleaseEquipment(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger.Logger)
+
+	var req struct {
+		ReservationID string `json:"reservationId" binding:"required"`
+		EquipmentType string `json:"equipmentType"`
+		OrderID       string `json:"orderId"`
+		Reason        string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation":      "release",
+		"reservation.id": req.ReservationID,
+		"order.id":       req.OrderID,
+	})
+
+	result, err := h.service.ReleaseEquipment(c.Request.Context(), application.ReleaseEquipmentCommand{
+		ReservationID: req.ReservationID,
+		EquipmentType: req.EquipmentType,
+		OrderID:       req.OrderID,
+		Reason:        req.Reason,
+	})
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			responder.RespondWithAppError(appErr)
+		} else {
+			responder.RespondInternalError(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SetStatus handles equipment status changes
+func (h *EquipmentHandlers) SetStatus(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger.Logger)
+
+	equipmentID := c.Param("equipmentId")
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation":    "update",
+		"equipment.id": equipmentID,
+	})
+
+	var req struct {
+		Status     string `json:"status" binding:"required"`
+		AssignedTo string `json:"assignedTo"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	equipment, err := h.service.SetEquipmentStatus(c.Request.Context(), application.SetEquipmentStatusCommand{
+		EquipmentID: equipmentID,
+		Status:      req.Status,
+		AssignedTo:  req.AssignedTo,
+	})
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			responder.RespondWithAppError(appErr)
+		} else {
+			responder.RespondInternalError(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, equipment)
+}
+
+// AssignToStation handles linking equipment to a station
+func (h *EquipmentHandlers) AssignToStation(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger.Logger)
+
+	equipmentID := c.Param("equipmentId")
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation":    "update",
+		"equipment.id": equipmentID,
+	})
+
+	var req struct {
+		StationID string `json:"stationId"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	equipment, err := h.service.AssignToStation(c.Request.Context(), application.AssignEquipmentToStationCommand{
+		EquipmentID: equipmentID,
+		StationID:   req.StationID,
+	})
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			responder.RespondWithAppError(appErr)
+		} else {
+			responder.RespondInternalError(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, equipment)
+}
+
+// DeleteEquipment handles equipment deletion
+func (h *EquipmentHandlers) DeleteEquipment(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger.Logger)
+
+	equipmentID := c.Param("equipmentId")
+	middleware.AddSpanAttributes(c, map[string]interface{}{
+		"operation":    "delete",
+		"equipment.id": equipmentID,
+	})
+
+	if err := h.service.DeleteEquipment(c.Request.Context(), application.DeleteEquipmentCommand{EquipmentID: equipmentID}); err != nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			responder.RespondWithAppError(appErr)
+		} else {
+			responder.RespondInternalError(err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+
+	"github.com/wms-platform/facility-service/internal/application"
+)
+
+type mockEquipmentService struct {
+	registerFn     func(ctx context.Context, cmd application.RegisterEquipmentCommand) (*application.EquipmentDTO, error)
+	getFn          func(ctx context.Context, query application.GetEquipmentQuery) (*application.EquipmentDTO, error)
+	listFn         func(ctx context.Context, query application.ListEquipmentQuery) ([]application.EquipmentDTO, error)
+	getByTypeFn    func(ctx context.Context, query application.GetEquipmentByTypeQuery) ([]application.EquipmentDTO, error)
+	availabilityFn func(ctx context.Context, query application.CheckEquipmentAvailabilityQuery) (*application.EquipmentAvailabilityDTO, error)
+	reserveFn      func(ctx context.Context, cmd application.ReserveEquipmentCommand) (*application.EquipmentReservationResultDTO, error)
+	releaseFn      func(ctx context.Context, cmd application.ReleaseEquipmentCommand) (*application.EquipmentReleaseResultDTO, error)
+	setStatusFn    func(ctx context.Context, cmd application.SetEquipmentStatusCommand) (*application.EquipmentDTO, error)
+	assignFn       func(ctx context.Context, cmd application.AssignEquipmentToStationCommand) (*application.EquipmentDTO, error)
+	deleteFn       func(ctx context.Context, cmd application.DeleteEquipmentCommand) error
+}
+
+func (m *mockEquipmentService) RegisterEquipment(ctx context.Context, cmd application.RegisterEquipmentCommand) (*application.EquipmentDTO, error) {
+	return m.registerFn(ctx, cmd)
+}
+
+func (m *mockEquipmentService) GetEquipment(ctx context.Context, query application.GetEquipmentQuery) (*application.EquipmentDTO, error) {
+	return m.getFn(ctx, query)
+}
+
+func (m *mockEquipmentService) ListEquipment(ctx context.Context, query application.ListEquipmentQuery) ([]application.EquipmentDTO, error) {
+	return m.listFn(ctx, query)
+}
+
+func (m *mockEquipmentService) GetByType(ctx context.Context, query application.GetEquipmentByTypeQuery) ([]application.EquipmentDTO, error) {
+	return m.getByTypeFn(ctx, query)
+}
+
+func (m *mockEquipmentService) CheckAvailability(ctx context.Context, query application.CheckEquipmentAvailabilityQuery) (*application.EquipmentAvailabilityDTO, error) {
+	return m.availabilityFn(ctx, query)
+}
+
+func (m *mockEquipmentService) ReserveEquipment(ctx context.Context, cmd application.ReserveEquipmentCommand) (*application.EquipmentReservationResultDTO, error) {
+	return m.reserveFn(ctx, cmd)
+}
+
+func (m *mockEquipmentService) ReleaseEquipment(ctx context.Context, cmd application.ReleaseEquipmentCommand) (*application.EquipmentReleaseResultDTO, error) {
+	return m.releaseFn(ctx, cmd)
+}
+
+func (m *mockEquipmentService) SetEquipmentStatus(ctx context.Context, cmd application.SetEquipmentStatusCommand) (*application.EquipmentDTO, error) {
+	return m.setStatusFn(ctx, cmd)
+}
+
+func (m *mockEquipmentService) AssignToStation(ctx context.Context, cmd application.AssignEquipmentToStationCommand) (*application.EquipmentDTO, error) {
+	return m.assignFn(ctx, cmd)
+}
+
+func (m *mockEquipmentService) DeleteEquipment(ctx context.Context, cmd application.DeleteEquipmentCommand) error {
+	return m.deleteFn(ctx, cmd)
+}
+
+func newEquipmentTestRouter(service EquipmentService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	logger := logging.New(logging.DefaultConfig("test"))
+	handlers := NewEquipmentHandlers(service, logger)
+	handlers.RegisterRoutes(router.Group("/api/v1"))
+	return router
+}
+
+func TestEquipmentHandlers_RegisterEquipment(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		service := &mockEquipmentService{
+			registerFn: func(ctx context.Context, cmd application.RegisterEquipmentCommand) (*application.EquipmentDTO, error) {
+				if cmd.EquipmentID != "EQ-1" || cmd.StationID != "STN-1" {
+					t.Fatalf("unexpected cmd %+v", cmd)
+				}
+				return &application.EquipmentDTO{EquipmentID: cmd.EquipmentID}, nil
+			},
+		}
+		router := newEquipmentTestRouter(service)
+		rec := performRequest(router, http.MethodPost, "/api/v1/equipment",
+			`{"equipmentId":"EQ-1","equipmentType":"scale","name":"Scale","stationId":"STN-1"}`)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("status = %d", rec.Code)
+		}
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		router := newEquipmentTestRouter(&mockEquipmentService{})
+		rec := performRequest(router, http.MethodPost, "/api/v1/equipment", `{"equipmentId":"EQ-1"}`)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d", rec.Code)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		service := &mockEquipmentService{
+			registerFn: func(ctx context.Context, cmd application.RegisterEquipmentCommand) (*application.EquipmentDTO, error) {
+				return nil, errors.ErrConflict("equipment already exists")
+			},
+		}
+		router := newEquipmentTestRouter(service)
+		rec := performRequest(router, http.MethodPost, "/api/v1/equipment",
+			`{"equipmentId":"EQ-1","equipmentType":"scale","name":"Scale"}`)
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("status = %d", rec.Code)
+		}
+	})
+}
+
+func TestEquipmentHandlers_CheckAvailability(t *testing.T) {
+	service := &mockEquipmentService{
+		availabilityFn: func(ctx context.Context, query application.CheckEquipmentAvailabilityQuery) (*application.EquipmentAvailabilityDTO, error) {
+			if len(query.EquipmentTypes) != 2 || query.Zone != "A" || query.RequiredCount != 1 {
+				t.Fatalf("unexpected query %+v", query)
+			}
+			return &application.EquipmentAvailabilityDTO{AvailableEquipment: map[string]int{"forklift": 2, "scale": 0}}, nil
+		},
+	}
+	router := newEquipmentTestRouter(service)
+	rec := performRequest(router, http.MethodPost, "/api/v1/equipment/availability",
+		`{"equipmentTypes":["forklift","scale"],"zone":"A","requiredCount":1}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+
+	var body struct {
+		AvailableEquipment map[string]int `json:"availableEquipment"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.AvailableEquipment["forklift"] != 2 {
+		t.Fatalf("unexpected body %s", rec.Body.String())
+	}
+}
+
+func TestEquipmentHandlers_ReserveEquipment(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		service := &mockEquipmentService{
+			reserveFn: func(ctx context.Context, cmd application.ReserveEquipmentCommand) (*application.EquipmentReservationResultDTO, error) {
+				if cmd.ReservationID != "RES-1" || cmd.Quantity != 2 {
+					t.Fatalf("unexpected cmd %+v", cmd)
+				}
+				return &application.EquipmentReservationResultDTO{
+					ReservationID:        cmd.ReservationID,
+					EquipmentType:        cmd.EquipmentType,
+					ReservedEquipmentIDs: []string{"FL-1", "FL-2"},
+				}, nil
+			},
+		}
+		router := newEquipmentTestRouter(service)
+		rec := performRequest(router, http.MethodPost, "/api/v1/equipment/reserve",
+			`{"reservationId":"RES-1","equipmentType":"forklift","orderId":"ORD-1","quantity":2}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d", rec.Code)
+		}
+
+		var body struct {
+			ReservedEquipmentIDs []string `json:"reservedEquipmentIds"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || len(body.ReservedEquipmentIDs) != 2 {
+			t.Fatalf("unexpected body %s", rec.Body.String())
+		}
+	})
+
+	t.Run("missing reservation id", func(t *testing.T) {
+		router := newEquipmentTestRouter(&mockEquipmentService{})
+		rec := performRequest(router, http.MethodPost, "/api/v1/equipment/reserve",
+			`{"equipmentType":"forklift","orderId":"ORD-1"}`)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d", rec.Code)
+		}
+	})
+
+	t.Run("insufficient equipment", func(t *testing.T) {
+		service := &mockEquipmentService{
+			reserveFn: func(ctx context.Context, cmd application.ReserveEquipmentCommand) (*application.EquipmentReservationResultDTO, error) {
+				return nil, errors.ErrConflict("insufficient forklift equipment available")
+			},
+		}
+		router := newEquipmentTestRouter(service)
+		rec := performRequest(router, http.MethodPost, "/api/v1/equipment/reserve",
+			`{"reservationId":"RES-1","equipmentType":"forklift","orderId":"ORD-1"}`)
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("status = %d", rec.Code)
+		}
+	})
+}
+
+func TestEquipmentHandlers_ReleaseEquipment(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		service := &mockEquipmentService{
+			releaseFn: func(ctx context.Context, cmd application.ReleaseEquipmentCommand) (*application.EquipmentReleaseResultDTO, error) {
+				if cmd.ReservationID != "RES-1" || cmd.Reason != "workflow_failed" {
+					t.Fatalf("unexpected cmd %+v", cmd)
+				}
+				return &application.EquipmentReleaseResultDTO{ReservationID: cmd.ReservationID}, nil
+			},
+		}
+		router := newEquipmentTestRouter(service)
+		rec := performRequest(router, http.MethodPost, "/api/v1/equipment/release",
+			`{"reservationId":"RES-1","equipmentType":"forklift","orderId":"ORD-1","reason":"workflow_failed"}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d", rec.Code)
+		}
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		service := &mockEquipmentService{
+			releaseFn: func(ctx context.Context, cmd application.ReleaseEquipmentCommand) (*application.EquipmentReleaseResultDTO, error) {
+				return nil, fmt.Errorf("boom")
+			},
+		}
+		router := newEquipmentTestRouter(service)
+		rec := performRequest(router, http.MethodPost, "/api/v1/equipment/release", `{"reservationId":"RES-1"}`)
+		if rec.Code != http.StatusInternalServerError {
+			t.Fatalf("status = %d", rec.Code)
+		}
+	})
+}
+
+func TestEquipmentHandlers_GetByType(t *testing.T) {
+	service := &mockEquipmentService{
+		getByTypeFn: func(ctx context.Context, query application.GetEquipmentByTypeQuery) ([]application.EquipmentDTO, error) {
+			if query.EquipmentType != "forklift" || query.Zone != "A" || query.Status != "available" {
+				t.Fatalf("unexpected query %+v", query)
+			}
+			return []application.EquipmentDTO{{EquipmentID: "FL-1"}}, nil
+		},
+	}
+	router := newEquipmentTestRouter(service)
+	rec := performRequest(router, http.MethodGet, "/api/v1/equipment/type/forklift?zone=A&status=available", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+}
+
+func TestEquipmentHandlers_GetListDelete(t *testing.T) {
+	service := &mockEquipmentService{
+		getFn: func(ctx context.Context, query application.GetEquipmentQuery) (*application.EquipmentDTO, error) {
+			return nil, errors.ErrNotFound("equipment")
+		},
+		listFn: func(ctx context.Context, query application.ListEquipmentQuery) ([]application.EquipmentDTO, error) {
+			if query.Limit != 10 {
+				t.Fatalf("Limit = %d", query.Limit)
+			}
+			return []application.EquipmentDTO{}, nil
+		},
+		deleteFn: func(ctx context.Context, cmd application.DeleteEquipmentCommand) error {
+			if cmd.EquipmentID != "EQ-1" {
+				t.Fatalf("EquipmentID = %s", cmd.EquipmentID)
+			}
+			return nil
+		},
+	}
+	router := newEquipmentTestRouter(service)
+
+	if rec := performRequest(router, http.MethodGet, "/api/v1/equipment/EQ-404", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("get status = %d", rec.Code)
+	}
+	if rec := performRequest(router, http.MethodGet, "/api/v1/equipment?limit=10", ""); rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d", rec.Code)
+	}
+	if rec := performRequest(router, http.MethodDelete, "/api/v1/equipment/EQ-1", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d", rec.Code)
+	}
+}
+
+func TestEquipmentHandlers_SetStatusAndStation(t *testing.T) {
+	service := &mockEquipmentService{
+		setStatusFn: func(ctx context.Context, cmd application.SetEquipmentStatusCommand) (*application.EquipmentDTO, error) {
+			if cmd.Status == "reserved" {
+				return nil, errors.ErrValidation("invalid equipment status")
+			}
+			return &application.EquipmentDTO{EquipmentID: cmd.EquipmentID, Status: cmd.Status}, nil
+		},
+		assignFn: func(ctx context.Context, cmd application.AssignEquipmentToStationCommand) (*application.EquipmentDTO, error) {
+			return &application.EquipmentDTO{EquipmentID: cmd.EquipmentID, StationID: cmd.StationID}, nil
+		},
+	}
+	router := newEquipmentTestRouter(service)
+
+	if rec := performRequest(router, http.MethodPut, "/api/v1/equipment/EQ-1/status", `{"status":"maintenance"}`); rec.Code != http.StatusOK {
+		t.Fatalf("status update = %d", rec.Code)
+	}
+	if rec := performRequest(router, http.MethodPut, "/api/v1/equipment/EQ-1/status", `{"status":"reserved"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid status update = %d", rec.Code)
+	}
+	if rec := performRequest(router, http.MethodPut, "/api/v1/equipment/EQ-1/status", `{}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("missing status = %d", rec.Code)
+	}
+	if rec := performRequest(router, http.MethodPut, "/api/v1/equipment/EQ-1/station", `{"stationId":"STN-1"}`); rec.Code != http.StatusOK {
+		t.Fatalf("assign station = %d", rec.Code)
+	}
+}
@@ -25,3 +25,44 @@ type StationEquipmentDTO struct {
 	EquipmentType string `json:"equipmentType"`
 	Status        string `json:"status"`
 }
+
+// EquipmentDTO represents an equipment data transfer object
+type EquipmentDTO struct {
+	EquipmentID   string                   `json:"equipmentId"`
+	EquipmentType string                   `json:"equipmentType"`
+	Name          string                   `json:"name"`
+	Zone          string                   `json:"zone"`
+	Status        string                   `json:"status"`
+	StationID     string                   `json:"stationId,omitempty"`
+	AssignedTo    string                   `json:"assignedTo,omitempty"`
+	Reservation   *EquipmentReservationDTO `json:"reservation,omitempty"`
+	CreatedAt     time.Time                `json:"createdAt"`
+	UpdatedAt     time.Time                `json:"updatedAt"`
+}
+
+// EquipmentReservationDTO represents a time-boxed equipment reservation
+type EquipmentReservationDTO struct {
+	ReservationID string    `json:"reservationId"`
+	OrderID       string    `json:"orderId"`
+	ReservedAt    time.Time `json:"reservedAt"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// EquipmentAvailabilityDTO represents the reservable equipment count per type
+type EquipmentAvailabilityDTO struct {
+	AvailableEquipment map[string]int `json:"availableEquipment"`
+}
+
+// EquipmentReservationResultDTO represents the result of reserving equipment
+type EquipmentReservationResultDTO struct {
+	ReservationID        string    `json:"reservationId"`
+	EquipmentType        string    `json:"equipmentType"`
+	ReservedEquipmentIDs []string  `json:"reservedEquipmentIds"`
+	ExpiresAt            time.Time `json:"expiresAt"`
+}
+
+// EquipmentReleaseResultDTO represents the result of releasing a reservation
+type EquipmentReleaseResultDTO struct {
+	ReservationID        string   `json:"reservationId"`
+	ReleasedEquipmentIDs []string `json:"releasedEquipmentIds"`
+}
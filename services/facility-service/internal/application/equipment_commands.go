@@ -0,0 +1,74 @@
+package application
+
+// Equipment Commands
+
+// RegisterEquipmentCommand registers a new piece of equipment
+type RegisterEquipmentCommand struct {
+	EquipmentID   string `json:"equipmentId"`
+	EquipmentType string `json:"equipmentType"`
+	Name          string `json:"name"`
+	Zone          string `json:"zone"`
+	StationID     string `json:"stationId,omitempty"`
+}
+
+// SetEquipmentStatusCommand sets the lifecycle status of equipment
+type SetEquipmentStatusCommand struct {
+	EquipmentID string `json:"equipmentId"`
+	Status      string `json:"status"`
+	AssignedTo  string `json:"assignedTo,omitempty"` // Order or task ID when putting equipment in use
+}
+
+// AssignEquipmentToStationCommand links equipment to a station (empty StationID unlinks it)
+type AssignEquipmentToStationCommand struct {
+	EquipmentID string `json:"equipmentId"`
+	StationID   string `json:"stationId"`
+}
+
+// ReserveEquipmentCommand reserves a quantity of equipment of one type for an order
+type ReserveEquipmentCommand struct {
+	ReservationID string `json:"reservationId"`
+	EquipmentType string `json:"equipmentType"`
+	OrderID       string `json:"orderId"`
+	Quantity      int    `json:"quantity"`
+	Zone          string `json:"zone,omitempty"`
+}
+
+// ReleaseEquipmentCommand releases all equipment held by a reservation
+type ReleaseEquipmentCommand struct {
+	ReservationID string `json:"reservationId"`
+	EquipmentType string `json:"equipmentType,omitempty"` // Optional: only release this type
+	OrderID       string `json:"orderId,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// DeleteEquipmentCommand deletes equipment
+type DeleteEquipmentCommand struct {
+	EquipmentID string `json:"equipmentId"`
+}
+
+// Equipment Queries
+
+// GetEquipmentQuery retrieves equipment by ID
+type GetEquipmentQuery struct {
+	EquipmentID string `json:"equipmentId"`
+}
+
+// ListEquipmentQuery lists all equipment
+type ListEquipmentQuery struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// GetEquipmentByTypeQuery retrieves equipment by type
+type GetEquipmentByTypeQuery struct {
+	EquipmentType string `json:"equipmentType"`
+	Zone          string `json:"zone,omitempty"`
+	Status        string `json:"status,omitempty"`
+}
+
+// CheckEquipmentAvailabilityQuery counts reservable equipment per type
+type CheckEquipmentAvailabilityQuery struct {
+	EquipmentTypes []string `json:"equipmentTypes"`
+	Zone           string   `json:"zone,omitempty"`
+	RequiredCount  int      `json:"requiredCount"`
+}
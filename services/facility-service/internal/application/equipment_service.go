@@ -0,0 +1,515 @@
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/facility-service/internal/domain"
+)
+
+// EquipmentRepository interface for equipment persistence
+type EquipmentRepository interface {
+	// Save persists equipment. Equipment that was modified since it was loaded fails with ErrConcurrencyConflict.
+	Save(ctx context.Context, equipment *domain.Equipment) error
+	FindByID(ctx context.Context, equipmentID string) (*domain.Equipment, error)
+	FindByType(ctx context.Context, equipmentType, zone string, status domain.EquipmentStatus) ([]*domain.Equipment, error)
+	FindAvailable(ctx context.Context, equipmentType, zone string, asOf time.Time, limit int) ([]*domain.Equipment, error)
+	FindByReservationID(ctx context.Context, reservationID string) ([]*domain.Equipment, error)
+	FindByStationID(ctx context.Context, stationID string) ([]*domain.Equipment, error)
+	FindAll(ctx context.Context, limit, offset int) ([]*domain.Equipment, error)
+	Delete(ctx context.Context, equipmentID string) error
+}
+
+// EquipmentApplicationService handles equipment registry and reservation use cases
+type EquipmentApplicationService struct {
+	repo                EquipmentRepository
+	stationRepo         StationRepository
+	logger              *logging.Logger
+	reservationDuration time.Duration
+	now                 func() time.Time
+}
+
+// NewEquipmentApplicationService creates a new EquipmentApplicationService.
+// A non-positive reservationDuration falls back to domain.DefaultEquipmentReservationDuration.
+func NewEquipmentApplicationService(
+	repo EquipmentRepository,
+	stationRepo StationRepository,
+	logger *logging.Logger,
+	reservationDuration time.Duration,
+) *EquipmentApplicationService {
+	if reservationDuration <= 0 {
+		reservationDuration = domain.DefaultEquipmentReservationDuration
+	}
+	return &EquipmentApplicationService{
+		repo:                repo,
+		stationRepo:         stationRepo,
+		logger:              logger,
+		reservationDuration: reservationDuration,
+		now:                 time.Now,
+	}
+}
+
+// RegisterEquipment registers new equipment, optionally linking it to a station
+func (s *EquipmentApplicationService) RegisterEquipment(ctx context.Context, cmd RegisterEquipmentCommand) (*EquipmentDTO, error) {
+	existing, err := s.repo.FindByID(ctx, cmd.EquipmentID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get equipment", "equipmentId", cmd.EquipmentID)
+		return nil, fmt.Errorf("failed to get equipment: %w", err)
+	}
+	if existing != nil {
+		return nil, errors.ErrConflict("equipment already exists")
+	}
+
+	var station *domain.Station
+	if cmd.StationID != "" {
+		station, err = s.findStation(ctx, cmd.StationID)
+		if err != nil {
+			return nil, err
+		}
+		if cmd.Zone == "" {
+			cmd.Zone = station.Zone
+		}
+	}
+
+	equipment, err := domain.NewEquipmentWithTenant(
+		cmd.EquipmentID, cmd.EquipmentType, cmd.Name, cmd.Zone,
+		tenant.GetTenantID(ctx), tenant.GetFacilityID(ctx), tenant.GetWarehouseID(ctx),
+	)
+	if err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	if station != nil {
+		equipment.AssignToStation(station.StationID)
+	}
+
+	if err := s.saveEquipment(ctx, equipment); err != nil {
+		return nil, err
+	}
+
+	if station != nil {
+		station.UpsertEquipment(equipment.ToStationEquipment())
+		if err := s.saveStation(ctx, station); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "equipment.registered",
+		EntityType: "equipment",
+		EntityID:   equipment.EquipmentID,
+		Action:     "registered",
+		RelatedIDs: map[string]string{
+			"equipmentType": equipment.EquipmentType,
+			"zone":          equipment.Zone,
+			"stationId":     equipment.StationID,
+		},
+	})
+
+	return ToEquipmentDTO(equipment), nil
+}
+
+// GetEquipment retrieves equipment by ID
+func (s *EquipmentApplicationService) GetEquipment(ctx context.Context, query GetEquipmentQuery) (*EquipmentDTO, error) {
+	equipment, err := s.findEquipment(ctx, query.EquipmentID)
+	if err != nil {
+		return nil, err
+	}
+	return ToEquipmentDTO(equipment), nil
+}
+
+// ListEquipment lists all equipment
+func (s *EquipmentApplicationService) ListEquipment(ctx context.Context, query ListEquipmentQuery) ([]EquipmentDTO, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	equipment, err := s.repo.FindAll(ctx, limit, query.Offset)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list equipment")
+		return nil, fmt.Errorf("failed to list equipment: %w", err)
+	}
+
+	return ToEquipmentDTOs(equipment), nil
+}
+
+// GetByType retrieves equipment of a type, optionally filtered by zone and status
+func (s *EquipmentApplicationService) GetByType(ctx context.Context, query GetEquipmentByTypeQuery) ([]EquipmentDTO, error) {
+	status := domain.EquipmentStatus(query.Status)
+	if status != "" && !status.IsValid() {
+		return nil, errors.ErrValidation(domain.ErrInvalidEquipmentStatus.Error())
+	}
+
+	equipment, err := s.repo.FindByType(ctx, query.EquipmentType, query.Zone, status)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get equipment by type", "equipmentType", query.EquipmentType)
+		return nil, fmt.Errorf("failed to get equipment by type: %w", err)
+	}
+
+	return ToEquipmentDTOs(equipment), nil
+}
+
+// CheckAvailability counts the equipment of each requested type that can be reserved right now
+func (s *EquipmentApplicationService) CheckAvailability(ctx context.Context, query CheckEquipmentAvailabilityQuery) (*EquipmentAvailabilityDTO, error) {
+	now := s.now()
+	available := make(map[string]int, len(query.EquipmentTypes))
+
+	for _, equipmentType := range query.EquipmentTypes {
+		equipment, err := s.repo.FindAvailable(ctx, equipmentType, query.Zone, now, 0)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to check equipment availability", "equipmentType", equipmentType)
+			return nil, fmt.Errorf("failed to check equipment availability: %w", err)
+		}
+		available[equipmentType] = len(equipment)
+	}
+
+	return &EquipmentAvailabilityDTO{AvailableEquipment: available}, nil
+}
+
+// ReserveEquipment reserves Quantity pieces of equipment for an order. Retrying with the same
+// reservation ID is idempotent. Either the full quantity is reserved or nothing is.
+func (s *EquipmentApplicationService) ReserveEquipment(ctx context.Context, cmd ReserveEquipmentCommand) (*EquipmentReservationResultDTO, error) {
+	if cmd.Quantity <= 0 {
+		cmd.Quantity = 1
+	}
+	now := s.now()
+
+	// Equipment already held by this reservation counts toward the quantity
+	held, err := s.repo.FindByReservationID(ctx, cmd.ReservationID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get reservation", "reservationId", cmd.ReservationID)
+		return nil, fmt.Errorf("failed to get equipment reservation: %w", err)
+	}
+
+	reservedIDs := make([]string, 0, cmd.Quantity)
+	heldIDs := make(map[string]bool)
+	for _, eq := range held {
+		if eq.EquipmentType != cmd.EquipmentType || !eq.IsReservedBy(cmd.ReservationID) || eq.Reservation.IsExpired(now) {
+			continue
+		}
+		if len(reservedIDs) < cmd.Quantity {
+			reservedIDs = append(reservedIDs, eq.EquipmentID)
+		}
+		heldIDs[eq.EquipmentID] = true
+	}
+
+	needed := cmd.Quantity - len(reservedIDs)
+	if needed > 0 {
+		candidates, err := s.repo.FindAvailable(ctx, cmd.EquipmentType, cmd.Zone, now, 0)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to find available equipment", "equipmentType", cmd.EquipmentType)
+			return nil, fmt.Errorf("failed to find available equipment: %w", err)
+		}
+
+		eligible := make([]*domain.Equipment, 0, len(candidates))
+		for _, eq := range candidates {
+			if !heldIDs[eq.EquipmentID] && eq.IsAvailable(now) {
+				eligible = append(eligible, eq)
+			}
+		}
+		if len(eligible) < needed {
+			return nil, errors.ErrConflict(fmt.Sprintf("insufficient %s equipment available: requested %d, available %d",
+				cmd.EquipmentType, needed, len(eligible)))
+		}
+
+		// Each save only succeeds if the equipment is unchanged since it was loaded, so two
+		// concurrent reservations cannot both claim it. Equipment taken by a concurrent
+		// reservation is skipped in favour of the next candidate.
+		reserved := make([]*domain.Equipment, 0, needed)
+		for _, eq := range eligible {
+			if len(reserved) == needed {
+				break
+			}
+			if err := eq.Reserve(cmd.ReservationID, cmd.OrderID, s.reservationDuration, now); err != nil {
+				continue
+			}
+			if err := s.repo.Save(ctx, eq); err != nil {
+				if errors.IsConcurrencyConflict(err) {
+					s.logger.Info("Equipment claimed concurrently, trying next candidate", "equipmentId", eq.EquipmentID)
+					continue
+				}
+				s.logger.WithError(err).Error("Failed to save equipment", "equipmentId", eq.EquipmentID)
+				s.rollbackReservations(ctx, reserved, cmd.ReservationID)
+				return nil, fmt.Errorf("failed to save equipment: %w", err)
+			}
+			reserved = append(reserved, eq)
+			reservedIDs = append(reservedIDs, eq.EquipmentID)
+		}
+		if len(reserved) < needed {
+			s.rollbackReservations(ctx, reserved, cmd.ReservationID)
+			return nil, errors.ErrConflict(fmt.Sprintf("insufficient %s equipment available: requested %d, available %d",
+				cmd.EquipmentType, needed, len(reserved)))
+		}
+	}
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "equipment.reserved",
+		EntityType: "equipment_reservation",
+		EntityID:   cmd.ReservationID,
+		Action:     "reserved",
+		RelatedIDs: map[string]string{
+			"orderId":       cmd.OrderID,
+			"equipmentType": cmd.EquipmentType,
+		},
+	})
+
+	return &EquipmentReservationResultDTO{
+		ReservationID:        cmd.ReservationID,
+		EquipmentType:        cmd.EquipmentType,
+		ReservedEquipmentIDs: reservedIDs,
+		ExpiresAt:            now.Add(s.reservationDuration),
+	}, nil
+}
+
+// rollbackReservations releases equipment reserved earlier in a failed ReserveEquipment call
+func (s *EquipmentApplicationService) rollbackReservations(ctx context.Context, reserved []*domain.Equipment, reservationID string) {
+	for _, eq := range reserved {
+		if err := eq.Release(reservationID, "reservation_rollback"); err != nil {
+			continue
+		}
+		if err := s.repo.Save(ctx, eq); err != nil {
+			s.logger.WithError(err).Error("Failed to roll back equipment reservation",
+				"equipmentId", eq.EquipmentID, "reservationId", reservationID)
+		}
+	}
+}
+
+// ReleaseEquipment releases all equipment held by a reservation. Releasing an unknown or
+// already released reservation is a no-op so compensations can be retried safely.
+func (s *EquipmentApplicationService) ReleaseEquipment(ctx context.Context, cmd ReleaseEquipmentCommand) (*EquipmentReleaseResultDTO, error) {
+	held, err := s.repo.FindByReservationID(ctx, cmd.ReservationID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get reservation", "reservationId", cmd.ReservationID)
+		return nil, fmt.Errorf("failed to get equipment reservation: %w", err)
+	}
+
+	reason := cmd.Reason
+	if reason == "" {
+		reason = "released"
+	}
+
+	released := make([]string, 0, len(held))
+	for _, eq := range held {
+		if cmd.EquipmentType != "" && eq.EquipmentType != cmd.EquipmentType {
+			continue
+		}
+		if err := eq.Release(cmd.ReservationID, reason); err != nil {
+			if stdErrors.Is(err, domain.ErrEquipmentNotReserved) || stdErrors.Is(err, domain.ErrReservationMismatch) {
+				continue
+			}
+			return nil, errors.ErrValidation(err.Error())
+		}
+		if err := s.saveEquipment(ctx, eq); err != nil {
+			return nil, err
+		}
+		released = append(released, eq.EquipmentID)
+	}
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "equipment.released",
+		EntityType: "equipment_reservation",
+		EntityID:   cmd.ReservationID,
+		Action:     "released",
+		RelatedIDs: map[string]string{
+			"orderId": cmd.OrderID,
+			"reason":  reason,
+		},
+	})
+
+	return &EquipmentReleaseResultDTO{
+		ReservationID:        cmd.ReservationID,
+		ReleasedEquipmentIDs: released,
+	}, nil
+}
+
+// SetEquipmentStatus moves equipment through its lifecycle and keeps the linked station in sync
+func (s *EquipmentApplicationService) SetEquipmentStatus(ctx context.Context, cmd SetEquipmentStatusCommand) (*EquipmentDTO, error) {
+	equipment, err := s.findEquipment(ctx, cmd.EquipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch domain.EquipmentStatus(cmd.Status) {
+	case domain.EquipmentStatusInUse:
+		err = equipment.StartUse(cmd.AssignedTo)
+	case domain.EquipmentStatusAvailable:
+		if equipment.Status == domain.EquipmentStatusInUse {
+			err = equipment.FinishUse()
+		} else {
+			err = equipment.SetStatus(domain.EquipmentStatusAvailable)
+		}
+	case domain.EquipmentStatusMaintenance:
+		err = equipment.SetMaintenance()
+	default:
+		err = domain.ErrInvalidEquipmentStatus
+	}
+	if err != nil {
+		if stdErrors.Is(err, domain.ErrEquipmentNotAvailable) {
+			return nil, errors.ErrConflict(err.Error())
+		}
+		return nil, errors.ErrValidation(err.Error())
+	}
+
+	if err := s.saveEquipment(ctx, equipment); err != nil {
+		return nil, err
+	}
+
+	if equipment.StationID != "" {
+		if err := s.syncStationEquipment(ctx, equipment.StationID, equipment); err != nil {
+			return nil, err
+		}
+	}
+
+	return ToEquipmentDTO(equipment), nil
+}
+
+// AssignToStation links equipment to a station, unlinking it from its previous station
+func (s *EquipmentApplicationService) AssignToStation(ctx context.Context, cmd AssignEquipmentToStationCommand) (*EquipmentDTO, error) {
+	equipment, err := s.findEquipment(ctx, cmd.EquipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var station *domain.Station
+	if cmd.StationID != "" {
+		station, err = s.findStation(ctx, cmd.StationID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	oldStationID := equipment.StationID
+	equipment.AssignToStation(cmd.StationID)
+
+	if err := s.saveEquipment(ctx, equipment); err != nil {
+		return nil, err
+	}
+
+	if oldStationID != "" && oldStationID != cmd.StationID {
+		if err := s.unlinkFromStation(ctx, oldStationID, equipment.EquipmentID); err != nil {
+			return nil, err
+		}
+	}
+	if station != nil {
+		station.UpsertEquipment(equipment.ToStationEquipment())
+		if err := s.saveStation(ctx, station); err != nil {
+			return nil, err
+		}
+	}
+
+	return ToEquipmentDTO(equipment), nil
+}
+
+// DeleteEquipment deletes equipment and unlinks it from its station
+func (s *EquipmentApplicationService) DeleteEquipment(ctx context.Context, cmd DeleteEquipmentCommand) error {
+	equipment, err := s.findEquipment(ctx, cmd.EquipmentID)
+	if err != nil {
+		return err
+	}
+
+	if equipment.Status == domain.EquipmentStatusInUse || (equipment.Reservation != nil && !equipment.Reservation.IsExpired(s.now())) {
+		return errors.ErrConflict("equipment is reserved or in use")
+	}
+
+	if err := s.repo.Delete(ctx, cmd.EquipmentID); err != nil {
+		s.logger.WithError(err).Error("Failed to delete equipment", "equipmentId", cmd.EquipmentID)
+		return fmt.Errorf("failed to delete equipment: %w", err)
+	}
+
+	if equipment.StationID != "" {
+		if err := s.unlinkFromStation(ctx, equipment.StationID, equipment.EquipmentID); err != nil {
+			return err
+		}
+	}
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "equipment.deleted",
+		EntityType: "equipment",
+		EntityID:   cmd.EquipmentID,
+		Action:     "deleted",
+	})
+
+	return nil
+}
+
+func (s *EquipmentApplicationService) findEquipment(ctx context.Context, equipmentID string) (*domain.Equipment, error) {
+	equipment, err := s.repo.FindByID(ctx, equipmentID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get equipment", "equipmentId", equipmentID)
+		return nil, fmt.Errorf("failed to get equipment: %w", err)
+	}
+	if equipment == nil {
+		return nil, errors.ErrNotFound("equipment")
+	}
+	return equipment, nil
+}
+
+// saveEquipment saves equipment, reporting a save that lost a race with another writer as a conflict
+func (s *EquipmentApplicationService) saveEquipment(ctx context.Context, equipment *domain.Equipment) error {
+	if err := s.repo.Save(ctx, equipment); err != nil {
+		if errors.IsConcurrencyConflict(err) {
+			return errors.ErrConflict(fmt.Sprintf("equipment %s was modified concurrently, please retry", equipment.EquipmentID)).Wrap(err)
+		}
+		s.logger.WithError(err).Error("Failed to save equipment", "equipmentId", equipment.EquipmentID)
+		return fmt.Errorf("failed to save equipment: %w", err)
+	}
+	return nil
+}
+
+func (s *EquipmentApplicationService) findStation(ctx context.Context, stationID string) (*domain.Station, error) {
+	station, err := s.stationRepo.FindByID(ctx, stationID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get station", "stationId", stationID)
+		return nil, fmt.Errorf("failed to get station: %w", err)
+	}
+	if station == nil {
+		return nil, errors.ErrNotFound("station")
+	}
+	return station, nil
+}
+
+func (s *EquipmentApplicationService) saveStation(ctx context.Context, station *domain.Station) error {
+	if err := s.stationRepo.Save(ctx, station); err != nil {
+		if errors.IsConcurrencyConflict(err) {
+			return errors.ErrConflict(fmt.Sprintf("station %s was modified concurrently, please retry", station.StationID)).Wrap(err)
+		}
+		s.logger.WithError(err).Error("Failed to save station", "stationId", station.StationID)
+		return fmt.Errorf("failed to save station: %w", err)
+	}
+	return nil
+}
+
+// syncStationEquipment refreshes the station's view of the equipment. A missing station is ignored.
+func (s *EquipmentApplicationService) syncStationEquipment(ctx context.Context, stationID string, equipment *domain.Equipment) error {
+	station, err := s.stationRepo.FindByID(ctx, stationID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get station", "stationId", stationID)
+		return fmt.Errorf("failed to get station: %w", err)
+	}
+	if station == nil {
+		return nil
+	}
+
+	station.UpsertEquipment(equipment.ToStationEquipment())
+	return s.saveStation(ctx, station)
+}
+
+// unlinkFromStation removes the equipment from the station's equipment list. A missing station is ignored.
+func (s *EquipmentApplicationService) unlinkFromStation(ctx context.Context, stationID, equipmentID string) error {
+	station, err := s.stationRepo.FindByID(ctx, stationID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get station", "stationId", stationID)
+		return fmt.Errorf("failed to get station: %w", err)
+	}
+	if station == nil || !station.RemoveEquipment(equipmentID) {
+		return nil
+	}
+
+	return s.saveStation(ctx, station)
+}
@@ -0,0 +1,619 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wms-platform/facility-service/internal/domain"
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+// MockEquipmentRepository is a mock implementation of EquipmentRepository for testing
+type MockEquipmentRepository struct {
+	equipment map[string]*domain.Equipment
+	saveErr   error
+	saveErrOn string // Only fail saves for this equipment ID when set
+	findErr   error
+	saves     int
+}
+
+func NewMockEquipmentRepository() *MockEquipmentRepository {
+	return &MockEquipmentRepository{
+		equipment: make(map[string]*domain.Equipment),
+	}
+}
+
+func (m *MockEquipmentRepository) Save(ctx context.Context, equipment *domain.Equipment) error {
+	if m.saveErr != nil && (m.saveErrOn == "" || m.saveErrOn == equipment.EquipmentID) {
+		return m.saveErr
+	}
+	m.saves++
+	equipment.ClearDomainEvents()
+	m.equipment[equipment.EquipmentID] = equipment
+	return nil
+}
+
+func (m *MockEquipmentRepository) FindByID(ctx context.Context, equipmentID string) (*domain.Equipment, error) {
+	if m.findErr != nil {
+		return nil, m.findErr
+	}
+	return m.equipment[equipmentID], nil
+}
+
+func (m *MockEquipmentRepository) FindByType(ctx context.Context, equipmentType, zone string, status domain.EquipmentStatus) ([]*domain.Equipment, error) {
+	return m.filter(func(eq *domain.Equipment) bool {
+		return eq.EquipmentType == equipmentType &&
+			(zone == "" || eq.Zone == zone) &&
+			(status == "" || eq.Status == status)
+	})
+}
+
+func (m *MockEquipmentRepository) FindAvailable(ctx context.Context, equipmentType, zone string, asOf time.Time, limit int) ([]*domain.Equipment, error) {
+	result, err := m.filter(func(eq *domain.Equipment) bool {
+		return eq.EquipmentType == equipmentType && (zone == "" || eq.Zone == zone) && eq.IsAvailable(asOf)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, err
+}
+
+func (m *MockEquipmentRepository) FindByReservationID(ctx context.Context, reservationID string) ([]*domain.Equipment, error) {
+	return m.filter(func(eq *domain.Equipment) bool {
+		return eq.IsReservedBy(reservationID)
+	})
+}
+
+func (m *MockEquipmentRepository) FindByStationID(ctx context.Context, stationID string) ([]*domain.Equipment, error) {
+	return m.filter(func(eq *domain.Equipment) bool {
+		return eq.StationID == stationID
+	})
+}
+
+func (m *MockEquipmentRepository) FindAll(ctx context.Context, limit, offset int) ([]*domain.Equipment, error) {
+	return m.filter(func(eq *domain.Equipment) bool { return true })
+}
+
+func (m *MockEquipmentRepository) Delete(ctx context.Context, equipmentID string) error {
+	if m.findErr != nil {
+		return m.findErr
+	}
+	delete(m.equipment, equipmentID)
+	return nil
+}
+
+func (m *MockEquipmentRepository) filter(match func(eq *domain.Equipment) bool) ([]*domain.Equipment, error) {
+	if m.findErr != nil {
+		return nil, m.findErr
+	}
+	var result []*domain.Equipment
+	for _, eq := range m.equipment {
+		if match(eq) {
+			result = append(result, eq)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].EquipmentID < result[j].EquipmentID })
+	return result, nil
+}
+
+// AddEquipment adds equipment directly to the mock (for test setup)
+func (m *MockEquipmentRepository) AddEquipment(t *testing.T, equipmentID, equipmentType, zone string) *domain.Equipment {
+	t.Helper()
+	equipment, err := domain.NewEquipment(equipmentID, equipmentType, equipmentID, zone)
+	if err != nil {
+		t.Fatalf("NewEquipment() error = %v", err)
+	}
+	equipment.ClearDomainEvents()
+	m.equipment[equipmentID] = equipment
+	return equipment
+}
+
+// versionedEquipmentRepository hands out copies and rejects stale saves like the Mongo
+// repository, so concurrent reservations race the way they do in production
+type versionedEquipmentRepository struct {
+	*MockEquipmentRepository
+	mu sync.Mutex
+}
+
+func copyEquipment(eq *domain.Equipment) *domain.Equipment {
+	clone := *eq
+	if eq.Reservation != nil {
+		reservation := *eq.Reservation
+		clone.Reservation = &reservation
+	}
+	clone.DomainEvents = nil
+	return &clone
+}
+
+func (r *versionedEquipmentRepository) Save(ctx context.Context, equipment *domain.Equipment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.equipment[equipment.EquipmentID]; ok && stored.Version != equipment.Version {
+		return sharedErrors.NewConcurrencyConflictError("Equipment", equipment.EquipmentID, equipment.Version)
+	}
+	equipment.Version++
+	equipment.ClearDomainEvents()
+	r.equipment[equipment.EquipmentID] = copyEquipment(equipment)
+	return nil
+}
+
+func (r *versionedEquipmentRepository) FindAvailable(ctx context.Context, equipmentType, zone string, asOf time.Time, limit int) ([]*domain.Equipment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found, err := r.MockEquipmentRepository.FindAvailable(ctx, equipmentType, zone, asOf, limit)
+	return copyAll(found), err
+}
+
+func (r *versionedEquipmentRepository) FindByReservationID(ctx context.Context, reservationID string) ([]*domain.Equipment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found, err := r.MockEquipmentRepository.FindByReservationID(ctx, reservationID)
+	return copyAll(found), err
+}
+
+func copyAll(equipment []*domain.Equipment) []*domain.Equipment {
+	copies := make([]*domain.Equipment, 0, len(equipment))
+	for _, eq := range equipment {
+		copies = append(copies, copyEquipment(eq))
+	}
+	return copies
+}
+
+// createTestEquipmentService creates a service with mock repositories for testing
+func createTestEquipmentService() (*EquipmentApplicationService, *MockEquipmentRepository, *MockStationRepository) {
+	repo := NewMockEquipmentRepository()
+	stationRepo := NewMockStationRepository()
+	logger := logging.New(logging.DefaultConfig("test"))
+	service := NewEquipmentApplicationService(repo, stationRepo, logger, 10*time.Minute)
+	return service, repo, stationRepo
+}
+
+func assertAppErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var appErr *sharedErrors.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected AppError, got %v", err)
+	}
+	if appErr.Code != code {
+		t.Errorf("error code = %v, want %v", appErr.Code, code)
+	}
+}
+
+// =============================================================================
+// RegisterEquipment Tests
+// =============================================================================
+
+func TestEquipmentApplicationService_RegisterEquipment(t *testing.T) {
+	t.Run("registers and links equipment to station", func(t *testing.T) {
+		service, repo, stationRepo := createTestEquipmentService()
+		station, _ := domain.NewStation("STN-001", "Packing 1", "zone-a", domain.StationTypePacking, 2)
+		stationRepo.AddStation(station)
+
+		dto, err := service.RegisterEquipment(context.Background(), RegisterEquipmentCommand{
+			EquipmentID:   "EQ-001",
+			EquipmentType: "scale",
+			Name:          "Scale 1",
+			StationID:     "STN-001",
+		})
+		if err != nil {
+			t.Fatalf("RegisterEquipment() error = %v", err)
+		}
+		if dto.Status != "available" || dto.StationID != "STN-001" {
+			t.Errorf("unexpected dto %+v", dto)
+		}
+		if dto.Zone != "zone-a" {
+			t.Errorf("Zone = %v, want station zone zone-a", dto.Zone)
+		}
+		if repo.equipment["EQ-001"] == nil {
+			t.Fatal("equipment not saved")
+		}
+		if len(station.Equipment) != 1 || station.Equipment[0].EquipmentID != "EQ-001" || station.Equipment[0].Status != "active" {
+			t.Errorf("station equipment = %+v", station.Equipment)
+		}
+	})
+
+	t.Run("rejects duplicate equipment", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		repo.AddEquipment(t, "EQ-001", "scale", "zone-a")
+
+		_, err := service.RegisterEquipment(context.Background(), RegisterEquipmentCommand{
+			EquipmentID: "EQ-001", EquipmentType: "scale", Name: "Scale 1",
+		})
+		assertAppErrorCode(t, err, sharedErrors.CodeConflict)
+	})
+
+	t.Run("rejects unknown station", func(t *testing.T) {
+		service, _, _ := createTestEquipmentService()
+
+		_, err := service.RegisterEquipment(context.Background(), RegisterEquipmentCommand{
+			EquipmentID: "EQ-001", EquipmentType: "scale", Name: "Scale 1", StationID: "STN-404",
+		})
+		assertAppErrorCode(t, err, sharedErrors.CodeNotFound)
+	})
+
+	t.Run("rejects missing equipment type", func(t *testing.T) {
+		service, _, _ := createTestEquipmentService()
+
+		_, err := service.RegisterEquipment(context.Background(), RegisterEquipmentCommand{EquipmentID: "EQ-001"})
+		assertAppErrorCode(t, err, sharedErrors.CodeValidationError)
+	})
+}
+
+// =============================================================================
+// Availability and Reservation Tests
+// =============================================================================
+
+func TestEquipmentApplicationService_CheckAvailability(t *testing.T) {
+	service, repo, _ := createTestEquipmentService()
+	repo.AddEquipment(t, "FL-1", "forklift", "zone-a")
+	repo.AddEquipment(t, "FL-2", "forklift", "zone-b")
+	busy := repo.AddEquipment(t, "FL-3", "forklift", "zone-a")
+	_ = busy.StartUse("TASK-1")
+
+	dto, err := service.CheckAvailability(context.Background(), CheckEquipmentAvailabilityQuery{
+		EquipmentTypes: []string{"forklift", "cold_storage"},
+		Zone:           "zone-a",
+	})
+	if err != nil {
+		t.Fatalf("CheckAvailability() error = %v", err)
+	}
+	if dto.AvailableEquipment["forklift"] != 1 {
+		t.Errorf("forklift = %v, want 1", dto.AvailableEquipment["forklift"])
+	}
+	if count, ok := dto.AvailableEquipment["cold_storage"]; !ok || count != 0 {
+		t.Errorf("cold_storage = %v (present %v), want 0", count, ok)
+	}
+}
+
+func TestEquipmentApplicationService_ReserveEquipment(t *testing.T) {
+	t.Run("reserves requested quantity", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		repo.AddEquipment(t, "FL-1", "forklift", "zone-a")
+		repo.AddEquipment(t, "FL-2", "forklift", "zone-a")
+		repo.AddEquipment(t, "FL-3", "forklift", "zone-a")
+
+		result, err := service.ReserveEquipment(context.Background(), ReserveEquipmentCommand{
+			ReservationID: "RES-1", EquipmentType: "forklift", OrderID: "ORD-1", Quantity: 2, Zone: "zone-a",
+		})
+		if err != nil {
+			t.Fatalf("ReserveEquipment() error = %v", err)
+		}
+		if len(result.ReservedEquipmentIDs) != 2 {
+			t.Fatalf("reserved = %v, want 2", result.ReservedEquipmentIDs)
+		}
+		for _, id := range result.ReservedEquipmentIDs {
+			if !repo.equipment[id].IsReservedBy("RES-1") {
+				t.Errorf("%s not reserved by RES-1", id)
+			}
+		}
+	})
+
+	t.Run("retry with same reservation is idempotent", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		repo.AddEquipment(t, "FL-1", "forklift", "zone-a")
+		repo.AddEquipment(t, "FL-2", "forklift", "zone-a")
+		cmd := ReserveEquipmentCommand{ReservationID: "RES-1", EquipmentType: "forklift", OrderID: "ORD-1", Quantity: 1}
+
+		first, err := service.ReserveEquipment(context.Background(), cmd)
+		if err != nil {
+			t.Fatalf("first ReserveEquipment() error = %v", err)
+		}
+		saves := repo.saves
+		second, err := service.ReserveEquipment(context.Background(), cmd)
+		if err != nil {
+			t.Fatalf("second ReserveEquipment() error = %v", err)
+		}
+		if first.ReservedEquipmentIDs[0] != second.ReservedEquipmentIDs[0] {
+			t.Errorf("retry reserved %v, want %v", second.ReservedEquipmentIDs, first.ReservedEquipmentIDs)
+		}
+		if repo.saves != saves {
+			t.Errorf("retry saved %d times, want 0", repo.saves-saves)
+		}
+	})
+
+	t.Run("insufficient equipment is a conflict", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		repo.AddEquipment(t, "FL-1", "forklift", "zone-a")
+
+		_, err := service.ReserveEquipment(context.Background(), ReserveEquipmentCommand{
+			ReservationID: "RES-1", EquipmentType: "forklift", OrderID: "ORD-1", Quantity: 2,
+		})
+		assertAppErrorCode(t, err, sharedErrors.CodeConflict)
+		if repo.equipment["FL-1"].Status != domain.EquipmentStatusAvailable {
+			t.Error("expected no partial reservation")
+		}
+	})
+
+	t.Run("save failure rolls back partial reservation", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		repo.AddEquipment(t, "FL-1", "forklift", "zone-a")
+		repo.AddEquipment(t, "FL-2", "forklift", "zone-a")
+		repo.saveErr = errors.New("db down")
+		repo.saveErrOn = "FL-2"
+
+		_, err := service.ReserveEquipment(context.Background(), ReserveEquipmentCommand{
+			ReservationID: "RES-1", EquipmentType: "forklift", OrderID: "ORD-1", Quantity: 2,
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if repo.equipment["FL-1"].Status != domain.EquipmentStatusAvailable {
+			t.Errorf("FL-1 status = %v, want available after rollback", repo.equipment["FL-1"].Status)
+		}
+	})
+
+	t.Run("lapsed reservations are reusable", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		eq := repo.AddEquipment(t, "FL-1", "forklift", "zone-a")
+		_ = eq.Reserve("RES-OLD", "ORD-OLD", time.Minute, time.Now().Add(-time.Hour))
+
+		result, err := service.ReserveEquipment(context.Background(), ReserveEquipmentCommand{
+			ReservationID: "RES-1", EquipmentType: "forklift", OrderID: "ORD-1", Quantity: 1,
+		})
+		if err != nil {
+			t.Fatalf("ReserveEquipment() error = %v", err)
+		}
+		if result.ReservedEquipmentIDs[0] != "FL-1" || !eq.IsReservedBy("RES-1") {
+			t.Errorf("expected FL-1 reserved by RES-1, got %v", result.ReservedEquipmentIDs)
+		}
+	})
+}
+
+func TestEquipmentApplicationService_ReserveEquipment_Concurrent(t *testing.T) {
+	repo := &versionedEquipmentRepository{MockEquipmentRepository: NewMockEquipmentRepository()}
+	for _, id := range []string{"FL-1", "FL-2", "FL-3"} {
+		repo.AddEquipment(t, id, "forklift", "zone-a")
+	}
+	logger := logging.New(logging.DefaultConfig("test"))
+	service := NewEquipmentApplicationService(repo, NewMockStationRepository(), logger, 10*time.Minute)
+
+	const attempts = 8
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	claimed := make(map[string]string)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			reservationID := fmt.Sprintf("RES-%d", i)
+			result, err := service.ReserveEquipment(context.Background(), ReserveEquipmentCommand{
+				ReservationID: reservationID, EquipmentType: "forklift", OrderID: "ORD-" + reservationID, Quantity: 1,
+			})
+			if err != nil {
+				assertAppErrorCode(t, err, sharedErrors.CodeConflict)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			succeeded++
+			for _, id := range result.ReservedEquipmentIDs {
+				if other, taken := claimed[id]; taken {
+					t.Errorf("%s reserved by both %s and %s", id, other, reservationID)
+				}
+				claimed[id] = reservationID
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if succeeded != 3 {
+		t.Errorf("succeeded = %d, want 3", succeeded)
+	}
+	for id, reservationID := range claimed {
+		if !repo.equipment[id].IsReservedBy(reservationID) {
+			t.Errorf("%s stored reservation does not match %s", id, reservationID)
+		}
+	}
+}
+
+func TestEquipmentApplicationService_ReleaseEquipment(t *testing.T) {
+	t.Run("releases all equipment of the reservation", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		repo.AddEquipment(t, "FL-1", "forklift", "zone-a")
+		repo.AddEquipment(t, "FL-2", "forklift", "zone-a")
+		_, _ = service.ReserveEquipment(context.Background(), ReserveEquipmentCommand{
+			ReservationID: "RES-1", EquipmentType: "forklift", OrderID: "ORD-1", Quantity: 2,
+		})
+
+		result, err := service.ReleaseEquipment(context.Background(), ReleaseEquipmentCommand{
+			ReservationID: "RES-1", OrderID: "ORD-1", Reason: "workflow_failed",
+		})
+		if err != nil {
+			t.Fatalf("ReleaseEquipment() error = %v", err)
+		}
+		if len(result.ReleasedEquipmentIDs) != 2 {
+			t.Errorf("released = %v, want 2", result.ReleasedEquipmentIDs)
+		}
+		for _, eq := range repo.equipment {
+			if eq.Status != domain.EquipmentStatusAvailable {
+				t.Errorf("%s status = %v, want available", eq.EquipmentID, eq.Status)
+			}
+		}
+	})
+
+	t.Run("unknown reservation is a no-op", func(t *testing.T) {
+		service, _, _ := createTestEquipmentService()
+
+		result, err := service.ReleaseEquipment(context.Background(), ReleaseEquipmentCommand{ReservationID: "RES-404"})
+		if err != nil {
+			t.Fatalf("ReleaseEquipment() error = %v", err)
+		}
+		if len(result.ReleasedEquipmentIDs) != 0 {
+			t.Errorf("released = %v, want none", result.ReleasedEquipmentIDs)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		repo.findErr = errors.New("db down")
+
+		if _, err := service.ReleaseEquipment(context.Background(), ReleaseEquipmentCommand{ReservationID: "RES-1"}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+// =============================================================================
+// Status and Station Tests
+// =============================================================================
+
+func TestEquipmentApplicationService_SetEquipmentStatus(t *testing.T) {
+	t.Run("maintenance syncs station equipment", func(t *testing.T) {
+		service, repo, stationRepo := createTestEquipmentService()
+		station, _ := domain.NewStation("STN-001", "Packing 1", "zone-a", domain.StationTypePacking, 2)
+		stationRepo.AddStation(station)
+		eq := repo.AddEquipment(t, "EQ-001", "scale", "zone-a")
+		eq.StationID = "STN-001"
+		station.AddEquipment(eq.ToStationEquipment())
+
+		dto, err := service.SetEquipmentStatus(context.Background(), SetEquipmentStatusCommand{EquipmentID: "EQ-001", Status: "maintenance"})
+		if err != nil {
+			t.Fatalf("SetEquipmentStatus() error = %v", err)
+		}
+		if dto.Status != "maintenance" {
+			t.Errorf("Status = %v, want maintenance", dto.Status)
+		}
+		if station.Equipment[0].Status != "maintenance" {
+			t.Errorf("station equipment status = %v, want maintenance", station.Equipment[0].Status)
+		}
+	})
+
+	t.Run("in use then available", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		repo.AddEquipment(t, "EQ-001", "forklift", "zone-a")
+
+		dto, err := service.SetEquipmentStatus(context.Background(), SetEquipmentStatusCommand{EquipmentID: "EQ-001", Status: "in_use", AssignedTo: "TASK-1"})
+		if err != nil {
+			t.Fatalf("SetEquipmentStatus(in_use) error = %v", err)
+		}
+		if dto.AssignedTo != "TASK-1" {
+			t.Errorf("AssignedTo = %v, want TASK-1", dto.AssignedTo)
+		}
+
+		_, err = service.SetEquipmentStatus(context.Background(), SetEquipmentStatusCommand{EquipmentID: "EQ-001", Status: "in_use"})
+		assertAppErrorCode(t, err, sharedErrors.CodeConflict)
+
+		dto, err = service.SetEquipmentStatus(context.Background(), SetEquipmentStatusCommand{EquipmentID: "EQ-001", Status: "available"})
+		if err != nil {
+			t.Fatalf("SetEquipmentStatus(available) error = %v", err)
+		}
+		if dto.Status != "available" || dto.AssignedTo != "" {
+			t.Errorf("unexpected dto %+v", dto)
+		}
+	})
+
+	t.Run("rejects reserved and unknown statuses", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		repo.AddEquipment(t, "EQ-001", "forklift", "zone-a")
+
+		_, err := service.SetEquipmentStatus(context.Background(), SetEquipmentStatusCommand{EquipmentID: "EQ-001", Status: "reserved"})
+		assertAppErrorCode(t, err, sharedErrors.CodeValidationError)
+		_, err = service.SetEquipmentStatus(context.Background(), SetEquipmentStatusCommand{EquipmentID: "EQ-001", Status: "broken"})
+		assertAppErrorCode(t, err, sharedErrors.CodeValidationError)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		service, _, _ := createTestEquipmentService()
+
+		_, err := service.SetEquipmentStatus(context.Background(), SetEquipmentStatusCommand{EquipmentID: "EQ-404", Status: "maintenance"})
+		assertAppErrorCode(t, err, sharedErrors.CodeNotFound)
+	})
+}
+
+func TestEquipmentApplicationService_AssignToStation(t *testing.T) {
+	service, repo, stationRepo := createTestEquipmentService()
+	oldStation, _ := domain.NewStation("STN-001", "Packing 1", "zone-a", domain.StationTypePacking, 2)
+	newStation, _ := domain.NewStation("STN-002", "Packing 2", "zone-a", domain.StationTypePacking, 2)
+	stationRepo.AddStation(oldStation)
+	stationRepo.AddStation(newStation)
+	eq := repo.AddEquipment(t, "EQ-001", "scale", "zone-a")
+	eq.StationID = "STN-001"
+	oldStation.AddEquipment(eq.ToStationEquipment())
+
+	dto, err := service.AssignToStation(context.Background(), AssignEquipmentToStationCommand{EquipmentID: "EQ-001", StationID: "STN-002"})
+	if err != nil {
+		t.Fatalf("AssignToStation() error = %v", err)
+	}
+	if dto.StationID != "STN-002" {
+		t.Errorf("StationID = %v, want STN-002", dto.StationID)
+	}
+	if len(oldStation.Equipment) != 0 {
+		t.Errorf("old station equipment = %+v, want empty", oldStation.Equipment)
+	}
+	if len(newStation.Equipment) != 1 {
+		t.Errorf("new station equipment = %+v, want one", newStation.Equipment)
+	}
+
+	_, err = service.AssignToStation(context.Background(), AssignEquipmentToStationCommand{EquipmentID: "EQ-001", StationID: "STN-404"})
+	assertAppErrorCode(t, err, sharedErrors.CodeNotFound)
+}
+
+func TestEquipmentApplicationService_DeleteEquipment(t *testing.T) {
+	t.Run("deletes and unlinks from station", func(t *testing.T) {
+		service, repo, stationRepo := createTestEquipmentService()
+		station, _ := domain.NewStation("STN-001", "Packing 1", "zone-a", domain.StationTypePacking, 2)
+		stationRepo.AddStation(station)
+		eq := repo.AddEquipment(t, "EQ-001", "scale", "zone-a")
+		eq.StationID = "STN-001"
+		station.AddEquipment(eq.ToStationEquipment())
+
+		if err := service.DeleteEquipment(context.Background(), DeleteEquipmentCommand{EquipmentID: "EQ-001"}); err != nil {
+			t.Fatalf("DeleteEquipment() error = %v", err)
+		}
+		if repo.equipment["EQ-001"] != nil {
+			t.Error("equipment not deleted")
+		}
+		if len(station.Equipment) != 0 {
+			t.Errorf("station equipment = %+v, want empty", station.Equipment)
+		}
+	})
+
+	t.Run("rejects reserved equipment", func(t *testing.T) {
+		service, repo, _ := createTestEquipmentService()
+		eq := repo.AddEquipment(t, "EQ-001", "scale", "zone-a")
+		_ = eq.Reserve("RES-1", "ORD-1", time.Hour, time.Now())
+
+		err := service.DeleteEquipment(context.Background(), DeleteEquipmentCommand{EquipmentID: "EQ-001"})
+		assertAppErrorCode(t, err, sharedErrors.CodeConflict)
+	})
+}
+
+func TestEquipmentApplicationService_Queries(t *testing.T) {
+	service, repo, _ := createTestEquipmentService()
+	repo.AddEquipment(t, "FL-1", "forklift", "zone-a")
+	repo.AddEquipment(t, "FL-2", "forklift", "zone-b")
+	repo.AddEquipment(t, "SC-1", "scale", "zone-a")
+
+	byType, err := service.GetByType(context.Background(), GetEquipmentByTypeQuery{EquipmentType: "forklift", Zone: "zone-b"})
+	if err != nil {
+		t.Fatalf("GetByType() error = %v", err)
+	}
+	if len(byType) != 1 || byType[0].EquipmentID != "FL-2" {
+		t.Errorf("GetByType() = %+v", byType)
+	}
+
+	_, err = service.GetByType(context.Background(), GetEquipmentByTypeQuery{EquipmentType: "forklift", Status: "broken"})
+	assertAppErrorCode(t, err, sharedErrors.CodeValidationError)
+
+	all, err := service.ListEquipment(context.Background(), ListEquipmentQuery{})
+	if err != nil {
+		t.Fatalf("ListEquipment() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("ListEquipment() = %d, want 3", len(all))
+	}
+
+	dto, err := service.GetEquipment(context.Background(), GetEquipmentQuery{EquipmentID: "SC-1"})
+	if err != nil || dto.EquipmentType != "scale" {
+		t.Errorf("GetEquipment() = %+v, %v", dto, err)
+	}
+	_, err = service.GetEquipment(context.Background(), GetEquipmentQuery{EquipmentID: "XX"})
+	assertAppErrorCode(t, err, sharedErrors.CodeNotFound)
+}
@@ -49,3 +49,44 @@ func ToStationDTOs(stations []*domain.Station) []StationDTO {
 	}
 	return dtos
 }
+
+// ToEquipmentDTO converts a domain Equipment to EquipmentDTO
+func ToEquipmentDTO(equipment *domain.Equipment) *EquipmentDTO {
+	if equipment == nil {
+		return nil
+	}
+
+	dto := &EquipmentDTO{
+		EquipmentID:   equipment.EquipmentID,
+		EquipmentType: equipment.EquipmentType,
+		Name:          equipment.Name,
+		Zone:          equipment.Zone,
+		Status:        string(equipment.Status),
+		StationID:     equipment.StationID,
+		AssignedTo:    equipment.AssignedTo,
+		CreatedAt:     equipment.CreatedAt,
+		UpdatedAt:     equipment.UpdatedAt,
+	}
+
+	if equipment.Reservation != nil {
+		dto.Reservation = &EquipmentReservationDTO{
+			ReservationID: equipment.Reservation.ReservationID,
+			OrderID:       equipment.Reservation.OrderID,
+			ReservedAt:    equipment.Reservation.ReservedAt,
+			ExpiresAt:     equipment.Reservation.ExpiresAt,
+		}
+	}
+
+	return dto
+}
+
+// ToEquipmentDTOs converts a slice of domain Equipment to EquipmentDTOs
+func ToEquipmentDTOs(equipment []*domain.Equipment) []EquipmentDTO {
+	dtos := make([]EquipmentDTO, 0, len(equipment))
+	for _, eq := range equipment {
+		if dto := ToEquipmentDTO(eq); dto != nil {
+			dtos = append(dtos, *dto)
+		}
+	}
+	return dtos
+}
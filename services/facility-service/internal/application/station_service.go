@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 
 	"github.com/wms-platform/facility-service/internal/domain"
 )
@@ -100,29 +101,20 @@ func (s *StationApplicationService) GetStation(ctx context.Context, query GetSta
 
 // UpdateStation updates a station
 func (s *StationApplicationService) UpdateStation(ctx context.Context, cmd UpdateStationCommand) (*StationDTO, error) {
-	station, err := s.repo.FindByID(ctx, cmd.StationID)
+	station, err := s.updateStation(ctx, cmd.StationID, func(station *domain.Station) error {
+		if cmd.Name != "" {
+			station.Name = cmd.Name
+		}
+		if cmd.Zone != "" {
+			station.Zone = cmd.Zone
+		}
+		if cmd.MaxConcurrentTasks > 0 {
+			station.MaxConcurrentTasks = cmd.MaxConcurrentTasks
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get station", "stationId", cmd.StationID)
-		return nil, fmt.Errorf("failed to get station: %w", err)
-	}
-
-	if station == nil {
-		return nil, errors.ErrNotFound("station")
-	}
-
-	if cmd.Name != "" {
-		station.Name = cmd.Name
-	}
-	if cmd.Zone != "" {
-		station.Zone = cmd.Zone
-	}
-	if cmd.MaxConcurrentTasks > 0 {
-		station.MaxConcurrentTasks = cmd.MaxConcurrentTasks
-	}
-
-	if err := s.repo.Save(ctx, station); err != nil {
-		s.logger.WithError(err).Error("Failed to save station", "stationId", cmd.StationID)
-		return nil, fmt.Errorf("failed to save station: %w", err)
+		return nil, err
 	}
 
 	return ToStationDTO(station), nil
@@ -130,27 +122,18 @@ func (s *StationApplicationService) UpdateStation(ctx context.Context, cmd Updat
 
 // AddCapability adds a capability to a station
 func (s *StationApplicationService) AddCapability(ctx context.Context, cmd AddCapabilityCommand) (*StationDTO, error) {
-	station, err := s.repo.FindByID(ctx, cmd.StationID)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to get station", "stationId", cmd.StationID)
-		return nil, fmt.Errorf("failed to get station: %w", err)
-	}
-
-	if station == nil {
-		return nil, errors.ErrNotFound("station")
-	}
-
-	cap := domain.StationCapability(cmd.Capability)
-	if err := station.AddCapability(cap); err != nil {
-		if err == domain.ErrCapabilityExists {
-			return nil, errors.ErrConflict("capability already exists")
+	station, err := s.updateStation(ctx, cmd.StationID, func(station *domain.Station) error {
+		cap := domain.StationCapability(cmd.Capability)
+		if err := station.AddCapability(cap); err != nil {
+			if err == domain.ErrCapabilityExists {
+				return errors.ErrConflict("capability already exists")
+			}
+			return errors.ErrValidation(err.Error())
 		}
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, station); err != nil {
-		s.logger.WithError(err).Error("Failed to save station", "stationId", cmd.StationID)
-		return nil, fmt.Errorf("failed to save station: %w", err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
@@ -168,27 +151,18 @@ func (s *StationApplicationService) AddCapability(ctx context.Context, cmd AddCa
 
 // RemoveCapability removes a capability from a station
 func (s *StationApplicationService) RemoveCapability(ctx context.Context, cmd RemoveCapabilityCommand) (*StationDTO, error) {
-	station, err := s.repo.FindByID(ctx, cmd.StationID)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to get station", "stationId", cmd.StationID)
-		return nil, fmt.Errorf("failed to get station: %w", err)
-	}
-
-	if station == nil {
-		return nil, errors.ErrNotFound("station")
-	}
-
-	cap := domain.StationCapability(cmd.Capability)
-	if err := station.RemoveCapability(cap); err != nil {
-		if err == domain.ErrCapabilityNotFound {
-			return nil, errors.ErrNotFound("capability")
+	station, err := s.updateStation(ctx, cmd.StationID, func(station *domain.Station) error {
+		cap := domain.StationCapability(cmd.Capability)
+		if err := station.RemoveCapability(cap); err != nil {
+			if err == domain.ErrCapabilityNotFound {
+				return errors.ErrNotFound("capability")
+			}
+			return errors.ErrValidation(err.Error())
 		}
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, station); err != nil {
-		s.logger.WithError(err).Error("Failed to save station", "stationId", cmd.StationID)
-		return nil, fmt.Errorf("failed to save station: %w", err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
@@ -206,28 +180,19 @@ func (s *StationApplicationService) RemoveCapability(ctx context.Context, cmd Re
 
 // SetCapabilities sets all capabilities for a station
 func (s *StationApplicationService) SetCapabilities(ctx context.Context, cmd SetCapabilitiesCommand) (*StationDTO, error) {
-	station, err := s.repo.FindByID(ctx, cmd.StationID)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to get station", "stationId", cmd.StationID)
-		return nil, fmt.Errorf("failed to get station: %w", err)
-	}
-
-	if station == nil {
-		return nil, errors.ErrNotFound("station")
-	}
-
 	capabilities := make([]domain.StationCapability, len(cmd.Capabilities))
 	for i, capStr := range cmd.Capabilities {
 		capabilities[i] = domain.StationCapability(capStr)
 	}
 
-	if err := station.SetCapabilities(capabilities); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, station); err != nil {
-		s.logger.WithError(err).Error("Failed to save station", "stationId", cmd.StationID)
-		return nil, fmt.Errorf("failed to save station: %w", err)
+	station, err := s.updateStation(ctx, cmd.StationID, func(station *domain.Station) error {
+		if err := station.SetCapabilities(capabilities); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
@@ -242,26 +207,56 @@ func (s *StationApplicationService) SetCapabilities(ctx context.Context, cmd Set
 
 // SetStatus updates the station status
 func (s *StationApplicationService) SetStatus(ctx context.Context, cmd SetStationStatusCommand) (*StationDTO, error) {
-	station, err := s.repo.FindByID(ctx, cmd.StationID)
+	station, err := s.updateStation(ctx, cmd.StationID, func(station *domain.Station) error {
+		if err := station.SetStatus(domain.StationStatus(cmd.Status)); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get station", "stationId", cmd.StationID)
-		return nil, fmt.Errorf("failed to get station: %w", err)
+		return nil, err
 	}
 
-	if station == nil {
-		return nil, errors.ErrNotFound("station")
-	}
+	return ToStationDTO(station), nil
+}
 
-	if err := station.SetStatus(domain.StationStatus(cmd.Status)); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
+// updateStation loads a station, applies change and saves it. A save that loses a race
+// with another writer reloads the station and applies change again.
+func (s *StationApplicationService) updateStation(ctx context.Context, stationID string, change func(station *domain.Station) error) (*domain.Station, error) {
+	var station *domain.Station
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.repo.FindByID(ctx, stationID)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to get station", "stationId", stationID)
+			return fmt.Errorf("failed to get station: %w", err)
+		}
 
-	if err := s.repo.Save(ctx, station); err != nil {
-		s.logger.WithError(err).Error("Failed to save station", "stationId", cmd.StationID)
-		return nil, fmt.Errorf("failed to save station: %w", err)
-	}
+		if loaded == nil {
+			return errors.ErrNotFound("station")
+		}
 
-	return ToStationDTO(station), nil
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				s.logger.Debug("Station changed concurrently, retrying", "stationId", stationID)
+				return err
+			}
+			s.logger.WithError(err).Error("Failed to save station", "stationId", stationID)
+			return fmt.Errorf("failed to save station: %w", err)
+		}
+		station = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("station %s was modified concurrently, please retry", stationID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return station, nil
 }
 
 // FindCapableStations finds stations that have all required capabilities
@@ -0,0 +1,405 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Equipment errors
+var (
+	ErrEquipmentNotAvailable     = errors.New("equipment is not available")
+	ErrEquipmentNotReserved      = errors.New("equipment is not reserved")
+	ErrEquipmentNotInUse         = errors.New("equipment is not in use")
+	ErrReservationMismatch       = errors.New("equipment is reserved under a different reservation")
+	ErrInvalidEquipmentStatus    = errors.New("invalid equipment status")
+	ErrInvalidEquipmentType      = errors.New("equipment type is required")
+	ErrInvalidReservationTimeout = errors.New("reservation duration must be positive")
+)
+
+// DefaultEquipmentReservationDuration is how long a reservation holds equipment when no duration is given
+const DefaultEquipmentReservationDuration = 30 * time.Minute
+
+// EquipmentStatus represents the lifecycle status of a piece of equipment
+type EquipmentStatus string
+
+const (
+	EquipmentStatusAvailable   EquipmentStatus = "available"
+	EquipmentStatusReserved    EquipmentStatus = "reserved"
+	EquipmentStatusInUse       EquipmentStatus = "in_use"
+	EquipmentStatusMaintenance EquipmentStatus = "maintenance"
+)
+
+// IsValid checks if the status is valid
+func (s EquipmentStatus) IsValid() bool {
+	switch s {
+	case EquipmentStatusAvailable, EquipmentStatusReserved, EquipmentStatusInUse, EquipmentStatusMaintenance:
+		return true
+	default:
+		return false
+	}
+}
+
+// StationEquipmentStatus maps the equipment lifecycle status to the station equipment status
+func (s EquipmentStatus) StationEquipmentStatus() string {
+	if s == EquipmentStatusMaintenance {
+		return "maintenance"
+	}
+	return "active"
+}
+
+// EquipmentReservation is a time-boxed hold on equipment for an order
+type EquipmentReservation struct {
+	ReservationID string    `bson:"reservationId" json:"reservationId"`
+	OrderID       string    `bson:"orderId" json:"orderId"`
+	ReservedAt    time.Time `bson:"reservedAt" json:"reservedAt"`
+	ExpiresAt     time.Time `bson:"expiresAt" json:"expiresAt"`
+}
+
+// IsExpired checks if the reservation has passed its expiry time
+func (r *EquipmentReservation) IsExpired(asOf time.Time) bool {
+	return !asOf.Before(r.ExpiresAt)
+}
+
+// Equipment represents a piece of facility equipment (scale, printer, forklift, cold storage, ...)
+type Equipment struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	EquipmentID string             `bson:"equipmentId"`
+	// Multi-tenant context
+	TenantID      string                `bson:"tenantId"`
+	FacilityID    string                `bson:"facilityId"`
+	WarehouseID   string                `bson:"warehouseId,omitempty"`
+	EquipmentType string                `bson:"equipmentType"`
+	Name          string                `bson:"name"`
+	Zone          string                `bson:"zone"`
+	StationID     string                `bson:"stationId,omitempty"`
+	Status        EquipmentStatus       `bson:"status"`
+	Reservation   *EquipmentReservation `bson:"reservation,omitempty"`
+	AssignedTo    string                `bson:"assignedTo,omitempty"` // Order or task ID while in use
+	Version       int                   `bson:"version"`
+	CreatedAt     time.Time             `bson:"createdAt"`
+	UpdatedAt     time.Time             `bson:"updatedAt"`
+	DomainEvents  []DomainEvent         `bson:"-"`
+}
+
+// NewEquipment creates a new Equipment aggregate
+func NewEquipment(equipmentID, equipmentType, name, zone string) (*Equipment, error) {
+	return NewEquipmentWithTenant(equipmentID, equipmentType, name, zone, "", "", "")
+}
+
+// NewEquipmentWithTenant creates a new Equipment aggregate with tenant context
+func NewEquipmentWithTenant(equipmentID, equipmentType, name, zone, tenantID, facilityID, warehouseID string) (*Equipment, error) {
+	if equipmentType == "" {
+		return nil, ErrInvalidEquipmentType
+	}
+
+	now := time.Now()
+	equipment := &Equipment{
+		EquipmentID:   equipmentID,
+		TenantID:      tenantID,
+		FacilityID:    facilityID,
+		WarehouseID:   warehouseID,
+		EquipmentType: equipmentType,
+		Name:          name,
+		Zone:          zone,
+		Status:        EquipmentStatusAvailable,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		DomainEvents:  make([]DomainEvent, 0),
+	}
+
+	equipment.AddDomainEvent(&EquipmentRegisteredEvent{
+		EquipmentID:   equipmentID,
+		TenantID:      tenantID,
+		FacilityID:    facilityID,
+		EquipmentType: equipmentType,
+		Name:          name,
+		Zone:          zone,
+		RegisteredAt:  now,
+	})
+
+	return equipment, nil
+}
+
+// IsAvailable checks if the equipment can be reserved, treating lapsed reservations as released
+func (e *Equipment) IsAvailable(asOf time.Time) bool {
+	switch e.Status {
+	case EquipmentStatusAvailable:
+		return true
+	case EquipmentStatusReserved:
+		return e.Reservation == nil || e.Reservation.IsExpired(asOf)
+	default:
+		return false
+	}
+}
+
+// IsReservedBy checks if the equipment is held by the given reservation
+func (e *Equipment) IsReservedBy(reservationID string) bool {
+	return e.Reservation != nil && e.Reservation.ReservationID == reservationID
+}
+
+// Reserve places a time-boxed hold on the equipment for an order
+func (e *Equipment) Reserve(reservationID, orderID string, duration time.Duration, now time.Time) error {
+	if duration <= 0 {
+		return ErrInvalidReservationTimeout
+	}
+	if e.Status == EquipmentStatusReserved && e.IsReservedBy(reservationID) {
+		return nil // Idempotent re-reservation
+	}
+	if !e.IsAvailable(now) {
+		return ErrEquipmentNotAvailable
+	}
+
+	// A lapsed reservation is expired before the new one takes over
+	e.ExpireReservation(now)
+
+	e.Reservation = &EquipmentReservation{
+		ReservationID: reservationID,
+		OrderID:       orderID,
+		ReservedAt:    now,
+		ExpiresAt:     now.Add(duration),
+	}
+	e.setStatus(EquipmentStatusReserved, now)
+
+	e.AddDomainEvent(&EquipmentReservedEvent{
+		EquipmentID:   e.EquipmentID,
+		EquipmentType: e.EquipmentType,
+		ReservationID: reservationID,
+		OrderID:       orderID,
+		ExpiresAt:     e.Reservation.ExpiresAt,
+		ReservedAt:    now,
+	})
+
+	return nil
+}
+
+// Release releases the equipment held by a reservation, including equipment already put in use
+func (e *Equipment) Release(reservationID, reason string) error {
+	if e.Reservation == nil || (e.Status != EquipmentStatusReserved && e.Status != EquipmentStatusInUse) {
+		return ErrEquipmentNotReserved
+	}
+	if !e.IsReservedBy(reservationID) {
+		return ErrReservationMismatch
+	}
+
+	now := time.Now()
+	orderID := e.Reservation.OrderID
+	e.Reservation = nil
+	e.AssignedTo = ""
+	e.setStatus(EquipmentStatusAvailable, now)
+
+	e.AddDomainEvent(&EquipmentReleasedEvent{
+		EquipmentID:   e.EquipmentID,
+		EquipmentType: e.EquipmentType,
+		ReservationID: reservationID,
+		OrderID:       orderID,
+		Reason:        reason,
+		ReleasedAt:    now,
+	})
+
+	return nil
+}
+
+// ExpireReservation clears a reservation whose time box has lapsed. Returns true if one was expired.
+func (e *Equipment) ExpireReservation(now time.Time) bool {
+	if e.Status != EquipmentStatusReserved || e.Reservation == nil || !e.Reservation.IsExpired(now) {
+		return false
+	}
+
+	expired := e.Reservation
+	e.Reservation = nil
+	e.setStatus(EquipmentStatusAvailable, now)
+
+	e.AddDomainEvent(&EquipmentReservationExpiredEvent{
+		EquipmentID:   e.EquipmentID,
+		ReservationID: expired.ReservationID,
+		OrderID:       expired.OrderID,
+		ExpiredAt:     expired.ExpiresAt,
+	})
+
+	return true
+}
+
+// StartUse puts the equipment in use, either directly or under its current reservation
+func (e *Equipment) StartUse(assignedTo string) error {
+	now := time.Now()
+	switch {
+	case e.Status == EquipmentStatusAvailable:
+	case e.Status == EquipmentStatusReserved && e.Reservation != nil && !e.Reservation.IsExpired(now):
+	default:
+		return ErrEquipmentNotAvailable
+	}
+
+	e.AssignedTo = assignedTo
+	return e.SetStatus(EquipmentStatusInUse)
+}
+
+// FinishUse returns in-use equipment to the available pool
+func (e *Equipment) FinishUse() error {
+	if e.Status != EquipmentStatusInUse {
+		return ErrEquipmentNotInUse
+	}
+
+	e.Reservation = nil
+	e.AssignedTo = ""
+	return e.SetStatus(EquipmentStatusAvailable)
+}
+
+// SetMaintenance takes the equipment out of service, dropping any reservation
+func (e *Equipment) SetMaintenance() error {
+	e.Reservation = nil
+	e.AssignedTo = ""
+	return e.SetStatus(EquipmentStatusMaintenance)
+}
+
+// SetStatus updates the equipment status
+func (e *Equipment) SetStatus(status EquipmentStatus) error {
+	if !status.IsValid() {
+		return ErrInvalidEquipmentStatus
+	}
+	if status == EquipmentStatusReserved {
+		// Reservations must go through Reserve so they are time-boxed
+		return ErrInvalidEquipmentStatus
+	}
+	if status == EquipmentStatusAvailable {
+		e.Reservation = nil
+		e.AssignedTo = ""
+	}
+
+	e.setStatus(status, time.Now())
+	return nil
+}
+
+// setStatus changes the status and records the transition
+func (e *Equipment) setStatus(status EquipmentStatus, now time.Time) {
+	oldStatus := e.Status
+	e.Status = status
+	e.UpdatedAt = now
+
+	if oldStatus == status {
+		return
+	}
+
+	e.AddDomainEvent(&EquipmentStatusChangedEvent{
+		EquipmentID: e.EquipmentID,
+		StationID:   e.StationID,
+		OldStatus:   string(oldStatus),
+		NewStatus:   string(status),
+		ChangedAt:   now,
+	})
+}
+
+// AssignToStation links the equipment to a station
+func (e *Equipment) AssignToStation(stationID string) {
+	oldStationID := e.StationID
+	e.StationID = stationID
+	e.UpdatedAt = time.Now()
+
+	e.AddDomainEvent(&EquipmentStationAssignedEvent{
+		EquipmentID:  e.EquipmentID,
+		OldStationID: oldStationID,
+		StationID:    stationID,
+		AssignedAt:   e.UpdatedAt,
+	})
+}
+
+// ToStationEquipment returns the station's view of this equipment
+func (e *Equipment) ToStationEquipment() StationEquipment {
+	return StationEquipment{
+		EquipmentID:   e.EquipmentID,
+		EquipmentType: e.EquipmentType,
+		Status:        e.Status.StationEquipmentStatus(),
+	}
+}
+
+// AddDomainEvent adds a domain event
+func (e *Equipment) AddDomainEvent(event DomainEvent) {
+	e.DomainEvents = append(e.DomainEvents, event)
+}
+
+// ClearDomainEvents clears all domain events
+func (e *Equipment) ClearDomainEvents() {
+	e.DomainEvents = make([]DomainEvent, 0)
+}
+
+// GetDomainEvents returns all domain events
+func (e *Equipment) GetDomainEvents() []DomainEvent {
+	return e.DomainEvents
+}
+
+// Equipment Domain Events
+
+// EquipmentRegisteredEvent is emitted when equipment is registered
+type EquipmentRegisteredEvent struct {
+	EquipmentID   string    `json:"equipmentId"`
+	TenantID      string    `json:"tenantId,omitempty"`
+	FacilityID    string    `json:"facilityId,omitempty"`
+	EquipmentType string    `json:"equipmentType"`
+	Name          string    `json:"name"`
+	Zone          string    `json:"zone"`
+	RegisteredAt  time.Time `json:"registeredAt"`
+}
+
+func (e *EquipmentRegisteredEvent) EventType() string     { return "equipment.registered" }
+func (e *EquipmentRegisteredEvent) OccurredAt() time.Time { return e.RegisteredAt }
+
+// EquipmentReservedEvent is emitted when equipment is reserved for an order
+type EquipmentReservedEvent struct {
+	EquipmentID   string    `json:"equipmentId"`
+	EquipmentType string    `json:"equipmentType"`
+	ReservationID string    `json:"reservationId"`
+	OrderID       string    `json:"orderId"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	ReservedAt    time.Time `json:"reservedAt"`
+}
+
+func (e *EquipmentReservedEvent) EventType() string     { return "equipment.reserved" }
+func (e *EquipmentReservedEvent) OccurredAt() time.Time { return e.ReservedAt }
+
+// EquipmentReleasedEvent is emitted when a reservation on equipment is released
+type EquipmentReleasedEvent struct {
+	EquipmentID   string    `json:"equipmentId"`
+	EquipmentType string    `json:"equipmentType"`
+	ReservationID string    `json:"reservationId"`
+	OrderID       string    `json:"orderId"`
+	Reason        string    `json:"reason,omitempty"`
+	ReleasedAt    time.Time `json:"releasedAt"`
+}
+
+func (e *EquipmentReleasedEvent) EventType() string     { return "equipment.released" }
+func (e *EquipmentReleasedEvent) OccurredAt() time.Time { return e.ReleasedAt }
+
+// EquipmentReservationExpiredEvent is emitted when a reservation lapses without being released
+type EquipmentReservationExpiredEvent struct {
+	EquipmentID   string    `json:"equipmentId"`
+	ReservationID string    `json:"reservationId"`
+	OrderID       string    `json:"orderId"`
+	ExpiredAt     time.Time `json:"expiredAt"`
+}
+
+func (e *EquipmentReservationExpiredEvent) EventType() string     { return "equipment.reservation.expired" }
+func (e *EquipmentReservationExpiredEvent) OccurredAt() time.Time { return e.ExpiredAt }
+
+// EquipmentStatusChangedEvent is emitted when equipment status changes
+type EquipmentStatusChangedEvent struct {
+	EquipmentID string    `json:"equipmentId"`
+	StationID   string    `json:"stationId,omitempty"`
+	OldStatus   string    `json:"oldStatus"`
+	NewStatus   string    `json:"newStatus"`
+	ChangedAt   time.Time `json:"changedAt"`
+}
+
+func (e *EquipmentStatusChangedEvent) EventType() string     { return "equipment.status.changed" }
+func (e *EquipmentStatusChangedEvent) OccurredAt() time.Time { return e.ChangedAt }
+
+// EquipmentStationAssignedEvent is emitted when equipment is linked to (or unlinked from) a station
+type EquipmentStationAssignedEvent struct {
+	EquipmentID  string    `json:"equipmentId"`
+	OldStationID string    `json:"oldStationId,omitempty"`
+	StationID    string    `json:"stationId,omitempty"`
+	AssignedAt   time.Time `json:"assignedAt"`
+}
+
+func (e *EquipmentStationAssignedEvent) EventType() string     { return "equipment.station.assigned" }
+func (e *EquipmentStationAssignedEvent) OccurredAt() time.Time { return e.AssignedAt }
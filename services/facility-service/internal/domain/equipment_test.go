@@ -0,0 +1,315 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestEquipment(t *testing.T) *Equipment {
+	t.Helper()
+	equipment, err := NewEquipment("EQ-001", "forklift", "Forklift 1", "zone-a")
+	if err != nil {
+		t.Fatalf("NewEquipment() error = %v", err)
+	}
+	equipment.ClearDomainEvents()
+	return equipment
+}
+
+func TestEquipmentStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		status EquipmentStatus
+		want   bool
+	}{
+		{EquipmentStatusAvailable, true},
+		{EquipmentStatusReserved, true},
+		{EquipmentStatusInUse, true},
+		{EquipmentStatusMaintenance, true},
+		{EquipmentStatus("broken"), false},
+		{EquipmentStatus(""), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEquipment(t *testing.T) {
+	t.Run("creates available equipment with registered event", func(t *testing.T) {
+		equipment, err := NewEquipmentWithTenant("EQ-001", "scale", "Scale 1", "zone-a", "T1", "F1", "W1")
+		if err != nil {
+			t.Fatalf("NewEquipmentWithTenant() error = %v", err)
+		}
+		if equipment.Status != EquipmentStatusAvailable {
+			t.Errorf("Status = %v, want %v", equipment.Status, EquipmentStatusAvailable)
+		}
+		if equipment.TenantID != "T1" || equipment.FacilityID != "F1" || equipment.WarehouseID != "W1" {
+			t.Errorf("tenant context not set: %+v", equipment)
+		}
+		events := equipment.GetDomainEvents()
+		if len(events) != 1 || events[0].EventType() != "equipment.registered" {
+			t.Fatalf("events = %v, want one equipment.registered", events)
+		}
+	})
+
+	t.Run("rejects missing equipment type", func(t *testing.T) {
+		_, err := NewEquipment("EQ-002", "", "Unknown", "zone-a")
+		if !errors.Is(err, ErrInvalidEquipmentType) {
+			t.Errorf("error = %v, want %v", err, ErrInvalidEquipmentType)
+		}
+	})
+}
+
+func TestEquipment_Reserve(t *testing.T) {
+	now := time.Now()
+
+	t.Run("reserves available equipment", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+
+		if err := equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, now); err != nil {
+			t.Fatalf("Reserve() error = %v", err)
+		}
+		if equipment.Status != EquipmentStatusReserved {
+			t.Errorf("Status = %v, want %v", equipment.Status, EquipmentStatusReserved)
+		}
+		if !equipment.IsReservedBy("RES-1") {
+			t.Error("IsReservedBy(RES-1) = false, want true")
+		}
+		if !equipment.Reservation.ExpiresAt.Equal(now.Add(10 * time.Minute)) {
+			t.Errorf("ExpiresAt = %v, want %v", equipment.Reservation.ExpiresAt, now.Add(10*time.Minute))
+		}
+
+		var reserved *EquipmentReservedEvent
+		for _, event := range equipment.GetDomainEvents() {
+			if e, ok := event.(*EquipmentReservedEvent); ok {
+				reserved = e
+			}
+		}
+		if reserved == nil || reserved.OrderID != "ORD-1" {
+			t.Errorf("expected reserved event for ORD-1, got %+v", reserved)
+		}
+	})
+
+	t.Run("is idempotent for the same reservation", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+		_ = equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, now)
+		equipment.ClearDomainEvents()
+
+		if err := equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, now); err != nil {
+			t.Fatalf("Reserve() error = %v", err)
+		}
+		if len(equipment.GetDomainEvents()) != 0 {
+			t.Errorf("expected no events on idempotent reserve, got %d", len(equipment.GetDomainEvents()))
+		}
+	})
+
+	t.Run("rejects a second reservation", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+		_ = equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, now)
+
+		err := equipment.Reserve("RES-2", "ORD-2", 10*time.Minute, now)
+		if !errors.Is(err, ErrEquipmentNotAvailable) {
+			t.Errorf("error = %v, want %v", err, ErrEquipmentNotAvailable)
+		}
+	})
+
+	t.Run("takes over a lapsed reservation", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+		_ = equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, now)
+		equipment.ClearDomainEvents()
+
+		later := now.Add(11 * time.Minute)
+		if !equipment.IsAvailable(later) {
+			t.Fatal("IsAvailable() = false after expiry, want true")
+		}
+		if err := equipment.Reserve("RES-2", "ORD-2", 10*time.Minute, later); err != nil {
+			t.Fatalf("Reserve() error = %v", err)
+		}
+		if !equipment.IsReservedBy("RES-2") {
+			t.Error("IsReservedBy(RES-2) = false, want true")
+		}
+
+		var expired bool
+		for _, event := range equipment.GetDomainEvents() {
+			if _, ok := event.(*EquipmentReservationExpiredEvent); ok {
+				expired = true
+			}
+		}
+		if !expired {
+			t.Error("expected reservation expired event")
+		}
+	})
+
+	t.Run("rejects equipment in maintenance", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+		_ = equipment.SetMaintenance()
+
+		err := equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, now)
+		if !errors.Is(err, ErrEquipmentNotAvailable) {
+			t.Errorf("error = %v, want %v", err, ErrEquipmentNotAvailable)
+		}
+	})
+
+	t.Run("rejects non-positive duration", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+
+		err := equipment.Reserve("RES-1", "ORD-1", 0, now)
+		if !errors.Is(err, ErrInvalidReservationTimeout) {
+			t.Errorf("error = %v, want %v", err, ErrInvalidReservationTimeout)
+		}
+	})
+}
+
+func TestEquipment_Release(t *testing.T) {
+	now := time.Now()
+
+	t.Run("releases reserved equipment", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+		_ = equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, now)
+
+		if err := equipment.Release("RES-1", "workflow_failed"); err != nil {
+			t.Fatalf("Release() error = %v", err)
+		}
+		if equipment.Status != EquipmentStatusAvailable || equipment.Reservation != nil {
+			t.Errorf("expected available with no reservation, got %v %+v", equipment.Status, equipment.Reservation)
+		}
+	})
+
+	t.Run("releases equipment in use under the reservation", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+		_ = equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, now)
+		_ = equipment.StartUse("ORD-1")
+
+		if err := equipment.Release("RES-1", "order_cancelled"); err != nil {
+			t.Fatalf("Release() error = %v", err)
+		}
+		if equipment.Status != EquipmentStatusAvailable || equipment.AssignedTo != "" {
+			t.Errorf("expected available and unassigned, got %v %q", equipment.Status, equipment.AssignedTo)
+		}
+	})
+
+	t.Run("rejects a different reservation", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+		_ = equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, now)
+
+		err := equipment.Release("RES-2", "")
+		if !errors.Is(err, ErrReservationMismatch) {
+			t.Errorf("error = %v, want %v", err, ErrReservationMismatch)
+		}
+	})
+
+	t.Run("rejects unreserved equipment", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+
+		err := equipment.Release("RES-1", "")
+		if !errors.Is(err, ErrEquipmentNotReserved) {
+			t.Errorf("error = %v, want %v", err, ErrEquipmentNotReserved)
+		}
+	})
+}
+
+func TestEquipment_ExpireReservation(t *testing.T) {
+	now := time.Now()
+	equipment := newTestEquipment(t)
+	_ = equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, now)
+
+	if equipment.ExpireReservation(now.Add(5 * time.Minute)) {
+		t.Error("ExpireReservation() = true before expiry, want false")
+	}
+	if !equipment.ExpireReservation(now.Add(10 * time.Minute)) {
+		t.Error("ExpireReservation() = false at expiry, want true")
+	}
+	if equipment.Status != EquipmentStatusAvailable || equipment.Reservation != nil {
+		t.Errorf("expected available with no reservation, got %v %+v", equipment.Status, equipment.Reservation)
+	}
+}
+
+func TestEquipment_Lifecycle(t *testing.T) {
+	t.Run("start and finish use", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+
+		if err := equipment.StartUse("TASK-1"); err != nil {
+			t.Fatalf("StartUse() error = %v", err)
+		}
+		if equipment.Status != EquipmentStatusInUse || equipment.AssignedTo != "TASK-1" {
+			t.Errorf("expected in_use assigned to TASK-1, got %v %q", equipment.Status, equipment.AssignedTo)
+		}
+		if err := equipment.StartUse("TASK-2"); !errors.Is(err, ErrEquipmentNotAvailable) {
+			t.Errorf("second StartUse() error = %v, want %v", err, ErrEquipmentNotAvailable)
+		}
+		if err := equipment.FinishUse(); err != nil {
+			t.Fatalf("FinishUse() error = %v", err)
+		}
+		if equipment.Status != EquipmentStatusAvailable {
+			t.Errorf("Status = %v, want %v", equipment.Status, EquipmentStatusAvailable)
+		}
+		if err := equipment.FinishUse(); !errors.Is(err, ErrEquipmentNotInUse) {
+			t.Errorf("FinishUse() error = %v, want %v", err, ErrEquipmentNotInUse)
+		}
+	})
+
+	t.Run("maintenance drops reservation", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+		_ = equipment.Reserve("RES-1", "ORD-1", 10*time.Minute, time.Now())
+
+		if err := equipment.SetMaintenance(); err != nil {
+			t.Fatalf("SetMaintenance() error = %v", err)
+		}
+		if equipment.Reservation != nil {
+			t.Error("expected reservation cleared")
+		}
+		if equipment.ToStationEquipment().Status != "maintenance" {
+			t.Errorf("station status = %v, want maintenance", equipment.ToStationEquipment().Status)
+		}
+	})
+
+	t.Run("reserved cannot be set directly", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+
+		if err := equipment.SetStatus(EquipmentStatusReserved); !errors.Is(err, ErrInvalidEquipmentStatus) {
+			t.Errorf("SetStatus(reserved) error = %v, want %v", err, ErrInvalidEquipmentStatus)
+		}
+		if err := equipment.SetStatus("broken"); !errors.Is(err, ErrInvalidEquipmentStatus) {
+			t.Errorf("SetStatus(broken) error = %v, want %v", err, ErrInvalidEquipmentStatus)
+		}
+	})
+
+	t.Run("status change emits event", func(t *testing.T) {
+		equipment := newTestEquipment(t)
+		equipment.StationID = "STN-1"
+		_ = equipment.SetMaintenance()
+
+		events := equipment.GetDomainEvents()
+		if len(events) != 1 {
+			t.Fatalf("events = %d, want 1", len(events))
+		}
+		changed, ok := events[0].(*EquipmentStatusChangedEvent)
+		if !ok || changed.OldStatus != "available" || changed.NewStatus != "maintenance" || changed.StationID != "STN-1" {
+			t.Errorf("unexpected event %+v", events[0])
+		}
+	})
+}
+
+func TestEquipment_AssignToStation(t *testing.T) {
+	equipment := newTestEquipment(t)
+	equipment.AssignToStation("STN-1")
+	equipment.AssignToStation("STN-2")
+
+	if equipment.StationID != "STN-2" {
+		t.Errorf("StationID = %v, want STN-2", equipment.StationID)
+	}
+	events := equipment.GetDomainEvents()
+	last, ok := events[len(events)-1].(*EquipmentStationAssignedEvent)
+	if !ok || last.OldStationID != "STN-1" || last.StationID != "STN-2" {
+		t.Errorf("unexpected event %+v", events[len(events)-1])
+	}
+
+	stationEquipment := equipment.ToStationEquipment()
+	if stationEquipment.EquipmentID != "EQ-001" || stationEquipment.EquipmentType != "forklift" || stationEquipment.Status != "active" {
+		t.Errorf("ToStationEquipment() = %+v", stationEquipment)
+	}
+}
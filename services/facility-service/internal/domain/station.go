@@ -116,6 +116,7 @@ type Station struct {
 	CurrentTasks       int                 `bson:"currentTasks"`
 	AssignedWorkerID   string              `bson:"assignedWorkerId,omitempty"`
 	Equipment          []StationEquipment  `bson:"equipment"`
+	Version            int                 `bson:"version"`
 	CreatedAt          time.Time           `bson:"createdAt"`
 	UpdatedAt          time.Time           `bson:"updatedAt"`
 	DomainEvents       []DomainEvent       `bson:"-"`
@@ -362,6 +363,18 @@ func (s *Station) RemoveEquipment(equipmentID string) bool {
 	return false
 }
 
+// UpsertEquipment adds equipment to the station or refreshes the existing entry with the same ID
+func (s *Station) UpsertEquipment(equipment StationEquipment) {
+	for i, eq := range s.Equipment {
+		if eq.EquipmentID == equipment.EquipmentID {
+			s.Equipment[i] = equipment
+			s.UpdatedAt = time.Now()
+			return
+		}
+	}
+	s.AddEquipment(equipment)
+}
+
 // GetAvailableCapacity returns the number of additional tasks the station can accept
 func (s *Station) GetAvailableCapacity() int {
 	if s.Status != StationStatusActive {
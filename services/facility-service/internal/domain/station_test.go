@@ -751,3 +751,17 @@ func TestDomainEvents_OccurredAt(t *testing.T) {
 		t.Error("OccurredAt() should not be zero")
 	}
 }
+
+func TestStation_UpsertEquipment(t *testing.T) {
+	station, _ := NewStation("STN-001", "Station", "zone-a", StationTypePacking, 5)
+
+	station.UpsertEquipment(StationEquipment{EquipmentID: "EQ-001", EquipmentType: "scale", Status: "active"})
+	station.UpsertEquipment(StationEquipment{EquipmentID: "EQ-001", EquipmentType: "scale", Status: "maintenance"})
+
+	if len(station.Equipment) != 1 {
+		t.Fatalf("Equipment length = %v, want 1", len(station.Equipment))
+	}
+	if station.Equipment[0].Status != "maintenance" {
+		t.Errorf("Equipment[0].Status = %v, want maintenance", station.Equipment[0].Status)
+	}
+}
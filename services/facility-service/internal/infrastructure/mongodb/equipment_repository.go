@@ -0,0 +1,253 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/facility-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/cloudevents"
+	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
+	"github.com/wms-platform/shared/pkg/outbox"
+	"github.com/wms-platform/shared/pkg/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type EquipmentRepository struct {
+	collection   mongoCollection
+	db           mongoDatabase
+	outboxRepo   outbox.Repository
+	eventFactory *cloudevents.EventFactory
+	tenantHelper *tenant.RepositoryHelper
+}
+
+// NewEquipmentRepository creates an equipment repository that shares the given outbox repository
+func NewEquipmentRepository(db *mongo.Database, outboxRepo outbox.Repository, eventFactory *cloudevents.EventFactory) *EquipmentRepository {
+	repo := newEquipmentRepository(
+		mongoDatabaseWrapper{db: db},
+		outboxRepo,
+		eventFactory,
+	)
+	repo.ensureIndexes(context.Background())
+
+	return repo
+}
+
+func newEquipmentRepository(db mongoDatabase, outboxRepo outbox.Repository, eventFactory *cloudevents.EventFactory) *EquipmentRepository {
+	return &EquipmentRepository{
+		collection:   db.Collection("equipment"),
+		db:           db,
+		outboxRepo:   outboxRepo,
+		eventFactory: eventFactory,
+		tenantHelper: tenant.NewRepositoryHelper(false),
+	}
+}
+
+func (r *EquipmentRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "equipmentId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{
+			{Key: "equipmentType", Value: 1},
+			{Key: "zone", Value: 1},
+			{Key: "status", Value: 1},
+		}},
+		{Keys: bson.D{{Key: "reservation.reservationId", Value: 1}}},
+		{Keys: bson.D{{Key: "reservation.expiresAt", Value: 1}}},
+		{Keys: bson.D{{Key: "stationId", Value: 1}}},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+// Save persists equipment with its domain events in a single transaction. The write only
+// succeeds if the stored equipment still has the version that was loaded, so concurrent
+// reservations cannot both claim the same piece of equipment.
+func (r *EquipmentRepository) Save(ctx context.Context, equipment *domain.Equipment) error {
+	equipment.UpdatedAt = time.Now()
+	expectedVersion := equipment.Version
+	equipment.Version = expectedVersion + 1
+
+	// Start a MongoDB session for transaction
+	session, err := r.db.Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	// Execute transaction
+	err = session.WithTransaction(ctx, func(sessCtx context.Context) error {
+		// 1. Save the aggregate. Reservation is unset explicitly so a released hold does not linger.
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"equipmentId": equipment.EquipmentID}, expectedVersion)
+		update := bson.M{"$set": equipment}
+		if equipment.Reservation == nil {
+			update["$unset"] = bson.M{"reservation": ""}
+		}
+
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "Equipment", equipment.EquipmentID, expectedVersion); err != nil {
+			return fmt.Errorf("failed to save equipment: %w", err)
+		}
+
+		// 2. Save domain events to outbox
+		domainEvents := equipment.GetDomainEvents()
+		if len(domainEvents) > 0 {
+			outboxEvents := make([]*outbox.OutboxEvent, 0, len(domainEvents))
+
+			for _, event := range domainEvents {
+				// Convert domain event to CloudEvent
+				var cloudEvent *cloudevents.WMSCloudEvent
+				switch e := event.(type) {
+				case *domain.EquipmentRegisteredEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "equipment/"+e.EquipmentID, e)
+				case *domain.EquipmentReservedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "equipment/"+e.EquipmentID, e)
+				case *domain.EquipmentReleasedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "equipment/"+e.EquipmentID, e)
+				case *domain.EquipmentReservationExpiredEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "equipment/"+e.EquipmentID, e)
+				case *domain.EquipmentStatusChangedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "equipment/"+e.EquipmentID, e)
+				case *domain.EquipmentStationAssignedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "equipment/"+e.EquipmentID, e)
+				default:
+					continue
+				}
+
+				// Create outbox event from CloudEvent - publish to FacilityEvents topic
+				outboxEvent, err := outbox.NewOutboxEventFromCloudEvent(
+					equipment.EquipmentID,
+					"Equipment",
+					kafka.Topics.FacilityEvents,
+					cloudEvent,
+				)
+				if err != nil {
+					return fmt.Errorf("failed to create outbox event: %w", err)
+				}
+
+				outboxEvents = append(outboxEvents, outboxEvent)
+			}
+
+			// Save all outbox events in the same transaction
+			if len(outboxEvents) > 0 {
+				if err := r.outboxRepo.SaveAll(sessCtx, outboxEvents); err != nil {
+					return fmt.Errorf("failed to save outbox events: %w", err)
+				}
+			}
+		}
+
+		// 3. Clear domain events from the aggregate
+		equipment.ClearDomainEvents()
+
+		return nil
+	})
+
+	if err != nil {
+		equipment.Version = expectedVersion
+		return fmt.Errorf("transaction failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *EquipmentRepository) FindByID(ctx context.Context, equipmentID string) (*domain.Equipment, error) {
+	filter := bson.M{"equipmentId": equipmentID}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	var equipment domain.Equipment
+	err := r.collection.FindOne(ctx, filter).Decode(&equipment)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return &equipment, err
+}
+
+// FindByType finds equipment of a type, optionally narrowed by zone and status
+func (r *EquipmentRepository) FindByType(ctx context.Context, equipmentType, zone string, status domain.EquipmentStatus) ([]*domain.Equipment, error) {
+	filter := bson.M{"equipmentType": equipmentType}
+	if zone != "" {
+		filter["zone"] = zone
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	return r.find(ctx, filter)
+}
+
+// FindAvailable finds equipment of a type that can be reserved as of the given time.
+// Equipment held by a lapsed reservation counts as available.
+func (r *EquipmentRepository) FindAvailable(ctx context.Context, equipmentType, zone string, asOf time.Time, limit int) ([]*domain.Equipment, error) {
+	filter := bson.M{
+		"equipmentType": equipmentType,
+		"$or": bson.A{
+			bson.M{"status": domain.EquipmentStatusAvailable},
+			bson.M{
+				"status":                domain.EquipmentStatusReserved,
+				"reservation.expiresAt": bson.M{"$lte": asOf},
+			},
+		},
+	}
+	if zone != "" {
+		filter["zone"] = zone
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().SetSort(bson.D{{Key: "equipmentId", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	return r.find(ctx, filter, opts)
+}
+
+// FindByReservationID finds all equipment held by a reservation
+func (r *EquipmentRepository) FindByReservationID(ctx context.Context, reservationID string) ([]*domain.Equipment, error) {
+	filter := bson.M{"reservation.reservationId": reservationID}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	return r.find(ctx, filter)
+}
+
+// FindByStationID finds all equipment linked to a station
+func (r *EquipmentRepository) FindByStationID(ctx context.Context, stationID string) ([]*domain.Equipment, error) {
+	filter := bson.M{"stationId": stationID}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	return r.find(ctx, filter)
+}
+
+func (r *EquipmentRepository) FindAll(ctx context.Context, limit, offset int) ([]*domain.Equipment, error) {
+	filter := bson.M{}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().SetLimit(int64(limit)).SetSkip(int64(offset))
+	return r.find(ctx, filter, opts)
+}
+
+func (r *EquipmentRepository) Delete(ctx context.Context, equipmentID string) error {
+	filter := bson.M{"equipmentId": equipmentID}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	_, err := r.collection.DeleteOne(ctx, filter)
+	return err
+}
+
+func (r *EquipmentRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*domain.Equipment, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var equipment []*domain.Equipment
+	err = cursor.All(ctx, &equipment)
+	return equipment, err
+}
+
+// GetOutboxRepository returns the outbox repository for this service
+func (r *EquipmentRepository) GetOutboxRepository() outbox.Repository {
+	return r.outboxRepo
+}
@@ -0,0 +1,218 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/cloudevents"
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/wms-platform/facility-service/internal/domain"
+)
+
+func TestEquipmentRepository_Save(t *testing.T) {
+	t.Run("saves equipment and outbox events", func(t *testing.T) {
+		equipment, err := domain.NewEquipment("EQ-1", "forklift", "Forklift 1", "A")
+		if err != nil {
+			t.Fatalf("NewEquipment error: %v", err)
+		}
+		if err := equipment.Reserve("RES-1", "ORD-1", time.Minute, time.Now()); err != nil {
+			t.Fatalf("Reserve error: %v", err)
+		}
+
+		collection := &fakeCollection{}
+		outboxRepo := &fakeOutboxRepo{}
+		db := &fakeDatabase{collection: collection, client: &fakeSessionClient{}}
+		repo := newEquipmentRepository(db, outboxRepo, cloudevents.NewEventFactory("/facility-service"))
+
+		if err := repo.Save(context.Background(), equipment); err != nil {
+			t.Fatalf("Save error: %v", err)
+		}
+
+		filter, ok := collection.updateFilter.(bson.M)
+		if !ok || filter["equipmentId"] != "EQ-1" {
+			t.Fatalf("unexpected filter: %#v", collection.updateFilter)
+		}
+		update, _ := collection.updateDoc.(bson.M)
+		if _, hasUnset := update["$unset"]; hasUnset {
+			t.Fatalf("reserved equipment should not unset reservation: %#v", update)
+		}
+		// registered, reserved and status changed
+		if len(outboxRepo.lastEvents) != 3 || outboxRepo.saveAllCalls != 1 {
+			t.Fatalf("expected 3 outbox events, got %d", len(outboxRepo.lastEvents))
+		}
+		if outboxRepo.lastEvents[0].AggregateType != "Equipment" {
+			t.Fatalf("aggregate type = %s", outboxRepo.lastEvents[0].AggregateType)
+		}
+		if len(equipment.GetDomainEvents()) != 0 {
+			t.Fatalf("expected domain events cleared")
+		}
+	})
+
+	t.Run("released equipment unsets reservation", func(t *testing.T) {
+		equipment, _ := domain.NewEquipment("EQ-2", "forklift", "Forklift 2", "A")
+		equipment.ClearDomainEvents()
+
+		collection := &fakeCollection{}
+		outboxRepo := &fakeOutboxRepo{}
+		db := &fakeDatabase{collection: collection, client: &fakeSessionClient{}}
+		repo := newEquipmentRepository(db, outboxRepo, cloudevents.NewEventFactory("/facility-service"))
+
+		if err := repo.Save(context.Background(), equipment); err != nil {
+			t.Fatalf("Save error: %v", err)
+		}
+		update, _ := collection.updateDoc.(bson.M)
+		if _, hasUnset := update["$unset"]; !hasUnset {
+			t.Fatalf("expected reservation unset: %#v", update)
+		}
+		if outboxRepo.saveAllCalls != 0 {
+			t.Fatalf("expected no outbox SaveAll calls, got %d", outboxRepo.saveAllCalls)
+		}
+	})
+
+	t.Run("update error fails transaction", func(t *testing.T) {
+		equipment, _ := domain.NewEquipment("EQ-3", "forklift", "Forklift 3", "A")
+		collection := &fakeCollection{updateErr: errors.New("update failed")}
+		db := &fakeDatabase{collection: collection, client: &fakeSessionClient{}}
+		repo := newEquipmentRepository(db, &fakeOutboxRepo{}, cloudevents.NewEventFactory("/facility-service"))
+
+		err := repo.Save(context.Background(), equipment)
+		if err == nil || !strings.Contains(err.Error(), "failed to save equipment") {
+			t.Fatalf("expected save error, got %v", err)
+		}
+	})
+
+	t.Run("stale version is a concurrency conflict", func(t *testing.T) {
+		equipment, _ := domain.NewEquipment("EQ-5", "forklift", "Forklift 5", "A")
+		equipment.Version = 3
+		collection := &fakeCollection{updateResult: &mongo.UpdateResult{}}
+		db := &fakeDatabase{collection: collection, client: &fakeSessionClient{}}
+		repo := newEquipmentRepository(db, &fakeOutboxRepo{}, cloudevents.NewEventFactory("/facility-service"))
+
+		err := repo.Save(context.Background(), equipment)
+		if !sharedErrors.IsConcurrencyConflict(err) {
+			t.Fatalf("expected concurrency conflict, got %v", err)
+		}
+		filter, _ := collection.updateFilter.(bson.M)
+		if filter["version"] != 3 {
+			t.Fatalf("expected filter on loaded version, got %#v", filter)
+		}
+		if equipment.Version != 3 {
+			t.Fatalf("version = %d, want 3 restored after conflict", equipment.Version)
+		}
+	})
+
+	t.Run("outbox error fails transaction", func(t *testing.T) {
+		equipment, _ := domain.NewEquipment("EQ-4", "forklift", "Forklift 4", "A")
+		outboxRepo := &fakeOutboxRepo{saveAllErr: errors.New("outbox failed")}
+		db := &fakeDatabase{collection: &fakeCollection{}, client: &fakeSessionClient{}}
+		repo := newEquipmentRepository(db, outboxRepo, cloudevents.NewEventFactory("/facility-service"))
+
+		err := repo.Save(context.Background(), equipment)
+		if err == nil || !strings.Contains(err.Error(), "failed to save outbox events") {
+			t.Fatalf("expected outbox error, got %v", err)
+		}
+	})
+}
+
+func TestEquipmentRepository_FindByID(t *testing.T) {
+	collection := &fakeCollection{
+		findOneResult: fakeSingleResult{equipment: &domain.Equipment{EquipmentID: "EQ-1"}},
+	}
+	db := &fakeDatabase{collection: collection, client: &fakeSessionClient{}}
+	repo := newEquipmentRepository(db, &fakeOutboxRepo{}, cloudevents.NewEventFactory("/facility-service"))
+
+	found, err := repo.FindByID(context.Background(), "EQ-1")
+	if err != nil || found == nil || found.EquipmentID != "EQ-1" {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+
+	collection.findOneResult = fakeSingleResult{decodeErr: mongo.ErrNoDocuments}
+	found, err = repo.FindByID(context.Background(), "missing")
+	if err != nil || found != nil {
+		t.Fatalf("FindByID missing expected nil, err=%v", err)
+	}
+}
+
+func TestEquipmentRepository_FindAvailable(t *testing.T) {
+	cursor := &fakeCursor{equipment: []*domain.Equipment{{EquipmentID: "EQ-1"}}}
+	collection := &fakeCollection{findCursor: cursor}
+	db := &fakeDatabase{collection: collection, client: &fakeSessionClient{}}
+	repo := newEquipmentRepository(db, &fakeOutboxRepo{}, cloudevents.NewEventFactory("/facility-service"))
+
+	asOf := time.Now()
+	equipment, err := repo.FindAvailable(context.Background(), "forklift", "Z1", asOf, 5)
+	if err != nil || len(equipment) != 1 {
+		t.Fatalf("FindAvailable failed: %v", err)
+	}
+
+	filter, ok := collection.findFilter.(bson.M)
+	if !ok || filter["equipmentType"] != "forklift" || filter["zone"] != "Z1" {
+		t.Fatalf("unexpected filter: %#v", collection.findFilter)
+	}
+	or, ok := filter["$or"].(bson.A)
+	if !ok || len(or) != 2 {
+		t.Fatalf("expected available-or-lapsed clause: %#v", filter["$or"])
+	}
+	lapsed, _ := or[1].(bson.M)
+	if lapsed["status"] != domain.EquipmentStatusReserved {
+		t.Fatalf("unexpected lapsed clause: %#v", lapsed)
+	}
+	if *collection.findOpts[0].Limit != 5 {
+		t.Fatalf("unexpected limit: %#v", collection.findOpts[0])
+	}
+	if !cursor.closed {
+		t.Fatalf("expected cursor closed")
+	}
+}
+
+func TestEquipmentRepository_FindLists(t *testing.T) {
+	cursor := &fakeCursor{equipment: []*domain.Equipment{{EquipmentID: "EQ-1"}}}
+	collection := &fakeCollection{findCursor: cursor}
+	db := &fakeDatabase{collection: collection, client: &fakeSessionClient{}}
+	repo := newEquipmentRepository(db, &fakeOutboxRepo{}, cloudevents.NewEventFactory("/facility-service"))
+
+	_, _ = repo.FindByType(context.Background(), "scale", "", domain.EquipmentStatusMaintenance)
+	filter, _ := collection.findFilter.(bson.M)
+	if filter["equipmentType"] != "scale" || filter["status"] != domain.EquipmentStatusMaintenance {
+		t.Fatalf("FindByType filter: %#v", filter)
+	}
+	if _, hasZone := filter["zone"]; hasZone {
+		t.Fatalf("FindByType should omit empty zone: %#v", filter)
+	}
+
+	_, _ = repo.FindByReservationID(context.Background(), "RES-1")
+	filter, _ = collection.findFilter.(bson.M)
+	if filter["reservation.reservationId"] != "RES-1" {
+		t.Fatalf("FindByReservationID filter: %#v", filter)
+	}
+
+	_, _ = repo.FindByStationID(context.Background(), "STN-1")
+	filter, _ = collection.findFilter.(bson.M)
+	if filter["stationId"] != "STN-1" {
+		t.Fatalf("FindByStationID filter: %#v", filter)
+	}
+
+	_, _ = repo.FindAll(context.Background(), 10, 5)
+	if *collection.findOpts[0].Limit != 10 || *collection.findOpts[0].Skip != 5 {
+		t.Fatalf("unexpected find options: %#v", collection.findOpts[0])
+	}
+
+	collection.findErr = errors.New("find failed")
+	if _, err := repo.FindByStationID(context.Background(), "STN-1"); err == nil {
+		t.Fatalf("expected find error")
+	}
+
+	if err := repo.Delete(context.Background(), "EQ-1"); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	filter, _ = collection.deleteFilter.(bson.M)
+	if filter["equipmentId"] != "EQ-1" {
+		t.Fatalf("Delete filter: %#v", filter)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/facility-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -183,8 +184,12 @@ func (r *StationRepository) ensureIndexes(ctx context.Context) {
 	r.collection.Indexes().CreateMany(ctx, indexes)
 }
 
+// Save persists a station with its domain events in a single transaction. The write only
+// succeeds if the stored station still has the version that was loaded.
 func (r *StationRepository) Save(ctx context.Context, station *domain.Station) error {
 	station.UpdatedAt = time.Now()
+	expectedVersion := station.Version
+	station.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -196,11 +201,12 @@ func (r *StationRepository) Save(ctx context.Context, station *domain.Station) e
 	// Execute transaction
 	err = session.WithTransaction(ctx, func(sessCtx context.Context) error {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"stationId": station.StationID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"stationId": station.StationID}, expectedVersion)
 		update := bson.M{"$set": station}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "Station", station.StationID, expectedVersion); err != nil {
 			return fmt.Errorf("failed to save station: %w", err)
 		}
 
@@ -258,6 +264,7 @@ func (r *StationRepository) Save(ctx context.Context, station *domain.Station) e
 	})
 
 	if err != nil {
+		station.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/wms-platform/shared/pkg/cloudevents"
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/outbox"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -64,6 +65,7 @@ func (f fakeIndexView) CreateMany(ctx context.Context, models []mongo.IndexModel
 
 type fakeSingleResult struct {
 	station   *domain.Station
+	equipment *domain.Equipment
 	decodeErr error
 }
 
@@ -75,15 +77,19 @@ func (f fakeSingleResult) Decode(v interface{}) error {
 	case *domain.Station:
 		*target = *f.station
 		return nil
+	case *domain.Equipment:
+		*target = *f.equipment
+		return nil
 	default:
 		return fmt.Errorf("unexpected decode target %T", v)
 	}
 }
 
 type fakeCursor struct {
-	stations []*domain.Station
-	allErr   error
-	closed   bool
+	stations  []*domain.Station
+	equipment []*domain.Equipment
+	allErr    error
+	closed    bool
 }
 
 func (f *fakeCursor) All(ctx context.Context, results interface{}) error {
@@ -94,6 +100,9 @@ func (f *fakeCursor) All(ctx context.Context, results interface{}) error {
 	case *[]*domain.Station:
 		*target = f.stations
 		return nil
+	case *[]*domain.Equipment:
+		*target = f.equipment
+		return nil
 	default:
 		return fmt.Errorf("unexpected results target %T", results)
 	}
@@ -109,6 +118,7 @@ type fakeCollection struct {
 	updateDoc    interface{}
 	updateErr    error
 	updateOpts   []*options.UpdateOptions
+	updateResult *mongo.UpdateResult
 
 	findOneFilter interface{}
 	findOneResult mongoSingleResult
@@ -129,7 +139,10 @@ func (f *fakeCollection) UpdateOne(ctx context.Context, filter interface{}, upda
 	if f.updateErr != nil {
 		return nil, f.updateErr
 	}
-	return &mongo.UpdateResult{}, nil
+	if f.updateResult != nil {
+		return f.updateResult, nil
+	}
+	return &mongo.UpdateResult{MatchedCount: 1}, nil
 }
 
 func (f *fakeCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) mongoSingleResult {
@@ -264,6 +277,26 @@ func TestStationRepository_Save(t *testing.T) {
 		}
 	})
 
+	t.Run("stale version is a concurrency conflict", func(t *testing.T) {
+		station, _ := domain.NewStation("STN-6", "Station 6", "F", domain.StationTypePacking, 1)
+		station.Version = 2
+		collection := &fakeCollection{updateResult: &mongo.UpdateResult{}}
+		db := &fakeDatabase{collection: collection, client: &fakeSessionClient{}}
+		repo := newStationRepository(db, &fakeOutboxRepo{}, cloudevents.NewEventFactory("/facility-service"))
+
+		err := repo.Save(context.Background(), station)
+		if !sharedErrors.IsConcurrencyConflict(err) {
+			t.Fatalf("expected concurrency conflict, got %v", err)
+		}
+		filter, _ := collection.updateFilter.(bson.M)
+		if filter["version"] != 2 {
+			t.Fatalf("expected filter on loaded version, got %#v", filter)
+		}
+		if station.Version != 2 {
+			t.Fatalf("version = %d, want 2 restored after conflict", station.Version)
+		}
+	})
+
 	t.Run("outbox error fails transaction", func(t *testing.T) {
 		station, _ := domain.NewStation("STN-4", "Station 4", "D", domain.StationTypePacking, 1)
 		outboxRepo := &fakeOutboxRepo{saveAllErr: errors.New("outbox failed")}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -20,9 +21,12 @@ import (
 	"github.com/wms-platform/shared/pkg/middleware"
 	"github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
+	"github.com/wms-platform/shared/pkg/tenant"
 	"github.com/wms-platform/shared/pkg/tracing"
 
 	"github.com/wms-platform/inventory-service/internal/application"
+	"github.com/wms-platform/inventory-service/internal/domain"
+	"github.com/wms-platform/inventory-service/internal/infrastructure/clients"
 	mongoRepo "github.com/wms-platform/inventory-service/internal/infrastructure/mongodb"
 	"github.com/wms-platform/inventory-service/internal/infrastructure/projections"
 )
@@ -150,6 +154,92 @@ func main() {
 	inventoryService.SetLedgerService(ledgerService)
 	logger.Info("Ledger service initialized and integrated")
 
+	// Configure minimum remaining shelf life for FEFO lot allocation
+	inventoryService.SetShelfLifePolicy(config.ShelfLife)
+
+	// Start lot expiry monitor (blocks expired lots, warns about expiring lots)
+	lotExpiryMonitor := application.NewLotExpiryMonitor(inventoryService, config.LotExpiry, logger)
+	if err := lotExpiryMonitor.Start(ctx); err != nil {
+		logger.WithError(err).Error("Failed to start lot expiry monitor")
+		os.Exit(1)
+	}
+	defer lotExpiryMonitor.Stop()
+	logger.Info("Lot expiry monitor started",
+		"checkInterval", config.LotExpiry.CheckInterval,
+		"warningWindow", config.LotExpiry.WarningWindow,
+	)
+
+	// Release unit reservations in unit-service when stale reservations are swept
+	inventoryService.SetUnitReleaser(clients.NewUnitServiceClient(config.UnitServiceURL))
+
+	// Start reservation sweeper (releases reservations past their expiry time)
+	reservationSweeper := application.NewReservationSweeper(inventoryService, config.ReservationSweep, logger)
+	if config.ReservationSweepEnabled {
+		if err := reservationSweeper.Start(ctx); err != nil {
+			logger.WithError(err).Error("Failed to start reservation sweeper")
+			os.Exit(1)
+		}
+		defer reservationSweeper.Stop()
+		logger.Info("Reservation sweeper started",
+			"sweepInterval", config.ReservationSweep.SweepInterval,
+			"dryRun", config.ReservationSweep.DryRun,
+			"tenantIds", config.ReservationSweep.TenantIDs,
+		)
+	}
+
+	// Initialize cycle count service and scheduler (ABC cycle counting with recounts and approval)
+	cycleCountRepo := mongoRepo.NewCycleCountRepository(instrumentedMongo.Database(), eventFactory)
+	cycleCountService := application.NewCycleCountService(cycleCountRepo, inventoryService, config.CycleCountPolicy, logger)
+	cycleCountScheduler := application.NewCycleCountScheduler(cycleCountService, config.CycleCount, logger)
+	if config.CycleCountEnabled {
+		if err := cycleCountScheduler.Start(ctx); err != nil {
+			logger.WithError(err).Error("Failed to start cycle count scheduler")
+			os.Exit(1)
+		}
+		defer cycleCountScheduler.Stop()
+		logger.Info("Cycle count scheduler started",
+			"checkInterval", config.CycleCount.CheckInterval,
+			"blindCounts", config.CycleCountPolicy.BlindCounts,
+			"approvalValueLimit", config.CycleCountPolicy.ApprovalValueLimit,
+		)
+	}
+
+	// Initialize replenishment service and monitor (pick face min/max and wave top-off)
+	replenishmentRepo := mongoRepo.NewReplenishmentTaskRepository(instrumentedMongo.Database(), eventFactory)
+	replenishmentService := application.NewReplenishmentService(replenishmentRepo, inventoryService, config.ReplenishmentPriority, logger)
+	replenishmentService.SetLaborQueue(clients.NewLaborServiceClient(config.LaborServiceURL))
+	inventoryService.SetReplenishmentService(replenishmentService)
+	replenishmentMonitor := application.NewReplenishmentMonitor(replenishmentService, config.Replenishment, logger)
+	if config.ReplenishmentEnabled {
+		if err := replenishmentMonitor.Start(ctx); err != nil {
+			logger.WithError(err).Error("Failed to start replenishment monitor")
+			os.Exit(1)
+		}
+		defer replenishmentMonitor.Stop()
+		logger.Info("Replenishment monitor started",
+			"checkInterval", config.Replenishment.CheckInterval,
+			"priority", config.ReplenishmentPriority,
+		)
+	}
+
+	// Initialize slotting service (re-slot recommendations measured with routing-service's travel model)
+	slottingService := application.NewSlottingService(
+		mongoRepo.NewSlottingRecommendationRepository(instrumentedMongo.Database()),
+		mongoRepo.NewSlotLocationRepository(instrumentedMongo.Database()),
+		inventoryService,
+		clients.NewRoutingServiceClient(config.RoutingServiceURL),
+		config.Slotting,
+		logger,
+	)
+	slottingService.SetLaborQueue(clients.NewLaborServiceClient(config.LaborServiceURL))
+
+	// Initialize inventory hold service (holds by SKU, lot or location)
+	holdService := application.NewInventoryHoldService(
+		mongoRepo.NewInventoryHoldRepository(instrumentedMongo.Database()),
+		inventoryService,
+		logger,
+	)
+
 	// Setup Gin router with middleware
 	router := gin.New()
 
@@ -205,6 +295,45 @@ func main() {
 		api.GET("/low-stock", getLowStockHandler(inventoryService, logger))
 		api.POST("/reserve", reserveBulkHandler(inventoryService, logger))
 		api.POST("/release/:orderId", releaseByOrderHandler(inventoryService, logger))
+		api.POST("/lots/expiry-check", processLotExpiryHandler(inventoryService, config.LotExpiry, logger))
+		api.POST("/reservations/sweep", sweepExpiredReservationsHandler(inventoryService, config.ReservationSweep, logger))
+
+		// Cycle count routes
+		api.POST("/cycle-counts", createCycleCountHandler(cycleCountService, logger))
+		api.GET("/cycle-counts", listCycleCountsHandler(cycleCountService, logger))
+		api.POST("/cycle-counts/schedule", scheduleCycleCountsHandler(cycleCountService, config.CycleCount, logger))
+		api.GET("/cycle-counts/:taskId", getCycleCountHandler(cycleCountService, logger))
+		api.POST("/cycle-counts/:taskId/count", submitCycleCountHandler(cycleCountService, logger))
+		api.POST("/cycle-counts/:taskId/approve", approveCycleCountHandler(cycleCountService, logger))
+		api.POST("/cycle-counts/:taskId/reject", rejectCycleCountHandler(cycleCountService, logger))
+
+		// Replenishment routes
+		api.GET("/replenishments", listReplenishmentsHandler(replenishmentService, logger))
+		api.POST("/replenishments/generate", generateReplenishmentsHandler(replenishmentService, config.Replenishment, logger))
+		api.POST("/replenishments/wave", topOffWaveHandler(replenishmentService, logger))
+		api.GET("/replenishments/:taskId", getReplenishmentHandler(replenishmentService, logger))
+		api.POST("/replenishments/:taskId/complete", completeReplenishmentHandler(replenishmentService, logger))
+		api.POST("/replenishments/:taskId/cancel", cancelReplenishmentHandler(replenishmentService, logger))
+
+		// Slotting routes
+		api.GET("/slotting/locations", listSlotLocationsHandler(slottingService, logger))
+		api.PUT("/slotting/locations/:locationId", saveSlotLocationHandler(slottingService, logger))
+		api.POST("/slotting/recommendations/generate", generateSlottingHandler(slottingService, logger))
+		api.GET("/slotting/recommendations", listSlottingHandler(slottingService, logger))
+		api.GET("/slotting/recommendations/:recommendationId", getSlottingHandler(slottingService, logger))
+		api.POST("/slotting/recommendations/:recommendationId/approve", approveSlottingHandler(slottingService, logger))
+		api.POST("/slotting/recommendations/:recommendationId/reject", rejectSlottingHandler(slottingService, logger))
+		api.POST("/slotting/recommendations/:recommendationId/complete", completeSlottingMoveHandler(slottingService, logger))
+		api.POST("/slotting/recommendations/:recommendationId/cancel", cancelSlottingMoveHandler(slottingService, logger))
+
+		// Inventory hold routes
+		api.GET("/holds", listHoldsHandler(holdService, logger))
+		api.POST("/holds", placeHoldHandler(holdService, logger))
+		api.GET("/holds/:holdId", getHoldHandler(holdService, logger))
+		api.POST("/holds/:holdId/release", releaseHoldHandler(holdService, logger))
+
+		// LPN/tote moves: all contents of a location at once
+		api.POST("/locations/:locationId/move", moveContainerHandler(inventoryService, logger))
 
 		// Wildcard SKU routes (must come after static routes)
 		api.GET("/:sku", getItemHandler(inventoryService, logger))
@@ -213,6 +342,13 @@ func main() {
 		api.POST("/:sku/pick", pickHandler(inventoryService, logger))
 		api.POST("/:sku/release", releaseReservationHandler(inventoryService, logger))
 		api.POST("/:sku/adjust", adjustHandler(inventoryService, logger))
+		api.POST("/:sku/status-change", changeStatusHandler(inventoryService, logger))
+		api.POST("/:sku/transfer", transferHandler(inventoryService, logger))
+		api.POST("/:sku/moves", startMoveHandler(inventoryService, logger))
+		api.POST("/:sku/moves/:moveId/drop", dropMoveHandler(inventoryService, logger))
+		api.PUT("/:sku/customs", setCustomsProfileHandler(inventoryService, logger))
+		api.PUT("/:sku/pick-faces/:locationId", setPickFaceLimitsHandler(replenishmentService, logger))
+		api.PUT("/:sku/slotting-profile", setSlottingProfileHandler(slottingService, logger))
 
 		// Hard allocation routes (physical staging lifecycle)
 		api.POST("/:sku/stage", stageHandler(inventoryService, logger))
@@ -266,6 +402,24 @@ type Config struct {
 	ServerAddr string
 	MongoDB    *mongodb.Config
 	Kafka      *kafka.Config
+	ShelfLife  *domain.ShelfLifePolicy
+	LotExpiry  application.LotExpiryMonitorConfig
+
+	UnitServiceURL          string
+	ReservationSweepEnabled bool
+	ReservationSweep        application.ReservationSweeperConfig
+
+	CycleCountEnabled bool
+	CycleCount        application.CycleCountSchedulerConfig
+	CycleCountPolicy  domain.CycleCountPolicy
+
+	LaborServiceURL       string
+	ReplenishmentEnabled  bool
+	Replenishment         application.ReplenishmentMonitorConfig
+	ReplenishmentPriority int
+
+	RoutingServiceURL string
+	Slotting          application.SlottingSettings
 }
 
 func loadConfig() *Config {
@@ -286,7 +440,133 @@ func loadConfig() *Config {
 			BatchTimeout:  10 * time.Millisecond,
 			RequiredAcks:  -1,
 		},
+		ShelfLife: &domain.ShelfLifePolicy{
+			DefaultMinDays: getEnvInt("SHELF_LIFE_MIN_DAYS", 0),
+			SellerMinDays:  parseDaysMap(getEnv("SHELF_LIFE_SELLER_MIN_DAYS", "")),
+			ChannelMinDays: parseDaysMap(getEnv("SHELF_LIFE_CHANNEL_MIN_DAYS", "")),
+		},
+		LotExpiry: loadLotExpiryConfig(),
+
+		UnitServiceURL:          getEnv("UNIT_SERVICE_URL", "http://localhost:8014"),
+		ReservationSweepEnabled: getEnv("RESERVATION_SWEEP_ENABLED", "true") == "true",
+		ReservationSweep:        loadReservationSweepConfig(),
+
+		CycleCountEnabled: getEnv("CYCLE_COUNT_ENABLED", "true") == "true",
+		CycleCount:        loadCycleCountConfig(),
+		CycleCountPolicy:  loadCycleCountPolicy(),
+
+		LaborServiceURL:       getEnv("LABOR_SERVICE_URL", "http://localhost:8009"),
+		ReplenishmentEnabled:  getEnv("REPLENISHMENT_ENABLED", "true") == "true",
+		Replenishment:         loadReplenishmentConfig(),
+		ReplenishmentPriority: getEnvInt("REPLENISHMENT_PRIORITY", 5),
+
+		RoutingServiceURL: getEnv("ROUTING_SERVICE_URL", "http://localhost:8003"),
+		Slotting:          loadSlottingSettings(),
+	}
+}
+
+func loadSlottingSettings() application.SlottingSettings {
+	settings := application.DefaultSlottingSettings()
+	if gain, err := strconv.ParseFloat(getEnv("SLOTTING_MIN_SCORE_GAIN", ""), 64); err == nil && gain >= 0 {
+		settings.Scoring.MinScoreGain = gain
+	}
+	if moves := getEnvInt("SLOTTING_MAX_MOVES", -1); moves >= 0 {
+		settings.Scoring.MaxMoves = moves
+	}
+	if orders := getEnvInt("SLOTTING_MIN_SHARED_ORDERS", 0); orders > 0 {
+		settings.MinSharedOrders = orders
+	}
+	if priority := getEnvInt("SLOTTING_MOVE_PRIORITY", 0); priority > 0 {
+		settings.MovePriority = priority
+	}
+	return settings
+}
+
+func loadReplenishmentConfig() application.ReplenishmentMonitorConfig {
+	config := application.DefaultReplenishmentMonitorConfig()
+	if interval, err := time.ParseDuration(getEnv("REPLENISHMENT_CHECK_INTERVAL", "")); err == nil && interval > 0 {
+		config.CheckInterval = interval
+	}
+	if batchSize := getEnvInt("REPLENISHMENT_BATCH_SIZE", 0); batchSize > 0 {
+		config.BatchSize = batchSize
+	}
+	return config
+}
+
+func loadCycleCountConfig() application.CycleCountSchedulerConfig {
+	config := application.DefaultCycleCountSchedulerConfig()
+	if interval, err := time.ParseDuration(getEnv("CYCLE_COUNT_CHECK_INTERVAL", "")); err == nil && interval > 0 {
+		config.CheckInterval = interval
+	}
+	if batchSize := getEnvInt("CYCLE_COUNT_BATCH_SIZE", 0); batchSize > 0 {
+		config.BatchSize = batchSize
+	}
+	return config
+}
+
+func loadCycleCountPolicy() domain.CycleCountPolicy {
+	policy := domain.DefaultCycleCountPolicy()
+	policy.BlindCounts = getEnv("CYCLE_COUNT_BLIND", "true") == "true"
+	if percent, err := strconv.ParseFloat(getEnv("CYCLE_COUNT_RECOUNT_VARIANCE_PERCENT", ""), 64); err == nil && percent >= 0 {
+		policy.RecountVariancePercent = percent
+	}
+	if recounts := getEnvInt("CYCLE_COUNT_MAX_RECOUNTS", -1); recounts >= 0 {
+		policy.MaxRecounts = recounts
+	}
+	if limit, err := strconv.ParseFloat(getEnv("CYCLE_COUNT_APPROVAL_VALUE_LIMIT", ""), 64); err == nil && limit >= 0 {
+		policy.ApprovalValueLimit = limit
+	}
+	return policy
+}
+
+func loadReservationSweepConfig() application.ReservationSweeperConfig {
+	config := application.DefaultReservationSweeperConfig()
+	if interval, err := time.ParseDuration(getEnv("RESERVATION_SWEEP_INTERVAL", "")); err == nil && interval > 0 {
+		config.SweepInterval = interval
+	}
+	if batchSize := getEnvInt("RESERVATION_SWEEP_BATCH_SIZE", 0); batchSize > 0 {
+		config.BatchSize = batchSize
+	}
+	config.DryRun = getEnv("RESERVATION_SWEEP_DRY_RUN", "false") == "true"
+	for _, tenantID := range strings.Split(getEnv("RESERVATION_SWEEP_TENANTS", ""), ",") {
+		if tenantID = strings.TrimSpace(tenantID); tenantID != "" {
+			config.TenantIDs = append(config.TenantIDs, tenantID)
+		}
+	}
+	return config
+}
+
+func loadLotExpiryConfig() application.LotExpiryMonitorConfig {
+	config := application.DefaultLotExpiryMonitorConfig()
+	if interval, err := time.ParseDuration(getEnv("LOT_EXPIRY_CHECK_INTERVAL", "")); err == nil && interval > 0 {
+		config.CheckInterval = interval
+	}
+	if days := getEnvInt("LOT_EXPIRY_WARNING_DAYS", 0); days > 0 {
+		config.WarningWindow = time.Duration(days) * 24 * time.Hour
+	}
+	return config
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// parseDaysMap parses "key:days,key:days" into a map, skipping malformed entries
+func parseDaysMap(value string) map[string]int {
+	result := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		key, days, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || key == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(days)); err == nil && n >= 0 {
+			result[key] = n
+		}
 	}
+	return result
 }
 
 func getEnv(key, defaultValue string) string {
@@ -353,11 +633,17 @@ func receiveStockHandler(service *application.InventoryApplicationService, logge
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
 		var req struct {
-			LocationID  string `json:"locationId" binding:"required"`
-			Zone        string `json:"zone"`
-			Quantity    int    `json:"quantity" binding:"required"`
-			ReferenceID string `json:"referenceId"`
-			CreatedBy   string `json:"createdBy" binding:"required"`
+			LocationID      string     `json:"locationId" binding:"required"`
+			Zone            string     `json:"zone"`
+			Quantity        int        `json:"quantity" binding:"required"`
+			ReferenceID     string     `json:"referenceId"`
+			CreatedBy       string     `json:"createdBy" binding:"required"`
+			LotNumber       string     `json:"lotNumber"`
+			ManufactureDate *time.Time `json:"manufactureDate"`
+			ExpiryDate      *time.Time `json:"expiryDate"`
+			Status          string     `json:"status"`     // Non-sellable status to receive into, e.g. customer_return
+			ReasonCode      string     `json:"reasonCode"` // Required with a non-sellable status
+			Condition       string     `json:"condition"`  // Receiving-service item condition, used when no status is given
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -371,6 +657,14 @@ func receiveStockHandler(service *application.InventoryApplicationService, logge
 			Quantity:    req.Quantity,
 			ReferenceID: req.ReferenceID,
 			CreatedBy:   req.CreatedBy,
+
+			LotNumber:       req.LotNumber,
+			ManufactureDate: req.ManufactureDate,
+			ExpiryDate:      req.ExpiryDate,
+
+			Status:     req.Status,
+			ReasonCode: req.ReasonCode,
+			Condition:  req.Condition,
 		}
 
 		item, err := service.ReceiveStock(c.Request.Context(), cmd)
@@ -395,6 +689,7 @@ func reserveHandler(service *application.InventoryApplicationService, logger *lo
 			OrderID    string `json:"orderId" binding:"required"`
 			LocationID string `json:"locationId" binding:"required"`
 			Quantity   int    `json:"quantity" binding:"required"`
+			Channel    string `json:"channel"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -406,6 +701,7 @@ func reserveHandler(service *application.InventoryApplicationService, logger *lo
 			OrderID:    req.OrderID,
 			LocationID: req.LocationID,
 			Quantity:   req.Quantity,
+			Channel:    req.Channel,
 		}
 
 		item, err := service.Reserve(c.Request.Context(), cmd)
@@ -428,6 +724,7 @@ func reserveBulkHandler(service *application.InventoryApplicationService, logger
 
 		var req struct {
 			OrderID string `json:"orderId" binding:"required"`
+			Channel string `json:"channel"`
 			Items   []struct {
 				SKU        string `json:"sku" binding:"required"`
 				Quantity   int    `json:"quantity" binding:"required"`
@@ -452,6 +749,7 @@ func reserveBulkHandler(service *application.InventoryApplicationService, logger
 
 		cmd := application.ReserveInventoryBulkCommand{
 			OrderID: req.OrderID,
+			Channel: req.Channel,
 			Items:   items,
 		}
 
@@ -473,98 +771,81 @@ func reserveBulkHandler(service *application.InventoryApplicationService, logger
 	}
 }
 
-func pickHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+func processLotExpiryHandler(service *application.InventoryApplicationService, config application.LotExpiryMonitorConfig, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
-		var req struct {
-			OrderID    string `json:"orderId" binding:"required"`
-			LocationID string `json:"locationId" binding:"required"`
-			Quantity   int    `json:"quantity" binding:"required"`
-			CreatedBy  string `json:"createdBy" binding:"required"`
-		}
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-
-		cmd := application.PickCommand{
-			SKU:        c.Param("sku"),
-			OrderID:    req.OrderID,
-			LocationID: req.LocationID,
-			Quantity:   req.Quantity,
-			CreatedBy:  req.CreatedBy,
+		cmd := application.ProcessLotExpiryCommand{
+			WarningWindow: config.WarningWindow,
+			BatchSize:     config.BatchSize,
 		}
 
-		item, err := service.Pick(c.Request.Context(), cmd)
+		result, err := service.ProcessLotExpiry(c.Request.Context(), cmd)
 		if err != nil {
-			if appErr, ok := err.(*errors.AppError); ok {
-				responder.RespondWithAppError(appErr)
-			} else {
-				responder.RespondInternalError(err)
-			}
+			responder.RespondInternalError(err)
 			return
 		}
 
-		c.JSON(http.StatusOK, item)
+		c.JSON(http.StatusOK, result)
 	}
 }
 
-func releaseReservationHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+func sweepExpiredReservationsHandler(service *application.InventoryApplicationService, config application.ReservationSweeperConfig, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
-		var req struct {
-			OrderID string `json:"orderId" binding:"required"`
+		// Manual sweeps are scoped to the caller's tenant and default to a dry run
+		cmd := application.SweepExpiredReservationsCommand{
+			TenantID:  tenant.GetTenantID(c.Request.Context()),
+			BatchSize: config.BatchSize,
+			DryRun:    c.DefaultQuery("dryRun", "true") != "false",
 		}
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+
+		result, err := service.SweepExpiredReservations(c.Request.Context(), cmd)
+		if err != nil {
+			responder.RespondInternalError(err)
 			return
 		}
 
-		cmd := application.ReleaseReservationCommand{
-			SKU:     c.Param("sku"),
-			OrderID: req.OrderID,
-		}
+		c.JSON(http.StatusOK, result)
+	}
+}
 
-		item, err := service.ReleaseReservation(c.Request.Context(), cmd)
+func scheduleCycleCountsHandler(service *application.CycleCountService, config application.CycleCountSchedulerConfig, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		result, err := service.ScheduleCycleCounts(c.Request.Context(), application.ScheduleCycleCountsCommand{
+			BatchSize: config.BatchSize,
+		})
 		if err != nil {
-			if appErr, ok := err.(*errors.AppError); ok {
-				responder.RespondWithAppError(appErr)
-			} else {
-				responder.RespondInternalError(err)
-			}
+			responder.RespondInternalError(err)
 			return
 		}
 
-		c.JSON(http.StatusOK, item)
+		c.JSON(http.StatusOK, result)
 	}
 }
 
-func adjustHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+func createCycleCountHandler(service *application.CycleCountService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
 		var req struct {
-			LocationID  string `json:"locationId" binding:"required"`
-			NewQuantity int    `json:"newQuantity" binding:"required"`
-			Reason      string `json:"reason" binding:"required"`
-			CreatedBy   string `json:"createdBy" binding:"required"`
+			SKU        string `json:"sku" binding:"required"`
+			LocationID string `json:"locationId" binding:"required"`
+			Blind      bool   `json:"blind"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		cmd := application.AdjustCommand{
-			SKU:         c.Param("sku"),
-			LocationID:  req.LocationID,
-			NewQuantity: req.NewQuantity,
-			Reason:      req.Reason,
-			CreatedBy:   req.CreatedBy,
-		}
-
-		item, err := service.Adjust(c.Request.Context(), cmd)
+		task, err := service.CreateCycleCount(c.Request.Context(), application.CreateCycleCountCommand{
+			SKU:        req.SKU,
+			LocationID: req.LocationID,
+			Blind:      req.Blind,
+		})
 		if err != nil {
 			if appErr, ok := err.(*errors.AppError); ok {
 				responder.RespondWithAppError(appErr)
@@ -574,99 +855,93 @@ func adjustHandler(service *application.InventoryApplicationService, logger *log
 			return
 		}
 
-		c.JSON(http.StatusOK, item)
+		c.JSON(http.StatusCreated, task)
 	}
 }
 
-func getByLocationHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+func listCycleCountsHandler(service *application.CycleCountService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		query := application.GetByLocationQuery{LocationID: c.Param("locationId")}
-		items, _ := service.GetByLocation(c.Request.Context(), query)
-		c.JSON(http.StatusOK, items)
-	}
-}
+		responder := middleware.NewErrorResponder(c, logger.Logger)
 
-func getByZoneHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		query := application.GetByZoneQuery{Zone: c.Param("zone")}
-		items, _ := service.GetByZone(c.Request.Context(), query)
-		c.JSON(http.StatusOK, items)
-	}
-}
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+		tasks, err := service.ListTasks(c.Request.Context(), application.ListCycleCountsQuery{
+			Status: c.Query("status"),
+			Limit:  limit,
+		})
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
 
-func getLowStockHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		items, _ := service.GetLowStock(c.Request.Context())
-		c.JSON(http.StatusOK, items)
+		c.JSON(http.StatusOK, tasks)
 	}
 }
 
-func listInventoryHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+func getCycleCountHandler(service *application.CycleCountService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
-		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+		responder := middleware.NewErrorResponder(c, logger.Logger)
 
-		query := application.ListInventoryQuery{
-			Limit:  limit,
-			Offset: offset,
+		task, err := service.GetTask(c.Request.Context(), c.Param("taskId"))
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
 		}
 
-		items, _ := service.ListInventory(c.Request.Context(), query)
-		c.JSON(http.StatusOK, items)
+		c.JSON(http.StatusOK, task)
 	}
 }
 
-func releaseByOrderHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+func submitCycleCountHandler(service *application.CycleCountService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
-		orderID := c.Param("orderId")
-		if orderID == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "orderId is required"})
-			return
+		var req struct {
+			CountedQuantity *int   `json:"countedQuantity" binding:"required"`
+			CountedBy       string `json:"countedBy" binding:"required"`
 		}
-
-		cmd := application.ReleaseByOrderCommand{
-			OrderID: orderID,
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
 
-		releasedCount, err := service.ReleaseByOrder(c.Request.Context(), cmd)
+		task, err := service.SubmitCount(c.Request.Context(), application.SubmitCycleCountCommand{
+			TaskID:          c.Param("taskId"),
+			CountedQuantity: *req.CountedQuantity,
+			CountedBy:       req.CountedBy,
+		})
 		if err != nil {
-			responder.RespondInternalError(err)
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"orderId":       orderID,
-			"releasedCount": releasedCount,
-			"message":       "Reservations released successfully",
-		})
+		c.JSON(http.StatusOK, task)
 	}
 }
 
-// stageHandler converts a soft reservation to hard allocation (physical staging)
-func stageHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+func approveCycleCountHandler(service *application.CycleCountService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
 		var req struct {
-			ReservationID     string `json:"reservationId" binding:"required"`
-			StagingLocationID string `json:"stagingLocationId" binding:"required"`
-			StagedBy          string `json:"stagedBy" binding:"required"`
+			ApprovedBy string `json:"approvedBy" binding:"required"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		cmd := application.StageCommand{
-			SKU:               c.Param("sku"),
-			ReservationID:     req.ReservationID,
-			StagingLocationID: req.StagingLocationID,
-			StagedBy:          req.StagedBy,
-		}
-
-		item, err := service.Stage(c.Request.Context(), cmd)
+		task, err := service.Approve(c.Request.Context(), application.ApproveCycleCountCommand{
+			TaskID:     c.Param("taskId"),
+			ApprovedBy: req.ApprovedBy,
+		})
 		if err != nil {
 			if appErr, ok := err.(*errors.AppError); ok {
 				responder.RespondWithAppError(appErr)
@@ -676,31 +951,28 @@ func stageHandler(service *application.InventoryApplicationService, logger *logg
 			return
 		}
 
-		c.JSON(http.StatusOK, item)
+		c.JSON(http.StatusOK, task)
 	}
 }
 
-// packHandler marks a hard allocation as packed
-func packHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+func rejectCycleCountHandler(service *application.CycleCountService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
 		var req struct {
-			AllocationID string `json:"allocationId" binding:"required"`
-			PackedBy     string `json:"packedBy" binding:"required"`
+			RejectedBy string `json:"rejectedBy" binding:"required"`
+			Reason     string `json:"reason" binding:"required"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		cmd := application.PackCommand{
-			SKU:          c.Param("sku"),
-			AllocationID: req.AllocationID,
-			PackedBy:     req.PackedBy,
-		}
-
-		item, err := service.Pack(c.Request.Context(), cmd)
+		task, err := service.Reject(c.Request.Context(), application.RejectCycleCountCommand{
+			TaskID:     c.Param("taskId"),
+			RejectedBy: req.RejectedBy,
+			Reason:     req.Reason,
+		})
 		if err != nil {
 			if appErr, ok := err.(*errors.AppError); ok {
 				responder.RespondWithAppError(appErr)
@@ -710,29 +982,31 @@ func packHandler(service *application.InventoryApplicationService, logger *loggi
 			return
 		}
 
-		c.JSON(http.StatusOK, item)
+		c.JSON(http.StatusOK, task)
 	}
 }
 
-// shipHandler ships a packed allocation (removes inventory from system)
-func shipHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+func setPickFaceLimitsHandler(service *application.ReplenishmentService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
 		var req struct {
-			AllocationID string `json:"allocationId" binding:"required"`
+			Zone        string `json:"zone"`
+			MinQuantity int    `json:"minQuantity"`
+			MaxQuantity int    `json:"maxQuantity"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
 
-		cmd := application.ShipCommand{
-			SKU:          c.Param("sku"),
-			AllocationID: req.AllocationID,
-		}
-
-		item, err := service.Ship(c.Request.Context(), cmd)
+		item, err := service.SetPickFaceLimits(c.Request.Context(), application.SetPickFaceLimitsCommand{
+			SKU:         c.Param("sku"),
+			LocationID:  c.Param("locationId"),
+			Zone:        req.Zone,
+			MinQuantity: req.MinQuantity,
+			MaxQuantity: req.MaxQuantity,
+		})
 		if err != nil {
 			if appErr, ok := err.(*errors.AppError); ok {
 				responder.RespondWithAppError(appErr)
@@ -746,17 +1020,1004 @@ func shipHandler(service *application.InventoryApplicationService, logger *loggi
 	}
 }
 
-// returnToShelfHandler returns hard allocated inventory back to shelf
-func returnToShelfHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+func generateReplenishmentsHandler(service *application.ReplenishmentService, config application.ReplenishmentMonitorConfig, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
-		var req struct {
-			AllocationID string `json:"allocationId" binding:"required"`
-			ReturnedBy   string `json:"returnedBy" binding:"required"`
-			Reason       string `json:"reason" binding:"required"`
-		}
-		if err := c.ShouldBindJSON(&req); err != nil {
+		result, err := service.GenerateTasks(c.Request.Context(), application.GenerateReplenishmentsCommand{
+			BatchSize: config.BatchSize,
+		})
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func topOffWaveHandler(service *application.ReplenishmentService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			WaveID   string   `json:"waveId" binding:"required"`
+			OrderIDs []string `json:"orderIds" binding:"required,min=1"`
+			Priority int      `json:"priority"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result, err := service.TopOffForWave(c.Request.Context(), application.TopOffWaveCommand{
+			WaveID:   req.WaveID,
+			OrderIDs: req.OrderIDs,
+			Priority: req.Priority,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func listReplenishmentsHandler(service *application.ReplenishmentService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+		tasks, err := service.ListTasks(c.Request.Context(), application.ListReplenishmentsQuery{
+			Status: c.Query("status"),
+			WaveID: c.Query("waveId"),
+			Limit:  limit,
+		})
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, tasks)
+	}
+}
+
+func getReplenishmentHandler(service *application.ReplenishmentService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		task, err := service.GetTask(c.Request.Context(), c.Param("taskId"))
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+func completeReplenishmentHandler(service *application.ReplenishmentService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			MovedQuantity *int   `json:"movedQuantity" binding:"required"`
+			CompletedBy   string `json:"completedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		task, err := service.CompleteTask(c.Request.Context(), application.CompleteReplenishmentCommand{
+			TaskID:        c.Param("taskId"),
+			MovedQuantity: *req.MovedQuantity,
+			CompletedBy:   req.CompletedBy,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+func cancelReplenishmentHandler(service *application.ReplenishmentService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			Reason string `json:"reason" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		task, err := service.CancelTask(c.Request.Context(), application.CancelReplenishmentCommand{
+			TaskID: c.Param("taskId"),
+			Reason: req.Reason,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+func setSlottingProfileHandler(service *application.SlottingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			UnitCube   float64 `json:"unitCube"`
+			UnitWeight float64 `json:"unitWeight"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		item, err := service.SetSlottingProfile(c.Request.Context(), application.SetSlottingProfileCommand{
+			SKU:        c.Param("sku"),
+			UnitCube:   req.UnitCube,
+			UnitWeight: req.UnitWeight,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+func saveSlotLocationHandler(service *application.SlottingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			Zone         string  `json:"zone" binding:"required"`
+			Aisle        string  `json:"aisle"`
+			Rack         int     `json:"rack"`
+			Level        int     `json:"level" binding:"required"`
+			CubeCapacity float64 `json:"cubeCapacity"`
+			MaxWeight    float64 `json:"maxWeight"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		slot, err := service.SaveSlotLocation(c.Request.Context(), application.SaveSlotLocationCommand{
+			LocationID:   c.Param("locationId"),
+			Zone:         req.Zone,
+			Aisle:        req.Aisle,
+			Rack:         req.Rack,
+			Level:        req.Level,
+			CubeCapacity: req.CubeCapacity,
+			MaxWeight:    req.MaxWeight,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, slot)
+	}
+}
+
+func listSlotLocationsHandler(service *application.SlottingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		slots, err := service.ListSlotLocations(c.Request.Context(), c.Query("zone"))
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, slots)
+	}
+}
+
+func generateSlottingHandler(service *application.SlottingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		maxMoves, _ := strconv.Atoi(c.DefaultQuery("maxMoves", "0"))
+		result, err := service.GenerateRecommendations(c.Request.Context(), application.GenerateSlottingCommand{
+			Zone:     c.Query("zone"),
+			MaxMoves: maxMoves,
+		})
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func listSlottingHandler(service *application.SlottingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+		recs, err := service.ListRecommendations(c.Request.Context(), application.ListSlottingQuery{
+			Status: c.Query("status"),
+			Limit:  limit,
+		})
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, recs)
+	}
+}
+
+func getSlottingHandler(service *application.SlottingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		rec, err := service.GetRecommendation(c.Request.Context(), c.Param("recommendationId"))
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, rec)
+	}
+}
+
+func approveSlottingHandler(service *application.SlottingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			ApprovedBy string `json:"approvedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		rec, err := service.Approve(c.Request.Context(), application.ApproveSlottingCommand{
+			RecommendationID: c.Param("recommendationId"),
+			ApprovedBy:       req.ApprovedBy,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, rec)
+	}
+}
+
+func rejectSlottingHandler(service *application.SlottingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			RejectedBy string `json:"rejectedBy" binding:"required"`
+			Reason     string `json:"reason" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		rec, err := service.Reject(c.Request.Context(), application.RejectSlottingCommand{
+			RecommendationID: c.Param("recommendationId"),
+			RejectedBy:       req.RejectedBy,
+			Reason:           req.Reason,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, rec)
+	}
+}
+
+func completeSlottingMoveHandler(service *application.SlottingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			MovedBy string `json:"movedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		rec, err := service.CompleteMove(c.Request.Context(), application.CompleteSlottingMoveCommand{
+			RecommendationID: c.Param("recommendationId"),
+			MovedBy:          req.MovedBy,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, rec)
+	}
+}
+
+func cancelSlottingMoveHandler(service *application.SlottingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			Reason string `json:"reason" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		rec, err := service.CancelMove(c.Request.Context(), application.CancelSlottingMoveCommand{
+			RecommendationID: c.Param("recommendationId"),
+			Reason:           req.Reason,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, rec)
+	}
+}
+
+func changeStatusHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			LocationID  string `json:"locationId" binding:"required"`
+			LotNumber   string `json:"lotNumber"`
+			Quantity    int    `json:"quantity" binding:"required"`
+			FromStatus  string `json:"fromStatus" binding:"required"`
+			ToStatus    string `json:"toStatus" binding:"required"`
+			ReasonCode  string `json:"reasonCode" binding:"required"`
+			ReferenceID string `json:"referenceId"`
+			ChangedBy   string `json:"changedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		item, err := service.ChangeStatus(c.Request.Context(), application.ChangeInventoryStatusCommand{
+			SKU:         c.Param("sku"),
+			LocationID:  req.LocationID,
+			LotNumber:   req.LotNumber,
+			Quantity:    req.Quantity,
+			FromStatus:  req.FromStatus,
+			ToStatus:    req.ToStatus,
+			ReasonCode:  req.ReasonCode,
+			ReferenceID: req.ReferenceID,
+			ChangedBy:   req.ChangedBy,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+func transferHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			FromLocationID string   `json:"fromLocationId" binding:"required"`
+			ToLocationID   string   `json:"toLocationId" binding:"required"`
+			ToZone         string   `json:"toZone"`
+			Quantity       int      `json:"quantity" binding:"required"`
+			LotNumber      string   `json:"lotNumber"`
+			Status         string   `json:"status"`
+			OrderIDs       []string `json:"orderIds"`
+			Reason         string   `json:"reason"`
+			ReferenceID    string   `json:"referenceId"`
+			MovedBy        string   `json:"movedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		item, err := service.Transfer(c.Request.Context(), application.TransferStockCommand{
+			SKU:            c.Param("sku"),
+			FromLocationID: req.FromLocationID,
+			ToLocationID:   req.ToLocationID,
+			ToZone:         req.ToZone,
+			Quantity:       req.Quantity,
+			LotNumber:      req.LotNumber,
+			Status:         req.Status,
+			OrderIDs:       req.OrderIDs,
+			Reason:         req.Reason,
+			ReferenceID:    req.ReferenceID,
+			MovedBy:        req.MovedBy,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+func startMoveHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			FromLocationID string `json:"fromLocationId" binding:"required"`
+			ToLocationID   string `json:"toLocationId"`
+			ToZone         string `json:"toZone"`
+			ContainerID    string `json:"containerId" binding:"required"`
+			Quantity       int    `json:"quantity" binding:"required"`
+			LotNumber      string `json:"lotNumber"`
+			Status         string `json:"status"`
+			Reason         string `json:"reason"`
+			ReferenceID    string `json:"referenceId"`
+			MovedBy        string `json:"movedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		item, moveID, err := service.StartMove(c.Request.Context(), application.StartMoveCommand{
+			SKU:            c.Param("sku"),
+			FromLocationID: req.FromLocationID,
+			ToLocationID:   req.ToLocationID,
+			ToZone:         req.ToZone,
+			ContainerID:    req.ContainerID,
+			Quantity:       req.Quantity,
+			LotNumber:      req.LotNumber,
+			Status:         req.Status,
+			Reason:         req.Reason,
+			ReferenceID:    req.ReferenceID,
+			MovedBy:        req.MovedBy,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"moveId": moveID, "item": item})
+	}
+}
+
+func dropMoveHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			ToLocationID string `json:"toLocationId"`
+			ToZone       string `json:"toZone"`
+			DroppedBy    string `json:"droppedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		item, err := service.DropMove(c.Request.Context(), application.DropMoveCommand{
+			SKU:          c.Param("sku"),
+			MoveID:       c.Param("moveId"),
+			ToLocationID: req.ToLocationID,
+			ToZone:       req.ToZone,
+			DroppedBy:    req.DroppedBy,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+func moveContainerHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			ToLocationID string `json:"toLocationId" binding:"required"`
+			ToZone       string `json:"toZone"`
+			Reason       string `json:"reason"`
+			ReferenceID  string `json:"referenceId"`
+			MovedBy      string `json:"movedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result, err := service.MoveContainer(c.Request.Context(), application.MoveContainerCommand{
+			LocationID:   c.Param("locationId"),
+			ToLocationID: req.ToLocationID,
+			ToZone:       req.ToZone,
+			Reason:       req.Reason,
+			ReferenceID:  req.ReferenceID,
+			MovedBy:      req.MovedBy,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func placeHoldHandler(service *application.InventoryHoldService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			Scope      string `json:"scope" binding:"required"`
+			SKU        string `json:"sku"`
+			LotNumber  string `json:"lotNumber"`
+			LocationID string `json:"locationId"`
+			ReasonCode string `json:"reasonCode" binding:"required"`
+			Notes      string `json:"notes"`
+			PlacedBy   string `json:"placedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		hold, err := service.PlaceHold(c.Request.Context(), application.PlaceHoldCommand{
+			Scope:      req.Scope,
+			SKU:        req.SKU,
+			LotNumber:  req.LotNumber,
+			LocationID: req.LocationID,
+			ReasonCode: req.ReasonCode,
+			Notes:      req.Notes,
+			PlacedBy:   req.PlacedBy,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusCreated, hold)
+	}
+}
+
+func listHoldsHandler(service *application.InventoryHoldService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
+		holds, err := service.ListHolds(c.Request.Context(), application.ListHoldsQuery{
+			Status: c.Query("status"),
+			Limit:  limit,
+		})
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, holds)
+	}
+}
+
+func getHoldHandler(service *application.InventoryHoldService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		hold, err := service.GetHold(c.Request.Context(), c.Param("holdId"))
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, hold)
+	}
+}
+
+func releaseHoldHandler(service *application.InventoryHoldService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			ReleasedBy string `json:"releasedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		hold, err := service.ReleaseHold(c.Request.Context(), application.ReleaseHoldCommand{
+			HoldID:     c.Param("holdId"),
+			ReleasedBy: req.ReleasedBy,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, hold)
+	}
+}
+
+func pickHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			OrderID    string `json:"orderId" binding:"required"`
+			LocationID string `json:"locationId" binding:"required"`
+			Quantity   int    `json:"quantity" binding:"required"`
+			CreatedBy  string `json:"createdBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.PickCommand{
+			SKU:        c.Param("sku"),
+			OrderID:    req.OrderID,
+			LocationID: req.LocationID,
+			Quantity:   req.Quantity,
+			CreatedBy:  req.CreatedBy,
+		}
+
+		item, err := service.Pick(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+func releaseReservationHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			OrderID string `json:"orderId" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.ReleaseReservationCommand{
+			SKU:     c.Param("sku"),
+			OrderID: req.OrderID,
+		}
+
+		item, err := service.ReleaseReservation(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+func adjustHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			LocationID  string `json:"locationId" binding:"required"`
+			NewQuantity int    `json:"newQuantity" binding:"required"`
+			Reason      string `json:"reason" binding:"required"`
+			CreatedBy   string `json:"createdBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.AdjustCommand{
+			SKU:         c.Param("sku"),
+			LocationID:  req.LocationID,
+			NewQuantity: req.NewQuantity,
+			Reason:      req.Reason,
+			CreatedBy:   req.CreatedBy,
+		}
+
+		item, err := service.Adjust(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+func getByLocationHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := application.GetByLocationQuery{LocationID: c.Param("locationId")}
+		items, _ := service.GetByLocation(c.Request.Context(), query)
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+func getByZoneHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := application.GetByZoneQuery{Zone: c.Param("zone")}
+		items, _ := service.GetByZone(c.Request.Context(), query)
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+func getLowStockHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, _ := service.GetLowStock(c.Request.Context())
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+func listInventoryHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+		offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+		query := application.ListInventoryQuery{
+			Limit:  limit,
+			Offset: offset,
+		}
+
+		items, _ := service.ListInventory(c.Request.Context(), query)
+		c.JSON(http.StatusOK, items)
+	}
+}
+
+func releaseByOrderHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		orderID := c.Param("orderId")
+		if orderID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "orderId is required"})
+			return
+		}
+
+		cmd := application.ReleaseByOrderCommand{
+			OrderID: orderID,
+		}
+
+		releasedCount, err := service.ReleaseByOrder(c.Request.Context(), cmd)
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"orderId":       orderID,
+			"releasedCount": releasedCount,
+			"message":       "Reservations released successfully",
+		})
+	}
+}
+
+// stageHandler converts a soft reservation to hard allocation (physical staging)
+func stageHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			ReservationID     string `json:"reservationId" binding:"required"`
+			StagingLocationID string `json:"stagingLocationId" binding:"required"`
+			StagedBy          string `json:"stagedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.StageCommand{
+			SKU:               c.Param("sku"),
+			ReservationID:     req.ReservationID,
+			StagingLocationID: req.StagingLocationID,
+			StagedBy:          req.StagedBy,
+		}
+
+		item, err := service.Stage(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+// packHandler marks a hard allocation as packed
+func packHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			AllocationID string `json:"allocationId" binding:"required"`
+			PackedBy     string `json:"packedBy" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.PackCommand{
+			SKU:          c.Param("sku"),
+			AllocationID: req.AllocationID,
+			PackedBy:     req.PackedBy,
+		}
+
+		item, err := service.Pack(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+// shipHandler ships a packed allocation (removes inventory from system)
+func shipHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			AllocationID string `json:"allocationId" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.ShipCommand{
+			SKU:          c.Param("sku"),
+			AllocationID: req.AllocationID,
+		}
+
+		item, err := service.Ship(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
+// returnToShelfHandler returns hard allocated inventory back to shelf
+func returnToShelfHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			AllocationID string `json:"allocationId" binding:"required"`
+			ReturnedBy   string `json:"returnedBy" binding:"required"`
+			Reason       string `json:"reason" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
@@ -782,6 +2043,46 @@ func returnToShelfHandler(service *application.InventoryApplicationService, logg
 	}
 }
 
+// setCustomsProfileHandler sets the customs data used for international shipments of a SKU
+func setCustomsProfileHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			HSCode          string  `json:"hsCode" binding:"required"`
+			CountryOfOrigin string  `json:"countryOfOrigin" binding:"required"`
+			Description     string  `json:"description" binding:"required"`
+			DeclaredValue   float64 `json:"declaredValue"`
+			Currency        string  `json:"currency"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.SetCustomsProfileCommand{
+			SKU:             c.Param("sku"),
+			HSCode:          req.HSCode,
+			CountryOfOrigin: req.CountryOfOrigin,
+			Description:     req.Description,
+			DeclaredValue:   req.DeclaredValue,
+			Currency:        req.Currency,
+		}
+
+		item, err := service.SetCustomsProfile(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, item)
+	}
+}
+
 // recordShortageHandler records a confirmed stock shortage discovered during picking
 func recordShortageHandler(service *application.InventoryApplicationService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -1,5 +1,7 @@
 package application
 
+import "time"
+
 // CreateItemCommand represents the command to create a new inventory item
 type CreateItemCommand struct {
 	SKU             string
@@ -18,6 +20,17 @@ type ReceiveStockCommand struct {
 	CreatedBy   string
 	UnitCost    int64  // Unit cost in cents (for ledger tracking)
 	Currency    string // ISO 4217 currency code (default: USD)
+
+	// Lot tracking (optional)
+	LotNumber       string
+	ManufactureDate *time.Time
+	ExpiryDate      *time.Time
+
+	// Inventory status (optional): stock is received as available unless a non-sellable
+	// status is given or follows from the receiving-service item condition
+	Status     string
+	ReasonCode string
+	Condition  string // good, damaged, needs_prep, prepped
 }
 
 // ReserveCommand represents the command to reserve stock
@@ -26,6 +39,7 @@ type ReserveCommand struct {
 	OrderID    string
 	LocationID string
 	Quantity   int
+	Channel    string // Sales channel, used for minimum remaining shelf life
 }
 
 // PickCommand represents the command to pick stock
@@ -57,6 +71,16 @@ type AdjustCommand struct {
 	CreatedBy   string
 }
 
+// SetCustomsProfileCommand represents the command to set a SKU's customs data
+type SetCustomsProfileCommand struct {
+	SKU             string
+	HSCode          string
+	CountryOfOrigin string
+	Description     string
+	DeclaredValue   float64 // per unit
+	Currency        string
+}
+
 // GetItemQuery represents the query to get an item by SKU
 type GetItemQuery struct {
 	SKU string
@@ -138,6 +162,7 @@ type RecordShortageCommand struct {
 // ReserveInventoryBulkCommand represents the command to reserve multiple items atomically
 type ReserveInventoryBulkCommand struct {
 	OrderID string
+	Channel string // Sales channel, used for minimum remaining shelf life
 	Items   []ReserveInventoryBulkItem
 }
 
@@ -147,3 +172,235 @@ type ReserveInventoryBulkItem struct {
 	Quantity   int
 	LocationID string
 }
+
+// ProcessLotExpiryCommand represents the command to block expired lots and warn about expiring lots
+type ProcessLotExpiryCommand struct {
+	WarningWindow time.Duration
+	BatchSize     int
+}
+
+// SweepExpiredReservationsCommand represents the command to release reservations past their expiry
+type SweepExpiredReservationsCommand struct {
+	TenantID  string // Optional: restrict the sweep to a single tenant
+	BatchSize int
+	DryRun    bool // Report what would be released without changing anything
+}
+
+// ScheduleCycleCountsCommand represents the command to create count tasks for items due by velocity class
+type ScheduleCycleCountsCommand struct {
+	BatchSize int
+}
+
+// CreateCycleCountCommand represents the command to create an ad-hoc count task for a location
+type CreateCycleCountCommand struct {
+	SKU        string
+	LocationID string
+	Blind      bool
+}
+
+// SubmitCycleCountCommand represents the command to record a physical count
+type SubmitCycleCountCommand struct {
+	TaskID          string
+	CountedQuantity int
+	CountedBy       string
+}
+
+// ApproveCycleCountCommand represents the command for a supervisor to approve and post a count
+type ApproveCycleCountCommand struct {
+	TaskID     string
+	ApprovedBy string
+}
+
+// RejectCycleCountCommand represents the command for a supervisor to reject a count
+type RejectCycleCountCommand struct {
+	TaskID     string
+	RejectedBy string
+	Reason     string
+}
+
+// ListCycleCountsQuery represents the query to list cycle count tasks
+type ListCycleCountsQuery struct {
+	Status string // Empty for all open tasks
+	Limit  int
+}
+
+// SetPickFaceLimitsCommand represents the command to set the min/max of a forward pick location
+type SetPickFaceLimitsCommand struct {
+	SKU         string
+	LocationID  string
+	Zone        string
+	MinQuantity int
+	MaxQuantity int // 0 turns the location back into reserve storage
+}
+
+// GenerateReplenishmentsCommand represents the command to create tasks for pick faces below their minimum
+type GenerateReplenishmentsCommand struct {
+	BatchSize int
+}
+
+// TopOffWaveCommand represents the command to top off pick faces for a released wave
+type TopOffWaveCommand struct {
+	WaveID   string
+	OrderIDs []string
+	Priority int // The wave's priority, 1 = highest
+}
+
+// CompleteReplenishmentCommand represents the command to confirm stock moved to the pick face
+type CompleteReplenishmentCommand struct {
+	TaskID        string
+	MovedQuantity int
+	CompletedBy   string
+}
+
+// CancelReplenishmentCommand represents the command to cancel a replenishment task
+type CancelReplenishmentCommand struct {
+	TaskID string
+	Reason string
+}
+
+// ListReplenishmentsQuery represents the query to list replenishment tasks
+type ListReplenishmentsQuery struct {
+	Status string // Empty for pending tasks
+	WaveID string
+	Limit  int
+}
+
+// SetSlottingProfileCommand represents the command to set a SKU's unit cube and weight
+type SetSlottingProfileCommand struct {
+	SKU        string
+	UnitCube   float64 // cm³
+	UnitWeight float64 // kg
+}
+
+// SaveSlotLocationCommand represents the command to register a storage slot and its size limits
+type SaveSlotLocationCommand struct {
+	LocationID   string
+	Zone         string
+	Aisle        string
+	Rack         int
+	Level        int
+	CubeCapacity float64 // cm³, 0 = unknown
+	MaxWeight    float64 // kg, 0 = unknown
+}
+
+// GenerateSlottingCommand represents the command to score current slots and recommend re-slots
+type GenerateSlottingCommand struct {
+	Zone     string // Empty for all zones
+	MaxMoves int    // 0 uses the configured cap
+}
+
+// ApproveSlottingCommand represents the command to approve a recommendation and generate its move task
+type ApproveSlottingCommand struct {
+	RecommendationID string
+	ApprovedBy       string
+}
+
+// RejectSlottingCommand represents the command to decline a recommendation
+type RejectSlottingCommand struct {
+	RecommendationID string
+	RejectedBy       string
+	Reason           string
+}
+
+// CompleteSlottingMoveCommand represents the command to confirm the stock moved to the new slot
+type CompleteSlottingMoveCommand struct {
+	RecommendationID string
+	MovedBy          string
+}
+
+// CancelSlottingMoveCommand represents the command to withdraw an approved move task
+type CancelSlottingMoveCommand struct {
+	RecommendationID string
+	Reason           string
+}
+
+// ListSlottingQuery represents the query to list slotting recommendations
+type ListSlottingQuery struct {
+	Status string // Empty for pending recommendations
+	Limit  int
+}
+
+// ChangeInventoryStatusCommand represents the command to move stock between inventory statuses
+type ChangeInventoryStatusCommand struct {
+	SKU         string
+	LocationID  string
+	LotNumber   string // Lot-tracked stock only
+	Quantity    int
+	FromStatus  string
+	ToStatus    string
+	ReasonCode  string
+	ReferenceID string // Problem ticket, RMA, inspection, etc.
+	ChangedBy   string
+}
+
+// PlaceHoldCommand represents the command to put stock on hold by SKU, lot or location
+type PlaceHoldCommand struct {
+	Scope      string // sku, lot, location
+	SKU        string
+	LotNumber  string
+	LocationID string
+	ReasonCode string
+	Notes      string
+	PlacedBy   string
+}
+
+// ReleaseHoldCommand represents the command to return the stock of a hold to available
+type ReleaseHoldCommand struct {
+	HoldID     string
+	ReleasedBy string
+}
+
+// ListHoldsQuery represents the query to list inventory holds
+type ListHoldsQuery struct {
+	Status string // Empty for active holds
+	Limit  int
+}
+
+// TransferStockCommand represents the command to move stock between two locations in one step
+type TransferStockCommand struct {
+	SKU            string
+	FromLocationID string
+	ToLocationID   string
+	ToZone         string // Only needed when the SKU has no stock at the destination yet
+	Quantity       int
+	LotNumber      string   // Moves a single lot
+	Status         string   // Status of the stock to move, available when empty
+	OrderIDs       []string // Orders whose reservations at the source move along
+	Reason         string
+	ReferenceID    string
+	MovedBy        string
+}
+
+// StartMoveCommand represents the command to pick stock onto a cart or tote for a two-step move
+type StartMoveCommand struct {
+	SKU            string
+	FromLocationID string
+	ToLocationID   string // Planned destination, optional
+	ToZone         string
+	ContainerID    string
+	Quantity       int
+	LotNumber      string
+	Status         string
+	Reason         string
+	ReferenceID    string
+	MovedBy        string
+}
+
+// DropMoveCommand represents the command to put the stock of a two-step move at its destination
+type DropMoveCommand struct {
+	SKU          string
+	MoveID       string
+	ToLocationID string // Defaults to the destination planned when the move started
+	ToZone       string
+	DroppedBy    string
+}
+
+// MoveContainerCommand represents the command to move all contents of an LPN or tote
+type MoveContainerCommand struct {
+	LocationID   string // The LPN or tote, as a location
+	ToLocationID string
+	ToZone       string
+	Reason       string
+	ReferenceID  string
+	MovedBy      string
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+// CycleCountScheduler periodically creates count tasks for items due by velocity class
+type CycleCountScheduler struct {
+	service  *CycleCountService
+	config   CycleCountSchedulerConfig
+	logger   *logging.Logger
+	mu       sync.RWMutex
+	running  bool
+	stopChan chan struct{}
+}
+
+// CycleCountSchedulerConfig configuration for the cycle count scheduler
+type CycleCountSchedulerConfig struct {
+	// CheckInterval is how often to look for items due for a count
+	CheckInterval time.Duration `json:"checkInterval"`
+
+	// BatchSize is the maximum number of items scheduled per check
+	BatchSize int `json:"batchSize"`
+}
+
+// DefaultCycleCountSchedulerConfig returns default configuration
+func DefaultCycleCountSchedulerConfig() CycleCountSchedulerConfig {
+	return CycleCountSchedulerConfig{
+		CheckInterval: 6 * time.Hour,
+		BatchSize:     500,
+	}
+}
+
+// NewCycleCountScheduler creates a new cycle count scheduler
+func NewCycleCountScheduler(
+	service *CycleCountService,
+	config CycleCountSchedulerConfig,
+	logger *logging.Logger,
+) *CycleCountScheduler {
+	return &CycleCountScheduler{
+		service:  service,
+		config:   config,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins periodic cycle count scheduling
+func (s *CycleCountScheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return fmt.Errorf("cycle count scheduler is already running")
+	}
+	s.running = true
+	s.stopChan = make(chan struct{})
+	s.mu.Unlock()
+
+	go s.run(ctx)
+	return nil
+}
+
+// Stop stops periodic cycle count scheduling
+func (s *CycleCountScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		close(s.stopChan)
+		s.running = false
+	}
+}
+
+// IsRunning returns whether the scheduler is running
+func (s *CycleCountScheduler) IsRunning() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running
+}
+
+// run is the main loop for the cycle count scheduler
+func (s *CycleCountScheduler) run(ctx context.Context) {
+	// Schedule once at startup so due counts are not delayed by a full interval
+	s.schedule(ctx)
+
+	ticker := time.NewTicker(s.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			s.schedule(ctx)
+		}
+	}
+}
+
+func (s *CycleCountScheduler) schedule(ctx context.Context) {
+	cmd := ScheduleCycleCountsCommand{BatchSize: s.config.BatchSize}
+	if _, err := s.service.ScheduleCycleCounts(ctx, cmd); err != nil {
+		s.logger.Error("Cycle count scheduling failed", "error", err)
+	}
+}
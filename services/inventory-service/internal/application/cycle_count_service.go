@@ -0,0 +1,340 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+// defaultCycleCountListLimit caps task listings when no limit is given
+const defaultCycleCountListLimit = 100
+
+// CycleCountService handles cycle count use cases: scheduling count tasks by velocity
+// class, recording counts, supervisor approval and posting variances to stock and the ledger
+type CycleCountService struct {
+	repo      domain.CycleCountRepository
+	inventory *InventoryApplicationService
+	policy    domain.CycleCountPolicy
+	logger    *logging.Logger
+}
+
+// NewCycleCountService creates a new CycleCountService
+func NewCycleCountService(
+	repo domain.CycleCountRepository,
+	inventory *InventoryApplicationService,
+	policy domain.CycleCountPolicy,
+	logger *logging.Logger,
+) *CycleCountService {
+	return &CycleCountService{
+		repo:      repo,
+		inventory: inventory,
+		policy:    policy,
+		logger:    logger,
+	}
+}
+
+// ScheduleCycleCounts creates count tasks for every location of items whose count is due
+// for their velocity class. Locations that already have an open task are skipped.
+func (s *CycleCountService) ScheduleCycleCounts(ctx context.Context, cmd ScheduleCycleCountsCommand) (*CycleCountScheduleResultDTO, error) {
+	now := time.Now()
+	items, err := s.inventory.repo.FindDueForCycleCount(ctx, s.policy.DueCutoffs(now), cmd.BatchSize)
+	if err != nil {
+		s.logger.Error("Failed to find items due for cycle count", "error", err)
+		return nil, fmt.Errorf("failed to find items due for cycle count: %w", err)
+	}
+
+	result := &CycleCountScheduleResultDTO{ItemsChecked: len(items)}
+	for _, item := range items {
+		open, err := s.openLocations(ctx, item.SKU)
+		if err != nil {
+			s.logger.Error("Failed to find open cycle counts", "sku", item.SKU, "error", err)
+			result.Failed++
+			continue
+		}
+
+		for _, loc := range item.Locations {
+			if open[loc.LocationID] {
+				continue
+			}
+			task, err := domain.NewCycleCountTask(item, loc.LocationID, s.policy.BlindCounts, now)
+			if err != nil {
+				result.Failed++
+				continue
+			}
+			if err := s.repo.Save(ctx, task); err != nil {
+				s.logger.Error("Failed to save cycle count task", "sku", item.SKU, "locationId", loc.LocationID, "error", err)
+				result.Failed++
+				continue
+			}
+			result.TasksCreated++
+		}
+	}
+
+	if result.TasksCreated > 0 || result.Failed > 0 {
+		s.logger.Info("Scheduled cycle counts",
+			"itemsChecked", result.ItemsChecked,
+			"tasksCreated", result.TasksCreated,
+			"failed", result.Failed,
+		)
+	}
+
+	return result, nil
+}
+
+// CreateCycleCount creates an ad-hoc count task for one location of an item
+func (s *CycleCountService) CreateCycleCount(ctx context.Context, cmd CreateCycleCountCommand) (*CycleCountTaskDTO, error) {
+	item, err := s.getItem(ctx, cmd.SKU)
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.openLocations(ctx, cmd.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open cycle counts: %w", err)
+	}
+	if open[cmd.LocationID] {
+		return nil, errors.ErrConflict(domain.ErrCycleCountAlreadyOpen.Error())
+	}
+
+	task, err := domain.NewCycleCountTask(item, cmd.LocationID, cmd.Blind, time.Now())
+	if err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	if err := s.repo.Save(ctx, task); err != nil {
+		s.logger.Error("Failed to save cycle count task", "sku", cmd.SKU, "error", err)
+		return nil, fmt.Errorf("failed to save cycle count task: %w", err)
+	}
+
+	s.logger.Info("Created cycle count", "taskId", task.TaskID, "sku", cmd.SKU, "locationId", cmd.LocationID, "blind", cmd.Blind)
+	return toCycleCountTaskDTO(task, s.expectedQuantity(item, task)), nil
+}
+
+// SubmitCount records a physical count. The variance is measured against the shelf quantity
+// at the time of counting and valued at the ledger's average unit cost. Counts that need no
+// recount or approval are posted immediately.
+func (s *CycleCountService) SubmitCount(ctx context.Context, cmd SubmitCycleCountCommand) (*CycleCountTaskDTO, error) {
+	task, err := s.getTask(ctx, cmd.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.getItem(ctx, task.SKU)
+	if err != nil {
+		return nil, err
+	}
+	loc := item.GetLocationStock(task.LocationID)
+	if loc == nil {
+		return nil, errors.ErrValidation(domain.ErrLocationNotFound.Error())
+	}
+
+	unitCost := s.inventory.unitCost(ctx, item)
+	if err := task.SubmitCount(cmd.CountedQuantity, loc.ShelfQuantity(), cmd.CountedBy, unitCost, s.policy); err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	if err := s.saveTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Recorded cycle count",
+		"taskId", task.TaskID,
+		"sku", task.SKU,
+		"locationId", task.LocationID,
+		"status", task.Status,
+		"countedBy", cmd.CountedBy,
+	)
+
+	if task.Status == domain.CycleCountStatusApproved {
+		if err := s.post(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+	return toCycleCountTaskDTO(task, s.expectedQuantity(item, task)), nil
+}
+
+// Approve approves a count held for a supervisor and posts it. An approved task whose
+// posting failed earlier is posted again.
+func (s *CycleCountService) Approve(ctx context.Context, cmd ApproveCycleCountCommand) (*CycleCountTaskDTO, error) {
+	task, err := s.getTask(ctx, cmd.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Status != domain.CycleCountStatusApproved {
+		if err := task.Approve(cmd.ApprovedBy); err != nil {
+			return nil, errors.ErrValidation(err.Error())
+		}
+		if err := s.saveTask(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.post(ctx, task); err != nil {
+		return nil, err
+	}
+	return toCycleCountTaskDTO(task, nil), nil
+}
+
+// Reject discards a count held for a supervisor without changing stock
+func (s *CycleCountService) Reject(ctx context.Context, cmd RejectCycleCountCommand) (*CycleCountTaskDTO, error) {
+	task, err := s.getTask(ctx, cmd.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := task.Reject(cmd.RejectedBy, cmd.Reason); err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	if err := s.saveTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Rejected cycle count", "taskId", task.TaskID, "rejectedBy", cmd.RejectedBy, "reason", cmd.Reason)
+	return toCycleCountTaskDTO(task, nil), nil
+}
+
+// GetTask retrieves a cycle count task
+func (s *CycleCountService) GetTask(ctx context.Context, taskID string) (*CycleCountTaskDTO, error) {
+	task, err := s.getTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var expected *int
+	if !task.Blind && task.Status.IsOpen() {
+		if item, err := s.inventory.repo.FindBySKU(ctx, task.SKU); err == nil && item != nil {
+			expected = s.expectedQuantity(item, task)
+		}
+	}
+	return toCycleCountTaskDTO(task, expected), nil
+}
+
+// ListTasks lists cycle count tasks by status, oldest due first
+func (s *CycleCountService) ListTasks(ctx context.Context, query ListCycleCountsQuery) ([]CycleCountTaskDTO, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultCycleCountListLimit
+	}
+
+	tasks, err := s.repo.FindByStatus(ctx, domain.CycleCountStatus(query.Status), limit)
+	if err != nil {
+		s.logger.Error("Failed to list cycle count tasks", "status", query.Status, "error", err)
+		return nil, fmt.Errorf("failed to list cycle count tasks: %w", err)
+	}
+
+	dtos := make([]CycleCountTaskDTO, 0, len(tasks))
+	for _, task := range tasks {
+		dtos = append(dtos, *toCycleCountTaskDTO(task, nil))
+	}
+	return dtos, nil
+}
+
+// post applies an approved task's variance to stock and the ledger, then marks it posted.
+// Stock is posted before the task is saved; if the save fails, posting again does not
+// apply the variance twice. A task changed concurrently is reloaded, and left alone when
+// another request posted it in the meantime.
+func (s *CycleCountService) post(ctx context.Context, task *domain.CycleCountTask) error {
+	transactionID, err := s.inventory.PostCycleCount(ctx, task)
+	if err != nil {
+		s.logger.Error("Failed to post cycle count", "taskId", task.TaskID, "sku", task.SKU, "error", err)
+		return err
+	}
+
+	err = resilience.RetryOnConflict(ctx, func() error {
+		if task.Status == domain.CycleCountStatusPosted {
+			return nil
+		}
+		if err := task.MarkPosted(transactionID); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		err := s.repo.Save(ctx, task)
+		if errors.IsConcurrencyConflict(err) {
+			s.logger.Debug("Cycle count task changed concurrently, retrying", "taskId", task.TaskID)
+			current, findErr := s.getTask(ctx, task.TaskID)
+			if findErr != nil {
+				return findErr
+			}
+			*task = *current
+		}
+		return err
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return errors.ErrConflict(fmt.Sprintf("cycle count task %s was modified concurrently, please retry", task.TaskID)).Wrap(err)
+	}
+	if err != nil {
+		s.logger.Error("Failed to save posted cycle count", "taskId", task.TaskID, "error", err)
+		return fmt.Errorf("failed to save cycle count task: %w", err)
+	}
+
+	s.logger.Info("Posted cycle count",
+		"taskId", task.TaskID,
+		"sku", task.SKU,
+		"locationId", task.LocationID,
+		"variance", task.Variance,
+		"ledgerTransactionId", transactionID,
+	)
+	return nil
+}
+
+// openLocations returns the locations of an item that already have an open count task
+func (s *CycleCountService) openLocations(ctx context.Context, sku string) (map[string]bool, error) {
+	tasks, err := s.repo.FindOpenBySKU(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	open := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		open[task.LocationID] = true
+	}
+	return open, nil
+}
+
+// expectedQuantity returns the shelf quantity a counter of a non-blind task should find
+func (s *CycleCountService) expectedQuantity(item *domain.InventoryItem, task *domain.CycleCountTask) *int {
+	loc := item.GetLocationStock(task.LocationID)
+	if loc == nil {
+		return nil
+	}
+	quantity := loc.ShelfQuantity()
+	return &quantity
+}
+
+// saveTask saves a task, reporting a concurrent change to it as a conflict
+func (s *CycleCountService) saveTask(ctx context.Context, task *domain.CycleCountTask) error {
+	if err := s.repo.Save(ctx, task); err != nil {
+		if errors.IsConcurrencyConflict(err) {
+			return errors.ErrConflict(fmt.Sprintf("cycle count task %s was modified concurrently, please retry", task.TaskID)).Wrap(err)
+		}
+		s.logger.Error("Failed to save cycle count task", "taskId", task.TaskID, "error", err)
+		return fmt.Errorf("failed to save cycle count task: %w", err)
+	}
+	return nil
+}
+
+func (s *CycleCountService) getTask(ctx context.Context, taskID string) (*domain.CycleCountTask, error) {
+	task, err := s.repo.FindByID(ctx, taskID)
+	if err != nil {
+		s.logger.Error("Failed to get cycle count task", "taskId", taskID, "error", err)
+		return nil, fmt.Errorf("failed to get cycle count task: %w", err)
+	}
+	if task == nil {
+		return nil, errors.ErrNotFound("cycle count task")
+	}
+	return task, nil
+}
+
+func (s *CycleCountService) getItem(ctx context.Context, sku string) (*domain.InventoryItem, error) {
+	item, err := s.inventory.repo.FindBySKU(ctx, sku)
+	if err != nil {
+		s.logger.Error("Failed to get item", "sku", sku, "error", err)
+		return nil, fmt.Errorf("failed to get item: %w", err)
+	}
+	if item == nil {
+		return nil, errors.ErrNotFound("item")
+	}
+	return item, nil
+}
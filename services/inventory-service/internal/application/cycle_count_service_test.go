@@ -0,0 +1,336 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+type fakeCycleCountRepo struct {
+	tasks    map[string]*domain.CycleCountTask
+	saveErrs []error // Returned by the next saves in turn
+}
+
+func (f *fakeCycleCountRepo) Save(ctx context.Context, task *domain.CycleCountTask) error {
+	if len(f.saveErrs) > 0 {
+		err := f.saveErrs[0]
+		f.saveErrs = f.saveErrs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	if f.tasks == nil {
+		f.tasks = make(map[string]*domain.CycleCountTask)
+	}
+	task.PullEvents()
+	stored := *task
+	f.tasks[task.TaskID] = &stored
+	return nil
+}
+
+func (f *fakeCycleCountRepo) FindByID(ctx context.Context, taskID string) (*domain.CycleCountTask, error) {
+	task, ok := f.tasks[taskID]
+	if !ok {
+		return nil, nil
+	}
+	loaded := *task
+	return &loaded, nil
+}
+
+func (f *fakeCycleCountRepo) FindOpenBySKU(ctx context.Context, sku string) ([]*domain.CycleCountTask, error) {
+	results := make([]*domain.CycleCountTask, 0)
+	for _, task := range f.tasks {
+		if task.SKU == sku && task.Status.IsOpen() {
+			results = append(results, task)
+		}
+	}
+	return results, nil
+}
+
+func (f *fakeCycleCountRepo) FindByStatus(ctx context.Context, status domain.CycleCountStatus, limit int) ([]*domain.CycleCountTask, error) {
+	results := make([]*domain.CycleCountTask, 0)
+	for _, task := range f.tasks {
+		if (status == "" && task.Status.IsOpen()) || task.Status == status {
+			results = append(results, task)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].DueAt.Before(results[j].DueAt) })
+	return results, nil
+}
+
+type fakeLedgerRepo struct {
+	ledgers map[string]*domain.InventoryLedger
+}
+
+func (f *fakeLedgerRepo) Save(ctx context.Context, ledger *domain.InventoryLedger) error {
+	f.ledgers[ledger.SKU] = ledger
+	return nil
+}
+
+func (f *fakeLedgerRepo) FindBySKU(ctx context.Context, tenantID, facilityID, sku string) (*domain.InventoryLedger, error) {
+	return f.ledgers[sku], nil
+}
+
+func (f *fakeLedgerRepo) FindByLocation(ctx context.Context, tenantID, facilityID, locationID string) ([]*domain.InventoryLedger, error) {
+	return nil, nil
+}
+
+func (f *fakeLedgerRepo) FindAll(ctx context.Context, tenantID, facilityID string, limit, offset int) ([]*domain.InventoryLedger, error) {
+	return nil, nil
+}
+
+func (f *fakeLedgerRepo) Delete(ctx context.Context, tenantID, facilityID, sku string) error {
+	delete(f.ledgers, sku)
+	return nil
+}
+
+type fakeLedgerEntryRepo struct {
+	entries     []*domain.LedgerEntryAggregate
+	saveAllErrs []error // Returned by the next saves in turn
+}
+
+func (f *fakeLedgerEntryRepo) Save(ctx context.Context, entry *domain.LedgerEntryAggregate) error {
+	return f.SaveAll(ctx, []*domain.LedgerEntryAggregate{entry})
+}
+
+func (f *fakeLedgerEntryRepo) SaveAll(ctx context.Context, entries []*domain.LedgerEntryAggregate) error {
+	if len(f.saveAllErrs) > 0 {
+		err := f.saveAllErrs[0]
+		f.saveAllErrs = f.saveAllErrs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	f.entries = append(f.entries, entries...)
+	return nil
+}
+
+func (f *fakeLedgerEntryRepo) FindBySKU(ctx context.Context, tenantID, facilityID, sku string, limit int) ([]*domain.LedgerEntryAggregate, error) {
+	return f.find(func(entry *domain.LedgerEntryAggregate) bool { return entry.Entry.SKU == sku }), nil
+}
+
+func (f *fakeLedgerEntryRepo) FindByTransactionID(ctx context.Context, tenantID, transactionID string) ([]*domain.LedgerEntryAggregate, error) {
+	return f.find(func(entry *domain.LedgerEntryAggregate) bool { return entry.Entry.TransactionID.String() == transactionID }), nil
+}
+
+func (f *fakeLedgerEntryRepo) FindByReference(ctx context.Context, tenantID, facilityID, sku, referenceType, referenceID string) ([]*domain.LedgerEntryAggregate, error) {
+	return f.find(func(entry *domain.LedgerEntryAggregate) bool {
+		return entry.Entry.SKU == sku && entry.Entry.ReferenceType == referenceType && entry.Entry.ReferenceID == referenceID
+	}), nil
+}
+
+func (f *fakeLedgerEntryRepo) FindByTimeRange(ctx context.Context, tenantID, facilityID, sku string, start, end time.Time) ([]*domain.LedgerEntryAggregate, error) {
+	return nil, nil
+}
+
+func (f *fakeLedgerEntryRepo) FindByAccountType(ctx context.Context, tenantID, facilityID, sku string, accountType domain.AccountType, limit int) ([]*domain.LedgerEntryAggregate, error) {
+	return nil, nil
+}
+
+func (f *fakeLedgerEntryRepo) GetBalanceAtTime(ctx context.Context, tenantID, facilityID, sku string, timestamp time.Time) (int, domain.Money, error) {
+	return 0, domain.Money{}, nil
+}
+
+func (f *fakeLedgerEntryRepo) find(match func(*domain.LedgerEntryAggregate) bool) []*domain.LedgerEntryAggregate {
+	results := make([]*domain.LedgerEntryAggregate, 0)
+	for _, entry := range f.entries {
+		if match(entry) {
+			results = append(results, entry)
+		}
+	}
+	return results
+}
+
+func newTestCycleCountService(repo *fakeInventoryRepo, policy domain.CycleCountPolicy) (*CycleCountService, *fakeCycleCountRepo) {
+	logger := logging.New(logging.DefaultConfig("test"))
+	countRepo := &fakeCycleCountRepo{}
+	return NewCycleCountService(countRepo, newTestService(repo), policy, logger), countRepo
+}
+
+func TestCycleCountService_ScheduleCycleCounts(t *testing.T) {
+	recent := time.Now().Add(-24 * time.Hour)
+	counted := newItemWithStock("SKU-2", 10)
+	counted.LastCycleCount = &recent
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{
+		"SKU-1": newItemWithStock("SKU-1", 10),
+		"SKU-2": counted,
+	}}
+	svc, countRepo := newTestCycleCountService(repo, domain.DefaultCycleCountPolicy())
+
+	result, err := svc.ScheduleCycleCounts(context.Background(), ScheduleCycleCountsCommand{BatchSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ItemsChecked)
+	assert.Equal(t, 1, result.TasksCreated)
+
+	// The open task is not scheduled twice
+	result, err = svc.ScheduleCycleCounts(context.Background(), ScheduleCycleCountsCommand{BatchSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.TasksCreated)
+
+	tasks, err := svc.ListTasks(context.Background(), ListCycleCountsQuery{})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "SKU-1", tasks[0].SKU)
+	assert.True(t, tasks[0].Blind)
+	assert.Nil(t, tasks[0].ExpectedQuantity)
+	assert.Len(t, countRepo.tasks, 1)
+}
+
+func TestCycleCountService_SubmitCountPostsSmallVariance(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newItemWithStock("SKU-1", 100)}}
+	policy := domain.DefaultCycleCountPolicy()
+	policy.ApprovalValueLimit = 0
+	svc, _ := newTestCycleCountService(repo, policy)
+
+	task, err := svc.CreateCycleCount(context.Background(), CreateCycleCountCommand{SKU: "SKU-1", LocationID: "LOC-1"})
+	require.NoError(t, err)
+	require.NotNil(t, task.ExpectedQuantity)
+	assert.Equal(t, 100, *task.ExpectedQuantity)
+
+	_, err = svc.CreateCycleCount(context.Background(), CreateCycleCountCommand{SKU: "SKU-1", LocationID: "LOC-1"})
+	require.Error(t, err)
+
+	// A count that matches the system needs no approval and is posted
+	counted, err := svc.SubmitCount(context.Background(), SubmitCycleCountCommand{
+		TaskID:          task.TaskID,
+		CountedQuantity: 100,
+		CountedBy:       "counter1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(domain.CycleCountStatusPosted), counted.Status)
+	assert.NotNil(t, repo.items["SKU-1"].LastCycleCount)
+	assert.Equal(t, 100, repo.items["SKU-1"].TotalQuantity)
+}
+
+func TestCycleCountService_ApprovalRequiredBeforePosting(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newItemWithStock("SKU-1", 100)}}
+	policy := domain.DefaultCycleCountPolicy()
+	policy.MaxRecounts = 0
+	svc, _ := newTestCycleCountService(repo, policy)
+
+	task, err := svc.CreateCycleCount(context.Background(), CreateCycleCountCommand{SKU: "SKU-1", LocationID: "LOC-1", Blind: true})
+	require.NoError(t, err)
+	assert.Nil(t, task.ExpectedQuantity)
+
+	// Without a ledger the unit cost is unknown, so the variance is held for a supervisor
+	counted, err := svc.SubmitCount(context.Background(), SubmitCycleCountCommand{
+		TaskID:          task.TaskID,
+		CountedQuantity: 90,
+		CountedBy:       "counter1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, string(domain.CycleCountStatusPendingApproval), counted.Status)
+	assert.Equal(t, 100, repo.items["SKU-1"].TotalQuantity)
+
+	_, err = svc.Approve(context.Background(), ApproveCycleCountCommand{TaskID: task.TaskID, ApprovedBy: "counter1"})
+	appErr, ok := err.(*sharedErrors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code)
+
+	approved, err := svc.Approve(context.Background(), ApproveCycleCountCommand{TaskID: task.TaskID, ApprovedBy: "supervisor1"})
+	require.NoError(t, err)
+	assert.Equal(t, string(domain.CycleCountStatusPosted), approved.Status)
+	assert.Equal(t, "supervisor1", approved.ApprovedBy)
+	assert.Equal(t, 90, repo.items["SKU-1"].TotalQuantity)
+}
+
+func TestCycleCountService_PostingAgainAfterFailedSave(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newItemWithStock("SKU-1", 100)}}
+	policy := domain.DefaultCycleCountPolicy()
+	policy.MaxRecounts = 0
+	svc, countRepo := newTestCycleCountService(repo, policy)
+
+	task, err := svc.CreateCycleCount(context.Background(), CreateCycleCountCommand{SKU: "SKU-1", LocationID: "LOC-1"})
+	require.NoError(t, err)
+	_, err = svc.SubmitCount(context.Background(), SubmitCycleCountCommand{TaskID: task.TaskID, CountedQuantity: 90, CountedBy: "counter1"})
+	require.NoError(t, err)
+
+	// The variance is posted to stock but the posted task is not saved
+	countRepo.saveErrs = []error{nil, assert.AnError}
+	_, err = svc.Approve(context.Background(), ApproveCycleCountCommand{TaskID: task.TaskID, ApprovedBy: "supervisor1"})
+	require.Error(t, err)
+	assert.Equal(t, 90, repo.items["SKU-1"].TotalQuantity)
+	assert.Equal(t, domain.CycleCountStatusApproved, countRepo.tasks[task.TaskID].Status)
+
+	approved, err := svc.Approve(context.Background(), ApproveCycleCountCommand{TaskID: task.TaskID, ApprovedBy: "supervisor1"})
+	require.NoError(t, err)
+	assert.Equal(t, string(domain.CycleCountStatusPosted), approved.Status)
+	assert.Equal(t, 90, repo.items["SKU-1"].TotalQuantity)
+}
+
+func TestCycleCountService_PostingAgainAfterFailedLedgerWrite(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newItemWithStock("SKU-1", 100)}}
+	policy := domain.DefaultCycleCountPolicy()
+	policy.MaxRecounts = 0
+	ledger, err := domain.NewInventoryLedger("SKU-1", domain.ValuationFIFO, &domain.LedgerTenantInfo{}, "USD")
+	require.NoError(t, err)
+	ledgerRepo := &fakeLedgerRepo{ledgers: map[string]*domain.InventoryLedger{"SKU-1": ledger}}
+	entryRepo := &fakeLedgerEntryRepo{}
+	logger := logging.New(logging.DefaultConfig("test"))
+	inventory := newTestService(repo)
+	inventory.SetLedgerService(NewLedgerApplicationService(ledgerRepo, entryRepo))
+	countRepo := &fakeCycleCountRepo{}
+	svc := NewCycleCountService(countRepo, inventory, policy, logger)
+
+	task, err := svc.CreateCycleCount(context.Background(), CreateCycleCountCommand{SKU: "SKU-1", LocationID: "LOC-1"})
+	require.NoError(t, err)
+	_, err = svc.SubmitCount(context.Background(), SubmitCycleCountCommand{TaskID: task.TaskID, CountedQuantity: 110, CountedBy: "counter1"})
+	require.NoError(t, err)
+
+	// The variance is posted to stock but the ledger adjustment is not recorded
+	entryRepo.saveAllErrs = []error{assert.AnError}
+	_, err = svc.Approve(context.Background(), ApproveCycleCountCommand{TaskID: task.TaskID, ApprovedBy: "supervisor1"})
+	require.Error(t, err)
+	assert.Equal(t, 110, repo.items["SKU-1"].TotalQuantity)
+	assert.Equal(t, domain.CycleCountStatusApproved, countRepo.tasks[task.TaskID].Status)
+	assert.Empty(t, entryRepo.entries)
+
+	approved, err := svc.Approve(context.Background(), ApproveCycleCountCommand{TaskID: task.TaskID, ApprovedBy: "supervisor1"})
+	require.NoError(t, err)
+	assert.Equal(t, string(domain.CycleCountStatusPosted), approved.Status)
+	assert.Equal(t, 110, repo.items["SKU-1"].TotalQuantity)
+	require.Len(t, entryRepo.entries, 2)
+	assert.Equal(t, entryRepo.entries[0].Entry.TransactionID.String(), countRepo.tasks[task.TaskID].LedgerTransactionID)
+}
+
+func TestCycleCountService_PostRetriesTaskConflict(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newItemWithStock("SKU-1", 100)}}
+	svc, countRepo := newTestCycleCountService(repo, domain.DefaultCycleCountPolicy())
+
+	task, err := svc.CreateCycleCount(context.Background(), CreateCycleCountCommand{SKU: "SKU-1", LocationID: "LOC-1"})
+	require.NoError(t, err)
+
+	// The task changes between the count being recorded and posted
+	countRepo.saveErrs = []error{nil, sharedErrors.NewConcurrencyConflictError("CycleCountTask", task.TaskID, 1)}
+	counted, err := svc.SubmitCount(context.Background(), SubmitCycleCountCommand{TaskID: task.TaskID, CountedQuantity: 100, CountedBy: "counter1"})
+	require.NoError(t, err)
+	assert.Equal(t, string(domain.CycleCountStatusPosted), counted.Status)
+	assert.Equal(t, domain.CycleCountStatusPosted, countRepo.tasks[task.TaskID].Status)
+	assert.Equal(t, 100, repo.items["SKU-1"].TotalQuantity)
+
+	// A concurrent change to a task while the count is recorded is a conflict
+	other, err := svc.CreateCycleCount(context.Background(), CreateCycleCountCommand{SKU: "SKU-1", LocationID: "LOC-1"})
+	require.NoError(t, err)
+	countRepo.saveErrs = []error{sharedErrors.NewConcurrencyConflictError("CycleCountTask", other.TaskID, 1)}
+	_, err = svc.SubmitCount(context.Background(), SubmitCycleCountCommand{TaskID: other.TaskID, CountedQuantity: 100, CountedBy: "counter1"})
+	appErr, ok := err.(*sharedErrors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, sharedErrors.CodeConflict, appErr.Code)
+}
+
+func TestCycleCountService_TaskNotFound(t *testing.T) {
+	svc, _ := newTestCycleCountService(&fakeInventoryRepo{}, domain.DefaultCycleCountPolicy())
+
+	_, err := svc.GetTask(context.Background(), "CC-MISSING")
+	appErr, ok := err.(*sharedErrors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, sharedErrors.CodeNotFound, appErr.Code)
+}
@@ -4,44 +4,125 @@ import "time"
 
 // InventoryItemDTO represents an inventory item in responses
 type InventoryItemDTO struct {
-	SKU                   string               `json:"sku"`
-	ProductName           string               `json:"productName"`
-	Locations             []StockLocationDTO   `json:"locations"`
-	TotalQuantity         int                  `json:"totalQuantity"`
-	ReservedQuantity      int                  `json:"reservedQuantity"`
-	HardAllocatedQuantity int                  `json:"hardAllocatedQuantity"`
-	AvailableQuantity     int                  `json:"availableQuantity"`
-	ReorderPoint          int                  `json:"reorderPoint"`
-	ReorderQuantity       int                  `json:"reorderQuantity"`
-	Reservations          []ReservationDTO     `json:"reservations,omitempty"`
-	HardAllocations       []HardAllocationDTO  `json:"hardAllocations,omitempty"`
-	LastCycleCount        *time.Time           `json:"lastCycleCount,omitempty"`
-	CreatedAt             time.Time            `json:"createdAt"`
-	UpdatedAt             time.Time            `json:"updatedAt"`
+	SKU                   string              `json:"sku"`
+	ProductName           string              `json:"productName"`
+	Locations             []StockLocationDTO  `json:"locations"`
+	TotalQuantity         int                 `json:"totalQuantity"`
+	ReservedQuantity      int                 `json:"reservedQuantity"`
+	HardAllocatedQuantity int                 `json:"hardAllocatedQuantity"`
+	AvailableQuantity     int                 `json:"availableQuantity"`
+	NonSellableQuantity   int                 `json:"nonSellableQuantity"`
+	InTransitQuantity     int                 `json:"inTransitQuantity"`
+	ReorderPoint          int                 `json:"reorderPoint"`
+	ReorderQuantity       int                 `json:"reorderQuantity"`
+	Reservations          []ReservationDTO    `json:"reservations,omitempty"`
+	HardAllocations       []HardAllocationDTO `json:"hardAllocations,omitempty"`
+	InTransit             []InTransitStockDTO `json:"inTransit,omitempty"`
+	LastCycleCount        *time.Time          `json:"lastCycleCount,omitempty"`
+	Customs               *CustomsProfileDTO  `json:"customs,omitempty"`
+	Slotting              *SlottingProfileDTO `json:"slotting,omitempty"`
+	CreatedAt             time.Time           `json:"createdAt"`
+	UpdatedAt             time.Time           `json:"updatedAt"`
+}
+
+// CustomsProfileDTO represents a SKU's customs data
+type CustomsProfileDTO struct {
+	HSCode          string    `json:"hsCode"`
+	CountryOfOrigin string    `json:"countryOfOrigin"`
+	Description     string    `json:"description"`
+	DeclaredValue   float64   `json:"declaredValue"`
+	Currency        string    `json:"currency"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// SlottingProfileDTO represents a SKU's unit cube and weight
+type SlottingProfileDTO struct {
+	UnitCube   float64   `json:"unitCube"`
+	UnitWeight float64   `json:"unitWeight"`
+	UpdatedAt  time.Time `json:"updatedAt"`
 }
 
 // StockLocationDTO represents stock at a specific location
 type StockLocationDTO struct {
-	LocationID    string `json:"locationId"`
-	Zone          string `json:"zone"`
-	Aisle         string `json:"aisle"`
-	Rack          int    `json:"rack"`
-	Level         int    `json:"level"`
-	Quantity      int    `json:"quantity"`
-	Reserved      int    `json:"reserved"`
-	HardAllocated int    `json:"hardAllocated"`
-	Available     int    `json:"available"`
+	LocationID    string            `json:"locationId"`
+	Zone          string            `json:"zone"`
+	Aisle         string            `json:"aisle"`
+	Rack          int               `json:"rack"`
+	Level         int               `json:"level"`
+	Quantity      int               `json:"quantity"`
+	Reserved      int               `json:"reserved"`
+	HardAllocated int               `json:"hardAllocated"`
+	Available     int               `json:"available"`
+	Blocked       int               `json:"blocked,omitempty"`
+	MinQuantity   int               `json:"minQuantity,omitempty"`
+	MaxQuantity   int               `json:"maxQuantity,omitempty"`
+	Lots          []StockLotDTO     `json:"lots,omitempty"`
+	NonSellable   int               `json:"nonSellable,omitempty"`
+	Statuses      []StatusBucketDTO `json:"statuses,omitempty"`
+}
+
+// StatusBucketDTO represents non-sellable stock of one status at a location
+type StatusBucketDTO struct {
+	Status     string    `json:"status"`
+	LotNumber  string    `json:"lotNumber,omitempty"`
+	HoldID     string    `json:"holdId,omitempty"`
+	ReasonCode string    `json:"reasonCode"`
+	Quantity   int       `json:"quantity"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// InTransitStockDTO represents stock picked onto a cart or tote and not yet dropped
+type InTransitStockDTO struct {
+	MoveID         string    `json:"moveId"`
+	ContainerID    string    `json:"containerId"`
+	FromLocationID string    `json:"fromLocationId"`
+	ToLocationID   string    `json:"toLocationId,omitempty"`
+	Status         string    `json:"status"`
+	Quantity       int       `json:"quantity"`
+	LotNumbers     []string  `json:"lotNumbers,omitempty"`
+	Reason         string    `json:"reason"`
+	PickedBy       string    `json:"pickedBy"`
+	PickedAt       time.Time `json:"pickedAt"`
+}
+
+// ContainerMoveDTO represents the result of moving all contents of an LPN or tote
+type ContainerMoveDTO struct {
+	LocationID   string         `json:"locationId"`
+	ToLocationID string         `json:"toLocationId"`
+	Quantity     int            `json:"quantity"`
+	Items        map[string]int `json:"items"` // Quantity moved by SKU
+}
+
+// StockLotDTO represents a lot of stock at a location
+type StockLotDTO struct {
+	LotNumber       string     `json:"lotNumber"`
+	ManufactureDate *time.Time `json:"manufactureDate,omitempty"`
+	ExpiryDate      *time.Time `json:"expiryDate,omitempty"`
+	Quantity        int        `json:"quantity"`
+	Reserved        int        `json:"reserved"`
+	Held            int        `json:"held,omitempty"`
+	Available       int        `json:"available"`
+	Status          string     `json:"status"`
+	ReceivedAt      time.Time  `json:"receivedAt"`
+}
+
+// LotAllocationDTO represents the lots a reservation was allocated from
+type LotAllocationDTO struct {
+	LotNumber  string     `json:"lotNumber"`
+	ExpiryDate *time.Time `json:"expiryDate,omitempty"`
+	Quantity   int        `json:"quantity"`
 }
 
 // ReservationDTO represents a stock reservation
 type ReservationDTO struct {
-	ReservationID string    `json:"reservationId"`
-	OrderID       string    `json:"orderId"`
-	Quantity      int       `json:"quantity"`
-	LocationID    string    `json:"locationId"`
-	Status        string    `json:"status"`
-	CreatedAt     time.Time `json:"createdAt"`
-	ExpiresAt     time.Time `json:"expiresAt"`
+	ReservationID string             `json:"reservationId"`
+	OrderID       string             `json:"orderId"`
+	Quantity      int                `json:"quantity"`
+	LocationID    string             `json:"locationId"`
+	Status        string             `json:"status"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	ExpiresAt     time.Time          `json:"expiresAt"`
+	Lots          []LotAllocationDTO `json:"lots,omitempty"`
 }
 
 // HardAllocationDTO represents a hard allocation (physically staged inventory)
@@ -62,13 +143,15 @@ type HardAllocationDTO struct {
 
 // InventoryListDTO represents a simplified inventory item for list operations
 type InventoryListDTO struct {
-	SKU               string    `json:"sku"`
-	ProductName       string    `json:"productName"`
-	TotalQuantity     int       `json:"totalQuantity"`
-	ReservedQuantity  int       `json:"reservedQuantity"`
-	AvailableQuantity int       `json:"availableQuantity"`
-	ReorderPoint      int       `json:"reorderPoint"`
-	ReorderQuantity   int       `json:"reorderQuantity"`
+	SKU                 string `json:"sku"`
+	ProductName         string `json:"productName"`
+	TotalQuantity       int    `json:"totalQuantity"`
+	ReservedQuantity    int    `json:"reservedQuantity"`
+	AvailableQuantity   int    `json:"availableQuantity"`
+	NonSellableQuantity int    `json:"nonSellableQuantity"`
+	InTransitQuantity   int    `json:"inTransitQuantity"`
+	ReorderPoint        int    `json:"reorderPoint"`
+	ReorderQuantity     int    `json:"reorderQuantity"`
 
 	// CQRS computed fields
 	IsLowStock         bool     `json:"isLowStock"`
@@ -81,3 +164,192 @@ type InventoryListDTO struct {
 
 	UpdatedAt time.Time `json:"updatedAt"`
 }
+
+// LotExpiryResultDTO represents the outcome of a lot expiry check
+type LotExpiryResultDTO struct {
+	ItemsChecked int `json:"itemsChecked"`
+	LotsWarned   int `json:"lotsWarned"`
+	LotsBlocked  int `json:"lotsBlocked"`
+}
+
+// ExpiredReservationDTO represents a reservation released (or, in a dry run, found) by the expiry sweeper
+type ExpiredReservationDTO struct {
+	SKU           string    `json:"sku"`
+	TenantID      string    `json:"tenantId,omitempty"`
+	ReservationID string    `json:"reservationId"`
+	OrderID       string    `json:"orderId"`
+	LocationID    string    `json:"locationId"`
+	Quantity      int       `json:"quantity"`
+	UnitIDs       []string  `json:"unitIds,omitempty"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// ReservationSweepResultDTO represents the outcome of a reservation expiry sweep
+type ReservationSweepResultDTO struct {
+	DryRun       bool                    `json:"dryRun"`
+	TenantID     string                  `json:"tenantId,omitempty"`
+	ItemsChecked int                     `json:"itemsChecked"`
+	Released     []ExpiredReservationDTO `json:"released"`
+	Failed       int                     `json:"failed"`
+}
+
+// CycleCountTaskDTO represents a cycle count task. The expected quantity and variances
+// are omitted while a blind count is still being counted.
+type CycleCountTaskDTO struct {
+	TaskID              string            `json:"taskId"`
+	SKU                 string            `json:"sku"`
+	LocationID          string            `json:"locationId"`
+	VelocityClass       string            `json:"velocityClass"`
+	Blind               bool              `json:"blind"`
+	Status              string            `json:"status"`
+	ExpectedQuantity    *int              `json:"expectedQuantity,omitempty"`
+	Attempts            []CountAttemptDTO `json:"attempts"`
+	Variance            int               `json:"variance"`
+	UnitCost            float64           `json:"unitCost"`
+	VarianceValue       float64           `json:"varianceValue"`
+	ApprovedBy          string            `json:"approvedBy,omitempty"`
+	RejectedBy          string            `json:"rejectedBy,omitempty"`
+	RejectionReason     string            `json:"rejectionReason,omitempty"`
+	LedgerTransactionID string            `json:"ledgerTransactionId,omitempty"`
+	DueAt               time.Time         `json:"dueAt"`
+	CreatedAt           time.Time         `json:"createdAt"`
+	UpdatedAt           time.Time         `json:"updatedAt"`
+	CompletedAt         *time.Time        `json:"completedAt,omitempty"`
+}
+
+// CountAttemptDTO represents one physical count of a task
+type CountAttemptDTO struct {
+	CountedQuantity int       `json:"countedQuantity"`
+	SystemQuantity  *int      `json:"systemQuantity,omitempty"`
+	Variance        *int      `json:"variance,omitempty"`
+	CountedBy       string    `json:"countedBy"`
+	CountedAt       time.Time `json:"countedAt"`
+}
+
+// CycleCountScheduleResultDTO represents the outcome of a cycle count scheduling run
+type CycleCountScheduleResultDTO struct {
+	ItemsChecked int `json:"itemsChecked"`
+	TasksCreated int `json:"tasksCreated"`
+	Failed       int `json:"failed"`
+}
+
+// ReplenishmentTaskDTO represents a task moving stock from reserve storage to a pick face
+type ReplenishmentTaskDTO struct {
+	TaskID         string     `json:"taskId"`
+	SKU            string     `json:"sku"`
+	FromLocationID string     `json:"fromLocationId"`
+	ToLocationID   string     `json:"toLocationId"`
+	Zone           string     `json:"zone"`
+	Quantity       int        `json:"quantity"`
+	MovedQuantity  int        `json:"movedQuantity"`
+	Trigger        string     `json:"trigger"`
+	WaveID         string     `json:"waveId,omitempty"`
+	Priority       int        `json:"priority"`
+	Status         string     `json:"status"`
+	LaborQueued    bool       `json:"laborQueued"`
+	CompletedBy    string     `json:"completedBy,omitempty"`
+	CancelReason   string     `json:"cancelReason,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+	CompletedAt    *time.Time `json:"completedAt,omitempty"`
+}
+
+// ReplenishmentRunResultDTO represents the outcome of a replenishment check
+type ReplenishmentRunResultDTO struct {
+	ItemsChecked int `json:"itemsChecked"`
+	TasksCreated int `json:"tasksCreated"`
+	TasksQueued  int `json:"tasksQueued"`
+	Failed       int `json:"failed"`
+}
+
+// WaveTopOffResultDTO represents the top-off tasks created for a released wave
+type WaveTopOffResultDTO struct {
+	WaveID      string                 `json:"waveId"`
+	SKUsChecked int                    `json:"skusChecked"`
+	Tasks       []ReplenishmentTaskDTO `json:"tasks"`
+}
+
+// SlotLocationDTO represents a storage slot and its size limits
+type SlotLocationDTO struct {
+	LocationID   string    `json:"locationId"`
+	Zone         string    `json:"zone"`
+	Aisle        string    `json:"aisle"`
+	Rack         int       `json:"rack"`
+	Level        int       `json:"level"`
+	CubeCapacity float64   `json:"cubeCapacity"`
+	MaxWeight    float64   `json:"maxWeight"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// SlotScoreDTO represents how well a slot suits a SKU
+type SlotScoreDTO struct {
+	Velocity  float64 `json:"velocity"`
+	Ergonomic float64 `json:"ergonomic"`
+	CubeFit   float64 `json:"cubeFit"`
+	Weight    float64 `json:"weight"`
+	Affinity  float64 `json:"affinity"`
+	Total     float64 `json:"total"`
+	Fits      bool    `json:"fits"`
+}
+
+// SlottingRecommendationDTO represents a recommended move of a SKU to a better slot
+type SlottingRecommendationDTO struct {
+	RecommendationID string       `json:"recommendationId"`
+	SKU              string       `json:"sku"`
+	VelocityClass    string       `json:"velocityClass"`
+	PickFrequency    int          `json:"pickFrequency"`
+	Zone             string       `json:"zone"`
+	FromLocationID   string       `json:"fromLocationId"`
+	ToLocationID     string       `json:"toLocationId"`
+	ToLevel          int          `json:"toLevel"`
+	Quantity         int          `json:"quantity"`
+	CurrentScore     SlotScoreDTO `json:"currentScore"`
+	ProposedScore    SlotScoreDTO `json:"proposedScore"`
+	FromDistance     float64      `json:"fromDistance"`
+	ToDistance       float64      `json:"toDistance"`
+	TravelSavings    float64      `json:"travelSavings"` // meters per week
+	Status           string       `json:"status"`
+	MoveTaskID       string       `json:"moveTaskId,omitempty"`
+	LaborQueued      bool         `json:"laborQueued"`
+	DecidedBy        string       `json:"decidedBy,omitempty"`
+	RejectReason     string       `json:"rejectReason,omitempty"`
+	MovedQuantity    int          `json:"movedQuantity"`
+	MovedBy          string       `json:"movedBy,omitempty"`
+	CreatedAt        time.Time    `json:"createdAt"`
+	DecidedAt        *time.Time   `json:"decidedAt,omitempty"`
+	CompletedAt      *time.Time   `json:"completedAt,omitempty"`
+}
+
+// SlottingRunResultDTO represents the outcome of a slotting run
+type SlottingRunResultDTO struct {
+	SKUsScored      int                         `json:"skusScored"`
+	FreeSlots       int                         `json:"freeSlots"`
+	TravelSavings   float64                     `json:"travelSavings"` // meters per week across all recommendations
+	Recommendations []SlottingRecommendationDTO `json:"recommendations"`
+}
+
+// InventoryHoldDTO represents an inventory hold and the stock it set aside
+type InventoryHoldDTO struct {
+	HoldID       string             `json:"holdId"`
+	Scope        string             `json:"scope"`
+	SKU          string             `json:"sku,omitempty"`
+	LotNumber    string             `json:"lotNumber,omitempty"`
+	LocationID   string             `json:"locationId,omitempty"`
+	ReasonCode   string             `json:"reasonCode"`
+	Notes        string             `json:"notes,omitempty"`
+	Status       string             `json:"status"`
+	HeldQuantity int                `json:"heldQuantity"`
+	Placements   []HoldPlacementDTO `json:"placements"`
+	PlacedBy     string             `json:"placedBy"`
+	ReleasedBy   string             `json:"releasedBy,omitempty"`
+	CreatedAt    time.Time          `json:"createdAt"`
+	ReleasedAt   *time.Time         `json:"releasedAt,omitempty"`
+}
+
+// HoldPlacementDTO represents stock a hold set aside at one location
+type HoldPlacementDTO struct {
+	SKU        string `json:"sku"`
+	LocationID string `json:"locationId"`
+	LotNumber  string `json:"lotNumber,omitempty"`
+	Quantity   int    `json:"quantity"`
+}
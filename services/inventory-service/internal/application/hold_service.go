@@ -0,0 +1,208 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+// defaultHoldListLimit caps hold listings when no limit is given
+const defaultHoldListLimit = 100
+
+// InventoryHoldService places and releases inventory holds. A hold moves the available stock
+// in its scope into on_hold, where it can't be reserved or offered on sales channels, and
+// returns it to available when released.
+type InventoryHoldService struct {
+	repo      domain.InventoryHoldRepository
+	inventory *InventoryApplicationService
+	logger    *logging.Logger
+}
+
+// NewInventoryHoldService creates a new InventoryHoldService
+func NewInventoryHoldService(
+	repo domain.InventoryHoldRepository,
+	inventory *InventoryApplicationService,
+	logger *logging.Logger,
+) *InventoryHoldService {
+	return &InventoryHoldService{
+		repo:      repo,
+		inventory: inventory,
+		logger:    logger,
+	}
+}
+
+// PlaceHold puts the available stock of a SKU, a lot or a location on hold
+func (s *InventoryHoldService) PlaceHold(ctx context.Context, cmd PlaceHoldCommand) (*InventoryHoldDTO, error) {
+	hold, err := domain.NewInventoryHold(
+		domain.HoldScope(cmd.Scope),
+		cmd.SKU,
+		cmd.LotNumber,
+		cmd.LocationID,
+		domain.StatusReasonCode(cmd.ReasonCode),
+		cmd.Notes,
+		cmd.PlacedBy,
+	)
+	if err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	hold.TenantID = tenant.GetTenantID(ctx)
+	hold.FacilityID = tenant.GetFacilityID(ctx)
+	hold.WarehouseID = tenant.GetWarehouseID(ctx)
+
+	skus, err := s.skusInScope(ctx, hold)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stock already placed stays on the hold if a later SKU fails, so it can be released
+	var placeErr error
+	for _, sku := range skus {
+		var placements []domain.HoldPlacement
+		item, events, err := s.inventory.updateItem(ctx, sku, func(item *domain.InventoryItem) error {
+			placed, err := item.PlaceHold(hold)
+			placements = placed
+			return err
+		})
+		if err != nil {
+			placeErr = err
+			break
+		}
+		hold.RecordPlacements(placements)
+
+		s.inventory.updateProjections(ctx, sku, events)
+		s.inventory.recordStatusChanges(ctx, item, events)
+	}
+
+	if err := s.repo.Save(ctx, hold); err != nil {
+		s.logger.Error("Failed to save inventory hold", "holdId", hold.HoldID, "error", err)
+		return nil, fmt.Errorf("failed to save inventory hold: %w", err)
+	}
+	if placeErr != nil {
+		s.logger.Warn("Inventory hold placed partially", "holdId", hold.HoldID, "error", placeErr)
+		return nil, placeErr
+	}
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "inventory.hold_placed",
+		EntityType: "inventory_hold",
+		EntityID:   hold.HoldID,
+		Action:     "placed",
+		RelatedIDs: map[string]string{
+			"scope":      string(hold.Scope),
+			"reasonCode": string(hold.ReasonCode),
+			"quantity":   fmt.Sprintf("%d", hold.HeldQuantity()),
+		},
+	})
+
+	return ToInventoryHoldDTO(hold), nil
+}
+
+// ReleaseHold returns the stock of a hold to available
+func (s *InventoryHoldService) ReleaseHold(ctx context.Context, cmd ReleaseHoldCommand) (*InventoryHoldDTO, error) {
+	hold, err := s.getHold(ctx, cmd.HoldID)
+	if err != nil {
+		return nil, err
+	}
+	if !hold.IsActive() {
+		return nil, errors.ErrValidation(domain.ErrHoldNotActive.Error())
+	}
+
+	for _, sku := range hold.SKUs() {
+		item, events, err := s.inventory.updateItem(ctx, sku, func(item *domain.InventoryItem) error {
+			_, err := item.ReleaseHold(hold.HoldID, cmd.ReleasedBy)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		s.inventory.updateProjections(ctx, sku, events)
+		s.inventory.recordStatusChanges(ctx, item, events)
+	}
+
+	if err := hold.Release(cmd.ReleasedBy); err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	if err := s.repo.Save(ctx, hold); err != nil {
+		if errors.IsConcurrencyConflict(err) {
+			return nil, errors.ErrConflict(fmt.Sprintf("inventory hold %s was modified concurrently, please retry", hold.HoldID)).Wrap(err)
+		}
+		s.logger.Error("Failed to save inventory hold", "holdId", hold.HoldID, "error", err)
+		return nil, fmt.Errorf("failed to save inventory hold: %w", err)
+	}
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "inventory.hold_released",
+		EntityType: "inventory_hold",
+		EntityID:   hold.HoldID,
+		Action:     "released",
+		RelatedIDs: map[string]string{
+			"releasedBy": cmd.ReleasedBy,
+		},
+	})
+
+	return ToInventoryHoldDTO(hold), nil
+}
+
+// GetHold retrieves an inventory hold
+func (s *InventoryHoldService) GetHold(ctx context.Context, holdID string) (*InventoryHoldDTO, error) {
+	hold, err := s.getHold(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+	return ToInventoryHoldDTO(hold), nil
+}
+
+// ListHolds lists inventory holds by status, newest first
+func (s *InventoryHoldService) ListHolds(ctx context.Context, query ListHoldsQuery) ([]InventoryHoldDTO, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultHoldListLimit
+	}
+
+	status := domain.HoldStatus(query.Status)
+	if status == "" {
+		status = domain.HoldStatusActive
+	}
+	holds, err := s.repo.FindByStatus(ctx, status, limit)
+	if err != nil {
+		s.logger.Error("Failed to list inventory holds", "status", status, "error", err)
+		return nil, fmt.Errorf("failed to list inventory holds: %w", err)
+	}
+	return ToInventoryHoldDTOs(holds), nil
+}
+
+// skusInScope returns the SKUs a hold applies to: its SKU, or every SKU stored at its location
+func (s *InventoryHoldService) skusInScope(ctx context.Context, hold *domain.InventoryHold) ([]string, error) {
+	if hold.Scope != domain.HoldScopeLocation {
+		return []string{hold.SKU}, nil
+	}
+
+	items, err := s.inventory.repo.FindByLocation(ctx, hold.LocationID)
+	if err != nil {
+		s.logger.Error("Failed to get items", "locationId", hold.LocationID, "error", err)
+		return nil, fmt.Errorf("failed to get items: %w", err)
+	}
+	skus := make([]string, 0, len(items))
+	for _, item := range items {
+		skus = append(skus, item.SKU)
+	}
+	return skus, nil
+}
+
+func (s *InventoryHoldService) getHold(ctx context.Context, holdID string) (*domain.InventoryHold, error) {
+	hold, err := s.repo.FindByID(ctx, holdID)
+	if err != nil {
+		s.logger.Error("Failed to get inventory hold", "holdId", holdID, "error", err)
+		return nil, fmt.Errorf("failed to get inventory hold: %w", err)
+	}
+	if hold == nil {
+		return nil, errors.ErrNotFound("inventory hold")
+	}
+	return hold, nil
+}
@@ -0,0 +1,160 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+type fakeHoldRepo struct {
+	holds map[string]*domain.InventoryHold
+}
+
+func (f *fakeHoldRepo) Save(ctx context.Context, hold *domain.InventoryHold) error {
+	if f.holds == nil {
+		f.holds = make(map[string]*domain.InventoryHold)
+	}
+	f.holds[hold.HoldID] = hold
+	return nil
+}
+
+func (f *fakeHoldRepo) FindByID(ctx context.Context, holdID string) (*domain.InventoryHold, error) {
+	return f.holds[holdID], nil
+}
+
+func (f *fakeHoldRepo) FindByStatus(ctx context.Context, status domain.HoldStatus, limit int) ([]*domain.InventoryHold, error) {
+	results := make([]*domain.InventoryHold, 0)
+	for _, hold := range f.holds {
+		if status == "" || hold.Status == status {
+			results = append(results, hold)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].HoldID < results[j].HoldID })
+	return results, nil
+}
+
+func newHoldFixture(t *testing.T) (*InventoryHoldService, *fakeInventoryRepo, *fakeHoldRepo) {
+	shelf := domain.NewInventoryItem("SKU-1", "Widget", 5, 10)
+	require.NoError(t, shelf.ReceiveStock("LOC-1", "ZONE-A", 10, "PO-1", "user1"))
+	require.NoError(t, shelf.ReceiveStock("LOC-2", "ZONE-A", 4, "PO-1", "user1"))
+	neighbour := domain.NewInventoryItem("SKU-2", "Gadget", 5, 10)
+	require.NoError(t, neighbour.ReceiveStock("LOC-1", "ZONE-A", 6, "PO-2", "user1"))
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": shelf, "SKU-2": neighbour}}
+
+	holds := &fakeHoldRepo{}
+	logger := logging.New(logging.DefaultConfig("test"))
+	return NewInventoryHoldService(holds, newTestService(repo), logger), repo, holds
+}
+
+func TestInventoryHoldService_PlaceAndReleaseLocationHold(t *testing.T) {
+	svc, repo, holds := newHoldFixture(t)
+	ctx := context.Background()
+
+	dto, err := svc.PlaceHold(ctx, PlaceHoldCommand{
+		Scope:      "location",
+		LocationID: "LOC-1",
+		ReasonCode: "investigation",
+		PlacedBy:   "supervisor",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "active", dto.Status)
+	assert.Equal(t, 16, dto.HeldQuantity, "every SKU at the location is held")
+	assert.Len(t, dto.Placements, 2)
+	assert.Equal(t, 4, repo.items["SKU-1"].AvailableQuantity, "stock at other locations stays available")
+	assert.Equal(t, 10, repo.items["SKU-1"].NonSellableQuantity)
+	assert.Equal(t, 0, repo.items["SKU-2"].AvailableQuantity)
+
+	_, err = svc.inventory.Reserve(ctx, ReserveCommand{SKU: "SKU-2", OrderID: "ORD-1", LocationID: "LOC-1", Quantity: 1})
+	assert.Error(t, err, "held stock can't be reserved")
+
+	listed, err := svc.ListHolds(ctx, ListHoldsQuery{})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	released, err := svc.ReleaseHold(ctx, ReleaseHoldCommand{HoldID: dto.HoldID, ReleasedBy: "supervisor"})
+	require.NoError(t, err)
+	assert.Equal(t, "released", released.Status)
+	assert.Equal(t, 14, repo.items["SKU-1"].AvailableQuantity)
+	assert.Equal(t, 0, repo.items["SKU-1"].NonSellableQuantity)
+	assert.Equal(t, 6, repo.items["SKU-2"].AvailableQuantity)
+	assert.False(t, holds.holds[dto.HoldID].IsActive())
+
+	_, err = svc.ReleaseHold(ctx, ReleaseHoldCommand{HoldID: dto.HoldID, ReleasedBy: "supervisor"})
+	var appErr *sharedErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code)
+}
+
+func TestInventoryHoldService_PlaceHoldValidation(t *testing.T) {
+	svc, _, _ := newHoldFixture(t)
+	ctx := context.Background()
+
+	_, err := svc.PlaceHold(ctx, PlaceHoldCommand{Scope: "lot", SKU: "SKU-1", ReasonCode: "recall"})
+	var appErr *sharedErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code, "a lot hold needs a lot number")
+
+	_, err = svc.PlaceHold(ctx, PlaceHoldCommand{Scope: "sku", SKU: "MISSING", ReasonCode: "recall"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeNotFound, appErr.Code)
+
+	_, err = svc.GetHold(ctx, "HLD-missing")
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeNotFound, appErr.Code)
+}
+
+func TestInventoryApplicationService_ReceiveDamagedAndChangeStatus(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newItemWithStock("SKU-1", 10)}}
+	svc := newTestService(repo)
+	ctx := context.Background()
+
+	dto, err := svc.ReceiveStock(ctx, ReceiveStockCommand{
+		SKU:         "SKU-1",
+		LocationID:  "LOC-1",
+		Zone:        "ZONE-A",
+		Quantity:    3,
+		Condition:   "damaged",
+		ReferenceID: "PO-2",
+		CreatedBy:   "user1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 13, dto.TotalQuantity)
+	assert.Equal(t, 10, dto.AvailableQuantity, "damaged receipts are not sellable")
+	assert.Equal(t, 3, dto.NonSellableQuantity)
+
+	dto, err = svc.ChangeStatus(ctx, ChangeInventoryStatusCommand{
+		SKU:        "SKU-1",
+		LocationID: "LOC-1",
+		Quantity:   2,
+		FromStatus: "available",
+		ToStatus:   "quarantine",
+		ReasonCode: "problem_ticket",
+		ChangedBy:  "user1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 8, dto.AvailableQuantity)
+	assert.Equal(t, 5, dto.NonSellableQuantity)
+
+	_, err = svc.ChangeStatus(ctx, ChangeInventoryStatusCommand{
+		SKU:        "SKU-1",
+		LocationID: "LOC-1",
+		Quantity:   4,
+		FromStatus: "damaged",
+		ToStatus:   "available",
+		ReasonCode: "inspection_passed",
+	})
+	var appErr *sharedErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code, "only 3 units are damaged")
+
+	_, err = svc.ReceiveStock(ctx, ReceiveStockCommand{SKU: "SKU-1", LocationID: "LOC-1", Quantity: 1, Condition: "crushed"})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code)
+}
@@ -167,19 +167,21 @@ func (s *InventoryQueryService) CountInventoryByStatus(ctx context.Context, isLo
 // Helper: Convert projection to DTO
 func (s *InventoryQueryService) projectionToDTO(proj *projections.InventoryListProjection) InventoryListDTO {
 	return InventoryListDTO{
-		SKU:                proj.SKU,
-		ProductName:        proj.ProductName,
-		TotalQuantity:      proj.TotalQuantity,
-		ReservedQuantity:   proj.ReservedQuantity,
-		AvailableQuantity:  proj.AvailableQuantity,
-		ReorderPoint:       proj.ReorderPoint,
-		ReorderQuantity:    proj.ReorderQuantity,
-		IsLowStock:         proj.IsLowStock,
-		IsOutOfStock:       proj.IsOutOfStock,
-		LocationCount:      proj.LocationCount,
-		PrimaryLocation:    proj.PrimaryLocation,
-		AvailableLocations: proj.AvailableLocations,
-		ActiveReservations: proj.ActiveReservations,
-		ReservedOrders:     proj.ReservedOrders,
+		SKU:                 proj.SKU,
+		ProductName:         proj.ProductName,
+		TotalQuantity:       proj.TotalQuantity,
+		ReservedQuantity:    proj.ReservedQuantity,
+		AvailableQuantity:   proj.AvailableQuantity,
+		NonSellableQuantity: proj.NonSellableQuantity,
+		InTransitQuantity:   proj.InTransitQuantity,
+		ReorderPoint:        proj.ReorderPoint,
+		ReorderQuantity:     proj.ReorderQuantity,
+		IsLowStock:          proj.IsLowStock,
+		IsOutOfStock:        proj.IsOutOfStock,
+		LocationCount:       proj.LocationCount,
+		PrimaryLocation:     proj.PrimaryLocation,
+		AvailableLocations:  proj.AvailableLocations,
+		ActiveReservations:  proj.ActiveReservations,
+		ReservedOrders:      proj.ReservedOrders,
 	}
 }
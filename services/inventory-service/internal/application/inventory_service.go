@@ -9,6 +9,7 @@ import (
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 
 	"github.com/wms-platform/inventory-service/internal/domain"
 	"github.com/wms-platform/inventory-service/internal/infrastructure/projections"
@@ -21,6 +22,9 @@ type InventoryApplicationService struct {
 	eventFactory *cloudevents.EventFactory
 	projector    *projections.InventoryProjector // CQRS projector for read model
 	ledgerService *LedgerApplicationService      // Optional: for double-entry ledger
+	shelfLife    *domain.ShelfLifePolicy         // Optional: minimum remaining shelf life for FEFO allocation
+	unitReleaser domain.UnitReleaser             // Optional: releases unit reservations in unit-service
+	replenishment *ReplenishmentService          // Optional: replenishes pick faces that drop below their minimum
 	logger       *logging.Logger
 }
 
@@ -47,6 +51,26 @@ func (s *InventoryApplicationService) SetLedgerService(ledgerService *LedgerAppl
 	s.ledgerService = ledgerService
 }
 
+// SetShelfLifePolicy sets the minimum remaining shelf life policy used for FEFO allocation
+func (s *InventoryApplicationService) SetShelfLifePolicy(policy *domain.ShelfLifePolicy) {
+	s.shelfLife = policy
+}
+
+// SetUnitReleaser sets the unit releaser used when expired reservations are swept
+func (s *InventoryApplicationService) SetUnitReleaser(releaser domain.UnitReleaser) {
+	s.unitReleaser = releaser
+}
+
+// SetReplenishmentService sets the service that creates replenishment tasks after picks
+func (s *InventoryApplicationService) SetReplenishmentService(replenishment *ReplenishmentService) {
+	s.replenishment = replenishment
+}
+
+// allocationPolicy returns the lot allocation policy for a seller and channel
+func (s *InventoryApplicationService) allocationPolicy(sellerID, channel string) domain.AllocationPolicy {
+	return s.shelfLife.AllocationPolicy(sellerID, channel, time.Now())
+}
+
 // CreateItem creates a new inventory item
 func (s *InventoryApplicationService) CreateItem(ctx context.Context, cmd CreateItemCommand) (*InventoryItemDTO, error) {
 	item := domain.NewInventoryItem(cmd.SKU, cmd.ProductName, cmd.ReorderPoint, cmd.ReorderQuantity)
@@ -83,28 +107,31 @@ func (s *InventoryApplicationService) GetItem(ctx context.Context, query GetItem
 
 // ReceiveStock receives stock into a location
 func (s *InventoryApplicationService) ReceiveStock(ctx context.Context, cmd ReceiveStockCommand) (*InventoryItemDTO, error) {
-	item, err := s.repo.FindBySKU(ctx, cmd.SKU)
-	if err != nil {
-		s.logger.Error("Failed to get item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to get item: %w", err)
-	}
-
-	if item == nil {
-		return nil, errors.ErrNotFound("item")
-	}
-
 	// Receive stock (domain logic)
-	if err := item.ReceiveStock(cmd.LocationID, cmd.Zone, cmd.Quantity, cmd.ReferenceID, cmd.CreatedBy); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	// Capture events before save
-	events := item.GetDomainEvents()
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		status, reasonCode, err := receiptStatus(cmd)
+		if err != nil {
+			return err
+		}
 
-	// Save the updated item
-	if err := s.repo.Save(ctx, item); err != nil {
-		s.logger.Error("Failed to save item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to save item: %w", err)
+		var lot *domain.LotInfo
+		if cmd.LotNumber != "" {
+			lot = &domain.LotInfo{
+				LotNumber:       cmd.LotNumber,
+				ManufactureDate: cmd.ManufactureDate,
+				ExpiryDate:      cmd.ExpiryDate,
+			}
+		}
+		if !status.IsSellable() {
+			return item.ReceiveIntoStatus(cmd.LocationID, cmd.Zone, cmd.Quantity, lot, status, reasonCode, cmd.ReferenceID, cmd.CreatedBy)
+		}
+		if lot != nil {
+			return item.ReceiveLotStock(cmd.LocationID, cmd.Zone, cmd.Quantity, *lot, cmd.ReferenceID, cmd.CreatedBy)
+		}
+		return item.ReceiveStock(cmd.LocationID, cmd.Zone, cmd.Quantity, cmd.ReferenceID, cmd.CreatedBy)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -138,6 +165,7 @@ func (s *InventoryApplicationService) ReceiveStock(ctx context.Context, cmd Rece
 			s.logger.Debug("Recorded receiving in ledger", "sku", cmd.SKU, "quantity", cmd.Quantity)
 		}
 	}
+	s.recordStatusChanges(ctx, item, events)
 
 	// Events are saved to outbox by repository in transaction
 
@@ -145,21 +173,107 @@ func (s *InventoryApplicationService) ReceiveStock(ctx context.Context, cmd Rece
 	return ToInventoryItemDTO(item), nil
 }
 
-// Reserve reserves stock for an order
-func (s *InventoryApplicationService) Reserve(ctx context.Context, cmd ReserveCommand) (*InventoryItemDTO, error) {
-	item, err := s.repo.FindBySKU(ctx, cmd.SKU)
+// receiptStatus returns the inventory status received stock goes into: the status named by
+// the command, else the one for the receiving-service item condition
+func receiptStatus(cmd ReceiveStockCommand) (domain.InventoryStatus, domain.StatusReasonCode, error) {
+	if cmd.Status != "" {
+		return domain.InventoryStatus(cmd.Status), domain.StatusReasonCode(cmd.ReasonCode), nil
+	}
+
+	status, reasonCode, err := domain.StatusForCondition(cmd.Condition)
+	if cmd.ReasonCode != "" {
+		reasonCode = domain.StatusReasonCode(cmd.ReasonCode)
+	}
+	return status, reasonCode, err
+}
+
+// ChangeStatus moves stock at a location between inventory statuses, e.g. damaged in the
+// warehouse, quarantined for a problem ticket or a customer return graded resellable
+func (s *InventoryApplicationService) ChangeStatus(ctx context.Context, cmd ChangeInventoryStatusCommand) (*InventoryItemDTO, error) {
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.ChangeStatus(domain.StatusChange{
+			LocationID:  cmd.LocationID,
+			LotNumber:   cmd.LotNumber,
+			Quantity:    cmd.Quantity,
+			From:        domain.InventoryStatus(cmd.FromStatus),
+			To:          domain.InventoryStatus(cmd.ToStatus),
+			ReasonCode:  domain.StatusReasonCode(cmd.ReasonCode),
+			ReferenceID: cmd.ReferenceID,
+			ChangedBy:   cmd.ChangedBy,
+		})
+	})
 	if err != nil {
-		s.logger.Error("Failed to get item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to get item: %w", err)
+		return nil, err
 	}
 
-	if item == nil {
-		return nil, errors.ErrNotFound("item")
+	s.updateProjections(ctx, cmd.SKU, events)
+	s.recordStatusChanges(ctx, item, events)
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "inventory.status_changed",
+		EntityType: "inventory",
+		EntityID:   cmd.SKU,
+		Action:     "status_changed",
+		RelatedIDs: map[string]string{
+			"locationId": cmd.LocationID,
+			"fromStatus": cmd.FromStatus,
+			"toStatus":   cmd.ToStatus,
+			"quantity":   fmt.Sprintf("%d", cmd.Quantity),
+			"reasonCode": cmd.ReasonCode,
+		},
+	})
+
+	return ToInventoryItemDTO(item), nil
+}
+
+// recordStatusChanges moves the value of stock that changed inventory status between the
+// ledger accounts of its statuses, if ledger service is enabled
+func (s *InventoryApplicationService) recordStatusChanges(ctx context.Context, item *domain.InventoryItem, events []domain.DomainEvent) {
+	if s.ledgerService == nil {
+		return
 	}
 
-	// Reserve stock (domain logic)
-	if err := item.Reserve(cmd.OrderID, cmd.LocationID, cmd.Quantity); err != nil {
-		return nil, errors.ErrValidation(err.Error())
+	for _, event := range events {
+		changed, ok := event.(*domain.InventoryStatusChangedEvent)
+		if !ok {
+			continue
+		}
+		from := domain.InventoryStatus(changed.FromStatus).LedgerAccount()
+		to := domain.InventoryStatus(changed.ToStatus).LedgerAccount()
+		if from == to {
+			continue // e.g. damaged to quarantine, both carried as non-sellable
+		}
+
+		ledgerCmd := RecordStatusChangeCommand{
+			SKU:         item.SKU,
+			Quantity:    changed.Quantity,
+			FromAccount: from,
+			ToAccount:   to,
+			Reason:      changed.ReasonCode,
+			LocationID:  changed.LocationID,
+			ReferenceID: changed.ReferenceID,
+			CreatedBy:   changed.ChangedBy,
+			TenantID:    item.TenantID,
+			FacilityID:  item.FacilityID,
+			WarehouseID: item.WarehouseID,
+			SellerID:    item.SellerID,
+		}
+		if _, err := s.ledgerService.RecordStatusChange(ctx, ledgerCmd); err != nil {
+			// Log error but don't fail the operation - ledger is supplementary
+			s.logger.Warn("Failed to record status change in ledger", "sku", item.SKU, "error", err)
+		}
+	}
+}
+
+// Reserve reserves stock for an order
+func (s *InventoryApplicationService) Reserve(ctx context.Context, cmd ReserveCommand) (*InventoryItemDTO, error) {
+	// Reserve stock (domain logic, FEFO for lot-tracked stock)
+	item, _, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		policy := s.allocationPolicy(item.SellerID, cmd.Channel)
+		return item.ReserveWithPolicy(cmd.OrderID, cmd.LocationID, cmd.Quantity, nil, policy)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Note: Reserve doesn't generate domain events, but we still need to update projections
@@ -168,12 +282,6 @@ func (s *InventoryApplicationService) Reserve(ctx context.Context, cmd ReserveCo
 		_ = s.projector.OnInventoryReserved(ctx, cmd.SKU, cmd.OrderID)
 	}
 
-	// Save the updated item
-	if err := s.repo.Save(ctx, item); err != nil {
-		s.logger.Error("Failed to save item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to save item: %w", err)
-	}
-
 	s.logger.Info("Reserved stock", "sku", cmd.SKU, "orderId", cmd.OrderID, "quantity", cmd.Quantity)
 	return ToInventoryItemDTO(item), nil
 }
@@ -229,18 +337,18 @@ func (s *InventoryApplicationService) ReserveBulk(ctx context.Context, cmd Reser
 				))
 			}
 
-			// Find first location with sufficient quantity
-			found := false
-			for _, loc := range availableLocations {
-				if loc.Available >= itemReq.Quantity {
-					locationID = loc.LocationID
-					available = loc.Available
-					found = true
-					break
-				}
+			// Select the location whose stock expires first (FEFO)
+			selected, err := item.SelectFEFOLocation(itemReq.Quantity, s.allocationPolicy(item.SellerID, cmd.Channel))
+			if err == domain.ErrInsufficientShelfLife {
+				return errors.ErrValidation(fmt.Sprintf(
+					"insufficient stock for SKU %s meeting minimum remaining shelf life",
+					itemReq.SKU,
+				))
 			}
-
-			if !found {
+			if err == nil {
+				locationID = selected
+				available = item.GetLocationStock(selected).Available
+			} else {
 				// Calculate total available
 				totalAvailable := 0
 				for _, loc := range availableLocations {
@@ -268,7 +376,8 @@ func (s *InventoryApplicationService) ReserveBulk(ctx context.Context, cmd Reser
 	for _, itemReq := range cmd.Items {
 		item := itemsMap[itemReq.SKU]
 		locationID := locationSelections[itemReq.SKU]
-		if err := item.Reserve(cmd.OrderID, locationID, itemReq.Quantity); err != nil {
+		policy := s.allocationPolicy(item.SellerID, cmd.Channel)
+		if err := item.ReserveWithPolicy(cmd.OrderID, locationID, itemReq.Quantity, nil, policy); err != nil {
 			s.logger.Error("Failed to reserve item", "sku", itemReq.SKU, "orderId", cmd.OrderID, "error", err)
 			return errors.ErrValidation(fmt.Sprintf("failed to reserve %s: %s", itemReq.SKU, err.Error()))
 		}
@@ -279,7 +388,14 @@ func (s *InventoryApplicationService) ReserveBulk(ctx context.Context, cmd Reser
 		item := itemsMap[itemReq.SKU]
 		locationID := locationSelections[itemReq.SKU]
 
-		if err := s.repo.Save(ctx, item); err != nil {
+		err := s.repo.Save(ctx, item)
+		if errors.IsConcurrencyConflict(err) {
+			// The item changed since phase 1; reserve against its latest state
+			_, _, err = s.updateItem(ctx, itemReq.SKU, func(item *domain.InventoryItem) error {
+				return item.ReserveWithPolicy(cmd.OrderID, locationID, itemReq.Quantity, nil, s.allocationPolicy(item.SellerID, cmd.Channel))
+			})
+		}
+		if err != nil {
 			s.logger.Error("Failed to save item after reservation", "sku", itemReq.SKU, "error", err)
 			// Note: At this point some items may have been saved.
 			// Consider implementing compensating transaction in production
@@ -308,28 +424,12 @@ func (s *InventoryApplicationService) ReserveBulk(ctx context.Context, cmd Reser
 
 // Pick picks stock (reduces quantity)
 func (s *InventoryApplicationService) Pick(ctx context.Context, cmd PickCommand) (*InventoryItemDTO, error) {
-	item, err := s.repo.FindBySKU(ctx, cmd.SKU)
-	if err != nil {
-		s.logger.Error("Failed to get item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to get item: %w", err)
-	}
-
-	if item == nil {
-		return nil, errors.ErrNotFound("item")
-	}
-
 	// Pick stock (domain logic)
-	if err := item.Pick(cmd.OrderID, cmd.LocationID, cmd.Quantity, cmd.CreatedBy); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	// Capture events before save
-	events := item.GetDomainEvents()
-
-	// Save the updated item
-	if err := s.repo.Save(ctx, item); err != nil {
-		s.logger.Error("Failed to save item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to save item: %w", err)
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.Pick(cmd.OrderID, cmd.LocationID, cmd.Quantity, cmd.CreatedBy)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Update CQRS projections (including pick-specific updates)
@@ -360,6 +460,13 @@ func (s *InventoryApplicationService) Pick(ctx context.Context, cmd PickCommand)
 		}
 	}
 
+	// Replenish the pick face if the pick took it below its minimum
+	if s.replenishment != nil {
+		if _, err := s.replenishment.ReplenishItem(ctx, item); err != nil {
+			s.logger.Warn("Failed to create replenishment tasks after pick", "sku", cmd.SKU, "error", err)
+		}
+	}
+
 	// Events are saved to outbox by repository in transaction
 
 	s.logger.Info("Picked stock", "sku", cmd.SKU, "orderId", cmd.OrderID, "quantity", cmd.Quantity)
@@ -368,25 +475,12 @@ func (s *InventoryApplicationService) Pick(ctx context.Context, cmd PickCommand)
 
 // ReleaseReservation releases a reservation
 func (s *InventoryApplicationService) ReleaseReservation(ctx context.Context, cmd ReleaseReservationCommand) (*InventoryItemDTO, error) {
-	item, err := s.repo.FindBySKU(ctx, cmd.SKU)
-	if err != nil {
-		s.logger.Error("Failed to get item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to get item: %w", err)
-	}
-
-	if item == nil {
-		return nil, errors.ErrNotFound("item")
-	}
-
 	// Release reservation (domain logic)
-	if err := item.ReleaseReservation(cmd.OrderID); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	// Save the updated item
-	if err := s.repo.Save(ctx, item); err != nil {
-		s.logger.Error("Failed to save item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to save item: %w", err)
+	item, _, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.ReleaseReservation(cmd.OrderID)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Update projections for reservation release
@@ -415,8 +509,14 @@ func (s *InventoryApplicationService) ReleaseByOrder(ctx context.Context, cmd Re
 			continue // Continue with other items
 		}
 
-		// Save the updated item
-		if err := s.repo.Save(ctx, item); err != nil {
+		// Save the updated item, releasing against the latest state if it changed since loading
+		err := s.repo.Save(ctx, item)
+		if errors.IsConcurrencyConflict(err) {
+			_, _, err = s.updateItem(ctx, item.SKU, func(item *domain.InventoryItem) error {
+				return item.ReleaseReservation(cmd.OrderID)
+			})
+		}
+		if err != nil {
 			s.logger.Error("Failed to save item after release", "sku", item.SKU, "error", err)
 			continue
 		}
@@ -435,38 +535,23 @@ func (s *InventoryApplicationService) ReleaseByOrder(ctx context.Context, cmd Re
 
 // Adjust adjusts inventory quantity
 func (s *InventoryApplicationService) Adjust(ctx context.Context, cmd AdjustCommand) (*InventoryItemDTO, error) {
-	item, err := s.repo.FindBySKU(ctx, cmd.SKU)
-	if err != nil {
-		s.logger.Error("Failed to get item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to get item: %w", err)
-	}
-
-	if item == nil {
-		return nil, errors.ErrNotFound("item")
-	}
-
-	// Calculate adjustment delta before modifying
-	oldQty := 0
-	for _, loc := range item.Locations {
-		if loc.LocationID == cmd.LocationID {
-			oldQty = loc.Quantity
-			break
+	var adjustmentQty int
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		// Calculate adjustment delta before modifying
+		oldQty := 0
+		for _, loc := range item.Locations {
+			if loc.LocationID == cmd.LocationID {
+				oldQty = loc.Quantity
+				break
+			}
 		}
-	}
-	adjustmentQty := cmd.NewQuantity - oldQty
-
-	// Adjust inventory (domain logic)
-	if err := item.Adjust(cmd.LocationID, cmd.NewQuantity, cmd.Reason, cmd.CreatedBy); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
+		adjustmentQty = cmd.NewQuantity - oldQty
 
-	// Capture events before save
-	events := item.GetDomainEvents()
-
-	// Save the updated item
-	if err := s.repo.Save(ctx, item); err != nil {
-		s.logger.Error("Failed to save item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to save item: %w", err)
+		// Adjust inventory (domain logic)
+		return item.Adjust(cmd.LocationID, cmd.NewQuantity, cmd.Reason, cmd.CreatedBy)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -513,6 +598,25 @@ func (s *InventoryApplicationService) Adjust(ctx context.Context, cmd AdjustComm
 	return ToInventoryItemDTO(item), nil
 }
 
+// SetCustomsProfile sets the customs data used for international shipments of a SKU
+func (s *InventoryApplicationService) SetCustomsProfile(ctx context.Context, cmd SetCustomsProfileCommand) (*InventoryItemDTO, error) {
+	item, _, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.SetCustomsProfile(domain.CustomsProfile{
+			HSCode:          cmd.HSCode,
+			CountryOfOrigin: cmd.CountryOfOrigin,
+			Description:     cmd.Description,
+			DeclaredValue:   cmd.DeclaredValue,
+			Currency:        cmd.Currency,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Set customs profile", "sku", cmd.SKU, "hsCode", item.Customs.HSCode, "countryOfOrigin", item.Customs.CountryOfOrigin)
+	return ToInventoryItemDTO(item), nil
+}
+
 // GetByLocation retrieves items by location
 func (s *InventoryApplicationService) GetByLocation(ctx context.Context, query GetByLocationQuery) ([]InventoryListDTO, error) {
 	items, err := s.repo.FindByLocation(ctx, query.LocationID)
@@ -559,28 +663,12 @@ func (s *InventoryApplicationService) ListInventory(ctx context.Context, query L
 
 // Stage converts a soft reservation to hard allocation (physical staging)
 func (s *InventoryApplicationService) Stage(ctx context.Context, cmd StageCommand) (*InventoryItemDTO, error) {
-	item, err := s.repo.FindBySKU(ctx, cmd.SKU)
-	if err != nil {
-		s.logger.Error("Failed to get item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to get item: %w", err)
-	}
-
-	if item == nil {
-		return nil, errors.ErrNotFound("item")
-	}
-
 	// Stage inventory (domain logic - converts soft to hard allocation)
-	if err := item.Stage(cmd.ReservationID, cmd.StagingLocationID, cmd.StagedBy); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	// Capture events before save
-	events := item.GetDomainEvents()
-
-	// Save the updated item
-	if err := s.repo.Save(ctx, item); err != nil {
-		s.logger.Error("Failed to save item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to save item: %w", err)
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.Stage(cmd.ReservationID, cmd.StagingLocationID, cmd.StagedBy)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -605,28 +693,12 @@ func (s *InventoryApplicationService) Stage(ctx context.Context, cmd StageComman
 
 // Pack marks a hard allocation as packed
 func (s *InventoryApplicationService) Pack(ctx context.Context, cmd PackCommand) (*InventoryItemDTO, error) {
-	item, err := s.repo.FindBySKU(ctx, cmd.SKU)
-	if err != nil {
-		s.logger.Error("Failed to get item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to get item: %w", err)
-	}
-
-	if item == nil {
-		return nil, errors.ErrNotFound("item")
-	}
-
 	// Pack inventory (domain logic)
-	if err := item.Pack(cmd.AllocationID, cmd.PackedBy); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	// Capture events before save
-	events := item.GetDomainEvents()
-
-	// Save the updated item
-	if err := s.repo.Save(ctx, item); err != nil {
-		s.logger.Error("Failed to save item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to save item: %w", err)
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.Pack(cmd.AllocationID, cmd.PackedBy)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -650,28 +722,12 @@ func (s *InventoryApplicationService) Pack(ctx context.Context, cmd PackCommand)
 
 // Ship ships a packed allocation (removes inventory from system)
 func (s *InventoryApplicationService) Ship(ctx context.Context, cmd ShipCommand) (*InventoryItemDTO, error) {
-	item, err := s.repo.FindBySKU(ctx, cmd.SKU)
-	if err != nil {
-		s.logger.Error("Failed to get item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to get item: %w", err)
-	}
-
-	if item == nil {
-		return nil, errors.ErrNotFound("item")
-	}
-
 	// Ship inventory (domain logic - removes from system)
-	if err := item.Ship(cmd.AllocationID); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	// Capture events before save
-	events := item.GetDomainEvents()
-
-	// Save the updated item
-	if err := s.repo.Save(ctx, item); err != nil {
-		s.logger.Error("Failed to save item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to save item: %w", err)
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.Ship(cmd.AllocationID)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -694,28 +750,12 @@ func (s *InventoryApplicationService) Ship(ctx context.Context, cmd ShipCommand)
 
 // ReturnToShelf returns hard allocated inventory back to shelf
 func (s *InventoryApplicationService) ReturnToShelf(ctx context.Context, cmd ReturnToShelfCommand) (*InventoryItemDTO, error) {
-	item, err := s.repo.FindBySKU(ctx, cmd.SKU)
-	if err != nil {
-		s.logger.Error("Failed to get item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to get item: %w", err)
-	}
-
-	if item == nil {
-		return nil, errors.ErrNotFound("item")
-	}
-
 	// Return to shelf (domain logic - moves from hard allocation back to available)
-	if err := item.ReturnToShelf(cmd.AllocationID, cmd.ReturnedBy, cmd.Reason); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	// Capture events before save
-	events := item.GetDomainEvents()
-
-	// Save the updated item
-	if err := s.repo.Save(ctx, item); err != nil {
-		s.logger.Error("Failed to save item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to save item: %w", err)
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.ReturnToShelf(cmd.AllocationID, cmd.ReturnedBy, cmd.Reason)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -740,28 +780,12 @@ func (s *InventoryApplicationService) ReturnToShelf(ctx context.Context, cmd Ret
 
 // RecordShortage records a confirmed stock shortage discovered during picking
 func (s *InventoryApplicationService) RecordShortage(ctx context.Context, cmd RecordShortageCommand) (*InventoryItemDTO, error) {
-	item, err := s.repo.FindBySKU(ctx, cmd.SKU)
-	if err != nil {
-		s.logger.Error("Failed to get item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to get item: %w", err)
-	}
-
-	if item == nil {
-		return nil, errors.ErrNotFound("item")
-	}
-
 	// Record shortage (domain logic - adjusts inventory and emits events)
-	if err := item.RecordShortage(cmd.LocationID, cmd.OrderID, cmd.ExpectedQty, cmd.ActualQty, cmd.Reason, cmd.ReportedBy); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	// Capture events before save
-	events := item.GetDomainEvents()
-
-	// Save the updated item
-	if err := s.repo.Save(ctx, item); err != nil {
-		s.logger.Error("Failed to save item", "sku", cmd.SKU, "error", err)
-		return nil, fmt.Errorf("failed to save item: %w", err)
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.RecordShortage(cmd.LocationID, cmd.OrderID, cmd.ExpectedQty, cmd.ActualQty, cmd.Reason, cmd.ReportedBy)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -794,6 +818,556 @@ func (s *InventoryApplicationService) RecordShortage(ctx context.Context, cmd Re
 	return ToInventoryItemDTO(item), nil
 }
 
+// PostCycleCount applies an approved cycle count variance to stock and records it in the
+// ledger as an adjustment. It returns the ledger transaction ID, empty when no entry was recorded.
+// A failed ledger write fails the post so the task is not marked posted; posting again does not
+// apply the variance to stock twice, and records the ledger adjustment only if it is still missing.
+func (s *InventoryApplicationService) PostCycleCount(ctx context.Context, task *domain.CycleCountTask) (string, error) {
+	postedBy := task.ApprovedBy
+	if postedBy == "" {
+		postedBy = task.LastAttempt().CountedBy
+	}
+
+	item, events, err := s.updateItem(ctx, task.SKU, func(item *domain.InventoryItem) error {
+		return item.PostCycleCount(task.LocationID, task.Variance, task.TaskID, postedBy)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Update CQRS projections
+	s.updateProjections(ctx, task.SKU, events)
+
+	// Record in ledger if ledger service is enabled
+	var transactionID string
+	if s.ledgerService != nil && task.Variance != 0 {
+		ledgerCmd := RecordAdjustmentCommand{
+			SKU:         task.SKU,
+			Quantity:    task.Variance,
+			Reason:      domain.CycleCountReason(task.TaskID),
+			LocationID:  task.LocationID,
+			ReferenceID: task.TaskID,
+			CreatedBy:   postedBy,
+			TenantID:    item.TenantID,
+			FacilityID:  item.FacilityID,
+			WarehouseID: item.WarehouseID,
+			SellerID:    item.SellerID,
+		}
+
+		transactionID, err = s.ledgerService.RecordAdjustmentOnce(ctx, ledgerCmd)
+		if err != nil {
+			s.logger.Error("Failed to record cycle count in ledger", "sku", task.SKU, "taskId", task.TaskID, "error", err)
+			return "", fmt.Errorf("failed to record cycle count in ledger: %w", err)
+		}
+	}
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "inventory.cycle_count_posted",
+		EntityType: "inventory",
+		EntityID:   task.SKU,
+		Action:     "cycle_count_posted",
+		RelatedIDs: map[string]string{
+			"taskId":     task.TaskID,
+			"locationId": task.LocationID,
+			"variance":   fmt.Sprintf("%d", task.Variance),
+		},
+	})
+
+	return transactionID, nil
+}
+
+// Replenish moves quantity of a replenishment task from its reserve location to the pick face
+func (s *InventoryApplicationService) Replenish(ctx context.Context, task *domain.ReplenishmentTask, quantity int, movedBy string) error {
+	item, events, err := s.updateItem(ctx, task.SKU, func(item *domain.InventoryItem) error {
+		return item.Replenish(task.TaskID, task.FromLocationID, task.ToLocationID, quantity, task.OrderIDs, movedBy)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Update CQRS projections
+	s.updateProjections(ctx, task.SKU, events)
+	s.recordTransfers(ctx, item, events)
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "inventory.replenished",
+		EntityType: "inventory",
+		EntityID:   task.SKU,
+		Action:     "replenished",
+		RelatedIDs: map[string]string{
+			"taskId":         task.TaskID,
+			"fromLocationId": task.FromLocationID,
+			"toLocationId":   task.ToLocationID,
+			"quantity":       fmt.Sprintf("%d", quantity),
+		},
+	})
+	return nil
+}
+
+// Reslot moves an item's stock to the new slot of an approved slotting recommendation and
+// returns the quantity moved. A move task already carried out returns the quantity it moved.
+func (s *InventoryApplicationService) Reslot(ctx context.Context, rec *domain.SlottingRecommendation, movedBy string) (int, error) {
+	moved := 0
+	alreadyMoved := false
+	item, events, err := s.updateItem(ctx, rec.SKU, func(item *domain.InventoryItem) error {
+		_, alreadyMoved = item.Reslotted(rec.MoveTaskID)
+		quantity, err := item.Reslot(rec.MoveTaskID, rec.FromLocationID, rec.TargetSlot(), movedBy)
+		moved = quantity
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if alreadyMoved {
+		return moved, nil
+	}
+
+	// Update CQRS projections
+	s.updateProjections(ctx, rec.SKU, events)
+	s.recordTransfers(ctx, item, events)
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "inventory.reslotted",
+		EntityType: "inventory",
+		EntityID:   rec.SKU,
+		Action:     "reslotted",
+		RelatedIDs: map[string]string{
+			"recommendationId": rec.RecommendationID,
+			"moveTaskId":       rec.MoveTaskID,
+			"fromLocationId":   rec.FromLocationID,
+			"toLocationId":     rec.ToLocationID,
+			"quantity":         fmt.Sprintf("%d", moved),
+		},
+	})
+	return moved, nil
+}
+
+// Transfer moves stock between two locations in one step, keeping its lots, status and
+// reservations, instead of a negative and a positive adjustment
+func (s *InventoryApplicationService) Transfer(ctx context.Context, cmd TransferStockCommand) (*InventoryItemDTO, error) {
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.Transfer(domain.StockTransfer{
+			FromLocationID: cmd.FromLocationID,
+			ToLocationID:   cmd.ToLocationID,
+			ToZone:         cmd.ToZone,
+			Quantity:       cmd.Quantity,
+			LotNumber:      cmd.LotNumber,
+			Status:         domain.InventoryStatus(cmd.Status),
+			OrderIDs:       cmd.OrderIDs,
+			Reason:         cmd.Reason,
+			ReferenceID:    cmd.ReferenceID,
+			MovedBy:        cmd.MovedBy,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.updateProjections(ctx, cmd.SKU, events)
+	s.recordTransfers(ctx, item, events)
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "inventory.transferred",
+		EntityType: "inventory",
+		EntityID:   cmd.SKU,
+		Action:     "transferred",
+		RelatedIDs: map[string]string{
+			"fromLocationId": cmd.FromLocationID,
+			"toLocationId":   cmd.ToLocationID,
+			"quantity":       fmt.Sprintf("%d", cmd.Quantity),
+		},
+	})
+
+	return ToInventoryItemDTO(item), nil
+}
+
+// StartMove picks stock onto a cart or tote for a two-step move. The stock is counted in
+// transit until DropMove; the returned string is the move ID to drop it with.
+func (s *InventoryApplicationService) StartMove(ctx context.Context, cmd StartMoveCommand) (*InventoryItemDTO, string, error) {
+	var moveID string
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		id, err := item.StartMove(domain.StockTransfer{
+			FromLocationID: cmd.FromLocationID,
+			ToLocationID:   cmd.ToLocationID,
+			ToZone:         cmd.ToZone,
+			Quantity:       cmd.Quantity,
+			LotNumber:      cmd.LotNumber,
+			Status:         domain.InventoryStatus(cmd.Status),
+			Reason:         cmd.Reason,
+			ReferenceID:    cmd.ReferenceID,
+			MovedBy:        cmd.MovedBy,
+		}, cmd.ContainerID)
+		moveID = id
+		return err
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.updateProjections(ctx, cmd.SKU, events)
+	s.recordTransfers(ctx, item, events)
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "inventory.move_started",
+		EntityType: "inventory",
+		EntityID:   cmd.SKU,
+		Action:     "move_started",
+		RelatedIDs: map[string]string{
+			"moveId":         moveID,
+			"containerId":    cmd.ContainerID,
+			"fromLocationId": cmd.FromLocationID,
+			"quantity":       fmt.Sprintf("%d", cmd.Quantity),
+		},
+	})
+
+	return ToInventoryItemDTO(item), moveID, nil
+}
+
+// DropMove puts the stock of a two-step move at its destination
+func (s *InventoryApplicationService) DropMove(ctx context.Context, cmd DropMoveCommand) (*InventoryItemDTO, error) {
+	item, events, err := s.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.DropMove(cmd.MoveID, cmd.ToLocationID, cmd.ToZone, cmd.DroppedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.updateProjections(ctx, cmd.SKU, events)
+	s.recordTransfers(ctx, item, events)
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "inventory.move_dropped",
+		EntityType: "inventory",
+		EntityID:   cmd.SKU,
+		Action:     "move_dropped",
+		RelatedIDs: map[string]string{
+			"moveId":       cmd.MoveID,
+			"toLocationId": cmd.ToLocationID,
+		},
+	})
+
+	return ToInventoryItemDTO(item), nil
+}
+
+// MoveContainer moves all contents of an LPN or tote, every SKU it holds, to another location
+func (s *InventoryApplicationService) MoveContainer(ctx context.Context, cmd MoveContainerCommand) (*ContainerMoveDTO, error) {
+	items, err := s.repo.FindByLocation(ctx, cmd.LocationID)
+	if err != nil {
+		s.logger.Error("Failed to get items", "locationId", cmd.LocationID, "error", err)
+		return nil, fmt.Errorf("failed to get items: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, errors.ErrNotFound("location")
+	}
+
+	result := &ContainerMoveDTO{
+		LocationID:   cmd.LocationID,
+		ToLocationID: cmd.ToLocationID,
+		Items:        make(map[string]int),
+	}
+	for _, found := range items {
+		moved := 0
+		item, events, err := s.updateItem(ctx, found.SKU, func(item *domain.InventoryItem) error {
+			quantity, err := item.MoveAllStock(cmd.LocationID, cmd.ToLocationID, cmd.ToZone, cmd.Reason, cmd.ReferenceID, cmd.MovedBy)
+			moved = quantity
+			return err
+		})
+		if err != nil {
+			// SKUs already moved stay at the destination; moving the container again picks up the rest
+			s.logger.Warn("Container moved partially", "locationId", cmd.LocationID, "sku", found.SKU, "error", err)
+			return nil, err
+		}
+		if moved == 0 {
+			continue
+		}
+
+		s.updateProjections(ctx, item.SKU, events)
+		s.recordTransfers(ctx, item, events)
+		result.Items[item.SKU] = moved
+		result.Quantity += moved
+	}
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "inventory.container_moved",
+		EntityType: "location",
+		EntityID:   cmd.LocationID,
+		Action:     "moved",
+		RelatedIDs: map[string]string{
+			"toLocationId": cmd.ToLocationID,
+			"skus":         fmt.Sprintf("%d", len(result.Items)),
+			"quantity":     fmt.Sprintf("%d", result.Quantity),
+		},
+	})
+
+	return result, nil
+}
+
+// recordTransfers records stock moved between locations in the ledger, if ledger service is
+// enabled. Stock picked onto a cart or tote is carried as goods in transit until dropped.
+// stow-service keeps location capacity current from the same move events, published through
+// the outbox with the item.
+func (s *InventoryApplicationService) recordTransfers(ctx context.Context, item *domain.InventoryItem, events []domain.DomainEvent) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *domain.StockMovedEvent:
+			account := transferStatus(e.Status).LedgerAccount()
+			from, fromLocationID := account, e.FromLocationID
+			if e.MoveID != "" {
+				// Dropped from a cart: the source was emptied when the move started
+				from, fromLocationID = domain.AccountGoodsInTransit, e.ContainerID
+			}
+			s.recordTransfer(ctx, item, e.Quantity, from, account, fromLocationID, e.ToLocationID, e.ReferenceID, e.MovedBy)
+		case *domain.StockMoveStartedEvent:
+			account := transferStatus(e.Status).LedgerAccount()
+			s.recordTransfer(ctx, item, e.Quantity, account, domain.AccountGoodsInTransit, e.FromLocationID, e.ContainerID, e.MoveID, e.MovedBy)
+		}
+	}
+}
+
+// transferStatus returns the inventory status of moved stock; events leave it empty for available stock
+func transferStatus(status string) domain.InventoryStatus {
+	if status == "" {
+		return domain.StatusAvailable
+	}
+	return domain.InventoryStatus(status)
+}
+
+func (s *InventoryApplicationService) recordTransfer(ctx context.Context, item *domain.InventoryItem, quantity int, from, to domain.AccountType, fromLocationID, toLocationID, referenceID, movedBy string) {
+	if s.ledgerService == nil {
+		return
+	}
+
+	ledgerCmd := RecordTransferCommand{
+		SKU:            item.SKU,
+		Quantity:       quantity,
+		FromAccount:    from,
+		ToAccount:      to,
+		FromLocationID: fromLocationID,
+		ToLocationID:   toLocationID,
+		ReferenceID:    referenceID,
+		CreatedBy:      movedBy,
+		TenantID:       item.TenantID,
+		FacilityID:     item.FacilityID,
+		WarehouseID:    item.WarehouseID,
+		SellerID:       item.SellerID,
+	}
+	if _, err := s.ledgerService.RecordTransfer(ctx, ledgerCmd); err != nil {
+		// Log error but don't fail the operation - ledger is supplementary
+		s.logger.Warn("Failed to record transfer in ledger", "sku", item.SKU, "error", err)
+	}
+}
+
+// unitCost returns the item's average unit cost from the ledger, or 0 when it is unknown
+func (s *InventoryApplicationService) unitCost(ctx context.Context, item *domain.InventoryItem) float64 {
+	if s.ledgerService == nil {
+		return 0
+	}
+	ledger, err := s.ledgerService.GetLedger(ctx, GetLedgerQuery{
+		SKU:        item.SKU,
+		TenantID:   item.TenantID,
+		FacilityID: item.FacilityID,
+	})
+	if err != nil {
+		s.logger.Debug("No ledger cost for item", "sku", item.SKU, "error", err)
+		return 0
+	}
+	return float64(ledger.AverageUnitCost.Amount) / 100
+}
+
+// ProcessLotExpiry blocks expired lots and publishes warnings for lots nearing expiry
+func (s *InventoryApplicationService) ProcessLotExpiry(ctx context.Context, cmd ProcessLotExpiryCommand) (*LotExpiryResultDTO, error) {
+	now := time.Now()
+	items, err := s.repo.FindWithLotsExpiringBefore(ctx, now, now.Add(cmd.WarningWindow), cmd.BatchSize)
+	if err != nil {
+		s.logger.Error("Failed to find items with expiring lots", "error", err)
+		return nil, fmt.Errorf("failed to find items with expiring lots: %w", err)
+	}
+
+	result := &LotExpiryResultDTO{ItemsChecked: len(items)}
+	for _, item := range items {
+		warned, blocked := item.CheckLotExpiry(now, cmd.WarningWindow)
+		if warned == 0 && blocked == 0 {
+			continue
+		}
+
+		// Events are saved to outbox by repository in transaction
+		if err := s.repo.Save(ctx, item); err != nil {
+			s.logger.Error("Failed to save item after lot expiry check", "sku", item.SKU, "error", err)
+			continue
+		}
+
+		result.LotsWarned += warned
+		result.LotsBlocked += blocked
+	}
+
+	if result.LotsWarned > 0 || result.LotsBlocked > 0 {
+		s.logger.Info("Processed lot expiry",
+			"itemsChecked", result.ItemsChecked,
+			"lotsWarned", result.LotsWarned,
+			"lotsBlocked", result.LotsBlocked,
+		)
+	}
+
+	return result, nil
+}
+
+// SweepExpiredReservations releases active reservations that have passed their expiry time,
+// along with any units reserved for them in unit-service
+func (s *InventoryApplicationService) SweepExpiredReservations(ctx context.Context, cmd SweepExpiredReservationsCommand) (*ReservationSweepResultDTO, error) {
+	now := time.Now()
+	items, err := s.repo.FindWithExpiredReservations(ctx, now, cmd.TenantID, cmd.BatchSize)
+	if err != nil {
+		s.logger.Error("Failed to find items with expired reservations", "tenantId", cmd.TenantID, "error", err)
+		return nil, fmt.Errorf("failed to find items with expired reservations: %w", err)
+	}
+
+	result := &ReservationSweepResultDTO{
+		DryRun:       cmd.DryRun,
+		TenantID:     cmd.TenantID,
+		ItemsChecked: len(items),
+		Released:     make([]ExpiredReservationDTO, 0),
+	}
+
+	for _, item := range items {
+		expired := item.ExpiredReservations(now)
+		if cmd.DryRun {
+			for _, res := range expired {
+				result.Released = append(result.Released, toExpiredReservationDTO(item, res))
+			}
+			continue
+		}
+
+		released := make([]domain.Reservation, 0, len(expired))
+		for _, res := range expired {
+			if err := item.ExpireReservation(res.ReservationID, now); err != nil {
+				s.logger.Warn("Failed to expire reservation", "sku", item.SKU, "reservationId", res.ReservationID, "error", err)
+				result.Failed++
+				continue
+			}
+			released = append(released, res)
+		}
+
+		if len(released) > 0 {
+			// Events are saved to outbox by repository in transaction. Expired reservations
+			// holding units are saved with a pending unit release, so units are released
+			// below or by a later sweep even if this process stops in between.
+			if err := s.repo.Save(ctx, item); err != nil {
+				s.logger.Error("Failed to save item after expiring reservations", "sku", item.SKU, "error", err)
+				result.Failed += len(released)
+				continue
+			}
+
+			for _, res := range released {
+				if s.projector != nil {
+					_ = s.projector.OnInventoryReserved(ctx, item.SKU, res.OrderID)
+				}
+				result.Released = append(result.Released, toExpiredReservationDTO(item, res))
+			}
+		}
+
+		s.releasePendingUnits(ctx, item)
+	}
+
+	if len(result.Released) > 0 || result.Failed > 0 {
+		s.logger.Info("Swept expired reservations",
+			"tenantId", cmd.TenantID,
+			"dryRun", cmd.DryRun,
+			"released", len(result.Released),
+			"failed", result.Failed,
+		)
+	}
+
+	return result, nil
+}
+
+// releasePendingUnits releases the units of expired reservations in unit-service and clears
+// their pending marker. Releases that fail stay pending and are retried by the next sweep;
+// unit-service skips units that are no longer reserved, so retrying is safe.
+func (s *InventoryApplicationService) releasePendingUnits(ctx context.Context, item *domain.InventoryItem) {
+	if s.unitReleaser == nil {
+		return
+	}
+
+	releasedAny := false
+	for _, res := range item.PendingUnitReleases() {
+		req := domain.UnitReleaseRequest{
+			TenantID:    item.TenantID,
+			FacilityID:  item.FacilityID,
+			WarehouseID: item.WarehouseID,
+			SellerID:    item.SellerID,
+			OrderID:     res.OrderID,
+			UnitIDs:     res.UnitIDs,
+			Reason:      "Reservation expired",
+		}
+		if err := s.unitReleaser.ReleaseUnits(ctx, req); err != nil {
+			s.logger.Warn("Failed to release units for expired reservation, will retry on next sweep",
+				"sku", item.SKU,
+				"reservationId", res.ReservationID,
+				"orderId", res.OrderID,
+				"error", err,
+			)
+			continue
+		}
+		if err := item.MarkUnitsReleased(res.ReservationID); err == nil {
+			releasedAny = true
+		}
+	}
+	if !releasedAny {
+		return
+	}
+
+	if err := s.repo.Save(ctx, item); err != nil {
+		s.logger.Warn("Failed to clear pending unit releases, will retry on next sweep", "sku", item.SKU, "error", err)
+	}
+}
+
+// updateItem loads an item, applies change and saves it. When another writer saved the
+// item in between, the save fails with a concurrency conflict and the item is reloaded
+// and change applied again. Errors from change are reported as validation errors. It
+// returns the saved item and the domain events change raised.
+func (s *InventoryApplicationService) updateItem(ctx context.Context, sku string, change func(item *domain.InventoryItem) error) (*domain.InventoryItem, []domain.DomainEvent, error) {
+	var item *domain.InventoryItem
+	var events []domain.DomainEvent
+
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.repo.FindBySKU(ctx, sku)
+		if err != nil {
+			s.logger.Error("Failed to get item", "sku", sku, "error", err)
+			return fmt.Errorf("failed to get item: %w", err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("item")
+		}
+
+		if err := change(loaded); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+
+		// Capture events before save
+		captured := loaded.GetDomainEvents()
+		if err := s.repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				s.logger.Debug("Item changed concurrently, retrying", "sku", sku)
+				return err
+			}
+			s.logger.Error("Failed to save item", "sku", sku, "error", err)
+			return fmt.Errorf("failed to save item: %w", err)
+		}
+
+		item = loaded
+		events = captured
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		s.logger.Warn("Gave up saving item after repeated concurrency conflicts", "sku", sku, "error", err)
+		return nil, nil, errors.ErrConflict(fmt.Sprintf("item %s was modified concurrently, please retry", sku)).Wrap(err)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return item, events, nil
+}
+
 // updateProjections updates the CQRS read model based on domain events
 // Call this after successfully saving an inventory item to keep projections in sync
 func (s *InventoryApplicationService) updateProjections(ctx context.Context, sku string, events []domain.DomainEvent) {
@@ -814,6 +1388,12 @@ func (s *InventoryApplicationService) updateProjections(ctx context.Context, sku
 			err = s.projector.OnStockShortage(ctx, e)
 		case *domain.InventoryDiscrepancyEvent:
 			err = s.projector.OnInventoryDiscrepancy(ctx, e)
+		case *domain.StockMovedEvent:
+			err = s.projector.OnStockMoved(ctx, e)
+		case *domain.InventoryStatusChangedEvent:
+			err = s.projector.OnInventoryStatusChanged(ctx, e)
+		case *domain.StockMoveStartedEvent:
+			err = s.projector.OnStockMoveStarted(ctx, e)
 		}
 
 		if err != nil {
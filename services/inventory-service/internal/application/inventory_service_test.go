@@ -2,12 +2,15 @@ package application
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/wms-platform/inventory-service/internal/domain"
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/logging"
 )
 
@@ -21,6 +24,7 @@ type fakeInventoryRepo struct {
 	findLowStockErr error
 	findAllErr      error
 	deleteErr       error
+	published       []domain.DomainEvent
 }
 
 func (f *fakeInventoryRepo) Save(ctx context.Context, item *domain.InventoryItem) error {
@@ -31,6 +35,8 @@ func (f *fakeInventoryRepo) Save(ctx context.Context, item *domain.InventoryItem
 		f.items = make(map[string]*domain.InventoryItem)
 	}
 	f.items[item.SKU] = item
+	f.published = append(f.published, item.GetDomainEvents()...)
+	item.ClearDomainEvents() // Published through the outbox on save
 	return nil
 }
 
@@ -116,6 +122,62 @@ func (f *fakeInventoryRepo) FindAll(ctx context.Context, limit, offset int) ([]*
 	return results, nil
 }
 
+func (f *fakeInventoryRepo) FindWithLotsExpiringBefore(ctx context.Context, now, cutoff time.Time, limit int) ([]*domain.InventoryItem, error) {
+	results := make([]*domain.InventoryItem, 0)
+	for _, item := range f.items {
+		for _, lot := range item.GetLots() {
+			if lot.Status != domain.LotStatusActive || lot.ExpiryDate == nil || lot.ExpiryDate.After(cutoff) {
+				continue
+			}
+			if lot.ExpiryWarnedAt == nil || !lot.ExpiryDate.After(now) {
+				results = append(results, item)
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+func (f *fakeInventoryRepo) FindWithExpiredReservations(ctx context.Context, asOf time.Time, tenantID string, limit int) ([]*domain.InventoryItem, error) {
+	results := make([]*domain.InventoryItem, 0)
+	for _, item := range f.items {
+		if tenantID != "" && item.TenantID != tenantID {
+			continue
+		}
+		if len(item.ExpiredReservations(asOf)) > 0 || len(item.PendingUnitReleases()) > 0 {
+			results = append(results, item)
+		}
+	}
+	return results, nil
+}
+
+func (f *fakeInventoryRepo) FindDueForCycleCount(ctx context.Context, cutoffs map[domain.VelocityClass]time.Time, limit int) ([]*domain.InventoryItem, error) {
+	results := make([]*domain.InventoryItem, 0)
+	for _, item := range f.items {
+		if item.TotalQuantity <= 0 {
+			continue
+		}
+		cutoff, ok := cutoffs[item.VelocityClass]
+		if !ok {
+			cutoff = cutoffs[domain.VelocityC]
+		}
+		if item.LastCycleCount == nil || item.LastCycleCount.Before(cutoff) {
+			results = append(results, item)
+		}
+	}
+	return results, nil
+}
+
+func (f *fakeInventoryRepo) FindBelowPickFaceMinimum(ctx context.Context, limit int) ([]*domain.InventoryItem, error) {
+	results := make([]*domain.InventoryItem, 0)
+	for _, item := range f.items {
+		if len(item.ReplenishmentNeeds()) > 0 {
+			results = append(results, item)
+		}
+	}
+	return results, nil
+}
+
 func (f *fakeInventoryRepo) Delete(ctx context.Context, sku string) error {
 	if f.deleteErr != nil {
 		return f.deleteErr
@@ -318,3 +380,168 @@ func TestInventoryApplicationService_RecordShortage(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 2, repo.items["SKU-1"].TotalQuantity)
 }
+
+// conflictingInventoryRepo simulates another writer saving the item between load and save
+type conflictingInventoryRepo struct {
+	fakeInventoryRepo
+	conflicts int
+	saves     int
+	latest    func() *domain.InventoryItem
+}
+
+func (r *conflictingInventoryRepo) Save(ctx context.Context, item *domain.InventoryItem) error {
+	r.saves++
+	if r.conflicts > 0 {
+		r.conflicts--
+		r.items[item.SKU] = r.latest()
+		return sharedErrors.NewConcurrencyConflictError("InventoryItem", item.SKU, item.Version)
+	}
+	return r.fakeInventoryRepo.Save(ctx, item)
+}
+
+func TestInventoryApplicationService_RetriesOnConcurrencyConflict(t *testing.T) {
+	repo := &conflictingInventoryRepo{
+		fakeInventoryRepo: fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newItemWithStock("SKU-1", 10)}},
+		conflicts:         1,
+		latest:            func() *domain.InventoryItem { return newItemWithStock("SKU-1", 20) },
+	}
+	svc := NewInventoryApplicationService(repo, nil, nil, nil, logging.New(logging.DefaultConfig("test")))
+
+	_, err := svc.Reserve(context.Background(), ReserveCommand{
+		SKU:        "SKU-1",
+		OrderID:    "ORD-1",
+		LocationID: "LOC-1",
+		Quantity:   5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.saves)
+
+	// The reservation is applied once, on top of the concurrent writer's state
+	stored := repo.items["SKU-1"]
+	assert.Equal(t, 20, stored.TotalQuantity)
+	assert.Equal(t, 5, stored.ReservedQuantity)
+	assert.Equal(t, 15, stored.AvailableQuantity)
+}
+
+func TestInventoryApplicationService_ConflictRetriesExhausted(t *testing.T) {
+	repo := &conflictingInventoryRepo{
+		fakeInventoryRepo: fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newItemWithStock("SKU-1", 10)}},
+		conflicts:         100,
+		latest:            func() *domain.InventoryItem { return newItemWithStock("SKU-1", 10) },
+	}
+	svc := NewInventoryApplicationService(repo, nil, nil, nil, logging.New(logging.DefaultConfig("test")))
+
+	_, err := svc.ReceiveStock(context.Background(), ReceiveStockCommand{
+		SKU:         "SKU-1",
+		LocationID:  "LOC-1",
+		Zone:        "ZONE-A",
+		Quantity:    5,
+		ReferenceID: "PO-2",
+		CreatedBy:   "user1",
+	})
+	require.Error(t, err)
+	assert.True(t, sharedErrors.IsConcurrencyConflict(err))
+
+	var appErr *sharedErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "CONFLICT", appErr.Code)
+}
+
+// movedStock returns the location changes of the stock move events published on save, as
+// stow-service applies them to location capacity: quantity and weight by location ID
+func (f *fakeInventoryRepo) movedStock() []string {
+	moves := make([]string, 0)
+	for _, event := range f.published {
+		switch e := event.(type) {
+		case *domain.StockMovedEvent:
+			if e.MoveID == "" {
+				moves = append(moves, fmt.Sprintf("%s %d %.1f", e.FromLocationID, -e.Quantity, -e.UnitWeight*float64(e.Quantity)))
+			}
+			moves = append(moves, fmt.Sprintf("%s %d %.1f", e.ToLocationID, e.Quantity, e.UnitWeight*float64(e.Quantity)))
+		case *domain.StockMoveStartedEvent:
+			moves = append(moves, fmt.Sprintf("%s %d %.1f", e.FromLocationID, -e.Quantity, -e.UnitWeight*float64(e.Quantity)))
+		}
+	}
+	return moves
+}
+
+func TestInventoryApplicationService_TransferAndTwoStepMove(t *testing.T) {
+	item := newItemWithStock("SKU-1", 10)
+	require.NoError(t, item.SetSlottingProfile(domain.SlottingProfile{UnitWeight: 0.5}))
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": item}}
+	svc := newTestService(repo)
+	ctx := context.Background()
+
+	dto, err := svc.Transfer(ctx, TransferStockCommand{
+		SKU:            "SKU-1",
+		FromLocationID: "LOC-1",
+		ToLocationID:   "LOC-2",
+		ToZone:         "ZONE-A",
+		Quantity:       4,
+		MovedBy:        "user1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 10, dto.TotalQuantity)
+	assert.Len(t, dto.Locations, 2)
+	assert.Equal(t, []string{"LOC-1 -4 -2.0", "LOC-2 4 2.0"}, repo.movedStock())
+
+	repo.published = nil
+	dto, moveID, err := svc.StartMove(ctx, StartMoveCommand{
+		SKU:            "SKU-1",
+		FromLocationID: "LOC-1",
+		ContainerID:    "CART-7",
+		Quantity:       6,
+		MovedBy:        "picker1",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, moveID)
+	assert.Equal(t, 6, dto.InTransitQuantity)
+	assert.Equal(t, 4, dto.AvailableQuantity)
+	require.Len(t, dto.InTransit, 1)
+	assert.Equal(t, "CART-7", dto.InTransit[0].ContainerID)
+
+	dto, err = svc.DropMove(ctx, DropMoveCommand{SKU: "SKU-1", MoveID: moveID, ToLocationID: "LOC-3", ToZone: "ZONE-B", DroppedBy: "stower1"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, dto.InTransitQuantity)
+	assert.Equal(t, 10, dto.AvailableQuantity)
+	assert.Equal(t, []string{"LOC-1 -6 -3.0", "LOC-3 6 3.0"}, repo.movedStock(), "the cart itself isn't tracked")
+
+	_, err = svc.DropMove(ctx, DropMoveCommand{SKU: "SKU-1", MoveID: moveID, ToLocationID: "LOC-3", DroppedBy: "stower1"})
+	var appErr *sharedErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code)
+
+	_, err = svc.Transfer(ctx, TransferStockCommand{SKU: "MISSING", FromLocationID: "LOC-1", ToLocationID: "LOC-2", Quantity: 1})
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeNotFound, appErr.Code)
+}
+
+func TestInventoryApplicationService_MoveContainer(t *testing.T) {
+	widget := domain.NewInventoryItem("SKU-1", "Widget", 5, 10)
+	require.NoError(t, widget.ReceiveStock("LPN-1", "INBOUND", 6, "PO-1", "user1"))
+	gadget := domain.NewInventoryItem("SKU-2", "Gadget", 5, 10)
+	require.NoError(t, gadget.ReceiveStock("LPN-1", "INBOUND", 3, "PO-1", "user1"))
+	require.NoError(t, gadget.ReceiveStock("LOC-9", "ZONE-A", 2, "PO-1", "user1"))
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": widget, "SKU-2": gadget}}
+	svc := newTestService(repo)
+	ctx := context.Background()
+
+	result, err := svc.MoveContainer(ctx, MoveContainerCommand{
+		LocationID:   "LPN-1",
+		ToLocationID: "LOC-9",
+		ToZone:       "ZONE-A",
+		MovedBy:      "user1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 9, result.Quantity)
+	assert.Equal(t, map[string]int{"SKU-1": 6, "SKU-2": 3}, result.Items)
+	assert.Equal(t, 6, repo.items["SKU-1"].GetLocationStock("LOC-9").Quantity)
+	assert.Equal(t, 5, repo.items["SKU-2"].GetLocationStock("LOC-9").Quantity)
+	assert.Equal(t, 0, repo.items["SKU-2"].GetLocationStock("LPN-1").Quantity)
+	assert.Len(t, repo.movedStock(), 4)
+
+	_, err = svc.MoveContainer(ctx, MoveContainerCommand{LocationID: "LPN-404", ToLocationID: "LOC-9", MovedBy: "user1"})
+	var appErr *sharedErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeNotFound, appErr.Code)
+}
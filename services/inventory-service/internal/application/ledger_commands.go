@@ -1,5 +1,7 @@
 package application
 
+import "github.com/wms-platform/inventory-service/internal/domain"
+
 // RecordReceivingCommand represents the command to record stock receiving in ledger
 type RecordReceivingCommand struct {
 	SKU         string
@@ -42,6 +44,39 @@ type RecordAdjustmentCommand struct {
 	SellerID    string
 }
 
+// RecordStatusChangeCommand represents the command to reclassify stock that changed inventory
+// status between the ledger accounts of its statuses
+type RecordStatusChangeCommand struct {
+	SKU         string
+	Quantity    int
+	FromAccount domain.AccountType
+	ToAccount   domain.AccountType
+	Reason      string
+	LocationID  string
+	ReferenceID string
+	CreatedBy   string
+	TenantID    string
+	FacilityID  string
+	WarehouseID string
+	SellerID    string
+}
+
+// RecordTransferCommand represents the command to record stock moving between locations
+type RecordTransferCommand struct {
+	SKU            string
+	Quantity       int
+	FromAccount    domain.AccountType
+	ToAccount      domain.AccountType
+	FromLocationID string
+	ToLocationID   string
+	ReferenceID    string
+	CreatedBy      string
+	TenantID       string
+	FacilityID     string
+	WarehouseID    string
+	SellerID       string
+}
+
 // CreateLedgerCommand represents the command to create a new inventory ledger
 type CreateLedgerCommand struct {
 	SKU             string
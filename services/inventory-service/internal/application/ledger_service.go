@@ -148,6 +148,74 @@ func (s *LedgerApplicationService) RecordAdjustment(ctx context.Context, cmd Rec
 	return transactionID.String(), nil
 }
 
+// RecordAdjustmentOnce records an inventory adjustment in ledger unless one was already recorded
+// for the command's reference, in which case the existing transaction ID is returned
+func (s *LedgerApplicationService) RecordAdjustmentOnce(ctx context.Context, cmd RecordAdjustmentCommand) (string, error) {
+	existing, err := s.entryRepo.FindByReference(ctx, cmd.TenantID, cmd.FacilityID, cmd.SKU, "adjustment", cmd.ReferenceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find adjustment entries: %w", err)
+	}
+	if len(existing) > 0 {
+		return existing[0].Entry.TransactionID.String(), nil
+	}
+
+	return s.RecordAdjustment(ctx, cmd)
+}
+
+// RecordStatusChange records stock moving between the ledger accounts of two inventory statuses
+func (s *LedgerApplicationService) RecordStatusChange(ctx context.Context, cmd RecordStatusChangeCommand) (string, error) {
+	// Get ledger
+	ledger, err := s.ledgerRepo.FindBySKU(ctx, cmd.TenantID, cmd.FacilityID, cmd.SKU)
+	if err != nil {
+		return "", fmt.Errorf("failed to get ledger: %w", err)
+	}
+
+	// Record status change
+	transactionID, entries, err := ledger.RecordStatusChange(cmd.Quantity, cmd.FromAccount, cmd.ToAccount, cmd.Reason, cmd.LocationID, cmd.ReferenceID, cmd.CreatedBy)
+	if err != nil {
+		return "", fmt.Errorf("failed to record status change: %w", err)
+	}
+
+	// Save ledger
+	if err := s.ledgerRepo.Save(ctx, ledger); err != nil {
+		return "", fmt.Errorf("failed to save ledger: %w", err)
+	}
+
+	// Save entries
+	if err := s.saveEntries(ctx, entries, ledger.TenantID, ledger.FacilityID, ledger.WarehouseID, ledger.SellerID); err != nil {
+		return "", fmt.Errorf("failed to save entries: %w", err)
+	}
+
+	return transactionID.String(), nil
+}
+
+// RecordTransfer records stock moving between locations or into and out of transit
+func (s *LedgerApplicationService) RecordTransfer(ctx context.Context, cmd RecordTransferCommand) (string, error) {
+	// Get ledger
+	ledger, err := s.ledgerRepo.FindBySKU(ctx, cmd.TenantID, cmd.FacilityID, cmd.SKU)
+	if err != nil {
+		return "", fmt.Errorf("failed to get ledger: %w", err)
+	}
+
+	// Record transfer
+	transactionID, entries, err := ledger.RecordTransfer(cmd.Quantity, cmd.FromAccount, cmd.ToAccount, cmd.FromLocationID, cmd.ToLocationID, cmd.ReferenceID, cmd.CreatedBy)
+	if err != nil {
+		return "", fmt.Errorf("failed to record transfer: %w", err)
+	}
+
+	// Save ledger
+	if err := s.ledgerRepo.Save(ctx, ledger); err != nil {
+		return "", fmt.Errorf("failed to save ledger: %w", err)
+	}
+
+	// Save entries
+	if err := s.saveEntries(ctx, entries, ledger.TenantID, ledger.FacilityID, ledger.WarehouseID, ledger.SellerID); err != nil {
+		return "", fmt.Errorf("failed to save entries: %w", err)
+	}
+
+	return transactionID.String(), nil
+}
+
 // GetLedger retrieves a ledger by SKU
 func (s *LedgerApplicationService) GetLedger(ctx context.Context, query GetLedgerQuery) (*LedgerDTO, error) {
 	ledger, err := s.ledgerRepo.FindBySKU(ctx, query.TenantID, query.FacilityID, query.SKU)
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+// LotExpiryMonitor periodically blocks expired lots and warns about lots nearing expiry
+type LotExpiryMonitor struct {
+	service  *InventoryApplicationService
+	config   LotExpiryMonitorConfig
+	logger   *logging.Logger
+	mu       sync.RWMutex
+	running  bool
+	stopChan chan struct{}
+}
+
+// LotExpiryMonitorConfig configuration for the lot expiry monitor
+type LotExpiryMonitorConfig struct {
+	// CheckInterval is how often to scan for expiring lots
+	CheckInterval time.Duration `json:"checkInterval"`
+
+	// WarningWindow is how far ahead of expiry to publish a warning
+	WarningWindow time.Duration `json:"warningWindow"`
+
+	// BatchSize is the maximum number of items processed per check
+	BatchSize int `json:"batchSize"`
+}
+
+// DefaultLotExpiryMonitorConfig returns default configuration
+func DefaultLotExpiryMonitorConfig() LotExpiryMonitorConfig {
+	return LotExpiryMonitorConfig{
+		CheckInterval: 1 * time.Hour,
+		WarningWindow: 30 * 24 * time.Hour,
+		BatchSize:     500,
+	}
+}
+
+// NewLotExpiryMonitor creates a new lot expiry monitor
+func NewLotExpiryMonitor(
+	service *InventoryApplicationService,
+	config LotExpiryMonitorConfig,
+	logger *logging.Logger,
+) *LotExpiryMonitor {
+	return &LotExpiryMonitor{
+		service:  service,
+		config:   config,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the periodic lot expiry check
+func (m *LotExpiryMonitor) Start(ctx context.Context) error {
+	m.mu.Lock()
+	if m.running {
+		m.mu.Unlock()
+		return fmt.Errorf("lot expiry monitor is already running")
+	}
+	m.running = true
+	m.stopChan = make(chan struct{})
+	m.mu.Unlock()
+
+	go m.run(ctx)
+	return nil
+}
+
+// Stop stops the periodic lot expiry check
+func (m *LotExpiryMonitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.running {
+		close(m.stopChan)
+		m.running = false
+	}
+}
+
+// IsRunning returns whether the monitor is running
+func (m *LotExpiryMonitor) IsRunning() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.running
+}
+
+// run is the main loop for the lot expiry monitor
+func (m *LotExpiryMonitor) run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.stopChan:
+			return
+		case <-ticker.C:
+			if _, err := m.service.ProcessLotExpiry(ctx, ProcessLotExpiryCommand{
+				WarningWindow: m.config.WarningWindow,
+				BatchSize:     m.config.BatchSize,
+			}); err != nil {
+				// Log error but continue
+				m.logger.Error("Lot expiry check failed", "error", err)
+			}
+		}
+	}
+}
@@ -20,6 +20,12 @@ func ToInventoryItemDTO(item *domain.InventoryItem) *InventoryItemDTO {
 			Reserved:      loc.Reserved,
 			HardAllocated: loc.HardAllocated,
 			Available:     loc.Available,
+			Blocked:       loc.Blocked,
+			MinQuantity:   loc.MinQuantity,
+			MaxQuantity:   loc.MaxQuantity,
+			Lots:          toStockLotDTOs(loc.Lots),
+			NonSellable:   loc.NonSellable(),
+			Statuses:      toStatusBucketDTOs(loc.Statuses),
 		})
 	}
 
@@ -33,6 +39,7 @@ func ToInventoryItemDTO(item *domain.InventoryItem) *InventoryItemDTO {
 			Status:        res.Status,
 			CreatedAt:     res.CreatedAt,
 			ExpiresAt:     res.ExpiresAt,
+			Lots:          toLotAllocationDTOs(res.Lots),
 		})
 	}
 
@@ -62,16 +69,50 @@ func ToInventoryItemDTO(item *domain.InventoryItem) *InventoryItemDTO {
 		ReservedQuantity:      item.ReservedQuantity,
 		HardAllocatedQuantity: item.HardAllocatedQuantity,
 		AvailableQuantity:     item.AvailableQuantity,
+		NonSellableQuantity:   item.NonSellableQuantity,
+		InTransitQuantity:     item.InTransitQuantity,
 		ReorderPoint:          item.ReorderPoint,
 		ReorderQuantity:       item.ReorderQuantity,
 		Reservations:          reservations,
 		HardAllocations:       hardAllocations,
+		InTransit:             toInTransitStockDTOs(item.InTransit),
 		LastCycleCount:        item.LastCycleCount,
+		Customs:               toCustomsProfileDTO(item.Customs),
+		Slotting:              toSlottingProfileDTO(item.Slotting),
 		CreatedAt:             item.CreatedAt,
 		UpdatedAt:             item.UpdatedAt,
 	}
 }
 
+// toCustomsProfileDTO converts a domain CustomsProfile to CustomsProfileDTO
+func toCustomsProfileDTO(profile *domain.CustomsProfile) *CustomsProfileDTO {
+	if profile == nil {
+		return nil
+	}
+
+	return &CustomsProfileDTO{
+		HSCode:          profile.HSCode,
+		CountryOfOrigin: profile.CountryOfOrigin,
+		Description:     profile.Description,
+		DeclaredValue:   profile.DeclaredValue,
+		Currency:        profile.Currency,
+		UpdatedAt:       profile.UpdatedAt,
+	}
+}
+
+// toSlottingProfileDTO converts a domain SlottingProfile to SlottingProfileDTO
+func toSlottingProfileDTO(profile *domain.SlottingProfile) *SlottingProfileDTO {
+	if profile == nil {
+		return nil
+	}
+
+	return &SlottingProfileDTO{
+		UnitCube:   profile.UnitCube,
+		UnitWeight: profile.UnitWeight,
+		UpdatedAt:  profile.UpdatedAt,
+	}
+}
+
 // ToInventoryListDTO converts a domain InventoryItem to InventoryListDTO (simplified)
 func ToInventoryListDTO(item *domain.InventoryItem) *InventoryListDTO {
 	if item == nil {
@@ -79,14 +120,16 @@ func ToInventoryListDTO(item *domain.InventoryItem) *InventoryListDTO {
 	}
 
 	return &InventoryListDTO{
-		SKU:               item.SKU,
-		ProductName:       item.ProductName,
-		TotalQuantity:     item.TotalQuantity,
-		ReservedQuantity:  item.ReservedQuantity,
-		AvailableQuantity: item.AvailableQuantity,
-		ReorderPoint:      item.ReorderPoint,
-		LocationCount:     len(item.Locations),
-		UpdatedAt:         item.UpdatedAt,
+		SKU:                 item.SKU,
+		ProductName:         item.ProductName,
+		TotalQuantity:       item.TotalQuantity,
+		ReservedQuantity:    item.ReservedQuantity,
+		AvailableQuantity:   item.AvailableQuantity,
+		NonSellableQuantity: item.NonSellableQuantity,
+		InTransitQuantity:   item.InTransitQuantity,
+		ReorderPoint:        item.ReorderPoint,
+		LocationCount:       len(item.Locations),
+		UpdatedAt:           item.UpdatedAt,
 	}
 }
 
@@ -100,3 +143,304 @@ func ToInventoryListDTOs(items []*domain.InventoryItem) []InventoryListDTO {
 	}
 	return dtos
 }
+
+// toStatusBucketDTOs converts a location's non-sellable status buckets to DTOs
+func toStatusBucketDTOs(buckets []domain.StatusBucket) []StatusBucketDTO {
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	dtos := make([]StatusBucketDTO, 0, len(buckets))
+	for _, bucket := range buckets {
+		dtos = append(dtos, StatusBucketDTO{
+			Status:     string(bucket.Status),
+			LotNumber:  bucket.LotNumber,
+			HoldID:     bucket.HoldID,
+			ReasonCode: string(bucket.ReasonCode),
+			Quantity:   bucket.Quantity,
+			UpdatedAt:  bucket.UpdatedAt,
+		})
+	}
+	return dtos
+}
+
+// toInTransitStockDTOs converts domain in-transit moves to DTOs
+func toInTransitStockDTOs(moves []domain.InTransitStock) []InTransitStockDTO {
+	if len(moves) == 0 {
+		return nil
+	}
+
+	dtos := make([]InTransitStockDTO, 0, len(moves))
+	for _, move := range moves {
+		lotNumbers := make([]string, 0, len(move.Stock))
+		for _, stock := range move.Stock {
+			if stock.LotNumber != "" {
+				lotNumbers = append(lotNumbers, stock.LotNumber)
+			}
+		}
+		dtos = append(dtos, InTransitStockDTO{
+			MoveID:         move.MoveID,
+			ContainerID:    move.ContainerID,
+			FromLocationID: move.FromLocationID,
+			ToLocationID:   move.ToLocationID,
+			Status:         string(move.Status),
+			Quantity:       move.Quantity,
+			LotNumbers:     lotNumbers,
+			Reason:         move.Reason,
+			PickedBy:       move.PickedBy,
+			PickedAt:       move.PickedAt,
+		})
+	}
+	return dtos
+}
+
+// toStockLotDTOs converts domain lots to DTOs
+func toStockLotDTOs(lots []domain.StockLot) []StockLotDTO {
+	if len(lots) == 0 {
+		return nil
+	}
+
+	dtos := make([]StockLotDTO, 0, len(lots))
+	for _, lot := range lots {
+		dtos = append(dtos, StockLotDTO{
+			LotNumber:       lot.LotNumber,
+			ManufactureDate: lot.ManufactureDate,
+			ExpiryDate:      lot.ExpiryDate,
+			Quantity:        lot.Quantity,
+			Reserved:        lot.Reserved,
+			Held:            lot.Held,
+			Available:       lot.Available(),
+			Status:          string(lot.Status),
+			ReceivedAt:      lot.ReceivedAt,
+		})
+	}
+	return dtos
+}
+
+// toLotAllocationDTOs converts reservation lot allocations to DTOs
+func toLotAllocationDTOs(allocations []domain.LotAllocation) []LotAllocationDTO {
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	dtos := make([]LotAllocationDTO, 0, len(allocations))
+	for _, alloc := range allocations {
+		dtos = append(dtos, LotAllocationDTO{
+			LotNumber:  alloc.LotNumber,
+			ExpiryDate: alloc.ExpiryDate,
+			Quantity:   alloc.Quantity,
+		})
+	}
+	return dtos
+}
+
+// toExpiredReservationDTO converts an expired reservation to a DTO
+func toExpiredReservationDTO(item *domain.InventoryItem, res domain.Reservation) ExpiredReservationDTO {
+	return ExpiredReservationDTO{
+		SKU:           item.SKU,
+		TenantID:      item.TenantID,
+		ReservationID: res.ReservationID,
+		OrderID:       res.OrderID,
+		LocationID:    res.LocationID,
+		Quantity:      res.Quantity,
+		UnitIDs:       res.UnitIDs,
+		ExpiresAt:     res.ExpiresAt,
+	}
+}
+
+// toCycleCountTaskDTO converts a cycle count task to a DTO. expectedQuantity is shown to
+// counters of non-blind tasks; a blind task hides system quantities until counting is done.
+func toCycleCountTaskDTO(task *domain.CycleCountTask, expectedQuantity *int) *CycleCountTaskDTO {
+	hidden := task.Blind && (task.Status == domain.CycleCountStatusPending || task.Status == domain.CycleCountStatusRecount)
+
+	attempts := make([]CountAttemptDTO, 0, len(task.Attempts))
+	for _, attempt := range task.Attempts {
+		dto := CountAttemptDTO{
+			CountedQuantity: attempt.CountedQuantity,
+			CountedBy:       attempt.CountedBy,
+			CountedAt:       attempt.CountedAt,
+		}
+		if !hidden {
+			systemQuantity, variance := attempt.SystemQuantity, attempt.Variance
+			dto.SystemQuantity = &systemQuantity
+			dto.Variance = &variance
+		}
+		attempts = append(attempts, dto)
+	}
+
+	dto := &CycleCountTaskDTO{
+		TaskID:              task.TaskID,
+		SKU:                 task.SKU,
+		LocationID:          task.LocationID,
+		VelocityClass:       string(task.VelocityClass),
+		Blind:               task.Blind,
+		Status:              string(task.Status),
+		Attempts:            attempts,
+		Variance:            task.Variance,
+		UnitCost:            task.UnitCost,
+		VarianceValue:       task.VarianceValue,
+		ApprovedBy:          task.ApprovedBy,
+		RejectedBy:          task.RejectedBy,
+		RejectionReason:     task.RejectionReason,
+		LedgerTransactionID: task.LedgerTransactionID,
+		DueAt:               task.DueAt,
+		CreatedAt:           task.CreatedAt,
+		UpdatedAt:           task.UpdatedAt,
+		CompletedAt:         task.CompletedAt,
+	}
+	if !hidden {
+		dto.ExpectedQuantity = expectedQuantity
+	}
+	return dto
+}
+
+// toReplenishmentTaskDTO converts a replenishment task to a DTO
+func toReplenishmentTaskDTO(task *domain.ReplenishmentTask) *ReplenishmentTaskDTO {
+	return &ReplenishmentTaskDTO{
+		TaskID:         task.TaskID,
+		SKU:            task.SKU,
+		FromLocationID: task.FromLocationID,
+		ToLocationID:   task.ToLocationID,
+		Zone:           task.Zone,
+		Quantity:       task.Quantity,
+		MovedQuantity:  task.MovedQuantity,
+		Trigger:        string(task.Trigger),
+		WaveID:         task.WaveID,
+		Priority:       task.Priority,
+		Status:         string(task.Status),
+		LaborQueued:    task.LaborQueuedAt != nil,
+		CompletedBy:    task.CompletedBy,
+		CancelReason:   task.CancelReason,
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+		CompletedAt:    task.CompletedAt,
+	}
+}
+
+// toReplenishmentTaskDTOs converts replenishment tasks to DTOs
+func toReplenishmentTaskDTOs(tasks []*domain.ReplenishmentTask) []ReplenishmentTaskDTO {
+	dtos := make([]ReplenishmentTaskDTO, 0, len(tasks))
+	for _, task := range tasks {
+		dtos = append(dtos, *toReplenishmentTaskDTO(task))
+	}
+	return dtos
+}
+
+// toSlotLocationDTO converts a storage slot to a DTO
+func toSlotLocationDTO(slot *domain.SlotLocation) *SlotLocationDTO {
+	return &SlotLocationDTO{
+		LocationID:   slot.LocationID,
+		Zone:         slot.Zone,
+		Aisle:        slot.Aisle,
+		Rack:         slot.Rack,
+		Level:        slot.Level,
+		CubeCapacity: slot.CubeCapacity,
+		MaxWeight:    slot.MaxWeight,
+		UpdatedAt:    slot.UpdatedAt,
+	}
+}
+
+// toSlotLocationDTOs converts storage slots to DTOs
+func toSlotLocationDTOs(slots []*domain.SlotLocation) []SlotLocationDTO {
+	dtos := make([]SlotLocationDTO, 0, len(slots))
+	for _, slot := range slots {
+		dtos = append(dtos, *toSlotLocationDTO(slot))
+	}
+	return dtos
+}
+
+func toSlotScoreDTO(score domain.SlotScore) SlotScoreDTO {
+	return SlotScoreDTO{
+		Velocity:  score.Velocity,
+		Ergonomic: score.Ergonomic,
+		CubeFit:   score.CubeFit,
+		Weight:    score.Weight,
+		Affinity:  score.Affinity,
+		Total:     score.Total,
+		Fits:      score.Fits,
+	}
+}
+
+// toSlottingRecommendationDTO converts a slotting recommendation to a DTO
+func toSlottingRecommendationDTO(rec *domain.SlottingRecommendation) *SlottingRecommendationDTO {
+	return &SlottingRecommendationDTO{
+		RecommendationID: rec.RecommendationID,
+		SKU:              rec.SKU,
+		VelocityClass:    string(rec.VelocityClass),
+		PickFrequency:    rec.PickFrequency,
+		Zone:             rec.Zone,
+		FromLocationID:   rec.FromLocationID,
+		ToLocationID:     rec.ToLocationID,
+		ToLevel:          rec.ToLevel,
+		Quantity:         rec.Quantity,
+		CurrentScore:     toSlotScoreDTO(rec.CurrentScore),
+		ProposedScore:    toSlotScoreDTO(rec.ProposedScore),
+		FromDistance:     rec.FromDistance,
+		ToDistance:       rec.ToDistance,
+		TravelSavings:    rec.TravelSavings,
+		Status:           string(rec.Status),
+		MoveTaskID:       rec.MoveTaskID,
+		LaborQueued:      rec.LaborQueuedAt != nil,
+		DecidedBy:        rec.DecidedBy,
+		RejectReason:     rec.RejectReason,
+		MovedQuantity:    rec.MovedQuantity,
+		MovedBy:          rec.MovedBy,
+		CreatedAt:        rec.CreatedAt,
+		DecidedAt:        rec.DecidedAt,
+		CompletedAt:      rec.CompletedAt,
+	}
+}
+
+// toSlottingRecommendationDTOs converts slotting recommendations to DTOs
+func toSlottingRecommendationDTOs(recs []*domain.SlottingRecommendation) []SlottingRecommendationDTO {
+	dtos := make([]SlottingRecommendationDTO, 0, len(recs))
+	for _, rec := range recs {
+		dtos = append(dtos, *toSlottingRecommendationDTO(rec))
+	}
+	return dtos
+}
+
+// ToInventoryHoldDTO converts a domain InventoryHold to InventoryHoldDTO
+func ToInventoryHoldDTO(hold *domain.InventoryHold) *InventoryHoldDTO {
+	if hold == nil {
+		return nil
+	}
+
+	placements := make([]HoldPlacementDTO, 0, len(hold.Placements))
+	for _, placement := range hold.Placements {
+		placements = append(placements, HoldPlacementDTO{
+			SKU:        placement.SKU,
+			LocationID: placement.LocationID,
+			LotNumber:  placement.LotNumber,
+			Quantity:   placement.Quantity,
+		})
+	}
+
+	return &InventoryHoldDTO{
+		HoldID:       hold.HoldID,
+		Scope:        string(hold.Scope),
+		SKU:          hold.SKU,
+		LotNumber:    hold.LotNumber,
+		LocationID:   hold.LocationID,
+		ReasonCode:   string(hold.ReasonCode),
+		Notes:        hold.Notes,
+		Status:       string(hold.Status),
+		HeldQuantity: hold.HeldQuantity(),
+		Placements:   placements,
+		PlacedBy:     hold.PlacedBy,
+		ReleasedBy:   hold.ReleasedBy,
+		CreatedAt:    hold.CreatedAt,
+		ReleasedAt:   hold.ReleasedAt,
+	}
+}
+
+// ToInventoryHoldDTOs converts a slice of domain InventoryHolds to DTOs
+func ToInventoryHoldDTOs(holds []*domain.InventoryHold) []InventoryHoldDTO {
+	dtos := make([]InventoryHoldDTO, 0, len(holds))
+	for _, hold := range holds {
+		if dto := ToInventoryHoldDTO(hold); dto != nil {
+			dtos = append(dtos, *dto)
+		}
+	}
+	return dtos
+}
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+// ReplenishmentMonitor periodically creates tasks for pick faces below their minimum and
+// retries handing tasks to labor-service. Picks trigger replenishment immediately, the monitor
+// catches faces drained by adjustments and tasks labor-service could not accept.
+type ReplenishmentMonitor struct {
+	service  *ReplenishmentService
+	config   ReplenishmentMonitorConfig
+	logger   *logging.Logger
+	mu       sync.RWMutex
+	running  bool
+	stopChan chan struct{}
+}
+
+// ReplenishmentMonitorConfig configuration for the replenishment monitor
+type ReplenishmentMonitorConfig struct {
+	// CheckInterval is how often to look for pick faces below their minimum
+	CheckInterval time.Duration `json:"checkInterval"`
+
+	// BatchSize is the maximum number of items replenished per check
+	BatchSize int `json:"batchSize"`
+}
+
+// DefaultReplenishmentMonitorConfig returns default configuration
+func DefaultReplenishmentMonitorConfig() ReplenishmentMonitorConfig {
+	return ReplenishmentMonitorConfig{
+		CheckInterval: 5 * time.Minute,
+		BatchSize:     200,
+	}
+}
+
+// NewReplenishmentMonitor creates a new replenishment monitor
+func NewReplenishmentMonitor(
+	service *ReplenishmentService,
+	config ReplenishmentMonitorConfig,
+	logger *logging.Logger,
+) *ReplenishmentMonitor {
+	return &ReplenishmentMonitor{
+		service:  service,
+		config:   config,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins periodic replenishment checks
+func (s *ReplenishmentMonitor) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return fmt.Errorf("replenishment monitor is already running")
+	}
+	s.running = true
+	s.stopChan = make(chan struct{})
+	s.mu.Unlock()
+
+	go s.run(ctx)
+	return nil
+}
+
+// Stop stops periodic replenishment checks
+func (s *ReplenishmentMonitor) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		close(s.stopChan)
+		s.running = false
+	}
+}
+
+// IsRunning returns whether the monitor is running
+func (s *ReplenishmentMonitor) IsRunning() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running
+}
+
+// run is the main loop for the replenishment monitor
+func (s *ReplenishmentMonitor) run(ctx context.Context) {
+	// Check once at startup so faces drained while the service was down are not left waiting
+	s.check(ctx)
+
+	ticker := time.NewTicker(s.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			s.check(ctx)
+		}
+	}
+}
+
+func (s *ReplenishmentMonitor) check(ctx context.Context) {
+	cmd := GenerateReplenishmentsCommand{BatchSize: s.config.BatchSize}
+	if _, err := s.service.GenerateTasks(ctx, cmd); err != nil {
+		s.logger.Error("Replenishment check failed", "error", err)
+	}
+}
@@ -0,0 +1,481 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+const (
+	// defaultReplenishmentListLimit caps task listings when no limit is given
+	defaultReplenishmentListLimit = 100
+	// laborTaskTypeReplenishment is labor-service's task type for replenishment work
+	laborTaskTypeReplenishment = "replenishment"
+)
+
+// ReplenishmentService handles pick face replenishment: min/max settings per forward pick
+// location, tasks moving stock from reserve storage when a pick face drops below its minimum
+// or a released wave needs more than the pick faces hold, and handing the tasks to labor-service
+type ReplenishmentService struct {
+	repo       domain.ReplenishmentTaskRepository
+	inventory  *InventoryApplicationService
+	laborQueue domain.LaborTaskQueue // Optional: dispatches tasks to workers in labor-service
+	priority   int                   // Priority of min/max tasks, 1 = highest
+	logger     *logging.Logger
+}
+
+// NewReplenishmentService creates a new ReplenishmentService
+func NewReplenishmentService(
+	repo domain.ReplenishmentTaskRepository,
+	inventory *InventoryApplicationService,
+	priority int,
+	logger *logging.Logger,
+) *ReplenishmentService {
+	return &ReplenishmentService{
+		repo:      repo,
+		inventory: inventory,
+		priority:  priority,
+		logger:    logger,
+	}
+}
+
+// SetLaborQueue sets the queue replenishment tasks are handed to for dispatch
+func (s *ReplenishmentService) SetLaborQueue(queue domain.LaborTaskQueue) {
+	s.laborQueue = queue
+}
+
+// SetPickFaceLimits sets the min/max of a forward pick location and creates a task right
+// away when the location is already below its new minimum
+func (s *ReplenishmentService) SetPickFaceLimits(ctx context.Context, cmd SetPickFaceLimitsCommand) (*InventoryItemDTO, error) {
+	item, _, err := s.inventory.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.SetPickFaceLimits(cmd.LocationID, cmd.Zone, cmd.MinQuantity, cmd.MaxQuantity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Set pick face limits",
+		"sku", cmd.SKU,
+		"locationId", cmd.LocationID,
+		"min", cmd.MinQuantity,
+		"max", cmd.MaxQuantity,
+	)
+
+	if _, err := s.ReplenishItem(ctx, item); err != nil {
+		s.logger.Warn("Failed to create replenishment tasks", "sku", cmd.SKU, "error", err)
+	}
+	return ToInventoryItemDTO(item), nil
+}
+
+// GenerateTasks creates tasks for pick faces below their minimum and hands tasks that
+// labor-service has not accepted yet to it again
+func (s *ReplenishmentService) GenerateTasks(ctx context.Context, cmd GenerateReplenishmentsCommand) (*ReplenishmentRunResultDTO, error) {
+	items, err := s.inventory.repo.FindBelowPickFaceMinimum(ctx, cmd.BatchSize)
+	if err != nil {
+		s.logger.Error("Failed to find pick faces below minimum", "error", err)
+		return nil, fmt.Errorf("failed to find pick faces below minimum: %w", err)
+	}
+
+	result := &ReplenishmentRunResultDTO{ItemsChecked: len(items)}
+	for _, item := range items {
+		tasks, err := s.ReplenishItem(ctx, item)
+		result.TasksCreated += len(tasks)
+		if err != nil {
+			s.logger.Error("Failed to replenish item", "sku", item.SKU, "error", err)
+			result.Failed++
+		}
+	}
+
+	if s.laborQueue != nil {
+		unqueued, err := s.repo.FindNotQueued(ctx, cmd.BatchSize)
+		if err != nil {
+			s.logger.Error("Failed to find replenishment tasks not queued for labor", "error", err)
+		}
+		for _, task := range unqueued {
+			if s.queue(ctx, task) {
+				result.TasksQueued++
+			}
+		}
+	}
+
+	if result.TasksCreated > 0 || result.TasksQueued > 0 || result.Failed > 0 {
+		s.logger.Info("Generated replenishment tasks",
+			"itemsChecked", result.ItemsChecked,
+			"tasksCreated", result.TasksCreated,
+			"tasksQueued", result.TasksQueued,
+			"failed", result.Failed,
+		)
+	}
+
+	return result, nil
+}
+
+// ReplenishItem creates min/max tasks for the item's pick faces that are below their minimum,
+// filling each back to its maximum. Pick faces with a task already open are skipped.
+func (s *ReplenishmentService) ReplenishItem(ctx context.Context, item *domain.InventoryItem) ([]*domain.ReplenishmentTask, error) {
+	needs := item.ReplenishmentNeeds()
+	if len(needs) == 0 {
+		return nil, nil
+	}
+
+	incoming, err := s.incoming(ctx, item.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open replenishment tasks: %w", err)
+	}
+
+	created := make([]*domain.ReplenishmentTask, 0)
+	for _, need := range needs {
+		if incoming[need.LocationID] > 0 {
+			continue
+		}
+		sources, err := item.ReplenishmentSources(need.Quantity)
+		if err != nil {
+			s.logger.Warn("Pick face below minimum has no reserve stock",
+				"sku", item.SKU,
+				"locationId", need.LocationID,
+				"needed", need.Quantity,
+			)
+			continue
+		}
+		tasks, err := s.createTasks(ctx, item, need.LocationID, sources, domain.ReplenishmentTriggerMinMax, "", nil, s.priority)
+		created = append(created, tasks...)
+		if err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// TopOffForWave creates tasks bringing stock reserved for a released wave's orders forward
+// to the pick faces, topping the faces up to their maximum on the way. Tasks carry the
+// wave's priority so replenishment is dispatched before the wave's picks.
+func (s *ReplenishmentService) TopOffForWave(ctx context.Context, cmd TopOffWaveCommand) (*WaveTopOffResultDTO, error) {
+	if cmd.WaveID == "" || len(cmd.OrderIDs) == 0 {
+		return nil, errors.ErrValidation("waveId and orderIds are required")
+	}
+	priority := cmd.Priority
+	if priority <= 0 {
+		priority = s.priority
+	}
+
+	items := make(map[string]*domain.InventoryItem)
+	for _, orderID := range cmd.OrderIDs {
+		found, err := s.inventory.repo.FindByOrderID(ctx, orderID)
+		if err != nil {
+			s.logger.Error("Failed to find items reserved for order", "orderId", orderID, "error", err)
+			return nil, fmt.Errorf("failed to find items reserved for order %s: %w", orderID, err)
+		}
+		for _, item := range found {
+			items[item.SKU] = item
+		}
+	}
+
+	skus := make([]string, 0, len(items))
+	for sku := range items {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	result := &WaveTopOffResultDTO{
+		WaveID:      cmd.WaveID,
+		SKUsChecked: len(skus),
+		Tasks:       make([]ReplenishmentTaskDTO, 0),
+	}
+	for _, sku := range skus {
+		tasks, err := s.topOffItem(ctx, items[sku], cmd.WaveID, cmd.OrderIDs, priority)
+		result.Tasks = append(result.Tasks, toReplenishmentTaskDTOs(tasks)...)
+		if err != nil {
+			s.logger.Error("Failed to top off pick face for wave", "waveId", cmd.WaveID, "sku", sku, "error", err)
+			return nil, err
+		}
+	}
+
+	s.logger.Info("Topped off pick faces for wave",
+		"waveId", cmd.WaveID,
+		"skusChecked", result.SKUsChecked,
+		"tasksCreated", len(result.Tasks),
+	)
+	return result, nil
+}
+
+// topOffItem creates one task per reserve location holding stock reserved for the wave,
+// moving the reservations to the pick face with the most room. Where the face has room
+// left, the task also brings available stock from the same location.
+func (s *ReplenishmentService) topOffItem(ctx context.Context, item *domain.InventoryItem, waveID string, orderIDs []string, priority int) ([]*domain.ReplenishmentTask, error) {
+	faces := item.PickFaces()
+	if len(faces) == 0 {
+		return nil, nil // Nothing forward to top off, the wave picks from reserve storage
+	}
+	offFace := item.ReservedOffPickFace(orderIDs)
+	if len(offFace) == 0 {
+		return nil, nil // The wave's demand is already on the pick faces
+	}
+
+	open, err := s.repo.FindOpenBySKU(ctx, item.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find open replenishment tasks: %w", err)
+	}
+	incoming := make(map[string]int, len(open))
+	for _, task := range open {
+		incoming[task.ToLocationID] += task.Quantity
+		if task.WaveID == waveID {
+			delete(offFace, task.FromLocationID) // Already topped off for this wave
+		}
+	}
+	if len(offFace) == 0 {
+		return nil, nil
+	}
+
+	target := faces[0]
+	room := target.MaxQuantity - target.ShelfQuantity() - incoming[target.LocationID]
+	for _, face := range faces[1:] {
+		if faceRoom := face.MaxQuantity - face.ShelfQuantity() - incoming[face.LocationID]; faceRoom > room {
+			target, room = face, faceRoom
+		}
+	}
+
+	locationIDs := make([]string, 0, len(offFace))
+	for locationID := range offFace {
+		locationIDs = append(locationIDs, locationID)
+	}
+	sort.Strings(locationIDs)
+
+	sources := make([]domain.ReplenishmentSource, 0, len(locationIDs))
+	for _, locationID := range locationIDs {
+		quantity := offFace[locationID]
+		room -= quantity
+		if room > 0 {
+			extra := item.GetLocationStock(locationID).Available
+			if extra > room {
+				extra = room
+			}
+			quantity += extra
+			room -= extra
+		}
+		sources = append(sources, domain.ReplenishmentSource{LocationID: locationID, Quantity: quantity})
+	}
+
+	return s.createTasks(ctx, item, target.LocationID, sources, domain.ReplenishmentTriggerWaveDemand, waveID, orderIDs, priority)
+}
+
+// CompleteTask moves the stock to the pick face and closes the task. Less than the task
+// quantity may be moved, e.g. when the reserve location held less than expected. Stock is
+// moved before the task is saved; if the save fails, completing again does not move it
+// twice. A task changed concurrently, e.g. queued for labor, is reloaded before it is closed.
+func (s *ReplenishmentService) CompleteTask(ctx context.Context, cmd CompleteReplenishmentCommand) (*ReplenishmentTaskDTO, error) {
+	task, err := s.getTask(ctx, cmd.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if !task.IsOpen() {
+		return nil, errors.ErrValidation(domain.ErrReplenishmentTaskClosed.Error())
+	}
+	if cmd.MovedQuantity < 0 || cmd.MovedQuantity > task.Quantity {
+		return nil, errors.ErrValidation(domain.ErrReplenishmentQuantityRange.Error())
+	}
+
+	if cmd.MovedQuantity > 0 {
+		if err := s.inventory.Replenish(ctx, task, cmd.MovedQuantity, cmd.CompletedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	err = resilience.RetryOnConflict(ctx, func() error {
+		if err := task.Complete(cmd.MovedQuantity, cmd.CompletedBy); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		err := s.repo.Save(ctx, task)
+		if errors.IsConcurrencyConflict(err) {
+			s.logger.Debug("Replenishment task changed concurrently, retrying", "taskId", task.TaskID)
+			current, findErr := s.getTask(ctx, task.TaskID)
+			if findErr != nil {
+				return findErr
+			}
+			*task = *current
+		}
+		return err
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("replenishment task %s was modified concurrently, please retry", task.TaskID)).Wrap(err)
+	}
+	if err != nil {
+		if _, ok := err.(*errors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to save replenishment task", "taskId", task.TaskID, "error", err)
+		return nil, fmt.Errorf("failed to save replenishment task: %w", err)
+	}
+
+	if s.laborQueue != nil && task.LaborQueuedAt != nil {
+		if err := s.laborQueue.CompleteTask(ctx, toLaborTask(task)); err != nil {
+			s.logger.Warn("Failed to complete labor task", "taskId", task.TaskID, "error", err)
+		}
+	}
+
+	s.logger.Info("Completed replenishment",
+		"taskId", task.TaskID,
+		"sku", task.SKU,
+		"toLocationId", task.ToLocationID,
+		"movedQuantity", cmd.MovedQuantity,
+		"completedBy", cmd.CompletedBy,
+	)
+	return toReplenishmentTaskDTO(task), nil
+}
+
+// CancelTask closes a task without moving stock
+func (s *ReplenishmentService) CancelTask(ctx context.Context, cmd CancelReplenishmentCommand) (*ReplenishmentTaskDTO, error) {
+	task, err := s.getTask(ctx, cmd.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := task.Cancel(cmd.Reason); err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	if err := s.repo.Save(ctx, task); err != nil {
+		s.logger.Error("Failed to save replenishment task", "taskId", task.TaskID, "error", err)
+		return nil, fmt.Errorf("failed to save replenishment task: %w", err)
+	}
+
+	if s.laborQueue != nil && task.LaborQueuedAt != nil {
+		if err := s.laborQueue.CancelTask(ctx, toLaborTask(task)); err != nil {
+			s.logger.Warn("Failed to cancel labor task", "taskId", task.TaskID, "error", err)
+		}
+	}
+
+	s.logger.Info("Cancelled replenishment", "taskId", task.TaskID, "reason", cmd.Reason)
+	return toReplenishmentTaskDTO(task), nil
+}
+
+// GetTask retrieves a replenishment task
+func (s *ReplenishmentService) GetTask(ctx context.Context, taskID string) (*ReplenishmentTaskDTO, error) {
+	task, err := s.getTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return toReplenishmentTaskDTO(task), nil
+}
+
+// ListTasks lists replenishment tasks of a wave or by status, highest priority first
+func (s *ReplenishmentService) ListTasks(ctx context.Context, query ListReplenishmentsQuery) ([]ReplenishmentTaskDTO, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultReplenishmentListLimit
+	}
+
+	var tasks []*domain.ReplenishmentTask
+	var err error
+	if query.WaveID != "" {
+		tasks, err = s.repo.FindByWave(ctx, query.WaveID)
+	} else {
+		tasks, err = s.repo.FindByStatus(ctx, domain.ReplenishmentTaskStatus(query.Status), limit)
+	}
+	if err != nil {
+		s.logger.Error("Failed to list replenishment tasks", "status", query.Status, "waveId", query.WaveID, "error", err)
+		return nil, fmt.Errorf("failed to list replenishment tasks: %w", err)
+	}
+	return toReplenishmentTaskDTOs(tasks), nil
+}
+
+// createTasks saves a task per source and hands each to labor-service
+func (s *ReplenishmentService) createTasks(
+	ctx context.Context,
+	item *domain.InventoryItem,
+	toLocationID string,
+	sources []domain.ReplenishmentSource,
+	trigger domain.ReplenishmentTrigger,
+	waveID string,
+	orderIDs []string,
+	priority int,
+) ([]*domain.ReplenishmentTask, error) {
+	created := make([]*domain.ReplenishmentTask, 0, len(sources))
+	for _, source := range sources {
+		task, err := domain.NewReplenishmentTask(item, source.LocationID, toLocationID, source.Quantity, trigger, waveID, orderIDs, priority)
+		if err != nil {
+			return created, errors.ErrValidation(err.Error())
+		}
+		if err := s.repo.Save(ctx, task); err != nil {
+			s.logger.Error("Failed to save replenishment task", "sku", item.SKU, "toLocationId", toLocationID, "error", err)
+			return created, fmt.Errorf("failed to save replenishment task: %w", err)
+		}
+
+		s.logger.Info("Created replenishment task",
+			"taskId", task.TaskID,
+			"sku", task.SKU,
+			"fromLocationId", task.FromLocationID,
+			"toLocationId", task.ToLocationID,
+			"quantity", task.Quantity,
+			"trigger", task.Trigger,
+			"waveId", task.WaveID,
+		)
+		s.queue(ctx, task)
+		created = append(created, task)
+	}
+	return created, nil
+}
+
+// queue hands a task to labor-service and records that it was accepted. Failures are
+// logged and retried by the replenishment monitor.
+func (s *ReplenishmentService) queue(ctx context.Context, task *domain.ReplenishmentTask) bool {
+	if s.laborQueue == nil {
+		return false
+	}
+
+	if err := s.laborQueue.EnqueueTask(ctx, toLaborTask(task)); err != nil {
+		s.logger.Warn("Failed to queue replenishment task for labor", "taskId", task.TaskID, "error", err)
+		return false
+	}
+
+	task.MarkQueued(time.Now())
+	if err := s.repo.Save(ctx, task); err != nil {
+		s.logger.Warn("Failed to save queued replenishment task", "taskId", task.TaskID, "error", err)
+		return false
+	}
+	return true
+}
+
+// toLaborTask describes a replenishment task to labor-service
+func toLaborTask(task *domain.ReplenishmentTask) domain.LaborTask {
+	return domain.LaborTask{
+		TenantID:    task.TenantID,
+		FacilityID:  task.FacilityID,
+		WarehouseID: task.WarehouseID,
+		TaskID:      task.TaskID,
+		TaskType:    laborTaskTypeReplenishment,
+		Priority:    task.Priority,
+		Zone:        task.Zone,
+		WaveID:      task.WaveID,
+	}
+}
+
+// incoming returns the quantity already on its way to each location of a SKU by open tasks
+func (s *ReplenishmentService) incoming(ctx context.Context, sku string) (map[string]int, error) {
+	tasks, err := s.repo.FindOpenBySKU(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	incoming := make(map[string]int, len(tasks))
+	for _, task := range tasks {
+		incoming[task.ToLocationID] += task.Quantity
+	}
+	return incoming, nil
+}
+
+func (s *ReplenishmentService) getTask(ctx context.Context, taskID string) (*domain.ReplenishmentTask, error) {
+	task, err := s.repo.FindByID(ctx, taskID)
+	if err != nil {
+		s.logger.Error("Failed to get replenishment task", "taskId", taskID, "error", err)
+		return nil, fmt.Errorf("failed to get replenishment task: %w", err)
+	}
+	if task == nil {
+		return nil, errors.ErrNotFound("replenishment task")
+	}
+	return task, nil
+}
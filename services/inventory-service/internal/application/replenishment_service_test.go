@@ -0,0 +1,306 @@
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+type fakeReplenishmentRepo struct {
+	tasks    map[string]*domain.ReplenishmentTask
+	saveErrs []error // Returned by the next saves in turn
+}
+
+func (f *fakeReplenishmentRepo) Save(ctx context.Context, task *domain.ReplenishmentTask) error {
+	if len(f.saveErrs) > 0 {
+		err := f.saveErrs[0]
+		f.saveErrs = f.saveErrs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	if f.tasks == nil {
+		f.tasks = make(map[string]*domain.ReplenishmentTask)
+	}
+	task.PullEvents()
+	stored := *task
+	f.tasks[task.TaskID] = &stored
+	return nil
+}
+
+func (f *fakeReplenishmentRepo) FindByID(ctx context.Context, taskID string) (*domain.ReplenishmentTask, error) {
+	task, ok := f.tasks[taskID]
+	if !ok {
+		return nil, nil
+	}
+	loaded := *task
+	return &loaded, nil
+}
+
+func (f *fakeReplenishmentRepo) FindOpenBySKU(ctx context.Context, sku string) ([]*domain.ReplenishmentTask, error) {
+	return f.filter(func(task *domain.ReplenishmentTask) bool { return task.SKU == sku && task.IsOpen() }), nil
+}
+
+func (f *fakeReplenishmentRepo) FindByWave(ctx context.Context, waveID string) ([]*domain.ReplenishmentTask, error) {
+	return f.filter(func(task *domain.ReplenishmentTask) bool { return task.WaveID == waveID }), nil
+}
+
+func (f *fakeReplenishmentRepo) FindByStatus(ctx context.Context, status domain.ReplenishmentTaskStatus, limit int) ([]*domain.ReplenishmentTask, error) {
+	if status == "" {
+		status = domain.ReplenishmentStatusPending
+	}
+	return f.filter(func(task *domain.ReplenishmentTask) bool { return task.Status == status }), nil
+}
+
+func (f *fakeReplenishmentRepo) FindNotQueued(ctx context.Context, limit int) ([]*domain.ReplenishmentTask, error) {
+	return f.filter(func(task *domain.ReplenishmentTask) bool { return task.IsOpen() && task.LaborQueuedAt == nil }), nil
+}
+
+func (f *fakeReplenishmentRepo) filter(match func(*domain.ReplenishmentTask) bool) []*domain.ReplenishmentTask {
+	results := make([]*domain.ReplenishmentTask, 0)
+	for _, task := range f.tasks {
+		if match(task) {
+			results = append(results, task)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].FromLocationID < results[j].FromLocationID })
+	return results
+}
+
+type fakeLaborQueue struct {
+	err       error
+	queued    []domain.LaborTask
+	completed []string
+	cancelled []string
+}
+
+func (f *fakeLaborQueue) EnqueueTask(ctx context.Context, task domain.LaborTask) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.queued = append(f.queued, task)
+	return nil
+}
+
+func (f *fakeLaborQueue) CompleteTask(ctx context.Context, task domain.LaborTask) error {
+	f.completed = append(f.completed, task.TaskID)
+	return nil
+}
+
+func (f *fakeLaborQueue) CancelTask(ctx context.Context, task domain.LaborTask) error {
+	f.cancelled = append(f.cancelled, task.TaskID)
+	return nil
+}
+
+// newPickFaceItem returns an item with qty in reserve location LOC-1 and an empty pick face PF-1 (min 5, max 20)
+func newPickFaceItem(sku string, qty int) *domain.InventoryItem {
+	item := newItemWithStock(sku, qty)
+	_ = item.SetPickFaceLimits("PF-1", "ZONE-P", 5, 20)
+	return item
+}
+
+func newTestReplenishmentService(repo *fakeInventoryRepo) (*ReplenishmentService, *fakeReplenishmentRepo, *fakeLaborQueue) {
+	logger := logging.New(logging.DefaultConfig("test"))
+	taskRepo := &fakeReplenishmentRepo{}
+	queue := &fakeLaborQueue{}
+	inventory := newTestService(repo)
+	svc := NewReplenishmentService(taskRepo, inventory, 5, logger)
+	svc.SetLaborQueue(queue)
+	inventory.SetReplenishmentService(svc)
+	return svc, taskRepo, queue
+}
+
+func TestReplenishmentService_SetPickFaceLimitsCreatesTask(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newItemWithStock("SKU-1", 50)}}
+	svc, taskRepo, queue := newTestReplenishmentService(repo)
+
+	dto, err := svc.SetPickFaceLimits(context.Background(), SetPickFaceLimitsCommand{
+		SKU:         "SKU-1",
+		LocationID:  "PF-1",
+		Zone:        "ZONE-P",
+		MinQuantity: 5,
+		MaxQuantity: 20,
+	})
+	require.NoError(t, err)
+	require.Len(t, dto.Locations, 2)
+	assert.Equal(t, 20, dto.Locations[1].MaxQuantity)
+
+	require.Len(t, taskRepo.tasks, 1)
+	for _, task := range taskRepo.tasks {
+		assert.Equal(t, "LOC-1", task.FromLocationID)
+		assert.Equal(t, "PF-1", task.ToLocationID)
+		assert.Equal(t, 20, task.Quantity)
+		assert.Equal(t, domain.ReplenishmentTriggerMinMax, task.Trigger)
+		assert.NotNil(t, task.LaborQueuedAt)
+	}
+	require.Len(t, queue.queued, 1)
+	assert.Equal(t, "replenishment", queue.queued[0].TaskType)
+	assert.Equal(t, "ZONE-P", queue.queued[0].Zone)
+
+	_, err = svc.SetPickFaceLimits(context.Background(), SetPickFaceLimitsCommand{SKU: "SKU-1", LocationID: "PF-1", MinQuantity: 20, MaxQuantity: 10})
+	var appErr *sharedErrors.AppError
+	require.True(t, stdErrors.As(err, &appErr))
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code)
+}
+
+func TestReplenishmentService_PickBelowMinimumCreatesTask(t *testing.T) {
+	item := newPickFaceItem("SKU-1", 50)
+	require.NoError(t, item.Replenish("SEED", "LOC-1", "PF-1", 8, nil, "user1"))
+	require.NoError(t, item.Reserve("ORD-1", "PF-1", 4))
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": item}}
+	svc, taskRepo, _ := newTestReplenishmentService(repo)
+
+	_, err := svc.inventory.Pick(context.Background(), PickCommand{SKU: "SKU-1", OrderID: "ORD-1", LocationID: "PF-1", Quantity: 4, CreatedBy: "picker1"})
+	require.NoError(t, err)
+
+	tasks, err := svc.ListTasks(context.Background(), ListReplenishmentsQuery{})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, 16, tasks[0].Quantity, "fills the face back to its maximum")
+	assert.True(t, tasks[0].LaborQueued)
+
+	// An open task is not duplicated by the monitor
+	result, err := svc.GenerateTasks(context.Background(), GenerateReplenishmentsCommand{BatchSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ItemsChecked)
+	assert.Equal(t, 0, result.TasksCreated)
+	assert.Len(t, taskRepo.tasks, 1)
+}
+
+func TestReplenishmentService_GenerateTasksRequeuesUnqueuedTasks(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newPickFaceItem("SKU-1", 50)}}
+	svc, _, queue := newTestReplenishmentService(repo)
+	queue.err = stdErrors.New("labor-service unavailable")
+
+	result, err := svc.GenerateTasks(context.Background(), GenerateReplenishmentsCommand{BatchSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TasksCreated)
+	assert.Equal(t, 0, result.TasksQueued)
+
+	queue.err = nil
+	result, err = svc.GenerateTasks(context.Background(), GenerateReplenishmentsCommand{BatchSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.TasksCreated)
+	assert.Equal(t, 1, result.TasksQueued)
+	assert.Len(t, queue.queued, 1)
+}
+
+func TestReplenishmentService_TopOffForWave(t *testing.T) {
+	item := newPickFaceItem("SKU-1", 50)
+	require.NoError(t, item.Replenish("SEED", "LOC-1", "PF-1", 10, nil, "user1"))
+	require.NoError(t, item.Reserve("ORD-1", "LOC-1", 12))
+	require.NoError(t, item.Reserve("ORD-2", "PF-1", 3))
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{
+		"SKU-1": item,
+		"SKU-2": newItemWithStock("SKU-2", 10), // No pick face, picked from reserve storage
+	}}
+	require.NoError(t, repo.items["SKU-2"].Reserve("ORD-1", "LOC-1", 2))
+	svc, _, queue := newTestReplenishmentService(repo)
+
+	result, err := svc.TopOffForWave(context.Background(), TopOffWaveCommand{
+		WaveID:   "WAVE-1",
+		OrderIDs: []string{"ORD-1", "ORD-2"},
+		Priority: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.SKUsChecked)
+	require.Len(t, result.Tasks, 1)
+
+	task := result.Tasks[0]
+	assert.Equal(t, "LOC-1", task.FromLocationID)
+	assert.Equal(t, "PF-1", task.ToLocationID)
+	assert.Equal(t, 12, task.Quantity, "the wave's reserve stock; the face has no room for more")
+	assert.Equal(t, "wave_demand", task.Trigger)
+	assert.Equal(t, "WAVE-1", task.WaveID)
+	assert.Equal(t, 2, task.Priority)
+	require.Len(t, queue.queued, 1)
+	assert.Equal(t, "WAVE-1", queue.queued[0].WaveID)
+
+	// Releasing the wave again does not duplicate the top-off
+	result, err = svc.TopOffForWave(context.Background(), TopOffWaveCommand{WaveID: "WAVE-1", OrderIDs: []string{"ORD-1", "ORD-2"}})
+	require.NoError(t, err)
+	assert.Empty(t, result.Tasks)
+
+	// Completing the task brings the reservation forward so the order is picked at the face
+	completed, err := svc.CompleteTask(context.Background(), CompleteReplenishmentCommand{TaskID: task.TaskID, MovedQuantity: 12, CompletedBy: "worker1"})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", completed.Status)
+	assert.Equal(t, []string{task.TaskID}, queue.completed)
+
+	face := repo.items["SKU-1"].GetLocationStock("PF-1")
+	assert.Equal(t, 22, face.Quantity)
+	assert.Equal(t, 15, face.Reserved)
+	_, err = svc.inventory.Pick(context.Background(), PickCommand{SKU: "SKU-1", OrderID: "ORD-1", LocationID: "PF-1", Quantity: 12, CreatedBy: "picker1"})
+	require.NoError(t, err)
+}
+
+func TestReplenishmentService_CompleteAndCancel(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newPickFaceItem("SKU-1", 50)}}
+	svc, taskRepo, queue := newTestReplenishmentService(repo)
+
+	result, err := svc.GenerateTasks(context.Background(), GenerateReplenishmentsCommand{BatchSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.TasksCreated)
+	var taskID string
+	for id := range taskRepo.tasks {
+		taskID = id
+	}
+
+	_, err = svc.CompleteTask(context.Background(), CompleteReplenishmentCommand{TaskID: taskID, MovedQuantity: 21, CompletedBy: "worker1"})
+	var appErr *sharedErrors.AppError
+	require.True(t, stdErrors.As(err, &appErr))
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code)
+
+	dto, err := svc.CompleteTask(context.Background(), CompleteReplenishmentCommand{TaskID: taskID, MovedQuantity: 18, CompletedBy: "worker1"})
+	require.NoError(t, err)
+	assert.Equal(t, 18, dto.MovedQuantity)
+	assert.Equal(t, 18, repo.items["SKU-1"].GetLocationStock("PF-1").Available)
+	assert.Equal(t, 32, repo.items["SKU-1"].GetLocationStock("LOC-1").Available)
+
+	_, err = svc.CancelTask(context.Background(), CancelReplenishmentCommand{TaskID: taskID, Reason: "late"})
+	require.True(t, stdErrors.As(err, &appErr))
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code)
+	assert.Empty(t, queue.cancelled)
+
+	_, err = svc.GetTask(context.Background(), "RPL-missing")
+	require.True(t, stdErrors.As(err, &appErr))
+	assert.Equal(t, sharedErrors.CodeNotFound, appErr.Code)
+}
+
+func TestReplenishmentService_CompleteAgainAfterFailedSave(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-1": newPickFaceItem("SKU-1", 50)}}
+	svc, taskRepo, _ := newTestReplenishmentService(repo)
+
+	result, err := svc.GenerateTasks(context.Background(), GenerateReplenishmentsCommand{BatchSize: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.TasksCreated)
+	var taskID string
+	for id := range taskRepo.tasks {
+		taskID = id
+	}
+
+	// The stock is moved but the completed task is not saved
+	taskRepo.saveErrs = []error{stdErrors.New("mongo unavailable")}
+	_, err = svc.CompleteTask(context.Background(), CompleteReplenishmentCommand{TaskID: taskID, MovedQuantity: 18, CompletedBy: "worker1"})
+	require.Error(t, err)
+	assert.Equal(t, 18, repo.items["SKU-1"].GetLocationStock("PF-1").Quantity)
+	assert.True(t, taskRepo.tasks[taskID].IsOpen())
+
+	// Completing again closes the task without moving the stock twice; a concurrent
+	// change to the task is reloaded first
+	taskRepo.saveErrs = []error{sharedErrors.NewConcurrencyConflictError("ReplenishmentTask", taskID, 2)}
+	dto, err := svc.CompleteTask(context.Background(), CompleteReplenishmentCommand{TaskID: taskID, MovedQuantity: 18, CompletedBy: "worker1"})
+	require.NoError(t, err)
+	assert.Equal(t, string(domain.ReplenishmentStatusCompleted), dto.Status)
+	assert.False(t, taskRepo.tasks[taskID].IsOpen())
+	assert.Equal(t, 18, repo.items["SKU-1"].GetLocationStock("PF-1").Quantity)
+	assert.Equal(t, 32, repo.items["SKU-1"].GetLocationStock("LOC-1").Quantity)
+}
@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+// ReservationSweeper periodically releases reservations that have passed their expiry time
+type ReservationSweeper struct {
+	service  *InventoryApplicationService
+	config   ReservationSweeperConfig
+	logger   *logging.Logger
+	mu       sync.RWMutex
+	running  bool
+	stopChan chan struct{}
+}
+
+// ReservationSweeperConfig configuration for the reservation sweeper
+type ReservationSweeperConfig struct {
+	// SweepInterval is how often to look for expired reservations
+	SweepInterval time.Duration `json:"sweepInterval"`
+
+	// BatchSize is the maximum number of items processed per sweep and tenant
+	BatchSize int `json:"batchSize"`
+
+	// DryRun logs what would be released without releasing anything
+	DryRun bool `json:"dryRun"`
+
+	// TenantIDs restricts sweeps to these tenants (empty for all tenants)
+	TenantIDs []string `json:"tenantIds,omitempty"`
+}
+
+// DefaultReservationSweeperConfig returns default configuration
+func DefaultReservationSweeperConfig() ReservationSweeperConfig {
+	return ReservationSweeperConfig{
+		SweepInterval: 5 * time.Minute,
+		BatchSize:     200,
+		DryRun:        false,
+	}
+}
+
+// NewReservationSweeper creates a new reservation sweeper
+func NewReservationSweeper(
+	service *InventoryApplicationService,
+	config ReservationSweeperConfig,
+	logger *logging.Logger,
+) *ReservationSweeper {
+	return &ReservationSweeper{
+		service:  service,
+		config:   config,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the periodic reservation sweep
+func (s *ReservationSweeper) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return fmt.Errorf("reservation sweeper is already running")
+	}
+	s.running = true
+	s.stopChan = make(chan struct{})
+	s.mu.Unlock()
+
+	go s.run(ctx)
+	return nil
+}
+
+// Stop stops the periodic reservation sweep
+func (s *ReservationSweeper) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		close(s.stopChan)
+		s.running = false
+	}
+}
+
+// IsRunning returns whether the sweeper is running
+func (s *ReservationSweeper) IsRunning() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running
+}
+
+// run is the main loop for the reservation sweeper
+func (s *ReservationSweeper) run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			s.Sweep(ctx)
+		}
+	}
+}
+
+// Sweep runs one sweep for every configured tenant
+func (s *ReservationSweeper) Sweep(ctx context.Context) []*ReservationSweepResultDTO {
+	tenantIDs := s.config.TenantIDs
+	if len(tenantIDs) == 0 {
+		tenantIDs = []string{""}
+	}
+
+	results := make([]*ReservationSweepResultDTO, 0, len(tenantIDs))
+	for _, tenantID := range tenantIDs {
+		result, err := s.service.SweepExpiredReservations(ctx, SweepExpiredReservationsCommand{
+			TenantID:  tenantID,
+			BatchSize: s.config.BatchSize,
+			DryRun:    s.config.DryRun,
+		})
+		if err != nil {
+			// Log error but continue with other tenants
+			s.logger.Error("Reservation sweep failed", "tenantId", tenantID, "error", err)
+			continue
+		}
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+type fakeUnitReleaser struct {
+	requests []domain.UnitReleaseRequest
+	err      error
+}
+
+func (f *fakeUnitReleaser) ReleaseUnits(ctx context.Context, req domain.UnitReleaseRequest) error {
+	f.requests = append(f.requests, req)
+	return f.err
+}
+
+// newItemWithExpiredReservation creates an item for a tenant holding one stale reservation
+func newItemWithExpiredReservation(t *testing.T, sku, tenantID string) *domain.InventoryItem {
+	item := newItemWithStock(sku, 10)
+	item.TenantID = tenantID
+	require.NoError(t, item.ReserveWithUnits("ORD-"+sku, "LOC-1", 4, []string{"UNIT-1", "UNIT-2"}))
+	item.Reservations[0].ExpiresAt = time.Now().Add(-time.Hour)
+	item.ClearDomainEvents()
+	return item
+}
+
+func TestInventoryApplicationService_SweepExpiredReservations(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{
+		"SKU-1": newItemWithExpiredReservation(t, "SKU-1", "TENANT-A"),
+		"SKU-2": newItemWithExpiredReservation(t, "SKU-2", "TENANT-B"),
+	}}
+	releaser := &fakeUnitReleaser{}
+	svc := newTestService(repo)
+	svc.SetUnitReleaser(releaser)
+
+	// Dry run reports without releasing
+	result, err := svc.SweepExpiredReservations(context.Background(), SweepExpiredReservationsCommand{
+		TenantID:  "TENANT-A",
+		BatchSize: 10,
+		DryRun:    true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Released, 1)
+	assert.Equal(t, "SKU-1", result.Released[0].SKU)
+	assert.Equal(t, 4, repo.items["SKU-1"].ReservedQuantity)
+	assert.Empty(t, releaser.requests)
+
+	// Real sweep is scoped to the tenant
+	result, err = svc.SweepExpiredReservations(context.Background(), SweepExpiredReservationsCommand{
+		TenantID:  "TENANT-A",
+		BatchSize: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Released, 1)
+
+	item := repo.items["SKU-1"]
+	assert.Equal(t, 0, item.ReservedQuantity)
+	assert.Equal(t, 10, item.AvailableQuantity)
+	assert.Equal(t, string(domain.ReservationStatusExpired), item.Reservations[0].Status)
+	assert.Equal(t, 4, repo.items["SKU-2"].ReservedQuantity)
+
+	require.Len(t, releaser.requests, 1)
+	assert.Equal(t, "ORD-SKU-1", releaser.requests[0].OrderID)
+	assert.Equal(t, "TENANT-A", releaser.requests[0].TenantID)
+	assert.Equal(t, []string{"UNIT-1", "UNIT-2"}, releaser.requests[0].UnitIDs)
+
+	// Nothing left to release for the tenant
+	result, err = svc.SweepExpiredReservations(context.Background(), SweepExpiredReservationsCommand{
+		TenantID:  "TENANT-A",
+		BatchSize: 10,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Released)
+}
+
+func TestInventoryApplicationService_SweepExpiredReservations_RetriesUnitRelease(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{
+		"SKU-1": newItemWithExpiredReservation(t, "SKU-1", "TENANT-A"),
+	}}
+	releaser := &fakeUnitReleaser{err: errors.New("unit-service unavailable")}
+	svc := newTestService(repo)
+	svc.SetUnitReleaser(releaser)
+
+	cmd := SweepExpiredReservationsCommand{TenantID: "TENANT-A", BatchSize: 10}
+
+	// Inventory is released but the units stay pending
+	result, err := svc.SweepExpiredReservations(context.Background(), cmd)
+	require.NoError(t, err)
+	require.Len(t, result.Released, 1)
+	assert.Len(t, repo.items["SKU-1"].PendingUnitReleases(), 1)
+
+	// The next sweep retries the unit release without releasing inventory again
+	releaser.err = nil
+	result, err = svc.SweepExpiredReservations(context.Background(), cmd)
+	require.NoError(t, err)
+	assert.Empty(t, result.Released)
+	require.Len(t, releaser.requests, 2)
+	assert.Equal(t, []string{"UNIT-1", "UNIT-2"}, releaser.requests[1].UnitIDs)
+	assert.Empty(t, repo.items["SKU-1"].PendingUnitReleases())
+	assert.Equal(t, 10, repo.items["SKU-1"].AvailableQuantity)
+
+	// Nothing left to do
+	_, err = svc.SweepExpiredReservations(context.Background(), cmd)
+	require.NoError(t, err)
+	assert.Len(t, releaser.requests, 2)
+}
+
+func TestReservationSweeper_SweepAllConfiguredTenants(t *testing.T) {
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{
+		"SKU-1": newItemWithExpiredReservation(t, "SKU-1", "TENANT-A"),
+		"SKU-2": newItemWithExpiredReservation(t, "SKU-2", "TENANT-B"),
+	}}
+	svc := newTestService(repo)
+
+	config := DefaultReservationSweeperConfig()
+	config.TenantIDs = []string{"TENANT-A", "TENANT-B"}
+	sweeper := NewReservationSweeper(svc, config, svc.logger)
+
+	results := sweeper.Sweep(context.Background())
+	require.Len(t, results, 2)
+	assert.Len(t, results[0].Released, 1)
+	assert.Len(t, results[1].Released, 1)
+	assert.Equal(t, 0, repo.items["SKU-1"].ReservedQuantity)
+	assert.Equal(t, 0, repo.items["SKU-2"].ReservedQuantity)
+}
@@ -0,0 +1,489 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+const (
+	// defaultSlottingListLimit caps recommendation listings when no limit is given
+	defaultSlottingListLimit = 100
+	// slottingPageSize is how many items a slotting run loads per page
+	slottingPageSize = 500
+)
+
+// SlottingSettings tunes slotting runs
+type SlottingSettings struct {
+	Scoring         domain.SlottingConfig
+	MinSharedOrders int // Orders two SKUs must share before they count as co-picked
+	MovePriority    int // Priority of generated move tasks, 1 = highest
+}
+
+// DefaultSlottingSettings returns the default slotting settings
+func DefaultSlottingSettings() SlottingSettings {
+	return SlottingSettings{
+		Scoring:         domain.DefaultSlottingConfig(),
+		MinSharedOrders: 2,
+		MovePriority:    5,
+	}
+}
+
+// SlottingService acts on velocity: it scores each SKU's current slot against velocity,
+// cube fit, weight, ergonomic level and co-pick affinity, recommends re-slots with the travel
+// they save, and generates move tasks for the recommendations that are approved
+type SlottingService struct {
+	repo       domain.SlottingRecommendationRepository
+	slots      domain.SlotLocationRepository
+	inventory  *InventoryApplicationService
+	travel     domain.TravelDistanceEstimator
+	laborQueue domain.LaborTaskQueue // Optional: dispatches move tasks to workers in labor-service
+	settings   SlottingSettings
+	logger     *logging.Logger
+}
+
+// NewSlottingService creates a new SlottingService
+func NewSlottingService(
+	repo domain.SlottingRecommendationRepository,
+	slots domain.SlotLocationRepository,
+	inventory *InventoryApplicationService,
+	travel domain.TravelDistanceEstimator,
+	settings SlottingSettings,
+	logger *logging.Logger,
+) *SlottingService {
+	return &SlottingService{
+		repo:      repo,
+		slots:     slots,
+		inventory: inventory,
+		travel:    travel,
+		settings:  settings,
+		logger:    logger,
+	}
+}
+
+// SetLaborQueue sets the queue move tasks are handed to for dispatch
+func (s *SlottingService) SetLaborQueue(queue domain.LaborTaskQueue) {
+	s.laborQueue = queue
+}
+
+// SetSlottingProfile sets the unit cube and weight used to check which slots a SKU fits
+func (s *SlottingService) SetSlottingProfile(ctx context.Context, cmd SetSlottingProfileCommand) (*InventoryItemDTO, error) {
+	item, _, err := s.inventory.updateItem(ctx, cmd.SKU, func(item *domain.InventoryItem) error {
+		return item.SetSlottingProfile(domain.SlottingProfile{
+			UnitCube:   cmd.UnitCube,
+			UnitWeight: cmd.UnitWeight,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Set slotting profile", "sku", cmd.SKU, "unitCube", cmd.UnitCube, "unitWeight", cmd.UnitWeight)
+	return ToInventoryItemDTO(item), nil
+}
+
+// SaveSlotLocation registers a storage slot or updates its position and size limits
+func (s *SlottingService) SaveSlotLocation(ctx context.Context, cmd SaveSlotLocationCommand) (*SlotLocationDTO, error) {
+	slot, err := domain.NewSlotLocation(cmd.LocationID, cmd.Zone, cmd.Aisle, cmd.Rack, cmd.Level, cmd.CubeCapacity, cmd.MaxWeight)
+	if err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	slot.TenantID = tenant.GetTenantID(ctx)
+	slot.FacilityID = tenant.GetFacilityID(ctx)
+	slot.WarehouseID = tenant.GetWarehouseID(ctx)
+
+	if err := s.slots.Save(ctx, slot); err != nil {
+		s.logger.Error("Failed to save slot location", "locationId", cmd.LocationID, "error", err)
+		return nil, fmt.Errorf("failed to save slot location: %w", err)
+	}
+	return toSlotLocationDTO(slot), nil
+}
+
+// ListSlotLocations lists the storage slots of a zone, or all slots when zone is empty
+func (s *SlottingService) ListSlotLocations(ctx context.Context, zone string) ([]SlotLocationDTO, error) {
+	slots, err := s.slots.FindByZone(ctx, zone)
+	if err != nil {
+		s.logger.Error("Failed to list slot locations", "zone", zone, "error", err)
+		return nil, fmt.Errorf("failed to list slot locations: %w", err)
+	}
+	return toSlotLocationDTOs(slots), nil
+}
+
+// GenerateRecommendations scores every SKU in its current slot and recommends moves into free
+// slots that score clearly better. SKUs in chaotic storage and SKUs with an open recommendation
+// are left where they are, and slots already targeted by an open recommendation are not offered.
+func (s *SlottingService) GenerateRecommendations(ctx context.Context, cmd GenerateSlottingCommand) (*SlottingRunResultDTO, error) {
+	items, err := s.loadItems(ctx, cmd.Zone)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := s.slots.FindByZone(ctx, cmd.Zone)
+	if err != nil {
+		s.logger.Error("Failed to list slot locations", "zone", cmd.Zone, "error", err)
+		return nil, fmt.Errorf("failed to list slot locations: %w", err)
+	}
+	open, err := s.repo.FindOpen(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list open slotting recommendations", "error", err)
+		return nil, fmt.Errorf("failed to list open slotting recommendations: %w", err)
+	}
+
+	pendingSKUs := make(map[string]bool, len(open))
+	taken := make(map[string]bool, len(open))
+	for _, rec := range open {
+		pendingSKUs[rec.SKU] = true
+		taken[rec.ToLocationID] = true
+	}
+
+	known := make(map[string]domain.SlotLocation, len(catalog))
+	for _, slot := range catalog {
+		known[slot.LocationID] = *slot
+	}
+
+	bySKU := make(map[string]*domain.InventoryItem, len(items))
+	ordersBySKU := make(map[string][]string, len(items))
+	placed := make(map[string]domain.SlotLocation, len(items))
+	skus := make([]domain.SlottedSKU, 0, len(items))
+	for _, item := range items {
+		bySKU[item.SKU] = item
+		ordersBySKU[item.SKU] = item.OrderHistory()
+		for _, loc := range item.Locations {
+			if loc.Quantity > 0 || loc.IsPickFace() {
+				taken[loc.LocationID] = true
+			}
+		}
+
+		primary := item.PrimarySlot()
+		if primary == nil {
+			continue
+		}
+		slot, ok := known[primary.LocationID]
+		if !ok {
+			slot = domain.SlotOf(*primary)
+		}
+		placed[item.SKU] = slot
+
+		if item.StorageStrategy == domain.StorageChaotic || pendingSKUs[item.SKU] {
+			continue
+		}
+		skus = append(skus, slottedSKU(item, *primary, slot))
+	}
+
+	free := make([]domain.SlotLocation, 0)
+	for _, slot := range catalog {
+		if !taken[slot.LocationID] {
+			free = append(free, *slot)
+		}
+	}
+
+	scored := make([]domain.SlotLocation, 0, len(skus)+len(free))
+	for _, sku := range skus {
+		scored = append(scored, sku.Slot)
+	}
+	scored = append(scored, free...)
+
+	result := &SlottingRunResultDTO{
+		SKUsScored:      len(skus),
+		FreeSlots:       len(free),
+		Recommendations: make([]SlottingRecommendationDTO, 0),
+	}
+	if len(skus) == 0 || len(free) == 0 {
+		return result, nil
+	}
+
+	distances, err := s.travel.DistancesFromPickStart(ctx, scored)
+	if err != nil {
+		s.logger.Error("Failed to measure slot travel distances", "slots", len(scored), "error", err)
+		return nil, fmt.Errorf("failed to measure slot travel distances: %w", err)
+	}
+
+	config := s.settings.Scoring
+	if cmd.MaxMoves > 0 {
+		config.MaxMoves = cmd.MaxMoves
+	}
+	affinity := domain.MineCoPickAffinity(ordersBySKU, s.settings.MinSharedOrders)
+	engine := domain.NewSlottingEngine(config, scored, distances, affinity, placed)
+
+	for _, move := range engine.Plan(skus, free) {
+		rec := domain.NewSlottingRecommendation(bySKU[move.SKU.SKU], move)
+		if err := s.repo.Save(ctx, rec); err != nil {
+			s.logger.Error("Failed to save slotting recommendation", "sku", rec.SKU, "error", err)
+			return nil, fmt.Errorf("failed to save slotting recommendation: %w", err)
+		}
+		result.TravelSavings += rec.TravelSavings
+		result.Recommendations = append(result.Recommendations, *toSlottingRecommendationDTO(rec))
+	}
+
+	s.logger.Info("Generated slotting recommendations",
+		"zone", cmd.Zone,
+		"skusScored", result.SKUsScored,
+		"freeSlots", result.FreeSlots,
+		"recommendations", len(result.Recommendations),
+		"travelSavings", result.TravelSavings,
+	)
+	return result, nil
+}
+
+// Approve accepts a recommendation and generates its move task, handing it to labor-service.
+// Approving an approved recommendation again retries handing its move task to labor-service.
+func (s *SlottingService) Approve(ctx context.Context, cmd ApproveSlottingCommand) (*SlottingRecommendationDTO, error) {
+	rec, err := s.getRecommendation(ctx, cmd.RecommendationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.Status != domain.SlottingStatusApproved {
+		if err := rec.Approve(cmd.ApprovedBy, s.settings.MovePriority); err != nil {
+			return nil, errors.ErrValidation(err.Error())
+		}
+		if err := s.save(ctx, rec); err != nil {
+			return nil, err
+		}
+		s.logger.Info("Approved slotting recommendation",
+			"recommendationId", rec.RecommendationID,
+			"sku", rec.SKU,
+			"moveTaskId", rec.MoveTaskID,
+			"approvedBy", cmd.ApprovedBy,
+		)
+	}
+
+	if rec.LaborQueuedAt == nil {
+		s.queue(ctx, rec)
+	}
+	return toSlottingRecommendationDTO(rec), nil
+}
+
+// Reject declines a recommendation awaiting approval
+func (s *SlottingService) Reject(ctx context.Context, cmd RejectSlottingCommand) (*SlottingRecommendationDTO, error) {
+	rec, err := s.getRecommendation(ctx, cmd.RecommendationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rec.Reject(cmd.RejectedBy, cmd.Reason); err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	if err := s.save(ctx, rec); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Rejected slotting recommendation", "recommendationId", rec.RecommendationID, "reason", cmd.Reason)
+	return toSlottingRecommendationDTO(rec), nil
+}
+
+// CompleteMove relocates the SKU's stock to the new slot and closes the move task. The stock
+// moves at most once per move task, so completing again after a failed save is safe.
+func (s *SlottingService) CompleteMove(ctx context.Context, cmd CompleteSlottingMoveCommand) (*SlottingRecommendationDTO, error) {
+	rec, err := s.getRecommendation(ctx, cmd.RecommendationID)
+	if err != nil {
+		return nil, err
+	}
+	if rec.Status != domain.SlottingStatusApproved {
+		return nil, errors.ErrValidation(domain.ErrSlottingRecommendationNotApproved.Error())
+	}
+
+	moved, err := s.inventory.Reslot(ctx, rec, cmd.MovedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resilience.RetryOnConflict(ctx, func() error {
+		if err := rec.Complete(moved, cmd.MovedBy); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		err := s.repo.Save(ctx, rec)
+		if errors.IsConcurrencyConflict(err) {
+			s.logger.Debug("Slotting recommendation changed concurrently, retrying", "recommendationId", rec.RecommendationID)
+			current, findErr := s.getRecommendation(ctx, rec.RecommendationID)
+			if findErr != nil {
+				return findErr
+			}
+			*rec = *current
+		}
+		return err
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("slotting recommendation %s was modified concurrently, please retry", rec.RecommendationID)).Wrap(err)
+	}
+	if err != nil {
+		if _, ok := err.(*errors.AppError); ok {
+			return nil, err
+		}
+		s.logger.Error("Failed to save slotting recommendation", "recommendationId", rec.RecommendationID, "error", err)
+		return nil, fmt.Errorf("failed to save slotting recommendation: %w", err)
+	}
+
+	if s.laborQueue != nil && rec.LaborQueuedAt != nil {
+		if err := s.laborQueue.CompleteTask(ctx, toSlottingLaborTask(rec)); err != nil {
+			s.logger.Warn("Failed to complete labor task", "taskId", rec.MoveTaskID, "error", err)
+		}
+	}
+
+	s.logger.Info("Completed slotting move",
+		"recommendationId", rec.RecommendationID,
+		"sku", rec.SKU,
+		"fromLocationId", rec.FromLocationID,
+		"toLocationId", rec.ToLocationID,
+		"movedQuantity", moved,
+	)
+	return toSlottingRecommendationDTO(rec), nil
+}
+
+// CancelMove withdraws the move task of an approved recommendation without moving stock
+func (s *SlottingService) CancelMove(ctx context.Context, cmd CancelSlottingMoveCommand) (*SlottingRecommendationDTO, error) {
+	rec, err := s.getRecommendation(ctx, cmd.RecommendationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rec.Cancel(cmd.Reason); err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	if err := s.save(ctx, rec); err != nil {
+		return nil, err
+	}
+
+	if s.laborQueue != nil && rec.LaborQueuedAt != nil {
+		if err := s.laborQueue.CancelTask(ctx, toSlottingLaborTask(rec)); err != nil {
+			s.logger.Warn("Failed to cancel labor task", "taskId", rec.MoveTaskID, "error", err)
+		}
+	}
+
+	s.logger.Info("Cancelled slotting move", "recommendationId", rec.RecommendationID, "reason", cmd.Reason)
+	return toSlottingRecommendationDTO(rec), nil
+}
+
+// GetRecommendation retrieves a slotting recommendation
+func (s *SlottingService) GetRecommendation(ctx context.Context, recommendationID string) (*SlottingRecommendationDTO, error) {
+	rec, err := s.getRecommendation(ctx, recommendationID)
+	if err != nil {
+		return nil, err
+	}
+	return toSlottingRecommendationDTO(rec), nil
+}
+
+// ListRecommendations lists slotting recommendations by status, largest travel savings first
+func (s *SlottingService) ListRecommendations(ctx context.Context, query ListSlottingQuery) ([]SlottingRecommendationDTO, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSlottingListLimit
+	}
+
+	status := domain.SlottingRecommendationStatus(query.Status)
+	if status == "" {
+		status = domain.SlottingStatusPending
+	}
+	recs, err := s.repo.FindByStatus(ctx, status, limit)
+	if err != nil {
+		s.logger.Error("Failed to list slotting recommendations", "status", status, "error", err)
+		return nil, fmt.Errorf("failed to list slotting recommendations: %w", err)
+	}
+	return toSlottingRecommendationDTOs(recs), nil
+}
+
+// loadItems returns the items of a zone, or every item when zone is empty
+func (s *SlottingService) loadItems(ctx context.Context, zone string) ([]*domain.InventoryItem, error) {
+	if zone != "" {
+		items, err := s.inventory.repo.FindByZone(ctx, zone)
+		if err != nil {
+			s.logger.Error("Failed to get items", "zone", zone, "error", err)
+			return nil, fmt.Errorf("failed to get items: %w", err)
+		}
+		return items, nil
+	}
+
+	items := make([]*domain.InventoryItem, 0)
+	for offset := 0; ; offset += slottingPageSize {
+		page, err := s.inventory.repo.FindAll(ctx, slottingPageSize, offset)
+		if err != nil {
+			s.logger.Error("Failed to get items", "offset", offset, "error", err)
+			return nil, fmt.Errorf("failed to get items: %w", err)
+		}
+		items = append(items, page...)
+		if len(page) < slottingPageSize {
+			break
+		}
+	}
+	return items, nil
+}
+
+// slottedSKU describes an item in its primary slot. A pick face has to hold its maximum;
+// any other slot the stock it holds now.
+func slottedSKU(item *domain.InventoryItem, primary domain.StockLocation, slot domain.SlotLocation) domain.SlottedSKU {
+	quantity := primary.Quantity
+	if primary.IsPickFace() && primary.MaxQuantity > quantity {
+		quantity = primary.MaxQuantity
+	}
+
+	sku := domain.SlottedSKU{
+		SKU:           item.SKU,
+		VelocityClass: item.VelocityClass,
+		PickFrequency: item.PickFrequency,
+		Slot:          slot,
+		Quantity:      quantity,
+	}
+	if item.Slotting != nil {
+		sku.UnitCube = item.Slotting.UnitCube
+		sku.UnitWeight = item.Slotting.UnitWeight
+	}
+	return sku
+}
+
+// queue hands an approved recommendation's move task to labor-service. Failures are logged;
+// approving the recommendation again retries.
+func (s *SlottingService) queue(ctx context.Context, rec *domain.SlottingRecommendation) {
+	if s.laborQueue == nil {
+		return
+	}
+
+	if err := s.laborQueue.EnqueueTask(ctx, toSlottingLaborTask(rec)); err != nil {
+		s.logger.Warn("Failed to queue move task for labor", "taskId", rec.MoveTaskID, "error", err)
+		return
+	}
+
+	rec.MarkQueued(time.Now())
+	if err := s.repo.Save(ctx, rec); err != nil {
+		s.logger.Warn("Failed to save queued slotting recommendation", "recommendationId", rec.RecommendationID, "error", err)
+	}
+}
+
+// toSlottingLaborTask describes a move task to labor-service. Moves are worked by the same
+// workers as replenishment, so they are dispatched as replenishment work.
+func toSlottingLaborTask(rec *domain.SlottingRecommendation) domain.LaborTask {
+	return domain.LaborTask{
+		TenantID:    rec.TenantID,
+		FacilityID:  rec.FacilityID,
+		WarehouseID: rec.WarehouseID,
+		TaskID:      rec.MoveTaskID,
+		TaskType:    laborTaskTypeReplenishment,
+		Priority:    rec.Priority,
+		Zone:        rec.Zone,
+	}
+}
+
+func (s *SlottingService) save(ctx context.Context, rec *domain.SlottingRecommendation) error {
+	if err := s.repo.Save(ctx, rec); err != nil {
+		s.logger.Error("Failed to save slotting recommendation", "recommendationId", rec.RecommendationID, "error", err)
+		return fmt.Errorf("failed to save slotting recommendation: %w", err)
+	}
+	return nil
+}
+
+func (s *SlottingService) getRecommendation(ctx context.Context, recommendationID string) (*domain.SlottingRecommendation, error) {
+	rec, err := s.repo.FindByID(ctx, recommendationID)
+	if err != nil {
+		s.logger.Error("Failed to get slotting recommendation", "recommendationId", recommendationID, "error", err)
+		return nil, fmt.Errorf("failed to get slotting recommendation: %w", err)
+	}
+	if rec == nil {
+		return nil, errors.ErrNotFound("slotting recommendation")
+	}
+	return rec, nil
+}
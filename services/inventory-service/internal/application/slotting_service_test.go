@@ -0,0 +1,312 @@
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+type fakeSlotLocationRepo struct {
+	slots map[string]*domain.SlotLocation
+}
+
+func (f *fakeSlotLocationRepo) Save(ctx context.Context, slot *domain.SlotLocation) error {
+	if f.slots == nil {
+		f.slots = make(map[string]*domain.SlotLocation)
+	}
+	f.slots[slot.LocationID] = slot
+	return nil
+}
+
+func (f *fakeSlotLocationRepo) FindByZone(ctx context.Context, zone string) ([]*domain.SlotLocation, error) {
+	results := make([]*domain.SlotLocation, 0)
+	for _, slot := range f.slots {
+		if zone == "" || slot.Zone == zone {
+			results = append(results, slot)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].LocationID < results[j].LocationID })
+	return results, nil
+}
+
+type fakeSlottingRepo struct {
+	recs     map[string]*domain.SlottingRecommendation
+	saveErrs []error // Returned by the next saves in turn
+}
+
+func (f *fakeSlottingRepo) Save(ctx context.Context, rec *domain.SlottingRecommendation) error {
+	if len(f.saveErrs) > 0 {
+		err := f.saveErrs[0]
+		f.saveErrs = f.saveErrs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	if f.recs == nil {
+		f.recs = make(map[string]*domain.SlottingRecommendation)
+	}
+	stored := *rec
+	f.recs[rec.RecommendationID] = &stored
+	return nil
+}
+
+func (f *fakeSlottingRepo) FindByID(ctx context.Context, recommendationID string) (*domain.SlottingRecommendation, error) {
+	rec, ok := f.recs[recommendationID]
+	if !ok {
+		return nil, nil
+	}
+	loaded := *rec
+	return &loaded, nil
+}
+
+func (f *fakeSlottingRepo) FindOpen(ctx context.Context) ([]*domain.SlottingRecommendation, error) {
+	results := make([]*domain.SlottingRecommendation, 0)
+	for _, rec := range f.recs {
+		if rec.IsOpen() {
+			results = append(results, rec)
+		}
+	}
+	return results, nil
+}
+
+func (f *fakeSlottingRepo) FindByStatus(ctx context.Context, status domain.SlottingRecommendationStatus, limit int) ([]*domain.SlottingRecommendation, error) {
+	results := make([]*domain.SlottingRecommendation, 0)
+	for _, rec := range f.recs {
+		if rec.Status == status {
+			results = append(results, rec)
+		}
+	}
+	return results, nil
+}
+
+type fakeTravelEstimator struct {
+	err       error
+	distances map[string]float64
+}
+
+func (f *fakeTravelEstimator) DistancesFromPickStart(ctx context.Context, slots []domain.SlotLocation) (map[string]float64, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.distances, nil
+}
+
+// newSlottingFixture returns a service with an A-class SKU in top-level slot A-20-6 and a free
+// golden zone slot A-01-2 nearer the pick start
+func newSlottingFixture(t *testing.T) (*SlottingService, *fakeInventoryRepo, *fakeSlottingRepo, *fakeTravelEstimator, *fakeLaborQueue) {
+	item := domain.NewInventoryItem("SKU-FAST", "Widget", 5, 10)
+	require.NoError(t, item.ReceiveStock("A-20-6", "ZONE-A", 30, "PO-1", "user1"))
+	item.UpdatePickFrequency(80)
+	repo := &fakeInventoryRepo{items: map[string]*domain.InventoryItem{"SKU-FAST": item}}
+
+	logger := logging.New(logging.DefaultConfig("test"))
+	recRepo := &fakeSlottingRepo{}
+	travel := &fakeTravelEstimator{distances: map[string]float64{"A-01-2": 5, "A-20-6": 45}}
+	queue := &fakeLaborQueue{}
+	svc := NewSlottingService(recRepo, &fakeSlotLocationRepo{}, newTestService(repo), travel, DefaultSlottingSettings(), logger)
+	svc.SetLaborQueue(queue)
+
+	for _, cmd := range []SaveSlotLocationCommand{
+		{LocationID: "A-01-2", Zone: "ZONE-A", Aisle: "A", Rack: 1, Level: 2},
+		{LocationID: "A-20-6", Zone: "ZONE-A", Aisle: "A", Rack: 20, Level: 6},
+	} {
+		_, err := svc.SaveSlotLocation(context.Background(), cmd)
+		require.NoError(t, err)
+	}
+	return svc, repo, recRepo, travel, queue
+}
+
+func TestSlottingService_GenerateRecommendations(t *testing.T) {
+	svc, _, _, _, _ := newSlottingFixture(t)
+	ctx := context.Background()
+
+	result, err := svc.GenerateRecommendations(ctx, GenerateSlottingCommand{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.SKUsScored)
+	assert.Equal(t, 1, result.FreeSlots, "the occupied slot is not free")
+	require.Len(t, result.Recommendations, 1)
+
+	rec := result.Recommendations[0]
+	assert.Equal(t, "SKU-FAST", rec.SKU)
+	assert.Equal(t, "A-20-6", rec.FromLocationID)
+	assert.Equal(t, "A-01-2", rec.ToLocationID)
+	assert.Equal(t, "pending", rec.Status)
+	assert.Equal(t, 6400.0, rec.TravelSavings, "80 picks a week, 2 × 40 m closer")
+	assert.Equal(t, 6400.0, result.TravelSavings)
+
+	again, err := svc.GenerateRecommendations(ctx, GenerateSlottingCommand{})
+	require.NoError(t, err)
+	assert.Empty(t, again.Recommendations, "a SKU with an open recommendation is not re-slotted twice")
+}
+
+func TestSlottingService_GenerateRecommendationsSkipsChaoticStorage(t *testing.T) {
+	svc, repo, _, _, _ := newSlottingFixture(t)
+	repo.items["SKU-FAST"].StorageStrategy = domain.StorageChaotic
+
+	result, err := svc.GenerateRecommendations(context.Background(), GenerateSlottingCommand{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.SKUsScored)
+	assert.Empty(t, result.Recommendations)
+}
+
+func TestSlottingService_GenerateRecommendationsTravelError(t *testing.T) {
+	svc, _, _, travel, _ := newSlottingFixture(t)
+	travel.err = stdErrors.New("routing unavailable")
+
+	_, err := svc.GenerateRecommendations(context.Background(), GenerateSlottingCommand{})
+	assert.ErrorContains(t, err, "routing unavailable")
+}
+
+func TestSlottingService_ApproveAndCompleteMove(t *testing.T) {
+	svc, repo, _, _, queue := newSlottingFixture(t)
+	ctx := context.Background()
+	require.NoError(t, repo.items["SKU-FAST"].Reserve("ORD-1", "A-20-6", 3))
+
+	result, err := svc.GenerateRecommendations(ctx, GenerateSlottingCommand{})
+	require.NoError(t, err)
+	require.Len(t, result.Recommendations, 1)
+	id := result.Recommendations[0].RecommendationID
+
+	approved, err := svc.Approve(ctx, ApproveSlottingCommand{RecommendationID: id, ApprovedBy: "manager"})
+	require.NoError(t, err)
+	assert.Equal(t, "approved", approved.Status)
+	assert.True(t, approved.LaborQueued)
+	require.Len(t, queue.queued, 1)
+	assert.Equal(t, approved.MoveTaskID, queue.queued[0].TaskID)
+	assert.Equal(t, "replenishment", queue.queued[0].TaskType)
+	assert.Equal(t, "ZONE-A", queue.queued[0].Zone)
+
+	completed, err := svc.CompleteMove(ctx, CompleteSlottingMoveCommand{RecommendationID: id, MovedBy: "worker1"})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", completed.Status)
+	assert.Equal(t, 30, completed.MovedQuantity)
+	assert.Equal(t, []string{approved.MoveTaskID}, queue.completed)
+
+	item := repo.items["SKU-FAST"]
+	slot := item.GetLocationStock("A-01-2")
+	require.NotNil(t, slot)
+	assert.Equal(t, 30, slot.Quantity)
+	assert.Equal(t, 3, slot.Reserved, "the reservation moves with its stock")
+	assert.Equal(t, 2, slot.Level)
+	assert.Equal(t, 0, item.GetLocationStock("A-20-6").Quantity)
+
+	_, err = svc.CompleteMove(ctx, CompleteSlottingMoveCommand{RecommendationID: id, MovedBy: "worker1"})
+	var appErr *sharedErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code)
+}
+
+func TestSlottingService_CompleteMoveAgainAfterFailedSave(t *testing.T) {
+	svc, repo, recRepo, _, queue := newSlottingFixture(t)
+	ctx := context.Background()
+
+	result, err := svc.GenerateRecommendations(ctx, GenerateSlottingCommand{})
+	require.NoError(t, err)
+	require.Len(t, result.Recommendations, 1)
+	id := result.Recommendations[0].RecommendationID
+	_, err = svc.Approve(ctx, ApproveSlottingCommand{RecommendationID: id, ApprovedBy: "manager"})
+	require.NoError(t, err)
+
+	// The stock is moved but the completed recommendation is not saved
+	recRepo.saveErrs = []error{stdErrors.New("mongo unavailable")}
+	_, err = svc.CompleteMove(ctx, CompleteSlottingMoveCommand{RecommendationID: id, MovedBy: "worker1"})
+	require.Error(t, err)
+	assert.Equal(t, 30, repo.items["SKU-FAST"].GetLocationStock("A-01-2").Quantity)
+	assert.Equal(t, domain.SlottingStatusApproved, recRepo.recs[id].Status)
+	assert.Empty(t, queue.completed)
+
+	// Completing again closes the move without moving the stock twice; a concurrent
+	// change to the recommendation is reloaded first
+	recRepo.saveErrs = []error{sharedErrors.NewConcurrencyConflictError("SlottingRecommendation", id, 2)}
+	completed, err := svc.CompleteMove(ctx, CompleteSlottingMoveCommand{RecommendationID: id, MovedBy: "worker1"})
+	require.NoError(t, err)
+	assert.Equal(t, "completed", completed.Status)
+	assert.Equal(t, 30, completed.MovedQuantity)
+	assert.Equal(t, domain.SlottingStatusCompleted, recRepo.recs[id].Status)
+	assert.Equal(t, 30, repo.items["SKU-FAST"].GetLocationStock("A-01-2").Quantity)
+	assert.Len(t, queue.completed, 1)
+}
+
+func TestSlottingService_RejectAndCancel(t *testing.T) {
+	svc, _, recRepo, _, queue := newSlottingFixture(t)
+	ctx := context.Background()
+
+	result, err := svc.GenerateRecommendations(ctx, GenerateSlottingCommand{})
+	require.NoError(t, err)
+	id := result.Recommendations[0].RecommendationID
+
+	rejected, err := svc.Reject(ctx, RejectSlottingCommand{RecommendationID: id, RejectedBy: "manager", Reason: "aisle under maintenance"})
+	require.NoError(t, err)
+	assert.Equal(t, "rejected", rejected.Status)
+	assert.Empty(t, queue.queued)
+
+	pending, err := svc.ListRecommendations(ctx, ListSlottingQuery{})
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// A rejected recommendation no longer holds the SKU back
+	result, err = svc.GenerateRecommendations(ctx, GenerateSlottingCommand{})
+	require.NoError(t, err)
+	require.Len(t, result.Recommendations, 1)
+	id = result.Recommendations[0].RecommendationID
+
+	_, err = svc.CancelMove(ctx, CancelSlottingMoveCommand{RecommendationID: id, Reason: "not approved"})
+	var appErr *sharedErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code)
+
+	approved, err := svc.Approve(ctx, ApproveSlottingCommand{RecommendationID: id, ApprovedBy: "manager"})
+	require.NoError(t, err)
+	cancelled, err := svc.CancelMove(ctx, CancelSlottingMoveCommand{RecommendationID: id, Reason: "peak season"})
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", cancelled.Status)
+	assert.Equal(t, []string{approved.MoveTaskID}, queue.cancelled)
+	assert.Equal(t, domain.SlottingStatusCancelled, recRepo.recs[id].Status)
+}
+
+func TestSlottingService_ApproveRetriesLaborQueue(t *testing.T) {
+	svc, _, _, _, queue := newSlottingFixture(t)
+	ctx := context.Background()
+
+	result, err := svc.GenerateRecommendations(ctx, GenerateSlottingCommand{})
+	require.NoError(t, err)
+	id := result.Recommendations[0].RecommendationID
+
+	queue.err = stdErrors.New("labor unavailable")
+	approved, err := svc.Approve(ctx, ApproveSlottingCommand{RecommendationID: id, ApprovedBy: "manager"})
+	require.NoError(t, err)
+	assert.False(t, approved.LaborQueued)
+
+	queue.err = nil
+	retried, err := svc.Approve(ctx, ApproveSlottingCommand{RecommendationID: id, ApprovedBy: "manager"})
+	require.NoError(t, err)
+	assert.True(t, retried.LaborQueued)
+	assert.Equal(t, approved.MoveTaskID, retried.MoveTaskID)
+}
+
+func TestSlottingService_SetSlottingProfileAndNotFound(t *testing.T) {
+	svc, _, _, _, _ := newSlottingFixture(t)
+	ctx := context.Background()
+
+	dto, err := svc.SetSlottingProfile(ctx, SetSlottingProfileCommand{SKU: "SKU-FAST", UnitCube: 1200, UnitWeight: 1.5})
+	require.NoError(t, err)
+	require.NotNil(t, dto.Slotting)
+	assert.Equal(t, 1200.0, dto.Slotting.UnitCube)
+
+	_, err = svc.SetSlottingProfile(ctx, SetSlottingProfileCommand{SKU: "SKU-FAST", UnitWeight: -1})
+	var appErr *sharedErrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeValidationError, appErr.Code)
+
+	_, err = svc.GetRecommendation(ctx, "SLT-missing")
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, sharedErrors.CodeNotFound, appErr.Code)
+}
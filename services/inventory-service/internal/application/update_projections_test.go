@@ -65,6 +65,19 @@ func (u *updateInventoryRepo) FindLowStock(ctx context.Context) ([]*domain.Inven
 func (u *updateInventoryRepo) FindAll(ctx context.Context, limit, offset int) ([]*domain.InventoryItem, error) {
 	return nil, nil
 }
+func (u *updateInventoryRepo) FindWithLotsExpiringBefore(ctx context.Context, now, cutoff time.Time, limit int) ([]*domain.InventoryItem, error) {
+	return nil, nil
+}
+
+func (u *updateInventoryRepo) FindWithExpiredReservations(ctx context.Context, asOf time.Time, tenantID string, limit int) ([]*domain.InventoryItem, error) {
+	return nil, nil
+}
+func (u *updateInventoryRepo) FindDueForCycleCount(ctx context.Context, cutoffs map[domain.VelocityClass]time.Time, limit int) ([]*domain.InventoryItem, error) {
+	return nil, nil
+}
+func (u *updateInventoryRepo) FindBelowPickFaceMinimum(ctx context.Context, limit int) ([]*domain.InventoryItem, error) {
+	return nil, nil
+}
 func (u *updateInventoryRepo) Delete(ctx context.Context, sku string) error {
 	return nil
 }
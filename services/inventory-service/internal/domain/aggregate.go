@@ -12,6 +12,7 @@ var (
 	ErrInsufficientStock           = errors.New("insufficient stock")
 	ErrInvalidQuantity             = errors.New("invalid quantity")
 	ErrReservationNotFound         = errors.New("reservation not found")
+	ErrReservationNotExpired       = errors.New("reservation has not expired")
 	ErrAllocationNotFound          = errors.New("hard allocation not found")
 	ErrAlreadyHardAllocated        = errors.New("reservation already hard allocated")
 	ErrCannotReleaseHardAllocation = errors.New("cannot release hard allocation without physical return")
@@ -82,10 +83,13 @@ type InventoryItem struct {
 	ReservedQuantity      int                    `bson:"reservedQuantity"`
 	HardAllocatedQuantity int                    `bson:"hardAllocatedQuantity"`
 	AvailableQuantity     int                    `bson:"availableQuantity"`
+	NonSellableQuantity   int                    `bson:"nonSellableQuantity"` // Damaged, quarantined, held and returned stock
+	InTransitQuantity     int                    `bson:"inTransitQuantity"`   // Picked onto carts or totes for two-step moves
 	ReorderPoint          int                    `bson:"reorderPoint"`
 	ReorderQuantity       int                    `bson:"reorderQuantity"`
 	Reservations          []Reservation          `bson:"reservations"`
 	HardAllocations       []HardAllocation       `bson:"hardAllocations"`
+	InTransit             []InTransitStock       `bson:"inTransit,omitempty"`
 	Transactions          []InventoryTransaction `bson:"transactions,omitempty"`
 	LastCycleCount        *time.Time             `bson:"lastCycleCount,omitempty"`
 	// Velocity and storage fields (Amazon-style optimization)
@@ -94,34 +98,50 @@ type InventoryItem struct {
 	PickFrequency   int             `bson:"pickFrequency" json:"pickFrequency"`     // picks per week
 	LastStowedAt    *time.Time      `bson:"lastStowedAt,omitempty" json:"lastStowedAt,omitempty"`
 	LastPickedAt    *time.Time      `bson:"lastPickedAt,omitempty" json:"lastPickedAt,omitempty"`
+
+	// Customs data for international shipments
+	Customs *CustomsProfile `bson:"customs,omitempty" json:"customs,omitempty"`
+
+	// Unit cube and weight for slotting
+	Slotting *SlottingProfile `bson:"slotting,omitempty" json:"slotting,omitempty"`
+
 	CreatedAt       time.Time       `bson:"createdAt"`
 	UpdatedAt       time.Time       `bson:"updatedAt"`
+	Version         int             `bson:"version" json:"version"` // Incremented on every save for optimistic concurrency
 	DomainEvents    []DomainEvent   `bson:"-"`
 }
 
 // StockLocation represents inventory at a specific location
 type StockLocation struct {
-	LocationID    string `bson:"locationId"`
-	Zone          string `bson:"zone"`
-	Aisle         string `bson:"aisle"`
-	Rack          int    `bson:"rack"`
-	Level         int    `bson:"level"`
-	Quantity      int    `bson:"quantity"`
-	Reserved      int    `bson:"reserved"`
-	HardAllocated int    `bson:"hardAllocated"`
-	Available     int    `bson:"available"`
+	LocationID    string         `bson:"locationId"`
+	Zone          string         `bson:"zone"`
+	Aisle         string         `bson:"aisle"`
+	Rack          int            `bson:"rack"`
+	Level         int            `bson:"level"`
+	Quantity      int            `bson:"quantity"`
+	Reserved      int            `bson:"reserved"`
+	HardAllocated int            `bson:"hardAllocated"`
+	Available     int            `bson:"available"`
+	Blocked       int            `bson:"blocked,omitempty"`     // Expired lot stock not available for allocation
+	Lots          []StockLot     `bson:"lots,omitempty"`        // Lot/batch breakdown for lot-tracked stock
+	MinQuantity   int            `bson:"minQuantity,omitempty"` // Pick face: replenish when shelf stock drops below
+	MaxQuantity   int            `bson:"maxQuantity,omitempty"` // Pick face: fill up to this quantity (0 = reserve storage)
+	Statuses      []StatusBucket `bson:"statuses,omitempty"`    // Non-sellable stock by inventory status
 }
 
 // Reservation represents a stock reservation for an order
 type Reservation struct {
-	ReservationID string    `bson:"reservationId"`
-	OrderID       string    `bson:"orderId"`
-	Quantity      int       `bson:"quantity"`
-	LocationID    string    `bson:"locationId"`
-	Status        string    `bson:"status"` // active, staged, fulfilled, cancelled
-	UnitIDs       []string  `bson:"unitIds,omitempty"` // Specific units reserved for unit-level tracking
-	CreatedAt     time.Time `bson:"createdAt"`
-	ExpiresAt     time.Time `bson:"expiresAt"`
+	ReservationID string          `bson:"reservationId"`
+	OrderID       string          `bson:"orderId"`
+	Quantity      int             `bson:"quantity"`
+	LocationID    string          `bson:"locationId"`
+	Status        string          `bson:"status"`            // active, staged, fulfilled, cancelled
+	UnitIDs       []string        `bson:"unitIds,omitempty"` // Specific units reserved for unit-level tracking
+	Lots          []LotAllocation `bson:"lots,omitempty"`    // Lots allocated FEFO for lot-tracked stock
+	CreatedAt     time.Time       `bson:"createdAt"`
+	ExpiresAt     time.Time       `bson:"expiresAt"`
+	// UnitReleasePending is set when an expired reservation's units still have to be released in unit-service
+	UnitReleasePending bool `bson:"unitReleasePending,omitempty"`
 }
 
 // HardAllocation represents physically staged/locked inventory
@@ -145,11 +165,13 @@ type HardAllocation struct {
 // InventoryTransaction represents an inventory change
 type InventoryTransaction struct {
 	TransactionID string    `bson:"transactionId"`
-	Type          string    `bson:"type"` // receive, pick, adjust, transfer
+	Type          string    `bson:"type"` // receive, pick, adjust, transfer, status_change
 	Quantity      int       `bson:"quantity"`
 	LocationID    string    `bson:"locationId"`
 	ReferenceID   string    `bson:"referenceId"` // Order ID, PO ID, etc.
 	Reason        string    `bson:"reason,omitempty"`
+	FromStatus    string    `bson:"fromStatus,omitempty"` // Status changes only
+	ToStatus      string    `bson:"toStatus,omitempty"`   // Status changes only
 	CreatedAt     time.Time `bson:"createdAt"`
 	CreatedBy     string    `bson:"createdBy"`
 }
@@ -206,33 +228,69 @@ func NewInventoryItemWithTenant(sku, productName string, reorderPoint, reorderQt
 
 // ReceiveStock adds stock to a location
 func (i *InventoryItem) ReceiveStock(locationID, zone string, quantity int, referenceID, createdBy string) error {
+	return i.receive(locationID, zone, quantity, nil, referenceID, createdBy)
+}
+
+// receive adds stock to a location, optionally into a lot
+func (i *InventoryItem) receive(locationID, zone string, quantity int, lot *LotInfo, referenceID, createdBy string) error {
 	if quantity <= 0 {
 		return ErrInvalidQuantity
 	}
 
 	// Find or create location
-	found := false
+	locIdx := -1
 	for idx := range i.Locations {
 		if i.Locations[idx].LocationID == locationID {
-			i.Locations[idx].Quantity += quantity
-			i.Locations[idx].Available += quantity
-			found = true
+			locIdx = idx
 			break
 		}
 	}
 
-	if !found {
+	if locIdx == -1 {
 		i.Locations = append(i.Locations, StockLocation{
 			LocationID: locationID,
 			Zone:       zone,
-			Quantity:   quantity,
-			Available:  quantity,
 		})
+		locIdx = len(i.Locations) - 1
 	}
 
+	now := time.Now()
+	location := &i.Locations[locIdx]
+	location.Quantity += quantity
+
+	// Lots received already expired are blocked straight away
+	availableQty := quantity
+	if lot != nil {
+		stockLot := location.GetLot(lot.LotNumber)
+		if stockLot == nil {
+			location.Lots = append(location.Lots, StockLot{
+				LotNumber:       lot.LotNumber,
+				ManufactureDate: lot.ManufactureDate,
+				ExpiryDate:      lot.ExpiryDate,
+				Status:          LotStatusActive,
+				ReceivedAt:      now,
+			})
+			stockLot = &location.Lots[len(location.Lots)-1]
+		}
+		stockLot.Quantity += quantity
+
+		if stockLot.Status == LotStatusActive && stockLot.IsExpired(now) {
+			// Block the received quantity and whatever of the lot was still available
+			previouslyAvailable := stockLot.Available() - quantity
+			stockLot.Status = LotStatusExpired
+			stockLot.BlockedAt = &now
+			location.Blocked += quantity + previouslyAvailable
+			availableQty = -previouslyAvailable
+		} else if stockLot.Status == LotStatusExpired {
+			location.Blocked += quantity
+			availableQty = 0
+		}
+	}
+
+	location.Available += availableQty
 	i.TotalQuantity += quantity
-	i.AvailableQuantity += quantity
-	i.UpdatedAt = time.Now()
+	i.AvailableQuantity += availableQty
+	i.UpdatedAt = now
 
 	// Record transaction
 	i.Transactions = append(i.Transactions, InventoryTransaction{
@@ -245,12 +303,17 @@ func (i *InventoryItem) ReceiveStock(locationID, zone string, quantity int, refe
 		CreatedBy:     createdBy,
 	})
 
-	i.AddDomainEvent(&InventoryReceivedEvent{
+	receivedEvent := &InventoryReceivedEvent{
 		SKU:        i.SKU,
 		Quantity:   quantity,
 		LocationID: locationID,
 		ReceivedAt: time.Now(),
-	})
+	}
+	if lot != nil {
+		receivedEvent.LotNumber = lot.LotNumber
+		receivedEvent.ExpiryDate = lot.ExpiryDate
+	}
+	i.AddDomainEvent(receivedEvent)
 
 	return nil
 }
@@ -262,11 +325,18 @@ func (i *InventoryItem) Reserve(orderID, locationID string, quantity int) error
 
 // ReserveWithUnits reserves stock for an order with specific unit IDs
 func (i *InventoryItem) ReserveWithUnits(orderID, locationID string, quantity int, unitIDs []string) error {
+	return i.ReserveWithPolicy(orderID, locationID, quantity, unitIDs, AllocationPolicy{})
+}
+
+// ReserveWithPolicy reserves stock for an order, allocating lot-tracked stock FEFO
+// (first-expired, first-out) among lots meeting the policy's minimum remaining shelf life
+func (i *InventoryItem) ReserveWithPolicy(orderID, locationID string, quantity int, unitIDs []string, policy AllocationPolicy) error {
 	if quantity <= 0 {
 		return ErrInvalidQuantity
 	}
 
 	// Check availability at location
+	var lotAllocations []LotAllocation
 	foundLocation := false
 	for idx := range i.Locations {
 		if i.Locations[idx].LocationID == locationID {
@@ -275,6 +345,14 @@ func (i *InventoryItem) ReserveWithUnits(orderID, locationID string, quantity in
 				return ErrInsufficientStock
 			}
 
+			if i.Locations[idx].IsLotTracked() {
+				allocations, err := i.Locations[idx].allocateFEFO(quantity, policy)
+				if err != nil {
+					return err
+				}
+				lotAllocations = allocations
+			}
+
 			i.Locations[idx].Reserved += quantity
 			i.Locations[idx].Available -= quantity
 			break
@@ -294,6 +372,7 @@ func (i *InventoryItem) ReserveWithUnits(orderID, locationID string, quantity in
 		LocationID:    locationID,
 		Status:        "active",
 		UnitIDs:       unitIDs,
+		Lots:          lotAllocations,
 		CreatedAt:     time.Now(),
 		ExpiresAt:     time.Now().Add(24 * time.Hour),
 	}
@@ -343,6 +422,11 @@ func (i *InventoryItem) Pick(orderID, locationID string, quantity int, createdBy
 	if locationIdx == -1 {
 		return ErrLocationNotFound
 	}
+	if i.Locations[locationIdx].holdsExpiredLot(i.Reservations[reservationIdx].Lots) {
+		return ErrLotExpired
+	}
+
+	i.Reservations[reservationIdx].Lots = i.Locations[locationIdx].consumeLots(i.Reservations[reservationIdx].Lots, quantity)
 
 	if i.Reservations[reservationIdx].Quantity == quantity {
 		i.Reservations[reservationIdx].Status = "fulfilled"
@@ -385,38 +469,52 @@ func (i *InventoryItem) Pick(orderID, locationID string, quantity int, createdBy
 func (i *InventoryItem) ReleaseReservation(orderID string) error {
 	for idx := range i.Reservations {
 		if i.Reservations[idx].OrderID == orderID && i.Reservations[idx].Status == "active" {
-			reservation := &i.Reservations[idx]
-			reservation.Status = "cancelled"
-
-			// Return stock to available
-			for locIdx := range i.Locations {
-				if i.Locations[locIdx].LocationID == reservation.LocationID {
-					i.Locations[locIdx].Reserved -= reservation.Quantity
-					i.Locations[locIdx].Available += reservation.Quantity
-					break
-				}
-			}
-
-			i.ReservedQuantity -= reservation.Quantity
-			i.AvailableQuantity += reservation.Quantity
-			i.UpdatedAt = time.Now()
+			i.releaseReservationAt(idx, "cancelled")
 			return nil
 		}
 	}
 	return ErrReservationNotFound
 }
 
+// releaseReservationAt returns the stock held by an active reservation to available
+func (i *InventoryItem) releaseReservationAt(idx int, status string) {
+	reservation := &i.Reservations[idx]
+	reservation.Status = status
+
+	// Return stock to available (stock from lots that expired meanwhile stays blocked)
+	blocked := 0
+	for locIdx := range i.Locations {
+		if i.Locations[locIdx].LocationID == reservation.LocationID {
+			blocked = i.Locations[locIdx].releaseLots(reservation.Lots)
+			i.Locations[locIdx].Reserved -= reservation.Quantity
+			i.Locations[locIdx].Available += reservation.Quantity - blocked
+			i.Locations[locIdx].Blocked += blocked
+			break
+		}
+	}
+
+	i.ReservedQuantity -= reservation.Quantity
+	i.AvailableQuantity += reservation.Quantity - blocked
+	i.UpdatedAt = time.Now()
+}
+
 // Adjust adjusts stock quantity (for cycle counts, corrections)
 func (i *InventoryItem) Adjust(locationID string, newQuantity int, reason, createdBy string) error {
 	for idx := range i.Locations {
 		if i.Locations[idx].LocationID == locationID {
 			oldQty := i.Locations[idx].Quantity
+			oldAvailable := i.Locations[idx].Available
 			diff := newQuantity - oldQty
 
+			// A shortage comes out of the location's lots first-expired first-out
+			if diff < 0 && i.Locations[idx].IsLotTracked() {
+				i.Locations[idx].Blocked -= i.Locations[idx].shrinkLotsFEFO(-diff)
+			}
+
 			i.Locations[idx].Quantity = newQuantity
-			i.Locations[idx].Available = newQuantity - i.Locations[idx].Reserved
+			i.Locations[idx].Available = newQuantity - i.Locations[idx].Reserved - i.Locations[idx].Blocked - i.Locations[idx].NonSellable()
 			i.TotalQuantity += diff
-			i.AvailableQuantity += diff
+			i.AvailableQuantity += i.Locations[idx].Available - oldAvailable
 
 			// Record transaction
 			i.Transactions = append(i.Transactions, InventoryTransaction{
@@ -445,6 +543,17 @@ func (i *InventoryItem) Adjust(locationID string, newQuantity int, reason, creat
 	return errors.New("location not found")
 }
 
+// hasTransaction reports whether the item recorded a transaction with the reason and reference,
+// so a task whose stock change was saved but not marked done can be completed again safely
+func (i *InventoryItem) hasTransaction(reason, referenceID string) bool {
+	for _, txn := range i.Transactions {
+		if txn.Reason == reason && txn.ReferenceID == referenceID {
+			return true
+		}
+	}
+	return false
+}
+
 // RecordCycleCount records a cycle count
 func (i *InventoryItem) RecordCycleCount() {
 	now := time.Now()
@@ -588,6 +697,12 @@ func (i *InventoryItem) Stage(reservationID, stagingLocationID, stagedBy string)
 		return ErrReservationNotFound
 	}
 
+	for idx := range i.Locations {
+		if i.Locations[idx].LocationID == reservation.LocationID && i.Locations[idx].holdsExpiredLot(reservation.Lots) {
+			return ErrLotExpired
+		}
+	}
+
 	// Check if already hard allocated
 	for _, alloc := range i.HardAllocations {
 		if alloc.ReservationID == reservationID && alloc.Status != "returned" {
@@ -676,6 +791,25 @@ func (i *InventoryItem) Ship(allocationID string) error {
 				return ErrInvalidAllocationStatus
 			}
 			allocation := &i.HardAllocations[idx]
+
+			// Find the associated reservation
+			var reservation *Reservation
+			for resIdx := range i.Reservations {
+				if i.Reservations[resIdx].ReservationID == allocation.ReservationID {
+					reservation = &i.Reservations[resIdx]
+					break
+				}
+			}
+
+			// Stock from a lot that expired since it was packed cannot leave the warehouse
+			if reservation != nil {
+				for locIdx := range i.Locations {
+					if i.Locations[locIdx].LocationID == allocation.SourceLocationID && i.Locations[locIdx].holdsExpiredLot(reservation.Lots) {
+						return ErrLotExpired
+					}
+				}
+			}
+
 			now := time.Now()
 			allocation.Status = "shipped"
 			allocation.ShippedAt = &now
@@ -689,16 +823,16 @@ func (i *InventoryItem) Ship(allocationID string) error {
 				if i.Locations[locIdx].LocationID == allocation.SourceLocationID {
 					i.Locations[locIdx].Quantity -= allocation.Quantity
 					i.Locations[locIdx].HardAllocated -= allocation.Quantity
+					if reservation != nil {
+						reservation.Lots = i.Locations[locIdx].consumeLots(reservation.Lots, allocation.Quantity)
+					}
 					break
 				}
 			}
 
 			// Mark associated reservation as fulfilled
-			for resIdx := range i.Reservations {
-				if i.Reservations[resIdx].ReservationID == allocation.ReservationID {
-					i.Reservations[resIdx].Status = "fulfilled"
-					break
-				}
+			if reservation != nil {
+				reservation.Status = "fulfilled"
 			}
 
 			i.UpdatedAt = time.Now()
@@ -749,28 +883,40 @@ func (i *InventoryItem) ReturnToShelf(allocationID, returnedBy, reason string) e
 				return errors.New("cannot return shipped inventory")
 			}
 
-			// Move quantity back from HardAllocated to Available
+			// Find the associated reservation
+			var reservation *Reservation
+			for resIdx := range i.Reservations {
+				if i.Reservations[resIdx].ReservationID == allocation.ReservationID {
+					reservation = &i.Reservations[resIdx]
+					break
+				}
+			}
+
+			// Move quantity back from HardAllocated to Available (expired lots stay blocked)
+			blocked := 0
 			for locIdx := range i.Locations {
 				if i.Locations[locIdx].LocationID == allocation.SourceLocationID {
+					if reservation != nil {
+						blocked = i.Locations[locIdx].releaseLots(reservation.Lots)
+						reservation.Lots = nil
+					}
 					i.Locations[locIdx].HardAllocated -= allocation.Quantity
-					i.Locations[locIdx].Available += allocation.Quantity
+					i.Locations[locIdx].Available += allocation.Quantity - blocked
+					i.Locations[locIdx].Blocked += blocked
 					break
 				}
 			}
 
 			// Update aggregate counters
 			i.HardAllocatedQuantity -= allocation.Quantity
-			i.AvailableQuantity += allocation.Quantity
+			i.AvailableQuantity += allocation.Quantity - blocked
 
 			// Mark allocation as returned
 			allocation.Status = "returned"
 
 			// Cancel the associated reservation
-			for resIdx := range i.Reservations {
-				if i.Reservations[resIdx].ReservationID == allocation.ReservationID {
-					i.Reservations[resIdx].Status = "cancelled"
-					break
-				}
+			if reservation != nil {
+				reservation.Status = "cancelled"
 			}
 
 			i.UpdatedAt = time.Now()
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/customs"
+)
+
+// ErrInvalidCustomsProfile is returned when a SKU's customs data is malformed
+var ErrInvalidCustomsProfile = errors.New("invalid customs profile")
+
+// defaultCustomsCurrency is used when a customs profile does not name a currency
+const defaultCustomsCurrency = "USD"
+
+// CustomsProfile is the customs data of a product, used to fill order lines and
+// customs declarations for international shipments
+type CustomsProfile struct {
+	HSCode          string    `bson:"hsCode" json:"hsCode"`
+	CountryOfOrigin string    `bson:"countryOfOrigin" json:"countryOfOrigin"`
+	Description     string    `bson:"description" json:"description"`
+	DeclaredValue   float64   `bson:"declaredValue" json:"declaredValue"` // per unit
+	Currency        string    `bson:"currency" json:"currency"`
+	UpdatedAt       time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// normalize upper-cases codes, strips HS code punctuation and checks the profile is complete
+func (p *CustomsProfile) normalize() error {
+	p.HSCode = customs.NormalizeHSCode(p.HSCode)
+	p.CountryOfOrigin = customs.NormalizeCountryCode(p.CountryOfOrigin)
+	p.Description = strings.TrimSpace(p.Description)
+	p.Currency = strings.ToUpper(strings.TrimSpace(p.Currency))
+	if p.Currency == "" {
+		p.Currency = defaultCustomsCurrency
+	}
+
+	if err := customs.ValidateHSCode(p.HSCode); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCustomsProfile, err)
+	}
+	if err := customs.ValidateCountryCode(p.CountryOfOrigin); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCustomsProfile, err)
+	}
+	if p.Description == "" {
+		return fmt.Errorf("%w: description is required", ErrInvalidCustomsProfile)
+	}
+	if p.DeclaredValue < 0 {
+		return fmt.Errorf("%w: declared value cannot be negative", ErrInvalidCustomsProfile)
+	}
+	if len(p.Currency) != 3 {
+		return fmt.Errorf("%w: currency %q must be an ISO 4217 code", ErrInvalidCustomsProfile, p.Currency)
+	}
+	return nil
+}
+
+// SetCustomsProfile replaces the item's customs data
+func (i *InventoryItem) SetCustomsProfile(profile CustomsProfile) error {
+	if err := profile.normalize(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	profile.UpdatedAt = now
+	i.Customs = &profile
+	i.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCustomsProfile(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Cotton T-Shirt", 10, 50)
+
+	err := item.SetCustomsProfile(CustomsProfile{
+		HSCode:          "6109.10.00",
+		CountryOfOrigin: "bd",
+		Description:     "  Men's cotton t-shirt ",
+		DeclaredValue:   7.5,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, item.Customs)
+	assert.Equal(t, "61091000", item.Customs.HSCode)
+	assert.Equal(t, "BD", item.Customs.CountryOfOrigin)
+	assert.Equal(t, "Men's cotton t-shirt", item.Customs.Description)
+	assert.Equal(t, "USD", item.Customs.Currency)
+	assert.False(t, item.Customs.UpdatedAt.IsZero())
+}
+
+func TestSetCustomsProfileValidation(t *testing.T) {
+	valid := CustomsProfile{HSCode: "610910", CountryOfOrigin: "BD", Description: "T-shirt", DeclaredValue: 7.5}
+
+	tests := []struct {
+		name   string
+		mutate func(p *CustomsProfile)
+	}{
+		{name: "short HS code", mutate: func(p *CustomsProfile) { p.HSCode = "6109" }},
+		{name: "bad country", mutate: func(p *CustomsProfile) { p.CountryOfOrigin = "BGD" }},
+		{name: "missing description", mutate: func(p *CustomsProfile) { p.Description = " " }},
+		{name: "negative value", mutate: func(p *CustomsProfile) { p.DeclaredValue = -1 }},
+		{name: "bad currency", mutate: func(p *CustomsProfile) { p.Currency = "DOLLARS" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := NewInventoryItem("SKU-001", "Cotton T-Shirt", 10, 50)
+			profile := valid
+			tt.mutate(&profile)
+
+			err := item.SetCustomsProfile(profile)
+			assert.ErrorIs(t, err, ErrInvalidCustomsProfile)
+			assert.Nil(t, item.Customs)
+		})
+	}
+}
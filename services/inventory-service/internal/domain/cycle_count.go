@@ -0,0 +1,410 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Cycle count errors
+var (
+	ErrCycleCountNotFound      = errors.New("cycle count task not found")
+	ErrCycleCountNotCountable  = errors.New("cycle count task is not awaiting a count")
+	ErrCycleCountNotApprovable = errors.New("cycle count task is not awaiting approval")
+	ErrCycleCountNotPostable   = errors.New("cycle count task is not approved for posting")
+	ErrCycleCountAlreadyOpen   = errors.New("an open cycle count task already exists for this location")
+	ErrSelfApproval            = errors.New("a count cannot be approved by the person who counted it")
+)
+
+// CycleCountStatus represents the lifecycle of a cycle count task
+type CycleCountStatus string
+
+const (
+	CycleCountStatusPending         CycleCountStatus = "pending"          // Waiting for the first count
+	CycleCountStatusRecount         CycleCountStatus = "recount"          // Variance over threshold, waiting for a recount
+	CycleCountStatusPendingApproval CycleCountStatus = "pending_approval" // Adjustment value over limit, waiting for a supervisor
+	CycleCountStatusApproved        CycleCountStatus = "approved"         // Ready to post
+	CycleCountStatusPosted          CycleCountStatus = "posted"           // Adjustment applied to stock and ledger
+	CycleCountStatusRejected        CycleCountStatus = "rejected"         // Supervisor rejected the count, stock unchanged
+)
+
+// IsOpen reports whether the task still needs work
+func (s CycleCountStatus) IsOpen() bool {
+	switch s {
+	case CycleCountStatusPending, CycleCountStatusRecount, CycleCountStatusPendingApproval, CycleCountStatusApproved:
+		return true
+	default:
+		return false
+	}
+}
+
+// CycleCountPolicy controls how often SKUs are counted and how variances are handled
+type CycleCountPolicy struct {
+	// Count frequency per velocity class
+	IntervalA time.Duration `json:"intervalA"`
+	IntervalB time.Duration `json:"intervalB"`
+	IntervalC time.Duration `json:"intervalC"`
+
+	// BlindCounts hides the system quantity from counters on scheduled tasks
+	BlindCounts bool `json:"blindCounts"`
+
+	// RecountVariancePercent triggers a recount when |variance| / system quantity exceeds it
+	RecountVariancePercent float64 `json:"recountVariancePercent"`
+
+	// MaxRecounts is how many recounts are requested before the last count is accepted
+	MaxRecounts int `json:"maxRecounts"`
+
+	// ApprovalValueLimit is the adjustment value above which a supervisor must approve
+	ApprovalValueLimit float64 `json:"approvalValueLimit"`
+}
+
+// DefaultCycleCountPolicy counts A items monthly, B quarterly and C yearly
+func DefaultCycleCountPolicy() CycleCountPolicy {
+	return CycleCountPolicy{
+		IntervalA:              30 * 24 * time.Hour,
+		IntervalB:              91 * 24 * time.Hour,
+		IntervalC:              365 * 24 * time.Hour,
+		BlindCounts:            true,
+		RecountVariancePercent: 5,
+		MaxRecounts:            1,
+		ApprovalValueLimit:     500,
+	}
+}
+
+// Interval returns the count frequency for a velocity class. Unclassified items are counted as C.
+func (p CycleCountPolicy) Interval(class VelocityClass) time.Duration {
+	switch class {
+	case VelocityA:
+		return p.IntervalA
+	case VelocityB:
+		return p.IntervalB
+	default:
+		return p.IntervalC
+	}
+}
+
+// DueCutoffs returns, per velocity class, the last cycle count time before which an item is due
+func (p CycleCountPolicy) DueCutoffs(now time.Time) map[VelocityClass]time.Time {
+	return map[VelocityClass]time.Time{
+		VelocityA: now.Add(-p.IntervalA),
+		VelocityB: now.Add(-p.IntervalB),
+		VelocityC: now.Add(-p.IntervalC),
+	}
+}
+
+// IsDue reports whether an item's next cycle count is due
+func (p CycleCountPolicy) IsDue(item *InventoryItem, now time.Time) bool {
+	if item.LastCycleCount == nil {
+		return true
+	}
+	return !item.LastCycleCount.Add(p.Interval(item.VelocityClass)).After(now)
+}
+
+// exceedsRecountThreshold reports whether a variance is large enough to warrant a recount
+func (p CycleCountPolicy) exceedsRecountThreshold(systemQuantity, variance int) bool {
+	if variance == 0 {
+		return false
+	}
+	if systemQuantity == 0 {
+		return true
+	}
+	percent := math.Abs(float64(variance)) / float64(systemQuantity) * 100
+	return percent > p.RecountVariancePercent
+}
+
+// requiresApproval reports whether an adjustment needs a supervisor. Without a known unit
+// cost the value cannot be shown to be under the limit, so any variance needs approval.
+func (p CycleCountPolicy) requiresApproval(variance int, unitCost float64) bool {
+	if variance == 0 {
+		return false
+	}
+	if unitCost <= 0 {
+		return true
+	}
+	return math.Abs(float64(variance))*unitCost > p.ApprovalValueLimit
+}
+
+// CountAttempt is one physical count of a location
+type CountAttempt struct {
+	CountedQuantity int       `bson:"countedQuantity" json:"countedQuantity"`
+	SystemQuantity  int       `bson:"systemQuantity" json:"systemQuantity"` // Snapshot when the count was submitted
+	Variance        int       `bson:"variance" json:"variance"`
+	CountedBy       string    `bson:"countedBy" json:"countedBy"`
+	CountedAt       time.Time `bson:"countedAt" json:"countedAt"`
+}
+
+// CycleCountTask is the aggregate for counting one SKU at one location
+type CycleCountTask struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	TaskID string             `bson:"taskId"`
+
+	// Multi-tenant fields
+	TenantID    string `bson:"tenantId"`
+	FacilityID  string `bson:"facilityId"`
+	WarehouseID string `bson:"warehouseId"`
+	SellerID    string `bson:"sellerId,omitempty"`
+
+	SKU           string           `bson:"sku"`
+	LocationID    string           `bson:"locationId"`
+	VelocityClass VelocityClass    `bson:"velocityClass"`
+	Blind         bool             `bson:"blind"` // Counter does not see the system quantity
+	Status        CycleCountStatus `bson:"status"`
+	Attempts      []CountAttempt   `bson:"attempts"`
+
+	// Set once the final count is accepted
+	Variance      int     `bson:"variance"`
+	UnitCost      float64 `bson:"unitCost"`
+	VarianceValue float64 `bson:"varianceValue"`
+
+	ApprovedBy          string `bson:"approvedBy,omitempty"`
+	RejectedBy          string `bson:"rejectedBy,omitempty"`
+	RejectionReason     string `bson:"rejectionReason,omitempty"`
+	LedgerTransactionID string `bson:"ledgerTransactionId,omitempty"`
+
+	DueAt       time.Time  `bson:"dueAt"`
+	CreatedAt   time.Time  `bson:"createdAt"`
+	UpdatedAt   time.Time  `bson:"updatedAt"`
+	CompletedAt *time.Time `bson:"completedAt,omitempty"`
+	Version     int        `bson:"version"` // Incremented on every save for optimistic concurrency
+
+	DomainEvents []DomainEvent `bson:"-"`
+}
+
+// NewCycleCountTask creates a count task for a location of an item
+func NewCycleCountTask(item *InventoryItem, locationID string, blind bool, dueAt time.Time) (*CycleCountTask, error) {
+	if item.GetLocationStock(locationID) == nil {
+		return nil, ErrLocationNotFound
+	}
+
+	now := time.Now()
+	class := item.VelocityClass
+	if !class.IsValid() {
+		class = VelocityC
+	}
+	task := &CycleCountTask{
+		TaskID:        "CC-" + uuid.New().String()[:8],
+		TenantID:      item.TenantID,
+		FacilityID:    item.FacilityID,
+		WarehouseID:   item.WarehouseID,
+		SellerID:      item.SellerID,
+		SKU:           item.SKU,
+		LocationID:    locationID,
+		VelocityClass: class,
+		Blind:         blind,
+		Status:        CycleCountStatusPending,
+		Attempts:      make([]CountAttempt, 0),
+		DueAt:         dueAt,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		DomainEvents:  make([]DomainEvent, 0),
+	}
+
+	task.addDomainEvent(&CycleCountScheduledEvent{
+		TaskID:        task.TaskID,
+		SKU:           task.SKU,
+		LocationID:    task.LocationID,
+		VelocityClass: string(task.VelocityClass),
+		Blind:         task.Blind,
+		DueAt:         task.DueAt,
+		ScheduledAt:   now,
+	})
+	return task, nil
+}
+
+// SubmitCount records a physical count against the system quantity at the time of counting.
+// A variance over the recount threshold asks for a recount until MaxRecounts is reached;
+// the accepted count is then approved, or held for a supervisor when its value exceeds the limit.
+func (t *CycleCountTask) SubmitCount(countedQuantity, systemQuantity int, countedBy string, unitCost float64, policy CycleCountPolicy) error {
+	if t.Status != CycleCountStatusPending && t.Status != CycleCountStatusRecount {
+		return ErrCycleCountNotCountable
+	}
+	if countedQuantity < 0 {
+		return ErrInvalidQuantity
+	}
+
+	now := time.Now()
+	attempt := CountAttempt{
+		CountedQuantity: countedQuantity,
+		SystemQuantity:  systemQuantity,
+		Variance:        countedQuantity - systemQuantity,
+		CountedBy:       countedBy,
+		CountedAt:       now,
+	}
+	t.Attempts = append(t.Attempts, attempt)
+	t.UpdatedAt = now
+
+	recounts := len(t.Attempts) - 1
+	if recounts < policy.MaxRecounts && policy.exceedsRecountThreshold(systemQuantity, attempt.Variance) {
+		// A recount that agrees with the previous count confirms the variance
+		if recounts == 0 || t.Attempts[recounts-1].CountedQuantity != countedQuantity {
+			t.Status = CycleCountStatusRecount
+			t.addDomainEvent(&CycleCountRecountRequestedEvent{
+				TaskID:      t.TaskID,
+				SKU:         t.SKU,
+				LocationID:  t.LocationID,
+				Attempt:     len(t.Attempts),
+				RequestedAt: now,
+			})
+			return nil
+		}
+	}
+
+	t.Variance = attempt.Variance
+	t.UnitCost = unitCost
+	t.VarianceValue = float64(attempt.Variance) * unitCost
+
+	if policy.requiresApproval(attempt.Variance, unitCost) {
+		t.Status = CycleCountStatusPendingApproval
+		t.addDomainEvent(&CycleCountApprovalRequiredEvent{
+			TaskID:        t.TaskID,
+			SKU:           t.SKU,
+			LocationID:    t.LocationID,
+			Variance:      t.Variance,
+			VarianceValue: t.VarianceValue,
+			RequestedAt:   now,
+		})
+		return nil
+	}
+
+	t.Status = CycleCountStatusApproved
+	return nil
+}
+
+// Approve accepts a count held for supervisor approval
+func (t *CycleCountTask) Approve(approvedBy string) error {
+	if t.Status != CycleCountStatusPendingApproval {
+		return ErrCycleCountNotApprovable
+	}
+	if approvedBy == t.LastAttempt().CountedBy {
+		return ErrSelfApproval
+	}
+
+	t.Status = CycleCountStatusApproved
+	t.ApprovedBy = approvedBy
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Reject discards a count held for supervisor approval without changing stock
+func (t *CycleCountTask) Reject(rejectedBy, reason string) error {
+	if t.Status != CycleCountStatusPendingApproval {
+		return ErrCycleCountNotApprovable
+	}
+
+	now := time.Now()
+	t.Status = CycleCountStatusRejected
+	t.RejectedBy = rejectedBy
+	t.RejectionReason = reason
+	t.UpdatedAt = now
+	t.CompletedAt = &now
+	return nil
+}
+
+// MarkPosted records that the variance was applied to stock and the ledger
+func (t *CycleCountTask) MarkPosted(ledgerTransactionID string) error {
+	if t.Status != CycleCountStatusApproved {
+		return ErrCycleCountNotPostable
+	}
+
+	now := time.Now()
+	t.Status = CycleCountStatusPosted
+	t.LedgerTransactionID = ledgerTransactionID
+	t.UpdatedAt = now
+	t.CompletedAt = &now
+
+	t.addDomainEvent(&CycleCountPostedEvent{
+		TaskID:              t.TaskID,
+		SKU:                 t.SKU,
+		LocationID:          t.LocationID,
+		CountedQuantity:     t.LastAttempt().CountedQuantity,
+		Variance:            t.Variance,
+		VarianceValue:       t.VarianceValue,
+		ApprovedBy:          t.ApprovedBy,
+		LedgerTransactionID: ledgerTransactionID,
+		PostedAt:            now,
+	})
+	return nil
+}
+
+// LastAttempt returns the most recent count, or an empty attempt before the first count
+func (t *CycleCountTask) LastAttempt() CountAttempt {
+	if len(t.Attempts) == 0 {
+		return CountAttempt{}
+	}
+	return t.Attempts[len(t.Attempts)-1]
+}
+
+// CycleCountReason is the reason recorded on the stock adjustment and ledger entries of a posted count
+func CycleCountReason(taskID string) string {
+	return "cycle count " + taskID
+}
+
+func (t *CycleCountTask) addDomainEvent(event DomainEvent) {
+	t.DomainEvents = append(t.DomainEvents, event)
+}
+
+// PullEvents returns and clears the task's domain events
+func (t *CycleCountTask) PullEvents() []DomainEvent {
+	events := t.DomainEvents
+	t.DomainEvents = make([]DomainEvent, 0)
+	return events
+}
+
+// ShelfQuantity is the stock a counter should find at the location: staged (hard allocated)
+// units have already been moved to a staging location
+func (l StockLocation) ShelfQuantity() int {
+	return l.Quantity - l.HardAllocated
+}
+
+// CycleCountPosted reports whether the variance of a cycle count task was already applied
+func (i *InventoryItem) CycleCountPosted(taskID string) bool {
+	return i.hasTransaction(CycleCountReason(taskID), "")
+}
+
+// PostCycleCount applies a counted variance to a location as a cycle count adjustment.
+// The variance is applied to the current quantity so picks and receipts since the count are kept.
+// Posting a task whose variance was already applied changes nothing.
+func (i *InventoryItem) PostCycleCount(locationID string, variance int, taskID, countedBy string) error {
+	if i.CycleCountPosted(taskID) {
+		return nil
+	}
+
+	loc := i.GetLocationStock(locationID)
+	if loc == nil {
+		return ErrLocationNotFound
+	}
+
+	systemQuantity := loc.Quantity
+	newQuantity := systemQuantity + variance
+	if newQuantity < loc.Reserved+loc.HardAllocated {
+		return fmt.Errorf("%w: count would leave less stock than is reserved at %s", ErrInsufficientStock, locationID)
+	}
+
+	if variance != 0 {
+		if err := i.Adjust(locationID, newQuantity, CycleCountReason(taskID), countedBy); err != nil {
+			return err
+		}
+
+		discrepancyType := "overage"
+		if variance < 0 {
+			discrepancyType = "shortage"
+		}
+		i.AddDomainEvent(&InventoryDiscrepancyEvent{
+			SKU:             i.SKU,
+			LocationID:      locationID,
+			SystemQuantity:  systemQuantity,
+			ActualQuantity:  newQuantity,
+			DiscrepancyType: discrepancyType,
+			Source:          "cycle_count",
+			ReferenceID:     taskID,
+			DetectedAt:      time.Now(),
+		})
+	}
+
+	i.RecordCycleCount()
+	return nil
+}
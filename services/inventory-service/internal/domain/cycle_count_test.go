@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCycleCountItem(t *testing.T, quantity int) *InventoryItem {
+	item := NewInventoryItem("SKU-001", "Test Product", 10, 50)
+	require.NoError(t, item.ReceiveStock("LOC-A1", "ZONE-A", quantity, "PO-001", "user1"))
+	item.ClearDomainEvents()
+	return item
+}
+
+// TestCycleCountPolicy_IsDue tests count frequency by velocity class
+func TestCycleCountPolicy_IsDue(t *testing.T) {
+	policy := DefaultCycleCountPolicy()
+	now := time.Now()
+	lastCount := now.Add(-60 * 24 * time.Hour)
+
+	tests := []struct {
+		class     VelocityClass
+		lastCount *time.Time
+		due       bool
+	}{
+		{VelocityA, nil, true},
+		{VelocityA, &lastCount, true},
+		{VelocityB, &lastCount, false},
+		{VelocityC, &lastCount, false},
+		{"", &lastCount, false},
+	}
+	for _, tt := range tests {
+		item := newCycleCountItem(t, 10)
+		item.VelocityClass = tt.class
+		item.LastCycleCount = tt.lastCount
+		assert.Equal(t, tt.due, policy.IsDue(item, now), "class %q", tt.class)
+	}
+
+	cutoffs := policy.DueCutoffs(now)
+	assert.Equal(t, now.Add(-30*24*time.Hour), cutoffs[VelocityA])
+	assert.Equal(t, now.Add(-365*24*time.Hour), cutoffs[VelocityC])
+}
+
+// TestCycleCountTask_Recount tests that a large variance asks for a recount before it is accepted
+func TestCycleCountTask_Recount(t *testing.T) {
+	item := newCycleCountItem(t, 100)
+	task, err := NewCycleCountTask(item, "LOC-A1", true, time.Now())
+	require.NoError(t, err)
+	require.Len(t, task.PullEvents(), 1)
+
+	policy := DefaultCycleCountPolicy()
+
+	// 10% short is over the 5% threshold
+	require.NoError(t, task.SubmitCount(90, 100, "counter1", 1, policy))
+	assert.Equal(t, CycleCountStatusRecount, task.Status)
+	events := task.PullEvents()
+	require.Len(t, events, 1)
+	assert.IsType(t, &CycleCountRecountRequestedEvent{}, events[0])
+
+	// The recount is accepted even if it disagrees: MaxRecounts is 1
+	require.NoError(t, task.SubmitCount(92, 100, "counter2", 1, policy))
+	assert.Equal(t, CycleCountStatusApproved, task.Status)
+	assert.Equal(t, -8, task.Variance)
+	assert.Equal(t, -8.0, task.VarianceValue)
+	assert.Len(t, task.Attempts, 2)
+
+	assert.ErrorIs(t, task.SubmitCount(92, 100, "counter2", 1, policy), ErrCycleCountNotCountable)
+}
+
+// TestCycleCountTask_WithinThreshold tests that small variances are accepted on the first count
+func TestCycleCountTask_WithinThreshold(t *testing.T) {
+	item := newCycleCountItem(t, 100)
+	task, err := NewCycleCountTask(item, "LOC-A1", false, time.Now())
+	require.NoError(t, err)
+
+	require.NoError(t, task.SubmitCount(98, 100, "counter1", 1, DefaultCycleCountPolicy()))
+	assert.Equal(t, CycleCountStatusApproved, task.Status)
+	assert.Equal(t, -2, task.Variance)
+}
+
+// TestCycleCountTask_Approval tests supervisor approval of high value adjustments
+func TestCycleCountTask_Approval(t *testing.T) {
+	item := newCycleCountItem(t, 100)
+	policy := DefaultCycleCountPolicy()
+	policy.MaxRecounts = 0
+
+	task, err := NewCycleCountTask(item, "LOC-A1", true, time.Now())
+	require.NoError(t, err)
+	task.PullEvents()
+
+	// 20 units at 50.00 is over the 500 limit
+	require.NoError(t, task.SubmitCount(80, 100, "counter1", 50, policy))
+	assert.Equal(t, CycleCountStatusPendingApproval, task.Status)
+	assert.Equal(t, -1000.0, task.VarianceValue)
+	events := task.PullEvents()
+	require.Len(t, events, 1)
+	assert.IsType(t, &CycleCountApprovalRequiredEvent{}, events[0])
+
+	assert.ErrorIs(t, task.MarkPosted("TX-1"), ErrCycleCountNotPostable)
+	assert.ErrorIs(t, task.Approve("counter1"), ErrSelfApproval)
+	require.NoError(t, task.Approve("supervisor1"))
+	assert.Equal(t, CycleCountStatusApproved, task.Status)
+
+	require.NoError(t, task.MarkPosted("TX-1"))
+	assert.Equal(t, CycleCountStatusPosted, task.Status)
+	assert.False(t, task.Status.IsOpen())
+	require.NotNil(t, task.CompletedAt)
+	events = task.PullEvents()
+	require.Len(t, events, 1)
+	posted, ok := events[0].(*CycleCountPostedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "supervisor1", posted.ApprovedBy)
+	assert.Equal(t, "TX-1", posted.LedgerTransactionID)
+}
+
+// TestCycleCountTask_UnknownCostNeedsApproval tests that variances without a unit cost need approval
+func TestCycleCountTask_UnknownCostNeedsApproval(t *testing.T) {
+	item := newCycleCountItem(t, 100)
+	task, err := NewCycleCountTask(item, "LOC-A1", true, time.Now())
+	require.NoError(t, err)
+
+	require.NoError(t, task.SubmitCount(99, 100, "counter1", 0, DefaultCycleCountPolicy()))
+	assert.Equal(t, CycleCountStatusPendingApproval, task.Status)
+
+	require.NoError(t, task.Reject("supervisor1", "recount next cycle"))
+	assert.Equal(t, CycleCountStatusRejected, task.Status)
+	assert.ErrorIs(t, task.Approve("supervisor1"), ErrCycleCountNotApprovable)
+}
+
+// TestNewCycleCountTask_UnknownLocation tests that tasks are only created for stocked locations
+func TestNewCycleCountTask_UnknownLocation(t *testing.T) {
+	item := newCycleCountItem(t, 10)
+	_, err := NewCycleCountTask(item, "LOC-Z9", true, time.Now())
+	assert.ErrorIs(t, err, ErrLocationNotFound)
+}
+
+// TestPostCycleCount tests applying a counted variance to stock
+func TestPostCycleCount(t *testing.T) {
+	item := newCycleCountItem(t, 100)
+	require.NoError(t, item.Reserve("ORD-001", "LOC-A1", 10))
+	item.ClearDomainEvents()
+
+	require.NoError(t, item.PostCycleCount("LOC-A1", -5, "CC-1", "supervisor1"))
+	assert.Equal(t, 95, item.TotalQuantity)
+	assert.Equal(t, 95, item.GetLocationStock("LOC-A1").Quantity)
+	require.NotNil(t, item.LastCycleCount)
+
+	var discrepancy *InventoryDiscrepancyEvent
+	for _, event := range item.GetDomainEvents() {
+		if e, ok := event.(*InventoryDiscrepancyEvent); ok {
+			discrepancy = e
+		}
+	}
+	require.NotNil(t, discrepancy)
+	assert.Equal(t, "shortage", discrepancy.DiscrepancyType)
+	assert.Equal(t, "cycle_count", discrepancy.Source)
+	assert.Equal(t, "CC-1", discrepancy.ReferenceID)
+
+	// Posting the same task again, e.g. after its save failed, changes nothing
+	assert.True(t, item.CycleCountPosted("CC-1"))
+	item.ClearDomainEvents()
+	require.NoError(t, item.PostCycleCount("LOC-A1", -5, "CC-1", "supervisor1"))
+	assert.Equal(t, 95, item.TotalQuantity)
+	assert.Empty(t, item.GetDomainEvents())
+
+	// A count cannot remove reserved stock
+	err := item.PostCycleCount("LOC-A1", -90, "CC-2", "supervisor1")
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+	assert.Equal(t, 95, item.TotalQuantity)
+}
@@ -10,10 +10,12 @@ type DomainEvent interface {
 
 // InventoryReceivedEvent is published when inventory is received
 type InventoryReceivedEvent struct {
-	SKU        string    `json:"sku"`
-	Quantity   int       `json:"quantity"`
-	LocationID string    `json:"locationId"`
-	ReceivedAt time.Time `json:"receivedAt"`
+	SKU        string     `json:"sku"`
+	Quantity   int        `json:"quantity"`
+	LocationID string     `json:"locationId"`
+	LotNumber  string     `json:"lotNumber,omitempty"`
+	ExpiryDate *time.Time `json:"expiryDate,omitempty"`
+	ReceivedAt time.Time  `json:"receivedAt"`
 }
 
 func (e *InventoryReceivedEvent) EventType() string    { return "wms.inventory.received" }
@@ -164,3 +166,204 @@ type VelocityClassChangedEvent struct {
 
 func (e *VelocityClassChangedEvent) EventType() string     { return "wms.inventory.velocity-class-changed" }
 func (e *VelocityClassChangedEvent) OccurredAt() time.Time { return e.ChangedAt }
+
+// LotExpiringEvent is published when a lot is approaching its expiry date
+type LotExpiringEvent struct {
+	SKU           string    `json:"sku"`
+	SellerID      string    `json:"sellerId,omitempty"`
+	LocationID    string    `json:"locationId"`
+	LotNumber     string    `json:"lotNumber"`
+	ExpiryDate    time.Time `json:"expiryDate"`
+	Quantity      int       `json:"quantity"`
+	DaysRemaining int       `json:"daysRemaining"`
+	DetectedAt    time.Time `json:"detectedAt"`
+}
+
+func (e *LotExpiringEvent) EventType() string     { return "wms.inventory.lot-expiring" }
+func (e *LotExpiringEvent) OccurredAt() time.Time { return e.DetectedAt }
+
+// LotExpiredEvent is published when an expired lot is blocked from allocation
+type LotExpiredEvent struct {
+	SKU             string    `json:"sku"`
+	LocationID      string    `json:"locationId"`
+	LotNumber       string    `json:"lotNumber"`
+	ExpiryDate      time.Time `json:"expiryDate"`
+	BlockedQuantity int       `json:"blockedQuantity"`
+	// ReservedQuantity is stock of the lot still held by reservations; it cannot be picked
+	// and the orders in AffectedOrderIDs need to be re-allocated
+	ReservedQuantity int       `json:"reservedQuantity,omitempty"`
+	AffectedOrderIDs []string  `json:"affectedOrderIds,omitempty"`
+	BlockedAt        time.Time `json:"blockedAt"`
+}
+
+func (e *LotExpiredEvent) EventType() string     { return "wms.inventory.lot-expired" }
+func (e *LotExpiredEvent) OccurredAt() time.Time { return e.BlockedAt }
+
+// ReservationExpiredEvent is published when a stale reservation is released by the expiry sweeper
+type ReservationExpiredEvent struct {
+	SKU           string    `json:"sku"`
+	TenantID      string    `json:"tenantId,omitempty"`
+	ReservationID string    `json:"reservationId"`
+	OrderID       string    `json:"orderId"`
+	LocationID    string    `json:"locationId"`
+	Quantity      int       `json:"quantity"`
+	UnitIDs       []string  `json:"unitIds,omitempty"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	ReleasedAt    time.Time `json:"releasedAt"`
+}
+
+func (e *ReservationExpiredEvent) EventType() string     { return "wms.inventory.reservation-expired" }
+func (e *ReservationExpiredEvent) OccurredAt() time.Time { return e.ReleasedAt }
+
+// CycleCountScheduledEvent is published when a cycle count task is created for a location
+type CycleCountScheduledEvent struct {
+	TaskID        string    `json:"taskId"`
+	SKU           string    `json:"sku"`
+	LocationID    string    `json:"locationId"`
+	VelocityClass string    `json:"velocityClass"`
+	Blind         bool      `json:"blind"`
+	DueAt         time.Time `json:"dueAt"`
+	ScheduledAt   time.Time `json:"scheduledAt"`
+}
+
+func (e *CycleCountScheduledEvent) EventType() string     { return "wms.inventory.cycle-count-scheduled" }
+func (e *CycleCountScheduledEvent) OccurredAt() time.Time { return e.ScheduledAt }
+
+// CycleCountRecountRequestedEvent is published when a count variance exceeds the recount threshold
+type CycleCountRecountRequestedEvent struct {
+	TaskID      string    `json:"taskId"`
+	SKU         string    `json:"sku"`
+	LocationID  string    `json:"locationId"`
+	Attempt     int       `json:"attempt"` // Number of counts so far
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+func (e *CycleCountRecountRequestedEvent) EventType() string {
+	return "wms.inventory.cycle-count-recount-requested"
+}
+func (e *CycleCountRecountRequestedEvent) OccurredAt() time.Time { return e.RequestedAt }
+
+// CycleCountApprovalRequiredEvent is published when a count adjustment exceeds the approval value limit
+type CycleCountApprovalRequiredEvent struct {
+	TaskID        string    `json:"taskId"`
+	SKU           string    `json:"sku"`
+	LocationID    string    `json:"locationId"`
+	Variance      int       `json:"variance"`
+	VarianceValue float64   `json:"varianceValue"`
+	RequestedAt   time.Time `json:"requestedAt"`
+}
+
+func (e *CycleCountApprovalRequiredEvent) EventType() string {
+	return "wms.inventory.cycle-count-approval-required"
+}
+func (e *CycleCountApprovalRequiredEvent) OccurredAt() time.Time { return e.RequestedAt }
+
+// CycleCountPostedEvent is published when a count variance is applied to stock and the ledger
+type CycleCountPostedEvent struct {
+	TaskID              string    `json:"taskId"`
+	SKU                 string    `json:"sku"`
+	LocationID          string    `json:"locationId"`
+	CountedQuantity     int       `json:"countedQuantity"`
+	Variance            int       `json:"variance"`
+	VarianceValue       float64   `json:"varianceValue"`
+	ApprovedBy          string    `json:"approvedBy,omitempty"`
+	LedgerTransactionID string    `json:"ledgerTransactionId,omitempty"`
+	PostedAt            time.Time `json:"postedAt"`
+}
+
+func (e *CycleCountPostedEvent) EventType() string     { return "wms.inventory.cycle-count-posted" }
+func (e *CycleCountPostedEvent) OccurredAt() time.Time { return e.PostedAt }
+
+// StockMovedEvent is published when stock is moved between two locations of a SKU
+type StockMovedEvent struct {
+	SKU              string    `json:"sku"`
+	FromLocationID   string    `json:"fromLocationId"`
+	ToLocationID     string    `json:"toLocationId"`
+	Quantity         int       `json:"quantity"`
+	ReservedQuantity int       `json:"reservedQuantity,omitempty"` // Part of the quantity moved with its reservations
+	LotNumber        string    `json:"lotNumber,omitempty"`        // Set when a single lot was moved
+	Status           string    `json:"status,omitempty"`           // Set when non-sellable stock was moved
+	MoveID           string    `json:"moveId,omitempty"`           // Two-step moves: the move dropped at the destination
+	ContainerID      string    `json:"containerId,omitempty"`      // Two-step moves: the cart or tote that carried the stock
+	UnitWeight       float64   `json:"unitWeight,omitempty"`       // For location capacity, if the SKU's weight is known
+	Reason           string    `json:"reason"`
+	ReferenceID      string    `json:"referenceId,omitempty"`
+	MovedBy          string    `json:"movedBy"`
+	MovedAt          time.Time `json:"movedAt"`
+}
+
+func (e *StockMovedEvent) EventType() string     { return "wms.inventory.stock-moved" }
+func (e *StockMovedEvent) OccurredAt() time.Time { return e.MovedAt }
+
+// StockMoveStartedEvent is published when stock is picked onto a cart or tote for a two-step
+// move. The stock is in transit until a StockMovedEvent with the same move ID.
+type StockMoveStartedEvent struct {
+	SKU            string    `json:"sku"`
+	MoveID         string    `json:"moveId"`
+	ContainerID    string    `json:"containerId"`
+	FromLocationID string    `json:"fromLocationId"`
+	ToLocationID   string    `json:"toLocationId,omitempty"` // Planned destination, if known
+	Quantity       int       `json:"quantity"`
+	LotNumber      string    `json:"lotNumber,omitempty"`
+	Status         string    `json:"status,omitempty"`
+	UnitWeight     float64   `json:"unitWeight,omitempty"`
+	Reason         string    `json:"reason"`
+	ReferenceID    string    `json:"referenceId,omitempty"`
+	MovedBy        string    `json:"movedBy"`
+	StartedAt      time.Time `json:"startedAt"`
+}
+
+func (e *StockMoveStartedEvent) EventType() string     { return "wms.inventory.move-started" }
+func (e *StockMoveStartedEvent) OccurredAt() time.Time { return e.StartedAt }
+
+// InventoryStatusChangedEvent is published when stock moves between inventory statuses
+type InventoryStatusChangedEvent struct {
+	SKU         string    `json:"sku"`
+	LocationID  string    `json:"locationId"`
+	LotNumber   string    `json:"lotNumber,omitempty"`
+	Quantity    int       `json:"quantity"`
+	FromStatus  string    `json:"fromStatus"`
+	ToStatus    string    `json:"toStatus"`
+	ReasonCode  string    `json:"reasonCode"`
+	HoldID      string    `json:"holdId,omitempty"`
+	ReferenceID string    `json:"referenceId,omitempty"`
+	ChangedBy   string    `json:"changedBy"`
+	ChangedAt   time.Time `json:"changedAt"`
+}
+
+func (e *InventoryStatusChangedEvent) EventType() string     { return "wms.inventory.status-changed" }
+func (e *InventoryStatusChangedEvent) OccurredAt() time.Time { return e.ChangedAt }
+
+// ReplenishmentTaskCreatedEvent is published when a pick face replenishment task is created
+type ReplenishmentTaskCreatedEvent struct {
+	TaskID         string    `json:"taskId"`
+	SKU            string    `json:"sku"`
+	FromLocationID string    `json:"fromLocationId"`
+	ToLocationID   string    `json:"toLocationId"`
+	Quantity       int       `json:"quantity"`
+	Trigger        string    `json:"trigger"` // min_max, wave_demand
+	WaveID         string    `json:"waveId,omitempty"`
+	Priority       int       `json:"priority"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func (e *ReplenishmentTaskCreatedEvent) EventType() string {
+	return "wms.inventory.replenishment-task-created"
+}
+func (e *ReplenishmentTaskCreatedEvent) OccurredAt() time.Time { return e.CreatedAt }
+
+// ReplenishmentTaskCompletedEvent is published when stock has been moved to the pick face
+type ReplenishmentTaskCompletedEvent struct {
+	TaskID        string    `json:"taskId"`
+	SKU           string    `json:"sku"`
+	ToLocationID  string    `json:"toLocationId"`
+	MovedQuantity int       `json:"movedQuantity"`
+	WaveID        string    `json:"waveId,omitempty"`
+	CompletedBy   string    `json:"completedBy"`
+	CompletedAt   time.Time `json:"completedAt"`
+}
+
+func (e *ReplenishmentTaskCompletedEvent) EventType() string {
+	return "wms.inventory.replenishment-task-completed"
+}
+func (e *ReplenishmentTaskCompletedEvent) OccurredAt() time.Time { return e.CompletedAt }
@@ -51,6 +51,7 @@ type InventoryAllocationAggregate struct {
 	PackedAt  *time.Time `bson:"packedAt,omitempty"`
 	ShippedAt *time.Time `bson:"shippedAt,omitempty"`
 	UpdatedAt time.Time  `bson:"updatedAt"`
+	Version   int        `bson:"version"` // Incremented on every save for optimistic concurrency
 
 	DomainEvents []DomainEvent `bson:"-"`
 }
@@ -0,0 +1,254 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Inventory hold errors
+var (
+	ErrInvalidHoldScope = errors.New("a SKU hold needs a SKU, a lot hold a SKU and lot number, a location hold a location")
+	ErrHoldNotActive    = errors.New("inventory hold is already released")
+)
+
+// HoldScope is what an inventory hold applies to
+type HoldScope string
+
+const (
+	HoldScopeSKU      HoldScope = "sku"      // All stock of a SKU
+	HoldScopeLot      HoldScope = "lot"      // One lot of a SKU, wherever it is stored
+	HoldScopeLocation HoldScope = "location" // All SKUs stored at a location
+)
+
+// IsValid checks if the hold scope is valid
+func (s HoldScope) IsValid() bool {
+	switch s {
+	case HoldScopeSKU, HoldScopeLot, HoldScopeLocation:
+		return true
+	default:
+		return false
+	}
+}
+
+// HoldStatus represents the lifecycle of an inventory hold
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "active"
+	HoldStatusReleased HoldStatus = "released"
+)
+
+// HoldPlacement is stock a hold moved into on_hold at one location
+type HoldPlacement struct {
+	SKU        string `bson:"sku"`
+	LocationID string `bson:"locationId"`
+	LotNumber  string `bson:"lotNumber,omitempty"`
+	Quantity   int    `bson:"quantity"`
+}
+
+// InventoryHold sets stock aside by SKU, lot or location, e.g. for a recall or an
+// investigation. The hold takes the available stock in its scope when placed; stock already
+// reserved for orders stays with them.
+type InventoryHold struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	HoldID string             `bson:"holdId"`
+
+	TenantID    string `bson:"tenantId"`
+	FacilityID  string `bson:"facilityId"`
+	WarehouseID string `bson:"warehouseId"`
+
+	Scope      HoldScope        `bson:"scope"`
+	SKU        string           `bson:"sku,omitempty"`
+	LotNumber  string           `bson:"lotNumber,omitempty"`
+	LocationID string           `bson:"locationId,omitempty"`
+	ReasonCode StatusReasonCode `bson:"reasonCode"`
+	Notes      string           `bson:"notes,omitempty"`
+	Status     HoldStatus       `bson:"status"`
+	Placements []HoldPlacement  `bson:"placements"`
+
+	PlacedBy   string     `bson:"placedBy"`
+	ReleasedBy string     `bson:"releasedBy,omitempty"`
+	CreatedAt  time.Time  `bson:"createdAt"`
+	UpdatedAt  time.Time  `bson:"updatedAt"`
+	ReleasedAt *time.Time `bson:"releasedAt,omitempty"`
+	Version    int        `bson:"version"` // Incremented on every save for optimistic concurrency
+}
+
+// NewInventoryHold creates an active hold over a SKU, a lot of a SKU or a location
+func NewInventoryHold(scope HoldScope, sku, lotNumber, locationID string, reasonCode StatusReasonCode, notes, placedBy string) (*InventoryHold, error) {
+	switch {
+	case !scope.IsValid():
+		return nil, ErrInvalidHoldScope
+	case scope == HoldScopeSKU && sku == "":
+		return nil, ErrInvalidHoldScope
+	case scope == HoldScopeLot && (sku == "" || lotNumber == ""):
+		return nil, ErrInvalidHoldScope
+	case scope == HoldScopeLocation && locationID == "":
+		return nil, ErrInvalidHoldScope
+	}
+	if !reasonCode.IsValid() {
+		return nil, ErrInvalidStatusReason
+	}
+
+	now := time.Now()
+	return &InventoryHold{
+		HoldID:     "HLD-" + uuid.New().String()[:8],
+		Scope:      scope,
+		SKU:        sku,
+		LotNumber:  lotNumber,
+		LocationID: locationID,
+		ReasonCode: reasonCode,
+		Notes:      notes,
+		Status:     HoldStatusActive,
+		Placements: make([]HoldPlacement, 0),
+		PlacedBy:   placedBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// IsActive returns true while the hold keeps its stock aside
+func (h *InventoryHold) IsActive() bool {
+	return h.Status == HoldStatusActive
+}
+
+// Covers reports whether stock of a lot at a location falls in the hold's scope
+func (h *InventoryHold) Covers(locationID, lotNumber string) bool {
+	switch h.Scope {
+	case HoldScopeLot:
+		return lotNumber == h.LotNumber
+	case HoldScopeLocation:
+		return locationID == h.LocationID
+	default:
+		return true
+	}
+}
+
+// SKUs returns the distinct SKUs the hold placed stock of
+func (h *InventoryHold) SKUs() []string {
+	seen := make(map[string]bool)
+	skus := make([]string, 0)
+	for _, placement := range h.Placements {
+		if !seen[placement.SKU] {
+			seen[placement.SKU] = true
+			skus = append(skus, placement.SKU)
+		}
+	}
+	return skus
+}
+
+// HeldQuantity returns the total quantity the hold placed on hold
+func (h *InventoryHold) HeldQuantity() int {
+	total := 0
+	for _, placement := range h.Placements {
+		total += placement.Quantity
+	}
+	return total
+}
+
+// RecordPlacements adds stock the hold moved into on_hold
+func (h *InventoryHold) RecordPlacements(placements []HoldPlacement) {
+	h.Placements = append(h.Placements, placements...)
+	h.UpdatedAt = time.Now()
+}
+
+// Release ends the hold
+func (h *InventoryHold) Release(releasedBy string) error {
+	if !h.IsActive() {
+		return ErrHoldNotActive
+	}
+
+	now := time.Now()
+	h.Status = HoldStatusReleased
+	h.ReleasedBy = releasedBy
+	h.ReleasedAt = &now
+	h.UpdatedAt = now
+	return nil
+}
+
+// PlaceHold moves the item's available stock in the hold's scope into on_hold, per lot at
+// lot-tracked locations, and returns what it set aside
+func (i *InventoryItem) PlaceHold(hold *InventoryHold) ([]HoldPlacement, error) {
+	if !hold.IsActive() {
+		return nil, ErrHoldNotActive
+	}
+
+	placements := make([]HoldPlacement, 0)
+	place := func(locationID, lotNumber string, quantity int) error {
+		if quantity <= 0 || !hold.Covers(locationID, lotNumber) {
+			return nil
+		}
+		if err := i.ChangeStatus(StatusChange{
+			LocationID:  locationID,
+			LotNumber:   lotNumber,
+			Quantity:    quantity,
+			From:        StatusAvailable,
+			To:          StatusOnHold,
+			ReasonCode:  hold.ReasonCode,
+			HoldID:      hold.HoldID,
+			ReferenceID: hold.HoldID,
+			ChangedBy:   hold.PlacedBy,
+		}); err != nil {
+			return err
+		}
+		placements = append(placements, HoldPlacement{
+			SKU:        i.SKU,
+			LocationID: locationID,
+			LotNumber:  lotNumber,
+			Quantity:   quantity,
+		})
+		return nil
+	}
+
+	for idx := range i.Locations {
+		loc := &i.Locations[idx]
+		for _, lot := range loc.Lots {
+			if err := place(loc.LocationID, lot.LotNumber, lot.Available()); err != nil {
+				return nil, err
+			}
+		}
+		if err := place(loc.LocationID, "", loc.untrackedAvailable()); err != nil {
+			return nil, err
+		}
+	}
+	return placements, nil
+}
+
+// ReleaseHold returns the item's stock set aside by a hold to available and returns the
+// quantity released. Stock of lots that expired meanwhile stays blocked.
+func (i *InventoryItem) ReleaseHold(holdID, releasedBy string) (int, error) {
+	type held struct {
+		locationID, lotNumber string
+		quantity              int
+	}
+	buckets := make([]held, 0)
+	for _, loc := range i.Locations {
+		for _, bucket := range loc.Statuses {
+			if bucket.Status == StatusOnHold && bucket.HoldID == holdID {
+				buckets = append(buckets, held{loc.LocationID, bucket.LotNumber, bucket.Quantity})
+			}
+		}
+	}
+
+	released := 0
+	for _, b := range buckets {
+		if err := i.ChangeStatus(StatusChange{
+			LocationID:  b.locationID,
+			LotNumber:   b.lotNumber,
+			Quantity:    b.quantity,
+			From:        StatusOnHold,
+			To:          StatusAvailable,
+			ReasonCode:  StatusReasonHoldReleased,
+			HoldID:      holdID,
+			ReferenceID: holdID,
+			ChangedBy:   releasedBy,
+		}); err != nil {
+			return released, err
+		}
+		released += b.quantity
+	}
+	return released, nil
+}
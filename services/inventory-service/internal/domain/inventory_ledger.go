@@ -77,6 +77,7 @@ func NewInventoryLedger(sku string, valuationMethod ValuationMethod, tenant *Led
 	ledger.AccountBalances[AccountGoodsInTransit] = ZeroAccountBalance(currency)
 	ledger.AccountBalances[AccountAdjustments] = ZeroAccountBalance(currency)
 	ledger.AccountBalances[AccountReturns] = ZeroAccountBalance(currency)
+	ledger.AccountBalances[AccountNonSellable] = ZeroAccountBalance(currency)
 
 	return ledger, nil
 }
@@ -380,6 +381,84 @@ func (l *InventoryLedger) RecordAdjustment(qty int, reason, locationID, referenc
 	return transactionID, entries, nil
 }
 
+// RecordStatusChange reclassifies stock moving between inventory statuses at average cost.
+// Quantity and value on hand are unchanged, only the account carrying them.
+// Debit the target status account, Credit the source status account
+func (l *InventoryLedger) RecordStatusChange(qty int, from, to AccountType, reason, locationID, referenceID, createdBy string) (LedgerTransactionID, []LedgerEntry, error) {
+	if qty <= 0 {
+		return LedgerTransactionID{}, nil, ErrInvalidQuantity
+	}
+	if from == to {
+		return LedgerTransactionID{}, nil, fmt.Errorf("status change must move stock between two accounts")
+	}
+	if l.CurrentBalance < qty {
+		return LedgerTransactionID{}, nil, ErrInsufficientStock
+	}
+
+	transactionID := NewLedgerTransactionID()
+	unitCost := l.AverageUnitCost
+	value, err := unitCost.Multiply(qty)
+	if err != nil {
+		return LedgerTransactionID{}, nil, err
+	}
+
+	l.updateAccountBalance(from, -qty, Money{amount: -value.Amount(), currency: value.Currency()})
+	l.updateAccountBalance(to, qty, value)
+	fromBalance := l.GetAccountBalance(from)
+	toBalance := l.GetAccountBalance(to)
+
+	description := fmt.Sprintf("Status change: %s", reason)
+	debitEntry, err := NewDebitEntry(transactionID, to, qty, unitCost, toBalance.Balance, toBalance.Value, l.SKU, locationID, referenceID, "status_change", description, createdBy)
+	if err != nil {
+		return LedgerTransactionID{}, nil, err
+	}
+	creditEntry, err := NewCreditEntry(transactionID, from, qty, unitCost, fromBalance.Balance, fromBalance.Value, l.SKU, locationID, referenceID, "status_change", description, createdBy)
+	if err != nil {
+		return LedgerTransactionID{}, nil, err
+	}
+
+	l.UpdatedAt = time.Now().UTC()
+	return transactionID, []LedgerEntry{debitEntry, creditEntry}, nil
+}
+
+// RecordTransfer records stock moving between locations at average cost. Cost layers are left
+// untouched, so the stock keeps the cost it was received at. The accounts are the same for a
+// direct move; stock picked onto a cart moves to goods in transit and back when dropped.
+// Debit the destination account at toLocationID, Credit the source account at fromLocationID
+func (l *InventoryLedger) RecordTransfer(qty int, from, to AccountType, fromLocationID, toLocationID, referenceID, createdBy string) (LedgerTransactionID, []LedgerEntry, error) {
+	if qty <= 0 {
+		return LedgerTransactionID{}, nil, ErrInvalidQuantity
+	}
+	if l.CurrentBalance < qty {
+		return LedgerTransactionID{}, nil, ErrInsufficientStock
+	}
+
+	transactionID := NewLedgerTransactionID()
+	unitCost := l.AverageUnitCost
+	value, err := unitCost.Multiply(qty)
+	if err != nil {
+		return LedgerTransactionID{}, nil, err
+	}
+
+	l.updateAccountBalance(from, -qty, Money{amount: -value.Amount(), currency: value.Currency()})
+	l.updateAccountBalance(to, qty, value)
+	fromBalance := l.GetAccountBalance(from)
+	toBalance := l.GetAccountBalance(to)
+
+	description := fmt.Sprintf("Transfer from %s to %s", fromLocationID, toLocationID)
+	debitEntry, err := NewDebitEntry(transactionID, to, qty, unitCost, toBalance.Balance, toBalance.Value, l.SKU, toLocationID, referenceID, "transfer", description, createdBy)
+	if err != nil {
+		return LedgerTransactionID{}, nil, err
+	}
+	creditEntry, err := NewCreditEntry(transactionID, from, qty, unitCost, fromBalance.Balance, fromBalance.Value, l.SKU, fromLocationID, referenceID, "transfer", description, createdBy)
+	if err != nil {
+		return LedgerTransactionID{}, nil, err
+	}
+
+	l.UpdatedAt = time.Now().UTC()
+	return transactionID, []LedgerEntry{debitEntry, creditEntry}, nil
+}
+
 // AddCostLayer adds a new cost layer (for FIFO/LIFO)
 func (l *InventoryLedger) AddCostLayer(qty int, unitCost Money, referenceID string) {
 	layer := NewCostLayer(qty, unitCost, referenceID)
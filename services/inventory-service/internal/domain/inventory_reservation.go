@@ -46,6 +46,7 @@ type InventoryReservationAggregate struct {
 	CreatedAt time.Time `bson:"createdAt"`
 	ExpiresAt time.Time `bson:"expiresAt"`
 	UpdatedAt time.Time `bson:"updatedAt"`
+	Version   int       `bson:"version"` // Incremented on every save for optimistic concurrency
 
 	// Optional: Track who/what created/updated the reservation
 	CreatedBy string `bson:"createdBy,omitempty"`
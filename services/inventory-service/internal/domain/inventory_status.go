@@ -0,0 +1,329 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Inventory status errors
+var (
+	ErrInvalidInventoryStatus = errors.New("invalid inventory status")
+	ErrInvalidStatusReason    = errors.New("invalid status reason code")
+	ErrSameInventoryStatus    = errors.New("source and target inventory status must differ")
+	ErrStatusNotSellable      = errors.New("stock can only be received into a non-sellable status")
+	ErrUnknownItemCondition   = errors.New("unknown item condition")
+)
+
+// InventoryStatus is the sellability bucket stock sits in at a location. Only available
+// stock can be reserved, allocated or offered on sales channels.
+type InventoryStatus string
+
+const (
+	StatusAvailable      InventoryStatus = "available"       // Sellable
+	StatusDamaged        InventoryStatus = "damaged"         // Damaged on receipt or in the warehouse
+	StatusQuarantine     InventoryStatus = "quarantine"      // Under investigation, e.g. an open problem ticket
+	StatusQCHold         InventoryStatus = "qc_hold"         // Awaiting quality inspection or prep
+	StatusCustomerReturn InventoryStatus = "customer_return" // Returned by a customer, awaiting disposition
+	StatusOnHold         InventoryStatus = "on_hold"         // Set aside by an inventory hold
+)
+
+// IsValid checks if the inventory status is valid
+func (s InventoryStatus) IsValid() bool {
+	switch s {
+	case StatusAvailable, StatusDamaged, StatusQuarantine, StatusQCHold, StatusCustomerReturn, StatusOnHold:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSellable returns true if stock in the status can be reserved and sold
+func (s InventoryStatus) IsSellable() bool {
+	return s == StatusAvailable
+}
+
+// LedgerAccount returns the ledger account stock in the status is carried in
+func (s InventoryStatus) LedgerAccount() AccountType {
+	switch s {
+	case StatusAvailable:
+		return AccountInventory
+	case StatusCustomerReturn:
+		return AccountReturns
+	default:
+		return AccountNonSellable
+	}
+}
+
+// StatusReasonCode is why stock changed inventory status
+type StatusReasonCode string
+
+const (
+	StatusReasonDamagedOnReceipt   StatusReasonCode = "damaged_on_receipt"
+	StatusReasonDamagedInWarehouse StatusReasonCode = "damaged_in_warehouse"
+	StatusReasonProblemTicket      StatusReasonCode = "problem_ticket"
+	StatusReasonNeedsPrep          StatusReasonCode = "needs_prep"
+	StatusReasonQualityInspection  StatusReasonCode = "quality_inspection"
+	StatusReasonCustomerReturn     StatusReasonCode = "customer_return"
+	StatusReasonRecall             StatusReasonCode = "recall"
+	StatusReasonInvestigation      StatusReasonCode = "investigation"
+	StatusReasonInspectionPassed   StatusReasonCode = "inspection_passed" // Back to sellable after inspection or prep
+	StatusReasonReturnRestocked    StatusReasonCode = "return_restocked"  // Customer return graded resellable
+	StatusReasonHoldReleased       StatusReasonCode = "hold_released"
+)
+
+// IsValid checks if the reason code is valid
+func (r StatusReasonCode) IsValid() bool {
+	switch r {
+	case StatusReasonDamagedOnReceipt, StatusReasonDamagedInWarehouse, StatusReasonProblemTicket,
+		StatusReasonNeedsPrep, StatusReasonQualityInspection, StatusReasonCustomerReturn,
+		StatusReasonRecall, StatusReasonInvestigation, StatusReasonInspectionPassed,
+		StatusReasonReturnRestocked, StatusReasonHoldReleased:
+		return true
+	default:
+		return false
+	}
+}
+
+// StatusForCondition maps a receiving-service item condition (good, damaged, needs_prep,
+// prepped) to the status received stock goes into and the reason recorded for it
+func StatusForCondition(condition string) (InventoryStatus, StatusReasonCode, error) {
+	switch condition {
+	case "", "good", "prepped":
+		return StatusAvailable, "", nil
+	case "damaged":
+		return StatusDamaged, StatusReasonDamagedOnReceipt, nil
+	case "needs_prep":
+		return StatusQCHold, StatusReasonNeedsPrep, nil
+	default:
+		return "", "", ErrUnknownItemCondition
+	}
+}
+
+// StatusBucket is non-sellable stock of one status at a location, per lot and per hold
+type StatusBucket struct {
+	Status     InventoryStatus  `bson:"status"`
+	LotNumber  string           `bson:"lotNumber,omitempty"`
+	HoldID     string           `bson:"holdId,omitempty"` // Set on stock put on hold by an inventory hold
+	ReasonCode StatusReasonCode `bson:"reasonCode"`       // Reason the stock was last moved in
+	Quantity   int              `bson:"quantity"`
+	UpdatedAt  time.Time        `bson:"updatedAt"`
+}
+
+// NonSellable returns the stock at the location held in non-sellable statuses
+func (l StockLocation) NonSellable() int {
+	total := 0
+	for _, bucket := range l.Statuses {
+		total += bucket.Quantity
+	}
+	return total
+}
+
+// StatusQuantity returns the stock at the location in a status
+func (l StockLocation) StatusQuantity(status InventoryStatus) int {
+	if status.IsSellable() {
+		return l.Available
+	}
+	total := 0
+	for _, bucket := range l.Statuses {
+		if bucket.Status == status {
+			total += bucket.Quantity
+		}
+	}
+	return total
+}
+
+// statusBucket returns the index of the bucket for a status, lot and hold, or -1
+func (l *StockLocation) statusBucket(status InventoryStatus, lotNumber, holdID string) int {
+	for idx, bucket := range l.Statuses {
+		if bucket.Status == status && bucket.LotNumber == lotNumber && bucket.HoldID == holdID {
+			return idx
+		}
+	}
+	return -1
+}
+
+// sellableAvailable returns the available stock of a lot at the location, or the available
+// stock not held in any lot when lotNumber is empty
+func (l *StockLocation) sellableAvailable(lotNumber string) (int, error) {
+	if lotNumber == "" {
+		return l.untrackedAvailable(), nil
+	}
+	lot := l.GetLot(lotNumber)
+	if lot == nil {
+		return 0, ErrLotNotFound
+	}
+	return lot.Available(), nil
+}
+
+// StatusChange moves stock at a location from one inventory status to another
+type StatusChange struct {
+	LocationID  string
+	LotNumber   string // Lot-tracked stock only
+	Quantity    int
+	From        InventoryStatus
+	To          InventoryStatus
+	ReasonCode  StatusReasonCode
+	HoldID      string // Hold the on_hold stock belongs to
+	ReferenceID string // Problem ticket, RMA, inspection, etc.
+	ChangedBy   string
+}
+
+// ChangeStatus moves stock between inventory statuses at a location. Stock leaves the
+// sellable status only from available stock; reserved and staged stock stays with its orders.
+// Lot stock released from a non-sellable status into a lot that expired meanwhile is blocked.
+func (i *InventoryItem) ChangeStatus(change StatusChange) error {
+	if change.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if !change.From.IsValid() || !change.To.IsValid() {
+		return ErrInvalidInventoryStatus
+	}
+	if change.From == change.To {
+		return ErrSameInventoryStatus
+	}
+	if !change.ReasonCode.IsValid() {
+		return ErrInvalidStatusReason
+	}
+	if i.GetLocationStock(change.LocationID) == nil {
+		return ErrLocationNotFound
+	}
+	loc := &i.Locations[i.locationIndex(change.LocationID, "")]
+
+	var lot *StockLot
+	if change.LotNumber != "" {
+		if lot = loc.GetLot(change.LotNumber); lot == nil {
+			return ErrLotNotFound
+		}
+	}
+
+	now := time.Now()
+
+	// Take the stock out of its current status
+	if change.From.IsSellable() {
+		available, err := loc.sellableAvailable(change.LotNumber)
+		if err != nil {
+			return err
+		}
+		if available < change.Quantity {
+			return ErrInsufficientStock
+		}
+		loc.Available -= change.Quantity
+		i.AvailableQuantity -= change.Quantity
+		if lot != nil {
+			lot.Held += change.Quantity
+		}
+	} else {
+		idx := loc.statusBucket(change.From, change.LotNumber, change.HoldID)
+		if idx == -1 || loc.Statuses[idx].Quantity < change.Quantity {
+			return ErrInsufficientStock
+		}
+		loc.Statuses[idx].Quantity -= change.Quantity
+		if loc.Statuses[idx].Quantity == 0 {
+			loc.Statuses = append(loc.Statuses[:idx], loc.Statuses[idx+1:]...)
+		}
+		i.NonSellableQuantity -= change.Quantity
+	}
+
+	// Put it into the target status
+	if change.To.IsSellable() {
+		if lot != nil {
+			lot.Held -= change.Quantity
+		}
+		if lot != nil && lot.Status == LotStatusExpired {
+			loc.Blocked += change.Quantity
+		} else {
+			loc.Available += change.Quantity
+			i.AvailableQuantity += change.Quantity
+		}
+	} else {
+		idx := loc.statusBucket(change.To, change.LotNumber, change.HoldID)
+		if idx == -1 {
+			loc.Statuses = append(loc.Statuses, StatusBucket{
+				Status:    change.To,
+				LotNumber: change.LotNumber,
+				HoldID:    change.HoldID,
+			})
+			idx = len(loc.Statuses) - 1
+		}
+		loc.Statuses[idx].Quantity += change.Quantity
+		loc.Statuses[idx].ReasonCode = change.ReasonCode
+		loc.Statuses[idx].UpdatedAt = now
+		i.NonSellableQuantity += change.Quantity
+	}
+
+	i.UpdatedAt = now
+	i.Transactions = append(i.Transactions, InventoryTransaction{
+		TransactionID: generateTransactionID(),
+		Type:          "status_change",
+		Quantity:      change.Quantity,
+		LocationID:    change.LocationID,
+		ReferenceID:   change.ReferenceID,
+		Reason:        string(change.ReasonCode),
+		FromStatus:    string(change.From),
+		ToStatus:      string(change.To),
+		CreatedAt:     now,
+		CreatedBy:     change.ChangedBy,
+	})
+
+	i.AddDomainEvent(&InventoryStatusChangedEvent{
+		SKU:         i.SKU,
+		LocationID:  change.LocationID,
+		LotNumber:   change.LotNumber,
+		Quantity:    change.Quantity,
+		FromStatus:  string(change.From),
+		ToStatus:    string(change.To),
+		ReasonCode:  string(change.ReasonCode),
+		HoldID:      change.HoldID,
+		ReferenceID: change.ReferenceID,
+		ChangedBy:   change.ChangedBy,
+		ChangedAt:   now,
+	})
+	return nil
+}
+
+// ReceiveIntoStatus adds stock to a location straight into a non-sellable status, e.g. a
+// damaged receipt or a customer return awaiting inspection. Lot is optional.
+func (i *InventoryItem) ReceiveIntoStatus(locationID, zone string, quantity int, lot *LotInfo, status InventoryStatus, reasonCode StatusReasonCode, referenceID, createdBy string) error {
+	if !status.IsValid() {
+		return ErrInvalidInventoryStatus
+	}
+	if status.IsSellable() {
+		return ErrStatusNotSellable
+	}
+	if !reasonCode.IsValid() {
+		return ErrInvalidStatusReason
+	}
+
+	lotNumber := ""
+	if lot != nil {
+		if err := lot.Validate(); err != nil {
+			return err
+		}
+		lotNumber = lot.LotNumber
+	}
+	if err := i.receive(locationID, zone, quantity, lot, referenceID, createdBy); err != nil {
+		return err
+	}
+
+	return i.ChangeStatus(StatusChange{
+		LocationID:  locationID,
+		LotNumber:   lotNumber,
+		Quantity:    quantity,
+		From:        StatusAvailable,
+		To:          status,
+		ReasonCode:  reasonCode,
+		ReferenceID: referenceID,
+		ChangedBy:   createdBy,
+	})
+}
+
+// StatusSummary returns the item's stock per inventory status across all locations
+func (i *InventoryItem) StatusSummary() map[InventoryStatus]int {
+	summary := map[InventoryStatus]int{StatusAvailable: i.AvailableQuantity}
+	for _, loc := range i.Locations {
+		for _, bucket := range loc.Statuses {
+			summary[bucket.Status] += bucket.Quantity
+		}
+	}
+	return summary
+}
@@ -0,0 +1,246 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInventoryItem_ChangeStatus tests moving stock out of and back into sellable stock
+func TestInventoryItem_ChangeStatus(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 0, 50)
+	require.NoError(t, item.ReceiveStock("LOC-1", "ZONE-A", 20, "PO-1", "user1"))
+	require.NoError(t, item.Reserve("ORD-1", "LOC-1", 5))
+	item.ClearDomainEvents()
+
+	require.NoError(t, item.ChangeStatus(StatusChange{
+		LocationID:  "LOC-1",
+		Quantity:    4,
+		From:        StatusAvailable,
+		To:          StatusDamaged,
+		ReasonCode:  StatusReasonDamagedInWarehouse,
+		ReferenceID: "PT-1",
+		ChangedBy:   "user1",
+	}))
+
+	loc := item.GetLocationStock("LOC-1")
+	assert.Equal(t, 20, loc.Quantity, "status changes keep the stock on hand")
+	assert.Equal(t, 11, loc.Available)
+	assert.Equal(t, 4, loc.NonSellable())
+	assert.Equal(t, 4, loc.StatusQuantity(StatusDamaged))
+	assert.Equal(t, 11, item.AvailableQuantity)
+	assert.Equal(t, 4, item.NonSellableQuantity)
+	assert.Equal(t, 20, item.TotalQuantity)
+
+	txn := item.Transactions[len(item.Transactions)-1]
+	assert.Equal(t, "status_change", txn.Type)
+	assert.Equal(t, "damaged_in_warehouse", txn.Reason)
+	assert.Equal(t, "available", txn.FromStatus)
+	assert.Equal(t, "damaged", txn.ToStatus)
+
+	events := item.GetDomainEvents()
+	require.Len(t, events, 1)
+	changed, ok := events[0].(*InventoryStatusChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, 4, changed.Quantity)
+	assert.Equal(t, "PT-1", changed.ReferenceID)
+
+	// Reserved stock cannot be moved out of sellable
+	assert.ErrorIs(t, item.ChangeStatus(StatusChange{
+		LocationID: "LOC-1", Quantity: 12, From: StatusAvailable, To: StatusQuarantine, ReasonCode: StatusReasonInvestigation,
+	}), ErrInsufficientStock)
+
+	// Non-sellable to non-sellable, then back to sellable
+	require.NoError(t, item.ChangeStatus(StatusChange{
+		LocationID: "LOC-1", Quantity: 4, From: StatusDamaged, To: StatusQCHold, ReasonCode: StatusReasonQualityInspection,
+	}))
+	assert.Equal(t, 0, item.GetLocationStock("LOC-1").StatusQuantity(StatusDamaged))
+	require.NoError(t, item.ChangeStatus(StatusChange{
+		LocationID: "LOC-1", Quantity: 3, From: StatusQCHold, To: StatusAvailable, ReasonCode: StatusReasonInspectionPassed,
+	}))
+	loc = item.GetLocationStock("LOC-1")
+	assert.Equal(t, 14, loc.Available)
+	assert.Equal(t, 1, loc.NonSellable())
+	assert.Equal(t, 1, item.NonSellableQuantity)
+	assert.Equal(t, map[InventoryStatus]int{StatusAvailable: 14, StatusQCHold: 1}, item.StatusSummary())
+}
+
+// TestInventoryItem_ChangeStatusValidation tests rejected status changes
+func TestInventoryItem_ChangeStatusValidation(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 0, 50)
+	require.NoError(t, item.ReceiveStock("LOC-1", "ZONE-A", 10, "PO-1", "user1"))
+
+	base := StatusChange{LocationID: "LOC-1", Quantity: 1, From: StatusAvailable, To: StatusDamaged, ReasonCode: StatusReasonDamagedInWarehouse}
+
+	change := base
+	change.Quantity = 0
+	assert.ErrorIs(t, item.ChangeStatus(change), ErrInvalidQuantity)
+
+	change = base
+	change.To = "lost"
+	assert.ErrorIs(t, item.ChangeStatus(change), ErrInvalidInventoryStatus)
+
+	change = base
+	change.To = StatusAvailable
+	assert.ErrorIs(t, item.ChangeStatus(change), ErrSameInventoryStatus)
+
+	change = base
+	change.ReasonCode = "because"
+	assert.ErrorIs(t, item.ChangeStatus(change), ErrInvalidStatusReason)
+
+	change = base
+	change.LocationID = "LOC-X"
+	assert.ErrorIs(t, item.ChangeStatus(change), ErrLocationNotFound)
+
+	change = base
+	change.From, change.To = StatusDamaged, StatusAvailable
+	assert.ErrorIs(t, item.ChangeStatus(change), ErrInsufficientStock, "nothing is damaged yet")
+}
+
+// TestInventoryItem_ReserveOnlyFromSellableStock tests that non-sellable lot stock is not allocated
+func TestInventoryItem_ReserveOnlyFromSellableStock(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 0, 50)
+	require.NoError(t, item.ReceiveLotStock("LOC-1", "ZONE-A", 10, LotInfo{LotNumber: "LOT-EARLY", ExpiryDate: daysFromNow(10)}, "PO-1", "user1"))
+	require.NoError(t, item.ReceiveLotStock("LOC-1", "ZONE-A", 10, LotInfo{LotNumber: "LOT-LATE", ExpiryDate: daysFromNow(90)}, "PO-2", "user1"))
+
+	require.NoError(t, item.ChangeStatus(StatusChange{
+		LocationID: "LOC-1", LotNumber: "LOT-EARLY", Quantity: 10, From: StatusAvailable, To: StatusQuarantine, ReasonCode: StatusReasonRecall,
+	}))
+	assert.Equal(t, 10, item.GetLocationStock("LOC-1").Available)
+
+	assert.ErrorIs(t, item.Reserve("ORD-1", "LOC-1", 11), ErrInsufficientStock)
+	require.NoError(t, item.Reserve("ORD-1", "LOC-1", 6))
+	require.Len(t, item.Reservations[0].Lots, 1)
+	assert.Equal(t, "LOT-LATE", item.Reservations[0].Lots[0].LotNumber, "the quarantined earlier lot is skipped")
+
+	assert.ErrorIs(t, item.ChangeStatus(StatusChange{
+		LocationID: "LOC-1", LotNumber: "LOT-NONE", Quantity: 1, From: StatusAvailable, To: StatusDamaged, ReasonCode: StatusReasonDamagedInWarehouse,
+	}), ErrLotNotFound)
+}
+
+// TestInventoryItem_ReceiveIntoStatus tests damaged receipts and customer returns
+func TestInventoryItem_ReceiveIntoStatus(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 0, 50)
+	require.NoError(t, item.ReceiveStock("LOC-1", "ZONE-A", 10, "PO-1", "user1"))
+
+	require.NoError(t, item.ReceiveIntoStatus("LOC-1", "ZONE-A", 3, nil, StatusDamaged, StatusReasonDamagedOnReceipt, "PO-1", "user1"))
+	require.NoError(t, item.ReceiveIntoStatus("RTN-1", "ZONE-R", 2, nil, StatusCustomerReturn, StatusReasonCustomerReturn, "RMA-1", "user1"))
+
+	assert.Equal(t, 15, item.TotalQuantity)
+	assert.Equal(t, 10, item.AvailableQuantity)
+	assert.Equal(t, 5, item.NonSellableQuantity)
+	assert.Equal(t, 0, item.GetLocationStock("RTN-1").Available)
+	assert.Equal(t, 2, item.GetLocationStock("RTN-1").StatusQuantity(StatusCustomerReturn))
+
+	assert.ErrorIs(t, item.ReceiveIntoStatus("LOC-1", "ZONE-A", 1, nil, StatusAvailable, StatusReasonCustomerReturn, "PO-1", "user1"), ErrStatusNotSellable)
+
+	// Adjusting a location keeps non-sellable stock out of available
+	require.NoError(t, item.Adjust("LOC-1", 12, "count", "user1"))
+	assert.Equal(t, 9, item.GetLocationStock("LOC-1").Available)
+}
+
+// TestStatusForCondition tests mapping receiving conditions to statuses
+func TestStatusForCondition(t *testing.T) {
+	status, reason, err := StatusForCondition("damaged")
+	require.NoError(t, err)
+	assert.Equal(t, StatusDamaged, status)
+	assert.Equal(t, StatusReasonDamagedOnReceipt, reason)
+
+	status, _, err = StatusForCondition("needs_prep")
+	require.NoError(t, err)
+	assert.Equal(t, StatusQCHold, status)
+
+	status, _, err = StatusForCondition("good")
+	require.NoError(t, err)
+	assert.True(t, status.IsSellable())
+
+	_, _, err = StatusForCondition("soggy")
+	assert.ErrorIs(t, err, ErrUnknownItemCondition)
+}
+
+// TestInventoryItem_PlaceAndReleaseHold tests SKU, lot and location holds
+func TestInventoryItem_PlaceAndReleaseHold(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 0, 50)
+	require.NoError(t, item.ReceiveLotStock("LOC-1", "ZONE-A", 8, LotInfo{LotNumber: "LOT-1", ExpiryDate: daysFromNow(30)}, "PO-1", "user1"))
+	require.NoError(t, item.ReceiveLotStock("LOC-2", "ZONE-A", 6, LotInfo{LotNumber: "LOT-1", ExpiryDate: daysFromNow(30)}, "PO-1", "user1"))
+	require.NoError(t, item.ReceiveStock("LOC-2", "ZONE-A", 5, "PO-2", "user1"))
+	require.NoError(t, item.Reserve("ORD-1", "LOC-1", 2))
+
+	lotHold, err := NewInventoryHold(HoldScopeLot, "SKU-001", "LOT-1", "", StatusReasonRecall, "supplier recall", "qa1")
+	require.NoError(t, err)
+	placements, err := item.PlaceHold(lotHold)
+	require.NoError(t, err)
+	require.Len(t, placements, 2)
+	lotHold.RecordPlacements(placements)
+	assert.Equal(t, 12, lotHold.HeldQuantity(), "the reserved 2 units stay with their order")
+	assert.Equal(t, []string{"SKU-001"}, lotHold.SKUs())
+	assert.Equal(t, 5, item.AvailableQuantity, "untracked stock is outside the lot hold")
+
+	locationHold, err := NewInventoryHold(HoldScopeLocation, "", "", "LOC-2", StatusReasonInvestigation, "", "qa1")
+	require.NoError(t, err)
+	placements, err = item.PlaceHold(locationHold)
+	require.NoError(t, err)
+	require.Len(t, placements, 1)
+	assert.Equal(t, HoldPlacement{SKU: "SKU-001", LocationID: "LOC-2", Quantity: 5}, placements[0])
+	assert.Equal(t, 0, item.AvailableQuantity)
+	assert.Equal(t, 17, item.NonSellableQuantity)
+
+	released, err := item.ReleaseHold(lotHold.HoldID, "qa2")
+	require.NoError(t, err)
+	assert.Equal(t, 12, released)
+	assert.Equal(t, 12, item.AvailableQuantity)
+	assert.Equal(t, 5, item.NonSellableQuantity, "the location hold still holds its stock")
+	assert.Equal(t, 0, item.GetLocationStock("LOC-1").GetLot("LOT-1").Held)
+
+	require.NoError(t, lotHold.Release("qa2"))
+	assert.ErrorIs(t, lotHold.Release("qa2"), ErrHoldNotActive)
+	_, err = item.PlaceHold(lotHold)
+	assert.ErrorIs(t, err, ErrHoldNotActive)
+}
+
+// TestNewInventoryHold tests hold scope validation
+func TestNewInventoryHold(t *testing.T) {
+	_, err := NewInventoryHold(HoldScopeSKU, "", "", "", StatusReasonRecall, "", "qa1")
+	assert.ErrorIs(t, err, ErrInvalidHoldScope)
+	_, err = NewInventoryHold(HoldScopeLot, "SKU-001", "", "", StatusReasonRecall, "", "qa1")
+	assert.ErrorIs(t, err, ErrInvalidHoldScope)
+	_, err = NewInventoryHold(HoldScopeLocation, "SKU-001", "", "", StatusReasonRecall, "", "qa1")
+	assert.ErrorIs(t, err, ErrInvalidHoldScope)
+	_, err = NewInventoryHold("pallet", "SKU-001", "", "", StatusReasonRecall, "", "qa1")
+	assert.ErrorIs(t, err, ErrInvalidHoldScope)
+	_, err = NewInventoryHold(HoldScopeSKU, "SKU-001", "", "", "", "", "qa1")
+	assert.ErrorIs(t, err, ErrInvalidStatusReason)
+
+	hold, err := NewInventoryHold(HoldScopeSKU, "SKU-001", "", "", StatusReasonRecall, "", "qa1")
+	require.NoError(t, err)
+	assert.True(t, hold.IsActive())
+	assert.True(t, hold.Covers("ANY", "ANY-LOT"))
+}
+
+// TestInventoryLedger_RecordStatusChange tests reclassifying stock between status accounts
+func TestInventoryLedger_RecordStatusChange(t *testing.T) {
+	ledger, err := NewInventoryLedger("SKU-001", ValuationWeightedAverage, &LedgerTenantInfo{TenantID: "T1", FacilityID: "F1"}, "USD")
+	require.NoError(t, err)
+	unitCost, err := NewMoney(500, "USD")
+	require.NoError(t, err)
+	_, _, err = ledger.RecordReceiving(10, unitCost, "LOC-1", "PO-1", "user1")
+	require.NoError(t, err)
+
+	_, entries, err := ledger.RecordStatusChange(4, AccountInventory, AccountNonSellable, "damaged_in_warehouse", "LOC-1", "PT-1", "user1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.True(t, entries[0].IsDebit())
+	assert.Equal(t, AccountNonSellable, entries[0].AccountType)
+	assert.Equal(t, AccountInventory, entries[1].AccountType)
+
+	assert.Equal(t, 10, ledger.CurrentBalance, "stock on hand is unchanged")
+	assert.Equal(t, 6, ledger.GetAccountBalance(AccountInventory).Balance)
+	assert.Equal(t, 4, ledger.GetAccountBalance(AccountNonSellable).Balance)
+	assert.Equal(t, int64(2000), ledger.GetAccountBalance(AccountNonSellable).Value.Amount())
+
+	_, _, err = ledger.RecordStatusChange(4, AccountInventory, AccountInventory, "noop", "LOC-1", "PT-1", "user1")
+	assert.Error(t, err)
+	_, _, err = ledger.RecordStatusChange(11, AccountInventory, AccountNonSellable, "too much", "LOC-1", "PT-1", "user1")
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+}
@@ -18,12 +18,15 @@ const (
 
 	// AccountReturns - Asset account for returned goods pending processing
 	AccountReturns AccountType = "RETURNS"
+
+	// AccountNonSellable - Asset account for damaged, quarantined and held goods still on hand
+	AccountNonSellable AccountType = "NON_SELLABLE"
 )
 
 // IsValid checks if the account type is valid
 func (a AccountType) IsValid() bool {
 	switch a {
-	case AccountInventory, AccountCOGS, AccountGoodsInTransit, AccountAdjustments, AccountReturns:
+	case AccountInventory, AccountCOGS, AccountGoodsInTransit, AccountAdjustments, AccountReturns, AccountNonSellable:
 		return true
 	default:
 		return false
@@ -38,7 +41,7 @@ func (a AccountType) String() string {
 // IsAsset returns true if this is an asset account
 func (a AccountType) IsAsset() bool {
 	switch a {
-	case AccountInventory, AccountGoodsInTransit, AccountReturns:
+	case AccountInventory, AccountGoodsInTransit, AccountReturns, AccountNonSellable:
 		return true
 	default:
 		return false
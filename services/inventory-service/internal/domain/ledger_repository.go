@@ -37,6 +37,9 @@ type LedgerEntryRepository interface {
 	// FindByTransactionID retrieves entries for a transaction (debit/credit pair)
 	FindByTransactionID(ctx context.Context, tenantID, transactionID string) ([]*LedgerEntryAggregate, error)
 
+	// FindByReference retrieves entries recorded for a reference of the given type
+	FindByReference(ctx context.Context, tenantID, facilityID, sku, referenceType, referenceID string) ([]*LedgerEntryAggregate, error)
+
 	// FindByTimeRange retrieves entries within a time range
 	FindByTimeRange(ctx context.Context, tenantID, facilityID, sku string, start, end time.Time) ([]*LedgerEntryAggregate, error)
 
@@ -0,0 +1,481 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// Lot errors
+var (
+	ErrLotNumberRequired     = errors.New("lot number is required")
+	ErrInvalidLotDates       = errors.New("lot expiry date must be after manufacture date")
+	ErrInsufficientShelfLife = errors.New("insufficient stock meeting minimum remaining shelf life")
+	ErrLotNotFound           = errors.New("lot not found at location")
+	ErrLotExpired            = errors.New("reservation draws on an expired lot")
+)
+
+// LotStatus represents the status of a lot at a location
+type LotStatus string
+
+const (
+	LotStatusActive  LotStatus = "active"  // Sellable
+	LotStatusExpired LotStatus = "expired" // Past expiry date, blocked from allocation
+)
+
+// LotInfo captures lot attributes when receiving stock
+type LotInfo struct {
+	LotNumber       string
+	ManufactureDate *time.Time
+	ExpiryDate      *time.Time
+}
+
+// Validate validates the lot attributes
+func (l LotInfo) Validate() error {
+	if l.LotNumber == "" {
+		return ErrLotNumberRequired
+	}
+	if l.ManufactureDate != nil && l.ExpiryDate != nil && !l.ExpiryDate.After(*l.ManufactureDate) {
+		return ErrInvalidLotDates
+	}
+	return nil
+}
+
+// StockLot represents the quantity of a single lot/batch at a location
+// Reserved covers both soft reservations and hard allocations until the stock ships
+type StockLot struct {
+	LotNumber       string     `bson:"lotNumber"`
+	ManufactureDate *time.Time `bson:"manufactureDate,omitempty"`
+	ExpiryDate      *time.Time `bson:"expiryDate,omitempty"`
+	Quantity        int        `bson:"quantity"`
+	Reserved        int        `bson:"reserved"`
+	Held            int        `bson:"held,omitempty"` // In a non-sellable inventory status
+	Status          LotStatus  `bson:"status"`
+	ReceivedAt      time.Time  `bson:"receivedAt"`
+	ExpiryWarnedAt  *time.Time `bson:"expiryWarnedAt,omitempty"`
+	BlockedAt       *time.Time `bson:"blockedAt,omitempty"`
+}
+
+// Available returns the quantity of the lot that can still be allocated
+func (l StockLot) Available() int {
+	if l.Status != LotStatusActive {
+		return 0
+	}
+	return l.Quantity - l.Reserved - l.Held
+}
+
+// IsExpired returns true if the lot is past its expiry date at the given time
+func (l StockLot) IsExpired(asOf time.Time) bool {
+	return l.ExpiryDate != nil && !l.ExpiryDate.After(asOf)
+}
+
+// meetsShelfLife returns true if the lot has at least the minimum remaining shelf life
+func (l StockLot) meetsShelfLife(policy AllocationPolicy) bool {
+	if l.ExpiryDate == nil {
+		return true
+	}
+	return !l.ExpiryDate.Before(policy.asOf().Add(policy.MinRemainingShelfLife))
+}
+
+// LotAllocation records how much of a lot a reservation holds
+type LotAllocation struct {
+	LotNumber  string     `bson:"lotNumber"`
+	ExpiryDate *time.Time `bson:"expiryDate,omitempty"`
+	Quantity   int        `bson:"quantity"`
+}
+
+// AllocationPolicy controls lot selection when reserving stock
+type AllocationPolicy struct {
+	// MinRemainingShelfLife excludes lots expiring sooner than AsOf + MinRemainingShelfLife
+	MinRemainingShelfLife time.Duration
+	// AsOf is the reference time for shelf life checks (defaults to now)
+	AsOf time.Time
+}
+
+func (p AllocationPolicy) asOf() time.Time {
+	if p.AsOf.IsZero() {
+		return time.Now()
+	}
+	return p.AsOf
+}
+
+// ShelfLifePolicy configures the minimum remaining shelf life per seller and sales channel
+type ShelfLifePolicy struct {
+	DefaultMinDays int            `json:"defaultMinDays"`
+	SellerMinDays  map[string]int `json:"sellerMinDays,omitempty"`
+	ChannelMinDays map[string]int `json:"channelMinDays,omitempty"`
+}
+
+// MinRemainingDays returns the strictest minimum remaining shelf life for a seller and channel
+func (p *ShelfLifePolicy) MinRemainingDays(sellerID, channel string) int {
+	if p == nil {
+		return 0
+	}
+
+	days := p.DefaultMinDays
+	if sellerDays, ok := p.SellerMinDays[sellerID]; ok && sellerID != "" {
+		days = sellerDays
+	}
+	if channelDays, ok := p.ChannelMinDays[channel]; ok && channel != "" && channelDays > days {
+		days = channelDays
+	}
+	return days
+}
+
+// AllocationPolicy returns the allocation policy for a seller and channel
+func (p *ShelfLifePolicy) AllocationPolicy(sellerID, channel string, asOf time.Time) AllocationPolicy {
+	return AllocationPolicy{
+		MinRemainingShelfLife: time.Duration(p.MinRemainingDays(sellerID, channel)) * 24 * time.Hour,
+		AsOf:                  asOf,
+	}
+}
+
+// IsLotTracked returns true if the location holds lot-tracked stock
+func (l *StockLocation) IsLotTracked() bool {
+	return len(l.Lots) > 0
+}
+
+// GetLot returns a lot at the location by lot number
+func (l *StockLocation) GetLot(lotNumber string) *StockLot {
+	for idx := range l.Lots {
+		if l.Lots[idx].LotNumber == lotNumber {
+			return &l.Lots[idx]
+		}
+	}
+	return nil
+}
+
+// untrackedAvailable returns the available quantity at the location that is not held in a lot
+func (l *StockLocation) untrackedAvailable() int {
+	lotAvailable := 0
+	for _, lot := range l.Lots {
+		lotAvailable += lot.Available()
+	}
+	return l.Available - lotAvailable
+}
+
+// fefoLots returns the indexes of lots eligible for allocation under the policy,
+// ordered first-expired first-out (lots without expiry last)
+func (l *StockLocation) fefoLots(policy AllocationPolicy) []int {
+	eligible := make([]int, 0, len(l.Lots))
+	for idx, lot := range l.Lots {
+		if lot.Available() > 0 && lot.meetsShelfLife(policy) {
+			eligible = append(eligible, idx)
+		}
+	}
+
+	sort.SliceStable(eligible, func(a, b int) bool {
+		return expiresBefore(l.Lots[eligible[a]], l.Lots[eligible[b]])
+	})
+
+	return eligible
+}
+
+// expiresBefore orders lots first-expired first-out, lots without expiry last
+func expiresBefore(la, lb StockLot) bool {
+	switch {
+	case la.ExpiryDate == nil && lb.ExpiryDate == nil:
+		return la.LotNumber < lb.LotNumber
+	case la.ExpiryDate == nil:
+		return false
+	case lb.ExpiryDate == nil:
+		return true
+	case !la.ExpiryDate.Equal(*lb.ExpiryDate):
+		return la.ExpiryDate.Before(*lb.ExpiryDate)
+	default:
+		return la.LotNumber < lb.LotNumber
+	}
+}
+
+// eligibleAvailable returns the quantity allocatable under the policy and the earliest eligible expiry
+func (l *StockLocation) eligibleAvailable(policy AllocationPolicy) (int, *time.Time) {
+	if !l.IsLotTracked() {
+		return l.Available, nil
+	}
+
+	total := l.untrackedAvailable()
+	var earliest *time.Time
+	for _, idx := range l.fefoLots(policy) {
+		lot := l.Lots[idx]
+		total += lot.Available()
+		if earliest == nil && lot.ExpiryDate != nil {
+			earliest = lot.ExpiryDate
+		}
+	}
+	return total, earliest
+}
+
+// allocateFEFO reserves quantity from eligible lots in FEFO order, falling back to
+// untracked stock at the location last
+func (l *StockLocation) allocateFEFO(quantity int, policy AllocationPolicy) ([]LotAllocation, error) {
+	available, _ := l.eligibleAvailable(policy)
+	if available < quantity {
+		if l.Available >= quantity {
+			return nil, ErrInsufficientShelfLife
+		}
+		return nil, ErrInsufficientStock
+	}
+
+	allocations := make([]LotAllocation, 0)
+	remaining := quantity
+	for _, idx := range l.fefoLots(policy) {
+		if remaining == 0 {
+			break
+		}
+		lot := &l.Lots[idx]
+		take := lot.Available()
+		if take > remaining {
+			take = remaining
+		}
+		lot.Reserved += take
+		remaining -= take
+		allocations = append(allocations, LotAllocation{
+			LotNumber:  lot.LotNumber,
+			ExpiryDate: lot.ExpiryDate,
+			Quantity:   take,
+		})
+	}
+
+	return allocations, nil
+}
+
+// releaseLots returns allocated lot quantities to their lots and reports how much of it
+// belongs to lots that have since expired (and must stay blocked)
+func (l *StockLocation) releaseLots(allocations []LotAllocation) int {
+	blocked := 0
+	for _, alloc := range allocations {
+		lot := l.GetLot(alloc.LotNumber)
+		if lot == nil {
+			continue
+		}
+		lot.Reserved -= alloc.Quantity
+		if lot.Status == LotStatusExpired {
+			blocked += alloc.Quantity
+		}
+	}
+	return blocked
+}
+
+// consumeLots removes picked/shipped quantity from the lots held by a reservation in FEFO order
+// and returns the remaining lot allocations
+func (l *StockLocation) consumeLots(allocations []LotAllocation, quantity int) []LotAllocation {
+	remaining := quantity
+	result := make([]LotAllocation, 0, len(allocations))
+	for _, alloc := range allocations {
+		take := alloc.Quantity
+		if take > remaining {
+			take = remaining
+		}
+		if take > 0 {
+			if lot := l.GetLot(alloc.LotNumber); lot != nil {
+				lot.Quantity -= take
+				lot.Reserved -= take
+			}
+			remaining -= take
+		}
+		if alloc.Quantity-take > 0 {
+			alloc.Quantity -= take
+			result = append(result, alloc)
+		}
+	}
+	l.pruneEmptyLots()
+	return result
+}
+
+// shrinkLotsFEFO removes quantity no reservation or hold is using from the lots in FEFO order,
+// expired lots first; any quantity beyond that comes out of untracked stock. It returns how much
+// of the removed quantity was blocked in expired lots.
+func (l *StockLocation) shrinkLotsFEFO(quantity int) int {
+	order := make([]int, 0, len(l.Lots))
+	for idx, lot := range l.Lots {
+		if lot.Quantity-lot.Reserved-lot.Held > 0 {
+			order = append(order, idx)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return expiresBefore(l.Lots[order[a]], l.Lots[order[b]])
+	})
+
+	blocked := 0
+	remaining := quantity
+	for _, idx := range order {
+		if remaining == 0 {
+			break
+		}
+		lot := &l.Lots[idx]
+		take := lot.Quantity - lot.Reserved - lot.Held
+		if take > remaining {
+			take = remaining
+		}
+		lot.Quantity -= take
+		remaining -= take
+		if lot.Status == LotStatusExpired {
+			blocked += take
+		}
+	}
+	l.pruneEmptyLots()
+	return blocked
+}
+
+// pruneEmptyLots removes lots with no remaining quantity
+func (l *StockLocation) pruneEmptyLots() {
+	lots := l.Lots[:0]
+	for _, lot := range l.Lots {
+		if lot.Quantity > 0 || lot.Reserved > 0 || lot.Held > 0 {
+			lots = append(lots, lot)
+		}
+	}
+	l.Lots = lots
+}
+
+// ReceiveLotStock adds lot-tracked stock to a location
+func (i *InventoryItem) ReceiveLotStock(locationID, zone string, quantity int, lot LotInfo, referenceID, createdBy string) error {
+	if err := lot.Validate(); err != nil {
+		return err
+	}
+	return i.receive(locationID, zone, quantity, &lot, referenceID, createdBy)
+}
+
+// SelectFEFOLocation selects the location holding enough allocatable stock whose
+// earliest eligible lot expires first. Locations without expiring stock are chosen last.
+func (i *InventoryItem) SelectFEFOLocation(quantity int, policy AllocationPolicy) (string, error) {
+	selected := ""
+	var selectedExpiry *time.Time
+	shelfLifeShort := false
+
+	for idx := range i.Locations {
+		loc := &i.Locations[idx]
+		available, earliest := loc.eligibleAvailable(policy)
+		if available < quantity {
+			if loc.Available >= quantity {
+				shelfLifeShort = true
+			}
+			continue
+		}
+
+		switch {
+		case selected == "":
+		case selectedExpiry == nil && earliest != nil:
+		case earliest != nil && earliest.Before(*selectedExpiry):
+		default:
+			continue
+		}
+		selected = loc.LocationID
+		selectedExpiry = earliest
+	}
+
+	if selected == "" {
+		if shelfLifeShort {
+			return "", ErrInsufficientShelfLife
+		}
+		return "", ErrInsufficientStock
+	}
+	return selected, nil
+}
+
+// CheckLotExpiry blocks lots that have expired and warns about lots expiring within the
+// warning window. Returns the number of lots warned about and blocked.
+func (i *InventoryItem) CheckLotExpiry(now time.Time, warningWindow time.Duration) (int, int) {
+	warned, blocked := 0, 0
+
+	for locIdx := range i.Locations {
+		loc := &i.Locations[locIdx]
+		for lotIdx := range loc.Lots {
+			lot := &loc.Lots[lotIdx]
+			if lot.Status != LotStatusActive || lot.ExpiryDate == nil {
+				continue
+			}
+
+			if lot.IsExpired(now) {
+				// Reserved units stay with their reservations but can no longer be picked or
+				// staged; the affected orders are flagged so they can be re-allocated.
+				blockQty := lot.Available()
+				affectedOrders := i.ordersReservingLot(loc.LocationID, lot.LotNumber)
+				lot.Status = LotStatusExpired
+				lot.BlockedAt = &now
+
+				loc.Available -= blockQty
+				loc.Blocked += blockQty
+				i.AvailableQuantity -= blockQty
+				blocked++
+
+				i.AddDomainEvent(&LotExpiredEvent{
+					SKU:              i.SKU,
+					LocationID:       loc.LocationID,
+					LotNumber:        lot.LotNumber,
+					ExpiryDate:       *lot.ExpiryDate,
+					BlockedQuantity:  blockQty,
+					ReservedQuantity: lot.Reserved,
+					AffectedOrderIDs: affectedOrders,
+					BlockedAt:        now,
+				})
+				continue
+			}
+
+			if lot.ExpiryWarnedAt == nil && lot.ExpiryDate.Before(now.Add(warningWindow)) {
+				lot.ExpiryWarnedAt = &now
+				warned++
+
+				i.AddDomainEvent(&LotExpiringEvent{
+					SKU:           i.SKU,
+					SellerID:      i.SellerID,
+					LocationID:    loc.LocationID,
+					LotNumber:     lot.LotNumber,
+					ExpiryDate:    *lot.ExpiryDate,
+					Quantity:      lot.Quantity,
+					DaysRemaining: int(lot.ExpiryDate.Sub(now).Hours() / 24),
+					DetectedAt:    now,
+				})
+			}
+		}
+	}
+
+	if warned > 0 || blocked > 0 {
+		i.UpdatedAt = now
+	}
+
+	return warned, blocked
+}
+
+// ordersReservingLot returns the orders whose active reservations at the location hold the lot
+func (i *InventoryItem) ordersReservingLot(locationID, lotNumber string) []string {
+	var orderIDs []string
+	for _, res := range i.Reservations {
+		if res.Status != "active" || res.LocationID != locationID {
+			continue
+		}
+		for _, alloc := range res.Lots {
+			if alloc.LotNumber == lotNumber && alloc.Quantity > 0 {
+				orderIDs = append(orderIDs, res.OrderID)
+				break
+			}
+		}
+	}
+	return orderIDs
+}
+
+// holdsExpiredLot reports whether any of the allocations draw on a lot that has been blocked as
+// expired or is past its expiry date
+func (l *StockLocation) holdsExpiredLot(allocations []LotAllocation) bool {
+	now := time.Now()
+	for _, alloc := range allocations {
+		if alloc.Quantity <= 0 {
+			continue
+		}
+		for _, lot := range l.Lots {
+			if lot.LotNumber == alloc.LotNumber && (lot.Status == LotStatusExpired || lot.IsExpired(now)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetLots returns all lots across locations
+func (i *InventoryItem) GetLots() []StockLot {
+	lots := make([]StockLot, 0)
+	for _, loc := range i.Locations {
+		lots = append(lots, loc.Lots...)
+	}
+	return lots
+}
@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func daysFromNow(days int) *time.Time {
+	t := time.Now().Add(time.Duration(days) * 24 * time.Hour)
+	return &t
+}
+
+// createLotItem creates an item with two lots at LOC-A1 and one lot at LOC-B1
+func createLotItem(t *testing.T) *InventoryItem {
+	item := NewInventoryItem("SKU-001", "Yogurt", 10, 50)
+	require.NoError(t, item.ReceiveLotStock("LOC-A1", "ZONE-A", 20, LotInfo{LotNumber: "LOT-LATE", ExpiryDate: daysFromNow(90)}, "PO-1", "user1"))
+	require.NoError(t, item.ReceiveLotStock("LOC-A1", "ZONE-A", 10, LotInfo{LotNumber: "LOT-EARLY", ExpiryDate: daysFromNow(10)}, "PO-2", "user1"))
+	require.NoError(t, item.ReceiveLotStock("LOC-B1", "ZONE-B", 30, LotInfo{LotNumber: "LOT-MID", ExpiryDate: daysFromNow(40)}, "PO-3", "user1"))
+	return item
+}
+
+// TestReceiveLotStock tests lot capture on receipt
+func TestReceiveLotStock(t *testing.T) {
+	item := createLotItem(t)
+
+	assert.Equal(t, 60, item.TotalQuantity)
+	assert.Len(t, item.GetLots(), 3)
+	assert.True(t, item.GetLocationStock("LOC-A1").IsLotTracked())
+
+	// Receiving the same lot again merges quantities
+	require.NoError(t, item.ReceiveLotStock("LOC-A1", "ZONE-A", 5, LotInfo{LotNumber: "LOT-EARLY", ExpiryDate: daysFromNow(10)}, "PO-4", "user1"))
+	assert.Equal(t, 15, item.GetLocationStock("LOC-A1").GetLot("LOT-EARLY").Quantity)
+
+	// Invalid lot info is rejected
+	err := item.ReceiveLotStock("LOC-A1", "ZONE-A", 5, LotInfo{}, "PO-5", "user1")
+	assert.ErrorIs(t, err, ErrLotNumberRequired)
+
+	err = item.ReceiveLotStock("LOC-A1", "ZONE-A", 5, LotInfo{LotNumber: "LOT-X", ManufactureDate: daysFromNow(5), ExpiryDate: daysFromNow(1)}, "PO-6", "user1")
+	assert.ErrorIs(t, err, ErrInvalidLotDates)
+
+	// Received event carries lot attributes
+	var received *InventoryReceivedEvent
+	for _, event := range item.GetDomainEvents() {
+		if e, ok := event.(*InventoryReceivedEvent); ok {
+			received = e
+		}
+	}
+	require.NotNil(t, received)
+	assert.Equal(t, "LOT-EARLY", received.LotNumber)
+	assert.NotNil(t, received.ExpiryDate)
+}
+
+// TestReserveFEFO tests that reservations draw from the earliest-expiring lots first
+func TestReserveFEFO(t *testing.T) {
+	item := createLotItem(t)
+
+	require.NoError(t, item.ReserveWithPolicy("ORD-001", "LOC-A1", 15, nil, AllocationPolicy{}))
+
+	loc := item.GetLocationStock("LOC-A1")
+	assert.Equal(t, 10, loc.GetLot("LOT-EARLY").Reserved)
+	assert.Equal(t, 5, loc.GetLot("LOT-LATE").Reserved)
+
+	require.Len(t, item.Reservations, 1)
+	require.Len(t, item.Reservations[0].Lots, 2)
+	assert.Equal(t, "LOT-EARLY", item.Reservations[0].Lots[0].LotNumber)
+	assert.Equal(t, 10, item.Reservations[0].Lots[0].Quantity)
+
+	// Releasing returns quantities to the lots
+	require.NoError(t, item.ReleaseReservation("ORD-001"))
+	loc = item.GetLocationStock("LOC-A1")
+	assert.Equal(t, 0, loc.GetLot("LOT-EARLY").Reserved)
+	assert.Equal(t, 0, loc.GetLot("LOT-LATE").Reserved)
+	assert.Equal(t, 30, loc.Available)
+}
+
+// TestReserveMinimumShelfLife tests that lots with too little remaining shelf life are skipped
+func TestReserveMinimumShelfLife(t *testing.T) {
+	item := createLotItem(t)
+	policy := AllocationPolicy{MinRemainingShelfLife: 30 * 24 * time.Hour}
+
+	require.NoError(t, item.ReserveWithPolicy("ORD-001", "LOC-A1", 20, nil, policy))
+	assert.Equal(t, 0, item.GetLocationStock("LOC-A1").GetLot("LOT-EARLY").Reserved)
+	assert.Equal(t, 20, item.GetLocationStock("LOC-A1").GetLot("LOT-LATE").Reserved)
+
+	err := item.ReserveWithPolicy("ORD-002", "LOC-A1", 5, nil, policy)
+	assert.ErrorIs(t, err, ErrInsufficientShelfLife)
+}
+
+// TestSelectFEFOLocation tests location selection by earliest eligible expiry
+func TestSelectFEFOLocation(t *testing.T) {
+	item := createLotItem(t)
+
+	locationID, err := item.SelectFEFOLocation(10, AllocationPolicy{})
+	require.NoError(t, err)
+	assert.Equal(t, "LOC-A1", locationID)
+
+	// With a 30 day minimum, LOT-EARLY is ineligible and LOT-MID expires before LOT-LATE
+	locationID, err = item.SelectFEFOLocation(10, AllocationPolicy{MinRemainingShelfLife: 30 * 24 * time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, "LOC-B1", locationID)
+
+	_, err = item.SelectFEFOLocation(25, AllocationPolicy{MinRemainingShelfLife: 60 * 24 * time.Hour})
+	assert.ErrorIs(t, err, ErrInsufficientShelfLife)
+}
+
+// TestShelfLifePolicy tests per-seller and per-channel minimum shelf life
+func TestShelfLifePolicy(t *testing.T) {
+	policy := &ShelfLifePolicy{
+		DefaultMinDays: 7,
+		SellerMinDays:  map[string]int{"SLR-FOOD": 30},
+		ChannelMinDays: map[string]int{"amazon": 60, "outlet": 1},
+	}
+
+	assert.Equal(t, 7, policy.MinRemainingDays("SLR-OTHER", ""))
+	assert.Equal(t, 30, policy.MinRemainingDays("SLR-FOOD", ""))
+	assert.Equal(t, 60, policy.MinRemainingDays("SLR-FOOD", "amazon"))
+	assert.Equal(t, 30, policy.MinRemainingDays("SLR-FOOD", "outlet"))
+
+	var none *ShelfLifePolicy
+	assert.Equal(t, 0, none.MinRemainingDays("SLR-FOOD", "amazon"))
+}
+
+// TestCheckLotExpiry tests blocking of expired lots and expiry warnings
+func TestCheckLotExpiry(t *testing.T) {
+	item := createLotItem(t)
+	item.ClearDomainEvents()
+
+	// 15 days ahead: LOT-EARLY expired, LOT-MID within a 30 day warning window
+	now := time.Now().Add(15 * 24 * time.Hour)
+	warned, blocked := item.CheckLotExpiry(now, 30*24*time.Hour)
+	assert.Equal(t, 1, warned)
+	assert.Equal(t, 1, blocked)
+
+	loc := item.GetLocationStock("LOC-A1")
+	assert.Equal(t, LotStatusExpired, loc.GetLot("LOT-EARLY").Status)
+	assert.Equal(t, 10, loc.Blocked)
+	assert.Equal(t, 20, loc.Available)
+	assert.Equal(t, 50, item.AvailableQuantity)
+
+	var expiring *LotExpiringEvent
+	var expired *LotExpiredEvent
+	for _, event := range item.GetDomainEvents() {
+		switch e := event.(type) {
+		case *LotExpiringEvent:
+			expiring = e
+		case *LotExpiredEvent:
+			expired = e
+		}
+	}
+	require.NotNil(t, expiring)
+	assert.Equal(t, "wms.inventory.lot-expiring", expiring.EventType())
+	assert.Equal(t, "LOT-MID", expiring.LotNumber)
+	require.NotNil(t, expired)
+	assert.Equal(t, "LOT-EARLY", expired.LotNumber)
+	assert.Equal(t, 10, expired.BlockedQuantity)
+
+	// Expired stock can no longer be reserved
+	err := item.ReserveWithPolicy("ORD-001", "LOC-A1", 25, nil, AllocationPolicy{AsOf: now})
+	assert.Error(t, err)
+
+	// Warnings are only published once per lot
+	warned, blocked = item.CheckLotExpiry(now, 30*24*time.Hour)
+	assert.Equal(t, 0, warned)
+	assert.Equal(t, 0, blocked)
+}
+
+// TestCheckLotExpiryFlagsReservedStock tests that reserved units of an expired lot are flagged and cannot ship
+func TestCheckLotExpiryFlagsReservedStock(t *testing.T) {
+	item := createLotItem(t)
+	require.NoError(t, item.ReserveWithPolicy("ORD-001", "LOC-A1", 4, nil, AllocationPolicy{}))
+	item.ClearDomainEvents()
+
+	now := time.Now().Add(15 * 24 * time.Hour)
+	_, blocked := item.CheckLotExpiry(now, 30*24*time.Hour)
+	assert.Equal(t, 1, blocked)
+
+	var expired *LotExpiredEvent
+	for _, event := range item.GetDomainEvents() {
+		if e, ok := event.(*LotExpiredEvent); ok {
+			expired = e
+		}
+	}
+	require.NotNil(t, expired)
+	assert.Equal(t, 6, expired.BlockedQuantity)
+	assert.Equal(t, 4, expired.ReservedQuantity)
+	assert.Equal(t, []string{"ORD-001"}, expired.AffectedOrderIDs)
+
+	// The reservation still holds the expired lot, so it can be neither picked nor staged
+	err := item.Pick("ORD-001", "LOC-A1", 4, "picker1")
+	assert.ErrorIs(t, err, ErrLotExpired)
+	err = item.Stage(item.Reservations[0].ReservationID, "STAGE-01", "picker1")
+	assert.ErrorIs(t, err, ErrLotExpired)
+	assert.Equal(t, 4, item.ReservedQuantity)
+}
+
+// TestShipBlocksExpiredLot tests that packed stock of a lot that has since expired cannot ship
+func TestShipBlocksExpiredLot(t *testing.T) {
+	item := createLotItem(t)
+	require.NoError(t, item.ReserveWithPolicy("ORD-001", "LOC-A1", 4, nil, AllocationPolicy{}))
+	require.NoError(t, item.Stage(item.Reservations[0].ReservationID, "STAGE-01", "picker1"))
+	allocationID := item.HardAllocations[0].AllocationID
+	require.NoError(t, item.Pack(allocationID, "packer1"))
+
+	_, blocked := item.CheckLotExpiry(time.Now().Add(15*24*time.Hour), 30*24*time.Hour)
+	assert.Equal(t, 1, blocked)
+
+	err := item.Ship(allocationID)
+	assert.ErrorIs(t, err, ErrLotExpired)
+	assert.Equal(t, "packed", item.HardAllocations[0].Status)
+	assert.Equal(t, 60, item.TotalQuantity)
+	assert.Equal(t, 4, item.HardAllocatedQuantity)
+}
+
+// TestAdjustShrinksLotsFEFO tests that a shortage comes out of the earliest-expiring lots first
+func TestAdjustShrinksLotsFEFO(t *testing.T) {
+	item := createLotItem(t)
+	item.CheckLotExpiry(time.Now().Add(15*24*time.Hour), 0)
+
+	// The expired lot is consumed first, releasing its blocked quantity
+	require.NoError(t, item.Adjust("LOC-A1", 25, "cycle count", "counter1"))
+	loc := item.GetLocationStock("LOC-A1")
+	assert.Equal(t, 5, loc.GetLot("LOT-EARLY").Quantity)
+	assert.Equal(t, 20, loc.GetLot("LOT-LATE").Quantity)
+	assert.Equal(t, 5, loc.Blocked)
+	assert.Equal(t, 20, loc.Available)
+	assert.Equal(t, 50, item.AvailableQuantity)
+
+	// Then the next lot to expire
+	require.NoError(t, item.Adjust("LOC-A1", 12, "cycle count", "counter1"))
+	loc = item.GetLocationStock("LOC-A1")
+	assert.Nil(t, loc.GetLot("LOT-EARLY"))
+	assert.Equal(t, 12, loc.GetLot("LOT-LATE").Quantity)
+	assert.Equal(t, 0, loc.Blocked)
+	assert.Equal(t, 12, loc.Available)
+	assert.Equal(t, 42, item.AvailableQuantity)
+	assert.Equal(t, 42, item.TotalQuantity)
+}
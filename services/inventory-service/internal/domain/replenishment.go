@@ -0,0 +1,392 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Replenishment errors
+var (
+	ErrInvalidPickFaceLimits      = errors.New("pick face minimum must be at least zero and below its maximum")
+	ErrNoReplenishmentSource      = errors.New("no reserve stock available to replenish from")
+	ErrReplenishmentTaskClosed    = errors.New("replenishment task is no longer open")
+	ErrSameLocation               = errors.New("source and destination locations must differ")
+	ErrReplenishmentQuantityRange = errors.New("moved quantity must be between zero and the task quantity")
+)
+
+// ReplenishmentTrigger is why a replenishment task was created
+type ReplenishmentTrigger string
+
+const (
+	ReplenishmentTriggerMinMax     ReplenishmentTrigger = "min_max"     // Pick face dropped below its minimum
+	ReplenishmentTriggerWaveDemand ReplenishmentTrigger = "wave_demand" // Released wave needs more than the pick face holds
+)
+
+// ReplenishmentTaskStatus represents the lifecycle of a replenishment task
+type ReplenishmentTaskStatus string
+
+const (
+	ReplenishmentStatusPending   ReplenishmentTaskStatus = "pending"
+	ReplenishmentStatusCompleted ReplenishmentTaskStatus = "completed"
+	ReplenishmentStatusCancelled ReplenishmentTaskStatus = "cancelled"
+)
+
+// ReplenishmentReason is the reason recorded on stock moved by a replenishment task
+const ReplenishmentReason = "replenishment"
+
+// IsPickFace reports whether the location is a forward pick location with min/max settings
+func (l StockLocation) IsPickFace() bool {
+	return l.MaxQuantity > 0
+}
+
+// ReplenishmentNeed is the quantity a pick face needs to be filled back to its maximum
+type ReplenishmentNeed struct {
+	LocationID string
+	Zone       string
+	Quantity   int
+}
+
+// SetPickFaceLimits sets the min/max of a forward pick location, creating the location if the
+// SKU has no stock there yet. Setting both to zero turns the location back into reserve storage.
+func (i *InventoryItem) SetPickFaceLimits(locationID, zone string, minQuantity, maxQuantity int) error {
+	if minQuantity < 0 || maxQuantity < 0 || (maxQuantity > 0 && minQuantity >= maxQuantity) {
+		return ErrInvalidPickFaceLimits
+	}
+	if maxQuantity == 0 && minQuantity != 0 {
+		return ErrInvalidPickFaceLimits
+	}
+
+	idx := i.locationIndex(locationID, zone)
+	i.Locations[idx].MinQuantity = minQuantity
+	i.Locations[idx].MaxQuantity = maxQuantity
+	i.UpdatedAt = time.Now()
+	return nil
+}
+
+// ReplenishmentNeeds returns the pick faces whose shelf stock has dropped below their minimum
+func (i *InventoryItem) ReplenishmentNeeds() []ReplenishmentNeed {
+	needs := make([]ReplenishmentNeed, 0)
+	for _, loc := range i.Locations {
+		if !loc.IsPickFace() || loc.ShelfQuantity() >= loc.MinQuantity {
+			continue
+		}
+		needs = append(needs, ReplenishmentNeed{
+			LocationID: loc.LocationID,
+			Zone:       loc.Zone,
+			Quantity:   loc.MaxQuantity - loc.ShelfQuantity(),
+		})
+	}
+	return needs
+}
+
+// PickFaces returns the item's forward pick locations
+func (i *InventoryItem) PickFaces() []StockLocation {
+	faces := make([]StockLocation, 0)
+	for _, loc := range i.Locations {
+		if loc.IsPickFace() {
+			faces = append(faces, loc)
+		}
+	}
+	return faces
+}
+
+// ReplenishmentSource is reserve stock planned to be moved to a pick face
+type ReplenishmentSource struct {
+	LocationID string
+	Quantity   int
+}
+
+// ReplenishmentSources plans where to take quantity from to replenish a pick face: reserve
+// locations whose earliest lot expires first, then those holding the most available stock.
+// The plan covers as much of the quantity as reserve storage holds.
+func (i *InventoryItem) ReplenishmentSources(quantity int) ([]ReplenishmentSource, error) {
+	type candidate struct {
+		locationID string
+		available  int
+		expiry     *time.Time
+	}
+
+	candidates := make([]candidate, 0)
+	for idx := range i.Locations {
+		loc := &i.Locations[idx]
+		if loc.IsPickFace() {
+			continue
+		}
+		available, expiry := loc.eligibleAvailable(AllocationPolicy{})
+		if available <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{loc.LocationID, available, expiry})
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoReplenishmentSource
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		ca, cb := candidates[a], candidates[b]
+		switch {
+		case ca.expiry != nil && cb.expiry == nil:
+			return true
+		case ca.expiry == nil && cb.expiry != nil:
+			return false
+		case ca.expiry != nil && !ca.expiry.Equal(*cb.expiry):
+			return ca.expiry.Before(*cb.expiry)
+		default:
+			return ca.available > cb.available
+		}
+	})
+
+	sources := make([]ReplenishmentSource, 0)
+	remaining := quantity
+	for _, c := range candidates {
+		if remaining <= 0 {
+			break
+		}
+		take := c.available
+		if take > remaining {
+			take = remaining
+		}
+		sources = append(sources, ReplenishmentSource{LocationID: c.locationID, Quantity: take})
+		remaining -= take
+	}
+	return sources, nil
+}
+
+// ReservedOffPickFace returns, by location, the stock reserved for the given orders at the
+// item's reserve locations. Pickers work the pick faces, so this is demand that has to be
+// brought forward before picking starts.
+func (i *InventoryItem) ReservedOffPickFace(orderIDs []string) map[string]int {
+	orders := make(map[string]bool, len(orderIDs))
+	for _, orderID := range orderIDs {
+		orders[orderID] = true
+	}
+
+	reserved := make(map[string]int)
+	for _, res := range i.Reservations {
+		if res.Status != "active" || !orders[res.OrderID] {
+			continue
+		}
+		loc := i.GetLocationStock(res.LocationID)
+		if loc == nil || loc.IsPickFace() {
+			continue
+		}
+		reserved[res.LocationID] += res.Quantity
+	}
+	return reserved
+}
+
+// Replenished reports whether stock was already moved for a replenishment task
+func (i *InventoryItem) Replenished(taskID string) bool {
+	return i.hasTransaction(ReplenishmentReason, taskID)
+}
+
+// Replenish moves stock from a reserve location to a pick face for a replenishment task.
+// Reservations of orderIDs at the source move to the pick face along with their stock.
+// Replenishing for a task whose stock was already moved changes nothing.
+func (i *InventoryItem) Replenish(taskID, fromLocationID, toLocationID string, quantity int, orderIDs []string, movedBy string) error {
+	if i.Replenished(taskID) {
+		return nil
+	}
+	return i.moveStock(fromLocationID, toLocationID, "", quantity, orderIDs, ReplenishmentReason, taskID, movedBy)
+}
+
+// moveStock moves stock between two locations of the item. Active reservations of orderIDs
+// at the source move first, whole reservations only, keeping their lots; the rest of the
+// quantity is taken from available stock, lots earliest expiry first. Staged and blocked
+// stock stays where it is. The destination is created in toZone when the item has no stock
+// there yet.
+func (i *InventoryItem) moveStock(fromLocationID, toLocationID, toZone string, quantity int, orderIDs []string, reason, referenceID, movedBy string) error {
+	return i.transfer(StockTransfer{
+		FromLocationID: fromLocationID,
+		ToLocationID:   toLocationID,
+		ToZone:         toZone,
+		Quantity:       quantity,
+		OrderIDs:       orderIDs,
+		Reason:         reason,
+		ReferenceID:    referenceID,
+		MovedBy:        movedBy,
+	})
+}
+
+// destinationLot returns the location's lot matching a lot being moved in, adding an empty one if missing
+func (l *StockLocation) destinationLot(source StockLot) *StockLot {
+	if lot := l.GetLot(source.LotNumber); lot != nil {
+		return lot
+	}
+	l.Lots = append(l.Lots, StockLot{
+		LotNumber:       source.LotNumber,
+		ManufactureDate: source.ManufactureDate,
+		ExpiryDate:      source.ExpiryDate,
+		Status:          LotStatusActive,
+		ReceivedAt:      source.ReceivedAt,
+	})
+	return &l.Lots[len(l.Lots)-1]
+}
+
+// locationIndex returns the index of a location, adding an empty one in zone if missing
+func (i *InventoryItem) locationIndex(locationID, zone string) int {
+	for idx := range i.Locations {
+		if i.Locations[idx].LocationID == locationID {
+			return idx
+		}
+	}
+	i.Locations = append(i.Locations, StockLocation{
+		LocationID: locationID,
+		Zone:       zone,
+	})
+	return len(i.Locations) - 1
+}
+
+// ReplenishmentTask moves stock from reserve storage to a forward pick location
+type ReplenishmentTask struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	TaskID string             `bson:"taskId"`
+
+	TenantID    string `bson:"tenantId"`
+	FacilityID  string `bson:"facilityId"`
+	WarehouseID string `bson:"warehouseId"`
+	SellerID    string `bson:"sellerId,omitempty"`
+
+	SKU            string                  `bson:"sku"`
+	FromLocationID string                  `bson:"fromLocationId"`
+	ToLocationID   string                  `bson:"toLocationId"`
+	Zone           string                  `bson:"zone"`
+	Quantity       int                     `bson:"quantity"`
+	MovedQuantity  int                     `bson:"movedQuantity"`
+	Trigger        ReplenishmentTrigger    `bson:"trigger"`
+	WaveID         string                  `bson:"waveId,omitempty"`
+	OrderIDs       []string                `bson:"orderIds,omitempty"` // Wave orders whose reservations move with the stock
+	Priority       int                     `bson:"priority"`           // 1 = highest
+	Status         ReplenishmentTaskStatus `bson:"status"`
+
+	LaborQueuedAt *time.Time `bson:"laborQueuedAt,omitempty"` // When the task was handed to labor-service
+	CompletedBy   string     `bson:"completedBy,omitempty"`
+	CancelReason  string     `bson:"cancelReason,omitempty"`
+
+	CreatedAt   time.Time  `bson:"createdAt"`
+	UpdatedAt   time.Time  `bson:"updatedAt"`
+	CompletedAt *time.Time `bson:"completedAt,omitempty"`
+	Version     int        `bson:"version"` // Incremented on every save for optimistic concurrency
+
+	DomainEvents []DomainEvent `bson:"-"`
+}
+
+// NewReplenishmentTask creates a task to move quantity of an item from a reserve location to a pick face
+func NewReplenishmentTask(item *InventoryItem, fromLocationID, toLocationID string, quantity int, trigger ReplenishmentTrigger, waveID string, orderIDs []string, priority int) (*ReplenishmentTask, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+	if fromLocationID == toLocationID {
+		return nil, ErrSameLocation
+	}
+	if item.GetLocationStock(fromLocationID) == nil {
+		return nil, ErrLocationNotFound
+	}
+	to := item.GetLocationStock(toLocationID)
+	if to == nil {
+		return nil, ErrLocationNotFound
+	}
+
+	now := time.Now()
+	task := &ReplenishmentTask{
+		TaskID:         "RPL-" + uuid.New().String()[:8],
+		TenantID:       item.TenantID,
+		FacilityID:     item.FacilityID,
+		WarehouseID:    item.WarehouseID,
+		SellerID:       item.SellerID,
+		SKU:            item.SKU,
+		FromLocationID: fromLocationID,
+		ToLocationID:   toLocationID,
+		Zone:           to.Zone,
+		Quantity:       quantity,
+		Trigger:        trigger,
+		WaveID:         waveID,
+		OrderIDs:       orderIDs,
+		Priority:       priority,
+		Status:         ReplenishmentStatusPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		DomainEvents:   make([]DomainEvent, 0),
+	}
+
+	task.addDomainEvent(&ReplenishmentTaskCreatedEvent{
+		TaskID:         task.TaskID,
+		SKU:            task.SKU,
+		FromLocationID: task.FromLocationID,
+		ToLocationID:   task.ToLocationID,
+		Quantity:       task.Quantity,
+		Trigger:        string(task.Trigger),
+		WaveID:         task.WaveID,
+		Priority:       task.Priority,
+		CreatedAt:      now,
+	})
+	return task, nil
+}
+
+// IsOpen reports whether the task still has to be worked
+func (t *ReplenishmentTask) IsOpen() bool {
+	return t.Status == ReplenishmentStatusPending
+}
+
+// MarkQueued records that the task was handed to labor-service
+func (t *ReplenishmentTask) MarkQueued(at time.Time) {
+	t.LaborQueuedAt = &at
+	t.UpdatedAt = at
+}
+
+// Complete records the quantity actually moved to the pick face
+func (t *ReplenishmentTask) Complete(movedQuantity int, completedBy string) error {
+	if !t.IsOpen() {
+		return ErrReplenishmentTaskClosed
+	}
+	if movedQuantity < 0 || movedQuantity > t.Quantity {
+		return ErrReplenishmentQuantityRange
+	}
+
+	now := time.Now()
+	t.Status = ReplenishmentStatusCompleted
+	t.MovedQuantity = movedQuantity
+	t.CompletedBy = completedBy
+	t.UpdatedAt = now
+	t.CompletedAt = &now
+
+	t.addDomainEvent(&ReplenishmentTaskCompletedEvent{
+		TaskID:        t.TaskID,
+		SKU:           t.SKU,
+		ToLocationID:  t.ToLocationID,
+		MovedQuantity: movedQuantity,
+		WaveID:        t.WaveID,
+		CompletedBy:   completedBy,
+		CompletedAt:   now,
+	})
+	return nil
+}
+
+// Cancel closes the task without moving stock
+func (t *ReplenishmentTask) Cancel(reason string) error {
+	if !t.IsOpen() {
+		return ErrReplenishmentTaskClosed
+	}
+
+	now := time.Now()
+	t.Status = ReplenishmentStatusCancelled
+	t.CancelReason = reason
+	t.UpdatedAt = now
+	t.CompletedAt = &now
+	return nil
+}
+
+func (t *ReplenishmentTask) addDomainEvent(event DomainEvent) {
+	t.DomainEvents = append(t.DomainEvents, event)
+}
+
+// PullEvents returns and clears the task's domain events
+func (t *ReplenishmentTask) PullEvents() []DomainEvent {
+	events := t.DomainEvents
+	t.DomainEvents = make([]DomainEvent, 0)
+	return events
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPickFaceItem returns an item with stock in reserve location RSV-1 and pick face PF-1 (min 5, max 20)
+func newPickFaceItem(t *testing.T, reserve, face int) *InventoryItem {
+	item := NewInventoryItem("SKU-001", "Test Product", 10, 50)
+	if reserve > 0 {
+		require.NoError(t, item.ReceiveStock("RSV-1", "ZONE-R", reserve, "PO-001", "user1"))
+	}
+	if face > 0 {
+		require.NoError(t, item.ReceiveStock("PF-1", "ZONE-A", face, "PO-001", "user1"))
+	}
+	require.NoError(t, item.SetPickFaceLimits("PF-1", "ZONE-A", 5, 20))
+	item.ClearDomainEvents()
+	return item
+}
+
+// TestInventoryItem_SetPickFaceLimits tests min/max validation and clearing
+func TestInventoryItem_SetPickFaceLimits(t *testing.T) {
+	item := newPickFaceItem(t, 50, 0)
+
+	face := item.GetLocationStock("PF-1")
+	require.NotNil(t, face, "the pick face is created without stock")
+	assert.True(t, face.IsPickFace())
+	assert.Equal(t, "ZONE-A", face.Zone)
+	assert.False(t, item.GetLocationStock("RSV-1").IsPickFace())
+
+	assert.ErrorIs(t, item.SetPickFaceLimits("PF-1", "ZONE-A", 20, 20), ErrInvalidPickFaceLimits)
+	assert.ErrorIs(t, item.SetPickFaceLimits("PF-1", "ZONE-A", -1, 20), ErrInvalidPickFaceLimits)
+	assert.ErrorIs(t, item.SetPickFaceLimits("PF-1", "ZONE-A", 5, 0), ErrInvalidPickFaceLimits)
+
+	require.NoError(t, item.SetPickFaceLimits("PF-1", "ZONE-A", 0, 0))
+	assert.False(t, item.GetLocationStock("PF-1").IsPickFace())
+}
+
+// TestInventoryItem_ReplenishmentNeeds tests that only pick faces below their minimum need stock
+func TestInventoryItem_ReplenishmentNeeds(t *testing.T) {
+	item := newPickFaceItem(t, 50, 5)
+	assert.Empty(t, item.ReplenishmentNeeds(), "at the minimum is not below it")
+
+	require.NoError(t, item.Reserve("ORD-1", "PF-1", 2))
+	require.NoError(t, item.Pick("ORD-1", "PF-1", 2, "picker1"))
+
+	needs := item.ReplenishmentNeeds()
+	require.Len(t, needs, 1)
+	assert.Equal(t, "PF-1", needs[0].LocationID)
+	assert.Equal(t, 17, needs[0].Quantity, "fills back to the maximum")
+}
+
+// TestInventoryItem_ReplenishmentSources tests FEFO source selection across reserve locations
+func TestInventoryItem_ReplenishmentSources(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 10, 50)
+	require.NoError(t, item.ReceiveLotStock("RSV-1", "ZONE-R", 10, LotInfo{LotNumber: "LOT-LATE", ExpiryDate: daysFromNow(90)}, "PO-1", "user1"))
+	require.NoError(t, item.ReceiveLotStock("RSV-2", "ZONE-R", 4, LotInfo{LotNumber: "LOT-EARLY", ExpiryDate: daysFromNow(10)}, "PO-2", "user1"))
+	require.NoError(t, item.ReceiveStock("PF-1", "ZONE-A", 50, "PO-3", "user1"))
+	require.NoError(t, item.SetPickFaceLimits("PF-1", "ZONE-A", 5, 60))
+
+	sources, err := item.ReplenishmentSources(8)
+	require.NoError(t, err)
+	assert.Equal(t, []ReplenishmentSource{
+		{LocationID: "RSV-2", Quantity: 4},
+		{LocationID: "RSV-1", Quantity: 4},
+	}, sources, "earliest expiry first, pick faces are never a source")
+
+	empty := newPickFaceItem(t, 0, 0)
+	_, err = empty.ReplenishmentSources(5)
+	assert.ErrorIs(t, err, ErrNoReplenishmentSource)
+}
+
+// TestInventoryItem_Replenish tests moving available stock and lots to the pick face
+func TestInventoryItem_Replenish(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 10, 50)
+	require.NoError(t, item.ReceiveLotStock("RSV-1", "ZONE-R", 30, LotInfo{LotNumber: "LOT-1", ExpiryDate: daysFromNow(30)}, "PO-1", "user1"))
+	require.NoError(t, item.SetPickFaceLimits("PF-1", "ZONE-A", 5, 20))
+	require.NoError(t, item.Reserve("ORD-OTHER", "RSV-1", 25))
+	item.ClearDomainEvents()
+
+	assert.ErrorIs(t, item.Replenish("RPL-1", "RSV-1", "PF-1", 10, nil, "worker1"), ErrInsufficientStock,
+		"stock reserved for other orders stays put")
+
+	require.NoError(t, item.Replenish("RPL-1", "RSV-1", "PF-1", 5, nil, "worker1"))
+
+	from, to := item.GetLocationStock("RSV-1"), item.GetLocationStock("PF-1")
+	assert.Equal(t, 25, from.Quantity)
+	assert.Equal(t, 0, from.Available)
+	assert.Equal(t, 5, to.Quantity)
+	assert.Equal(t, 5, to.Available)
+	require.NotNil(t, to.GetLot("LOT-1"))
+	assert.Equal(t, 5, to.GetLot("LOT-1").Quantity)
+	assert.Equal(t, 30, item.TotalQuantity, "a move does not change totals")
+
+	events := item.GetDomainEvents()
+	require.Len(t, events, 1)
+	moved, ok := events[0].(*StockMovedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "RPL-1", moved.ReferenceID)
+	assert.Equal(t, ReplenishmentReason, moved.Reason)
+
+	// Completing the task again, e.g. after its save failed, moves nothing
+	assert.True(t, item.Replenished("RPL-1"))
+	item.ClearDomainEvents()
+	require.NoError(t, item.Replenish("RPL-1", "RSV-1", "PF-1", 5, nil, "worker1"))
+	assert.Equal(t, 5, item.GetLocationStock("PF-1").Quantity)
+	assert.Empty(t, item.GetDomainEvents())
+
+	assert.ErrorIs(t, item.Replenish("RPL-2", "PF-1", "PF-1", 1, nil, "worker1"), ErrSameLocation)
+}
+
+// TestInventoryItem_ReplenishMovesWaveReservations tests that a wave's reservations follow its stock
+func TestInventoryItem_ReplenishMovesWaveReservations(t *testing.T) {
+	item := newPickFaceItem(t, 30, 0)
+	require.NoError(t, item.Reserve("ORD-1", "RSV-1", 8))
+	require.NoError(t, item.Reserve("ORD-2", "RSV-1", 4))
+
+	assert.Equal(t, map[string]int{"RSV-1": 8}, item.ReservedOffPickFace([]string{"ORD-1"}))
+
+	require.NoError(t, item.Replenish("RPL-1", "RSV-1", "PF-1", 15, []string{"ORD-1"}, "worker1"))
+
+	from, to := item.GetLocationStock("RSV-1"), item.GetLocationStock("PF-1")
+	assert.Equal(t, 15, from.Quantity)
+	assert.Equal(t, 4, from.Reserved, "other orders' reservations stay")
+	assert.Equal(t, 11, from.Available)
+	assert.Equal(t, 15, to.Quantity)
+	assert.Equal(t, 8, to.Reserved)
+	assert.Equal(t, 7, to.Available)
+	assert.Empty(t, item.ReservedOffPickFace([]string{"ORD-1"}))
+
+	// The order is now picked from the pick face
+	require.NoError(t, item.Pick("ORD-1", "PF-1", 8, "picker1"))
+}
+
+// TestReplenishmentTask_Lifecycle tests task creation, completion and cancellation
+func TestReplenishmentTask_Lifecycle(t *testing.T) {
+	item := newPickFaceItem(t, 50, 0)
+
+	_, err := NewReplenishmentTask(item, "RSV-1", "PF-1", 0, ReplenishmentTriggerMinMax, "", nil, 5)
+	assert.ErrorIs(t, err, ErrInvalidQuantity)
+	_, err = NewReplenishmentTask(item, "RSV-9", "PF-1", 5, ReplenishmentTriggerMinMax, "", nil, 5)
+	assert.ErrorIs(t, err, ErrLocationNotFound)
+
+	task, err := NewReplenishmentTask(item, "RSV-1", "PF-1", 20, ReplenishmentTriggerWaveDemand, "WAVE-1", []string{"ORD-1"}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "ZONE-A", task.Zone, "the task is worked in the pick face's zone")
+	assert.True(t, task.IsOpen())
+	require.Len(t, task.PullEvents(), 1)
+
+	assert.ErrorIs(t, task.Complete(21, "worker1"), ErrReplenishmentQuantityRange)
+	require.NoError(t, task.Complete(18, "worker1"))
+	assert.Equal(t, ReplenishmentStatusCompleted, task.Status)
+	assert.Equal(t, 18, task.MovedQuantity)
+	require.NotNil(t, task.CompletedAt)
+
+	events := task.PullEvents()
+	require.Len(t, events, 1)
+	completed, ok := events[0].(*ReplenishmentTaskCompletedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "WAVE-1", completed.WaveID)
+
+	assert.ErrorIs(t, task.Cancel("no longer needed"), ErrReplenishmentTaskClosed)
+}
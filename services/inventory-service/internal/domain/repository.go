@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // InventoryRepository defines the interface for inventory persistence
 type InventoryRepository interface {
@@ -11,9 +14,116 @@ type InventoryRepository interface {
 	FindByOrderID(ctx context.Context, orderID string) ([]*InventoryItem, error)
 	FindLowStock(ctx context.Context) ([]*InventoryItem, error)
 	FindAll(ctx context.Context, limit, offset int) ([]*InventoryItem, error)
+	// FindWithLotsExpiringBefore returns items holding active lots that still need an expiry action:
+	// lots expiring before the cutoff that have not been warned yet, or that have expired as of now.
+	// Results are ordered by lot expiry so the soonest-expiring stock is handled first.
+	FindWithLotsExpiringBefore(ctx context.Context, now, cutoff time.Time, limit int) ([]*InventoryItem, error)
+	// FindWithExpiredReservations returns items holding active reservations that expired before asOf,
+	// or expired reservations whose units are still to be released. An empty tenantID searches across all tenants.
+	FindWithExpiredReservations(ctx context.Context, asOf time.Time, tenantID string, limit int) ([]*InventoryItem, error)
+	// FindDueForCycleCount returns items holding stock whose last cycle count is before the cutoff
+	// for their velocity class, or that were never counted. Unclassified items use the C cutoff.
+	FindDueForCycleCount(ctx context.Context, cutoffs map[VelocityClass]time.Time, limit int) ([]*InventoryItem, error)
+	// FindBelowPickFaceMinimum returns items with a pick face whose shelf stock is below its minimum
+	FindBelowPickFaceMinimum(ctx context.Context, limit int) ([]*InventoryItem, error)
 	Delete(ctx context.Context, sku string) error
 }
 
+// CycleCountRepository defines the interface for cycle count task persistence
+type CycleCountRepository interface {
+	Save(ctx context.Context, task *CycleCountTask) error
+	// FindByID returns nil when the task does not exist
+	FindByID(ctx context.Context, taskID string) (*CycleCountTask, error)
+	// FindOpenBySKU returns the SKU's tasks that are not yet posted or rejected
+	FindOpenBySKU(ctx context.Context, sku string) ([]*CycleCountTask, error)
+	// FindByStatus returns tasks in a status, oldest due first. An empty status returns all open tasks.
+	FindByStatus(ctx context.Context, status CycleCountStatus, limit int) ([]*CycleCountTask, error)
+}
+
+// ReplenishmentTaskRepository defines the interface for replenishment task persistence
+type ReplenishmentTaskRepository interface {
+	Save(ctx context.Context, task *ReplenishmentTask) error
+	// FindByID returns nil when the task does not exist
+	FindByID(ctx context.Context, taskID string) (*ReplenishmentTask, error)
+	// FindOpenBySKU returns the SKU's pending tasks
+	FindOpenBySKU(ctx context.Context, sku string) ([]*ReplenishmentTask, error)
+	// FindByWave returns all tasks created for a wave
+	FindByWave(ctx context.Context, waveID string) ([]*ReplenishmentTask, error)
+	// FindByStatus returns tasks in a status, highest priority and oldest first
+	FindByStatus(ctx context.Context, status ReplenishmentTaskStatus, limit int) ([]*ReplenishmentTask, error)
+	// FindNotQueued returns pending tasks not yet handed to labor-service, highest priority first
+	FindNotQueued(ctx context.Context, limit int) ([]*ReplenishmentTask, error)
+}
+
+// SlotLocationRepository defines the interface for storage slot persistence
+type SlotLocationRepository interface {
+	Save(ctx context.Context, slot *SlotLocation) error
+	// FindByZone returns the slots of a zone, or all slots when zone is empty
+	FindByZone(ctx context.Context, zone string) ([]*SlotLocation, error)
+}
+
+// SlottingRecommendationRepository defines the interface for slotting recommendation persistence
+type SlottingRecommendationRepository interface {
+	Save(ctx context.Context, rec *SlottingRecommendation) error
+	// FindByID returns nil when the recommendation does not exist
+	FindByID(ctx context.Context, recommendationID string) (*SlottingRecommendation, error)
+	// FindOpen returns recommendations awaiting approval or an approved move
+	FindOpen(ctx context.Context) ([]*SlottingRecommendation, error)
+	// FindByStatus returns recommendations in a status, largest travel savings first
+	FindByStatus(ctx context.Context, status SlottingRecommendationStatus, limit int) ([]*SlottingRecommendation, error)
+}
+
+// InventoryHoldRepository defines the interface for inventory hold persistence
+type InventoryHoldRepository interface {
+	Save(ctx context.Context, hold *InventoryHold) error
+	// FindByID returns nil when the hold does not exist
+	FindByID(ctx context.Context, holdID string) (*InventoryHold, error)
+	// FindByStatus returns holds in a status, newest first. An empty status returns all holds.
+	FindByStatus(ctx context.Context, status HoldStatus, limit int) ([]*InventoryHold, error)
+}
+
+// TravelDistanceEstimator measures travel with routing-service's distance model
+type TravelDistanceEstimator interface {
+	// DistancesFromPickStart returns, by location ID, the meters from the pick start of each
+	// slot's zone to the slot
+	DistancesFromPickStart(ctx context.Context, slots []SlotLocation) (map[string]float64, error)
+}
+
+// LaborTask describes a task handed to labor-service for dispatch
+type LaborTask struct {
+	TenantID    string
+	FacilityID  string
+	WarehouseID string
+	TaskID      string
+	TaskType    string
+	Priority    int // 1 = highest
+	Zone        string
+	WaveID      string
+}
+
+// LaborTaskQueue queues work for dispatch to workers in labor-service
+type LaborTaskQueue interface {
+	EnqueueTask(ctx context.Context, task LaborTask) error
+	CompleteTask(ctx context.Context, task LaborTask) error
+	CancelTask(ctx context.Context, task LaborTask) error
+}
+
+// UnitReleaseRequest identifies the units to release for an order
+type UnitReleaseRequest struct {
+	TenantID    string
+	FacilityID  string
+	WarehouseID string
+	SellerID    string
+	OrderID     string
+	UnitIDs     []string
+	Reason      string
+}
+
+// UnitReleaser releases unit-level reservations held in unit-service
+type UnitReleaser interface {
+	ReleaseUnits(ctx context.Context, req UnitReleaseRequest) error
+}
+
 // EventPublisher defines the interface for publishing domain events
 type EventPublisher interface {
 	Publish(ctx context.Context, event DomainEvent) error
@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// IsExpired returns true if the reservation is still active past its expiry time
+func (r Reservation) IsExpired(asOf time.Time) bool {
+	return r.Status == "active" && asOf.After(r.ExpiresAt)
+}
+
+// ExpiredReservations returns active reservations that have passed their expiry time
+func (i *InventoryItem) ExpiredReservations(asOf time.Time) []Reservation {
+	expired := make([]Reservation, 0)
+	for _, res := range i.Reservations {
+		if res.IsExpired(asOf) {
+			expired = append(expired, res)
+		}
+	}
+	return expired
+}
+
+// ExpireReservation releases a stale reservation back to available stock
+// and records that it expired rather than being cancelled by the order
+func (i *InventoryItem) ExpireReservation(reservationID string, asOf time.Time) error {
+	for idx := range i.Reservations {
+		res := i.Reservations[idx]
+		if res.ReservationID != reservationID || res.Status != "active" {
+			continue
+		}
+		if !res.IsExpired(asOf) {
+			return ErrReservationNotExpired
+		}
+
+		i.releaseReservationAt(idx, string(ReservationStatusExpired))
+		i.Reservations[idx].UnitReleasePending = len(res.UnitIDs) > 0
+
+		i.AddDomainEvent(&ReservationExpiredEvent{
+			SKU:           i.SKU,
+			TenantID:      i.TenantID,
+			ReservationID: res.ReservationID,
+			OrderID:       res.OrderID,
+			LocationID:    res.LocationID,
+			Quantity:      res.Quantity,
+			UnitIDs:       res.UnitIDs,
+			ExpiresAt:     res.ExpiresAt,
+			ReleasedAt:    asOf,
+		})
+		return nil
+	}
+	return ErrReservationNotFound
+}
+
+// PendingUnitReleases returns expired reservations whose units have not yet been released in unit-service
+func (i *InventoryItem) PendingUnitReleases() []Reservation {
+	pending := make([]Reservation, 0)
+	for _, res := range i.Reservations {
+		if res.UnitReleasePending {
+			pending = append(pending, res)
+		}
+	}
+	return pending
+}
+
+// MarkUnitsReleased records that the units of an expired reservation were released in unit-service
+func (i *InventoryItem) MarkUnitsReleased(reservationID string) error {
+	for idx := range i.Reservations {
+		if i.Reservations[idx].ReservationID == reservationID && i.Reservations[idx].UnitReleasePending {
+			i.Reservations[idx].UnitReleasePending = false
+			i.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrReservationNotFound
+}
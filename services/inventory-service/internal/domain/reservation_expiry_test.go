@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExpireReservation tests releasing stale reservations
+func TestExpireReservation(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 10, 50)
+	require.NoError(t, item.ReceiveStock("LOC-A1", "ZONE-A", 100, "PO-001", "user1"))
+	require.NoError(t, item.ReserveWithUnits("ORD-001", "LOC-A1", 30, []string{"UNIT-1"}))
+	item.ClearDomainEvents()
+
+	reservationID := item.Reservations[0].ReservationID
+
+	// Not yet expired
+	assert.Empty(t, item.ExpiredReservations(time.Now()))
+	assert.ErrorIs(t, item.ExpireReservation(reservationID, time.Now()), ErrReservationNotExpired)
+
+	// Past expiry
+	later := time.Now().Add(25 * time.Hour)
+	require.Len(t, item.ExpiredReservations(later), 1)
+	require.NoError(t, item.ExpireReservation(reservationID, later))
+
+	assert.Equal(t, "expired", item.Reservations[0].Status)
+	assert.Equal(t, 0, item.ReservedQuantity)
+	assert.Equal(t, 100, item.AvailableQuantity)
+	assert.Empty(t, item.ExpiredReservations(later))
+
+	events := item.GetDomainEvents()
+	require.Len(t, events, 1)
+	expired, ok := events[0].(*ReservationExpiredEvent)
+	require.True(t, ok)
+	assert.Equal(t, "wms.inventory.reservation-expired", expired.EventType())
+	assert.Equal(t, "ORD-001", expired.OrderID)
+	assert.Equal(t, 30, expired.Quantity)
+	assert.Equal(t, []string{"UNIT-1"}, expired.UnitIDs)
+
+	// Units stay pending until unit-service confirms the release
+	require.Len(t, item.PendingUnitReleases(), 1)
+	require.NoError(t, item.MarkUnitsReleased(reservationID))
+	assert.Empty(t, item.PendingUnitReleases())
+	assert.ErrorIs(t, item.MarkUnitsReleased(reservationID), ErrReservationNotFound)
+
+	// Already released
+	assert.ErrorIs(t, item.ExpireReservation(reservationID, later), ErrReservationNotFound)
+}
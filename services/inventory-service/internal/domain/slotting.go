@@ -0,0 +1,718 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Slotting errors
+var (
+	ErrInvalidSlottingProfile            = errors.New("unit cube and unit weight cannot be negative")
+	ErrInvalidSlotLocation               = errors.New("slot location requires an ID, a zone and a level of at least 1, and its capacities cannot be negative")
+	ErrSlottingRecommendationNotPending  = errors.New("slotting recommendation is not awaiting approval")
+	ErrSlottingRecommendationNotApproved = errors.New("slotting recommendation is not approved or its move is already closed")
+	ErrNothingToReslot                   = errors.New("no movable stock left in the current slot")
+)
+
+// ReslotReason is the reason recorded on stock moved by an approved slotting recommendation
+const ReslotReason = "reslot"
+
+// Slot score weights; they add up to 1
+const (
+	slotWeightVelocity  = 0.35
+	slotWeightErgonomic = 0.25
+	slotWeightCubeFit   = 0.15
+	slotWeightWeight    = 0.15
+	slotWeightAffinity  = 0.10
+)
+
+// SlottingProfile is the unit cube and weight of a product, used to check which slots it fits
+type SlottingProfile struct {
+	UnitCube   float64   `bson:"unitCube" json:"unitCube"`     // cm³ per unit, 0 = unknown
+	UnitWeight float64   `bson:"unitWeight" json:"unitWeight"` // kg per unit, 0 = unknown
+	UpdatedAt  time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// SetSlottingProfile replaces the item's unit cube and weight
+func (i *InventoryItem) SetSlottingProfile(profile SlottingProfile) error {
+	if profile.UnitCube < 0 || profile.UnitWeight < 0 {
+		return ErrInvalidSlottingProfile
+	}
+
+	now := time.Now()
+	profile.UpdatedAt = now
+	i.Slotting = &profile
+	i.UpdatedAt = now
+	return nil
+}
+
+// SlotLocation is a storage slot with its position and size limits
+type SlotLocation struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	LocationID  string             `bson:"locationId"`
+	TenantID    string             `bson:"tenantId"`
+	FacilityID  string             `bson:"facilityId"`
+	WarehouseID string             `bson:"warehouseId"`
+
+	Zone         string    `bson:"zone"`
+	Aisle        string    `bson:"aisle"`
+	Rack         int       `bson:"rack"`
+	Level        int       `bson:"level"`        // 1 = floor
+	CubeCapacity float64   `bson:"cubeCapacity"` // cm³, 0 = unknown
+	MaxWeight    float64   `bson:"maxWeight"`    // kg, 0 = unknown
+	UpdatedAt    time.Time `bson:"updatedAt"`
+}
+
+// NewSlotLocation creates a storage slot
+func NewSlotLocation(locationID, zone, aisle string, rack, level int, cubeCapacity, maxWeight float64) (*SlotLocation, error) {
+	if locationID == "" || zone == "" || level < 1 || cubeCapacity < 0 || maxWeight < 0 {
+		return nil, ErrInvalidSlotLocation
+	}
+
+	return &SlotLocation{
+		LocationID:   locationID,
+		Zone:         zone,
+		Aisle:        aisle,
+		Rack:         rack,
+		Level:        level,
+		CubeCapacity: cubeCapacity,
+		MaxWeight:    maxWeight,
+		UpdatedAt:    time.Now(),
+	}, nil
+}
+
+// SlotOf describes a stock location as a slot. Its size limits are unknown.
+func SlotOf(loc StockLocation) SlotLocation {
+	return SlotLocation{
+		LocationID: loc.LocationID,
+		Zone:       loc.Zone,
+		Aisle:      loc.Aisle,
+		Rack:       loc.Rack,
+		Level:      loc.Level,
+	}
+}
+
+// PrimarySlot returns the location the item is picked from: its fullest pick face, or
+// without pick faces its fullest location. Nil when the item holds no stock.
+func (i *InventoryItem) PrimarySlot() *StockLocation {
+	var primary *StockLocation
+	for idx := range i.Locations {
+		loc := &i.Locations[idx]
+		if loc.Quantity <= 0 && !loc.IsPickFace() {
+			continue
+		}
+		switch {
+		case primary == nil:
+			primary = loc
+		case loc.IsPickFace() != primary.IsPickFace():
+			if loc.IsPickFace() {
+				primary = loc
+			}
+		case loc.Quantity > primary.Quantity:
+			primary = loc
+		}
+	}
+	if primary == nil {
+		return nil
+	}
+	slot := *primary
+	return &slot
+}
+
+// OrderHistory returns the IDs of the orders the item was reserved or picked for
+func (i *InventoryItem) OrderHistory() []string {
+	seen := make(map[string]bool)
+	orderIDs := make([]string, 0)
+	add := func(orderID string) {
+		if orderID != "" && !seen[orderID] {
+			seen[orderID] = true
+			orderIDs = append(orderIDs, orderID)
+		}
+	}
+
+	for _, res := range i.Reservations {
+		if res.Status != "cancelled" {
+			add(res.OrderID)
+		}
+	}
+	for _, txn := range i.Transactions {
+		if txn.Type == "pick" || txn.Type == "ship" {
+			add(txn.ReferenceID)
+		}
+	}
+	return orderIDs
+}
+
+// Reslotted returns the quantity already moved into the new slot for a slotting move task,
+// and whether the move was made
+func (i *InventoryItem) Reslotted(moveTaskID string) (int, bool) {
+	for _, txn := range i.Transactions {
+		if txn.Reason == ReslotReason && txn.ReferenceID == moveTaskID && txn.Quantity > 0 {
+			return txn.Quantity, true
+		}
+	}
+	return 0, false
+}
+
+// Reslot moves the item's stock out of a slot into a new one for an approved slotting
+// recommendation. Active reservations move with their stock; staged and blocked stock
+// stays behind. Pick face min/max settings move to the new slot. Returns the quantity moved.
+// Reslotting for a move task whose stock was already moved changes nothing and returns the
+// quantity moved then.
+func (i *InventoryItem) Reslot(referenceID, fromLocationID string, to SlotLocation, movedBy string) (int, error) {
+	if moved, ok := i.Reslotted(referenceID); ok && referenceID != "" {
+		return moved, nil
+	}
+
+	from := i.GetLocationStock(fromLocationID)
+	if from == nil {
+		return 0, ErrLocationNotFound
+	}
+
+	orderIDs := make([]string, 0)
+	reserved := 0
+	for _, res := range i.Reservations {
+		if res.Status == "active" && res.LocationID == fromLocationID {
+			orderIDs = append(orderIDs, res.OrderID)
+			reserved += res.Quantity
+		}
+	}
+	quantity := from.Available + reserved
+	if quantity <= 0 {
+		return 0, ErrNothingToReslot
+	}
+
+	if err := i.moveStock(fromLocationID, to.LocationID, to.Zone, quantity, orderIDs, ReslotReason, referenceID, movedBy); err != nil {
+		return 0, err
+	}
+
+	dest := &i.Locations[i.locationIndex(to.LocationID, to.Zone)]
+	dest.Aisle = to.Aisle
+	dest.Rack = to.Rack
+	dest.Level = to.Level
+
+	source := &i.Locations[i.locationIndex(fromLocationID, "")]
+	if source.IsPickFace() && !dest.IsPickFace() {
+		dest.MinQuantity, dest.MaxQuantity = source.MinQuantity, source.MaxQuantity
+		source.MinQuantity, source.MaxQuantity = 0, 0
+	}
+	return quantity, nil
+}
+
+// CoPickAffinity is how strongly two SKUs are picked for the same orders, 0-1, by SKU pair
+type CoPickAffinity map[string]map[string]float64
+
+// MineCoPickAffinity derives co-pick affinity from the orders each SKU was reserved or picked
+// for. SKUs sharing at least minSharedOrders orders get the number of shared orders over the
+// smaller of their order counts, so a SKU always ordered along with another scores 1.
+func MineCoPickAffinity(ordersBySKU map[string][]string, minSharedOrders int) CoPickAffinity {
+	if minSharedOrders < 1 {
+		minSharedOrders = 1
+	}
+
+	skusByOrder := make(map[string][]string)
+	orderCount := make(map[string]int, len(ordersBySKU))
+	for sku, orderIDs := range ordersBySKU {
+		orderCount[sku] = len(orderIDs)
+		for _, orderID := range orderIDs {
+			skusByOrder[orderID] = append(skusByOrder[orderID], sku)
+		}
+	}
+
+	shared := make(map[[2]string]int)
+	for _, skus := range skusByOrder {
+		for a := 0; a < len(skus); a++ {
+			for b := a + 1; b < len(skus); b++ {
+				pair := [2]string{skus[a], skus[b]}
+				if pair[1] < pair[0] {
+					pair[0], pair[1] = pair[1], pair[0]
+				}
+				shared[pair]++
+			}
+		}
+	}
+
+	affinity := make(CoPickAffinity)
+	for pair, count := range shared {
+		if count < minSharedOrders {
+			continue
+		}
+		smaller := orderCount[pair[0]]
+		if orderCount[pair[1]] < smaller {
+			smaller = orderCount[pair[1]]
+		}
+		score := float64(count) / float64(smaller)
+		affinity.set(pair[0], pair[1], score)
+		affinity.set(pair[1], pair[0], score)
+	}
+	return affinity
+}
+
+func (a CoPickAffinity) set(sku, partner string, score float64) {
+	if a[sku] == nil {
+		a[sku] = make(map[string]float64)
+	}
+	a[sku][partner] = score
+}
+
+// SlottingConfig tunes how slots are scored and when a move is worth recommending
+type SlottingConfig struct {
+	GoldenZoneMinLevel int     // Lowest level in easy reach (waist height)
+	GoldenZoneMaxLevel int     // Highest level in easy reach (shoulder height)
+	HeavyUnitWeight    float64 // kg per unit from which a SKU belongs low in the rack
+	MinScoreGain       float64 // Points a new slot must score above the current one
+	MaxMoves           int     // Cap on recommendations per run, 0 = no cap
+}
+
+// DefaultSlottingConfig returns the default slotting configuration
+func DefaultSlottingConfig() SlottingConfig {
+	return SlottingConfig{
+		GoldenZoneMinLevel: 2,
+		GoldenZoneMaxLevel: 3,
+		HeavyUnitWeight:    10,
+		MinScoreGain:       10,
+	}
+}
+
+// SlottedSKU is a SKU in its current slot, as seen by the slotting engine
+type SlottedSKU struct {
+	SKU           string
+	VelocityClass VelocityClass
+	PickFrequency int // picks per week
+	UnitCube      float64
+	UnitWeight    float64
+	Slot          SlotLocation
+	Quantity      int // units the slot has to hold
+}
+
+// SlotScore is how well a slot suits a SKU. Each factor is 0-1; the total is 0-100 and 0
+// when the SKU does not fit the slot's cube or weight limits.
+type SlotScore struct {
+	Velocity  float64 `bson:"velocity" json:"velocity"`
+	Ergonomic float64 `bson:"ergonomic" json:"ergonomic"`
+	CubeFit   float64 `bson:"cubeFit" json:"cubeFit"`
+	Weight    float64 `bson:"weight" json:"weight"`
+	Affinity  float64 `bson:"affinity" json:"affinity"`
+	Total     float64 `bson:"total" json:"total"`
+	Fits      bool    `bson:"fits" json:"fits"`
+}
+
+// SlotMove is a recommended move of a SKU from its current slot to a better one
+type SlotMove struct {
+	SKU           SlottedSKU
+	To            SlotLocation
+	CurrentScore  SlotScore
+	ProposedScore SlotScore
+	FromDistance  float64 // meters from the zone's pick start
+	ToDistance    float64
+	// TravelSavings is the expected walk saved per week: a round trip from the pick start
+	// per pick. Negative when a slow mover is moved further out to free a better slot.
+	TravelSavings float64
+}
+
+// SlottingEngine scores slots for SKUs and plans re-slotting moves
+type SlottingEngine struct {
+	config    SlottingConfig
+	distances map[string]float64 // by location ID, from the zone's pick start
+	nearest   map[string]float64 // by zone
+	farthest  map[string]float64 // by zone
+	affinity  CoPickAffinity
+	placed    map[string]SlotLocation // current slot by SKU, for co-pick affinity
+}
+
+// NewSlottingEngine creates a slotting engine. distances holds the travel distance from the
+// pick start of their zone to every slot scored, as measured by routing-service.
+func NewSlottingEngine(config SlottingConfig, slots []SlotLocation, distances map[string]float64, affinity CoPickAffinity, placed map[string]SlotLocation) *SlottingEngine {
+	e := &SlottingEngine{
+		config:    config,
+		distances: distances,
+		nearest:   make(map[string]float64),
+		farthest:  make(map[string]float64),
+		affinity:  affinity,
+		placed:    make(map[string]SlotLocation, len(placed)),
+	}
+	for sku, slot := range placed {
+		e.placed[sku] = slot
+	}
+
+	for _, slot := range slots {
+		d, ok := distances[slot.LocationID]
+		if !ok {
+			continue
+		}
+		if nearest, seen := e.nearest[slot.Zone]; !seen || d < nearest {
+			e.nearest[slot.Zone] = d
+		}
+		if farthest, seen := e.farthest[slot.Zone]; !seen || d > farthest {
+			e.farthest[slot.Zone] = d
+		}
+	}
+	return e
+}
+
+// Score rates a slot for a SKU on velocity against travel distance, ergonomic level, cube
+// fit, weight and closeness to the SKUs it is often picked with
+func (e *SlottingEngine) Score(sku SlottedSKU, slot SlotLocation) SlotScore {
+	score := SlotScore{
+		Velocity:  e.velocityScore(sku, slot),
+		Ergonomic: e.ergonomicScore(sku, slot),
+		CubeFit:   1,
+		Weight:    1,
+		Affinity:  e.affinityScore(sku, slot),
+		Fits:      true,
+	}
+
+	if sku.UnitCube > 0 && slot.CubeCapacity > 0 {
+		utilization := sku.UnitCube * float64(sku.Quantity) / slot.CubeCapacity
+		switch {
+		case utilization > 1:
+			score.CubeFit = 0
+			score.Fits = false
+		case utilization < 0.5:
+			// Mostly empty slot: space better used by a bigger SKU
+			score.CubeFit = 0.2 + 0.8*utilization/0.5
+		}
+	}
+
+	if sku.UnitWeight > 0 {
+		if slot.MaxWeight > 0 && sku.UnitWeight*float64(sku.Quantity) > slot.MaxWeight {
+			score.Weight = 0
+			score.Fits = false
+		} else if sku.UnitWeight >= e.config.HeavyUnitWeight {
+			score.Weight = heavyLevelScore(slot.Level)
+		}
+	}
+
+	if score.Fits {
+		total := slotWeightVelocity*score.Velocity +
+			slotWeightErgonomic*score.Ergonomic +
+			slotWeightCubeFit*score.CubeFit +
+			slotWeightWeight*score.Weight +
+			slotWeightAffinity*score.Affinity
+		score.Total = round1(100 * total)
+	}
+	return score
+}
+
+// velocityScore compares how close the slot is to the pick start, among the zone's slots,
+// with how close the SKU's velocity class wants to be: A nearest, C farthest
+func (e *SlottingEngine) velocityScore(sku SlottedSKU, slot SlotLocation) float64 {
+	proximity := 0.5
+	if d, ok := e.distances[slot.LocationID]; ok {
+		nearest, farthest := e.nearest[slot.Zone], e.farthest[slot.Zone]
+		if farthest > nearest {
+			proximity = 1 - (d-nearest)/(farthest-nearest)
+		} else {
+			proximity = 1
+		}
+	}
+
+	target := 0.0
+	switch sku.VelocityClass {
+	case VelocityA:
+		target = 1
+	case VelocityB:
+		target = 0.5
+	}
+	return 1 - math.Abs(proximity-target)
+}
+
+// ergonomicScore wants fast movers in the golden zone between waist and shoulder height and
+// slow movers out of it. An unknown level scores neutral.
+func (e *SlottingEngine) ergonomicScore(sku SlottedSKU, slot SlotLocation) float64 {
+	if slot.Level < 1 {
+		return 0.5
+	}
+
+	outside := 0
+	if slot.Level < e.config.GoldenZoneMinLevel {
+		outside = e.config.GoldenZoneMinLevel - slot.Level
+	} else if slot.Level > e.config.GoldenZoneMaxLevel {
+		outside = slot.Level - e.config.GoldenZoneMaxLevel
+	}
+
+	switch sku.VelocityClass {
+	case VelocityA:
+		return math.Max(0, 1-0.4*float64(outside))
+	case VelocityB:
+		return math.Max(0.2, 1-0.2*float64(outside))
+	default:
+		if outside == 0 {
+			return 0.5
+		}
+		return 1
+	}
+}
+
+// affinityScore wants a SKU in the same aisle as the SKUs it is often picked with, weighted
+// by affinity. SKUs without co-pick partners score neutral.
+func (e *SlottingEngine) affinityScore(sku SlottedSKU, slot SlotLocation) float64 {
+	total, weighted := 0.0, 0.0
+	for partner, affinity := range e.affinity[sku.SKU] {
+		partnerSlot, ok := e.placed[partner]
+		if !ok {
+			continue
+		}
+		closeness := 0.0
+		if partnerSlot.Zone == slot.Zone {
+			closeness = 0.4
+			if partnerSlot.Aisle == slot.Aisle {
+				closeness = 1
+			}
+		}
+		total += affinity
+		weighted += affinity * closeness
+	}
+	if total == 0 {
+		return 1
+	}
+	return weighted / total
+}
+
+// heavyLevelScore wants heavy SKUs on the floor level, acceptable one up and poor higher
+func heavyLevelScore(level int) float64 {
+	switch {
+	case level < 1:
+		return 0.5
+	case level == 1:
+		return 1
+	default:
+		return math.Max(0, 0.6-0.3*float64(level-2))
+	}
+}
+
+// Plan recommends moves into free slots of the same zone, fastest movers first so they get
+// the best slots. A move is recommended when the new slot scores at least MinScoreGain more
+// than the current one; the slot it frees is offered to the SKUs planned after it.
+func (e *SlottingEngine) Plan(skus []SlottedSKU, free []SlotLocation) []SlotMove {
+	ordered := make([]SlottedSKU, len(skus))
+	copy(ordered, skus)
+	sort.SliceStable(ordered, func(a, b int) bool {
+		if ordered[a].PickFrequency != ordered[b].PickFrequency {
+			return ordered[a].PickFrequency > ordered[b].PickFrequency
+		}
+		return ordered[a].SKU < ordered[b].SKU
+	})
+
+	open := make([]SlotLocation, len(free))
+	copy(open, free)
+
+	moves := make([]SlotMove, 0)
+	for _, sku := range ordered {
+		if e.config.MaxMoves > 0 && len(moves) >= e.config.MaxMoves {
+			break
+		}
+
+		current := e.Score(sku, sku.Slot)
+		best := -1
+		var bestScore SlotScore
+		for idx, slot := range open {
+			if slot.Zone != sku.Slot.Zone || slot.LocationID == sku.Slot.LocationID {
+				continue
+			}
+			score := e.Score(sku, slot)
+			if !score.Fits {
+				continue
+			}
+			if best == -1 || score.Total > bestScore.Total {
+				best, bestScore = idx, score
+			}
+		}
+		if best == -1 || bestScore.Total-current.Total < e.config.MinScoreGain {
+			continue
+		}
+
+		to := open[best]
+		fromDistance := e.distances[sku.Slot.LocationID]
+		toDistance := e.distances[to.LocationID]
+		moves = append(moves, SlotMove{
+			SKU:           sku,
+			To:            to,
+			CurrentScore:  current,
+			ProposedScore: bestScore,
+			FromDistance:  fromDistance,
+			ToDistance:    toDistance,
+			TravelSavings: round1(float64(sku.PickFrequency) * 2 * (fromDistance - toDistance)),
+		})
+
+		e.placed[sku.SKU] = to
+		open[best] = sku.Slot
+	}
+	return moves
+}
+
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// SlottingRecommendationStatus represents the lifecycle of a slotting recommendation
+type SlottingRecommendationStatus string
+
+const (
+	SlottingStatusPending   SlottingRecommendationStatus = "pending"   // Awaiting approval
+	SlottingStatusApproved  SlottingRecommendationStatus = "approved"  // Move task generated
+	SlottingStatusRejected  SlottingRecommendationStatus = "rejected"  // Declined before approval
+	SlottingStatusCompleted SlottingRecommendationStatus = "completed" // Stock moved to the new slot
+	SlottingStatusCancelled SlottingRecommendationStatus = "cancelled" // Move task withdrawn
+)
+
+// SlottingRecommendation proposes moving a SKU to a better slot. Approving it generates the
+// move task that relocates the stock.
+type SlottingRecommendation struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`
+	RecommendationID string             `bson:"recommendationId"`
+
+	TenantID    string `bson:"tenantId"`
+	FacilityID  string `bson:"facilityId"`
+	WarehouseID string `bson:"warehouseId"`
+	SellerID    string `bson:"sellerId,omitempty"`
+
+	SKU            string        `bson:"sku"`
+	VelocityClass  VelocityClass `bson:"velocityClass"`
+	PickFrequency  int           `bson:"pickFrequency"`
+	Zone           string        `bson:"zone"`
+	FromLocationID string        `bson:"fromLocationId"`
+	ToLocationID   string        `bson:"toLocationId"`
+	ToAisle        string        `bson:"toAisle"`
+	ToRack         int           `bson:"toRack"`
+	ToLevel        int           `bson:"toLevel"`
+	Quantity       int           `bson:"quantity"` // Units in the current slot when recommended
+
+	CurrentScore  SlotScore `bson:"currentScore"`
+	ProposedScore SlotScore `bson:"proposedScore"`
+	FromDistance  float64   `bson:"fromDistance"`
+	ToDistance    float64   `bson:"toDistance"`
+	TravelSavings float64   `bson:"travelSavings"` // Expected meters walked less per week
+
+	Status        SlottingRecommendationStatus `bson:"status"`
+	MoveTaskID    string                       `bson:"moveTaskId,omitempty"`
+	Priority      int                          `bson:"priority,omitempty"`      // Move task priority, 1 = highest
+	LaborQueuedAt *time.Time                   `bson:"laborQueuedAt,omitempty"` // When the move task was handed to labor-service
+	DecidedBy     string                       `bson:"decidedBy,omitempty"`
+	RejectReason  string                       `bson:"rejectReason,omitempty"`
+	MovedQuantity int                          `bson:"movedQuantity"`
+	MovedBy       string                       `bson:"movedBy,omitempty"`
+
+	CreatedAt   time.Time  `bson:"createdAt"`
+	UpdatedAt   time.Time  `bson:"updatedAt"`
+	DecidedAt   *time.Time `bson:"decidedAt,omitempty"`
+	CompletedAt *time.Time `bson:"completedAt,omitempty"`
+	Version     int        `bson:"version"` // Incremented on every save for optimistic concurrency
+}
+
+// NewSlottingRecommendation records a planned move of an item as a recommendation awaiting approval
+func NewSlottingRecommendation(item *InventoryItem, move SlotMove) *SlottingRecommendation {
+	now := time.Now()
+	return &SlottingRecommendation{
+		RecommendationID: "SLT-" + uuid.New().String()[:8],
+		TenantID:         item.TenantID,
+		FacilityID:       item.FacilityID,
+		WarehouseID:      item.WarehouseID,
+		SellerID:         item.SellerID,
+		SKU:              item.SKU,
+		VelocityClass:    move.SKU.VelocityClass,
+		PickFrequency:    move.SKU.PickFrequency,
+		Zone:             move.To.Zone,
+		FromLocationID:   move.SKU.Slot.LocationID,
+		ToLocationID:     move.To.LocationID,
+		ToAisle:          move.To.Aisle,
+		ToRack:           move.To.Rack,
+		ToLevel:          move.To.Level,
+		Quantity:         move.SKU.Quantity,
+		CurrentScore:     move.CurrentScore,
+		ProposedScore:    move.ProposedScore,
+		FromDistance:     move.FromDistance,
+		ToDistance:       move.ToDistance,
+		TravelSavings:    move.TravelSavings,
+		Status:           SlottingStatusPending,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// IsOpen reports whether the recommendation still awaits approval or its move
+func (r *SlottingRecommendation) IsOpen() bool {
+	return r.Status == SlottingStatusPending || r.Status == SlottingStatusApproved
+}
+
+// TargetSlot returns the slot the SKU is recommended to move to
+func (r *SlottingRecommendation) TargetSlot() SlotLocation {
+	return SlotLocation{
+		LocationID: r.ToLocationID,
+		Zone:       r.Zone,
+		Aisle:      r.ToAisle,
+		Rack:       r.ToRack,
+		Level:      r.ToLevel,
+	}
+}
+
+// Approve accepts the recommendation and generates its move task
+func (r *SlottingRecommendation) Approve(approvedBy string, priority int) error {
+	if r.Status != SlottingStatusPending {
+		return ErrSlottingRecommendationNotPending
+	}
+
+	now := time.Now()
+	r.Status = SlottingStatusApproved
+	r.MoveTaskID = "MOV-" + uuid.New().String()[:8]
+	r.Priority = priority
+	r.DecidedBy = approvedBy
+	r.DecidedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// Reject declines a recommendation awaiting approval
+func (r *SlottingRecommendation) Reject(rejectedBy, reason string) error {
+	if r.Status != SlottingStatusPending {
+		return ErrSlottingRecommendationNotPending
+	}
+
+	now := time.Now()
+	r.Status = SlottingStatusRejected
+	r.DecidedBy = rejectedBy
+	r.RejectReason = reason
+	r.DecidedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// MarkQueued records that the move task was handed to labor-service
+func (r *SlottingRecommendation) MarkQueued(at time.Time) {
+	r.LaborQueuedAt = &at
+	r.UpdatedAt = at
+}
+
+// Complete records that the move task relocated the stock
+func (r *SlottingRecommendation) Complete(movedQuantity int, movedBy string) error {
+	if r.Status != SlottingStatusApproved {
+		return ErrSlottingRecommendationNotApproved
+	}
+
+	now := time.Now()
+	r.Status = SlottingStatusCompleted
+	r.MovedQuantity = movedQuantity
+	r.MovedBy = movedBy
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// Cancel withdraws the move task of an approved recommendation without moving stock
+func (r *SlottingRecommendation) Cancel(reason string) error {
+	if r.Status != SlottingStatusApproved {
+		return ErrSlottingRecommendationNotApproved
+	}
+
+	now := time.Now()
+	r.Status = SlottingStatusCancelled
+	r.RejectReason = reason
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSlot returns a slot in ZONE-A aisle A with room for anything
+func newTestSlot(t *testing.T, locationID string, level int) SlotLocation {
+	slot, err := NewSlotLocation(locationID, "ZONE-A", "A", 1, level, 0, 0)
+	require.NoError(t, err)
+	return *slot
+}
+
+// TestNewSlotLocation tests slot validation
+func TestNewSlotLocation(t *testing.T) {
+	_, err := NewSlotLocation("", "ZONE-A", "A", 1, 1, 0, 0)
+	assert.ErrorIs(t, err, ErrInvalidSlotLocation)
+	_, err = NewSlotLocation("A-01-0", "ZONE-A", "A", 1, 0, 0, 0)
+	assert.ErrorIs(t, err, ErrInvalidSlotLocation)
+	_, err = NewSlotLocation("A-01-1", "ZONE-A", "A", 1, 1, -1, 0)
+	assert.ErrorIs(t, err, ErrInvalidSlotLocation)
+
+	slot, err := NewSlotLocation("A-01-1", "ZONE-A", "A", 1, 1, 50000, 200)
+	require.NoError(t, err)
+	assert.Equal(t, 50000.0, slot.CubeCapacity)
+}
+
+// TestInventoryItem_SetSlottingProfile tests unit cube and weight validation
+func TestInventoryItem_SetSlottingProfile(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 10, 50)
+
+	assert.ErrorIs(t, item.SetSlottingProfile(SlottingProfile{UnitCube: -1}), ErrInvalidSlottingProfile)
+	assert.Nil(t, item.Slotting)
+
+	require.NoError(t, item.SetSlottingProfile(SlottingProfile{UnitCube: 1000, UnitWeight: 2.5}))
+	require.NotNil(t, item.Slotting)
+	assert.Equal(t, 2.5, item.Slotting.UnitWeight)
+}
+
+// TestInventoryItem_PrimarySlot tests that the pick face wins over fuller reserve locations
+func TestInventoryItem_PrimarySlot(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 10, 50)
+	assert.Nil(t, item.PrimarySlot())
+
+	require.NoError(t, item.ReceiveStock("RSV-1", "ZONE-R", 100, "PO-1", "user1"))
+	require.NoError(t, item.ReceiveStock("RSV-2", "ZONE-R", 40, "PO-1", "user1"))
+	assert.Equal(t, "RSV-1", item.PrimarySlot().LocationID, "fullest location without pick faces")
+
+	require.NoError(t, item.SetPickFaceLimits("PF-1", "ZONE-A", 5, 20))
+	assert.Equal(t, "PF-1", item.PrimarySlot().LocationID)
+}
+
+// TestInventoryItem_OrderHistory tests orders are collected from reservations and picks once each
+func TestInventoryItem_OrderHistory(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 10, 50)
+	require.NoError(t, item.ReceiveStock("LOC-1", "ZONE-A", 20, "PO-1", "user1"))
+	require.NoError(t, item.Reserve("ORD-1", "LOC-1", 2))
+	require.NoError(t, item.Pick("ORD-1", "LOC-1", 2, "picker1"))
+	require.NoError(t, item.Reserve("ORD-2", "LOC-1", 1))
+
+	assert.ElementsMatch(t, []string{"ORD-1", "ORD-2"}, item.OrderHistory())
+}
+
+// TestMineCoPickAffinity tests shared-order affinity and the minimum shared orders
+func TestMineCoPickAffinity(t *testing.T) {
+	affinity := MineCoPickAffinity(map[string][]string{
+		"SKU-A": {"ORD-1", "ORD-2", "ORD-3", "ORD-4"},
+		"SKU-B": {"ORD-1", "ORD-2"},
+		"SKU-C": {"ORD-4"},
+	}, 2)
+
+	assert.Equal(t, 1.0, affinity["SKU-A"]["SKU-B"], "SKU-B is always ordered with SKU-A")
+	assert.Equal(t, 1.0, affinity["SKU-B"]["SKU-A"])
+	assert.NotContains(t, affinity["SKU-A"], "SKU-C", "one shared order is below the minimum")
+}
+
+// TestSlottingEngine_Score tests the velocity, ergonomic, cube and weight factors
+func TestSlottingEngine_Score(t *testing.T) {
+	near, far := newTestSlot(t, "A-01-2", 2), newTestSlot(t, "A-20-5", 5)
+	distances := map[string]float64{"A-01-2": 5, "A-20-5": 45}
+	engine := NewSlottingEngine(DefaultSlottingConfig(), []SlotLocation{near, far}, distances, nil, nil)
+
+	fast := SlottedSKU{SKU: "SKU-A", VelocityClass: VelocityA, PickFrequency: 80, Slot: far, Quantity: 10}
+	nearScore := engine.Score(fast, near)
+	farScore := engine.Score(fast, far)
+	assert.Equal(t, 1.0, nearScore.Velocity)
+	assert.Equal(t, 0.0, farScore.Velocity)
+	assert.Equal(t, 1.0, nearScore.Ergonomic, "level 2 is in the golden zone")
+	assert.Greater(t, nearScore.Total, farScore.Total)
+
+	slow := SlottedSKU{SKU: "SKU-C", VelocityClass: VelocityC, PickFrequency: 1, Slot: near, Quantity: 10}
+	assert.Greater(t, engine.Score(slow, far).Total, engine.Score(slow, near).Total, "slow movers belong far out")
+
+	tight := near
+	tight.CubeCapacity = 5000
+	bulky := fast
+	bulky.UnitCube = 1000
+	assert.False(t, engine.Score(bulky, tight).Fits, "10 units of 1000 cm³ overflow 5000 cm³")
+	assert.Equal(t, 0.0, engine.Score(bulky, tight).Total)
+
+	floor := newTestSlot(t, "A-01-1", 1)
+	heavy := fast
+	heavy.UnitWeight = 25
+	assert.Equal(t, 1.0, engine.Score(heavy, floor).Weight)
+	assert.Equal(t, 0.0, engine.Score(heavy, far).Weight)
+
+	limited := floor
+	limited.MaxWeight = 100
+	assert.False(t, engine.Score(heavy, limited).Fits, "250 kg is over the 100 kg limit")
+}
+
+// TestSlottingEngine_ScoreAffinity tests that co-picked SKUs pull each other into their aisle
+func TestSlottingEngine_ScoreAffinity(t *testing.T) {
+	sameAisle := newTestSlot(t, "A-02-2", 2)
+	otherAisle := sameAisle
+	otherAisle.LocationID, otherAisle.Aisle = "B-02-2", "B"
+	partner := newTestSlot(t, "A-01-2", 2)
+
+	affinity := CoPickAffinity{"SKU-A": {"SKU-B": 1}}
+	engine := NewSlottingEngine(DefaultSlottingConfig(), nil, nil, affinity, map[string]SlotLocation{"SKU-B": partner})
+
+	sku := SlottedSKU{SKU: "SKU-A", VelocityClass: VelocityB, Slot: otherAisle, Quantity: 1}
+	assert.Equal(t, 1.0, engine.Score(sku, sameAisle).Affinity)
+	assert.Equal(t, 0.4, engine.Score(sku, otherAisle).Affinity)
+
+	loner := SlottedSKU{SKU: "SKU-Z", VelocityClass: VelocityB, Slot: otherAisle, Quantity: 1}
+	assert.Equal(t, 1.0, engine.Score(loner, otherAisle).Affinity, "no co-pick partners is neutral")
+}
+
+// TestSlottingEngine_Plan tests that the fastest mover takes the best slot and frees its own
+func TestSlottingEngine_Plan(t *testing.T) {
+	golden := newTestSlot(t, "A-01-2", 2)
+	middle := newTestSlot(t, "A-10-2", 2)
+	top := newTestSlot(t, "A-20-6", 6)
+	slots := []SlotLocation{golden, middle, top}
+	distances := map[string]float64{"A-01-2": 5, "A-10-2": 25, "A-20-6": 45}
+	engine := NewSlottingEngine(DefaultSlottingConfig(), slots, distances, nil, nil)
+
+	fast := SlottedSKU{SKU: "SKU-FAST", VelocityClass: VelocityA, PickFrequency: 100, Slot: top, Quantity: 10}
+	settled := SlottedSKU{SKU: "SKU-OK", VelocityClass: VelocityB, PickFrequency: 20, Slot: middle, Quantity: 10}
+
+	moves := engine.Plan([]SlottedSKU{settled, fast}, []SlotLocation{golden})
+	require.Len(t, moves, 1, "the B item is already well placed")
+
+	move := moves[0]
+	assert.Equal(t, "SKU-FAST", move.SKU.SKU)
+	assert.Equal(t, "A-01-2", move.To.LocationID)
+	assert.Greater(t, move.ProposedScore.Total, move.CurrentScore.Total)
+	assert.Equal(t, 8000.0, move.TravelSavings, "100 picks a week, 2 × 40 m closer")
+
+	capped := DefaultSlottingConfig()
+	capped.MaxMoves = 1
+	engine = NewSlottingEngine(capped, slots, distances, nil, nil)
+	slow := SlottedSKU{SKU: "SKU-SLOW", VelocityClass: VelocityC, PickFrequency: 1, Slot: golden, Quantity: 1}
+	assert.Len(t, engine.Plan([]SlottedSKU{fast, slow}, []SlotLocation{middle}), 1)
+}
+
+// TestInventoryItem_Reslot tests that stock, reservations and pick face limits move to the new slot once per move task
+func TestInventoryItem_Reslot(t *testing.T) {
+	item := newPickFaceItem(t, 0, 15)
+	require.NoError(t, item.Reserve("ORD-1", "PF-1", 4))
+	item.ClearDomainEvents()
+
+	to := newTestSlot(t, "A-01-2", 2)
+	moved, err := item.Reslot("MOV-1", "PF-1", to, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 15, moved)
+
+	dest := item.GetLocationStock("A-01-2")
+	require.NotNil(t, dest)
+	assert.Equal(t, 15, dest.Quantity)
+	assert.Equal(t, 4, dest.Reserved)
+	assert.Equal(t, 2, dest.Level)
+	assert.Equal(t, 20, dest.MaxQuantity, "pick face limits move with the stock")
+	assert.False(t, item.GetLocationStock("PF-1").IsPickFace())
+	assert.Equal(t, "A-01-2", item.Reservations[0].LocationID)
+
+	events := item.GetDomainEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, ReslotReason, events[0].(*StockMovedEvent).Reason)
+
+	item.ClearDomainEvents()
+	moved, err = item.Reslot("MOV-1", "PF-1", to, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 15, moved, "a repeated move reports the quantity moved the first time")
+	assert.Equal(t, 15, item.GetLocationStock("A-01-2").Quantity)
+	assert.Empty(t, item.GetDomainEvents())
+
+	_, err = item.Reslot("MOV-2", "PF-1", newTestSlot(t, "A-02-2", 2), "user1")
+	assert.ErrorIs(t, err, ErrNothingToReslot)
+}
+
+// TestSlottingRecommendation_Lifecycle tests approval, completion and the closed states
+func TestSlottingRecommendation_Lifecycle(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 10, 50)
+	move := SlotMove{
+		SKU: SlottedSKU{SKU: "SKU-001", VelocityClass: VelocityA, Slot: newTestSlot(t, "A-20-6", 6), Quantity: 10},
+		To:  newTestSlot(t, "A-01-2", 2),
+	}
+
+	rec := NewSlottingRecommendation(item, move)
+	assert.Equal(t, SlottingStatusPending, rec.Status)
+	assert.Equal(t, "A-20-6", rec.FromLocationID)
+	assert.Equal(t, 2, rec.TargetSlot().Level)
+	assert.ErrorIs(t, rec.Complete(10, "user1"), ErrSlottingRecommendationNotApproved)
+
+	require.NoError(t, rec.Approve("manager", 5))
+	assert.Equal(t, SlottingStatusApproved, rec.Status)
+	assert.NotEmpty(t, rec.MoveTaskID)
+	assert.ErrorIs(t, rec.Reject("manager", "late"), ErrSlottingRecommendationNotPending)
+
+	require.NoError(t, rec.Complete(10, "user1"))
+	assert.Equal(t, SlottingStatusCompleted, rec.Status)
+	assert.False(t, rec.IsOpen())
+	assert.ErrorIs(t, rec.Cancel("late"), ErrSlottingRecommendationNotApproved)
+
+	rejected := NewSlottingRecommendation(item, move)
+	require.NoError(t, rejected.Reject("manager", "aisle under maintenance"))
+	assert.Equal(t, SlottingStatusRejected, rejected.Status)
+}
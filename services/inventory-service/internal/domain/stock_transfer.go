@@ -0,0 +1,589 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock transfer errors
+var (
+	ErrDestinationRequired    = errors.New("destination location is required")
+	ErrContainerRequired      = errors.New("a two-step move needs the cart or tote carrying the stock")
+	ErrReservedStockInTransit = errors.New("reserved stock can only be moved in a single-step transfer")
+	ErrMoveNotFound           = errors.New("in-transit move not found")
+)
+
+// TransferReason is recorded on transfers requested without a reason
+const TransferReason = "transfer"
+
+// StockTransfer moves stock of the item from one location to another
+type StockTransfer struct {
+	FromLocationID string
+	ToLocationID   string // Optional when a two-step move starts, if the destination isn't known yet
+	ToZone         string // Zone of the destination when the item has no stock there yet
+	Quantity       int
+	LotNumber      string          // Moves one lot only; otherwise lots move earliest expiry first
+	Status         InventoryStatus // Status of the stock to move, available when empty
+	OrderIDs       []string        // Orders whose reservations at the source move along (single-step only)
+	Reason         string
+	ReferenceID    string
+	MovedBy        string
+}
+
+func (t StockTransfer) status() InventoryStatus {
+	if t.Status == "" {
+		return StatusAvailable
+	}
+	return t.Status
+}
+
+func (t StockTransfer) reason() string {
+	if t.Reason == "" {
+		return TransferReason
+	}
+	return t.Reason
+}
+
+// MovedStock is part of the stock taken from a location for a move: a quantity of one lot, or
+// of stock not held in a lot, with the hold and reason code of non-sellable stock
+type MovedStock struct {
+	LotNumber       string           `bson:"lotNumber,omitempty"`
+	ManufactureDate *time.Time       `bson:"manufactureDate,omitempty"`
+	ExpiryDate      *time.Time       `bson:"expiryDate,omitempty"`
+	ReceivedAt      time.Time        `bson:"receivedAt"`
+	HoldID          string           `bson:"holdId,omitempty"`
+	ReasonCode      StatusReasonCode `bson:"reasonCode,omitempty"`
+	Quantity        int              `bson:"quantity"`
+}
+
+// InTransitStock is stock picked onto a cart or tote for a two-step move and not yet dropped
+// at its destination. It still counts towards the item's total but can't be reserved.
+type InTransitStock struct {
+	MoveID         string          `bson:"moveId"`
+	ContainerID    string          `bson:"containerId"` // Cart or tote carrying the stock
+	FromLocationID string          `bson:"fromLocationId"`
+	ToLocationID   string          `bson:"toLocationId,omitempty"` // Planned destination, if known
+	ToZone         string          `bson:"toZone,omitempty"`
+	Status         InventoryStatus `bson:"status"`
+	Quantity       int             `bson:"quantity"`
+	Stock          []MovedStock    `bson:"stock"`
+	Reason         string          `bson:"reason"`
+	ReferenceID    string          `bson:"referenceId,omitempty"`
+	PickedBy       string          `bson:"pickedBy"`
+	PickedAt       time.Time       `bson:"pickedAt"`
+}
+
+// Transfer moves stock between two locations in one step. The stock keeps its lots and, for
+// non-sellable stock, its status and hold; reservations of t.OrderIDs at the source move along.
+func (i *InventoryItem) Transfer(t StockTransfer) error {
+	if t.ToLocationID == "" {
+		return ErrDestinationRequired
+	}
+	return i.transfer(t)
+}
+
+// transfer moves stock between two locations of the item. Active reservations of t.OrderIDs
+// at the source move first, whole reservations only, keeping their lots; the rest of the
+// quantity is taken from stock of t.Status, lots earliest expiry first. Staged and blocked
+// stock stays where it is.
+func (i *InventoryItem) transfer(t StockTransfer) error {
+	status := t.status()
+	if err := i.checkMove(t, status); err != nil {
+		return err
+	}
+
+	moving := make([]int, 0)
+	reservedQty := 0
+	if status.IsSellable() {
+		moving, reservedQty = i.movingReservations(t)
+	}
+	movableQty := t.Quantity - reservedQty
+	if i.GetLocationStock(t.FromLocationID).movable(status, t.LotNumber) < movableQty {
+		return ErrInsufficientStock
+	}
+
+	// Resolve the destination first: creating it may grow the locations slice
+	toIdx := i.locationIndex(t.ToLocationID, t.ToZone)
+	from := &i.Locations[i.locationIndex(t.FromLocationID, "")]
+	to := &i.Locations[toIdx]
+
+	for _, idx := range moving {
+		res := &i.Reservations[idx]
+		for _, alloc := range res.Lots {
+			lot := from.GetLot(alloc.LotNumber)
+			if lot == nil {
+				continue
+			}
+			lot.Quantity -= alloc.Quantity
+			lot.Reserved -= alloc.Quantity
+			dest := to.destinationLot(*lot)
+			dest.Quantity += alloc.Quantity
+			dest.Reserved += alloc.Quantity
+		}
+		res.LocationID = t.ToLocationID
+	}
+	from.Quantity -= reservedQty
+	from.Reserved -= reservedQty
+	to.Quantity += reservedQty
+	to.Reserved += reservedQty
+
+	if movableQty > 0 {
+		i.putStock(to, status, i.takeStock(from, status, t.LotNumber, movableQty))
+	}
+	from.pruneEmptyLots()
+
+	now := time.Now()
+	i.UpdatedAt = now
+	i.recordTransfer(t.FromLocationID, -t.Quantity, t.reason(), t.ReferenceID, t.MovedBy, now)
+	i.recordTransfer(t.ToLocationID, t.Quantity, t.reason(), t.ReferenceID, t.MovedBy, now)
+
+	event := &StockMovedEvent{
+		SKU:              i.SKU,
+		FromLocationID:   t.FromLocationID,
+		ToLocationID:     t.ToLocationID,
+		Quantity:         t.Quantity,
+		ReservedQuantity: reservedQty,
+		LotNumber:        t.LotNumber,
+		UnitWeight:       i.unitWeight(),
+		Reason:           t.reason(),
+		ReferenceID:      t.ReferenceID,
+		MovedBy:          t.MovedBy,
+		MovedAt:          now,
+	}
+	if !status.IsSellable() {
+		event.Status = string(status)
+	}
+	i.AddDomainEvent(event)
+	return nil
+}
+
+// StartMove picks stock onto a cart or tote for a two-step move and returns the move ID. The
+// stock is in transit, not available, until DropMove puts it at its destination.
+func (i *InventoryItem) StartMove(t StockTransfer, containerID string) (string, error) {
+	if containerID == "" {
+		return "", ErrContainerRequired
+	}
+	if len(t.OrderIDs) > 0 {
+		return "", ErrReservedStockInTransit
+	}
+	status := t.status()
+	if err := i.checkMove(t, status); err != nil {
+		return "", err
+	}
+
+	from := &i.Locations[i.locationIndex(t.FromLocationID, "")]
+	if from.movable(status, t.LotNumber) < t.Quantity {
+		return "", ErrInsufficientStock
+	}
+	stock := i.takeStock(from, status, t.LotNumber, t.Quantity)
+	from.pruneEmptyLots()
+	i.InTransitQuantity += t.Quantity
+
+	now := time.Now()
+	move := InTransitStock{
+		MoveID:         "MOV-" + uuid.New().String()[:8],
+		ContainerID:    containerID,
+		FromLocationID: t.FromLocationID,
+		ToLocationID:   t.ToLocationID,
+		ToZone:         t.ToZone,
+		Status:         status,
+		Quantity:       t.Quantity,
+		Stock:          stock,
+		Reason:         t.reason(),
+		ReferenceID:    t.ReferenceID,
+		PickedBy:       t.MovedBy,
+		PickedAt:       now,
+	}
+	i.InTransit = append(i.InTransit, move)
+
+	i.UpdatedAt = now
+	i.recordTransfer(t.FromLocationID, -t.Quantity, move.Reason, move.MoveID, t.MovedBy, now)
+
+	event := &StockMoveStartedEvent{
+		SKU:            i.SKU,
+		MoveID:         move.MoveID,
+		ContainerID:    containerID,
+		FromLocationID: t.FromLocationID,
+		ToLocationID:   t.ToLocationID,
+		Quantity:       t.Quantity,
+		LotNumber:      t.LotNumber,
+		UnitWeight:     i.unitWeight(),
+		Reason:         move.Reason,
+		ReferenceID:    t.ReferenceID,
+		MovedBy:        t.MovedBy,
+		StartedAt:      now,
+	}
+	if !status.IsSellable() {
+		event.Status = string(status)
+	}
+	i.AddDomainEvent(event)
+	return move.MoveID, nil
+}
+
+// DropMove puts the stock of a two-step move at toLocationID, or at the destination planned
+// when the move started. Dropping it at its source puts it back.
+func (i *InventoryItem) DropMove(moveID, toLocationID, toZone, droppedBy string) error {
+	idx := i.inTransitIndex(moveID)
+	if idx == -1 {
+		return ErrMoveNotFound
+	}
+	move := i.InTransit[idx]
+	if toLocationID == "" {
+		toLocationID, toZone = move.ToLocationID, move.ToZone
+	}
+	if toLocationID == "" {
+		return ErrDestinationRequired
+	}
+
+	to := &i.Locations[i.locationIndex(toLocationID, toZone)]
+	i.putStock(to, move.Status, move.Stock)
+	i.InTransitQuantity -= move.Quantity
+	i.InTransit = append(i.InTransit[:idx], i.InTransit[idx+1:]...)
+
+	now := time.Now()
+	i.UpdatedAt = now
+	i.recordTransfer(toLocationID, move.Quantity, move.Reason, move.MoveID, droppedBy, now)
+
+	event := &StockMovedEvent{
+		SKU:            i.SKU,
+		FromLocationID: move.FromLocationID,
+		ToLocationID:   toLocationID,
+		Quantity:       move.Quantity,
+		MoveID:         move.MoveID,
+		ContainerID:    move.ContainerID,
+		UnitWeight:     i.unitWeight(),
+		Reason:         move.Reason,
+		ReferenceID:    move.ReferenceID,
+		MovedBy:        droppedBy,
+		MovedAt:        now,
+	}
+	if len(move.Stock) == 1 {
+		event.LotNumber = move.Stock[0].LotNumber
+	}
+	if !move.Status.IsSellable() {
+		event.Status = string(move.Status)
+	}
+	i.AddDomainEvent(event)
+	return nil
+}
+
+// GetInTransitMove returns a two-step move not yet dropped, or nil
+func (i *InventoryItem) GetInTransitMove(moveID string) *InTransitStock {
+	if idx := i.inTransitIndex(moveID); idx != -1 {
+		move := i.InTransit[idx]
+		return &move
+	}
+	return nil
+}
+
+// MoveAllStock moves everything the item has at a location, e.g. the contents of an LPN or a
+// tote, to another location: all lots and statuses, reserved and blocked stock, with the
+// reservations and staged allocations drawing on it. Returns the quantity moved.
+func (i *InventoryItem) MoveAllStock(fromLocationID, toLocationID, toZone, reason, referenceID, movedBy string) (int, error) {
+	if toLocationID == "" {
+		return 0, ErrDestinationRequired
+	}
+	if fromLocationID == toLocationID {
+		return 0, ErrSameLocation
+	}
+	source := i.GetLocationStock(fromLocationID)
+	if source == nil {
+		return 0, ErrLocationNotFound
+	}
+	if source.Quantity == 0 {
+		return 0, nil
+	}
+	if reason == "" {
+		reason = TransferReason
+	}
+
+	// Resolve the destination first: creating it may grow the locations slice
+	toIdx := i.locationIndex(toLocationID, toZone)
+	from := &i.Locations[i.locationIndex(fromLocationID, "")]
+	to := &i.Locations[toIdx]
+	quantity := from.Quantity
+	reservedQty := from.Reserved + from.HardAllocated
+
+	now := time.Now()
+	for _, lot := range from.Lots {
+		dest := to.destinationLot(lot)
+		dest.Quantity += lot.Quantity
+		dest.Reserved += lot.Reserved
+		dest.Held += lot.Held
+		if lot.Status == LotStatusExpired && dest.Status != LotStatusExpired {
+			dest.Status = LotStatusExpired
+			dest.BlockedAt = lot.BlockedAt
+		}
+	}
+	for _, bucket := range from.Statuses {
+		idx := to.statusBucket(bucket.Status, bucket.LotNumber, bucket.HoldID)
+		if idx == -1 {
+			to.Statuses = append(to.Statuses, bucket)
+			continue
+		}
+		to.Statuses[idx].Quantity += bucket.Quantity
+		to.Statuses[idx].ReasonCode = bucket.ReasonCode
+		to.Statuses[idx].UpdatedAt = now
+	}
+	to.Quantity += from.Quantity
+	to.Reserved += from.Reserved
+	to.HardAllocated += from.HardAllocated
+	to.Available += from.Available
+	to.Blocked += from.Blocked
+
+	from.Quantity, from.Reserved, from.HardAllocated, from.Available, from.Blocked = 0, 0, 0, 0, 0
+	from.Lots = nil
+	from.Statuses = nil
+
+	for idx := range i.Reservations {
+		res := &i.Reservations[idx]
+		if res.LocationID == fromLocationID && (res.Status == "active" || res.Status == "staged") {
+			res.LocationID = toLocationID
+		}
+	}
+	for idx := range i.HardAllocations {
+		alloc := &i.HardAllocations[idx]
+		if alloc.SourceLocationID == fromLocationID && (alloc.Status == "staged" || alloc.Status == "packed") {
+			alloc.SourceLocationID = toLocationID
+		}
+	}
+
+	i.UpdatedAt = now
+	i.recordTransfer(fromLocationID, -quantity, reason, referenceID, movedBy, now)
+	i.recordTransfer(toLocationID, quantity, reason, referenceID, movedBy, now)
+
+	i.AddDomainEvent(&StockMovedEvent{
+		SKU:              i.SKU,
+		FromLocationID:   fromLocationID,
+		ToLocationID:     toLocationID,
+		Quantity:         quantity,
+		ReservedQuantity: reservedQty,
+		UnitWeight:       i.unitWeight(),
+		Reason:           reason,
+		ReferenceID:      referenceID,
+		MovedBy:          movedBy,
+		MovedAt:          now,
+	})
+	return quantity, nil
+}
+
+// checkMove validates a move out of a location before any stock is touched
+func (i *InventoryItem) checkMove(t StockTransfer, status InventoryStatus) error {
+	if t.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if !status.IsValid() {
+		return ErrInvalidInventoryStatus
+	}
+	if t.FromLocationID == t.ToLocationID {
+		return ErrSameLocation
+	}
+	from := i.GetLocationStock(t.FromLocationID)
+	if from == nil {
+		return ErrLocationNotFound
+	}
+	if t.LotNumber != "" && from.GetLot(t.LotNumber) == nil {
+		return ErrLotNotFound
+	}
+	return nil
+}
+
+// movingReservations returns the indexes of the active reservations of t.OrderIDs at the
+// source that fit in the quantity moved, whole reservations only, and their total quantity.
+// With a lot number, only reservations held entirely in that lot move.
+func (i *InventoryItem) movingReservations(t StockTransfer) ([]int, int) {
+	orders := make(map[string]bool, len(t.OrderIDs))
+	for _, orderID := range t.OrderIDs {
+		orders[orderID] = true
+	}
+
+	moving := make([]int, 0)
+	reservedQty := 0
+	for idx, res := range i.Reservations {
+		if res.Status != "active" || res.LocationID != t.FromLocationID || !orders[res.OrderID] {
+			continue
+		}
+		if reservedQty+res.Quantity > t.Quantity || !res.heldInLot(t.LotNumber) {
+			continue
+		}
+		moving = append(moving, idx)
+		reservedQty += res.Quantity
+	}
+	return moving, reservedQty
+}
+
+// heldInLot reports whether the reservation is held entirely in a lot; any lot when empty
+func (r Reservation) heldInLot(lotNumber string) bool {
+	if lotNumber == "" {
+		return true
+	}
+	if len(r.Lots) == 0 {
+		return false
+	}
+	for _, alloc := range r.Lots {
+		if alloc.LotNumber != lotNumber {
+			return false
+		}
+	}
+	return true
+}
+
+// movable returns the stock of a status at the location that can be moved, of one lot when
+// lotNumber is set
+func (l *StockLocation) movable(status InventoryStatus, lotNumber string) int {
+	if status.IsSellable() {
+		if lotNumber == "" {
+			return l.Available
+		}
+		available, _ := l.sellableAvailable(lotNumber)
+		return available
+	}
+
+	total := 0
+	for _, bucket := range l.Statuses {
+		if bucket.Status == status && (lotNumber == "" || bucket.LotNumber == lotNumber) {
+			total += bucket.Quantity
+		}
+	}
+	return total
+}
+
+// takeStock removes stock of a status from a location, lots earliest expiry first, and
+// returns what it took. The caller checks the location has enough movable stock.
+func (i *InventoryItem) takeStock(from *StockLocation, status InventoryStatus, lotNumber string, quantity int) []MovedStock {
+	taken := make([]MovedStock, 0)
+	take := func(lot *StockLot, holdID string, reasonCode StatusReasonCode, qty int) {
+		moved := MovedStock{HoldID: holdID, ReasonCode: reasonCode, Quantity: qty}
+		if lot != nil {
+			moved.LotNumber = lot.LotNumber
+			moved.ManufactureDate = lot.ManufactureDate
+			moved.ExpiryDate = lot.ExpiryDate
+			moved.ReceivedAt = lot.ReceivedAt
+			lot.Quantity -= qty
+		}
+		taken = append(taken, moved)
+	}
+
+	remaining := quantity
+	if status.IsSellable() {
+		if lotNumber != "" {
+			take(from.GetLot(lotNumber), "", "", remaining)
+			remaining = 0
+		}
+		for _, idx := range from.fefoLots(AllocationPolicy{}) {
+			if remaining == 0 {
+				break
+			}
+			qty := min(from.Lots[idx].Available(), remaining)
+			take(&from.Lots[idx], "", "", qty)
+			remaining -= qty
+		}
+		if remaining > 0 {
+			take(nil, "", "", remaining)
+		}
+		from.Available -= quantity
+		i.AvailableQuantity -= quantity
+	} else {
+		kept := make([]StatusBucket, 0, len(from.Statuses))
+		for _, bucket := range from.Statuses {
+			if remaining > 0 && bucket.Status == status && (lotNumber == "" || bucket.LotNumber == lotNumber) {
+				qty := min(bucket.Quantity, remaining)
+				lot := from.GetLot(bucket.LotNumber)
+				if lot != nil {
+					lot.Held -= qty
+				}
+				take(lot, bucket.HoldID, bucket.ReasonCode, qty)
+				bucket.Quantity -= qty
+				remaining -= qty
+			}
+			if bucket.Quantity > 0 {
+				kept = append(kept, bucket)
+			}
+		}
+		from.Statuses = kept
+		i.NonSellableQuantity -= quantity
+	}
+
+	from.Quantity -= quantity
+	return taken
+}
+
+// putStock adds stock taken by takeStock to a location in the same status. Sellable stock of
+// a lot that expired at the destination is blocked.
+func (i *InventoryItem) putStock(to *StockLocation, status InventoryStatus, stock []MovedStock) {
+	now := time.Now()
+	for _, moved := range stock {
+		var lot *StockLot
+		if moved.LotNumber != "" {
+			lot = to.destinationLot(StockLot{
+				LotNumber:       moved.LotNumber,
+				ManufactureDate: moved.ManufactureDate,
+				ExpiryDate:      moved.ExpiryDate,
+				ReceivedAt:      moved.ReceivedAt,
+			})
+			lot.Quantity += moved.Quantity
+		}
+		to.Quantity += moved.Quantity
+
+		if !status.IsSellable() {
+			if lot != nil {
+				lot.Held += moved.Quantity
+			}
+			idx := to.statusBucket(status, moved.LotNumber, moved.HoldID)
+			if idx == -1 {
+				to.Statuses = append(to.Statuses, StatusBucket{
+					Status:    status,
+					LotNumber: moved.LotNumber,
+					HoldID:    moved.HoldID,
+				})
+				idx = len(to.Statuses) - 1
+			}
+			to.Statuses[idx].Quantity += moved.Quantity
+			to.Statuses[idx].ReasonCode = moved.ReasonCode
+			to.Statuses[idx].UpdatedAt = now
+			i.NonSellableQuantity += moved.Quantity
+			continue
+		}
+
+		if lot != nil && lot.Status == LotStatusExpired {
+			to.Blocked += moved.Quantity
+			continue
+		}
+		to.Available += moved.Quantity
+		i.AvailableQuantity += moved.Quantity
+	}
+}
+
+// recordTransfer records one leg of a transfer: stock leaving (negative) or arriving at a location
+func (i *InventoryItem) recordTransfer(locationID string, quantity int, reason, referenceID, movedBy string, at time.Time) {
+	i.Transactions = append(i.Transactions, InventoryTransaction{
+		TransactionID: generateTransactionID(),
+		Type:          "transfer",
+		Quantity:      quantity,
+		LocationID:    locationID,
+		ReferenceID:   referenceID,
+		Reason:        reason,
+		CreatedAt:     at,
+		CreatedBy:     movedBy,
+	})
+}
+
+// unitWeight returns the weight of one unit of the SKU, or 0 when it is unknown
+func (i *InventoryItem) unitWeight() float64 {
+	if i.Slotting == nil {
+		return 0
+	}
+	return i.Slotting.UnitWeight
+}
+
+// inTransitIndex returns the index of a two-step move not yet dropped, or -1
+func (i *InventoryItem) inTransitIndex(moveID string) int {
+	for idx, move := range i.InTransit {
+		if move.MoveID == moveID {
+			return idx
+		}
+	}
+	return -1
+}
@@ -0,0 +1,282 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInventoryItem_Transfer tests a single-step transfer that keeps lots and reservations
+func TestInventoryItem_Transfer(t *testing.T) {
+	item := createLotItem(t)
+	require.NoError(t, item.Reserve("ORD-1", "LOC-A1", 5))
+	item.ClearDomainEvents()
+
+	require.NoError(t, item.Transfer(StockTransfer{
+		FromLocationID: "LOC-A1",
+		ToLocationID:   "LOC-C1",
+		ToZone:         "ZONE-C",
+		Quantity:       12,
+		OrderIDs:       []string{"ORD-1"},
+		ReferenceID:    "MV-1",
+		MovedBy:        "user1",
+	}))
+
+	from := item.GetLocationStock("LOC-A1")
+	assert.Equal(t, 18, from.Quantity)
+	assert.Equal(t, 0, from.Reserved)
+	assert.Nil(t, from.GetLot("LOT-EARLY"), "emptied lots are pruned")
+	assert.Equal(t, 18, from.GetLot("LOT-LATE").Quantity)
+
+	to := item.GetLocationStock("LOC-C1")
+	require.NotNil(t, to)
+	assert.Equal(t, "ZONE-C", to.Zone)
+	assert.Equal(t, 12, to.Quantity)
+	assert.Equal(t, 5, to.Reserved, "the reservation moves with its stock")
+	assert.Equal(t, 7, to.Available)
+	assert.Equal(t, 10, to.GetLot("LOT-EARLY").Quantity, "earliest expiry moves first")
+	assert.Equal(t, 5, to.GetLot("LOT-EARLY").Reserved)
+	assert.Equal(t, 2, to.GetLot("LOT-LATE").Quantity)
+	assert.Equal(t, "LOC-C1", item.Reservations[0].LocationID)
+
+	assert.Equal(t, 60, item.TotalQuantity, "transfers keep the stock on hand")
+	assert.Equal(t, 55, item.AvailableQuantity)
+
+	txns := item.Transactions[len(item.Transactions)-2:]
+	assert.Equal(t, "transfer", txns[0].Type)
+	assert.Equal(t, -12, txns[0].Quantity)
+	assert.Equal(t, "LOC-A1", txns[0].LocationID)
+	assert.Equal(t, 12, txns[1].Quantity)
+	assert.Equal(t, "LOC-C1", txns[1].LocationID)
+	assert.Equal(t, "MV-1", txns[1].ReferenceID)
+
+	events := item.GetDomainEvents()
+	require.Len(t, events, 1)
+	moved, ok := events[0].(*StockMovedEvent)
+	require.True(t, ok)
+	assert.Equal(t, 12, moved.Quantity)
+	assert.Equal(t, 5, moved.ReservedQuantity)
+	assert.Equal(t, TransferReason, moved.Reason)
+	assert.Empty(t, moved.Status)
+}
+
+// TestInventoryItem_TransferNonSellable tests that moved stock keeps its status
+func TestInventoryItem_TransferNonSellable(t *testing.T) {
+	item := NewInventoryItem("SKU-001", "Test Product", 0, 50)
+	require.NoError(t, item.ReceiveStock("LOC-1", "ZONE-A", 10, "PO-1", "user1"))
+	require.NoError(t, item.ChangeStatus(StatusChange{
+		LocationID: "LOC-1", Quantity: 3, From: StatusAvailable, To: StatusDamaged, ReasonCode: StatusReasonDamagedInWarehouse,
+	}))
+	item.ClearDomainEvents()
+
+	require.NoError(t, item.Transfer(StockTransfer{
+		FromLocationID: "LOC-1",
+		ToLocationID:   "DMG-1",
+		ToZone:         "RETURNS",
+		Quantity:       3,
+		Status:         StatusDamaged,
+		MovedBy:        "user1",
+	}))
+
+	assert.Equal(t, 0, item.GetLocationStock("LOC-1").NonSellable())
+	assert.Equal(t, 3, item.GetLocationStock("DMG-1").StatusQuantity(StatusDamaged))
+	assert.Equal(t, 0, item.GetLocationStock("DMG-1").Available)
+	assert.Equal(t, 3, item.NonSellableQuantity)
+	assert.Equal(t, 7, item.AvailableQuantity)
+
+	moved := item.GetDomainEvents()[0].(*StockMovedEvent)
+	assert.Equal(t, "damaged", moved.Status)
+}
+
+// TestInventoryItem_TransferValidation tests rejected transfers
+func TestInventoryItem_TransferValidation(t *testing.T) {
+	item := createLotItem(t)
+	require.NoError(t, item.Reserve("ORD-1", "LOC-B1", 25))
+
+	base := StockTransfer{FromLocationID: "LOC-B1", ToLocationID: "LOC-C1", Quantity: 1, MovedBy: "user1"}
+
+	transfer := base
+	transfer.ToLocationID = ""
+	assert.ErrorIs(t, item.Transfer(transfer), ErrDestinationRequired)
+
+	transfer = base
+	transfer.ToLocationID = "LOC-B1"
+	assert.ErrorIs(t, item.Transfer(transfer), ErrSameLocation)
+
+	transfer = base
+	transfer.FromLocationID = "LOC-X"
+	assert.ErrorIs(t, item.Transfer(transfer), ErrLocationNotFound)
+
+	transfer = base
+	transfer.LotNumber = "LOT-EARLY"
+	assert.ErrorIs(t, item.Transfer(transfer), ErrLotNotFound)
+
+	transfer = base
+	transfer.Quantity = 0
+	assert.ErrorIs(t, item.Transfer(transfer), ErrInvalidQuantity)
+
+	transfer = base
+	transfer.Quantity = 6
+	assert.ErrorIs(t, item.Transfer(transfer), ErrInsufficientStock, "reserved stock stays unless its order moves")
+	assert.Equal(t, 30, item.GetLocationStock("LOC-B1").Quantity)
+	assert.Nil(t, item.GetLocationStock("LOC-C1"))
+}
+
+// TestInventoryItem_TwoStepMove tests picking stock onto a cart and dropping it later
+func TestInventoryItem_TwoStepMove(t *testing.T) {
+	item := createLotItem(t)
+	item.ClearDomainEvents()
+
+	moveID, err := item.StartMove(StockTransfer{
+		FromLocationID: "LOC-B1",
+		ToLocationID:   "LOC-C1",
+		ToZone:         "ZONE-C",
+		Quantity:       8,
+		LotNumber:      "LOT-MID",
+		MovedBy:        "picker1",
+	}, "CART-7")
+	require.NoError(t, err)
+	assert.NotEmpty(t, moveID)
+
+	assert.Equal(t, 22, item.GetLocationStock("LOC-B1").Quantity)
+	assert.Equal(t, 8, item.InTransitQuantity)
+	assert.Equal(t, 52, item.AvailableQuantity, "stock on the cart can't be reserved")
+	assert.Equal(t, 60, item.TotalQuantity)
+
+	move := item.GetInTransitMove(moveID)
+	require.NotNil(t, move)
+	assert.Equal(t, "CART-7", move.ContainerID)
+	assert.Equal(t, StatusAvailable, move.Status)
+	require.Len(t, move.Stock, 1)
+	assert.Equal(t, "LOT-MID", move.Stock[0].LotNumber)
+
+	started, ok := item.GetDomainEvents()[0].(*StockMoveStartedEvent)
+	require.True(t, ok)
+	assert.Equal(t, moveID, started.MoveID)
+	assert.Equal(t, "CART-7", started.ContainerID)
+	item.ClearDomainEvents()
+
+	// Dropping without a destination uses the planned one
+	require.NoError(t, item.DropMove(moveID, "", "", "stower1"))
+	to := item.GetLocationStock("LOC-C1")
+	require.NotNil(t, to)
+	assert.Equal(t, 8, to.Available)
+	assert.Equal(t, 8, to.GetLot("LOT-MID").Quantity)
+	assert.Equal(t, 0, item.InTransitQuantity)
+	assert.Empty(t, item.InTransit)
+	assert.Equal(t, 60, item.AvailableQuantity)
+
+	moved, ok := item.GetDomainEvents()[0].(*StockMovedEvent)
+	require.True(t, ok)
+	assert.Equal(t, moveID, moved.MoveID)
+	assert.Equal(t, "LOC-B1", moved.FromLocationID)
+	assert.Equal(t, "LOC-C1", moved.ToLocationID)
+	assert.Equal(t, "LOT-MID", moved.LotNumber)
+	assert.Equal(t, "stower1", moved.MovedBy)
+
+	assert.ErrorIs(t, item.DropMove(moveID, "LOC-C1", "", "stower1"), ErrMoveNotFound)
+}
+
+// TestInventoryItem_TwoStepMoveValidation tests rejected two-step moves
+func TestInventoryItem_TwoStepMoveValidation(t *testing.T) {
+	item := createLotItem(t)
+	base := StockTransfer{FromLocationID: "LOC-B1", Quantity: 5, MovedBy: "picker1"}
+
+	_, err := item.StartMove(base, "")
+	assert.ErrorIs(t, err, ErrContainerRequired)
+
+	withOrders := base
+	withOrders.OrderIDs = []string{"ORD-1"}
+	_, err = item.StartMove(withOrders, "CART-7")
+	assert.ErrorIs(t, err, ErrReservedStockInTransit)
+
+	tooMany := base
+	tooMany.Quantity = 31
+	_, err = item.StartMove(tooMany, "CART-7")
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	// A move started without a destination needs one when dropped
+	moveID, err := item.StartMove(base, "CART-7")
+	require.NoError(t, err)
+	assert.ErrorIs(t, item.DropMove(moveID, "", "", "stower1"), ErrDestinationRequired)
+	require.NoError(t, item.DropMove(moveID, "LOC-B1", "", "stower1"), "dropping at the source puts it back")
+	assert.Equal(t, 30, item.GetLocationStock("LOC-B1").Available)
+}
+
+// TestInventoryItem_MoveAllStock tests moving the whole contents of an LPN
+func TestInventoryItem_MoveAllStock(t *testing.T) {
+	item := createLotItem(t)
+	require.NoError(t, item.Reserve("ORD-1", "LOC-A1", 4))
+	require.NoError(t, item.ChangeStatus(StatusChange{
+		LocationID: "LOC-A1", LotNumber: "LOT-LATE", Quantity: 2, From: StatusAvailable, To: StatusDamaged, ReasonCode: StatusReasonDamagedInWarehouse,
+	}))
+	item.ClearDomainEvents()
+
+	moved, err := item.MoveAllStock("LOC-A1", "LOC-C1", "ZONE-C", "", "LPN-1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 30, moved)
+
+	from := item.GetLocationStock("LOC-A1")
+	assert.Equal(t, 0, from.Quantity)
+	assert.Empty(t, from.Lots)
+
+	to := item.GetLocationStock("LOC-C1")
+	assert.Equal(t, 30, to.Quantity)
+	assert.Equal(t, 4, to.Reserved)
+	assert.Equal(t, 24, to.Available)
+	assert.Equal(t, 2, to.StatusQuantity(StatusDamaged))
+	assert.Equal(t, 2, to.GetLot("LOT-LATE").Held)
+	assert.Equal(t, "LOC-C1", item.Reservations[0].LocationID)
+	assert.Equal(t, 60, item.TotalQuantity)
+
+	event, ok := item.GetDomainEvents()[0].(*StockMovedEvent)
+	require.True(t, ok)
+	assert.Equal(t, 30, event.Quantity)
+	assert.Equal(t, 4, event.ReservedQuantity)
+	assert.Equal(t, "LPN-1", event.ReferenceID)
+
+	// Nothing left to move
+	moved, err = item.MoveAllStock("LOC-A1", "LOC-C1", "", "", "LPN-1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, moved)
+
+	_, err = item.MoveAllStock("LOC-A1", "LOC-D1", "", "", "LPN-1", "user1")
+	require.NoError(t, err)
+	assert.Nil(t, item.GetLocationStock("LOC-D1"), "an empty move creates no destination")
+
+	_, err = item.MoveAllStock("LOC-A1", "", "", "", "", "user1")
+	assert.ErrorIs(t, err, ErrDestinationRequired)
+	_, err = item.MoveAllStock("LOC-X", "LOC-C1", "", "", "", "user1")
+	assert.ErrorIs(t, err, ErrLocationNotFound)
+}
+
+// TestInventoryLedger_RecordTransfer tests moving stock value between locations and transit
+func TestInventoryLedger_RecordTransfer(t *testing.T) {
+	ledger, err := NewInventoryLedger("SKU-001", ValuationWeightedAverage, &LedgerTenantInfo{TenantID: "T1", FacilityID: "F1"}, "USD")
+	require.NoError(t, err)
+	unitCost, err := NewMoney(500, "USD")
+	require.NoError(t, err)
+	_, _, err = ledger.RecordReceiving(10, unitCost, "LOC-1", "PO-1", "user1")
+	require.NoError(t, err)
+
+	_, entries, err := ledger.RecordTransfer(4, AccountInventory, AccountInventory, "LOC-1", "LOC-2", "MV-1", "user1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.True(t, entries[0].IsDebit())
+	assert.Equal(t, "LOC-2", entries[0].LocationID)
+	assert.Equal(t, "LOC-1", entries[1].LocationID)
+	assert.Equal(t, 10, ledger.GetAccountBalance(AccountInventory).Balance, "a direct move keeps the account balance")
+
+	_, _, err = ledger.RecordTransfer(3, AccountInventory, AccountGoodsInTransit, "LOC-1", "CART-7", "MOV-1", "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 7, ledger.GetAccountBalance(AccountInventory).Balance)
+	assert.Equal(t, 3, ledger.GetAccountBalance(AccountGoodsInTransit).Balance)
+	assert.Equal(t, int64(1500), ledger.GetAccountBalance(AccountGoodsInTransit).Value.Amount())
+	assert.Equal(t, 10, ledger.CurrentBalance)
+
+	_, _, err = ledger.RecordTransfer(0, AccountInventory, AccountInventory, "LOC-1", "LOC-2", "MV-2", "user1")
+	assert.ErrorIs(t, err, ErrInvalidQuantity)
+	_, _, err = ledger.RecordTransfer(11, AccountInventory, AccountInventory, "LOC-1", "LOC-2", "MV-2", "user1")
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+}
@@ -0,0 +1,104 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+// LaborServiceClient handles communication with labor-service
+// Implements domain.LaborTaskQueue interface
+type LaborServiceClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewLaborServiceClient creates a new LaborServiceClient
+func NewLaborServiceClient(baseURL string) *LaborServiceClient {
+	return &LaborServiceClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// EnqueueTask queues a task for dispatch to a worker. Queueing a task twice is harmless.
+func (c *LaborServiceClient) EnqueueTask(ctx context.Context, task domain.LaborTask) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/queue", c.baseURL)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"taskId":   task.TaskID,
+		"taskType": task.TaskType,
+		"priority": task.Priority,
+		"zone":     task.Zone,
+		"waveId":   task.WaveID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setTenantHeaders(req, task)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue task: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("labor service returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// CompleteTask marks a queued task done, freeing the worker it was dispatched to
+func (c *LaborServiceClient) CompleteTask(ctx context.Context, task domain.LaborTask) error {
+	return c.closeTask(ctx, task, "complete")
+}
+
+// CancelTask withdraws a queued task
+func (c *LaborServiceClient) CancelTask(ctx context.Context, task domain.LaborTask) error {
+	return c.closeTask(ctx, task, "cancel")
+}
+
+func (c *LaborServiceClient) closeTask(ctx context.Context, task domain.LaborTask, action string) error {
+	url := fmt.Sprintf("%s/api/v1/tasks/queue/%s/%s", c.baseURL, task.TaskID, action)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	setTenantHeaders(req, task)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to %s task: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("labor service returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func setTenantHeaders(req *http.Request, task domain.LaborTask) {
+	req.Header.Set(middleware.HeaderWMSTenantID, task.TenantID)
+	req.Header.Set(middleware.HeaderWMSFacilityID, task.FacilityID)
+	req.Header.Set(middleware.HeaderWMSWarehouseID, task.WarehouseID)
+}
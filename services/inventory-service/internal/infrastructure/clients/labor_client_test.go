@@ -0,0 +1,85 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+func TestLaborServiceClient_EnqueueTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/tasks/queue", r.URL.Path)
+		assert.Equal(t, "TENANT-1", r.Header.Get(middleware.HeaderWMSTenantID))
+		assert.Equal(t, "FAC-1", r.Header.Get(middleware.HeaderWMSFacilityID))
+		assert.Equal(t, "WH-1", r.Header.Get(middleware.HeaderWMSWarehouseID))
+
+		var body struct {
+			TaskID   string `json:"taskId"`
+			TaskType string `json:"taskType"`
+			Priority int    `json:"priority"`
+			Zone     string `json:"zone"`
+			WaveID   string `json:"waveId"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "RPL-1", body.TaskID)
+		assert.Equal(t, "replenishment", body.TaskType)
+		assert.Equal(t, 2, body.Priority)
+		assert.Equal(t, "ZONE-A", body.Zone)
+		assert.Equal(t, "WAVE-1", body.WaveID)
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewLaborServiceClient(server.URL)
+	err := client.EnqueueTask(context.Background(), domain.LaborTask{
+		TenantID:    "TENANT-1",
+		FacilityID:  "FAC-1",
+		WarehouseID: "WH-1",
+		TaskID:      "RPL-1",
+		TaskType:    "replenishment",
+		Priority:    2,
+		Zone:        "ZONE-A",
+		WaveID:      "WAVE-1",
+	})
+	require.NoError(t, err)
+}
+
+func TestLaborServiceClient_CompleteAndCancelTask(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "TENANT-1", r.Header.Get(middleware.HeaderWMSTenantID))
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewLaborServiceClient(server.URL)
+	task := domain.LaborTask{TenantID: "TENANT-1", TaskID: "RPL-1"}
+	require.NoError(t, client.CompleteTask(context.Background(), task))
+	require.NoError(t, client.CancelTask(context.Background(), task))
+	assert.Equal(t, []string{"/api/v1/tasks/queue/RPL-1/complete", "/api/v1/tasks/queue/RPL-1/cancel"}, paths)
+}
+
+func TestLaborServiceClient_EnqueueTaskError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewLaborServiceClient(server.URL)
+	err := client.EnqueueTask(context.Background(), domain.LaborTask{TaskID: "RPL-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 503")
+}
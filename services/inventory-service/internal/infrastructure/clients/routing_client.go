@@ -0,0 +1,97 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/middleware"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+// RoutingServiceClient handles communication with routing-service
+// Implements domain.TravelDistanceEstimator interface
+type RoutingServiceClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewRoutingServiceClient creates a new RoutingServiceClient
+func NewRoutingServiceClient(baseURL string) *RoutingServiceClient {
+	return &RoutingServiceClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// routingLocation is a location as routing-service expects it
+type routingLocation struct {
+	LocationID string `json:"locationId"`
+	Aisle      string `json:"aisle"`
+	Rack       int    `json:"rack"`
+	Level      int    `json:"level"`
+	Zone       string `json:"zone"`
+}
+
+// DistancesFromPickStart measures, with routing-service's travel model over the warehouse
+// layout, the meters from the pick start of each slot's zone to the slot
+func (c *RoutingServiceClient) DistancesFromPickStart(ctx context.Context, slots []domain.SlotLocation) (map[string]float64, error) {
+	url := fmt.Sprintf("%s/api/v1/analysis/travel-distances", c.baseURL)
+
+	locations := make([]routingLocation, 0, len(slots))
+	for _, slot := range slots {
+		locations = append(locations, routingLocation{
+			LocationID: slot.LocationID,
+			Aisle:      slot.Aisle,
+			Rack:       slot.Rack,
+			Level:      slot.Level,
+			Zone:       slot.Zone,
+		})
+	}
+	body, err := json.Marshal(map[string]interface{}{"locations": locations})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.HeaderWMSTenantID, tenant.GetTenantID(ctx))
+	req.Header.Set(middleware.HeaderWMSFacilityID, tenant.GetFacilityID(ctx))
+	req.Header.Set(middleware.HeaderWMSWarehouseID, tenant.GetWarehouseID(ctx))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get travel distances: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("routing service returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Distances []struct {
+			LocationID string  `json:"locationId"`
+			Distance   float64 `json:"distance"`
+		} `json:"distances"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	distances := make(map[string]float64, len(result.Distances))
+	for _, d := range result.Distances {
+		distances[d.LocationID] = d.Distance
+	}
+	return distances, nil
+}
@@ -0,0 +1,63 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/shared/pkg/middleware"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+func TestRoutingServiceClient_DistancesFromPickStart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/analysis/travel-distances", r.URL.Path)
+		assert.Equal(t, "TENANT-1", r.Header.Get(middleware.HeaderWMSTenantID))
+		assert.Equal(t, "FAC-1", r.Header.Get(middleware.HeaderWMSFacilityID))
+
+		var body struct {
+			Locations []struct {
+				LocationID string `json:"locationId"`
+				Zone       string `json:"zone"`
+				Level      int    `json:"level"`
+			} `json:"locations"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Locations, 2)
+		assert.Equal(t, "A-01-2", body.Locations[0].LocationID)
+		assert.Equal(t, "ZONE-A", body.Locations[0].Zone)
+		assert.Equal(t, 2, body.Locations[0].Level)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"distances":[{"locationId":"A-01-2","zone":"ZONE-A","distance":4.5},{"locationId":"A-20-5","zone":"ZONE-A","distance":42}]}`))
+	}))
+	defer server.Close()
+
+	ctx := tenant.WithFacilityID(tenant.WithTenantID(context.Background(), "TENANT-1"), "FAC-1")
+	client := NewRoutingServiceClient(server.URL)
+	distances, err := client.DistancesFromPickStart(ctx, []domain.SlotLocation{
+		{LocationID: "A-01-2", Zone: "ZONE-A", Aisle: "A", Rack: 1, Level: 2},
+		{LocationID: "A-20-5", Zone: "ZONE-A", Aisle: "A", Rack: 20, Level: 5},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"A-01-2": 4.5, "A-20-5": 42}, distances)
+}
+
+func TestRoutingServiceClient_DistancesFromPickStartError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := NewRoutingServiceClient(server.URL)
+	_, err := client.DistancesFromPickStart(context.Background(), []domain.SlotLocation{{LocationID: "A-01-2"}})
+	assert.EqualError(t, err, "routing service returned status 400")
+}
@@ -0,0 +1,69 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+// UnitServiceClient handles communication with unit-service
+// Implements domain.UnitReleaser interface
+type UnitServiceClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewUnitServiceClient creates a new UnitServiceClient
+func NewUnitServiceClient(baseURL string) *UnitServiceClient {
+	return &UnitServiceClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// ReleaseUnits releases the given reserved units for an order
+func (c *UnitServiceClient) ReleaseUnits(ctx context.Context, release domain.UnitReleaseRequest) error {
+	url := fmt.Sprintf("%s/api/v1/units/release/%s", c.baseURL, release.OrderID)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"handlerId": "inventory-reservation-sweeper",
+		"reason":    release.Reason,
+		"unitIds":   release.UnitIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.HeaderWMSTenantID, release.TenantID)
+	req.Header.Set(middleware.HeaderWMSFacilityID, release.FacilityID)
+	req.Header.Set(middleware.HeaderWMSWarehouseID, release.WarehouseID)
+	if release.SellerID != "" {
+		req.Header.Set(middleware.HeaderWMSSellerID, release.SellerID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to release units: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unit service returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
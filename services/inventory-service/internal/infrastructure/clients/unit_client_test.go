@@ -0,0 +1,60 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+)
+
+func TestUnitServiceClient_ReleaseUnits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/units/release/ORD-001", r.URL.Path)
+		assert.Equal(t, "TENANT-1", r.Header.Get(middleware.HeaderWMSTenantID))
+		assert.Equal(t, "FAC-1", r.Header.Get(middleware.HeaderWMSFacilityID))
+		assert.Equal(t, "WH-1", r.Header.Get(middleware.HeaderWMSWarehouseID))
+
+		var body struct {
+			Reason  string   `json:"reason"`
+			UnitIDs []string `json:"unitIds"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Reservation expired", body.Reason)
+		assert.Equal(t, []string{"UNIT-1", "UNIT-2"}, body.UnitIDs)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewUnitServiceClient(server.URL)
+	err := client.ReleaseUnits(context.Background(), domain.UnitReleaseRequest{
+		TenantID:    "TENANT-1",
+		FacilityID:  "FAC-1",
+		WarehouseID: "WH-1",
+		OrderID:     "ORD-001",
+		UnitIDs:     []string{"UNIT-1", "UNIT-2"},
+		Reason:      "Reservation expired",
+	})
+	require.NoError(t, err)
+}
+
+func TestUnitServiceClient_ReleaseUnitsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewUnitServiceClient(server.URL)
+	err := client.ReleaseUnits(context.Background(), domain.UnitReleaseRequest{OrderID: "ORD-001"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 500")
+}
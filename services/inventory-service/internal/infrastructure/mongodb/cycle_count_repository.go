@@ -0,0 +1,169 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/cloudevents"
+	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
+	"github.com/wms-platform/shared/pkg/outbox"
+	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
+	"github.com/wms-platform/shared/pkg/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// openCycleCountStatuses are the statuses of tasks that still need work
+var openCycleCountStatuses = []domain.CycleCountStatus{
+	domain.CycleCountStatusPending,
+	domain.CycleCountStatusRecount,
+	domain.CycleCountStatusPendingApproval,
+	domain.CycleCountStatusApproved,
+}
+
+type CycleCountRepository struct {
+	collection   *mongo.Collection
+	db           *mongo.Database
+	outboxRepo   *outboxMongo.OutboxRepository
+	eventFactory *cloudevents.EventFactory
+	tenantHelper *tenant.RepositoryHelper
+}
+
+func NewCycleCountRepository(db *mongo.Database, eventFactory *cloudevents.EventFactory) *CycleCountRepository {
+	collection := db.Collection("cycle_count_tasks")
+	outboxRepo := outboxMongo.NewOutboxRepository(db)
+
+	repo := &CycleCountRepository{
+		collection:   collection,
+		db:           db,
+		outboxRepo:   outboxRepo,
+		eventFactory: eventFactory,
+		tenantHelper: tenant.NewRepositoryHelper(false),
+	}
+	repo.ensureIndexes(context.Background())
+
+	return repo
+}
+
+func (r *CycleCountRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "taskId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{
+			{Key: "tenantId", Value: 1},
+			{Key: "facilityId", Value: 1},
+			{Key: "sku", Value: 1},
+			{Key: "status", Value: 1},
+		}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "dueAt", Value: 1}}},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+// Save persists the task and its domain events to the outbox in one transaction.
+// It fails with ErrConcurrencyConflict if the task changed since it was loaded
+func (r *CycleCountRepository) Save(ctx context.Context, task *domain.CycleCountTask) error {
+	task.UpdatedAt = time.Now()
+	expectedVersion := task.Version
+	task.Version = expectedVersion + 1
+
+	session, err := r.db.Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"taskId": task.TaskID}, expectedVersion)
+		update := bson.M{"$set": task}
+
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "CycleCountTask", task.TaskID, expectedVersion); err != nil {
+			return nil, fmt.Errorf("failed to save cycle count task: %w", err)
+		}
+
+		domainEvents := task.PullEvents()
+		if len(domainEvents) > 0 {
+			outboxEvents := make([]*outbox.OutboxEvent, 0, len(domainEvents))
+			for _, event := range domainEvents {
+				cloudEvent := r.eventFactory.CreateEvent(sessCtx, event.EventType(), "inventory/"+task.SKU, event)
+
+				outboxEvent, err := outbox.NewOutboxEventFromCloudEvent(
+					task.TaskID,
+					"CycleCountTask",
+					kafka.Topics.InventoryEvents,
+					cloudEvent,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create outbox event: %w", err)
+				}
+				outboxEvents = append(outboxEvents, outboxEvent)
+			}
+
+			if err := r.outboxRepo.SaveAll(sessCtx, outboxEvents); err != nil {
+				return nil, fmt.Errorf("failed to save outbox events: %w", err)
+			}
+		}
+
+		return nil, nil
+	})
+
+	if err != nil {
+		task.Version = expectedVersion
+		return err
+	}
+	return nil
+}
+
+func (r *CycleCountRepository) FindByID(ctx context.Context, taskID string) (*domain.CycleCountTask, error) {
+	filter := bson.M{"taskId": taskID}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	var task domain.CycleCountTask
+	err := r.collection.FindOne(ctx, filter).Decode(&task)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return &task, err
+}
+
+func (r *CycleCountRepository) FindOpenBySKU(ctx context.Context, sku string) ([]*domain.CycleCountTask, error) {
+	filter := bson.M{
+		"sku":    sku,
+		"status": bson.M{"$in": openCycleCountStatuses},
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var tasks []*domain.CycleCountTask
+	err = cursor.All(ctx, &tasks)
+	return tasks, err
+}
+
+func (r *CycleCountRepository) FindByStatus(ctx context.Context, status domain.CycleCountStatus, limit int) ([]*domain.CycleCountTask, error) {
+	filter := bson.M{"status": bson.M{"$in": openCycleCountStatuses}}
+	if status != "" {
+		filter = bson.M{"status": status}
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "dueAt", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var tasks []*domain.CycleCountTask
+	err = cursor.All(ctx, &tasks)
+	return tasks, err
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/wms-platform/inventory-service/internal/domain"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -83,13 +84,19 @@ func (r *InventoryAllocationRepository) ensureIndexes(ctx context.Context) {
 	r.collection.Indexes().CreateMany(ctx, indexes)
 }
 
+// Save persists an allocation. The write only succeeds if the stored allocation still has
+// the version that was loaded, otherwise it fails with ErrConcurrencyConflict.
 func (r *InventoryAllocationRepository) Save(ctx context.Context, allocation *domain.InventoryAllocationAggregate) error {
-	opts := options.Update().SetUpsert(true)
-	filter := bson.M{"allocationId": allocation.AllocationID}
+	expectedVersion := allocation.Version
+	allocation.Version = expectedVersion + 1
+
+	opts := options.Update().SetUpsert(expectedVersion == 0)
+	filter := sharedMongo.VersionedFilter(bson.M{"allocationId": allocation.AllocationID}, expectedVersion)
 	update := bson.M{"$set": allocation}
 
-	_, err := r.collection.UpdateOne(ctx, filter, update, opts)
-	if err != nil {
+	result, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if err := sharedMongo.CheckVersionedUpdate(result, err, "InventoryAllocation", allocation.AllocationID, expectedVersion); err != nil {
+		allocation.Version = expectedVersion
 		return fmt.Errorf("failed to save allocation: %w", err)
 	}
 	return nil
@@ -232,6 +239,7 @@ func (r *InventoryAllocationRepository) UpdateStatus(ctx context.Context, alloca
 			"status":    newStatus,
 			"updatedAt": time.Now(),
 		},
+		"$inc": bson.M{sharedMongo.VersionField: 1},
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -0,0 +1,93 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
+	"github.com/wms-platform/shared/pkg/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type InventoryHoldRepository struct {
+	collection   *mongo.Collection
+	tenantHelper *tenant.RepositoryHelper
+}
+
+func NewInventoryHoldRepository(db *mongo.Database) *InventoryHoldRepository {
+	repo := &InventoryHoldRepository{
+		collection:   db.Collection("inventory_holds"),
+		tenantHelper: tenant.NewRepositoryHelper(false),
+	}
+	repo.ensureIndexes(context.Background())
+
+	return repo
+}
+
+func (r *InventoryHoldRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "holdId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{
+			{Key: "tenantId", Value: 1},
+			{Key: "facilityId", Value: 1},
+			{Key: "status", Value: 1},
+			{Key: "createdAt", Value: -1},
+		}},
+		{Keys: bson.D{{Key: "placements.sku", Value: 1}}},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+// Save persists a hold. The write only succeeds if the stored hold still has the version
+// that was loaded, otherwise it fails with ErrConcurrencyConflict.
+func (r *InventoryHoldRepository) Save(ctx context.Context, hold *domain.InventoryHold) error {
+	hold.UpdatedAt = time.Now()
+	expectedVersion := hold.Version
+	hold.Version = expectedVersion + 1
+
+	opts := options.Update().SetUpsert(expectedVersion == 0)
+	filter := sharedMongo.VersionedFilter(bson.M{"holdId": hold.HoldID}, expectedVersion)
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": hold}, opts)
+	if err := sharedMongo.CheckVersionedUpdate(result, err, "InventoryHold", hold.HoldID, expectedVersion); err != nil {
+		hold.Version = expectedVersion
+		return fmt.Errorf("failed to save inventory hold: %w", err)
+	}
+	return nil
+}
+
+func (r *InventoryHoldRepository) FindByID(ctx context.Context, holdID string) (*domain.InventoryHold, error) {
+	filter := bson.M{"holdId": holdID}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	var hold domain.InventoryHold
+	err := r.collection.FindOne(ctx, filter).Decode(&hold)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return &hold, err
+}
+
+func (r *InventoryHoldRepository) FindByStatus(ctx context.Context, status domain.HoldStatus, limit int) ([]*domain.InventoryHold, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var holds []*domain.InventoryHold
+	err = cursor.All(ctx, &holds)
+	return holds, err
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/inventory-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -49,12 +50,19 @@ func (r *InventoryRepository) ensureIndexes(ctx context.Context) {
 		{Keys: bson.D{{Key: "locations.locationId", Value: 1}}},
 		{Keys: bson.D{{Key: "locations.zone", Value: 1}}},
 		{Keys: bson.D{{Key: "availableQuantity", Value: 1}}},
+		{Keys: bson.D{{Key: "locations.lots.expiryDate", Value: 1}}},
+		{Keys: bson.D{{Key: "reservations.status", Value: 1}, {Key: "reservations.expiresAt", Value: 1}}},
+		{Keys: bson.D{{Key: "velocityClass", Value: 1}, {Key: "lastCycleCount", Value: 1}}},
 	}
 	r.collection.Indexes().CreateMany(ctx, indexes)
 }
 
+// Save persists the item if it is unchanged since it was loaded and fails with
+// ErrConcurrencyConflict otherwise
 func (r *InventoryRepository) Save(ctx context.Context, item *domain.InventoryItem) error {
 	item.UpdatedAt = time.Now()
+	expectedVersion := item.Version
+	item.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -66,11 +74,12 @@ func (r *InventoryRepository) Save(ctx context.Context, item *domain.InventoryIt
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"sku": item.SKU}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"sku": item.SKU}, expectedVersion)
 		update := bson.M{"$set": item}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "InventoryItem", item.SKU, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save inventory item: %w", err)
 		}
 
@@ -89,9 +98,28 @@ func (r *InventoryRepository) Save(ctx context.Context, item *domain.InventoryIt
 					cloudEvent = r.eventFactory.CreateInventoryAdjustedEvent(sessCtx, e.SKU, e.LocationID, e.OldQuantity, e.NewQuantity, "adjustment", e.Reason)
 				case *domain.LowStockAlertEvent:
 					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "inventory/"+e.SKU, e)
+				case *domain.LotExpiringEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "inventory/"+e.SKU, e)
+				case *domain.LotExpiredEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "inventory/"+e.SKU, e)
+				case *domain.ReservationExpiredEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "inventory/"+e.SKU, e)
+				case *domain.StockMovedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "inventory/"+e.SKU, e)
+				case *domain.InventoryStatusChangedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "inventory/"+e.SKU, e)
+				case *domain.StockMoveStartedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "inventory/"+e.SKU, e)
 				default:
 					continue
 				}
+				// Consumers such as stow-service scope the event to the item's tenant and facility
+				cloudEvent.SetTenantContext(&tenant.Context{
+					TenantID:    item.TenantID,
+					FacilityID:  item.FacilityID,
+					WarehouseID: item.WarehouseID,
+					SellerID:    item.SellerID,
+				})
 
 				// Create outbox event from CloudEvent
 				outboxEvent, err := outbox.NewOutboxEventFromCloudEvent(
@@ -122,6 +150,7 @@ func (r *InventoryRepository) Save(ctx context.Context, item *domain.InventoryIt
 	})
 
 	if err != nil {
+		item.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
@@ -213,6 +242,129 @@ func (r *InventoryRepository) FindAll(ctx context.Context, limit, offset int) ([
 	return items, err
 }
 
+func (r *InventoryRepository) FindWithLotsExpiringBefore(ctx context.Context, now, cutoff time.Time, limit int) ([]*domain.InventoryItem, error) {
+	// Lots that were already warned keep matching the cutoff until they expire, so only
+	// unwarned lots and lots that are now due for blocking are selected. Without this the
+	// same warned items would fill every batch and newly expiring lots would never be seen.
+	filter := bson.M{
+		"locations.lots": bson.M{"$elemMatch": bson.M{
+			"status":     domain.LotStatusActive,
+			"expiryDate": bson.M{"$lte": cutoff},
+			"$or": bson.A{
+				bson.M{"expiryWarnedAt": nil},
+				bson.M{"expiryDate": bson.M{"$lte": now}},
+			},
+		}},
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "locations.lots.expiryDate", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var items []*domain.InventoryItem
+	err = cursor.All(ctx, &items)
+	return items, err
+}
+
+func (r *InventoryRepository) FindWithExpiredReservations(ctx context.Context, asOf time.Time, tenantID string, limit int) ([]*domain.InventoryItem, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"reservations": bson.M{"$elemMatch": bson.M{
+				"status":    "active",
+				"expiresAt": bson.M{"$lt": asOf},
+			}}},
+			bson.M{"reservations.unitReleasePending": true},
+		},
+	}
+	if tenantID != "" {
+		filter["tenantId"] = tenantID
+	} else {
+		filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+	}
+
+	opts := options.Find().SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var items []*domain.InventoryItem
+	err = cursor.All(ctx, &items)
+	return items, err
+}
+
+func (r *InventoryRepository) FindDueForCycleCount(ctx context.Context, cutoffs map[domain.VelocityClass]time.Time, limit int) ([]*domain.InventoryItem, error) {
+	neverCounted := bson.M{"lastCycleCount": bson.M{"$exists": false}}
+	classes := make([]bson.M, 0, len(cutoffs)+1)
+	for class, cutoff := range cutoffs {
+		classFilter := bson.M{"velocityClass": class}
+		if class == domain.VelocityC {
+			// Items without a velocity class are counted with the C items
+			classFilter = bson.M{"velocityClass": bson.M{"$nin": []domain.VelocityClass{domain.VelocityA, domain.VelocityB}}}
+		}
+		classes = append(classes, bson.M{"$and": []bson.M{
+			classFilter,
+			{"$or": []bson.M{neverCounted, {"lastCycleCount": bson.M{"$lt": cutoff}}}},
+		}})
+	}
+
+	filter := bson.M{
+		"totalQuantity": bson.M{"$gt": 0},
+		"$or":           classes,
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "lastCycleCount", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var items []*domain.InventoryItem
+	err = cursor.All(ctx, &items)
+	return items, err
+}
+
+func (r *InventoryRepository) FindBelowPickFaceMinimum(ctx context.Context, limit int) ([]*domain.InventoryItem, error) {
+	// A pick face needs stock when its shelf quantity (on hand less staged) is below its minimum
+	belowMinimum := bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+		"input": bson.M{"$ifNull": bson.A{"$locations", bson.A{}}},
+		"as":    "loc",
+		"in": bson.M{"$and": bson.A{
+			bson.M{"$gt": bson.A{"$$loc.maxQuantity", 0}},
+			bson.M{"$lt": bson.A{
+				bson.M{"$subtract": bson.A{"$$loc.quantity", bson.M{"$ifNull": bson.A{"$$loc.hardAllocated", 0}}}},
+				"$$loc.minQuantity",
+			}},
+		}},
+	}}}}
+
+	filter := bson.M{
+		"locations.maxQuantity": bson.M{"$gt": 0},
+		"$expr":                 belowMinimum,
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updatedAt", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var items []*domain.InventoryItem
+	err = cursor.All(ctx, &items)
+	return items, err
+}
+
 func (r *InventoryRepository) Delete(ctx context.Context, sku string) error {
 	filter := bson.M{"sku": sku}
 	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
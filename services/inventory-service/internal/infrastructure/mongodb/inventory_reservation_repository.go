@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/wms-platform/inventory-service/internal/domain"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -77,13 +78,19 @@ func (r *InventoryReservationRepository) ensureIndexes(ctx context.Context) {
 	r.collection.Indexes().CreateMany(ctx, indexes)
 }
 
+// Save persists a reservation. The write only succeeds if the stored reservation still has
+// the version that was loaded, otherwise it fails with ErrConcurrencyConflict.
 func (r *InventoryReservationRepository) Save(ctx context.Context, reservation *domain.InventoryReservationAggregate) error {
-	opts := options.Update().SetUpsert(true)
-	filter := bson.M{"reservationId": reservation.ReservationID}
+	expectedVersion := reservation.Version
+	reservation.Version = expectedVersion + 1
+
+	opts := options.Update().SetUpsert(expectedVersion == 0)
+	filter := sharedMongo.VersionedFilter(bson.M{"reservationId": reservation.ReservationID}, expectedVersion)
 	update := bson.M{"$set": reservation}
 
-	_, err := r.collection.UpdateOne(ctx, filter, update, opts)
-	if err != nil {
+	result, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if err := sharedMongo.CheckVersionedUpdate(result, err, "InventoryReservation", reservation.ReservationID, expectedVersion); err != nil {
+		reservation.Version = expectedVersion
 		return fmt.Errorf("failed to save reservation: %w", err)
 	}
 	return nil
@@ -201,6 +208,7 @@ func (r *InventoryReservationRepository) UpdateStatus(ctx context.Context, reser
 			"status":    newStatus,
 			"updatedAt": time.Now(),
 		},
+		"$inc": bson.M{sharedMongo.VersionField: 1},
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -256,6 +256,29 @@ func (r *LedgerEntryRepository) FindByTransactionID(ctx context.Context, tenantI
 	return entries, nil
 }
 
+func (r *LedgerEntryRepository) FindByReference(ctx context.Context, tenantID, facilityID, sku, referenceType, referenceID string) ([]*domain.LedgerEntryAggregate, error) {
+	filter := bson.M{
+		"tenantId":            tenantID,
+		"facilityId":          facilityID,
+		"entry.sku":           sku,
+		"entry.referenceType": referenceType,
+		"entry.referenceId":   referenceID,
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*domain.LedgerEntryAggregate
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode entries: %w", err)
+	}
+
+	return entries, nil
+}
+
 func (r *LedgerEntryRepository) FindByTimeRange(ctx context.Context, tenantID, facilityID, sku string, start, end time.Time) ([]*domain.LedgerEntryAggregate, error) {
 	filter := bson.M{
 		"tenantId":   tenantID,
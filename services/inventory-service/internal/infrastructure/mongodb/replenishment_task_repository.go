@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/cloudevents"
+	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
+	"github.com/wms-platform/shared/pkg/outbox"
+	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
+	"github.com/wms-platform/shared/pkg/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// replenishmentTaskSort orders tasks by priority (1 = highest), oldest first
+var replenishmentTaskSort = bson.D{{Key: "priority", Value: 1}, {Key: "createdAt", Value: 1}}
+
+type ReplenishmentTaskRepository struct {
+	collection   *mongo.Collection
+	db           *mongo.Database
+	outboxRepo   *outboxMongo.OutboxRepository
+	eventFactory *cloudevents.EventFactory
+	tenantHelper *tenant.RepositoryHelper
+}
+
+func NewReplenishmentTaskRepository(db *mongo.Database, eventFactory *cloudevents.EventFactory) *ReplenishmentTaskRepository {
+	collection := db.Collection("replenishment_tasks")
+	outboxRepo := outboxMongo.NewOutboxRepository(db)
+
+	repo := &ReplenishmentTaskRepository{
+		collection:   collection,
+		db:           db,
+		outboxRepo:   outboxRepo,
+		eventFactory: eventFactory,
+		tenantHelper: tenant.NewRepositoryHelper(false),
+	}
+	repo.ensureIndexes(context.Background())
+
+	return repo
+}
+
+func (r *ReplenishmentTaskRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "taskId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{
+			{Key: "tenantId", Value: 1},
+			{Key: "facilityId", Value: 1},
+			{Key: "sku", Value: 1},
+			{Key: "status", Value: 1},
+		}},
+		{Keys: bson.D{{Key: "waveId", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "priority", Value: 1}, {Key: "createdAt", Value: 1}}},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+// Save persists the task and its domain events to the outbox in one transaction.
+// It fails with ErrConcurrencyConflict if the task changed since it was loaded
+func (r *ReplenishmentTaskRepository) Save(ctx context.Context, task *domain.ReplenishmentTask) error {
+	task.UpdatedAt = time.Now()
+	expectedVersion := task.Version
+	task.Version = expectedVersion + 1
+
+	session, err := r.db.Client().StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"taskId": task.TaskID}, expectedVersion)
+		update := bson.M{"$set": task}
+
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "ReplenishmentTask", task.TaskID, expectedVersion); err != nil {
+			return nil, fmt.Errorf("failed to save replenishment task: %w", err)
+		}
+
+		domainEvents := task.PullEvents()
+		if len(domainEvents) > 0 {
+			outboxEvents := make([]*outbox.OutboxEvent, 0, len(domainEvents))
+			for _, event := range domainEvents {
+				cloudEvent := r.eventFactory.CreateEvent(sessCtx, event.EventType(), "inventory/"+task.SKU, event)
+
+				outboxEvent, err := outbox.NewOutboxEventFromCloudEvent(
+					task.TaskID,
+					"ReplenishmentTask",
+					kafka.Topics.InventoryEvents,
+					cloudEvent,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create outbox event: %w", err)
+				}
+				outboxEvents = append(outboxEvents, outboxEvent)
+			}
+
+			if err := r.outboxRepo.SaveAll(sessCtx, outboxEvents); err != nil {
+				return nil, fmt.Errorf("failed to save outbox events: %w", err)
+			}
+		}
+
+		return nil, nil
+	})
+
+	if err != nil {
+		task.Version = expectedVersion
+		return err
+	}
+	return nil
+}
+
+func (r *ReplenishmentTaskRepository) FindByID(ctx context.Context, taskID string) (*domain.ReplenishmentTask, error) {
+	filter := bson.M{"taskId": taskID}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	var task domain.ReplenishmentTask
+	err := r.collection.FindOne(ctx, filter).Decode(&task)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return &task, err
+}
+
+func (r *ReplenishmentTaskRepository) FindOpenBySKU(ctx context.Context, sku string) ([]*domain.ReplenishmentTask, error) {
+	filter := bson.M{
+		"sku":    sku,
+		"status": domain.ReplenishmentStatusPending,
+	}
+	return r.find(ctx, filter, 0)
+}
+
+func (r *ReplenishmentTaskRepository) FindByWave(ctx context.Context, waveID string) ([]*domain.ReplenishmentTask, error) {
+	return r.find(ctx, bson.M{"waveId": waveID}, 0)
+}
+
+func (r *ReplenishmentTaskRepository) FindByStatus(ctx context.Context, status domain.ReplenishmentTaskStatus, limit int) ([]*domain.ReplenishmentTask, error) {
+	if status == "" {
+		status = domain.ReplenishmentStatusPending
+	}
+	return r.find(ctx, bson.M{"status": status}, limit)
+}
+
+func (r *ReplenishmentTaskRepository) FindNotQueued(ctx context.Context, limit int) ([]*domain.ReplenishmentTask, error) {
+	filter := bson.M{
+		"status":        domain.ReplenishmentStatusPending,
+		"laborQueuedAt": bson.M{"$exists": false},
+	}
+	return r.find(ctx, filter, limit)
+}
+
+func (r *ReplenishmentTaskRepository) find(ctx context.Context, filter bson.M, limit int) ([]*domain.ReplenishmentTask, error) {
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().SetSort(replenishmentTaskSort)
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var tasks []*domain.ReplenishmentTask
+	err = cursor.All(ctx, &tasks)
+	return tasks, err
+}
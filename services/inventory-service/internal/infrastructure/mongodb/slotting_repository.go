@@ -0,0 +1,164 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/inventory-service/internal/domain"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
+	"github.com/wms-platform/shared/pkg/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SlotLocationRepository struct {
+	collection   *mongo.Collection
+	tenantHelper *tenant.RepositoryHelper
+}
+
+func NewSlotLocationRepository(db *mongo.Database) *SlotLocationRepository {
+	repo := &SlotLocationRepository{
+		collection:   db.Collection("slot_locations"),
+		tenantHelper: tenant.NewRepositoryHelper(false),
+	}
+	repo.ensureIndexes(context.Background())
+
+	return repo
+}
+
+func (r *SlotLocationRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "tenantId", Value: 1},
+				{Key: "facilityId", Value: 1},
+				{Key: "locationId", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "zone", Value: 1}}},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+func (r *SlotLocationRepository) Save(ctx context.Context, slot *domain.SlotLocation) error {
+	slot.UpdatedAt = time.Now()
+
+	filter := bson.M{
+		"tenantId":   slot.TenantID,
+		"facilityId": slot.FacilityID,
+		"locationId": slot.LocationID,
+	}
+	opts := options.Update().SetUpsert(true)
+	if _, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": slot}, opts); err != nil {
+		return fmt.Errorf("failed to save slot location: %w", err)
+	}
+	return nil
+}
+
+func (r *SlotLocationRepository) FindByZone(ctx context.Context, zone string) ([]*domain.SlotLocation, error) {
+	filter := bson.M{}
+	if zone != "" {
+		filter["zone"] = zone
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().SetSort(bson.D{{Key: "locationId", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var slots []*domain.SlotLocation
+	err = cursor.All(ctx, &slots)
+	return slots, err
+}
+
+type SlottingRecommendationRepository struct {
+	collection   *mongo.Collection
+	tenantHelper *tenant.RepositoryHelper
+}
+
+func NewSlottingRecommendationRepository(db *mongo.Database) *SlottingRecommendationRepository {
+	repo := &SlottingRecommendationRepository{
+		collection:   db.Collection("slotting_recommendations"),
+		tenantHelper: tenant.NewRepositoryHelper(false),
+	}
+	repo.ensureIndexes(context.Background())
+
+	return repo
+}
+
+func (r *SlottingRecommendationRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "recommendationId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{
+			{Key: "tenantId", Value: 1},
+			{Key: "facilityId", Value: 1},
+			{Key: "status", Value: 1},
+			{Key: "travelSavings", Value: -1},
+		}},
+		{Keys: bson.D{{Key: "sku", Value: 1}}},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+// Save persists the recommendation if it is unchanged since it was loaded and
+// fails with ErrConcurrencyConflict otherwise
+func (r *SlottingRecommendationRepository) Save(ctx context.Context, rec *domain.SlottingRecommendation) error {
+	rec.UpdatedAt = time.Now()
+	expectedVersion := rec.Version
+	rec.Version = expectedVersion + 1
+
+	opts := options.Update().SetUpsert(expectedVersion == 0)
+	filter := sharedMongo.VersionedFilter(bson.M{"recommendationId": rec.RecommendationID}, expectedVersion)
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": rec}, opts)
+	if err := sharedMongo.CheckVersionedUpdate(result, err, "SlottingRecommendation", rec.RecommendationID, expectedVersion); err != nil {
+		rec.Version = expectedVersion
+		return fmt.Errorf("failed to save slotting recommendation: %w", err)
+	}
+	return nil
+}
+
+func (r *SlottingRecommendationRepository) FindByID(ctx context.Context, recommendationID string) (*domain.SlottingRecommendation, error) {
+	filter := bson.M{"recommendationId": recommendationID}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	var rec domain.SlottingRecommendation
+	err := r.collection.FindOne(ctx, filter).Decode(&rec)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return &rec, err
+}
+
+func (r *SlottingRecommendationRepository) FindOpen(ctx context.Context) ([]*domain.SlottingRecommendation, error) {
+	filter := bson.M{"status": bson.M{"$in": []domain.SlottingRecommendationStatus{
+		domain.SlottingStatusPending,
+		domain.SlottingStatusApproved,
+	}}}
+	return r.find(ctx, filter, 0)
+}
+
+func (r *SlottingRecommendationRepository) FindByStatus(ctx context.Context, status domain.SlottingRecommendationStatus, limit int) ([]*domain.SlottingRecommendation, error) {
+	return r.find(ctx, bson.M{"status": status}, limit)
+}
+
+func (r *SlottingRecommendationRepository) find(ctx context.Context, filter bson.M, limit int) ([]*domain.SlottingRecommendation, error) {
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().SetSort(bson.D{{Key: "travelSavings", Value: -1}, {Key: "createdAt", Value: 1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var recs []*domain.SlottingRecommendation
+	err = cursor.All(ctx, &recs)
+	return recs, err
+}
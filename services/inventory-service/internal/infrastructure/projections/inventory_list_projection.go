@@ -10,9 +10,11 @@ type InventoryListProjection struct {
 	ProductName string `bson:"productName"`
 
 	// Quantity tracking
-	TotalQuantity     int `bson:"totalQuantity"`
-	ReservedQuantity  int `bson:"reservedQuantity"`
-	AvailableQuantity int `bson:"availableQuantity"`
+	TotalQuantity       int `bson:"totalQuantity"`
+	ReservedQuantity    int `bson:"reservedQuantity"`
+	AvailableQuantity   int `bson:"availableQuantity"`
+	NonSellableQuantity int `bson:"nonSellableQuantity"` // Damaged, quarantined, on hold, etc.
+	InTransitQuantity   int `bson:"inTransitQuantity"`   // On carts or totes mid-move
 
 	// Reorder management
 	ReorderPoint    int  `bson:"reorderPoint"`
@@ -220,6 +220,72 @@ func (p *InventoryProjector) OnInventoryDiscrepancy(ctx context.Context, event *
 	return p.projectionRepo.UpdateFields(ctx, event.SKU, updates)
 }
 
+// OnStockMoved handles StockMovedEvent. Totals are unchanged by a move, only where the stock sits.
+func (p *InventoryProjector) OnStockMoved(ctx context.Context, event *domain.StockMovedEvent) error {
+	item, err := p.inventoryRepo.FindBySKU(ctx, event.SKU)
+	if err != nil || item == nil {
+		p.logger.Error("Failed to find inventory for projection", "sku", event.SKU, "error", err)
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"availableQuantity":   item.AvailableQuantity,
+		"nonSellableQuantity": item.NonSellableQuantity,
+		"inTransitQuantity":   item.InTransitQuantity,
+		"isLowStock":          item.AvailableQuantity <= item.ReorderPoint,
+		"isOutOfStock":        item.AvailableQuantity == 0,
+		"locationCount":       len(item.Locations),
+		"availableLocations":  p.extractAvailableLocations(item),
+		"primaryLocation":     p.findPrimaryLocation(item),
+	}
+
+	return p.projectionRepo.UpdateFields(ctx, event.SKU, updates)
+}
+
+// OnStockMoveStarted handles StockMoveStartedEvent. Stock picked onto a cart or tote is in
+// transit and no longer available at its source.
+func (p *InventoryProjector) OnStockMoveStarted(ctx context.Context, event *domain.StockMoveStartedEvent) error {
+	item, err := p.inventoryRepo.FindBySKU(ctx, event.SKU)
+	if err != nil || item == nil {
+		p.logger.Error("Failed to find inventory for projection", "sku", event.SKU, "error", err)
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"availableQuantity":   item.AvailableQuantity,
+		"nonSellableQuantity": item.NonSellableQuantity,
+		"inTransitQuantity":   item.InTransitQuantity,
+		"isLowStock":          item.AvailableQuantity <= item.ReorderPoint,
+		"isOutOfStock":        item.AvailableQuantity == 0,
+		"locationCount":       len(item.Locations),
+		"availableLocations":  p.extractAvailableLocations(item),
+		"primaryLocation":     p.findPrimaryLocation(item),
+	}
+
+	return p.projectionRepo.UpdateFields(ctx, event.SKU, updates)
+}
+
+// OnInventoryStatusChanged handles InventoryStatusChangedEvent. Stock moving between sellable
+// and non-sellable statuses changes what is available, not the total.
+func (p *InventoryProjector) OnInventoryStatusChanged(ctx context.Context, event *domain.InventoryStatusChangedEvent) error {
+	item, err := p.inventoryRepo.FindBySKU(ctx, event.SKU)
+	if err != nil || item == nil {
+		p.logger.Error("Failed to find inventory for projection", "sku", event.SKU, "error", err)
+		return err
+	}
+
+	updates := map[string]interface{}{
+		"availableQuantity":   item.AvailableQuantity,
+		"nonSellableQuantity": item.NonSellableQuantity,
+		"isLowStock":          item.AvailableQuantity <= item.ReorderPoint,
+		"isOutOfStock":        item.AvailableQuantity == 0,
+		"availableLocations":  p.extractAvailableLocations(item),
+		"primaryLocation":     p.findPrimaryLocation(item),
+	}
+
+	return p.projectionRepo.UpdateFields(ctx, event.SKU, updates)
+}
+
 // buildProjectionFromAggregate creates a new projection from a full aggregate
 func (p *InventoryProjector) buildProjectionFromAggregate(item *domain.InventoryItem) *InventoryListProjection {
 	// Extract active reservation order IDs
@@ -233,23 +299,25 @@ func (p *InventoryProjector) buildProjectionFromAggregate(item *domain.Inventory
 	}
 
 	projection := &InventoryListProjection{
-		SKU:                item.SKU,
-		ProductName:        item.ProductName,
-		TotalQuantity:      item.TotalQuantity,
-		ReservedQuantity:   item.ReservedQuantity,
-		AvailableQuantity:  item.AvailableQuantity,
-		ReorderPoint:       item.ReorderPoint,
-		ReorderQuantity:    item.ReorderQuantity,
-		IsLowStock:         item.AvailableQuantity <= item.ReorderPoint,
-		IsOutOfStock:       item.AvailableQuantity == 0,
-		LocationCount:      len(item.Locations),
-		PrimaryLocation:    p.findPrimaryLocation(item),
-		AvailableLocations: p.extractAvailableLocations(item),
-		ActiveReservations: activeReservations,
-		ReservedOrders:     reservedOrders,
-		LastCycleCount:     item.LastCycleCount,
-		CreatedAt:          item.CreatedAt,
-		UpdatedAt:          item.UpdatedAt,
+		SKU:                 item.SKU,
+		ProductName:         item.ProductName,
+		TotalQuantity:       item.TotalQuantity,
+		ReservedQuantity:    item.ReservedQuantity,
+		AvailableQuantity:   item.AvailableQuantity,
+		NonSellableQuantity: item.NonSellableQuantity,
+		InTransitQuantity:   item.InTransitQuantity,
+		ReorderPoint:        item.ReorderPoint,
+		ReorderQuantity:     item.ReorderQuantity,
+		IsLowStock:          item.AvailableQuantity <= item.ReorderPoint,
+		IsOutOfStock:        item.AvailableQuantity == 0,
+		LocationCount:       len(item.Locations),
+		PrimaryLocation:     p.findPrimaryLocation(item),
+		AvailableLocations:  p.extractAvailableLocations(item),
+		ActiveReservations:  activeReservations,
+		ReservedOrders:      reservedOrders,
+		LastCycleCount:      item.LastCycleCount,
+		CreatedAt:           item.CreatedAt,
+		UpdatedAt:           item.UpdatedAt,
 	}
 
 	return projection
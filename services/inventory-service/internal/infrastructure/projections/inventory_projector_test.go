@@ -91,6 +91,22 @@ func (p *projectorInventoryRepo) Delete(ctx context.Context, sku string) error {
 	return nil
 }
 
+func (p *projectorInventoryRepo) FindWithLotsExpiringBefore(ctx context.Context, now, cutoff time.Time, limit int) ([]*domain.InventoryItem, error) {
+	return nil, nil
+}
+
+func (p *projectorInventoryRepo) FindWithExpiredReservations(ctx context.Context, asOf time.Time, tenantID string, limit int) ([]*domain.InventoryItem, error) {
+	return nil, nil
+}
+
+func (p *projectorInventoryRepo) FindDueForCycleCount(ctx context.Context, cutoffs map[domain.VelocityClass]time.Time, limit int) ([]*domain.InventoryItem, error) {
+	return nil, nil
+}
+
+func (p *projectorInventoryRepo) FindBelowPickFaceMinimum(ctx context.Context, limit int) ([]*domain.InventoryItem, error) {
+	return nil, nil
+}
+
 func TestInventoryProjector_OnInventoryReceived(t *testing.T) {
 	item := domain.NewInventoryItem("SKU-1", "Widget", 5, 10)
 	require.NoError(t, item.ReceiveStock("LOC-1", "ZONE-A", 5, "PO-1", "user1"))
@@ -163,6 +179,9 @@ func TestInventoryProjector_OnShortageAndDiscrepancy(t *testing.T) {
 
 	require.NoError(t, projector.OnInventoryDiscrepancy(context.Background(), &domain.InventoryDiscrepancyEvent{SKU: "SKU-4", DiscrepancyType: "shortage"}))
 	require.Contains(t, projRepo.updates["SKU-4"], "lastDiscrepancy")
+
+	require.NoError(t, projector.OnStockMoved(context.Background(), &domain.StockMovedEvent{SKU: "SKU-4"}))
+	require.Contains(t, projRepo.updates["SKU-4"], "primaryLocation")
 }
 
 func TestInventoryProjector_Helpers(t *testing.T) {
@@ -0,0 +1,169 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/inventory-service/internal/application"
+	"github.com/wms-platform/inventory-service/internal/domain"
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
+)
+
+// TestInventoryRepository_StaleSaveConflicts tests that saving a stale copy of an item is rejected
+func TestInventoryRepository_StaleSaveConflicts(t *testing.T) {
+	repo, _, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	item := domain.NewInventoryItem("SKU-CONC-001", "Concurrency Product", 10, 50)
+	require.NoError(t, item.ReceiveStock("H-10-1-A", "ZONE-H", 10, "PO-1", "tester"))
+	require.NoError(t, repo.Save(ctx, item))
+
+	first, err := repo.FindBySKU(ctx, item.SKU)
+	require.NoError(t, err)
+	second, err := repo.FindBySKU(ctx, item.SKU)
+	require.NoError(t, err)
+
+	require.NoError(t, first.Reserve("ORD-1", "H-10-1-A", 6))
+	require.NoError(t, repo.Save(ctx, first))
+
+	require.NoError(t, second.Reserve("ORD-2", "H-10-1-A", 6))
+	err = repo.Save(ctx, second)
+	require.Error(t, err)
+	assert.True(t, sharedErrors.IsConcurrencyConflict(err))
+
+	stored, err := repo.FindBySKU(ctx, item.SKU)
+	require.NoError(t, err)
+	assert.Equal(t, 6, stored.ReservedQuantity)
+	assert.Equal(t, 4, stored.AvailableQuantity)
+	assert.Equal(t, first.Version, stored.Version)
+}
+
+// TestInventoryApplicationService_ConcurrentReservations hammers a single item with
+// parallel reservations and verifies it is never oversold.
+func TestInventoryApplicationService_ConcurrentReservations(t *testing.T) {
+	repo, _, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	const available = 50
+	const workers = 100
+
+	item := domain.NewInventoryItem("SKU-CONC-002", "Contended Product", 10, 50)
+	require.NoError(t, item.ReceiveStock("H-10-1-B", "ZONE-H", available, "PO-1", "tester"))
+	require.NoError(t, repo.Save(ctx, item))
+	initialVersion := item.Version
+
+	service := application.NewInventoryApplicationService(repo, nil, nil, nil, logging.New(logging.DefaultConfig("test")))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := service.Reserve(ctx, application.ReserveCommand{
+				SKU:        item.SKU,
+				OrderID:    fmt.Sprintf("ORD-CONC-%03d", i),
+				LocationID: "H-10-1-B",
+				Quantity:   1,
+			})
+			if err == nil {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	stored, err := repo.FindBySKU(ctx, item.SKU)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, reserved, available)
+	assert.Equal(t, reserved, stored.ReservedQuantity)
+	assert.Len(t, stored.Reservations, reserved)
+	assert.Equal(t, available-reserved, stored.AvailableQuantity)
+	assert.GreaterOrEqual(t, stored.AvailableQuantity, 0)
+	assert.Equal(t, initialVersion+reserved, stored.Version, "every successful reservation saves exactly one new version")
+}
+
+// TestInventoryApplicationService_ConcurrentReservationsWithinRetryBudget runs no more
+// parallel reservations than the conflict retry allows attempts. Every conflict means
+// another reservation was saved, so each one either succeeds or runs out of stock.
+func TestInventoryApplicationService_ConcurrentReservationsWithinRetryBudget(t *testing.T) {
+	repo, _, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	const workers = resilience.DefaultConflictRetryMaxAttempts
+
+	tests := []struct {
+		name  string
+		sku   string
+		stock int
+	}{
+		{name: "stock covers every worker", sku: "SKU-CONC-003", stock: workers + 2},
+		{name: "workers exceed stock", sku: "SKU-CONC-004", stock: workers - 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			item := domain.NewInventoryItem(tt.sku, "Contended Product", 10, 50)
+			require.NoError(t, item.ReceiveStock("H-10-1-C", "ZONE-H", tt.stock, "PO-1", "tester"))
+			require.NoError(t, repo.Save(ctx, item))
+			initialVersion := item.Version
+
+			service := application.NewInventoryApplicationService(repo, nil, nil, nil, logging.New(logging.DefaultConfig("test")))
+
+			var wg sync.WaitGroup
+			var mu sync.Mutex
+			reserved := 0
+			var unexpected []error
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					_, err := service.Reserve(ctx, application.ReserveCommand{
+						SKU:        tt.sku,
+						OrderID:    fmt.Sprintf("ORD-%s-%d", tt.sku, i),
+						LocationID: "H-10-1-C",
+						Quantity:   1,
+					})
+					mu.Lock()
+					defer mu.Unlock()
+					if err == nil {
+						reserved++
+					} else if sharedErrors.IsConcurrencyConflict(err) {
+						unexpected = append(unexpected, err)
+					}
+				}(i)
+			}
+			wg.Wait()
+
+			assert.Empty(t, unexpected, "conflicts within the retry budget must be retried")
+			assert.Equal(t, min(workers, tt.stock), reserved)
+
+			stored, err := repo.FindBySKU(ctx, tt.sku)
+			require.NoError(t, err)
+			assert.Equal(t, reserved, stored.ReservedQuantity)
+			assert.Len(t, stored.Reservations, reserved)
+			assert.Equal(t, tt.stock-reserved, stored.AvailableQuantity)
+			assert.Equal(t, initialVersion+reserved, stored.Version, "every successful reservation saves exactly one new version")
+		})
+	}
+}
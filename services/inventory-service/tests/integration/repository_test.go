@@ -10,6 +10,7 @@ import (
 
 	"github.com/wms-platform/inventory-service/internal/domain"
 	"github.com/wms-platform/inventory-service/internal/infrastructure/mongodb"
+	"github.com/wms-platform/shared/pkg/cloudevents"
 	sharedtesting "github.com/wms-platform/shared/pkg/testing"
 )
 
@@ -36,8 +37,8 @@ func createTestInventoryItem(sku, productName string, locations []domain.StockLo
 func setupTestRepository(t *testing.T) (*mongodb.InventoryRepository, *sharedtesting.MongoDBContainer, func()) {
 	ctx := context.Background()
 
-	// Start MongoDB container
-	mongoContainer, err := sharedtesting.NewMongoDBContainer(ctx)
+	// Start MongoDB container (Save runs in a transaction, which needs a replica set)
+	mongoContainer, err := sharedtesting.NewMongoDBReplicaSetContainer(ctx)
 	require.NoError(t, err)
 
 	// Get MongoDB client
@@ -46,7 +47,7 @@ func setupTestRepository(t *testing.T) (*mongodb.InventoryRepository, *sharedtes
 
 	// Create repository
 	db := client.Database("test_inventory_db")
-	repo := mongodb.NewInventoryRepository(db)
+	repo := mongodb.NewInventoryRepository(db, cloudevents.NewEventFactory("inventory-service"))
 
 	cleanup := func() {
 		if err := client.Disconnect(ctx); err != nil {
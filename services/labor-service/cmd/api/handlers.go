@@ -1,6 +1,7 @@
 package main
 
 import (
+	"io"
 	"net/http"
 	"os"
 	"strconv"
@@ -361,9 +362,10 @@ func addSkillHandler(service *application.LaborApplicationService, logger *loggi
 		})
 
 		var req struct {
-			TaskType  string `json:"taskType" binding:"required"`
-			Level     int    `json:"level" binding:"required"`
-			Certified bool   `json:"certified"`
+			TaskType  string     `json:"taskType" binding:"required"`
+			Level     int        `json:"level" binding:"required"`
+			Certified bool       `json:"certified"`
+			ExpiresAt *time.Time `json:"expiresAt"`
 		}
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -375,11 +377,16 @@ func addSkillHandler(service *application.LaborApplicationService, logger *loggi
 			TaskType:  domain.TaskType(req.TaskType),
 			Level:     req.Level,
 			Certified: req.Certified,
+			ExpiresAt: req.ExpiresAt,
 		}
 
 		worker, err := service.AddSkill(c.Request.Context(), cmd)
 		if err != nil {
-			responder.RespondInternalError(err)
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
 			return
 		}
 
@@ -427,9 +434,10 @@ func getAvailableHandler(service *application.LaborApplicationService, logger *l
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
-		zone := c.Query("zone")
-
-		query := application.GetAvailableQuery{Zone: zone}
+		query := application.GetAvailableQuery{
+			Zone:     c.Query("zone"),
+			TaskType: domain.TaskType(c.Query("taskType")),
+		}
 
 		workers, err := service.GetAvailable(c.Request.Context(), query)
 		if err != nil {
@@ -462,3 +470,327 @@ func listWorkersHandler(service *application.LaborApplicationService, logger *lo
 		c.JSON(http.StatusOK, workers)
 	}
 }
+
+func findCertifiedWorkersHandler(service *application.LaborApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			RequiredSkills []string `json:"requiredSkills" binding:"required,min=1"`
+			Zone           string   `json:"zone"`
+			ShiftTime      string   `json:"shiftTime"`
+			MinCount       int      `json:"minCount"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		asOf, err := parseShiftTime(req.ShiftTime)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"zone":           req.Zone,
+			"skills.count":   len(req.RequiredSkills),
+			"workers.needed": req.MinCount,
+		})
+
+		query := application.FindCertifiedWorkersQuery{
+			RequiredSkills: toTaskTypes(req.RequiredSkills),
+			Zone:           req.Zone,
+			AsOf:           asOf,
+			MinCount:       req.MinCount,
+		}
+
+		workers, err := service.FindCertifiedWorkers(c.Request.Context(), query)
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, workers)
+	}
+}
+
+func getCertifiedWorkersHandler(service *application.LaborApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			Zone           string   `json:"zone"`
+			RequiredSkills []string `json:"requiredSkills"`
+			ShiftTime      string   `json:"shiftTime"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		asOf, err := parseShiftTime(req.ShiftTime)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		query := application.FindCertifiedWorkersQuery{
+			RequiredSkills: toTaskTypes(req.RequiredSkills),
+			Zone:           req.Zone,
+			AsOf:           asOf,
+		}
+
+		workers, err := service.FindCertifiedWorkers(c.Request.Context(), query)
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, workers)
+	}
+}
+
+func assignCertifiedWorkerHandler(service *application.LaborApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			OrderID        string   `json:"orderId" binding:"required"`
+			StationID      string   `json:"stationId" binding:"required"`
+			RequiredSkills []string `json:"requiredSkills"`
+			TaskType       string   `json:"taskType"`
+			Zone           string   `json:"zone"`
+			Priority       string   `json:"priority"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"order.id":   req.OrderID,
+			"station.id": req.StationID,
+		})
+
+		cmd := application.AssignCertifiedWorkerCommand{
+			OrderID:        req.OrderID,
+			StationID:      req.StationID,
+			RequiredSkills: toTaskTypes(req.RequiredSkills),
+			TaskType:       domain.TaskType(req.TaskType),
+			Zone:           req.Zone,
+			Priority:       getPriorityValue(req.Priority),
+		}
+
+		worker, err := service.AssignCertifiedWorker(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, worker)
+	}
+}
+
+func assignWorkerToTaskHandler(service *application.LaborApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			TaskID   string `json:"taskId" binding:"required"`
+			TaskType string `json:"taskType" binding:"required"`
+			WorkerID string `json:"workerId"`
+			Zone     string `json:"zone"`
+			Priority int    `json:"priority"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"task.id":   req.TaskID,
+			"task.type": req.TaskType,
+			"worker.id": req.WorkerID,
+		})
+
+		cmd := application.AssignWorkerToTaskCommand{
+			TaskID:   req.TaskID,
+			TaskType: domain.TaskType(req.TaskType),
+			WorkerID: req.WorkerID,
+			Zone:     req.Zone,
+			Priority: req.Priority,
+		}
+
+		task, err := service.AssignWorkerToTask(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusCreated, task)
+	}
+}
+
+func enqueueTaskHandler(service *application.LaborApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			TaskID   string `json:"taskId" binding:"required"`
+			TaskType string `json:"taskType" binding:"required"`
+			Priority int    `json:"priority"`
+			Zone     string `json:"zone"`
+			WaveID   string `json:"waveId"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"task.id":   req.TaskID,
+			"task.type": req.TaskType,
+			"wave.id":   req.WaveID,
+		})
+
+		cmd := application.EnqueueTaskCommand{
+			TaskID:   req.TaskID,
+			TaskType: domain.TaskType(req.TaskType),
+			Priority: req.Priority,
+			Zone:     req.Zone,
+			WaveID:   req.WaveID,
+		}
+
+		task, err := service.EnqueueTask(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusCreated, task)
+	}
+}
+
+func completeQueuedTaskHandler(service *application.LaborApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		taskID := c.Param("taskId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"task.id": taskID,
+		})
+
+		// The body is optional; callers that only close the task send none
+		var req struct {
+			ItemsProcessed int `json:"itemsProcessed"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.CompleteQueuedTaskCommand{
+			TaskID:         taskID,
+			ItemsProcessed: req.ItemsProcessed,
+		}
+
+		task, err := service.CompleteQueuedTask(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+func cancelQueuedTaskHandler(service *application.LaborApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		taskID := c.Param("taskId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"task.id": taskID,
+		})
+
+		task, err := service.CancelQueuedTask(c.Request.Context(), application.CancelQueuedTaskCommand{TaskID: taskID})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+func dispatchNextTaskHandler(service *application.LaborApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		workerID := c.Param("workerId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"worker.id": workerID,
+		})
+
+		task, err := service.DispatchNextTask(c.Request.Context(), application.DispatchNextTaskCommand{WorkerID: workerID})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, task)
+	}
+}
+
+// parseShiftTime parses an optional RFC3339 time; empty means now
+func parseShiftTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func toTaskTypes(skills []string) []domain.TaskType {
+	taskTypes := make([]domain.TaskType, 0, len(skills))
+	for _, skill := range skills {
+		taskTypes = append(taskTypes, domain.TaskType(skill))
+	}
+	return taskTypes
+}
+
+// getPriorityValue converts priority string to numeric value
+func getPriorityValue(priority string) int {
+	switch priority {
+	case "same_day":
+		return 1
+	case "next_day":
+		return 2
+	default:
+		return 3
+	}
+}
@@ -17,11 +17,12 @@ import (
 )
 
 type stubWorkerRepo struct {
-	SaveFn         func(ctx context.Context, worker *domain.Worker) error
-	FindByIDFn     func(ctx context.Context, workerID string) (*domain.Worker, error)
-	FindByStatusFn func(ctx context.Context, status domain.WorkerStatus) ([]*domain.Worker, error)
-	FindByZoneFn   func(ctx context.Context, zone string) ([]*domain.Worker, error)
-	FindAllFn      func(ctx context.Context, limit, offset int) ([]*domain.Worker, error)
+	SaveFn                    func(ctx context.Context, worker *domain.Worker) error
+	FindByIDFn                func(ctx context.Context, workerID string) (*domain.Worker, error)
+	FindByStatusFn            func(ctx context.Context, status domain.WorkerStatus) ([]*domain.Worker, error)
+	FindByZoneFn              func(ctx context.Context, zone string) ([]*domain.Worker, error)
+	FindAllFn                 func(ctx context.Context, limit, offset int) ([]*domain.Worker, error)
+	FindAvailableWithSkillsFn func(ctx context.Context, skills []domain.TaskType, zone string) ([]*domain.Worker, error)
 }
 
 func (s *stubWorkerRepo) Save(ctx context.Context, worker *domain.Worker) error {
@@ -60,6 +61,17 @@ func (s *stubWorkerRepo) FindAvailableBySkill(ctx context.Context, taskType doma
 	return nil, nil
 }
 
+func (s *stubWorkerRepo) FindAvailableWithSkills(ctx context.Context, skills []domain.TaskType, zone string) ([]*domain.Worker, error) {
+	if s.FindAvailableWithSkillsFn != nil {
+		return s.FindAvailableWithSkillsFn(ctx, skills, zone)
+	}
+	return nil, nil
+}
+
+func (s *stubWorkerRepo) SaveAssignment(ctx context.Context, worker *domain.Worker) error {
+	return s.Save(ctx, worker)
+}
+
 func (s *stubWorkerRepo) FindAll(ctx context.Context, limit, offset int) ([]*domain.Worker, error) {
 	if s.FindAllFn != nil {
 		return s.FindAllFn(ctx, limit, offset)
@@ -363,3 +375,211 @@ func TestQueryHandlers_Success(t *testing.T) {
 		t.Fatalf("expected 200, got %d", listResp.Code)
 	}
 }
+
+func TestCertifiedWorkerHandlers_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	worker := newWorkerWithShift(t)
+	worker.AddSkill("hazmat_certification", 3, true)
+	repo := &stubWorkerRepo{
+		FindAvailableWithSkillsFn: func(_ context.Context, _ []domain.TaskType, _ string) ([]*domain.Worker, error) {
+			return []*domain.Worker{worker}, nil
+		},
+	}
+	service, logger := newTestService(repo)
+	router := gin.New()
+	router.POST("/workers/certified", findCertifiedWorkersHandler(service, logger))
+	router.POST("/workers/available", getCertifiedWorkersHandler(service, logger))
+	router.POST("/workers/assign", assignCertifiedWorkerHandler(service, logger))
+
+	findResp := requestJSON(t, router, http.MethodPost, "/workers/certified", map[string]any{
+		"requiredSkills": []string{"hazmat_certification"},
+		"zone":           "A",
+		"minCount":       1,
+	})
+	if findResp.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", findResp.Code)
+	}
+	var found []application.CertifiedWorkerDTO
+	if err := json.Unmarshal(findResp.Body.Bytes(), &found); err != nil || len(found) != 1 {
+		t.Fatalf("unexpected body: %s", findResp.Body.String())
+	}
+
+	availableResp := requestJSON(t, router, http.MethodPost, "/workers/available", map[string]any{
+		"zone": "A",
+	})
+	if availableResp.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", availableResp.Code)
+	}
+
+	assignResp := requestJSON(t, router, http.MethodPost, "/workers/assign", map[string]any{
+		"orderId":        "order-1",
+		"stationId":      "station-1",
+		"requiredSkills": []string{"hazmat_certification"},
+		"priority":       "same_day",
+	})
+	if assignResp.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", assignResp.Code)
+	}
+	if worker.CurrentTask == nil || worker.CurrentTask.Priority != 1 || worker.CurrentTask.StationID != "station-1" {
+		t.Fatalf("unexpected assignment: %#v", worker.CurrentTask)
+	}
+
+	// The only certified worker is now busy
+	conflictResp := requestJSON(t, router, http.MethodPost, "/workers/assign", map[string]any{
+		"orderId":        "order-2",
+		"stationId":      "station-1",
+		"requiredSkills": []string{"hazmat_certification"},
+	})
+	if conflictResp.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", conflictResp.Code)
+	}
+}
+
+func TestCertifiedWorkerHandlers_BadRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service, logger := newTestService(&stubWorkerRepo{})
+	router := gin.New()
+	router.POST("/workers/certified", findCertifiedWorkersHandler(service, logger))
+	router.POST("/workers/available", getCertifiedWorkersHandler(service, logger))
+	router.POST("/workers/assign", assignCertifiedWorkerHandler(service, logger))
+
+	missingSkills := requestJSON(t, router, http.MethodPost, "/workers/certified", map[string]any{"zone": "A"})
+	if missingSkills.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", missingSkills.Code)
+	}
+
+	badTime := requestJSON(t, router, http.MethodPost, "/workers/available", map[string]any{"shiftTime": "tomorrow"})
+	if badTime.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", badTime.Code)
+	}
+
+	missingStation := requestJSON(t, router, http.MethodPost, "/workers/assign", map[string]any{"orderId": "order-1"})
+	if missingStation.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", missingStation.Code)
+	}
+}
+
+func TestAssignWorkerToTaskHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	worker := newWorkerWithShift(t)
+	repo := &stubWorkerRepo{
+		FindByIDFn: func(_ context.Context, _ string) (*domain.Worker, error) {
+			return worker, nil
+		},
+	}
+	service, logger := newTestService(repo)
+	router := gin.New()
+	router.POST("/tasks", assignWorkerToTaskHandler(service, logger))
+
+	resp := requestJSON(t, router, http.MethodPost, "/tasks", map[string]any{
+		"taskId":   "task-1",
+		"taskType": "picking",
+		"workerId": "worker-1",
+		"priority": 2,
+	})
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.Code)
+	}
+	var task application.LaborTaskDTO
+	if err := json.Unmarshal(resp.Body.Bytes(), &task); err != nil || task.TaskID != "task-1" || task.Status != "assigned" {
+		t.Fatalf("unexpected body: %s", resp.Body.String())
+	}
+
+	busyResp := requestJSON(t, router, http.MethodPost, "/tasks", map[string]any{
+		"taskId":   "task-2",
+		"taskType": "picking",
+		"workerId": "worker-1",
+	})
+	if busyResp.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", busyResp.Code)
+	}
+
+	badResp := requestJSON(t, router, http.MethodPost, "/tasks", map[string]any{"taskType": "picking"})
+	if badResp.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", badResp.Code)
+	}
+}
+
+type memQueuedTaskRepo struct {
+	tasks map[string]*domain.QueuedTask
+}
+
+func (m *memQueuedTaskRepo) Save(_ context.Context, task *domain.QueuedTask) error {
+	m.tasks[task.TaskID] = task
+	return nil
+}
+
+func (m *memQueuedTaskRepo) FindByTaskID(_ context.Context, taskID string) (*domain.QueuedTask, error) {
+	return m.tasks[taskID], nil
+}
+
+func (m *memQueuedTaskRepo) FindOpen(_ context.Context) ([]*domain.QueuedTask, error) {
+	open := make([]*domain.QueuedTask, 0, len(m.tasks))
+	for _, task := range m.tasks {
+		if task.IsOpen() {
+			open = append(open, task)
+		}
+	}
+	return open, nil
+}
+
+func (m *memQueuedTaskRepo) Claim(ctx context.Context, task *domain.QueuedTask) error {
+	return m.Save(ctx, task)
+}
+
+func TestTaskQueueHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	worker := newWorkerWithShift(t)
+	worker.AddSkill(domain.TaskTypeReplenishment, 1, false)
+	repo := &stubWorkerRepo{
+		FindByIDFn: func(_ context.Context, _ string) (*domain.Worker, error) {
+			return worker, nil
+		},
+	}
+	service, logger := newTestService(repo)
+	service.SetTaskQueue(&memQueuedTaskRepo{tasks: make(map[string]*domain.QueuedTask)})
+	router := gin.New()
+	router.POST("/tasks/queue", enqueueTaskHandler(service, logger))
+	router.POST("/tasks/queue/:taskId/complete", completeQueuedTaskHandler(service, logger))
+	router.POST("/tasks/queue/:taskId/cancel", cancelQueuedTaskHandler(service, logger))
+	router.POST("/workers/:workerId/task/next", dispatchNextTaskHandler(service, logger))
+
+	resp := requestJSON(t, router, http.MethodPost, "/tasks/queue", map[string]any{
+		"taskId":   "RPL-1",
+		"taskType": "replenishment",
+		"priority": 2,
+		"waveId":   "WAVE-1",
+	})
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.Code)
+	}
+
+	resp = requestJSON(t, router, http.MethodPost, "/workers/worker-1/task/next", nil)
+	var task application.LaborTaskDTO
+	if resp.Code != http.StatusOK || json.Unmarshal(resp.Body.Bytes(), &task) != nil || task.TaskID != "RPL-1" {
+		t.Fatalf("unexpected dispatch response %d: %s", resp.Code, resp.Body.String())
+	}
+
+	// Callers closing a task without reporting items send no body
+	resp = requestJSON(t, router, http.MethodPost, "/tasks/queue/RPL-1/complete", nil)
+	var queued application.QueuedTaskDTO
+	if resp.Code != http.StatusOK || json.Unmarshal(resp.Body.Bytes(), &queued) != nil || queued.Status != "completed" {
+		t.Fatalf("unexpected complete response %d: %s", resp.Code, resp.Body.String())
+	}
+
+	if resp := requestJSON(t, router, http.MethodPost, "/tasks/queue/RPL-1/cancel", nil); resp.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 cancelling a completed task, got %d", resp.Code)
+	}
+	if resp := requestJSON(t, router, http.MethodPost, "/workers/worker-1/task/next", nil); resp.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 with an empty queue, got %d", resp.Code)
+	}
+	if resp := requestJSON(t, router, http.MethodPost, "/tasks/queue", map[string]any{"taskType": "picking"}); resp.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.Code)
+	}
+}
+
+func TestGetPriorityValue(t *testing.T) {
+	if getPriorityValue("same_day") != 1 || getPriorityValue("next_day") != 2 || getPriorityValue("") != 3 {
+		t.Fatal("unexpected priority mapping")
+	}
+}
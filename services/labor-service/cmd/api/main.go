@@ -124,6 +124,7 @@ func main() {
 		eventFactory,
 		logger,
 	)
+	laborService.SetTaskQueue(mongoRepo.NewQueuedTaskRepository(instrumentedMongo.Database()))
 
 	// Setup Gin router with middleware
 	router := gin.New()
@@ -184,13 +185,26 @@ func main() {
 		workers.POST("/:workerId/task/assign", assignTaskHandler(laborService, logger))
 		workers.POST("/:workerId/task/start", startTaskHandler(laborService, logger))
 		workers.POST("/:workerId/task/complete", completeTaskHandler(laborService, logger))
+		workers.POST("/:workerId/task/next", dispatchNextTaskHandler(laborService, logger))
 		workers.POST("/:workerId/skills", addSkillHandler(laborService, logger))
 		workers.GET("/status/:status", getByStatusHandler(laborService, logger))
 		workers.GET("/zone/:zone", getByZoneHandler(laborService, logger))
 		workers.GET("/available", getAvailableHandler(laborService, logger))
+		workers.POST("/available", getCertifiedWorkersHandler(laborService, logger))
+		workers.POST("/certified", findCertifiedWorkersHandler(laborService, logger))
+		workers.POST("/assign", assignCertifiedWorkerHandler(laborService, logger))
 		workers.GET("", listWorkersHandler(laborService, logger))
 	}
 
+	// Task routes
+	tasks := apiV1.Group("/tasks")
+	{
+		tasks.POST("", assignWorkerToTaskHandler(laborService, logger))
+		tasks.POST("/queue", enqueueTaskHandler(laborService, logger))
+		tasks.POST("/queue/:taskId/complete", completeQueuedTaskHandler(laborService, logger))
+		tasks.POST("/queue/:taskId/cancel", cancelQueuedTaskHandler(laborService, logger))
+	}
+
 	// Start server
 	srv := &http.Server{
 		Addr:         config.ServerAddr,
@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+
+	"github.com/wms-platform/labor-service/internal/domain"
+)
+
+// FindCertifiedWorkers finds available workers holding a valid certification for every
+// required skill, ranked best match first
+func (s *LaborApplicationService) FindCertifiedWorkers(ctx context.Context, query FindCertifiedWorkersQuery) ([]CertifiedWorkerDTO, error) {
+	asOf := query.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	candidates, err := s.repo.FindAvailableWithSkills(ctx, query.RequiredSkills, query.Zone)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to find candidate workers", "zone", query.Zone)
+		return nil, fmt.Errorf("failed to find candidate workers: %w", err)
+	}
+
+	assigned, err := s.assignedTaskCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := domain.MatchWorkers(candidates, domain.WorkerMatchCriteria{
+		RequiredSkills:       query.RequiredSkills,
+		RequireCertification: true,
+		Zone:                 query.Zone,
+		AsOf:                 asOf,
+		AssignedTasks:        assigned,
+	})
+
+	if query.MinCount > 0 && len(matches) < query.MinCount {
+		s.logger.Warn("Insufficient certified workers",
+			"requiredSkills", query.RequiredSkills,
+			"zone", query.Zone,
+			"found", len(matches),
+			"minCount", query.MinCount,
+		)
+	}
+
+	return ToCertifiedWorkerDTOs(matches, asOf), nil
+}
+
+// AssignCertifiedWorker assigns the best ranked certified worker to order work at a station
+func (s *LaborApplicationService) AssignCertifiedWorker(ctx context.Context, cmd AssignCertifiedWorkerCommand) (*CertifiedWorkerDTO, error) {
+	taskType := cmd.TaskType
+	if taskType == "" {
+		taskType = domain.TaskTypePacking
+	}
+
+	now := time.Now()
+	candidates, err := s.repo.FindAvailableWithSkills(ctx, cmd.RequiredSkills, cmd.Zone)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to find candidate workers", "orderId", cmd.OrderID)
+		return nil, fmt.Errorf("failed to find candidate workers: %w", err)
+	}
+
+	assigned, err := s.assignedTaskCounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := domain.MatchWorkers(candidates, domain.WorkerMatchCriteria{
+		RequiredSkills:       cmd.RequiredSkills,
+		RequireCertification: true,
+		Zone:                 cmd.Zone,
+		AsOf:                 now,
+		AssignedTasks:        assigned,
+	})
+
+	worker, err := s.claimWorker(ctx, matches, func(w *domain.Worker) error {
+		return w.AssignStationTask(cmd.OrderID, taskType, cmd.Priority, cmd.OrderID, cmd.StationID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if worker == nil {
+		return nil, errors.ErrConflict("no certified worker available")
+	}
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "labor.task_assigned",
+		EntityType: "worker",
+		EntityID:   worker.WorkerID,
+		Action:     "certified_worker_assigned",
+		RelatedIDs: map[string]string{
+			"orderId":   cmd.OrderID,
+			"stationId": cmd.StationID,
+		},
+	})
+
+	return ToCertifiedWorkerDTO(worker, now), nil
+}
+
+// AssignWorkerToTask assigns a task to the requested worker, or to the best ranked
+// worker with the task's skill when no worker is given
+func (s *LaborApplicationService) AssignWorkerToTask(ctx context.Context, cmd AssignWorkerToTaskCommand) (*LaborTaskDTO, error) {
+	assign := func(w *domain.Worker) error {
+		return w.AssignTask(cmd.TaskID, cmd.TaskType, cmd.Priority)
+	}
+
+	var worker *domain.Worker
+	if cmd.WorkerID != "" {
+		requested, err := s.repo.FindByID(ctx, cmd.WorkerID)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to get worker", "workerId", cmd.WorkerID)
+			return nil, fmt.Errorf("failed to get worker: %w", err)
+		}
+		if requested == nil {
+			return nil, errors.ErrNotFound("worker")
+		}
+
+		if err := assign(requested); err != nil {
+			return nil, errors.ErrConflict(err.Error())
+		}
+		if err := s.repo.SaveAssignment(ctx, requested); err != nil {
+			if stdErrors.Is(err, domain.ErrWorkerAlreadyAssigned) {
+				return nil, errors.ErrConflict(err.Error())
+			}
+			s.logger.WithError(err).Error("Failed to save worker", "workerId", cmd.WorkerID)
+			return nil, fmt.Errorf("failed to save worker: %w", err)
+		}
+		worker = requested
+	} else {
+		candidates, err := s.repo.FindAvailableWithSkills(ctx, []domain.TaskType{cmd.TaskType}, cmd.Zone)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to find candidate workers", "taskId", cmd.TaskID)
+			return nil, fmt.Errorf("failed to find candidate workers: %w", err)
+		}
+
+		assigned, err := s.assignedTaskCounts(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		matches := domain.MatchWorkers(candidates, domain.WorkerMatchCriteria{
+			RequiredSkills: []domain.TaskType{cmd.TaskType},
+			Zone:           cmd.Zone,
+			AssignedTasks:  assigned,
+		})
+
+		worker, err = s.claimWorker(ctx, matches, assign)
+		if err != nil {
+			return nil, err
+		}
+		if worker == nil {
+			return nil, errors.ErrConflict("no worker available for task")
+		}
+	}
+
+	// Events are saved to outbox by repository in transaction
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "labor.task_assigned",
+		EntityType: "worker",
+		EntityID:   worker.WorkerID,
+		Action:     "task_assigned",
+		RelatedIDs: map[string]string{
+			"taskId":   cmd.TaskID,
+			"taskType": string(cmd.TaskType),
+		},
+	})
+
+	return ToLaborTaskDTO(worker), nil
+}
+
+// assignedTaskCounts returns the open tasks assigned to each worker, used to rank matches by load.
+// It returns nil when the task queue is not enabled.
+func (s *LaborApplicationService) assignedTaskCounts(ctx context.Context) (map[string]int, error) {
+	if s.queue == nil {
+		return nil, nil
+	}
+
+	open, err := s.queue.FindOpen(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get queued tasks")
+		return nil, fmt.Errorf("failed to get queued tasks: %w", err)
+	}
+
+	return domain.AssignedTaskCounts(open), nil
+}
+
+// claimWorker walks the ranked matches and assigns the first worker whose conditional
+// save succeeds. Workers taken by a concurrent assignment are skipped. It returns nil
+// when every candidate was taken.
+func (s *LaborApplicationService) claimWorker(ctx context.Context, matches []domain.WorkerMatch, assign func(*domain.Worker) error) (*domain.Worker, error) {
+	for _, match := range matches {
+		worker := match.Worker
+		if err := assign(worker); err != nil {
+			continue
+		}
+
+		err := s.repo.SaveAssignment(ctx, worker)
+		if err == nil {
+			return worker, nil
+		}
+		if stdErrors.Is(err, domain.ErrWorkerAlreadyAssigned) {
+			s.logger.Info("Worker claimed concurrently, trying next candidate", "workerId", worker.WorkerID)
+			continue
+		}
+
+		s.logger.WithError(err).Error("Failed to save worker", "workerId", worker.WorkerID)
+		return nil, fmt.Errorf("failed to save worker: %w", err)
+	}
+
+	return nil, nil
+}
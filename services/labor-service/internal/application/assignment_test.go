@@ -0,0 +1,295 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+
+	"github.com/wms-platform/labor-service/internal/domain"
+)
+
+func certifiedWorker(t *testing.T, workerID string, skill domain.TaskType, level int) *domain.Worker {
+	t.Helper()
+	worker := domain.NewWorker(workerID, "emp-"+workerID, "Worker "+workerID)
+	if err := worker.StartShift("shift-"+workerID, "morning", "A"); err != nil {
+		t.Fatalf("unexpected start shift err: %v", err)
+	}
+	worker.AddSkill(skill, level, true)
+	return worker
+}
+
+func TestLaborApplicationService_FindCertifiedWorkers(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+	best := certifiedWorker(t, "worker-1", "hazmat_certification", 5)
+	other := certifiedWorker(t, "worker-2", "hazmat_certification", 2)
+	lapsed := certifiedWorker(t, "worker-3", "hazmat_certification", 5)
+	lapsed.Skills[0].ExpiresAt = &expired
+
+	var gotSkills []domain.TaskType
+	var gotZone string
+	repo := &stubWorkerRepo{
+		FindAvailableWithSkillsFn: func(_ context.Context, skills []domain.TaskType, zone string) ([]*domain.Worker, error) {
+			gotSkills, gotZone = skills, zone
+			return []*domain.Worker{other, lapsed, best}, nil
+		},
+	}
+	service := newTestService(repo)
+
+	workers, err := service.FindCertifiedWorkers(context.Background(), FindCertifiedWorkersQuery{
+		RequiredSkills: []domain.TaskType{"hazmat_certification"},
+		Zone:           "A",
+		MinCount:       3,
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(gotSkills) != 1 || gotZone != "A" {
+		t.Fatalf("unexpected repository filter: %v %q", gotSkills, gotZone)
+	}
+	if len(workers) != 2 || workers[0].WorkerID != "worker-1" || workers[1].WorkerID != "worker-2" {
+		t.Fatalf("unexpected workers: %#v", workers)
+	}
+	if len(workers[0].Certifications) != 1 || workers[0].CurrentShift != "shift-worker-1" {
+		t.Fatalf("unexpected dto: %#v", workers[0])
+	}
+}
+
+func TestLaborApplicationService_FindCertifiedWorkers_RepoError(t *testing.T) {
+	repo := &stubWorkerRepo{
+		FindAvailableWithSkillsFn: func(_ context.Context, _ []domain.TaskType, _ string) ([]*domain.Worker, error) {
+			return nil, errors.New("db down")
+		},
+	}
+	service := newTestService(repo)
+
+	if _, err := service.FindCertifiedWorkers(context.Background(), FindCertifiedWorkersQuery{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestLaborApplicationService_AssignCertifiedWorker_SkipsWorkerTakenConcurrently(t *testing.T) {
+	first := certifiedWorker(t, "worker-1", "cold_chain_handling", 5)
+	second := certifiedWorker(t, "worker-2", "cold_chain_handling", 3)
+
+	var attempts []string
+	repo := &stubWorkerRepo{
+		FindAvailableWithSkillsFn: func(_ context.Context, _ []domain.TaskType, _ string) ([]*domain.Worker, error) {
+			return []*domain.Worker{second, first}, nil
+		},
+		SaveAssignmentFn: func(_ context.Context, worker *domain.Worker) error {
+			attempts = append(attempts, worker.WorkerID)
+			if worker.WorkerID == "worker-1" {
+				return domain.ErrWorkerAlreadyAssigned
+			}
+			return nil
+		},
+	}
+	service := newTestService(repo)
+
+	dto, err := service.AssignCertifiedWorker(context.Background(), AssignCertifiedWorkerCommand{
+		OrderID:        "order-1",
+		StationID:      "station-1",
+		RequiredSkills: []domain.TaskType{"cold_chain_handling"},
+		Priority:       1,
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(attempts) != 2 || attempts[0] != "worker-1" {
+		t.Fatalf("expected best match tried first, got %v", attempts)
+	}
+	if dto.WorkerID != "worker-2" || dto.AssignedStation != "station-1" || dto.CurrentTask != "order-1" {
+		t.Fatalf("unexpected dto: %#v", dto)
+	}
+	if second.CurrentTask.TaskType != domain.TaskTypePacking {
+		t.Fatalf("expected packing default, got %s", second.CurrentTask.TaskType)
+	}
+}
+
+func TestLaborApplicationService_AssignCertifiedWorker_NoneAvailable(t *testing.T) {
+	uncertified := workerWithShift(t)
+	uncertified.AddSkill("cold_chain_handling", 5, false)
+	repo := &stubWorkerRepo{
+		FindAvailableWithSkillsFn: func(_ context.Context, _ []domain.TaskType, _ string) ([]*domain.Worker, error) {
+			return []*domain.Worker{uncertified}, nil
+		},
+	}
+	service := newTestService(repo)
+
+	_, err := service.AssignCertifiedWorker(context.Background(), AssignCertifiedWorkerCommand{
+		OrderID:        "order-1",
+		StationID:      "station-1",
+		RequiredSkills: []domain.TaskType{"cold_chain_handling"},
+	})
+	var appErr *sharedErrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != sharedErrors.CodeConflict {
+		t.Fatalf("expected conflict AppError, got %#v", err)
+	}
+}
+
+func TestLaborApplicationService_AssignCertifiedWorker_SaveError(t *testing.T) {
+	worker := certifiedWorker(t, "worker-1", "cold_chain_handling", 5)
+	repo := &stubWorkerRepo{
+		FindAvailableWithSkillsFn: func(_ context.Context, _ []domain.TaskType, _ string) ([]*domain.Worker, error) {
+			return []*domain.Worker{worker}, nil
+		},
+		SaveAssignmentFn: func(_ context.Context, _ *domain.Worker) error {
+			return errors.New("db down")
+		},
+	}
+	service := newTestService(repo)
+
+	_, err := service.AssignCertifiedWorker(context.Background(), AssignCertifiedWorkerCommand{
+		OrderID:        "order-1",
+		StationID:      "station-1",
+		RequiredSkills: []domain.TaskType{"cold_chain_handling"},
+	})
+	var appErr *sharedErrors.AppError
+	if err == nil || errors.As(err, &appErr) {
+		t.Fatalf("expected internal error, got %#v", err)
+	}
+}
+
+func TestLaborApplicationService_AssignWorkerToTask_Matched(t *testing.T) {
+	worker := certifiedWorker(t, "worker-1", domain.TaskTypePicking, 2)
+	worker.Skills[0].Certified = false
+	repo := &stubWorkerRepo{
+		FindAvailableWithSkillsFn: func(_ context.Context, skills []domain.TaskType, _ string) ([]*domain.Worker, error) {
+			if len(skills) != 1 || skills[0] != domain.TaskTypePicking {
+				t.Fatalf("unexpected skills: %v", skills)
+			}
+			return []*domain.Worker{worker}, nil
+		},
+	}
+	service := newTestService(repo)
+
+	task, err := service.AssignWorkerToTask(context.Background(), AssignWorkerToTaskCommand{
+		TaskID:   "task-1",
+		TaskType: domain.TaskTypePicking,
+		Zone:     "A",
+		Priority: 2,
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if task.WorkerID != "worker-1" || task.Status != "assigned" || task.Zone != "A" || task.Priority != 2 {
+		t.Fatalf("unexpected task: %#v", task)
+	}
+}
+
+func TestLaborApplicationService_AssignWorkerToTask_PrefersFewerOpenTasks(t *testing.T) {
+	loaded := certifiedWorker(t, "worker-1", domain.TaskTypePicking, 2)
+	idle := certifiedWorker(t, "worker-2", domain.TaskTypePicking, 2)
+	idle.CurrentShift.TasksCompleted = 30
+	repo := &stubWorkerRepo{
+		FindAvailableWithSkillsFn: func(_ context.Context, _ []domain.TaskType, _ string) ([]*domain.Worker, error) {
+			return []*domain.Worker{loaded, idle}, nil
+		},
+	}
+	service := newTestService(repo)
+	queue := &stubQueuedTaskRepo{}
+	service.SetTaskQueue(queue)
+
+	queued, err := domain.NewQueuedTask("queued-1", domain.TaskTypePicking, 1, "A", "")
+	if err != nil {
+		t.Fatalf("unexpected queued task err: %v", err)
+	}
+	if err := queued.Assign("worker-1"); err != nil {
+		t.Fatalf("unexpected assign err: %v", err)
+	}
+	_ = queue.Save(context.Background(), queued)
+
+	task, err := service.AssignWorkerToTask(context.Background(), AssignWorkerToTaskCommand{
+		TaskID:   "task-1",
+		TaskType: domain.TaskTypePicking,
+		Zone:     "A",
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if task.WorkerID != "worker-2" {
+		t.Fatalf("expected the worker without open tasks, got %q", task.WorkerID)
+	}
+}
+
+func TestLaborApplicationService_AssignWorkerToTask_NoMatch(t *testing.T) {
+	service := newTestService(&stubWorkerRepo{})
+
+	_, err := service.AssignWorkerToTask(context.Background(), AssignWorkerToTaskCommand{
+		TaskID:   "task-1",
+		TaskType: domain.TaskTypePicking,
+	})
+	var appErr *sharedErrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != sharedErrors.CodeConflict {
+		t.Fatalf("expected conflict AppError, got %#v", err)
+	}
+}
+
+func TestLaborApplicationService_AssignWorkerToTask_RequestedWorker(t *testing.T) {
+	worker := workerWithShift(t)
+	repo := &stubWorkerRepo{
+		FindByIDFn: func(_ context.Context, _ string) (*domain.Worker, error) {
+			return worker, nil
+		},
+	}
+	service := newTestService(repo)
+
+	task, err := service.AssignWorkerToTask(context.Background(), AssignWorkerToTaskCommand{
+		TaskID:   "task-1",
+		TaskType: domain.TaskTypePicking,
+		WorkerID: "worker-1",
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if task.TaskID != "task-1" || task.WorkerID != "worker-1" {
+		t.Fatalf("unexpected task: %#v", task)
+	}
+}
+
+func TestLaborApplicationService_AssignWorkerToTask_RequestedWorkerErrors(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		service := newTestService(&stubWorkerRepo{})
+		_, err := service.AssignWorkerToTask(context.Background(), AssignWorkerToTaskCommand{TaskID: "task-1", WorkerID: "missing"})
+		var appErr *sharedErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Code != sharedErrors.CodeNotFound {
+			t.Fatalf("expected not found AppError, got %#v", err)
+		}
+	})
+
+	t.Run("busy", func(t *testing.T) {
+		worker := workerWithTask(t)
+		repo := &stubWorkerRepo{
+			FindByIDFn: func(_ context.Context, _ string) (*domain.Worker, error) {
+				return worker, nil
+			},
+		}
+		service := newTestService(repo)
+		_, err := service.AssignWorkerToTask(context.Background(), AssignWorkerToTaskCommand{TaskID: "task-2", WorkerID: "worker-1"})
+		var appErr *sharedErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Code != sharedErrors.CodeConflict {
+			t.Fatalf("expected conflict AppError, got %#v", err)
+		}
+	})
+
+	t.Run("claimed concurrently", func(t *testing.T) {
+		worker := workerWithShift(t)
+		repo := &stubWorkerRepo{
+			FindByIDFn: func(_ context.Context, _ string) (*domain.Worker, error) {
+				return worker, nil
+			},
+			SaveAssignmentFn: func(_ context.Context, _ *domain.Worker) error {
+				return domain.ErrWorkerAlreadyAssigned
+			},
+		}
+		service := newTestService(repo)
+		_, err := service.AssignWorkerToTask(context.Background(), AssignWorkerToTaskCommand{TaskID: "task-1", WorkerID: "worker-1"})
+		var appErr *sharedErrors.AppError
+		if !errors.As(err, &appErr) || appErr.Code != sharedErrors.CodeConflict {
+			t.Fatalf("expected conflict AppError, got %#v", err)
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package application
 
-import "github.com/wms-platform/labor-service/internal/domain"
+import (
+	"time"
+
+	"github.com/wms-platform/labor-service/internal/domain"
+)
 
 // CreateWorkerCommand creates a new worker
 type CreateWorkerCommand struct {
@@ -63,6 +67,7 @@ type AddSkillCommand struct {
 	TaskType  domain.TaskType
 	Level     int
 	Certified bool
+	ExpiresAt *time.Time // Optional certification expiry
 }
 
 // GetByStatusQuery retrieves workers by status
@@ -77,7 +82,60 @@ type GetByZoneQuery struct {
 
 // GetAvailableQuery retrieves available workers
 type GetAvailableQuery struct {
-	Zone string // Optional filter by zone
+	Zone     string          // Optional filter by zone
+	TaskType domain.TaskType // Optional filter by skill
+}
+
+// FindCertifiedWorkersQuery finds workers certified for a set of skills
+type FindCertifiedWorkersQuery struct {
+	RequiredSkills []domain.TaskType
+	Zone           string    // Optional filter by zone
+	AsOf           time.Time // Certifications must be valid at this time; zero = now
+	MinCount       int       // Minimum number of workers the caller needs
+}
+
+// AssignCertifiedWorkerCommand assigns the best certified worker to order work at a station
+type AssignCertifiedWorkerCommand struct {
+	OrderID        string
+	StationID      string
+	RequiredSkills []domain.TaskType
+	TaskType       domain.TaskType // Defaults to packing
+	Zone           string
+	Priority       int
+}
+
+// AssignWorkerToTaskCommand assigns a task to a specific worker or the best matching one
+type AssignWorkerToTaskCommand struct {
+	TaskID   string
+	TaskType domain.TaskType
+	WorkerID string // Optional; matched by skill when empty
+	Zone     string
+	Priority int
+}
+
+// EnqueueTaskCommand queues a task for dispatch to the next suitable worker
+type EnqueueTaskCommand struct {
+	TaskID   string
+	TaskType domain.TaskType
+	Priority int    // Lower values are dispatched first
+	Zone     string // Optional; only workers in the zone receive the task
+	WaveID   string // Optional; picking waits for the wave's replenishment
+}
+
+// DispatchNextTaskCommand hands the next queued task to a worker
+type DispatchNextTaskCommand struct {
+	WorkerID string
+}
+
+// CompleteQueuedTaskCommand completes a queued task and frees its worker
+type CompleteQueuedTaskCommand struct {
+	TaskID         string
+	ItemsProcessed int
+}
+
+// CancelQueuedTaskCommand withdraws a queued task and frees its worker
+type CancelQueuedTaskCommand struct {
+	TaskID string
 }
 
 // ListWorkersQuery retrieves all workers
@@ -23,6 +23,7 @@ type SkillDTO struct {
 	Level       int        `json:"level"`
 	Certified   bool       `json:"certified"`
 	CertifiedAt *time.Time `json:"certifiedAt,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
 }
 
 // ShiftDTO represents a worker shift
@@ -52,6 +53,8 @@ type TaskAssignmentDTO struct {
 	AssignedAt  time.Time  `json:"assignedAt"`
 	StartedAt   *time.Time `json:"startedAt,omitempty"`
 	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	OrderID     string     `json:"orderId,omitempty"`
+	StationID   string     `json:"stationId,omitempty"`
 }
 
 // PerformanceMetricsDTO represents performance metrics
@@ -63,3 +66,43 @@ type PerformanceMetricsDTO struct {
 	AccuracyRate         float64   `json:"accuracyRate"`
 	LastUpdated          time.Time `json:"lastUpdated"`
 }
+
+// CertifiedWorkerDTO represents a worker matched against required certifications
+type CertifiedWorkerDTO struct {
+	WorkerID        string   `json:"workerId"`
+	Name            string   `json:"name"`
+	Skills          []string `json:"skills"`
+	Certifications  []string `json:"certifications"`
+	CurrentTask     string   `json:"currentTask,omitempty"`
+	Zone            string   `json:"zone"`
+	Status          string   `json:"status"`
+	CurrentShift    string   `json:"currentShift,omitempty"`
+	AssignedStation string   `json:"assignedStation,omitempty"`
+}
+
+// LaborTaskDTO represents a task assigned to a worker
+type LaborTaskDTO struct {
+	TaskID      string     `json:"taskId"`
+	TaskType    string     `json:"taskType"`
+	WorkerID    string     `json:"workerId"`
+	Status      string     `json:"status"`
+	Zone        string     `json:"zone"`
+	Priority    int        `json:"priority"`
+	AssignedAt  time.Time  `json:"assignedAt"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// QueuedTaskDTO represents a task in the dispatch queue
+type QueuedTaskDTO struct {
+	TaskID     string     `json:"taskId"`
+	TaskType   string     `json:"taskType"`
+	Priority   int        `json:"priority"`
+	Zone       string     `json:"zone,omitempty"`
+	WaveID     string     `json:"waveId,omitempty"`
+	Status     string     `json:"status"`
+	WorkerID   string     `json:"workerId,omitempty"`
+	QueuedAt   time.Time  `json:"queuedAt"`
+	AssignedAt *time.Time `json:"assignedAt,omitempty"`
+	ClosedAt   *time.Time `json:"closedAt,omitempty"`
+}
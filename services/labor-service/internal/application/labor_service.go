@@ -15,6 +15,7 @@ import (
 // LaborApplicationService handles labor-related use cases
 type LaborApplicationService struct {
 	repo         domain.WorkerRepository
+	queue        domain.QueuedTaskRepository
 	producer     *kafka.InstrumentedProducer
 	eventFactory *cloudevents.EventFactory
 	logger       *logging.Logger
@@ -35,6 +36,11 @@ func NewLaborApplicationService(
 	}
 }
 
+// SetTaskQueue enables the task queue used to dispatch work to workers
+func (s *LaborApplicationService) SetTaskQueue(queue domain.QueuedTaskRepository) {
+	s.queue = queue
+}
+
 // CreateWorker creates a new worker
 func (s *LaborApplicationService) CreateWorker(ctx context.Context, cmd CreateWorkerCommand) (*WorkerDTO, error) {
 	worker := domain.NewWorker(cmd.WorkerID, cmd.EmployeeID, cmd.Name)
@@ -263,6 +269,11 @@ func (s *LaborApplicationService) CompleteTask(ctx context.Context, cmd Complete
 		return nil, errors.ErrNotFound("worker")
 	}
 
+	var taskID string
+	if worker.CurrentTask != nil {
+		taskID = worker.CurrentTask.TaskID
+	}
+
 	if err := worker.CompleteTask(cmd.ItemsProcessed); err != nil {
 		return nil, errors.ErrValidation(err.Error())
 	}
@@ -274,6 +285,8 @@ func (s *LaborApplicationService) CompleteTask(ctx context.Context, cmd Complete
 
 	// Events are saved to outbox by repository in transaction
 
+	s.closeQueuedTask(ctx, taskID)
+
 	s.logger.Info("Completed task", "workerId", cmd.WorkerID, "itemsProcessed", cmd.ItemsProcessed)
 	return ToWorkerDTO(worker), nil
 }
@@ -291,6 +304,11 @@ func (s *LaborApplicationService) AddSkill(ctx context.Context, cmd AddSkillComm
 	}
 
 	worker.AddSkill(cmd.TaskType, cmd.Level, cmd.Certified)
+	if cmd.Certified && cmd.ExpiresAt != nil {
+		if err := worker.SetCertificationExpiry(cmd.TaskType, *cmd.ExpiresAt); err != nil {
+			return nil, errors.ErrValidation(err.Error())
+		}
+	}
 
 	if err := s.repo.Save(ctx, worker); err != nil {
 		s.logger.WithError(err).Error("Failed to save worker", "workerId", cmd.WorkerID)
@@ -342,6 +360,17 @@ func (s *LaborApplicationService) GetAvailable(ctx context.Context, query GetAva
 		workers = filtered
 	}
 
+	// Filter by skill if specified
+	if query.TaskType != "" {
+		filtered := make([]*domain.Worker, 0)
+		for _, worker := range workers {
+			if worker.HasSkill(query.TaskType, 1) {
+				filtered = append(filtered, worker)
+			}
+		}
+		workers = filtered
+	}
+
 	return ToWorkerDTOs(workers), nil
 }
 
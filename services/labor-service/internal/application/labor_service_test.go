@@ -12,14 +12,16 @@ import (
 )
 
 type stubWorkerRepo struct {
-	SaveFn                 func(ctx context.Context, worker *domain.Worker) error
-	FindByIDFn             func(ctx context.Context, workerID string) (*domain.Worker, error)
-	FindByEmployeeIDFn     func(ctx context.Context, employeeID string) (*domain.Worker, error)
-	FindByStatusFn         func(ctx context.Context, status domain.WorkerStatus) ([]*domain.Worker, error)
-	FindByZoneFn           func(ctx context.Context, zone string) ([]*domain.Worker, error)
-	FindAvailableBySkillFn func(ctx context.Context, taskType domain.TaskType, zone string) ([]*domain.Worker, error)
-	FindAllFn              func(ctx context.Context, limit, offset int) ([]*domain.Worker, error)
-	DeleteFn               func(ctx context.Context, workerID string) error
+	SaveFn                    func(ctx context.Context, worker *domain.Worker) error
+	FindByIDFn                func(ctx context.Context, workerID string) (*domain.Worker, error)
+	FindByEmployeeIDFn        func(ctx context.Context, employeeID string) (*domain.Worker, error)
+	FindByStatusFn            func(ctx context.Context, status domain.WorkerStatus) ([]*domain.Worker, error)
+	FindByZoneFn              func(ctx context.Context, zone string) ([]*domain.Worker, error)
+	FindAvailableBySkillFn    func(ctx context.Context, taskType domain.TaskType, zone string) ([]*domain.Worker, error)
+	FindAvailableWithSkillsFn func(ctx context.Context, skills []domain.TaskType, zone string) ([]*domain.Worker, error)
+	SaveAssignmentFn          func(ctx context.Context, worker *domain.Worker) error
+	FindAllFn                 func(ctx context.Context, limit, offset int) ([]*domain.Worker, error)
+	DeleteFn                  func(ctx context.Context, workerID string) error
 }
 
 func (s *stubWorkerRepo) Save(ctx context.Context, worker *domain.Worker) error {
@@ -64,6 +66,20 @@ func (s *stubWorkerRepo) FindAvailableBySkill(ctx context.Context, taskType doma
 	return nil, nil
 }
 
+func (s *stubWorkerRepo) FindAvailableWithSkills(ctx context.Context, skills []domain.TaskType, zone string) ([]*domain.Worker, error) {
+	if s.FindAvailableWithSkillsFn != nil {
+		return s.FindAvailableWithSkillsFn(ctx, skills, zone)
+	}
+	return nil, nil
+}
+
+func (s *stubWorkerRepo) SaveAssignment(ctx context.Context, worker *domain.Worker) error {
+	if s.SaveAssignmentFn != nil {
+		return s.SaveAssignmentFn(ctx, worker)
+	}
+	return nil
+}
+
 func (s *stubWorkerRepo) FindAll(ctx context.Context, limit, offset int) ([]*domain.Worker, error) {
 	if s.FindAllFn != nil {
 		return s.FindAllFn(ctx, limit, offset)
@@ -1,6 +1,10 @@
 package application
 
-import "github.com/wms-platform/labor-service/internal/domain"
+import (
+	"time"
+
+	"github.com/wms-platform/labor-service/internal/domain"
+)
 
 // ToWorkerDTO converts a domain Worker to WorkerDTO
 func ToWorkerDTO(worker *domain.Worker) *WorkerDTO {
@@ -43,6 +47,7 @@ func ToSkillDTO(skill domain.Skill) SkillDTO {
 		Level:       skill.Level,
 		Certified:   skill.Certified,
 		CertifiedAt: skill.CertifiedAt,
+		ExpiresAt:   skill.ExpiresAt,
 	}
 }
 
@@ -91,6 +96,8 @@ func ToTaskAssignmentDTO(task *domain.TaskAssignment) *TaskAssignmentDTO {
 		AssignedAt:  task.AssignedAt,
 		StartedAt:   task.StartedAt,
 		CompletedAt: task.CompletedAt,
+		OrderID:     task.OrderID,
+		StationID:   task.StationID,
 	}
 }
 
@@ -116,3 +123,99 @@ func ToWorkerDTOs(workers []*domain.Worker) []WorkerDTO {
 	}
 	return dtos
 }
+
+// ToCertifiedWorkerDTO converts a domain Worker to CertifiedWorkerDTO,
+// listing only the certifications that are valid at asOf
+func ToCertifiedWorkerDTO(worker *domain.Worker, asOf time.Time) *CertifiedWorkerDTO {
+	if worker == nil {
+		return nil
+	}
+
+	skills := make([]string, 0, len(worker.Skills))
+	certifications := make([]string, 0, len(worker.Skills))
+	for _, skill := range worker.Skills {
+		skills = append(skills, string(skill.Type))
+		if skill.IsCertifiedAt(asOf) {
+			certifications = append(certifications, string(skill.Type))
+		}
+	}
+
+	dto := &CertifiedWorkerDTO{
+		WorkerID:       worker.WorkerID,
+		Name:           worker.Name,
+		Skills:         skills,
+		Certifications: certifications,
+		Zone:           worker.CurrentZone,
+		Status:         string(worker.Status),
+	}
+
+	if worker.HasActiveShift() {
+		dto.CurrentShift = worker.CurrentShift.ShiftID
+	}
+
+	if worker.CurrentTask != nil {
+		dto.CurrentTask = worker.CurrentTask.TaskID
+		dto.AssignedStation = worker.CurrentTask.StationID
+	}
+
+	return dto
+}
+
+// ToCertifiedWorkerDTOs converts ranked worker matches to CertifiedWorkerDTOs, preserving rank order
+func ToCertifiedWorkerDTOs(matches []domain.WorkerMatch, asOf time.Time) []CertifiedWorkerDTO {
+	dtos := make([]CertifiedWorkerDTO, 0, len(matches))
+	for _, match := range matches {
+		if dto := ToCertifiedWorkerDTO(match.Worker, asOf); dto != nil {
+			dtos = append(dtos, *dto)
+		}
+	}
+	return dtos
+}
+
+// ToLaborTaskDTO converts a worker's current task to LaborTaskDTO
+func ToLaborTaskDTO(worker *domain.Worker) *LaborTaskDTO {
+	if worker == nil || worker.CurrentTask == nil {
+		return nil
+	}
+
+	task := worker.CurrentTask
+	status := "assigned"
+	if task.StartedAt != nil {
+		status = "in_progress"
+	}
+	if task.CompletedAt != nil {
+		status = "completed"
+	}
+
+	return &LaborTaskDTO{
+		TaskID:      task.TaskID,
+		TaskType:    string(task.TaskType),
+		WorkerID:    worker.WorkerID,
+		Status:      status,
+		Zone:        worker.CurrentZone,
+		Priority:    task.Priority,
+		AssignedAt:  task.AssignedAt,
+		StartedAt:   task.StartedAt,
+		CompletedAt: task.CompletedAt,
+	}
+}
+
+// ToQueuedTaskDTO converts a queued task to a DTO
+func ToQueuedTaskDTO(task *domain.QueuedTask) *QueuedTaskDTO {
+	if task == nil {
+		return nil
+	}
+
+	return &QueuedTaskDTO{
+		TaskID:     task.TaskID,
+		TaskType:   string(task.TaskType),
+		Priority:   task.Priority,
+		Zone:       task.Zone,
+		WaveID:     task.WaveID,
+		Status:     string(task.Status),
+		WorkerID:   task.WorkerID,
+		QueuedAt:   task.QueuedAt,
+		AssignedAt: task.AssignedAt,
+		ClosedAt:   task.ClosedAt,
+	}
+}
@@ -0,0 +1,241 @@
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/labor-service/internal/domain"
+)
+
+// EnqueueTask queues a task for dispatch. Queueing a task that is already known returns
+// it unchanged, so callers can safely retry.
+func (s *LaborApplicationService) EnqueueTask(ctx context.Context, cmd EnqueueTaskCommand) (*QueuedTaskDTO, error) {
+	existing, err := s.queue.FindByTaskID(ctx, cmd.TaskID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get queued task", "taskId", cmd.TaskID)
+		return nil, fmt.Errorf("failed to get queued task: %w", err)
+	}
+	if existing != nil {
+		return ToQueuedTaskDTO(existing), nil
+	}
+
+	task, err := domain.NewQueuedTask(cmd.TaskID, cmd.TaskType, cmd.Priority, cmd.Zone, cmd.WaveID)
+	if err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	task.TenantID = tenant.GetTenantID(ctx)
+	task.FacilityID = tenant.GetFacilityID(ctx)
+	task.WarehouseID = tenant.GetWarehouseID(ctx)
+
+	if err := s.queue.Save(ctx, task); err != nil {
+		s.logger.WithError(err).Error("Failed to save queued task", "taskId", cmd.TaskID)
+		return nil, fmt.Errorf("failed to save queued task: %w", err)
+	}
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "labor.task_queued",
+		EntityType: "task",
+		EntityID:   cmd.TaskID,
+		Action:     "task_queued",
+		RelatedIDs: map[string]string{
+			"taskType": string(cmd.TaskType),
+			"waveId":   cmd.WaveID,
+		},
+	})
+
+	return ToQueuedTaskDTO(task), nil
+}
+
+// DispatchNextTask assigns the first queued task the worker can take, in dispatch order.
+// Tasks restricted to another zone or needing a skill the worker lacks are passed over.
+func (s *LaborApplicationService) DispatchNextTask(ctx context.Context, cmd DispatchNextTaskCommand) (*LaborTaskDTO, error) {
+	worker, err := s.repo.FindByID(ctx, cmd.WorkerID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get worker", "workerId", cmd.WorkerID)
+		return nil, fmt.Errorf("failed to get worker: %w", err)
+	}
+	if worker == nil {
+		return nil, errors.ErrNotFound("worker")
+	}
+	if !worker.Matches(domain.WorkerMatchCriteria{}) {
+		return nil, errors.ErrConflict(domain.ErrWorkerNotAvailable.Error())
+	}
+
+	open, err := s.queue.FindOpen(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get queued tasks")
+		return nil, fmt.Errorf("failed to get queued tasks: %w", err)
+	}
+
+	for _, task := range domain.DispatchOrder(open) {
+		if task.Zone != "" && task.Zone != worker.CurrentZone {
+			continue
+		}
+		if !worker.HasSkill(task.TaskType, 1) {
+			continue
+		}
+
+		if err := task.Assign(worker.WorkerID); err != nil {
+			continue
+		}
+		if err := s.queue.Claim(ctx, task); err != nil {
+			if stdErrors.Is(err, domain.ErrQueuedTaskClaimed) {
+				s.logger.Info("Task dispatched concurrently, trying next task", "taskId", task.TaskID)
+				continue
+			}
+			s.logger.WithError(err).Error("Failed to claim queued task", "taskId", task.TaskID)
+			return nil, fmt.Errorf("failed to claim queued task: %w", err)
+		}
+
+		if err := s.assignQueuedTask(ctx, worker, task); err != nil {
+			return nil, err
+		}
+
+		s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+			EventType:  "labor.task_assigned",
+			EntityType: "worker",
+			EntityID:   worker.WorkerID,
+			Action:     "task_dispatched",
+			RelatedIDs: map[string]string{
+				"taskId":   task.TaskID,
+				"taskType": string(task.TaskType),
+				"waveId":   task.WaveID,
+			},
+		})
+
+		return ToLaborTaskDTO(worker), nil
+	}
+
+	return nil, errors.ErrNotFound("dispatchable task")
+}
+
+// assignQueuedTask gives a claimed task to the worker. If the worker cannot be saved the
+// task goes back in the queue for the next worker.
+func (s *LaborApplicationService) assignQueuedTask(ctx context.Context, worker *domain.Worker, task *domain.QueuedTask) error {
+	err := worker.AssignTask(task.TaskID, task.TaskType, task.Priority)
+	if err == nil {
+		err = s.repo.SaveAssignment(ctx, worker)
+	}
+	if err == nil {
+		return nil
+	}
+
+	task.Release()
+	if saveErr := s.queue.Save(ctx, task); saveErr != nil {
+		s.logger.WithError(saveErr).Error("Failed to release queued task", "taskId", task.TaskID)
+	}
+
+	if stdErrors.Is(err, domain.ErrWorkerAlreadyAssigned) || stdErrors.Is(err, domain.ErrWorkerNotAvailable) {
+		return errors.ErrConflict(err.Error())
+	}
+	s.logger.WithError(err).Error("Failed to save worker", "workerId", worker.WorkerID)
+	return fmt.Errorf("failed to save worker: %w", err)
+}
+
+// CompleteQueuedTask completes a queued task, crediting the worker it was dispatched to.
+// Completing an already completed task is a no-op.
+func (s *LaborApplicationService) CompleteQueuedTask(ctx context.Context, cmd CompleteQueuedTaskCommand) (*QueuedTaskDTO, error) {
+	task, err := s.getQueuedTask(ctx, cmd.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status == domain.QueuedTaskStatusCompleted {
+		return ToQueuedTaskDTO(task), nil
+	}
+
+	return s.closeQueuedTaskWithWorker(ctx, task, task.Complete, func(w *domain.Worker) error {
+		return w.CompleteTask(cmd.ItemsProcessed)
+	})
+}
+
+// CancelQueuedTask withdraws a queued task, freeing the worker it was dispatched to
+func (s *LaborApplicationService) CancelQueuedTask(ctx context.Context, cmd CancelQueuedTaskCommand) (*QueuedTaskDTO, error) {
+	task, err := s.getQueuedTask(ctx, cmd.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status == domain.QueuedTaskStatusCancelled {
+		return ToQueuedTaskDTO(task), nil
+	}
+
+	return s.closeQueuedTaskWithWorker(ctx, task, task.Cancel, func(w *domain.Worker) error {
+		return w.CancelTask()
+	})
+}
+
+func (s *LaborApplicationService) closeQueuedTaskWithWorker(
+	ctx context.Context,
+	task *domain.QueuedTask,
+	closeTask func() error,
+	release func(*domain.Worker) error,
+) (*QueuedTaskDTO, error) {
+	workerID := task.WorkerID
+	if err := closeTask(); err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+
+	if workerID != "" {
+		worker, err := s.repo.FindByID(ctx, workerID)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to get worker", "workerId", workerID)
+			return nil, fmt.Errorf("failed to get worker: %w", err)
+		}
+		if worker != nil && worker.CurrentTask != nil && worker.CurrentTask.TaskID == task.TaskID {
+			if err := release(worker); err != nil {
+				return nil, errors.ErrValidation(err.Error())
+			}
+			if err := s.repo.Save(ctx, worker); err != nil {
+				s.logger.WithError(err).Error("Failed to save worker", "workerId", workerID)
+				return nil, fmt.Errorf("failed to save worker: %w", err)
+			}
+		}
+	}
+
+	if err := s.queue.Save(ctx, task); err != nil {
+		if errors.IsConcurrencyConflict(err) {
+			return nil, errors.ErrConflict(fmt.Sprintf("queued task %s was modified concurrently, please retry", task.TaskID)).Wrap(err)
+		}
+		s.logger.WithError(err).Error("Failed to save queued task", "taskId", task.TaskID)
+		return nil, fmt.Errorf("failed to save queued task: %w", err)
+	}
+
+	s.logger.Info("Closed queued task", "taskId", task.TaskID, "status", task.Status, "workerId", workerID)
+	return ToQueuedTaskDTO(task), nil
+}
+
+// closeQueuedTask marks a dispatched task completed after the worker finished it directly
+func (s *LaborApplicationService) closeQueuedTask(ctx context.Context, taskID string) {
+	if s.queue == nil || taskID == "" {
+		return
+	}
+
+	task, err := s.queue.FindByTaskID(ctx, taskID)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to get queued task", "taskId", taskID)
+		return
+	}
+	if task == nil || task.Complete() != nil {
+		return
+	}
+
+	if err := s.queue.Save(ctx, task); err != nil {
+		s.logger.WithError(err).Warn("Failed to complete queued task", "taskId", taskID)
+	}
+}
+
+func (s *LaborApplicationService) getQueuedTask(ctx context.Context, taskID string) (*domain.QueuedTask, error) {
+	task, err := s.queue.FindByTaskID(ctx, taskID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get queued task", "taskId", taskID)
+		return nil, fmt.Errorf("failed to get queued task: %w", err)
+	}
+	if task == nil {
+		return nil, errors.ErrNotFound("queued task")
+	}
+	return task, nil
+}
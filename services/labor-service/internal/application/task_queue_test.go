@@ -0,0 +1,201 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+
+	"github.com/wms-platform/labor-service/internal/domain"
+)
+
+type stubQueuedTaskRepo struct {
+	tasks    map[string]*domain.QueuedTask
+	claimErr error
+}
+
+func (s *stubQueuedTaskRepo) Save(_ context.Context, task *domain.QueuedTask) error {
+	if s.tasks == nil {
+		s.tasks = make(map[string]*domain.QueuedTask)
+	}
+	s.tasks[task.TaskID] = task
+	return nil
+}
+
+func (s *stubQueuedTaskRepo) FindByTaskID(_ context.Context, taskID string) (*domain.QueuedTask, error) {
+	return s.tasks[taskID], nil
+}
+
+func (s *stubQueuedTaskRepo) FindOpen(_ context.Context) ([]*domain.QueuedTask, error) {
+	open := make([]*domain.QueuedTask, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		if task.IsOpen() {
+			open = append(open, task)
+		}
+	}
+	return open, nil
+}
+
+func (s *stubQueuedTaskRepo) Claim(_ context.Context, task *domain.QueuedTask) error {
+	if s.claimErr != nil {
+		err := s.claimErr
+		s.claimErr = nil
+		return err
+	}
+	return s.Save(context.Background(), task)
+}
+
+func newQueueTestService(t *testing.T, worker *domain.Worker) (*LaborApplicationService, *stubQueuedTaskRepo) {
+	t.Helper()
+	repo := &stubWorkerRepo{
+		FindByIDFn: func(_ context.Context, _ string) (*domain.Worker, error) {
+			return worker, nil
+		},
+	}
+	queue := &stubQueuedTaskRepo{}
+	service := newTestService(repo)
+	service.SetTaskQueue(queue)
+	return service, queue
+}
+
+func enqueue(t *testing.T, service *LaborApplicationService, cmd EnqueueTaskCommand) {
+	t.Helper()
+	if _, err := service.EnqueueTask(context.Background(), cmd); err != nil {
+		t.Fatalf("unexpected enqueue err: %v", err)
+	}
+}
+
+func TestLaborApplicationService_EnqueueTask(t *testing.T) {
+	service, queue := newQueueTestService(t, nil)
+
+	cmd := EnqueueTaskCommand{TaskID: "RPL-1", TaskType: domain.TaskTypeReplenishment, Priority: 2, Zone: "A", WaveID: "WAVE-1"}
+	enqueue(t, service, cmd)
+	enqueue(t, service, cmd)
+	if len(queue.tasks) != 1 || queue.tasks["RPL-1"].Status != domain.QueuedTaskStatusQueued {
+		t.Fatalf("expected one queued task, got %#v", queue.tasks)
+	}
+
+	_, err := service.EnqueueTask(context.Background(), EnqueueTaskCommand{TaskID: "RPL-2"})
+	var appErr *sharedErrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != sharedErrors.CodeValidationError {
+		t.Fatalf("expected validation AppError, got %#v", err)
+	}
+}
+
+func TestLaborApplicationService_DispatchNextTask_ReplenishmentBeforeWavePicking(t *testing.T) {
+	worker := certifiedWorker(t, "worker-1", domain.TaskTypePicking, 2)
+	worker.AddSkill(domain.TaskTypeReplenishment, 2, false)
+	service, queue := newQueueTestService(t, worker)
+
+	enqueue(t, service, EnqueueTaskCommand{TaskID: "PICK-1", TaskType: domain.TaskTypePicking, Priority: 1, Zone: "A", WaveID: "WAVE-1"})
+	enqueue(t, service, EnqueueTaskCommand{TaskID: "RPL-1", TaskType: domain.TaskTypeReplenishment, Priority: 5, Zone: "A", WaveID: "WAVE-1"})
+	enqueue(t, service, EnqueueTaskCommand{TaskID: "RPL-OTHER-ZONE", TaskType: domain.TaskTypeReplenishment, Priority: 1, Zone: "B"})
+
+	task, err := service.DispatchNextTask(context.Background(), DispatchNextTaskCommand{WorkerID: "worker-1"})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if task.TaskID != "RPL-1" || worker.CurrentTask == nil || worker.CurrentTask.TaskID != "RPL-1" {
+		t.Fatalf("expected replenishment dispatched first, got %#v", task)
+	}
+	if queue.tasks["RPL-1"].Status != domain.QueuedTaskStatusAssigned || queue.tasks["RPL-1"].WorkerID != "worker-1" {
+		t.Fatalf("unexpected queued task: %#v", queue.tasks["RPL-1"])
+	}
+
+	// Completing the replenishment frees the worker and releases the wave's picking
+	if _, err := service.CompleteQueuedTask(context.Background(), CompleteQueuedTaskCommand{TaskID: "RPL-1", ItemsProcessed: 12}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if worker.CurrentTask != nil || worker.Status != domain.WorkerStatusAvailable || worker.PerformanceMetrics.TotalItemsProcessed != 12 {
+		t.Fatalf("expected worker freed and credited, got %#v", worker)
+	}
+
+	task, err = service.DispatchNextTask(context.Background(), DispatchNextTaskCommand{WorkerID: "worker-1"})
+	if err != nil || task.TaskID != "PICK-1" {
+		t.Fatalf("expected wave picking dispatched, got %#v, %v", task, err)
+	}
+
+	// Completing the task from the worker closes it in the queue too
+	if _, err := service.CompleteTask(context.Background(), CompleteTaskCommand{WorkerID: "worker-1", ItemsProcessed: 3}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if queue.tasks["PICK-1"].Status != domain.QueuedTaskStatusCompleted {
+		t.Fatalf("expected queued task completed, got %s", queue.tasks["PICK-1"].Status)
+	}
+
+	_, err = service.DispatchNextTask(context.Background(), DispatchNextTaskCommand{WorkerID: "worker-1"})
+	var appErr *sharedErrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != sharedErrors.CodeNotFound {
+		t.Fatalf("expected not found AppError, got %#v", err)
+	}
+}
+
+func TestLaborApplicationService_DispatchNextTask_SkipsClaimedTask(t *testing.T) {
+	worker := certifiedWorker(t, "worker-1", domain.TaskTypeReplenishment, 2)
+	service, queue := newQueueTestService(t, worker)
+
+	enqueue(t, service, EnqueueTaskCommand{TaskID: "RPL-1", TaskType: domain.TaskTypeReplenishment, Priority: 1})
+	enqueue(t, service, EnqueueTaskCommand{TaskID: "RPL-2", TaskType: domain.TaskTypeReplenishment, Priority: 2})
+	queue.claimErr = domain.ErrQueuedTaskClaimed
+
+	task, err := service.DispatchNextTask(context.Background(), DispatchNextTaskCommand{WorkerID: "worker-1"})
+	if err != nil || task.TaskID != "RPL-2" {
+		t.Fatalf("expected next task dispatched, got %#v, %v", task, err)
+	}
+}
+
+func TestLaborApplicationService_DispatchNextTask_ReleasesTaskWhenWorkerTaken(t *testing.T) {
+	worker := certifiedWorker(t, "worker-1", domain.TaskTypeReplenishment, 2)
+	repo := &stubWorkerRepo{
+		FindByIDFn: func(_ context.Context, _ string) (*domain.Worker, error) {
+			return worker, nil
+		},
+		SaveAssignmentFn: func(_ context.Context, _ *domain.Worker) error {
+			return domain.ErrWorkerAlreadyAssigned
+		},
+	}
+	queue := &stubQueuedTaskRepo{}
+	service := newTestService(repo)
+	service.SetTaskQueue(queue)
+	enqueue(t, service, EnqueueTaskCommand{TaskID: "RPL-1", TaskType: domain.TaskTypeReplenishment})
+
+	_, err := service.DispatchNextTask(context.Background(), DispatchNextTaskCommand{WorkerID: "worker-1"})
+	var appErr *sharedErrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != sharedErrors.CodeConflict {
+		t.Fatalf("expected conflict AppError, got %#v", err)
+	}
+	if queue.tasks["RPL-1"].Status != domain.QueuedTaskStatusQueued || queue.tasks["RPL-1"].WorkerID != "" {
+		t.Fatalf("expected task back in the queue, got %#v", queue.tasks["RPL-1"])
+	}
+}
+
+func TestLaborApplicationService_CancelQueuedTask(t *testing.T) {
+	worker := certifiedWorker(t, "worker-1", domain.TaskTypeReplenishment, 2)
+	service, queue := newQueueTestService(t, worker)
+	enqueue(t, service, EnqueueTaskCommand{TaskID: "RPL-1", TaskType: domain.TaskTypeReplenishment})
+
+	if _, err := service.DispatchNextTask(context.Background(), DispatchNextTaskCommand{WorkerID: "worker-1"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if _, err := service.CancelQueuedTask(context.Background(), CancelQueuedTaskCommand{TaskID: "RPL-1"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if queue.tasks["RPL-1"].Status != domain.QueuedTaskStatusCancelled {
+		t.Fatalf("expected cancelled task, got %s", queue.tasks["RPL-1"].Status)
+	}
+	if worker.CurrentTask != nil || worker.PerformanceMetrics.TotalTasksCompleted != 0 {
+		t.Fatalf("expected worker freed without credit, got %#v", worker)
+	}
+
+	_, err := service.CompleteQueuedTask(context.Background(), CompleteQueuedTaskCommand{TaskID: "RPL-1"})
+	var appErr *sharedErrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != sharedErrors.CodeValidationError {
+		t.Fatalf("expected validation AppError, got %#v", err)
+	}
+
+	_, err = service.CancelQueuedTask(context.Background(), CancelQueuedTaskCommand{TaskID: "missing"})
+	if !errors.As(err, &appErr) || appErr.Code != sharedErrors.CodeNotFound {
+		t.Fatalf("expected not found AppError, got %#v", err)
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"errors"
 	"time"
 
+	sharedDomain "github.com/wms-platform/shared/pkg/domain"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // Errors
 var (
-	ErrWorkerNotAvailable = errors.New("worker is not available")
-	ErrShiftAlreadyEnded  = errors.New("shift has already ended")
-	ErrNoActiveShift      = errors.New("no active shift")
+	ErrWorkerNotAvailable    = errors.New("worker is not available")
+	ErrShiftAlreadyEnded     = errors.New("shift has already ended")
+	ErrNoActiveShift         = errors.New("no active shift")
+	ErrSkillNotFound         = errors.New("worker does not have this skill")
+	ErrWorkerAlreadyAssigned = errors.New("worker was assigned to another task concurrently")
 )
 
 // WorkerStatus represents the status of a worker
@@ -36,6 +39,16 @@ const (
 	TaskTypeWalling       TaskType = "walling"
 )
 
+// Handling skills required for orders with special requirements, shared with the orchestrator
+const (
+	SkillHazmatCertification   TaskType = sharedDomain.SkillHazmatCertification
+	SkillColdChainHandling     TaskType = sharedDomain.SkillColdChainHandling
+	SkillHighValueVerification TaskType = sharedDomain.SkillHighValueVerification
+	SkillFragileHandling       TaskType = sharedDomain.SkillFragileHandling
+	SkillHeavyLifting          TaskType = sharedDomain.SkillHeavyLifting
+	SkillGiftWrapping          TaskType = sharedDomain.SkillGiftWrapping
+)
+
 // Worker is the aggregate root for the Labor bounded context
 type Worker struct {
 	ID                primitive.ObjectID  `bson:"_id,omitempty"`
@@ -62,6 +75,15 @@ type Skill struct {
 	Level       int      `bson:"level"` // 1-5
 	Certified   bool     `bson:"certified"`
 	CertifiedAt *time.Time `bson:"certifiedAt,omitempty"`
+	ExpiresAt   *time.Time `bson:"expiresAt,omitempty"` // certification expiry, nil = never expires
+}
+
+// IsCertifiedAt reports whether the skill holds a certification that is valid at asOf
+func (s Skill) IsCertifiedAt(asOf time.Time) bool {
+	if !s.Certified {
+		return false
+	}
+	return s.ExpiresAt == nil || asOf.Before(*s.ExpiresAt)
 }
 
 // Shift represents a work shift
@@ -91,6 +113,8 @@ type TaskAssignment struct {
 	AssignedAt time.Time `bson:"assignedAt"`
 	StartedAt  *time.Time `bson:"startedAt,omitempty"`
 	CompletedAt *time.Time `bson:"completedAt,omitempty"`
+	OrderID     string     `bson:"orderId,omitempty"`
+	StationID   string     `bson:"stationId,omitempty"`
 }
 
 // PerformanceMetrics represents worker performance
@@ -236,6 +260,24 @@ func (w *Worker) AssignTask(taskID string, taskType TaskType, priority int) erro
 	return nil
 }
 
+// AssignStationTask assigns order work at a station to the worker
+func (w *Worker) AssignStationTask(taskID string, taskType TaskType, priority int, orderID, stationID string) error {
+	if err := w.AssignTask(taskID, taskType, priority); err != nil {
+		return err
+	}
+
+	w.CurrentTask.OrderID = orderID
+	w.CurrentTask.StationID = stationID
+	if len(w.DomainEvents) > 0 {
+		if event, ok := w.DomainEvents[len(w.DomainEvents)-1].(*TaskAssignedEvent); ok {
+			event.OrderID = orderID
+			event.StationID = stationID
+		}
+	}
+
+	return nil
+}
+
 // StartTask marks the current task as started
 func (w *Worker) StartTask() error {
 	if w.CurrentTask == nil {
@@ -284,6 +326,19 @@ func (w *Worker) CompleteTask(itemsProcessed int) error {
 	return nil
 }
 
+// CancelTask withdraws the current task without crediting it to the worker
+func (w *Worker) CancelTask() error {
+	if w.CurrentTask == nil {
+		return errors.New("no task assigned")
+	}
+
+	w.CurrentTask = nil
+	w.Status = WorkerStatusAvailable
+	w.UpdatedAt = time.Now()
+
+	return nil
+}
+
 // UpdateZone updates the worker's current zone
 func (w *Worker) UpdateZone(zone string) {
 	w.CurrentZone = zone
@@ -317,6 +372,33 @@ func (w *Worker) AddSkill(taskType TaskType, level int, certified bool) {
 	w.UpdatedAt = now
 }
 
+// SetCertificationExpiry sets when the certification for a skill lapses
+func (w *Worker) SetCertificationExpiry(taskType TaskType, expiresAt time.Time) error {
+	for i := range w.Skills {
+		if w.Skills[i].Type == taskType {
+			w.Skills[i].ExpiresAt = &expiresAt
+			w.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrSkillNotFound
+}
+
+// HasCertifiedSkill checks if worker holds a valid certification for a skill at asOf
+func (w *Worker) HasCertifiedSkill(taskType TaskType, minLevel int, asOf time.Time) bool {
+	for _, skill := range w.Skills {
+		if skill.Type == taskType && skill.Level >= minLevel && skill.IsCertifiedAt(asOf) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasActiveShift checks if the worker is clocked in
+func (w *Worker) HasActiveShift() bool {
+	return w.CurrentShift != nil && w.CurrentShift.EndTime == nil
+}
+
 // HasSkill checks if worker has a skill
 func (w *Worker) HasSkill(taskType TaskType, minLevel int) bool {
 	for _, skill := range w.Skills {
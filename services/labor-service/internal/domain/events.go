@@ -37,6 +37,8 @@ type TaskAssignedEvent struct {
 	WorkerID   string    `json:"workerId"`
 	TaskID     string    `json:"taskId"`
 	TaskType   string    `json:"taskType"`
+	OrderID    string    `json:"orderId,omitempty"`
+	StationID  string    `json:"stationId,omitempty"`
 	AssignedAt time.Time `json:"assignedAt"`
 }
 
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// WorkerMatchCriteria describes what a worker needs to be eligible for a piece of work
+type WorkerMatchCriteria struct {
+	RequiredSkills       []TaskType
+	MinLevel             int
+	RequireCertification bool
+	Zone                 string    // empty = any zone
+	AsOf                 time.Time      // point in time certifications must be valid at
+	AssignedTasks        map[string]int // open tasks already assigned, by worker ID
+	Limit                int            // 0 = no limit
+}
+
+// WorkerMatch is an eligible worker together with the values used to rank it
+type WorkerMatch struct {
+	Worker        *Worker
+	SkillLevel    int // sum of levels across the required skills
+	AssignedTasks int // open tasks already assigned to the worker
+}
+
+// Matches checks whether the worker satisfies the criteria.
+// A worker must be clocked in, not on a break and not already working a task.
+func (w *Worker) Matches(criteria WorkerMatchCriteria) bool {
+	if w.Status != WorkerStatusAvailable || w.CurrentTask != nil || !w.HasActiveShift() {
+		return false
+	}
+
+	if criteria.Zone != "" && w.CurrentZone != criteria.Zone {
+		return false
+	}
+
+	minLevel := criteria.MinLevel
+	if minLevel <= 0 {
+		minLevel = 1
+	}
+
+	for _, required := range criteria.RequiredSkills {
+		if criteria.RequireCertification {
+			if !w.HasCertifiedSkill(required, minLevel, criteria.AsOf) {
+				return false
+			}
+		} else if !w.HasSkill(required, minLevel) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchWorkers filters workers by the criteria and ranks the eligible ones.
+// Ranking prefers higher skill level, then fewer open assigned tasks, then higher
+// throughput, falling back to worker ID so results are deterministic.
+func MatchWorkers(workers []*Worker, criteria WorkerMatchCriteria) []WorkerMatch {
+	if criteria.AsOf.IsZero() {
+		criteria.AsOf = time.Now()
+	}
+
+	matches := make([]WorkerMatch, 0, len(workers))
+	for _, worker := range workers {
+		if worker == nil || !worker.Matches(criteria) {
+			continue
+		}
+
+		match := WorkerMatch{Worker: worker}
+		for _, required := range criteria.RequiredSkills {
+			for _, skill := range worker.Skills {
+				if skill.Type == required {
+					match.SkillLevel += skill.Level
+					break
+				}
+			}
+		}
+		match.AssignedTasks = criteria.AssignedTasks[worker.WorkerID]
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.SkillLevel != b.SkillLevel {
+			return a.SkillLevel > b.SkillLevel
+		}
+		if a.AssignedTasks != b.AssignedTasks {
+			return a.AssignedTasks < b.AssignedTasks
+		}
+		if a.Worker.PerformanceMetrics.AverageItemsPerHour != b.Worker.PerformanceMetrics.AverageItemsPerHour {
+			return a.Worker.PerformanceMetrics.AverageItemsPerHour > b.Worker.PerformanceMetrics.AverageItemsPerHour
+		}
+		return a.Worker.WorkerID < b.Worker.WorkerID
+	})
+
+	if criteria.Limit > 0 && len(matches) > criteria.Limit {
+		matches = matches[:criteria.Limit]
+	}
+
+	return matches
+}
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMatchableWorker(t *testing.T, workerID, zone string) *Worker {
+	t.Helper()
+	worker := NewWorker(workerID, "EMP-"+workerID, "Worker "+workerID)
+	require.NoError(t, worker.StartShift("SHIFT-"+workerID, "morning", zone))
+	return worker
+}
+
+// TestSkillIsCertifiedAt tests certification validity and expiry
+func TestSkillIsCertifiedAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	assert.False(t, Skill{Type: SkillHazmatCertification, Certified: false}.IsCertifiedAt(now))
+	assert.True(t, Skill{Type: SkillHazmatCertification, Certified: true}.IsCertifiedAt(now))
+	assert.True(t, Skill{Type: SkillHazmatCertification, Certified: true, ExpiresAt: &future}.IsCertifiedAt(now))
+	assert.False(t, Skill{Type: SkillHazmatCertification, Certified: true, ExpiresAt: &past}.IsCertifiedAt(now))
+}
+
+// TestWorkerSetCertificationExpiry tests setting certification expiry
+func TestWorkerSetCertificationExpiry(t *testing.T) {
+	worker := NewWorker("WORKER-001", "EMP-001", "John Doe")
+	worker.AddSkill("forklift", 3, true)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	require.NoError(t, worker.SetCertificationExpiry("forklift", expiresAt))
+	require.NotNil(t, worker.Skills[0].ExpiresAt)
+	assert.True(t, worker.HasCertifiedSkill("forklift", 1, time.Now()))
+	assert.False(t, worker.HasCertifiedSkill("forklift", 1, expiresAt.Add(time.Minute)))
+
+	assert.ErrorIs(t, worker.SetCertificationExpiry(SkillHazmatCertification, expiresAt), ErrSkillNotFound)
+}
+
+// TestWorkerAssignStationTask tests station task assignment
+func TestWorkerAssignStationTask(t *testing.T) {
+	worker := newMatchableWorker(t, "WORKER-001", "ZONE-A")
+	worker.ClearDomainEvents()
+
+	require.NoError(t, worker.AssignStationTask("ORD-1", TaskTypePacking, 1, "ORD-1", "STN-1"))
+	assert.Equal(t, WorkerStatusOnTask, worker.Status)
+	assert.Equal(t, "STN-1", worker.CurrentTask.StationID)
+	assert.Equal(t, "ORD-1", worker.CurrentTask.OrderID)
+
+	require.Len(t, worker.GetDomainEvents(), 1)
+	event := worker.GetDomainEvents()[0].(*TaskAssignedEvent)
+	assert.Equal(t, "STN-1", event.StationID)
+
+	assert.ErrorIs(t, worker.AssignStationTask("ORD-2", TaskTypePacking, 1, "ORD-2", "STN-1"), ErrWorkerNotAvailable)
+}
+
+// TestWorkerMatches tests eligibility filters
+func TestWorkerMatches(t *testing.T) {
+	now := time.Now()
+	expired := now.Add(-time.Hour)
+	criteria := WorkerMatchCriteria{
+		RequiredSkills:       []TaskType{SkillHazmatCertification},
+		RequireCertification: true,
+		Zone:                 "ZONE-A",
+		AsOf:                 now,
+	}
+
+	tests := []struct {
+		name   string
+		setup  func() *Worker
+		expect bool
+	}{
+		{
+			name: "Certified worker in zone",
+			setup: func() *Worker {
+				w := newMatchableWorker(t, "W1", "ZONE-A")
+				w.AddSkill(SkillHazmatCertification, 2, true)
+				return w
+			},
+			expect: true,
+		},
+		{
+			name: "Uncertified skill",
+			setup: func() *Worker {
+				w := newMatchableWorker(t, "W2", "ZONE-A")
+				w.AddSkill(SkillHazmatCertification, 2, false)
+				return w
+			},
+			expect: false,
+		},
+		{
+			name: "Expired certification",
+			setup: func() *Worker {
+				w := newMatchableWorker(t, "W3", "ZONE-A")
+				w.AddSkill(SkillHazmatCertification, 2, true)
+				w.Skills[0].ExpiresAt = &expired
+				return w
+			},
+			expect: false,
+		},
+		{
+			name: "Wrong zone",
+			setup: func() *Worker {
+				w := newMatchableWorker(t, "W4", "ZONE-B")
+				w.AddSkill(SkillHazmatCertification, 2, true)
+				return w
+			},
+			expect: false,
+		},
+		{
+			name: "No active shift",
+			setup: func() *Worker {
+				w := NewWorker("W5", "EMP-W5", "Worker 5")
+				w.AddSkill(SkillHazmatCertification, 2, true)
+				w.Status = WorkerStatusAvailable
+				w.CurrentZone = "ZONE-A"
+				return w
+			},
+			expect: false,
+		},
+		{
+			name: "On break",
+			setup: func() *Worker {
+				w := newMatchableWorker(t, "W6", "ZONE-A")
+				w.AddSkill(SkillHazmatCertification, 2, true)
+				require.NoError(t, w.StartBreak("lunch"))
+				return w
+			},
+			expect: false,
+		},
+		{
+			name: "Already on a task",
+			setup: func() *Worker {
+				w := newMatchableWorker(t, "W7", "ZONE-A")
+				w.AddSkill(SkillHazmatCertification, 2, true)
+				require.NoError(t, w.AssignTask("TASK-1", TaskTypePacking, 1))
+				return w
+			},
+			expect: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, tt.setup().Matches(criteria))
+		})
+	}
+}
+
+// TestMatchWorkers tests ranking of eligible workers
+func TestMatchWorkers(t *testing.T) {
+	expert := newMatchableWorker(t, "W-EXPERT", "ZONE-A")
+	expert.AddSkill(TaskTypePacking, 5, true)
+
+	busy := newMatchableWorker(t, "W-BUSY", "ZONE-A")
+	busy.AddSkill(TaskTypePacking, 3, true)
+
+	// Tasks completed this shift do not count as load
+	fresh := newMatchableWorker(t, "W-FRESH", "ZONE-A")
+	fresh.AddSkill(TaskTypePacking, 3, true)
+	fresh.CurrentShift.TasksCompleted = 40
+
+	fast := newMatchableWorker(t, "W-FAST", "ZONE-A")
+	fast.AddSkill(TaskTypePacking, 3, true)
+	fast.PerformanceMetrics.AverageItemsPerHour = 120
+
+	unqualified := newMatchableWorker(t, "W-NONE", "ZONE-A")
+
+	matches := MatchWorkers(
+		[]*Worker{busy, unqualified, fresh, expert, nil, fast},
+		WorkerMatchCriteria{
+			RequiredSkills:       []TaskType{TaskTypePacking},
+			RequireCertification: true,
+			AssignedTasks:        map[string]int{"W-BUSY": 3, "W-FRESH": 1, "W-FAST": 1},
+		},
+	)
+
+	ids := make([]string, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.Worker.WorkerID)
+	}
+	assert.Equal(t, []string{"W-EXPERT", "W-FAST", "W-FRESH", "W-BUSY"}, ids)
+	assert.Equal(t, 5, matches[0].SkillLevel)
+	assert.Equal(t, 3, matches[3].AssignedTasks)
+
+	limited := MatchWorkers(
+		[]*Worker{busy, fresh, expert},
+		WorkerMatchCriteria{RequiredSkills: []TaskType{TaskTypePacking}, Limit: 1},
+	)
+	require.Len(t, limited, 1)
+	assert.Equal(t, "W-EXPERT", limited[0].Worker.WorkerID)
+}
@@ -10,10 +10,25 @@ type WorkerRepository interface {
 	FindByStatus(ctx context.Context, status WorkerStatus) ([]*Worker, error)
 	FindByZone(ctx context.Context, zone string) ([]*Worker, error)
 	FindAvailableBySkill(ctx context.Context, taskType TaskType, zone string) ([]*Worker, error)
+	FindAvailableWithSkills(ctx context.Context, skills []TaskType, zone string) ([]*Worker, error)
+	// SaveAssignment persists a newly assigned task only if the worker is still available.
+	// It returns ErrWorkerAlreadyAssigned when another assignment won the race.
+	SaveAssignment(ctx context.Context, worker *Worker) error
 	FindAll(ctx context.Context, limit, offset int) ([]*Worker, error)
 	Delete(ctx context.Context, workerID string) error
 }
 
+// QueuedTaskRepository defines the interface for task queue persistence
+type QueuedTaskRepository interface {
+	Save(ctx context.Context, task *QueuedTask) error
+	FindByTaskID(ctx context.Context, taskID string) (*QueuedTask, error)
+	// FindOpen returns queued and assigned tasks
+	FindOpen(ctx context.Context) ([]*QueuedTask, error)
+	// Claim persists a dispatched task only if it is still queued.
+	// It returns ErrQueuedTaskClaimed when another dispatch won the race.
+	Claim(ctx context.Context, task *QueuedTask) error
+}
+
 // EventPublisher defines the interface for publishing domain events
 type EventPublisher interface {
 	Publish(ctx context.Context, event DomainEvent) error
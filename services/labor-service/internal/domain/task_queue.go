@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Task queue errors
+var (
+	ErrQueuedTaskClosed    = errors.New("queued task is already completed or cancelled")
+	ErrQueuedTaskNotQueued = errors.New("queued task is not waiting for dispatch")
+	ErrQueuedTaskClaimed   = errors.New("queued task was dispatched to another worker concurrently")
+	ErrInvalidQueuedTask   = errors.New("queued task requires a task ID and task type")
+)
+
+// QueuedTaskStatus represents where a queued task is in its lifecycle
+type QueuedTaskStatus string
+
+const (
+	QueuedTaskStatusQueued    QueuedTaskStatus = "queued"
+	QueuedTaskStatusAssigned  QueuedTaskStatus = "assigned"
+	QueuedTaskStatusCompleted QueuedTaskStatus = "completed"
+	QueuedTaskStatusCancelled QueuedTaskStatus = "cancelled"
+)
+
+// QueuedTask is work waiting to be dispatched to the next suitable worker.
+// Lower priority values are dispatched first.
+type QueuedTask struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	TaskID      string             `bson:"taskId"`
+	TenantID    string             `bson:"tenantId" json:"tenantId"`
+	FacilityID  string             `bson:"facilityId" json:"facilityId"`
+	WarehouseID string             `bson:"warehouseId" json:"warehouseId"`
+	TaskType    TaskType           `bson:"taskType"`
+	Priority    int                `bson:"priority"`
+	Zone        string             `bson:"zone,omitempty"`
+	WaveID      string             `bson:"waveId,omitempty"`
+	Status      QueuedTaskStatus   `bson:"status"`
+	WorkerID    string             `bson:"workerId,omitempty"`
+	QueuedAt    time.Time          `bson:"queuedAt"`
+	AssignedAt  *time.Time         `bson:"assignedAt,omitempty"`
+	ClosedAt    *time.Time         `bson:"closedAt,omitempty"`
+	Version     int                `bson:"version"` // Incremented on every save for optimistic concurrency
+	UpdatedAt   time.Time          `bson:"updatedAt"`
+}
+
+// NewQueuedTask creates a task waiting for dispatch
+func NewQueuedTask(taskID string, taskType TaskType, priority int, zone, waveID string) (*QueuedTask, error) {
+	if taskID == "" || taskType == "" {
+		return nil, ErrInvalidQueuedTask
+	}
+
+	now := time.Now()
+	return &QueuedTask{
+		TaskID:    taskID,
+		TaskType:  taskType,
+		Priority:  priority,
+		Zone:      zone,
+		WaveID:    waveID,
+		Status:    QueuedTaskStatusQueued,
+		QueuedAt:  now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsOpen reports whether the task is still waiting or being worked
+func (t *QueuedTask) IsOpen() bool {
+	return t.Status == QueuedTaskStatusQueued || t.Status == QueuedTaskStatusAssigned
+}
+
+// Assign dispatches the task to a worker
+func (t *QueuedTask) Assign(workerID string) error {
+	if t.Status != QueuedTaskStatusQueued {
+		return ErrQueuedTaskNotQueued
+	}
+
+	now := time.Now()
+	t.Status = QueuedTaskStatusAssigned
+	t.WorkerID = workerID
+	t.AssignedAt = &now
+	t.UpdatedAt = now
+	return nil
+}
+
+// Release puts an assigned task back in the queue
+func (t *QueuedTask) Release() {
+	if t.Status != QueuedTaskStatusAssigned {
+		return
+	}
+
+	t.Status = QueuedTaskStatusQueued
+	t.WorkerID = ""
+	t.AssignedAt = nil
+	t.UpdatedAt = time.Now()
+}
+
+// Complete closes the task as done
+func (t *QueuedTask) Complete() error {
+	return t.close(QueuedTaskStatusCompleted)
+}
+
+// Cancel withdraws the task
+func (t *QueuedTask) Cancel() error {
+	return t.close(QueuedTaskStatusCancelled)
+}
+
+func (t *QueuedTask) close(status QueuedTaskStatus) error {
+	if !t.IsOpen() {
+		return ErrQueuedTaskClosed
+	}
+
+	now := time.Now()
+	t.Status = status
+	t.ClosedAt = &now
+	t.UpdatedAt = now
+	return nil
+}
+
+// DispatchOrder returns the queued tasks in the order they should be handed to workers:
+// lowest priority value first, replenishment ahead of other work at equal priority, then
+// oldest first. Picking tasks of a wave are held back while any replenishment for that
+// wave is still open, so pickers are not sent to pick faces that have not been topped off.
+func DispatchOrder(open []*QueuedTask) []*QueuedTask {
+	replenishingWaves := make(map[string]bool)
+	for _, task := range open {
+		if task.IsOpen() && task.TaskType == TaskTypeReplenishment && task.WaveID != "" {
+			replenishingWaves[task.WaveID] = true
+		}
+	}
+
+	ready := make([]*QueuedTask, 0, len(open))
+	for _, task := range open {
+		if task.Status != QueuedTaskStatusQueued {
+			continue
+		}
+		if task.TaskType == TaskTypePicking && replenishingWaves[task.WaveID] {
+			continue
+		}
+		ready = append(ready, task)
+	}
+
+	sort.SliceStable(ready, func(i, j int) bool {
+		a, b := ready[i], ready[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		aReplenish, bReplenish := a.TaskType == TaskTypeReplenishment, b.TaskType == TaskTypeReplenishment
+		if aReplenish != bReplenish {
+			return aReplenish
+		}
+		if !a.QueuedAt.Equal(b.QueuedAt) {
+			return a.QueuedAt.Before(b.QueuedAt)
+		}
+		return a.TaskID < b.TaskID
+	})
+
+	return ready
+}
+
+// AssignedTaskCounts counts the open tasks assigned to each worker
+func AssignedTaskCounts(open []*QueuedTask) map[string]int {
+	counts := make(map[string]int)
+	for _, task := range open {
+		if task.Status == QueuedTaskStatusAssigned && task.WorkerID != "" {
+			counts[task.WorkerID]++
+		}
+	}
+	return counts
+}
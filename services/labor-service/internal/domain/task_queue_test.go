@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func queuedTask(t *testing.T, taskID string, taskType TaskType, priority int, waveID string, queuedAt time.Time) *QueuedTask {
+	t.Helper()
+	task, err := NewQueuedTask(taskID, taskType, priority, "ZONE-A", waveID)
+	require.NoError(t, err)
+	task.QueuedAt = queuedAt
+	return task
+}
+
+func taskIDs(tasks []*QueuedTask) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.TaskID)
+	}
+	return ids
+}
+
+// TestQueuedTaskLifecycle tests dispatch, release and closing of a queued task
+func TestQueuedTaskLifecycle(t *testing.T) {
+	_, err := NewQueuedTask("", TaskTypePicking, 1, "", "")
+	assert.ErrorIs(t, err, ErrInvalidQueuedTask)
+
+	task, err := NewQueuedTask("TASK-001", TaskTypeReplenishment, 2, "ZONE-A", "WAVE-001")
+	require.NoError(t, err)
+	assert.Equal(t, QueuedTaskStatusQueued, task.Status)
+	assert.True(t, task.IsOpen())
+
+	require.NoError(t, task.Assign("WORKER-001"))
+	assert.Equal(t, QueuedTaskStatusAssigned, task.Status)
+	assert.Equal(t, "WORKER-001", task.WorkerID)
+	assert.ErrorIs(t, task.Assign("WORKER-002"), ErrQueuedTaskNotQueued)
+
+	task.Release()
+	assert.Equal(t, QueuedTaskStatusQueued, task.Status)
+	assert.Empty(t, task.WorkerID)
+	assert.Nil(t, task.AssignedAt)
+
+	require.NoError(t, task.Complete())
+	assert.False(t, task.IsOpen())
+	require.NotNil(t, task.ClosedAt)
+	assert.ErrorIs(t, task.Cancel(), ErrQueuedTaskClosed)
+}
+
+// TestDispatchOrder tests priority, replenishment-first and wave blocking rules
+func TestDispatchOrder(t *testing.T) {
+	now := time.Now()
+	pickOld := queuedTask(t, "PICK-OLD", TaskTypePicking, 3, "", now.Add(-time.Hour))
+	pickWave := queuedTask(t, "PICK-WAVE", TaskTypePicking, 1, "WAVE-001", now.Add(-2*time.Hour))
+	replenishWave := queuedTask(t, "RPL-WAVE", TaskTypeReplenishment, 3, "WAVE-001", now)
+	replenishMinMax := queuedTask(t, "RPL-MINMAX", TaskTypeReplenishment, 5, "", now)
+	packUrgent := queuedTask(t, "PACK-URGENT", TaskTypePacking, 1, "", now)
+
+	open := []*QueuedTask{pickOld, pickWave, replenishWave, replenishMinMax, packUrgent}
+	assert.Equal(t,
+		[]string{"PACK-URGENT", "RPL-WAVE", "PICK-OLD", "RPL-MINMAX"},
+		taskIDs(DispatchOrder(open)),
+		"picking for a wave waits for its replenishment; replenishment wins ties",
+	)
+
+	// Replenishment being worked still holds back the wave's picking
+	require.NoError(t, replenishWave.Assign("WORKER-001"))
+	assert.NotContains(t, taskIDs(DispatchOrder(open)), "PICK-WAVE")
+	assert.NotContains(t, taskIDs(DispatchOrder(open)), "RPL-WAVE", "assigned tasks are not dispatched again")
+
+	require.NoError(t, replenishWave.Complete())
+	assert.Equal(t, "PICK-WAVE", taskIDs(DispatchOrder(open))[0], "released once the wave is topped off")
+}
+
+// TestAssignedTaskCounts tests that only open assigned tasks count towards a worker's load
+func TestAssignedTaskCounts(t *testing.T) {
+	now := time.Now()
+	first := queuedTask(t, "TASK-1", TaskTypePicking, 1, "", now)
+	second := queuedTask(t, "TASK-2", TaskTypePicking, 1, "", now)
+	other := queuedTask(t, "TASK-3", TaskTypePacking, 1, "", now)
+	queued := queuedTask(t, "TASK-4", TaskTypePacking, 1, "", now)
+	require.NoError(t, first.Assign("WORKER-001"))
+	require.NoError(t, second.Assign("WORKER-001"))
+	require.NoError(t, other.Assign("WORKER-002"))
+	require.NoError(t, other.Complete())
+
+	counts := AssignedTaskCounts([]*QueuedTask{first, second, other, queued})
+	assert.Equal(t, map[string]int{"WORKER-001": 2}, counts)
+}
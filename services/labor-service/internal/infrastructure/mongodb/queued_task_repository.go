@@ -0,0 +1,115 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/labor-service/internal/domain"
+)
+
+// QueuedTaskRepository is the MongoDB implementation of domain.QueuedTaskRepository
+type QueuedTaskRepository struct {
+	collection   *mongo.Collection
+	tenantHelper *tenant.RepositoryHelper
+}
+
+// NewQueuedTaskRepository creates a new QueuedTaskRepository
+func NewQueuedTaskRepository(db *mongo.Database) *QueuedTaskRepository {
+	repo := &QueuedTaskRepository{
+		collection: db.Collection("queued_tasks"),
+	}
+	repo.ensureIndexes(context.Background())
+	return repo
+}
+
+func (r *QueuedTaskRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "taskId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "priority", Value: 1}, {Key: "queuedAt", Value: 1}}},
+		{Keys: bson.D{{Key: "waveId", Value: 1}}},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+// Save persists a queued task. The write only succeeds if the stored task still has the
+// version that was loaded, otherwise it fails with ErrConcurrencyConflict.
+func (r *QueuedTaskRepository) Save(ctx context.Context, task *domain.QueuedTask) error {
+	task.UpdatedAt = time.Now()
+	expectedVersion := task.Version
+	task.Version = expectedVersion + 1
+
+	opts := options.Update().SetUpsert(expectedVersion == 0)
+	filter := sharedMongo.VersionedFilter(bson.M{"taskId": task.TaskID}, expectedVersion)
+	update := bson.M{"$set": task}
+	if task.WorkerID == "" {
+		update["$unset"] = bson.M{"workerId": "", "assignedAt": ""}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	if err := sharedMongo.CheckVersionedUpdate(result, err, "QueuedTask", task.TaskID, expectedVersion); err != nil {
+		task.Version = expectedVersion
+		return fmt.Errorf("failed to save queued task: %w", err)
+	}
+	return nil
+}
+
+// Claim persists a dispatched task only if the stored task is still queued at the version
+// that was loaded, so two workers asking for work at the same time cannot both receive it.
+func (r *QueuedTaskRepository) Claim(ctx context.Context, task *domain.QueuedTask) error {
+	task.UpdatedAt = time.Now()
+	expectedVersion := task.Version
+	task.Version = expectedVersion + 1
+
+	filter := sharedMongo.VersionedFilter(bson.M{
+		"taskId": task.TaskID,
+		"status": domain.QueuedTaskStatusQueued,
+	}, expectedVersion)
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": task})
+	if err != nil {
+		task.Version = expectedVersion
+		return fmt.Errorf("failed to claim queued task: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		task.Version = expectedVersion
+		return domain.ErrQueuedTaskClaimed
+	}
+	return nil
+}
+
+func (r *QueuedTaskRepository) FindByTaskID(ctx context.Context, taskID string) (*domain.QueuedTask, error) {
+	var task domain.QueuedTask
+	filter := bson.M{"taskId": taskID}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	err := r.collection.FindOne(ctx, filter).Decode(&task)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return &task, err
+}
+
+func (r *QueuedTaskRepository) FindOpen(ctx context.Context) ([]*domain.QueuedTask, error) {
+	filter := bson.M{"status": bson.M{"$in": []domain.QueuedTaskStatus{
+		domain.QueuedTaskStatusQueued,
+		domain.QueuedTaskStatusAssigned,
+	}}}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().SetSort(bson.D{{Key: "priority", Value: 1}, {Key: "queuedAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var tasks []*domain.QueuedTask
+	err = cursor.All(ctx, &tasks)
+	return tasks, err
+}
@@ -56,6 +56,22 @@ func (r *WorkerRepository) ensureIndexes(ctx context.Context) {
 }
 
 func (r *WorkerRepository) Save(ctx context.Context, worker *domain.Worker) error {
+	return r.save(ctx, worker, bson.M{"workerId": worker.WorkerID}, true)
+}
+
+// SaveAssignment persists a task assignment only if the stored worker is still available.
+// The conditional update runs inside the transaction, so two concurrent assignments
+// cannot both claim the same worker.
+func (r *WorkerRepository) SaveAssignment(ctx context.Context, worker *domain.Worker) error {
+	filter := bson.M{
+		"workerId": worker.WorkerID,
+		"status":   domain.WorkerStatusAvailable,
+	}
+
+	return r.save(ctx, worker, filter, false)
+}
+
+func (r *WorkerRepository) save(ctx context.Context, worker *domain.Worker, filter bson.M, upsert bool) error {
 	worker.UpdatedAt = time.Now()
 
 	// Start a MongoDB session for transaction
@@ -67,14 +83,20 @@ func (r *WorkerRepository) Save(ctx context.Context, worker *domain.Worker) erro
 
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
-		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"workerId": worker.WorkerID}
+		// 1. Save the aggregate. A finished task is unset explicitly so it does not linger.
+		opts := options.Update().SetUpsert(upsert)
 		update := bson.M{"$set": worker}
+		if worker.CurrentTask == nil {
+			update["$unset"] = bson.M{"currentTask": ""}
+		}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err != nil {
 			return nil, fmt.Errorf("failed to save worker: %w", err)
 		}
+		if !upsert && result.MatchedCount == 0 {
+			return nil, domain.ErrWorkerAlreadyAssigned
+		}
 
 		// 2. Save domain events to outbox
 		domainEvents := worker.GetDomainEvents()
@@ -204,6 +226,27 @@ func (r *WorkerRepository) FindAvailableBySkill(ctx context.Context, taskType do
 	return workers, err
 }
 
+// FindAvailableWithSkills finds available workers holding every one of the given skills
+func (r *WorkerRepository) FindAvailableWithSkills(ctx context.Context, skills []domain.TaskType, zone string) ([]*domain.Worker, error) {
+	filter := bson.M{"status": domain.WorkerStatusAvailable}
+	if len(skills) > 0 {
+		filter["skills.type"] = bson.M{"$all": skills}
+	}
+	if zone != "" {
+		filter["currentZone"] = zone
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var workers []*domain.Worker
+	err = cursor.All(ctx, &workers)
+	return workers, err
+}
+
 func (r *WorkerRepository) FindAll(ctx context.Context, limit, offset int) ([]*domain.Worker, error) {
 	opts := options.Find().SetLimit(int64(limit)).SetSkip(int64(offset))
 	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
@@ -302,6 +302,48 @@ func TestWorkerRepository_FindAvailableBySkill(t *testing.T) {
 	})
 }
 
+// TestWorkerRepository_SaveAssignment tests that a worker cannot be double-booked
+func TestWorkerRepository_SaveAssignment(t *testing.T) {
+	repo, _, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	worker := createTestWorker("WRK-ASSIGN-1", "EMP-ASSIGN-1", "Assign Worker", domain.WorkerStatusOffline)
+	require.NoError(t, worker.StartShift("SHIFT-ASSIGN-1", "morning", "ZONE-C"))
+	require.NoError(t, repo.Save(ctx, worker))
+
+	// Two callers load the same available worker
+	first, err := repo.FindByID(ctx, "WRK-ASSIGN-1")
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, "WRK-ASSIGN-1")
+	require.NoError(t, err)
+
+	require.NoError(t, first.AssignTask("TASK-1", domain.TaskTypePicking, 1))
+	require.NoError(t, repo.SaveAssignment(ctx, first))
+
+	require.NoError(t, second.AssignTask("TASK-2", domain.TaskTypePicking, 1))
+	err = repo.SaveAssignment(ctx, second)
+	assert.ErrorIs(t, err, domain.ErrWorkerAlreadyAssigned)
+
+	stored, err := repo.FindByID(ctx, "WRK-ASSIGN-1")
+	require.NoError(t, err)
+	require.NotNil(t, stored.CurrentTask)
+	assert.Equal(t, "TASK-1", stored.CurrentTask.TaskID)
+
+	// Completing the task clears it from the stored document
+	require.NoError(t, stored.CompleteTask(3))
+	require.NoError(t, repo.Save(ctx, stored))
+	stored, err = repo.FindByID(ctx, "WRK-ASSIGN-1")
+	require.NoError(t, err)
+	assert.Nil(t, stored.CurrentTask)
+
+	workers, err := repo.FindAvailableWithSkills(ctx, []domain.TaskType{domain.TaskTypePicking, domain.TaskTypePacking}, "ZONE-C")
+	require.NoError(t, err)
+	assert.Len(t, workers, 1)
+}
+
 // TestWorkerRepository_FindAll tests finding all workers with pagination
 func TestWorkerRepository_FindAll(t *testing.T) {
 	repo, _, cleanup := setupTestRepository(t)
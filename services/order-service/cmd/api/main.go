@@ -344,6 +344,7 @@ type CreateOrderRequest struct {
 	ShippingAddress    domain.Address     `json:"shippingAddress" binding:"required"`
 	Priority           string             `json:"priority" binding:"required"`
 	PromisedDeliveryAt time.Time          `json:"promisedDeliveryAt" binding:"required"`
+	ExternalOrderID    string             `json:"externalOrderId,omitempty"` // Order ID in the sales channel the order was imported from
 }
 
 // CancelOrderRequest is the request body for cancelling an order
@@ -373,6 +374,7 @@ func createOrderHandler(service *application.OrderApplicationService, logger *lo
 			ShippingAddress:    toAddressInput(req.ShippingAddress),
 			Priority:           req.Priority,
 			PromisedDeliveryAt: req.PromisedDeliveryAt,
+			ExternalOrderID:    req.ExternalOrderID,
 		}
 
 		// Add span attributes for tracing
@@ -739,9 +741,14 @@ func toOrderItemInputs(items []domain.OrderItem) []application.OrderItemInput {
 	inputs := make([]application.OrderItemInput, 0, len(items))
 	for _, item := range items {
 		inputs = append(inputs, application.OrderItemInput{
-			SKU:      item.SKU,
-			Quantity: item.Quantity,
-			Weight:   item.Weight,
+			SKU:                item.SKU,
+			Name:               item.Name,
+			Quantity:           item.Quantity,
+			Weight:             item.Weight,
+			UnitPrice:          item.UnitPrice,
+			HSCode:             item.HSCode,
+			CountryOfOrigin:    item.CountryOfOrigin,
+			CustomsDescription: item.CustomsDescription,
 		})
 	}
 	return inputs
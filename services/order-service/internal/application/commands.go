@@ -24,9 +24,15 @@ type CreateOrderCommand struct {
 
 // OrderItemInput represents an order item in a command
 type OrderItemInput struct {
-	SKU      string
-	Quantity int
-	Weight   float64
+	SKU       string
+	Name      string
+	Quantity  int
+	Weight    float64
+	UnitPrice float64
+	// Customs data for international shipments
+	HSCode             string
+	CountryOfOrigin    string
+	CustomsDescription string
 }
 
 // AddressInput represents an address in a command
@@ -98,9 +104,14 @@ func (c *CreateOrderCommand) ToDomainOrderItems() []domain.OrderItem {
 	items := make([]domain.OrderItem, 0, len(c.Items))
 	for _, item := range c.Items {
 		items = append(items, domain.OrderItem{
-			SKU:      item.SKU,
-			Quantity: item.Quantity,
-			Weight:   item.Weight,
+			SKU:                item.SKU,
+			Name:               item.Name,
+			Quantity:           item.Quantity,
+			Weight:             item.Weight,
+			UnitPrice:          item.UnitPrice,
+			HSCode:             item.HSCode,
+			CountryOfOrigin:    item.CountryOfOrigin,
+			CustomsDescription: item.CustomsDescription,
 		})
 	}
 	return items
@@ -22,9 +22,25 @@ type OrderDTO struct {
 
 // OrderItemDTO represents an order item in responses
 type OrderItemDTO struct {
-	SKU      string  `json:"sku"`
-	Quantity int     `json:"quantity"`
-	Weight   float64 `json:"weight"`
+	SKU        string         `json:"sku"`
+	Quantity   int            `json:"quantity"`
+	Weight     float64        `json:"weight"`
+	Dimensions *DimensionsDTO `json:"dimensions,omitempty"`
+	IsFragile  bool           `json:"isFragile"`
+	IsHazmat   bool           `json:"isHazmat"`
+	UnitPrice  float64        `json:"unitPrice,omitempty"`
+
+	// Customs data for international shipments
+	HSCode             string `json:"hsCode,omitempty"`
+	CountryOfOrigin    string `json:"countryOfOrigin,omitempty"`
+	CustomsDescription string `json:"customsDescription,omitempty"`
+}
+
+// DimensionsDTO represents item dimensions in cm
+type DimensionsDTO struct {
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
 }
 
 // AddressDTO represents an address in responses
@@ -14,11 +14,26 @@ func ToOrderDTO(order *domain.Order) *OrderDTO {
 
 	items := make([]OrderItemDTO, 0, len(order.Items))
 	for _, item := range order.Items {
-		items = append(items, OrderItemDTO{
-			SKU:      item.SKU,
-			Quantity: item.Quantity,
-			Weight:   item.Weight,
-		})
+		dto := OrderItemDTO{
+			SKU:       item.SKU,
+			Quantity:  item.Quantity,
+			Weight:    item.Weight,
+			IsFragile: item.IsFragile,
+			IsHazmat:  item.IsHazmat,
+			UnitPrice: item.UnitPrice,
+
+			HSCode:             item.HSCode,
+			CountryOfOrigin:    item.CountryOfOrigin,
+			CustomsDescription: item.CustomsDescription,
+		}
+		if item.Dimensions.Length > 0 && item.Dimensions.Width > 0 && item.Dimensions.Height > 0 {
+			dto.Dimensions = &DimensionsDTO{
+				Length: item.Dimensions.Length,
+				Width:  item.Dimensions.Width,
+				Height: item.Dimensions.Height,
+			}
+		}
+		items = append(items, dto)
 	}
 
 	return &OrderDTO{
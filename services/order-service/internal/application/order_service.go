@@ -12,6 +12,7 @@ import (
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
 	"github.com/wms-platform/shared/pkg/middleware"
+	"github.com/wms-platform/shared/pkg/resilience"
 	"github.com/wms-platform/shared/pkg/temporal"
 	"github.com/wms-platform/shared/pkg/tenant"
 
@@ -66,6 +67,12 @@ func (s *OrderApplicationService) CreateOrder(ctx context.Context, cmd CreateOrd
 		ExternalOrderID: cmd.ExternalOrderID,
 	}
 
+	// A channel order is imported at most once. Retries after a lost response or a crash
+	// return the order created by the first attempt.
+	if existing, err := s.findImportedOrder(ctx, tenantInfo); err != nil || existing != nil {
+		return existing, err
+	}
+
 	// Generate order ID
 	orderID := "ORD-" + uuid.New().String()[:8]
 
@@ -88,6 +95,12 @@ func (s *OrderApplicationService) CreateOrder(ctx context.Context, cmd CreateOrd
 
 	// Save to repository
 	if err := s.orderRepo.Save(ctx, order); err != nil {
+		if errors.IsConcurrencyConflict(err) {
+			// A concurrent import of the same channel order won the unique index
+			if existing, findErr := s.findImportedOrder(ctx, tenantInfo); findErr != nil || existing != nil {
+				return existing, findErr
+			}
+		}
 		s.logger.WithError(err).Error("Failed to save order", "orderId", orderID)
 		return nil, fmt.Errorf("failed to save order: %w", err)
 	}
@@ -127,6 +140,28 @@ func (s *OrderApplicationService) CreateOrder(ctx context.Context, cmd CreateOrd
 	}, nil
 }
 
+// findImportedOrder returns the order already created for a channel order, or nil when the
+// order was not imported from a channel or has not been created yet
+func (s *OrderApplicationService) findImportedOrder(ctx context.Context, tenantInfo *domain.TenantInfo) (*OrderCreatedResponse, error) {
+	if tenantInfo.ChannelID == "" || tenantInfo.ExternalOrderID == "" {
+		return nil, nil
+	}
+
+	existing, err := s.orderRepo.FindByExternalOrderID(ctx, tenantInfo.ChannelID, tenantInfo.ExternalOrderID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to look up imported order",
+			"channelId", tenantInfo.ChannelID, "externalOrderId", tenantInfo.ExternalOrderID)
+		return nil, errors.ErrInternal("failed to look up imported order").Wrap(err)
+	}
+	if existing == nil {
+		return nil, nil
+	}
+
+	s.logger.Info("Channel order already imported",
+		"orderId", existing.OrderID, "channelId", tenantInfo.ChannelID, "externalOrderId", tenantInfo.ExternalOrderID)
+	return &OrderCreatedResponse{Order: *ToOrderDTO(existing)}, nil
+}
+
 // GetOrder retrieves an order by ID
 func (s *OrderApplicationService) GetOrder(ctx context.Context, query GetOrderQuery) (*OrderDTO, error) {
 	order, err := s.orderRepo.FindByID(ctx, query.OrderID)
@@ -144,28 +179,15 @@ func (s *OrderApplicationService) GetOrder(ctx context.Context, query GetOrderQu
 
 // ValidateOrder validates an order
 func (s *OrderApplicationService) ValidateOrder(ctx context.Context, cmd ValidateOrderCommand) (*OrderDTO, error) {
-	order, err := s.orderRepo.FindByID(ctx, cmd.OrderID)
+	order, events, err := updateOrder(ctx, s.orderRepo, s.logger, cmd.OrderID, func(order *domain.Order) error {
+		// Validate the order (domain logic)
+		if err := order.Validate(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
-	}
-
-	if order == nil {
-		return nil, errors.ErrNotFound("order")
-	}
-
-	// Validate the order (domain logic)
-	if err := order.Validate(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	// Capture events before save
-	events := order.DomainEvents()
-
-	// Save the updated order
-	if err := s.orderRepo.Save(ctx, order); err != nil {
-		s.logger.WithError(err).Error("Failed to save order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to save order: %w", err)
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -186,28 +208,15 @@ func (s *OrderApplicationService) ValidateOrder(ctx context.Context, cmd Validat
 
 // CancelOrder cancels an order with a reason
 func (s *OrderApplicationService) CancelOrder(ctx context.Context, cmd CancelOrderCommand) (*OrderDTO, error) {
-	order, err := s.orderRepo.FindByID(ctx, cmd.OrderID)
+	order, events, err := updateOrder(ctx, s.orderRepo, s.logger, cmd.OrderID, func(order *domain.Order) error {
+		// Cancel the order (domain logic)
+		if err := order.Cancel(cmd.Reason); err != nil {
+			return errors.ErrConflict(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
-	}
-
-	if order == nil {
-		return nil, errors.ErrNotFound("order")
-	}
-
-	// Cancel the order (domain logic)
-	if err := order.Cancel(cmd.Reason); err != nil {
-		return nil, errors.ErrConflict(err.Error())
-	}
-
-	// Capture events before save
-	events := order.DomainEvents()
-
-	// Save the updated order
-	if err := s.orderRepo.Save(ctx, order); err != nil {
-		s.logger.WithError(err).Error("Failed to save order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to save order: %w", err)
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -269,28 +278,15 @@ func (s *OrderApplicationService) ListOrders(ctx context.Context, query ListOrde
 
 // AssignToWave assigns an order to a wave (called by waving-service)
 func (s *OrderApplicationService) AssignToWave(ctx context.Context, cmd AssignToWaveCommand) (*OrderDTO, error) {
-	order, err := s.orderRepo.FindByID(ctx, cmd.OrderID)
+	order, events, err := updateOrder(ctx, s.orderRepo, s.logger, cmd.OrderID, func(order *domain.Order) error {
+		// Assign to wave (domain logic)
+		if err := order.AssignToWave(cmd.WaveID); err != nil {
+			return errors.ErrConflict(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
-	}
-
-	if order == nil {
-		return nil, errors.ErrNotFound("order")
-	}
-
-	// Assign to wave (domain logic)
-	if err := order.AssignToWave(cmd.WaveID); err != nil {
-		return nil, errors.ErrConflict(err.Error())
-	}
-
-	// Capture events before save
-	events := order.DomainEvents()
-
-	// Save the updated order
-	if err := s.orderRepo.Save(ctx, order); err != nil {
-		s.logger.WithError(err).Error("Failed to save order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to save order: %w", err)
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -314,28 +310,15 @@ func (s *OrderApplicationService) AssignToWave(ctx context.Context, cmd AssignTo
 
 // MarkShipped marks an order as shipped (called by shipping-service)
 func (s *OrderApplicationService) MarkShipped(ctx context.Context, cmd MarkShippedCommand) (*OrderDTO, error) {
-	order, err := s.orderRepo.FindByID(ctx, cmd.OrderID)
+	order, events, err := updateOrder(ctx, s.orderRepo, s.logger, cmd.OrderID, func(order *domain.Order) error {
+		// Mark as shipped (domain logic)
+		if err := order.MarkShipped(cmd.TrackingNumber); err != nil {
+			return errors.ErrConflict(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
-	}
-
-	if order == nil {
-		return nil, errors.ErrNotFound("order")
-	}
-
-	// Mark as shipped (domain logic)
-	if err := order.MarkShipped(cmd.TrackingNumber); err != nil {
-		return nil, errors.ErrConflict(err.Error())
-	}
-
-	// Capture events before save
-	events := order.DomainEvents()
-
-	// Save the updated order
-	if err := s.orderRepo.Save(ctx, order); err != nil {
-		s.logger.WithError(err).Error("Failed to save order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to save order: %w", err)
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -359,28 +342,15 @@ func (s *OrderApplicationService) MarkShipped(ctx context.Context, cmd MarkShipp
 
 // StartPicking marks an order as picking in progress (called by orchestrator)
 func (s *OrderApplicationService) StartPicking(ctx context.Context, cmd StartPickingCommand) (*OrderDTO, error) {
-	order, err := s.orderRepo.FindByID(ctx, cmd.OrderID)
+	order, events, err := updateOrder(ctx, s.orderRepo, s.logger, cmd.OrderID, func(order *domain.Order) error {
+		// Start picking (domain logic)
+		if err := order.StartPicking(); err != nil {
+			return errors.ErrConflict(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
-	}
-
-	if order == nil {
-		return nil, errors.ErrNotFound("order")
-	}
-
-	// Start picking (domain logic)
-	if err := order.StartPicking(); err != nil {
-		return nil, errors.ErrConflict(err.Error())
-	}
-
-	// Capture events before save
-	events := order.DomainEvents()
-
-	// Save the updated order
-	if err := s.orderRepo.Save(ctx, order); err != nil {
-		s.logger.WithError(err).Error("Failed to save order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to save order: %w", err)
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -399,28 +369,15 @@ func (s *OrderApplicationService) StartPicking(ctx context.Context, cmd StartPic
 
 // MarkConsolidated marks an order as consolidated (called by orchestrator)
 func (s *OrderApplicationService) MarkConsolidated(ctx context.Context, cmd MarkConsolidatedCommand) (*OrderDTO, error) {
-	order, err := s.orderRepo.FindByID(ctx, cmd.OrderID)
+	order, events, err := updateOrder(ctx, s.orderRepo, s.logger, cmd.OrderID, func(order *domain.Order) error {
+		// Mark as consolidated (domain logic)
+		if err := order.MarkConsolidated(); err != nil {
+			return errors.ErrConflict(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
-	}
-
-	if order == nil {
-		return nil, errors.ErrNotFound("order")
-	}
-
-	// Mark as consolidated (domain logic)
-	if err := order.MarkConsolidated(); err != nil {
-		return nil, errors.ErrConflict(err.Error())
-	}
-
-	// Capture events before save
-	events := order.DomainEvents()
-
-	// Save the updated order
-	if err := s.orderRepo.Save(ctx, order); err != nil {
-		s.logger.WithError(err).Error("Failed to save order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to save order: %w", err)
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -439,28 +396,15 @@ func (s *OrderApplicationService) MarkConsolidated(ctx context.Context, cmd Mark
 
 // MarkPacked marks an order as packed (called by orchestrator)
 func (s *OrderApplicationService) MarkPacked(ctx context.Context, cmd MarkPackedCommand) (*OrderDTO, error) {
-	order, err := s.orderRepo.FindByID(ctx, cmd.OrderID)
+	order, events, err := updateOrder(ctx, s.orderRepo, s.logger, cmd.OrderID, func(order *domain.Order) error {
+		// Mark as packed (domain logic)
+		if err := order.MarkPacked(); err != nil {
+			return errors.ErrConflict(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
-	}
-
-	if order == nil {
-		return nil, errors.ErrNotFound("order")
-	}
-
-	// Mark as packed (domain logic)
-	if err := order.MarkPacked(); err != nil {
-		return nil, errors.ErrConflict(err.Error())
-	}
-
-	// Capture events before save
-	events := order.DomainEvents()
-
-	// Save the updated order
-	if err := s.orderRepo.Save(ctx, order); err != nil {
-		s.logger.WithError(err).Error("Failed to save order", "orderId", cmd.OrderID)
-		return nil, fmt.Errorf("failed to save order: %w", err)
+		return nil, err
 	}
 
 	// Update CQRS projections
@@ -536,6 +480,57 @@ type WorkflowItem struct {
 	Weight   float64 `json:"weight"`
 }
 
+// updateOrder loads an order, applies change and saves it. If another writer saved the
+// order in the meantime, the order is reloaded and change applied again, so change must
+// only depend on the order it is given. Errors returned by change are passed through as is.
+func updateOrder(
+	ctx context.Context,
+	repo domain.OrderRepository,
+	logger *logging.Logger,
+	orderID string,
+	change func(order *domain.Order) error,
+) (*domain.Order, []domain.DomainEvent, error) {
+	var order *domain.Order
+	var events []domain.DomainEvent
+
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := repo.FindByID(ctx, orderID)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get order", "orderId", orderID)
+			return fmt.Errorf("failed to get order: %w", err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("order")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		// Capture events before save
+		captured := loaded.DomainEvents()
+		if err := repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				logger.Debug("Order changed concurrently, retrying", "orderId", orderID)
+				return err
+			}
+			logger.WithError(err).Error("Failed to save order", "orderId", orderID)
+			return fmt.Errorf("failed to save order: %w", err)
+		}
+
+		order = loaded
+		events = captured
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, nil, errors.ErrConflict(fmt.Sprintf("order %s was modified concurrently, please retry", orderID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return order, events, nil
+}
+
 // updateProjections updates the CQRS read model based on domain events
 // Call this after successfully saving an order to keep projections in sync
 func (s *OrderApplicationService) updateProjections(ctx context.Context, events []domain.DomainEvent) {
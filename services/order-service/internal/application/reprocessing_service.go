@@ -295,25 +295,15 @@ func (s *ReprocessingService) IncrementRetryCount(ctx context.Context, cmd Incre
 
 // ResetOrderForRetry resets an order for reprocessing
 func (s *ReprocessingService) ResetOrderForRetry(ctx context.Context, orderID string) (*OrderDTO, error) {
-	order, err := s.orderRepo.FindByID(ctx, orderID)
+	order, _, err := updateOrder(ctx, s.orderRepo, s.logger, orderID, func(order *domain.Order) error {
+		// Reset the order using domain method
+		if err := order.ResetForRetry(); err != nil {
+			return errors.ErrConflict(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get order", "orderId", orderID)
-		return nil, fmt.Errorf("failed to get order: %w", err)
-	}
-
-	if order == nil {
-		return nil, errors.ErrNotFound("order")
-	}
-
-	// Reset the order using domain method
-	if err := order.ResetForRetry(); err != nil {
-		return nil, errors.ErrConflict(err.Error())
-	}
-
-	// Save the updated order
-	if err := s.orderRepo.Save(ctx, order); err != nil {
-		s.logger.WithError(err).Error("Failed to save order", "orderId", orderID)
-		return nil, fmt.Errorf("failed to save order: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Reset order for retry", "orderId", orderID)
@@ -372,12 +362,11 @@ func (s *ReprocessingService) MoveToDeadLetterQueue(ctx context.Context, cmd Mov
 	}
 
 	// Update order status
-	if err := order.MoveToDeadLetter(cmd.FailureReason); err != nil {
+	_, _, err = updateOrder(ctx, s.orderRepo, s.logger, cmd.OrderID, func(order *domain.Order) error {
+		return order.MoveToDeadLetter(cmd.FailureReason)
+	})
+	if err != nil {
 		s.logger.WithError(err).Warn("Failed to update order status to dead_letter", "orderId", cmd.OrderID)
-	} else {
-		if err := s.orderRepo.Save(ctx, order); err != nil {
-			s.logger.WithError(err).Warn("Failed to save order after DLQ move", "orderId", cmd.OrderID)
-		}
 	}
 
 	// Clean up retry metadata
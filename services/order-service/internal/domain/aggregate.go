@@ -2,18 +2,22 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/wms-platform/shared/pkg/customs"
 )
 
 // Errors for Order aggregate
 var (
-	ErrNoItems           = errors.New("order must have at least one item")
-	ErrInvalidPriority   = errors.New("invalid order priority")
-	ErrInvalidStatus     = errors.New("invalid status transition")
-	ErrOrderCancelled    = errors.New("order has been cancelled")
-	ErrOrderAlreadyWaved = errors.New("order already assigned to a wave")
+	ErrNoItems            = errors.New("order must have at least one item")
+	ErrInvalidPriority    = errors.New("invalid order priority")
+	ErrInvalidStatus      = errors.New("invalid status transition")
+	ErrOrderCancelled     = errors.New("order has been cancelled")
+	ErrOrderAlreadyWaved  = errors.New("order already assigned to a wave")
+	ErrInvalidCustomsData = errors.New("invalid customs data")
 )
 
 // Priority represents order priority levels
@@ -78,6 +82,7 @@ type Order struct {
 	TrackingNumber      string             `bson:"trackingNumber,omitempty" json:"trackingNumber,omitempty"`
 	CreatedAt           time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt           time.Time          `bson:"updatedAt" json:"updatedAt"`
+	Version             int                `bson:"version" json:"version"`
 	GiftWrap            bool               `bson:"giftWrap" json:"giftWrap"`
 	ProcessRequirements OrderRequirements  `bson:"processRequirements" json:"processRequirements"`
 
@@ -98,6 +103,34 @@ type OrderItem struct {
 	IsFragile         bool    `bson:"isFragile" json:"isFragile"`
 	IsHazmat          bool    `bson:"isHazmat" json:"isHazmat"`
 	RequiresColdChain bool    `bson:"requiresColdChain" json:"requiresColdChain"`
+
+	// Customs data for international shipments
+	HSCode             string `bson:"hsCode,omitempty" json:"hsCode,omitempty"`                         // Harmonized System tariff code
+	CountryOfOrigin    string `bson:"countryOfOrigin,omitempty" json:"countryOfOrigin,omitempty"`       // ISO 3166-1 alpha-2
+	CustomsDescription string `bson:"customsDescription,omitempty" json:"customsDescription,omitempty"` // Plain-language description for customs forms
+}
+
+// normalizeCustoms normalizes the item's customs data and rejects malformed codes.
+// Customs data is optional; only what is present is checked.
+func (i *OrderItem) normalizeCustoms() error {
+	if i.HSCode != "" {
+		i.HSCode = customs.NormalizeHSCode(i.HSCode)
+		if err := customs.ValidateHSCode(i.HSCode); err != nil {
+			return fmt.Errorf("%w: item %s: %v", ErrInvalidCustomsData, i.SKU, err)
+		}
+	}
+	if i.CountryOfOrigin != "" {
+		i.CountryOfOrigin = customs.NormalizeCountryCode(i.CountryOfOrigin)
+		if err := customs.ValidateCountryCode(i.CountryOfOrigin); err != nil {
+			return fmt.Errorf("%w: item %s: %v", ErrInvalidCustomsData, i.SKU, err)
+		}
+	}
+	return nil
+}
+
+// HasCustomsData reports whether the item carries what a customs declaration needs
+func (i *OrderItem) HasCustomsData() bool {
+	return i.HSCode != "" && i.CountryOfOrigin != ""
 }
 
 // IsOversized returns true if the item exceeds standard shipping dimensions
@@ -178,6 +211,12 @@ func NewOrderWithTenant(
 		return nil, ErrInvalidPriority
 	}
 
+	for i := range items {
+		if err := items[i].normalizeCustoms(); err != nil {
+			return nil, err
+		}
+	}
+
 	now := time.Now().UTC()
 	order := &Order{
 		ID:                 primitive.NewObjectID(),
@@ -383,6 +422,18 @@ func (o *Order) TotalValue() float64 {
 	return total
 }
 
+// ItemsMissingCustomsData returns the SKUs of items without an HS code or country of origin.
+// International shipments cannot be labeled until these are filled in.
+func (o *Order) ItemsMissingCustomsData() []string {
+	var skus []string
+	for _, item := range o.Items {
+		if !item.HasCustomsData() {
+			skus = append(skus, item.SKU)
+		}
+	}
+	return skus
+}
+
 // CalculateRequirements analyzes the order and populates ProcessRequirements
 // This should be called when the order is created or modified
 func (o *Order) CalculateRequirements() {
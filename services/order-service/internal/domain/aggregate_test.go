@@ -118,6 +118,30 @@ func TestNewOrder(t *testing.T) {
 	}
 }
 
+// TestNewOrderCustomsData tests customs data on order lines
+func TestNewOrderCustomsData(t *testing.T) {
+	items := createTestOrderItems()
+	items[0].HSCode = "6109.10.00"
+	items[0].CountryOfOrigin = "pt"
+	items = append(items, OrderItem{SKU: "SKU-002", Quantity: 1, Weight: 0.5, UnitPrice: 10})
+
+	order, err := NewOrder("ORD-010", "CUST-001", items, createTestAddress(), PriorityStandard, time.Now().Add(72*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "61091000", order.Items[0].HSCode)
+	assert.Equal(t, "PT", order.Items[0].CountryOfOrigin)
+	assert.Equal(t, []string{"SKU-002"}, order.ItemsMissingCustomsData())
+
+	invalid := createTestOrderItems()
+	invalid[0].HSCode = "61-09"
+	_, err = NewOrder("ORD-011", "CUST-001", invalid, createTestAddress(), PriorityStandard, time.Now().Add(72*time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidCustomsData)
+
+	invalid = createTestOrderItems()
+	invalid[0].CountryOfOrigin = "Portugal"
+	_, err = NewOrder("ORD-012", "CUST-001", invalid, createTestAddress(), PriorityStandard, time.Now().Add(72*time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidCustomsData)
+}
+
 // TestOrderValidate tests order validation
 func TestOrderValidate(t *testing.T) {
 	tests := []struct {
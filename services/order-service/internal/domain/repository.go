@@ -18,6 +18,9 @@ type OrderRepository interface {
 	// FindByStatus retrieves orders by status
 	FindByStatus(ctx context.Context, status Status, pagination Pagination) ([]*Order, error)
 
+	// FindByExternalOrderID retrieves the order imported from a sales channel order, or nil if none was
+	FindByExternalOrderID(ctx context.Context, channelID, externalOrderID string) (*Order, error)
+
 	// FindByWaveID retrieves all orders in a wave
 	FindByWaveID(ctx context.Context, waveID string) ([]*Order, error)
 
@@ -13,6 +13,7 @@ import (
 	"github.com/wms-platform/services/order-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -89,7 +90,8 @@ func NewOrderRepository(db *mongo.Database, eventFactory *cloudevents.EventFacto
 				{Key: "priority", Value: 1},
 			},
 		},
-		// Channel integration index
+		// Channel integration index. A channel order can only be imported once, so a retried
+		// import that races the original fails on insert instead of creating a second order.
 		{
 			Keys: bson.D{
 				{Key: "tenantId", Value: 1},
@@ -97,6 +99,10 @@ func NewOrderRepository(db *mongo.Database, eventFactory *cloudevents.EventFacto
 				{Key: "channelId", Value: 1},
 				{Key: "externalOrderId", Value: 1},
 			},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+				"channelId":       bson.M{"$type": "string"},
+				"externalOrderId": bson.M{"$type": "string"},
+			}),
 		},
 	}
 
@@ -114,9 +120,12 @@ func NewOrderRepository(db *mongo.Database, eventFactory *cloudevents.EventFacto
 	}
 }
 
-// Save persists an order with its domain events in a single transaction
+// Save persists an order with its domain events in a single transaction. The update is
+// conditional on the order's version so a stale copy cannot overwrite a newer one.
 func (r *OrderRepository) Save(ctx context.Context, order *domain.Order) error {
 	order.UpdatedAt = time.Now().UTC()
+	expectedVersion := order.Version
+	order.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -128,11 +137,12 @@ func (r *OrderRepository) Save(ctx context.Context, order *domain.Order) error {
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"orderId": order.OrderID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"orderId": order.OrderID}, expectedVersion)
 		update := bson.M{"$set": order}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "Order", order.OrderID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save order: %w", err)
 		}
 
@@ -188,6 +198,7 @@ func (r *OrderRepository) Save(ctx context.Context, order *domain.Order) error {
 	})
 
 	if err != nil {
+		order.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
@@ -357,6 +368,7 @@ func (r *OrderRepository) UpdateStatus(ctx context.Context, orderID string, stat
 			"status":    status,
 			"updatedAt": time.Now().UTC(),
 		},
+		"$inc": bson.M{sharedMongo.VersionField: 1},
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -385,6 +397,7 @@ func (r *OrderRepository) AssignToWave(ctx context.Context, orderID string, wave
 			"status":    domain.StatusWaveAssigned,
 			"updatedAt": time.Now().UTC(),
 		},
+		"$inc": bson.M{sharedMongo.VersionField: 1},
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -409,6 +422,7 @@ func (r *OrderRepository) Delete(ctx context.Context, orderID string) error {
 			"status":    domain.StatusCancelled,
 			"updatedAt": time.Now().UTC(),
 		},
+		"$inc": bson.M{sharedMongo.VersionField: 1},
 	}
 
 	_, err := r.collection.UpdateOne(ctx, filter, update)
@@ -95,6 +95,7 @@ func main() {
 
 	// Initialize repositories with instrumented client and event factory
 	repo := mongoRepo.NewPackTaskRepository(instrumentedMongo.Database(), eventFactory)
+	cartonRepo := mongoRepo.NewCartonRepository(instrumentedMongo.Database())
 
 	// Initialize idempotency repository
 	idempotencyKeyRepo := idempotency.NewMongoKeyRepository(instrumentedMongo.Database())
@@ -121,10 +122,12 @@ func main() {
 	// Initialize application service
 	packingService := application.NewPackingApplicationService(
 		repo,
+		cartonRepo,
 		instrumentedProducer,
 		eventFactory,
 		logger,
 	)
+	cartonService := application.NewCartonCatalogService(cartonRepo, logger)
 
 	// Setup Gin router with middleware
 	router := gin.New()
@@ -178,6 +181,7 @@ func main() {
 		api.POST("/:taskId/assign", assignPackTaskHandler(packingService, logger))
 		api.POST("/:taskId/start", startPackTaskHandler(packingService, logger))
 		api.POST("/:taskId/verify", verifyItemHandler(packingService, logger))
+		api.POST("/:taskId/cartonize", cartonizePackTaskHandler(packingService, logger))
 		api.POST("/:taskId/package", selectPackagingHandler(packingService, logger))
 		api.POST("/:taskId/seal", sealPackageHandler(packingService, logger))
 		api.POST("/:taskId/label", applyLabelHandler(packingService, logger))
@@ -188,6 +192,17 @@ func main() {
 		api.GET("/pending", getPendingHandler(packingService, logger))
 	}
 
+	// Carton catalog for the facility in the tenant headers
+	cartons := router.Group("/api/v1/cartons")
+	cartons.Use(middleware.RequireTenantAuth())
+	{
+		cartons.POST("", saveCartonHandler(cartonService, logger))
+		cartons.GET("", listCartonsHandler(cartonService, logger))
+		cartons.GET("/:cartonId", getCartonHandler(cartonService, logger))
+		cartons.PUT("/:cartonId", saveCartonHandler(cartonService, logger))
+		cartons.DELETE("/:cartonId", deleteCartonHandler(cartonService, logger))
+	}
+
 	// Start server
 	srv := &http.Server{
 		Addr:         config.ServerAddr,
@@ -445,10 +460,14 @@ func selectPackagingHandler(service *application.PackingApplicationService, logg
 			"task.id": taskID,
 		})
 
+		// Either a catalog carton or a package type and dimensions. A reason is
+		// required when the choice differs from the cartonization recommendation.
 		var req struct {
-			PackageType string            `json:"packageType" binding:"required"`
-			Dimensions  domain.Dimensions `json:"dimensions" binding:"required"`
-			Materials   []string          `json:"materials"`
+			CartonID       string            `json:"cartonId"`
+			PackageType    string            `json:"packageType"`
+			Dimensions     domain.Dimensions `json:"dimensions"`
+			Materials      []string          `json:"materials"`
+			OverrideReason string            `json:"overrideReason"`
 		}
 
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -458,13 +477,16 @@ func selectPackagingHandler(service *application.PackingApplicationService, logg
 
 		middleware.AddSpanAttributes(c, map[string]interface{}{
 			"package.type": req.PackageType,
+			"carton.id":    req.CartonID,
 		})
 
 		cmd := application.SelectPackagingCommand{
-			TaskID:      taskID,
-			PackageType: domain.PackageType(req.PackageType),
-			Dimensions:  req.Dimensions,
-			Materials:   req.Materials,
+			TaskID:         taskID,
+			CartonID:       req.CartonID,
+			PackageType:    domain.PackageType(req.PackageType),
+			Dimensions:     req.Dimensions,
+			Materials:      req.Materials,
+			OverrideReason: req.OverrideReason,
 		}
 
 		task, err := service.SelectPackaging(c.Request.Context(), cmd)
@@ -481,6 +503,33 @@ func selectPackagingHandler(service *application.PackingApplicationService, logg
 	}
 }
 
+func cartonizePackTaskHandler(service *application.PackingApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		taskID := c.Param("taskId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"task.id": taskID,
+		})
+
+		cmd := application.CartonizePackTaskCommand{
+			TaskID: taskID,
+		}
+
+		task, err := service.CartonizePackTask(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, task)
+	}
+}
+
 func sealPackageHandler(service *application.PackingApplicationService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
@@ -663,3 +712,129 @@ func getPendingHandler(service *application.PackingApplicationService, logger *l
 		c.JSON(http.StatusOK, tasks)
 	}
 }
+
+func saveCartonHandler(service *application.CartonCatalogService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			CartonID       string            `json:"cartonId"`
+			Name           string            `json:"name" binding:"required"`
+			Type           string            `json:"type" binding:"required"`
+			Dimensions     domain.Dimensions `json:"dimensions" binding:"required"`
+			TareWeight     float64           `json:"tareWeight"`
+			MaxWeight      float64           `json:"maxWeight"`
+			Cost           float64           `json:"cost"`
+			HazmatApproved bool              `json:"hazmatApproved"`
+			Active         *bool             `json:"active"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// PUT takes the carton ID from the path
+		if cartonID := c.Param("cartonId"); cartonID != "" {
+			req.CartonID = cartonID
+		}
+
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"carton.id": req.CartonID,
+		})
+
+		active := true
+		if req.Active != nil {
+			active = *req.Active
+		}
+
+		cmd := application.SaveCartonCommand{
+			CartonID:       req.CartonID,
+			Name:           req.Name,
+			Type:           domain.PackageType(req.Type),
+			Dimensions:     req.Dimensions,
+			TareWeight:     req.TareWeight,
+			MaxWeight:      req.MaxWeight,
+			Cost:           req.Cost,
+			HazmatApproved: req.HazmatApproved,
+			Active:         active,
+		}
+
+		carton, err := service.SaveCarton(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, carton)
+	}
+}
+
+func getCartonHandler(service *application.CartonCatalogService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		cartonID := c.Param("cartonId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"carton.id": cartonID,
+		})
+
+		query := application.GetCartonQuery{CartonID: cartonID}
+
+		carton, err := service.GetCarton(c.Request.Context(), query)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, carton)
+	}
+}
+
+func listCartonsHandler(service *application.CartonCatalogService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		query := application.ListCartonsQuery{ActiveOnly: c.Query("active") == "true"}
+
+		cartons, err := service.ListCartons(c.Request.Context(), query)
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, cartons)
+	}
+}
+
+func deleteCartonHandler(service *application.CartonCatalogService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		cartonID := c.Param("cartonId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"carton.id": cartonID,
+		})
+
+		cmd := application.DeleteCartonCommand{CartonID: cartonID}
+
+		if err := service.DeleteCarton(c.Request.Context(), cmd); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
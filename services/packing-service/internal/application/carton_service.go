@@ -0,0 +1,123 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/packing-service/internal/domain"
+)
+
+// CartonCatalogService manages the carton sizes stocked at each facility
+type CartonCatalogService struct {
+	repo   domain.CartonRepository
+	logger *logging.Logger
+}
+
+// NewCartonCatalogService creates a new CartonCatalogService
+func NewCartonCatalogService(repo domain.CartonRepository, logger *logging.Logger) *CartonCatalogService {
+	return &CartonCatalogService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// SaveCarton creates or updates a carton in the caller's facility catalog
+func (s *CartonCatalogService) SaveCarton(ctx context.Context, cmd SaveCartonCommand) (*CartonDTO, error) {
+	tc := tenant.FromContextOptional(ctx)
+	if tc.FacilityID == "" {
+		return nil, errors.ErrValidation("facility is required")
+	}
+
+	existing, err := s.repo.FindByID(ctx, tc.FacilityID, cmd.CartonID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get carton", "cartonId", cmd.CartonID)
+		return nil, fmt.Errorf("failed to get carton: %w", err)
+	}
+
+	now := time.Now()
+	carton := &domain.Carton{
+		CartonID:       cmd.CartonID,
+		TenantID:       tc.TenantID,
+		FacilityID:     tc.FacilityID,
+		Name:           cmd.Name,
+		Type:           cmd.Type,
+		Dimensions:     cmd.Dimensions,
+		TareWeight:     cmd.TareWeight,
+		MaxWeight:      cmd.MaxWeight,
+		Cost:           cmd.Cost,
+		HazmatApproved: cmd.HazmatApproved,
+		Active:         cmd.Active,
+		CreatedAt:      now,
+	}
+	if existing != nil {
+		carton.ID = existing.ID
+		carton.CreatedAt = existing.CreatedAt
+	}
+
+	if err := carton.Validate(); err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+
+	if err := s.repo.Save(ctx, carton); err != nil {
+		s.logger.WithError(err).Error("Failed to save carton", "cartonId", cmd.CartonID)
+		return nil, fmt.Errorf("failed to save carton: %w", err)
+	}
+
+	s.logger.Info("Saved carton", "cartonId", cmd.CartonID, "facilityId", tc.FacilityID)
+	return ToCartonDTO(carton), nil
+}
+
+// GetCarton retrieves a carton from the caller's facility catalog
+func (s *CartonCatalogService) GetCarton(ctx context.Context, query GetCartonQuery) (*CartonDTO, error) {
+	tc := tenant.FromContextOptional(ctx)
+	carton, err := s.repo.FindByID(ctx, tc.FacilityID, query.CartonID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get carton", "cartonId", query.CartonID)
+		return nil, fmt.Errorf("failed to get carton: %w", err)
+	}
+
+	if carton == nil {
+		return nil, errors.ErrNotFound("carton")
+	}
+
+	return ToCartonDTO(carton), nil
+}
+
+// ListCartons lists the caller's facility catalog
+func (s *CartonCatalogService) ListCartons(ctx context.Context, query ListCartonsQuery) ([]CartonDTO, error) {
+	tc := tenant.FromContextOptional(ctx)
+	cartons, err := s.repo.FindByFacility(ctx, tc.FacilityID, query.ActiveOnly)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list cartons", "facilityId", tc.FacilityID)
+		return nil, fmt.Errorf("failed to list cartons: %w", err)
+	}
+
+	return ToCartonDTOs(cartons), nil
+}
+
+// DeleteCarton removes a carton from the caller's facility catalog
+func (s *CartonCatalogService) DeleteCarton(ctx context.Context, cmd DeleteCartonCommand) error {
+	tc := tenant.FromContextOptional(ctx)
+	carton, err := s.repo.FindByID(ctx, tc.FacilityID, cmd.CartonID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get carton", "cartonId", cmd.CartonID)
+		return fmt.Errorf("failed to get carton: %w", err)
+	}
+
+	if carton == nil {
+		return errors.ErrNotFound("carton")
+	}
+
+	if err := s.repo.Delete(ctx, tc.FacilityID, cmd.CartonID); err != nil {
+		s.logger.WithError(err).Error("Failed to delete carton", "cartonId", cmd.CartonID)
+		return fmt.Errorf("failed to delete carton: %w", err)
+	}
+
+	s.logger.Info("Deleted carton", "cartonId", cmd.CartonID, "facilityId", tc.FacilityID)
+	return nil
+}
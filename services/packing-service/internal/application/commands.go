@@ -28,12 +28,20 @@ type VerifyItemCommand struct {
 	SKU    string
 }
 
-// SelectPackagingCommand selects packaging for the task
+// SelectPackagingCommand selects packaging for the task.
+// OverrideReason is required when the packaging differs from the cartonization recommendation.
 type SelectPackagingCommand struct {
-	TaskID      string
-	PackageType domain.PackageType
-	Dimensions  domain.Dimensions
-	Materials   []string
+	TaskID         string
+	CartonID       string
+	PackageType    domain.PackageType
+	Dimensions     domain.Dimensions
+	Materials      []string
+	OverrideReason string
+}
+
+// CartonizePackTaskCommand recommends cartons for the task from the facility catalog
+type CartonizePackTaskCommand struct {
+	TaskID string
 }
 
 // SealPackageCommand seals the package
@@ -76,3 +84,31 @@ type GetByTrackingQuery struct {
 type GetPendingQuery struct {
 	Limit int
 }
+
+// SaveCartonCommand creates or updates a carton in the facility catalog
+type SaveCartonCommand struct {
+	CartonID       string
+	Name           string
+	Type           domain.PackageType
+	Dimensions     domain.Dimensions
+	TareWeight     float64
+	MaxWeight      float64
+	Cost           float64
+	HazmatApproved bool
+	Active         bool
+}
+
+// DeleteCartonCommand removes a carton from the facility catalog
+type DeleteCartonCommand struct {
+	CartonID string
+}
+
+// GetCartonQuery retrieves a carton from the facility catalog
+type GetCartonQuery struct {
+	CartonID string
+}
+
+// ListCartonsQuery lists the facility carton catalog
+type ListCartonsQuery struct {
+	ActiveOnly bool
+}
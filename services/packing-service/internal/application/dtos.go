@@ -4,33 +4,39 @@ import "time"
 
 // PackTaskDTO represents a packing task in responses
 type PackTaskDTO struct {
-	TaskID          string              `json:"taskId"`
-	OrderID         string              `json:"orderId"`
-	ConsolidationID string              `json:"consolidationId,omitempty"`
-	WaveID          string              `json:"waveId"`
-	Status          string              `json:"status"`
-	Items           []PackItemDTO       `json:"items"`
-	Package         PackageDTO          `json:"package"`
-	ShippingLabel   *ShippingLabelDTO   `json:"shippingLabel,omitempty"`
-	PackerID        string              `json:"packerId,omitempty"`
-	Station         string              `json:"station"`
-	Priority        int                 `json:"priority"`
-	CreatedAt       time.Time           `json:"createdAt"`
-	UpdatedAt       time.Time           `json:"updatedAt"`
-	StartedAt       *time.Time          `json:"startedAt,omitempty"`
-	PackedAt        *time.Time          `json:"packedAt,omitempty"`
-	LabeledAt       *time.Time          `json:"labeledAt,omitempty"`
-	CompletedAt     *time.Time          `json:"completedAt,omitempty"`
+	TaskID            string                   `json:"taskId"`
+	OrderID           string                   `json:"orderId"`
+	ConsolidationID   string                   `json:"consolidationId,omitempty"`
+	WaveID            string                   `json:"waveId"`
+	Status            string                   `json:"status"`
+	Items             []PackItemDTO            `json:"items"`
+	Package           PackageDTO               `json:"package"`
+	Cartonization     *CartonRecommendationDTO `json:"cartonization,omitempty"`
+	PackagingOverride *PackagingOverrideDTO    `json:"packagingOverride,omitempty"`
+	ShippingLabel     *ShippingLabelDTO        `json:"shippingLabel,omitempty"`
+	PackerID          string                   `json:"packerId,omitempty"`
+	Station           string                   `json:"station"`
+	Priority          int                      `json:"priority"`
+	CreatedAt         time.Time                `json:"createdAt"`
+	UpdatedAt         time.Time                `json:"updatedAt"`
+	StartedAt         *time.Time               `json:"startedAt,omitempty"`
+	PackedAt          *time.Time               `json:"packedAt,omitempty"`
+	LabeledAt         *time.Time               `json:"labeledAt,omitempty"`
+	CompletedAt       *time.Time               `json:"completedAt,omitempty"`
 }
 
 // PackItemDTO represents an item to be packed
 type PackItemDTO struct {
-	SKU         string  `json:"sku"`
-	ProductName string  `json:"productName"`
-	Quantity    int     `json:"quantity"`
-	Weight      float64 `json:"weight"`
-	Fragile     bool    `json:"fragile"`
-	Verified    bool    `json:"verified"`
+	SKU         string         `json:"sku"`
+	ProductName string         `json:"productName"`
+	Quantity    int            `json:"quantity"`
+	Weight      float64        `json:"weight"`
+	Dimensions  *DimensionsDTO `json:"dimensions,omitempty"`
+	Fragile     bool           `json:"fragile"`
+	Hazmat      bool           `json:"hazmat"`
+	HazmatClass string         `json:"hazmatClass,omitempty"`
+	ThisSideUp  bool           `json:"thisSideUp"`
+	Verified    bool           `json:"verified"`
 }
 
 // PackageDTO represents the packaging used
@@ -63,3 +69,74 @@ type ShippingLabelDTO struct {
 	GeneratedAt    time.Time  `json:"generatedAt"`
 	AppliedAt      *time.Time `json:"appliedAt,omitempty"`
 }
+
+// CartonRecommendationDTO represents a cartonization recommendation
+type CartonRecommendationDTO struct {
+	Boxes         []CartonBoxDTO `json:"boxes"`
+	BoxCount      int            `json:"boxCount"`
+	TotalCost     float64        `json:"totalCost"`
+	TotalVoidFill float64        `json:"totalVoidFill"`
+	GeneratedAt   time.Time      `json:"generatedAt"`
+}
+
+// CartonBoxDTO represents one recommended carton and its contents
+type CartonBoxDTO struct {
+	CartonID       string             `json:"cartonId"`
+	Name           string             `json:"name"`
+	Type           string             `json:"type"`
+	Dimensions     DimensionsDTO      `json:"dimensions"`
+	Items          []CartonItemDTO    `json:"items"`
+	Placements     []ItemPlacementDTO `json:"placements"`
+	ItemWeight     float64            `json:"itemWeight"`
+	GrossWeight    float64            `json:"grossWeight"`
+	ItemVolume     float64            `json:"itemVolume"`
+	VoidFillVolume float64            `json:"voidFillVolume"`
+	FillRatio      float64            `json:"fillRatio"`
+	Cost           float64            `json:"cost"`
+	Hazmat         bool               `json:"hazmat"`
+	HazmatClass    string             `json:"hazmatClass,omitempty"`
+}
+
+// CartonItemDTO represents the quantity of a SKU in a recommended carton
+type CartonItemDTO struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+// ItemPlacementDTO represents where a unit goes in a recommended carton
+type ItemPlacementDTO struct {
+	SKU        string        `json:"sku"`
+	X          float64       `json:"x"`
+	Y          float64       `json:"y"`
+	Z          float64       `json:"z"`
+	Dimensions DimensionsDTO `json:"dimensions"`
+}
+
+// PackagingOverrideDTO represents a packer override of the recommended carton
+type PackagingOverrideDTO struct {
+	RecommendedCartonID   string        `json:"recommendedCartonId"`
+	RecommendedType       string        `json:"recommendedType"`
+	RecommendedDimensions DimensionsDTO `json:"recommendedDimensions"`
+	SelectedCartonID      string        `json:"selectedCartonId,omitempty"`
+	SelectedType          string        `json:"selectedType"`
+	SelectedDimensions    DimensionsDTO `json:"selectedDimensions"`
+	Reason                string        `json:"reason"`
+	PackerID              string        `json:"packerId,omitempty"`
+	OverriddenAt          time.Time     `json:"overriddenAt"`
+}
+
+// CartonDTO represents a carton in the facility catalog
+type CartonDTO struct {
+	CartonID       string        `json:"cartonId"`
+	FacilityID     string        `json:"facilityId"`
+	Name           string        `json:"name"`
+	Type           string        `json:"type"`
+	Dimensions     DimensionsDTO `json:"dimensions"`
+	TareWeight     float64       `json:"tareWeight"`
+	MaxWeight      float64       `json:"maxWeight"`
+	Cost           float64       `json:"cost"`
+	HazmatApproved bool          `json:"hazmatApproved"`
+	Active         bool          `json:"active"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+}
@@ -36,19 +36,37 @@ func ToPackTaskDTO(task *domain.PackTask) *PackTaskDTO {
 		dto.ShippingLabel = ToShippingLabelDTO(*task.ShippingLabel)
 	}
 
+	if task.Cartonization != nil {
+		dto.Cartonization = ToCartonRecommendationDTO(*task.Cartonization)
+	}
+
+	if task.PackagingOverride != nil {
+		dto.PackagingOverride = ToPackagingOverrideDTO(*task.PackagingOverride)
+	}
+
 	return dto
 }
 
 // ToPackItemDTO converts a domain PackItem to PackItemDTO
 func ToPackItemDTO(item domain.PackItem) PackItemDTO {
-	return PackItemDTO{
+	dto := PackItemDTO{
 		SKU:         item.SKU,
 		ProductName: item.ProductName,
 		Quantity:    item.Quantity,
 		Weight:      item.Weight,
 		Fragile:     item.Fragile,
+		Hazmat:      item.Hazmat,
+		HazmatClass: item.HazmatClass,
+		ThisSideUp:  item.ThisSideUp,
 		Verified:    item.Verified,
 	}
+
+	if item.Dimensions.IsSet() {
+		dims := ToDimensionsDTO(item.Dimensions)
+		dto.Dimensions = &dims
+	}
+
+	return dto
 }
 
 // ToPackageDTO converts domain Package to PackageDTO
@@ -88,6 +106,106 @@ func ToShippingLabelDTO(label domain.ShippingLabel) *ShippingLabelDTO {
 	}
 }
 
+// ToCartonRecommendationDTO converts a domain CartonRecommendation to DTO
+func ToCartonRecommendationDTO(rec domain.CartonRecommendation) *CartonRecommendationDTO {
+	boxes := make([]CartonBoxDTO, 0, len(rec.Boxes))
+	for _, box := range rec.Boxes {
+		boxes = append(boxes, ToCartonBoxDTO(box))
+	}
+
+	return &CartonRecommendationDTO{
+		Boxes:         boxes,
+		BoxCount:      len(boxes),
+		TotalCost:     rec.TotalCost,
+		TotalVoidFill: rec.TotalVoidFill,
+		GeneratedAt:   rec.GeneratedAt,
+	}
+}
+
+// ToCartonBoxDTO converts a domain CartonBox to CartonBoxDTO
+func ToCartonBoxDTO(box domain.CartonBox) CartonBoxDTO {
+	items := make([]CartonItemDTO, 0, len(box.Items))
+	for _, item := range box.Items {
+		items = append(items, CartonItemDTO{SKU: item.SKU, Quantity: item.Quantity})
+	}
+
+	placements := make([]ItemPlacementDTO, 0, len(box.Placements))
+	for _, p := range box.Placements {
+		placements = append(placements, ItemPlacementDTO{
+			SKU:        p.SKU,
+			X:          p.X,
+			Y:          p.Y,
+			Z:          p.Z,
+			Dimensions: ToDimensionsDTO(p.Dimensions),
+		})
+	}
+
+	return CartonBoxDTO{
+		CartonID:       box.CartonID,
+		Name:           box.Name,
+		Type:           string(box.Type),
+		Dimensions:     ToDimensionsDTO(box.Dimensions),
+		Items:          items,
+		Placements:     placements,
+		ItemWeight:     box.ItemWeight,
+		GrossWeight:    box.GrossWeight,
+		ItemVolume:     box.ItemVolume,
+		VoidFillVolume: box.VoidFillVolume,
+		FillRatio:      box.FillRatio,
+		Cost:           box.Cost,
+		Hazmat:         box.Hazmat,
+		HazmatClass:    box.HazmatClass,
+	}
+}
+
+// ToPackagingOverrideDTO converts a domain PackagingOverride to DTO
+func ToPackagingOverrideDTO(override domain.PackagingOverride) *PackagingOverrideDTO {
+	return &PackagingOverrideDTO{
+		RecommendedCartonID:   override.RecommendedCartonID,
+		RecommendedType:       string(override.RecommendedType),
+		RecommendedDimensions: ToDimensionsDTO(override.RecommendedDimensions),
+		SelectedCartonID:      override.SelectedCartonID,
+		SelectedType:          string(override.SelectedType),
+		SelectedDimensions:    ToDimensionsDTO(override.SelectedDimensions),
+		Reason:                override.Reason,
+		PackerID:              override.PackerID,
+		OverriddenAt:          override.OverriddenAt,
+	}
+}
+
+// ToCartonDTO converts a domain Carton to CartonDTO
+func ToCartonDTO(carton *domain.Carton) *CartonDTO {
+	if carton == nil {
+		return nil
+	}
+
+	return &CartonDTO{
+		CartonID:       carton.CartonID,
+		FacilityID:     carton.FacilityID,
+		Name:           carton.Name,
+		Type:           string(carton.Type),
+		Dimensions:     ToDimensionsDTO(carton.Dimensions),
+		TareWeight:     carton.TareWeight,
+		MaxWeight:      carton.MaxWeight,
+		Cost:           carton.Cost,
+		HazmatApproved: carton.HazmatApproved,
+		Active:         carton.Active,
+		CreatedAt:      carton.CreatedAt,
+		UpdatedAt:      carton.UpdatedAt,
+	}
+}
+
+// ToCartonDTOs converts a slice of domain Cartons to CartonDTOs
+func ToCartonDTOs(cartons []*domain.Carton) []CartonDTO {
+	dtos := make([]CartonDTO, 0, len(cartons))
+	for _, carton := range cartons {
+		if dto := ToCartonDTO(carton); dto != nil {
+			dtos = append(dtos, *dto)
+		}
+	}
+	return dtos
+}
+
 // ToPackTaskDTOs converts a slice of domain PackTasks to PackTaskDTOs
 func ToPackTaskDTOs(tasks []*domain.PackTask) []PackTaskDTO {
 	dtos := make([]PackTaskDTO, 0, len(tasks))
@@ -2,12 +2,14 @@ package application
 
 import (
 	"context"
+	stdErrors "errors"
 	"fmt"
 
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 	"github.com/wms-platform/shared/pkg/tenant"
 
 	"github.com/wms-platform/packing-service/internal/domain"
@@ -16,6 +18,7 @@ import (
 // PackingApplicationService handles packing-related use cases
 type PackingApplicationService struct {
 	repo         domain.PackTaskRepository
+	cartonRepo   domain.CartonRepository
 	producer     *kafka.InstrumentedProducer
 	eventFactory *cloudevents.EventFactory
 	logger       *logging.Logger
@@ -24,12 +27,14 @@ type PackingApplicationService struct {
 // NewPackingApplicationService creates a new PackingApplicationService
 func NewPackingApplicationService(
 	repo domain.PackTaskRepository,
+	cartonRepo domain.CartonRepository,
 	producer *kafka.InstrumentedProducer,
 	eventFactory *cloudevents.EventFactory,
 	logger *logging.Logger,
 ) *PackingApplicationService {
 	return &PackingApplicationService{
 		repo:         repo,
+		cartonRepo:   cartonRepo,
 		producer:     producer,
 		eventFactory: eventFactory,
 		logger:       logger,
@@ -49,6 +54,14 @@ func (s *PackingApplicationService) CreatePackTask(ctx context.Context, cmd Crea
 	task.FacilityID = tc.FacilityID
 	task.WarehouseID = tc.WarehouseID
 
+	// Recommend cartons when the facility has a catalog; the heuristic suggestion
+	// from NewPackTask stands when it does not or the items cannot be cartonized
+	if task.FacilityID != "" && task.CanCartonize() {
+		if err := s.cartonize(ctx, task); err != nil && !stdErrors.Is(err, domain.ErrNoCartonsAvailable) {
+			s.logger.Warn("Cartonization skipped", "taskId", cmd.TaskID, "error", err.Error())
+		}
+	}
+
 	if err := s.repo.Save(ctx, task); err != nil {
 		s.logger.WithError(err).Error("Failed to create pack task", "taskId", cmd.TaskID)
 		return nil, fmt.Errorf("failed to create pack task: %w", err)
@@ -88,23 +101,14 @@ func (s *PackingApplicationService) GetPackTask(ctx context.Context, query GetPa
 
 // AssignPackTask assigns a packing task to a packer
 func (s *PackingApplicationService) AssignPackTask(ctx context.Context, cmd AssignPackTaskCommand) (*PackTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PackTask) error {
+		if err := task.Assign(cmd.PackerID, cmd.Station); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pack task: %w", err)
-	}
-
-	if task == nil {
-		return nil, errors.ErrNotFound("pack task")
-	}
-
-	if err := task.Assign(cmd.PackerID, cmd.Station); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pack task: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -115,23 +119,14 @@ func (s *PackingApplicationService) AssignPackTask(ctx context.Context, cmd Assi
 
 // StartPackTask starts a packing task
 func (s *PackingApplicationService) StartPackTask(ctx context.Context, cmd StartPackTaskCommand) (*PackTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PackTask) error {
+		if err := task.Start(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pack task: %w", err)
-	}
-
-	if task == nil {
-		return nil, errors.ErrNotFound("pack task")
-	}
-
-	if err := task.Start(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pack task: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -142,23 +137,14 @@ func (s *PackingApplicationService) StartPackTask(ctx context.Context, cmd Start
 
 // VerifyItem verifies an item during packing
 func (s *PackingApplicationService) VerifyItem(ctx context.Context, cmd VerifyItemCommand) (*PackTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PackTask) error {
+		if err := task.VerifyItem(cmd.SKU); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pack task: %w", err)
-	}
-
-	if task == nil {
-		return nil, errors.ErrNotFound("pack task")
-	}
-
-	if err := task.VerifyItem(cmd.SKU); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pack task: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -169,50 +155,134 @@ func (s *PackingApplicationService) VerifyItem(ctx context.Context, cmd VerifyIt
 
 // SelectPackaging selects packaging for the task
 func (s *PackingApplicationService) SelectPackaging(ctx context.Context, cmd SelectPackagingCommand) (*PackTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	var packageType domain.PackageType
+	var overridden bool
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PackTask) error {
+		var dimensions domain.Dimensions
+		packageType, dimensions = cmd.PackageType, cmd.Dimensions
+		if cmd.CartonID != "" {
+			carton, err := s.cartonRepo.FindByID(ctx, task.FacilityID, cmd.CartonID)
+			if err != nil {
+				s.logger.WithError(err).Error("Failed to get carton", "cartonId", cmd.CartonID)
+				return fmt.Errorf("failed to get carton: %w", err)
+			}
+			if carton == nil {
+				return errors.ErrNotFound("carton")
+			}
+			packageType, dimensions = carton.Type, carton.Dimensions
+		}
+		if packageType == "" {
+			return errors.ErrValidation("package type or carton is required")
+		}
+
+		var err error
+		overridden = !task.FollowsRecommendation(cmd.CartonID, packageType, dimensions)
+		if overridden {
+			err = task.OverridePackaging(cmd.CartonID, packageType, dimensions, cmd.Materials, cmd.OverrideReason)
+		} else {
+			err = task.SelectPackaging(packageType, dimensions, cmd.Materials)
+		}
+		if err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pack task: %w", err)
+		return nil, err
 	}
 
-	if task == nil {
-		return nil, errors.ErrNotFound("pack task")
-	}
+	// Events are saved to outbox by repository in transaction
 
-	if err := task.SelectPackaging(cmd.PackageType, cmd.Dimensions, cmd.Materials); err != nil {
-		return nil, errors.ErrValidation(err.Error())
+	if overridden {
+		s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+			EventType:  "packing.packaging_overridden",
+			EntityType: "packTask",
+			EntityID:   cmd.TaskID,
+			Action:     "packaging_overridden",
+			RelatedIDs: map[string]string{
+				"recommendedCartonId": task.PackagingOverride.RecommendedCartonID,
+				"selectedCartonId":    cmd.CartonID,
+			},
+		})
 	}
 
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pack task: %w", err)
-	}
+	s.logger.Info("Selected packaging", "taskId", cmd.TaskID, "packageType", packageType, "overridden", overridden)
+	return ToPackTaskDTO(task), nil
+}
 
-	// Events are saved to outbox by repository in transaction
+// CartonizePackTask re-runs cartonization for a task against the facility carton catalog
+func (s *PackingApplicationService) CartonizePackTask(ctx context.Context, cmd CartonizePackTaskCommand) (*PackTaskDTO, error) {
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PackTask) error {
+		if err := s.cartonize(ctx, task); err != nil {
+			if isCartonizationError(err) {
+				return errors.ErrValidation(err.Error())
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	s.logger.Info("Selected packaging", "taskId", cmd.TaskID, "packageType", cmd.PackageType)
+	recommendation := task.Cartonization
+	s.logger.Info("Cartonized pack task",
+		"taskId", cmd.TaskID,
+		"boxes", len(recommendation.Boxes),
+		"cartonId", recommendation.Boxes[0].CartonID,
+		"voidFill", recommendation.TotalVoidFill,
+	)
 	return ToPackTaskDTO(task), nil
 }
 
-// SealPackage seals the package
-func (s *PackingApplicationService) SealPackage(ctx context.Context, cmd SealPackageCommand) (*PackTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+// cartonize runs the cartonization engine for the task's facility catalog
+func (s *PackingApplicationService) cartonize(ctx context.Context, task *domain.PackTask) error {
+	cartons, err := s.cartonRepo.FindByFacility(ctx, task.FacilityID, true)
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pack task: %w", err)
+		s.logger.WithError(err).Error("Failed to get carton catalog", "facilityId", task.FacilityID)
+		return fmt.Errorf("failed to get carton catalog: %w", err)
 	}
 
-	if task == nil {
-		return nil, errors.ErrNotFound("pack task")
+	catalog := make([]domain.Carton, 0, len(cartons))
+	for _, carton := range cartons {
+		catalog = append(catalog, *carton)
 	}
 
-	if err := task.SealPackage(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
+	recommendation, err := domain.Cartonize(task.Items, catalog)
+	if err != nil {
+		return err
 	}
+	return task.ApplyCartonization(recommendation)
+}
 
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pack task: %w", err)
+// isCartonizationError returns true for errors caused by the task or catalog contents
+func isCartonizationError(err error) bool {
+	for _, target := range []error{
+		domain.ErrNoCartonsAvailable,
+		domain.ErrItemDimensionsMissing,
+		domain.ErrItemDoesNotFit,
+		domain.ErrNoHazmatCarton,
+		domain.ErrNoRecommendation,
+		domain.ErrPackageSealed,
+		domain.ErrPackTaskCompleted,
+	} {
+		if stdErrors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// SealPackage seals the package
+func (s *PackingApplicationService) SealPackage(ctx context.Context, cmd SealPackageCommand) (*PackTaskDTO, error) {
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PackTask) error {
+		if err := task.SealPackage(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -223,23 +293,14 @@ func (s *PackingApplicationService) SealPackage(ctx context.Context, cmd SealPac
 
 // ApplyLabel applies a shipping label to the package
 func (s *PackingApplicationService) ApplyLabel(ctx context.Context, cmd ApplyLabelCommand) (*PackTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PackTask) error {
+		if err := task.ApplyLabel(cmd.Label); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pack task: %w", err)
-	}
-
-	if task == nil {
-		return nil, errors.ErrNotFound("pack task")
-	}
-
-	if err := task.ApplyLabel(cmd.Label); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pack task: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -261,23 +322,14 @@ func (s *PackingApplicationService) ApplyLabel(ctx context.Context, cmd ApplyLab
 
 // CompletePackTask completes a packing task
 func (s *PackingApplicationService) CompletePackTask(ctx context.Context, cmd CompletePackTaskCommand) (*PackTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PackTask) error {
+		if err := task.Complete(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pack task: %w", err)
-	}
-
-	if task == nil {
-		return nil, errors.ErrNotFound("pack task")
-	}
-
-	if err := task.Complete(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pack task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pack task: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -296,6 +348,46 @@ func (s *PackingApplicationService) CompletePackTask(ctx context.Context, cmd Co
 	return ToPackTaskDTO(task), nil
 }
 
+// updateTask loads a pack task, applies change and saves it. A save that loses a race with
+// another writer reloads the task and applies change again.
+func (s *PackingApplicationService) updateTask(ctx context.Context, taskID string, change func(task *domain.PackTask) error) (*domain.PackTask, error) {
+	var task *domain.PackTask
+
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.repo.FindByID(ctx, taskID)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to get pack task", "taskId", taskID)
+			return fmt.Errorf("failed to get pack task: %w", err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("pack task")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				s.logger.Debug("Pack task changed concurrently, retrying", "taskId", taskID)
+				return err
+			}
+			s.logger.WithError(err).Error("Failed to save pack task", "taskId", taskID)
+			return fmt.Errorf("failed to save pack task: %w", err)
+		}
+
+		task = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("pack task %s was modified concurrently, please retry", taskID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
 // GetByOrder retrieves a packing task by order ID
 func (s *PackingApplicationService) GetByOrder(ctx context.Context, query GetByOrderQuery) (*PackTaskDTO, error) {
 	task, err := s.repo.FindByOrderID(ctx, query.OrderID)
@@ -40,38 +40,45 @@ const (
 
 // PackTask is the aggregate root for the Packing bounded context
 type PackTask struct {
-	ID               primitive.ObjectID `bson:"_id,omitempty"`
-	TaskID           string             `bson:"taskId"`
-	TenantID         string             `bson:"tenantId"`
-	FacilityID       string             `bson:"facilityId"`
-	WarehouseID      string             `bson:"warehouseId"`
-	OrderID          string             `bson:"orderId"`
-	ConsolidationID  string             `bson:"consolidationId,omitempty"`
-	WaveID           string             `bson:"waveId"`
-	PackerID         string             `bson:"packerId,omitempty"`
-	Status           PackTaskStatus     `bson:"status"`
-	Items            []PackItem         `bson:"items"`
-	Package          Package            `bson:"package"`
-	ShippingLabel    *ShippingLabel     `bson:"shippingLabel,omitempty"`
-	Station          string             `bson:"station"`
-	Priority         int                `bson:"priority"`
-	CreatedAt        time.Time          `bson:"createdAt"`
-	UpdatedAt        time.Time          `bson:"updatedAt"`
-	StartedAt        *time.Time         `bson:"startedAt,omitempty"`
-	PackedAt         *time.Time         `bson:"packedAt,omitempty"`
-	LabeledAt        *time.Time         `bson:"labeledAt,omitempty"`
-	CompletedAt      *time.Time         `bson:"completedAt,omitempty"`
-	DomainEvents     []DomainEvent      `bson:"-"`
+	ID                primitive.ObjectID    `bson:"_id,omitempty"`
+	TaskID            string                `bson:"taskId"`
+	TenantID          string                `bson:"tenantId"`
+	FacilityID        string                `bson:"facilityId"`
+	WarehouseID       string                `bson:"warehouseId"`
+	OrderID           string                `bson:"orderId"`
+	ConsolidationID   string                `bson:"consolidationId,omitempty"`
+	WaveID            string                `bson:"waveId"`
+	PackerID          string                `bson:"packerId,omitempty"`
+	Status            PackTaskStatus        `bson:"status"`
+	Items             []PackItem            `bson:"items"`
+	Package           Package               `bson:"package"`
+	Cartonization     *CartonRecommendation `bson:"cartonization,omitempty"`
+	PackagingOverride *PackagingOverride    `bson:"packagingOverride,omitempty"`
+	ShippingLabel     *ShippingLabel        `bson:"shippingLabel,omitempty"`
+	Station           string                `bson:"station"`
+	Priority          int                   `bson:"priority"`
+	CreatedAt         time.Time             `bson:"createdAt"`
+	UpdatedAt         time.Time             `bson:"updatedAt"`
+	StartedAt         *time.Time            `bson:"startedAt,omitempty"`
+	PackedAt          *time.Time            `bson:"packedAt,omitempty"`
+	LabeledAt         *time.Time            `bson:"labeledAt,omitempty"`
+	CompletedAt       *time.Time            `bson:"completedAt,omitempty"`
+	Version           int                   `bson:"version"` // Incremented on every save for optimistic concurrency
+	DomainEvents      []DomainEvent         `bson:"-"`
 }
 
 // PackItem represents an item to be packed
 type PackItem struct {
-	SKU         string `bson:"sku"`
-	ProductName string `bson:"productName"`
-	Quantity    int    `bson:"quantity"`
-	Weight      float64 `bson:"weight"` // in kg
-	Fragile     bool   `bson:"fragile"`
-	Verified    bool   `bson:"verified"`
+	SKU         string     `bson:"sku"`
+	ProductName string     `bson:"productName"`
+	Quantity    int        `bson:"quantity"`
+	Weight      float64    `bson:"weight"`     // in kg, per unit
+	Dimensions  Dimensions `bson:"dimensions"` // per unit, used for cartonization
+	Fragile     bool       `bson:"fragile"`
+	Hazmat      bool       `bson:"hazmat"`
+	HazmatClass string     `bson:"hazmatClass,omitempty"` // DOT hazard class, e.g. "3" or "9"
+	ThisSideUp  bool       `bson:"thisSideUp"`            // may only be rotated about the vertical axis
+	Verified    bool       `bson:"verified"`
 }
 
 // Package represents the packaging used
@@ -132,7 +139,7 @@ func NewPackTask(taskID, orderID, waveID string, items []PackItem) (*PackTask, e
 		DomainEvents: make([]DomainEvent, 0),
 	}
 
-	// Suggest package type
+	// Suggest package type until the task is cartonized against a carton catalog
 	task.Package.SuggestedType = suggestPackageType(items, totalWeight)
 
 	task.AddDomainEvent(&PackTaskCreatedEvent{
@@ -0,0 +1,567 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Cartonization errors
+var (
+	ErrNoCartonsAvailable     = errors.New("no cartons available for cartonization")
+	ErrItemDimensionsMissing  = errors.New("item dimensions are required for cartonization")
+	ErrItemDoesNotFit         = errors.New("item does not fit in any available carton")
+	ErrNoHazmatCarton         = errors.New("no hazmat approved carton available")
+	ErrInvalidCarton          = errors.New("invalid carton")
+	ErrNoRecommendation       = errors.New("pack task has no cartonization recommendation")
+	ErrOverrideReasonRequired = errors.New("override reason is required when packaging differs from the recommendation")
+)
+
+// dimensionTolerance absorbs floating point error when comparing placements (cm)
+const dimensionTolerance = 1e-6
+
+// Carton is a box size stocked at a facility
+type Carton struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`
+	CartonID       string             `bson:"cartonId"`
+	TenantID       string             `bson:"tenantId"`
+	FacilityID     string             `bson:"facilityId"`
+	Name           string             `bson:"name"`
+	Type           PackageType        `bson:"type"`
+	Dimensions     Dimensions         `bson:"dimensions"` // Inner dimensions in cm
+	TareWeight     float64            `bson:"tareWeight"` // Empty carton weight in kg
+	MaxWeight      float64            `bson:"maxWeight"`  // Maximum content weight in kg, 0 for no limit
+	Cost           float64            `bson:"cost"`
+	HazmatApproved bool               `bson:"hazmatApproved"`
+	Active         bool               `bson:"active"`
+	CreatedAt      time.Time          `bson:"createdAt"`
+	UpdatedAt      time.Time          `bson:"updatedAt"`
+}
+
+// Validate checks the carton can be used for cartonization
+func (c *Carton) Validate() error {
+	if strings.TrimSpace(c.CartonID) == "" {
+		return fmt.Errorf("%w: carton ID is required", ErrInvalidCarton)
+	}
+	if !c.Dimensions.IsSet() {
+		return fmt.Errorf("%w: length, width and height must be positive", ErrInvalidCarton)
+	}
+	if c.TareWeight < 0 || c.MaxWeight < 0 || c.Cost < 0 {
+		return fmt.Errorf("%w: weights and cost cannot be negative", ErrInvalidCarton)
+	}
+	switch c.Type {
+	case PackageTypeBox, PackageTypeEnvelope, PackageTypeBag, PackageTypePadded, PackageTypeCustom:
+		return nil
+	default:
+		return ErrInvalidPackageType
+	}
+}
+
+// IsSet returns true if all three dimensions are positive
+func (d Dimensions) IsSet() bool {
+	return d.Length > 0 && d.Width > 0 && d.Height > 0
+}
+
+// Volume returns the volume in cubic cm
+func (d Dimensions) Volume() float64 {
+	return d.Length * d.Width * d.Height
+}
+
+// CartonRecommendation is the output of the cartonization engine
+type CartonRecommendation struct {
+	Boxes         []CartonBox `bson:"boxes"`
+	TotalCost     float64     `bson:"totalCost"`
+	TotalVoidFill float64     `bson:"totalVoidFill"` // Void fill needed across all boxes in cubic cm
+	GeneratedAt   time.Time   `bson:"generatedAt"`
+}
+
+// CartonBox is one carton in a recommendation and the units packed into it
+type CartonBox struct {
+	CartonID       string          `bson:"cartonId"`
+	Name           string          `bson:"name"`
+	Type           PackageType     `bson:"type"`
+	Dimensions     Dimensions      `bson:"dimensions"`
+	Items          []CartonItem    `bson:"items"`
+	Placements     []ItemPlacement `bson:"placements"`
+	ItemWeight     float64         `bson:"itemWeight"`
+	GrossWeight    float64         `bson:"grossWeight"` // Items + carton tare
+	ItemVolume     float64         `bson:"itemVolume"`
+	VoidFillVolume float64         `bson:"voidFillVolume"` // Empty space to fill in cubic cm
+	FillRatio      float64         `bson:"fillRatio"`
+	Cost           float64         `bson:"cost"`
+	Hazmat         bool            `bson:"hazmat"`
+	HazmatClass    string          `bson:"hazmatClass,omitempty"`
+}
+
+// CartonItem is the quantity of a SKU packed into a carton
+type CartonItem struct {
+	SKU      string `bson:"sku"`
+	Quantity int    `bson:"quantity"`
+}
+
+// ItemPlacement is the position of one unit inside a carton, measured from the
+// back-left-bottom corner, with the unit's dimensions as oriented in the carton
+type ItemPlacement struct {
+	SKU        string     `bson:"sku"`
+	X          float64    `bson:"x"`
+	Y          float64    `bson:"y"`
+	Z          float64    `bson:"z"`
+	Dimensions Dimensions `bson:"dimensions"`
+}
+
+// PackagingOverride records a packer choosing different packaging than recommended
+type PackagingOverride struct {
+	RecommendedCartonID   string      `bson:"recommendedCartonId"`
+	RecommendedType       PackageType `bson:"recommendedType"`
+	RecommendedDimensions Dimensions  `bson:"recommendedDimensions"`
+	SelectedCartonID      string      `bson:"selectedCartonId,omitempty"`
+	SelectedType          PackageType `bson:"selectedType"`
+	SelectedDimensions    Dimensions  `bson:"selectedDimensions"`
+	Reason                string      `bson:"reason"`
+	PackerID              string      `bson:"packerId,omitempty"`
+	OverriddenAt          time.Time   `bson:"overriddenAt"`
+}
+
+// packUnit is a single unit of a pack item
+type packUnit struct {
+	sku        string
+	dimensions Dimensions
+	weight     float64
+	fragile    bool
+	thisSideUp bool
+}
+
+func (u packUnit) volume() float64 {
+	return u.dimensions.Volume()
+}
+
+// orientations returns the distinct ways the unit can be placed.
+// Units marked this side up may only be turned about the vertical axis.
+func (u packUnit) orientations() []Dimensions {
+	l, w, h := u.dimensions.Length, u.dimensions.Width, u.dimensions.Height
+	candidates := []Dimensions{{l, w, h}, {w, l, h}}
+	if !u.thisSideUp {
+		candidates = append(candidates,
+			Dimensions{l, h, w}, Dimensions{h, l, w},
+			Dimensions{w, h, l}, Dimensions{h, w, l},
+		)
+	}
+
+	result := make([]Dimensions, 0, len(candidates))
+	for _, c := range candidates {
+		duplicate := false
+		for _, r := range result {
+			if r == c {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// placedUnit is a unit that has been positioned inside a carton
+type placedUnit struct {
+	unit          packUnit
+	x, y, z       float64
+	length, width float64
+	height        float64
+}
+
+// below returns true if the unit lies under a unit placed at x, y, z with
+// dimensions d, whether or not the two touch
+func (p placedUnit) below(x, y, z float64, d Dimensions) bool {
+	return p.z+p.height <= z+dimensionTolerance &&
+		x < p.x+p.length-dimensionTolerance && p.x < x+d.Length-dimensionTolerance &&
+		y < p.y+p.width-dimensionTolerance && p.y < y+d.Width-dimensionTolerance
+}
+
+func (p placedUnit) overlaps(x, y, z float64, d Dimensions) bool {
+	return x < p.x+p.length-dimensionTolerance && p.x < x+d.Length-dimensionTolerance &&
+		y < p.y+p.width-dimensionTolerance && p.y < y+d.Width-dimensionTolerance &&
+		z < p.z+p.height-dimensionTolerance && p.z < z+d.Height-dimensionTolerance
+}
+
+// extremePoint is a candidate corner for the next placement
+type extremePoint struct {
+	x, y, z float64
+}
+
+// packResult is the outcome of packing units into one carton
+type packResult struct {
+	carton    Carton
+	placed    []placedUnit
+	remaining []packUnit
+	weight    float64
+	volume    float64
+}
+
+// Cartonize recommends cartons for the items from the facility catalog.
+//
+// Items are expanded into units and packed with an extreme point 3D bin packing
+// heuristic: larger units go first and units marked this side up are only rotated
+// about the vertical axis. Fragile units go last and are never placed under another
+// unit, so they ride on top of the load; one that cannot go on top moves to another
+// carton. Hazmat units are never packed with other units and each hazard class gets
+// its own hazmat approved cartons. When one carton can hold a group it is the
+// smallest such carton, otherwise the group is split across cartons, filling the
+// fullest one first.
+func Cartonize(items []PackItem, cartons []Carton) (*CartonRecommendation, error) {
+	if len(items) == 0 {
+		return nil, ErrNoItemsToPack
+	}
+
+	available := make([]Carton, 0, len(cartons))
+	for _, carton := range cartons {
+		if carton.Active && carton.Dimensions.IsSet() {
+			available = append(available, carton)
+		}
+	}
+	if len(available) == 0 {
+		return nil, ErrNoCartonsAvailable
+	}
+	// Smallest first, cheapest on ties, so the first carton that fits is the best fit
+	sort.SliceStable(available, func(i, j int) bool {
+		vi, vj := available[i].Dimensions.Volume(), available[j].Dimensions.Volume()
+		if vi != vj {
+			return vi < vj
+		}
+		return available[i].Cost < available[j].Cost
+	})
+
+	var general []packUnit
+	// Hazmat units grouped by hazard class, classes in the order they first appear
+	hazmat := make(map[string][]packUnit)
+	var hazmatClasses []string
+	for _, item := range items {
+		if !item.Dimensions.IsSet() {
+			return nil, fmt.Errorf("%w: %s", ErrItemDimensionsMissing, item.SKU)
+		}
+		for i := 0; i < item.Quantity; i++ {
+			unit := packUnit{
+				sku:        item.SKU,
+				dimensions: item.Dimensions,
+				weight:     item.Weight,
+				fragile:    item.Fragile,
+				thisSideUp: item.ThisSideUp,
+			}
+			if item.Hazmat {
+				class := strings.TrimSpace(item.HazmatClass)
+				if _, ok := hazmat[class]; !ok {
+					hazmatClasses = append(hazmatClasses, class)
+				}
+				hazmat[class] = append(hazmat[class], unit)
+			} else {
+				general = append(general, unit)
+			}
+		}
+	}
+
+	recommendation := &CartonRecommendation{GeneratedAt: time.Now()}
+
+	generalBoxes, err := cartonizeGroup(general, available, false, "")
+	if err != nil {
+		return nil, err
+	}
+	recommendation.Boxes = append(recommendation.Boxes, generalBoxes...)
+
+	if len(hazmatClasses) > 0 {
+		approved := make([]Carton, 0, len(available))
+		for _, carton := range available {
+			if carton.HazmatApproved {
+				approved = append(approved, carton)
+			}
+		}
+		if len(approved) == 0 {
+			return nil, ErrNoHazmatCarton
+		}
+		for _, class := range hazmatClasses {
+			hazmatBoxes, err := cartonizeGroup(hazmat[class], approved, true, class)
+			if err != nil {
+				return nil, err
+			}
+			recommendation.Boxes = append(recommendation.Boxes, hazmatBoxes...)
+		}
+	}
+
+	for _, box := range recommendation.Boxes {
+		recommendation.TotalCost += box.Cost
+		recommendation.TotalVoidFill += box.VoidFillVolume
+	}
+
+	return recommendation, nil
+}
+
+// cartonizeGroup packs units that may share a carton
+func cartonizeGroup(units []packUnit, cartons []Carton, hazmat bool, hazmatClass string) ([]CartonBox, error) {
+	if len(units) == 0 {
+		return nil, nil
+	}
+
+	remaining := sortUnits(units)
+	var boxes []CartonBox
+
+	for len(remaining) > 0 {
+		var best *packResult
+		for _, carton := range cartons {
+			result := packCarton(carton, remaining)
+			if len(result.remaining) == 0 {
+				best = result
+				break
+			}
+			// Split shipments start with the carton that takes the most volume
+			if len(result.placed) > 0 && (best == nil || result.volume > best.volume+dimensionTolerance) {
+				best = result
+			}
+		}
+
+		if best == nil {
+			return nil, fmt.Errorf("%w: %s", ErrItemDoesNotFit, remaining[0].sku)
+		}
+
+		box := best.toBox(hazmat)
+		box.HazmatClass = hazmatClass
+		boxes = append(boxes, box)
+		remaining = best.remaining
+	}
+
+	return boxes, nil
+}
+
+// sortUnits orders units for packing: sturdy before fragile, then largest first
+func sortUnits(units []packUnit) []packUnit {
+	sorted := make([]packUnit, len(units))
+	copy(sorted, units)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].fragile != sorted[j].fragile {
+			return !sorted[i].fragile
+		}
+		return sorted[i].volume() > sorted[j].volume()
+	})
+	return sorted
+}
+
+// packCarton places as many units as possible into the carton
+func packCarton(carton Carton, units []packUnit) *packResult {
+	result := &packResult{carton: carton}
+	points := []extremePoint{{0, 0, 0}}
+
+	for _, unit := range units {
+		if carton.MaxWeight > 0 && result.weight+unit.weight > carton.MaxWeight+dimensionTolerance {
+			result.remaining = append(result.remaining, unit)
+			continue
+		}
+
+		pointIndex, placement, ok := findPlacement(carton.Dimensions, result.placed, points, unit)
+		if !ok {
+			result.remaining = append(result.remaining, unit)
+			continue
+		}
+
+		result.placed = append(result.placed, placement)
+		result.weight += unit.weight
+		result.volume += unit.volume()
+
+		points = append(points[:pointIndex], points[pointIndex+1:]...)
+		points = append(points,
+			extremePoint{placement.x + placement.length, placement.y, placement.z},
+			extremePoint{placement.x, placement.y + placement.width, placement.z},
+		)
+		// Nothing is stacked on top of a fragile unit
+		if !unit.fragile {
+			points = append(points, extremePoint{placement.x, placement.y, placement.z + placement.height})
+		}
+		// Fill from the bottom back corner outwards
+		sort.SliceStable(points, func(i, j int) bool {
+			if points[i].z != points[j].z {
+				return points[i].z < points[j].z
+			}
+			if points[i].y != points[j].y {
+				return points[i].y < points[j].y
+			}
+			return points[i].x < points[j].x
+		})
+	}
+
+	return result
+}
+
+// findPlacement returns the first extreme point and orientation where the unit fits
+func findPlacement(bounds Dimensions, placed []placedUnit, points []extremePoint, unit packUnit) (int, placedUnit, bool) {
+	orientations := unit.orientations()
+	for i, point := range points {
+		for _, o := range orientations {
+			if point.x+o.Length > bounds.Length+dimensionTolerance ||
+				point.y+o.Width > bounds.Width+dimensionTolerance ||
+				point.z+o.Height > bounds.Height+dimensionTolerance {
+				continue
+			}
+
+			fits := true
+			for _, p := range placed {
+				// Overhangs must not reach over a fragile unit either
+				if p.overlaps(point.x, point.y, point.z, o) || (p.unit.fragile && p.below(point.x, point.y, point.z, o)) {
+					fits = false
+					break
+				}
+			}
+			if !fits {
+				continue
+			}
+
+			return i, placedUnit{
+				unit:   unit,
+				x:      point.x,
+				y:      point.y,
+				z:      point.z,
+				length: o.Length,
+				width:  o.Width,
+				height: o.Height,
+			}, true
+		}
+	}
+	return 0, placedUnit{}, false
+}
+
+// toBox converts a packing result into a recommended carton
+func (r *packResult) toBox(hazmat bool) CartonBox {
+	cartonVolume := r.carton.Dimensions.Volume()
+	box := CartonBox{
+		CartonID:       r.carton.CartonID,
+		Name:           r.carton.Name,
+		Type:           r.carton.Type,
+		Dimensions:     r.carton.Dimensions,
+		Placements:     make([]ItemPlacement, 0, len(r.placed)),
+		ItemWeight:     r.weight,
+		GrossWeight:    r.weight + r.carton.TareWeight,
+		ItemVolume:     r.volume,
+		VoidFillVolume: cartonVolume - r.volume,
+		FillRatio:      r.volume / cartonVolume,
+		Cost:           r.carton.Cost,
+		Hazmat:         hazmat,
+	}
+
+	quantities := make(map[string]int)
+	for _, p := range r.placed {
+		if quantities[p.unit.sku] == 0 {
+			box.Items = append(box.Items, CartonItem{SKU: p.unit.sku})
+		}
+		quantities[p.unit.sku]++
+		box.Placements = append(box.Placements, ItemPlacement{
+			SKU:        p.unit.sku,
+			X:          p.x,
+			Y:          p.y,
+			Z:          p.z,
+			Dimensions: Dimensions{Length: p.length, Width: p.width, Height: p.height},
+		})
+	}
+	for i := range box.Items {
+		box.Items[i].Quantity = quantities[box.Items[i].SKU]
+	}
+
+	return box
+}
+
+// CanCartonize returns true if every item has the dimensions the engine needs
+func (t *PackTask) CanCartonize() bool {
+	for _, item := range t.Items {
+		if !item.Dimensions.IsSet() {
+			return false
+		}
+	}
+	return len(t.Items) > 0
+}
+
+// ApplyCartonization records a cartonization recommendation on the task.
+// The primary carton becomes the suggested package type.
+func (t *PackTask) ApplyCartonization(recommendation *CartonRecommendation) error {
+	if t.Status == PackTaskStatusCompleted {
+		return ErrPackTaskCompleted
+	}
+	if t.Package.Sealed {
+		return ErrPackageSealed
+	}
+	if recommendation == nil || len(recommendation.Boxes) == 0 {
+		return ErrNoRecommendation
+	}
+
+	t.Cartonization = recommendation
+	t.Package.SuggestedType = recommendation.Boxes[0].Type
+	t.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// RecommendedBox returns the primary carton of the recommendation, if any
+func (t *PackTask) RecommendedBox() *CartonBox {
+	if t.Cartonization == nil || len(t.Cartonization.Boxes) == 0 {
+		return nil
+	}
+	return &t.Cartonization.Boxes[0]
+}
+
+// FollowsRecommendation returns true if the packaging matches the primary recommended
+// carton, or if there is no recommendation to follow
+func (t *PackTask) FollowsRecommendation(cartonID string, packageType PackageType, dimensions Dimensions) bool {
+	box := t.RecommendedBox()
+	if box == nil {
+		return true
+	}
+	if cartonID != "" {
+		return cartonID == box.CartonID
+	}
+	return packageType == box.Type && dimensions == box.Dimensions
+}
+
+// OverridePackaging selects packaging other than the recommended carton.
+// The packer must give a reason, which is kept on the task and published.
+func (t *PackTask) OverridePackaging(cartonID string, packageType PackageType, dimensions Dimensions, materials []string, reason string) error {
+	box := t.RecommendedBox()
+	if box == nil {
+		return ErrNoRecommendation
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrOverrideReasonRequired
+	}
+
+	if err := t.SelectPackaging(packageType, dimensions, materials); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	t.PackagingOverride = &PackagingOverride{
+		RecommendedCartonID:   box.CartonID,
+		RecommendedType:       box.Type,
+		RecommendedDimensions: box.Dimensions,
+		SelectedCartonID:      cartonID,
+		SelectedType:          packageType,
+		SelectedDimensions:    dimensions,
+		Reason:                reason,
+		PackerID:              t.PackerID,
+		OverriddenAt:          now,
+	}
+
+	t.AddDomainEvent(&PackagingOverriddenEvent{
+		TaskID:              t.TaskID,
+		OrderID:             t.OrderID,
+		PackageID:           t.Package.PackageID,
+		PackerID:            t.PackerID,
+		RecommendedCartonID: box.CartonID,
+		RecommendedType:     string(box.Type),
+		SelectedCartonID:    cartonID,
+		SelectedType:        string(packageType),
+		SelectedDimensions:  dimensions,
+		Reason:              reason,
+		OverriddenAt:        now,
+	})
+
+	return nil
+}
@@ -0,0 +1,282 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestCartons() []Carton {
+	return []Carton{
+		{CartonID: "LARGE", Name: "Large box", Type: PackageTypeBox, Dimensions: Dimensions{Length: 40, Width: 30, Height: 30}, TareWeight: 0.6, Cost: 1.50, Active: true},
+		{CartonID: "SMALL", Name: "Small box", Type: PackageTypeBox, Dimensions: Dimensions{Length: 20, Width: 15, Height: 10}, TareWeight: 0.2, Cost: 0.50, Active: true},
+		{CartonID: "MEDIUM", Name: "Medium box", Type: PackageTypeBox, Dimensions: Dimensions{Length: 30, Width: 20, Height: 15}, TareWeight: 0.4, Cost: 0.90, Active: true},
+		{CartonID: "RETIRED", Name: "Retired mailer", Type: PackageTypePadded, Dimensions: Dimensions{Length: 25, Width: 20, Height: 5}, Cost: 0.10, Active: false},
+	}
+}
+
+func cube(sku string, quantity int, side float64) PackItem {
+	return PackItem{
+		SKU:        sku,
+		Quantity:   quantity,
+		Weight:     0.5,
+		Dimensions: Dimensions{Length: side, Width: side, Height: side},
+	}
+}
+
+func TestCartonize_SmallestCartonThatFits(t *testing.T) {
+	rec, err := Cartonize([]PackItem{cube("SKU-001", 2, 10)}, createTestCartons())
+	require.NoError(t, err)
+
+	require.Len(t, rec.Boxes, 1)
+	box := rec.Boxes[0]
+	assert.Equal(t, "SMALL", box.CartonID)
+	assert.Equal(t, []CartonItem{{SKU: "SKU-001", Quantity: 2}}, box.Items)
+	assert.Len(t, box.Placements, 2)
+	assert.InDelta(t, 2000, box.ItemVolume, 0.001)
+	assert.InDelta(t, 1000, box.VoidFillVolume, 0.001)
+	assert.InDelta(t, 2000.0/3000.0, box.FillRatio, 0.001)
+	assert.InDelta(t, 1.2, box.GrossWeight, 0.001)
+	assert.InDelta(t, 0.50, rec.TotalCost, 0.001)
+	assert.InDelta(t, 1000, rec.TotalVoidFill, 0.001)
+}
+
+func TestCartonize_SplitsAcrossCartons(t *testing.T) {
+	// The largest carton holds 36 of the 40 cubes; the rest go in the smallest carton that fits them
+	rec, err := Cartonize([]PackItem{cube("SKU-001", 40, 10)}, createTestCartons())
+	require.NoError(t, err)
+
+	require.Len(t, rec.Boxes, 2)
+	assert.Equal(t, "LARGE", rec.Boxes[0].CartonID)
+	assert.Equal(t, 36, rec.Boxes[0].Items[0].Quantity)
+	assert.Equal(t, "MEDIUM", rec.Boxes[1].CartonID)
+	assert.Equal(t, 4, rec.Boxes[1].Items[0].Quantity)
+	assert.InDelta(t, 2.40, rec.TotalCost, 0.001)
+
+	packed := 0
+	for _, box := range rec.Boxes {
+		packed += len(box.Placements)
+	}
+	assert.Equal(t, 40, packed)
+}
+
+func TestCartonize_Orientation(t *testing.T) {
+	cartons := []Carton{
+		{CartonID: "FLAT", Type: PackageTypeBox, Dimensions: Dimensions{Length: 31, Width: 6, Height: 6}, Active: true},
+		{CartonID: "TALL", Type: PackageTypeBox, Dimensions: Dimensions{Length: 8, Width: 8, Height: 32}, Active: true},
+	}
+	item := PackItem{SKU: "BOTTLE", Quantity: 1, Dimensions: Dimensions{Length: 5, Width: 5, Height: 30}}
+
+	t.Run("item may be laid down", func(t *testing.T) {
+		rec, err := Cartonize([]PackItem{item}, cartons)
+		require.NoError(t, err)
+		assert.Equal(t, "FLAT", rec.Boxes[0].CartonID)
+	})
+
+	t.Run("this side up keeps the item upright", func(t *testing.T) {
+		item.ThisSideUp = true
+		rec, err := Cartonize([]PackItem{item}, cartons)
+		require.NoError(t, err)
+		assert.Equal(t, "TALL", rec.Boxes[0].CartonID)
+		assert.Equal(t, 30.0, rec.Boxes[0].Placements[0].Dimensions.Height)
+	})
+}
+
+func TestCartonize_NothingStackedOnFragile(t *testing.T) {
+	cartons := []Carton{
+		{CartonID: "TOWER", Type: PackageTypeBox, Dimensions: Dimensions{Length: 10, Width: 10, Height: 20}, Cost: 1, Active: true},
+		{CartonID: "WIDE", Type: PackageTypeBox, Dimensions: Dimensions{Length: 20, Width: 10, Height: 10}, Cost: 2, Active: true},
+	}
+	sturdy := cube("MUG", 1, 10)
+	fragile := cube("VASE", 1, 10)
+	fragile.Fragile = true
+
+	t.Run("fragile unit goes on top", func(t *testing.T) {
+		rec, err := Cartonize([]PackItem{fragile, sturdy}, cartons)
+		require.NoError(t, err)
+
+		require.Len(t, rec.Boxes, 1)
+		assert.Equal(t, "TOWER", rec.Boxes[0].CartonID)
+		placements := rec.Boxes[0].Placements
+		assert.Equal(t, "MUG", placements[0].SKU)
+		assert.Equal(t, 0.0, placements[0].Z)
+		assert.Equal(t, "VASE", placements[1].SKU)
+		assert.Equal(t, 10.0, placements[1].Z)
+	})
+
+	t.Run("nothing overhangs a fragile unit", func(t *testing.T) {
+		carton := []Carton{
+			{CartonID: "SHELF", Type: PackageTypeBox, Dimensions: Dimensions{Length: 20, Width: 10, Height: 20}, Active: true},
+		}
+		plate := PackItem{
+			SKU: "PLATE", Quantity: 1, Fragile: true,
+			Dimensions: Dimensions{Length: 20, Width: 10, Height: 4},
+		}
+
+		rec, err := Cartonize([]PackItem{sturdy, fragile, plate}, carton)
+		require.NoError(t, err)
+
+		// The plate only fits across the mug and the vase, so it needs a carton of its own
+		require.Len(t, rec.Boxes, 2)
+		assert.Equal(t, []CartonItem{{SKU: "MUG", Quantity: 1}, {SKU: "VASE", Quantity: 1}}, rec.Boxes[0].Items)
+		assert.Equal(t, []CartonItem{{SKU: "PLATE", Quantity: 1}}, rec.Boxes[1].Items)
+	})
+
+	t.Run("fragile units are not stacked", func(t *testing.T) {
+		rec, err := Cartonize([]PackItem{{
+			SKU: "VASE", Quantity: 2, Fragile: true,
+			Dimensions: Dimensions{Length: 10, Width: 10, Height: 10},
+		}}, cartons)
+		require.NoError(t, err)
+
+		require.Len(t, rec.Boxes, 1)
+		assert.Equal(t, "WIDE", rec.Boxes[0].CartonID)
+	})
+}
+
+func TestCartonize_HazmatSeparation(t *testing.T) {
+	battery := cube("BATTERY", 1, 5)
+	battery.Hazmat = true
+	items := []PackItem{cube("SKU-001", 1, 10), battery}
+
+	t.Run("hazmat ships in its own approved carton", func(t *testing.T) {
+		cartons := append(createTestCartons(), Carton{
+			CartonID: "HAZMAT", Type: PackageTypeBox, Dimensions: Dimensions{Length: 20, Width: 20, Height: 20},
+			HazmatApproved: true, Active: true,
+		})
+
+		rec, err := Cartonize(items, cartons)
+		require.NoError(t, err)
+
+		require.Len(t, rec.Boxes, 2)
+		assert.Equal(t, "SMALL", rec.Boxes[0].CartonID)
+		assert.False(t, rec.Boxes[0].Hazmat)
+		assert.Equal(t, "HAZMAT", rec.Boxes[1].CartonID)
+		assert.True(t, rec.Boxes[1].Hazmat)
+		assert.Equal(t, []CartonItem{{SKU: "BATTERY", Quantity: 1}}, rec.Boxes[1].Items)
+	})
+
+	t.Run("each hazard class ships separately", func(t *testing.T) {
+		cartons := append(createTestCartons(), Carton{
+			CartonID: "HAZMAT", Type: PackageTypeBox, Dimensions: Dimensions{Length: 20, Width: 20, Height: 20},
+			HazmatApproved: true, Active: true,
+		})
+		lithium := cube("BATTERY", 2, 5)
+		lithium.Hazmat, lithium.HazmatClass = true, "9"
+		aerosol := cube("AEROSOL", 1, 5)
+		aerosol.Hazmat, aerosol.HazmatClass = true, "2.1"
+
+		rec, err := Cartonize([]PackItem{lithium, aerosol}, cartons)
+		require.NoError(t, err)
+
+		require.Len(t, rec.Boxes, 2)
+		assert.Equal(t, "9", rec.Boxes[0].HazmatClass)
+		assert.Equal(t, []CartonItem{{SKU: "BATTERY", Quantity: 2}}, rec.Boxes[0].Items)
+		assert.Equal(t, "2.1", rec.Boxes[1].HazmatClass)
+		assert.Equal(t, []CartonItem{{SKU: "AEROSOL", Quantity: 1}}, rec.Boxes[1].Items)
+	})
+
+	t.Run("no approved carton", func(t *testing.T) {
+		_, err := Cartonize(items, createTestCartons())
+		assert.ErrorIs(t, err, ErrNoHazmatCarton)
+	})
+}
+
+func TestCartonize_WeightLimit(t *testing.T) {
+	cartons := []Carton{
+		{CartonID: "MAILER", Type: PackageTypeBox, Dimensions: Dimensions{Length: 40, Width: 40, Height: 40}, MaxWeight: 1.0, Active: true},
+	}
+	item := cube("DUMBBELL", 3, 10)
+	item.Weight = 0.6
+
+	rec, err := Cartonize([]PackItem{item}, cartons)
+	require.NoError(t, err)
+	assert.Len(t, rec.Boxes, 3)
+}
+
+func TestCartonize_Errors(t *testing.T) {
+	t.Run("missing dimensions", func(t *testing.T) {
+		_, err := Cartonize([]PackItem{{SKU: "SKU-001", Quantity: 1}}, createTestCartons())
+		assert.ErrorIs(t, err, ErrItemDimensionsMissing)
+	})
+
+	t.Run("no active cartons", func(t *testing.T) {
+		_, err := Cartonize([]PackItem{cube("SKU-001", 1, 10)}, createTestCartons()[3:])
+		assert.ErrorIs(t, err, ErrNoCartonsAvailable)
+	})
+
+	t.Run("item larger than every carton", func(t *testing.T) {
+		_, err := Cartonize([]PackItem{cube("SKU-001", 1, 10), cube("SOFA", 1, 50)}, createTestCartons())
+		assert.ErrorIs(t, err, ErrItemDoesNotFit)
+		assert.Contains(t, err.Error(), "SOFA")
+	})
+}
+
+func TestPackTask_OverridePackaging(t *testing.T) {
+	newCartonizedTask := func(t *testing.T) *PackTask {
+		task, err := NewPackTask("PACK-001", "ORD-001", "WAVE-001", []PackItem{cube("SKU-001", 2, 10)})
+		require.NoError(t, err)
+		task.PackerID = "PACKER-1"
+
+		rec, err := Cartonize(task.Items, createTestCartons())
+		require.NoError(t, err)
+		require.NoError(t, task.ApplyCartonization(rec))
+		task.ClearDomainEvents()
+		return task
+	}
+
+	t.Run("recommendation sets the suggested type", func(t *testing.T) {
+		task := newCartonizedTask(t)
+		assert.Equal(t, PackageTypeBox, task.Package.SuggestedType)
+		assert.True(t, task.FollowsRecommendation("SMALL", "", Dimensions{}))
+		assert.True(t, task.FollowsRecommendation("", PackageTypeBox, Dimensions{Length: 20, Width: 15, Height: 10}))
+		assert.False(t, task.FollowsRecommendation("MEDIUM", "", Dimensions{}))
+	})
+
+	t.Run("override requires a reason", func(t *testing.T) {
+		task := newCartonizedTask(t)
+		err := task.OverridePackaging("MEDIUM", PackageTypeBox, Dimensions{Length: 30, Width: 20, Height: 15}, nil, "  ")
+		assert.ErrorIs(t, err, ErrOverrideReasonRequired)
+		assert.Nil(t, task.PackagingOverride)
+		assert.Empty(t, task.GetDomainEvents())
+	})
+
+	t.Run("override is recorded and published", func(t *testing.T) {
+		task := newCartonizedTask(t)
+		dims := Dimensions{Length: 30, Width: 20, Height: 15}
+		err := task.OverridePackaging("MEDIUM", PackageTypeBox, dims, []string{"bubble_wrap"}, "Small box out of stock")
+		require.NoError(t, err)
+
+		require.NotNil(t, task.PackagingOverride)
+		assert.Equal(t, "SMALL", task.PackagingOverride.RecommendedCartonID)
+		assert.Equal(t, "MEDIUM", task.PackagingOverride.SelectedCartonID)
+		assert.Equal(t, "Small box out of stock", task.PackagingOverride.Reason)
+		assert.Equal(t, "PACKER-1", task.PackagingOverride.PackerID)
+		assert.Equal(t, dims, task.Package.Dimensions)
+
+		events := task.GetDomainEvents()
+		require.Len(t, events, 2)
+		assert.IsType(t, &PackagingSuggestedEvent{}, events[0])
+		overridden, ok := events[1].(*PackagingOverriddenEvent)
+		require.True(t, ok)
+		assert.Equal(t, "wms.packing.packaging-overridden", overridden.EventType())
+		assert.Equal(t, "Small box out of stock", overridden.Reason)
+	})
+
+	t.Run("nothing to override without a recommendation", func(t *testing.T) {
+		task, err := NewPackTask("PACK-002", "ORD-002", "WAVE-001", createTestPackItems())
+		require.NoError(t, err)
+
+		assert.True(t, task.FollowsRecommendation("", PackageTypeBag, Dimensions{}))
+		err = task.OverridePackaging("", PackageTypeBag, Dimensions{}, nil, "reason")
+		assert.ErrorIs(t, err, ErrNoRecommendation)
+	})
+
+	t.Run("sealed package cannot be cartonized", func(t *testing.T) {
+		task := newCartonizedTask(t)
+		task.Package.Sealed = true
+		err := task.ApplyCartonization(task.Cartonization)
+		assert.ErrorIs(t, err, ErrPackageSealed)
+	})
+}
@@ -67,3 +67,21 @@ type PackTaskCompletedEvent struct {
 
 func (e *PackTaskCompletedEvent) EventType() string    { return "wms.packing.task-completed" }
 func (e *PackTaskCompletedEvent) OccurredAt() time.Time { return e.CompletedAt }
+
+// PackagingOverriddenEvent is published when a packer overrides the recommended carton
+type PackagingOverriddenEvent struct {
+	TaskID              string     `json:"taskId"`
+	OrderID             string     `json:"orderId"`
+	PackageID           string     `json:"packageId"`
+	PackerID            string     `json:"packerId,omitempty"`
+	RecommendedCartonID string     `json:"recommendedCartonId"`
+	RecommendedType     string     `json:"recommendedType"`
+	SelectedCartonID    string     `json:"selectedCartonId,omitempty"`
+	SelectedType        string     `json:"selectedType"`
+	SelectedDimensions  Dimensions `json:"selectedDimensions"`
+	Reason              string     `json:"reason"`
+	OverriddenAt        time.Time  `json:"overriddenAt"`
+}
+
+func (e *PackagingOverriddenEvent) EventType() string    { return "wms.packing.packaging-overridden" }
+func (e *PackagingOverriddenEvent) OccurredAt() time.Time { return e.OverriddenAt }
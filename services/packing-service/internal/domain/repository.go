@@ -16,6 +16,14 @@ type PackTaskRepository interface {
 	Delete(ctx context.Context, taskID string) error
 }
 
+// CartonRepository defines the interface for the per-facility carton catalog
+type CartonRepository interface {
+	Save(ctx context.Context, carton *Carton) error
+	FindByID(ctx context.Context, facilityID, cartonID string) (*Carton, error)
+	FindByFacility(ctx context.Context, facilityID string, activeOnly bool) ([]*Carton, error)
+	Delete(ctx context.Context, facilityID, cartonID string) error
+}
+
 // LabelGenerator defines the interface for generating shipping labels
 type LabelGenerator interface {
 	GenerateLabel(ctx context.Context, request LabelRequest) (*ShippingLabel, error)
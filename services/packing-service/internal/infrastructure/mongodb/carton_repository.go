@@ -0,0 +1,95 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/wms-platform/packing-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/tenant"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CartonRepository persists the per-facility carton catalog
+type CartonRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCartonRepository(db *mongo.Database) *CartonRepository {
+	repo := &CartonRepository{
+		collection: db.Collection("cartons"),
+	}
+	repo.ensureIndexes(context.Background())
+	return repo
+}
+
+func (r *CartonRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "facilityId", Value: 1}, {Key: "cartonId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "facilityId", Value: 1}, {Key: "active", Value: 1}}},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+func (r *CartonRepository) Save(ctx context.Context, carton *domain.Carton) error {
+	carton.UpdatedAt = time.Now()
+
+	opts := options.Update().SetUpsert(true)
+	filter := bson.M{
+		"tenantId":   carton.TenantID,
+		"facilityId": carton.FacilityID,
+		"cartonId":   carton.CartonID,
+	}
+	update := bson.M{"$set": carton}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, opts)
+	return err
+}
+
+func (r *CartonRepository) FindByID(ctx context.Context, facilityID, cartonID string) (*domain.Carton, error) {
+	filter := r.facilityFilter(ctx, facilityID)
+	filter["cartonId"] = cartonID
+	var carton domain.Carton
+	err := r.collection.FindOne(ctx, filter).Decode(&carton)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	return &carton, err
+}
+
+func (r *CartonRepository) FindByFacility(ctx context.Context, facilityID string, activeOnly bool) ([]*domain.Carton, error) {
+	filter := r.facilityFilter(ctx, facilityID)
+	if activeOnly {
+		filter["active"] = true
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "cartonId", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var cartons []*domain.Carton
+	err = cursor.All(ctx, &cartons)
+	return cartons, err
+}
+
+func (r *CartonRepository) Delete(ctx context.Context, facilityID, cartonID string) error {
+	filter := r.facilityFilter(ctx, facilityID)
+	filter["cartonId"] = cartonID
+	_, err := r.collection.DeleteOne(ctx, filter)
+	return err
+}
+
+// facilityFilter scopes a query to the facility's catalog. The catalog is shared by
+// every warehouse and seller in the facility, so only the tenant is added from context.
+func (r *CartonRepository) facilityFilter(ctx context.Context, facilityID string) bson.M {
+	filter := bson.M{"facilityId": facilityID}
+	if tc := tenant.FromContextOptional(ctx); tc.TenantID != "" {
+		filter["tenantId"] = tc.TenantID
+	}
+	return filter
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/packing-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -58,8 +59,12 @@ func (r *PackTaskRepository) ensureIndexes(ctx context.Context) {
 	r.collection.Indexes().CreateMany(ctx, indexes)
 }
 
+// Save persists the pack task and its events. The write only succeeds if the stored
+// task still has the version that was loaded, otherwise it fails with ErrConcurrencyConflict.
 func (r *PackTaskRepository) Save(ctx context.Context, task *domain.PackTask) error {
 	task.UpdatedAt = time.Now()
+	expectedVersion := task.Version
+	task.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -71,11 +76,12 @@ func (r *PackTaskRepository) Save(ctx context.Context, task *domain.PackTask) er
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"taskId": task.TaskID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"taskId": task.TaskID}, expectedVersion)
 		update := bson.M{"$set": task}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "PackTask", task.TaskID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save pack task: %w", err)
 		}
 
@@ -98,6 +104,8 @@ func (r *PackTaskRepository) Save(ctx context.Context, task *domain.PackTask) er
 					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "pack-task/"+e.TaskID, e)
 				case *domain.PackTaskCompletedEvent:
 					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "pack-task/"+e.TaskID, e)
+				case *domain.PackagingOverriddenEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "pack-task/"+e.TaskID, e)
 				default:
 					continue
 				}
@@ -131,6 +139,7 @@ func (r *PackTaskRepository) Save(ctx context.Context, task *domain.PackTask) er
 	})
 
 	if err != nil {
+		task.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
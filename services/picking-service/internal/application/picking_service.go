@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 	"github.com/wms-platform/shared/pkg/tenant"
 
 	"github.com/wms-platform/picking-service/internal/domain"
@@ -82,23 +83,14 @@ func (s *PickingApplicationService) GetPickTask(ctx context.Context, query GetPi
 
 // AssignTask assigns a pick task to a picker
 func (s *PickingApplicationService) AssignTask(ctx context.Context, cmd AssignTaskCommand) (*PickTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PickTask) error {
+		if err := task.Assign(cmd.PickerID, cmd.ToteID); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pick task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pick task: %w", err)
-	}
-
-	if task == nil {
-		return nil, errors.ErrNotFound("pick task")
-	}
-
-	if err := task.Assign(cmd.PickerID, cmd.ToteID); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pick task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pick task: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -120,23 +112,14 @@ func (s *PickingApplicationService) AssignTask(ctx context.Context, cmd AssignTa
 
 // StartTask starts a pick task
 func (s *PickingApplicationService) StartTask(ctx context.Context, cmd StartTaskCommand) (*PickTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PickTask) error {
+		if err := task.Start(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pick task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pick task: %w", err)
-	}
-
-	if task == nil {
-		return nil, errors.ErrNotFound("pick task")
-	}
-
-	if err := task.Start(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pick task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pick task: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Started pick task", "taskId", cmd.TaskID)
@@ -145,23 +128,14 @@ func (s *PickingApplicationService) StartTask(ctx context.Context, cmd StartTask
 
 // ConfirmPick confirms an item was picked
 func (s *PickingApplicationService) ConfirmPick(ctx context.Context, cmd ConfirmPickCommand) (*PickTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PickTask) error {
+		if err := task.ConfirmPick(cmd.SKU, cmd.LocationID, cmd.PickedQty, cmd.ToteID); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pick task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pick task: %w", err)
-	}
-
-	if task == nil {
-		return nil, errors.ErrNotFound("pick task")
-	}
-
-	if err := task.ConfirmPick(cmd.SKU, cmd.LocationID, cmd.PickedQty, cmd.ToteID); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pick task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pick task: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -184,23 +158,14 @@ func (s *PickingApplicationService) ConfirmPick(ctx context.Context, cmd Confirm
 
 // ReportException reports a pick exception
 func (s *PickingApplicationService) ReportException(ctx context.Context, cmd ReportExceptionCommand) (*PickTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PickTask) error {
+		if err := task.ReportException(cmd.SKU, cmd.LocationID, cmd.Reason, cmd.RequestedQty, cmd.AvailableQty); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pick task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pick task: %w", err)
-	}
-
-	if task == nil {
-		return nil, errors.ErrNotFound("pick task")
-	}
-
-	if err := task.ReportException(cmd.SKU, cmd.LocationID, cmd.Reason, cmd.RequestedQty, cmd.AvailableQty); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pick task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pick task: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -222,23 +187,14 @@ func (s *PickingApplicationService) ReportException(ctx context.Context, cmd Rep
 
 // CompleteTask completes a pick task
 func (s *PickingApplicationService) CompleteTask(ctx context.Context, cmd CompleteTaskCommand) (*PickTaskDTO, error) {
-	task, err := s.repo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PickTask) error {
+		if err := task.Complete(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get pick task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to get pick task: %w", err)
-	}
-
-	if task == nil {
-		return nil, errors.ErrNotFound("pick task")
-	}
-
-	if err := task.Complete(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, task); err != nil {
-		s.logger.WithError(err).Error("Failed to save pick task", "taskId", cmd.TaskID)
-		return nil, fmt.Errorf("failed to save pick task: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -257,6 +213,45 @@ func (s *PickingApplicationService) CompleteTask(ctx context.Context, cmd Comple
 	return ToPickTaskDTO(task), nil
 }
 
+// updateTask loads a pick task, applies change and saves it. Concurrent modifications
+// are resolved by reloading the task and applying change again.
+func (s *PickingApplicationService) updateTask(ctx context.Context, taskID string, change func(task *domain.PickTask) error) (*domain.PickTask, error) {
+	var task *domain.PickTask
+
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.repo.FindByID(ctx, taskID)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to get pick task", "taskId", taskID)
+			return fmt.Errorf("failed to get pick task: %w", err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("pick task")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				return err
+			}
+			s.logger.WithError(err).Error("Failed to save pick task", "taskId", taskID)
+			return fmt.Errorf("failed to save pick task: %w", err)
+		}
+
+		task = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("pick task %s was modified concurrently, please retry", taskID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
 // GetTasksByOrder retrieves pick tasks by order ID
 func (s *PickingApplicationService) GetTasksByOrder(ctx context.Context, query GetTasksByOrderQuery) ([]PickTaskDTO, error) {
 	tasks, err := s.repo.FindByOrderID(ctx, query.OrderID)
@@ -60,6 +60,7 @@ type PickTask struct {
 	Exceptions    []PickException    `bson:"exceptions,omitempty"`
 	CreatedAt     time.Time          `bson:"createdAt"`
 	UpdatedAt     time.Time          `bson:"updatedAt"`
+	Version       int                `bson:"version"`
 	AssignedAt    *time.Time         `bson:"assignedAt,omitempty"`
 	StartedAt     *time.Time         `bson:"startedAt,omitempty"`
 	CompletedAt   *time.Time         `bson:"completedAt,omitempty"`
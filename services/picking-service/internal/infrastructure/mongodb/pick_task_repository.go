@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/picking-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -54,9 +55,12 @@ func (r *PickTaskRepository) ensureIndexes(ctx context.Context) {
 	_ = r.outboxRepo.EnsureIndexes(ctx)
 }
 
-// Save persists a pick task with its domain events in a single transaction
+// Save persists a pick task with its domain events in a single transaction, guarded
+// by the task version
 func (r *PickTaskRepository) Save(ctx context.Context, task *domain.PickTask) error {
 	task.UpdatedAt = time.Now()
+	expectedVersion := task.Version
+	task.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -68,11 +72,12 @@ func (r *PickTaskRepository) Save(ctx context.Context, task *domain.PickTask) er
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"taskId": task.TaskID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"taskId": task.TaskID}, expectedVersion)
 		update := bson.M{"$set": task}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "PickTask", task.TaskID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save pick task: %w", err)
 		}
 
@@ -128,6 +133,7 @@ func (r *PickTaskRepository) Save(ctx context.Context, task *domain.PickTask) er
 	})
 
 	if err != nil {
+		task.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/wms-platform/services/receiving-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 )
 
 // ReceivingService handles receiving operations
@@ -53,21 +55,12 @@ func (s *ReceivingService) CreateShipment(ctx context.Context, cmd CreateShipmen
 
 // MarkShipmentArrived marks a shipment as arrived at the dock
 func (s *ReceivingService) MarkShipmentArrived(ctx context.Context, cmd MarkArrivedCommand) (*domain.InboundShipment, error) {
-	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
+	shipment, err := s.updateShipment(ctx, cmd.ShipmentID, func(shipment *domain.InboundShipment) error {
+		return shipment.MarkArrived(cmd.DockID)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if shipment == nil {
-		return nil, fmt.Errorf("shipment not found: %s", cmd.ShipmentID)
-	}
-
-	if err := shipment.MarkArrived(cmd.DockID); err != nil {
-		return nil, err
-	}
-
-	if err := s.repo.Save(ctx, shipment); err != nil {
-		return nil, err
-	}
 
 	s.logger.Info("Shipment arrived",
 		"shipmentId", cmd.ShipmentID,
@@ -79,21 +72,12 @@ func (s *ReceivingService) MarkShipmentArrived(ctx context.Context, cmd MarkArri
 
 // StartReceiving starts the receiving process
 func (s *ReceivingService) StartReceiving(ctx context.Context, cmd StartReceivingCommand) (*domain.InboundShipment, error) {
-	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
+	shipment, err := s.updateShipment(ctx, cmd.ShipmentID, func(shipment *domain.InboundShipment) error {
+		return shipment.StartReceiving(cmd.WorkerID)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if shipment == nil {
-		return nil, fmt.Errorf("shipment not found: %s", cmd.ShipmentID)
-	}
-
-	if err := shipment.StartReceiving(cmd.WorkerID); err != nil {
-		return nil, err
-	}
-
-	if err := s.repo.Save(ctx, shipment); err != nil {
-		return nil, err
-	}
 
 	s.logger.Info("Started receiving",
 		"shipmentId", cmd.ShipmentID,
@@ -105,21 +89,12 @@ func (s *ReceivingService) StartReceiving(ctx context.Context, cmd StartReceivin
 
 // ReceiveItem records the receipt of an item
 func (s *ReceivingService) ReceiveItem(ctx context.Context, cmd ReceiveItemCommand) (*domain.InboundShipment, error) {
-	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
+	shipment, err := s.updateShipment(ctx, cmd.ShipmentID, func(shipment *domain.InboundShipment) error {
+		return shipment.ReceiveItem(cmd.SKU, cmd.Quantity, cmd.Condition, cmd.ToteID, cmd.WorkerID, cmd.Notes)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if shipment == nil {
-		return nil, fmt.Errorf("shipment not found: %s", cmd.ShipmentID)
-	}
-
-	if err := shipment.ReceiveItem(cmd.SKU, cmd.Quantity, cmd.Condition, cmd.ToteID, cmd.WorkerID, cmd.Notes); err != nil {
-		return nil, err
-	}
-
-	if err := s.repo.Save(ctx, shipment); err != nil {
-		return nil, err
-	}
 
 	s.logger.Info("Received item",
 		"shipmentId", cmd.ShipmentID,
@@ -132,21 +107,12 @@ func (s *ReceivingService) ReceiveItem(ctx context.Context, cmd ReceiveItemComma
 
 // CompleteReceiving completes the receiving process
 func (s *ReceivingService) CompleteReceiving(ctx context.Context, cmd CompleteReceivingCommand) (*domain.InboundShipment, error) {
-	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
+	shipment, err := s.updateShipment(ctx, cmd.ShipmentID, func(shipment *domain.InboundShipment) error {
+		return shipment.Complete()
+	})
 	if err != nil {
 		return nil, err
 	}
-	if shipment == nil {
-		return nil, fmt.Errorf("shipment not found: %s", cmd.ShipmentID)
-	}
-
-	if err := shipment.Complete(); err != nil {
-		return nil, err
-	}
-
-	if err := s.repo.Save(ctx, shipment); err != nil {
-		return nil, err
-	}
 
 	s.logger.Info("Completed receiving",
 		"shipmentId", cmd.ShipmentID,
@@ -157,21 +123,12 @@ func (s *ReceivingService) CompleteReceiving(ctx context.Context, cmd CompleteRe
 
 // BatchReceiveByCarton receives all items in a carton at once (batch ASN receive)
 func (s *ReceivingService) BatchReceiveByCarton(ctx context.Context, cmd BatchReceiveByCartonCommand) (*domain.InboundShipment, error) {
-	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
+	shipment, err := s.updateShipment(ctx, cmd.ShipmentID, func(shipment *domain.InboundShipment) error {
+		return shipment.BatchReceiveByCarton(cmd.CartonID, cmd.WorkerID, cmd.ToteID)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if shipment == nil {
-		return nil, fmt.Errorf("shipment not found: %s", cmd.ShipmentID)
-	}
-
-	if err := shipment.BatchReceiveByCarton(cmd.CartonID, cmd.WorkerID, cmd.ToteID); err != nil {
-		return nil, err
-	}
-
-	if err := s.repo.Save(ctx, shipment); err != nil {
-		return nil, err
-	}
 
 	s.logger.Info("Batch received carton",
 		"shipmentId", cmd.ShipmentID,
@@ -184,21 +141,12 @@ func (s *ReceivingService) BatchReceiveByCarton(ctx context.Context, cmd BatchRe
 
 // MarkItemForPrep marks an item as needing prep (repackaging)
 func (s *ReceivingService) MarkItemForPrep(ctx context.Context, cmd MarkItemForPrepCommand) (*domain.InboundShipment, error) {
-	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
+	shipment, err := s.updateShipment(ctx, cmd.ShipmentID, func(shipment *domain.InboundShipment) error {
+		return shipment.MarkItemForPrep(cmd.SKU, cmd.Quantity, cmd.WorkerID, cmd.ToteID, cmd.Reason)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if shipment == nil {
-		return nil, fmt.Errorf("shipment not found: %s", cmd.ShipmentID)
-	}
-
-	if err := shipment.MarkItemForPrep(cmd.SKU, cmd.Quantity, cmd.WorkerID, cmd.ToteID, cmd.Reason); err != nil {
-		return nil, err
-	}
-
-	if err := s.repo.Save(ctx, shipment); err != nil {
-		return nil, err
-	}
 
 	s.logger.Info("Marked item for prep",
 		"shipmentId", cmd.ShipmentID,
@@ -212,21 +160,12 @@ func (s *ReceivingService) MarkItemForPrep(ctx context.Context, cmd MarkItemForP
 
 // CompletePrep completes prep for an item
 func (s *ReceivingService) CompletePrep(ctx context.Context, cmd CompletePrepCommand) (*domain.InboundShipment, error) {
-	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
+	shipment, err := s.updateShipment(ctx, cmd.ShipmentID, func(shipment *domain.InboundShipment) error {
+		return shipment.CompletePrepForItem(cmd.SKU, cmd.Quantity, cmd.WorkerID, cmd.ToteID)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if shipment == nil {
-		return nil, fmt.Errorf("shipment not found: %s", cmd.ShipmentID)
-	}
-
-	if err := shipment.CompletePrepForItem(cmd.SKU, cmd.Quantity, cmd.WorkerID, cmd.ToteID); err != nil {
-		return nil, err
-	}
-
-	if err := s.repo.Save(ctx, shipment); err != nil {
-		return nil, err
-	}
 
 	s.logger.Info("Completed prep for item",
 		"shipmentId", cmd.ShipmentID,
@@ -237,6 +176,41 @@ func (s *ReceivingService) CompletePrep(ctx context.Context, cmd CompletePrepCom
 	return shipment, nil
 }
 
+// updateShipment loads a shipment, applies change and saves it. A save that loses a race
+// with another writer reloads the shipment and applies change again.
+func (s *ReceivingService) updateShipment(ctx context.Context, shipmentID string, change func(shipment *domain.InboundShipment) error) (*domain.InboundShipment, error) {
+	var shipment *domain.InboundShipment
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.repo.FindByID(ctx, shipmentID)
+		if err != nil {
+			return err
+		}
+		if loaded == nil {
+			return fmt.Errorf("shipment not found: %s", shipmentID)
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				s.logger.Debug("Shipment changed concurrently, retrying", "shipmentId", shipmentID)
+			}
+			return err
+		}
+		shipment = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("shipment %s was modified concurrently, please retry", shipmentID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
 // GetShipment retrieves a shipment by ID
 func (s *ReceivingService) GetShipment(ctx context.Context, shipmentID string) (*domain.InboundShipment, error) {
 	return s.repo.FindByID(ctx, shipmentID)
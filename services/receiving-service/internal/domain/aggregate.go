@@ -197,6 +197,7 @@ type InboundShipment struct {
 	CompletedAt      *time.Time            `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
 	CreatedAt        time.Time             `bson:"createdAt" json:"createdAt"`
 	UpdatedAt        time.Time             `bson:"updatedAt" json:"updatedAt"`
+	Version          int                   `bson:"version" json:"version"` // Incremented on every save for optimistic concurrency
 	DomainEvents     []DomainEvent         `bson:"-" json:"-"`
 }
 
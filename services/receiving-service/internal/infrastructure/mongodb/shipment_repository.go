@@ -9,6 +9,7 @@ import (
 	"github.com/wms-platform/services/receiving-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"go.mongodb.org/mongo-driver/bson"
@@ -55,9 +56,12 @@ func (r *InboundShipmentRepository) ensureIndexes(ctx context.Context) {
 	_ = r.outboxRepo.EnsureIndexes(ctx)
 }
 
-// Save persists an inbound shipment with its domain events in a single transaction
+// Save persists an inbound shipment with its domain events in a single transaction. The
+// write only succeeds if the stored shipment still has the version that was loaded.
 func (r *InboundShipmentRepository) Save(ctx context.Context, shipment *domain.InboundShipment) error {
 	shipment.UpdatedAt = time.Now()
+	expectedVersion := shipment.Version
+	shipment.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -69,11 +73,12 @@ func (r *InboundShipmentRepository) Save(ctx context.Context, shipment *domain.I
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"shipmentId": shipment.ShipmentID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"shipmentId": shipment.ShipmentID}, expectedVersion)
 		update := bson.M{"$set": shipment}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "InboundShipment", shipment.ShipmentID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save inbound shipment: %w", err)
 		}
 
@@ -131,6 +136,7 @@ func (r *InboundShipmentRepository) Save(ctx context.Context, shipment *domain.I
 	})
 
 	if err != nil {
+		shipment.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
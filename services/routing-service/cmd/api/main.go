@@ -24,6 +24,7 @@ import (
 
 	"github.com/wms-platform/routing-service/internal/application"
 	"github.com/wms-platform/routing-service/internal/domain"
+	"github.com/wms-platform/routing-service/internal/infrastructure/layout"
 	mongoRepo "github.com/wms-platform/routing-service/internal/infrastructure/mongodb"
 )
 
@@ -119,8 +120,21 @@ func main() {
 	defer outboxPublisher.Stop()
 	logger.Info("Outbox publisher started")
 
-	// Initialize route calculator (nil for warehouse layout and inventory locator for now)
-	routeCalculator := application.NewRouteCalculator(repo, nil, nil)
+	// Initialize warehouse layout graph (straight-line travel when no layout file is configured)
+	var warehouseLayout domain.WarehouseLayout
+	if config.LayoutFile != "" {
+		graphLayout, err := layout.LoadGraphWarehouseLayout(config.LayoutFile)
+		if err != nil {
+			logger.WithError(err).Error("Failed to load warehouse layout", "file", config.LayoutFile)
+			os.Exit(1)
+		}
+		graphLayout.SetLogger(logger.Logger)
+		warehouseLayout = graphLayout
+		logger.Info("Warehouse layout loaded", "file", config.LayoutFile, "nodes", graphLayout.Graph().Nodes())
+	}
+
+	// Initialize route calculator (nil inventory locator for now)
+	routeCalculator := application.NewRouteCalculator(repo, warehouseLayout, nil)
 
 	// Initialize application service
 	routingService := application.NewRoutingApplicationService(
@@ -207,6 +221,7 @@ func main() {
 		{
 			analysis.GET("/route/:routeId", analyzeRouteHandler(routingService, logger))
 			analysis.POST("/suggest-strategy", suggestStrategyHandler(routingService, logger))
+			analysis.POST("/travel-distances", travelDistancesHandler(routingService, logger))
 		}
 	}
 
@@ -247,6 +262,7 @@ type Config struct {
 	ServerAddr string
 	MongoDB    *mongodb.Config
 	Kafka      *kafka.Config
+	LayoutFile string
 }
 
 func loadConfig() *Config {
@@ -267,6 +283,7 @@ func loadConfig() *Config {
 			BatchTimeout:  10 * time.Millisecond,
 			RequiredAcks:  -1,
 		},
+		LayoutFile: getEnv("WAREHOUSE_LAYOUT_FILE", ""),
 	}
 }
 
@@ -744,3 +761,31 @@ func suggestStrategyHandler(service *application.RoutingApplicationService, logg
 		c.JSON(http.StatusOK, gin.H{"strategy": strategy})
 	}
 }
+
+func travelDistancesHandler(service *application.RoutingApplicationService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		var req struct {
+			Locations []domain.Location `json:"locations" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		query := application.TravelDistancesQuery{Locations: req.Locations}
+
+		distances, err := service.CalculateTravelDistances(c.Request.Context(), query)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"distances": distances})
+	}
+}
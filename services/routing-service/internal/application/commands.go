@@ -95,6 +95,11 @@ type SuggestStrategyQuery struct {
 	Items []domain.RouteItem
 }
 
+// TravelDistancesQuery measures travel from the pick start to storage locations
+type TravelDistancesQuery struct {
+	Locations []domain.Location
+}
+
 // CalculateMultiRouteCommand calculates multiple routes for an order
 // Supports zone-based splitting and capacity limits
 type CalculateMultiRouteCommand struct {
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/wms-platform/routing-service/internal/domain"
@@ -129,7 +130,7 @@ func (c *RouteCalculator) CalculateRoutes(ctx context.Context, request domain.Ro
 		}
 
 		// Optimize route
-		if err := route.OptimizeRoute(startLoc, endLoc); err != nil {
+		if err := route.OptimizeRouteWithModel(startLoc, endLoc, c.travelModel(ctx)); err != nil {
 			return nil, fmt.Errorf("failed to optimize route %d: %w", i, err)
 		}
 
@@ -226,7 +227,7 @@ func (c *RouteCalculator) CalculateRoute(ctx context.Context, request domain.Rou
 	}
 
 	// Optimize route
-	if err := route.OptimizeRoute(startLoc, endLoc); err != nil {
+	if err := route.OptimizeRouteWithModel(startLoc, endLoc, c.travelModel(ctx)); err != nil {
 		return nil, fmt.Errorf("failed to optimize route: %w", err)
 	}
 
@@ -267,7 +268,7 @@ func (c *RouteCalculator) RecalculateRoute(ctx context.Context, route *domain.Pi
 	}
 
 	// Optimize from current position
-	if err := newRoute.OptimizeRoute(currentLoc, route.EndLocation); err != nil {
+	if err := newRoute.OptimizeRouteWithModel(currentLoc, route.EndLocation, c.travelModel(ctx)); err != nil {
 		return nil, err
 	}
 
@@ -378,7 +379,7 @@ func (c *RouteCalculator) AnalyzeRouteEfficiency(ctx context.Context, route *dom
 	}
 
 	// Estimate efficiency compared to naive approach
-	naiveDistance := estimateNaiveDistance(route.Stops)
+	naiveDistance := estimateNaiveDistance(c.travelModel(ctx), route)
 	if naiveDistance > 0 {
 		analysis.EfficiencyGain = (naiveDistance - route.EstimatedDistance) / naiveDistance * 100
 	}
@@ -400,6 +401,41 @@ type RouteAnalysis struct {
 	EfficiencyGain    float64                `json:"efficiencyGain"` // percentage
 }
 
+// LocationDistance is the travel from a zone's pick start to a storage location
+type LocationDistance struct {
+	LocationID string        `json:"locationId"`
+	Zone       string        `json:"zone"`
+	Distance   float64       `json:"distance"` // meters
+	TravelTime time.Duration `json:"travelTime"`
+}
+
+// DistancesFromPickStart measures, with the layout's travel model, how far each location
+// is from the pick start of its zone. Slotting uses this to weigh where fast movers live.
+func (c *RouteCalculator) DistancesFromPickStart(ctx context.Context, locations []domain.Location) []LocationDistance {
+	model := c.travelModel(ctx)
+	starts := make(map[string]domain.Location)
+
+	distances := make([]LocationDistance, 0, len(locations))
+	for _, loc := range locations {
+		if loc.Zone == "" {
+			loc.Zone = domain.GetZoneForAisle(loc.Aisle)
+		}
+		start, ok := starts[loc.Zone]
+		if !ok && c.warehouseLayout != nil {
+			start = c.warehouseLayout.GetPickStartLocation(ctx, loc.Zone)
+			starts[loc.Zone] = start
+		}
+
+		distances = append(distances, LocationDistance{
+			LocationID: loc.LocationID,
+			Zone:       loc.Zone,
+			Distance:   model.Distance(start, loc),
+			TravelTime: model.TravelTime(start, loc),
+		})
+	}
+	return distances
+}
+
 // Helper functions
 
 func generateRouteID(orderID string) string {
@@ -416,18 +452,47 @@ func sortRoutesByTime(routes []*domain.PickRoute) {
 	}
 }
 
-func estimateNaiveDistance(stops []domain.RouteStop) float64 {
-	if len(stops) <= 1 {
+// estimateNaiveDistance estimates the travel distance of an unoptimized route that
+// visits the stops in location ID order
+func estimateNaiveDistance(model domain.TravelModel, route *domain.PickRoute) float64 {
+	if len(route.Stops) <= 1 {
 		return 0
 	}
 
-	// Naive approach: visit stops in original order
-	totalDistance := 0.0
+	stops := make([]domain.RouteStop, len(route.Stops))
+	copy(stops, route.Stops)
+	sort.Slice(stops, func(i, j int) bool {
+		return stops[i].Location.LocationID < stops[j].Location.LocationID
+	})
+
+	totalDistance := model.Distance(route.StartLocation, stops[0].Location)
 	for i := 0; i < len(stops)-1; i++ {
-		dx := stops[i+1].Location.X - stops[i].Location.X
-		dy := stops[i+1].Location.Y - stops[i].Location.Y
-		totalDistance += (dx*dx + dy*dy)
+		totalDistance += model.Distance(stops[i].Location, stops[i+1].Location)
 	}
+	totalDistance += model.Distance(stops[len(stops)-1].Location, route.EndLocation)
 
 	return totalDistance
 }
+
+// travelModel returns the travel model backed by the warehouse layout, falling back
+// to straight-line travel when no layout is configured
+func (c *RouteCalculator) travelModel(ctx context.Context) domain.TravelModel {
+	if c.warehouseLayout == nil {
+		return domain.DefaultTravelModel
+	}
+	return &layoutTravelModel{ctx: ctx, layout: c.warehouseLayout}
+}
+
+// layoutTravelModel adapts a WarehouseLayout to the domain TravelModel
+type layoutTravelModel struct {
+	ctx    context.Context
+	layout domain.WarehouseLayout
+}
+
+func (m *layoutTravelModel) Distance(from, to domain.Location) float64 {
+	return m.layout.GetDistance(m.ctx, from, to)
+}
+
+func (m *layoutTravelModel) TravelTime(from, to domain.Location) time.Duration {
+	return m.layout.GetTravelTime(m.ctx, from, to)
+}
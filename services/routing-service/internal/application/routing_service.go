@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 
 	"github.com/wms-platform/routing-service/internal/domain"
 )
@@ -136,23 +137,14 @@ func (s *RoutingApplicationService) DeleteRoute(ctx context.Context, cmd DeleteR
 
 // StartRoute starts a route
 func (s *RoutingApplicationService) StartRoute(ctx context.Context, cmd StartRouteCommand) (*PickRouteDTO, error) {
-	route, err := s.repo.FindByID(ctx, cmd.RouteID)
+	route, err := s.updateRoute(ctx, cmd.RouteID, func(route *domain.PickRoute) error {
+		if err := route.Start(cmd.PickerID); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to get route: %w", err)
-	}
-
-	if route == nil {
-		return nil, errors.ErrNotFound("route")
-	}
-
-	if err := route.Start(cmd.PickerID); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, route); err != nil {
-		s.logger.WithError(err).Error("Failed to save route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to save route: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -163,23 +155,14 @@ func (s *RoutingApplicationService) StartRoute(ctx context.Context, cmd StartRou
 
 // CompleteStop completes a stop in the route
 func (s *RoutingApplicationService) CompleteStop(ctx context.Context, cmd CompleteStopCommand) (*PickRouteDTO, error) {
-	route, err := s.repo.FindByID(ctx, cmd.RouteID)
+	route, err := s.updateRoute(ctx, cmd.RouteID, func(route *domain.PickRoute) error {
+		if err := route.CompleteStop(cmd.StopNumber, cmd.PickedQty, cmd.ToteID); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to get route: %w", err)
-	}
-
-	if route == nil {
-		return nil, errors.ErrNotFound("route")
-	}
-
-	if err := route.CompleteStop(cmd.StopNumber, cmd.PickedQty, cmd.ToteID); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, route); err != nil {
-		s.logger.WithError(err).Error("Failed to save route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to save route: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -190,23 +173,14 @@ func (s *RoutingApplicationService) CompleteStop(ctx context.Context, cmd Comple
 
 // SkipStop skips a stop in the route
 func (s *RoutingApplicationService) SkipStop(ctx context.Context, cmd SkipStopCommand) (*PickRouteDTO, error) {
-	route, err := s.repo.FindByID(ctx, cmd.RouteID)
+	route, err := s.updateRoute(ctx, cmd.RouteID, func(route *domain.PickRoute) error {
+		if err := route.SkipStop(cmd.StopNumber, cmd.Reason); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to get route: %w", err)
-	}
-
-	if route == nil {
-		return nil, errors.ErrNotFound("route")
-	}
-
-	if err := route.SkipStop(cmd.StopNumber, cmd.Reason); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, route); err != nil {
-		s.logger.WithError(err).Error("Failed to save route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to save route: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Skipped stop", "routeId", cmd.RouteID, "stopNumber", cmd.StopNumber, "reason", cmd.Reason)
@@ -215,23 +189,14 @@ func (s *RoutingApplicationService) SkipStop(ctx context.Context, cmd SkipStopCo
 
 // CompleteRoute completes a route
 func (s *RoutingApplicationService) CompleteRoute(ctx context.Context, cmd CompleteRouteCommand) (*PickRouteDTO, error) {
-	route, err := s.repo.FindByID(ctx, cmd.RouteID)
+	route, err := s.updateRoute(ctx, cmd.RouteID, func(route *domain.PickRoute) error {
+		if err := route.Complete(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to get route: %w", err)
-	}
-
-	if route == nil {
-		return nil, errors.ErrNotFound("route")
-	}
-
-	if err := route.Complete(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, route); err != nil {
-		s.logger.WithError(err).Error("Failed to save route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to save route: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -252,23 +217,14 @@ func (s *RoutingApplicationService) CompleteRoute(ctx context.Context, cmd Compl
 
 // PauseRoute pauses a route
 func (s *RoutingApplicationService) PauseRoute(ctx context.Context, cmd PauseRouteCommand) (*PickRouteDTO, error) {
-	route, err := s.repo.FindByID(ctx, cmd.RouteID)
+	route, err := s.updateRoute(ctx, cmd.RouteID, func(route *domain.PickRoute) error {
+		if err := route.Pause(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to get route: %w", err)
-	}
-
-	if route == nil {
-		return nil, errors.ErrNotFound("route")
-	}
-
-	if err := route.Pause(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, route); err != nil {
-		s.logger.WithError(err).Error("Failed to save route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to save route: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Paused route", "routeId", cmd.RouteID)
@@ -277,23 +233,14 @@ func (s *RoutingApplicationService) PauseRoute(ctx context.Context, cmd PauseRou
 
 // CancelRoute cancels a route
 func (s *RoutingApplicationService) CancelRoute(ctx context.Context, cmd CancelRouteCommand) (*PickRouteDTO, error) {
-	route, err := s.repo.FindByID(ctx, cmd.RouteID)
+	route, err := s.updateRoute(ctx, cmd.RouteID, func(route *domain.PickRoute) error {
+		if err := route.Cancel(cmd.Reason); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to get route: %w", err)
-	}
-
-	if route == nil {
-		return nil, errors.ErrNotFound("route")
-	}
-
-	if err := route.Cancel(cmd.Reason); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, route); err != nil {
-		s.logger.WithError(err).Error("Failed to save route", "routeId", cmd.RouteID)
-		return nil, fmt.Errorf("failed to save route: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -302,6 +249,45 @@ func (s *RoutingApplicationService) CancelRoute(ctx context.Context, cmd CancelR
 	return ToPickRouteDTO(route), nil
 }
 
+// updateRoute loads a route, applies change and saves it, retrying from a fresh load
+// when the route was modified concurrently
+func (s *RoutingApplicationService) updateRoute(ctx context.Context, routeID string, change func(route *domain.PickRoute) error) (*domain.PickRoute, error) {
+	var route *domain.PickRoute
+
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.repo.FindByID(ctx, routeID)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to get route", "routeId", routeID)
+			return fmt.Errorf("failed to get route: %w", err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("route")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				return err
+			}
+			s.logger.WithError(err).Error("Failed to save route", "routeId", routeID)
+			return fmt.Errorf("failed to save route: %w", err)
+		}
+
+		route = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("route %s was modified concurrently, please retry", routeID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return route, nil
+}
+
 // GetRoutesByOrder retrieves routes by order ID
 func (s *RoutingApplicationService) GetRoutesByOrder(ctx context.Context, query GetRoutesByOrderQuery) ([]PickRouteDTO, error) {
 	routes, err := s.repo.FindByOrderID(ctx, query.OrderID)
@@ -408,3 +394,17 @@ func (s *RoutingApplicationService) SuggestStrategy(ctx context.Context, query S
 
 	return strategy, nil
 }
+
+// CalculateTravelDistances measures how far each location is from its zone's pick start
+func (s *RoutingApplicationService) CalculateTravelDistances(ctx context.Context, query TravelDistancesQuery) ([]LocationDistance, error) {
+	if len(query.Locations) == 0 {
+		return nil, errors.ErrValidation("at least one location is required")
+	}
+	for _, loc := range query.Locations {
+		if loc.LocationID == "" {
+			return nil, errors.ErrValidation("location ID is required")
+		}
+	}
+
+	return s.routeCalculator.DistancesFromPickStart(ctx, query.Locations), nil
+}
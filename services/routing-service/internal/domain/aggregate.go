@@ -104,6 +104,7 @@ type PickRoute struct {
 	PickedItems       int                `bson:"pickedItems"`
 	CreatedAt         time.Time          `bson:"createdAt"`
 	UpdatedAt         time.Time          `bson:"updatedAt"`
+	Version           int                `bson:"version"`
 	StartedAt         *time.Time         `bson:"startedAt,omitempty"`
 	CompletedAt       *time.Time         `bson:"completedAt,omitempty"`
 	DomainEvents      []DomainEvent      `bson:"-"`
@@ -233,27 +234,37 @@ func NewMultiRoutePickRoute(routeID, orderID, waveID string, strategy RoutingStr
 	return route, nil
 }
 
-// OptimizeRoute optimizes the stop sequence based on the strategy
+// OptimizeRoute optimizes the stop sequence based on the strategy using straight-line travel
 func (r *PickRoute) OptimizeRoute(startLoc, endLoc Location) error {
+	return r.OptimizeRouteWithModel(startLoc, endLoc, DefaultTravelModel)
+}
+
+// OptimizeRouteWithModel optimizes the stop sequence based on the strategy, measuring
+// travel between stops with the given travel model (e.g. a warehouse layout graph)
+func (r *PickRoute) OptimizeRouteWithModel(startLoc, endLoc Location, model TravelModel) error {
 	if r.Status != RouteStatusPending {
 		return ErrRouteAlreadyStarted
 	}
 
+	if model == nil {
+		model = DefaultTravelModel
+	}
+
 	r.StartLocation = startLoc
 	r.EndLocation = endLoc
 
 	// Apply routing strategy
 	switch r.Strategy {
 	case StrategyReturn:
-		r.optimizeReturn()
+		r.optimizeReturn(model)
 	case StrategySShape:
-		r.optimizeSShape()
+		r.optimizeSShape(model)
 	case StrategyLargestGap:
-		r.optimizeLargestGap()
+		r.optimizeLargestGap(model)
 	case StrategyCombined:
-		r.optimizeCombined()
+		r.optimizeCombined(model)
 	case StrategyNearest:
-		r.optimizeNearest()
+		r.optimizeNearest(model)
 	}
 
 	// Recalculate stop numbers after optimization
@@ -262,8 +273,8 @@ func (r *PickRoute) OptimizeRoute(startLoc, endLoc Location) error {
 	}
 
 	// Calculate estimated distance and time
-	r.EstimatedDistance = r.calculateTotalDistance()
-	r.EstimatedTime = r.calculateEstimatedTime()
+	r.EstimatedDistance = r.calculateTotalDistance(model)
+	r.EstimatedTime = r.calculateEstimatedTime(model)
 
 	r.UpdatedAt = time.Now()
 
@@ -280,26 +291,22 @@ func (r *PickRoute) OptimizeRoute(startLoc, endLoc Location) error {
 
 // optimizeReturn implements the Return Strategy
 // Picker enters aisle, picks items, returns to the front
-func (r *PickRoute) optimizeReturn() {
-	// Group stops by aisle
-	aisleGroups := make(map[string][]RouteStop)
-	for _, stop := range r.Stops {
-		aisleGroups[stop.Location.Aisle] = append(aisleGroups[stop.Location.Aisle], stop)
-	}
+func (r *PickRoute) optimizeReturn(model TravelModel) {
+	aisleGroups := groupStopsByAisle(r.Stops)
 
-	// Sort each aisle by position (depth)
-	for aisle := range aisleGroups {
+	optimized := make([]RouteStop, 0, len(r.Stops))
+	current := r.StartLocation
+
+	for len(aisleGroups) > 0 {
+		// Enter the closest remaining aisle from the front
+		aisle := nearestAisle(model, current, aisleGroups, false)
 		stops := aisleGroups[aisle]
-		sortByRack(stops)
-		aisleGroups[aisle] = stops
-	}
+		delete(aisleGroups, aisle)
 
-	// Build optimized route: process each aisle, return to front
-	optimized := make([]RouteStop, 0, len(r.Stops))
-	aisles := getSortedAisles(aisleGroups)
+		optimized = append(optimized, stops...)
 
-	for _, aisle := range aisles {
-		optimized = append(optimized, aisleGroups[aisle]...)
+		// Picker returns to the aisle entry
+		current = stops[0].Location
 	}
 
 	r.Stops = optimized
@@ -307,26 +314,25 @@ func (r *PickRoute) optimizeReturn() {
 
 // optimizeSShape implements the S-Shape Strategy
 // Picker traverses entire aisle before moving to next
-func (r *PickRoute) optimizeSShape() {
-	// Group stops by aisle
-	aisleGroups := make(map[string][]RouteStop)
-	for _, stop := range r.Stops {
-		aisleGroups[stop.Location.Aisle] = append(aisleGroups[stop.Location.Aisle], stop)
-	}
+func (r *PickRoute) optimizeSShape(model TravelModel) {
+	aisleGroups := groupStopsByAisle(r.Stops)
 
-	// Build S-shape route
 	optimized := make([]RouteStop, 0, len(r.Stops))
-	aisles := getSortedAisles(aisleGroups)
+	current := r.StartLocation
 
-	for i, aisle := range aisles {
+	for len(aisleGroups) > 0 {
+		aisle := nearestAisle(model, current, aisleGroups, true)
 		stops := aisleGroups[aisle]
-		sortByRack(stops)
+		delete(aisleGroups, aisle)
 
-		// Alternate direction for S-shape
-		if i%2 == 1 {
+		// Traverse from whichever end is closer, which alternates direction
+		// between aisles and honours one-way aisles on a layout graph
+		if model.Distance(current, stops[len(stops)-1].Location) < model.Distance(current, stops[0].Location) {
 			reverse(stops)
 		}
 		optimized = append(optimized, stops...)
+
+		current = stops[len(stops)-1].Location
 	}
 
 	r.Stops = optimized
@@ -334,46 +340,47 @@ func (r *PickRoute) optimizeSShape() {
 
 // optimizeLargestGap implements the Largest Gap Strategy
 // Minimizes travel by avoiding large gaps between picks
-func (r *PickRoute) optimizeLargestGap() {
-	// Group stops by aisle
-	aisleGroups := make(map[string][]RouteStop)
-	for _, stop := range r.Stops {
-		aisleGroups[stop.Location.Aisle] = append(aisleGroups[stop.Location.Aisle], stop)
-	}
+func (r *PickRoute) optimizeLargestGap(model TravelModel) {
+	aisleGroups := groupStopsByAisle(r.Stops)
 
 	optimized := make([]RouteStop, 0, len(r.Stops))
-	aisles := getSortedAisles(aisleGroups)
+	current := r.StartLocation
 
-	for _, aisle := range aisles {
+	for len(aisleGroups) > 0 {
+		aisle := nearestAisle(model, current, aisleGroups, false)
 		stops := aisleGroups[aisle]
-		sortByRack(stops)
+		delete(aisleGroups, aisle)
 
 		if len(stops) <= 1 {
 			optimized = append(optimized, stops...)
+			current = stops[0].Location
 			continue
 		}
 
-		// Find largest gap
-		maxGap := 0
+		// Find largest gap in travel distance between adjacent picks
+		maxGap := 0.0
 		maxGapIndex := -1
+		span := 0.0
 		for i := 0; i < len(stops)-1; i++ {
-			gap := stops[i+1].Location.Rack - stops[i].Location.Rack
+			gap := model.Distance(stops[i].Location, stops[i+1].Location)
+			span += gap
 			if gap > maxGap {
 				maxGap = gap
 				maxGapIndex = i
 			}
 		}
 
-		// If largest gap is significant, split the aisle
-		if maxGapIndex > 0 && maxGap > 3 {
-			// Pick from front to gap
+		// If the largest gap covers more than the rest of the aisle, skip it:
+		// pick from the front up to the gap, then the remainder from the back
+		if maxGapIndex >= 0 && maxGap > span-maxGap {
 			optimized = append(optimized, stops[:maxGapIndex+1]...)
-			// Reverse remaining and append
 			remaining := stops[maxGapIndex+1:]
 			reverse(remaining)
 			optimized = append(optimized, remaining...)
+			current = stops[0].Location
 		} else {
 			optimized = append(optimized, stops...)
+			current = stops[len(stops)-1].Location
 		}
 	}
 
@@ -381,26 +388,39 @@ func (r *PickRoute) optimizeLargestGap() {
 }
 
 // optimizeCombined implements a hybrid strategy
-func (r *PickRoute) optimizeCombined() {
-	// Analyze item density per aisle
-	aisleGroups := make(map[string][]RouteStop)
-	for _, stop := range r.Stops {
-		aisleGroups[stop.Location.Aisle] = append(aisleGroups[stop.Location.Aisle], stop)
-	}
+// Each aisle is either fully traversed or entered and returned, whichever travels less
+func (r *PickRoute) optimizeCombined(model TravelModel) {
+	aisleGroups := groupStopsByAisle(r.Stops)
 
 	optimized := make([]RouteStop, 0, len(r.Stops))
-	aisles := getSortedAisles(aisleGroups)
+	current := r.StartLocation
 
-	for _, aisle := range aisles {
+	for len(aisleGroups) > 0 {
+		aisle := nearestAisle(model, current, aisleGroups, true)
 		stops := aisleGroups[aisle]
-		sortByRack(stops)
+		delete(aisleGroups, aisle)
 
-		// Use S-shape for aisles with many items, return for few items
-		if len(stops) > 3 {
-			optimized = append(optimized, stops...)
+		if model.Distance(current, stops[len(stops)-1].Location) < model.Distance(current, stops[0].Location) {
+			reverse(stops)
+		}
+		optimized = append(optimized, stops...)
+
+		entry := stops[0].Location
+		exit := stops[len(stops)-1].Location
+		if len(aisleGroups) == 0 {
+			break
+		}
+
+		// Compare continuing from the far end (traverse) against walking back to the entry (return)
+		traverseNext := nearestAisle(model, exit, aisleGroups, true)
+		returnNext := nearestAisle(model, entry, aisleGroups, true)
+		traverseCost := nearestEndDistance(model, exit, aisleGroups[traverseNext])
+		returnCost := model.Distance(exit, entry) + nearestEndDistance(model, entry, aisleGroups[returnNext])
+
+		if returnCost < traverseCost {
+			current = entry
 		} else {
-			// Return strategy - pick and return
-			optimized = append(optimized, stops...)
+			current = exit
 		}
 	}
 
@@ -408,7 +428,7 @@ func (r *PickRoute) optimizeCombined() {
 }
 
 // optimizeNearest implements nearest neighbor algorithm
-func (r *PickRoute) optimizeNearest() {
+func (r *PickRoute) optimizeNearest(model TravelModel) {
 	if len(r.Stops) <= 1 {
 		return
 	}
@@ -418,7 +438,7 @@ func (r *PickRoute) optimizeNearest() {
 	copy(remaining, r.Stops)
 
 	// Start from start location
-	currentX, currentY := r.StartLocation.X, r.StartLocation.Y
+	current := r.StartLocation
 
 	for len(remaining) > 0 {
 		// Find nearest stop
@@ -426,7 +446,7 @@ func (r *PickRoute) optimizeNearest() {
 		nearestDist := math.MaxFloat64
 
 		for i, stop := range remaining {
-			dist := distance(currentX, currentY, stop.Location.X, stop.Location.Y)
+			dist := model.Distance(current, stop.Location)
 			if dist < nearestDist {
 				nearestDist = dist
 				nearestIdx = i
@@ -438,7 +458,7 @@ func (r *PickRoute) optimizeNearest() {
 		optimized = append(optimized, nearest)
 
 		// Update current position
-		currentX, currentY = nearest.Location.X, nearest.Location.Y
+		current = nearest.Location
 
 		// Remove from remaining
 		remaining = append(remaining[:nearestIdx], remaining[nearestIdx+1:]...)
@@ -618,44 +638,45 @@ func (r *PickRoute) GetCurrentStop() *RouteStop {
 }
 
 // calculateTotalDistance calculates the total route distance
-func (r *PickRoute) calculateTotalDistance() float64 {
+func (r *PickRoute) calculateTotalDistance(model TravelModel) float64 {
 	if len(r.Stops) == 0 {
 		return 0
 	}
 
 	totalDistance := 0.0
+	for _, leg := range r.legs() {
+		totalDistance += model.Distance(leg[0], leg[1])
+	}
 
-	// Distance from start to first stop
-	totalDistance += distance(
-		r.StartLocation.X, r.StartLocation.Y,
-		r.Stops[0].Location.X, r.Stops[0].Location.Y,
-	)
+	return totalDistance
+}
 
-	// Distance between stops
-	for i := 0; i < len(r.Stops)-1; i++ {
-		totalDistance += distance(
-			r.Stops[i].Location.X, r.Stops[i].Location.Y,
-			r.Stops[i+1].Location.X, r.Stops[i+1].Location.Y,
-		)
+// calculateEstimatedTime estimates time based on travel time and items
+func (r *PickRoute) calculateEstimatedTime(model TravelModel) time.Duration {
+	// Assume 10 seconds per pick
+	var walkingTime time.Duration
+	for _, leg := range r.legs() {
+		walkingTime += model.TravelTime(leg[0], leg[1])
 	}
+	pickTime := time.Duration(r.TotalItems) * 10 * time.Second
 
-	// Distance from last stop to end
-	lastStop := r.Stops[len(r.Stops)-1]
-	totalDistance += distance(
-		lastStop.Location.X, lastStop.Location.Y,
-		r.EndLocation.X, r.EndLocation.Y,
-	)
-
-	return totalDistance
+	return (walkingTime + pickTime).Round(time.Second)
 }
 
-// calculateEstimatedTime estimates time based on distance and items
-func (r *PickRoute) calculateEstimatedTime() time.Duration {
-	// Assume walking speed of 1.2 m/s and 10 seconds per pick
-	walkingTime := r.EstimatedDistance / 1.2
-	pickTime := float64(r.TotalItems) * 10
+// legs returns the consecutive from/to pairs of the route: start, each stop, end
+func (r *PickRoute) legs() [][2]Location {
+	if len(r.Stops) == 0 {
+		return nil
+	}
+
+	legs := make([][2]Location, 0, len(r.Stops)+1)
+	legs = append(legs, [2]Location{r.StartLocation, r.Stops[0].Location})
+	for i := 0; i < len(r.Stops)-1; i++ {
+		legs = append(legs, [2]Location{r.Stops[i].Location, r.Stops[i+1].Location})
+	}
+	legs = append(legs, [2]Location{r.Stops[len(r.Stops)-1].Location, r.EndLocation})
 
-	return time.Duration(walkingTime+pickTime) * time.Second
+	return legs
 }
 
 // AddDomainEvent adds a domain event
@@ -700,6 +721,48 @@ func sortByRack(stops []RouteStop) {
 	}
 }
 
+// groupStopsByAisle groups stops by aisle, each aisle sorted front to back by rack
+func groupStopsByAisle(stops []RouteStop) map[string][]RouteStop {
+	aisleGroups := make(map[string][]RouteStop)
+	for _, stop := range stops {
+		aisleGroups[stop.Location.Aisle] = append(aisleGroups[stop.Location.Aisle], stop)
+	}
+	for aisle := range aisleGroups {
+		sortByRack(aisleGroups[aisle])
+	}
+	return aisleGroups
+}
+
+// nearestAisle returns the remaining aisle closest to the current position.
+// When eitherEnd is false only the aisle front (lowest rack) is considered.
+// Ties are broken by aisle name for deterministic routes.
+func nearestAisle(model TravelModel, current Location, groups map[string][]RouteStop, eitherEnd bool) string {
+	nearest := ""
+	nearestDist := math.MaxFloat64
+
+	for _, aisle := range getSortedAisles(groups) {
+		stops := groups[aisle]
+		dist := model.Distance(current, stops[0].Location)
+		if eitherEnd {
+			dist = nearestEndDistance(model, current, stops)
+		}
+		if dist < nearestDist {
+			nearestDist = dist
+			nearest = aisle
+		}
+	}
+
+	return nearest
+}
+
+// nearestEndDistance returns the travel distance to the closer end of an aisle's stops
+func nearestEndDistance(model TravelModel, current Location, stops []RouteStop) float64 {
+	return math.Min(
+		model.Distance(current, stops[0].Location),
+		model.Distance(current, stops[len(stops)-1].Location),
+	)
+}
+
 func reverse(stops []RouteStop) {
 	for i, j := 0, len(stops)-1; i < j; i, j = i+1, j-1 {
 		stops[i], stops[j] = stops[j], stops[i]
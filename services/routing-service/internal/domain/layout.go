@@ -0,0 +1,394 @@
+package domain
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Layout errors
+var (
+	ErrLayoutEmpty       = errors.New("layout must have at least one node and one segment")
+	ErrLayoutUnknownNode = errors.New("segment references an unknown node")
+	ErrLayoutDuplicateID = errors.New("duplicate layout node id")
+	ErrLayoutBadSpeed    = errors.New("travel speed must be greater than zero")
+)
+
+// DefaultWalkingSpeed is the walking speed used when no zone speed is configured (m/s)
+const DefaultWalkingSpeed = 1.2
+
+// SegmentKind classifies a travel segment in the warehouse layout
+type SegmentKind string
+
+const (
+	SegmentKindAisle      SegmentKind = "aisle"       // Pick aisle between two rack faces
+	SegmentKindCrossAisle SegmentKind = "cross_aisle" // Cross-aisle connecting pick aisles
+	SegmentKindMainAisle  SegmentKind = "main_aisle"  // Main travel aisle / front or back of the zone
+)
+
+// TravelModel computes travel distance and time between two warehouse locations
+type TravelModel interface {
+	// Distance returns the travel distance in meters
+	Distance(from, to Location) float64
+
+	// TravelTime returns the expected travel time
+	TravelTime(from, to Location) time.Duration
+}
+
+// EuclideanTravelModel measures straight-line distance at a constant walking speed.
+// It is used when no warehouse layout graph is configured.
+type EuclideanTravelModel struct {
+	SpeedMps float64
+}
+
+// DefaultTravelModel is the straight-line travel model at the default walking speed
+var DefaultTravelModel TravelModel = EuclideanTravelModel{SpeedMps: DefaultWalkingSpeed}
+
+// Distance returns the straight-line distance between two locations
+func (m EuclideanTravelModel) Distance(from, to Location) float64 {
+	return distance(from.X, from.Y, to.X, to.Y)
+}
+
+// TravelTime returns the straight-line travel time between two locations
+func (m EuclideanTravelModel) TravelTime(from, to Location) time.Duration {
+	speed := m.SpeedMps
+	if speed <= 0 {
+		speed = DefaultWalkingSpeed
+	}
+	return secondsToDuration(m.Distance(from, to) / speed)
+}
+
+// LayoutNode is a waypoint in the warehouse travel graph (aisle end, intersection, dock, etc.)
+type LayoutNode struct {
+	NodeID string  `json:"nodeId"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Zone   string  `json:"zone,omitempty"`
+}
+
+// LayoutSegment is a travelable segment between two layout nodes.
+// One-way segments may only be travelled from From to To.
+type LayoutSegment struct {
+	From    string      `json:"from"`
+	To      string      `json:"to"`
+	Kind    SegmentKind `json:"kind"`
+	Aisle   string      `json:"aisle,omitempty"`
+	Zone    string      `json:"zone,omitempty"`
+	OneWay  bool        `json:"oneWay,omitempty"`
+	Blocked bool        `json:"blocked,omitempty"`
+}
+
+// LayoutGraph is a warehouse travel graph made of aisles, cross-aisles and main aisles.
+// Locations are attached to the closest segment of their aisle (the rack face they sit on),
+// and travel between locations follows the shortest path over unblocked segments while
+// honouring one-way restrictions and per-zone travel speeds.
+type LayoutGraph struct {
+	nodes        map[string]LayoutNode
+	segments     []LayoutSegment
+	adjacency    map[string][]graphArc
+	zoneSpeeds   map[string]float64
+	defaultSpeed float64
+}
+
+// graphArc is a directed, travelable arc derived from a layout segment
+type graphArc struct {
+	to       string
+	length   float64
+	duration float64 // seconds
+}
+
+// NewLayoutGraph builds a travel graph from nodes, segments and per-zone speeds (m/s)
+func NewLayoutGraph(nodes []LayoutNode, segments []LayoutSegment, zoneSpeeds map[string]float64, defaultSpeed float64) (*LayoutGraph, error) {
+	if len(nodes) == 0 || len(segments) == 0 {
+		return nil, ErrLayoutEmpty
+	}
+
+	if defaultSpeed == 0 {
+		defaultSpeed = DefaultWalkingSpeed
+	}
+	if defaultSpeed < 0 {
+		return nil, ErrLayoutBadSpeed
+	}
+
+	g := &LayoutGraph{
+		nodes:        make(map[string]LayoutNode, len(nodes)),
+		segments:     make([]LayoutSegment, 0, len(segments)),
+		adjacency:    make(map[string][]graphArc, len(nodes)),
+		zoneSpeeds:   make(map[string]float64, len(zoneSpeeds)),
+		defaultSpeed: defaultSpeed,
+	}
+
+	for zone, speed := range zoneSpeeds {
+		if speed <= 0 {
+			return nil, fmt.Errorf("zone %s: %w", zone, ErrLayoutBadSpeed)
+		}
+		g.zoneSpeeds[zone] = speed
+	}
+
+	for _, node := range nodes {
+		if _, exists := g.nodes[node.NodeID]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrLayoutDuplicateID, node.NodeID)
+		}
+		g.nodes[node.NodeID] = node
+	}
+
+	for _, seg := range segments {
+		from, okFrom := g.nodes[seg.From]
+		to, okTo := g.nodes[seg.To]
+		if !okFrom || !okTo {
+			return nil, fmt.Errorf("%w: %s -> %s", ErrLayoutUnknownNode, seg.From, seg.To)
+		}
+		if seg.Zone == "" {
+			seg.Zone = from.Zone
+		}
+		g.segments = append(g.segments, seg)
+
+		if seg.Blocked {
+			continue
+		}
+
+		length := distance(from.X, from.Y, to.X, to.Y)
+		duration := length / g.speedFor(seg.Zone)
+		g.adjacency[seg.From] = append(g.adjacency[seg.From], graphArc{to: seg.To, length: length, duration: duration})
+		if !seg.OneWay {
+			g.adjacency[seg.To] = append(g.adjacency[seg.To], graphArc{to: seg.From, length: length, duration: duration})
+		}
+	}
+
+	return g, nil
+}
+
+// Nodes returns the number of nodes in the graph
+func (g *LayoutGraph) Nodes() int {
+	return len(g.nodes)
+}
+
+// Segments returns a copy of the layout segments
+func (g *LayoutGraph) Segments() []LayoutSegment {
+	segments := make([]LayoutSegment, len(g.segments))
+	copy(segments, g.segments)
+	return segments
+}
+
+// SpeedForZone returns the configured travel speed for a zone (m/s)
+func (g *LayoutGraph) SpeedForZone(zone string) float64 {
+	return g.speedFor(zone)
+}
+
+// ShortestPath returns the travel distance (m) and time along the shortest path between
+// two locations. When either location cannot be attached to the graph or no path exists
+// it returns the straight-line distance and time at the default speed, and false.
+func (g *LayoutGraph) ShortestPath(from, to Location) (float64, time.Duration, bool) {
+	length, seconds, ok := g.shortestPath(from, to)
+	if !ok {
+		length = distance(from.X, from.Y, to.X, to.Y)
+		return length, secondsToDuration(length / g.defaultSpeed), false
+	}
+	return length, secondsToDuration(seconds), true
+}
+
+// Distance returns the shortest travel distance between two locations.
+// Falls back to straight-line distance when either location cannot be attached
+// to the graph or no path exists.
+func (g *LayoutGraph) Distance(from, to Location) float64 {
+	length, _, _ := g.ShortestPath(from, to)
+	return length
+}
+
+// TravelTime returns the travel time along the shortest path between two locations
+func (g *LayoutGraph) TravelTime(from, to Location) time.Duration {
+	_, travelTime, _ := g.ShortestPath(from, to)
+	return travelTime
+}
+
+// attachment describes how a location connects onto a layout segment
+type attachment struct {
+	segment  LayoutSegment
+	fraction float64 // position along the segment, 0 = From, 1 = To
+	lateral  float64 // distance from the location to the segment (rack face offset)
+	length   float64 // segment length
+	speed    float64
+}
+
+// shortestPath returns the distance (m) and time (s) of the shortest path between two locations
+func (g *LayoutGraph) shortestPath(from, to Location) (float64, float64, bool) {
+	src, okSrc := g.attach(from)
+	dst, okDst := g.attach(to)
+	if !okSrc || !okDst {
+		return 0, 0, false
+	}
+
+	lateralLength := src.lateral + dst.lateral
+	lateralTime := src.lateral/src.speed + dst.lateral/dst.speed
+
+	bestLength := math.Inf(1)
+	bestTime := math.Inf(1)
+
+	// Both locations sit on the same segment: travel directly along it if allowed
+	if src.segment == dst.segment {
+		if !src.segment.OneWay || dst.fraction >= src.fraction {
+			along := math.Abs(dst.fraction-src.fraction) * src.length
+			bestLength = along
+			bestTime = along / src.speed
+		}
+	}
+
+	// Leave the source segment through either end, then search the graph
+	starts := make(map[string][2]float64, 2)
+	starts[src.segment.To] = [2]float64{(1 - src.fraction) * src.length, (1 - src.fraction) * src.length / src.speed}
+	if !src.segment.OneWay {
+		back := src.fraction * src.length
+		if cur, ok := starts[src.segment.From]; !ok || back < cur[0] {
+			starts[src.segment.From] = [2]float64{back, back / src.speed}
+		}
+	}
+
+	lengths, times := g.dijkstra(starts)
+
+	// Enter the destination segment through either end
+	if l, ok := lengths[dst.segment.From]; ok {
+		enter := dst.fraction * dst.length
+		if l+enter < bestLength {
+			bestLength = l + enter
+			bestTime = times[dst.segment.From] + enter/dst.speed
+		}
+	}
+	if !dst.segment.OneWay {
+		if l, ok := lengths[dst.segment.To]; ok {
+			enter := (1 - dst.fraction) * dst.length
+			if l+enter < bestLength {
+				bestLength = l + enter
+				bestTime = times[dst.segment.To] + enter/dst.speed
+			}
+		}
+	}
+
+	if math.IsInf(bestLength, 1) {
+		return 0, 0, false
+	}
+
+	return bestLength + lateralLength, bestTime + lateralTime, true
+}
+
+// attach finds the segment a location sits on. Segments in the location's aisle are
+// preferred; otherwise the nearest unblocked segment is used.
+func (g *LayoutGraph) attach(loc Location) (attachment, bool) {
+	best := attachment{lateral: math.Inf(1)}
+	bestInAisle := false
+	found := false
+
+	for _, seg := range g.segments {
+		if seg.Blocked {
+			continue
+		}
+
+		inAisle := loc.Aisle != "" && seg.Aisle == loc.Aisle
+		if bestInAisle && !inAisle {
+			continue
+		}
+
+		from := g.nodes[seg.From]
+		to := g.nodes[seg.To]
+		fraction, lateral, length := projectOntoSegment(loc.X, loc.Y, from, to)
+
+		if (inAisle && !bestInAisle) || lateral < best.lateral {
+			best = attachment{
+				segment:  seg,
+				fraction: fraction,
+				lateral:  lateral,
+				length:   length,
+				speed:    g.speedFor(seg.Zone),
+			}
+			bestInAisle = inAisle
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// dijkstra computes the shortest distances (and the travel time along those paths)
+// from a set of start nodes with initial costs
+func (g *LayoutGraph) dijkstra(starts map[string][2]float64) (map[string]float64, map[string]float64) {
+	lengths := make(map[string]float64, len(g.nodes))
+	times := make(map[string]float64, len(g.nodes))
+	visited := make(map[string]bool, len(g.nodes))
+
+	pq := &nodeQueue{}
+	for nodeID, cost := range starts {
+		lengths[nodeID] = cost[0]
+		times[nodeID] = cost[1]
+		heap.Push(pq, queuedNode{nodeID: nodeID, length: cost[0]})
+	}
+
+	for pq.Len() > 0 {
+		current := heap.Pop(pq).(queuedNode)
+		if visited[current.nodeID] {
+			continue
+		}
+		visited[current.nodeID] = true
+
+		for _, arc := range g.adjacency[current.nodeID] {
+			candidate := lengths[current.nodeID] + arc.length
+			if existing, ok := lengths[arc.to]; !ok || candidate < existing {
+				lengths[arc.to] = candidate
+				times[arc.to] = times[current.nodeID] + arc.duration
+				heap.Push(pq, queuedNode{nodeID: arc.to, length: candidate})
+			}
+		}
+	}
+
+	return lengths, times
+}
+
+func (g *LayoutGraph) speedFor(zone string) float64 {
+	if speed, ok := g.zoneSpeeds[zone]; ok {
+		return speed
+	}
+	return g.defaultSpeed
+}
+
+// projectOntoSegment projects a point onto a segment and returns the position along
+// the segment (0..1), the perpendicular distance and the segment length
+func projectOntoSegment(x, y float64, from, to LayoutNode) (float64, float64, float64) {
+	dx := to.X - from.X
+	dy := to.Y - from.Y
+	length := math.Sqrt(dx*dx + dy*dy)
+	if length == 0 {
+		return 0, distance(x, y, from.X, from.Y), 0
+	}
+
+	fraction := ((x-from.X)*dx + (y-from.Y)*dy) / (length * length)
+	fraction = math.Max(0, math.Min(1, fraction))
+
+	px := from.X + fraction*dx
+	py := from.Y + fraction*dy
+
+	return fraction, distance(x, y, px, py), length
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// queuedNode is a priority queue entry for Dijkstra's algorithm
+type queuedNode struct {
+	nodeID string
+	length float64
+}
+
+type nodeQueue []queuedNode
+
+func (q nodeQueue) Len() int            { return len(q) }
+func (q nodeQueue) Less(i, j int) bool  { return q[i].length < q[j].length }
+func (q nodeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *nodeQueue) Push(x interface{}) { *q = append(*q, x.(queuedNode)) }
+func (q *nodeQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	*q = old[:n-1]
+	return item
+}
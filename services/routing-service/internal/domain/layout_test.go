@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestLayout builds two 20m aisles (A at x=0, B at x=10) joined by a
+// front cross-aisle at y=0 and a back cross-aisle at y=20
+func createTestLayout(t *testing.T, aisleAOneWay, backBlocked bool, zoneSpeeds map[string]float64) *LayoutGraph {
+	nodes := []LayoutNode{
+		{NodeID: "A-FRONT", X: 0, Y: 0, Zone: "ZONE-1"},
+		{NodeID: "A-BACK", X: 0, Y: 20, Zone: "ZONE-1"},
+		{NodeID: "B-FRONT", X: 10, Y: 0, Zone: "ZONE-1"},
+		{NodeID: "B-BACK", X: 10, Y: 20, Zone: "ZONE-1"},
+	}
+	segments := []LayoutSegment{
+		{From: "A-FRONT", To: "A-BACK", Kind: SegmentKindAisle, Aisle: "A", OneWay: aisleAOneWay},
+		{From: "B-FRONT", To: "B-BACK", Kind: SegmentKindAisle, Aisle: "B"},
+		{From: "A-FRONT", To: "B-FRONT", Kind: SegmentKindCrossAisle},
+		{From: "A-BACK", To: "B-BACK", Kind: SegmentKindCrossAisle, Blocked: backBlocked},
+	}
+
+	graph, err := NewLayoutGraph(nodes, segments, zoneSpeeds, 0)
+	require.NoError(t, err)
+	return graph
+}
+
+// TestLayoutGraphDistance tests shortest-path travel over the layout graph
+func TestLayoutGraphDistance(t *testing.T) {
+	a5 := createTestLocation("A-05-1-A", "A", 5, 1, 0, 5)
+	a15 := createTestLocation("A-15-1-A", "A", 15, 1, 0, 15)
+	b15 := createTestLocation("B-15-1-A", "B", 15, 1, 10, 15)
+
+	tests := []struct {
+		name         string
+		aisleAOneWay bool
+		backBlocked  bool
+		from         Location
+		to           Location
+		expected     float64
+	}{
+		{"Same aisle", false, false, a5, a15, 10},
+		{"Adjacent aisles via back cross-aisle", false, false, a15, b15, 20},
+		{"Back cross-aisle blocked", false, true, a15, b15, 40},
+		{"One-way aisle with travel direction", true, false, a5, a15, 10},
+		{"One-way aisle against travel direction", true, false, a15, a5, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := createTestLayout(t, tt.aisleAOneWay, tt.backBlocked, nil)
+			assert.InDelta(t, tt.expected, graph.Distance(tt.from, tt.to), 0.001)
+		})
+	}
+}
+
+// TestLayoutGraphRackFaceOffset tests that locations off the aisle centerline add lateral travel
+func TestLayoutGraphRackFaceOffset(t *testing.T) {
+	graph := createTestLayout(t, false, false, nil)
+
+	from := createTestLocation("A-05-1-L", "A", 5, 1, -1, 5)
+	to := createTestLocation("A-15-1-R", "A", 15, 1, 1, 15)
+
+	assert.InDelta(t, 12.0, graph.Distance(from, to), 0.001)
+}
+
+// TestLayoutGraphTravelTime tests per-zone travel speeds
+func TestLayoutGraphTravelTime(t *testing.T) {
+	graph := createTestLayout(t, false, false, map[string]float64{"ZONE-1": 0.5})
+
+	from := createTestLocation("A-05-1-A", "A", 5, 1, 0, 5)
+	to := createTestLocation("A-15-1-A", "A", 15, 1, 0, 15)
+
+	assert.Equal(t, 20*time.Second, graph.TravelTime(from, to))
+	assert.Equal(t, 0.5, graph.SpeedForZone("ZONE-1"))
+	assert.Equal(t, DefaultWalkingSpeed, graph.SpeedForZone("ZONE-9"))
+}
+
+// TestNewLayoutGraphValidation tests layout validation
+func TestNewLayoutGraphValidation(t *testing.T) {
+	nodes := []LayoutNode{{NodeID: "N1"}, {NodeID: "N2", X: 5}}
+
+	_, err := NewLayoutGraph(nil, nil, nil, 0)
+	assert.ErrorIs(t, err, ErrLayoutEmpty)
+
+	_, err = NewLayoutGraph(nodes, []LayoutSegment{{From: "N1", To: "N3"}}, nil, 0)
+	assert.ErrorIs(t, err, ErrLayoutUnknownNode)
+
+	_, err = NewLayoutGraph(append(nodes, LayoutNode{NodeID: "N1"}), []LayoutSegment{{From: "N1", To: "N2"}}, nil, 0)
+	assert.ErrorIs(t, err, ErrLayoutDuplicateID)
+
+	_, err = NewLayoutGraph(nodes, []LayoutSegment{{From: "N1", To: "N2"}}, map[string]float64{"ZONE-1": 0}, 0)
+	assert.ErrorIs(t, err, ErrLayoutBadSpeed)
+}
+
+// TestPickRouteOptimizeWithLayout tests that route estimates follow the layout graph
+func TestPickRouteOptimizeWithLayout(t *testing.T) {
+	graph := createTestLayout(t, false, false, nil)
+
+	items := []RouteItem{
+		{SKU: "SKU-001", Quantity: 1, Location: createTestLocation("A-15-1-A", "A", 15, 1, 0, 15)},
+		{SKU: "SKU-002", Quantity: 1, Location: createTestLocation("B-15-1-A", "B", 15, 1, 10, 15)},
+	}
+	start := createTestLocation("START", "", 0, 0, 0, 0)
+
+	strategies := []RoutingStrategy{StrategyReturn, StrategySShape, StrategyLargestGap, StrategyCombined, StrategyNearest}
+	for _, strategy := range strategies {
+		t.Run(string(strategy), func(t *testing.T) {
+			route, err := NewPickRoute("ROUTE-001", "ORD-001", "WAVE-001", strategy, items)
+			require.NoError(t, err)
+
+			require.NoError(t, route.OptimizeRouteWithModel(start, start, graph))
+
+			// Start -> A15 (15) -> B15 (20) -> front of B -> start (15 + 10)
+			assert.InDelta(t, 60.0, route.EstimatedDistance, 0.001)
+			assert.Equal(t, 70*time.Second, route.EstimatedTime)
+		})
+	}
+}
@@ -81,8 +81,11 @@ type WarehouseLayout interface {
 	// GetConsolidationLocation retrieves the consolidation area location
 	GetConsolidationLocation(ctx context.Context, zone string) Location
 
-	// GetDistance calculates distance between two locations
+	// GetDistance calculates travel distance between two locations
 	GetDistance(ctx context.Context, from, to Location) float64
+
+	// GetTravelTime calculates travel time between two locations
+	GetTravelTime(ctx context.Context, from, to Location) time.Duration
 }
 
 // InventoryLocator provides inventory location information
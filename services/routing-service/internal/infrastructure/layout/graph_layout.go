@@ -0,0 +1,240 @@
+package layout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wms-platform/routing-service/internal/domain"
+)
+
+// LayoutFile is the on-disk representation of a warehouse travel layout
+//
+// Example:
+//
+//	{
+//	  "facilityId": "FAC-001",
+//	  "defaultSpeedMps": 1.2,
+//	  "zones": [
+//	    {"zone": "ZONE-1", "speedMps": 0.8,
+//	     "pickStart": {"locationId": "ZONE-1-START", "x": 0, "y": 0},
+//	     "consolidation": {"locationId": "PACK-01", "x": 0, "y": -5}}
+//	  ],
+//	  "nodes": [{"nodeId": "A-FRONT", "x": 2, "y": 0, "zone": "ZONE-1"}],
+//	  "segments": [{"from": "A-FRONT", "to": "A-BACK", "kind": "aisle", "aisle": "A", "oneWay": true}],
+//	  "locations": [{"locationId": "A-05-1-A", "aisle": "A", "rack": 5, "x": 1, "y": 10, "zone": "ZONE-1"}]
+//	}
+type LayoutFile struct {
+	FacilityID      string                 `json:"facilityId"`
+	DefaultSpeedMps float64                `json:"defaultSpeedMps"`
+	Zones           []ZoneConfig           `json:"zones"`
+	Nodes           []domain.LayoutNode    `json:"nodes"`
+	Segments        []domain.LayoutSegment `json:"segments"`
+	Locations       []LocationConfig       `json:"locations"`
+}
+
+// ZoneConfig holds per-zone travel speed and pick start / consolidation points
+type ZoneConfig struct {
+	Zone          string          `json:"zone"`
+	SpeedMps      float64         `json:"speedMps"`
+	PickStart     *LocationConfig `json:"pickStart,omitempty"`
+	Consolidation *LocationConfig `json:"consolidation,omitempty"`
+}
+
+// LocationConfig is a storage or staging location in the layout file
+type LocationConfig struct {
+	LocationID string  `json:"locationId"`
+	Aisle      string  `json:"aisle"`
+	Rack       int     `json:"rack"`
+	Level      int     `json:"level"`
+	Position   string  `json:"position"`
+	Zone       string  `json:"zone"`
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+}
+
+func (l LocationConfig) toDomain() domain.Location {
+	return domain.Location{
+		LocationID: l.LocationID,
+		Aisle:      l.Aisle,
+		Rack:       l.Rack,
+		Level:      l.Level,
+		Position:   l.Position,
+		Zone:       l.Zone,
+		X:          l.X,
+		Y:          l.Y,
+	}
+}
+
+// GraphWarehouseLayout implements domain.WarehouseLayout on top of a layout graph
+type GraphWarehouseLayout struct {
+	facilityID    string
+	graph         *domain.LayoutGraph
+	locations     map[string]domain.Location
+	byAisle       map[string][]domain.Location
+	byZone        map[string][]domain.Location
+	pickStarts    map[string]domain.Location
+	consolidation map[string]domain.Location
+	logger        *slog.Logger
+	unreachable   sync.Map // "from->to" location pairs already warned about
+}
+
+// LoadGraphWarehouseLayout reads a JSON layout file and builds the warehouse layout
+func LoadGraphWarehouseLayout(path string) (*GraphWarehouseLayout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layout file: %w", err)
+	}
+
+	var file LayoutFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse layout file: %w", err)
+	}
+
+	return NewGraphWarehouseLayout(file)
+}
+
+// NewGraphWarehouseLayout builds a warehouse layout from a parsed layout file
+func NewGraphWarehouseLayout(file LayoutFile) (*GraphWarehouseLayout, error) {
+	zoneSpeeds := make(map[string]float64)
+	for _, zone := range file.Zones {
+		if zone.SpeedMps != 0 {
+			zoneSpeeds[zone.Zone] = zone.SpeedMps
+		}
+	}
+
+	graph, err := domain.NewLayoutGraph(file.Nodes, file.Segments, zoneSpeeds, file.DefaultSpeedMps)
+	if err != nil {
+		return nil, fmt.Errorf("invalid layout: %w", err)
+	}
+
+	l := &GraphWarehouseLayout{
+		facilityID:    file.FacilityID,
+		graph:         graph,
+		locations:     make(map[string]domain.Location, len(file.Locations)),
+		byAisle:       make(map[string][]domain.Location),
+		byZone:        make(map[string][]domain.Location),
+		pickStarts:    make(map[string]domain.Location),
+		consolidation: make(map[string]domain.Location),
+		logger:        slog.Default(),
+	}
+
+	for _, cfg := range file.Locations {
+		loc := cfg.toDomain()
+		if loc.Zone == "" {
+			loc.Zone = domain.GetZoneForAisle(loc.Aisle)
+		}
+		l.locations[loc.LocationID] = loc
+		l.byAisle[loc.Aisle] = append(l.byAisle[loc.Aisle], loc)
+		l.byZone[loc.Zone] = append(l.byZone[loc.Zone], loc)
+	}
+
+	for _, zone := range file.Zones {
+		if zone.PickStart != nil {
+			l.pickStarts[zone.Zone] = zone.PickStart.toDomain()
+		}
+		if zone.Consolidation != nil {
+			l.consolidation[zone.Zone] = zone.Consolidation.toDomain()
+		}
+	}
+
+	return l, nil
+}
+
+// SetLogger sets the logger used to report locations the graph cannot route between
+func (l *GraphWarehouseLayout) SetLogger(logger *slog.Logger) {
+	if logger != nil {
+		l.logger = logger
+	}
+}
+
+// FacilityID returns the facility the layout describes
+func (l *GraphWarehouseLayout) FacilityID() string {
+	return l.facilityID
+}
+
+// Graph returns the underlying travel graph
+func (l *GraphWarehouseLayout) Graph() *domain.LayoutGraph {
+	return l.graph
+}
+
+// GetLocation retrieves location details by ID
+func (l *GraphWarehouseLayout) GetLocation(ctx context.Context, locationID string) (*domain.Location, error) {
+	loc, ok := l.locations[locationID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidLocation, locationID)
+	}
+	return &loc, nil
+}
+
+// GetAisleLocations retrieves all locations in an aisle
+func (l *GraphWarehouseLayout) GetAisleLocations(ctx context.Context, aisle string) ([]domain.Location, error) {
+	return l.byAisle[aisle], nil
+}
+
+// GetZoneLocations retrieves all locations in a zone
+func (l *GraphWarehouseLayout) GetZoneLocations(ctx context.Context, zone string) ([]domain.Location, error) {
+	return l.byZone[zone], nil
+}
+
+// GetPickStartLocation retrieves the default pick start location for a zone
+func (l *GraphWarehouseLayout) GetPickStartLocation(ctx context.Context, zone string) domain.Location {
+	if loc, ok := l.pickStarts[zone]; ok {
+		return loc
+	}
+	return domain.Location{LocationID: strings.ToUpper(zone) + "-START", Zone: zone}
+}
+
+// GetConsolidationLocation retrieves the consolidation area location for a zone
+func (l *GraphWarehouseLayout) GetConsolidationLocation(ctx context.Context, zone string) domain.Location {
+	if loc, ok := l.consolidation[zone]; ok {
+		return loc
+	}
+	return domain.Location{LocationID: strings.ToUpper(zone) + "-CONSOLIDATION", Zone: zone}
+}
+
+// GetDistance calculates shortest-path travel distance between two locations
+func (l *GraphWarehouseLayout) GetDistance(ctx context.Context, from, to domain.Location) float64 {
+	length, _ := l.shortestPath(ctx, from, to)
+	return length
+}
+
+// GetTravelTime calculates shortest-path travel time between two locations
+func (l *GraphWarehouseLayout) GetTravelTime(ctx context.Context, from, to domain.Location) time.Duration {
+	_, travelTime := l.shortestPath(ctx, from, to)
+	return travelTime
+}
+
+// shortestPath routes between two locations over the graph. Pairs the graph cannot route
+// between get straight-line travel, which understates the walk, so each is logged once.
+func (l *GraphWarehouseLayout) shortestPath(ctx context.Context, from, to domain.Location) (float64, time.Duration) {
+	from, to = l.resolve(from), l.resolve(to)
+	length, travelTime, ok := l.graph.ShortestPath(from, to)
+	if !ok {
+		if _, warned := l.unreachable.LoadOrStore(from.LocationID+"->"+to.LocationID, true); !warned {
+			l.logger.WarnContext(ctx, "No layout path between locations, using straight-line travel",
+				"facilityId", l.facilityID,
+				"from", from.LocationID,
+				"to", to.LocationID,
+				"distance", length,
+			)
+		}
+	}
+	return length, travelTime
+}
+
+// resolve fills in coordinates from the layout when a location only carries its ID
+func (l *GraphWarehouseLayout) resolve(loc domain.Location) domain.Location {
+	if loc.X != 0 || loc.Y != 0 {
+		return loc
+	}
+	if known, ok := l.locations[loc.LocationID]; ok {
+		return known
+	}
+	return loc
+}
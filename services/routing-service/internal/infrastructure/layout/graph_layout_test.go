@@ -0,0 +1,136 @@
+package layout
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/routing-service/internal/domain"
+)
+
+// testLayoutJSON is two 20m aisles (A at x=0, B at x=10) joined by a front cross-aisle,
+// with aisle C off on its own and not connected to the rest of the graph
+const testLayoutJSON = `{
+  "facilityId": "FAC-001",
+  "defaultSpeedMps": 1.0,
+  "zones": [
+    {"zone": "ZONE-1", "speedMps": 0.5,
+     "pickStart": {"locationId": "ZONE-1-START", "x": 0, "y": 0},
+     "consolidation": {"locationId": "PACK-01", "x": 0, "y": -5}}
+  ],
+  "nodes": [
+    {"nodeId": "A-FRONT", "x": 0, "y": 0, "zone": "ZONE-1"},
+    {"nodeId": "A-BACK", "x": 0, "y": 20, "zone": "ZONE-1"},
+    {"nodeId": "B-FRONT", "x": 10, "y": 0, "zone": "ZONE-1"},
+    {"nodeId": "B-BACK", "x": 10, "y": 20, "zone": "ZONE-1"},
+    {"nodeId": "C-FRONT", "x": 50, "y": 0},
+    {"nodeId": "C-BACK", "x": 50, "y": 20}
+  ],
+  "segments": [
+    {"from": "A-FRONT", "to": "A-BACK", "kind": "aisle", "aisle": "A"},
+    {"from": "B-FRONT", "to": "B-BACK", "kind": "aisle", "aisle": "B"},
+    {"from": "A-FRONT", "to": "B-FRONT", "kind": "cross_aisle"},
+    {"from": "C-FRONT", "to": "C-BACK", "kind": "aisle", "aisle": "C"}
+  ],
+  "locations": [
+    {"locationId": "A-05-1-A", "aisle": "A", "rack": 5, "x": 0, "y": 5, "zone": "ZONE-1"},
+    {"locationId": "B-05-1-A", "aisle": "B", "rack": 5, "x": 10, "y": 5, "zone": "ZONE-1"},
+    {"locationId": "C-05-1-A", "aisle": "C", "rack": 5, "x": 50, "y": 5}
+  ]
+}`
+
+func writeLayoutFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "layout.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadGraphWarehouseLayout(t *testing.T) {
+	ctx := context.Background()
+	l, err := LoadGraphWarehouseLayout(writeLayoutFile(t, testLayoutJSON))
+	require.NoError(t, err)
+
+	assert.Equal(t, "FAC-001", l.FacilityID())
+	assert.Equal(t, 6, l.Graph().Nodes())
+	assert.Equal(t, 0.5, l.Graph().SpeedForZone("ZONE-1"))
+	assert.Equal(t, 1.0, l.Graph().SpeedForZone("ZONE-2"))
+
+	loc, err := l.GetLocation(ctx, "B-05-1-A")
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, loc.X)
+	_, err = l.GetLocation(ctx, "Z-99-1-A")
+	assert.ErrorIs(t, err, domain.ErrInvalidLocation)
+
+	aisle, err := l.GetAisleLocations(ctx, "A")
+	require.NoError(t, err)
+	require.Len(t, aisle, 1)
+	assert.Equal(t, "A-05-1-A", aisle[0].LocationID)
+
+	// Locations without a zone get the zone of their aisle
+	zone, err := l.GetZoneLocations(ctx, domain.GetZoneForAisle("C"))
+	require.NoError(t, err)
+	assert.Len(t, zone, 3)
+	cLoc, err := l.GetLocation(ctx, "C-05-1-A")
+	require.NoError(t, err)
+	assert.Equal(t, domain.GetZoneForAisle("C"), cLoc.Zone)
+
+	assert.Equal(t, "ZONE-1-START", l.GetPickStartLocation(ctx, "ZONE-1").LocationID)
+	assert.Equal(t, "PACK-01", l.GetConsolidationLocation(ctx, "ZONE-1").LocationID)
+	assert.Equal(t, "ZONE-2-START", l.GetPickStartLocation(ctx, "zone-2").LocationID)
+
+	// Locations given by ID only are resolved from the layout: up aisle A,
+	// across the front and up aisle B, at the zone's speed
+	from := domain.Location{LocationID: "A-05-1-A"}
+	to := domain.Location{LocationID: "B-05-1-A"}
+	assert.InDelta(t, 20.0, l.GetDistance(ctx, from, to), 0.001)
+	assert.Equal(t, 40*time.Second, l.GetTravelTime(ctx, from, to))
+}
+
+func TestLoadGraphWarehouseLayoutErrors(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadGraphWarehouseLayout(filepath.Join(t.TempDir(), "missing.json"))
+		assert.ErrorContains(t, err, "failed to read layout file")
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		_, err := LoadGraphWarehouseLayout(writeLayoutFile(t, `{"nodes": [`))
+		assert.ErrorContains(t, err, "failed to parse layout file")
+	})
+
+	t.Run("segment to unknown node", func(t *testing.T) {
+		content := strings.Replace(testLayoutJSON, `"to": "C-BACK"`, `"to": "D-BACK"`, 1)
+		_, err := LoadGraphWarehouseLayout(writeLayoutFile(t, content))
+		assert.ErrorIs(t, err, domain.ErrLayoutUnknownNode)
+	})
+}
+
+func TestGraphWarehouseLayoutUnreachable(t *testing.T) {
+	ctx := context.Background()
+	l, err := LoadGraphWarehouseLayout(writeLayoutFile(t, testLayoutJSON))
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+	l.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	from := domain.Location{LocationID: "A-05-1-A"}
+	to := domain.Location{LocationID: "C-05-1-A"}
+
+	// Aisle C is not connected, so travel falls back to the straight line
+	assert.InDelta(t, 50.0, l.GetDistance(ctx, from, to), 0.001)
+	assert.Equal(t, 50*time.Second, l.GetTravelTime(ctx, from, to))
+
+	_, _, ok := l.Graph().ShortestPath(l.locations["A-05-1-A"], l.locations["C-05-1-A"])
+	assert.False(t, ok)
+
+	// Reported once per location pair
+	assert.Equal(t, 1, strings.Count(logs.String(), "No layout path between locations"))
+	assert.Contains(t, logs.String(), "from=A-05-1-A to=C-05-1-A")
+}
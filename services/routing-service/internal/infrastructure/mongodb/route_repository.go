@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/routing-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -75,9 +76,12 @@ func (r *RouteRepository) ensureIndexes(ctx context.Context) {
 	r.collection.Indexes().CreateMany(ctx, indexes)
 }
 
-// Save persists a route with its domain events in a single transaction
+// Save persists a route with its domain events in a single transaction. A route that
+// was modified since it was loaded fails with ErrConcurrencyConflict.
 func (r *RouteRepository) Save(ctx context.Context, route *domain.PickRoute) error {
 	route.UpdatedAt = time.Now()
+	expectedVersion := route.Version
+	route.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -89,11 +93,12 @@ func (r *RouteRepository) Save(ctx context.Context, route *domain.PickRoute) err
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"routeId": route.RouteID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"routeId": route.RouteID}, expectedVersion)
 		update := bson.M{"$set": route}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "PickRoute", route.RouteID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save route: %w", err)
 		}
 
@@ -153,6 +158,7 @@ func (r *RouteRepository) Save(ctx context.Context, route *domain.PickRoute) err
 	})
 
 	if err != nil {
+		route.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
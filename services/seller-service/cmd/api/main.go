@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -17,6 +18,7 @@ import (
 	"github.com/wms-platform/shared/pkg/middleware"
 	"github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
+	"github.com/wms-platform/shared/pkg/secrets"
 	"github.com/wms-platform/shared/pkg/tracing"
 
 	"github.com/wms-platform/services/seller-service/internal/api/handlers"
@@ -86,6 +88,18 @@ func main() {
 	// Initialize repositories with instrumented client and event factory
 	sellerRepo := mongoRepo.NewSellerRepository(instrumentedMongo.Database(), eventFactory)
 
+	// Encrypt channel integration credentials at rest
+	credentialCipher, err := loadCredentialCipher(config)
+	if err != nil {
+		logger.WithError(err).Error("Failed to load credential encryption key")
+		os.Exit(1)
+	}
+	if credentialCipher != nil {
+		sellerRepo.SetCredentialCipher(credentialCipher)
+	} else {
+		logger.Warn("CREDENTIALS_KEY_FILE not set, integration credentials are stored unencrypted")
+	}
+
 	// Initialize and start outbox publisher
 	outboxPublisher := outbox.NewPublisher(
 		sellerRepo.GetOutboxRepository(),
@@ -208,14 +222,19 @@ func main() {
 
 // Config holds application configuration
 type Config struct {
-	ServerAddr string
-	MongoDB    *mongodb.Config
-	Kafka      *kafka.Config
+	ServerAddr  string
+	Environment string
+	MongoDB     *mongodb.Config
+	Kafka       *kafka.Config
+
+	// CredentialsKeyFile is the key file for encrypting integration credentials, required in production
+	CredentialsKeyFile string
 }
 
 func loadConfig() *Config {
 	return &Config{
-		ServerAddr: getEnv("SERVER_ADDR", ":8010"),
+		ServerAddr:  getEnv("SERVER_ADDR", ":8010"),
+		Environment: getEnv("ENVIRONMENT", "development"),
 		MongoDB: &mongodb.Config{
 			URI:            getEnv("MONGODB_URI", "mongodb://localhost:27017"),
 			Database:       getEnv("MONGODB_DATABASE", "sellers_db"),
@@ -231,7 +250,25 @@ func loadConfig() *Config {
 			BatchTimeout:  10 * time.Millisecond,
 			RequiredAcks:  -1,
 		},
+		CredentialsKeyFile: getEnv("CREDENTIALS_KEY_FILE", ""),
+	}
+}
+
+// loadCredentialCipher loads the credential encryption key. Outside production the key
+// file is optional so local setups keep working; a nil cipher stores credentials unencrypted.
+func loadCredentialCipher(config *Config) (*secrets.Cipher, error) {
+	if config.CredentialsKeyFile == "" {
+		if config.Environment == "production" {
+			return nil, fmt.Errorf("CREDENTIALS_KEY_FILE is required in production")
+		}
+		return nil, nil
+	}
+
+	provider, err := secrets.LoadLocalKeyProvider(config.CredentialsKeyFile)
+	if err != nil {
+		return nil, err
 	}
+	return secrets.NewCipher(provider), nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -120,3 +120,15 @@ func TestGetEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadCredentialCipher(t *testing.T) {
+	cipher, err := loadCredentialCipher(&Config{Environment: "development"})
+	assert.NoError(t, err)
+	assert.Nil(t, cipher)
+
+	_, err = loadCredentialCipher(&Config{Environment: "production"})
+	assert.Error(t, err)
+
+	_, err = loadCredentialCipher(&Config{Environment: "production", CredentialsKeyFile: "/nonexistent/keys.json"})
+	assert.Error(t, err)
+}
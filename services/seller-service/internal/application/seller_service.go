@@ -7,6 +7,7 @@ import (
 	"github.com/wms-platform/services/seller-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 	"github.com/wms-platform/shared/pkg/tenant"
 )
 
@@ -164,20 +165,14 @@ func (s *SellerApplicationService) ListSellers(ctx context.Context, query ListSe
 
 // ActivateSeller activates a seller account
 func (s *SellerApplicationService) ActivateSeller(ctx context.Context, cmd ActivateSellerCommand) (*SellerDTO, error) {
-	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), cmd.SellerID)
+	seller, err := s.updateSeller(ctx, cmd.SellerID, func(seller *domain.Seller) error {
+		if err := seller.Activate(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get seller: %w", err)
-	}
-	if seller == nil {
-		return nil, errors.ErrNotFound("seller not found")
-	}
-
-	if err := seller.Activate(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.sellerRepo.Save(s.withTenantOnlyContext(ctx), seller); err != nil {
-		return nil, fmt.Errorf("failed to save seller: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Seller activated", "sellerId", seller.SellerID)
@@ -187,20 +182,14 @@ func (s *SellerApplicationService) ActivateSeller(ctx context.Context, cmd Activ
 
 // SuspendSeller suspends a seller account
 func (s *SellerApplicationService) SuspendSeller(ctx context.Context, cmd SuspendSellerCommand) (*SellerDTO, error) {
-	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), cmd.SellerID)
+	seller, err := s.updateSeller(ctx, cmd.SellerID, func(seller *domain.Seller) error {
+		if err := seller.Suspend(cmd.Reason); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get seller: %w", err)
-	}
-	if seller == nil {
-		return nil, errors.ErrNotFound("seller not found")
-	}
-
-	if err := seller.Suspend(cmd.Reason); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.sellerRepo.Save(s.withTenantOnlyContext(ctx), seller); err != nil {
-		return nil, fmt.Errorf("failed to save seller: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Seller suspended", "sellerId", seller.SellerID, "reason", cmd.Reason)
@@ -210,20 +199,14 @@ func (s *SellerApplicationService) SuspendSeller(ctx context.Context, cmd Suspen
 
 // CloseSeller closes a seller account
 func (s *SellerApplicationService) CloseSeller(ctx context.Context, cmd CloseSellerCommand) (*SellerDTO, error) {
-	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), cmd.SellerID)
+	seller, err := s.updateSeller(ctx, cmd.SellerID, func(seller *domain.Seller) error {
+		if err := seller.Close(cmd.Reason); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get seller: %w", err)
-	}
-	if seller == nil {
-		return nil, errors.ErrNotFound("seller not found")
-	}
-
-	if err := seller.Close(cmd.Reason); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.sellerRepo.Save(s.withTenantOnlyContext(ctx), seller); err != nil {
-		return nil, fmt.Errorf("failed to save seller: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Seller closed", "sellerId", seller.SellerID, "reason", cmd.Reason)
@@ -233,25 +216,19 @@ func (s *SellerApplicationService) CloseSeller(ctx context.Context, cmd CloseSel
 
 // AssignFacility assigns a facility to a seller
 func (s *SellerApplicationService) AssignFacility(ctx context.Context, cmd AssignFacilityCommand) (*SellerDTO, error) {
-	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), cmd.SellerID)
+	seller, err := s.updateSeller(ctx, cmd.SellerID, func(seller *domain.Seller) error {
+		warehouseIDs := cmd.WarehouseIDs
+		if warehouseIDs == nil {
+			warehouseIDs = []string{}
+		}
+
+		if err := seller.AssignFacility(cmd.FacilityID, cmd.FacilityName, warehouseIDs, cmd.AllocatedSpace, cmd.IsDefault); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get seller: %w", err)
-	}
-	if seller == nil {
-		return nil, errors.ErrNotFound("seller not found")
-	}
-
-	warehouseIDs := cmd.WarehouseIDs
-	if warehouseIDs == nil {
-		warehouseIDs = []string{}
-	}
-
-	if err := seller.AssignFacility(cmd.FacilityID, cmd.FacilityName, warehouseIDs, cmd.AllocatedSpace, cmd.IsDefault); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.sellerRepo.Save(s.withTenantOnlyContext(ctx), seller); err != nil {
-		return nil, fmt.Errorf("failed to save seller: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Facility assigned to seller", "sellerId", seller.SellerID, "facilityId", cmd.FacilityID)
@@ -261,20 +238,14 @@ func (s *SellerApplicationService) AssignFacility(ctx context.Context, cmd Assig
 
 // RemoveFacility removes a facility from a seller
 func (s *SellerApplicationService) RemoveFacility(ctx context.Context, cmd RemoveFacilityCommand) (*SellerDTO, error) {
-	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), cmd.SellerID)
+	seller, err := s.updateSeller(ctx, cmd.SellerID, func(seller *domain.Seller) error {
+		if err := seller.RemoveFacility(cmd.FacilityID); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get seller: %w", err)
-	}
-	if seller == nil {
-		return nil, errors.ErrNotFound("seller not found")
-	}
-
-	if err := seller.RemoveFacility(cmd.FacilityID); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.sellerRepo.Save(s.withTenantOnlyContext(ctx), seller); err != nil {
-		return nil, fmt.Errorf("failed to save seller: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Facility removed from seller", "sellerId", seller.SellerID, "facilityId", cmd.FacilityID)
@@ -284,18 +255,12 @@ func (s *SellerApplicationService) RemoveFacility(ctx context.Context, cmd Remov
 
 // UpdateFeeSchedule updates a seller's fee schedule
 func (s *SellerApplicationService) UpdateFeeSchedule(ctx context.Context, cmd UpdateFeeScheduleCommand) (*SellerDTO, error) {
-	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), cmd.SellerID)
+	seller, err := s.updateSeller(ctx, cmd.SellerID, func(seller *domain.Seller) error {
+		seller.UpdateFeeSchedule(cmd.ToDomainFeeSchedule())
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get seller: %w", err)
-	}
-	if seller == nil {
-		return nil, errors.ErrNotFound("seller not found")
-	}
-
-	seller.UpdateFeeSchedule(cmd.ToDomainFeeSchedule())
-
-	if err := s.sellerRepo.Save(s.withTenantOnlyContext(ctx), seller); err != nil {
-		return nil, fmt.Errorf("failed to save seller: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Fee schedule updated", "sellerId", seller.SellerID)
@@ -305,26 +270,20 @@ func (s *SellerApplicationService) UpdateFeeSchedule(ctx context.Context, cmd Up
 
 // ConnectChannel connects a sales channel to a seller
 func (s *SellerApplicationService) ConnectChannel(ctx context.Context, cmd ConnectChannelCommand) (*SellerDTO, error) {
-	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), cmd.SellerID)
+	seller, err := s.updateSeller(ctx, cmd.SellerID, func(seller *domain.Seller) error {
+		if err := seller.AddChannelIntegration(
+			cmd.ChannelType,
+			cmd.StoreName,
+			cmd.StoreURL,
+			cmd.Credentials,
+			cmd.ToDomainSyncSettings(),
+		); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get seller: %w", err)
-	}
-	if seller == nil {
-		return nil, errors.ErrNotFound("seller not found")
-	}
-
-	if err := seller.AddChannelIntegration(
-		cmd.ChannelType,
-		cmd.StoreName,
-		cmd.StoreURL,
-		cmd.Credentials,
-		cmd.ToDomainSyncSettings(),
-	); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.sellerRepo.Save(s.withTenantOnlyContext(ctx), seller); err != nil {
-		return nil, fmt.Errorf("failed to save seller: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Channel connected", "sellerId", seller.SellerID, "channelType", cmd.ChannelType)
@@ -334,20 +293,14 @@ func (s *SellerApplicationService) ConnectChannel(ctx context.Context, cmd Conne
 
 // DisconnectChannel disconnects a sales channel from a seller
 func (s *SellerApplicationService) DisconnectChannel(ctx context.Context, cmd DisconnectChannelCommand) (*SellerDTO, error) {
-	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), cmd.SellerID)
+	seller, err := s.updateSeller(ctx, cmd.SellerID, func(seller *domain.Seller) error {
+		if err := seller.DisconnectChannel(cmd.ChannelID); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get seller: %w", err)
-	}
-	if seller == nil {
-		return nil, errors.ErrNotFound("seller not found")
-	}
-
-	if err := seller.DisconnectChannel(cmd.ChannelID); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.sellerRepo.Save(s.withTenantOnlyContext(ctx), seller); err != nil {
-		return nil, fmt.Errorf("failed to save seller: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Channel disconnected", "sellerId", seller.SellerID, "channelId", cmd.ChannelID)
@@ -357,21 +310,18 @@ func (s *SellerApplicationService) DisconnectChannel(ctx context.Context, cmd Di
 
 // GenerateAPIKey generates a new API key for a seller
 func (s *SellerApplicationService) GenerateAPIKey(ctx context.Context, cmd GenerateAPIKeyCommand) (*APIKeyCreatedDTO, error) {
-	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), cmd.SellerID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get seller: %w", err)
-	}
-	if seller == nil {
-		return nil, errors.ErrNotFound("seller not found")
-	}
-
-	apiKey, rawKey, err := seller.GenerateAPIKey(cmd.Name, cmd.Scopes, cmd.ExpiresAt)
+	var apiKey *domain.APIKey
+	var rawKey string
+	seller, err := s.updateSeller(ctx, cmd.SellerID, func(seller *domain.Seller) error {
+		key, raw, err := seller.GenerateAPIKey(cmd.Name, cmd.Scopes, cmd.ExpiresAt)
+		if err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		apiKey, rawKey = key, raw
+		return nil
+	})
 	if err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.sellerRepo.Save(s.withTenantOnlyContext(ctx), seller); err != nil {
-		return nil, fmt.Errorf("failed to save seller: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("API key generated", "sellerId", seller.SellerID, "keyId", apiKey.KeyID)
@@ -388,20 +338,14 @@ func (s *SellerApplicationService) GenerateAPIKey(ctx context.Context, cmd Gener
 
 // RevokeAPIKey revokes an API key
 func (s *SellerApplicationService) RevokeAPIKey(ctx context.Context, cmd RevokeAPIKeyCommand) error {
-	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), cmd.SellerID)
+	seller, err := s.updateSeller(ctx, cmd.SellerID, func(seller *domain.Seller) error {
+		if err := seller.RevokeAPIKey(cmd.KeyID); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to get seller: %w", err)
-	}
-	if seller == nil {
-		return errors.ErrNotFound("seller not found")
-	}
-
-	if err := seller.RevokeAPIKey(cmd.KeyID); err != nil {
-		return errors.ErrValidation(err.Error())
-	}
-
-	if err := s.sellerRepo.Save(s.withTenantOnlyContext(ctx), seller); err != nil {
-		return fmt.Errorf("failed to save seller: %w", err)
+		return err
 	}
 
 	s.logger.Info("API key revoked", "sellerId", seller.SellerID, "keyId", cmd.KeyID)
@@ -409,6 +353,44 @@ func (s *SellerApplicationService) RevokeAPIKey(ctx context.Context, cmd RevokeA
 	return nil
 }
 
+// updateSeller loads a seller, applies change and saves it. A save that loses a race
+// with another writer reloads the seller and applies change again.
+func (s *SellerApplicationService) updateSeller(ctx context.Context, sellerID string, change func(seller *domain.Seller) error) (*domain.Seller, error) {
+	ctx = s.withTenantOnlyContext(ctx)
+
+	var seller *domain.Seller
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.sellerRepo.FindByID(ctx, sellerID)
+		if err != nil {
+			return fmt.Errorf("failed to get seller: %w", err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("seller not found")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.sellerRepo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				s.logger.Debug("Seller changed concurrently, retrying", "sellerId", sellerID)
+				return err
+			}
+			return fmt.Errorf("failed to save seller: %w", err)
+		}
+		seller = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("seller %s was modified concurrently, please retry", sellerID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return seller, nil
+}
+
 // GetAPIKeys returns all API keys for a seller
 func (s *SellerApplicationService) GetAPIKeys(ctx context.Context, sellerID string) ([]APIKeyDTO, error) {
 	seller, err := s.sellerRepo.FindByID(s.withTenantOnlyContext(ctx), sellerID)
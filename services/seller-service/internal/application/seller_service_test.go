@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/wms-platform/services/seller-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/logging"
 )
 
@@ -397,6 +398,36 @@ func TestSellerApplicationService_ActivateSeller(t *testing.T) {
 	}
 }
 
+func TestSellerApplicationService_ActivateSeller_RetriesConcurrentSave(t *testing.T) {
+	mockRepo := newMockSellerRepository()
+	logger := logging.New(logging.DefaultConfig("test"))
+	service := NewSellerApplicationService(mockRepo, logger)
+
+	loads := 0
+	mockRepo.FindByIDFunc = func(ctx context.Context, sellerID string) (*domain.Seller, error) {
+		loads++
+		seller := newTestSeller()
+		seller.SellerID = sellerID
+		seller.Status = domain.SellerStatusPending
+		return seller, nil
+	}
+	saves := 0
+	mockRepo.SaveFunc = func(ctx context.Context, seller *domain.Seller) error {
+		saves++
+		if saves == 1 {
+			return errors.NewConcurrencyConflictError("Seller", seller.SellerID, 0)
+		}
+		return nil
+	}
+
+	result, err := service.ActivateSeller(context.Background(), ActivateSellerCommand{SellerID: "SLR-001"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "active", result.Status)
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, 2, saves)
+}
+
 func TestSellerApplicationService_SuspendSeller(t *testing.T) {
 	mockRepo := newMockSellerRepository()
 	logger := logging.New(logging.DefaultConfig("test"))
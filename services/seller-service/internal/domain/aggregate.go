@@ -93,6 +93,7 @@ type Seller struct {
 	// Metadata
 	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
 	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+	Version   int       `bson:"version" json:"version"` // Incremented on every save for optimistic concurrency
 
 	// Domain events (not persisted)
 	domainEvents []DomainEvent `bson:"-" json:"-"`
@@ -0,0 +1,87 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/wms-platform/services/seller-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/secrets"
+)
+
+func newTestCipher(t *testing.T, keys map[int][]byte, current int) *secrets.Cipher {
+	provider, err := secrets.NewLocalKeyProvider(keys, current)
+	require.NoError(t, err)
+	return secrets.NewCipher(provider)
+}
+
+func newTestKey(t *testing.T) []byte {
+	key, err := secrets.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func newSellerWithIntegration(t *testing.T) *domain.Seller {
+	seller, err := domain.NewSeller("TNT-001", "Test Corp", "John Doe", "john@test.com", domain.BillingCycleMonthly)
+	require.NoError(t, err)
+	seller.Status = domain.SellerStatusActive
+	err = seller.AddChannelIntegration("shopify", "Test Store", "https://test.myshopify.com",
+		map[string]string{"accessToken": "shpat_plaintext"}, domain.ChannelSyncSettings{})
+	require.NoError(t, err)
+	return seller
+}
+
+func TestEncryptCredentials(t *testing.T) {
+	ctx := context.Background()
+	cipher := newTestCipher(t, map[int][]byte{1: newTestKey(t)}, 1)
+	seller := newSellerWithIntegration(t)
+
+	stored, err := encryptCredentials(ctx, cipher, seller)
+	require.NoError(t, err)
+
+	// The caller's seller keeps plaintext credentials
+	assert.Equal(t, "shpat_plaintext", seller.Integrations[0].Credentials["accessToken"])
+	encrypted := stored.Integrations[0].Credentials["accessToken"]
+	assert.True(t, secrets.IsEncrypted(encrypted))
+
+	require.NoError(t, decryptCredentials(ctx, cipher, stored))
+	assert.Equal(t, "shpat_plaintext", stored.Integrations[0].Credentials["accessToken"])
+}
+
+func TestEncryptCredentialsWithoutCipher(t *testing.T) {
+	seller := newSellerWithIntegration(t)
+
+	stored, err := encryptCredentials(context.Background(), nil, seller)
+	require.NoError(t, err)
+	assert.Same(t, seller, stored)
+	assert.NoError(t, decryptCredentials(context.Background(), nil, seller))
+}
+
+func TestRotatedCredentialFields(t *testing.T) {
+	ctx := context.Background()
+	key1, key2 := newTestKey(t), newTestKey(t)
+	oldCipher := newTestCipher(t, map[int][]byte{1: key1}, 1)
+	newCipher := newTestCipher(t, map[int][]byte{1: key1, 2: key2}, 2)
+
+	seller := newSellerWithIntegration(t)
+	stored, err := encryptCredentials(ctx, oldCipher, seller)
+	require.NoError(t, err)
+
+	update, current, err := rotatedCredentialFields(ctx, newCipher, stored)
+	require.NoError(t, err)
+	require.Len(t, update, 1)
+	assert.Equal(t, bson.M{"integrations.0.credentials.accessToken": stored.Integrations[0].Credentials["accessToken"]}, current)
+
+	rotated := update["integrations.0.credentials.accessToken"].(string)
+	version, err := secrets.KeyVersion(rotated)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	stored.Integrations[0].Credentials["accessToken"] = rotated
+	update, _, err = rotatedCredentialFields(ctx, newCipher, stored)
+	require.NoError(t, err)
+	assert.Empty(t, update)
+}
@@ -13,8 +13,10 @@ import (
 	"github.com/wms-platform/services/seller-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
+	"github.com/wms-platform/shared/pkg/secrets"
 	"github.com/wms-platform/shared/pkg/tenant"
 )
 
@@ -25,6 +27,7 @@ type SellerRepository struct {
 	outboxRepo   *outboxMongo.OutboxRepository
 	eventFactory *cloudevents.EventFactory
 	tenantHelper *tenant.RepositoryHelper
+	cipher       *secrets.Cipher
 }
 
 // NewSellerRepository creates a new SellerRepository
@@ -105,9 +108,18 @@ func NewSellerRepository(db *mongo.Database, eventFactory *cloudevents.EventFact
 	}
 }
 
-// Save persists a seller with its domain events in a single transaction
+// Save persists a seller with its domain events in a single transaction. The write only
+// succeeds if the stored seller still has the version that was loaded.
 func (r *SellerRepository) Save(ctx context.Context, seller *domain.Seller) error {
 	seller.UpdatedAt = time.Now().UTC()
+	expectedVersion := seller.Version
+	seller.Version = expectedVersion + 1
+
+	stored, err := encryptCredentials(ctx, r.cipher, seller)
+	if err != nil {
+		seller.Version = expectedVersion
+		return err
+	}
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -119,11 +131,12 @@ func (r *SellerRepository) Save(ctx context.Context, seller *domain.Seller) erro
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"sellerId": seller.SellerID}
-		update := bson.M{"$set": seller}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"sellerId": seller.SellerID}, expectedVersion)
+		update := bson.M{"$set": stored}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "Seller", seller.SellerID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save seller: %w", err)
 		}
 
@@ -181,6 +194,7 @@ func (r *SellerRepository) Save(ctx context.Context, seller *domain.Seller) erro
 	})
 
 	if err != nil {
+		seller.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
@@ -203,6 +217,9 @@ func (r *SellerRepository) FindByID(ctx context.Context, sellerID string) (*doma
 		return nil, err
 	}
 
+	if err := decryptCredentials(ctx, r.cipher, &seller); err != nil {
+		return nil, err
+	}
 	return &seller, nil
 }
 
@@ -247,6 +264,9 @@ func (r *SellerRepository) FindByAPIKey(ctx context.Context, hashedKey string) (
 		return nil, err
 	}
 
+	if err := decryptCredentials(ctx, r.cipher, &seller); err != nil {
+		return nil, err
+	}
 	return &seller, nil
 }
 
@@ -264,6 +284,9 @@ func (r *SellerRepository) FindByEmail(ctx context.Context, email string) (*doma
 		return nil, err
 	}
 
+	if err := decryptCredentials(ctx, r.cipher, &seller); err != nil {
+		return nil, err
+	}
 	return &seller, nil
 }
 
@@ -330,6 +353,11 @@ func (r *SellerRepository) findMany(ctx context.Context, filter bson.M, opts *op
 		return nil, err
 	}
 
+	for _, seller := range sellers {
+		if err := decryptCredentials(ctx, r.cipher, seller); err != nil {
+			return nil, err
+		}
+	}
 	return sellers, nil
 }
 
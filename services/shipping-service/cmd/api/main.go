@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -23,7 +27,10 @@ import (
 
 	"github.com/wms-platform/shipping-service/internal/application"
 	"github.com/wms-platform/shipping-service/internal/domain"
+	"github.com/wms-platform/shipping-service/internal/infrastructure/carriers"
+	"github.com/wms-platform/shipping-service/internal/infrastructure/labels"
 	mongoRepo "github.com/wms-platform/shipping-service/internal/infrastructure/mongodb"
+	"github.com/wms-platform/shipping-service/internal/infrastructure/printing"
 )
 
 const serviceName = "shipping-service"
@@ -96,6 +103,9 @@ func main() {
 	// Initialize repositories with instrumented client and event factory
 	repo := mongoRepo.NewShipmentRepository(instrumentedMongo.Database(), eventFactory)
 	manifestRepo := mongoRepo.NewManifestRepository(instrumentedMongo.Database(), eventFactory)
+	carrierRuleRepo := mongoRepo.NewCarrierSelectionRuleRepository(instrumentedMongo.Database())
+	printJobRepo := mongoRepo.NewPrintJobRepository(instrumentedMongo.Database())
+	pickupScheduleRepo := mongoRepo.NewPickupScheduleRepository(instrumentedMongo.Database())
 
 	// Initialize idempotency repository
 	idempotencyKeyRepo := idempotency.NewMongoKeyRepository(instrumentedMongo.Database())
@@ -126,6 +136,7 @@ func main() {
 		eventFactory,
 		logger,
 	)
+	shippingService.SetCustomsPolicy(config.CustomsPolicy)
 	manifestService := application.NewManifestApplicationService(
 		manifestRepo,
 		instrumentedProducer,
@@ -133,6 +144,82 @@ func main() {
 		logger,
 	)
 
+	// Initialize carrier adapters used for rate shopping and labels
+	carrierAdapters := []domain.CarrierService{
+		carriers.NewUPSAdapter(config.UPS.AccessKey, config.UPS.Username, config.UPS.Password, config.UPS.AccountNumber, config.UPS.APIURL),
+		carriers.NewFedExAdapter(config.FedEx.ClientID, config.FedEx.ClientSecret, config.FedEx.AccountNumber, config.FedEx.MeterNumber, config.FedEx.APIURL),
+	}
+	// Regional and postal carriers are only quoted when the account is configured
+	if config.USPS.ClientID != "" {
+		carrierAdapters = append(carrierAdapters, carriers.NewUSPSAdapter(config.USPS.ClientID, config.USPS.ClientSecret, config.USPS.AccountNumber, config.USPS.APIURL))
+	}
+	if config.DHL.Username != "" {
+		carrierAdapters = append(carrierAdapters, carriers.NewDHLAdapter(config.DHL.Username, config.DHL.Password, config.DHL.AccountNumber, config.DHL.APIURL))
+	}
+	if config.OnTrac.AccountNumber != "" {
+		carrierAdapters = append(carrierAdapters, carriers.NewOnTracAdapter(config.OnTrac.AccountNumber, config.OnTrac.Password, config.OnTrac.APIURL))
+	}
+	rateShoppingService := application.NewRateShoppingService(
+		repo,
+		carrierRuleRepo,
+		carrierAdapters,
+		config.CarrierQuoteTimeout,
+		logger,
+	)
+	logger.Info("Rate shopping initialized", "carriers", len(carrierAdapters), "quoteTimeout", config.CarrierQuoteTimeout)
+
+	// Initialize station printer routing
+	printers, err := printing.ParsePrinters(config.LabelPrinters)
+	if err != nil {
+		logger.WithError(err).Error("Invalid LABEL_PRINTERS configuration, label printing disabled")
+	}
+	printRouter := printing.NewRouter(printers, printJobRepo, config.Printing, logger)
+	if err := printRouter.Start(ctx); err != nil {
+		logger.WithError(err).Error("Failed to start print router")
+		os.Exit(1)
+	}
+	defer printRouter.Stop()
+	logger.Info("Print router started", "printers", len(printers))
+
+	labelService := application.NewLabelService(repo, carrierAdapters, printRouter, logger)
+	labelService.SetCustomsPolicy(config.CustomsPolicy)
+	customsService := application.NewCustomsService(repo, labels.NewDocumentRenderer(), logger)
+	logger.Info("Customs validation initialized", "restrictedDestinations", config.CustomsPolicy.RestrictedCountries)
+
+	// Start carrier tracking poller (advances shipments from carrier scans)
+	trackingService := application.NewTrackingService(repo, carrierAdapters, config.TrackingPolicy, logger)
+	trackingPoller := application.NewTrackingPoller(trackingService, config.TrackingPoller, logger)
+	if config.TrackingPollEnabled {
+		if err := trackingPoller.Start(ctx); err != nil {
+			logger.WithError(err).Error("Failed to start tracking poller")
+			os.Exit(1)
+		}
+		defer trackingPoller.Stop()
+		logger.Info("Tracking poller started",
+			"pollInterval", config.TrackingPoller.PollInterval,
+			"batchSize", config.TrackingPoller.BatchSize,
+		)
+	}
+
+	// Start manifest cutoff scheduler (closes manifests at carrier cutoffs, alerts on missed pickups)
+	cutoffService := application.NewManifestCutoffService(
+		pickupScheduleRepo,
+		manifestRepo,
+		repo,
+		carrierAdapters,
+		labels.NewDocumentRenderer(),
+		logger,
+	)
+	cutoffScheduler := application.NewManifestCutoffScheduler(cutoffService, config.ManifestCutoff, logger)
+	if config.ManifestCutoffEnabled {
+		if err := cutoffScheduler.Start(ctx); err != nil {
+			logger.WithError(err).Error("Failed to start manifest cutoff scheduler")
+			os.Exit(1)
+		}
+		defer cutoffScheduler.Stop()
+		logger.Info("Manifest cutoff scheduler started", "checkInterval", config.ManifestCutoff.CheckInterval)
+	}
+
 	// Setup Gin router with middleware
 	router := gin.New()
 
@@ -179,11 +266,20 @@ func main() {
 	{
 		api.POST("", createShipmentHandler(shippingService, logger))
 		api.GET("/:shipmentId", getShipmentHandler(shippingService, logger))
+		api.POST("/:shipmentId/rate-shop", rateShopHandler(rateShoppingService, logger))
 		api.POST("/:shipmentId/label", generateLabelHandler(shippingService, logger))
+		api.POST("/:shipmentId/carrier-label", createCarrierLabelHandler(labelService, logger))
+		api.GET("/:shipmentId/label/document", getLabelDocumentHandler(labelService, logger))
+		api.POST("/:shipmentId/label/print", printLabelHandler(labelService, logger))
+		api.PUT("/:shipmentId/customs", setCustomsDeclarationHandler(customsService, logger))
+		api.GET("/:shipmentId/customs/commercial-invoice", getCommercialInvoiceHandler(customsService, logger))
+		api.GET("/:shipmentId/customs/form", getCustomsFormHandler(customsService, logger))
 		api.POST("/:shipmentId/manifest", addToManifestHandler(shippingService, logger))
 		api.POST("/:shipmentId/ship", confirmShipmentHandler(shippingService, logger))
+		api.POST("/:shipmentId/tracking/refresh", refreshTrackingHandler(trackingService, logger))
 		api.GET("/order/:orderId", getByOrderHandler(shippingService, logger))
 		api.GET("/tracking/:trackingNumber", getByTrackingHandler(shippingService, logger))
+		api.POST("/tracking/:trackingNumber/void", voidLabelHandler(labelService, logger))
 		api.GET("/status/:status", getByStatusHandler(shippingService, logger))
 		api.GET("/carrier/:carrierCode", getByCarrierHandler(shippingService, logger))
 		api.GET("/carrier/:carrierCode/pending", getPendingForManifestHandler(shippingService, logger))
@@ -199,12 +295,43 @@ func main() {
 		manifestAPI.POST("/:manifestId/close", closeManifestHandler(manifestService, logger))
 		manifestAPI.POST("/:manifestId/trailer", assignTrailerHandler(manifestService, logger))
 		manifestAPI.POST("/:manifestId/dispatch", dispatchManifestHandler(manifestService, logger))
+		manifestAPI.GET("/:manifestId/bol", getBillOfLadingHandler(cutoffService, logger))
+		manifestAPI.GET("/:manifestId/summary", getManifestSummaryHandler(cutoffService, logger))
+		manifestAPI.POST("/cutoff/run", runManifestCutoffHandler(cutoffService, logger))
 		manifestAPI.GET("/carrier/:carrierId", getManifestsByCarrierHandler(manifestService, logger))
 		manifestAPI.GET("/carrier/:carrierId/closed", getClosedManifestsByCarrierHandler(manifestService, logger))
 		manifestAPI.GET("/status/:status", getManifestsByStatusHandler(manifestService, logger))
 		manifestAPI.GET("/dispatched/today", getDispatchedTodayHandler(manifestService, logger))
 	}
 
+	// API v1 routes - Seller carrier selection rules with tenant context required
+	carrierRuleAPI := router.Group("/api/v1/carrier-rules")
+	carrierRuleAPI.Use(middleware.RequireTenantAuth()) // All API routes require tenant headers
+	{
+		carrierRuleAPI.GET("/:sellerId", getCarrierRuleHandler(rateShoppingService, logger))
+		carrierRuleAPI.PUT("/:sellerId", setCarrierRuleHandler(rateShoppingService, logger))
+	}
+
+	// API v1 routes - Carrier pickup schedules
+	pickupScheduleAPI := router.Group("/api/v1/pickup-schedules")
+	pickupScheduleAPI.Use(middleware.RequireTenantAuth()) // All API routes require tenant headers
+	{
+		pickupScheduleAPI.GET("", getPickupSchedulesHandler(cutoffService, logger))
+		pickupScheduleAPI.PUT("/:carrierCode", setPickupScheduleHandler(cutoffService, logger))
+		pickupScheduleAPI.DELETE("/:carrierCode", deletePickupScheduleHandler(cutoffService, logger))
+	}
+
+	// API v1 routes - Tracking
+	trackingAPI := router.Group("/api/v1/tracking")
+	{
+		trackingAPI.POST("/poll", middleware.RequireTenantAuth(), pollTrackingHandler(trackingService, config.TrackingPoller, logger))
+
+		// Carriers cannot send tenant headers; webhooks authenticate with a shared token instead
+		if config.TrackingWebhookToken != "" {
+			trackingAPI.POST("/webhooks/:carrierCode", trackingWebhookHandler(trackingService, config.TrackingWebhookToken, logger))
+		}
+	}
+
 	// Start server
 	srv := &http.Server{
 		Addr:         config.ServerAddr,
@@ -239,9 +366,68 @@ func main() {
 
 // Config holds application configuration
 type Config struct {
-	ServerAddr string
-	MongoDB    *mongodb.Config
-	Kafka      *kafka.Config
+	ServerAddr          string
+	MongoDB             *mongodb.Config
+	Kafka               *kafka.Config
+	UPS                 UPSConfig
+	FedEx               FedExConfig
+	USPS                USPSConfig
+	DHL                 DHLConfig
+	OnTrac              OnTracConfig
+	CarrierQuoteTimeout time.Duration
+	LabelPrinters       string
+	Printing            printing.RouterConfig
+
+	TrackingPollEnabled  bool
+	TrackingPoller       application.TrackingPollerConfig
+	TrackingPolicy       domain.TrackingPollPolicy
+	TrackingWebhookToken string
+
+	ManifestCutoffEnabled bool
+	ManifestCutoff        application.ManifestCutoffSchedulerConfig
+
+	CustomsPolicy domain.CustomsPolicy
+}
+
+// UPSConfig holds UPS API credentials
+type UPSConfig struct {
+	AccessKey     string
+	Username      string
+	Password      string
+	AccountNumber string
+	APIURL        string
+}
+
+// FedExConfig holds FedEx API credentials
+type FedExConfig struct {
+	ClientID      string
+	ClientSecret  string
+	AccountNumber string
+	MeterNumber   string
+	APIURL        string
+}
+
+// USPSConfig holds USPS Web Tools API credentials
+type USPSConfig struct {
+	ClientID      string
+	ClientSecret  string
+	AccountNumber string
+	APIURL        string
+}
+
+// DHLConfig holds MyDHL API credentials
+type DHLConfig struct {
+	Username      string
+	Password      string
+	AccountNumber string
+	APIURL        string
+}
+
+// OnTracConfig holds OnTrac web services credentials
+type OnTracConfig struct {
+	AccountNumber string
+	Password      string
+	APIURL        string
 }
 
 func loadConfig() *Config {
@@ -262,7 +448,99 @@ func loadConfig() *Config {
 			BatchTimeout:  10 * time.Millisecond,
 			RequiredAcks:  -1,
 		},
+		UPS: UPSConfig{
+			AccessKey:     getEnv("UPS_ACCESS_KEY", ""),
+			Username:      getEnv("UPS_USERNAME", ""),
+			Password:      getEnv("UPS_PASSWORD", ""),
+			AccountNumber: getEnv("UPS_ACCOUNT_NUMBER", ""),
+			APIURL:        getEnv("UPS_API_URL", "https://onlinetools.ups.com"),
+		},
+		FedEx: FedExConfig{
+			ClientID:      getEnv("FEDEX_CLIENT_ID", ""),
+			ClientSecret:  getEnv("FEDEX_CLIENT_SECRET", ""),
+			AccountNumber: getEnv("FEDEX_ACCOUNT_NUMBER", ""),
+			MeterNumber:   getEnv("FEDEX_METER_NUMBER", ""),
+			APIURL:        getEnv("FEDEX_API_URL", "https://apis.fedex.com"),
+		},
+		USPS: USPSConfig{
+			ClientID:      getEnv("USPS_CLIENT_ID", ""),
+			ClientSecret:  getEnv("USPS_CLIENT_SECRET", ""),
+			AccountNumber: getEnv("USPS_ACCOUNT_NUMBER", ""),
+			APIURL:        getEnv("USPS_API_URL", "https://apis.usps.com"),
+		},
+		DHL: DHLConfig{
+			Username:      getEnv("DHL_USERNAME", ""),
+			Password:      getEnv("DHL_PASSWORD", ""),
+			AccountNumber: getEnv("DHL_ACCOUNT_NUMBER", ""),
+			APIURL:        getEnv("DHL_API_URL", "https://express.api.dhl.com/mydhlapi"),
+		},
+		OnTrac: OnTracConfig{
+			AccountNumber: getEnv("ONTRAC_ACCOUNT_NUMBER", ""),
+			Password:      getEnv("ONTRAC_PASSWORD", ""),
+			APIURL:        getEnv("ONTRAC_API_URL", "https://www.shipontrac.net/OnTracWebServices/OnTracServices.svc"),
+		},
+		CarrierQuoteTimeout: getDurationEnv("CARRIER_QUOTE_TIMEOUT", application.DefaultCarrierQuoteTimeout),
+		LabelPrinters:       getEnv("LABEL_PRINTERS", ""),
+		Printing:            loadPrintingConfig(),
+
+		TrackingPollEnabled:  getEnv("TRACKING_POLL_ENABLED", "true") == "true",
+		TrackingPoller:       loadTrackingPollerConfig(),
+		TrackingPolicy:       loadTrackingPolicy(),
+		TrackingWebhookToken: getEnv("TRACKING_WEBHOOK_TOKEN", ""),
+
+		ManifestCutoffEnabled: getEnv("MANIFEST_CUTOFF_ENABLED", "true") == "true",
+		ManifestCutoff:        loadManifestCutoffConfig(),
+
+		CustomsPolicy: loadCustomsPolicy(),
+	}
+}
+
+func loadTrackingPollerConfig() application.TrackingPollerConfig {
+	config := application.DefaultTrackingPollerConfig()
+	config.PollInterval = getDurationEnv("TRACKING_POLL_INTERVAL", config.PollInterval)
+	if batchSize, err := strconv.Atoi(getEnv("TRACKING_POLL_BATCH_SIZE", "")); err == nil && batchSize > 0 {
+		config.BatchSize = batchSize
+	}
+	return config
+}
+
+func loadManifestCutoffConfig() application.ManifestCutoffSchedulerConfig {
+	config := application.DefaultManifestCutoffSchedulerConfig()
+	config.CheckInterval = getDurationEnv("MANIFEST_CUTOFF_CHECK_INTERVAL", config.CheckInterval)
+	return config
+}
+
+func loadTrackingPolicy() domain.TrackingPollPolicy {
+	policy := domain.DefaultTrackingPollPolicy()
+	policy.PreTransitInterval = getDurationEnv("TRACKING_PRE_TRANSIT_INTERVAL", policy.PreTransitInterval)
+	policy.InTransitInterval = getDurationEnv("TRACKING_IN_TRANSIT_INTERVAL", policy.InTransitInterval)
+	policy.OutForDeliveryInterval = getDurationEnv("TRACKING_OUT_FOR_DELIVERY_INTERVAL", policy.OutForDeliveryInterval)
+	policy.ExceptionInterval = getDurationEnv("TRACKING_EXCEPTION_INTERVAL", policy.ExceptionInterval)
+	policy.MaxInterval = getDurationEnv("TRACKING_MAX_INTERVAL", policy.MaxInterval)
+	policy.MaxTrackingAge = getDurationEnv("TRACKING_MAX_AGE", policy.MaxTrackingAge)
+	return policy
+}
+
+// loadCustomsPolicy reads RESTRICTED_DESTINATIONS as a comma-separated list of
+// ISO country codes, replacing the default embargo list when set
+func loadCustomsPolicy() domain.CustomsPolicy {
+	policy := domain.DefaultCustomsPolicy()
+	if value := getEnv("RESTRICTED_DESTINATIONS", ""); value != "" {
+		policy.RestrictedCountries = nil
+		for _, code := range strings.Split(value, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				policy.RestrictedCountries = append(policy.RestrictedCountries, code)
+			}
+		}
 	}
+	return policy
+}
+
+func loadPrintingConfig() printing.RouterConfig {
+	config := printing.DefaultRouterConfig()
+	config.SendTimeout = getDurationEnv("PRINT_SEND_TIMEOUT", config.SendTimeout)
+	config.SpoolInterval = getDurationEnv("PRINT_SPOOL_INTERVAL", config.SpoolInterval)
+	return config
 }
 
 func getEnv(key, defaultValue string) string {
@@ -272,20 +550,31 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
 // HTTP Handlers
 func createShipmentHandler(service *application.ShippingApplicationService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
 
 		var req struct {
-			ShipmentID string            `json:"shipmentId" binding:"required"`
-			OrderID    string            `json:"orderId" binding:"required"`
-			PackageID  string            `json:"packageId" binding:"required"`
-			WaveID     string            `json:"waveId"`
-			Carrier    domain.Carrier    `json:"carrier" binding:"required"`
-			Package    domain.PackageInfo `json:"package" binding:"required"`
-			Recipient  domain.Address    `json:"recipient" binding:"required"`
-			Shipper    domain.Address    `json:"shipper" binding:"required"`
+			ShipmentID string                     `json:"shipmentId" binding:"required"`
+			OrderID    string                     `json:"orderId" binding:"required"`
+			SellerID   string                     `json:"sellerId"`
+			PackageID  string                     `json:"packageId" binding:"required"`
+			WaveID     string                     `json:"waveId"`
+			Carrier    domain.Carrier             `json:"carrier" binding:"required"`
+			Package    domain.PackageInfo         `json:"package" binding:"required"`
+			Recipient  domain.Address             `json:"recipient" binding:"required"`
+			Shipper    domain.Address             `json:"shipper" binding:"required"`
+			Customs    *domain.CustomsDeclaration `json:"customs"`
 		}
 
 		if err := c.ShouldBindJSON(&req); err != nil {
@@ -302,17 +591,23 @@ func createShipmentHandler(service *application.ShippingApplicationService, logg
 		cmd := application.CreateShipmentCommand{
 			ShipmentID: req.ShipmentID,
 			OrderID:    req.OrderID,
+			SellerID:   req.SellerID,
 			PackageID:  req.PackageID,
 			WaveID:     req.WaveID,
 			Carrier:    req.Carrier,
 			Package:    req.Package,
 			Recipient:  req.Recipient,
 			Shipper:    req.Shipper,
+			Customs:    req.Customs,
 		}
 
 		shipment, err := service.CreateShipment(c.Request.Context(), cmd)
 		if err != nil {
-			responder.RespondInternalError(err)
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
 			return
 		}
 
@@ -453,6 +748,274 @@ func confirmShipmentHandler(service *application.ShippingApplicationService, log
 	}
 }
 
+func rateShopHandler(service *application.RateShoppingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		shipmentID := c.Param("shipmentId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"shipment.id": shipmentID,
+		})
+
+		var req struct {
+			PromisedDeliveryAt *time.Time `json:"promisedDeliveryAt"`
+			Hazmat             bool       `json:"hazmat"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.ShopRatesCommand{
+			ShipmentID:         shipmentID,
+			PromisedDeliveryAt: req.PromisedDeliveryAt,
+			Hazmat:             req.Hazmat,
+		}
+
+		shipment, err := service.ShopRates(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, shipment)
+	}
+}
+
+func createCarrierLabelHandler(service *application.LabelService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		shipmentID := c.Param("shipmentId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"shipment.id": shipmentID,
+		})
+
+		var req struct {
+			LabelFormat string `json:"labelFormat"`
+			Return      bool   `json:"return"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.CreateLabelCommand{
+			ShipmentID:  shipmentID,
+			LabelFormat: req.LabelFormat,
+			Return:      req.Return,
+		}
+
+		shipment, err := service.CreateLabel(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, shipment)
+	}
+}
+
+func voidLabelHandler(service *application.LabelService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		trackingNumber := c.Param("trackingNumber")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"shipment.tracking_number": trackingNumber,
+		})
+
+		var req struct {
+			Reason string `json:"reason"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		shipment, err := service.VoidLabel(c.Request.Context(), application.VoidLabelCommand{
+			TrackingNumber: trackingNumber,
+			Reason:         req.Reason,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, shipment)
+	}
+}
+
+func getLabelDocumentHandler(service *application.LabelService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		shipmentID := c.Param("shipmentId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"shipment.id": shipmentID,
+		})
+
+		query := application.GetLabelDocumentQuery{
+			ShipmentID: shipmentID,
+			Return:     c.Query("return") == "true",
+		}
+
+		data, format, err := service.GetLabelDocument(c.Request.Context(), query)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.Data(http.StatusOK, labels.ContentType(format), data)
+	}
+}
+
+func printLabelHandler(service *application.LabelService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		shipmentID := c.Param("shipmentId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"shipment.id": shipmentID,
+		})
+
+		var req struct {
+			PrinterID string `json:"printerId"`
+			StationID string `json:"stationId"`
+			Return    bool   `json:"return"`
+			Copies    int    `json:"copies"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.PrinterID == "" && req.StationID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "printerId or stationId is required"})
+			return
+		}
+
+		cmd := application.PrintLabelCommand{
+			ShipmentID: shipmentID,
+			PrinterID:  req.PrinterID,
+			StationID:  req.StationID,
+			Return:     req.Return,
+			Copies:     req.Copies,
+		}
+
+		job, err := service.PrintLabel(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		status := http.StatusOK
+		if job.Status == string(domain.PrintJobStatusSpooled) {
+			status = http.StatusAccepted
+		}
+		c.JSON(status, job)
+	}
+}
+
+func setCustomsDeclarationHandler(service *application.CustomsService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		shipmentID := c.Param("shipmentId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"shipment.id": shipmentID,
+		})
+
+		var declaration domain.CustomsDeclaration
+		if err := c.ShouldBindJSON(&declaration); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.SetCustomsDeclarationCommand{
+			ShipmentID:  shipmentID,
+			Declaration: declaration,
+		}
+
+		shipment, err := service.SetCustomsDeclaration(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, shipment)
+	}
+}
+
+func getCommercialInvoiceHandler(service *application.CustomsService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		shipmentID := c.Param("shipmentId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"shipment.id": shipmentID,
+		})
+
+		data, err := service.RenderCommercialInvoice(c.Request.Context(), shipmentID)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.Data(http.StatusOK, labels.ContentType(domain.LabelFormatPDF), data)
+	}
+}
+
+func getCustomsFormHandler(service *application.CustomsService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		shipmentID := c.Param("shipmentId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"shipment.id": shipmentID,
+		})
+
+		data, err := service.RenderCustomsForm(c.Request.Context(), shipmentID)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.Data(http.StatusOK, labels.ContentType(domain.LabelFormatPDF), data)
+	}
+}
+
 func getByOrderHandler(service *application.ShippingApplicationService, logger *logging.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		responder := middleware.NewErrorResponder(c, logger.Logger)
@@ -809,3 +1372,296 @@ func getDispatchedTodayHandler(service *application.ManifestApplicationService,
 		c.JSON(http.StatusOK, manifests)
 	}
 }
+
+func getCarrierRuleHandler(service *application.RateShoppingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		sellerID := c.Param("sellerId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"seller.id": sellerID,
+		})
+
+		rule, err := service.GetSelectionRule(c.Request.Context(), sellerID)
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, rule)
+	}
+}
+
+func setCarrierRuleHandler(service *application.RateShoppingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		sellerID := c.Param("sellerId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"seller.id": sellerID,
+		})
+
+		var req struct {
+			Strategy          string   `json:"strategy" binding:"required"`
+			AllowedCarriers   []string `json:"allowedCarriers"`
+			ExcludedCarriers  []string `json:"excludedCarriers"`
+			MaxCost           float64  `json:"maxCost"`
+			RequireGuaranteed bool     `json:"requireGuaranteed"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.SetCarrierSelectionRuleCommand{
+			SellerID:          sellerID,
+			Strategy:          req.Strategy,
+			AllowedCarriers:   req.AllowedCarriers,
+			ExcludedCarriers:  req.ExcludedCarriers,
+			MaxCost:           req.MaxCost,
+			RequireGuaranteed: req.RequireGuaranteed,
+		}
+
+		rule, err := service.SetSelectionRule(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, rule)
+	}
+}
+
+func refreshTrackingHandler(service *application.TrackingService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		shipmentID := c.Param("shipmentId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"shipment.id": shipmentID,
+		})
+
+		shipment, err := service.RefreshTracking(c.Request.Context(), application.RefreshTrackingCommand{ShipmentID: shipmentID})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, shipment)
+	}
+}
+
+func pollTrackingHandler(service *application.TrackingService, config application.TrackingPollerConfig, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		result, err := service.PollDue(c.Request.Context(), config.BatchSize)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func trackingWebhookHandler(service *application.TrackingService, token string, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Webhook-Token")), []byte(token)) != 1 {
+			responder.RespondWithAppError(errors.ErrUnauthorized("invalid webhook token"))
+			return
+		}
+
+		carrierCode := c.Param("carrierCode")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"carrier.code": carrierCode,
+		})
+
+		payload, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		result, err := service.IngestWebhook(c.Request.Context(), application.IngestTrackingWebhookCommand{
+			CarrierCode: carrierCode,
+			Payload:     payload,
+		})
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func getBillOfLadingHandler(service *application.ManifestCutoffService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		manifestID := c.Param("manifestId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"manifest.id": manifestID,
+		})
+
+		data, err := service.RenderBillOfLading(c.Request.Context(), manifestID)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.Data(http.StatusOK, labels.ContentType(domain.LabelFormatPDF), data)
+	}
+}
+
+func getManifestSummaryHandler(service *application.ManifestCutoffService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		manifestID := c.Param("manifestId")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"manifest.id": manifestID,
+		})
+
+		data, err := service.RenderManifestSummary(c.Request.Context(), manifestID)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.Data(http.StatusOK, labels.ContentType(domain.LabelFormatPDF), data)
+	}
+}
+
+func runManifestCutoffHandler(service *application.ManifestCutoffService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		result, err := service.RunCutoffs(c.Request.Context(), time.Now())
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func getPickupSchedulesHandler(service *application.ManifestCutoffService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		schedules, err := service.GetPickupSchedules(c.Request.Context())
+		if err != nil {
+			responder.RespondInternalError(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, schedules)
+	}
+}
+
+func setPickupScheduleHandler(service *application.ManifestCutoffService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		carrierCode := c.Param("carrierCode")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"carrier.code": carrierCode,
+		})
+
+		var req struct {
+			CarrierName              string         `json:"carrierName"`
+			ServiceType              string         `json:"serviceType"`
+			PickupDays               []time.Weekday `json:"pickupDays"`
+			CutoffTime               string         `json:"cutoffTime" binding:"required"`
+			PickupTime               string         `json:"pickupTime" binding:"required"`
+			TimeZone                 string         `json:"timeZone"`
+			MissedPickupGraceMinutes int            `json:"missedPickupGraceMinutes"`
+			ShipFrom                 domain.Address `json:"shipFrom"`
+			Active                   *bool          `json:"active"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		cmd := application.SetPickupScheduleCommand{
+			CarrierCode:              carrierCode,
+			CarrierName:              req.CarrierName,
+			ServiceType:              req.ServiceType,
+			PickupDays:               req.PickupDays,
+			CutoffTime:               req.CutoffTime,
+			PickupTime:               req.PickupTime,
+			TimeZone:                 req.TimeZone,
+			MissedPickupGraceMinutes: req.MissedPickupGraceMinutes,
+			ShipFrom:                 req.ShipFrom,
+			Active:                   req.Active == nil || *req.Active,
+		}
+
+		schedule, err := service.SetPickupSchedule(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, schedule)
+	}
+}
+
+func deletePickupScheduleHandler(service *application.ManifestCutoffService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		carrierCode := c.Param("carrierCode")
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"carrier.code": carrierCode,
+		})
+
+		if err := service.DeletePickupSchedule(c.Request.Context(), carrierCode); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
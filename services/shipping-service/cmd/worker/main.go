@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/temporal"
 	"github.com/wms-platform/shipping-service/internal/activities"
+	"github.com/wms-platform/shipping-service/internal/domain"
+	"github.com/wms-platform/shipping-service/internal/infrastructure/labels"
 	mongoRepo "github.com/wms-platform/shipping-service/internal/infrastructure/mongodb"
 	"github.com/wms-platform/shipping-service/internal/workflows"
 )
@@ -54,7 +57,8 @@ func main() {
 	logger.Info("Connected to Temporal", "hostPort", config.Temporal.HostPort)
 
 	// Create activities
-	shippingActivities := activities.NewShippingActivities(repo, logger)
+	shippingActivities := activities.NewShippingActivities(repo, labels.NewRenderer(), logger)
+	shippingActivities.SetCustomsPolicy(config.CustomsPolicy)
 
 	// Create worker
 	workerOpts := temporal.DefaultWorkerOptions(temporal.TaskQueues.Shipping)
@@ -96,6 +100,8 @@ func main() {
 type Config struct {
 	MongoDB  *mongodb.Config
 	Temporal *temporal.Config
+
+	CustomsPolicy domain.CustomsPolicy
 }
 
 func loadConfig() *Config {
@@ -112,7 +118,24 @@ func loadConfig() *Config {
 			Namespace: getEnv("TEMPORAL_NAMESPACE", "default"),
 			Identity:  "shipping-worker",
 		},
+
+		CustomsPolicy: loadCustomsPolicy(),
+	}
+}
+
+// loadCustomsPolicy reads RESTRICTED_DESTINATIONS as a comma-separated list of
+// ISO country codes, replacing the default embargo list when set
+func loadCustomsPolicy() domain.CustomsPolicy {
+	policy := domain.DefaultCustomsPolicy()
+	if value := getEnv("RESTRICTED_DESTINATIONS", ""); value != "" {
+		policy.RestrictedCountries = nil
+		for _, code := range strings.Split(value, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				policy.RestrictedCountries = append(policy.RestrictedCountries, code)
+			}
+		}
 	}
+	return policy
 }
 
 func getEnv(key, defaultValue string) string {
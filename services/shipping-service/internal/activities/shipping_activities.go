@@ -2,6 +2,7 @@ package activities
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"log/slog"
 	"time"
@@ -9,22 +10,32 @@ import (
 	"github.com/google/uuid"
 	"github.com/wms-platform/shipping-service/internal/domain"
 	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/temporal"
 )
 
 // ShippingActivities contains activities for the shipping workflow
 type ShippingActivities struct {
-	repo   domain.ShipmentRepository
-	logger *slog.Logger
+	repo          domain.ShipmentRepository
+	renderer      domain.LabelRenderer
+	customsPolicy domain.CustomsPolicy
+	logger        *slog.Logger
 }
 
 // NewShippingActivities creates a new ShippingActivities instance
-func NewShippingActivities(repo domain.ShipmentRepository, logger *slog.Logger) *ShippingActivities {
+func NewShippingActivities(repo domain.ShipmentRepository, renderer domain.LabelRenderer, logger *slog.Logger) *ShippingActivities {
 	return &ShippingActivities{
-		repo:   repo,
-		logger: logger,
+		repo:          repo,
+		renderer:      renderer,
+		customsPolicy: domain.DefaultCustomsPolicy(),
+		logger:        logger,
 	}
 }
 
+// SetCustomsPolicy replaces the policy that blocks labels for restricted or undeclared shipments
+func (a *ShippingActivities) SetCustomsPolicy(policy domain.CustomsPolicy) {
+	a.customsPolicy = policy
+}
+
 // CreateShipment creates a new shipment
 func (a *ShippingActivities) CreateShipment(ctx context.Context, input map[string]string) (string, error) {
 	logger := activity.GetLogger(ctx)
@@ -105,13 +116,29 @@ func (a *ShippingActivities) GenerateShippingLabel(ctx context.Context, shipment
 		return nil, fmt.Errorf("shipment not found: %s", shipmentID)
 	}
 
+	// A restricted destination or missing declaration won't change on retry
+	if err := a.customsPolicy.Check(shipment); err != nil {
+		logger.Warn("Label blocked by customs policy", "shipmentId", shipmentID, "error", err)
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("label blocked by customs policy: %v", err),
+			"CustomsPolicyViolation",
+			err,
+		)
+	}
+
 	// Generate tracking number (in real impl, would call carrier API)
 	trackingNumber := generateTrackingNumber(shipment.Carrier.Code)
 
+	request := domain.NewLabelRequest(shipment, domain.LabelFormatPDF, false)
+	labelData, err := a.renderer.Render(domain.NewLabelDocument(request, shipment.Carrier.Code, trackingNumber), request.LabelFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render label: %w", err)
+	}
+
 	label := domain.ShippingLabel{
 		TrackingNumber: trackingNumber,
-		LabelFormat:    "PDF",
-		LabelData:      "", // Base64 encoded label data
+		LabelFormat:    request.LabelFormat,
+		LabelData:      base64.StdEncoding.EncodeToString(labelData),
 		LabelURL:       fmt.Sprintf("https://labels.example.com/%s.pdf", trackingNumber),
 		GeneratedAt:    time.Now(),
 	}
@@ -287,6 +314,8 @@ func getCarrierName(code string) string {
 		return "United States Postal Service"
 	case "DHL":
 		return "DHL Express"
+	case "ONTRAC":
+		return "OnTrac"
 	default:
 		return code
 	}
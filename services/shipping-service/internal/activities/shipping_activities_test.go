@@ -0,0 +1,86 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/shipping-service/internal/domain"
+	"github.com/wms-platform/shipping-service/internal/infrastructure/labels"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type mockShipmentRepo struct {
+	domain.ShipmentRepository
+	shipment *domain.Shipment
+	saved    int
+}
+
+func (m *mockShipmentRepo) FindByID(context.Context, string) (*domain.Shipment, error) {
+	return m.shipment, nil
+}
+
+func (m *mockShipmentRepo) Save(context.Context, *domain.Shipment) error {
+	m.saved++
+	return nil
+}
+
+func testShipment(country string) *domain.Shipment {
+	return domain.NewShipment("SHP-1", "ORD-1", "PKG-1", "WAVE-1",
+		domain.Carrier{Code: "UPS", Name: "UPS", ServiceType: "ground"},
+		domain.PackageInfo{PackageID: "PKG-1", Weight: 1, Dimensions: domain.Dimensions{Length: 30, Width: 20, Height: 10}},
+		domain.Address{Name: "Customer", Street1: "1 Main St", City: "Anytown", PostalCode: "00001", Country: country},
+		domain.Address{Name: "WMS Warehouse", Street1: "456 Warehouse Blvd", City: "Logistics City", State: "TX", PostalCode: "75001", Country: "US"},
+	)
+}
+
+func TestGenerateShippingLabelEnforcesCustomsPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		country  string
+		expected error
+	}{
+		{"restricted destination", "KP", domain.ErrRestrictedDestination},
+		{"international without declaration", "DE", domain.ErrCustomsDataMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockShipmentRepo{shipment: testShipment(tt.country)}
+			acts := NewShippingActivities(repo, labels.NewRenderer(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			var ts testsuite.WorkflowTestSuite
+			env := ts.NewTestActivityEnvironment()
+			env.RegisterActivity(acts.GenerateShippingLabel)
+
+			_, err := env.ExecuteActivity(acts.GenerateShippingLabel, "SHP-1")
+			require.Error(t, err)
+			var appErr *temporal.ApplicationError
+			require.True(t, errors.As(err, &appErr))
+			require.True(t, appErr.NonRetryable())
+			require.Equal(t, "CustomsPolicyViolation", appErr.Type())
+			require.Contains(t, err.Error(), tt.expected.Error())
+			require.Zero(t, repo.saved)
+			require.Nil(t, repo.shipment.Label)
+		})
+	}
+}
+
+func TestGenerateShippingLabelDomestic(t *testing.T) {
+	repo := &mockShipmentRepo{shipment: testShipment("US")}
+	acts := NewShippingActivities(repo, labels.NewRenderer(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	var ts testsuite.WorkflowTestSuite
+	env := ts.NewTestActivityEnvironment()
+	env.RegisterActivity(acts.GenerateShippingLabel)
+
+	value, err := env.ExecuteActivity(acts.GenerateShippingLabel, "SHP-1")
+	require.NoError(t, err)
+	var info LabelInfo
+	require.NoError(t, value.Get(&info))
+	require.NotEmpty(t, info.TrackingNumber)
+	require.Equal(t, 1, repo.saved)
+}
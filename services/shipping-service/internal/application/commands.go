@@ -10,12 +10,31 @@ import (
 type CreateShipmentCommand struct {
 	ShipmentID string
 	OrderID    string
+	SellerID   string
 	PackageID  string
 	WaveID     string
 	Carrier    domain.Carrier
 	Package    domain.PackageInfo
 	Recipient  domain.Address
 	Shipper    domain.Address
+	Customs    *domain.CustomsDeclaration // required when the shipment crosses a border
+}
+
+// ShopRatesCommand represents the command to rate shop a shipment across all carriers
+type ShopRatesCommand struct {
+	ShipmentID         string
+	PromisedDeliveryAt *time.Time
+	Hazmat             bool
+}
+
+// SetCarrierSelectionRuleCommand represents the command to configure a seller's carrier selection rule
+type SetCarrierSelectionRuleCommand struct {
+	SellerID          string
+	Strategy          string
+	AllowedCarriers   []string
+	ExcludedCarriers  []string
+	MaxCost           float64
+	RequireGuaranteed bool
 }
 
 // GenerateLabelCommand represents the command to generate a shipping label
@@ -24,6 +43,34 @@ type GenerateLabelCommand struct {
 	Label      domain.ShippingLabel
 }
 
+// CreateLabelCommand represents the command to request a carrier label for a shipment
+type CreateLabelCommand struct {
+	ShipmentID  string
+	LabelFormat string // PDF or ZPL, defaults to PDF
+	Return      bool
+}
+
+// VoidLabelCommand represents the command to cancel a shipment's label with its carrier
+type VoidLabelCommand struct {
+	TrackingNumber string
+	Reason         string
+}
+
+// SetCustomsDeclarationCommand represents the command to declare a shipment's contents to customs
+type SetCustomsDeclarationCommand struct {
+	ShipmentID  string
+	Declaration domain.CustomsDeclaration
+}
+
+// PrintLabelCommand represents the command to print a shipment label at a station printer
+type PrintLabelCommand struct {
+	ShipmentID string
+	PrinterID  string
+	StationID  string
+	Return     bool
+	Copies     int
+}
+
 // AddToManifestCommand represents the command to add shipment to manifest
 type AddToManifestCommand struct {
 	ShipmentID string
@@ -36,11 +83,28 @@ type ConfirmShipmentCommand struct {
 	EstimatedDelivery *time.Time
 }
 
+// RefreshTrackingCommand represents the command to poll the carrier for a shipment now
+type RefreshTrackingCommand struct {
+	ShipmentID string
+}
+
+// IngestTrackingWebhookCommand represents a tracking push received from a carrier
+type IngestTrackingWebhookCommand struct {
+	CarrierCode string
+	Payload     []byte
+}
+
 // GetShipmentQuery represents the query to get a shipment by ID
 type GetShipmentQuery struct {
 	ShipmentID string
 }
 
+// GetLabelDocumentQuery represents the query to download a shipment label
+type GetLabelDocumentQuery struct {
+	ShipmentID string
+	Return     bool
+}
+
 // GetByOrderQuery represents the query to get shipment by order ID
 type GetByOrderQuery struct {
 	OrderID string
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// CustomsService records customs declarations on shipments and renders their customs paperwork
+type CustomsService struct {
+	repo      domain.ShipmentRepository
+	documents domain.CustomsDocumentRenderer
+	logger    *logging.Logger
+}
+
+// NewCustomsService creates a new CustomsService
+func NewCustomsService(
+	repo domain.ShipmentRepository,
+	documents domain.CustomsDocumentRenderer,
+	logger *logging.Logger,
+) *CustomsService {
+	return &CustomsService{
+		repo:      repo,
+		documents: documents,
+		logger:    logger,
+	}
+}
+
+// SetCustomsDeclaration records the customs declaration of a shipment before it is labeled
+func (s *CustomsService) SetCustomsDeclaration(ctx context.Context, cmd SetCustomsDeclarationCommand) (*ShipmentDTO, error) {
+	shipment, err := updateShipment(ctx, s.repo, s.logger, cmd.ShipmentID, func(shipment *domain.Shipment) error {
+		if err := shipment.SetCustomsDeclaration(cmd.Declaration); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Customs declaration set",
+		"shipmentId", cmd.ShipmentID,
+		"items", len(shipment.Customs.Items),
+		"totalValue", shipment.Customs.TotalValue(),
+		"incoterm", shipment.Customs.Incoterm,
+	)
+	return ToShipmentDTO(shipment), nil
+}
+
+// RenderCommercialInvoice returns the commercial invoice PDF for an international shipment
+func (s *CustomsService) RenderCommercialInvoice(ctx context.Context, shipmentID string) ([]byte, error) {
+	shipment, err := s.declaredShipment(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.documents.RenderCommercialInvoice(shipment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render commercial invoice: %w", err)
+	}
+	return data, nil
+}
+
+// RenderCustomsForm returns the CN22 or CN23 PDF for an international shipment
+func (s *CustomsService) RenderCustomsForm(ctx context.Context, shipmentID string) ([]byte, error) {
+	shipment, err := s.declaredShipment(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.documents.RenderCustomsForm(shipment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render customs form: %w", err)
+	}
+	return data, nil
+}
+
+func (s *CustomsService) declaredShipment(ctx context.Context, shipmentID string) (*domain.Shipment, error) {
+	shipment, err := s.findShipment(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.Customs == nil {
+		return nil, errors.ErrNotFound("customs declaration")
+	}
+	return shipment, nil
+}
+
+func (s *CustomsService) findShipment(ctx context.Context, shipmentID string) (*domain.Shipment, error) {
+	shipment, err := s.repo.FindByID(ctx, shipmentID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get shipment", "shipmentId", shipmentID)
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	if shipment == nil {
+		return nil, errors.ErrNotFound("shipment")
+	}
+	return shipment, nil
+}
+
+// checkCustoms applies the customs policy to a shipment about to be labeled
+func checkCustoms(policy domain.CustomsPolicy, shipment *domain.Shipment) error {
+	if err := policy.Check(shipment); err != nil {
+		return errors.ErrValidation(err.Error())
+	}
+	return nil
+}
@@ -6,13 +6,19 @@ import "time"
 type ShipmentDTO struct {
 	ShipmentID        string             `json:"shipmentId"`
 	OrderID           string             `json:"orderId"`
+	SellerID          string             `json:"sellerId,omitempty"`
 	PackageID         string             `json:"packageId"`
 	WaveID            string             `json:"waveId,omitempty"`
 	Status            string             `json:"status"`
 	Carrier           CarrierDTO         `json:"carrier"`
+	RateSelection     *RateSelectionDTO  `json:"rateSelection,omitempty"`
 	Label             *ShippingLabelDTO  `json:"label,omitempty"`
+	ReturnLabel       *ShippingLabelDTO  `json:"returnLabel,omitempty"`
 	Manifest          *ManifestSummaryDTO `json:"manifest,omitempty"`
+	Tracking          *TrackingDTO       `json:"tracking,omitempty"`
 	Package           PackageInfoDTO     `json:"package"`
+	BillableWeight    *BillableWeightDTO `json:"billableWeight,omitempty"`
+	Customs           *CustomsDeclarationDTO `json:"customs,omitempty"`
 	Recipient         AddressDTO         `json:"recipient"`
 	Shipper           AddressDTO         `json:"shipper"`
 	ServiceType       string             `json:"serviceType"`
@@ -33,6 +39,90 @@ type CarrierDTO struct {
 	ServiceType string `json:"serviceType"`
 }
 
+// RateSelectionDTO represents the audit record of a rate shopping decision
+type RateSelectionDTO struct {
+	Strategy     string                   `json:"strategy"`
+	Selected     RateQuoteDTO             `json:"selected"`
+	Alternatives []RejectedRateDTO        `json:"alternatives"`
+	Failures     []CarrierQuoteFailureDTO `json:"failures,omitempty"`
+	CostSavings  float64                  `json:"costSavings"`
+	SelectedAt   time.Time                `json:"selectedAt"`
+}
+
+// RateQuoteDTO represents a carrier service quote
+type RateQuoteDTO struct {
+	CarrierCode       string    `json:"carrierCode"`
+	ServiceType       string    `json:"serviceType"`
+	ServiceName       string    `json:"serviceName"`
+	TotalCost         float64   `json:"totalCost"`
+	Currency          string    `json:"currency"`
+	EstimatedDelivery time.Time `json:"estimatedDelivery"`
+	IsGuaranteed      bool      `json:"isGuaranteed"`
+	BillableWeight    float64   `json:"billableWeight"`
+	DimApplied        bool      `json:"dimApplied"`
+}
+
+// CustomsDeclarationDTO represents the customs declaration of an international shipment
+type CustomsDeclarationDTO struct {
+	ContentsType  string           `json:"contentsType"`
+	Incoterm      string           `json:"incoterm"`
+	Currency      string           `json:"currency"`
+	Items         []CustomsItemDTO `json:"items"`
+	InvoiceNumber string           `json:"invoiceNumber,omitempty"`
+	ExporterID    string           `json:"exporterId,omitempty"`
+	ImporterTaxID string           `json:"importerTaxId,omitempty"`
+	TotalValue    float64          `json:"totalValue"`
+	TotalWeight   float64          `json:"totalWeight"`
+	FormType      string           `json:"formType"` // CN22 or CN23
+	DeclaredAt    time.Time        `json:"declaredAt"`
+}
+
+// CustomsItemDTO represents one line of a customs declaration
+type CustomsItemDTO struct {
+	SKU             string  `json:"sku"`
+	Description     string  `json:"description"`
+	HSCode          string  `json:"hsCode"`
+	CountryOfOrigin string  `json:"countryOfOrigin"`
+	Quantity        int     `json:"quantity"`
+	UnitValue       float64 `json:"unitValue"`
+	Weight          float64 `json:"weight"`
+}
+
+// BillableWeightDTO represents a package's billable weight under the carrier's DIM rules
+type BillableWeightDTO struct {
+	CarrierCode        string  `json:"carrierCode"`
+	ActualWeight       float64 `json:"actualWeight"`
+	DimWeight          float64 `json:"dimWeight"`
+	BillableWeight     float64 `json:"billableWeight"`
+	Divisor            float64 `json:"divisor"`
+	DimApplied         bool    `json:"dimApplied"`
+	AdditionalHandling bool    `json:"additionalHandling"`
+	Oversize           bool    `json:"oversize"`
+}
+
+// RejectedRateDTO represents a quote that was not selected
+type RejectedRateDTO struct {
+	Quote  RateQuoteDTO `json:"quote"`
+	Reason string       `json:"reason"`
+}
+
+// CarrierQuoteFailureDTO represents a carrier that failed to quote
+type CarrierQuoteFailureDTO struct {
+	CarrierCode string `json:"carrierCode"`
+	Error       string `json:"error"`
+}
+
+// CarrierSelectionRuleDTO represents a seller's carrier selection rule
+type CarrierSelectionRuleDTO struct {
+	SellerID          string     `json:"sellerId"`
+	Strategy          string     `json:"strategy"`
+	AllowedCarriers   []string   `json:"allowedCarriers,omitempty"`
+	ExcludedCarriers  []string   `json:"excludedCarriers,omitempty"`
+	MaxCost           float64    `json:"maxCost,omitempty"`
+	RequireGuaranteed bool       `json:"requireGuaranteed"`
+	UpdatedAt         *time.Time `json:"updatedAt,omitempty"`
+}
+
 // ShippingLabelDTO represents a shipping label
 type ShippingLabelDTO struct {
 	TrackingNumber string    `json:"trackingNumber"`
@@ -42,6 +132,58 @@ type ShippingLabelDTO struct {
 	GeneratedAt    time.Time `json:"generatedAt"`
 }
 
+// TrackingDTO represents the carrier tracking state of a shipment
+type TrackingDTO struct {
+	Milestone       string             `json:"milestone"`
+	CarrierStatus   string             `json:"carrierStatus"`
+	StatusDetail    string             `json:"statusDetail,omitempty"`
+	CurrentLocation string             `json:"currentLocation,omitempty"`
+	Events          []TrackingEventDTO `json:"events,omitempty"`
+	LastEventAt     *time.Time         `json:"lastEventAt,omitempty"`
+	LastPolledAt    *time.Time         `json:"lastPolledAt,omitempty"`
+	NextPollAt      *time.Time         `json:"nextPollAt,omitempty"`
+	PollingStopped  bool               `json:"pollingStopped"`
+	LastError       string             `json:"lastError,omitempty"`
+}
+
+// TrackingEventDTO represents a single carrier scan
+type TrackingEventDTO struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Location    string    `json:"location,omitempty"`
+	Status      string    `json:"status"`
+	Description string    `json:"description,omitempty"`
+}
+
+// TrackingPollResultDTO summarizes one tracking poll cycle
+type TrackingPollResultDTO struct {
+	Polled  int `json:"polled"`
+	Updated int `json:"updated"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
+
+// TrackingWebhookResultDTO summarizes a carrier tracking webhook delivery
+type TrackingWebhookResultDTO struct {
+	Received int      `json:"received"`
+	Updated  int      `json:"updated"`
+	Unknown  []string `json:"unknownTrackingNumbers,omitempty"`
+}
+
+// PrintJobDTO represents a label print job
+type PrintJobDTO struct {
+	JobID      string     `json:"jobId"`
+	PrinterID  string     `json:"printerId"`
+	StationID  string     `json:"stationId,omitempty"`
+	ShipmentID string     `json:"shipmentId,omitempty"`
+	Format     string     `json:"format"`
+	Copies     int        `json:"copies"`
+	Status     string     `json:"status"`
+	Attempts   int        `json:"attempts"`
+	LastError  string     `json:"lastError,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	PrintedAt  *time.Time `json:"printedAt,omitempty"`
+}
+
 // ManifestSummaryDTO represents a shipping manifest summary (embedded in shipments)
 type ManifestSummaryDTO struct {
 	ManifestID    string    `json:"manifestId"`
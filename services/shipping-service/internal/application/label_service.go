@@ -0,0 +1,229 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	stdErrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// LabelService generates carrier labels for shipments and routes them to station printers
+type LabelService struct {
+	repo          domain.ShipmentRepository
+	carriers      []domain.CarrierService
+	printer       domain.LabelPrinter
+	customsPolicy domain.CustomsPolicy
+	logger        *logging.Logger
+}
+
+// NewLabelService creates a new LabelService
+func NewLabelService(
+	repo domain.ShipmentRepository,
+	carriers []domain.CarrierService,
+	printer domain.LabelPrinter,
+	logger *logging.Logger,
+) *LabelService {
+	return &LabelService{
+		repo:          repo,
+		carriers:      carriers,
+		printer:       printer,
+		customsPolicy: domain.DefaultCustomsPolicy(),
+		logger:        logger,
+	}
+}
+
+// SetCustomsPolicy replaces the policy that blocks labels for restricted or undeclared shipments
+func (s *LabelService) SetCustomsPolicy(policy domain.CustomsPolicy) {
+	s.customsPolicy = policy
+}
+
+// CreateLabel requests a label from the shipment's carrier and stores it on the shipment
+func (s *LabelService) CreateLabel(ctx context.Context, cmd CreateLabelCommand) (*ShipmentDTO, error) {
+	shipment, err := s.findShipment(ctx, cmd.ShipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	carrier := findCarrier(s.carriers, shipment.Carrier.Code)
+	if carrier == nil {
+		return nil, errors.ErrValidation(fmt.Sprintf("no carrier integration registered for %s", shipment.Carrier.Code))
+	}
+
+	if err := checkCustoms(s.customsPolicy, shipment); err != nil {
+		s.logger.Warn("Label blocked by customs policy", "shipmentId", cmd.ShipmentID, "error", err.Error())
+		return nil, err
+	}
+	if shipment.IsInternational() && !carrier.GetCapabilities().SupportsInternational {
+		return nil, errors.ErrValidation(fmt.Sprintf("carrier %s does not ship internationally", shipment.Carrier.Code))
+	}
+
+	label, err := carrier.GenerateLabel(ctx, domain.NewLabelRequest(shipment, cmd.LabelFormat, cmd.Return))
+	if err != nil {
+		if stdErrors.Is(err, domain.ErrUnsupportedLabelFormat) {
+			return nil, errors.ErrValidation(err.Error())
+		}
+		s.logger.WithError(err).Error("Carrier label request failed", "shipmentId", cmd.ShipmentID, "carrier", shipment.Carrier.Code)
+		return nil, errors.ErrServiceUnavailable(shipment.Carrier.Code).Wrap(err)
+	}
+
+	shipment, err = updateShipment(ctx, s.repo, s.logger, cmd.ShipmentID, func(shipment *domain.Shipment) error {
+		var err error
+		if cmd.Return {
+			err = shipment.AttachReturnLabel(*label)
+		} else {
+			err = shipment.GenerateLabel(*label)
+		}
+		if err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Events are saved to outbox by repository in transaction
+
+	s.logger.Info("Label created", "shipmentId", cmd.ShipmentID, "trackingNumber", label.TrackingNumber, "format", label.LabelFormat, "return", cmd.Return)
+	return ToShipmentDTO(shipment), nil
+}
+
+// VoidLabel cancels a label with its carrier so it isn't billed and returns the shipment to
+// pending for a new label. Voiding a label that was voided already changes nothing. A carrier
+// that rejects the void, e.g. because the package was picked up, fails with a validation error.
+func (s *LabelService) VoidLabel(ctx context.Context, cmd VoidLabelCommand) (*ShipmentDTO, error) {
+	shipment, err := s.repo.FindByTrackingNumber(ctx, cmd.TrackingNumber)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get shipment", "trackingNumber", cmd.TrackingNumber)
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	if shipment == nil {
+		return nil, errors.ErrNotFound("shipment")
+	}
+	if shipment.LabelVoided(cmd.TrackingNumber) {
+		return ToShipmentDTO(shipment), nil
+	}
+	if shipment.Label == nil || shipment.Label.TrackingNumber != cmd.TrackingNumber {
+		return nil, errors.ErrValidation(domain.ErrLabelNotCurrent.Error())
+	}
+
+	carrier := findCarrier(s.carriers, shipment.Carrier.Code)
+	if carrier == nil {
+		return nil, errors.ErrValidation(fmt.Sprintf("no carrier integration registered for %s", shipment.Carrier.Code))
+	}
+	if err := carrier.CancelShipment(ctx, cmd.TrackingNumber); err != nil {
+		var carrierErr *domain.CarrierError
+		if stdErrors.As(err, &carrierErr) && !carrierErr.Retryable {
+			s.logger.Warn("Carrier rejected label void", "trackingNumber", cmd.TrackingNumber, "carrier", shipment.Carrier.Code, "error", err.Error())
+			return nil, errors.ErrValidation(fmt.Sprintf("carrier rejected the void: %s", carrierErr.Message))
+		}
+		s.logger.WithError(err).Error("Carrier void request failed", "trackingNumber", cmd.TrackingNumber, "carrier", shipment.Carrier.Code)
+		return nil, errors.ErrServiceUnavailable(shipment.Carrier.Code).Wrap(err)
+	}
+
+	shipment, err = updateShipment(ctx, s.repo, s.logger, shipment.ShipmentID, func(shipment *domain.Shipment) error {
+		if err := shipment.VoidLabel(cmd.TrackingNumber, cmd.Reason); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Label voided", "shipmentId", shipment.ShipmentID, "trackingNumber", cmd.TrackingNumber, "reason", cmd.Reason)
+	return ToShipmentDTO(shipment), nil
+}
+
+// GetLabelDocument returns the decoded label bytes and their format
+func (s *LabelService) GetLabelDocument(ctx context.Context, query GetLabelDocumentQuery) ([]byte, string, error) {
+	shipment, err := s.findShipment(ctx, query.ShipmentID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	label, err := shipmentLabel(shipment, query.Return)
+	if err != nil {
+		return nil, "", errors.ErrNotFound("label")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(label.LabelData)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode label data: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, "", errors.ErrValidation("label has no printable data, regenerate it through the carrier")
+	}
+	return data, label.LabelFormat, nil
+}
+
+// PrintLabel sends the shipment's label to a printer, spooling it if the printer is offline.
+// Station printers take raw ZPL, so the label must have been created in ZPL.
+func (s *LabelService) PrintLabel(ctx context.Context, cmd PrintLabelCommand) (*PrintJobDTO, error) {
+	data, format, err := s.GetLabelDocument(ctx, GetLabelDocumentQuery{ShipmentID: cmd.ShipmentID, Return: cmd.Return})
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.printer.Print(ctx, domain.PrintRequest{
+		PrinterID:  cmd.PrinterID,
+		StationID:  cmd.StationID,
+		ShipmentID: cmd.ShipmentID,
+		Format:     format,
+		Data:       data,
+		Copies:     cmd.Copies,
+	})
+	if stdErrors.Is(err, domain.ErrPrinterNotFound) || stdErrors.Is(err, domain.ErrLabelNotPrintable) {
+		return nil, errors.ErrValidation(err.Error())
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to print label", "shipmentId", cmd.ShipmentID)
+		return nil, fmt.Errorf("failed to print label: %w", err)
+	}
+
+	s.logger.Info("Label print job routed", "shipmentId", cmd.ShipmentID, "jobId", job.JobID, "printerId", job.PrinterID, "status", job.Status)
+	return ToPrintJobDTO(job), nil
+}
+
+func (s *LabelService) findShipment(ctx context.Context, shipmentID string) (*domain.Shipment, error) {
+	shipment, err := s.repo.FindByID(ctx, shipmentID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get shipment", "shipmentId", shipmentID)
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	if shipment == nil {
+		return nil, errors.ErrNotFound("shipment")
+	}
+	return shipment, nil
+}
+
+// shipmentLabel returns the outbound or return label of a shipment
+func shipmentLabel(shipment *domain.Shipment, isReturn bool) (*domain.ShippingLabel, error) {
+	if isReturn {
+		if shipment.ReturnLabel == nil {
+			return nil, domain.ErrNoReturnLabel
+		}
+		return shipment.ReturnLabel, nil
+	}
+	if shipment.Label == nil {
+		return nil, domain.ErrNoLabel
+	}
+	return shipment.Label, nil
+}
+
+// findCarrier returns the registered carrier integration for a carrier code
+func findCarrier(carriers []domain.CarrierService, code string) domain.CarrierService {
+	for _, carrier := range carriers {
+		if strings.EqualFold(carrier.GetCarrierCode(), code) {
+			return carrier
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+// ManifestCutoffScheduler periodically closes manifests at carrier cutoffs and checks for missed pickups
+type ManifestCutoffScheduler struct {
+	service  *ManifestCutoffService
+	config   ManifestCutoffSchedulerConfig
+	logger   *logging.Logger
+	mu       sync.RWMutex
+	running  bool
+	stopChan chan struct{}
+}
+
+// ManifestCutoffSchedulerConfig configuration for the manifest cutoff scheduler
+type ManifestCutoffSchedulerConfig struct {
+	// CheckInterval is how often to look for passed cutoffs and missed pickups
+	CheckInterval time.Duration `json:"checkInterval"`
+}
+
+// DefaultManifestCutoffSchedulerConfig returns default configuration
+func DefaultManifestCutoffSchedulerConfig() ManifestCutoffSchedulerConfig {
+	return ManifestCutoffSchedulerConfig{
+		CheckInterval: 1 * time.Minute,
+	}
+}
+
+// NewManifestCutoffScheduler creates a new manifest cutoff scheduler
+func NewManifestCutoffScheduler(
+	service *ManifestCutoffService,
+	config ManifestCutoffSchedulerConfig,
+	logger *logging.Logger,
+) *ManifestCutoffScheduler {
+	return &ManifestCutoffScheduler{
+		service:  service,
+		config:   config,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the periodic cutoff check
+func (s *ManifestCutoffScheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return fmt.Errorf("manifest cutoff scheduler is already running")
+	}
+	s.running = true
+	s.stopChan = make(chan struct{})
+	s.mu.Unlock()
+
+	go s.run(ctx)
+	return nil
+}
+
+// Stop stops the periodic cutoff check
+func (s *ManifestCutoffScheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		close(s.stopChan)
+		s.running = false
+	}
+}
+
+// IsRunning returns whether the scheduler is running
+func (s *ManifestCutoffScheduler) IsRunning() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running
+}
+
+// run is the main loop for the manifest cutoff scheduler
+func (s *ManifestCutoffScheduler) run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopChan:
+			return
+		case <-ticker.C:
+			if _, err := s.service.RunCutoffs(ctx, time.Now()); err != nil {
+				s.logger.WithError(err).Warn("Manifest cutoff run failed")
+			}
+		}
+	}
+}
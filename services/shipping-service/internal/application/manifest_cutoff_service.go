@@ -0,0 +1,516 @@
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// errManifestUnchanged stops an update that has nothing to record on the manifest
+var errManifestUnchanged = stdErrors.New("manifest unchanged")
+
+// ManifestCutoffService closes open manifests at each carrier's pickup cutoff, files the
+// carrier manifest, issues the bill of lading and rolls late packages to the next manifest.
+// Closed manifests still waiting after their pickup raise wms.shipping.manifest-pickup-missed.
+type ManifestCutoffService struct {
+	schedules domain.PickupScheduleRepository
+	manifests ManifestRepository
+	shipments domain.ShipmentRepository
+	carriers  []domain.CarrierService
+	documents domain.ManifestDocumentRenderer
+	logger    *logging.Logger
+}
+
+// NewManifestCutoffService creates a new ManifestCutoffService
+func NewManifestCutoffService(
+	schedules domain.PickupScheduleRepository,
+	manifests ManifestRepository,
+	shipments domain.ShipmentRepository,
+	carriers []domain.CarrierService,
+	documents domain.ManifestDocumentRenderer,
+	logger *logging.Logger,
+) *ManifestCutoffService {
+	return &ManifestCutoffService{
+		schedules: schedules,
+		manifests: manifests,
+		shipments: shipments,
+		carriers:  carriers,
+		documents: documents,
+		logger:    logger,
+	}
+}
+
+// SetPickupScheduleCommand represents the command to configure a carrier's pickup at the facility
+type SetPickupScheduleCommand struct {
+	CarrierCode              string         `json:"carrierCode"`
+	CarrierName              string         `json:"carrierName"`
+	ServiceType              string         `json:"serviceType"`
+	PickupDays               []time.Weekday `json:"pickupDays"`
+	CutoffTime               string         `json:"cutoffTime"`
+	PickupTime               string         `json:"pickupTime"`
+	TimeZone                 string         `json:"timeZone"`
+	MissedPickupGraceMinutes int            `json:"missedPickupGraceMinutes"`
+	ShipFrom                 domain.Address `json:"shipFrom"`
+	Active                   bool           `json:"active"`
+}
+
+// PickupScheduleDTO represents a carrier pickup schedule response
+type PickupScheduleDTO struct {
+	FacilityID               string         `json:"facilityId"`
+	CarrierCode              string         `json:"carrierCode"`
+	CarrierName              string         `json:"carrierName"`
+	ServiceType              string         `json:"serviceType,omitempty"`
+	PickupDays               []time.Weekday `json:"pickupDays,omitempty"`
+	CutoffTime               string         `json:"cutoffTime"`
+	PickupTime               string         `json:"pickupTime"`
+	TimeZone                 string         `json:"timeZone"`
+	MissedPickupGraceMinutes int            `json:"missedPickupGraceMinutes"`
+	ShipFrom                 domain.Address `json:"shipFrom"`
+	Active                   bool           `json:"active"`
+	NextCutoff               *time.Time     `json:"nextCutoff,omitempty"`
+	NextPickup               *time.Time     `json:"nextPickup,omitempty"`
+	UpdatedAt                time.Time      `json:"updatedAt"`
+}
+
+// CutoffRunResultDTO summarizes one cutoff cycle
+type CutoffRunResultDTO struct {
+	Closed       []string `json:"closed"`
+	RolledOver   int      `json:"rolledOver"`
+	PickupAlerts []string `json:"pickupAlerts"`
+	Failed       int      `json:"failed"`
+}
+
+// SetPickupSchedule creates or replaces the carrier's pickup schedule for the facility in context
+func (s *ManifestCutoffService) SetPickupSchedule(ctx context.Context, cmd SetPickupScheduleCommand) (*PickupScheduleDTO, error) {
+	tc := tenant.FromContextOptional(ctx)
+	schedule := &domain.CarrierPickupSchedule{
+		TenantID:                 tc.TenantID,
+		FacilityID:               tc.FacilityID,
+		CarrierCode:              strings.ToUpper(cmd.CarrierCode),
+		CarrierName:              cmd.CarrierName,
+		ServiceType:              cmd.ServiceType,
+		PickupDays:               cmd.PickupDays,
+		CutoffTime:               cmd.CutoffTime,
+		PickupTime:               cmd.PickupTime,
+		TimeZone:                 cmd.TimeZone,
+		MissedPickupGraceMinutes: cmd.MissedPickupGraceMinutes,
+		ShipFrom:                 cmd.ShipFrom,
+		Active:                   cmd.Active,
+	}
+	if schedule.TimeZone == "" {
+		schedule.TimeZone = "UTC"
+	}
+
+	if err := schedule.Validate(); err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+
+	if err := s.schedules.Save(ctx, schedule); err != nil {
+		s.logger.WithError(err).Error("Failed to save pickup schedule", "carrierCode", schedule.CarrierCode)
+		return nil, fmt.Errorf("failed to save pickup schedule: %w", err)
+	}
+
+	s.logger.Info("Pickup schedule updated",
+		"facilityId", schedule.FacilityID,
+		"carrierCode", schedule.CarrierCode,
+		"cutoffTime", schedule.CutoffTime,
+		"pickupTime", schedule.PickupTime,
+	)
+	return toPickupScheduleDTO(schedule, time.Now()), nil
+}
+
+// GetPickupSchedules returns the pickup schedules for the facility in context
+func (s *ManifestCutoffService) GetPickupSchedules(ctx context.Context) ([]PickupScheduleDTO, error) {
+	schedules, err := s.schedules.FindAll(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get pickup schedules")
+		return nil, fmt.Errorf("failed to get pickup schedules: %w", err)
+	}
+
+	now := time.Now()
+	dtos := make([]PickupScheduleDTO, len(schedules))
+	for i, schedule := range schedules {
+		dtos[i] = *toPickupScheduleDTO(schedule, now)
+	}
+	return dtos, nil
+}
+
+// DeletePickupSchedule removes the carrier's pickup schedule for the facility in context
+func (s *ManifestCutoffService) DeletePickupSchedule(ctx context.Context, carrierCode string) error {
+	schedule, err := s.schedules.FindByCarrier(ctx, strings.ToUpper(carrierCode))
+	if err != nil {
+		return fmt.Errorf("failed to get pickup schedule: %w", err)
+	}
+	if schedule == nil {
+		return errors.ErrNotFound("pickup schedule")
+	}
+
+	if err := s.schedules.Delete(ctx, schedule.CarrierCode); err != nil {
+		s.logger.WithError(err).Error("Failed to delete pickup schedule", "carrierCode", schedule.CarrierCode)
+		return fmt.Errorf("failed to delete pickup schedule: %w", err)
+	}
+	return nil
+}
+
+// RunCutoffs closes every open manifest whose carrier cutoff has passed and raises
+// alerts for closed manifests that missed their pickup. Closed manifests whose carrier
+// filing was interrupted are filed first. Without a tenant in ctx it processes all facilities.
+func (s *ManifestCutoffService) RunCutoffs(ctx context.Context, now time.Time) (*CutoffRunResultDTO, error) {
+	schedules, err := s.schedules.FindActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pickup schedules: %w", err)
+	}
+
+	result := &CutoffRunResultDTO{Closed: []string{}, PickupAlerts: []string{}}
+	for _, schedule := range schedules {
+		scheduleCtx := tenant.ToContext(ctx, &tenant.Context{
+			TenantID:   schedule.TenantID,
+			FacilityID: schedule.FacilityID,
+		})
+
+		resumed, err := s.resumeCarrierFilings(scheduleCtx, schedule, now)
+		if err != nil {
+			result.Failed++
+			s.logger.Warn("Resuming carrier manifest filing failed",
+				"facilityId", schedule.FacilityID,
+				"carrierCode", schedule.CarrierCode,
+				"error", err.Error(),
+			)
+		}
+		result.Closed = append(result.Closed, resumed...)
+
+		manifest, rolledOver, err := s.closeAtCutoff(scheduleCtx, schedule, now)
+		if err != nil {
+			result.Failed++
+			s.logger.Warn("Manifest cutoff failed",
+				"facilityId", schedule.FacilityID,
+				"carrierCode", schedule.CarrierCode,
+				"error", err.Error(),
+			)
+		} else if manifest != nil {
+			result.Closed = append(result.Closed, manifest.ManifestID)
+			result.RolledOver += rolledOver
+		}
+
+		alerted, err := s.checkMissedPickups(scheduleCtx, schedule, now)
+		if err != nil {
+			result.Failed++
+			s.logger.Warn("Missed pickup check failed",
+				"facilityId", schedule.FacilityID,
+				"carrierCode", schedule.CarrierCode,
+				"error", err.Error(),
+			)
+		}
+		result.PickupAlerts = append(result.PickupAlerts, alerted...)
+	}
+
+	if len(result.Closed) > 0 || len(result.PickupAlerts) > 0 {
+		s.logger.Info("Manifest cutoff run completed",
+			"closed", len(result.Closed),
+			"rolledOver", result.RolledOver,
+			"pickupAlerts", len(result.PickupAlerts),
+			"failed", result.Failed,
+		)
+	}
+	return result, nil
+}
+
+// RenderBillOfLading returns the bill of lading PDF for a closed manifest
+func (s *ManifestCutoffService) RenderBillOfLading(ctx context.Context, manifestID string) ([]byte, error) {
+	manifest, err := s.closedManifest(ctx, manifestID)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.BillOfLading == nil {
+		return nil, errors.ErrNotFound("bill of lading")
+	}
+
+	data, err := s.documents.RenderBillOfLading(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render bill of lading: %w", err)
+	}
+	return data, nil
+}
+
+// RenderManifestSummary returns the manifest summary PDF for a closed manifest
+func (s *ManifestCutoffService) RenderManifestSummary(ctx context.Context, manifestID string) ([]byte, error) {
+	manifest, err := s.closedManifest(ctx, manifestID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.documents.RenderManifestSummary(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render manifest summary: %w", err)
+	}
+	return data, nil
+}
+
+// closeAtCutoff closes the carrier's open manifest if it was opened before the last cutoff.
+// It returns the closed manifest, or nil if there was nothing to close, and the number of late packages rolled over.
+func (s *ManifestCutoffService) closeAtCutoff(ctx context.Context, schedule *domain.CarrierPickupSchedule, now time.Time) (*domain.OutboundManifest, int, error) {
+	cutoff, pickup, err := schedule.LastCutoff(now)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	manifest, err := s.manifests.FindOpenByCarrier(ctx, schedule.CarrierCode)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find open manifest: %w", err)
+	}
+	// Manifests opened after the cutoff belong to the next pickup
+	if manifest == nil || !manifest.CreatedAt.Before(cutoff) {
+		return nil, 0, nil
+	}
+
+	late, err := manifest.CloseAtCutoff(cutoff, pickup)
+	if stdErrors.Is(err, domain.ErrNoPackagesBeforeCutoff) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	manifest.BeginCarrierFiling(now)
+
+	// Close the manifest and open the rollover in one versioned write before calling the
+	// carrier: a package added meanwhile fails the close with a conflict and the next run
+	// retries, and late packages are never left off both manifests
+	toSave := []*domain.OutboundManifest{manifest}
+	var next *domain.OutboundManifest
+	if len(late) > 0 {
+		nextCutoff, nextPickup, err := schedule.NextCutoff(cutoff)
+		if err != nil {
+			return nil, 0, err
+		}
+		next = domain.NewRolloverManifest(newManifestID(manifest.CarrierID), manifest, late, nextCutoff, nextPickup)
+		toSave = append(toSave, next)
+	}
+	if err := s.manifests.SaveAll(ctx, toSave...); err != nil {
+		return nil, 0, fmt.Errorf("failed to save closed manifest: %w", err)
+	}
+
+	if next != nil {
+		s.logger.Info("Rolled late packages to next manifest",
+			"manifestId", manifest.ManifestID,
+			"nextManifestId", next.ManifestID,
+			"packages", len(late),
+			"nextCutoff", next.CutoffAt,
+		)
+	}
+
+	if err := s.fileWithCarrier(ctx, manifest, schedule, now); err != nil {
+		return nil, 0, err
+	}
+	return manifest, len(late), nil
+}
+
+// resumeCarrierFilings completes filings of closed manifests that a previous run could not finish
+func (s *ManifestCutoffService) resumeCarrierFilings(ctx context.Context, schedule *domain.CarrierPickupSchedule, now time.Time) ([]string, error) {
+	manifests, err := s.manifests.FindClosedByCarrier(ctx, schedule.CarrierCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find closed manifests: %w", err)
+	}
+
+	filed := make([]string, 0)
+	for _, manifest := range manifests {
+		if !manifest.CarrierFilingPending() {
+			continue
+		}
+		if err := s.fileWithCarrier(ctx, manifest, schedule, now); err != nil {
+			return filed, err
+		}
+		filed = append(filed, manifest.ManifestID)
+	}
+	return filed, nil
+}
+
+// fileWithCarrier files the closed manifest with the carrier, marks its shipments manifested
+// and issues the bill of lading. The filing is sent with the manifest's idempotency key and
+// shipments manifested by an interrupted attempt are not filed again. A shipment that can't
+// be saved fails the filing before the bill of lading, so the next run resumes it.
+func (s *ManifestCutoffService) fileWithCarrier(ctx context.Context, manifest *domain.OutboundManifest, schedule *domain.CarrierPickupSchedule, now time.Time) error {
+	shipments, filed, err := s.manifestedShipments(ctx, manifest)
+	if err != nil {
+		return err
+	}
+	carrierManifest, err := s.createCarrierManifest(domain.WithIdempotencyKey(ctx, manifest.BeginCarrierFiling(now)), manifest, shipments)
+	if err != nil {
+		return err
+	}
+
+	if carrierManifest != nil {
+		for _, shipment := range shipments {
+			var rejected error
+			_, err := updateShipment(ctx, s.shipments, s.logger, shipment.ShipmentID, func(shipment *domain.Shipment) error {
+				rejected = shipment.AddToManifest(*carrierManifest)
+				return rejected
+			})
+			if rejected != nil {
+				s.logger.Warn("Shipment not manifested", "shipmentId", shipment.ShipmentID, "error", rejected.Error())
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to save manifested shipment %s: %w", shipment.ShipmentID, err)
+			}
+		}
+	} else {
+		carrierManifest = filed
+	}
+
+	carrierManifestID := ""
+	if carrierManifest != nil {
+		carrierManifestID = carrierManifest.ManifestID
+	}
+	saved, err := updateManifest(ctx, s.manifests, s.logger, manifest.ManifestID, func(manifest *domain.OutboundManifest) error {
+		manifest.BeginCarrierFiling(now)
+		return manifest.AttachBillOfLading(domain.NewBillOfLading(manifest, schedule, carrierManifestID, now.UTC()))
+	})
+	if err != nil {
+		return err
+	}
+	*manifest = *saved
+
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "manifest.closed",
+		EntityType: "manifest",
+		EntityID:   manifest.ManifestID,
+		Action:     "closed_at_cutoff",
+		RelatedIDs: map[string]string{
+			"carrierId":         manifest.CarrierID,
+			"carrierManifestId": carrierManifestID,
+			"bolNumber":         manifest.BillOfLading.BOLNumber,
+			"packageCount":      fmt.Sprintf("%d", manifest.TotalPackages),
+		},
+	})
+	return nil
+}
+
+// manifestedShipments loads the labeled shipments for the manifest's packages that still need
+// filing, and the carrier manifest of any already filed by an earlier attempt
+func (s *ManifestCutoffService) manifestedShipments(ctx context.Context, manifest *domain.OutboundManifest) ([]*domain.Shipment, *domain.Manifest, error) {
+	shipments := make([]*domain.Shipment, 0, len(manifest.Packages))
+	var filed *domain.Manifest
+	for _, pkg := range manifest.Packages {
+		if pkg.ShipmentID == "" {
+			continue
+		}
+		shipment, err := s.shipments.FindByID(ctx, pkg.ShipmentID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get shipment %s: %w", pkg.ShipmentID, err)
+		}
+		if shipment == nil {
+			continue
+		}
+		if shipment.Status == domain.ShipmentStatusManifested && shipment.Manifest != nil && filed == nil {
+			filed = shipment.Manifest
+		}
+		if shipment.Status != domain.ShipmentStatusLabeled {
+			continue
+		}
+		shipments = append(shipments, shipment)
+	}
+	return shipments, filed, nil
+}
+
+// createCarrierManifest files the end-of-day manifest with the carrier. Carriers without an
+// integration are closed without one; the BOL is still issued for the driver.
+func (s *ManifestCutoffService) createCarrierManifest(ctx context.Context, manifest *domain.OutboundManifest, shipments []*domain.Shipment) (*domain.Manifest, error) {
+	if len(shipments) == 0 {
+		return nil, nil
+	}
+	carrier := findCarrier(s.carriers, manifest.CarrierID)
+	if carrier == nil {
+		s.logger.Warn("No carrier integration registered, closing manifest without carrier manifest",
+			"manifestId", manifest.ManifestID,
+			"carrierId", manifest.CarrierID,
+		)
+		return nil, nil
+	}
+
+	request := make([]domain.Shipment, len(shipments))
+	for i, shipment := range shipments {
+		request[i] = *shipment
+	}
+	carrierManifest, err := carrier.CreateManifest(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s manifest: %w", manifest.CarrierID, err)
+	}
+	return carrierManifest, nil
+}
+
+// checkMissedPickups raises an alert for each closed manifest still waiting past its pickup
+func (s *ManifestCutoffService) checkMissedPickups(ctx context.Context, schedule *domain.CarrierPickupSchedule, now time.Time) ([]string, error) {
+	manifests, err := s.manifests.FindClosedByCarrier(ctx, schedule.CarrierCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find closed manifests: %w", err)
+	}
+
+	alerted := make([]string, 0)
+	for _, manifest := range manifests {
+		_, err := updateManifest(ctx, s.manifests, s.logger, manifest.ManifestID, func(manifest *domain.OutboundManifest) error {
+			if !manifest.FlagMissedPickup(now, schedule.MissedPickupGrace()) {
+				return errManifestUnchanged
+			}
+			return nil
+		})
+		if stdErrors.Is(err, errManifestUnchanged) {
+			continue
+		}
+		if err != nil {
+			return alerted, fmt.Errorf("failed to save manifest %s: %w", manifest.ManifestID, err)
+		}
+		s.logger.Warn("Manifest not dispatched by scheduled pickup",
+			"manifestId", manifest.ManifestID,
+			"carrierId", manifest.CarrierID,
+			"facilityId", manifest.FacilityID,
+			"scheduledPickup", manifest.ScheduledPickup,
+		)
+		alerted = append(alerted, manifest.ManifestID)
+	}
+	return alerted, nil
+}
+
+func (s *ManifestCutoffService) closedManifest(ctx context.Context, manifestID string) (*domain.OutboundManifest, error) {
+	manifest, err := s.manifests.FindByID(ctx, manifestID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get manifest", "manifestId", manifestID)
+		return nil, fmt.Errorf("failed to get manifest: %w", err)
+	}
+	if manifest == nil {
+		return nil, errors.ErrNotFound("manifest")
+	}
+	if manifest.Status == domain.ManifestStatusOpen || manifest.Status == domain.ManifestStatusCancelled {
+		return nil, errors.ErrValidation(fmt.Sprintf("manifest in status %s has no shipping documents", manifest.Status))
+	}
+	return manifest, nil
+}
+
+func toPickupScheduleDTO(schedule *domain.CarrierPickupSchedule, now time.Time) *PickupScheduleDTO {
+	dto := &PickupScheduleDTO{
+		FacilityID:               schedule.FacilityID,
+		CarrierCode:              schedule.CarrierCode,
+		CarrierName:              schedule.CarrierName,
+		ServiceType:              schedule.ServiceType,
+		PickupDays:               schedule.PickupDays,
+		CutoffTime:               schedule.CutoffTime,
+		PickupTime:               schedule.PickupTime,
+		TimeZone:                 schedule.TimeZone,
+		MissedPickupGraceMinutes: schedule.MissedPickupGraceMinutes,
+		ShipFrom:                 schedule.ShipFrom,
+		Active:                   schedule.Active,
+		UpdatedAt:                schedule.UpdatedAt,
+	}
+	if cutoff, pickup, err := schedule.NextCutoff(now); err == nil {
+		dto.NextCutoff = &cutoff
+		dto.NextPickup = &pickup
+	}
+	return dto
+}
@@ -0,0 +1,280 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+type fakeScheduleRepo struct {
+	domain.PickupScheduleRepository
+	schedules []*domain.CarrierPickupSchedule
+}
+
+func (r *fakeScheduleRepo) FindActive(_ context.Context) ([]*domain.CarrierPickupSchedule, error) {
+	return r.schedules, nil
+}
+
+type fakeManifestRepo struct {
+	ManifestRepository
+	manifests map[string]*domain.OutboundManifest
+	events    []domain.DomainEvent
+	saveErr   error // returned once by the next Save
+}
+
+func (r *fakeManifestRepo) Save(ctx context.Context, manifest *domain.OutboundManifest) error {
+	if err := r.saveErr; err != nil {
+		r.saveErr = nil
+		return err
+	}
+	return r.SaveAll(ctx, manifest)
+}
+
+func (r *fakeManifestRepo) SaveAll(_ context.Context, manifests ...*domain.OutboundManifest) error {
+	for _, manifest := range manifests {
+		r.manifests[manifest.ManifestID] = manifest
+		r.events = append(r.events, manifest.GetManifestDomainEvents()...)
+		manifest.ClearManifestDomainEvents()
+	}
+	return nil
+}
+
+func (r *fakeManifestRepo) FindByID(_ context.Context, manifestID string) (*domain.OutboundManifest, error) {
+	return r.manifests[manifestID], nil
+}
+
+func (r *fakeManifestRepo) FindOpenByCarrier(ctx context.Context, carrierID string) (*domain.OutboundManifest, error) {
+	facilityID := tenant.FromContextOptional(ctx).FacilityID
+	for _, manifest := range r.manifests {
+		if manifest.CarrierID == carrierID && manifest.FacilityID == facilityID && manifest.Status == domain.ManifestStatusOpen {
+			return manifest, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeManifestRepo) FindClosedByCarrier(_ context.Context, carrierID string) ([]*domain.OutboundManifest, error) {
+	var closed []*domain.OutboundManifest
+	for _, manifest := range r.manifests {
+		if manifest.CarrierID == carrierID && manifest.Status == domain.ManifestStatusClosed {
+			closed = append(closed, manifest)
+		}
+	}
+	return closed, nil
+}
+
+type fakeManifestCarrier struct {
+	domain.CarrierService
+	code      string
+	manifests [][]domain.Shipment
+	keys      []string
+	err       error
+}
+
+func (c *fakeManifestCarrier) GetCarrierCode() string { return c.code }
+
+func (c *fakeManifestCarrier) CreateManifest(ctx context.Context, shipments []domain.Shipment) (*domain.Manifest, error) {
+	c.keys = append(c.keys, domain.IdempotencyKeyFromContext(ctx))
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.manifests = append(c.manifests, shipments)
+	return &domain.Manifest{ManifestID: "UPS-EOD-1", CarrierCode: c.code, ShipmentCount: len(shipments), GeneratedAt: time.Now()}, nil
+}
+
+func newCutoffTestService(t *testing.T, cutoff time.Time) (*ManifestCutoffService, *fakeManifestRepo, *fakeShipmentRepo, *fakeManifestCarrier) {
+	t.Helper()
+
+	schedule := &domain.CarrierPickupSchedule{
+		TenantID:    "TENANT-1",
+		FacilityID:  "FAC-1",
+		CarrierCode: "UPS",
+		CarrierName: "UPS",
+		CutoffTime:  cutoff.Format("15:04"),
+		PickupTime:  cutoff.Add(time.Hour).Format("15:04"),
+		TimeZone:    "UTC",
+		PickupDays:  []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+		Active:      true,
+	}
+
+	shipment := domain.NewShipment("SHP-1", "ORD-1", "PKG-1", "", domain.Carrier{Code: "UPS"}, domain.PackageInfo{Weight: 2}, domain.Address{}, domain.Address{})
+	require.NoError(t, shipment.GenerateLabel(domain.ShippingLabel{TrackingNumber: "1Z0001"}))
+	shipment.ClearDomainEvents()
+	shipments := &fakeShipmentRepo{shipments: map[string]*domain.Shipment{shipment.ShipmentID: shipment}}
+
+	manifest := domain.NewOutboundManifest("MAN-UPS-ab12cd34", "UPS", "UPS", "Ground")
+	manifest.TenantID = "TENANT-1"
+	manifest.FacilityID = "FAC-1"
+	manifest.CreatedAt = cutoff.Add(-8 * time.Hour)
+	manifest.Packages = []domain.ManifestPackage{
+		{PackageID: "PKG-1", ShipmentID: "SHP-1", TrackingNumber: "1Z0001", Weight: 2, AddedAt: cutoff.Add(-time.Hour)},
+		{PackageID: "PKG-2", ShipmentID: "SHP-2", TrackingNumber: "1Z0002", Weight: 4, AddedAt: cutoff.Add(10 * time.Minute)},
+	}
+	manifest.TotalPackages = 2
+	manifest.TotalWeight = 6
+	manifests := &fakeManifestRepo{manifests: map[string]*domain.OutboundManifest{manifest.ManifestID: manifest}}
+
+	carrier := &fakeManifestCarrier{code: "UPS"}
+	logger := logging.New(logging.DefaultConfig("test"))
+	service := NewManifestCutoffService(&fakeScheduleRepo{schedules: []*domain.CarrierPickupSchedule{schedule}}, manifests, shipments, []domain.CarrierService{carrier}, nil, logger)
+	return service, manifests, shipments, carrier
+}
+
+func TestManifestCutoffService_RunCutoffs(t *testing.T) {
+	cutoff := time.Date(2026, 10, 12, 17, 0, 0, 0, time.UTC)
+	service, manifests, shipments, carrier := newCutoffTestService(t, cutoff)
+
+	result, err := service.RunCutoffs(context.Background(), cutoff.Add(15*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MAN-UPS-ab12cd34"}, result.Closed)
+	assert.Equal(t, 1, result.RolledOver)
+	assert.Empty(t, result.PickupAlerts)
+
+	closed := manifests.manifests["MAN-UPS-ab12cd34"]
+	assert.Equal(t, domain.ManifestStatusClosed, closed.Status)
+	assert.Equal(t, 1, closed.TotalPackages)
+	assert.Equal(t, "UPS-EOD-1", closed.CarrierManifestID)
+	require.NotNil(t, closed.BillOfLading)
+	assert.Equal(t, 1, closed.BillOfLading.PackageCount)
+
+	// Only the labeled shipment on time is filed with the carrier
+	require.Len(t, carrier.manifests, 1)
+	assert.Len(t, carrier.manifests[0], 1)
+	assert.Equal(t, []string{"manifest-MAN-UPS-ab12cd34"}, carrier.keys)
+	assert.Equal(t, domain.ShipmentStatusManifested, shipments.shipments["SHP-1"].Status)
+
+	// The late package rolls to a new open manifest for the next cutoff
+	require.Len(t, manifests.manifests, 2)
+	var next *domain.OutboundManifest
+	for id, manifest := range manifests.manifests {
+		if id != closed.ManifestID {
+			next = manifest
+		}
+	}
+	require.NotNil(t, next)
+	assert.Equal(t, domain.ManifestStatusOpen, next.Status)
+	assert.Equal(t, "FAC-1", next.FacilityID)
+	require.Len(t, next.Packages, 1)
+	assert.Equal(t, "PKG-2", next.Packages[0].PackageID)
+	assert.Equal(t, cutoff.Add(24*time.Hour), *next.CutoffAt)
+
+	// A second run before the next cutoff has nothing to close
+	result, err = service.RunCutoffs(context.Background(), cutoff.Add(20*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, result.Closed)
+}
+
+func TestManifestCutoffService_MissedPickupAlert(t *testing.T) {
+	cutoff := time.Date(2026, 10, 12, 17, 0, 0, 0, time.UTC)
+	service, manifests, _, _ := newCutoffTestService(t, cutoff)
+
+	_, err := service.RunCutoffs(context.Background(), cutoff.Add(time.Minute))
+	require.NoError(t, err)
+
+	// Pickup is an hour after cutoff; the default grace is 30 minutes
+	result, err := service.RunCutoffs(context.Background(), cutoff.Add(time.Hour+31*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MAN-UPS-ab12cd34"}, result.PickupAlerts)
+
+	var missed []*domain.ManifestPickupMissedEvent
+	for _, event := range manifests.events {
+		if e, ok := event.(*domain.ManifestPickupMissedEvent); ok {
+			missed = append(missed, e)
+		}
+	}
+	require.Len(t, missed, 1)
+	assert.Equal(t, "FAC-1", missed[0].FacilityID)
+
+	// Dispatched manifests are no longer checked
+	require.NoError(t, manifests.manifests["MAN-UPS-ab12cd34"].AssignTrailer("TRL-1", "DOCK-1"))
+	require.NoError(t, manifests.manifests["MAN-UPS-ab12cd34"].Dispatch())
+	result, err = service.RunCutoffs(context.Background(), cutoff.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, result.PickupAlerts)
+}
+
+func TestManifestCutoffService_CarrierFailureResumesFiling(t *testing.T) {
+	cutoff := time.Date(2026, 10, 12, 17, 0, 0, 0, time.UTC)
+	service, manifests, shipments, carrier := newCutoffTestService(t, cutoff)
+	carrier.err = errors.New("carrier unavailable")
+
+	result, err := service.RunCutoffs(context.Background(), cutoff.Add(15*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, result.Closed)
+	assert.Equal(t, 1, result.Failed)
+
+	// The manifest is closed and the late package rolled over even though filing failed
+	closed := manifests.manifests["MAN-UPS-ab12cd34"]
+	assert.Equal(t, domain.ManifestStatusClosed, closed.Status)
+	assert.True(t, closed.CarrierFilingPending())
+	assert.Nil(t, closed.BillOfLading)
+	require.Len(t, manifests.manifests, 2)
+	assert.Equal(t, domain.ShipmentStatusLabeled, shipments.shipments["SHP-1"].Status)
+
+	// The next run files it with the same idempotency key
+	carrier.err = nil
+	result, err = service.RunCutoffs(context.Background(), cutoff.Add(20*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MAN-UPS-ab12cd34"}, result.Closed)
+	assert.Equal(t, []string{"manifest-MAN-UPS-ab12cd34", "manifest-MAN-UPS-ab12cd34"}, carrier.keys)
+	assert.Equal(t, "UPS-EOD-1", closed.CarrierManifestID)
+	require.NotNil(t, closed.BillOfLading)
+	assert.False(t, closed.CarrierFilingPending())
+	assert.Equal(t, domain.ShipmentStatusManifested, shipments.shipments["SHP-1"].Status)
+	require.Len(t, manifests.manifests, 2)
+}
+
+func TestManifestCutoffService_SaveFailureDoesNotRefile(t *testing.T) {
+	cutoff := time.Date(2026, 10, 12, 17, 0, 0, 0, time.UTC)
+	service, manifests, _, carrier := newCutoffTestService(t, cutoff)
+	manifests.saveErr = errors.New("write conflict")
+
+	result, err := service.RunCutoffs(context.Background(), cutoff.Add(15*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	require.Len(t, carrier.manifests, 1)
+
+	// The fake shares the manifest in memory, so drop the bill of lading that was never saved
+	closed := manifests.manifests["MAN-UPS-ab12cd34"]
+	closed.BillOfLading = nil
+
+	// The carrier already filed the shipments; the next run only records the bill of lading
+	result, err = service.RunCutoffs(context.Background(), cutoff.Add(20*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MAN-UPS-ab12cd34"}, result.Closed)
+	assert.Len(t, carrier.manifests, 1)
+	assert.Equal(t, "UPS-EOD-1", closed.CarrierManifestID)
+	require.NotNil(t, closed.BillOfLading)
+}
+
+func TestManifestCutoffService_ShipmentSaveFailureResumesFiling(t *testing.T) {
+	cutoff := time.Date(2026, 10, 12, 17, 0, 0, 0, time.UTC)
+	service, manifests, shipments, carrier := newCutoffTestService(t, cutoff)
+	shipments.saveErrs = []error{errors.New("connection reset")}
+
+	result, err := service.RunCutoffs(context.Background(), cutoff.Add(15*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+
+	// The bill of lading waits until every filed shipment is saved as manifested
+	closed := manifests.manifests["MAN-UPS-ab12cd34"]
+	assert.True(t, closed.CarrierFilingPending())
+	assert.Nil(t, closed.BillOfLading)
+	assert.Equal(t, domain.ShipmentStatusLabeled, shipments.shipments["SHP-1"].Status)
+
+	// The next run files again with the same idempotency key and completes
+	result, err = service.RunCutoffs(context.Background(), cutoff.Add(20*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MAN-UPS-ab12cd34"}, result.Closed)
+	assert.Equal(t, []string{"manifest-MAN-UPS-ab12cd34", "manifest-MAN-UPS-ab12cd34"}, carrier.keys)
+	assert.Equal(t, domain.ShipmentStatusManifested, shipments.shipments["SHP-1"].Status)
+	require.NotNil(t, closed.BillOfLading)
+}
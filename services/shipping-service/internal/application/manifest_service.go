@@ -10,6 +10,7 @@ import (
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 	"github.com/wms-platform/shared/pkg/tenant"
 
 	"github.com/wms-platform/shipping-service/internal/domain"
@@ -18,6 +19,8 @@ import (
 // ManifestRepository defines the interface for manifest persistence
 type ManifestRepository interface {
 	Save(ctx context.Context, manifest *domain.OutboundManifest) error
+	// SaveAll saves the manifests atomically; nothing is written if any changed since it was loaded
+	SaveAll(ctx context.Context, manifests ...*domain.OutboundManifest) error
 	FindByID(ctx context.Context, manifestID string) (*domain.OutboundManifest, error)
 	FindByCarrierID(ctx context.Context, carrierID string) ([]*domain.OutboundManifest, error)
 	FindByStatus(ctx context.Context, status domain.ManifestStatus) ([]*domain.OutboundManifest, error)
@@ -119,6 +122,12 @@ type ManifestDTO struct {
 	DispatchedAt    *time.Time        `json:"dispatchedAt,omitempty"`
 	CreatedAt       time.Time         `json:"createdAt"`
 	UpdatedAt       time.Time         `json:"updatedAt"`
+
+	CutoffAt          *time.Time           `json:"cutoffAt,omitempty"`
+	AutoClosed        bool                 `json:"autoClosed"`
+	CarrierManifestID string               `json:"carrierManifestId,omitempty"`
+	BillOfLading      *domain.BillOfLading `json:"billOfLading,omitempty"`
+	PickupAlertedAt   *time.Time           `json:"pickupAlertedAt,omitempty"`
 }
 
 // ManifestPkgDTO represents a package in a manifest response
@@ -133,7 +142,7 @@ type ManifestPkgDTO struct {
 
 // CreateManifest creates a new outbound manifest
 func (s *ManifestApplicationService) CreateManifest(ctx context.Context, cmd CreateManifestCommand) (*ManifestDTO, error) {
-	manifestID := fmt.Sprintf("MAN-%s-%s", cmd.CarrierID, uuid.New().String()[:8])
+	manifestID := newManifestID(cmd.CarrierID)
 
 	manifest := domain.NewOutboundManifest(
 		manifestID,
@@ -198,16 +207,6 @@ func (s *ManifestApplicationService) GetOrCreateOpenManifest(ctx context.Context
 
 // AddPackage adds a package to a manifest
 func (s *ManifestApplicationService) AddPackage(ctx context.Context, cmd AddPackageCommand) (*ManifestDTO, error) {
-	manifest, err := s.repo.FindByID(ctx, cmd.ManifestID)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to get manifest", "manifestId", cmd.ManifestID)
-		return nil, fmt.Errorf("failed to get manifest: %w", err)
-	}
-
-	if manifest == nil {
-		return nil, errors.ErrNotFound("manifest")
-	}
-
 	pkg := domain.ManifestPackage{
 		PackageID:      cmd.PackageID,
 		ShipmentID:     cmd.ShipmentID,
@@ -216,13 +215,14 @@ func (s *ManifestApplicationService) AddPackage(ctx context.Context, cmd AddPack
 		Weight:         cmd.Weight,
 	}
 
-	if err := manifest.AddPackage(pkg); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, manifest); err != nil {
-		s.logger.WithError(err).Error("Failed to save manifest", "manifestId", cmd.ManifestID)
-		return nil, fmt.Errorf("failed to save manifest: %w", err)
+	manifest, err := updateManifest(ctx, s.repo, s.logger, cmd.ManifestID, func(manifest *domain.OutboundManifest) error {
+		if err := manifest.AddPackage(pkg); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	s.logger.Info("Added package to manifest",
@@ -235,23 +235,14 @@ func (s *ManifestApplicationService) AddPackage(ctx context.Context, cmd AddPack
 
 // CloseManifest closes a manifest
 func (s *ManifestApplicationService) CloseManifest(ctx context.Context, cmd CloseManifestCommand) (*ManifestDTO, error) {
-	manifest, err := s.repo.FindByID(ctx, cmd.ManifestID)
+	manifest, err := updateManifest(ctx, s.repo, s.logger, cmd.ManifestID, func(manifest *domain.OutboundManifest) error {
+		if err := manifest.Close(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get manifest", "manifestId", cmd.ManifestID)
-		return nil, fmt.Errorf("failed to get manifest: %w", err)
-	}
-
-	if manifest == nil {
-		return nil, errors.ErrNotFound("manifest")
-	}
-
-	if err := manifest.Close(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, manifest); err != nil {
-		s.logger.WithError(err).Error("Failed to save manifest", "manifestId", cmd.ManifestID)
-		return nil, fmt.Errorf("failed to save manifest: %w", err)
+		return nil, err
 	}
 
 	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
@@ -270,23 +261,14 @@ func (s *ManifestApplicationService) CloseManifest(ctx context.Context, cmd Clos
 
 // AssignTrailer assigns a trailer to a manifest
 func (s *ManifestApplicationService) AssignTrailer(ctx context.Context, cmd AssignTrailerCommand) (*ManifestDTO, error) {
-	manifest, err := s.repo.FindByID(ctx, cmd.ManifestID)
+	manifest, err := updateManifest(ctx, s.repo, s.logger, cmd.ManifestID, func(manifest *domain.OutboundManifest) error {
+		if err := manifest.AssignTrailer(cmd.TrailerID, cmd.DispatchDock); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get manifest", "manifestId", cmd.ManifestID)
-		return nil, fmt.Errorf("failed to get manifest: %w", err)
-	}
-
-	if manifest == nil {
-		return nil, errors.ErrNotFound("manifest")
-	}
-
-	if err := manifest.AssignTrailer(cmd.TrailerID, cmd.DispatchDock); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, manifest); err != nil {
-		s.logger.WithError(err).Error("Failed to save manifest", "manifestId", cmd.ManifestID)
-		return nil, fmt.Errorf("failed to save manifest: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Assigned trailer to manifest",
@@ -299,23 +281,14 @@ func (s *ManifestApplicationService) AssignTrailer(ctx context.Context, cmd Assi
 
 // DispatchManifest dispatches a manifest
 func (s *ManifestApplicationService) DispatchManifest(ctx context.Context, cmd DispatchManifestCommand) (*ManifestDTO, error) {
-	manifest, err := s.repo.FindByID(ctx, cmd.ManifestID)
+	manifest, err := updateManifest(ctx, s.repo, s.logger, cmd.ManifestID, func(manifest *domain.OutboundManifest) error {
+		if err := manifest.Dispatch(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get manifest", "manifestId", cmd.ManifestID)
-		return nil, fmt.Errorf("failed to get manifest: %w", err)
-	}
-
-	if manifest == nil {
-		return nil, errors.ErrNotFound("manifest")
-	}
-
-	if err := manifest.Dispatch(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, manifest); err != nil {
-		s.logger.WithError(err).Error("Failed to save manifest", "manifestId", cmd.ManifestID)
-		return nil, fmt.Errorf("failed to save manifest: %w", err)
+		return nil, err
 	}
 
 	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
@@ -333,6 +306,53 @@ func (s *ManifestApplicationService) DispatchManifest(ctx context.Context, cmd D
 	return toManifestDTO(manifest), nil
 }
 
+// updateManifest loads a manifest, applies change and saves it. If another writer saved the
+// manifest in the meantime, it is reloaded and change applied again. Errors returned by change
+// are passed through as is.
+func updateManifest(
+	ctx context.Context,
+	repo ManifestRepository,
+	logger *logging.Logger,
+	manifestID string,
+	change func(manifest *domain.OutboundManifest) error,
+) (*domain.OutboundManifest, error) {
+	var manifest *domain.OutboundManifest
+
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := repo.FindByID(ctx, manifestID)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get manifest", "manifestId", manifestID)
+			return fmt.Errorf("failed to get manifest: %w", err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("manifest")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				logger.Debug("Manifest changed concurrently, retrying", "manifestId", manifestID)
+				return err
+			}
+			logger.WithError(err).Error("Failed to save manifest", "manifestId", manifestID)
+			return fmt.Errorf("failed to save manifest: %w", err)
+		}
+
+		manifest = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("manifest %s was modified concurrently, please retry", manifestID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
 // GetByCarrier retrieves manifests by carrier
 func (s *ManifestApplicationService) GetByCarrier(ctx context.Context, query GetManifestsByCarrierQuery) ([]ManifestDTO, error) {
 	manifests, err := s.repo.FindByCarrierID(ctx, query.CarrierID)
@@ -379,6 +399,10 @@ func (s *ManifestApplicationService) GetDispatchedToday(ctx context.Context) ([]
 }
 
 // Helper functions
+func newManifestID(carrierID string) string {
+	return fmt.Sprintf("MAN-%s-%s", carrierID, uuid.New().String()[:8])
+}
+
 func toManifestDTO(m *domain.OutboundManifest) *ManifestDTO {
 	packages := make([]ManifestPkgDTO, len(m.Packages))
 	for i, pkg := range m.Packages {
@@ -408,6 +432,12 @@ func toManifestDTO(m *domain.OutboundManifest) *ManifestDTO {
 		DispatchedAt:    m.DispatchedAt,
 		CreatedAt:       m.CreatedAt,
 		UpdatedAt:       m.UpdatedAt,
+
+		CutoffAt:          m.CutoffAt,
+		AutoClosed:        m.AutoClosed,
+		CarrierManifestID: m.CarrierManifestID,
+		BillOfLading:      m.BillOfLading,
+		PickupAlertedAt:   m.PickupAlertedAt,
 	}
 }
 
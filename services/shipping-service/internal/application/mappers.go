@@ -11,6 +11,7 @@ func ToShipmentDTO(shipment *domain.Shipment) *ShipmentDTO {
 	dto := &ShipmentDTO{
 		ShipmentID:        shipment.ShipmentID,
 		OrderID:           shipment.OrderID,
+		SellerID:          shipment.SellerID,
 		PackageID:         shipment.PackageID,
 		WaveID:            shipment.WaveID,
 		Status:            string(shipment.Status),
@@ -32,10 +33,169 @@ func ToShipmentDTO(shipment *domain.Shipment) *ShipmentDTO {
 		dto.Label = ToShippingLabelDTO(shipment.Label)
 	}
 
+	if shipment.ReturnLabel != nil {
+		dto.ReturnLabel = ToShippingLabelDTO(shipment.ReturnLabel)
+	}
+
 	if shipment.Manifest != nil {
 		dto.Manifest = ToManifestSummaryDTO(shipment.Manifest)
 	}
 
+	if shipment.RateSelection != nil {
+		dto.RateSelection = ToRateSelectionDTO(shipment.RateSelection)
+	}
+
+	if shipment.Tracking != nil {
+		dto.Tracking = ToTrackingDTO(shipment.Tracking)
+	}
+
+	if shipment.BillableWeight != nil {
+		dto.BillableWeight = ToBillableWeightDTO(shipment.BillableWeight)
+	}
+
+	if shipment.Customs != nil {
+		dto.Customs = ToCustomsDeclarationDTO(shipment.Customs)
+	}
+
+	return dto
+}
+
+// ToTrackingDTO converts domain ShipmentTracking to TrackingDTO
+func ToTrackingDTO(tracking *domain.ShipmentTracking) *TrackingDTO {
+	events := make([]TrackingEventDTO, len(tracking.Events))
+	for i, event := range tracking.Events {
+		events[i] = TrackingEventDTO{
+			Timestamp:   event.Timestamp,
+			Location:    event.Location,
+			Status:      event.Status,
+			Description: event.Description,
+		}
+	}
+
+	return &TrackingDTO{
+		Milestone:       string(tracking.Milestone),
+		CarrierStatus:   tracking.CarrierStatus,
+		StatusDetail:    tracking.StatusDetail,
+		CurrentLocation: tracking.CurrentLocation,
+		Events:          events,
+		LastEventAt:     tracking.LastEventAt,
+		LastPolledAt:    tracking.LastPolledAt,
+		NextPollAt:      tracking.NextPollAt,
+		PollingStopped:  tracking.PollingStopped,
+		LastError:       tracking.LastError,
+	}
+}
+
+// ToRateSelectionDTO converts a domain RateSelection to RateSelectionDTO
+func ToRateSelectionDTO(selection *domain.RateSelection) *RateSelectionDTO {
+	if selection == nil {
+		return nil
+	}
+
+	dto := &RateSelectionDTO{
+		Strategy:     string(selection.Strategy),
+		Selected:     ToRateQuoteDTO(selection.Selected),
+		Alternatives: make([]RejectedRateDTO, len(selection.Alternatives)),
+		CostSavings:  selection.CostSavings,
+		SelectedAt:   selection.SelectedAt,
+	}
+
+	for i, alt := range selection.Alternatives {
+		dto.Alternatives[i] = RejectedRateDTO{
+			Quote:  ToRateQuoteDTO(alt.Quote),
+			Reason: alt.Reason,
+		}
+	}
+
+	for _, failure := range selection.Failures {
+		dto.Failures = append(dto.Failures, CarrierQuoteFailureDTO{
+			CarrierCode: failure.CarrierCode,
+			Error:       failure.Error,
+		})
+	}
+
+	return dto
+}
+
+// ToRateQuoteDTO converts a domain RateQuote to RateQuoteDTO
+func ToRateQuoteDTO(quote domain.RateQuote) RateQuoteDTO {
+	return RateQuoteDTO{
+		CarrierCode:       quote.CarrierCode,
+		ServiceType:       quote.ServiceType,
+		ServiceName:       quote.ServiceName,
+		TotalCost:         quote.TotalCost,
+		Currency:          quote.Currency,
+		EstimatedDelivery: quote.EstimatedDelivery,
+		IsGuaranteed:      quote.IsGuaranteed,
+		BillableWeight:    quote.BillableWeight,
+		DimApplied:        quote.DimApplied,
+	}
+}
+
+// ToBillableWeightDTO converts a domain BillableWeight to BillableWeightDTO
+func ToBillableWeightDTO(billable *domain.BillableWeight) *BillableWeightDTO {
+	return &BillableWeightDTO{
+		CarrierCode:        billable.CarrierCode,
+		ActualWeight:       billable.ActualWeight,
+		DimWeight:          billable.DimWeight,
+		BillableWeight:     billable.BillableWeight,
+		Divisor:            billable.Divisor,
+		DimApplied:         billable.DimApplied,
+		AdditionalHandling: billable.AdditionalHandling,
+		Oversize:           billable.Oversize,
+	}
+}
+
+// ToCustomsDeclarationDTO converts a domain CustomsDeclaration to CustomsDeclarationDTO
+func ToCustomsDeclarationDTO(declaration *domain.CustomsDeclaration) *CustomsDeclarationDTO {
+	items := make([]CustomsItemDTO, 0, len(declaration.Items))
+	for _, item := range declaration.Items {
+		items = append(items, CustomsItemDTO{
+			SKU:             item.SKU,
+			Description:     item.Description,
+			HSCode:          item.HSCode,
+			CountryOfOrigin: item.CountryOfOrigin,
+			Quantity:        item.Quantity,
+			UnitValue:       item.UnitValue,
+			Weight:          item.Weight,
+		})
+	}
+
+	return &CustomsDeclarationDTO{
+		ContentsType:  string(declaration.ContentsType),
+		Incoterm:      string(declaration.Incoterm),
+		Currency:      declaration.Currency,
+		Items:         items,
+		InvoiceNumber: declaration.InvoiceNumber,
+		ExporterID:    declaration.ExporterID,
+		ImporterTaxID: declaration.ImporterTaxID,
+		TotalValue:    declaration.TotalValue(),
+		TotalWeight:   declaration.TotalWeight(),
+		FormType:      string(declaration.FormType()),
+		DeclaredAt:    declaration.DeclaredAt,
+	}
+}
+
+// ToCarrierSelectionRuleDTO converts a domain CarrierSelectionRule to CarrierSelectionRuleDTO
+func ToCarrierSelectionRuleDTO(rule *domain.CarrierSelectionRule) *CarrierSelectionRuleDTO {
+	if rule == nil {
+		return nil
+	}
+
+	dto := &CarrierSelectionRuleDTO{
+		SellerID:          rule.SellerID,
+		Strategy:          string(rule.Strategy),
+		AllowedCarriers:   rule.AllowedCarriers,
+		ExcludedCarriers:  rule.ExcludedCarriers,
+		MaxCost:           rule.MaxCost,
+		RequireGuaranteed: rule.RequireGuaranteed,
+	}
+
+	if !rule.UpdatedAt.IsZero() {
+		updatedAt := rule.UpdatedAt
+		dto.UpdatedAt = &updatedAt
+	}
+
 	return dto
 }
 
@@ -123,3 +283,24 @@ func ToShipmentDTOs(shipments []*domain.Shipment) []ShipmentDTO {
 	}
 	return dtos
 }
+
+// ToPrintJobDTO converts a domain PrintJob to PrintJobDTO
+func ToPrintJobDTO(job *domain.PrintJob) *PrintJobDTO {
+	if job == nil {
+		return nil
+	}
+
+	return &PrintJobDTO{
+		JobID:      job.JobID,
+		PrinterID:  job.PrinterID,
+		StationID:  job.StationID,
+		ShipmentID: job.ShipmentID,
+		Format:     job.Format,
+		Copies:     job.Copies,
+		Status:     string(job.Status),
+		Attempts:   job.Attempts,
+		LastError:  job.LastError,
+		CreatedAt:  job.CreatedAt,
+		PrintedAt:  job.PrintedAt,
+	}
+}
@@ -0,0 +1,223 @@
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// DefaultCarrierQuoteTimeout bounds how long a single carrier may take to quote
+const DefaultCarrierQuoteTimeout = 3 * time.Second
+
+// RateShoppingService compares rates across all registered carriers and
+// assigns the best option to a shipment according to the seller's rule
+type RateShoppingService struct {
+	repo         domain.ShipmentRepository
+	ruleRepo     domain.CarrierSelectionRuleRepository
+	carriers     []domain.CarrierService
+	quoteTimeout time.Duration
+	logger       *logging.Logger
+}
+
+// NewRateShoppingService creates a new RateShoppingService
+func NewRateShoppingService(
+	repo domain.ShipmentRepository,
+	ruleRepo domain.CarrierSelectionRuleRepository,
+	carriers []domain.CarrierService,
+	quoteTimeout time.Duration,
+	logger *logging.Logger,
+) *RateShoppingService {
+	if quoteTimeout <= 0 {
+		quoteTimeout = DefaultCarrierQuoteTimeout
+	}
+	return &RateShoppingService{
+		repo:         repo,
+		ruleRepo:     ruleRepo,
+		carriers:     carriers,
+		quoteTimeout: quoteTimeout,
+		logger:       logger,
+	}
+}
+
+// ShopRates quotes the shipment with every carrier, selects a rate and records the decision on the shipment
+func (s *RateShoppingService) ShopRates(ctx context.Context, cmd ShopRatesCommand) (*ShipmentDTO, error) {
+	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get shipment", "shipmentId", cmd.ShipmentID)
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	if shipment == nil {
+		return nil, errors.ErrNotFound("shipment")
+	}
+
+	if shipment.Status != domain.ShipmentStatusPending {
+		return nil, errors.ErrValidation(domain.ErrCarrierAlreadyAssigned.Error())
+	}
+
+	rule, err := s.selectionRule(ctx, shipment.SellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, capabilities, failures := s.collectQuotes(ctx, domain.RateRequest{
+		Shipper:     shipment.Shipper,
+		Recipient:   shipment.Recipient,
+		PackageInfo: shipment.Package,
+	})
+
+	selection, err := domain.SelectRate(quotes, capabilities, domain.RateShoppingConstraints{
+		PromisedDeliveryAt: cmd.PromisedDeliveryAt,
+		Hazmat:             cmd.Hazmat,
+		International:      shipment.IsInternational(),
+		Package:            shipment.Package,
+	}, rule, failures)
+	if stdErrors.Is(err, domain.ErrNoRatesReturned) {
+		s.logger.Warn("No carrier returned rates", "shipmentId", cmd.ShipmentID, "failures", len(failures))
+		return nil, errors.ErrServiceUnavailable("carrier rating").Wrap(err)
+	}
+	if err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+
+	shipment, err = updateShipment(ctx, s.repo, s.logger, cmd.ShipmentID, func(shipment *domain.Shipment) error {
+		if err := shipment.ApplyRateSelection(*selection); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Events are saved to outbox by repository in transaction
+
+	// Log business event: carrier selected
+	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
+		EventType:  "shipment.carrier_selected",
+		EntityType: "shipment",
+		EntityID:   cmd.ShipmentID,
+		Action:     "carrier_selected",
+		RelatedIDs: map[string]string{
+			"carrier":     selection.Selected.CarrierCode,
+			"serviceType": selection.Selected.ServiceType,
+			"strategy":    string(selection.Strategy),
+		},
+	})
+
+	return ToShipmentDTO(shipment), nil
+}
+
+// GetSelectionRule returns the seller's carrier selection rule, or the default rule if none is configured
+func (s *RateShoppingService) GetSelectionRule(ctx context.Context, sellerID string) (*CarrierSelectionRuleDTO, error) {
+	rule, err := s.selectionRule(ctx, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	return ToCarrierSelectionRuleDTO(&rule), nil
+}
+
+// SetSelectionRule creates or replaces the seller's carrier selection rule
+func (s *RateShoppingService) SetSelectionRule(ctx context.Context, cmd SetCarrierSelectionRuleCommand) (*CarrierSelectionRuleDTO, error) {
+	rule := &domain.CarrierSelectionRule{
+		TenantID:          tenant.FromContextOptional(ctx).TenantID,
+		SellerID:          cmd.SellerID,
+		Strategy:          domain.SelectionStrategy(cmd.Strategy),
+		AllowedCarriers:   cmd.AllowedCarriers,
+		ExcludedCarriers:  cmd.ExcludedCarriers,
+		MaxCost:           cmd.MaxCost,
+		RequireGuaranteed: cmd.RequireGuaranteed,
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+
+	if err := s.ruleRepo.Save(ctx, rule); err != nil {
+		s.logger.WithError(err).Error("Failed to save carrier selection rule", "sellerId", cmd.SellerID)
+		return nil, fmt.Errorf("failed to save carrier selection rule: %w", err)
+	}
+
+	s.logger.Info("Carrier selection rule updated", "sellerId", cmd.SellerID, "strategy", cmd.Strategy)
+	return ToCarrierSelectionRuleDTO(rule), nil
+}
+
+// selectionRule loads the seller's rule, falling back to the default rule
+func (s *RateShoppingService) selectionRule(ctx context.Context, sellerID string) (domain.CarrierSelectionRule, error) {
+	if sellerID == "" {
+		return domain.DefaultCarrierSelectionRule(sellerID), nil
+	}
+
+	rule, err := s.ruleRepo.FindBySellerID(ctx, sellerID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get carrier selection rule", "sellerId", sellerID)
+		return domain.CarrierSelectionRule{}, fmt.Errorf("failed to get carrier selection rule: %w", err)
+	}
+
+	if rule == nil {
+		return domain.DefaultCarrierSelectionRule(sellerID), nil
+	}
+	return *rule, nil
+}
+
+// collectQuotes fans GetRates out to every carrier in parallel, each bounded by the quote timeout.
+// Carriers that fail or time out are reported as failures rather than aborting the shop.
+func (s *RateShoppingService) collectQuotes(ctx context.Context, request domain.RateRequest) ([]domain.RateQuote, map[string]domain.CarrierCapabilities, []domain.CarrierQuoteFailure) {
+	type carrierResult struct {
+		rates []domain.ShippingRate
+		err   error
+	}
+
+	results := make([]carrierResult, len(s.carriers))
+	var wg sync.WaitGroup
+	for i, carrier := range s.carriers {
+		wg.Add(1)
+		go func(i int, carrier domain.CarrierService) {
+			defer wg.Done()
+
+			quoteCtx, cancel := context.WithTimeout(ctx, s.quoteTimeout)
+			defer cancel()
+
+			done := make(chan carrierResult, 1)
+			go func() {
+				rates, err := carrier.GetRates(quoteCtx, request)
+				done <- carrierResult{rates: rates, err: err}
+			}()
+
+			select {
+			case result := <-done:
+				results[i] = result
+			case <-quoteCtx.Done():
+				results[i] = carrierResult{err: quoteCtx.Err()}
+			}
+		}(i, carrier)
+	}
+	wg.Wait()
+
+	quotes := make([]domain.RateQuote, 0)
+	capabilities := make(map[string]domain.CarrierCapabilities, len(s.carriers))
+	var failures []domain.CarrierQuoteFailure
+	for i, carrier := range s.carriers {
+		code := carrier.GetCarrierCode()
+		capabilities[code] = carrier.GetCapabilities()
+
+		if results[i].err != nil {
+			s.logger.Warn("Carrier failed to quote", "carrier", code, "error", results[i].err.Error())
+			failures = append(failures, domain.CarrierQuoteFailure{CarrierCode: code, Error: results[i].err.Error()})
+			continue
+		}
+		for _, rate := range results[i].rates {
+			quotes = append(quotes, domain.NewRateQuote(code, rate, request.PackageInfo))
+		}
+	}
+
+	return quotes, capabilities, failures
+}
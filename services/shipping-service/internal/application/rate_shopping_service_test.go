@@ -0,0 +1,166 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+type fakeShipmentRepo struct {
+	domain.ShipmentRepository
+	shipments map[string]*domain.Shipment
+	saveErrs  []error // Returned by the next saves in turn
+	saves     int
+}
+
+func (r *fakeShipmentRepo) FindByID(_ context.Context, shipmentID string) (*domain.Shipment, error) {
+	shipment, ok := r.shipments[shipmentID]
+	if !ok {
+		return nil, nil
+	}
+	loaded := *shipment
+	return &loaded, nil
+}
+
+func (r *fakeShipmentRepo) Save(_ context.Context, shipment *domain.Shipment) error {
+	r.saves++
+	if len(r.saveErrs) > 0 {
+		err := r.saveErrs[0]
+		r.saveErrs = r.saveErrs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	shipment.ClearDomainEvents()
+	saved := *shipment
+	r.shipments[shipment.ShipmentID] = &saved
+	return nil
+}
+
+type fakeRuleRepo struct {
+	rules map[string]*domain.CarrierSelectionRule
+}
+
+func (r *fakeRuleRepo) Save(_ context.Context, rule *domain.CarrierSelectionRule) error {
+	r.rules[rule.SellerID] = rule
+	return nil
+}
+
+func (r *fakeRuleRepo) FindBySellerID(_ context.Context, sellerID string) (*domain.CarrierSelectionRule, error) {
+	return r.rules[sellerID], nil
+}
+
+type fakeCarrier struct {
+	domain.CarrierService
+	code  string
+	delay time.Duration
+	rates []domain.ShippingRate
+}
+
+func (c *fakeCarrier) GetCarrierCode() string { return c.code }
+
+func (c *fakeCarrier) GetCapabilities() domain.CarrierCapabilities {
+	return domain.CarrierCapabilities{SupportsHazmat: true}
+}
+
+func (c *fakeCarrier) GetRates(ctx context.Context, _ domain.RateRequest) ([]domain.ShippingRate, error) {
+	select {
+	case <-time.After(c.delay):
+		return c.rates, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func newRateShoppingTestService(carriers ...domain.CarrierService) (*RateShoppingService, *fakeShipmentRepo, *fakeRuleRepo) {
+	shipment := domain.NewShipment(
+		"SHP-001", "ORD-001", "PKG-001", "",
+		domain.Carrier{Code: "UPS", ServiceType: "03"},
+		domain.PackageInfo{Weight: 2, Dimensions: domain.Dimensions{Length: 30, Width: 20, Height: 10}},
+		domain.Address{Name: "Recipient"}, domain.Address{Name: "Shipper"},
+	)
+	shipment.SellerID = "SELLER-1"
+	shipment.ClearDomainEvents()
+
+	repo := &fakeShipmentRepo{shipments: map[string]*domain.Shipment{shipment.ShipmentID: shipment}}
+	ruleRepo := &fakeRuleRepo{rules: map[string]*domain.CarrierSelectionRule{}}
+	logger := logging.New(logging.DefaultConfig("test"))
+	return NewRateShoppingService(repo, ruleRepo, carriers, 50*time.Millisecond, logger), repo, ruleRepo
+}
+
+func TestRateShoppingService_SlowCarrierTimesOut(t *testing.T) {
+	now := time.Now()
+	service, repo, _ := newRateShoppingTestService(
+		&fakeCarrier{code: "UPS", rates: []domain.ShippingRate{
+			{ServiceType: "03", TotalCost: 12.50, Currency: "USD", EstimatedDelivery: now.Add(72 * time.Hour)},
+		}},
+		&fakeCarrier{code: "FEDEX", delay: time.Second, rates: []domain.ShippingRate{
+			{ServiceType: "FEDEX_GROUND", TotalCost: 9.00, Currency: "USD", EstimatedDelivery: now.Add(72 * time.Hour)},
+		}},
+	)
+
+	start := time.Now()
+	dto, err := service.ShopRates(context.Background(), ShopRatesCommand{ShipmentID: "SHP-001"})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	assert.Equal(t, "UPS", dto.Carrier.Code)
+	require.NotNil(t, dto.RateSelection)
+	require.Len(t, dto.RateSelection.Failures, 1)
+	assert.Equal(t, "FEDEX", dto.RateSelection.Failures[0].CarrierCode)
+	assert.NotNil(t, repo.shipments["SHP-001"].RateSelection)
+}
+
+func TestRateShoppingService_UsesSellerRule(t *testing.T) {
+	now := time.Now()
+	service, _, ruleRepo := newRateShoppingTestService(
+		&fakeCarrier{code: "UPS", rates: []domain.ShippingRate{
+			{ServiceType: "03", TotalCost: 12.50, Currency: "USD", EstimatedDelivery: now.Add(72 * time.Hour)},
+			{ServiceType: "01", TotalCost: 40.00, Currency: "USD", EstimatedDelivery: now.Add(24 * time.Hour), IsGuaranteed: true},
+		}},
+		&fakeCarrier{code: "FEDEX", rates: []domain.ShippingRate{
+			{ServiceType: "FEDEX_2_DAY", TotalCost: 22.00, Currency: "USD", EstimatedDelivery: now.Add(48 * time.Hour), IsGuaranteed: true},
+		}},
+	)
+
+	_, err := service.SetSelectionRule(context.Background(), SetCarrierSelectionRuleCommand{SellerID: "SELLER-1", Strategy: "fastest"})
+	require.NoError(t, err)
+	require.Contains(t, ruleRepo.rules, "SELLER-1")
+
+	dto, err := service.ShopRates(context.Background(), ShopRatesCommand{ShipmentID: "SHP-001"})
+	require.NoError(t, err)
+	assert.Equal(t, "01", dto.ServiceType)
+	assert.Equal(t, "fastest", dto.RateSelection.Strategy)
+	assert.Len(t, dto.RateSelection.Alternatives, 2)
+}
+
+func TestRateShoppingService_AllCarriersFail(t *testing.T) {
+	service, _, _ := newRateShoppingTestService(&fakeCarrier{code: "UPS", delay: time.Second})
+
+	_, err := service.ShopRates(context.Background(), ShopRatesCommand{ShipmentID: "SHP-001"})
+	require.Error(t, err)
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.CodeServiceUnavailable, appErr.Code)
+}
+
+func TestRateShoppingService_RetriesConcurrentSave(t *testing.T) {
+	now := time.Now()
+	service, repo, _ := newRateShoppingTestService(&fakeCarrier{code: "UPS", rates: []domain.ShippingRate{
+		{ServiceType: "03", TotalCost: 12.50, Currency: "USD", EstimatedDelivery: now.Add(72 * time.Hour)},
+	}})
+	repo.saveErrs = []error{errors.NewConcurrencyConflictError("Shipment", "SHP-001", 0)}
+
+	dto, err := service.ShopRates(context.Background(), ShopRatesCommand{ShipmentID: "SHP-001"})
+	require.NoError(t, err)
+	assert.Equal(t, "UPS", dto.Carrier.Code)
+	assert.Equal(t, 2, repo.saves)
+	assert.NotNil(t, repo.shipments["SHP-001"].RateSelection)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 	"github.com/wms-platform/shared/pkg/tenant"
 
 	"github.com/wms-platform/shipping-service/internal/domain"
@@ -16,10 +17,11 @@ import (
 
 // ShippingApplicationService handles shipping-related use cases
 type ShippingApplicationService struct {
-	repo         domain.ShipmentRepository
-	producer     *kafka.InstrumentedProducer
-	eventFactory *cloudevents.EventFactory
-	logger       *logging.Logger
+	repo          domain.ShipmentRepository
+	producer      *kafka.InstrumentedProducer
+	eventFactory  *cloudevents.EventFactory
+	customsPolicy domain.CustomsPolicy
+	logger        *logging.Logger
 }
 
 // NewShippingApplicationService creates a new ShippingApplicationService
@@ -30,13 +32,19 @@ func NewShippingApplicationService(
 	logger *logging.Logger,
 ) *ShippingApplicationService {
 	return &ShippingApplicationService{
-		repo:         repo,
-		producer:     producer,
-		eventFactory: eventFactory,
-		logger:       logger,
+		repo:          repo,
+		producer:      producer,
+		eventFactory:  eventFactory,
+		customsPolicy: domain.DefaultCustomsPolicy(),
+		logger:        logger,
 	}
 }
 
+// SetCustomsPolicy replaces the policy that blocks labels for restricted or undeclared shipments
+func (s *ShippingApplicationService) SetCustomsPolicy(policy domain.CustomsPolicy) {
+	s.customsPolicy = policy
+}
+
 // CreateShipment creates a new shipment
 func (s *ShippingApplicationService) CreateShipment(ctx context.Context, cmd CreateShipmentCommand) (*ShipmentDTO, error) {
 	shipment := domain.NewShipment(
@@ -55,6 +63,13 @@ func (s *ShippingApplicationService) CreateShipment(ctx context.Context, cmd Cre
 	shipment.TenantID = tc.TenantID
 	shipment.FacilityID = tc.FacilityID
 	shipment.WarehouseID = tc.WarehouseID
+	shipment.SellerID = cmd.SellerID
+
+	if cmd.Customs != nil {
+		if err := shipment.SetCustomsDeclaration(*cmd.Customs); err != nil {
+			return nil, errors.ErrValidation(err.Error())
+		}
+	}
 
 	if err := s.repo.Save(ctx, shipment); err != nil {
 		s.logger.WithError(err).Error("Failed to create shipment", "shipmentId", cmd.ShipmentID)
@@ -95,27 +110,22 @@ func (s *ShippingApplicationService) GetShipment(ctx context.Context, query GetS
 
 // GenerateLabel generates a shipping label for a shipment
 func (s *ShippingApplicationService) GenerateLabel(ctx context.Context, cmd GenerateLabelCommand) (*ShipmentDTO, error) {
-	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to get shipment", "shipmentId", cmd.ShipmentID)
-		return nil, fmt.Errorf("failed to get shipment: %w", err)
-	}
-
-	if shipment == nil {
-		return nil, errors.ErrNotFound("shipment")
-	}
-
 	// Set generated time
 	label := cmd.Label
 	label.GeneratedAt = time.Now()
 
-	if err := shipment.GenerateLabel(label); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, shipment); err != nil {
-		s.logger.WithError(err).Error("Failed to save shipment", "shipmentId", cmd.ShipmentID)
-		return nil, fmt.Errorf("failed to save shipment: %w", err)
+	shipment, err := updateShipment(ctx, s.repo, s.logger, cmd.ShipmentID, func(shipment *domain.Shipment) error {
+		if err := checkCustoms(s.customsPolicy, shipment); err != nil {
+			s.logger.Warn("Label blocked by customs policy", "shipmentId", cmd.ShipmentID, "error", err.Error())
+			return err
+		}
+		if err := shipment.GenerateLabel(label); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -136,27 +146,18 @@ func (s *ShippingApplicationService) GenerateLabel(ctx context.Context, cmd Gene
 
 // AddToManifest adds a shipment to a manifest
 func (s *ShippingApplicationService) AddToManifest(ctx context.Context, cmd AddToManifestCommand) (*ShipmentDTO, error) {
-	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to get shipment", "shipmentId", cmd.ShipmentID)
-		return nil, fmt.Errorf("failed to get shipment: %w", err)
-	}
-
-	if shipment == nil {
-		return nil, errors.ErrNotFound("shipment")
-	}
-
 	// Set generated time
 	manifest := cmd.Manifest
 	manifest.GeneratedAt = time.Now()
 
-	if err := shipment.AddToManifest(manifest); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, shipment); err != nil {
-		s.logger.WithError(err).Error("Failed to save shipment", "shipmentId", cmd.ShipmentID)
-		return nil, fmt.Errorf("failed to save shipment: %w", err)
+	shipment, err := updateShipment(ctx, s.repo, s.logger, cmd.ShipmentID, func(shipment *domain.Shipment) error {
+		if err := shipment.AddToManifest(manifest); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -167,23 +168,14 @@ func (s *ShippingApplicationService) AddToManifest(ctx context.Context, cmd AddT
 
 // ConfirmShipment confirms a shipment has been shipped
 func (s *ShippingApplicationService) ConfirmShipment(ctx context.Context, cmd ConfirmShipmentCommand) (*ShipmentDTO, error) {
-	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
+	shipment, err := updateShipment(ctx, s.repo, s.logger, cmd.ShipmentID, func(shipment *domain.Shipment) error {
+		if err := shipment.ConfirmShipment(cmd.EstimatedDelivery); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get shipment", "shipmentId", cmd.ShipmentID)
-		return nil, fmt.Errorf("failed to get shipment: %w", err)
-	}
-
-	if shipment == nil {
-		return nil, errors.ErrNotFound("shipment")
-	}
-
-	if err := shipment.ConfirmShipment(cmd.EstimatedDelivery); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, shipment); err != nil {
-		s.logger.WithError(err).Error("Failed to save shipment", "shipmentId", cmd.ShipmentID)
-		return nil, fmt.Errorf("failed to save shipment: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -262,3 +254,50 @@ func (s *ShippingApplicationService) GetPendingForManifest(ctx context.Context,
 
 	return ToShipmentDTOs(shipments), nil
 }
+
+// updateShipment loads a shipment, applies change and saves it. If another writer saved the
+// shipment in the meantime, it is reloaded and change applied again, so change must only
+// depend on the shipment it is given. Errors returned by change are passed through as is.
+func updateShipment(
+	ctx context.Context,
+	repo domain.ShipmentRepository,
+	logger *logging.Logger,
+	shipmentID string,
+	change func(shipment *domain.Shipment) error,
+) (*domain.Shipment, error) {
+	var shipment *domain.Shipment
+
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := repo.FindByID(ctx, shipmentID)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get shipment", "shipmentId", shipmentID)
+			return fmt.Errorf("failed to get shipment: %w", err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("shipment")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				logger.Debug("Shipment changed concurrently, retrying", "shipmentId", shipmentID)
+				return err
+			}
+			logger.WithError(err).Error("Failed to save shipment", "shipmentId", shipmentID)
+			return fmt.Errorf("failed to save shipment: %w", err)
+		}
+
+		shipment = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("shipment %s was modified concurrently, please retry", shipmentID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/logging"
+)
+
+// TrackingPoller periodically polls carriers for shipments whose next tracking poll is due
+type TrackingPoller struct {
+	service  *TrackingService
+	config   TrackingPollerConfig
+	logger   *logging.Logger
+	mu       sync.RWMutex
+	running  bool
+	stopChan chan struct{}
+}
+
+// TrackingPollerConfig configuration for the tracking poller
+type TrackingPollerConfig struct {
+	// PollInterval is how often to look for shipments due for a poll
+	PollInterval time.Duration `json:"pollInterval"`
+
+	// BatchSize is the maximum number of carrier calls per cycle
+	BatchSize int `json:"batchSize"`
+}
+
+// DefaultTrackingPollerConfig returns default configuration
+func DefaultTrackingPollerConfig() TrackingPollerConfig {
+	return TrackingPollerConfig{
+		PollInterval: 5 * time.Minute,
+		BatchSize:    200,
+	}
+}
+
+// NewTrackingPoller creates a new tracking poller
+func NewTrackingPoller(
+	service *TrackingService,
+	config TrackingPollerConfig,
+	logger *logging.Logger,
+) *TrackingPoller {
+	return &TrackingPoller{
+		service:  service,
+		config:   config,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start begins the periodic tracking poll
+func (p *TrackingPoller) Start(ctx context.Context) error {
+	p.mu.Lock()
+	if p.running {
+		p.mu.Unlock()
+		return fmt.Errorf("tracking poller is already running")
+	}
+	p.running = true
+	p.stopChan = make(chan struct{})
+	p.mu.Unlock()
+
+	go p.run(ctx)
+	return nil
+}
+
+// Stop stops the periodic tracking poll
+func (p *TrackingPoller) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.running {
+		close(p.stopChan)
+		p.running = false
+	}
+}
+
+// IsRunning returns whether the poller is running
+func (p *TrackingPoller) IsRunning() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.running
+}
+
+// run is the main loop for the tracking poller
+func (p *TrackingPoller) run(ctx context.Context) {
+	ticker := time.NewTicker(p.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopChan:
+			return
+		case <-ticker.C:
+			if _, err := p.service.PollDue(ctx, p.config.BatchSize); err != nil {
+				p.logger.WithError(err).Warn("Tracking poll failed")
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package application
+
+import (
+	"context"
+	stdErrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// errTrackingUnchanged stops a tracking update that would leave the shipment as it was
+var errTrackingUnchanged = stdErrors.New("tracking unchanged")
+
+// TrackingService ingests carrier tracking from polls and webhooks and advances shipments.
+// Every milestone change is published as wms.shipping.tracking-updated for downstream channels.
+type TrackingService struct {
+	repo     domain.ShipmentRepository
+	carriers []domain.CarrierService
+	policy   domain.TrackingPollPolicy
+	logger   *logging.Logger
+}
+
+// NewTrackingService creates a new TrackingService
+func NewTrackingService(
+	repo domain.ShipmentRepository,
+	carriers []domain.CarrierService,
+	policy domain.TrackingPollPolicy,
+	logger *logging.Logger,
+) *TrackingService {
+	return &TrackingService{
+		repo:     repo,
+		carriers: carriers,
+		policy:   policy,
+		logger:   logger,
+	}
+}
+
+// PollDue polls the carrier for up to batchSize shipments whose next poll is due
+func (s *TrackingService) PollDue(ctx context.Context, batchSize int) (*TrackingPollResultDTO, error) {
+	now := time.Now()
+	shipments, err := s.repo.FindDueForTrackingPoll(ctx, now, batchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find shipments due for tracking: %w", err)
+	}
+
+	result := &TrackingPollResultDTO{}
+	for _, shipment := range shipments {
+		carrier := findCarrier(s.carriers, shipment.Carrier.Code)
+		if carrier == nil {
+			// Back off rather than re-reading the shipment every cycle
+			pollErr := fmt.Errorf("no carrier integration registered for %s", shipment.Carrier.Code)
+			_, err := updateShipment(shipmentContext(ctx, shipment), s.repo, s.logger, shipment.ShipmentID, func(shipment *domain.Shipment) error {
+				shipment.RecordTrackingPoll(s.policy, false, pollErr, now)
+				return nil
+			})
+			if err != nil {
+				s.logger.WithError(err).Warn("Failed to reschedule tracking poll", "shipmentId", shipment.ShipmentID)
+			}
+			result.Skipped++
+			continue
+		}
+
+		result.Polled++
+		changed, err := s.poll(ctx, carrier, shipment, now)
+		if err != nil {
+			result.Failed++
+			s.logger.Warn("Tracking poll failed", "shipmentId", shipment.ShipmentID, "carrier", shipment.Carrier.Code, "error", err.Error())
+			continue
+		}
+		if changed {
+			result.Updated++
+		}
+	}
+
+	if result.Polled > 0 {
+		s.logger.Info("Tracking poll completed", "polled", result.Polled, "updated", result.Updated, "failed", result.Failed, "skipped", result.Skipped)
+	}
+	return result, nil
+}
+
+// RefreshTracking polls the carrier for one shipment immediately
+func (s *TrackingService) RefreshTracking(ctx context.Context, cmd RefreshTrackingCommand) (*ShipmentDTO, error) {
+	shipment, err := s.repo.FindByID(ctx, cmd.ShipmentID)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get shipment", "shipmentId", cmd.ShipmentID)
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+	if shipment == nil {
+		return nil, errors.ErrNotFound("shipment")
+	}
+	if !shipment.IsTrackable() {
+		return nil, errors.ErrValidation(fmt.Sprintf("shipment in status %s has no carrier tracking", shipment.Status))
+	}
+
+	carrier := findCarrier(s.carriers, shipment.Carrier.Code)
+	if carrier == nil {
+		return nil, errors.ErrValidation(fmt.Sprintf("no carrier integration registered for %s", shipment.Carrier.Code))
+	}
+
+	if _, err := s.poll(ctx, carrier, shipment, time.Now()); err != nil {
+		var carrierErr *domain.CarrierError
+		if stdErrors.As(err, &carrierErr) {
+			return nil, errors.ErrServiceUnavailable(shipment.Carrier.Code).Wrap(err)
+		}
+		return nil, err
+	}
+	return ToShipmentDTO(shipment), nil
+}
+
+// IngestWebhook applies a tracking push from a carrier to the shipments it mentions
+func (s *TrackingService) IngestWebhook(ctx context.Context, cmd IngestTrackingWebhookCommand) (*TrackingWebhookResultDTO, error) {
+	carrier := findCarrier(s.carriers, cmd.CarrierCode)
+	if carrier == nil {
+		return nil, errors.ErrNotFound("carrier integration")
+	}
+	parser, ok := carrier.(domain.TrackingWebhookParser)
+	if !ok {
+		return nil, errors.ErrBadRequest(fmt.Sprintf("%s does not push tracking webhooks", carrier.GetCarrierCode()))
+	}
+
+	updates, err := parser.ParseTrackingWebhook(cmd.Payload)
+	if err != nil {
+		return nil, errors.ErrValidation(err.Error())
+	}
+
+	now := time.Now()
+	result := &TrackingWebhookResultDTO{Received: len(updates)}
+	for _, update := range updates {
+		shipment, err := s.repo.FindByTrackingNumber(ctx, update.TrackingNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get shipment for tracking number %s: %w", update.TrackingNumber, err)
+		}
+		if shipment == nil {
+			result.Unknown = append(result.Unknown, update.TrackingNumber)
+			continue
+		}
+
+		var rejected error
+		_, err = updateShipment(shipmentContext(ctx, shipment), s.repo, s.logger, shipment.ShipmentID, func(shipment *domain.Shipment) error {
+			changed, err := shipment.ApplyTracking(update, domain.TrackingSourceWebhook)
+			rejected = err
+			if err != nil || !changed {
+				return errTrackingUnchanged
+			}
+			shipment.ScheduleTrackingPoll(s.policy, now)
+			return nil
+		})
+		if stdErrors.Is(err, errTrackingUnchanged) {
+			if rejected != nil {
+				s.logger.Warn("Ignoring tracking webhook", "shipmentId", shipment.ShipmentID, "status", shipment.Status, "error", rejected.Error())
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Updated++
+	}
+
+	s.logger.Info("Tracking webhook ingested", "carrier", carrier.GetCarrierCode(), "received", result.Received, "updated", result.Updated, "unknown", len(result.Unknown))
+	return result, nil
+}
+
+// poll fetches tracking from the carrier, applies it and schedules the next poll.
+// The shipment is saved even when the carrier call fails so the backoff is persisted,
+// and shipment is updated to the saved state.
+func (s *TrackingService) poll(ctx context.Context, carrier domain.CarrierService, shipment *domain.Shipment, now time.Time) (bool, error) {
+	ctx = shipmentContext(ctx, shipment)
+
+	info, trackErr := carrier.TrackShipment(ctx, shipment.Label.TrackingNumber)
+
+	changed := false
+	var pollErr error
+	saved, err := updateShipment(ctx, s.repo, s.logger, shipment.ShipmentID, func(shipment *domain.Shipment) error {
+		changed, pollErr = false, trackErr
+		if pollErr == nil {
+			changed, pollErr = shipment.ApplyTracking(*info, domain.TrackingSourcePoll)
+		}
+		shipment.RecordTrackingPoll(s.policy, changed, pollErr, now)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	*shipment = *saved
+	return changed, pollErr
+}
+
+// shipmentContext scopes background work to the shipment's tenant so published events carry it
+func shipmentContext(ctx context.Context, shipment *domain.Shipment) context.Context {
+	return tenant.ToContext(ctx, &tenant.Context{
+		TenantID:    shipment.TenantID,
+		FacilityID:  shipment.FacilityID,
+		WarehouseID: shipment.WarehouseID,
+		SellerID:    shipment.SellerID,
+	})
+}
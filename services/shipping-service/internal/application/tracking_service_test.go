@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/logging"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+type fakeTrackingRepo struct {
+	fakeShipmentRepo
+	saved []*domain.Shipment
+}
+
+func (r *fakeTrackingRepo) Save(ctx context.Context, shipment *domain.Shipment) error {
+	snapshot := *shipment
+	snapshot.DomainEvents = append([]domain.DomainEvent(nil), shipment.DomainEvents...)
+	r.saved = append(r.saved, &snapshot)
+	return r.fakeShipmentRepo.Save(ctx, shipment)
+}
+
+func (r *fakeTrackingRepo) FindByTrackingNumber(_ context.Context, trackingNumber string) (*domain.Shipment, error) {
+	for _, shipment := range r.shipments {
+		if shipment.Label != nil && shipment.Label.TrackingNumber == trackingNumber {
+			return shipment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeTrackingRepo) FindDueForTrackingPoll(_ context.Context, now time.Time, limit int) ([]*domain.Shipment, error) {
+	var due []*domain.Shipment
+	for _, shipment := range r.shipments {
+		if !shipment.IsTrackable() || shipment.Status == domain.ShipmentStatusLabeled {
+			continue
+		}
+		if shipment.Tracking != nil && (shipment.Tracking.PollingStopped ||
+			(shipment.Tracking.NextPollAt != nil && shipment.Tracking.NextPollAt.After(now))) {
+			continue
+		}
+		if len(due) < limit {
+			due = append(due, shipment)
+		}
+	}
+	return due, nil
+}
+
+type fakeTrackingCarrier struct {
+	domain.CarrierService
+	code  string
+	info  *domain.TrackingInfo
+	err   error
+	calls int
+}
+
+func (c *fakeTrackingCarrier) GetCarrierCode() string { return c.code }
+
+func (c *fakeTrackingCarrier) TrackShipment(_ context.Context, _ string) (*domain.TrackingInfo, error) {
+	c.calls++
+	return c.info, c.err
+}
+
+type fakeWebhookCarrier struct {
+	fakeTrackingCarrier
+	updates []domain.TrackingInfo
+}
+
+func (c *fakeWebhookCarrier) ParseTrackingWebhook(_ []byte) ([]domain.TrackingInfo, error) {
+	return c.updates, nil
+}
+
+func newTrackingTestService(carriers ...domain.CarrierService) (*TrackingService, *fakeTrackingRepo) {
+	shipment := domain.NewShipment(
+		"SHP-001", "ORD-001", "PKG-001", "",
+		domain.Carrier{Code: "USPS"},
+		domain.PackageInfo{Weight: 2},
+		domain.Address{Name: "Recipient"}, domain.Address{Name: "Shipper"},
+	)
+	_ = shipment.GenerateLabel(domain.ShippingLabel{TrackingNumber: "9400111899223197428490"})
+	_ = shipment.ConfirmShipment(nil)
+	shipment.ClearDomainEvents()
+
+	repo := &fakeTrackingRepo{fakeShipmentRepo: fakeShipmentRepo{shipments: map[string]*domain.Shipment{shipment.ShipmentID: shipment}}}
+	logger := logging.New(logging.DefaultConfig("test"))
+	return NewTrackingService(repo, carriers, domain.DefaultTrackingPollPolicy(), logger), repo
+}
+
+func inTransitScan(at time.Time) *domain.TrackingInfo {
+	return &domain.TrackingInfo{
+		TrackingNumber: "9400111899223197428490",
+		Status:         "In Transit",
+		Events:         []domain.TrackingEvent{{Timestamp: at, Status: "Arrived"}},
+	}
+}
+
+func TestTrackingService_PollDue(t *testing.T) {
+	carrier := &fakeTrackingCarrier{code: "USPS", info: inTransitScan(time.Now().Add(-time.Hour))}
+	service, repo := newTrackingTestService(carrier)
+
+	result, err := service.PollDue(context.Background(), 50)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Polled)
+	assert.Equal(t, 1, result.Updated)
+
+	shipment := repo.shipments["SHP-001"]
+	assert.Equal(t, domain.ShipmentStatusInTransit, shipment.Status)
+	require.NotNil(t, shipment.Tracking.NextPollAt)
+	assert.True(t, shipment.Tracking.NextPollAt.After(time.Now()))
+
+	require.Len(t, repo.saved, 1)
+	require.Len(t, repo.saved[0].DomainEvents, 1)
+	assert.IsType(t, &domain.TrackingUpdatedEvent{}, repo.saved[0].DomainEvents[0])
+
+	// Nothing is due until the next scheduled poll
+	result, err = service.PollDue(context.Background(), 50)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Polled)
+	assert.Equal(t, 1, carrier.calls)
+}
+
+func TestTrackingService_PollFailureBacksOff(t *testing.T) {
+	carrier := &fakeTrackingCarrier{code: "USPS", err: domain.NewCarrierError("CARRIER_UNAVAILABLE", "USPS: down", "ERROR", true, nil)}
+	service, repo := newTrackingTestService(carrier)
+
+	result, err := service.PollDue(context.Background(), 50)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+
+	tracking := repo.shipments["SHP-001"].Tracking
+	assert.Equal(t, 1, tracking.ConsecutiveErrors)
+	assert.Contains(t, tracking.LastError, "USPS: down")
+	require.NotNil(t, tracking.NextPollAt)
+	assert.Equal(t, domain.ShipmentStatusShipped, repo.shipments["SHP-001"].Status)
+}
+
+func TestTrackingService_IngestWebhook(t *testing.T) {
+	carrier := &fakeWebhookCarrier{
+		fakeTrackingCarrier: fakeTrackingCarrier{code: "USPS"},
+		updates: []domain.TrackingInfo{
+			*inTransitScan(time.Now().Add(-time.Hour)),
+			{TrackingNumber: "UNKNOWN-123", Status: "In Transit"},
+		},
+	}
+	service, repo := newTrackingTestService(carrier)
+
+	result, err := service.IngestWebhook(context.Background(), IngestTrackingWebhookCommand{CarrierCode: "usps", Payload: []byte("{}")})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Received)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, []string{"UNKNOWN-123"}, result.Unknown)
+	assert.Equal(t, domain.ShipmentStatusInTransit, repo.shipments["SHP-001"].Status)
+	assert.NotNil(t, repo.shipments["SHP-001"].Tracking.NextPollAt)
+
+	// Redelivered webhooks do not publish again
+	result, err = service.IngestWebhook(context.Background(), IngestTrackingWebhookCommand{CarrierCode: "USPS", Payload: []byte("{}")})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Updated)
+	assert.Len(t, repo.saved, 1)
+}
+
+func TestTrackingService_IngestWebhookUnsupportedCarrier(t *testing.T) {
+	service, _ := newTrackingTestService(&fakeTrackingCarrier{code: "ONTRAC"})
+
+	_, err := service.IngestWebhook(context.Background(), IngestTrackingWebhookCommand{CarrierCode: "ONTRAC"})
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.CodeBadRequest, appErr.Code)
+}
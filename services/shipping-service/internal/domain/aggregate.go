@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/wms-platform/shared/pkg/customs"
 )
 
 // Errors
@@ -12,6 +14,7 @@ var (
 	ErrShipmentAlreadyManifested = errors.New("shipment is already manifested")
 	ErrShipmentAlreadyShipped    = errors.New("shipment is already shipped")
 	ErrNoLabel                   = errors.New("shipment has no label")
+	ErrLabelNotCurrent           = errors.New("tracking number is not the shipment's current label")
 )
 
 // ShipmentStatus represents the status of a shipment
@@ -22,6 +25,8 @@ const (
 	ShipmentStatusLabeled    ShipmentStatus = "labeled"
 	ShipmentStatusManifested ShipmentStatus = "manifested"
 	ShipmentStatusShipped    ShipmentStatus = "shipped"
+	ShipmentStatusInTransit  ShipmentStatus = "in_transit"
+	ShipmentStatusException  ShipmentStatus = "exception"
 	ShipmentStatusDelivered  ShipmentStatus = "delivered"
 	ShipmentStatusCancelled  ShipmentStatus = "cancelled"
 )
@@ -34,16 +39,23 @@ type Shipment struct {
 	FacilityID      string             `bson:"facilityId"`
 	WarehouseID     string             `bson:"warehouseId"`
 	OrderID         string             `bson:"orderId"`
+	SellerID        string             `bson:"sellerId,omitempty"`
 	PackageID       string             `bson:"packageId"`
 	WaveID          string             `bson:"waveId"`
 	Status          ShipmentStatus     `bson:"status"`
 	Carrier         Carrier            `bson:"carrier"`
+	RateSelection   *RateSelection     `bson:"rateSelection,omitempty"`
 	Label           *ShippingLabel     `bson:"label,omitempty"`
+	VoidedLabels    []VoidedLabel      `bson:"voidedLabels,omitempty"`
+	ReturnLabel     *ShippingLabel     `bson:"returnLabel,omitempty"`
 	Manifest        *Manifest          `bson:"manifest,omitempty"`
+	Tracking        *ShipmentTracking  `bson:"tracking,omitempty"`
 	Package         PackageInfo        `bson:"package"`
+	BillableWeight  *BillableWeight    `bson:"billableWeight,omitempty"`
 	Recipient       Address            `bson:"recipient"`
 	Shipper         Address            `bson:"shipper"`
 	ServiceType     string             `bson:"serviceType"`
+	Customs         *CustomsDeclaration `bson:"customs,omitempty"`
 	EstimatedDelivery *time.Time       `bson:"estimatedDelivery,omitempty"`
 	ActualDelivery  *time.Time         `bson:"actualDelivery,omitempty"`
 	CreatedAt       time.Time          `bson:"createdAt"`
@@ -51,6 +63,7 @@ type Shipment struct {
 	LabeledAt       *time.Time         `bson:"labeledAt,omitempty"`
 	ManifestedAt    *time.Time         `bson:"manifestedAt,omitempty"`
 	ShippedAt       *time.Time         `bson:"shippedAt,omitempty"`
+	Version         int                `bson:"version" json:"version"` // Incremented on every save for optimistic concurrency
 	DomainEvents    []DomainEvent      `bson:"-"`
 }
 
@@ -71,6 +84,13 @@ type ShippingLabel struct {
 	GeneratedAt    time.Time `bson:"generatedAt"`
 }
 
+// VoidedLabel is a label cancelled with the carrier so it is not billed
+type VoidedLabel struct {
+	TrackingNumber string    `bson:"trackingNumber"`
+	Reason         string    `bson:"reason"`
+	VoidedAt       time.Time `bson:"voidedAt"`
+}
+
 // Manifest represents a shipping manifest
 type Manifest struct {
 	ManifestID    string    `bson:"manifestId"`
@@ -126,6 +146,7 @@ func NewShipment(shipmentID, orderID, packageID, waveID string, carrier Carrier,
 		UpdatedAt:    now,
 		DomainEvents: make([]DomainEvent, 0),
 	}
+	s.calculateBillableWeight()
 
 	s.AddDomainEvent(&ShipmentCreatedEvent{
 		ShipmentID: shipmentID,
@@ -137,6 +158,71 @@ func NewShipment(shipmentID, orderID, packageID, waveID string, carrier Carrier,
 	return s
 }
 
+// ApplyRateSelection assigns the carrier and service chosen by rate shopping
+// and keeps the decision for cost auditing
+func (s *Shipment) ApplyRateSelection(selection RateSelection) error {
+	if s.Status != ShipmentStatusPending {
+		return ErrCarrierAlreadyAssigned
+	}
+
+	selected := selection.Selected
+	if s.Carrier.Code != selected.CarrierCode {
+		s.Carrier = Carrier{Code: selected.CarrierCode}
+	}
+	s.Carrier.ServiceType = selected.ServiceType
+	s.ServiceType = selected.ServiceType
+	if !selected.EstimatedDelivery.IsZero() {
+		estimatedDelivery := selected.EstimatedDelivery
+		s.EstimatedDelivery = &estimatedDelivery
+	}
+	s.RateSelection = &selection
+	s.calculateBillableWeight()
+	s.UpdatedAt = time.Now()
+
+	s.AddDomainEvent(&CarrierSelectedEvent{
+		ShipmentID:       s.ShipmentID,
+		OrderID:          s.OrderID,
+		SellerID:         s.SellerID,
+		Carrier:          selected.CarrierCode,
+		ServiceType:      selected.ServiceType,
+		TotalCost:        selected.TotalCost,
+		Currency:         selected.Currency,
+		Strategy:         string(selection.Strategy),
+		AlternativeCount: len(selection.Alternatives),
+		CostSavings:      selection.CostSavings,
+		SelectedAt:       selection.SelectedAt,
+	})
+
+	return nil
+}
+
+// IsInternational reports whether the shipment crosses a border
+func (s *Shipment) IsInternational() bool {
+	return customs.IsInternational(s.Shipper.Country, s.Recipient.Country)
+}
+
+// SetCustomsDeclaration records the trade data declared for the shipment's contents
+func (s *Shipment) SetCustomsDeclaration(declaration CustomsDeclaration) error {
+	if s.Label != nil || s.Status != ShipmentStatusPending {
+		return ErrCustomsDeclarationLocked
+	}
+	if err := declaration.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	declaration.DeclaredAt = now
+	s.Customs = &declaration
+	s.UpdatedAt = now
+	return nil
+}
+
+// calculateBillableWeight recalculates billable weight under the assigned carrier's rules
+func (s *Shipment) calculateBillableWeight() {
+	billable := CalculateBillableWeight(s.Carrier.Code, s.Package)
+	s.BillableWeight = &billable
+}
+
 // GenerateLabel generates and applies a shipping label
 func (s *Shipment) GenerateLabel(label ShippingLabel) error {
 	if s.Status == ShipmentStatusShipped {
@@ -159,6 +245,77 @@ func (s *Shipment) GenerateLabel(label ShippingLabel) error {
 	return nil
 }
 
+// LabelVoided reports whether the label with the tracking number was voided already
+func (s *Shipment) LabelVoided(trackingNumber string) bool {
+	for _, voided := range s.VoidedLabels {
+		if voided.TrackingNumber == trackingNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// VoidLabel records the current label as voided with the carrier and returns the shipment to
+// pending so it can be labeled again, e.g. for a changed address. A manifested shipment leaves
+// its manifest; a shipment that left the warehouse can't be voided.
+func (s *Shipment) VoidLabel(trackingNumber, reason string) error {
+	if s.LabelVoided(trackingNumber) {
+		return nil
+	}
+	if s.Status == ShipmentStatusShipped || s.Status == ShipmentStatusInTransit ||
+		s.Status == ShipmentStatusException || s.Status == ShipmentStatusDelivered {
+		return ErrShipmentAlreadyShipped
+	}
+	if s.Label == nil {
+		return ErrNoLabel
+	}
+	if s.Label.TrackingNumber != trackingNumber {
+		return ErrLabelNotCurrent
+	}
+
+	now := time.Now()
+	s.VoidedLabels = append(s.VoidedLabels, VoidedLabel{TrackingNumber: trackingNumber, Reason: reason, VoidedAt: now})
+	s.Label = nil
+	s.LabeledAt = nil
+	s.Manifest = nil
+	s.ManifestedAt = nil
+	if s.Status != ShipmentStatusCancelled {
+		s.Status = ShipmentStatusPending
+	}
+	s.UpdatedAt = now
+
+	s.AddDomainEvent(&LabelVoidedEvent{
+		ShipmentID:     s.ShipmentID,
+		OrderID:        s.OrderID,
+		TrackingNumber: trackingNumber,
+		Carrier:        s.Carrier.Code,
+		Reason:         reason,
+		VoidedAt:       now,
+	})
+	return nil
+}
+
+// AttachReturnLabel stores a prepaid return label for the shipment
+func (s *Shipment) AttachReturnLabel(label ShippingLabel) error {
+	if s.Status == ShipmentStatusCancelled {
+		return errors.New("cannot create return label for cancelled shipment")
+	}
+
+	now := time.Now()
+	s.ReturnLabel = &label
+	s.UpdatedAt = now
+
+	s.AddDomainEvent(&ReturnLabelGeneratedEvent{
+		ShipmentID:     s.ShipmentID,
+		OrderID:        s.OrderID,
+		TrackingNumber: label.TrackingNumber,
+		Carrier:        s.Carrier.Code,
+		GeneratedAt:    now,
+	})
+
+	return nil
+}
+
 // AddToManifest adds the shipment to a manifest
 func (s *Shipment) AddToManifest(manifest Manifest) error {
 	if s.Label == nil {
@@ -186,7 +343,7 @@ func (s *Shipment) AddToManifest(manifest Manifest) error {
 
 // ConfirmShipment confirms the shipment has left the warehouse
 func (s *Shipment) ConfirmShipment(estimatedDelivery *time.Time) error {
-	if s.Status == ShipmentStatusShipped {
+	if s.Status == ShipmentStatusShipped || s.Status == ShipmentStatusInTransit || s.Status == ShipmentStatusException {
 		return ErrShipmentAlreadyShipped
 	}
 
@@ -202,14 +359,20 @@ func (s *Shipment) ConfirmShipment(estimatedDelivery *time.Time) error {
 		trackingNumber = s.Label.TrackingNumber
 	}
 
-	s.AddDomainEvent(&ShipConfirmedEvent{
+	event := &ShipConfirmedEvent{
 		ShipmentID:        s.ShipmentID,
 		OrderID:           s.OrderID,
 		TrackingNumber:    trackingNumber,
 		Carrier:           s.Carrier.Code,
 		EstimatedDelivery: estimatedDelivery,
 		ShippedAt:         now,
-	})
+	}
+	if s.BillableWeight != nil {
+		event.BillableWeight = s.BillableWeight.BillableWeight
+		event.AdditionalHandling = s.BillableWeight.AdditionalHandling
+		event.Oversize = s.BillableWeight.Oversize
+	}
+	s.AddDomainEvent(event)
 
 	return nil
 }
@@ -224,7 +387,8 @@ func (s *Shipment) ConfirmDelivery(deliveredAt time.Time) error {
 
 // Cancel cancels the shipment
 func (s *Shipment) Cancel(reason string) error {
-	if s.Status == ShipmentStatusShipped || s.Status == ShipmentStatusDelivered {
+	if s.Status == ShipmentStatusShipped || s.Status == ShipmentStatusInTransit ||
+		s.Status == ShipmentStatusException || s.Status == ShipmentStatusDelivered {
 		return errors.New("cannot cancel shipped or delivered shipment")
 	}
 
@@ -291,7 +455,19 @@ type OutboundManifest struct {
 	DispatchedAt    *time.Time         `bson:"dispatchedAt,omitempty" json:"dispatchedAt,omitempty"`
 	CreatedAt       time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt       time.Time          `bson:"updatedAt" json:"updatedAt"`
+	Version         int                `bson:"version" json:"version"` // Incremented on every save for optimistic concurrency
 	DomainEvents    []DomainEvent      `bson:"-" json:"-"`
+
+	// Carrier cutoff
+	CutoffAt          *time.Time    `bson:"cutoffAt,omitempty" json:"cutoffAt,omitempty"`
+	AutoClosed        bool          `bson:"autoClosed" json:"autoClosed"`
+	CarrierManifestID string        `bson:"carrierManifestId,omitempty" json:"carrierManifestId,omitempty"`
+	BillOfLading      *BillOfLading `bson:"billOfLading,omitempty" json:"billOfLading,omitempty"`
+	PickupAlertedAt   *time.Time    `bson:"pickupAlertedAt,omitempty" json:"pickupAlertedAt,omitempty"`
+
+	// Carrier filing, recorded before the carrier is called so an interrupted filing is resumed, not repeated
+	CarrierFilingKey       string     `bson:"carrierFilingKey,omitempty" json:"-"`
+	CarrierFilingStartedAt *time.Time `bson:"carrierFilingStartedAt,omitempty" json:"-"`
 }
 
 // NewOutboundManifest creates a new OutboundManifest aggregate
@@ -90,6 +90,29 @@ func TestNewShipment(t *testing.T) {
 	assert.Equal(t, "SHIP-001", event.ShipmentID)
 }
 
+// TestShipmentBillableWeight tests billable weight follows the assigned carrier's DIM rules
+func TestShipmentBillableWeight(t *testing.T) {
+	pkg := createTestPackageInfo()
+	pkg.Weight = 2
+	pkg.Dimensions = Dimensions{Length: 40, Width: 30, Height: 30}
+
+	shipment := NewShipment("SHIP-001", "ORD-001", "PKG-001", "WAVE-001", createTestCarrier(), pkg, createTestAddress("John Doe"), createTestAddress("Warehouse A"))
+
+	require.NotNil(t, shipment.BillableWeight)
+	assert.Equal(t, "FEDEX", shipment.BillableWeight.CarrierCode)
+	assert.Equal(t, 139.0, shipment.BillableWeight.Divisor)
+	assert.True(t, shipment.BillableWeight.DimApplied)
+	assert.InDelta(t, 7.711, shipment.BillableWeight.BillableWeight, 0.001)
+	assert.False(t, shipment.BillableWeight.AdditionalHandling)
+
+	// Switching carriers recalculates under the new carrier's rules
+	require.NoError(t, shipment.ApplyRateSelection(RateSelection{
+		Selected: RateQuote{CarrierCode: "DHL", ServiceType: "EXPRESS_WORLDWIDE"},
+	}))
+	assert.Equal(t, "DHL", shipment.BillableWeight.CarrierCode)
+	assert.InDelta(t, 7.5, shipment.BillableWeight.BillableWeight, 0.001)
+}
+
 // TestShipmentGenerateLabel tests label generation
 func TestShipmentGenerateLabel(t *testing.T) {
 	tests := []struct {
@@ -148,6 +171,67 @@ func TestShipmentGenerateLabel(t *testing.T) {
 	}
 }
 
+// TestShipmentAttachReturnLabel tests return label creation
+func TestShipmentAttachReturnLabel(t *testing.T) {
+	shipment := NewShipment("SHIP-001", "ORD-001", "PKG-001", "WAVE-001",
+		createTestCarrier(), createTestPackageInfo(),
+		createTestAddress("John Doe"), createTestAddress("Warehouse A"))
+
+	request := NewLabelRequest(shipment, "zpl", true)
+	assert.Equal(t, "Warehouse A", request.Recipient.Name)
+	assert.Equal(t, "John Doe", request.Shipper.Name)
+	assert.Equal(t, LabelFormatZPL, request.LabelFormat)
+
+	require.NoError(t, shipment.AttachReturnLabel(createTestLabel()))
+	assert.NotNil(t, shipment.ReturnLabel)
+	assert.Nil(t, shipment.Label)
+	assert.Equal(t, ShipmentStatusPending, shipment.Status)
+
+	events := shipment.GetDomainEvents()
+	lastEvent, ok := events[len(events)-1].(*ReturnLabelGeneratedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "1Z999AA10123456784", lastEvent.TrackingNumber)
+
+	require.NoError(t, shipment.Cancel("customer request"))
+	assert.Error(t, shipment.AttachReturnLabel(createTestLabel()))
+}
+
+// TestShipmentVoidLabel tests voiding a label before the shipment leaves
+func TestShipmentVoidLabel(t *testing.T) {
+	shipment := NewShipment("SHIP-001", "ORD-001", "PKG-001", "WAVE-001",
+		createTestCarrier(), createTestPackageInfo(),
+		createTestAddress("John Doe"), createTestAddress("Warehouse A"))
+	assert.Equal(t, ErrNoLabel, shipment.VoidLabel("1Z999AA10123456784", "address_change"))
+
+	require.NoError(t, shipment.GenerateLabel(createTestLabel()))
+	require.NoError(t, shipment.AddToManifest(createTestManifest()))
+	assert.Equal(t, ErrLabelNotCurrent, shipment.VoidLabel("1Z000000000000000", "address_change"))
+
+	require.NoError(t, shipment.VoidLabel("1Z999AA10123456784", "address_change"))
+	assert.Equal(t, ShipmentStatusPending, shipment.Status)
+	assert.Nil(t, shipment.Label)
+	assert.Nil(t, shipment.Manifest)
+	assert.True(t, shipment.LabelVoided("1Z999AA10123456784"))
+
+	events := shipment.GetDomainEvents()
+	lastEvent, ok := events[len(events)-1].(*LabelVoidedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "address_change", lastEvent.Reason)
+
+	// Voiding again is a no-op and the shipment can be labeled anew
+	require.NoError(t, shipment.VoidLabel("1Z999AA10123456784", "address_change"))
+	assert.Len(t, shipment.VoidedLabels, 1)
+	assert.Len(t, shipment.GetDomainEvents(), len(events))
+	require.NoError(t, shipment.GenerateLabel(createTestLabel()))
+
+	shipped := NewShipment("SHIP-002", "ORD-002", "PKG-002", "WAVE-001",
+		createTestCarrier(), createTestPackageInfo(),
+		createTestAddress("Jane Smith"), createTestAddress("Warehouse A"))
+	require.NoError(t, shipped.GenerateLabel(createTestLabel()))
+	shipped.Status = ShipmentStatusShipped
+	assert.Equal(t, ErrShipmentAlreadyShipped, shipped.VoidLabel("1Z999AA10123456784", "address_change"))
+}
+
 // TestShipmentAddToManifest tests adding shipment to manifest
 func TestShipmentAddToManifest(t *testing.T) {
 	tests := []struct {
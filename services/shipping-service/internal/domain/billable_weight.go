@@ -0,0 +1,37 @@
+package domain
+
+import "github.com/wms-platform/shared/pkg/dimweight"
+
+// BillableWeight is the weight a carrier bills for a package: the greater of
+// actual and dimensional weight, after the carrier's rounding rules. Weights are in kg.
+type BillableWeight struct {
+	CarrierCode        string  `bson:"carrierCode"`
+	ActualWeight       float64 `bson:"actualWeight"`
+	DimWeight          float64 `bson:"dimWeight"`
+	BillableWeight     float64 `bson:"billableWeight"`
+	Divisor            float64 `bson:"divisor"`
+	DimApplied         bool    `bson:"dimApplied"`
+	AdditionalHandling bool    `bson:"additionalHandling"`
+	Oversize           bool    `bson:"oversize"`
+}
+
+// CalculateBillableWeight applies the carrier's dimensional weight rule to a package
+func CalculateBillableWeight(carrierCode string, pkg PackageInfo) BillableWeight {
+	result := dimweight.Calculate(carrierCode, dimweight.Package{
+		WeightKg: pkg.Weight,
+		LengthCm: pkg.Dimensions.Length,
+		WidthCm:  pkg.Dimensions.Width,
+		HeightCm: pkg.Dimensions.Height,
+	})
+
+	return BillableWeight{
+		CarrierCode:        result.CarrierCode,
+		ActualWeight:       result.ActualWeightKg,
+		DimWeight:          result.DimWeightKg,
+		BillableWeight:     result.BillableWeightKg,
+		Divisor:            result.Divisor,
+		DimApplied:         result.DimApplied,
+		AdditionalHandling: result.AdditionalHandling,
+		Oversize:           result.Oversize,
+	}
+}
@@ -28,6 +28,40 @@ type CarrierService interface {
 
 	// GetCarrierCode returns the carrier code this service handles (UPS, FEDEX, etc.)
 	GetCarrierCode() string
+
+	// GetCapabilities returns the package limits and special handling the carrier supports
+	GetCapabilities() CarrierCapabilities
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey attaches the key carrier adapters send as Idempotency-Key, so a
+// request repeated after a lost response is not executed twice by the carrier
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the key set by WithIdempotencyKey, or ""
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// TrackingWebhookParser is implemented by carrier integrations that push tracking
+// updates. It translates a webhook payload into one TrackingInfo per tracking number.
+type TrackingWebhookParser interface {
+	ParseTrackingWebhook(payload []byte) ([]TrackingInfo, error)
+}
+
+// CarrierCapabilities describes which packages a carrier accepts
+// Zero limits mean the carrier does not enforce that limit
+type CarrierCapabilities struct {
+	MaxWeightKg          float64
+	MaxLengthCm          float64
+	MaxLengthPlusGirthCm float64
+	SupportsHazmat       bool
+	// SupportsInternational is true when the carrier accepts customs data and ships cross-border
+	SupportsInternational bool
 }
 
 // LabelRequest represents a request to generate a shipping label
@@ -40,6 +74,9 @@ type LabelRequest struct {
 	LabelFormat string // PDF, ZPL, PNG
 	Reference1  string
 	Reference2  string
+	IsReturn    bool
+	// Customs is set for international shipments and sent to the carrier as electronic trade data
+	Customs *CustomsDeclaration
 }
 
 // RateRequest represents a request to get shipping rates
@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/customs"
+)
+
+// Customs errors
+var (
+	ErrCustomsDataMissing        = errors.New("international shipment has no customs declaration")
+	ErrRestrictedDestination     = errors.New("destination country is restricted")
+	ErrInvalidCustomsDeclaration = errors.New("invalid customs declaration")
+	ErrCustomsDeclarationLocked  = errors.New("customs declaration cannot change once the shipment is labeled")
+	ErrNoCustomsDeclaration      = errors.New("shipment has no customs declaration")
+)
+
+// defaultCustomsCurrency is used when a declaration does not name a currency
+const defaultCustomsCurrency = "USD"
+
+// CN22 applies while a parcel stays within the UPU limits of 300 SDR (about 400 USD)
+// and 2 kg; heavier or more valuable parcels need the CN23 dispatch note
+const (
+	CN22MaxValue    = 400.0
+	CN22MaxWeightKg = 2.0
+)
+
+// CustomsFormType is the postal customs declaration printed for a parcel
+type CustomsFormType string
+
+const (
+	CustomsFormCN22 CustomsFormType = "CN22"
+	CustomsFormCN23 CustomsFormType = "CN23"
+)
+
+// ContentsType is the nature of the goods declared to customs
+type ContentsType string
+
+const (
+	ContentsTypeMerchandise ContentsType = "merchandise"
+	ContentsTypeGift        ContentsType = "gift"
+	ContentsTypeDocuments   ContentsType = "documents"
+	ContentsTypeSample      ContentsType = "sample"
+	ContentsTypeReturn      ContentsType = "return"
+)
+
+// IsValid checks if the contents type is supported
+func (c ContentsType) IsValid() bool {
+	switch c {
+	case ContentsTypeMerchandise, ContentsTypeGift, ContentsTypeDocuments, ContentsTypeSample, ContentsTypeReturn:
+		return true
+	default:
+		return false
+	}
+}
+
+// CustomsItem is one line of a customs declaration
+type CustomsItem struct {
+	SKU             string  `bson:"sku" json:"sku"`
+	Description     string  `bson:"description" json:"description"`
+	HSCode          string  `bson:"hsCode" json:"hsCode"`
+	CountryOfOrigin string  `bson:"countryOfOrigin" json:"countryOfOrigin"`
+	Quantity        int     `bson:"quantity" json:"quantity"`
+	UnitValue       float64 `bson:"unitValue" json:"unitValue"`
+	Weight          float64 `bson:"weight" json:"weight"` // kg per unit
+}
+
+// Value returns the declared value of the line
+func (i CustomsItem) Value() float64 {
+	return i.UnitValue * float64(i.Quantity)
+}
+
+// CustomsDeclaration is the electronic trade data sent to the carrier and printed
+// on the commercial invoice and CN22/CN23 for an international shipment
+type CustomsDeclaration struct {
+	ContentsType  ContentsType     `bson:"contentsType" json:"contentsType"`
+	Incoterm      customs.Incoterm `bson:"incoterm" json:"incoterm"`
+	Currency      string           `bson:"currency" json:"currency"`
+	Items         []CustomsItem    `bson:"items" json:"items"`
+	InvoiceNumber string           `bson:"invoiceNumber,omitempty" json:"invoiceNumber,omitempty"`
+	ExporterID    string           `bson:"exporterId,omitempty" json:"exporterId,omitempty"` // EIN or EORI of the shipper
+	ImporterTaxID string           `bson:"importerTaxId,omitempty" json:"importerTaxId,omitempty"`
+	DeclaredAt    time.Time        `bson:"declaredAt" json:"declaredAt"`
+}
+
+// TotalValue returns the declared value of all lines
+func (d *CustomsDeclaration) TotalValue() float64 {
+	total := 0.0
+	for _, item := range d.Items {
+		total += item.Value()
+	}
+	return total
+}
+
+// TotalWeight returns the declared net weight of all lines in kg
+func (d *CustomsDeclaration) TotalWeight() float64 {
+	total := 0.0
+	for _, item := range d.Items {
+		total += item.Weight * float64(item.Quantity)
+	}
+	return total
+}
+
+// FormType returns which postal customs form the declaration needs
+func (d *CustomsDeclaration) FormType() CustomsFormType {
+	if d.TotalValue() <= CN22MaxValue && d.TotalWeight() <= CN22MaxWeightKg {
+		return CustomsFormCN22
+	}
+	return CustomsFormCN23
+}
+
+// Validate normalizes codes, applies defaults and checks every line carries complete customs data
+func (d *CustomsDeclaration) Validate() error {
+	if d.ContentsType == "" {
+		d.ContentsType = ContentsTypeMerchandise
+	}
+	if !d.ContentsType.IsValid() {
+		return fmt.Errorf("%w: unknown contents type %q", ErrInvalidCustomsDeclaration, d.ContentsType)
+	}
+
+	if d.Incoterm == "" {
+		d.Incoterm = customs.IncotermDAP
+	}
+	incoterm, err := customs.ParseIncoterm(string(d.Incoterm))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCustomsDeclaration, err)
+	}
+	d.Incoterm = incoterm
+
+	d.Currency = strings.ToUpper(strings.TrimSpace(d.Currency))
+	if d.Currency == "" {
+		d.Currency = defaultCustomsCurrency
+	}
+	if len(d.Currency) != 3 {
+		return fmt.Errorf("%w: currency %q must be an ISO 4217 code", ErrInvalidCustomsDeclaration, d.Currency)
+	}
+
+	if len(d.Items) == 0 {
+		return fmt.Errorf("%w: at least one item is required", ErrInvalidCustomsDeclaration)
+	}
+	for i := range d.Items {
+		item := &d.Items[i]
+		item.HSCode = customs.NormalizeHSCode(item.HSCode)
+		item.CountryOfOrigin = customs.NormalizeCountryCode(item.CountryOfOrigin)
+		item.Description = strings.TrimSpace(item.Description)
+
+		if err := customs.ValidateHSCode(item.HSCode); err != nil {
+			return fmt.Errorf("%w: item %s: %v", ErrInvalidCustomsDeclaration, item.SKU, err)
+		}
+		if err := customs.ValidateCountryCode(item.CountryOfOrigin); err != nil {
+			return fmt.Errorf("%w: item %s: %v", ErrInvalidCustomsDeclaration, item.SKU, err)
+		}
+		if item.Description == "" {
+			return fmt.Errorf("%w: item %s: description is required", ErrInvalidCustomsDeclaration, item.SKU)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("%w: item %s: quantity must be positive", ErrInvalidCustomsDeclaration, item.SKU)
+		}
+		if item.UnitValue < 0 || item.Weight < 0 {
+			return fmt.Errorf("%w: item %s: value and weight cannot be negative", ErrInvalidCustomsDeclaration, item.SKU)
+		}
+	}
+	return nil
+}
+
+// ForReturn copies the declaration for a return label, where the goods are declared
+// as returned merchandise coming back to the shipper
+func (d *CustomsDeclaration) ForReturn() *CustomsDeclaration {
+	declaration := *d
+	declaration.Items = append([]CustomsItem(nil), d.Items...)
+	declaration.ContentsType = ContentsTypeReturn
+	return &declaration
+}
+
+// CustomsPolicy decides whether a shipment may be labeled for its destination
+type CustomsPolicy struct {
+	RestrictedCountries []string
+}
+
+// DefaultCustomsPolicy returns the policy blocking comprehensively embargoed destinations
+func DefaultCustomsPolicy() CustomsPolicy {
+	return CustomsPolicy{RestrictedCountries: customs.DefaultRestrictedCountries}
+}
+
+// Check blocks shipments to restricted destinations and international shipments
+// without a complete customs declaration
+func (p CustomsPolicy) Check(shipment *Shipment) error {
+	if customs.IsRestricted(shipment.Recipient.Country, p.RestrictedCountries) {
+		return fmt.Errorf("%w: %s", ErrRestrictedDestination, customs.NormalizeCountryCode(shipment.Recipient.Country))
+	}
+	if !shipment.IsInternational() {
+		return nil
+	}
+	if shipment.Customs == nil {
+		return ErrCustomsDataMissing
+	}
+	return shipment.Customs.Validate()
+}
+
+// CustomsDocumentRenderer is the domain interface (port) for printing customs paperwork
+type CustomsDocumentRenderer interface {
+	// RenderCommercialInvoice produces the commercial invoice that travels with the shipment
+	RenderCommercialInvoice(shipment *Shipment) ([]byte, error)
+	// RenderCustomsForm produces the CN22 or CN23 declaration, whichever the contents require
+	RenderCustomsForm(shipment *Shipment) ([]byte, error)
+}
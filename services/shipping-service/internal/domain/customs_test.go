@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/shared/pkg/customs"
+)
+
+func createTestCustomsDeclaration() CustomsDeclaration {
+	return CustomsDeclaration{
+		Items: []CustomsItem{
+			{SKU: "SKU-001", Description: " Cotton T-shirt ", HSCode: "6109.10", CountryOfOrigin: "bd", Quantity: 2, UnitValue: 15, Weight: 0.2},
+		},
+	}
+}
+
+func createTestInternationalShipment() *Shipment {
+	recipient := createTestAddress("Jane Doe")
+	recipient.Country = "CA"
+	return NewShipment("SHP-001", "ORD-001", "PKG-001", "WAVE-001", createTestCarrier(), createTestPackageInfo(), recipient, createTestAddress("Warehouse"))
+}
+
+// TestCustomsDeclarationValidate tests defaults, normalization and rejected declarations
+func TestCustomsDeclarationValidate(t *testing.T) {
+	t.Run("Applies defaults and normalizes codes", func(t *testing.T) {
+		declaration := createTestCustomsDeclaration()
+
+		require.NoError(t, declaration.Validate())
+		assert.Equal(t, ContentsTypeMerchandise, declaration.ContentsType)
+		assert.Equal(t, customs.IncotermDAP, declaration.Incoterm)
+		assert.Equal(t, "USD", declaration.Currency)
+		assert.Equal(t, "610910", declaration.Items[0].HSCode)
+		assert.Equal(t, "BD", declaration.Items[0].CountryOfOrigin)
+		assert.Equal(t, "Cotton T-shirt", declaration.Items[0].Description)
+		assert.InDelta(t, 30.0, declaration.TotalValue(), 0.001)
+		assert.InDelta(t, 0.4, declaration.TotalWeight(), 0.001)
+	})
+
+	tests := []struct {
+		name   string
+		mutate func(d *CustomsDeclaration)
+	}{
+		{name: "no items", mutate: func(d *CustomsDeclaration) { d.Items = nil }},
+		{name: "unknown contents type", mutate: func(d *CustomsDeclaration) { d.ContentsType = "livestock" }},
+		{name: "unknown incoterm", mutate: func(d *CustomsDeclaration) { d.Incoterm = "FOB" }},
+		{name: "bad currency", mutate: func(d *CustomsDeclaration) { d.Currency = "DOLLARS" }},
+		{name: "missing HS code", mutate: func(d *CustomsDeclaration) { d.Items[0].HSCode = "" }},
+		{name: "bad country of origin", mutate: func(d *CustomsDeclaration) { d.Items[0].CountryOfOrigin = "BGD" }},
+		{name: "missing description", mutate: func(d *CustomsDeclaration) { d.Items[0].Description = "" }},
+		{name: "zero quantity", mutate: func(d *CustomsDeclaration) { d.Items[0].Quantity = 0 }},
+		{name: "negative value", mutate: func(d *CustomsDeclaration) { d.Items[0].UnitValue = -1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			declaration := createTestCustomsDeclaration()
+			tt.mutate(&declaration)
+
+			assert.ErrorIs(t, declaration.Validate(), ErrInvalidCustomsDeclaration)
+		})
+	}
+}
+
+// TestCustomsDeclarationFormType tests the CN22/CN23 thresholds
+func TestCustomsDeclarationFormType(t *testing.T) {
+	declaration := createTestCustomsDeclaration()
+	assert.Equal(t, CustomsFormCN22, declaration.FormType())
+
+	declaration.Items[0].UnitValue = 250
+	assert.Equal(t, CustomsFormCN23, declaration.FormType())
+
+	declaration.Items[0].UnitValue = 15
+	declaration.Items[0].Weight = 1.5
+	assert.Equal(t, CustomsFormCN23, declaration.FormType())
+}
+
+// TestCustomsPolicyCheck tests destination restrictions and required customs data
+func TestCustomsPolicyCheck(t *testing.T) {
+	policy := DefaultCustomsPolicy()
+
+	t.Run("Domestic shipment needs no declaration", func(t *testing.T) {
+		shipment := createTestInternationalShipment()
+		shipment.Recipient.Country = "US"
+
+		assert.NoError(t, policy.Check(shipment))
+	})
+
+	t.Run("International shipment without declaration is blocked", func(t *testing.T) {
+		assert.ErrorIs(t, policy.Check(createTestInternationalShipment()), ErrCustomsDataMissing)
+	})
+
+	t.Run("International shipment with declaration passes", func(t *testing.T) {
+		shipment := createTestInternationalShipment()
+		require.NoError(t, shipment.SetCustomsDeclaration(createTestCustomsDeclaration()))
+
+		assert.NoError(t, policy.Check(shipment))
+	})
+
+	t.Run("Restricted destination is blocked", func(t *testing.T) {
+		shipment := createTestInternationalShipment()
+		shipment.Recipient.Country = "kp"
+		require.NoError(t, shipment.SetCustomsDeclaration(createTestCustomsDeclaration()))
+
+		assert.ErrorIs(t, policy.Check(shipment), ErrRestrictedDestination)
+	})
+}
+
+// TestShipmentSetCustomsDeclaration tests the declaration is locked once labeled
+func TestShipmentSetCustomsDeclaration(t *testing.T) {
+	shipment := createTestInternationalShipment()
+
+	require.NoError(t, shipment.SetCustomsDeclaration(createTestCustomsDeclaration()))
+	assert.False(t, shipment.Customs.DeclaredAt.IsZero())
+
+	invalid := createTestCustomsDeclaration()
+	invalid.Items[0].HSCode = "12"
+	assert.ErrorIs(t, shipment.SetCustomsDeclaration(invalid), ErrInvalidCustomsDeclaration)
+
+	require.NoError(t, shipment.GenerateLabel(createTestLabel()))
+	assert.ErrorIs(t, shipment.SetCustomsDeclaration(createTestCustomsDeclaration()), ErrCustomsDeclarationLocked)
+}
+
+// TestNewLabelRequestCustoms tests customs data is passed to carriers for international labels
+func TestNewLabelRequestCustoms(t *testing.T) {
+	shipment := createTestInternationalShipment()
+	require.NoError(t, shipment.SetCustomsDeclaration(createTestCustomsDeclaration()))
+
+	request := NewLabelRequest(shipment, "PDF", false)
+	require.NotNil(t, request.Customs)
+	assert.Equal(t, ContentsTypeMerchandise, request.Customs.ContentsType)
+
+	returnRequest := NewLabelRequest(shipment, "PDF", true)
+	require.NotNil(t, returnRequest.Customs)
+	assert.Equal(t, ContentsTypeReturn, returnRequest.Customs.ContentsType)
+	assert.Equal(t, ContentsTypeMerchandise, shipment.Customs.ContentsType)
+
+	shipment.Recipient.Country = "US"
+	assert.Nil(t, NewLabelRequest(shipment, "PDF", false).Customs)
+}
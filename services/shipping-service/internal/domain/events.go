@@ -19,6 +19,24 @@ type ShipmentCreatedEvent struct {
 func (e *ShipmentCreatedEvent) EventType() string    { return "wms.shipping.shipment-created" }
 func (e *ShipmentCreatedEvent) OccurredAt() time.Time { return e.CreatedAt }
 
+// CarrierSelectedEvent is published when rate shopping assigns a carrier to a shipment
+type CarrierSelectedEvent struct {
+	ShipmentID       string    `json:"shipmentId"`
+	OrderID          string    `json:"orderId"`
+	SellerID         string    `json:"sellerId,omitempty"`
+	Carrier          string    `json:"carrier"`
+	ServiceType      string    `json:"serviceType"`
+	TotalCost        float64   `json:"totalCost"`
+	Currency         string    `json:"currency"`
+	Strategy         string    `json:"strategy"`
+	AlternativeCount int       `json:"alternativeCount"`
+	CostSavings      float64   `json:"costSavings"`
+	SelectedAt       time.Time `json:"selectedAt"`
+}
+
+func (e *CarrierSelectedEvent) EventType() string     { return "wms.shipping.carrier-selected" }
+func (e *CarrierSelectedEvent) OccurredAt() time.Time { return e.SelectedAt }
+
 // LabelGeneratedEvent is published when a shipping label is generated
 type LabelGeneratedEvent struct {
 	ShipmentID     string    `json:"shipmentId"`
@@ -30,6 +48,31 @@ type LabelGeneratedEvent struct {
 func (e *LabelGeneratedEvent) EventType() string    { return "wms.shipping.label-generated" }
 func (e *LabelGeneratedEvent) OccurredAt() time.Time { return e.GeneratedAt }
 
+// LabelVoidedEvent is published when a shipping label is cancelled with the carrier
+type LabelVoidedEvent struct {
+	ShipmentID     string    `json:"shipmentId"`
+	OrderID        string    `json:"orderId"`
+	TrackingNumber string    `json:"trackingNumber"`
+	Carrier        string    `json:"carrier"`
+	Reason         string    `json:"reason"`
+	VoidedAt       time.Time `json:"voidedAt"`
+}
+
+func (e *LabelVoidedEvent) EventType() string    { return "wms.shipping.label-voided" }
+func (e *LabelVoidedEvent) OccurredAt() time.Time { return e.VoidedAt }
+
+// ReturnLabelGeneratedEvent is published when a prepaid return label is generated
+type ReturnLabelGeneratedEvent struct {
+	ShipmentID     string    `json:"shipmentId"`
+	OrderID        string    `json:"orderId"`
+	TrackingNumber string    `json:"trackingNumber"`
+	Carrier        string    `json:"carrier"`
+	GeneratedAt    time.Time `json:"generatedAt"`
+}
+
+func (e *ReturnLabelGeneratedEvent) EventType() string     { return "wms.shipping.return-label-generated" }
+func (e *ReturnLabelGeneratedEvent) OccurredAt() time.Time { return e.GeneratedAt }
+
 // ShipmentManifestedEvent is published when a shipment is added to a manifest
 type ShipmentManifestedEvent struct {
 	ShipmentID     string    `json:"shipmentId"`
@@ -49,11 +92,38 @@ type ShipConfirmedEvent struct {
 	Carrier           string     `json:"carrier"`
 	EstimatedDelivery *time.Time `json:"estimatedDelivery,omitempty"`
 	ShippedAt         time.Time  `json:"shippedAt"`
+	// BillableWeight is the carrier's billable weight in kg, used for shipping fees
+	BillableWeight     float64 `json:"billableWeight,omitempty"`
+	AdditionalHandling bool    `json:"additionalHandling,omitempty"`
+	Oversize           bool    `json:"oversize,omitempty"`
 }
 
 func (e *ShipConfirmedEvent) EventType() string    { return "wms.shipping.confirmed" }
 func (e *ShipConfirmedEvent) OccurredAt() time.Time { return e.ShippedAt }
 
+// TrackingUpdatedEvent is published when a carrier scan moves a shipment to a new milestone
+type TrackingUpdatedEvent struct {
+	ShipmentID        string     `json:"shipmentId"`
+	OrderID           string     `json:"orderId"`
+	TenantID          string     `json:"tenantId,omitempty"`
+	SellerID          string     `json:"sellerId,omitempty"`
+	TrackingNumber    string     `json:"trackingNumber"`
+	Carrier           string     `json:"carrier"`
+	Milestone         string     `json:"milestone"`
+	ShipmentStatus    string     `json:"shipmentStatus"`
+	CarrierStatus     string     `json:"carrierStatus"`
+	StatusDetail      string     `json:"statusDetail,omitempty"`
+	Location          string     `json:"location,omitempty"`
+	EstimatedDelivery *time.Time `json:"estimatedDelivery,omitempty"`
+	DeliveredAt       *time.Time `json:"deliveredAt,omitempty"`
+	Source            string     `json:"source"`
+	EventAt           time.Time  `json:"eventAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
+
+func (e *TrackingUpdatedEvent) EventType() string     { return "wms.shipping.tracking-updated" }
+func (e *TrackingUpdatedEvent) OccurredAt() time.Time { return e.UpdatedAt }
+
 // ManifestClosedEvent is published when an outbound manifest is closed
 type ManifestClosedEvent struct {
 	ManifestID   string    `json:"manifestId"`
@@ -79,3 +149,18 @@ type ManifestDispatchedEvent struct {
 
 func (e *ManifestDispatchedEvent) EventType() string     { return "wms.shipping.manifest-dispatched" }
 func (e *ManifestDispatchedEvent) OccurredAt() time.Time { return e.DispatchedAt }
+
+// ManifestPickupMissedEvent is published when a closed manifest has not been dispatched
+// by its scheduled carrier pickup
+type ManifestPickupMissedEvent struct {
+	ManifestID      string    `json:"manifestId"`
+	CarrierID       string    `json:"carrierId"`
+	FacilityID      string    `json:"facilityId"`
+	TrailerID       string    `json:"trailerId,omitempty"`
+	PackageCount    int       `json:"packageCount"`
+	ScheduledPickup time.Time `json:"scheduledPickup"`
+	AlertedAt       time.Time `json:"alertedAt"`
+}
+
+func (e *ManifestPickupMissedEvent) EventType() string     { return "wms.shipping.manifest-pickup-missed" }
+func (e *ManifestPickupMissedEvent) OccurredAt() time.Time { return e.AlertedAt }
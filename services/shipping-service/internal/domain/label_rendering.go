@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Label rendering errors
+var (
+	ErrUnsupportedLabelFormat = errors.New("unsupported label format")
+	ErrNoReturnLabel          = errors.New("shipment has no return label")
+)
+
+// Label formats
+const (
+	LabelFormatPDF = "PDF"
+	LabelFormatZPL = "ZPL"
+	LabelFormatPNG = "PNG"
+)
+
+// NormalizeLabelFormat upper-cases a requested label format, defaulting to PDF
+func NormalizeLabelFormat(format string) string {
+	if format == "" {
+		return LabelFormatPDF
+	}
+	return strings.ToUpper(format)
+}
+
+// LabelDocument is everything printed on a 4x6 shipping label
+type LabelDocument struct {
+	TrackingNumber string
+	CarrierCode    string
+	ServiceType    string
+	Shipper        Address
+	Recipient      Address
+	Package        PackageInfo
+	Reference1     string
+	Reference2     string
+	IsReturn       bool
+	ShipDate       time.Time
+}
+
+// NewLabelDocument builds the printable label content for a carrier label request
+func NewLabelDocument(request LabelRequest, carrierCode, trackingNumber string) LabelDocument {
+	return LabelDocument{
+		TrackingNumber: trackingNumber,
+		CarrierCode:    carrierCode,
+		ServiceType:    request.ServiceType,
+		Shipper:        request.Shipper,
+		Recipient:      request.Recipient,
+		Package:        request.PackageInfo,
+		Reference1:     request.Reference1,
+		Reference2:     request.Reference2,
+		IsReturn:       request.IsReturn,
+		ShipDate:       time.Now(),
+	}
+}
+
+// NewLabelRequest builds a carrier label request for the shipment.
+// Return labels swap shipper and recipient so the package comes back to the warehouse,
+// and international return labels declare the contents as returned goods.
+func NewLabelRequest(shipment *Shipment, labelFormat string, isReturn bool) LabelRequest {
+	request := LabelRequest{
+		ShipmentID:  shipment.ShipmentID,
+		PackageInfo: shipment.Package,
+		Shipper:     shipment.Shipper,
+		Recipient:   shipment.Recipient,
+		ServiceType: shipment.ServiceType,
+		LabelFormat: NormalizeLabelFormat(labelFormat),
+		Reference1:  shipment.OrderID,
+		Reference2:  shipment.PackageID,
+		IsReturn:    isReturn,
+	}
+	if isReturn {
+		request.Shipper, request.Recipient = shipment.Recipient, shipment.Shipper
+	}
+	if shipment.Customs != nil && shipment.IsInternational() {
+		request.Customs = shipment.Customs
+		if isReturn {
+			request.Customs = shipment.Customs.ForReturn()
+		}
+	}
+	return request
+}
+
+// LabelRenderer is the domain interface (port) for turning label content into printable bytes
+type LabelRenderer interface {
+	// Render produces the label in the requested format (ZPL, PDF)
+	Render(doc LabelDocument, format string) ([]byte, error)
+}
@@ -0,0 +1,316 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Manifest cutoff errors
+var (
+	ErrInvalidPickupSchedule  = errors.New("invalid carrier pickup schedule")
+	ErrNoPackagesBeforeCutoff = errors.New("no packages were added before the cutoff")
+	ErrManifestNotClosed      = errors.New("manifest is not closed")
+	ErrNoBillOfLading         = errors.New("manifest has no bill of lading")
+	ErrNoPickupDaysInSchedule = errors.New("pickup schedule has no pickup days")
+)
+
+const (
+	pickupScheduleClockLayout = "15:04"
+	defaultMissedPickupGrace  = 30 * time.Minute
+)
+
+var defaultPickupDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// CarrierPickupSchedule is a carrier's daily pickup at a facility. Open manifests
+// for the carrier are closed at the cutoff and must be dispatched by the pickup time.
+type CarrierPickupSchedule struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID    string             `bson:"tenantId"`
+	FacilityID  string             `bson:"facilityId"`
+	CarrierCode string             `bson:"carrierCode"`
+	CarrierName string             `bson:"carrierName"`
+	ServiceType string             `bson:"serviceType,omitempty"`
+	// PickupDays are the days the carrier collects; empty means Monday to Friday
+	PickupDays []time.Weekday `bson:"pickupDays,omitempty"`
+	// CutoffTime and PickupTime are local wall-clock times (HH:MM) in TimeZone
+	CutoffTime string `bson:"cutoffTime"`
+	PickupTime string `bson:"pickupTime"`
+	TimeZone   string `bson:"timeZone"`
+	// MissedPickupGraceMinutes is how long after pickup an undispatched manifest raises an alert
+	MissedPickupGraceMinutes int       `bson:"missedPickupGraceMinutes"`
+	ShipFrom                 Address   `bson:"shipFrom"`
+	Active                   bool      `bson:"active"`
+	CreatedAt                time.Time `bson:"createdAt"`
+	UpdatedAt                time.Time `bson:"updatedAt"`
+}
+
+// Validate checks the schedule can be used to compute cutoffs
+func (s *CarrierPickupSchedule) Validate() error {
+	if s.FacilityID == "" {
+		return fmt.Errorf("%w: facilityId is required", ErrInvalidPickupSchedule)
+	}
+	if s.CarrierCode == "" {
+		return fmt.Errorf("%w: carrierCode is required", ErrInvalidPickupSchedule)
+	}
+	cutoff, err := time.Parse(pickupScheduleClockLayout, s.CutoffTime)
+	if err != nil {
+		return fmt.Errorf("%w: cutoffTime must be HH:MM", ErrInvalidPickupSchedule)
+	}
+	pickup, err := time.Parse(pickupScheduleClockLayout, s.PickupTime)
+	if err != nil {
+		return fmt.Errorf("%w: pickupTime must be HH:MM", ErrInvalidPickupSchedule)
+	}
+	if pickup.Before(cutoff) {
+		return fmt.Errorf("%w: pickupTime cannot be before cutoffTime", ErrInvalidPickupSchedule)
+	}
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		return fmt.Errorf("%w: unknown timeZone %q", ErrInvalidPickupSchedule, s.TimeZone)
+	}
+	if s.MissedPickupGraceMinutes < 0 {
+		return fmt.Errorf("%w: missedPickupGraceMinutes cannot be negative", ErrInvalidPickupSchedule)
+	}
+	for _, day := range s.PickupDays {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("%w: invalid pickup day %d", ErrInvalidPickupSchedule, day)
+		}
+	}
+	return nil
+}
+
+// LastCutoff returns the most recent cutoff at or before now and the pickup that follows it
+func (s *CarrierPickupSchedule) LastCutoff(now time.Time) (cutoff, pickup time.Time, err error) {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: unknown timeZone %q", ErrInvalidPickupSchedule, s.TimeZone)
+	}
+	local := now.In(loc)
+	for days := 0; days <= 7; days++ {
+		cutoff, pickup, ok := s.cutoffOn(local.AddDate(0, 0, -days), loc)
+		if ok && !cutoff.After(now) {
+			return cutoff, pickup, nil
+		}
+	}
+	return time.Time{}, time.Time{}, ErrNoPickupDaysInSchedule
+}
+
+// NextCutoff returns the first cutoff after the given time and its pickup
+func (s *CarrierPickupSchedule) NextCutoff(after time.Time) (cutoff, pickup time.Time, err error) {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: unknown timeZone %q", ErrInvalidPickupSchedule, s.TimeZone)
+	}
+	local := after.In(loc)
+	for days := 0; days <= 7; days++ {
+		cutoff, pickup, ok := s.cutoffOn(local.AddDate(0, 0, days), loc)
+		if ok && cutoff.After(after) {
+			return cutoff, pickup, nil
+		}
+	}
+	return time.Time{}, time.Time{}, ErrNoPickupDaysInSchedule
+}
+
+// MissedPickupGrace is how long after pickup a closed manifest may wait before alerting
+func (s *CarrierPickupSchedule) MissedPickupGrace() time.Duration {
+	if s.MissedPickupGraceMinutes == 0 {
+		return defaultMissedPickupGrace
+	}
+	return time.Duration(s.MissedPickupGraceMinutes) * time.Minute
+}
+
+// cutoffOn returns the cutoff and pickup on the given local day, if it is a pickup day
+func (s *CarrierPickupSchedule) cutoffOn(day time.Time, loc *time.Location) (cutoff, pickup time.Time, ok bool) {
+	if !s.isPickupDay(day.Weekday()) {
+		return time.Time{}, time.Time{}, false
+	}
+	cutoffClock, err := time.Parse(pickupScheduleClockLayout, s.CutoffTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	pickupClock, err := time.Parse(pickupScheduleClockLayout, s.PickupTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	cutoff = time.Date(day.Year(), day.Month(), day.Day(), cutoffClock.Hour(), cutoffClock.Minute(), 0, 0, loc)
+	pickup = time.Date(day.Year(), day.Month(), day.Day(), pickupClock.Hour(), pickupClock.Minute(), 0, 0, loc)
+	return cutoff, pickup, true
+}
+
+func (s *CarrierPickupSchedule) isPickupDay(weekday time.Weekday) bool {
+	days := s.PickupDays
+	if len(days) == 0 {
+		days = defaultPickupDays
+	}
+	for _, day := range days {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// BillOfLading is the shipping document handed to the carrier driver at pickup
+type BillOfLading struct {
+	BOLNumber         string    `bson:"bolNumber" json:"bolNumber"`
+	CarrierCode       string    `bson:"carrierCode" json:"carrierCode"`
+	CarrierName       string    `bson:"carrierName" json:"carrierName"`
+	CarrierManifestID string    `bson:"carrierManifestId,omitempty" json:"carrierManifestId,omitempty"`
+	ShipFrom          Address   `bson:"shipFrom" json:"shipFrom"`
+	TrailerID         string    `bson:"trailerId,omitempty" json:"trailerId,omitempty"`
+	PackageCount      int       `bson:"packageCount" json:"packageCount"`
+	TotalWeight       float64   `bson:"totalWeight" json:"totalWeight"`
+	PickupAt          time.Time `bson:"pickupAt" json:"pickupAt"`
+	IssuedAt          time.Time `bson:"issuedAt" json:"issuedAt"`
+}
+
+// NewBillOfLading builds the bill of lading for a closed manifest
+func NewBillOfLading(manifest *OutboundManifest, schedule *CarrierPickupSchedule, carrierManifestID string, issuedAt time.Time) BillOfLading {
+	bol := BillOfLading{
+		BOLNumber:         fmt.Sprintf("BOL-%s-%s-%s", strings.ToUpper(manifest.CarrierID), issuedAt.UTC().Format("20060102"), manifestSuffix(manifest.ManifestID)),
+		CarrierCode:       manifest.CarrierID,
+		CarrierName:       manifest.CarrierName,
+		CarrierManifestID: carrierManifestID,
+		ShipFrom:          schedule.ShipFrom,
+		TrailerID:         manifest.TrailerID,
+		PackageCount:      manifest.TotalPackages,
+		TotalWeight:       manifest.TotalWeight,
+		IssuedAt:          issuedAt,
+	}
+	if manifest.ScheduledPickup != nil {
+		bol.PickupAt = *manifest.ScheduledPickup
+	}
+	return bol
+}
+
+// manifestSuffix keeps BOL numbers unique per manifest on the same day
+func manifestSuffix(manifestID string) string {
+	if i := strings.LastIndex(manifestID, "-"); i >= 0 && i < len(manifestID)-1 {
+		return strings.ToUpper(manifestID[i+1:])
+	}
+	return strings.ToUpper(manifestID)
+}
+
+// CloseAtCutoff closes the manifest with the packages added up to the cutoff.
+// Packages added after the cutoff are removed and returned so they can roll to the next manifest.
+func (m *OutboundManifest) CloseAtCutoff(cutoff, pickup time.Time) ([]ManifestPackage, error) {
+	if m.Status != ManifestStatusOpen {
+		return nil, errors.New("manifest is not open")
+	}
+
+	onTime := make([]ManifestPackage, 0, len(m.Packages))
+	late := make([]ManifestPackage, 0)
+	for _, pkg := range m.Packages {
+		if pkg.AddedAt.After(cutoff) {
+			late = append(late, pkg)
+		} else {
+			onTime = append(onTime, pkg)
+		}
+	}
+	if len(onTime) == 0 {
+		return nil, ErrNoPackagesBeforeCutoff
+	}
+
+	m.Packages = onTime
+	m.TotalPackages = len(onTime)
+	m.TotalWeight = 0
+	for _, pkg := range onTime {
+		m.TotalWeight += pkg.Weight
+	}
+	cutoffAt := cutoff.UTC()
+	pickupAt := pickup.UTC()
+	m.CutoffAt = &cutoffAt
+	m.ScheduledPickup = &pickupAt
+	m.AutoClosed = true
+
+	if err := m.Close(); err != nil {
+		return nil, err
+	}
+	return late, nil
+}
+
+// BeginCarrierFiling records that the manifest is about to be filed with the carrier and
+// returns the idempotency key to send with the filing. The key is stable across retries.
+func (m *OutboundManifest) BeginCarrierFiling(now time.Time) string {
+	if m.CarrierFilingKey == "" {
+		startedAt := now.UTC()
+		m.CarrierFilingKey = "manifest-" + m.ManifestID
+		m.CarrierFilingStartedAt = &startedAt
+	}
+	return m.CarrierFilingKey
+}
+
+// CarrierFilingPending reports whether the manifest was closed but its carrier filing and
+// bill of lading were not recorded yet
+func (m *OutboundManifest) CarrierFilingPending() bool {
+	return m.Status == ManifestStatusClosed && m.CarrierFilingKey != "" && m.BillOfLading == nil
+}
+
+// AttachBillOfLading records the carrier's manifest and the bill of lading issued at close
+func (m *OutboundManifest) AttachBillOfLading(bol BillOfLading) error {
+	if m.Status != ManifestStatusClosed {
+		return ErrManifestNotClosed
+	}
+	m.CarrierManifestID = bol.CarrierManifestID
+	m.BillOfLading = &bol
+	m.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// FlagMissedPickup raises an alert once if the manifest is still waiting for dispatch
+// after its scheduled pickup plus grace. It reports whether an alert was raised.
+func (m *OutboundManifest) FlagMissedPickup(now time.Time, grace time.Duration) bool {
+	if m.Status != ManifestStatusClosed || m.ScheduledPickup == nil || m.PickupAlertedAt != nil {
+		return false
+	}
+	if !now.After(m.ScheduledPickup.Add(grace)) {
+		return false
+	}
+
+	alertedAt := now.UTC()
+	m.PickupAlertedAt = &alertedAt
+	m.UpdatedAt = alertedAt
+
+	m.addDomainEvent(&ManifestPickupMissedEvent{
+		ManifestID:      m.ManifestID,
+		CarrierID:       m.CarrierID,
+		FacilityID:      m.FacilityID,
+		TrailerID:       m.TrailerID,
+		PackageCount:    m.TotalPackages,
+		ScheduledPickup: *m.ScheduledPickup,
+		AlertedAt:       alertedAt,
+	})
+
+	return true
+}
+
+// NewRolloverManifest opens the next manifest for the carrier holding the packages that
+// missed the previous cutoff. Packages keep their original AddedAt time.
+func NewRolloverManifest(manifestID string, previous *OutboundManifest, late []ManifestPackage, cutoff, pickup time.Time) *OutboundManifest {
+	manifest := NewOutboundManifest(manifestID, previous.CarrierID, previous.CarrierName, previous.ServiceType)
+	manifest.TenantID = previous.TenantID
+	manifest.FacilityID = previous.FacilityID
+	manifest.WarehouseID = previous.WarehouseID
+
+	cutoffAt := cutoff.UTC()
+	pickupAt := pickup.UTC()
+	manifest.CutoffAt = &cutoffAt
+	manifest.ScheduledPickup = &pickupAt
+
+	for _, pkg := range late {
+		manifest.Packages = append(manifest.Packages, pkg)
+		manifest.TotalPackages++
+		manifest.TotalWeight += pkg.Weight
+	}
+	return manifest
+}
+
+// ManifestDocumentRenderer is the domain interface (port) for printing closed manifest paperwork
+type ManifestDocumentRenderer interface {
+	// RenderBillOfLading produces the bill of lading handed to the driver
+	RenderBillOfLading(manifest *OutboundManifest) ([]byte, error)
+	// RenderManifestSummary produces the package-level summary of the manifest
+	RenderManifestSummary(manifest *OutboundManifest) ([]byte, error)
+}
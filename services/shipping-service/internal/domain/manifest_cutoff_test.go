@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPickupSchedule() *CarrierPickupSchedule {
+	return &CarrierPickupSchedule{
+		TenantID:    "TENANT-1",
+		FacilityID:  "FAC-1",
+		CarrierCode: "UPS",
+		CarrierName: "UPS",
+		CutoffTime:  "17:00",
+		PickupTime:  "18:30",
+		TimeZone:    "America/Chicago",
+		Active:      true,
+	}
+}
+
+func TestCarrierPickupSchedule_Validate(t *testing.T) {
+	assert.NoError(t, testPickupSchedule().Validate())
+
+	tests := []struct {
+		name   string
+		mutate func(s *CarrierPickupSchedule)
+	}{
+		{"missing carrier", func(s *CarrierPickupSchedule) { s.CarrierCode = "" }},
+		{"bad cutoff", func(s *CarrierPickupSchedule) { s.CutoffTime = "5pm" }},
+		{"pickup before cutoff", func(s *CarrierPickupSchedule) { s.PickupTime = "16:00" }},
+		{"unknown time zone", func(s *CarrierPickupSchedule) { s.TimeZone = "Mars/Olympus" }},
+		{"invalid day", func(s *CarrierPickupSchedule) { s.PickupDays = []time.Weekday{9} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := testPickupSchedule()
+			tt.mutate(schedule)
+			assert.ErrorIs(t, schedule.Validate(), ErrInvalidPickupSchedule)
+		})
+	}
+}
+
+func TestCarrierPickupSchedule_Cutoffs(t *testing.T) {
+	schedule := testPickupSchedule()
+	chicago, err := time.LoadLocation("America/Chicago")
+	require.NoError(t, err)
+
+	// Monday 2026-10-12 16:00 CDT: the last cutoff was Friday, the next is today
+	monday := time.Date(2026, 10, 12, 16, 0, 0, 0, chicago)
+
+	cutoff, pickup, err := schedule.LastCutoff(monday)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 9, 17, 0, 0, 0, chicago), cutoff)
+	assert.Equal(t, time.Date(2026, 10, 9, 18, 30, 0, 0, chicago), pickup)
+
+	cutoff, pickup, err = schedule.NextCutoff(monday)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 12, 17, 0, 0, 0, chicago), cutoff)
+	assert.Equal(t, time.Date(2026, 10, 12, 18, 30, 0, 0, chicago), pickup)
+
+	// Saturday pickups only when configured
+	schedule.PickupDays = []time.Weekday{time.Saturday}
+	cutoff, _, err = schedule.NextCutoff(monday)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 17, 17, 0, 0, 0, chicago), cutoff)
+}
+
+func TestOutboundManifest_CloseAtCutoff(t *testing.T) {
+	cutoff := time.Date(2026, 10, 12, 22, 0, 0, 0, time.UTC)
+	pickup := cutoff.Add(90 * time.Minute)
+
+	manifest := NewOutboundManifest("MAN-UPS-ab12cd34", "UPS", "UPS", "Ground")
+	manifest.Packages = []ManifestPackage{
+		{PackageID: "PKG-1", ShipmentID: "SHP-1", Weight: 2, AddedAt: cutoff.Add(-time.Hour)},
+		{PackageID: "PKG-2", ShipmentID: "SHP-2", Weight: 3, AddedAt: cutoff.Add(5 * time.Minute)},
+		{PackageID: "PKG-3", ShipmentID: "SHP-3", Weight: 1.5, AddedAt: cutoff},
+	}
+	manifest.TotalPackages = 3
+	manifest.TotalWeight = 6.5
+
+	late, err := manifest.CloseAtCutoff(cutoff, pickup)
+	require.NoError(t, err)
+
+	require.Len(t, late, 1)
+	assert.Equal(t, "PKG-2", late[0].PackageID)
+	assert.Equal(t, ManifestStatusClosed, manifest.Status)
+	assert.True(t, manifest.AutoClosed)
+	assert.Equal(t, 2, manifest.TotalPackages)
+	assert.InDelta(t, 3.5, manifest.TotalWeight, 0.001)
+	assert.Equal(t, pickup, *manifest.ScheduledPickup)
+
+	events := manifest.GetManifestDomainEvents()
+	require.Len(t, events, 1)
+	closed, ok := events[0].(*ManifestClosedEvent)
+	require.True(t, ok)
+	assert.Equal(t, 2, closed.PackageCount)
+
+	next := NewRolloverManifest("MAN-UPS-ef56ab78", manifest, late, cutoff.Add(24*time.Hour), pickup.Add(24*time.Hour))
+	assert.Equal(t, ManifestStatusOpen, next.Status)
+	assert.Equal(t, 1, next.TotalPackages)
+	assert.InDelta(t, 3, next.TotalWeight, 0.001)
+	assert.Equal(t, late[0].AddedAt, next.Packages[0].AddedAt)
+}
+
+func TestOutboundManifest_CloseAtCutoffWithOnlyLatePackages(t *testing.T) {
+	cutoff := time.Date(2026, 10, 12, 22, 0, 0, 0, time.UTC)
+
+	manifest := NewOutboundManifest("MAN-UPS-ab12cd34", "UPS", "UPS", "Ground")
+	_ = manifest.AddPackage(ManifestPackage{PackageID: "PKG-1", Weight: 2})
+	manifest.Packages[0].AddedAt = cutoff.Add(time.Minute)
+
+	_, err := manifest.CloseAtCutoff(cutoff, cutoff.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrNoPackagesBeforeCutoff)
+	assert.Equal(t, ManifestStatusOpen, manifest.Status)
+	assert.Len(t, manifest.Packages, 1)
+}
+
+func TestOutboundManifest_BillOfLading(t *testing.T) {
+	cutoff := time.Date(2026, 10, 12, 22, 0, 0, 0, time.UTC)
+	manifest := NewOutboundManifest("MAN-UPS-ab12cd34", "UPS", "UPS", "Ground")
+	_ = manifest.AddPackage(ManifestPackage{PackageID: "PKG-1", Weight: 2})
+	manifest.Packages[0].AddedAt = cutoff.Add(-time.Hour)
+
+	bol := NewBillOfLading(manifest, testPickupSchedule(), "UPS-MAN-1", cutoff)
+	assert.ErrorIs(t, manifest.AttachBillOfLading(bol), ErrManifestNotClosed)
+
+	_, err := manifest.CloseAtCutoff(cutoff, cutoff.Add(time.Hour))
+	require.NoError(t, err)
+
+	bol = NewBillOfLading(manifest, testPickupSchedule(), "UPS-MAN-1", cutoff)
+	require.NoError(t, manifest.AttachBillOfLading(bol))
+	assert.Equal(t, "BOL-UPS-20261012-AB12CD34", manifest.BillOfLading.BOLNumber)
+	assert.Equal(t, "UPS-MAN-1", manifest.CarrierManifestID)
+	assert.Equal(t, 1, manifest.BillOfLading.PackageCount)
+	assert.Equal(t, cutoff.Add(time.Hour), manifest.BillOfLading.PickupAt)
+}
+
+func TestOutboundManifest_FlagMissedPickup(t *testing.T) {
+	cutoff := time.Date(2026, 10, 12, 22, 0, 0, 0, time.UTC)
+	pickup := cutoff.Add(90 * time.Minute)
+
+	manifest := NewOutboundManifest("MAN-UPS-ab12cd34", "UPS", "UPS", "Ground")
+	_ = manifest.AddPackage(ManifestPackage{PackageID: "PKG-1", Weight: 2})
+	manifest.Packages[0].AddedAt = cutoff.Add(-time.Hour)
+	_, err := manifest.CloseAtCutoff(cutoff, pickup)
+	require.NoError(t, err)
+	manifest.ClearManifestDomainEvents()
+
+	grace := 30 * time.Minute
+	assert.False(t, manifest.FlagMissedPickup(pickup.Add(20*time.Minute), grace))
+	assert.True(t, manifest.FlagMissedPickup(pickup.Add(31*time.Minute), grace))
+	// Alerts once per manifest
+	assert.False(t, manifest.FlagMissedPickup(pickup.Add(2*time.Hour), grace))
+
+	events := manifest.GetManifestDomainEvents()
+	require.Len(t, events, 1)
+	missed, ok := events[0].(*ManifestPickupMissedEvent)
+	require.True(t, ok)
+	assert.Equal(t, pickup, missed.ScheduledPickup)
+	assert.Equal(t, "wms.shipping.manifest-pickup-missed", missed.EventType())
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Printing errors
+var (
+	ErrPrinterNotFound   = errors.New("no printer configured for the requested printer or station")
+	ErrLabelNotPrintable = errors.New("raw label printers only accept ZPL labels, create the label with labelFormat ZPL")
+)
+
+// PrintJobStatus represents the status of a label print job
+type PrintJobStatus string
+
+const (
+	PrintJobStatusPrinted PrintJobStatus = "printed"
+	PrintJobStatusSpooled PrintJobStatus = "spooled"
+	PrintJobStatusFailed  PrintJobStatus = "failed" // Will never print, e.g. a non-ZPL label
+)
+
+// PrintRequest asks for a rendered label to be printed at a printer or station
+type PrintRequest struct {
+	PrinterID  string // takes precedence over StationID
+	StationID  string
+	ShipmentID string
+	Format     string
+	Data       []byte
+	Copies     int
+}
+
+// PrintJob tracks a label sent to a station printer.
+// Jobs for offline printers are spooled and retried until they print.
+type PrintJob struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	JobID      string             `bson:"jobId"`
+	TenantID   string             `bson:"tenantId"`
+	PrinterID  string             `bson:"printerId"`
+	StationID  string             `bson:"stationId,omitempty"`
+	ShipmentID string             `bson:"shipmentId,omitempty"`
+	Format     string             `bson:"format"`
+	Data       []byte             `bson:"data"`
+	Copies     int                `bson:"copies"`
+	Status     PrintJobStatus     `bson:"status"`
+	Attempts   int                `bson:"attempts"`
+	LastError  string             `bson:"lastError,omitempty"`
+	CreatedAt  time.Time          `bson:"createdAt"`
+	UpdatedAt  time.Time          `bson:"updatedAt"`
+	PrintedAt  *time.Time         `bson:"printedAt,omitempty"`
+
+	// Set while a router instance is sending a spooled job, so other replicas skip it
+	ClaimedBy      string     `bson:"claimedBy"`
+	ClaimExpiresAt *time.Time `bson:"claimExpiresAt"`
+}
+
+// NewPrintJob creates a print job for a resolved printer
+func NewPrintJob(jobID, printerID string, request PrintRequest) *PrintJob {
+	copies := request.Copies
+	if copies < 1 {
+		copies = 1
+	}
+
+	now := time.Now().UTC()
+	return &PrintJob{
+		JobID:      jobID,
+		PrinterID:  printerID,
+		StationID:  request.StationID,
+		ShipmentID: request.ShipmentID,
+		Format:     NormalizeLabelFormat(request.Format),
+		Data:       request.Data,
+		Copies:     copies,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// MarkPrinted records a successful delivery to the printer
+func (j *PrintJob) MarkPrinted() {
+	now := time.Now().UTC()
+	j.Attempts++
+	j.Status = PrintJobStatusPrinted
+	j.LastError = ""
+	j.PrintedAt = &now
+	j.UpdatedAt = now
+	j.releaseClaim()
+}
+
+// MarkSpooled records a failed delivery; the job stays queued for retry
+func (j *PrintJob) MarkSpooled(err error) {
+	j.Attempts++
+	j.Status = PrintJobStatusSpooled
+	if err != nil {
+		j.LastError = err.Error()
+	}
+	j.UpdatedAt = time.Now().UTC()
+	j.releaseClaim()
+}
+
+// MarkFailed records that the job can never print; it is dropped from the spool
+func (j *PrintJob) MarkFailed(err error) {
+	j.Status = PrintJobStatusFailed
+	if err != nil {
+		j.LastError = err.Error()
+	}
+	j.UpdatedAt = time.Now().UTC()
+	j.releaseClaim()
+}
+
+func (j *PrintJob) releaseClaim() {
+	j.ClaimedBy = ""
+	j.ClaimExpiresAt = nil
+}
+
+// LabelPrinter is the domain interface (port) for sending labels to station printers
+type LabelPrinter interface {
+	// Print sends the label to the resolved printer, spooling it if the printer is offline
+	Print(ctx context.Context, request PrintRequest) (*PrintJob, error)
+}
+
+// PrintJobRepository defines the interface for the print spool
+type PrintJobRepository interface {
+	Save(ctx context.Context, job *PrintJob) error
+	FindSpooled(ctx context.Context, limit int) ([]*PrintJob, error)
+	// Claim marks a spooled job as being sent by holder until ttl elapses.
+	// It returns false if the job is no longer spooled or another holder's claim is still live.
+	Claim(ctx context.Context, job *PrintJob, holder string, ttl time.Duration) (bool, error)
+}
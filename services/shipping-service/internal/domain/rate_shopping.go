@@ -0,0 +1,296 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Rate shopping errors
+var (
+	ErrNoRatesReturned        = errors.New("no carrier returned rates")
+	ErrNoEligibleRates        = errors.New("no carrier rate satisfies the shipment constraints")
+	ErrInvalidSelectionRule   = errors.New("invalid carrier selection rule")
+	ErrCarrierAlreadyAssigned = errors.New("carrier can only be selected for a pending shipment")
+)
+
+// SelectionStrategy determines how the winning rate is chosen among eligible quotes
+type SelectionStrategy string
+
+const (
+	SelectionStrategyCheapest SelectionStrategy = "cheapest"
+	SelectionStrategyFastest  SelectionStrategy = "fastest"
+)
+
+// IsValid checks if the strategy is supported
+func (s SelectionStrategy) IsValid() bool {
+	return s == SelectionStrategyCheapest || s == SelectionStrategyFastest
+}
+
+// Reasons a quote was not selected
+const (
+	RejectionMissesPromisedDelivery    = "misses_promised_delivery"
+	RejectionUnknownDelivery           = "unknown_delivery"
+	RejectionHazmatNotSupported        = "hazmat_not_supported"
+	RejectionInternationalNotSupported = "international_not_supported"
+	RejectionExceedsCarrierLimits      = "exceeds_carrier_limits"
+	RejectionCarrierNotAllowed         = "carrier_not_allowed"
+	RejectionExceedsMaxCost            = "exceeds_max_cost"
+	RejectionNotGuaranteed             = "not_guaranteed"
+	RejectionOutranked                 = "outranked"
+)
+
+// CarrierSelectionRule holds a seller's preferences for choosing a carrier
+type CarrierSelectionRule struct {
+	ID                primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID          string             `bson:"tenantId"`
+	SellerID          string             `bson:"sellerId"`
+	Strategy          SelectionStrategy  `bson:"strategy"`
+	AllowedCarriers   []string           `bson:"allowedCarriers,omitempty"` // empty allows every carrier
+	ExcludedCarriers  []string           `bson:"excludedCarriers,omitempty"`
+	MaxCost           float64            `bson:"maxCost,omitempty"` // 0 means no limit
+	RequireGuaranteed bool               `bson:"requireGuaranteed"`
+	CreatedAt         time.Time          `bson:"createdAt"`
+	UpdatedAt         time.Time          `bson:"updatedAt"`
+}
+
+// DefaultCarrierSelectionRule returns the rule used when a seller has not configured one
+func DefaultCarrierSelectionRule(sellerID string) CarrierSelectionRule {
+	return CarrierSelectionRule{
+		SellerID: sellerID,
+		Strategy: SelectionStrategyCheapest,
+	}
+}
+
+// Validate checks the rule is internally consistent
+func (r CarrierSelectionRule) Validate() error {
+	if r.SellerID == "" {
+		return fmt.Errorf("%w: sellerId is required", ErrInvalidSelectionRule)
+	}
+	if !r.Strategy.IsValid() {
+		return fmt.Errorf("%w: unknown strategy %q", ErrInvalidSelectionRule, r.Strategy)
+	}
+	if r.MaxCost < 0 {
+		return fmt.Errorf("%w: maxCost cannot be negative", ErrInvalidSelectionRule)
+	}
+	for _, code := range r.AllowedCarriers {
+		if containsCarrier(r.ExcludedCarriers, code) {
+			return fmt.Errorf("%w: carrier %s is both allowed and excluded", ErrInvalidSelectionRule, code)
+		}
+	}
+	return nil
+}
+
+// allowsCarrier reports whether the rule lets the given carrier be used
+func (r CarrierSelectionRule) allowsCarrier(code string) bool {
+	if containsCarrier(r.ExcludedCarriers, code) {
+		return false
+	}
+	return len(r.AllowedCarriers) == 0 || containsCarrier(r.AllowedCarriers, code)
+}
+
+// RateShoppingConstraints are the shipment-specific conditions a quote must meet
+type RateShoppingConstraints struct {
+	PromisedDeliveryAt *time.Time
+	Hazmat             bool
+	International      bool
+	Package            PackageInfo
+}
+
+// RateQuote is a single carrier service offer collected during rate shopping
+type RateQuote struct {
+	CarrierCode       string    `bson:"carrierCode"`
+	ServiceType       string    `bson:"serviceType"`
+	ServiceName       string    `bson:"serviceName"`
+	TotalCost         float64   `bson:"totalCost"`
+	Currency          string    `bson:"currency"`
+	EstimatedDelivery time.Time `bson:"estimatedDelivery"`
+	IsGuaranteed      bool      `bson:"isGuaranteed"`
+	// BillableWeight is the package's billable weight (kg) under this carrier's DIM rules
+	BillableWeight float64 `bson:"billableWeight"`
+	DimApplied     bool    `bson:"dimApplied"`
+}
+
+// NewRateQuote builds a quote from a carrier's rate response
+func NewRateQuote(carrierCode string, rate ShippingRate, pkg PackageInfo) RateQuote {
+	billable := CalculateBillableWeight(carrierCode, pkg)
+	return RateQuote{
+		CarrierCode:       carrierCode,
+		ServiceType:       rate.ServiceType,
+		ServiceName:       rate.ServiceName,
+		TotalCost:         rate.TotalCost,
+		Currency:          rate.Currency,
+		EstimatedDelivery: rate.EstimatedDelivery,
+		IsGuaranteed:      rate.IsGuaranteed,
+		BillableWeight:    billable.BillableWeight,
+		DimApplied:        billable.DimApplied,
+	}
+}
+
+// RejectedRate records a quote that lost and why
+type RejectedRate struct {
+	Quote  RateQuote `bson:"quote"`
+	Reason string    `bson:"reason"`
+}
+
+// CarrierQuoteFailure records a carrier that did not return rates in time
+type CarrierQuoteFailure struct {
+	CarrierCode string `bson:"carrierCode"`
+	Error       string `bson:"error"`
+}
+
+// RateSelection is the audit record of a rate shopping decision
+type RateSelection struct {
+	Strategy     SelectionStrategy     `bson:"strategy"`
+	Selected     RateQuote             `bson:"selected"`
+	Alternatives []RejectedRate        `bson:"alternatives"`
+	Failures     []CarrierQuoteFailure `bson:"failures,omitempty"`
+	// CostSavings is the difference between the most expensive eligible quote and the selected one
+	CostSavings float64   `bson:"costSavings"`
+	SelectedAt  time.Time `bson:"selectedAt"`
+}
+
+// SelectRate filters quotes by carrier capabilities, shipment constraints and the
+// seller rule, then picks the winner according to the rule's strategy.
+// Quotes from carriers missing in capabilities are treated as unconstrained.
+func SelectRate(
+	quotes []RateQuote,
+	capabilities map[string]CarrierCapabilities,
+	constraints RateShoppingConstraints,
+	rule CarrierSelectionRule,
+	failures []CarrierQuoteFailure,
+) (*RateSelection, error) {
+	if len(quotes) == 0 {
+		return nil, ErrNoRatesReturned
+	}
+
+	strategy := rule.Strategy
+	if !strategy.IsValid() {
+		strategy = SelectionStrategyCheapest
+	}
+
+	eligible := make([]RateQuote, 0, len(quotes))
+	rejected := make([]RejectedRate, 0, len(quotes))
+	for _, quote := range quotes {
+		if reason := rejectionReason(quote, capabilities, constraints, rule); reason != "" {
+			rejected = append(rejected, RejectedRate{Quote: quote, Reason: reason})
+			continue
+		}
+		eligible = append(eligible, quote)
+	}
+
+	if len(eligible) == 0 {
+		return nil, ErrNoEligibleRates
+	}
+
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return ranksBefore(eligible[i], eligible[j], strategy)
+	})
+
+	selected := eligible[0]
+	highest := selected.TotalCost
+	for _, quote := range eligible[1:] {
+		if quote.TotalCost > highest {
+			highest = quote.TotalCost
+		}
+		rejected = append(rejected, RejectedRate{Quote: quote, Reason: RejectionOutranked})
+	}
+
+	return &RateSelection{
+		Strategy:     strategy,
+		Selected:     selected,
+		Alternatives: rejected,
+		Failures:     failures,
+		CostSavings:  highest - selected.TotalCost,
+		SelectedAt:   time.Now().UTC(),
+	}, nil
+}
+
+// rejectionReason returns why a quote is ineligible, or an empty string if it is eligible
+func rejectionReason(quote RateQuote, capabilities map[string]CarrierCapabilities, constraints RateShoppingConstraints, rule CarrierSelectionRule) string {
+	if !rule.allowsCarrier(quote.CarrierCode) {
+		return RejectionCarrierNotAllowed
+	}
+	if caps, ok := capabilities[quote.CarrierCode]; ok {
+		if constraints.Hazmat && !caps.SupportsHazmat {
+			return RejectionHazmatNotSupported
+		}
+		if constraints.International && !caps.SupportsInternational {
+			return RejectionInternationalNotSupported
+		}
+		if !caps.accepts(constraints.Package) {
+			return RejectionExceedsCarrierLimits
+		}
+	}
+	if constraints.PromisedDeliveryAt != nil {
+		// A quote without an estimate cannot be shown to meet the promise
+		if quote.EstimatedDelivery.IsZero() {
+			return RejectionUnknownDelivery
+		}
+		if quote.EstimatedDelivery.After(*constraints.PromisedDeliveryAt) {
+			return RejectionMissesPromisedDelivery
+		}
+	}
+	if rule.RequireGuaranteed && !quote.IsGuaranteed {
+		return RejectionNotGuaranteed
+	}
+	if rule.MaxCost > 0 && quote.TotalCost > rule.MaxCost {
+		return RejectionExceedsMaxCost
+	}
+	return ""
+}
+
+// ranksBefore orders quotes by the strategy's primary key, breaking ties with the other key
+func ranksBefore(a, b RateQuote, strategy SelectionStrategy) bool {
+	if strategy == SelectionStrategyFastest {
+		if !a.EstimatedDelivery.Equal(b.EstimatedDelivery) {
+			return deliversBefore(a, b)
+		}
+		return a.TotalCost < b.TotalCost
+	}
+	if a.TotalCost != b.TotalCost {
+		return a.TotalCost < b.TotalCost
+	}
+	return deliversBefore(a, b)
+}
+
+// deliversBefore compares estimated delivery, with a quote that has no estimate
+// (a zero time) treated as unknown and ranked after any quote that has one
+func deliversBefore(a, b RateQuote) bool {
+	if a.EstimatedDelivery.IsZero() || b.EstimatedDelivery.IsZero() {
+		return !a.EstimatedDelivery.IsZero() && b.EstimatedDelivery.IsZero()
+	}
+	return a.EstimatedDelivery.Before(b.EstimatedDelivery)
+}
+
+// accepts reports whether a package fits within the carrier's limits
+func (c CarrierCapabilities) accepts(pkg PackageInfo) bool {
+	if c.MaxWeightKg > 0 && pkg.Weight > c.MaxWeightKg {
+		return false
+	}
+
+	sides := []float64{pkg.Dimensions.Length, pkg.Dimensions.Width, pkg.Dimensions.Height}
+	sort.Float64s(sides)
+	length := sides[2]
+	girth := 2 * (sides[0] + sides[1])
+
+	if c.MaxLengthCm > 0 && length > c.MaxLengthCm {
+		return false
+	}
+	if c.MaxLengthPlusGirthCm > 0 && length+girth > c.MaxLengthPlusGirthCm {
+		return false
+	}
+	return true
+}
+
+func containsCarrier(codes []string, code string) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
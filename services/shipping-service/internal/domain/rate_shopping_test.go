@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestQuotes(now time.Time) []RateQuote {
+	return []RateQuote{
+		{CarrierCode: "UPS", ServiceType: "03", ServiceName: "UPS Ground", TotalCost: 12.50, Currency: "USD", EstimatedDelivery: now.Add(5 * 24 * time.Hour)},
+		{CarrierCode: "UPS", ServiceType: "01", ServiceName: "UPS Next Day Air", TotalCost: 45.00, Currency: "USD", EstimatedDelivery: now.Add(24 * time.Hour), IsGuaranteed: true},
+		{CarrierCode: "FEDEX", ServiceType: "FEDEX_GROUND", ServiceName: "FedEx Ground", TotalCost: 11.75, Currency: "USD", EstimatedDelivery: now.Add(4 * 24 * time.Hour)},
+		{CarrierCode: "FEDEX", ServiceType: "FEDEX_2_DAY", ServiceName: "FedEx 2Day", TotalCost: 24.00, Currency: "USD", EstimatedDelivery: now.Add(2 * 24 * time.Hour), IsGuaranteed: true},
+	}
+}
+
+func createTestCapabilities() map[string]CarrierCapabilities {
+	return map[string]CarrierCapabilities{
+		"UPS":   {MaxWeightKg: 68, MaxLengthCm: 274, MaxLengthPlusGirthCm: 419, SupportsHazmat: true},
+		"FEDEX": {MaxWeightKg: 68, MaxLengthCm: 274, MaxLengthPlusGirthCm: 419},
+	}
+}
+
+// TestSelectRate tests rate selection across strategies and constraints
+func TestSelectRate(t *testing.T) {
+	now := time.Now()
+	quotes := createTestQuotes(now)
+	pkg := createTestPackageInfo()
+
+	t.Run("Cheapest selects lowest cost", func(t *testing.T) {
+		selection, err := SelectRate(quotes, createTestCapabilities(), RateShoppingConstraints{Package: pkg}, DefaultCarrierSelectionRule("SELLER-1"), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, SelectionStrategyCheapest, selection.Strategy)
+		assert.Equal(t, "FEDEX_GROUND", selection.Selected.ServiceType)
+		assert.Len(t, selection.Alternatives, 3)
+		assert.InDelta(t, 45.00-11.75, selection.CostSavings, 0.001)
+		for _, alt := range selection.Alternatives {
+			assert.Equal(t, RejectionOutranked, alt.Reason)
+		}
+	})
+
+	t.Run("Fastest selects earliest delivery", func(t *testing.T) {
+		rule := CarrierSelectionRule{SellerID: "SELLER-1", Strategy: SelectionStrategyFastest}
+		selection, err := SelectRate(quotes, createTestCapabilities(), RateShoppingConstraints{Package: pkg}, rule, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "01", selection.Selected.ServiceType)
+		assert.Zero(t, selection.CostSavings)
+	})
+
+	t.Run("Promised delivery filters late services", func(t *testing.T) {
+		promised := now.Add(3 * 24 * time.Hour)
+		selection, err := SelectRate(quotes, createTestCapabilities(), RateShoppingConstraints{Package: pkg, PromisedDeliveryAt: &promised}, DefaultCarrierSelectionRule("SELLER-1"), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "FEDEX_2_DAY", selection.Selected.ServiceType)
+		assert.Equal(t, 2, countRejections(selection, RejectionMissesPromisedDelivery))
+	})
+
+	t.Run("Unknown delivery ranks last for fastest", func(t *testing.T) {
+		unknown := quotes[0]
+		unknown.ServiceType = "NO_ESTIMATE"
+		unknown.TotalCost = 1
+		unknown.EstimatedDelivery = time.Time{}
+		rule := CarrierSelectionRule{SellerID: "SELLER-1", Strategy: SelectionStrategyFastest}
+
+		selection, err := SelectRate(append([]RateQuote{unknown}, quotes...), createTestCapabilities(), RateShoppingConstraints{Package: pkg}, rule, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "01", selection.Selected.ServiceType)
+	})
+
+	t.Run("Unknown delivery misses promised delivery", func(t *testing.T) {
+		unknown := quotes[0]
+		unknown.ServiceType = "NO_ESTIMATE"
+		unknown.TotalCost = 1
+		unknown.EstimatedDelivery = time.Time{}
+		promised := now.Add(3 * 24 * time.Hour)
+
+		selection, err := SelectRate(append([]RateQuote{unknown}, quotes...), createTestCapabilities(), RateShoppingConstraints{Package: pkg, PromisedDeliveryAt: &promised}, DefaultCarrierSelectionRule("SELLER-1"), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "FEDEX_2_DAY", selection.Selected.ServiceType)
+		assert.Equal(t, 1, countRejections(selection, RejectionUnknownDelivery))
+	})
+
+	t.Run("Hazmat excludes carriers without support", func(t *testing.T) {
+		selection, err := SelectRate(quotes, createTestCapabilities(), RateShoppingConstraints{Package: pkg, Hazmat: true}, DefaultCarrierSelectionRule("SELLER-1"), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "UPS", selection.Selected.CarrierCode)
+		assert.Equal(t, 2, countRejections(selection, RejectionHazmatNotSupported))
+	})
+
+	t.Run("International excludes domestic-only carriers", func(t *testing.T) {
+		capabilities := createTestCapabilities()
+		capabilities["UPS"] = CarrierCapabilities{SupportsHazmat: true, SupportsInternational: true}
+
+		selection, err := SelectRate(quotes, capabilities, RateShoppingConstraints{Package: pkg, International: true}, DefaultCarrierSelectionRule("SELLER-1"), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "UPS", selection.Selected.CarrierCode)
+		assert.Equal(t, 2, countRejections(selection, RejectionInternationalNotSupported))
+	})
+
+	t.Run("Oversized package exceeds carrier limits", func(t *testing.T) {
+		oversized := PackageInfo{Weight: 10, Dimensions: Dimensions{Length: 150, Width: 100, Height: 60}}
+		capabilities := createTestCapabilities()
+		capabilities["UPS"] = CarrierCapabilities{SupportsHazmat: true}
+
+		selection, err := SelectRate(quotes, capabilities, RateShoppingConstraints{Package: oversized}, DefaultCarrierSelectionRule("SELLER-1"), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "UPS", selection.Selected.CarrierCode)
+		assert.Equal(t, 2, countRejections(selection, RejectionExceedsCarrierLimits))
+	})
+
+	t.Run("Seller rule restricts carriers and guarantees", func(t *testing.T) {
+		rule := CarrierSelectionRule{SellerID: "SELLER-1", Strategy: SelectionStrategyCheapest, ExcludedCarriers: []string{"FEDEX"}, RequireGuaranteed: true}
+		selection, err := SelectRate(quotes, createTestCapabilities(), RateShoppingConstraints{Package: pkg}, rule, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "01", selection.Selected.ServiceType)
+		assert.Equal(t, 2, countRejections(selection, RejectionCarrierNotAllowed))
+		assert.Equal(t, 1, countRejections(selection, RejectionNotGuaranteed))
+	})
+
+	t.Run("No eligible rates", func(t *testing.T) {
+		rule := CarrierSelectionRule{SellerID: "SELLER-1", Strategy: SelectionStrategyCheapest, MaxCost: 5}
+		_, err := SelectRate(quotes, createTestCapabilities(), RateShoppingConstraints{Package: pkg}, rule, nil)
+
+		assert.ErrorIs(t, err, ErrNoEligibleRates)
+	})
+
+	t.Run("No rates returned", func(t *testing.T) {
+		_, err := SelectRate(nil, createTestCapabilities(), RateShoppingConstraints{Package: pkg}, DefaultCarrierSelectionRule("SELLER-1"), nil)
+
+		assert.ErrorIs(t, err, ErrNoRatesReturned)
+	})
+}
+
+// TestCarrierSelectionRuleValidate tests seller rule validation
+func TestCarrierSelectionRuleValidate(t *testing.T) {
+	assert.NoError(t, DefaultCarrierSelectionRule("SELLER-1").Validate())
+	assert.ErrorIs(t, CarrierSelectionRule{Strategy: SelectionStrategyCheapest}.Validate(), ErrInvalidSelectionRule)
+	assert.ErrorIs(t, CarrierSelectionRule{SellerID: "SELLER-1", Strategy: "slowest"}.Validate(), ErrInvalidSelectionRule)
+	assert.ErrorIs(t, CarrierSelectionRule{
+		SellerID:         "SELLER-1",
+		Strategy:         SelectionStrategyFastest,
+		AllowedCarriers:  []string{"UPS"},
+		ExcludedCarriers: []string{"UPS"},
+	}.Validate(), ErrInvalidSelectionRule)
+}
+
+// TestShipmentApplyRateSelection tests recording a rate shopping decision on a shipment
+func TestShipmentApplyRateSelection(t *testing.T) {
+	shipment := NewShipment("SHP-001", "ORD-001", "PKG-001", "WAVE-001", createTestCarrier(), createTestPackageInfo(), createTestAddress("Recipient"), createTestAddress("Shipper"))
+	shipment.ClearDomainEvents()
+
+	selection, err := SelectRate(createTestQuotes(time.Now()), createTestCapabilities(), RateShoppingConstraints{Package: shipment.Package}, DefaultCarrierSelectionRule(""), nil)
+	require.NoError(t, err)
+
+	require.NoError(t, shipment.ApplyRateSelection(*selection))
+	assert.Equal(t, "FEDEX", shipment.Carrier.Code)
+	assert.Equal(t, "FEDEX_GROUND", shipment.Carrier.ServiceType)
+	assert.Equal(t, "FEDEX_GROUND", shipment.ServiceType)
+	assert.Equal(t, "ACCT-12345", shipment.Carrier.AccountID)
+	require.NotNil(t, shipment.EstimatedDelivery)
+	require.NotNil(t, shipment.RateSelection)
+
+	events := shipment.GetDomainEvents()
+	require.Len(t, events, 1)
+	event, ok := events[0].(*CarrierSelectedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "wms.shipping.carrier-selected", event.EventType())
+	assert.Equal(t, 3, event.AlternativeCount)
+
+	require.NoError(t, shipment.GenerateLabel(createTestLabel()))
+	assert.ErrorIs(t, shipment.ApplyRateSelection(*selection), ErrCarrierAlreadyAssigned)
+}
+
+func countRejections(selection *RateSelection, reason string) int {
+	count := 0
+	for _, alt := range selection.Alternatives {
+		if alt.Reason == reason {
+			count++
+		}
+	}
+	return count
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // ShipmentRepository defines the interface for shipment persistence
 type ShipmentRepository interface {
@@ -12,9 +15,26 @@ type ShipmentRepository interface {
 	FindByCarrier(ctx context.Context, carrierCode string) ([]*Shipment, error)
 	FindByManifestID(ctx context.Context, manifestID string) ([]*Shipment, error)
 	FindPendingForManifest(ctx context.Context, carrierCode string) ([]*Shipment, error)
+	FindDueForTrackingPoll(ctx context.Context, now time.Time, limit int) ([]*Shipment, error)
 	Delete(ctx context.Context, shipmentID string) error
 }
 
+// CarrierSelectionRuleRepository defines the interface for seller carrier selection rule persistence
+type CarrierSelectionRuleRepository interface {
+	Save(ctx context.Context, rule *CarrierSelectionRule) error
+	FindBySellerID(ctx context.Context, sellerID string) (*CarrierSelectionRule, error)
+}
+
+// PickupScheduleRepository defines the interface for carrier pickup schedule persistence
+type PickupScheduleRepository interface {
+	Save(ctx context.Context, schedule *CarrierPickupSchedule) error
+	FindByCarrier(ctx context.Context, carrierCode string) (*CarrierPickupSchedule, error)
+	FindAll(ctx context.Context) ([]*CarrierPickupSchedule, error)
+	// FindActive returns active schedules; without a tenant in ctx it spans all tenants
+	FindActive(ctx context.Context) ([]*CarrierPickupSchedule, error)
+	Delete(ctx context.Context, carrierCode string) error
+}
+
 // EventPublisher defines the interface for publishing domain events
 type EventPublisher interface {
 	Publish(ctx context.Context, event DomainEvent) error
@@ -0,0 +1,333 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Tracking errors
+var (
+	ErrShipmentNotTrackable = errors.New("shipment is not trackable")
+)
+
+// TrackingMilestone is a carrier-neutral delivery milestone derived from carrier scans
+type TrackingMilestone string
+
+const (
+	TrackingMilestoneLabelCreated   TrackingMilestone = "label_created"
+	TrackingMilestoneInTransit      TrackingMilestone = "in_transit"
+	TrackingMilestoneOutForDelivery TrackingMilestone = "out_for_delivery"
+	TrackingMilestoneDelivered      TrackingMilestone = "delivered"
+	TrackingMilestoneException      TrackingMilestone = "exception"
+)
+
+// Sources of tracking updates
+const (
+	TrackingSourcePoll    = "poll"
+	TrackingSourceWebhook = "webhook"
+)
+
+// Carrier status keywords, checked in order. "Delivery attempted" is an exception
+// and "Out for delivery" is not a delivery, so both must be matched before "deliver".
+var (
+	outForDeliveryKeywords = []string{"out for delivery", "out_for_delivery", "with delivery courier"}
+	exceptionKeywords      = []string{"exception", "alert", "attempt", "undeliverable", "return to sender", "returned to sender", "damaged", "refused", "delay", "held", "failed"}
+	deliveredKeywords      = []string{"delivered"}
+	labelCreatedKeywords   = []string{"pre-shipment", "pre-transit", "label created", "shipping label", "information received", "shipment information", "awaiting item"}
+)
+
+// NormalizeTrackingStatus maps a carrier's status text to a milestone.
+// Unrecognised, non-empty statuses are treated as in transit.
+func NormalizeTrackingStatus(status, detail string) TrackingMilestone {
+	text := strings.ToLower(strings.TrimSpace(status + " " + detail))
+	if text == "" {
+		return ""
+	}
+
+	switch {
+	case containsAny(text, outForDeliveryKeywords):
+		return TrackingMilestoneOutForDelivery
+	case containsAny(text, exceptionKeywords):
+		return TrackingMilestoneException
+	case containsAny(text, deliveredKeywords):
+		return TrackingMilestoneDelivered
+	case containsAny(text, labelCreatedKeywords):
+		return TrackingMilestoneLabelCreated
+	default:
+		return TrackingMilestoneInTransit
+	}
+}
+
+func containsAny(text string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShipmentTracking is the carrier tracking state of a shipment
+type ShipmentTracking struct {
+	Milestone         TrackingMilestone `bson:"milestone"`
+	CarrierStatus     string            `bson:"carrierStatus"`
+	StatusDetail      string            `bson:"statusDetail,omitempty"`
+	CurrentLocation   string            `bson:"currentLocation,omitempty"`
+	Events            []TrackingEvent   `bson:"events,omitempty"`
+	LastEventAt       *time.Time        `bson:"lastEventAt,omitempty"`
+	LastChangedAt     *time.Time        `bson:"lastChangedAt,omitempty"`
+	LastPolledAt      *time.Time        `bson:"lastPolledAt,omitempty"`
+	NextPollAt        *time.Time        `bson:"nextPollAt,omitempty"`
+	PollingStopped    bool              `bson:"pollingStopped"`
+	UnchangedPolls    int               `bson:"unchangedPolls"`
+	ConsecutiveErrors int               `bson:"consecutiveErrors"`
+	LastError         string            `bson:"lastError,omitempty"`
+}
+
+// TrackingPollPolicy bounds how often a shipment is polled at the carrier.
+// Each milestone has a base interval that doubles with every poll that returns
+// nothing new, capped at MaxInterval. Polling stops once the shipment is
+// delivered or has been tracked for longer than MaxTrackingAge.
+type TrackingPollPolicy struct {
+	PreTransitInterval     time.Duration
+	InTransitInterval      time.Duration
+	OutForDeliveryInterval time.Duration
+	ExceptionInterval      time.Duration
+	MaxInterval            time.Duration
+	MaxBackoffSteps        int
+	MaxTrackingAge         time.Duration
+}
+
+// DefaultTrackingPollPolicy returns the default polling policy
+func DefaultTrackingPollPolicy() TrackingPollPolicy {
+	return TrackingPollPolicy{
+		PreTransitInterval:     4 * time.Hour,
+		InTransitInterval:      6 * time.Hour,
+		OutForDeliveryInterval: time.Hour,
+		ExceptionInterval:      2 * time.Hour,
+		MaxInterval:            24 * time.Hour,
+		MaxBackoffSteps:        3,
+		MaxTrackingAge:         30 * 24 * time.Hour,
+	}
+}
+
+// baseInterval returns the polling interval for a milestone before backoff
+func (p TrackingPollPolicy) baseInterval(milestone TrackingMilestone) time.Duration {
+	switch milestone {
+	case TrackingMilestoneInTransit:
+		return p.InTransitInterval
+	case TrackingMilestoneOutForDelivery:
+		return p.OutForDeliveryInterval
+	case TrackingMilestoneException:
+		return p.ExceptionInterval
+	default:
+		return p.PreTransitInterval
+	}
+}
+
+// NextPollAt returns when the shipment should be polled next, or nil to stop polling
+func (p TrackingPollPolicy) NextPollAt(s *Shipment, now time.Time) *time.Time {
+	if s.Status == ShipmentStatusDelivered || s.Status == ShipmentStatusCancelled {
+		return nil
+	}
+
+	trackingStart := s.CreatedAt
+	if s.ShippedAt != nil {
+		trackingStart = *s.ShippedAt
+	} else if s.ManifestedAt != nil {
+		trackingStart = *s.ManifestedAt
+	}
+	if p.MaxTrackingAge > 0 && now.Sub(trackingStart) > p.MaxTrackingAge {
+		return nil
+	}
+
+	var milestone TrackingMilestone
+	steps := 0
+	if s.Tracking != nil {
+		milestone = s.Tracking.Milestone
+		steps = s.Tracking.UnchangedPolls + s.Tracking.ConsecutiveErrors
+	}
+	if steps > p.MaxBackoffSteps {
+		steps = p.MaxBackoffSteps
+	}
+
+	interval := p.baseInterval(milestone) << steps
+	if p.MaxInterval > 0 && interval > p.MaxInterval {
+		interval = p.MaxInterval
+	}
+
+	next := now.Add(interval)
+	// A parcel due today is worth checking sooner than the backoff would allow
+	if s.EstimatedDelivery != nil && s.EstimatedDelivery.After(now) && s.EstimatedDelivery.Before(next) {
+		next = *s.EstimatedDelivery
+	}
+	return &next
+}
+
+// IsTrackable returns true if the carrier can report on this shipment
+func (s *Shipment) IsTrackable() bool {
+	if s.Label == nil || s.Label.TrackingNumber == "" {
+		return false
+	}
+	switch s.Status {
+	case ShipmentStatusLabeled, ShipmentStatusManifested, ShipmentStatusShipped,
+		ShipmentStatusInTransit, ShipmentStatusException:
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyTracking records a carrier tracking update and advances the shipment status.
+// Updates that carry nothing newer than what is already recorded are ignored, so
+// the same scan delivered by both a webhook and a poll is only applied once.
+// It returns true if the update changed the shipment.
+func (s *Shipment) ApplyTracking(info TrackingInfo, source string) (bool, error) {
+	if s.Status == ShipmentStatusDelivered {
+		return false, nil
+	}
+	if !s.IsTrackable() {
+		return false, ErrShipmentNotTrackable
+	}
+
+	milestone := NormalizeTrackingStatus(info.Status, info.StatusDetail)
+	if milestone == "" {
+		return false, nil
+	}
+	latestEventAt := latestTrackingEvent(info.Events)
+
+	if s.Tracking == nil {
+		s.Tracking = &ShipmentTracking{}
+	}
+	tracking := s.Tracking
+	hasNewEvent := latestEventAt != nil && (tracking.LastEventAt == nil || latestEventAt.After(*tracking.LastEventAt))
+	if tracking.LastEventAt != nil && latestEventAt != nil && !hasNewEvent {
+		// A late webhook or a lagging carrier replica must not roll the milestone back
+		return false, nil
+	}
+	if milestone == tracking.Milestone && !hasNewEvent {
+		return false, nil
+	}
+
+	now := time.Now()
+	tracking.Milestone = milestone
+	tracking.CarrierStatus = info.Status
+	tracking.StatusDetail = info.StatusDetail
+	tracking.CurrentLocation = info.CurrentLocation
+	if len(info.Events) > 0 {
+		tracking.Events = info.Events
+	}
+	if latestEventAt != nil {
+		tracking.LastEventAt = latestEventAt
+	}
+	tracking.LastChangedAt = &now
+	tracking.UnchangedPolls = 0
+
+	if info.EstimatedDelivery != nil {
+		s.EstimatedDelivery = info.EstimatedDelivery
+	}
+
+	switch milestone {
+	case TrackingMilestoneInTransit, TrackingMilestoneOutForDelivery:
+		s.markInCarrierCustody(now)
+		s.Status = ShipmentStatusInTransit
+	case TrackingMilestoneException:
+		s.markInCarrierCustody(now)
+		s.Status = ShipmentStatusException
+	case TrackingMilestoneDelivered:
+		s.markInCarrierCustody(now)
+		deliveredAt := now
+		if info.ActualDelivery != nil {
+			deliveredAt = *info.ActualDelivery
+		} else if latestEventAt != nil {
+			deliveredAt = *latestEventAt
+		}
+		s.Status = ShipmentStatusDelivered
+		s.ActualDelivery = &deliveredAt
+		tracking.NextPollAt = nil
+		tracking.PollingStopped = true
+	}
+	s.UpdatedAt = now
+
+	eventAt := now
+	if latestEventAt != nil {
+		eventAt = *latestEventAt
+	}
+	s.AddDomainEvent(&TrackingUpdatedEvent{
+		ShipmentID:        s.ShipmentID,
+		OrderID:           s.OrderID,
+		TenantID:          s.TenantID,
+		SellerID:          s.SellerID,
+		TrackingNumber:    s.Label.TrackingNumber,
+		Carrier:           s.Carrier.Code,
+		Milestone:         string(milestone),
+		ShipmentStatus:    string(s.Status),
+		CarrierStatus:     info.Status,
+		StatusDetail:      info.StatusDetail,
+		Location:          info.CurrentLocation,
+		EstimatedDelivery: s.EstimatedDelivery,
+		DeliveredAt:       s.ActualDelivery,
+		Source:            source,
+		EventAt:           eventAt,
+		UpdatedAt:         now,
+	})
+
+	return true, nil
+}
+
+// RecordTrackingPoll records the outcome of a carrier poll and schedules the next one
+func (s *Shipment) RecordTrackingPoll(policy TrackingPollPolicy, changed bool, pollErr error, now time.Time) {
+	if s.Tracking == nil {
+		s.Tracking = &ShipmentTracking{}
+	}
+	tracking := s.Tracking
+	tracking.LastPolledAt = &now
+
+	switch {
+	case pollErr != nil:
+		tracking.ConsecutiveErrors++
+		tracking.LastError = pollErr.Error()
+	case changed:
+		tracking.ConsecutiveErrors = 0
+		tracking.LastError = ""
+	default:
+		tracking.ConsecutiveErrors = 0
+		tracking.LastError = ""
+		tracking.UnchangedPolls++
+	}
+
+	s.ScheduleTrackingPoll(policy, now)
+}
+
+// ScheduleTrackingPoll sets when the carrier should next be polled for this shipment.
+// Webhook updates call it too, so carriers that push are polled less often.
+func (s *Shipment) ScheduleTrackingPoll(policy TrackingPollPolicy, now time.Time) {
+	if s.Tracking == nil {
+		s.Tracking = &ShipmentTracking{}
+	}
+	s.Tracking.NextPollAt = policy.NextPollAt(s, now)
+	s.Tracking.PollingStopped = s.Tracking.NextPollAt == nil
+}
+
+// markInCarrierCustody records the ship time for parcels the carrier scanned before ship confirmation
+func (s *Shipment) markInCarrierCustody(now time.Time) {
+	if s.ShippedAt == nil {
+		s.ShippedAt = &now
+	}
+}
+
+// latestTrackingEvent returns the time of the most recent event, whatever order the carrier listed them in
+func latestTrackingEvent(events []TrackingEvent) *time.Time {
+	var latest *time.Time
+	for i := range events {
+		if events[i].Timestamp.IsZero() {
+			continue
+		}
+		if latest == nil || events[i].Timestamp.After(*latest) {
+			ts := events[i].Timestamp
+			latest = &ts
+		}
+	}
+	return latest
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTrackableShipment() *Shipment {
+	shipment := NewShipment("SHP-001", "ORD-001", "PKG-001", "", Carrier{Code: "USPS"}, PackageInfo{}, Address{}, Address{})
+	shipment.SellerID = "SELLER-1"
+	_ = shipment.GenerateLabel(ShippingLabel{TrackingNumber: "9400111899223197428490"})
+	_ = shipment.ConfirmShipment(nil)
+	shipment.ClearDomainEvents()
+	return shipment
+}
+
+func TestNormalizeTrackingStatus(t *testing.T) {
+	tests := []struct {
+		status   string
+		detail   string
+		expected TrackingMilestone
+	}{
+		{"Delivered", "Delivered, In/At Mailbox", TrackingMilestoneDelivered},
+		{"Out for Delivery", "", TrackingMilestoneOutForDelivery},
+		{"In Transit", "Arrived at USPS Regional Facility", TrackingMilestoneInTransit},
+		{"Alert", "Delivery Attempted - No Access to Delivery Location", TrackingMilestoneException},
+		{"Exception", "Closed on arrival", TrackingMilestoneException},
+		{"Pre-Shipment", "Shipping Label Created, USPS Awaiting Item", TrackingMilestoneLabelCreated},
+		{"Departed", "Departed from facility", TrackingMilestoneInTransit},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status+" "+tt.detail, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeTrackingStatus(tt.status, tt.detail))
+		})
+	}
+}
+
+func TestShipment_ApplyTracking(t *testing.T) {
+	scan := func(status string, at time.Time) TrackingInfo {
+		return TrackingInfo{
+			TrackingNumber:  "9400111899223197428490",
+			Status:          status,
+			CurrentLocation: "AUSTIN, TX",
+			Events:          []TrackingEvent{{Timestamp: at, Status: status}},
+		}
+	}
+	base := time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC)
+
+	t.Run("advances to in transit and publishes event", func(t *testing.T) {
+		shipment := createTrackableShipment()
+
+		changed, err := shipment.ApplyTracking(scan("In Transit", base), TrackingSourcePoll)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, ShipmentStatusInTransit, shipment.Status)
+		assert.Equal(t, TrackingMilestoneInTransit, shipment.Tracking.Milestone)
+
+		require.Len(t, shipment.GetDomainEvents(), 1)
+		event := shipment.GetDomainEvents()[0].(*TrackingUpdatedEvent)
+		assert.Equal(t, "wms.shipping.tracking-updated", event.EventType())
+		assert.Equal(t, "in_transit", event.Milestone)
+		assert.Equal(t, "SELLER-1", event.SellerID)
+		assert.Equal(t, base, event.EventAt)
+	})
+
+	t.Run("same scan twice is applied once", func(t *testing.T) {
+		shipment := createTrackableShipment()
+		_, _ = shipment.ApplyTracking(scan("In Transit", base), TrackingSourceWebhook)
+
+		changed, err := shipment.ApplyTracking(scan("In Transit", base), TrackingSourcePoll)
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Len(t, shipment.GetDomainEvents(), 1)
+	})
+
+	t.Run("late update does not roll back the milestone", func(t *testing.T) {
+		shipment := createTrackableShipment()
+		_, _ = shipment.ApplyTracking(scan("Exception", base.Add(time.Hour)), TrackingSourceWebhook)
+
+		changed, err := shipment.ApplyTracking(scan("In Transit", base), TrackingSourcePoll)
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, ShipmentStatusException, shipment.Status)
+	})
+
+	t.Run("delivery is terminal", func(t *testing.T) {
+		shipment := createTrackableShipment()
+		deliveredAt := base.Add(48 * time.Hour)
+
+		changed, err := shipment.ApplyTracking(scan("Delivered", deliveredAt), TrackingSourcePoll)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, ShipmentStatusDelivered, shipment.Status)
+		require.NotNil(t, shipment.ActualDelivery)
+		assert.Equal(t, deliveredAt, *shipment.ActualDelivery)
+		assert.True(t, shipment.Tracking.PollingStopped)
+
+		changed, err = shipment.ApplyTracking(scan("Exception", deliveredAt.Add(time.Hour)), TrackingSourcePoll)
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, ShipmentStatusDelivered, shipment.Status)
+	})
+
+	t.Run("carrier scan before ship confirmation", func(t *testing.T) {
+		shipment := NewShipment("SHP-002", "ORD-002", "PKG-002", "", Carrier{Code: "USPS"}, PackageInfo{}, Address{}, Address{})
+		_ = shipment.GenerateLabel(ShippingLabel{TrackingNumber: "9400111899223197428506"})
+
+		changed, err := shipment.ApplyTracking(scan("In Transit", base), TrackingSourceWebhook)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.NotNil(t, shipment.ShippedAt)
+	})
+
+	t.Run("pending shipment is not trackable", func(t *testing.T) {
+		shipment := NewShipment("SHP-003", "ORD-003", "PKG-003", "", Carrier{Code: "USPS"}, PackageInfo{}, Address{}, Address{})
+
+		_, err := shipment.ApplyTracking(scan("In Transit", base), TrackingSourcePoll)
+		assert.ErrorIs(t, err, ErrShipmentNotTrackable)
+	})
+}
+
+func TestTrackingPollPolicy_NextPollAt(t *testing.T) {
+	policy := DefaultTrackingPollPolicy()
+	now := time.Now()
+
+	t.Run("interval follows the milestone", func(t *testing.T) {
+		shipment := createTrackableShipment()
+		assert.Equal(t, now.Add(policy.PreTransitInterval), *policy.NextPollAt(shipment, now))
+
+		shipment.Tracking = &ShipmentTracking{Milestone: TrackingMilestoneOutForDelivery}
+		assert.Equal(t, now.Add(policy.OutForDeliveryInterval), *policy.NextPollAt(shipment, now))
+	})
+
+	t.Run("unchanged polls back off up to the cap", func(t *testing.T) {
+		shipment := createTrackableShipment()
+		shipment.Tracking = &ShipmentTracking{Milestone: TrackingMilestoneInTransit, UnchangedPolls: 1}
+		assert.Equal(t, now.Add(2*policy.InTransitInterval), *policy.NextPollAt(shipment, now))
+
+		shipment.Tracking.UnchangedPolls = 10
+		assert.Equal(t, now.Add(policy.MaxInterval), *policy.NextPollAt(shipment, now))
+	})
+
+	t.Run("expected delivery pulls the next poll forward", func(t *testing.T) {
+		shipment := createTrackableShipment()
+		shipment.Tracking = &ShipmentTracking{Milestone: TrackingMilestoneInTransit, UnchangedPolls: 3}
+		eta := now.Add(90 * time.Minute)
+		shipment.EstimatedDelivery = &eta
+		assert.Equal(t, eta, *policy.NextPollAt(shipment, now))
+	})
+
+	t.Run("polling stops for old shipments", func(t *testing.T) {
+		shipment := createTrackableShipment()
+		shippedAt := now.Add(-policy.MaxTrackingAge - time.Hour)
+		shipment.ShippedAt = &shippedAt
+
+		shipment.RecordTrackingPoll(policy, false, nil, now)
+		assert.Nil(t, shipment.Tracking.NextPollAt)
+		assert.True(t, shipment.Tracking.PollingStopped)
+	})
+}
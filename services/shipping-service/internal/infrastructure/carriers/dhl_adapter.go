@@ -0,0 +1,787 @@
+package carriers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// dhlTimeLayout is the MyDHL API planned shipping date format
+const dhlTimeLayout = "2006-01-02T15:04:05 GMT-07:00"
+
+// dhlExceptionCodes are checkpoint codes that stop a shipment short of delivery
+var dhlExceptionCodes = map[string]bool{
+	"BA": true, // bad address
+	"CA": true, // closed on arrival
+	"NH": true, // not home
+	"RD": true, // refused delivery
+	"MS": true, // mis-sorted
+	"HP": true, // held for payment
+}
+
+// DHLAdapter is the Anti-Corruption Layer adapter for DHL Express integration
+// It translates between domain models and the MyDHL API, which works in kilograms and centimetres
+type DHLAdapter struct {
+	username      string
+	password      string
+	accountNumber string
+	client        *carrierHTTPClient
+}
+
+// NewDHLAdapter creates a new DHL Express carrier adapter
+func NewDHLAdapter(username, password, accountNumber, apiURL string) *DHLAdapter {
+	a := &DHLAdapter{
+		username:      username,
+		password:      password,
+		accountNumber: accountNumber,
+	}
+	a.client = newCarrierHTTPClient("DHL", apiURL, decodeDHLError)
+	a.client.authorize = func(_ context.Context, req *http.Request) error {
+		req.SetBasicAuth(a.username, a.password)
+		return nil
+	}
+	return a
+}
+
+// GetCarrierCode returns the carrier code this adapter handles
+func (a *DHLAdapter) GetCarrierCode() string {
+	return "DHL"
+}
+
+// GetCapabilities returns DHL Express piece limits (70 kg, 120 cm length)
+func (a *DHLAdapter) GetCapabilities() domain.CarrierCapabilities {
+	return domain.CarrierCapabilities{
+		MaxWeightKg:           70,
+		MaxLengthCm:           120,
+		SupportsHazmat:        true,
+		SupportsInternational: true,
+	}
+}
+
+// GenerateLabel creates a DHL Express shipment and returns its waybill label
+func (a *DHLAdapter) GenerateLabel(ctx context.Context, request domain.LabelRequest) (*domain.ShippingLabel, error) {
+	// 1. Translate domain LabelRequest → DHL ShipmentRequest (ACL translation)
+	dhlRequest, err := a.toDHLShipmentRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Call MyDHL Shipments API
+	var dhlResponse dhlShipmentResponse
+	if err := a.client.doJSON(ctx, http.MethodPost, "/shipments", nil, dhlRequest, &dhlResponse); err != nil {
+		return nil, err
+	}
+
+	// 3. Translate DHL ShipmentResponse → domain ShippingLabel (ACL translation)
+	return a.fromDHLShipmentResponse(&dhlResponse, domain.NormalizeLabelFormat(request.LabelFormat))
+}
+
+// CreateManifest books a DHL courier pickup for the shipments.
+// DHL Express has no end-of-day manifest; the pickup's dispatch confirmation number identifies the handover.
+func (a *DHLAdapter) CreateManifest(ctx context.Context, shipments []domain.Shipment) (*domain.Manifest, error) {
+	// 1. Translate domain Shipments → DHL PickupRequest
+	dhlRequest := &dhlPickupRequest{
+		PlannedPickupDateAndTime: time.Now().Format(dhlTimeLayout),
+		CloseTime:                "18:00",
+		Location:                 "reception",
+		Accounts:                 a.accounts(),
+		ShipmentDetails:          make([]dhlPickupShipment, 0, len(shipments)),
+	}
+	for _, shipment := range shipments {
+		if shipment.Label == nil {
+			continue
+		}
+		dhlRequest.ShipmentDetails = append(dhlRequest.ShipmentDetails, dhlPickupShipment{
+			ProductCode:         dhlProductCode(shipment.ServiceType, isInternational(shipment.Shipper, shipment.Recipient)),
+			IsCustomsDeclarable: isInternational(shipment.Shipper, shipment.Recipient),
+			UnitOfMeasurement:   "metric",
+			Packages:            []dhlPackage{toDHLPackage(shipment.Package, "", "")},
+		})
+	}
+	if len(shipments) > 0 {
+		dhlRequest.CustomerDetails.ShipperDetails = toDHLParty(shipments[0].Shipper)
+	}
+
+	// 2. Call MyDHL Pickups API
+	var dhlResponse dhlPickupResponse
+	if err := a.client.doJSON(ctx, http.MethodPost, "/pickups", nil, dhlRequest, &dhlResponse); err != nil {
+		return nil, err
+	}
+	if len(dhlResponse.DispatchConfirmationNumbers) == 0 {
+		return nil, domain.NewCarrierError("INVALID_RESPONSE", "DHL: pickup response has no dispatch confirmation number", "ERROR", false, nil)
+	}
+
+	// 3. Translate DHL PickupResponse → domain Manifest
+	return &domain.Manifest{
+		ManifestID:    dhlResponse.DispatchConfirmationNumbers[0],
+		CarrierCode:   "DHL",
+		ShipmentCount: len(dhlRequest.ShipmentDetails),
+		GeneratedAt:   time.Now(),
+	}, nil
+}
+
+// TrackShipment retrieves tracking information from DHL Express
+func (a *DHLAdapter) TrackShipment(ctx context.Context, trackingNumber string) (*domain.TrackingInfo, error) {
+	// 1. Call MyDHL Tracking API
+	var dhlResponse dhlTrackingResponse
+	query := url.Values{"trackingView": {"all-checkpoints"}, "levelOfDetail": {"shipment"}}
+	if err := a.client.doJSON(ctx, http.MethodGet, "/shipments/"+url.PathEscape(trackingNumber)+"/tracking", query, nil, &dhlResponse); err != nil {
+		return nil, err
+	}
+	if len(dhlResponse.Shipments) == 0 {
+		return nil, domain.NewCarrierError("NOT_FOUND", "DHL: no tracking found for "+trackingNumber, "ERROR", false, nil)
+	}
+
+	// 2. Translate DHL TrackingResponse → domain TrackingInfo
+	return a.fromDHLTrackingShipment(&dhlResponse.Shipments[0]), nil
+}
+
+// ParseTrackingWebhook translates a DHL push notification → domain TrackingInfo.
+// Pushes carry the same shipments document as the Tracking API.
+func (a *DHLAdapter) ParseTrackingWebhook(payload []byte) ([]domain.TrackingInfo, error) {
+	var dhlPush dhlTrackingResponse
+	if err := json.Unmarshal(payload, &dhlPush); err != nil {
+		return nil, domain.NewCarrierError("INVALID_WEBHOOK", "DHL: malformed tracking push", "ERROR", false, err)
+	}
+
+	updates := make([]domain.TrackingInfo, 0, len(dhlPush.Shipments))
+	for i := range dhlPush.Shipments {
+		updates = append(updates, *a.fromDHLTrackingShipment(&dhlPush.Shipments[i]))
+	}
+	return updates, nil
+}
+
+// ValidateAddress checks that DHL Express serves the address's city and postal code
+func (a *DHLAdapter) ValidateAddress(ctx context.Context, address domain.Address) (*domain.AddressValidationResult, error) {
+	// 1. Translate domain Address → DHL address-validate query
+	query := url.Values{"type": {"delivery"}, "countryCode": {address.Country}}
+	setIfNotEmpty(query, "postalCode", address.PostalCode)
+	setIfNotEmpty(query, "cityName", address.City)
+
+	// 2. Call MyDHL Address Validation API
+	var dhlResponse dhlAddressValidationResponse
+	if err := a.client.doJSON(ctx, http.MethodGet, "/address-validate", query, nil, &dhlResponse); err != nil {
+		var carrierErr *domain.CarrierError
+		if asCarrierError(err, &carrierErr) && (carrierErr.Code == "INVALID_REQUEST" || carrierErr.Code == "NOT_FOUND") {
+			return &domain.AddressValidationResult{IsValid: false, ValidationErrors: []string{carrierErr.Message}}, nil
+		}
+		return nil, err
+	}
+
+	// 3. Translate DHL matches → domain AddressValidationResult
+	result := &domain.AddressValidationResult{
+		IsValid:          len(dhlResponse.Address) > 0,
+		ValidationErrors: []string{},
+	}
+	if !result.IsValid {
+		result.ValidationErrors = append(result.ValidationErrors, "address is outside the DHL Express service area")
+		return result, nil
+	}
+
+	match := dhlResponse.Address[0]
+	if !strings.EqualFold(match.CityName, address.City) || match.PostalCode != address.PostalCode {
+		suggested := address
+		suggested.City = match.CityName
+		suggested.PostalCode = match.PostalCode
+		result.SuggestedAddress = &suggested
+	}
+	return result, nil
+}
+
+// GetRates retrieves DHL Express product rates
+func (a *DHLAdapter) GetRates(ctx context.Context, request domain.RateRequest) ([]domain.ShippingRate, error) {
+	// 1. Translate domain RateRequest → DHL RateRequest
+	dhlRequest := &dhlRateRequest{
+		CustomerDetails: dhlRateCustomerDetails{
+			ShipperDetails:  toDHLRateAddress(request.Shipper),
+			ReceiverDetails: toDHLRateAddress(request.Recipient),
+		},
+		Accounts:                   a.accounts(),
+		PlannedShippingDateAndTime: time.Now().Format(dhlTimeLayout),
+		UnitOfMeasurement:          "metric",
+		IsCustomsDeclarable:        isInternational(request.Shipper, request.Recipient),
+		Packages:                   []dhlPackage{toDHLPackage(request.PackageInfo, "", "")},
+	}
+	if request.ServiceType != "" {
+		dhlRequest.ProductCode = dhlProductCode(request.ServiceType, dhlRequest.IsCustomsDeclarable)
+	}
+
+	// 2. Call MyDHL Rates API
+	var dhlResponse dhlRateResponse
+	if err := a.client.doJSON(ctx, http.MethodPost, "/rates", nil, dhlRequest, &dhlResponse); err != nil {
+		return nil, err
+	}
+
+	// 3. Translate DHL products → domain ShippingRates
+	rates := make([]domain.ShippingRate, 0, len(dhlResponse.Products))
+	for _, product := range dhlResponse.Products {
+		price, currency, ok := product.billingPrice()
+		if !ok {
+			continue
+		}
+		rates = append(rates, domain.ShippingRate{
+			ServiceType:       product.ProductCode,
+			ServiceName:       product.ProductName,
+			TotalCost:         price,
+			Currency:          currency,
+			EstimatedDelivery: product.estimatedDelivery(),
+			IsGuaranteed:      true, // DHL Express products are time-definite
+		})
+	}
+
+	return rates, nil
+}
+
+// CancelShipment is a no-op for DHL Express: the MyDHL API cannot void a waybill,
+// and waybills that are never scanned are never billed
+func (a *DHLAdapter) CancelShipment(ctx context.Context, trackingNumber string) error {
+	_ = trackingNumber
+	return nil
+}
+
+// --- Translation methods (ACL) ---
+
+// toDHLShipmentRequest translates domain LabelRequest → DHL API request
+func (a *DHLAdapter) toDHLShipmentRequest(request domain.LabelRequest) (*dhlShipmentRequest, error) {
+	encodingFormat, err := mapLabelFormatToDHL(request.LabelFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	international := isInternational(request.Shipper, request.Recipient)
+	dhlRequest := &dhlShipmentRequest{
+		PlannedShippingDateAndTime: time.Now().Format(dhlTimeLayout),
+		Pickup:                     dhlPickupFlag{IsRequested: false},
+		ProductCode:                dhlProductCode(request.ServiceType, international),
+		Accounts:                   a.accounts(),
+		OutputImageProperties: dhlOutputImageProperties{
+			EncodingFormat: encodingFormat,
+			ImageOptions: []dhlImageOption{
+				{TypeCode: "label", TemplateName: "ECOM26_84_001"}, // 4x6 thermal template
+			},
+		},
+		CustomerDetails: dhlCustomerDetails{
+			ShipperDetails:  toDHLParty(request.Shipper),
+			ReceiverDetails: toDHLParty(request.Recipient),
+		},
+		Content: dhlContent{
+			Packages:            []dhlPackage{toDHLPackage(request.PackageInfo, request.Reference1, request.Reference2)},
+			IsCustomsDeclarable: international,
+			Description:         "Merchandise",
+			UnitOfMeasurement:   "metric",
+		},
+	}
+	if request.Reference1 != "" {
+		dhlRequest.CustomerReferences = []dhlReference{{Value: request.Reference1, TypeCode: "CU"}}
+	}
+	if request.IsReturn {
+		// Return waybills are billed to the warehouse's account as the receiver
+		dhlRequest.Accounts = append(dhlRequest.Accounts, dhlAccount{TypeCode: "payer", Number: a.accountNumber})
+	}
+	if request.Customs != nil {
+		applyDHLCustomsDeclaration(dhlRequest, request.Customs)
+	}
+	return dhlRequest, nil
+}
+
+// applyDHLCustomsDeclaration adds the export declaration and declared value to a DHL shipment,
+// with paperless trade so the commercial invoice is transmitted electronically
+func applyDHLCustomsDeclaration(dhlRequest *dhlShipmentRequest, declaration *domain.CustomsDeclaration) {
+	exportReasonType := "permanent"
+	if declaration.ContentsType == domain.ContentsTypeReturn {
+		exportReasonType = "return"
+	}
+
+	lineItems := make([]dhlLineItem, len(declaration.Items))
+	descriptions := make([]string, len(declaration.Items))
+	for i, item := range declaration.Items {
+		lineItems[i] = dhlLineItem{
+			Number:              i + 1,
+			Description:         item.Description,
+			Price:               item.UnitValue,
+			Quantity:            dhlQuantity{Value: item.Quantity, UnitOfMeasurement: "PCS"},
+			CommodityCodes:      []dhlCommodityCode{{TypeCode: "outbound", Value: item.HSCode}},
+			ExportReasonType:    exportReasonType,
+			ManufacturerCountry: item.CountryOfOrigin,
+			Weight: dhlLineItemWeight{
+				NetValue:   item.Weight * float64(item.Quantity),
+				GrossValue: item.Weight * float64(item.Quantity),
+			},
+		}
+		descriptions[i] = item.Description
+	}
+
+	content := &dhlRequest.Content
+	content.IsCustomsDeclarable = declaration.ContentsType != domain.ContentsTypeDocuments
+	content.Description = truncate(strings.Join(descriptions, ", "), 70)
+	content.DeclaredValue = declaration.TotalValue()
+	content.DeclaredValueCurrency = declaration.Currency
+	content.Incoterm = string(declaration.Incoterm)
+	content.ExportDeclaration = &dhlExportDeclaration{
+		LineItems:        lineItems,
+		Invoice:          dhlInvoice{Number: firstNonEmpty(declaration.InvoiceNumber, dhlRequest.firstReference()), Date: time.Now().Format("2006-01-02")},
+		ExportReason:     string(declaration.ContentsType),
+		ExportReasonType: exportReasonType,
+	}
+	dhlRequest.ValueAddedServices = append(dhlRequest.ValueAddedServices, dhlValueAddedService{ServiceCode: "WY"}) // Paperless trade
+}
+
+// firstReference returns the customer reference printed on the waybill, if any
+func (r *dhlShipmentRequest) firstReference() string {
+	if len(r.CustomerReferences) == 0 {
+		return ""
+	}
+	return r.CustomerReferences[0].Value
+}
+
+// fromDHLShipmentResponse translates DHL API response → domain ShippingLabel
+func (a *DHLAdapter) fromDHLShipmentResponse(response *dhlShipmentResponse, requestedFormat string) (*domain.ShippingLabel, error) {
+	if response.ShipmentTrackingNumber == "" {
+		return nil, domain.NewCarrierError("INVALID_RESPONSE", "DHL: shipment response has no tracking number", "ERROR", false, nil)
+	}
+
+	label := &domain.ShippingLabel{
+		TrackingNumber: response.ShipmentTrackingNumber,
+		LabelFormat:    requestedFormat,
+		GeneratedAt:    time.Now(),
+	}
+	for _, document := range response.Documents {
+		if document.TypeCode == "label" {
+			label.LabelData = document.Content
+			break
+		}
+	}
+	return label, nil
+}
+
+// fromDHLTrackingShipment translates a DHL tracked shipment → domain TrackingInfo
+func (a *DHLAdapter) fromDHLTrackingShipment(shipment *dhlTrackingShipment) *domain.TrackingInfo {
+	events := make([]domain.TrackingEvent, len(shipment.Events))
+	for i, evt := range shipment.Events {
+		events[i] = domain.TrackingEvent{
+			Timestamp:   evt.timestamp(),
+			Location:    evt.location(),
+			Status:      evt.TypeCode,
+			Description: evt.Description,
+		}
+	}
+
+	info := &domain.TrackingInfo{
+		TrackingNumber: shipment.ShipmentTrackingNumber,
+		Status:         "In Transit",
+		StatusDetail:   shipment.Description,
+		Events:         events,
+	}
+	if len(events) > 0 {
+		// MyDHL returns checkpoints oldest first; "OK" is the delivery checkpoint
+		latest := events[len(events)-1]
+		info.CurrentLocation = latest.Location
+		if latest.Status == "OK" {
+			delivered := latest.Timestamp
+			info.Status = "Delivered"
+			info.ActualDelivery = &delivered
+		} else if dhlExceptionCodes[latest.Status] {
+			info.Status = "Exception"
+			info.StatusDetail = latest.Description
+		}
+	}
+	if shipment.EstimatedTimeOfDelivery != "" {
+		if eta, err := time.Parse("2006-01-02T15:04:05", shipment.EstimatedTimeOfDelivery); err == nil {
+			info.EstimatedDelivery = &eta
+		}
+	}
+	return info
+}
+
+func (a *DHLAdapter) accounts() []dhlAccount {
+	return []dhlAccount{{TypeCode: "shipper", Number: a.accountNumber}}
+}
+
+// decodeDHLError translates MyDHL problem+json error bodies → domain CarrierError
+func decodeDHLError(statusCode int, body []byte) *domain.CarrierError {
+	var dhlErr dhlErrorResponse
+	_ = json.Unmarshal(body, &dhlErr)
+
+	message := dhlErr.Detail
+	if message == "" {
+		message = dhlErr.Title
+	}
+	if len(dhlErr.AdditionalDetails) > 0 {
+		message += ": " + strings.Join(dhlErr.AdditionalDetails, "; ")
+	}
+	return domain.NewCarrierError(httpStatusErrorCode(statusCode), message, "ERROR", false, nil)
+}
+
+func toDHLParty(address domain.Address) dhlParty {
+	return dhlParty{
+		PostalAddress: dhlPostalAddress{
+			PostalCode:   address.PostalCode,
+			CityName:     address.City,
+			CountryCode:  address.Country,
+			ProvinceCode: address.State,
+			AddressLine1: address.Street1,
+			AddressLine2: address.Street2,
+		},
+		ContactInformation: dhlContact{
+			FullName:    address.Name,
+			CompanyName: firstNonEmpty(address.Company, address.Name),
+			Phone:       address.Phone,
+			Email:       address.Email,
+		},
+	}
+}
+
+func toDHLRateAddress(address domain.Address) dhlRateAddress {
+	return dhlRateAddress{
+		PostalCode:  address.PostalCode,
+		CityName:    address.City,
+		CountryCode: address.Country,
+	}
+}
+
+func toDHLPackage(pkg domain.PackageInfo, reference1, reference2 string) dhlPackage {
+	dhlPkg := dhlPackage{
+		Weight: pkg.Weight,
+		Dimensions: dhlDimensions{
+			Length: pkg.Dimensions.Length,
+			Width:  pkg.Dimensions.Width,
+			Height: pkg.Dimensions.Height,
+		},
+	}
+	for _, ref := range []string{reference1, reference2} {
+		if ref != "" {
+			dhlPkg.CustomerReferences = append(dhlPkg.CustomerReferences, dhlReference{Value: ref, TypeCode: "CU"})
+		}
+	}
+	return dhlPkg
+}
+
+// --- DHL API Models ---
+
+type dhlAccount struct {
+	TypeCode string `json:"typeCode"`
+	Number   string `json:"number"`
+}
+
+type dhlPostalAddress struct {
+	PostalCode   string `json:"postalCode"`
+	CityName     string `json:"cityName"`
+	CountryCode  string `json:"countryCode"`
+	ProvinceCode string `json:"provinceCode,omitempty"`
+	AddressLine1 string `json:"addressLine1"`
+	AddressLine2 string `json:"addressLine2,omitempty"`
+}
+
+type dhlContact struct {
+	FullName    string `json:"fullName"`
+	CompanyName string `json:"companyName"`
+	Phone       string `json:"phone"`
+	Email       string `json:"email,omitempty"`
+}
+
+type dhlParty struct {
+	PostalAddress      dhlPostalAddress `json:"postalAddress"`
+	ContactInformation dhlContact       `json:"contactInformation"`
+}
+
+type dhlCustomerDetails struct {
+	ShipperDetails  dhlParty `json:"shipperDetails"`
+	ReceiverDetails dhlParty `json:"receiverDetails"`
+}
+
+type dhlDimensions struct {
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+type dhlReference struct {
+	Value    string `json:"value"`
+	TypeCode string `json:"typeCode"`
+}
+
+type dhlPackage struct {
+	Weight             float64        `json:"weight"`
+	Dimensions         dhlDimensions  `json:"dimensions"`
+	CustomerReferences []dhlReference `json:"customerReferences,omitempty"`
+}
+
+type dhlContent struct {
+	Packages              []dhlPackage          `json:"packages"`
+	IsCustomsDeclarable   bool                  `json:"isCustomsDeclarable"`
+	DeclaredValue         float64               `json:"declaredValue,omitempty"`
+	DeclaredValueCurrency string                `json:"declaredValueCurrency,omitempty"`
+	ExportDeclaration     *dhlExportDeclaration `json:"exportDeclaration,omitempty"`
+	Description           string                `json:"description"`
+	Incoterm              string                `json:"incoterm,omitempty"`
+	UnitOfMeasurement     string                `json:"unitOfMeasurement"`
+}
+
+type dhlExportDeclaration struct {
+	LineItems        []dhlLineItem `json:"lineItems"`
+	Invoice          dhlInvoice    `json:"invoice"`
+	ExportReason     string        `json:"exportReason,omitempty"`
+	ExportReasonType string        `json:"exportReasonType"`
+}
+
+type dhlLineItem struct {
+	Number              int                `json:"number"`
+	Description         string             `json:"description"`
+	Price               float64            `json:"price"`
+	Quantity            dhlQuantity        `json:"quantity"`
+	CommodityCodes      []dhlCommodityCode `json:"commodityCodes"`
+	ExportReasonType    string             `json:"exportReasonType"`
+	ManufacturerCountry string             `json:"manufacturerCountry"`
+	Weight              dhlLineItemWeight  `json:"weight"`
+}
+
+type dhlQuantity struct {
+	Value             int    `json:"value"`
+	UnitOfMeasurement string `json:"unitOfMeasurement"`
+}
+
+type dhlCommodityCode struct {
+	TypeCode string `json:"typeCode"`
+	Value    string `json:"value"`
+}
+
+type dhlLineItemWeight struct {
+	NetValue   float64 `json:"netValue"`
+	GrossValue float64 `json:"grossValue"`
+}
+
+type dhlInvoice struct {
+	Number string `json:"number"`
+	Date   string `json:"date"`
+}
+
+type dhlValueAddedService struct {
+	ServiceCode string `json:"serviceCode"`
+}
+
+type dhlImageOption struct {
+	TypeCode     string `json:"typeCode"`
+	TemplateName string `json:"templateName"`
+}
+
+type dhlOutputImageProperties struct {
+	EncodingFormat string           `json:"encodingFormat"`
+	ImageOptions   []dhlImageOption `json:"imageOptions"`
+}
+
+type dhlPickupFlag struct {
+	IsRequested bool `json:"isRequested"`
+}
+
+type dhlShipmentRequest struct {
+	PlannedShippingDateAndTime string                   `json:"plannedShippingDateAndTime"`
+	Pickup                     dhlPickupFlag            `json:"pickup"`
+	ProductCode                string                   `json:"productCode"`
+	Accounts                   []dhlAccount             `json:"accounts"`
+	OutputImageProperties      dhlOutputImageProperties `json:"outputImageProperties"`
+	CustomerDetails            dhlCustomerDetails       `json:"customerDetails"`
+	Content                    dhlContent               `json:"content"`
+	CustomerReferences         []dhlReference           `json:"customerReferences,omitempty"`
+	ValueAddedServices         []dhlValueAddedService   `json:"valueAddedServices,omitempty"`
+}
+
+type dhlShipmentResponse struct {
+	ShipmentTrackingNumber string `json:"shipmentTrackingNumber"`
+	Packages               []struct {
+		TrackingNumber string `json:"trackingNumber"`
+	} `json:"packages"`
+	Documents []struct {
+		ImageFormat string `json:"imageFormat"`
+		Content     string `json:"content"`
+		TypeCode    string `json:"typeCode"`
+	} `json:"documents"`
+}
+
+type dhlPickupShipment struct {
+	ProductCode         string       `json:"productCode"`
+	IsCustomsDeclarable bool         `json:"isCustomsDeclarable"`
+	UnitOfMeasurement   string       `json:"unitOfMeasurement"`
+	Packages            []dhlPackage `json:"packages"`
+}
+
+type dhlPickupRequest struct {
+	PlannedPickupDateAndTime string       `json:"plannedPickupDateAndTime"`
+	CloseTime                string       `json:"closeTime"`
+	Location                 string       `json:"location"`
+	Accounts                 []dhlAccount `json:"accounts"`
+	CustomerDetails          struct {
+		ShipperDetails dhlParty `json:"shipperDetails"`
+	} `json:"customerDetails"`
+	ShipmentDetails []dhlPickupShipment `json:"shipmentDetails"`
+}
+
+type dhlPickupResponse struct {
+	DispatchConfirmationNumbers []string `json:"dispatchConfirmationNumbers"`
+}
+
+type dhlTrackingResponse struct {
+	Shipments []dhlTrackingShipment `json:"shipments"`
+}
+
+type dhlTrackingShipment struct {
+	ShipmentTrackingNumber  string             `json:"shipmentTrackingNumber"`
+	Status                  string             `json:"status"`
+	Description             string             `json:"description"`
+	EstimatedTimeOfDelivery string             `json:"estimatedDeliveryDate"`
+	Events                  []dhlTrackingEvent `json:"events"`
+}
+
+type dhlTrackingEvent struct {
+	Date        string `json:"date"`
+	Time        string `json:"time"`
+	GMTOffset   string `json:"GMTOffset"`
+	TypeCode    string `json:"typeCode"`
+	Description string `json:"description"`
+	ServiceArea []struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"serviceArea"`
+}
+
+func (e dhlTrackingEvent) timestamp() time.Time {
+	offset := e.GMTOffset
+	if offset == "" {
+		offset = "+00:00"
+	}
+	ts, err := time.Parse("2006-01-02T15:04:05-07:00", e.Date+"T"+e.Time+offset)
+	if err != nil {
+		return time.Time{}
+	}
+	return ts
+}
+
+func (e dhlTrackingEvent) location() string {
+	if len(e.ServiceArea) == 0 {
+		return ""
+	}
+	return e.ServiceArea[0].Description
+}
+
+type dhlRateAddress struct {
+	PostalCode  string `json:"postalCode"`
+	CityName    string `json:"cityName"`
+	CountryCode string `json:"countryCode"`
+}
+
+type dhlRateCustomerDetails struct {
+	ShipperDetails  dhlRateAddress `json:"shipperDetails"`
+	ReceiverDetails dhlRateAddress `json:"receiverDetails"`
+}
+
+type dhlRateRequest struct {
+	CustomerDetails            dhlRateCustomerDetails `json:"customerDetails"`
+	Accounts                   []dhlAccount           `json:"accounts"`
+	ProductCode                string                 `json:"productCode,omitempty"`
+	PlannedShippingDateAndTime string                 `json:"plannedShippingDateAndTime"`
+	UnitOfMeasurement          string                 `json:"unitOfMeasurement"`
+	IsCustomsDeclarable        bool                   `json:"isCustomsDeclarable"`
+	Packages                   []dhlPackage           `json:"packages"`
+}
+
+type dhlRateResponse struct {
+	Products []dhlProduct `json:"products"`
+}
+
+type dhlProduct struct {
+	ProductName string `json:"productName"`
+	ProductCode string `json:"productCode"`
+	TotalPrice  []struct {
+		CurrencyType  string  `json:"currencyType"`
+		PriceCurrency string  `json:"priceCurrency"`
+		Price         float64 `json:"price"`
+	} `json:"totalPrice"`
+	DeliveryCapabilities struct {
+		EstimatedDeliveryDateAndTime string `json:"estimatedDeliveryDateAndTime"`
+		TotalTransitDays             string `json:"totalTransitDays"`
+	} `json:"deliveryCapabilities"`
+}
+
+// billingPrice returns the product price in the billing currency (BILLC)
+func (p dhlProduct) billingPrice() (float64, string, bool) {
+	for _, price := range p.TotalPrice {
+		if price.CurrencyType == "BILLC" {
+			return price.Price, price.PriceCurrency, true
+		}
+	}
+	return 0, "", false
+}
+
+func (p dhlProduct) estimatedDelivery() time.Time {
+	if eta, err := time.Parse("2006-01-02T15:04:05", p.DeliveryCapabilities.EstimatedDeliveryDateAndTime); err == nil {
+		return eta
+	}
+	days, err := strconv.Atoi(p.DeliveryCapabilities.TotalTransitDays)
+	if err != nil {
+		days = 1
+	}
+	return time.Now().Add(time.Duration(days) * 24 * time.Hour)
+}
+
+type dhlAddressValidationResponse struct {
+	Address []struct {
+		CountryCode string `json:"countryCode"`
+		PostalCode  string `json:"postalCode"`
+		CityName    string `json:"cityName"`
+	} `json:"address"`
+}
+
+type dhlErrorResponse struct {
+	Instance          string   `json:"instance"`
+	Detail            string   `json:"detail"`
+	Title             string   `json:"title"`
+	Message           string   `json:"message"`
+	Status            string   `json:"status"`
+	AdditionalDetails []string `json:"additionalDetails"`
+}
+
+// --- Helper mapping functions ---
+
+func mapServiceTypeToDHL(serviceType string) string {
+	// Map domain service type to DHL global product code; product codes chosen by rate shopping pass through
+	switch strings.ToLower(serviceType) {
+	case "p", "d", "n", "t", "y", "k", "u", "w":
+		return strings.ToUpper(serviceType)
+	case "domestic", "domesticexpress", "ground":
+		return "N" // Domestic Express
+	case "express1200", "noon":
+		return "T" // Express 12:00
+	case "express0900", "nextday", "overnight":
+		return "K" // Express 9:00
+	case "economy", "economyselect":
+		return "W" // Economy Select
+	default:
+		return "P" // Express Worldwide
+	}
+}
+
+// dhlProductCode maps the service type, falling back to Express Worldwide
+// when a domestic-only product is requested for an international shipment
+func dhlProductCode(serviceType string, international bool) string {
+	code := mapServiceTypeToDHL(serviceType)
+	if international && code == "N" {
+		return "P"
+	}
+	return code
+}
+
+func mapLabelFormatToDHL(format string) (string, error) {
+	switch domain.NormalizeLabelFormat(format) {
+	case domain.LabelFormatPDF:
+		return "pdf", nil
+	case domain.LabelFormatZPL:
+		return "zpl", nil
+	default:
+		return "", unsupportedLabelFormat("DHL", format)
+	}
+}
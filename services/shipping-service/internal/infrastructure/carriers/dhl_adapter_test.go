@@ -0,0 +1,206 @@
+package carriers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+func newDHLTestAdapter(t *testing.T, routes map[string]fixture) (*DHLAdapter, *fixtureServer) {
+	server := newFixtureServer(t, routes)
+	return NewDHLAdapter("apiuser", "apisecret", "848100000", server.URL), server
+}
+
+func internationalLabelRequest() domain.LabelRequest {
+	request := testLabelRequest("pdf")
+	request.ServiceType = "ground"
+	request.Recipient = domain.Address{Name: "John Smith", Street1: "1 Poultry", City: "London", PostalCode: "EC1A 1BB", Country: "GB", Phone: "+442071234567"}
+	return request
+}
+
+func TestDHLAdapter_GenerateLabel(t *testing.T) {
+	adapter, server := newDHLTestAdapter(t, map[string]fixture{
+		"POST /shipments": {file: "dhl/shipment.json"},
+	})
+
+	label, err := adapter.GenerateLabel(context.Background(), internationalLabelRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890", label.TrackingNumber)
+	assert.Equal(t, domain.LabelFormatPDF, label.LabelFormat)
+	assert.Equal(t, "JVBERi0xLjQKJcfsj6IKMSAwIG9iago8PC9UeXBlL0NhdGFsb2c+PgplbmRvYmoKJSVFT0YK", label.LabelData)
+
+	sent := server.last("POST /shipments")
+	username, password, ok := (&http.Request{Header: sent.header}).BasicAuth()
+	require.True(t, ok)
+	assert.Equal(t, "apiuser", username)
+	assert.Equal(t, "apisecret", password)
+
+	var body dhlShipmentRequest
+	require.NoError(t, json.Unmarshal([]byte(sent.body), &body))
+	assert.Equal(t, "P", body.ProductCode, "domestic product is not offered internationally")
+	assert.Equal(t, "pdf", body.OutputImageProperties.EncodingFormat)
+	assert.True(t, body.Content.IsCustomsDeclarable)
+	assert.Equal(t, "metric", body.Content.UnitOfMeasurement)
+	assert.Equal(t, 2.5, body.Content.Packages[0].Weight)
+	assert.Equal(t, "GB", body.CustomerDetails.ReceiverDetails.PostalAddress.CountryCode)
+	assert.Equal(t, "848100000", body.Accounts[0].Number)
+	assert.Nil(t, body.Content.ExportDeclaration)
+}
+
+func TestDHLAdapter_GenerateLabelWithCustomsDeclaration(t *testing.T) {
+	adapter, server := newDHLTestAdapter(t, map[string]fixture{
+		"POST /shipments": {file: "dhl/shipment.json"},
+	})
+
+	request := internationalLabelRequest()
+	request.Customs = testCustomsDeclaration()
+	_, err := adapter.GenerateLabel(context.Background(), request)
+	require.NoError(t, err)
+
+	var body dhlShipmentRequest
+	require.NoError(t, json.Unmarshal([]byte(server.last("POST /shipments").body), &body))
+	assert.True(t, body.Content.IsCustomsDeclarable)
+	assert.Equal(t, 30.0, body.Content.DeclaredValue)
+	assert.Equal(t, "USD", body.Content.DeclaredValueCurrency)
+	assert.Equal(t, "DDP", body.Content.Incoterm)
+	assert.Equal(t, "Cotton t-shirt", body.Content.Description)
+	require.NotNil(t, body.Content.ExportDeclaration)
+	assert.Equal(t, "INV-001", body.Content.ExportDeclaration.Invoice.Number)
+	require.Len(t, body.Content.ExportDeclaration.LineItems, 1)
+	item := body.Content.ExportDeclaration.LineItems[0]
+	assert.Equal(t, "610910", item.CommodityCodes[0].Value)
+	assert.Equal(t, "BD", item.ManufacturerCountry)
+	assert.Equal(t, 2, item.Quantity.Value)
+	assert.Equal(t, []dhlValueAddedService{{ServiceCode: "WY"}}, body.ValueAddedServices)
+}
+
+func TestDHLAdapter_GetRates(t *testing.T) {
+	adapter, _ := newDHLTestAdapter(t, map[string]fixture{
+		"POST /rates": {file: "dhl/rates.json"},
+	})
+
+	rates, err := adapter.GetRates(context.Background(), domain.RateRequest{
+		Shipper:     internationalLabelRequest().Shipper,
+		Recipient:   internationalLabelRequest().Recipient,
+		PackageInfo: internationalLabelRequest().PackageInfo,
+	})
+	require.NoError(t, err)
+
+	// Products without a billing-currency price are skipped
+	require.Len(t, rates, 2)
+	assert.Equal(t, "P", rates[0].ServiceType)
+	assert.Equal(t, 86.75, rates[0].TotalCost)
+	assert.Equal(t, "USD", rates[0].Currency)
+	assert.True(t, rates[0].IsGuaranteed)
+	assert.Equal(t, time.Date(2025, 1, 6, 23, 59, 0, 0, time.UTC), rates[0].EstimatedDelivery)
+}
+
+func TestDHLAdapter_TrackShipment(t *testing.T) {
+	adapter, _ := newDHLTestAdapter(t, map[string]fixture{
+		"GET /shipments/1234567890/tracking": {file: "dhl/tracking.json"},
+	})
+
+	info, err := adapter.TrackShipment(context.Background(), "1234567890")
+	require.NoError(t, err)
+	assert.Equal(t, "Delivered", info.Status)
+	assert.Equal(t, "London - UK", info.CurrentLocation)
+	require.Len(t, info.Events, 3)
+	assert.Equal(t, time.Date(2025, 1, 3, 23, 35, 0, 0, time.UTC), info.Events[0].Timestamp.UTC())
+	require.NotNil(t, info.ActualDelivery)
+	require.NotNil(t, info.EstimatedDelivery)
+}
+
+func TestDHLAdapter_ParseTrackingWebhook(t *testing.T) {
+	adapter := NewDHLAdapter("apiuser", "apisecret", "848100000", "")
+	payload := []byte(`{"shipments": [
+		{"shipmentTrackingNumber": "1234567890", "description": "Shipment on hold", "events": [
+			{"date": "2025-01-03", "time": "18:35:00", "GMTOffset": "-05:00", "typeCode": "PU", "description": "Shipment picked up"},
+			{"date": "2025-01-04", "time": "07:20:00", "GMTOffset": "-05:00", "typeCode": "CA", "description": "Closed on arrival", "serviceArea": [{"code": "CVG", "description": "Cincinnati Hub - USA"}]}
+		]},
+		{"shipmentTrackingNumber": "9876543210", "events": [
+			{"date": "2025-01-04", "time": "09:00:00", "GMTOffset": "+00:00", "typeCode": "AF", "description": "Arrived at DHL Sort Facility"}
+		]}
+	]}`)
+
+	updates, err := adapter.ParseTrackingWebhook(payload)
+	require.NoError(t, err)
+	require.Len(t, updates, 2)
+	assert.Equal(t, "1234567890", updates[0].TrackingNumber)
+	assert.Equal(t, "Exception", updates[0].Status)
+	assert.Equal(t, "Closed on arrival", updates[0].StatusDetail)
+	assert.Equal(t, domain.TrackingMilestoneException, domain.NormalizeTrackingStatus(updates[0].Status, updates[0].StatusDetail))
+	assert.Equal(t, "9876543210", updates[1].TrackingNumber)
+
+	_, err = adapter.ParseTrackingWebhook([]byte("not json"))
+	var carrierErr *domain.CarrierError
+	require.ErrorAs(t, err, &carrierErr)
+	assert.Equal(t, "INVALID_WEBHOOK", carrierErr.Code)
+}
+
+func TestDHLAdapter_CreateManifestBooksPickup(t *testing.T) {
+	adapter, server := newDHLTestAdapter(t, map[string]fixture{
+		"POST /pickups": {file: "dhl/pickup.json"},
+	})
+
+	request := internationalLabelRequest()
+	shipments := []domain.Shipment{
+		{Shipper: request.Shipper, Recipient: request.Recipient, Package: request.PackageInfo, Label: &domain.ShippingLabel{TrackingNumber: "1234567890"}},
+		{Shipper: request.Shipper, Recipient: request.Recipient, Package: request.PackageInfo},
+	}
+	manifest, err := adapter.CreateManifest(context.Background(), shipments)
+	require.NoError(t, err)
+	assert.Equal(t, "CBJ250103002345", manifest.ManifestID)
+	assert.Equal(t, 1, manifest.ShipmentCount)
+
+	var body dhlPickupRequest
+	require.NoError(t, json.Unmarshal([]byte(server.last("POST /pickups").body), &body))
+	assert.Len(t, body.ShipmentDetails, 1)
+}
+
+func TestDHLAdapter_ValidateAddress(t *testing.T) {
+	adapter, server := newDHLTestAdapter(t, map[string]fixture{
+		"GET /address-validate": {file: "dhl/address.json"},
+	})
+
+	address := internationalLabelRequest().Recipient
+	result, err := adapter.ValidateAddress(context.Background(), address)
+	require.NoError(t, err)
+	assert.True(t, result.IsValid)
+	assert.Nil(t, result.SuggestedAddress)
+	assert.Equal(t, "delivery", server.last("GET /address-validate").query["type"][0])
+
+	server.setRoute("GET /address-validate", fixture{status: 404, file: "dhl/address_not_found.json"})
+	result, err = adapter.ValidateAddress(context.Background(), address)
+	require.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Contains(t, result.ValidationErrors[0], "Address not found")
+}
+
+func TestDHLAdapter_ErrorMapping(t *testing.T) {
+	adapter, server := newDHLTestAdapter(t, map[string]fixture{
+		"POST /shipments": {status: 400, file: "dhl/error_400.json"},
+		"POST /rates":     {status: 429, file: "dhl/error_429.json"},
+	})
+
+	_, err := adapter.GenerateLabel(context.Background(), internationalLabelRequest())
+	carrierErr := requireCarrierError(t, err)
+	assert.Equal(t, "INVALID_REQUEST", carrierErr.Code)
+	assert.False(t, carrierErr.Retryable)
+	assert.Contains(t, carrierErr.Message, "420504: The receiver postal code is invalid.")
+
+	_, err = adapter.GetRates(context.Background(), testRateRequest())
+	carrierErr = requireCarrierError(t, err)
+	assert.Equal(t, "RATE_LIMITED", carrierErr.Code)
+	assert.True(t, carrierErr.Retryable)
+
+	_, err = adapter.GenerateLabel(context.Background(), testLabelRequest("png"))
+	assert.ErrorIs(t, err, domain.ErrUnsupportedLabelFormat)
+	assert.Len(t, server.received("POST /shipments"), 1, "unsupported formats are rejected before calling DHL")
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/wms-platform/shipping-service/internal/domain"
+	"github.com/wms-platform/shipping-service/internal/infrastructure/labels"
 )
 
 // FedExAdapter is the Anti-Corruption Layer adapter for FedEx carrier integration
@@ -17,6 +18,7 @@ type FedExAdapter struct {
 	accountNumber string
 	meterNumber   string
 	apiURL        string
+	renderer      domain.LabelRenderer
 }
 
 // NewFedExAdapter creates a new FedEx carrier adapter
@@ -27,6 +29,7 @@ func NewFedExAdapter(clientID, clientSecret, accountNumber, meterNumber, apiURL
 		accountNumber: accountNumber,
 		meterNumber:   meterNumber,
 		apiURL:        apiURL,
+		renderer:      labels.NewRenderer(),
 	}
 }
 
@@ -35,6 +38,17 @@ func (a *FedExAdapter) GetCarrierCode() string {
 	return "FEDEX"
 }
 
+// GetCapabilities returns FedEx package limits (150 lb, 108 in length, 165 in length plus girth)
+func (a *FedExAdapter) GetCapabilities() domain.CarrierCapabilities {
+	return domain.CarrierCapabilities{
+		MaxWeightKg:           68,
+		MaxLengthCm:           274,
+		MaxLengthPlusGirthCm:  419,
+		SupportsHazmat:        true,
+		SupportsInternational: true,
+	}
+}
+
 // GenerateLabel generates a shipping label using FedEx API
 func (a *FedExAdapter) GenerateLabel(ctx context.Context, request domain.LabelRequest) (*domain.ShippingLabel, error) {
 	// 1. Translate domain LabelRequest → FedEx ShipmentRequest (ACL translation)
@@ -46,16 +60,21 @@ func (a *FedExAdapter) GenerateLabel(ctx context.Context, request domain.LabelRe
 	//     return nil, a.translateFedExError(err)
 	// }
 
-	// Mock response
+	// Mock response: the label image is rendered locally in the requested format
 	_ = fedexRequest // Suppress unused variable warning (will use when integrating real API)
+	trackingNumber := "123456789012"
+	labelImage, err := renderLabelImage(a.renderer, request, a.GetCarrierCode(), trackingNumber)
+	if err != nil {
+		return nil, err
+	}
 	fedexResponse := &fedexShipmentResponse{
-		TrackingNumber: "123456789012",
-		LabelImage:     "base64encodedimage...",
-		LabelFormat:    "PNG",
+		TrackingNumber: trackingNumber,
+		LabelImage:     labelImage,
+		LabelFormat:    domain.NormalizeLabelFormat(request.LabelFormat),
 	}
 
 	// 3. Translate FedEx ShipmentResponse → domain ShippingLabel (ACL translation)
-	label := a.fromFedExShipmentResponse(fedexResponse, request.LabelFormat)
+	label := a.fromFedExShipmentResponse(fedexResponse, fedexResponse.LabelFormat)
 
 	return label, nil
 }
@@ -247,9 +266,56 @@ func (a *FedExAdapter) toFedExShipmentRequest(request domain.LabelRequest) *fede
 			Height:      request.PackageInfo.Dimensions.Height,
 			PackagingType: mapPackageTypeToFedEx(request.PackageInfo.PackageType),
 		},
-		ServiceType:  mapServiceTypeToFedEx(request.ServiceType),
-		LabelFormat:  request.LabelFormat,
-		Reference1:   request.Reference1,
+		ServiceType:            mapServiceTypeToFedEx(request.ServiceType),
+		LabelFormat:            request.LabelFormat,
+		Reference1:             request.Reference1,
+		CustomsClearanceDetail: toFedExCustomsClearanceDetail(request.Customs),
+	}
+}
+
+// toFedExCustomsClearanceDetail translates a customs declaration → FedEx customs clearance detail,
+// uploading the commercial invoice as an electronic trade document (ETD)
+func toFedExCustomsClearanceDetail(declaration *domain.CustomsDeclaration) *fedexCustomsClearanceDetail {
+	if declaration == nil {
+		return nil
+	}
+
+	commodities := make([]fedexCommodity, len(declaration.Items))
+	for i, item := range declaration.Items {
+		commodities[i] = fedexCommodity{
+			Description:          item.Description,
+			HarmonizedCode:       item.HSCode,
+			CountryOfManufacture: item.CountryOfOrigin,
+			Quantity:             item.Quantity,
+			QuantityUnits:        "PCS",
+			UnitPrice:            fedexMoney{Amount: item.UnitValue, Currency: declaration.Currency},
+			CustomsValue:         fedexMoney{Amount: item.Value(), Currency: declaration.Currency},
+			Weight:               item.Weight * float64(item.Quantity),
+		}
+	}
+
+	dutiesPaymentType := "RECIPIENT"
+	if declaration.Incoterm.ShipperPaysDuties() {
+		dutiesPaymentType = "SENDER"
+	}
+	documentContent := "NON_DOCUMENTS"
+	if declaration.ContentsType == domain.ContentsTypeDocuments {
+		documentContent = "DOCUMENTS_ONLY"
+	}
+
+	return &fedexCustomsClearanceDetail{
+		DutiesPaymentType: dutiesPaymentType,
+		DocumentContent:   documentContent,
+		Commodities:       commodities,
+		TotalCustomsValue: fedexMoney{Amount: declaration.TotalValue(), Currency: declaration.Currency},
+		CommercialInvoice: fedexCommercialInvoice{
+			InvoiceNumber:   declaration.InvoiceNumber,
+			ShipmentPurpose: mapContentsTypeToFedEx(declaration.ContentsType),
+			TermsOfSale:     string(declaration.Incoterm),
+			ImporterTaxID:   declaration.ImporterTaxID,
+			ExporterTaxID:   declaration.ExporterID,
+		},
+		ElectronicTradeDocuments: true,
 	}
 }
 
@@ -323,12 +389,46 @@ func (a *FedExAdapter) translateFedExError(err error) error {
 // --- FedEx API Models (would come from FedEx SDK) ---
 
 type fedexShipmentRequest struct {
-	Shipper     fedexAddress
-	Recipient   fedexAddress
-	Package     fedexPackage
-	ServiceType string
-	LabelFormat string
-	Reference1  string
+	Shipper                fedexAddress
+	Recipient              fedexAddress
+	Package                fedexPackage
+	ServiceType            string
+	LabelFormat            string
+	Reference1             string
+	CustomsClearanceDetail *fedexCustomsClearanceDetail
+}
+
+type fedexCustomsClearanceDetail struct {
+	DutiesPaymentType        string
+	DocumentContent          string
+	Commodities              []fedexCommodity
+	TotalCustomsValue        fedexMoney
+	CommercialInvoice        fedexCommercialInvoice
+	ElectronicTradeDocuments bool // ETD special service: invoice is sent electronically
+}
+
+type fedexCommodity struct {
+	Description          string
+	HarmonizedCode       string
+	CountryOfManufacture string
+	Quantity             int
+	QuantityUnits        string
+	UnitPrice            fedexMoney
+	CustomsValue         fedexMoney
+	Weight               float64
+}
+
+type fedexCommercialInvoice struct {
+	InvoiceNumber   string
+	ShipmentPurpose string
+	TermsOfSale     string
+	ImporterTaxID   string
+	ExporterTaxID   string
+}
+
+type fedexMoney struct {
+	Amount   float64
+	Currency string
 }
 
 type fedexShipmentResponse struct {
@@ -417,6 +517,21 @@ func mapPackageTypeToFedEx(packageType string) string {
 	}
 }
 
+func mapContentsTypeToFedEx(contentsType domain.ContentsType) string {
+	switch contentsType {
+	case domain.ContentsTypeGift:
+		return "GIFT"
+	case domain.ContentsTypeSample:
+		return "SAMPLE"
+	case domain.ContentsTypeReturn:
+		return "REPAIR_AND_RETURN"
+	case domain.ContentsTypeDocuments:
+		return "NOT_SOLD"
+	default:
+		return "SOLD"
+	}
+}
+
 func mapServiceTypeToFedEx(serviceType string) string {
 	// Map domain service type to FedEx service type
 	mapping := map[string]string{
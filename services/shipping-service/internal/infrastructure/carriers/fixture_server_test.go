@@ -0,0 +1,110 @@
+package carriers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fixture is a recorded carrier API response served by fixtureServer
+type fixture struct {
+	status int
+	file   string // path under testdata/
+}
+
+// recordedRequest is a request the adapter sent to the fixture server
+type recordedRequest struct {
+	method string
+	path   string
+	query  map[string][]string
+	header http.Header
+	body   string
+}
+
+// fixtureServer replays recorded carrier responses keyed by "METHOD /path",
+// so adapters can be exercised offline against real payloads
+type fixtureServer struct {
+	*httptest.Server
+	t        *testing.T
+	mu       sync.Mutex
+	routes   map[string]fixture
+	requests map[string][]recordedRequest
+}
+
+func newFixtureServer(t *testing.T, routes map[string]fixture) *fixtureServer {
+	t.Helper()
+	s := &fixtureServer{t: t, routes: routes, requests: make(map[string][]recordedRequest)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fixtureServer) serve(w http.ResponseWriter, r *http.Request) {
+	key := r.Method + " " + r.URL.Path
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests[key] = append(s.requests[key], recordedRequest{
+		method: r.Method,
+		path:   r.URL.Path,
+		query:  r.URL.Query(),
+		header: r.Header.Clone(),
+		body:   string(body),
+	})
+	route, ok := s.routes[key]
+	s.mu.Unlock()
+
+	if !ok {
+		s.t.Errorf("unexpected carrier request %s", key)
+		http.NotFound(w, r)
+		return
+	}
+
+	data, err := os.ReadFile(filepath.Join("testdata", route.file))
+	if err != nil {
+		s.t.Errorf("failed to read fixture %s: %v", route.file, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	contentType := "application/json"
+	if strings.HasSuffix(route.file, ".xml") {
+		contentType = "application/xml"
+	}
+	w.Header().Set("Content-Type", contentType)
+	status := route.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// setRoute replaces the fixture served for a route, e.g. to simulate an outage mid-test
+func (s *fixtureServer) setRoute(key string, f fixture) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes[key] = f
+}
+
+// received returns the requests sent to a route, oldest first
+func (s *fixtureServer) received(key string) []recordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]recordedRequest(nil), s.requests[key]...)
+}
+
+// last returns the most recent request sent to a route
+func (s *fixtureServer) last(key string) recordedRequest {
+	s.t.Helper()
+	requests := s.received(key)
+	if len(requests) == 0 {
+		s.t.Fatalf("no request received for %s", key)
+	}
+	return requests[len(requests)-1]
+}
@@ -0,0 +1,206 @@
+package carriers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// DefaultCarrierHTTPTimeout bounds a single carrier API call
+const DefaultCarrierHTTPTimeout = 30 * time.Second
+
+// maxErrorBodyBytes limits how much of an error response is kept for diagnostics
+const maxErrorBodyBytes = 4096
+
+// carrierErrorDecoder turns a non-2xx carrier response body into a carrier error
+type carrierErrorDecoder func(statusCode int, body []byte) *domain.CarrierError
+
+// carrierHTTPClient sends JSON or XML requests to a carrier API and maps
+// transport and HTTP failures to domain CarrierErrors
+type carrierHTTPClient struct {
+	carrierCode string
+	baseURL     string
+	httpClient  *http.Client
+	authorize   func(ctx context.Context, req *http.Request) error
+	decodeError carrierErrorDecoder
+}
+
+func newCarrierHTTPClient(carrierCode, baseURL string, decodeError carrierErrorDecoder) *carrierHTTPClient {
+	return &carrierHTTPClient{
+		carrierCode: carrierCode,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		httpClient:  &http.Client{Timeout: DefaultCarrierHTTPTimeout},
+		decodeError: decodeError,
+	}
+}
+
+// doJSON sends in as a JSON body (if not nil) and decodes a JSON response into out (if not nil)
+func (c *carrierHTTPClient) doJSON(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return domain.NewCarrierError("INVALID_REQUEST", c.carrierCode+": failed to encode request", "ERROR", false, err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	respBody, err := c.do(ctx, method, path, query, "application/json", body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return domain.NewCarrierError("INVALID_RESPONSE", c.carrierCode+": failed to decode response", "ERROR", false, err)
+	}
+	return nil
+}
+
+// doXML sends in as an XML body (if not nil) and decodes an XML response into out (if not nil)
+func (c *carrierHTTPClient) doXML(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := xml.Marshal(in)
+		if err != nil {
+			return domain.NewCarrierError("INVALID_REQUEST", c.carrierCode+": failed to encode request", "ERROR", false, err)
+		}
+		body = bytes.NewReader(append([]byte(xml.Header), data...))
+	}
+
+	respBody, err := c.do(ctx, method, path, query, "application/xml", body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := xml.Unmarshal(respBody, out); err != nil {
+		return domain.NewCarrierError("INVALID_RESPONSE", c.carrierCode+": failed to decode response", "ERROR", false, err)
+	}
+	return nil
+}
+
+func (c *carrierHTTPClient) do(ctx context.Context, method, path string, query url.Values, contentType string, body io.Reader) ([]byte, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, domain.NewCarrierError("INVALID_REQUEST", c.carrierCode+": failed to build request", "ERROR", false, err)
+	}
+	req.Header.Set("Accept", contentType)
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if key := domain.IdempotencyKeyFromContext(ctx); key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	if c.authorize != nil {
+		if err := c.authorize(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, c.transportError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, c.transportError(ctx, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, c.statusError(resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
+
+// transportError maps network failures, which are always worth retrying unless the caller gave up
+func (c *carrierHTTPClient) transportError(ctx context.Context, err error) *domain.CarrierError {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return domain.NewCarrierError("REQUEST_CANCELLED", c.carrierCode+": request cancelled", "ERROR", false, err)
+	}
+	return domain.NewCarrierError("CARRIER_UNAVAILABLE", c.carrierCode+": carrier API unreachable", "ERROR", true, err)
+}
+
+// statusError maps an HTTP error status to a CarrierError. The carrier's own
+// error decoder supplies the code and message; server-side and throttling
+// statuses are always retryable, and decoders may mark other cases retryable.
+func (c *carrierHTTPClient) statusError(statusCode int, body []byte) *domain.CarrierError {
+	var carrierErr *domain.CarrierError
+	if c.decodeError != nil {
+		carrierErr = c.decodeError(statusCode, body)
+	}
+	if carrierErr == nil {
+		carrierErr = domain.NewCarrierError(httpStatusErrorCode(statusCode), "", "ERROR", false, nil)
+	}
+	if carrierErr.Message == "" {
+		carrierErr.Message = http.StatusText(statusCode)
+	}
+	carrierErr.Message = c.carrierCode + ": " + carrierErr.Message
+	carrierErr.Retryable = carrierErr.Retryable || isRetryableStatus(statusCode)
+	if carrierErr.OriginalErr == nil {
+		if len(body) > maxErrorBodyBytes {
+			body = body[:maxErrorBodyBytes]
+		}
+		carrierErr.OriginalErr = fmt.Errorf("status %d: %s", statusCode, strings.TrimSpace(string(body)))
+	}
+	return carrierErr
+}
+
+// isRetryableStatus reports whether a request that failed with the status may succeed later
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= http.StatusInternalServerError
+}
+
+// httpStatusErrorCode is the fallback CarrierError code when the carrier body has none
+func httpStatusErrorCode(statusCode int) string {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return "AUTHENTICATION_FAILED"
+	case statusCode == http.StatusNotFound:
+		return "NOT_FOUND"
+	case statusCode == http.StatusTooManyRequests:
+		return "RATE_LIMITED"
+	case statusCode >= http.StatusInternalServerError:
+		return "CARRIER_UNAVAILABLE"
+	default:
+		return "INVALID_REQUEST"
+	}
+}
+
+// Unit conversions for carriers that work in imperial units
+
+const (
+	kgPerLb = 0.45359237
+	cmPerIn = 2.54
+)
+
+func kgToLb(kg float64) float64 { return roundTo(kg/kgPerLb, 2) }
+
+func cmToIn(cm float64) float64 { return roundTo(cm/cmPerIn, 2) }
+
+func roundTo(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,29 @@
+package carriers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// renderLabelImage renders the label for a request and returns it base64 encoded,
+// matching the label image field carrier APIs return
+func renderLabelImage(renderer domain.LabelRenderer, request domain.LabelRequest, carrierCode, trackingNumber string) (string, error) {
+	doc := domain.NewLabelDocument(request, carrierCode, trackingNumber)
+	data, err := renderer.Render(doc, request.LabelFormat)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnsupportedLabelFormat) {
+			return "", domain.NewCarrierError("UNSUPPORTED_LABEL_FORMAT", "label format not supported", "ERROR", false, err)
+		}
+		return "", domain.NewCarrierError("LABEL_RENDER_FAILED", "failed to render label", "ERROR", false, err)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// unsupportedLabelFormat reports a label format the carrier API cannot produce
+func unsupportedLabelFormat(carrierCode, format string) error {
+	return domain.NewCarrierError("UNSUPPORTED_LABEL_FORMAT", carrierCode+": label format not supported", "ERROR", false,
+		fmt.Errorf("%w: %s", domain.ErrUnsupportedLabelFormat, format))
+}
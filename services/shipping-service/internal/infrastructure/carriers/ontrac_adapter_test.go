@@ -0,0 +1,148 @@
+package carriers
+
+import (
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+func newOnTracTestAdapter(t *testing.T, routes map[string]fixture) (*OnTracAdapter, *fixtureServer) {
+	server := newFixtureServer(t, routes)
+	return NewOnTracAdapter("37", "testpass", server.URL), server
+}
+
+func TestOnTracAdapter_GenerateLabel(t *testing.T) {
+	adapter, server := newOnTracTestAdapter(t, map[string]fixture{
+		"POST /V4/37/shipments": {file: "ontrac/shipment.xml"},
+	})
+
+	label, err := adapter.GenerateLabel(context.Background(), testLabelRequest("zpl"))
+	require.NoError(t, err)
+	assert.Equal(t, "C10000012345678", label.TrackingNumber)
+	assert.Equal(t, domain.LabelFormatZPL, label.LabelFormat)
+	assert.NotEmpty(t, label.LabelData)
+
+	sent := server.last("POST /V4/37/shipments")
+	assert.Equal(t, "testpass", sent.query["pw"][0])
+	assert.True(t, strings.HasPrefix(sent.body, "<?xml"))
+
+	var body ontracShipmentRequest
+	require.NoError(t, xml.Unmarshal([]byte(sent.body), &body))
+	require.Len(t, body.Shipments, 1)
+	shipment := body.Shipments[0]
+	assert.Equal(t, "SHP-001", shipment.UID)
+	assert.Equal(t, ontracGround, shipment.Service)
+	assert.Equal(t, 6, shipment.LabelType)
+	assert.InDelta(t, 5.51, shipment.Weight, 0.001)
+	assert.InDelta(t, 8.0, shipment.DIM.Width, 0.001)
+	assert.True(t, shipment.Residential)
+	assert.Equal(t, "WMS", shipment.Shipper.Name)
+	assert.Equal(t, "ORD-001", shipment.Reference)
+}
+
+func TestOnTracAdapter_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		fixture   fixture
+		code      string
+		retryable bool
+	}{
+		{"shipment rejected", fixture{file: "ontrac/shipment_rejected.xml"}, "SHIPMENT_REJECTED", false},
+		{"bad credentials with HTTP 200", fixture{file: "ontrac/auth_error.xml"}, "AUTHENTICATION_FAILED", false},
+		{"service outage", fixture{status: 500, file: "ontrac/error_500.xml"}, "CARRIER_UNAVAILABLE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, _ := newOnTracTestAdapter(t, map[string]fixture{
+				"POST /V4/37/shipments": tt.fixture,
+			})
+
+			_, err := adapter.GenerateLabel(context.Background(), testLabelRequest("pdf"))
+			carrierErr := requireCarrierError(t, err)
+			assert.Equal(t, tt.code, carrierErr.Code)
+			assert.Equal(t, tt.retryable, carrierErr.Retryable)
+		})
+	}
+}
+
+func TestOnTracAdapter_GetRates(t *testing.T) {
+	adapter, server := newOnTracTestAdapter(t, map[string]fixture{
+		"GET /V4/37/rates": {file: "ontrac/rates.xml"},
+	})
+
+	rates, err := adapter.GetRates(context.Background(), testRateRequest())
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, ontracGround, rates[0].ServiceType)
+	assert.Equal(t, "OnTrac Ground", rates[0].ServiceName)
+	assert.Equal(t, 11.27, rates[0].TotalCost)
+	assert.False(t, rates[0].IsGuaranteed)
+	assert.True(t, rates[1].IsGuaranteed)
+
+	assert.Equal(t, "1;38118;78701;true;0;false;0;5.51;12;8;4;", server.last("GET /V4/37/rates").query["packages"][0])
+}
+
+func TestOnTracAdapter_TrackShipment(t *testing.T) {
+	adapter, server := newOnTracTestAdapter(t, map[string]fixture{
+		"GET /V4/37/shipments": {file: "ontrac/tracking.xml"},
+	})
+
+	info, err := adapter.TrackShipment(context.Background(), "C10000012345678")
+	require.NoError(t, err)
+	assert.Equal(t, "Delivered", info.Status)
+	assert.Equal(t, "DELIVERED", info.StatusDetail)
+	assert.Equal(t, "BEVERLY HILLS, CA", info.CurrentLocation)
+	assert.Len(t, info.Events, 3)
+	require.NotNil(t, info.ActualDelivery)
+	require.NotNil(t, info.EstimatedDelivery)
+
+	sent := server.last("GET /V4/37/shipments")
+	assert.Equal(t, "C10000012345678", sent.query["tn"][0])
+	assert.Equal(t, "track", sent.query["requestType"][0])
+}
+
+func TestOnTracAdapter_ValidateAddress(t *testing.T) {
+	adapter, server := newOnTracTestAdapter(t, map[string]fixture{
+		"GET /V4/37/zips": {file: "ontrac/zips.xml"},
+	})
+
+	address := domain.Address{Street1: "9 Rodeo Dr", City: "Beverly Hills", State: "CA", PostalCode: "90210"}
+	result, err := adapter.ValidateAddress(context.Background(), address)
+	require.NoError(t, err)
+	assert.True(t, result.IsValid)
+
+	server.setRoute("GET /V4/37/zips", fixture{file: "ontrac/zips_empty.xml"})
+	address.PostalCode = "10001"
+	result, err = adapter.ValidateAddress(context.Background(), address)
+	require.NoError(t, err)
+	assert.False(t, result.IsValid)
+	assert.Contains(t, result.ValidationErrors[0], "outside the OnTrac delivery area")
+}
+
+func TestOnTracAdapter_CancelShipment(t *testing.T) {
+	adapter, _ := newOnTracTestAdapter(t, map[string]fixture{
+		"DELETE /V4/37/shipments/C10000012345678": {file: "ontrac/void.xml"},
+	})
+
+	assert.NoError(t, adapter.CancelShipment(context.Background(), "C10000012345678"))
+}
+
+func TestOnTracAdapter_CreateManifest(t *testing.T) {
+	adapter, server := newOnTracTestAdapter(t, map[string]fixture{})
+
+	manifest, err := adapter.CreateManifest(context.Background(), []domain.Shipment{
+		{Label: &domain.ShippingLabel{TrackingNumber: "C10000012345678"}},
+		{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ONTRAC", manifest.CarrierCode)
+	assert.Equal(t, 1, manifest.ShipmentCount)
+	assert.Empty(t, server.requests, "OnTrac has no manifest call")
+}
@@ -0,0 +1,70 @@
+package carriers
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// Shared ACL translation helpers for carrier API models
+
+// splitZIP splits a US postal code into its ZIP and ZIP+4 parts
+func splitZIP(postalCode string) (string, string) {
+	zip5, zip4, _ := strings.Cut(strings.TrimSpace(postalCode), "-")
+	return zip5, zip4
+}
+
+// splitName splits a full name into first and last name for carriers that require both
+func splitName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	if i := strings.LastIndex(name, " "); i > 0 {
+		return strings.TrimSpace(name[:i]), name[i+1:]
+	}
+	return name, ""
+}
+
+// joinLocation formats a city and state or country as a tracking location
+func joinLocation(city, region string) string {
+	switch {
+	case city == "":
+		return region
+	case region == "":
+		return city
+	default:
+		return city + ", " + region
+	}
+}
+
+func setIfNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+func asCarrierError(err error, target **domain.CarrierError) bool {
+	return errors.As(err, target)
+}
+
+func isInternational(shipper, recipient domain.Address) bool {
+	return shipper.Country != "" && recipient.Country != "" && !strings.EqualFold(shipper.Country, recipient.Country)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// truncate cuts a string to the length limit of a carrier field
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
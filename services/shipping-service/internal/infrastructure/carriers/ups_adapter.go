@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/wms-platform/shipping-service/internal/domain"
+	"github.com/wms-platform/shipping-service/internal/infrastructure/labels"
 )
 
 // UPSAdapter is the Anti-Corruption Layer adapter for UPS carrier integration
@@ -17,6 +18,7 @@ type UPSAdapter struct {
 	password      string
 	accountNumber string
 	apiURL        string
+	renderer      domain.LabelRenderer
 }
 
 // NewUPSAdapter creates a new UPS carrier adapter
@@ -27,6 +29,7 @@ func NewUPSAdapter(accessKey, username, password, accountNumber, apiURL string)
 		password:      password,
 		accountNumber: accountNumber,
 		apiURL:        apiURL,
+		renderer:      labels.NewRenderer(),
 	}
 }
 
@@ -35,6 +38,17 @@ func (a *UPSAdapter) GetCarrierCode() string {
 	return "UPS"
 }
 
+// GetCapabilities returns UPS package limits (150 lb, 108 in length, 165 in length plus girth)
+func (a *UPSAdapter) GetCapabilities() domain.CarrierCapabilities {
+	return domain.CarrierCapabilities{
+		MaxWeightKg:           68,
+		MaxLengthCm:           274,
+		MaxLengthPlusGirthCm:  419,
+		SupportsHazmat:        true,
+		SupportsInternational: true,
+	}
+}
+
 // GenerateLabel generates a shipping label using UPS API
 func (a *UPSAdapter) GenerateLabel(ctx context.Context, request domain.LabelRequest) (*domain.ShippingLabel, error) {
 	// 1. Translate domain LabelRequest → UPS ShipmentRequest (ACL translation)
@@ -46,16 +60,21 @@ func (a *UPSAdapter) GenerateLabel(ctx context.Context, request domain.LabelRequ
 	//     return nil, a.translateUPSError(err)
 	// }
 
-	// For now, create a mock response to show the pattern
+	// Mock response: the label image is rendered locally in the requested format
 	_ = upsRequest // Suppress unused variable warning (will use when integrating real API)
+	trackingNumber := "1Z999AA10123456784"
+	labelImage, err := renderLabelImage(a.renderer, request, a.GetCarrierCode(), trackingNumber)
+	if err != nil {
+		return nil, err
+	}
 	upsResponse := &upsShipmentResponse{
-		TrackingNumber: "1Z999AA10123456784",
-		LabelImage:     "base64encodedimage...",
-		LabelFormat:    "GIF",
+		TrackingNumber: trackingNumber,
+		LabelImage:     labelImage,
+		LabelFormat:    domain.NormalizeLabelFormat(request.LabelFormat),
 	}
 
 	// 3. Translate UPS ShipmentResponse → domain ShippingLabel (ACL translation)
-	label := a.fromUPSShipmentResponse(upsResponse, request.LabelFormat)
+	label := a.fromUPSShipmentResponse(upsResponse, upsResponse.LabelFormat)
 
 	return label, nil
 }
@@ -241,10 +260,40 @@ func (a *UPSAdapter) toUPSShipmentRequest(request domain.LabelRequest) *upsShipm
 			Height:      request.PackageInfo.Dimensions.Height,
 			PackageType: mapPackageTypeToUPS(request.PackageInfo.PackageType),
 		},
-		Service:      mapServiceTypeToUPS(request.ServiceType),
-		Reference1:   request.Reference1,
-		Reference2:   request.Reference2,
-		LabelFormat:  request.LabelFormat,
+		Service:            mapServiceTypeToUPS(request.ServiceType),
+		Reference1:         request.Reference1,
+		Reference2:         request.Reference2,
+		LabelFormat:        request.LabelFormat,
+		InternationalForms: toUPSInternationalForms(request.Customs),
+	}
+}
+
+// toUPSInternationalForms translates a customs declaration → UPS paperless commercial invoice
+func toUPSInternationalForms(declaration *domain.CustomsDeclaration) *upsInternationalForms {
+	if declaration == nil {
+		return nil
+	}
+
+	products := make([]upsProduct, len(declaration.Items))
+	for i, item := range declaration.Items {
+		products[i] = upsProduct{
+			Description:       item.Description,
+			CommodityCode:     item.HSCode,
+			OriginCountryCode: item.CountryOfOrigin,
+			Quantity:          item.Quantity,
+			UnitValue:         item.UnitValue,
+			Weight:            item.Weight,
+		}
+	}
+
+	return &upsInternationalForms{
+		FormType:         "01", // Commercial invoice
+		InvoiceNumber:    declaration.InvoiceNumber,
+		ReasonForExport:  mapContentsTypeToUPS(declaration.ContentsType),
+		TermsOfShipment:  string(declaration.Incoterm),
+		CurrencyCode:     declaration.Currency,
+		Products:         products,
+		PaperlessInvoice: true,
 	}
 }
 
@@ -329,13 +378,33 @@ func (a *UPSAdapter) translateUPSError(err error) error {
 // --- UPS API Models (would come from UPS SDK) ---
 
 type upsShipmentRequest struct {
-	Shipper     upsAddress
-	ShipTo      upsAddress
-	Package     upsPackage
-	Service     string
-	Reference1  string
-	Reference2  string
-	LabelFormat string
+	Shipper            upsAddress
+	ShipTo             upsAddress
+	Package            upsPackage
+	Service            string
+	Reference1         string
+	Reference2         string
+	LabelFormat        string
+	InternationalForms *upsInternationalForms
+}
+
+type upsInternationalForms struct {
+	FormType         string
+	InvoiceNumber    string
+	ReasonForExport  string
+	TermsOfShipment  string
+	CurrencyCode     string
+	Products         []upsProduct
+	PaperlessInvoice bool // Invoice is sent electronically instead of printed
+}
+
+type upsProduct struct {
+	Description       string
+	CommodityCode     string
+	OriginCountryCode string
+	Quantity          int
+	UnitValue         float64
+	Weight            float64
 }
 
 type upsShipmentResponse struct {
@@ -426,6 +495,19 @@ func mapPackageTypeToUPS(packageType string) string {
 	}
 }
 
+func mapContentsTypeToUPS(contentsType domain.ContentsType) string {
+	switch contentsType {
+	case domain.ContentsTypeGift:
+		return "GIFT"
+	case domain.ContentsTypeSample:
+		return "SAMPLE"
+	case domain.ContentsTypeReturn:
+		return "RETURN"
+	default:
+		return "SALE"
+	}
+}
+
 func mapServiceTypeToUPS(serviceType string) string {
 	// Map domain service type to UPS service code
 	mapping := map[string]string{
@@ -0,0 +1,729 @@
+package carriers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// USPS mail classes
+const (
+	uspsGroundAdvantage     = "USPS_GROUND_ADVANTAGE"
+	uspsPriorityMail        = "PRIORITY_MAIL"
+	uspsPriorityMailExpress = "PRIORITY_MAIL_EXPRESS"
+
+	uspsFirstClassInternational      = "FIRST-CLASS_PACKAGE_INTERNATIONAL_SERVICE"
+	uspsPriorityMailInternational    = "PRIORITY_MAIL_INTERNATIONAL"
+	uspsPriorityExpressInternational = "PRIORITY_MAIL_EXPRESS_INTERNATIONAL"
+)
+
+// USPSAdapter is the Anti-Corruption Layer adapter for USPS carrier integration
+// It translates between domain models and the USPS v3 REST APIs, which work in pounds and inches
+type USPSAdapter struct {
+	clientID      string
+	clientSecret  string
+	accountNumber string
+	client        *carrierHTTPClient
+
+	tokenMu        sync.Mutex
+	accessToken    string
+	tokenExpiresAt time.Time
+}
+
+// NewUSPSAdapter creates a new USPS carrier adapter
+func NewUSPSAdapter(clientID, clientSecret, accountNumber, apiURL string) *USPSAdapter {
+	a := &USPSAdapter{
+		clientID:      clientID,
+		clientSecret:  clientSecret,
+		accountNumber: accountNumber,
+	}
+	a.client = newCarrierHTTPClient("USPS", apiURL, a.translateUSPSError)
+	a.client.authorize = a.authorize
+	return a
+}
+
+// GetCarrierCode returns the carrier code this adapter handles
+func (a *USPSAdapter) GetCarrierCode() string {
+	return "USPS"
+}
+
+// GetCapabilities returns USPS package limits (70 lb, 130 in length plus girth)
+func (a *USPSAdapter) GetCapabilities() domain.CarrierCapabilities {
+	return domain.CarrierCapabilities{
+		MaxWeightKg:           31.75,
+		MaxLengthPlusGirthCm:  330,
+		SupportsHazmat:        false,
+		SupportsInternational: true,
+	}
+}
+
+// GenerateLabel generates a shipping label using the USPS Labels API
+func (a *USPSAdapter) GenerateLabel(ctx context.Context, request domain.LabelRequest) (*domain.ShippingLabel, error) {
+	// 1. Translate domain LabelRequest → USPS LabelRequest (ACL translation)
+	uspsRequest, err := a.toUSPSLabelRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Call USPS API; return and international labels have their own endpoints
+	path := "/labels/v3/label"
+	switch {
+	case uspsRequest.CustomsForm != nil:
+		path = "/international-labels/v3/international-label"
+	case request.IsReturn:
+		path = "/labels/v3/return-label"
+	}
+	var uspsResponse uspsLabelResponse
+	if err := a.client.doJSON(ctx, http.MethodPost, path, nil, uspsRequest, &uspsResponse); err != nil {
+		return nil, err
+	}
+
+	// 3. Translate USPS LabelResponse → domain ShippingLabel (ACL translation)
+	return a.fromUSPSLabelResponse(&uspsResponse, domain.NormalizeLabelFormat(request.LabelFormat))
+}
+
+// CreateManifest creates a SCAN form (PS Form 5630) covering the shipments
+func (a *USPSAdapter) CreateManifest(ctx context.Context, shipments []domain.Shipment) (*domain.Manifest, error) {
+	// 1. Translate domain Shipments → USPS SCAN form request
+	uspsRequest := &uspsScanFormRequest{
+		Form:            "5630",
+		ImageType:       "PDF",
+		LabelType:       "8.5x11LABEL",
+		MailingDate:     time.Now().Format("2006-01-02"),
+		TrackingNumbers: make([]string, 0, len(shipments)),
+	}
+	for _, shipment := range shipments {
+		if shipment.Label != nil {
+			uspsRequest.TrackingNumbers = append(uspsRequest.TrackingNumbers, shipment.Label.TrackingNumber)
+		}
+	}
+	if len(shipments) > 0 {
+		uspsRequest.FromAddress = toUSPSAddress(shipments[0].Shipper)
+	}
+
+	// 2. Call USPS SCAN Form API
+	var uspsResponse uspsScanFormResponse
+	if err := a.client.doJSON(ctx, http.MethodPost, "/scan-forms/v3/scan-form", nil, uspsRequest, &uspsResponse); err != nil {
+		return nil, err
+	}
+
+	// 3. Translate USPS SCAN form → domain Manifest
+	return &domain.Manifest{
+		ManifestID:    uspsResponse.Metadata.ScanFormNumber,
+		CarrierCode:   "USPS",
+		ShipmentCount: len(uspsResponse.Metadata.TrackingNumbers),
+		GeneratedAt:   time.Now(),
+	}, nil
+}
+
+// TrackShipment retrieves tracking information from USPS
+func (a *USPSAdapter) TrackShipment(ctx context.Context, trackingNumber string) (*domain.TrackingInfo, error) {
+	// 1. Call USPS Tracking API
+	var uspsResponse uspsTrackingResponse
+	query := url.Values{"expand": {"DETAIL"}}
+	if err := a.client.doJSON(ctx, http.MethodGet, "/tracking/v3/tracking/"+url.PathEscape(trackingNumber), query, nil, &uspsResponse); err != nil {
+		return nil, err
+	}
+
+	// 2. Translate USPS TrackingResponse → domain TrackingInfo
+	return a.fromUSPSTrackingResponse(&uspsResponse), nil
+}
+
+// ParseTrackingWebhook translates a USPS tracking subscription notification → domain TrackingInfo.
+// Notifications carry the same document as the Tracking API.
+func (a *USPSAdapter) ParseTrackingWebhook(payload []byte) ([]domain.TrackingInfo, error) {
+	var uspsNotification uspsTrackingResponse
+	if err := json.Unmarshal(payload, &uspsNotification); err != nil {
+		return nil, domain.NewCarrierError("INVALID_WEBHOOK", "USPS: malformed tracking notification", "ERROR", false, err)
+	}
+	if uspsNotification.TrackingNumber == "" {
+		return nil, domain.NewCarrierError("INVALID_WEBHOOK", "USPS: tracking notification has no tracking number", "ERROR", false, nil)
+	}
+	return []domain.TrackingInfo{*a.fromUSPSTrackingResponse(&uspsNotification)}, nil
+}
+
+// ValidateAddress validates an address against the USPS address database
+func (a *USPSAdapter) ValidateAddress(ctx context.Context, address domain.Address) (*domain.AddressValidationResult, error) {
+	// 1. Translate domain Address → USPS address query
+	query := url.Values{}
+	setIfNotEmpty(query, "firm", address.Company)
+	setIfNotEmpty(query, "streetAddress", address.Street1)
+	setIfNotEmpty(query, "secondaryAddress", address.Street2)
+	setIfNotEmpty(query, "city", address.City)
+	setIfNotEmpty(query, "state", address.State)
+	zip5, _ := splitZIP(address.PostalCode)
+	setIfNotEmpty(query, "ZIPCode", zip5)
+
+	// 2. Call USPS Addresses API
+	var uspsResponse uspsAddressResponse
+	if err := a.client.doJSON(ctx, http.MethodGet, "/addresses/v3/address", query, nil, &uspsResponse); err != nil {
+		var carrierErr *domain.CarrierError
+		if asCarrierError(err, &carrierErr) && carrierErr.Code == "ADDRESS_NOT_FOUND" {
+			return &domain.AddressValidationResult{IsValid: false, ValidationErrors: []string{carrierErr.Message}}, nil
+		}
+		return nil, err
+	}
+
+	// 3. Translate USPS AddressResponse → domain AddressValidationResult
+	return a.fromUSPSAddressResponse(address, &uspsResponse), nil
+}
+
+// GetRates retrieves shipping rates from the USPS Prices API
+func (a *USPSAdapter) GetRates(ctx context.Context, request domain.RateRequest) ([]domain.ShippingRate, error) {
+	// 1. Translate domain RateRequest → USPS rate search
+	origin, _ := splitZIP(request.Shipper.PostalCode)
+	destination, _ := splitZIP(request.Recipient.PostalCode)
+	uspsRequest := &uspsRateSearchRequest{
+		OriginZIPCode:      origin,
+		DestinationZIPCode: destination,
+		Weight:             kgToLb(request.PackageInfo.Weight),
+		Length:             cmToIn(request.PackageInfo.Dimensions.Length),
+		Width:              cmToIn(request.PackageInfo.Dimensions.Width),
+		Height:             cmToIn(request.PackageInfo.Dimensions.Height),
+		MailClasses:        []string{uspsGroundAdvantage, uspsPriorityMail, uspsPriorityMailExpress},
+		PriceType:          "COMMERCIAL",
+		MailingDate:        time.Now().Format("2006-01-02"),
+	}
+	if request.ServiceType != "" {
+		uspsRequest.MailClasses = []string{mapServiceTypeToUSPS(request.ServiceType)}
+	}
+
+	// 2. Call USPS Prices API
+	var uspsResponse uspsRateSearchResponse
+	if err := a.client.doJSON(ctx, http.MethodPost, "/prices/v3/total-rates/search", nil, uspsRequest, &uspsResponse); err != nil {
+		return nil, err
+	}
+
+	// 3. Translate USPS rate options → domain ShippingRates
+	rates := make([]domain.ShippingRate, 0, len(uspsResponse.RateOptions))
+	for _, option := range uspsResponse.RateOptions {
+		if len(option.Rates) == 0 {
+			continue
+		}
+		mailClass := option.Rates[0].MailClass
+		rates = append(rates, domain.ShippingRate{
+			ServiceType:       mailClass,
+			ServiceName:       option.Rates[0].Description,
+			TotalCost:         option.TotalPrice,
+			Currency:          "USD",
+			EstimatedDelivery: time.Now().Add(time.Duration(uspsTransitDays(mailClass)) * 24 * time.Hour),
+			IsGuaranteed:      mailClass == uspsPriorityMailExpress, // only Priority Mail Express carries a money-back guarantee
+		})
+	}
+
+	return rates, nil
+}
+
+// CancelShipment cancels (refunds) an unused USPS label
+func (a *USPSAdapter) CancelShipment(ctx context.Context, trackingNumber string) error {
+	var uspsResponse uspsCancelResponse
+	if err := a.client.doJSON(ctx, http.MethodDelete, "/labels/v3/label/"+url.PathEscape(trackingNumber), nil, nil, &uspsResponse); err != nil {
+		return err
+	}
+	if uspsResponse.Status != "" && !strings.EqualFold(uspsResponse.Status, "CANCELED") {
+		return domain.NewCarrierError("CANCEL_REJECTED", "USPS: label cancellation "+strings.ToLower(uspsResponse.Status), "ERROR", false, nil)
+	}
+	return nil
+}
+
+// --- Authentication ---
+
+// authorize adds a cached OAuth client-credentials token to the request, fetching a new one when it expires
+func (a *USPSAdapter) authorize(ctx context.Context, req *http.Request) error {
+	a.tokenMu.Lock()
+	defer a.tokenMu.Unlock()
+
+	if a.accessToken == "" || time.Now().After(a.tokenExpiresAt) {
+		tokenClient := newCarrierHTTPClient("USPS", a.client.baseURL, decodeUSPSError)
+		tokenClient.httpClient = a.client.httpClient
+
+		var token uspsTokenResponse
+		tokenRequest := &uspsTokenRequest{GrantType: "client_credentials", ClientID: a.clientID, ClientSecret: a.clientSecret}
+		if err := tokenClient.doJSON(ctx, http.MethodPost, "/oauth2/v3/token", nil, tokenRequest, &token); err != nil {
+			return err
+		}
+
+		// Refresh a minute early so in-flight requests never carry an expired token
+		a.accessToken = token.AccessToken
+		a.tokenExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.accessToken)
+	return nil
+}
+
+func (a *USPSAdapter) invalidateToken() {
+	a.tokenMu.Lock()
+	defer a.tokenMu.Unlock()
+	a.accessToken = ""
+}
+
+// --- Translation methods (ACL) ---
+
+// toUSPSLabelRequest translates domain LabelRequest → USPS API request
+func (a *USPSAdapter) toUSPSLabelRequest(request domain.LabelRequest) (*uspsLabelRequest, error) {
+	imageType, err := mapLabelFormatToUSPS(request.LabelFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	references := make([]uspsCustomerReference, 0, 2)
+	for _, ref := range []string{request.Reference1, request.Reference2} {
+		if ref != "" {
+			references = append(references, uspsCustomerReference{ReferenceNumber: ref, PrintReferenceNumber: true})
+		}
+	}
+
+	uspsRequest := &uspsLabelRequest{
+		ImageInfo: uspsImageInfo{
+			ImageType: imageType,
+			LabelType: "4X6LABEL",
+		},
+		ToAddress:   toUSPSAddress(request.Recipient),
+		FromAddress: toUSPSAddress(request.Shipper),
+		PackageDescription: uspsPackageDescription{
+			MailClass:                    mapServiceTypeToUSPS(request.ServiceType),
+			RateIndicator:                "SP", // single piece
+			WeightUOM:                    "lb",
+			Weight:                       kgToLb(request.PackageInfo.Weight),
+			DimensionsUOM:                "in",
+			Length:                       cmToIn(request.PackageInfo.Dimensions.Length),
+			Width:                        cmToIn(request.PackageInfo.Dimensions.Width),
+			Height:                       cmToIn(request.PackageInfo.Dimensions.Height),
+			ProcessingCategory:           "MACHINABLE",
+			MailingDate:                  time.Now().Format("2006-01-02"),
+			DestinationEntryFacilityType: "NONE",
+			CustomerReference:            references,
+		},
+	}
+
+	// International labels carry the electronic customs form (PS Form 2976/2976-A)
+	if request.Customs != nil {
+		uspsRequest.PackageDescription.MailClass = mapServiceTypeToUSPSInternational(request.ServiceType)
+		uspsRequest.PackageDescription.DestinationEntryFacilityType = ""
+		uspsRequest.ToAddress = toUSPSInternationalAddress(request.Recipient)
+		uspsRequest.CustomsForm = toUSPSCustomsForm(request.Customs)
+	}
+	return uspsRequest, nil
+}
+
+// toUSPSCustomsForm translates a customs declaration → USPS customs form
+func toUSPSCustomsForm(declaration *domain.CustomsDeclaration) *uspsCustomsForm {
+	contents := make([]uspsCustomsContent, len(declaration.Items))
+	for i, item := range declaration.Items {
+		contents[i] = uspsCustomsContent{
+			ItemDescription: item.Description,
+			ItemQuantity:    item.Quantity,
+			ItemValue:       item.UnitValue,
+			ItemTotalValue:  item.Value(),
+			WeightUOM:       "lb",
+			ItemWeight:      kgToLb(item.Weight),
+			ItemTotalWeight: kgToLb(item.Weight * float64(item.Quantity)),
+			HSTariffNumber:  item.HSCode,
+			CountryOfOrigin: item.CountryOfOrigin,
+		}
+	}
+
+	return &uspsCustomsForm{
+		ContentType:        mapContentsTypeToUSPS(declaration.ContentsType),
+		RestrictionType:    "NONE",
+		InvoiceNumber:      declaration.InvoiceNumber,
+		ImportersReference: declaration.ImporterTaxID,
+		Contents:           contents,
+	}
+}
+
+// toUSPSInternationalAddress translates a foreign address, which has no ZIP code, → USPS address
+func toUSPSInternationalAddress(address domain.Address) uspsAddress {
+	firstName, lastName := splitName(address.Name)
+	return uspsAddress{
+		FirstName:            firstName,
+		LastName:             lastName,
+		Firm:                 address.Company,
+		StreetAddress:        address.Street1,
+		SecondaryAddress:     address.Street2,
+		City:                 address.City,
+		Province:             address.State,
+		PostalCode:           address.PostalCode,
+		CountryISOAlpha2Code: strings.ToUpper(address.Country),
+		Phone:                address.Phone,
+		Email:                address.Email,
+	}
+}
+
+// fromUSPSLabelResponse translates USPS API response → domain ShippingLabel
+func (a *USPSAdapter) fromUSPSLabelResponse(response *uspsLabelResponse, requestedFormat string) (*domain.ShippingLabel, error) {
+	trackingNumber := firstNonEmpty(response.LabelMetadata.TrackingNumber, response.LabelMetadata.InternationalTrackingNumber)
+	if trackingNumber == "" {
+		return nil, domain.NewCarrierError("INVALID_RESPONSE", "USPS: label response has no tracking number", "ERROR", false, nil)
+	}
+	return &domain.ShippingLabel{
+		TrackingNumber: trackingNumber,
+		LabelFormat:    requestedFormat,
+		LabelData:      response.LabelImage,
+		GeneratedAt:    time.Now(),
+	}, nil
+}
+
+// fromUSPSTrackingResponse translates USPS tracking response → domain TrackingInfo
+func (a *USPSAdapter) fromUSPSTrackingResponse(response *uspsTrackingResponse) *domain.TrackingInfo {
+	events := make([]domain.TrackingEvent, len(response.TrackingEvents))
+	for i, evt := range response.TrackingEvents {
+		events[i] = domain.TrackingEvent{
+			Timestamp:   evt.EventTimestamp,
+			Location:    joinLocation(evt.EventCity, evt.EventState),
+			Status:      evt.EventCode,
+			Description: evt.EventType,
+		}
+	}
+
+	info := &domain.TrackingInfo{
+		TrackingNumber: response.TrackingNumber,
+		Status:         response.StatusCategory,
+		StatusDetail:   response.Status,
+		Events:         events,
+	}
+	if len(events) > 0 {
+		info.CurrentLocation = events[0].Location
+	}
+	if response.ExpectedDeliveryTimeStamp != nil {
+		info.EstimatedDelivery = response.ExpectedDeliveryTimeStamp
+	}
+	if strings.EqualFold(response.StatusCategory, "Delivered") && len(response.TrackingEvents) > 0 {
+		delivered := response.TrackingEvents[0].EventTimestamp
+		info.ActualDelivery = &delivered
+	}
+	return info
+}
+
+// fromUSPSAddressResponse translates USPS standardized address → domain AddressValidationResult
+func (a *USPSAdapter) fromUSPSAddressResponse(original domain.Address, response *uspsAddressResponse) *domain.AddressValidationResult {
+	result := &domain.AddressValidationResult{
+		IsValid:          response.AdditionalInfo.DPVConfirmation == "Y",
+		ValidationErrors: []string{},
+	}
+	for _, correction := range response.Corrections {
+		if correction.Text != "" {
+			result.ValidationErrors = append(result.ValidationErrors, correction.Text)
+		}
+	}
+	if !result.IsValid && len(result.ValidationErrors) == 0 {
+		result.ValidationErrors = append(result.ValidationErrors, "address could not be confirmed as deliverable")
+	}
+
+	suggested := original
+	suggested.Street1 = response.Address.StreetAddress
+	suggested.Street2 = response.Address.SecondaryAddress
+	suggested.City = response.Address.City
+	suggested.State = response.Address.State
+	suggested.PostalCode = response.Address.ZIPCode
+	if response.Address.ZIPPlus4 != "" {
+		suggested.PostalCode += "-" + response.Address.ZIPPlus4
+	}
+	if suggested != original {
+		result.SuggestedAddress = &suggested
+	}
+	return result
+}
+
+// translateUSPSError translates USPS API error bodies → domain CarrierError.
+// A 401 means the cached token was revoked or expired early, so it is dropped
+// and the error marked retryable: the next attempt fetches a fresh token.
+func (a *USPSAdapter) translateUSPSError(statusCode int, body []byte) *domain.CarrierError {
+	carrierErr := decodeUSPSError(statusCode, body)
+	if statusCode == http.StatusUnauthorized {
+		a.invalidateToken()
+		carrierErr.Retryable = true
+	}
+	return carrierErr
+}
+
+// decodeUSPSError reads the code and message from a USPS error body
+func decodeUSPSError(statusCode int, body []byte) *domain.CarrierError {
+	var uspsErr uspsErrorResponse
+	_ = json.Unmarshal(body, &uspsErr)
+
+	code := httpStatusErrorCode(statusCode)
+	message := uspsErr.Error.Message
+	if len(uspsErr.Error.Errors) > 0 {
+		detail := uspsErr.Error.Errors[0]
+		if detail.Detail != "" {
+			message = detail.Detail
+		}
+		if detail.Code != "" && statusCode < http.StatusInternalServerError {
+			code = detail.Code
+		}
+	}
+	if statusCode < http.StatusInternalServerError && strings.Contains(strings.ToLower(message), "address not found") {
+		code = "ADDRESS_NOT_FOUND"
+	}
+
+	return domain.NewCarrierError(code, message, "ERROR", false, nil)
+}
+
+func toUSPSAddress(address domain.Address) uspsAddress {
+	zip5, zip4 := splitZIP(address.PostalCode)
+	firstName, lastName := splitName(address.Name)
+	return uspsAddress{
+		FirstName:        firstName,
+		LastName:         lastName,
+		Firm:             address.Company,
+		StreetAddress:    address.Street1,
+		SecondaryAddress: address.Street2,
+		City:             address.City,
+		State:            address.State,
+		ZIPCode:          zip5,
+		ZIPPlus4:         zip4,
+		Phone:            address.Phone,
+		Email:            address.Email,
+	}
+}
+
+// --- USPS API Models ---
+
+type uspsTokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+type uspsTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type uspsAddress struct {
+	FirstName            string `json:"firstName,omitempty"`
+	LastName             string `json:"lastName,omitempty"`
+	Firm                 string `json:"firm,omitempty"`
+	StreetAddress        string `json:"streetAddress"`
+	SecondaryAddress     string `json:"secondaryAddress,omitempty"`
+	City                 string `json:"city"`
+	State                string `json:"state,omitempty"`
+	ZIPCode              string `json:"ZIPCode,omitempty"`
+	ZIPPlus4             string `json:"ZIPPlus4,omitempty"`
+	Province             string `json:"province,omitempty"`
+	PostalCode           string `json:"postalCode,omitempty"`
+	CountryISOAlpha2Code string `json:"countryISOAlpha2Code,omitempty"`
+	Phone                string `json:"phone,omitempty"`
+	Email                string `json:"email,omitempty"`
+}
+
+type uspsImageInfo struct {
+	ImageType string `json:"imageType"`
+	LabelType string `json:"labelType"`
+}
+
+type uspsCustomerReference struct {
+	ReferenceNumber      string `json:"referenceNumber"`
+	PrintReferenceNumber bool   `json:"printReferenceNumber"`
+}
+
+type uspsPackageDescription struct {
+	MailClass                    string                  `json:"mailClass"`
+	RateIndicator                string                  `json:"rateIndicator"`
+	WeightUOM                    string                  `json:"weightUOM"`
+	Weight                       float64                 `json:"weight"`
+	DimensionsUOM                string                  `json:"dimensionsUOM"`
+	Length                       float64                 `json:"length"`
+	Width                        float64                 `json:"width"`
+	Height                       float64                 `json:"height"`
+	ProcessingCategory           string                  `json:"processingCategory"`
+	MailingDate                  string                  `json:"mailingDate"`
+	DestinationEntryFacilityType string                  `json:"destinationEntryFacilityType,omitempty"`
+	CustomerReference            []uspsCustomerReference `json:"customerReference,omitempty"`
+}
+
+type uspsLabelRequest struct {
+	ImageInfo          uspsImageInfo          `json:"imageInfo"`
+	ToAddress          uspsAddress            `json:"toAddress"`
+	FromAddress        uspsAddress            `json:"fromAddress"`
+	PackageDescription uspsPackageDescription `json:"packageDescription"`
+	CustomsForm        *uspsCustomsForm       `json:"customsForm,omitempty"`
+}
+
+type uspsCustomsForm struct {
+	ContentType        string               `json:"contentType"`
+	RestrictionType    string               `json:"restrictionType"`
+	InvoiceNumber      string               `json:"invoiceNumber,omitempty"`
+	ImportersReference string               `json:"importersReference,omitempty"`
+	Contents           []uspsCustomsContent `json:"contents"`
+}
+
+type uspsCustomsContent struct {
+	ItemDescription string  `json:"itemDescription"`
+	ItemQuantity    int     `json:"itemQuantity"`
+	ItemValue       float64 `json:"itemValue"`
+	ItemTotalValue  float64 `json:"itemTotalValue"`
+	WeightUOM       string  `json:"weightUOM"`
+	ItemWeight      float64 `json:"itemWeight"`
+	ItemTotalWeight float64 `json:"itemTotalWeight"`
+	HSTariffNumber  string  `json:"HSTariffNumber"`
+	CountryOfOrigin string  `json:"countryofOrigin"`
+}
+
+type uspsLabelResponse struct {
+	LabelMetadata struct {
+		TrackingNumber              string  `json:"trackingNumber"`
+		InternationalTrackingNumber string  `json:"internationalTrackingNumber"`
+		Postage                     float64 `json:"postage"`
+	} `json:"labelMetadata"`
+	LabelImage string `json:"labelImage"`
+}
+
+type uspsScanFormRequest struct {
+	Form            string      `json:"form"`
+	ImageType       string      `json:"imageType"`
+	LabelType       string      `json:"labelType"`
+	MailingDate     string      `json:"mailingDate"`
+	FromAddress     uspsAddress `json:"fromAddress"`
+	TrackingNumbers []string    `json:"trackingNumbers"`
+}
+
+type uspsScanFormResponse struct {
+	Metadata struct {
+		ScanFormNumber  string   `json:"SCANFormNumber"`
+		TrackingNumbers []string `json:"trackingNumbers"`
+	} `json:"SCANFormMetadata"`
+}
+
+type uspsTrackingResponse struct {
+	TrackingNumber            string              `json:"trackingNumber"`
+	StatusCategory            string              `json:"statusCategory"`
+	Status                    string              `json:"status"`
+	ExpectedDeliveryTimeStamp *time.Time          `json:"expectedDeliveryTimeStamp"`
+	TrackingEvents            []uspsTrackingEvent `json:"trackingEvents"`
+}
+
+type uspsTrackingEvent struct {
+	EventType      string    `json:"eventType"`
+	EventTimestamp time.Time `json:"eventTimestamp"`
+	EventCity      string    `json:"eventCity"`
+	EventState     string    `json:"eventState"`
+	EventCode      string    `json:"eventCode"`
+}
+
+type uspsAddressResponse struct {
+	Address        uspsAddress `json:"address"`
+	AdditionalInfo struct {
+		DPVConfirmation string `json:"DPVConfirmation"`
+	} `json:"additionalInfo"`
+	Corrections []struct {
+		Code string `json:"code"`
+		Text string `json:"text"`
+	} `json:"corrections"`
+}
+
+type uspsRateSearchRequest struct {
+	OriginZIPCode      string   `json:"originZIPCode"`
+	DestinationZIPCode string   `json:"destinationZIPCode"`
+	Weight             float64  `json:"weight"`
+	Length             float64  `json:"length"`
+	Width              float64  `json:"width"`
+	Height             float64  `json:"height"`
+	MailClasses        []string `json:"mailClasses"`
+	PriceType          string   `json:"priceType"`
+	MailingDate        string   `json:"mailingDate"`
+}
+
+type uspsRateSearchResponse struct {
+	RateOptions []struct {
+		TotalPrice float64 `json:"totalPrice"`
+		Rates      []struct {
+			MailClass   string  `json:"mailClass"`
+			Description string  `json:"description"`
+			Price       float64 `json:"price"`
+		} `json:"rates"`
+	} `json:"rateOptions"`
+}
+
+type uspsCancelResponse struct {
+	TrackingNumber string `json:"trackingNumber"`
+	Status         string `json:"status"`
+}
+
+type uspsErrorResponse struct {
+	APIVersion string `json:"apiVersion"`
+	Error      struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Errors  []struct {
+			Status string `json:"status"`
+			Code   string `json:"code"`
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		} `json:"errors"`
+	} `json:"error"`
+}
+
+// --- Helper mapping functions ---
+
+func mapServiceTypeToUSPS(serviceType string) string {
+	// Map domain service type to USPS mail class; mail classes chosen by rate shopping pass through
+	switch strings.ToLower(serviceType) {
+	case "ground", "groundadvantage", "ground_advantage", strings.ToLower(uspsGroundAdvantage):
+		return uspsGroundAdvantage
+	case "priority", "2day", "2dayair", "express_saver", strings.ToLower(uspsPriorityMail):
+		return uspsPriorityMail
+	case "express", "overnight", "nextday", "nextdayair", strings.ToLower(uspsPriorityMailExpress):
+		return uspsPriorityMailExpress
+	default:
+		return uspsGroundAdvantage // Default to Ground Advantage
+	}
+}
+
+func mapServiceTypeToUSPSInternational(serviceType string) string {
+	switch mapServiceTypeToUSPS(serviceType) {
+	case uspsPriorityMail:
+		return uspsPriorityMailInternational
+	case uspsPriorityMailExpress:
+		return uspsPriorityExpressInternational
+	default:
+		return uspsFirstClassInternational
+	}
+}
+
+func mapContentsTypeToUSPS(contentsType domain.ContentsType) string {
+	switch contentsType {
+	case domain.ContentsTypeGift:
+		return "GIFT"
+	case domain.ContentsTypeDocuments:
+		return "DOCUMENT"
+	case domain.ContentsTypeSample:
+		return "SAMPLE"
+	case domain.ContentsTypeReturn:
+		return "RETURN"
+	default:
+		return "MERCHANDISE"
+	}
+}
+
+func mapLabelFormatToUSPS(format string) (string, error) {
+	switch domain.NormalizeLabelFormat(format) {
+	case domain.LabelFormatPDF:
+		return "PDF", nil
+	case domain.LabelFormatZPL:
+		return "ZPL203DPI", nil
+	default:
+		return "", unsupportedLabelFormat("USPS", format)
+	}
+}
+
+// uspsTransitDays is the published service standard used to estimate delivery
+func uspsTransitDays(mailClass string) int {
+	switch mailClass {
+	case uspsPriorityMailExpress:
+		return 2
+	case uspsPriorityMail:
+		return 3
+	default:
+		return 5
+	}
+}
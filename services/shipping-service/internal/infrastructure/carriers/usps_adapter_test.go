@@ -0,0 +1,295 @@
+package carriers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+func testLabelRequest(format string) domain.LabelRequest {
+	return domain.LabelRequest{
+		ShipmentID: "SHP-001",
+		PackageInfo: domain.PackageInfo{
+			Weight:     2.5,
+			Dimensions: domain.Dimensions{Length: 30.48, Width: 20.32, Height: 10.16},
+		},
+		Shipper:     domain.Address{Name: "WMS Warehouse", Company: "WMS", Street1: "1 Dock Rd", City: "Memphis", State: "TN", PostalCode: "38118", Country: "US"},
+		Recipient:   domain.Address{Name: "Jane Doe", Street1: "42 Main St", Street2: "Apt 3", City: "Austin", State: "TX", PostalCode: "78701-1234", Country: "US", Phone: "5125550100"},
+		ServiceType: "ground",
+		LabelFormat: format,
+		Reference1:  "ORD-001",
+		Reference2:  "PKG-001",
+	}
+}
+
+func testRateRequest() domain.RateRequest {
+	request := testLabelRequest("")
+	return domain.RateRequest{Shipper: request.Shipper, Recipient: request.Recipient, PackageInfo: request.PackageInfo}
+}
+
+func requireCarrierError(t *testing.T, err error) *domain.CarrierError {
+	t.Helper()
+	var carrierErr *domain.CarrierError
+	require.True(t, errors.As(err, &carrierErr), "expected CarrierError, got %v", err)
+	return carrierErr
+}
+
+func newUSPSTestAdapter(t *testing.T, routes map[string]fixture) (*USPSAdapter, *fixtureServer) {
+	routes["POST /oauth2/v3/token"] = fixture{file: "usps/token.json"}
+	server := newFixtureServer(t, routes)
+	return NewUSPSAdapter("client-id", "client-secret", "1000012345", server.URL), server
+}
+
+func TestUSPSAdapter_GenerateLabel(t *testing.T) {
+	adapter, server := newUSPSTestAdapter(t, map[string]fixture{
+		"POST /labels/v3/label": {file: "usps/label.json"},
+	})
+
+	label, err := adapter.GenerateLabel(context.Background(), testLabelRequest("zpl"))
+	require.NoError(t, err)
+	assert.Equal(t, "9400111899223197428490", label.TrackingNumber)
+	assert.Equal(t, domain.LabelFormatZPL, label.LabelFormat)
+	assert.NotEmpty(t, label.LabelData)
+
+	// A second call reuses the cached token
+	_, err = adapter.GenerateLabel(context.Background(), testLabelRequest("pdf"))
+	require.NoError(t, err)
+	assert.Len(t, server.received("POST /oauth2/v3/token"), 1)
+
+	sent := server.received("POST /labels/v3/label")[0]
+	assert.Equal(t, "Bearer eyJraWQiOiJ1c3BzLXRlc3QiLCJhbGciOiJSUzI1NiJ9.test-token", sent.header.Get("Authorization"))
+
+	var body uspsLabelRequest
+	require.NoError(t, json.Unmarshal([]byte(sent.body), &body))
+	assert.Equal(t, "ZPL203DPI", body.ImageInfo.ImageType)
+	assert.Equal(t, uspsGroundAdvantage, body.PackageDescription.MailClass)
+	assert.Equal(t, "lb", body.PackageDescription.WeightUOM)
+	assert.InDelta(t, 5.51, body.PackageDescription.Weight, 0.001)
+	assert.InDelta(t, 12.0, body.PackageDescription.Length, 0.001)
+	assert.Equal(t, "78701", body.ToAddress.ZIPCode)
+	assert.Equal(t, "1234", body.ToAddress.ZIPPlus4)
+	assert.Equal(t, "Jane", body.ToAddress.FirstName)
+	assert.Equal(t, "Doe", body.ToAddress.LastName)
+	require.Len(t, body.PackageDescription.CustomerReference, 2)
+}
+
+func TestUSPSAdapter_GenerateReturnLabel(t *testing.T) {
+	adapter, _ := newUSPSTestAdapter(t, map[string]fixture{
+		"POST /labels/v3/return-label": {file: "usps/return_label.json"},
+	})
+
+	request := testLabelRequest("zpl")
+	request.IsReturn = true
+	label, err := adapter.GenerateLabel(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "9202090153540592867420", label.TrackingNumber)
+}
+
+func testCustomsDeclaration() *domain.CustomsDeclaration {
+	return &domain.CustomsDeclaration{
+		ContentsType: domain.ContentsTypeMerchandise,
+		Incoterm:     "DDP",
+		Currency:     "USD",
+		Items: []domain.CustomsItem{
+			{SKU: "SKU-1", Description: "Cotton t-shirt", HSCode: "610910", CountryOfOrigin: "BD", Quantity: 2, UnitValue: 15, Weight: 0.2},
+		},
+		InvoiceNumber: "INV-001",
+	}
+}
+
+func TestUSPSAdapter_GenerateInternationalLabel(t *testing.T) {
+	adapter, server := newUSPSTestAdapter(t, map[string]fixture{
+		"POST /international-labels/v3/international-label": {file: "usps/international_label.json"},
+	})
+
+	request := testLabelRequest("zpl")
+	request.ServiceType = "priority"
+	request.Recipient = domain.Address{Name: "Jean Tremblay", Street1: "100 Rue Sainte-Catherine", City: "Montreal", State: "QC", PostalCode: "H2X 1K4", Country: "ca"}
+	request.Customs = testCustomsDeclaration()
+	label, err := adapter.GenerateLabel(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "LZ123456785US", label.TrackingNumber)
+
+	var body uspsLabelRequest
+	require.NoError(t, json.Unmarshal([]byte(server.last("POST /international-labels/v3/international-label").body), &body))
+	assert.Equal(t, uspsPriorityMailInternational, body.PackageDescription.MailClass)
+	assert.Equal(t, "CA", body.ToAddress.CountryISOAlpha2Code)
+	assert.Equal(t, "H2X 1K4", body.ToAddress.PostalCode)
+	require.NotNil(t, body.CustomsForm)
+	assert.Equal(t, "MERCHANDISE", body.CustomsForm.ContentType)
+	require.Len(t, body.CustomsForm.Contents, 1)
+	assert.Equal(t, "610910", body.CustomsForm.Contents[0].HSTariffNumber)
+	assert.Equal(t, "BD", body.CustomsForm.Contents[0].CountryOfOrigin)
+	assert.InDelta(t, 30.0, body.CustomsForm.Contents[0].ItemTotalValue, 0.001)
+}
+
+func TestUSPSAdapter_UnsupportedLabelFormat(t *testing.T) {
+	adapter, _ := newUSPSTestAdapter(t, map[string]fixture{})
+
+	_, err := adapter.GenerateLabel(context.Background(), testLabelRequest("png"))
+	assert.ErrorIs(t, err, domain.ErrUnsupportedLabelFormat)
+	assert.False(t, requireCarrierError(t, err).Retryable)
+}
+
+func TestUSPSAdapter_GetRates(t *testing.T) {
+	adapter, server := newUSPSTestAdapter(t, map[string]fixture{
+		"POST /prices/v3/total-rates/search": {file: "usps/rates.json"},
+	})
+
+	rates, err := adapter.GetRates(context.Background(), testRateRequest())
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+
+	assert.Equal(t, uspsGroundAdvantage, rates[0].ServiceType)
+	assert.Equal(t, 8.47, rates[0].TotalCost)
+	assert.False(t, rates[0].IsGuaranteed)
+	assert.Equal(t, uspsPriorityMailExpress, rates[2].ServiceType)
+	assert.True(t, rates[2].IsGuaranteed)
+	assert.True(t, rates[0].EstimatedDelivery.After(rates[2].EstimatedDelivery))
+
+	var body uspsRateSearchRequest
+	require.NoError(t, json.Unmarshal([]byte(server.last("POST /prices/v3/total-rates/search").body), &body))
+	assert.Equal(t, "38118", body.OriginZIPCode)
+	assert.Equal(t, "78701", body.DestinationZIPCode)
+	assert.Len(t, body.MailClasses, 3)
+}
+
+func TestUSPSAdapter_TrackShipment(t *testing.T) {
+	adapter, server := newUSPSTestAdapter(t, map[string]fixture{
+		"GET /tracking/v3/tracking/9400111899223197428490": {file: "usps/tracking.json"},
+	})
+
+	info, err := adapter.TrackShipment(context.Background(), "9400111899223197428490")
+	require.NoError(t, err)
+	assert.Equal(t, "Delivered", info.Status)
+	assert.Equal(t, "AUSTIN, TX", info.CurrentLocation)
+	require.NotNil(t, info.ActualDelivery)
+	assert.Len(t, info.Events, 3)
+	assert.Equal(t, "DETAIL", server.last("GET /tracking/v3/tracking/9400111899223197428490").query["expand"][0])
+}
+
+func TestUSPSAdapter_ParseTrackingWebhook(t *testing.T) {
+	adapter, _ := newUSPSTestAdapter(t, map[string]fixture{})
+	payload, err := os.ReadFile(filepath.Join("testdata", "usps", "tracking.json"))
+	require.NoError(t, err)
+
+	updates, err := adapter.ParseTrackingWebhook(payload)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, "9400111899223197428490", updates[0].TrackingNumber)
+	assert.Equal(t, "Delivered", updates[0].Status)
+
+	_, err = adapter.ParseTrackingWebhook([]byte(`{"statusCategory": "In Transit"}`))
+	var carrierErr *domain.CarrierError
+	require.ErrorAs(t, err, &carrierErr)
+	assert.Equal(t, "INVALID_WEBHOOK", carrierErr.Code)
+}
+
+func TestUSPSAdapter_ValidateAddress(t *testing.T) {
+	adapter, server := newUSPSTestAdapter(t, map[string]fixture{
+		"GET /addresses/v3/address": {file: "usps/address.json"},
+	})
+
+	address := testLabelRequest("").Recipient
+	address.PostalCode = "78701"
+	result, err := adapter.ValidateAddress(context.Background(), address)
+	require.NoError(t, err)
+	assert.True(t, result.IsValid)
+	require.NotNil(t, result.SuggestedAddress)
+	assert.Equal(t, "78701-1234", result.SuggestedAddress.PostalCode)
+	assert.Equal(t, "AUSTIN", result.SuggestedAddress.City)
+	assert.Len(t, result.ValidationErrors, 1)
+	assert.Equal(t, "42 Main St", server.last("GET /addresses/v3/address").query["streetAddress"][0])
+}
+
+func TestUSPSAdapter_CreateManifest(t *testing.T) {
+	adapter, server := newUSPSTestAdapter(t, map[string]fixture{
+		"POST /scan-forms/v3/scan-form": {file: "usps/scan_form.json"},
+	})
+
+	shipments := []domain.Shipment{
+		{Shipper: testLabelRequest("").Shipper, Label: &domain.ShippingLabel{TrackingNumber: "9400111899223197428490"}},
+		{Shipper: testLabelRequest("").Shipper, Label: &domain.ShippingLabel{TrackingNumber: "9400111899223197428506"}},
+		{Shipper: testLabelRequest("").Shipper},
+	}
+	ctx := domain.WithIdempotencyKey(context.Background(), "manifest-MAN-USPS-1")
+	manifest, err := adapter.CreateManifest(ctx, shipments)
+	require.NoError(t, err)
+	assert.Equal(t, "9475711899223197428495", manifest.ManifestID)
+	assert.Equal(t, 2, manifest.ShipmentCount)
+
+	request := server.last("POST /scan-forms/v3/scan-form")
+	var body uspsScanFormRequest
+	require.NoError(t, json.Unmarshal([]byte(request.body), &body))
+	assert.Len(t, body.TrackingNumbers, 2)
+	assert.Equal(t, "38118", body.FromAddress.ZIPCode)
+	assert.Equal(t, "manifest-MAN-USPS-1", request.header.Get("Idempotency-Key"))
+}
+
+func TestUSPSAdapter_CancelShipment(t *testing.T) {
+	adapter, _ := newUSPSTestAdapter(t, map[string]fixture{
+		"DELETE /labels/v3/label/9400111899223197428490": {file: "usps/cancel.json"},
+	})
+
+	assert.NoError(t, adapter.CancelShipment(context.Background(), "9400111899223197428490"))
+}
+
+func TestUSPSAdapter_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name      string
+		fixture   fixture
+		code      string
+		retryable bool
+	}{
+		{"validation error", fixture{status: 400, file: "usps/error_400.json"}, "010160", false},
+		{"expired token", fixture{status: 401, file: "usps/error_401.json"}, "AUTHENTICATION_FAILED", true},
+		{"service outage", fixture{status: 503, file: "usps/error_503.json"}, "CARRIER_UNAVAILABLE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter, _ := newUSPSTestAdapter(t, map[string]fixture{
+				"POST /labels/v3/label": tt.fixture,
+			})
+
+			_, err := adapter.GenerateLabel(context.Background(), testLabelRequest("pdf"))
+			carrierErr := requireCarrierError(t, err)
+			assert.Equal(t, tt.code, carrierErr.Code)
+			assert.Equal(t, tt.retryable, carrierErr.Retryable)
+			assert.Contains(t, carrierErr.Message, "USPS: ")
+		})
+	}
+}
+
+func TestUSPSAdapter_RefreshesTokenAfterUnauthorized(t *testing.T) {
+	adapter, server := newUSPSTestAdapter(t, map[string]fixture{
+		"POST /labels/v3/label": {status: 401, file: "usps/error_401.json"},
+	})
+
+	_, err := adapter.GenerateLabel(context.Background(), testLabelRequest("pdf"))
+	require.Error(t, err)
+
+	server.setRoute("POST /labels/v3/label", fixture{file: "usps/label.json"})
+	_, err = adapter.GenerateLabel(context.Background(), testLabelRequest("pdf"))
+	require.NoError(t, err)
+	assert.Len(t, server.received("POST /oauth2/v3/token"), 2)
+}
+
+func TestUSPSAdapter_CarrierUnreachable(t *testing.T) {
+	server := newFixtureServer(t, map[string]fixture{})
+	adapter := NewUSPSAdapter("client-id", "client-secret", "1000012345", server.URL)
+	server.Close()
+
+	_, err := adapter.GetRates(context.Background(), testRateRequest())
+	carrierErr := requireCarrierError(t, err)
+	assert.Equal(t, "CARRIER_UNAVAILABLE", carrierErr.Code)
+	assert.True(t, carrierErr.Retryable)
+}
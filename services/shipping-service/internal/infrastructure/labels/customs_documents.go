@@ -0,0 +1,300 @@
+package labels
+
+import (
+	"fmt"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// invoiceRowsPerPage is how many item rows fit below the commercial invoice header
+const invoiceRowsPerPage = 24
+
+// cn22MaxRows is how many item rows fit on a 4x6 CN22; longer declarations print on a CN23
+const cn22MaxRows = 5
+
+// customsContentsBoxes are the contents categories ticked on CN22 and CN23 forms, in UPU order
+var customsContentsBoxes = []struct {
+	label    string
+	contents domain.ContentsType
+}{
+	{"GIFT", domain.ContentsTypeGift},
+	{"DOCUMENTS", domain.ContentsTypeDocuments},
+	{"COMMERCIAL SAMPLE", domain.ContentsTypeSample},
+	{"RETURNED GOODS", domain.ContentsTypeReturn},
+	{"SALE OF GOODS", domain.ContentsTypeMerchandise},
+}
+
+// RenderCommercialInvoice produces the commercial invoice for an international shipment,
+// with one row per declared item and the totals on the last page
+func (r *DocumentRenderer) RenderCommercialInvoice(shipment *domain.Shipment) ([]byte, error) {
+	declaration := shipment.Customs
+	if declaration == nil {
+		return nil, domain.ErrNoCustomsDeclaration
+	}
+
+	pageCount := (len(declaration.Items) + invoiceRowsPerPage - 1) / invoiceRowsPerPage
+	if pageCount == 0 {
+		pageCount = 1
+	}
+
+	pages := make([]*pdfCanvas, 0, pageCount)
+	for page := 0; page < pageCount; page++ {
+		c := &pdfCanvas{}
+		contentWidth := letterPageWidth - 2*letterMargin
+		y := letterPageHeight - letterMargin
+
+		y -= 20
+		c.text(fontBold, 18, letterMargin, y, "COMMERCIAL INVOICE")
+		c.text(fontRegular, 9, letterPageWidth-letterMargin-80, y, fmt.Sprintf("PAGE %d OF %d", page+1, pageCount))
+		for _, line := range invoiceHeaderLines(shipment) {
+			y -= 13
+			c.text(fontRegular, 9, letterMargin, y, line)
+		}
+		y -= 8
+		c.fillRect(letterMargin, y, contentWidth, 1)
+
+		// Exporter and consignee side by side
+		columnX := letterMargin + contentWidth/2
+		top := y - 14
+		c.text(fontBold, 10, letterMargin, top, "EXPORTER:")
+		c.text(fontBold, 10, columnX, top, "CONSIGNEE:")
+		left := top
+		for _, line := range append(addressLines(shipment.Shipper), "TAX ID: "+orNone(declaration.ExporterID)) {
+			left -= 13
+			c.text(fontRegular, 10, letterMargin+10, left, line)
+		}
+		right := top
+		for _, line := range append(addressLines(shipment.Recipient), "TAX ID: "+orNone(declaration.ImporterTaxID)) {
+			right -= 13
+			c.text(fontRegular, 10, columnX+10, right, line)
+		}
+		y = min(left, right) - 12
+		c.fillRect(letterMargin, y, contentWidth, 1)
+
+		y -= 14
+		c.text(fontBold, 8, letterMargin, y, "#")
+		c.text(fontBold, 8, letterMargin+20, y, "DESCRIPTION")
+		c.text(fontBold, 8, letterMargin+230, y, "HS CODE")
+		c.text(fontBold, 8, letterMargin+300, y, "ORIGIN")
+		c.text(fontBold, 8, letterMargin+345, y, "QTY")
+		c.text(fontBold, 8, letterMargin+385, y, "UNIT VALUE")
+		c.text(fontBold, 8, letterMargin+465, y, "TOTAL VALUE")
+		y -= 4
+
+		end := min((page+1)*invoiceRowsPerPage, len(declaration.Items))
+		for i := page * invoiceRowsPerPage; i < end; i++ {
+			item := declaration.Items[i]
+			y -= 13
+			c.text(fontRegular, 8, letterMargin, y, fmt.Sprintf("%d", i+1))
+			c.text(fontRegular, 8, letterMargin+20, y, item.Description)
+			c.text(fontRegular, 8, letterMargin+230, y, item.HSCode)
+			c.text(fontRegular, 8, letterMargin+300, y, item.CountryOfOrigin)
+			c.text(fontRegular, 8, letterMargin+345, y, fmt.Sprintf("%d", item.Quantity))
+			c.text(fontRegular, 8, letterMargin+385, y, fmt.Sprintf("%.2f", item.UnitValue))
+			c.text(fontRegular, 8, letterMargin+465, y, fmt.Sprintf("%.2f", item.Value()))
+		}
+
+		if page == pageCount-1 {
+			y -= 10
+			c.fillRect(letterMargin, y, contentWidth, 1)
+			y -= 14
+			c.text(fontBold, 10, letterMargin+300, y, fmt.Sprintf("TOTAL NET WEIGHT: %.2f KG", declaration.TotalWeight()))
+			y -= 14
+			c.text(fontBold, 10, letterMargin+300, y, fmt.Sprintf("TOTAL VALUE: %.2f %s", declaration.TotalValue(), declaration.Currency))
+
+			y -= 40
+			c.text(fontRegular, 8, letterMargin, y, "I declare that the information on this invoice is true and correct and that the contents of this shipment are as stated above.")
+			y -= 40
+			c.fillRect(letterMargin, y, contentWidth/2-20, 0.8)
+			y -= 12
+			c.text(fontRegular, 8, letterMargin, y, "EXPORTER SIGNATURE / DATE")
+		}
+
+		pages = append(pages, c)
+	}
+
+	return buildPDF(letterPageWidth, letterPageHeight, pages), nil
+}
+
+// RenderCustomsForm produces the postal customs declaration: a 4x6 CN22 for low-value,
+// light parcels or a letter-size CN23 dispatch note otherwise
+func (r *DocumentRenderer) RenderCustomsForm(shipment *domain.Shipment) ([]byte, error) {
+	declaration := shipment.Customs
+	if declaration == nil {
+		return nil, domain.ErrNoCustomsDeclaration
+	}
+
+	if declaration.FormType() == domain.CustomsFormCN22 && len(declaration.Items) <= cn22MaxRows {
+		return renderCN22(shipment), nil
+	}
+	return renderCN23(shipment), nil
+}
+
+// renderCN22 draws the CN22 customs declaration on a 4x6 page
+func renderCN22(shipment *domain.Shipment) []byte {
+	declaration := shipment.Customs
+	c := &pdfCanvas{}
+	contentWidth := pdfPageWidth - 2*pdfMargin
+	y := pdfPageHeight - pdfMargin
+
+	y -= 16
+	c.text(fontBold, 14, pdfMargin, y, "CUSTOMS DECLARATION")
+	c.text(fontBold, 14, pdfPageWidth-pdfMargin-40, y, string(domain.CustomsFormCN22))
+	y -= 12
+	c.text(fontRegular, 7, pdfMargin, y, "May be opened officially")
+	y -= 6
+	c.fillRect(pdfMargin, y, contentWidth, 1)
+
+	y = drawContentsBoxes(c, declaration.ContentsType, pdfMargin, y, 7, 2)
+	y -= 6
+	c.fillRect(pdfMargin, y, contentWidth, 1)
+
+	y -= 12
+	c.text(fontBold, 7, pdfMargin, y, "DETAILED DESCRIPTION")
+	c.text(fontBold, 7, pdfMargin+120, y, "QTY")
+	c.text(fontBold, 7, pdfMargin+145, y, "WEIGHT (KG)")
+	c.text(fontBold, 7, pdfMargin+205, y, "VALUE")
+	for _, item := range declaration.Items {
+		y -= 11
+		c.text(fontRegular, 7, pdfMargin, y, item.Description)
+		c.text(fontRegular, 7, pdfMargin+120, y, fmt.Sprintf("%d", item.Quantity))
+		c.text(fontRegular, 7, pdfMargin+145, y, fmt.Sprintf("%.3f", item.Weight*float64(item.Quantity)))
+		c.text(fontRegular, 7, pdfMargin+205, y, fmt.Sprintf("%.2f %s", item.Value(), declaration.Currency))
+		y -= 9
+		c.text(fontRegular, 6, pdfMargin+8, y, "HS "+item.HSCode+"  ORIGIN "+item.CountryOfOrigin)
+	}
+	y -= 8
+	c.fillRect(pdfMargin, y, contentWidth, 1)
+
+	y -= 12
+	c.text(fontBold, 8, pdfMargin, y, fmt.Sprintf("TOTAL WEIGHT: %.3f KG", declaration.TotalWeight()))
+	y -= 12
+	c.text(fontBold, 8, pdfMargin, y, fmt.Sprintf("TOTAL VALUE: %.2f %s", declaration.TotalValue(), declaration.Currency))
+
+	y -= 18
+	c.text(fontRegular, 6, pdfMargin, y, "I certify that the particulars given in this declaration are correct and that")
+	y -= 8
+	c.text(fontRegular, 6, pdfMargin, y, "this item does not contain any dangerous article prohibited by postal regulations.")
+	y -= 24
+	c.fillRect(pdfMargin, y, contentWidth*0.6, 0.8)
+	y -= 9
+	c.text(fontRegular, 6, pdfMargin, y, "DATE AND SENDER'S SIGNATURE")
+
+	return c.document()
+}
+
+// renderCN23 draws the CN23 dispatch note on a letter page
+func renderCN23(shipment *domain.Shipment) []byte {
+	declaration := shipment.Customs
+	c := &pdfCanvas{}
+	contentWidth := letterPageWidth - 2*letterMargin
+	y := letterPageHeight - letterMargin
+
+	y -= 20
+	c.text(fontBold, 18, letterMargin, y, "CUSTOMS DECLARATION")
+	c.text(fontBold, 18, letterPageWidth-letterMargin-60, y, string(domain.CustomsFormCN23))
+	y -= 14
+	c.text(fontRegular, 9, letterMargin, y, "SHIPMENT: "+shipment.ShipmentID+"  ORDER: "+shipment.OrderID+"  INVOICE: "+orNone(declaration.InvoiceNumber))
+	y -= 8
+	c.fillRect(letterMargin, y, contentWidth, 1)
+
+	columnX := letterMargin + contentWidth/2
+	top := y - 14
+	c.text(fontBold, 10, letterMargin, top, "FROM:")
+	c.text(fontBold, 10, columnX, top, "TO:")
+	left := top
+	for _, line := range addressLines(shipment.Shipper) {
+		left -= 13
+		c.text(fontRegular, 10, letterMargin+10, left, line)
+	}
+	right := top
+	for _, line := range append(addressLines(shipment.Recipient), "IMPORTER REFERENCE: "+orNone(declaration.ImporterTaxID)) {
+		right -= 13
+		c.text(fontRegular, 10, columnX+10, right, line)
+	}
+	y = min(left, right) - 12
+	c.fillRect(letterMargin, y, contentWidth, 1)
+
+	y -= 14
+	c.text(fontBold, 8, letterMargin, y, "DETAILED DESCRIPTION OF CONTENTS")
+	c.text(fontBold, 8, letterMargin+230, y, "QTY")
+	c.text(fontBold, 8, letterMargin+270, y, "NET WEIGHT (KG)")
+	c.text(fontBold, 8, letterMargin+350, y, "VALUE")
+	c.text(fontBold, 8, letterMargin+420, y, "HS CODE")
+	c.text(fontBold, 8, letterMargin+490, y, "ORIGIN")
+	y -= 4
+	for _, item := range declaration.Items {
+		y -= 13
+		c.text(fontRegular, 8, letterMargin, y, item.Description)
+		c.text(fontRegular, 8, letterMargin+230, y, fmt.Sprintf("%d", item.Quantity))
+		c.text(fontRegular, 8, letterMargin+270, y, fmt.Sprintf("%.3f", item.Weight*float64(item.Quantity)))
+		c.text(fontRegular, 8, letterMargin+350, y, fmt.Sprintf("%.2f %s", item.Value(), declaration.Currency))
+		c.text(fontRegular, 8, letterMargin+420, y, item.HSCode)
+		c.text(fontRegular, 8, letterMargin+490, y, item.CountryOfOrigin)
+	}
+	y -= 10
+	c.fillRect(letterMargin, y, contentWidth, 1)
+
+	y -= 14
+	c.text(fontBold, 10, letterMargin, y, fmt.Sprintf("TOTAL GROSS WEIGHT: %.2f KG", shipment.Package.Weight))
+	c.text(fontBold, 10, columnX, y, fmt.Sprintf("TOTAL VALUE: %.2f %s", declaration.TotalValue(), declaration.Currency))
+	y -= 8
+	y = drawContentsBoxes(c, declaration.ContentsType, letterMargin, y, 9, 3)
+	y -= 14
+	c.text(fontRegular, 9, letterMargin, y, "TERMS OF DELIVERY: "+string(declaration.Incoterm))
+
+	y -= 40
+	c.text(fontRegular, 8, letterMargin, y, "I certify that the particulars given in this customs declaration are correct and that this item does not contain")
+	y -= 10
+	c.text(fontRegular, 8, letterMargin, y, "any dangerous article or articles prohibited by legislation or by postal or customs regulations.")
+	y -= 40
+	c.fillRect(letterMargin, y, contentWidth/2-20, 0.8)
+	y -= 12
+	c.text(fontRegular, 8, letterMargin, y, "DATE AND SENDER'S SIGNATURE")
+
+	return buildPDF(letterPageWidth, letterPageHeight, []*pdfCanvas{c})
+}
+
+// drawContentsBoxes draws the contents category checkboxes, ticking the declared one,
+// and returns the y position below them
+func drawContentsBoxes(c *pdfCanvas, contents domain.ContentsType, x, y, size float64, columns int) float64 {
+	columnWidth := 130.0
+	if columns == 2 {
+		columnWidth = 125
+	}
+	for i, box := range customsContentsBoxes {
+		if i%columns == 0 {
+			y -= size + 5
+		}
+		bx := x + float64(i%columns)*columnWidth
+		c.strokeRect(bx, y, size, size)
+		if box.contents == contents {
+			c.text(fontBold, size, bx+1.5, y+1, "X")
+		}
+		c.text(fontRegular, size, bx+size+4, y+1, box.label)
+	}
+	return y
+}
+
+func invoiceHeaderLines(shipment *domain.Shipment) []string {
+	declaration := shipment.Customs
+	invoiceNumber := declaration.InvoiceNumber
+	if invoiceNumber == "" {
+		invoiceNumber = shipment.OrderID
+	}
+	lines := []string{
+		"INVOICE #: " + invoiceNumber,
+		"DATE: " + declaration.DeclaredAt.Format("2006-01-02"),
+		"SHIPMENT: " + shipment.ShipmentID + "  ORDER: " + shipment.OrderID,
+		fmt.Sprintf("TERMS OF SALE: %s  CURRENCY: %s  REASON FOR EXPORT: %s", declaration.Incoterm, declaration.Currency, declaration.ContentsType),
+	}
+	if shipment.Label != nil {
+		lines = append(lines, fmt.Sprintf("CARRIER: %s  TRACKING #: %s", shipment.Carrier.Code, shipment.Label.TrackingNumber))
+	}
+	if declaration.Incoterm.ShipperPaysDuties() {
+		lines = append(lines, "DUTIES AND TAXES: PAID BY SHIPPER")
+	} else {
+		lines = append(lines, "DUTIES AND TAXES: PAID BY RECEIVER")
+	}
+	return lines
+}
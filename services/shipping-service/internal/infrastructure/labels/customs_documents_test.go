@@ -0,0 +1,102 @@
+package labels
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/shared/pkg/customs"
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+func testInternationalShipment(items int, unitValue float64) *domain.Shipment {
+	shipment := &domain.Shipment{
+		ShipmentID: "SHP-001",
+		OrderID:    "ORD-001",
+		Shipper:    domain.Address{Name: "WMS Warehouse", Street1: "1 Dock Rd", City: "Memphis", State: "TN", PostalCode: "38118", Country: "US"},
+		Recipient:  domain.Address{Name: "Jane Doe", Street1: "10 Queen St W", City: "Toronto", State: "ON", PostalCode: "M5H 2N2", Country: "CA"},
+		Package:    domain.PackageInfo{Weight: 1.5},
+		Customs: &domain.CustomsDeclaration{
+			ContentsType:  domain.ContentsTypeMerchandise,
+			Incoterm:      customs.IncotermDDP,
+			Currency:      "USD",
+			InvoiceNumber: "INV-001",
+			DeclaredAt:    time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC),
+		},
+	}
+	for i := 0; i < items; i++ {
+		shipment.Customs.Items = append(shipment.Customs.Items, domain.CustomsItem{
+			SKU:             fmt.Sprintf("SKU-%03d", i+1),
+			Description:     "Cotton T-shirt",
+			HSCode:          "610910",
+			CountryOfOrigin: "BD",
+			Quantity:        1,
+			UnitValue:       unitValue,
+			Weight:          0.2,
+		})
+	}
+	return shipment
+}
+
+func TestDocumentRenderer_CommercialInvoice(t *testing.T) {
+	out, err := NewDocumentRenderer().RenderCommercialInvoice(testInternationalShipment(2, 15))
+	require.NoError(t, err)
+
+	pdf := string(out)
+	assert.Contains(t, pdf, "/MediaBox [0 0 612 792]")
+	assert.Contains(t, pdf, "(COMMERCIAL INVOICE) Tj")
+	assert.Contains(t, pdf, "(INVOICE #: INV-001) Tj")
+	assert.Contains(t, pdf, "(DUTIES AND TAXES: PAID BY SHIPPER) Tj")
+	assert.Contains(t, pdf, "(10 QUEEN ST W) Tj")
+	assert.Contains(t, pdf, "(610910) Tj")
+	assert.Contains(t, pdf, "(TOTAL VALUE: 30.00 USD) Tj")
+
+	shipment := testInternationalShipment(1, 15)
+	shipment.Customs = nil
+	_, err = NewDocumentRenderer().RenderCommercialInvoice(shipment)
+	assert.ErrorIs(t, err, domain.ErrNoCustomsDeclaration)
+}
+
+func TestDocumentRenderer_CommercialInvoicePaginates(t *testing.T) {
+	out, err := NewDocumentRenderer().RenderCommercialInvoice(testInternationalShipment(invoiceRowsPerPage+1, 1))
+	require.NoError(t, err)
+
+	pdf := string(out)
+	assert.Contains(t, pdf, "/Count 2")
+	assert.Contains(t, pdf, "(PAGE 2 OF 2) Tj")
+	assert.Equal(t, 1, strings.Count(pdf, "(TOTAL VALUE:"))
+}
+
+func TestDocumentRenderer_CustomsForm(t *testing.T) {
+	tests := []struct {
+		name     string
+		shipment *domain.Shipment
+		form     string
+		mediaBox string
+	}{
+		{name: "low value parcel prints a CN22", shipment: testInternationalShipment(2, 15), form: "(CN22) Tj", mediaBox: "/MediaBox [0 0 288 432]"},
+		{name: "high value parcel prints a CN23", shipment: testInternationalShipment(2, 300), form: "(CN23) Tj", mediaBox: "/MediaBox [0 0 612 792]"},
+		{name: "too many lines for a CN22", shipment: testInternationalShipment(cn22MaxRows+1, 1), form: "(CN23) Tj", mediaBox: "/MediaBox [0 0 612 792]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := NewDocumentRenderer().RenderCustomsForm(tt.shipment)
+			require.NoError(t, err)
+
+			pdf := string(out)
+			assert.Contains(t, pdf, tt.form)
+			assert.Contains(t, pdf, tt.mediaBox)
+			assert.Contains(t, pdf, "(SALE OF GOODS) Tj")
+		})
+	}
+
+	shipment := testInternationalShipment(1, 15)
+	shipment.Customs = nil
+	_, err := NewDocumentRenderer().RenderCustomsForm(shipment)
+	assert.ErrorIs(t, err, domain.ErrNoCustomsDeclaration)
+}
@@ -0,0 +1,188 @@
+package labels
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// US letter page in PDF points
+const (
+	letterPageWidth  = 612.0
+	letterPageHeight = 792.0
+	letterMargin     = 36.0
+)
+
+// summaryRowsPerPage is how many package rows fit below the manifest summary header
+const summaryRowsPerPage = 42
+
+// DocumentRenderer renders closed manifest and customs paperwork as PDFs
+type DocumentRenderer struct{}
+
+// NewDocumentRenderer creates a new manifest DocumentRenderer
+func NewDocumentRenderer() *DocumentRenderer {
+	return &DocumentRenderer{}
+}
+
+// RenderBillOfLading produces a single-page straight bill of lading for the manifest
+func (r *DocumentRenderer) RenderBillOfLading(manifest *domain.OutboundManifest) ([]byte, error) {
+	bol := manifest.BillOfLading
+	if bol == nil {
+		return nil, domain.ErrNoBillOfLading
+	}
+
+	c := &pdfCanvas{}
+	contentWidth := letterPageWidth - 2*letterMargin
+	y := letterPageHeight - letterMargin
+
+	y -= 20
+	c.text(fontBold, 18, letterMargin, y, "STRAIGHT BILL OF LADING")
+	c.text(fontRegular, 9, letterPageWidth-letterMargin-150, y+6, "DATE: "+bol.IssuedAt.Format("2006-01-02"))
+	c.text(fontBold, 9, letterPageWidth-letterMargin-150, y-6, "BOL #: "+bol.BOLNumber)
+
+	widths, err := encodeCode128(bol.BOLNumber)
+	if err != nil {
+		return nil, err
+	}
+	barHeight := 40.0
+	y -= 14 + barHeight
+	c.barcode(widths, letterMargin, y, contentWidth/2, barHeight)
+	y -= 10
+	c.fillRect(letterMargin, y, contentWidth, 1)
+
+	// Ship from and carrier side by side
+	columnX := letterMargin + contentWidth/2
+	top := y - 14
+	c.text(fontBold, 10, letterMargin, top, "SHIP FROM:")
+	c.text(fontBold, 10, columnX, top, "CARRIER:")
+	left := top
+	for _, line := range addressLines(bol.ShipFrom) {
+		left -= 13
+		c.text(fontRegular, 10, letterMargin+10, left, line)
+	}
+	right := top
+	for _, line := range []string{
+		bol.CarrierName + " (" + bol.CarrierCode + ")",
+		"CARRIER MANIFEST: " + orNone(bol.CarrierManifestID),
+		"WMS MANIFEST: " + manifest.ManifestID,
+		"TRAILER: " + orNone(bol.TrailerID),
+		"SCHEDULED PICKUP: " + formatDocumentTime(bol.PickupAt),
+	} {
+		right -= 13
+		c.text(fontRegular, 10, columnX+10, right, line)
+	}
+	y = min(left, right) - 12
+	c.fillRect(letterMargin, y, contentWidth, 1)
+
+	// Shipment totals
+	y -= 18
+	c.text(fontBold, 10, letterMargin, y, "HANDLING UNITS")
+	c.text(fontBold, 10, letterMargin+140, y, "PACKAGE TYPE")
+	c.text(fontBold, 10, letterMargin+280, y, "WEIGHT (KG)")
+	c.text(fontBold, 10, letterMargin+400, y, "DESCRIPTION")
+	y -= 16
+	c.text(fontRegular, 10, letterMargin, y, fmt.Sprintf("%d", bol.PackageCount))
+	c.text(fontRegular, 10, letterMargin+140, y, "PARCEL")
+	c.text(fontRegular, 10, letterMargin+280, y, fmt.Sprintf("%.2f", bol.TotalWeight))
+	c.text(fontRegular, 10, letterMargin+400, y, "SMALL PARCEL SHIPMENTS")
+	y -= 10
+	c.fillRect(letterMargin, y, contentWidth, 1)
+
+	// Signatures
+	y -= 60
+	c.fillRect(letterMargin, y, contentWidth/2-20, 0.8)
+	c.fillRect(columnX, y, contentWidth/2, 0.8)
+	y -= 12
+	c.text(fontRegular, 8, letterMargin, y, "SHIPPER SIGNATURE / DATE")
+	c.text(fontRegular, 8, columnX, y, "CARRIER SIGNATURE / PICKUP DATE")
+	y -= 16
+	c.text(fontRegular, 8, letterMargin, y, "Received the property described above in apparent good order, except as noted.")
+
+	return buildPDF(letterPageWidth, letterPageHeight, []*pdfCanvas{c}), nil
+}
+
+// RenderManifestSummary produces the manifest summary: header totals and one row per package
+func (r *DocumentRenderer) RenderManifestSummary(manifest *domain.OutboundManifest) ([]byte, error) {
+	pageCount := (len(manifest.Packages) + summaryRowsPerPage - 1) / summaryRowsPerPage
+	if pageCount == 0 {
+		pageCount = 1
+	}
+
+	pages := make([]*pdfCanvas, 0, pageCount)
+	for page := 0; page < pageCount; page++ {
+		c := &pdfCanvas{}
+		contentWidth := letterPageWidth - 2*letterMargin
+		y := letterPageHeight - letterMargin
+
+		y -= 18
+		c.text(fontBold, 16, letterMargin, y, "MANIFEST SUMMARY")
+		c.text(fontRegular, 9, letterPageWidth-letterMargin-80, y, fmt.Sprintf("PAGE %d OF %d", page+1, pageCount))
+
+		for _, line := range summaryHeaderLines(manifest) {
+			y -= 13
+			c.text(fontRegular, 9, letterMargin, y, line)
+		}
+		y -= 8
+		c.fillRect(letterMargin, y, contentWidth, 1)
+
+		y -= 14
+		c.text(fontBold, 9, letterMargin, y, "#")
+		c.text(fontBold, 9, letterMargin+30, y, "TRACKING NUMBER")
+		c.text(fontBold, 9, letterMargin+200, y, "PACKAGE")
+		c.text(fontBold, 9, letterMargin+330, y, "ORDER")
+		c.text(fontBold, 9, letterMargin+460, y, "WEIGHT (KG)")
+		y -= 4
+
+		end := (page + 1) * summaryRowsPerPage
+		if end > len(manifest.Packages) {
+			end = len(manifest.Packages)
+		}
+		for i := page * summaryRowsPerPage; i < end; i++ {
+			pkg := manifest.Packages[i]
+			y -= 13
+			c.text(fontRegular, 9, letterMargin, y, fmt.Sprintf("%d", i+1))
+			c.text(fontRegular, 9, letterMargin+30, y, pkg.TrackingNumber)
+			c.text(fontRegular, 9, letterMargin+200, y, pkg.PackageID)
+			c.text(fontRegular, 9, letterMargin+330, y, pkg.OrderID)
+			c.text(fontRegular, 9, letterMargin+460, y, fmt.Sprintf("%.2f", pkg.Weight))
+		}
+
+		pages = append(pages, c)
+	}
+
+	return buildPDF(letterPageWidth, letterPageHeight, pages), nil
+}
+
+func summaryHeaderLines(m *domain.OutboundManifest) []string {
+	lines := []string{
+		"MANIFEST: " + m.ManifestID,
+		fmt.Sprintf("CARRIER: %s (%s)  SERVICE: %s", m.CarrierName, m.CarrierID, orNone(m.ServiceType)),
+		"CARRIER MANIFEST: " + orNone(m.CarrierManifestID),
+		fmt.Sprintf("PACKAGES: %d  TOTAL WEIGHT: %.2f KG", m.TotalPackages, m.TotalWeight),
+	}
+	if m.BillOfLading != nil {
+		lines = append(lines, "BOL #: "+m.BillOfLading.BOLNumber)
+	}
+	if m.CutoffAt != nil {
+		lines = append(lines, "CUTOFF: "+formatDocumentTime(*m.CutoffAt))
+	}
+	if m.ScheduledPickup != nil {
+		lines = append(lines, "SCHEDULED PICKUP: "+formatDocumentTime(*m.ScheduledPickup))
+	}
+	return lines
+}
+
+func formatDocumentTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format("2006-01-02 15:04 MST")
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,70 @@
+package labels
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+func testClosedManifest(packages int) *domain.OutboundManifest {
+	pickup := time.Date(2026, 10, 12, 23, 30, 0, 0, time.UTC)
+	manifest := domain.NewOutboundManifest("MAN-UPS-ab12cd34", "UPS", "UPS", "Ground")
+	for i := 0; i < packages; i++ {
+		manifest.Packages = append(manifest.Packages, domain.ManifestPackage{
+			PackageID:      fmt.Sprintf("PKG-%03d", i+1),
+			OrderID:        fmt.Sprintf("ORD-%03d", i+1),
+			TrackingNumber: fmt.Sprintf("1Z999AA1%010d", i+1),
+			Weight:         1.25,
+		})
+	}
+	manifest.TotalPackages = packages
+	manifest.TotalWeight = 1.25 * float64(packages)
+	manifest.Status = domain.ManifestStatusClosed
+	manifest.ScheduledPickup = &pickup
+	manifest.BillOfLading = &domain.BillOfLading{
+		BOLNumber:         "BOL-UPS-20261012-AB12CD34",
+		CarrierCode:       "UPS",
+		CarrierName:       "UPS",
+		CarrierManifestID: "UPS-EOD-1",
+		ShipFrom:          domain.Address{Name: "WMS Warehouse", Street1: "1 Dock Rd", City: "Memphis", State: "TN", PostalCode: "38118", Country: "US"},
+		PackageCount:      packages,
+		TotalWeight:       manifest.TotalWeight,
+		PickupAt:          pickup,
+		IssuedAt:          pickup.Add(-90 * time.Minute),
+	}
+	return manifest
+}
+
+func TestDocumentRenderer_BillOfLading(t *testing.T) {
+	out, err := NewDocumentRenderer().RenderBillOfLading(testClosedManifest(3))
+	require.NoError(t, err)
+
+	pdf := string(out)
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	assert.Contains(t, pdf, "/MediaBox [0 0 612 792]")
+	assert.Contains(t, pdf, "(BOL #: BOL-UPS-20261012-AB12CD34) Tj")
+	assert.Contains(t, pdf, "(CARRIER MANIFEST: UPS-EOD-1) Tj")
+	assert.Contains(t, pdf, "(1 DOCK RD) Tj")
+
+	manifest := testClosedManifest(1)
+	manifest.BillOfLading = nil
+	_, err = NewDocumentRenderer().RenderBillOfLading(manifest)
+	assert.ErrorIs(t, err, domain.ErrNoBillOfLading)
+}
+
+func TestDocumentRenderer_ManifestSummaryPaginates(t *testing.T) {
+	out, err := NewDocumentRenderer().RenderManifestSummary(testClosedManifest(summaryRowsPerPage + 5))
+	require.NoError(t, err)
+
+	pdf := string(out)
+	assert.Contains(t, pdf, "/Count 2")
+	assert.Contains(t, pdf, "(PAGE 2 OF 2) Tj")
+	assert.Contains(t, pdf, fmt.Sprintf("(PKG-%03d) Tj", summaryRowsPerPage+5))
+	assert.Equal(t, 2, strings.Count(pdf, "/Type /Page /Parent"))
+}
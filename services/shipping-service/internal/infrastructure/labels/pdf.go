@@ -0,0 +1,193 @@
+package labels
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// 4x6 inch page in PDF points
+const (
+	pdfPageWidth  = 288.0
+	pdfPageHeight = 432.0
+	pdfMargin     = 12.0
+)
+
+const (
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+// renderPDF produces a single-page 4x6 PDF label using the standard Helvetica fonts
+func renderPDF(doc domain.LabelDocument) ([]byte, error) {
+	c := &pdfCanvas{}
+	contentWidth := pdfPageWidth - 2*pdfMargin
+	y := pdfPageHeight - pdfMargin
+
+	if doc.IsReturn {
+		c.fillRect(pdfMargin, y-22, contentWidth, 22)
+		c.whiteText(fontBold, 16, pdfMargin+contentWidth/2-55, y-17, "RETURN LABEL")
+		y -= 30
+	}
+
+	// Ship from
+	y -= 9
+	c.text(fontBold, 8, pdfMargin, y, "FROM:")
+	for _, line := range addressLines(doc.Shipper) {
+		y -= 9
+		c.text(fontRegular, 8, pdfMargin, y, line)
+	}
+	y -= 6
+	c.fillRect(pdfMargin, y, contentWidth, 1)
+
+	// Ship to
+	y -= 13
+	c.text(fontBold, 10, pdfMargin, y, "SHIP TO:")
+	for _, line := range addressLines(doc.Recipient) {
+		y -= 14
+		c.text(fontBold, 12, pdfMargin+10, y, line)
+	}
+	y -= 8
+	c.fillRect(pdfMargin, y, contentWidth, 1)
+
+	// Routing block: 2D symbol placeholder on the left, carrier and service on the right
+	symbolSize := 72.0
+	y -= 6 + symbolSize
+	c.strokeRect(pdfMargin, y, symbolSize, symbolSize)
+	symbolName := "PDF417"
+	if doc.CarrierCode == "UPS" {
+		symbolName = "MAXICODE"
+	}
+	c.text(fontRegular, 7, pdfMargin+6, y+symbolSize/2-2, symbolName)
+	c.text(fontBold, 22, 140, y+symbolSize-22, doc.CarrierCode)
+	c.text(fontBold, 11, 140, y+symbolSize-40, strings.ToUpper(doc.ServiceType))
+	c.text(fontRegular, 8, 140, y+symbolSize-56, weightLine(doc.Package))
+	y -= 6
+	c.fillRect(pdfMargin, y, contentWidth, 1)
+
+	// Tracking barcode
+	y -= 12
+	c.text(fontBold, 9, pdfMargin, y, "TRACKING #: "+doc.TrackingNumber)
+	widths, err := encodeCode128(doc.TrackingNumber)
+	if err != nil {
+		return nil, err
+	}
+	barHeight := 60.0
+	y -= 6 + barHeight
+	c.barcode(widths, pdfMargin+4, y, contentWidth-8, barHeight)
+	y -= 6
+	c.fillRect(pdfMargin, y, contentWidth, 1)
+
+	// References
+	for _, line := range referenceLines(doc) {
+		y -= 10
+		c.text(fontRegular, 8, pdfMargin, y, line)
+	}
+
+	return c.document(), nil
+}
+
+// pdfCanvas accumulates a page content stream
+type pdfCanvas struct {
+	content bytes.Buffer
+}
+
+func (c *pdfCanvas) text(font string, size, x, y float64, s string) {
+	fmt.Fprintf(&c.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfString(s))
+}
+
+func (c *pdfCanvas) whiteText(font string, size, x, y float64, s string) {
+	c.content.WriteString("1 g\n")
+	c.text(font, size, x, y, s)
+	c.content.WriteString("0 g\n")
+}
+
+func (c *pdfCanvas) fillRect(x, y, w, h float64) {
+	fmt.Fprintf(&c.content, "%.2f %.2f %.2f %.2f re f\n", x, y, w, h)
+}
+
+func (c *pdfCanvas) strokeRect(x, y, w, h float64) {
+	fmt.Fprintf(&c.content, "0.8 w %.2f %.2f %.2f %.2f re S\n", x, y, w, h)
+}
+
+// barcode draws alternating bar/space module widths scaled to fit maxWidth
+func (c *pdfCanvas) barcode(widths []int, x, y, maxWidth, height float64) {
+	modules := 0
+	for _, w := range widths {
+		modules += w
+	}
+	module := maxWidth / float64(modules)
+	if module > 1.5 {
+		module = 1.5
+	}
+
+	for i, w := range widths {
+		width := float64(w) * module
+		if i%2 == 0 {
+			c.fillRect(x, y, width, height)
+		}
+		x += width
+	}
+}
+
+// document wraps the content stream in a minimal single-page 4x6 PDF 1.4 file
+func (c *pdfCanvas) document() []byte {
+	return buildPDF(pdfPageWidth, pdfPageHeight, []*pdfCanvas{c})
+}
+
+// buildPDF writes a minimal PDF 1.4 file with one page per canvas
+func buildPDF(width, height float64, pages []*pdfCanvas) []byte {
+	// Objects 1-4 are the catalog, page tree and fonts; each page adds a page and a content object
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+	for i, page := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Contents %d 0 R /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> >>",
+				width, height, 6+2*i, fontRegular, fontBold),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()),
+		)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+// pdfString escapes a string for a PDF literal, replacing characters outside printable ASCII
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,100 @@
+package labels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// Renderer renders 4x6 shipping labels as ZPL II or PDF
+type Renderer struct{}
+
+// NewRenderer creates a new label Renderer
+func NewRenderer() *Renderer {
+	return &Renderer{}
+}
+
+// Render produces the label in the requested format
+func (r *Renderer) Render(doc domain.LabelDocument, format string) ([]byte, error) {
+	if doc.TrackingNumber == "" {
+		return nil, fmt.Errorf("label requires a tracking number")
+	}
+
+	switch domain.NormalizeLabelFormat(format) {
+	case domain.LabelFormatZPL:
+		return renderZPL(doc), nil
+	case domain.LabelFormatPDF:
+		return renderPDF(doc)
+	default:
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnsupportedLabelFormat, format)
+	}
+}
+
+// ContentType returns the MIME type of a rendered label format
+func ContentType(format string) string {
+	switch domain.NormalizeLabelFormat(format) {
+	case domain.LabelFormatZPL:
+		return "application/x-zpl"
+	case domain.LabelFormatPNG:
+		return "image/png"
+	default:
+		return "application/pdf"
+	}
+}
+
+// addressLines formats an address for printing, skipping empty lines
+func addressLines(a domain.Address) []string {
+	lines := make([]string, 0, 5)
+	for _, line := range []string{a.Name, a.Company, a.Street1, a.Street2} {
+		if line != "" {
+			lines = append(lines, strings.ToUpper(line))
+		}
+	}
+	cityLine := strings.TrimSpace(strings.Join(nonEmpty(a.City, strings.TrimSpace(a.State+" "+a.PostalCode)), ", "))
+	if cityLine != "" {
+		lines = append(lines, strings.ToUpper(cityLine))
+	}
+	if a.Country != "" {
+		lines = append(lines, strings.ToUpper(a.Country))
+	}
+	return lines
+}
+
+func weightLine(pkg domain.PackageInfo) string {
+	line := fmt.Sprintf("WT: %.2f KG", pkg.Weight)
+	d := pkg.Dimensions
+	if d.Length > 0 && d.Width > 0 && d.Height > 0 {
+		line += fmt.Sprintf("  DIM: %.0fx%.0fx%.0f CM", d.Length, d.Width, d.Height)
+	}
+	return line
+}
+
+func referenceLines(doc domain.LabelDocument) []string {
+	lines := make([]string, 0, 3)
+	if doc.Reference1 != "" {
+		lines = append(lines, "REF 1: "+doc.Reference1)
+	}
+	if doc.Reference2 != "" {
+		lines = append(lines, "REF 2: "+doc.Reference2)
+	}
+	if !doc.ShipDate.IsZero() {
+		lines = append(lines, "SHIP DATE: "+doc.ShipDate.Format("2006-01-02"))
+	}
+	return lines
+}
+
+// routingSymbolData is the placeholder payload for the 2D routing symbol
+func routingSymbolData(doc domain.LabelDocument) string {
+	return strings.Join([]string{doc.Recipient.PostalCode, doc.Recipient.Country, doc.ServiceType, doc.TrackingNumber}, "|")
+}
+
+func nonEmpty(values ...string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+package labels
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+func testLabelDocument(carrierCode string, isReturn bool) domain.LabelDocument {
+	return domain.LabelDocument{
+		TrackingNumber: "1Z999AA10123456784",
+		CarrierCode:    carrierCode,
+		ServiceType:    "Ground",
+		Shipper:        domain.Address{Name: "WMS Warehouse", Street1: "1 Dock Rd", City: "Memphis", State: "TN", PostalCode: "38118", Country: "US"},
+		Recipient:      domain.Address{Name: "Jane Doe", Street1: "42 Main St (Apt 3)", City: "Austin", State: "TX", PostalCode: "78701", Country: "US"},
+		Package:        domain.PackageInfo{Weight: 2.5, Dimensions: domain.Dimensions{Length: 30, Width: 20, Height: 10}},
+		Reference1:     "ORD-001",
+		Reference2:     "PKG_001",
+		IsReturn:       isReturn,
+	}
+}
+
+func TestEncodeCode128(t *testing.T) {
+	widths, err := encodeCode128("ABC")
+	require.NoError(t, err)
+
+	// start + 3 data + checksum symbols of 6 elements, stop of 7
+	assert.Len(t, widths, 5*6+7)
+
+	// Every symbol is 11 modules wide and the stop pattern is 13
+	sum := 0
+	for _, w := range widths {
+		sum += w
+	}
+	assert.Equal(t, 5*11+13, sum)
+
+	// Checksum: (104 + 1*33 + 2*34 + 3*35) mod 103 = 1
+	assert.Equal(t, code128Patterns[1], patternAt(widths, 4))
+}
+
+func TestEncodeCode128_RejectsNonASCII(t *testing.T) {
+	_, err := encodeCode128("ÄBC")
+	assert.Error(t, err)
+}
+
+func TestRender_ZPL(t *testing.T) {
+	out, err := NewRenderer().Render(testLabelDocument("FEDEX", false), "zpl")
+	require.NoError(t, err)
+
+	zpl := string(out)
+	assert.Regexp(t, `^\^XA`, zpl)
+	assert.Contains(t, zpl, "^XZ")
+	assert.Contains(t, zpl, "^BCN,180,N,N,N^FH^FD1Z999AA10123456784^FS")
+	assert.Contains(t, zpl, "^B7N")
+	assert.NotContains(t, zpl, "^BD")
+	assert.NotContains(t, zpl, "RETURN LABEL")
+	assert.Contains(t, zpl, "REF 2: PKG_5F001", "underscores must be escaped for ^FH")
+}
+
+func TestRender_ZPLReturnLabelForUPS(t *testing.T) {
+	out, err := NewRenderer().Render(testLabelDocument("UPS", true), domain.LabelFormatZPL)
+	require.NoError(t, err)
+
+	zpl := string(out)
+	assert.Contains(t, zpl, "^FR")
+	assert.Contains(t, zpl, "RETURN LABEL")
+	assert.Contains(t, zpl, "^BD2")
+}
+
+func TestRender_PDF(t *testing.T) {
+	out, err := NewRenderer().Render(testLabelDocument("FEDEX", false), "")
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), `(42 MAIN ST \(APT 3\)) Tj`)
+
+	// startxref points at the xref table, and each entry points at its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, startxref)
+	xrefOffset, err := strconv.Atoi(string(startxref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xrefOffset:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xrefOffset:], -1)
+	require.Len(t, entries, 6)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d offset", i+1)
+	}
+}
+
+func TestRender_UnsupportedFormat(t *testing.T) {
+	_, err := NewRenderer().Render(testLabelDocument("UPS", false), domain.LabelFormatPNG)
+	assert.ErrorIs(t, err, domain.ErrUnsupportedLabelFormat)
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "application/x-zpl", ContentType("zpl"))
+	assert.Equal(t, "application/pdf", ContentType(""))
+}
+
+// patternAt returns the symbol at index i of encoded widths as a pattern string
+func patternAt(widths []int, i int) string {
+	var b bytes.Buffer
+	for _, w := range widths[i*6 : i*6+6] {
+		b.WriteString(strconv.Itoa(w))
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package labels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// 4x6 inch label at 203 dpi
+const (
+	zplWidthDots  = 812
+	zplLengthDots = 1218
+	zplMargin     = 30
+)
+
+// renderZPL produces a ZPL II label for Zebra-compatible thermal printers
+func renderZPL(doc domain.LabelDocument) []byte {
+	var b strings.Builder
+	contentWidth := zplWidthDots - 2*zplMargin
+
+	b.WriteString("^XA\n")
+	b.WriteString("^CI28\n") // UTF-8 field data
+	fmt.Fprintf(&b, "^PW%d\n^LL%d\n^LH0,0\n", zplWidthDots, zplLengthDots)
+
+	y := zplMargin
+	if doc.IsReturn {
+		// Reverse-printed banner so return labels are obvious at the dock
+		fmt.Fprintf(&b, "^FO%d,%d^GB%d,60,60^FS\n", zplMargin, y, contentWidth)
+		fmt.Fprintf(&b, "^FO%d,%d^FR^A0N,48,48^FB%d,1,0,C^FH^FD%s^FS\n", zplMargin, y+8, contentWidth, zplField("RETURN LABEL"))
+		y += 75
+	}
+
+	// Ship from
+	fmt.Fprintf(&b, "^FO%d,%d^A0N,24,24^FDFROM:^FS\n", zplMargin, y)
+	y += 30
+	for _, line := range addressLines(doc.Shipper) {
+		fmt.Fprintf(&b, "^FO%d,%d^A0N,24,24^FH^FD%s^FS\n", zplMargin, y, zplField(line))
+		y += 28
+	}
+	y += 10
+	fmt.Fprintf(&b, "^FO%d,%d^GB%d,3,3^FS\n", zplMargin, y, contentWidth)
+	y += 20
+
+	// Ship to
+	fmt.Fprintf(&b, "^FO%d,%d^A0N,30,30^FDSHIP TO:^FS\n", zplMargin, y)
+	y += 40
+	for _, line := range addressLines(doc.Recipient) {
+		fmt.Fprintf(&b, "^FO%d,%d^A0N,38,38^FH^FD%s^FS\n", zplMargin+30, y, zplField(line))
+		y += 44
+	}
+	y += 10
+	fmt.Fprintf(&b, "^FO%d,%d^GB%d,3,3^FS\n", zplMargin, y, contentWidth)
+	y += 20
+
+	// Routing block: 2D carrier symbol on the left, carrier and service on the right
+	writeZPLRoutingSymbol(&b, doc, zplMargin, y)
+	fmt.Fprintf(&b, "^FO%d,%d^A0N,64,64^FH^FD%s^FS\n", 420, y+10, zplField(doc.CarrierCode))
+	fmt.Fprintf(&b, "^FO%d,%d^A0N,34,34^FB360,2,0,L^FH^FD%s^FS\n", 420, y+90, zplField(strings.ToUpper(doc.ServiceType)))
+	fmt.Fprintf(&b, "^FO%d,%d^A0N,26,26^FH^FD%s^FS\n", 420, y+170, zplField(weightLine(doc.Package)))
+	y += 240
+	fmt.Fprintf(&b, "^FO%d,%d^GB%d,3,3^FS\n", zplMargin, y, contentWidth)
+	y += 20
+
+	// Tracking barcode
+	fmt.Fprintf(&b, "^FO%d,%d^A0N,30,30^FH^FDTRACKING #: %s^FS\n", zplMargin, y, zplField(doc.TrackingNumber))
+	y += 45
+	fmt.Fprintf(&b, "^FO%d,%d^BY%d,3,180^BCN,180,N,N,N^FH^FD%s^FS\n", zplMargin+30, y, zplModuleWidth(doc.TrackingNumber, contentWidth-30), zplField(doc.TrackingNumber))
+	y += 200
+	fmt.Fprintf(&b, "^FO%d,%d^GB%d,3,3^FS\n", zplMargin, y, contentWidth)
+	y += 15
+
+	// References
+	for _, line := range referenceLines(doc) {
+		fmt.Fprintf(&b, "^FO%d,%d^A0N,24,24^FH^FD%s^FS\n", zplMargin, y, zplField(line))
+		y += 28
+	}
+
+	b.WriteString("^XZ\n")
+	return []byte(b.String())
+}
+
+// writeZPLRoutingSymbol emits the carrier's 2D routing symbol.
+// UPS labels carry a MaxiCode and other carriers a PDF417; the encoded
+// content is a placeholder until carrier-specific payloads are available.
+func writeZPLRoutingSymbol(b *strings.Builder, doc domain.LabelDocument, x, y int) {
+	data := zplField(routingSymbolData(doc))
+	if doc.CarrierCode == "UPS" {
+		fmt.Fprintf(b, "^FO%d,%d^BD2,1,1^FH^FD%s^FS\n", x, y, data)
+		return
+	}
+	fmt.Fprintf(b, "^FO%d,%d^BY2^B7N,6,5,,,N^FH^FD%s^FS\n", x, y, data)
+}
+
+// zplModuleWidth picks the widest Code 128 bar module, in dots, that keeps the barcode within maxWidth
+func zplModuleWidth(data string, maxWidth int) int {
+	modules := (len(data)+3)*11 + 2
+	for width := 3; width > 1; width-- {
+		if modules*width <= maxWidth {
+			return width
+		}
+	}
+	return 1
+}
+
+// zplField escapes field data for use after ^FH, which treats '_' as the hex escape indicator
+func zplField(s string) string {
+	replacer := strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E")
+	return replacer.Replace(s)
+}
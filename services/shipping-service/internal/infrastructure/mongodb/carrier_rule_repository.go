@@ -0,0 +1,79 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/tenant"
+	"github.com/wms-platform/shipping-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CarrierSelectionRuleRepository implements the repository for seller carrier selection rules
+type CarrierSelectionRuleRepository struct {
+	collection   *mongo.Collection
+	tenantHelper *tenant.RepositoryHelper
+}
+
+// NewCarrierSelectionRuleRepository creates a new CarrierSelectionRuleRepository
+func NewCarrierSelectionRuleRepository(db *mongo.Database) *CarrierSelectionRuleRepository {
+	repo := &CarrierSelectionRuleRepository{
+		collection:   db.Collection("carrier_selection_rules"),
+		tenantHelper: tenant.NewRepositoryHelper(false),
+	}
+	repo.ensureIndexes(context.Background())
+	return repo
+}
+
+func (r *CarrierSelectionRuleRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "sellerId", Value: 1}}, Options: options.Index().SetUnique(true)},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+// Save upserts the rule for its seller
+func (r *CarrierSelectionRuleRepository) Save(ctx context.Context, rule *domain.CarrierSelectionRule) error {
+	now := time.Now().UTC()
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = now
+	}
+	rule.UpdatedAt = now
+
+	filter := bson.M{"tenantId": rule.TenantID, "sellerId": rule.SellerID}
+	update := bson.M{
+		"$set": bson.M{
+			"strategy":          rule.Strategy,
+			"allowedCarriers":   rule.AllowedCarriers,
+			"excludedCarriers":  rule.ExcludedCarriers,
+			"maxCost":           rule.MaxCost,
+			"requireGuaranteed": rule.RequireGuaranteed,
+			"updatedAt":         rule.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{"createdAt": rule.CreatedAt},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to save carrier selection rule: %w", err)
+	}
+	return nil
+}
+
+// FindBySellerID returns the seller's rule, or nil if none is configured
+func (r *CarrierSelectionRuleRepository) FindBySellerID(ctx context.Context, sellerID string) (*domain.CarrierSelectionRule, error) {
+	filter := bson.M{"sellerId": sellerID}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	var rule domain.CarrierSelectionRule
+	err := r.collection.FindOne(ctx, filter).Decode(&rule)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find carrier selection rule: %w", err)
+	}
+	return &rule, nil
+}
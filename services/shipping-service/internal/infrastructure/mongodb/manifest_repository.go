@@ -7,6 +7,7 @@ import (
 
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -62,9 +63,23 @@ func (r *ManifestRepository) ensureIndexes(ctx context.Context) {
 	_ = r.outboxRepo.EnsureIndexes(ctx)
 }
 
-// Save saves an OutboundManifest with transactional outbox pattern
+// Save saves an OutboundManifest with transactional outbox pattern. The write only
+// succeeds if the stored manifest still has the version that was loaded.
 func (r *ManifestRepository) Save(ctx context.Context, manifest *domain.OutboundManifest) error {
-	manifest.UpdatedAt = time.Now().UTC()
+	return r.SaveAll(ctx, manifest)
+}
+
+// SaveAll saves several manifests and their events in one transaction, so a manifest
+// closed at cutoff and the manifest its late packages roll to are written together.
+// Nothing is written if any manifest changed since it was loaded.
+func (r *ManifestRepository) SaveAll(ctx context.Context, manifests ...*domain.OutboundManifest) error {
+	now := time.Now().UTC()
+	expectedVersions := make([]int, len(manifests))
+	for i, manifest := range manifests {
+		manifest.UpdatedAt = now
+		expectedVersions[i] = manifest.Version
+		manifest.Version = expectedVersions[i] + 1
+	}
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -75,63 +90,80 @@ func (r *ManifestRepository) Save(ctx context.Context, manifest *domain.Outbound
 
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
-		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"manifestId": manifest.ManifestID}
-		update := bson.M{"$set": manifest}
-
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
-			return nil, fmt.Errorf("failed to save manifest: %w", err)
-		}
-
-		// 2. Save domain events to outbox
-		domainEvents := manifest.GetManifestDomainEvents()
-		if len(domainEvents) > 0 {
-			outboxEvents := make([]*outbox.OutboxEvent, 0, len(domainEvents))
-
-			for _, event := range domainEvents {
-				var cloudEvent *cloudevents.WMSCloudEvent
-				switch e := event.(type) {
-				case *domain.ManifestClosedEvent:
-					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "manifest/"+e.ManifestID, e)
-				case *domain.ManifestDispatchedEvent:
-					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "manifest/"+e.ManifestID, e)
-				default:
-					continue
-				}
-
-				// Create outbox event from CloudEvent
-				outboxEvent, err := outbox.NewOutboxEventFromCloudEvent(
-					manifest.ManifestID,
-					"OutboundManifest",
-					kafka.Topics.ShippingEvents,
-					cloudEvent,
-				)
-				if err != nil {
-					return nil, fmt.Errorf("failed to create outbox event: %w", err)
-				}
-
-				outboxEvents = append(outboxEvents, outboxEvent)
-			}
-
-			// Save all outbox events in the same transaction
-			if len(outboxEvents) > 0 {
-				if err := r.outboxRepo.SaveAll(sessCtx, outboxEvents); err != nil {
-					return nil, fmt.Errorf("failed to save outbox events: %w", err)
-				}
+		for i, manifest := range manifests {
+			if err := r.saveInTransaction(sessCtx, manifest, expectedVersions[i]); err != nil {
+				return nil, err
 			}
 		}
-
-		// 3. Clear domain events from the aggregate
-		manifest.ClearManifestDomainEvents()
-
 		return nil, nil
 	})
 
 	if err != nil {
+		for i, manifest := range manifests {
+			manifest.Version = expectedVersions[i]
+		}
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
+	// Clear domain events once they are committed to the outbox
+	for _, manifest := range manifests {
+		manifest.ClearManifestDomainEvents()
+	}
+	return nil
+}
+
+// saveInTransaction writes one manifest and its domain events to the outbox
+func (r *ManifestRepository) saveInTransaction(sessCtx mongo.SessionContext, manifest *domain.OutboundManifest, expectedVersion int) error {
+	// 1. Save the aggregate
+	opts := options.Update().SetUpsert(expectedVersion == 0)
+	filter := sharedMongo.VersionedFilter(bson.M{"manifestId": manifest.ManifestID}, expectedVersion)
+	update := bson.M{"$set": manifest}
+
+	result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+	if err := sharedMongo.CheckVersionedUpdate(result, err, "OutboundManifest", manifest.ManifestID, expectedVersion); err != nil {
+		return fmt.Errorf("failed to save manifest: %w", err)
+	}
+
+	// 2. Save domain events to outbox
+	domainEvents := manifest.GetManifestDomainEvents()
+	if len(domainEvents) == 0 {
+		return nil
+	}
+
+	outboxEvents := make([]*outbox.OutboxEvent, 0, len(domainEvents))
+	for _, event := range domainEvents {
+		var cloudEvent *cloudevents.WMSCloudEvent
+		switch e := event.(type) {
+		case *domain.ManifestClosedEvent:
+			cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "manifest/"+e.ManifestID, e)
+		case *domain.ManifestDispatchedEvent:
+			cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "manifest/"+e.ManifestID, e)
+		case *domain.ManifestPickupMissedEvent:
+			cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "manifest/"+e.ManifestID, e)
+		default:
+			continue
+		}
+
+		// Create outbox event from CloudEvent
+		outboxEvent, err := outbox.NewOutboxEventFromCloudEvent(
+			manifest.ManifestID,
+			"OutboundManifest",
+			kafka.Topics.ShippingEvents,
+			cloudEvent,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create outbox event: %w", err)
+		}
+
+		outboxEvents = append(outboxEvents, outboxEvent)
+	}
+
+	// Save all outbox events in the same transaction
+	if len(outboxEvents) > 0 {
+		if err := r.outboxRepo.SaveAll(sessCtx, outboxEvents); err != nil {
+			return fmt.Errorf("failed to save outbox events: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/tenant"
+	"github.com/wms-platform/shipping-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PickupScheduleRepository implements the repository for carrier pickup schedules
+type PickupScheduleRepository struct {
+	collection   *mongo.Collection
+	tenantHelper *tenant.RepositoryHelper
+}
+
+// NewPickupScheduleRepository creates a new PickupScheduleRepository
+func NewPickupScheduleRepository(db *mongo.Database) *PickupScheduleRepository {
+	repo := &PickupScheduleRepository{
+		collection:   db.Collection("carrier_pickup_schedules"),
+		tenantHelper: tenant.NewRepositoryHelper(false),
+	}
+	repo.ensureIndexes(context.Background())
+	return repo
+}
+
+func (r *PickupScheduleRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "tenantId", Value: 1}, {Key: "facilityId", Value: 1}, {Key: "carrierCode", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "active", Value: 1}}},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+// Save upserts the schedule for its facility and carrier
+func (r *PickupScheduleRepository) Save(ctx context.Context, schedule *domain.CarrierPickupSchedule) error {
+	now := time.Now().UTC()
+	if schedule.CreatedAt.IsZero() {
+		schedule.CreatedAt = now
+	}
+	schedule.UpdatedAt = now
+
+	filter := bson.M{"tenantId": schedule.TenantID, "facilityId": schedule.FacilityID, "carrierCode": schedule.CarrierCode}
+	update := bson.M{
+		"$set": bson.M{
+			"carrierName":              schedule.CarrierName,
+			"serviceType":              schedule.ServiceType,
+			"pickupDays":               schedule.PickupDays,
+			"cutoffTime":               schedule.CutoffTime,
+			"pickupTime":               schedule.PickupTime,
+			"timeZone":                 schedule.TimeZone,
+			"missedPickupGraceMinutes": schedule.MissedPickupGraceMinutes,
+			"shipFrom":                 schedule.ShipFrom,
+			"active":                   schedule.Active,
+			"updatedAt":                schedule.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{"createdAt": schedule.CreatedAt},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to save pickup schedule: %w", err)
+	}
+	return nil
+}
+
+// FindByCarrier returns the carrier's schedule at the facility, or nil if none is configured
+func (r *PickupScheduleRepository) FindByCarrier(ctx context.Context, carrierCode string) (*domain.CarrierPickupSchedule, error) {
+	filter := bson.M{"carrierCode": carrierCode}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	var schedule domain.CarrierPickupSchedule
+	err := r.collection.FindOne(ctx, filter).Decode(&schedule)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pickup schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+// FindAll returns every schedule for the facility
+func (r *PickupScheduleRepository) FindAll(ctx context.Context) ([]*domain.CarrierPickupSchedule, error) {
+	return r.find(ctx, bson.M{})
+}
+
+// FindActive returns active schedules
+func (r *PickupScheduleRepository) FindActive(ctx context.Context) ([]*domain.CarrierPickupSchedule, error) {
+	return r.find(ctx, bson.M{"active": true})
+}
+
+// Delete removes the carrier's schedule at the facility
+func (r *PickupScheduleRepository) Delete(ctx context.Context, carrierCode string) error {
+	filter := bson.M{"carrierCode": carrierCode}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	if _, err := r.collection.DeleteOne(ctx, filter); err != nil {
+		return fmt.Errorf("failed to delete pickup schedule: %w", err)
+	}
+	return nil
+}
+
+func (r *PickupScheduleRepository) find(ctx context.Context, filter bson.M) ([]*domain.CarrierPickupSchedule, error) {
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().SetSort(bson.D{{Key: "carrierCode", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pickup schedules: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var schedules []*domain.CarrierPickupSchedule
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, fmt.Errorf("failed to decode pickup schedules: %w", err)
+	}
+	return schedules, nil
+}
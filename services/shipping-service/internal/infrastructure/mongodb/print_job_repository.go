@@ -0,0 +1,93 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PrintJobRepository implements the print spool for label print jobs
+type PrintJobRepository struct {
+	collection *mongo.Collection
+}
+
+// NewPrintJobRepository creates a new PrintJobRepository
+func NewPrintJobRepository(db *mongo.Database) *PrintJobRepository {
+	repo := &PrintJobRepository{
+		collection: db.Collection("print_jobs"),
+	}
+	repo.ensureIndexes(context.Background())
+	return repo
+}
+
+func (r *PrintJobRepository) ensureIndexes(ctx context.Context) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "jobId", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}}},
+		{Keys: bson.D{{Key: "shipmentId", Value: 1}}},
+	}
+	r.collection.Indexes().CreateMany(ctx, indexes)
+}
+
+// Save upserts a print job
+func (r *PrintJobRepository) Save(ctx context.Context, job *domain.PrintJob) error {
+	filter := bson.M{"jobId": job.JobID}
+	update := bson.M{"$set": job}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		return fmt.Errorf("failed to save print job: %w", err)
+	}
+	return nil
+}
+
+// Claim atomically marks a spooled job as being sent by holder. Only one
+// router replica can hold a live claim, so a job is never sent twice at once.
+func (r *PrintJobRepository) Claim(ctx context.Context, job *domain.PrintJob, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(ttl)
+	filter := bson.M{
+		"jobId":  job.JobID,
+		"status": domain.PrintJobStatusSpooled,
+		"$or": bson.A{
+			bson.M{"claimExpiresAt": nil},
+			bson.M{"claimExpiresAt": bson.M{"$lte": now}},
+			bson.M{"claimedBy": holder},
+		},
+	}
+	update := bson.M{"$set": bson.M{"claimedBy": holder, "claimExpiresAt": expiresAt}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim print job: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return false, nil
+	}
+	job.ClaimedBy = holder
+	job.ClaimExpiresAt = &expiresAt
+	return true, nil
+}
+
+// FindSpooled returns spooled jobs across all tenants, oldest first.
+// The spool is drained by a background worker without tenant context.
+func (r *PrintJobRepository) FindSpooled(ctx context.Context, limit int) ([]*domain.PrintJob, error) {
+	filter := bson.M{"status": domain.PrintJobStatusSpooled}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find spooled print jobs: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var jobs []*domain.PrintJob
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to decode spooled print jobs: %w", err)
+	}
+	return jobs, nil
+}
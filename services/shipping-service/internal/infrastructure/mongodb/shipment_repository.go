@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/shipping-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -47,6 +48,7 @@ func (r *ShipmentRepository) ensureIndexes(ctx context.Context) {
 		{Keys: bson.D{{Key: "status", Value: 1}}},
 		{Keys: bson.D{{Key: "carrier.code", Value: 1}}},
 		{Keys: bson.D{{Key: "manifest.manifestId", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "tracking.nextPollAt", Value: 1}}},
 	}
 	r.collection.Indexes().CreateMany(ctx, indexes)
 
@@ -54,8 +56,12 @@ func (r *ShipmentRepository) ensureIndexes(ctx context.Context) {
 	_ = r.outboxRepo.EnsureIndexes(ctx)
 }
 
+// Save persists the shipment and its events, failing with ErrConcurrencyConflict when
+// another writer saved it after it was loaded
 func (r *ShipmentRepository) Save(ctx context.Context, shipment *domain.Shipment) error {
 	shipment.UpdatedAt = time.Now()
+	expectedVersion := shipment.Version
+	shipment.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -67,11 +73,12 @@ func (r *ShipmentRepository) Save(ctx context.Context, shipment *domain.Shipment
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"shipmentId": shipment.ShipmentID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"shipmentId": shipment.ShipmentID}, expectedVersion)
 		update := bson.M{"$set": shipment}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "Shipment", shipment.ShipmentID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save shipment: %w", err)
 		}
 
@@ -86,12 +93,20 @@ func (r *ShipmentRepository) Save(ctx context.Context, shipment *domain.Shipment
 				switch e := event.(type) {
 				case *domain.ShipmentCreatedEvent:
 					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "shipment/"+e.ShipmentID, e)
+				case *domain.CarrierSelectedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "shipment/"+e.ShipmentID, e)
 				case *domain.LabelGeneratedEvent:
 					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "shipment/"+e.ShipmentID, e)
+				case *domain.ReturnLabelGeneratedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "shipment/"+e.ShipmentID, e)
+				case *domain.LabelVoidedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "shipment/"+e.ShipmentID, e)
 				case *domain.ShipmentManifestedEvent:
 					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "shipment/"+e.ShipmentID, e)
 				case *domain.ShipConfirmedEvent:
 					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "shipment/"+e.ShipmentID, e)
+				case *domain.TrackingUpdatedEvent:
+					cloudEvent = r.eventFactory.CreateEvent(sessCtx, e.EventType(), "shipment/"+e.ShipmentID, e)
 				default:
 					continue
 				}
@@ -125,6 +140,7 @@ func (r *ShipmentRepository) Save(ctx context.Context, shipment *domain.Shipment
 	})
 
 	if err != nil {
+		shipment.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
@@ -156,7 +172,11 @@ func (r *ShipmentRepository) FindByOrderID(ctx context.Context, orderID string)
 }
 
 func (r *ShipmentRepository) FindByTrackingNumber(ctx context.Context, trackingNumber string) (*domain.Shipment, error) {
-	filter := bson.M{"label.trackingNumber": trackingNumber}
+	// Voided labels still find their shipment, so voiding again is answered from the record
+	filter := bson.M{"$or": bson.A{
+		bson.M{"label.trackingNumber": trackingNumber},
+		bson.M{"voidedLabels.trackingNumber": trackingNumber},
+	}}
 	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
 
 	var s domain.Shipment
@@ -226,6 +246,39 @@ func (r *ShipmentRepository) FindPendingForManifest(ctx context.Context, carrier
 	return shipments, err
 }
 
+// FindDueForTrackingPoll returns shipments in carrier custody whose next tracking poll is due,
+// most overdue first. Shipments that have never been polled are due immediately.
+func (r *ShipmentRepository) FindDueForTrackingPoll(ctx context.Context, now time.Time, limit int) ([]*domain.Shipment, error) {
+	filter := bson.M{
+		"status": bson.M{"$in": []domain.ShipmentStatus{
+			domain.ShipmentStatusManifested,
+			domain.ShipmentStatusShipped,
+			domain.ShipmentStatusInTransit,
+			domain.ShipmentStatusException,
+		}},
+		"label.trackingNumber":    bson.M{"$exists": true, "$ne": ""},
+		"tracking.pollingStopped": bson.M{"$ne": true},
+		"$or": []bson.M{
+			{"tracking.nextPollAt": bson.M{"$lte": now}},
+			{"tracking.nextPollAt": bson.M{"$exists": false}},
+		},
+	}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "tracking.nextPollAt", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var shipments []*domain.Shipment
+	err = cursor.All(ctx, &shipments)
+	return shipments, err
+}
+
 func (r *ShipmentRepository) Delete(ctx context.Context, shipmentID string) error {
 	filter := bson.M{"shipmentId": shipmentID}
 	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
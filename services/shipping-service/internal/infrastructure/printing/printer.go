@@ -0,0 +1,69 @@
+package printing
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// DefaultRawPort is the raw TCP (JetDirect) port label printers listen on
+const DefaultRawPort = "9100"
+
+// Printer is a station label printer reachable over raw TCP
+type Printer struct {
+	PrinterID string
+	StationID string
+	Address   string // host:port
+}
+
+// ParsePrinters parses a printer list of the form
+// "PRN-01@STATION-01=10.0.0.21:9100,PRN-02@STATION-02=10.0.0.22".
+// The station is optional and the port defaults to 9100.
+func ParsePrinters(spec string) ([]Printer, error) {
+	printers := make([]Printer, 0)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, address, ok := strings.Cut(entry, "=")
+		if !ok || id == "" || address == "" {
+			return nil, fmt.Errorf("invalid printer entry %q, expected printerId[@stationId]=host[:port]", entry)
+		}
+
+		printer := Printer{PrinterID: id, Address: address}
+		if printerID, stationID, ok := strings.Cut(id, "@"); ok {
+			printer.PrinterID = printerID
+			printer.StationID = stationID
+		}
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			printer.Address = net.JoinHostPort(address, DefaultRawPort)
+		}
+
+		printers = append(printers, printer)
+	}
+	return printers, nil
+}
+
+// sendRaw streams the label bytes to the printer over a raw TCP connection
+func sendRaw(ctx context.Context, address string, data []byte, copies int, timeout time.Duration) error {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("printer %s unreachable: %w", address, err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+		return fmt.Errorf("failed to set write deadline: %w", err)
+	}
+	for i := 0; i < copies; i++ {
+		if _, err := conn.Write(data); err != nil {
+			return fmt.Errorf("failed to write to printer %s: %w", address, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package printing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/tenant"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+// RouterConfig holds print routing configuration
+type RouterConfig struct {
+	SendTimeout    time.Duration
+	SpoolInterval  time.Duration
+	SpoolBatchSize int
+	ClaimTTL       time.Duration // How long a replica holds a spooled job while sending it
+}
+
+// DefaultRouterConfig returns the default print routing configuration
+func DefaultRouterConfig() RouterConfig {
+	return RouterConfig{
+		SendTimeout:    3 * time.Second,
+		SpoolInterval:  10 * time.Second,
+		SpoolBatchSize: 100,
+		ClaimTTL:       30 * time.Second,
+	}
+}
+
+// Router routes ZPL label print jobs to station printers over raw TCP.
+// Jobs for unreachable printers are spooled and retried in order until they print.
+type Router struct {
+	printers   map[string]Printer
+	stations   map[string]string // stationID -> printerID
+	spool      domain.PrintJobRepository
+	config     RouterConfig
+	logger     *logging.Logger
+	instanceID string // Holder of this replica's spool claims
+
+	mu       sync.Mutex
+	offline  map[string]bool // printers with spooled jobs waiting
+	drainMu  sync.Mutex
+	running  bool
+	stopChan chan struct{}
+}
+
+// NewRouter creates a new print Router
+func NewRouter(printers []Printer, spool domain.PrintJobRepository, config RouterConfig, logger *logging.Logger) *Router {
+	r := &Router{
+		printers:   make(map[string]Printer, len(printers)),
+		stations:   make(map[string]string),
+		spool:      spool,
+		config:     config,
+		logger:     logger,
+		instanceID: uuid.New().String(),
+		offline:    make(map[string]bool),
+	}
+	for _, p := range printers {
+		r.printers[p.PrinterID] = p
+		if p.StationID != "" {
+			if _, exists := r.stations[p.StationID]; !exists {
+				r.stations[p.StationID] = p.PrinterID
+			}
+		}
+	}
+	return r
+}
+
+// Print sends the label to the requested printer, or the station's printer.
+// If the printer is offline, or already has jobs waiting, the job is spooled.
+// Raw printers interpret the bytes as ZPL, so any other format is rejected.
+func (r *Router) Print(ctx context.Context, request domain.PrintRequest) (*domain.PrintJob, error) {
+	printer, err := r.resolve(request)
+	if err != nil {
+		return nil, err
+	}
+	if domain.NormalizeLabelFormat(request.Format) != domain.LabelFormatZPL {
+		return nil, domain.ErrLabelNotPrintable
+	}
+
+	job := domain.NewPrintJob("PJ-"+uuid.New().String()[:8], printer.PrinterID, request)
+	job.TenantID = tenant.FromContextOptional(ctx).TenantID
+
+	if r.isOffline(printer.PrinterID) {
+		job.MarkSpooled(fmt.Errorf("printer %s has spooled jobs pending", printer.PrinterID))
+	} else if err := sendRaw(ctx, printer.Address, job.Data, job.Copies, r.config.SendTimeout); err != nil {
+		r.markOffline(printer.PrinterID)
+		job.MarkSpooled(err)
+		r.logger.Warn("Printer offline, spooling label", "printerId", printer.PrinterID, "jobId", job.JobID, "error", err.Error())
+	} else {
+		job.MarkPrinted()
+	}
+
+	if err := r.spool.Save(ctx, job); err != nil {
+		if job.Status == domain.PrintJobStatusSpooled {
+			return nil, fmt.Errorf("failed to spool print job: %w", err)
+		}
+		// The label already printed; losing the audit record is not worth failing the request
+		r.logger.WithError(err).Warn("Failed to record print job", "jobId", job.JobID)
+	}
+
+	return job, nil
+}
+
+// DrainSpool retries spooled jobs oldest first. A printer that fails again
+// keeps its remaining jobs spooled so labels print in their original order.
+// Each job is claimed before it is sent; when another replica holds a claim
+// on a printer's job, that printer's remaining jobs are left to it.
+func (r *Router) DrainSpool(ctx context.Context) (int, error) {
+	r.drainMu.Lock()
+	defer r.drainMu.Unlock()
+
+	jobs, err := r.spool.FindSpooled(ctx, r.config.SpoolBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load print spool: %w", err)
+	}
+
+	printed := 0
+	failed := make(map[string]bool)
+	for _, job := range jobs {
+		if failed[job.PrinterID] {
+			continue
+		}
+
+		printer, ok := r.printers[job.PrinterID]
+		if !ok {
+			failed[job.PrinterID] = true
+			r.logger.Warn("Spooled job references unknown printer", "printerId", job.PrinterID, "jobId", job.JobID)
+			continue
+		}
+
+		// Jobs spooled before non-ZPL labels were rejected would print as garbage
+		if job.Format != domain.LabelFormatZPL {
+			job.MarkFailed(domain.ErrLabelNotPrintable)
+			if err := r.spool.Save(ctx, job); err != nil {
+				return printed, fmt.Errorf("failed to update print job %s: %w", job.JobID, err)
+			}
+			r.logger.Warn("Dropped spooled job with a non-ZPL label", "printerId", job.PrinterID, "jobId", job.JobID, "format", job.Format)
+			continue
+		}
+
+		claimed, err := r.spool.Claim(ctx, job, r.instanceID, r.config.ClaimTTL)
+		if err != nil {
+			return printed, fmt.Errorf("failed to claim print job %s: %w", job.JobID, err)
+		}
+		if !claimed {
+			failed[job.PrinterID] = true
+			continue
+		}
+
+		if err := sendRaw(ctx, printer.Address, job.Data, job.Copies, r.config.SendTimeout); err != nil {
+			failed[job.PrinterID] = true
+			job.MarkSpooled(err)
+		} else {
+			job.MarkPrinted()
+			printed++
+		}
+
+		if err := r.spool.Save(ctx, job); err != nil {
+			return printed, fmt.Errorf("failed to update print job %s: %w", job.JobID, err)
+		}
+	}
+
+	// Printers that printed everything in the batch accept new jobs directly again
+	spooledRemain := make(map[string]bool)
+	if len(jobs) == r.config.SpoolBatchSize {
+		for _, job := range jobs {
+			if job.Status == domain.PrintJobStatusSpooled {
+				spooledRemain[job.PrinterID] = true
+			}
+		}
+	}
+	r.mu.Lock()
+	for printerID := range r.offline {
+		if !failed[printerID] && !spooledRemain[printerID] {
+			delete(r.offline, printerID)
+		}
+	}
+	r.mu.Unlock()
+
+	if printed > 0 {
+		r.logger.Info("Printed spooled labels", "count", printed)
+	}
+	return printed, nil
+}
+
+// Start begins retrying the spool in the background
+func (r *Router) Start(ctx context.Context) error {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return fmt.Errorf("print router is already running")
+	}
+	r.running = true
+	r.stopChan = make(chan struct{})
+	r.mu.Unlock()
+
+	// Printers with jobs left over from a previous run stay spooled until drained
+	jobs, err := r.spool.FindSpooled(ctx, r.config.SpoolBatchSize)
+	if err != nil {
+		r.logger.WithError(err).Warn("Failed to load print spool")
+	}
+	for _, job := range jobs {
+		r.markOffline(job.PrinterID)
+	}
+
+	go r.run(ctx)
+	return nil
+}
+
+// Stop stops retrying the spool
+func (r *Router) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running {
+		close(r.stopChan)
+		r.running = false
+	}
+}
+
+// run is the main loop for spool retries
+func (r *Router) run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.SpoolInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.stopChan:
+			return
+		case <-ticker.C:
+			if _, err := r.DrainSpool(ctx); err != nil {
+				r.logger.WithError(err).Warn("Failed to drain print spool")
+			}
+		}
+	}
+}
+
+// resolve finds the printer for a request, preferring an explicit printer over the station's printer
+func (r *Router) resolve(request domain.PrintRequest) (Printer, error) {
+	printerID := request.PrinterID
+	if printerID == "" {
+		printerID = r.stations[request.StationID]
+	}
+
+	printer, ok := r.printers[printerID]
+	if !ok {
+		return Printer{}, domain.ErrPrinterNotFound
+	}
+	return printer, nil
+}
+
+func (r *Router) isOffline(printerID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.offline[printerID]
+}
+
+func (r *Router) markOffline(printerID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.offline[printerID] = true
+}
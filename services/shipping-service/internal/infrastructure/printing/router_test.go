@@ -0,0 +1,267 @@
+package printing
+
+import (
+	"context"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/shared/pkg/logging"
+
+	"github.com/wms-platform/shipping-service/internal/domain"
+)
+
+type fakeSpool struct {
+	mu   sync.Mutex
+	jobs map[string]*domain.PrintJob
+}
+
+func newFakeSpool() *fakeSpool {
+	return &fakeSpool{jobs: make(map[string]*domain.PrintJob)}
+}
+
+func (s *fakeSpool) Save(_ context.Context, job *domain.PrintJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.JobID] = job
+	return nil
+}
+
+func (s *fakeSpool) Claim(_ context.Context, job *domain.PrintJob, holder string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.jobs[job.JobID]
+	if !ok || stored.Status != domain.PrintJobStatusSpooled {
+		return false, nil
+	}
+	now := time.Now().UTC()
+	if stored.ClaimExpiresAt != nil && stored.ClaimExpiresAt.After(now) && stored.ClaimedBy != holder {
+		return false, nil
+	}
+	expiresAt := now.Add(ttl)
+	stored.ClaimedBy = holder
+	stored.ClaimExpiresAt = &expiresAt
+	job.ClaimedBy = holder
+	job.ClaimExpiresAt = &expiresAt
+	return true, nil
+}
+
+func (s *fakeSpool) FindSpooled(_ context.Context, limit int) ([]*domain.PrintJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	spooled := make([]*domain.PrintJob, 0)
+	for _, job := range s.jobs {
+		if job.Status == domain.PrintJobStatusSpooled {
+			spooled = append(spooled, job)
+		}
+	}
+	sort.SliceStable(spooled, func(i, j int) bool { return spooled[i].CreatedAt.Before(spooled[j].CreatedAt) })
+	if len(spooled) > limit {
+		spooled = spooled[:limit]
+	}
+	return spooled, nil
+}
+
+// fakePrinter is a raw TCP listener that records what it receives
+type fakePrinter struct {
+	listener net.Listener
+	mu       sync.Mutex
+	received []string
+}
+
+func startFakePrinter(t *testing.T, address string) *fakePrinter {
+	t.Helper()
+	listener, err := net.Listen("tcp", address)
+	require.NoError(t, err)
+
+	p := &fakePrinter{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			data, _ := io.ReadAll(conn)
+			conn.Close()
+			p.mu.Lock()
+			p.received = append(p.received, string(data))
+			p.mu.Unlock()
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return p
+}
+
+func (p *fakePrinter) Received() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.received...)
+}
+
+// unusedAddress returns a local address with nothing listening on it
+func unusedAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+	return address
+}
+
+func newTestRouter(spool *fakeSpool, printers ...Printer) *Router {
+	config := DefaultRouterConfig()
+	config.SendTimeout = 500 * time.Millisecond
+	return NewRouter(printers, spool, config, logging.New(logging.DefaultConfig("test")))
+}
+
+func TestParsePrinters(t *testing.T) {
+	printers, err := ParsePrinters("PRN-01@STATION-01=10.0.0.21:9100, PRN-02=10.0.0.22")
+	require.NoError(t, err)
+	require.Len(t, printers, 2)
+
+	assert.Equal(t, Printer{PrinterID: "PRN-01", StationID: "STATION-01", Address: "10.0.0.21:9100"}, printers[0])
+	assert.Equal(t, Printer{PrinterID: "PRN-02", Address: "10.0.0.22:9100"}, printers[1])
+
+	_, err = ParsePrinters("PRN-03")
+	assert.Error(t, err)
+
+	printers, err = ParsePrinters("")
+	require.NoError(t, err)
+	assert.Empty(t, printers)
+}
+
+func TestRouter_PrintByStation(t *testing.T) {
+	printer := startFakePrinter(t, "127.0.0.1:0")
+	spool := newFakeSpool()
+	router := newTestRouter(spool, Printer{PrinterID: "PRN-01", StationID: "STATION-01", Address: printer.listener.Addr().String()})
+
+	job, err := router.Print(context.Background(), domain.PrintRequest{StationID: "STATION-01", ShipmentID: "SHP-001", Format: "zpl", Data: []byte("^XA^XZ"), Copies: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.PrintJobStatusPrinted, job.Status)
+	assert.Equal(t, "PRN-01", job.PrinterID)
+	assert.Equal(t, domain.LabelFormatZPL, job.Format)
+	assert.Contains(t, spool.jobs, job.JobID)
+	assert.Eventually(t, func() bool {
+		received := printer.Received()
+		return len(received) == 1 && received[0] == "^XA^XZ^XA^XZ"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRouter_UnknownPrinter(t *testing.T) {
+	router := newTestRouter(newFakeSpool(), Printer{PrinterID: "PRN-01", StationID: "STATION-01", Address: unusedAddress(t)})
+
+	_, err := router.Print(context.Background(), domain.PrintRequest{StationID: "STATION-99", Data: []byte("x")})
+	assert.ErrorIs(t, err, domain.ErrPrinterNotFound)
+}
+
+func TestRouter_SpoolsWhileOfflineAndDrainsInOrder(t *testing.T) {
+	address := unusedAddress(t)
+	spool := newFakeSpool()
+	router := newTestRouter(spool, Printer{PrinterID: "PRN-01", Address: address})
+	ctx := context.Background()
+
+	first, err := router.Print(ctx, domain.PrintRequest{PrinterID: "PRN-01", Format: "ZPL", Data: []byte("first")})
+	require.NoError(t, err)
+	assert.Equal(t, domain.PrintJobStatusSpooled, first.Status)
+	assert.NotEmpty(t, first.LastError)
+
+	second, err := router.Print(ctx, domain.PrintRequest{PrinterID: "PRN-01", Format: "ZPL", Data: []byte("second")})
+	require.NoError(t, err)
+	assert.Equal(t, domain.PrintJobStatusSpooled, second.Status)
+
+	// Still offline: nothing prints and both jobs stay queued
+	printed, err := router.DrainSpool(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, printed)
+	assert.Equal(t, 2, first.Attempts)
+
+	printer := startFakePrinter(t, address)
+	printed, err = router.DrainSpool(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, printed)
+	assert.Equal(t, domain.PrintJobStatusPrinted, first.Status)
+	assert.Equal(t, domain.PrintJobStatusPrinted, second.Status)
+	assert.Eventually(t, func() bool {
+		received := printer.Received()
+		return len(received) == 2 && received[0] == "first" && received[1] == "second"
+	}, time.Second, 10*time.Millisecond)
+
+	// The printer accepts new jobs directly once its spool is empty
+	third, err := router.Print(ctx, domain.PrintRequest{PrinterID: "PRN-01", Format: "ZPL", Data: []byte("third")})
+	require.NoError(t, err)
+	assert.Equal(t, domain.PrintJobStatusPrinted, third.Status)
+}
+
+func TestRouter_RejectsNonZPLLabels(t *testing.T) {
+	printer := startFakePrinter(t, "127.0.0.1:0")
+	spool := newFakeSpool()
+	router := newTestRouter(spool, Printer{PrinterID: "PRN-01", Address: printer.listener.Addr().String()})
+	ctx := context.Background()
+
+	_, err := router.Print(ctx, domain.PrintRequest{PrinterID: "PRN-01", Format: "PDF", Data: []byte("%PDF-1.4")})
+	assert.ErrorIs(t, err, domain.ErrLabelNotPrintable)
+	_, err = router.Print(ctx, domain.PrintRequest{PrinterID: "PRN-01", Data: []byte("%PDF-1.4")})
+	assert.ErrorIs(t, err, domain.ErrLabelNotPrintable)
+	assert.Empty(t, spool.jobs)
+
+	// A PDF job left in the spool is dropped instead of being sent
+	legacy := domain.NewPrintJob("PJ-legacy", "PRN-01", domain.PrintRequest{Format: "PDF", Data: []byte("%PDF-1.4")})
+	legacy.MarkSpooled(nil)
+	require.NoError(t, spool.Save(ctx, legacy))
+
+	printed, err := router.DrainSpool(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, printed)
+	assert.Equal(t, domain.PrintJobStatusFailed, legacy.Status)
+	assert.Equal(t, domain.ErrLabelNotPrintable.Error(), legacy.LastError)
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, printer.Received())
+}
+
+func TestRouter_DrainSkipsJobsClaimedByAnotherReplica(t *testing.T) {
+	address := unusedAddress(t)
+	spool := newFakeSpool()
+	router := newTestRouter(spool, Printer{PrinterID: "PRN-01", Address: address})
+	ctx := context.Background()
+
+	first, err := router.Print(ctx, domain.PrintRequest{PrinterID: "PRN-01", Format: "ZPL", Data: []byte("first")})
+	require.NoError(t, err)
+	second, err := router.Print(ctx, domain.PrintRequest{PrinterID: "PRN-01", Format: "ZPL", Data: []byte("second")})
+	require.NoError(t, err)
+
+	// Another replica is sending the oldest job, so this one leaves the printer's spool alone
+	claimed, err := spool.Claim(ctx, first, "other-replica", time.Minute)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	printer := startFakePrinter(t, address)
+	printed, err := router.DrainSpool(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, printed)
+	assert.Equal(t, domain.PrintJobStatusSpooled, second.Status)
+
+	// Once the other replica's claim lapses the jobs are sent here, in order and once each
+	expired := time.Now().Add(-time.Second)
+	first.ClaimExpiresAt = &expired
+	printed, err = router.DrainSpool(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, printed)
+	assert.Empty(t, first.ClaimedBy)
+	assert.Nil(t, first.ClaimExpiresAt)
+	assert.Eventually(t, func() bool {
+		received := printer.Received()
+		return len(received) == 2 && received[0] == "first" && received[1] == "second"
+	}, time.Second, 10*time.Millisecond)
+
+	printed, err = router.DrainSpool(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, printed)
+}
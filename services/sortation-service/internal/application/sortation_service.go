@@ -8,6 +8,7 @@ import (
 	"github.com/wms-platform/services/sortation-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 )
 
 // SortationService implements the application layer for sortation operations
@@ -69,14 +70,6 @@ type AddPackageCommand struct {
 
 // AddPackage adds a package to a batch
 func (s *SortationService) AddPackage(ctx context.Context, cmd AddPackageCommand) (*domain.SortationBatch, error) {
-	batch, err := s.batchRepo.FindByID(ctx, cmd.BatchID)
-	if err != nil {
-		return nil, errors.ErrInternal("failed to find batch").Wrap(err)
-	}
-	if batch == nil {
-		return nil, errors.ErrNotFound("batch")
-	}
-
 	pkg := domain.SortedPackage{
 		PackageID:      cmd.PackageID,
 		OrderID:        cmd.OrderID,
@@ -86,12 +79,14 @@ func (s *SortationService) AddPackage(ctx context.Context, cmd AddPackageCommand
 		Weight:         cmd.Weight,
 	}
 
-	if err := batch.AddPackage(pkg); err != nil {
-		return nil, errors.ErrValidation("cannot add package").Wrap(err)
-	}
-
-	if err := s.batchRepo.Save(ctx, batch); err != nil {
-		return nil, errors.ErrInternal("failed to save batch").Wrap(err)
+	batch, err := s.updateBatch(ctx, cmd.BatchID, func(batch *domain.SortationBatch) error {
+		if err := batch.AddPackage(pkg); err != nil {
+			return errors.ErrValidation("cannot add package").Wrap(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return batch, nil
@@ -107,25 +102,19 @@ type SortPackageCommand struct {
 
 // SortPackage sorts a package to a chute
 func (s *SortationService) SortPackage(ctx context.Context, cmd SortPackageCommand) (*domain.SortationBatch, error) {
-	batch, err := s.batchRepo.FindByID(ctx, cmd.BatchID)
+	batch, err := s.updateBatch(ctx, cmd.BatchID, func(batch *domain.SortationBatch) error {
+		if err := batch.SortPackage(cmd.PackageID, cmd.ChuteID, cmd.WorkerID); err != nil {
+			return errors.ErrValidation("cannot sort package").Wrap(err)
+		}
+
+		// Auto-mark as ready if all packages sorted
+		if batch.IsFullySorted() && batch.Status == domain.SortationStatusSorting {
+			_ = batch.MarkReady()
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, errors.ErrInternal("failed to find batch").Wrap(err)
-	}
-	if batch == nil {
-		return nil, errors.ErrNotFound("batch")
-	}
-
-	if err := batch.SortPackage(cmd.PackageID, cmd.ChuteID, cmd.WorkerID); err != nil {
-		return nil, errors.ErrValidation("cannot sort package").Wrap(err)
-	}
-
-	// Auto-mark as ready if all packages sorted
-	if batch.IsFullySorted() && batch.Status == domain.SortationStatusSorting {
-		_ = batch.MarkReady()
-	}
-
-	if err := s.batchRepo.Save(ctx, batch); err != nil {
-		return nil, errors.ErrInternal("failed to save batch").Wrap(err)
+		return nil, err
 	}
 
 	s.logger.Info("Sorted package",
@@ -145,20 +134,14 @@ type MarkReadyCommand struct {
 
 // MarkReady marks a batch as ready for dispatch
 func (s *SortationService) MarkReady(ctx context.Context, cmd MarkReadyCommand) (*domain.SortationBatch, error) {
-	batch, err := s.batchRepo.FindByID(ctx, cmd.BatchID)
+	batch, err := s.updateBatch(ctx, cmd.BatchID, func(batch *domain.SortationBatch) error {
+		if err := batch.MarkReady(); err != nil {
+			return errors.ErrValidation("cannot mark batch ready").Wrap(err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, errors.ErrInternal("failed to find batch").Wrap(err)
-	}
-	if batch == nil {
-		return nil, errors.ErrNotFound("batch")
-	}
-
-	if err := batch.MarkReady(); err != nil {
-		return nil, errors.ErrValidation("cannot mark batch ready").Wrap(err)
-	}
-
-	if err := s.batchRepo.Save(ctx, batch); err != nil {
-		return nil, errors.ErrInternal("failed to save batch").Wrap(err)
+		return nil, err
 	}
 
 	return batch, nil
@@ -173,26 +156,20 @@ type DispatchBatchCommand struct {
 
 // DispatchBatch dispatches a batch
 func (s *SortationService) DispatchBatch(ctx context.Context, cmd DispatchBatchCommand) (*domain.SortationBatch, error) {
-	batch, err := s.batchRepo.FindByID(ctx, cmd.BatchID)
+	batch, err := s.updateBatch(ctx, cmd.BatchID, func(batch *domain.SortationBatch) error {
+		// Assign to trailer first
+		if err := batch.AssignToTrailer(cmd.TrailerID, cmd.DispatchDock); err != nil {
+			return errors.ErrValidation("cannot assign to trailer").Wrap(err)
+		}
+
+		// Then dispatch
+		if err := batch.Dispatch(); err != nil {
+			return errors.ErrValidation("cannot dispatch batch").Wrap(err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, errors.ErrInternal("failed to find batch").Wrap(err)
-	}
-	if batch == nil {
-		return nil, errors.ErrNotFound("batch")
-	}
-
-	// Assign to trailer first
-	if err := batch.AssignToTrailer(cmd.TrailerID, cmd.DispatchDock); err != nil {
-		return nil, errors.ErrValidation("cannot assign to trailer").Wrap(err)
-	}
-
-	// Then dispatch
-	if err := batch.Dispatch(); err != nil {
-		return nil, errors.ErrValidation("cannot dispatch batch").Wrap(err)
-	}
-
-	if err := s.batchRepo.Save(ctx, batch); err != nil {
-		return nil, errors.ErrInternal("failed to save batch").Wrap(err)
+		return nil, err
 	}
 
 	s.logger.Info("Dispatched batch",
@@ -205,6 +182,45 @@ func (s *SortationService) DispatchBatch(ctx context.Context, cmd DispatchBatchC
 	return batch, nil
 }
 
+// updateBatch loads a batch, applies change and saves it. A save that loses a race with
+// another writer, e.g. two sorters scanning into the same batch, reloads the batch and
+// applies change again.
+func (s *SortationService) updateBatch(ctx context.Context, batchID string, change func(batch *domain.SortationBatch) error) (*domain.SortationBatch, error) {
+	var batch *domain.SortationBatch
+
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.batchRepo.FindByID(ctx, batchID)
+		if err != nil {
+			return errors.ErrInternal("failed to find batch").Wrap(err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("batch")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.batchRepo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				s.logger.Debug("Batch changed concurrently, retrying", "batchId", batchID)
+				return err
+			}
+			return errors.ErrInternal("failed to save batch").Wrap(err)
+		}
+
+		batch = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("batch %s was modified concurrently, please retry", batchID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
 // GetBatch retrieves a batch by ID
 func (s *SortationService) GetBatch(ctx context.Context, batchID string) (*domain.SortationBatch, error) {
 	batch, err := s.batchRepo.FindByID(ctx, batchID)
@@ -122,6 +122,7 @@ type SortationBatch struct {
 	CreatedAt        time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt        time.Time          `bson:"updatedAt" json:"updatedAt"`
 	DispatchedAt     *time.Time         `bson:"dispatchedAt,omitempty" json:"dispatchedAt,omitempty"`
+	Version          int                `bson:"version" json:"version"` // Incremented on every save for optimistic concurrency
 	DomainEvents     []DomainEvent      `bson:"-" json:"-"`
 }
 
@@ -1,6 +1,7 @@
 package mongodb
 
 import (
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
 	"context"
 	"fmt"
@@ -54,9 +55,12 @@ func (r *SortationBatchRepository) ensureIndexes(ctx context.Context) {
 	_ = r.outboxRepo.EnsureIndexes(ctx)
 }
 
-// Save persists a sortation batch with its domain events in a single transaction
+// Save persists a sortation batch with its domain events in a single transaction. A batch
+// saved by another writer since it was loaded fails with ErrConcurrencyConflict.
 func (r *SortationBatchRepository) Save(ctx context.Context, batch *domain.SortationBatch) error {
 	batch.UpdatedAt = time.Now()
+	expectedVersion := batch.Version
+	batch.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -68,11 +72,12 @@ func (r *SortationBatchRepository) Save(ctx context.Context, batch *domain.Sorta
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"batchId": batch.BatchID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"batchId": batch.BatchID}, expectedVersion)
 		update := bson.M{"$set": batch}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "SortationBatch", batch.BatchID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save sortation batch: %w", err)
 		}
 
@@ -126,6 +131,7 @@ func (r *SortationBatchRepository) Save(ctx context.Context, batch *domain.Sorta
 	})
 
 	if err != nil {
+		batch.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -19,6 +21,7 @@ import (
 	"github.com/wms-platform/shared/pkg/middleware"
 	"github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
+	"github.com/wms-platform/shared/pkg/tenant"
 	"github.com/wms-platform/shared/pkg/tracing"
 
 	"github.com/wms-platform/services/stow-service/internal/application"
@@ -110,6 +113,32 @@ func main() {
 	// Initialize application service
 	stowService := application.NewStowService(taskRepo, locationRepo, logger)
 
+	// Keep location capacity current as inventory-service moves stock between locations.
+	// Each change is applied once per event, so redelivered events aren't counted twice.
+	// Events the consumer gives up on are dead-lettered and can be inspected and replayed via the DLQ admin.
+	var dlqAdmin *kafka.DLQAdmin
+	if config.CapacitySyncEnabled {
+		dlqAdmin = kafka.NewDLQAdmin(config.Kafka, logger.Logger)
+		defer dlqAdmin.Close()
+
+		inventoryConsumer := kafka.NewConsumer(config.Kafka, logger.Logger)
+		inventoryConsumer.Subscribe(kafka.Topics.InventoryEvents, cloudevents.StockMoved, stockMovedHandler(stowService))
+		inventoryConsumer.Subscribe(kafka.Topics.InventoryEvents, cloudevents.StockMoveStarted, stockMoveStartedHandler(stowService))
+		defer inventoryConsumer.Close()
+
+		consumerCtx, cancelConsumer := context.WithCancel(ctx)
+		defer cancelConsumer()
+		go func() {
+			if err := inventoryConsumer.Start(consumerCtx); err != nil && err != context.Canceled {
+				logger.WithError(err).Error("Inventory events consumer stopped")
+			}
+		}()
+		logger.Info("Inventory events consumer started",
+			"topic", kafka.Topics.InventoryEvents,
+			"group", config.Kafka.ConsumerGroup,
+		)
+	}
+
 	// Setup Gin router with middleware
 	router := gin.New()
 
@@ -137,22 +166,7 @@ func main() {
 	router.GET("/metrics", middleware.MetricsEndpoint(m))
 
 	// API v1 routes with tenant context required
-	api := router.Group("/api/v1/tasks")
-	api.Use(middleware.RequireTenantAuth()) // All API routes require tenant headers
-	{
-		api.GET("", listTasksHandler(stowService, logger))
-		api.POST("", createTaskHandler(stowService, logger))
-		api.GET("/pending", getPendingTasksHandler(stowService, logger))
-		api.GET("/status/:status", getTasksByStatusHandler(stowService, logger))
-		api.GET("/worker/:workerId", getTasksByWorkerHandler(stowService, logger))
-		api.GET("/shipment/:shipmentId", getTasksByShipmentHandler(stowService, logger))
-		api.GET("/:taskId", getTaskHandler(stowService, logger))
-		api.POST("/:taskId/assign", assignTaskHandler(stowService, logger))
-		api.POST("/:taskId/start", startTaskHandler(stowService, logger))
-		api.POST("/:taskId/stow", recordStowHandler(stowService, logger))
-		api.POST("/:taskId/complete", completeTaskHandler(stowService, logger))
-		api.POST("/:taskId/fail", failTaskHandler(stowService, logger))
-	}
+	registerRoutes(router, stowService, dlqAdmin, logger)
 
 	// Start server
 	srv := &http.Server{
@@ -186,11 +200,45 @@ func main() {
 	logger.Info("Server stopped")
 }
 
+// registerRoutes registers the API v1 routes, all of which require tenant headers.
+// The DLQ admin is only mounted when the capacity consumer runs.
+func registerRoutes(router *gin.Engine, stowService *application.StowService, dlqAdmin *kafka.DLQAdmin, logger *logging.Logger) {
+	api := router.Group("/api/v1/tasks")
+	api.Use(middleware.RequireTenantAuth()) // All API routes require tenant headers
+	{
+		api.GET("", listTasksHandler(stowService, logger))
+		api.POST("", createTaskHandler(stowService, logger))
+		api.GET("/pending", getPendingTasksHandler(stowService, logger))
+		api.GET("/status/:status", getTasksByStatusHandler(stowService, logger))
+		api.GET("/worker/:workerId", getTasksByWorkerHandler(stowService, logger))
+		api.GET("/shipment/:shipmentId", getTasksByShipmentHandler(stowService, logger))
+		api.GET("/:taskId", getTaskHandler(stowService, logger))
+		api.POST("/:taskId/assign", assignTaskHandler(stowService, logger))
+		api.POST("/:taskId/start", startTaskHandler(stowService, logger))
+		api.POST("/:taskId/stow", recordStowHandler(stowService, logger))
+		api.POST("/:taskId/complete", completeTaskHandler(stowService, logger))
+		api.POST("/:taskId/fail", failTaskHandler(stowService, logger))
+	}
+
+	locations := router.Group("/api/v1/locations")
+	locations.Use(middleware.RequireTenantAuth())
+	{
+		locations.POST("/:locationId/capacity", adjustLocationCapacityHandler(stowService, logger))
+	}
+
+	if dlqAdmin != nil {
+		admin := router.Group("/api/v1/admin")
+		admin.Use(middleware.RequireTenantAuth())
+		kafka.NewDLQAdminHandler(dlqAdmin, logger.Logger).RegisterRoutes(admin)
+	}
+}
+
 // Config holds application configuration
 type Config struct {
-	ServerAddr string
-	MongoDB    *mongodb.Config
-	Kafka      *kafka.Config
+	ServerAddr          string
+	MongoDB             *mongodb.Config
+	Kafka               *kafka.Config
+	CapacitySyncEnabled bool
 }
 
 func loadConfig() *Config {
@@ -211,6 +259,7 @@ func loadConfig() *Config {
 			BatchTimeout:  10 * time.Millisecond,
 			RequiredAcks:  -1,
 		},
+		CapacitySyncEnabled: getEnv("CAPACITY_SYNC_ENABLED", "true") == "true",
 	}
 }
 
@@ -569,3 +618,113 @@ func failTaskHandler(service *application.StowService, logger *logging.Logger) g
 		c.JSON(http.StatusOK, task)
 	}
 }
+
+func adjustLocationCapacityHandler(service *application.StowService, logger *logging.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		responder := middleware.NewErrorResponder(c, logger.Logger)
+
+		locationID := c.Param("locationId")
+
+		var req struct {
+			QuantityChange int     `json:"quantityChange"`
+			WeightChange   float64 `json:"weightChange"`
+		}
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		middleware.AddSpanAttributes(c, map[string]interface{}{
+			"location.id":     locationID,
+			"quantity.change": req.QuantityChange,
+		})
+
+		cmd := application.AdjustLocationCapacityCommand{
+			LocationID:     locationID,
+			QuantityChange: req.QuantityChange,
+			WeightChange:   req.WeightChange,
+		}
+
+		location, err := service.AdjustLocationCapacity(c.Request.Context(), cmd)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				responder.RespondWithAppError(appErr)
+			} else {
+				responder.RespondInternalError(err)
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, location)
+	}
+}
+
+// Inventory event handlers
+
+// stockMovedHandler moves a location transfer's quantity and weight from the source location to
+// the destination. Stock dropped from a cart or tote left its source when the move started.
+func stockMovedHandler(service *application.StowService) kafka.EventHandler {
+	return func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
+		var data cloudevents.StockMovedData
+		if err := decodeEventData(event, &data); err != nil {
+			return fmt.Errorf("failed to decode stock moved event data: %w", err)
+		}
+
+		ctx = eventTenantContext(ctx, event)
+		weight := data.UnitWeight * float64(data.Quantity)
+		if data.MoveID == "" {
+			if err := applyCapacityChange(ctx, service, event.ID, data.FromLocationID, -data.Quantity, -weight); err != nil {
+				return err
+			}
+		}
+		return applyCapacityChange(ctx, service, event.ID, data.ToLocationID, data.Quantity, weight)
+	}
+}
+
+// stockMoveStartedHandler frees the source location of stock picked onto a cart or tote
+func stockMoveStartedHandler(service *application.StowService) kafka.EventHandler {
+	return func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
+		var data cloudevents.StockMoveStartedData
+		if err := decodeEventData(event, &data); err != nil {
+			return fmt.Errorf("failed to decode stock move started event data: %w", err)
+		}
+
+		ctx = eventTenantContext(ctx, event)
+		weight := data.UnitWeight * float64(data.Quantity)
+		return applyCapacityChange(ctx, service, event.ID, data.FromLocationID, -data.Quantity, -weight)
+	}
+}
+
+// applyCapacityChange applies an event's change to one location. Carts, totes and other
+// locations stow-service doesn't manage are skipped.
+func applyCapacityChange(ctx context.Context, service *application.StowService, eventID, locationID string, quantity int, weight float64) error {
+	_, err := service.AdjustLocationCapacity(ctx, application.AdjustLocationCapacityCommand{
+		LocationID:     locationID,
+		QuantityChange: quantity,
+		WeightChange:   weight,
+		ChangeID:       eventID,
+	})
+	if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.CodeNotFound {
+		return nil
+	}
+	return err
+}
+
+func decodeEventData(event *cloudevents.WMSCloudEvent, data interface{}) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, data)
+}
+
+// eventTenantContext scopes the handler to the event's tenant, facility and warehouse. Storage
+// locations are shared by the sellers of a warehouse, so the seller is left out.
+func eventTenantContext(ctx context.Context, event *cloudevents.WMSCloudEvent) context.Context {
+	return tenant.ToContext(ctx, &tenant.Context{
+		TenantID:    event.TenantID,
+		FacilityID:  event.FacilityID,
+		WarehouseID: event.WarehouseID,
+	})
+}
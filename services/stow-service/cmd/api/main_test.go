@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/wms-platform/shared/pkg/cloudevents"
+	"github.com/wms-platform/shared/pkg/kafka"
+	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/services/stow-service/internal/application"
+	"github.com/wms-platform/services/stow-service/internal/domain"
+)
+
+// memoryLocationRepo keeps locations in memory and, like the Mongo repository,
+// applies each capacity change ID to a location only once
+type memoryLocationRepo struct {
+	locations map[string]*domain.StorageLocation
+	applied   map[string]map[string]bool
+	failNext  map[string]error // locationID -> error returned by the next change to it
+}
+
+func newMemoryLocationRepo(locations ...domain.StorageLocation) *memoryLocationRepo {
+	repo := &memoryLocationRepo{
+		locations: make(map[string]*domain.StorageLocation),
+		applied:   make(map[string]map[string]bool),
+		failNext:  make(map[string]error),
+	}
+	for i := range locations {
+		location := locations[i]
+		repo.locations[location.LocationID] = &location
+	}
+	return repo
+}
+
+func (r *memoryLocationRepo) FindAvailableLocations(ctx context.Context, constraints domain.LocationConstraints, limit int) ([]domain.StorageLocation, error) {
+	return nil, nil
+}
+
+func (r *memoryLocationRepo) FindByID(ctx context.Context, locationID string) (*domain.StorageLocation, error) {
+	location, ok := r.locations[locationID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *location
+	return &copied, nil
+}
+
+func (r *memoryLocationRepo) FindByZone(ctx context.Context, zone string) ([]domain.StorageLocation, error) {
+	return nil, nil
+}
+
+func (r *memoryLocationRepo) UpdateCapacity(ctx context.Context, locationID string, quantityChange int, weightChange float64) error {
+	if err := r.failNext[locationID]; err != nil {
+		delete(r.failNext, locationID)
+		return err
+	}
+	r.locations[locationID].CurrentQuantity += quantityChange
+	r.locations[locationID].CurrentWeight += weightChange
+	return nil
+}
+
+func (r *memoryLocationRepo) ApplyCapacityChange(ctx context.Context, locationID, changeID string, quantityChange int, weightChange float64) (bool, error) {
+	if r.applied[locationID][changeID] {
+		return false, nil
+	}
+	if err := r.UpdateCapacity(ctx, locationID, quantityChange, weightChange); err != nil {
+		return false, err
+	}
+	if r.applied[locationID] == nil {
+		r.applied[locationID] = make(map[string]bool)
+	}
+	r.applied[locationID][changeID] = true
+	return true, nil
+}
+
+func testLogger() *logging.Logger {
+	config := logging.DefaultConfig(serviceName)
+	config.Output = io.Discard
+	return logging.New(config)
+}
+
+func newTestService(repo *memoryLocationRepo) *application.StowService {
+	return application.NewStowService(nil, repo, testLogger())
+}
+
+func testLocations() []domain.StorageLocation {
+	return []domain.StorageLocation{
+		{LocationID: "LOC-A", Capacity: 100, CurrentQuantity: 10, MaxWeight: 500, CurrentWeight: 20},
+		{LocationID: "LOC-B", Capacity: 100, MaxWeight: 500},
+	}
+}
+
+func stockMovedEvent(id string, data cloudevents.StockMovedData) *cloudevents.WMSCloudEvent {
+	return &cloudevents.WMSCloudEvent{ID: id, Type: cloudevents.StockMoved, TenantID: "TNT-001", FacilityID: "FAC-001", WarehouseID: "WH-001", Data: data}
+}
+
+func stockMoveStartedEvent(id string, data cloudevents.StockMoveStartedData) *cloudevents.WMSCloudEvent {
+	return &cloudevents.WMSCloudEvent{ID: id, Type: cloudevents.StockMoveStarted, TenantID: "TNT-001", FacilityID: "FAC-001", WarehouseID: "WH-001", Data: data}
+}
+
+func assertCapacity(t *testing.T, repo *memoryLocationRepo, locationID string, quantity int, weight float64) {
+	t.Helper()
+	location := repo.locations[locationID]
+	if location.CurrentQuantity != quantity || location.CurrentWeight != weight {
+		t.Errorf("%s: got quantity %d weight %.1f, want quantity %d weight %.1f",
+			locationID, location.CurrentQuantity, location.CurrentWeight, quantity, weight)
+	}
+}
+
+func TestStockMovedHandler(t *testing.T) {
+	ctx := context.Background()
+	moved := cloudevents.StockMovedData{SKU: "SKU-001", FromLocationID: "LOC-A", ToLocationID: "LOC-B", Quantity: 4, UnitWeight: 1.5}
+
+	t.Run("moves capacity between locations", func(t *testing.T) {
+		repo := newMemoryLocationRepo(testLocations()...)
+
+		if err := stockMovedHandler(newTestService(repo))(ctx, stockMovedEvent("evt-1", moved)); err != nil {
+			t.Fatalf("handler failed: %v", err)
+		}
+
+		assertCapacity(t, repo, "LOC-A", 6, 14)
+		assertCapacity(t, repo, "LOC-B", 4, 6)
+	})
+
+	t.Run("redelivered event is applied once", func(t *testing.T) {
+		repo := newMemoryLocationRepo(testLocations()...)
+		handler := stockMovedHandler(newTestService(repo))
+
+		for i := 0; i < 3; i++ {
+			if err := handler(ctx, stockMovedEvent("evt-1", moved)); err != nil {
+				t.Fatalf("delivery %d failed: %v", i+1, err)
+			}
+		}
+
+		assertCapacity(t, repo, "LOC-A", 6, 14)
+		assertCapacity(t, repo, "LOC-B", 4, 6)
+	})
+
+	t.Run("retry after a failed write only applies the missing change", func(t *testing.T) {
+		repo := newMemoryLocationRepo(testLocations()...)
+		repo.failNext["LOC-B"] = errors.New("write failed")
+		handler := stockMovedHandler(newTestService(repo))
+
+		if err := handler(ctx, stockMovedEvent("evt-1", moved)); err == nil {
+			t.Fatal("expected the failed write to be returned so the event is retried")
+		}
+		if err := handler(ctx, stockMovedEvent("evt-1", moved)); err != nil {
+			t.Fatalf("retry failed: %v", err)
+		}
+
+		assertCapacity(t, repo, "LOC-A", 6, 14)
+		assertCapacity(t, repo, "LOC-B", 4, 6)
+	})
+
+	t.Run("locations stow-service does not manage are skipped", func(t *testing.T) {
+		repo := newMemoryLocationRepo(testLocations()...)
+		toCart := moved
+		toCart.ToLocationID = "CART-7"
+
+		if err := stockMovedHandler(newTestService(repo))(ctx, stockMovedEvent("evt-1", toCart)); err != nil {
+			t.Fatalf("handler failed: %v", err)
+		}
+
+		assertCapacity(t, repo, "LOC-A", 6, 14)
+	})
+}
+
+func TestTwoStepMoveEvents(t *testing.T) {
+	ctx := context.Background()
+	started := stockMoveStartedEvent("evt-start", cloudevents.StockMoveStartedData{
+		SKU: "SKU-001", MoveID: "MOVE-1", ContainerID: "TOTE-1", FromLocationID: "LOC-A", Quantity: 4, UnitWeight: 1.5,
+	})
+	dropped := stockMovedEvent("evt-drop", cloudevents.StockMovedData{
+		SKU: "SKU-001", FromLocationID: "TOTE-1", ToLocationID: "LOC-B", Quantity: 4, UnitWeight: 1.5, MoveID: "MOVE-1",
+	})
+
+	orders := map[string][]*cloudevents.WMSCloudEvent{
+		"in order":     {started, dropped},
+		"out of order": {dropped, started},
+		"redelivered":  {dropped, started, dropped, started},
+	}
+	for name, events := range orders {
+		t.Run(name, func(t *testing.T) {
+			repo := newMemoryLocationRepo(testLocations()...)
+			service := newTestService(repo)
+
+			for _, event := range events {
+				handler := stockMovedHandler(service)
+				if event.Type == cloudevents.StockMoveStarted {
+					handler = stockMoveStartedHandler(service)
+				}
+				if err := handler(ctx, event); err != nil {
+					t.Fatalf("%s failed: %v", event.ID, err)
+				}
+			}
+
+			assertCapacity(t, repo, "LOC-A", 6, 14)
+			assertCapacity(t, repo, "LOC-B", 4, 6)
+		})
+	}
+}
+
+func TestAdjustLocationCapacityHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		locationID string
+		body       string
+		wantStatus int
+		wantQty    int
+	}{
+		{name: "adjusts capacity", locationID: "LOC-A", body: `{"quantityChange": 5, "weightChange": 2.5}`, wantStatus: http.StatusOK, wantQty: 15},
+		{name: "unknown location", locationID: "LOC-X", body: `{"quantityChange": 5}`, wantStatus: http.StatusNotFound},
+		{name: "invalid body", locationID: "LOC-A", body: `{"quantityChange": "five"}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryLocationRepo(testLocations()...)
+			router := gin.New()
+			registerRoutes(router, newTestService(repo), nil, testLogger())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/locations/"+tt.locationID+"/capacity", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(middleware.HeaderWMSTenantID, "TNT-001")
+			req.Header.Set(middleware.HeaderWMSFacilityID, "FAC-001")
+			req.Header.Set(middleware.HeaderWMSWarehouseID, "WH-001")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("got status %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var location domain.StorageLocation
+			if err := json.Unmarshal(rec.Body.Bytes(), &location); err != nil {
+				t.Fatalf("invalid response: %v", err)
+			}
+			if location.CurrentQuantity != tt.wantQty {
+				t.Errorf("got quantity %d, want %d", location.CurrentQuantity, tt.wantQty)
+			}
+		})
+	}
+}
+
+func TestRegisterRoutesDLQAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := newTestService(newMemoryLocationRepo())
+
+	hasRoute := func(router *gin.Engine, method, path string) bool {
+		for _, route := range router.Routes() {
+			if route.Method == method && route.Path == path {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("mounted with the capacity consumer", func(t *testing.T) {
+		admin := kafka.NewDLQAdmin(&kafka.Config{Brokers: []string{"localhost:9092"}}, nil)
+		defer admin.Close()
+		router := gin.New()
+		registerRoutes(router, service, admin, testLogger())
+
+		for _, route := range []struct{ method, path string }{
+			{http.MethodGet, "/api/v1/admin/dlq/:topic/messages"},
+			{http.MethodPost, "/api/v1/admin/dlq/:topic/replay"},
+			{http.MethodPost, "/api/v1/admin/dlq/:topic/replay-all"},
+		} {
+			if !hasRoute(router, route.method, route.path) {
+				t.Errorf("missing route %s %s", route.method, route.path)
+			}
+		}
+
+		// Like the rest of the API, the admin requires tenant headers
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/dlq/wms.inventory.events/messages", nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("got status %d without tenant headers, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("not mounted without it", func(t *testing.T) {
+		router := gin.New()
+		registerRoutes(router, service, nil, testLogger())
+
+		if hasRoute(router, http.MethodGet, "/api/v1/admin/dlq/:topic/messages") {
+			t.Error("DLQ admin mounted without the capacity consumer")
+		}
+	})
+}
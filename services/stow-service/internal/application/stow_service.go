@@ -9,6 +9,7 @@ import (
 	"github.com/wms-platform/services/stow-service/internal/domain"
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 )
 
 // StowService implements the application layer for stow operations
@@ -106,33 +107,27 @@ type AssignTaskCommand struct {
 
 // AssignTask assigns a task to a worker and finds a storage location
 func (s *StowService) AssignTask(ctx context.Context, cmd AssignTaskCommand) (*domain.PutawayTask, error) {
-	task, err := s.taskRepo.FindByID(ctx, cmd.TaskID)
-	if err != nil {
-		return nil, errors.ErrInternal("failed to find task").Wrap(err)
-	}
-	if task == nil {
-		return nil, errors.ErrNotFound("task")
-	}
-
-	// Assign worker
-	if err := task.AssignToWorker(cmd.WorkerID); err != nil {
-		return nil, errors.ErrValidation("cannot assign task").Wrap(err)
-	}
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PutawayTask) error {
+		// Assign worker
+		if err := task.AssignToWorker(cmd.WorkerID); err != nil {
+			return errors.ErrValidation("cannot assign task").Wrap(err)
+		}
 
-	// Find storage location based on strategy
-	if task.TargetLocationID == "" {
-		location, err := s.findStorageLocation(ctx, task)
-		if err != nil {
-			s.logger.WithError(err).Warn("Failed to find storage location", "taskId", task.TaskID)
-		} else if location != nil {
-			if err := task.AssignLocation(*location); err != nil {
-				s.logger.WithError(err).Warn("Failed to assign location", "taskId", task.TaskID)
+		// Find storage location based on strategy
+		if task.TargetLocationID == "" {
+			location, err := s.findStorageLocation(ctx, task)
+			if err != nil {
+				s.logger.WithError(err).Warn("Failed to find storage location", "taskId", task.TaskID)
+			} else if location != nil {
+				if err := task.AssignLocation(*location); err != nil {
+					s.logger.WithError(err).Warn("Failed to assign location", "taskId", task.TaskID)
+				}
 			}
 		}
-	}
-
-	if err := s.taskRepo.Save(ctx, task); err != nil {
-		return nil, errors.ErrInternal("failed to save task").Wrap(err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	s.logger.Info("Assigned putaway task",
@@ -180,43 +175,37 @@ type StartTaskCommand struct {
 
 // StartTask starts a putaway task
 func (s *StowService) StartTask(ctx context.Context, cmd StartTaskCommand) (*domain.PutawayTask, error) {
-	task, err := s.taskRepo.FindByID(ctx, cmd.TaskID)
-	if err != nil {
-		return nil, errors.ErrInternal("failed to find task").Wrap(err)
-	}
-	if task == nil {
-		return nil, errors.ErrNotFound("task")
-	}
-
-	// Auto-assign if still pending (for simulator compatibility)
-	if task.Status == domain.PutawayStatusPending {
-		workerID := "SYSTEM"
-		if err := task.AssignToWorker(workerID); err != nil {
-			return nil, errors.ErrValidation("cannot assign task").Wrap(err)
-		}
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PutawayTask) error {
+		// Auto-assign if still pending (for simulator compatibility)
+		if task.Status == domain.PutawayStatusPending {
+			workerID := "SYSTEM"
+			if err := task.AssignToWorker(workerID); err != nil {
+				return errors.ErrValidation("cannot assign task").Wrap(err)
+			}
 
-		// Try to find a location if not assigned
-		if task.TargetLocationID == "" {
-			location, err := s.findStorageLocation(ctx, task)
-			if err == nil && location != nil {
-				if err := task.AssignLocation(*location); err != nil {
-					s.logger.WithError(err).Warn("Failed to assign location", "taskId", task.TaskID)
+			// Try to find a location if not assigned
+			if task.TargetLocationID == "" {
+				location, err := s.findStorageLocation(ctx, task)
+				if err == nil && location != nil {
+					if err := task.AssignLocation(*location); err != nil {
+						s.logger.WithError(err).Warn("Failed to assign location", "taskId", task.TaskID)
+					}
 				}
 			}
 		}
-	}
-
-	// If still no location, assign a default one
-	if task.TargetLocationID == "" {
-		task.TargetLocationID = "LOC-DEFAULT-01"
-	}
 
-	if err := task.Start(); err != nil {
-		return nil, errors.ErrValidation("cannot start task").Wrap(err)
-	}
+		// If still no location, assign a default one
+		if task.TargetLocationID == "" {
+			task.TargetLocationID = "LOC-DEFAULT-01"
+		}
 
-	if err := s.taskRepo.Save(ctx, task); err != nil {
-		return nil, errors.ErrInternal("failed to save task").Wrap(err)
+		if err := task.Start(); err != nil {
+			return errors.ErrValidation("cannot start task").Wrap(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return task, nil
@@ -230,20 +219,14 @@ type RecordStowCommand struct {
 
 // RecordStow records stowing progress
 func (s *StowService) RecordStow(ctx context.Context, cmd RecordStowCommand) (*domain.PutawayTask, error) {
-	task, err := s.taskRepo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PutawayTask) error {
+		if err := task.RecordStow(cmd.Quantity); err != nil {
+			return errors.ErrValidation("cannot record stow").Wrap(err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, errors.ErrInternal("failed to find task").Wrap(err)
-	}
-	if task == nil {
-		return nil, errors.ErrNotFound("task")
-	}
-
-	if err := task.RecordStow(cmd.Quantity); err != nil {
-		return nil, errors.ErrValidation("cannot record stow").Wrap(err)
-	}
-
-	if err := s.taskRepo.Save(ctx, task); err != nil {
-		return nil, errors.ErrInternal("failed to save task").Wrap(err)
+		return nil, err
 	}
 
 	return task, nil
@@ -256,71 +239,65 @@ type CompleteTaskCommand struct {
 
 // CompleteTask completes a putaway task
 func (s *StowService) CompleteTask(ctx context.Context, cmd CompleteTaskCommand) (*domain.PutawayTask, error) {
-	task, err := s.taskRepo.FindByID(ctx, cmd.TaskID)
-	if err != nil {
-		return nil, errors.ErrInternal("failed to find task").Wrap(err)
-	}
-	if task == nil {
-		return nil, errors.ErrNotFound("task")
-	}
-
-	// Auto-transition through required states (for simulator compatibility)
-	if task.Status == domain.PutawayStatusPending {
-		// Assign to system worker
-		if err := task.AssignToWorker("SYSTEM"); err != nil {
-			return nil, errors.ErrValidation("cannot assign task").Wrap(err)
-		}
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PutawayTask) error {
+		// Auto-transition through required states (for simulator compatibility)
+		if task.Status == domain.PutawayStatusPending {
+			// Assign to system worker
+			if err := task.AssignToWorker("SYSTEM"); err != nil {
+				return errors.ErrValidation("cannot assign task").Wrap(err)
+			}
 
-		// Try to find location
-		if task.TargetLocationID == "" {
-			location, err := s.findStorageLocation(ctx, task)
-			if err == nil && location != nil {
-				if err := task.AssignLocation(*location); err != nil {
-					s.logger.WithError(err).Warn("Failed to assign location", "taskId", task.TaskID)
+			// Try to find location
+			if task.TargetLocationID == "" {
+				location, err := s.findStorageLocation(ctx, task)
+				if err == nil && location != nil {
+					if err := task.AssignLocation(*location); err != nil {
+						s.logger.WithError(err).Warn("Failed to assign location", "taskId", task.TaskID)
+					}
 				}
 			}
-		}
 
-		// Fallback to default location
-		if task.TargetLocationID == "" {
-			task.TargetLocationID = "LOC-DEFAULT-01"
+			// Fallback to default location
+			if task.TargetLocationID == "" {
+				task.TargetLocationID = "LOC-DEFAULT-01"
+			}
 		}
-	}
 
-	if task.Status == domain.PutawayStatusAssigned {
-		// Ensure location is assigned
-		if task.TargetLocationID == "" {
-			task.TargetLocationID = "LOC-DEFAULT-01"
-		}
+		if task.Status == domain.PutawayStatusAssigned {
+			// Ensure location is assigned
+			if task.TargetLocationID == "" {
+				task.TargetLocationID = "LOC-DEFAULT-01"
+			}
 
-		// Start the task
-		if err := task.Start(); err != nil {
-			return nil, errors.ErrValidation("cannot start task").Wrap(err)
+			// Start the task
+			if err := task.Start(); err != nil {
+				return errors.ErrValidation("cannot start task").Wrap(err)
+			}
 		}
-	}
 
-	// If not all items stowed yet, stow the remaining
-	if task.RemainingQuantity() > 0 {
-		if err := task.RecordStow(task.RemainingQuantity()); err != nil {
-			return nil, errors.ErrValidation("cannot record final stow").Wrap(err)
+		// If not all items stowed yet, stow the remaining
+		if task.RemainingQuantity() > 0 {
+			if err := task.RecordStow(task.RemainingQuantity()); err != nil {
+				return errors.ErrValidation("cannot record final stow").Wrap(err)
+			}
 		}
-	}
 
-	if err := task.Complete(); err != nil {
-		return nil, errors.ErrValidation("cannot complete task").Wrap(err)
+		if err := task.Complete(); err != nil {
+			return errors.ErrValidation("cannot complete task").Wrap(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Update location capacity
+	// Update location capacity once the completion is saved
 	if task.TargetLocationID != "" {
 		if err := s.locationRepo.UpdateCapacity(ctx, task.TargetLocationID, task.StowedQuantity, task.Constraints.Weight*float64(task.StowedQuantity)); err != nil {
 			s.logger.WithError(err).Warn("Failed to update location capacity", "locationId", task.TargetLocationID)
 		}
 	}
 
-	if err := s.taskRepo.Save(ctx, task); err != nil {
-		return nil, errors.ErrInternal("failed to save task").Wrap(err)
-	}
-
 	duration := "N/A"
 	if task.StartedAt != nil {
 		duration = time.Since(*task.StartedAt).String()
@@ -345,22 +322,54 @@ type FailTaskCommand struct {
 
 // FailTask marks a task as failed
 func (s *StowService) FailTask(ctx context.Context, cmd FailTaskCommand) (*domain.PutawayTask, error) {
-	task, err := s.taskRepo.FindByID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.PutawayTask) error {
+		if err := task.Fail(cmd.Reason); err != nil {
+			return errors.ErrValidation("cannot fail task").Wrap(err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, errors.ErrInternal("failed to find task").Wrap(err)
-	}
-	if task == nil {
-		return nil, errors.ErrNotFound("task")
+		return nil, err
 	}
 
-	if err := task.Fail(cmd.Reason); err != nil {
-		return nil, errors.ErrValidation("cannot fail task").Wrap(err)
-	}
+	return task, nil
+}
 
-	if err := s.taskRepo.Save(ctx, task); err != nil {
-		return nil, errors.ErrInternal("failed to save task").Wrap(err)
-	}
+// updateTask loads a putaway task, applies change and saves it. A save that loses a race
+// with another writer reloads the task and applies change again.
+func (s *StowService) updateTask(ctx context.Context, taskID string, change func(task *domain.PutawayTask) error) (*domain.PutawayTask, error) {
+	var task *domain.PutawayTask
+
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.taskRepo.FindByID(ctx, taskID)
+		if err != nil {
+			return errors.ErrInternal("failed to find task").Wrap(err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("task")
+		}
 
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.taskRepo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				s.logger.Debug("Putaway task changed concurrently, retrying", "taskId", taskID)
+				return err
+			}
+			return errors.ErrInternal("failed to save task").Wrap(err)
+		}
+
+		task = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("task %s was modified concurrently, please retry", taskID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
@@ -395,3 +404,46 @@ func (s *StowService) GetTasksByWorker(ctx context.Context, workerID string, pag
 func (s *StowService) GetTasksByShipment(ctx context.Context, shipmentID string) ([]*domain.PutawayTask, error) {
 	return s.taskRepo.FindByShipmentID(ctx, shipmentID)
 }
+
+// AdjustLocationCapacityCommand represents the command to adjust a location's used capacity
+// when stock is moved in or out of it outside a putaway task
+type AdjustLocationCapacityCommand struct {
+	LocationID     string
+	QuantityChange int
+	WeightChange   float64
+	ChangeID       string // Optional: applies the change only once, e.g. the ID of the event reporting it
+}
+
+// AdjustLocationCapacity applies a stock movement to a location's current quantity and weight
+func (s *StowService) AdjustLocationCapacity(ctx context.Context, cmd AdjustLocationCapacityCommand) (*domain.StorageLocation, error) {
+	location, err := s.locationRepo.FindByID(ctx, cmd.LocationID)
+	if err != nil {
+		return nil, errors.ErrInternal("failed to find location").Wrap(err)
+	}
+	if location == nil {
+		return nil, errors.ErrNotFound("location")
+	}
+
+	if cmd.ChangeID != "" {
+		applied, err := s.locationRepo.ApplyCapacityChange(ctx, cmd.LocationID, cmd.ChangeID, cmd.QuantityChange, cmd.WeightChange)
+		if err != nil {
+			return nil, errors.ErrInternal("failed to update location capacity").Wrap(err)
+		}
+		if !applied {
+			s.logger.Debug("Location capacity change already applied", "locationId", cmd.LocationID, "changeId", cmd.ChangeID)
+			return location, nil
+		}
+	} else if err := s.locationRepo.UpdateCapacity(ctx, cmd.LocationID, cmd.QuantityChange, cmd.WeightChange); err != nil {
+		return nil, errors.ErrInternal("failed to update location capacity").Wrap(err)
+	}
+	location.CurrentQuantity += cmd.QuantityChange
+	location.CurrentWeight += cmd.WeightChange
+
+	s.logger.Info("Adjusted location capacity",
+		"locationId", cmd.LocationID,
+		"quantityChange", cmd.QuantityChange,
+		"currentQuantity", location.CurrentQuantity,
+	)
+
+	return location, nil
+}
@@ -175,6 +175,7 @@ type PutawayTask struct {
 	CompletedAt      *time.Time         `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
 	CreatedAt        time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt        time.Time          `bson:"updatedAt" json:"updatedAt"`
+	Version          int                `bson:"version" json:"version"` // Incremented on every save for optimistic concurrency
 	DomainEvents     []DomainEvent      `bson:"-" json:"-"`
 }
 
@@ -50,6 +50,10 @@ type StorageLocationRepository interface {
 
 	// UpdateCapacity updates the location capacity
 	UpdateCapacity(ctx context.Context, locationID string, quantityChange int, weightChange float64) error
+
+	// ApplyCapacityChange updates the location capacity once per change ID; returns false
+	// when the change was applied already
+	ApplyCapacityChange(ctx context.Context, locationID, changeID string, quantityChange int, weightChange float64) (bool, error)
 }
 
 // LocationConstraints represents constraints for finding locations
@@ -149,6 +149,34 @@ func (r *StorageLocationRepository) UpdateCapacity(ctx context.Context, location
 	return err
 }
 
+// appliedChangeHistory is how many capacity change IDs a location remembers to skip redeliveries
+const appliedChangeHistory = 200
+
+// ApplyCapacityChange updates the location capacity unless the change ID was applied already.
+// The check and the update are one atomic write, so a redelivered change is never counted twice.
+func (r *StorageLocationRepository) ApplyCapacityChange(ctx context.Context, locationID, changeID string, quantityChange int, weightChange float64) (bool, error) {
+	update := bson.M{
+		"$inc": bson.M{
+			"currentQuantity": quantityChange,
+			"currentWeight":   weightChange,
+		},
+		"$set": bson.M{
+			"updatedAt": time.Now(),
+		},
+		"$push": bson.M{
+			"appliedChangeIds": bson.M{"$each": bson.A{changeID}, "$slice": -appliedChangeHistory},
+		},
+	}
+	filter := bson.M{"locationId": locationID, "appliedChangeIds": bson.M{"$ne": changeID}}
+	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
 // Save saves or updates a storage location
 func (r *StorageLocationRepository) Save(ctx context.Context, location *domain.StorageLocation) error {
 	opts := options.Update().SetUpsert(true)
@@ -0,0 +1,74 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/wms-platform/shared/pkg/tenant"
+)
+
+func TestStorageLocationRepository_ApplyCapacityChange(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("applies a new change", func(mt *mtest.T) {
+		repo := &StorageLocationRepository{collection: mt.DB.Collection("storage_locations")}
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		ctx := tenant.ToContext(context.Background(), &tenant.Context{TenantID: "TNT-001", FacilityID: "FAC-001", WarehouseID: "WH-001"})
+		applied, err := repo.ApplyCapacityChange(ctx, "LOC-A", "evt-1", -4, -6)
+		if err != nil {
+			mt.Fatalf("ApplyCapacityChange failed: %v", err)
+		}
+		if !applied {
+			mt.Error("expected the change to be applied")
+		}
+
+		event := mt.GetStartedEvent()
+		if event == nil {
+			mt.Fatal("no update sent")
+		}
+		update := event.Command.Lookup("updates", "0").Document()
+		filter := update.Lookup("q").Document()
+		if got := filter.Lookup("locationId").StringValue(); got != "LOC-A" {
+			mt.Errorf("filter locationId = %q, want LOC-A", got)
+		}
+		if got := filter.Lookup("appliedChangeIds", "$ne").StringValue(); got != "evt-1" {
+			mt.Errorf("filter skips change %q, want evt-1", got)
+		}
+		if got := filter.Lookup("tenantId").StringValue(); got != "TNT-001" {
+			mt.Errorf("filter tenantId = %q, want TNT-001", got)
+		}
+		if got := update.Lookup("u", "$inc", "currentQuantity").AsInt64(); got != -4 {
+			mt.Errorf("quantity change = %d, want -4", got)
+		}
+		if got := update.Lookup("u", "$push", "appliedChangeIds", "$each", "0").StringValue(); got != "evt-1" {
+			mt.Errorf("recorded change %q, want evt-1", got)
+		}
+	})
+
+	mt.Run("skips a change applied already", func(mt *mtest.T) {
+		repo := &StorageLocationRepository{collection: mt.DB.Collection("storage_locations")}
+		// The change ID filter no longer matches once the change is recorded
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}))
+
+		applied, err := repo.ApplyCapacityChange(context.Background(), "LOC-A", "evt-1", -4, -6)
+		if err != nil {
+			mt.Fatalf("ApplyCapacityChange failed: %v", err)
+		}
+		if applied {
+			mt.Error("expected a redelivered change to be skipped")
+		}
+	})
+
+	mt.Run("returns write errors", func(mt *mtest.T) {
+		repo := &StorageLocationRepository{collection: mt.DB.Collection("storage_locations")}
+		mt.AddMockResponses(mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 2, Message: "bad value"}))
+
+		if _, err := repo.ApplyCapacityChange(context.Background(), "LOC-A", "evt-1", -4, -6); err == nil {
+			mt.Error("expected the write error to be returned")
+		}
+	})
+}
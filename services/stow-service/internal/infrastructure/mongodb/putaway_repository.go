@@ -1,6 +1,7 @@
 package mongodb
 
 import (
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
 	"context"
 	"fmt"
@@ -55,9 +56,12 @@ func (r *PutawayTaskRepository) ensureIndexes(ctx context.Context) {
 	_ = r.outboxRepo.EnsureIndexes(ctx)
 }
 
-// Save persists a putaway task with its domain events in a single transaction
+// Save persists a putaway task with its domain events in a single transaction. The write
+// only succeeds if the stored task still has the version that was loaded.
 func (r *PutawayTaskRepository) Save(ctx context.Context, task *domain.PutawayTask) error {
 	task.UpdatedAt = time.Now()
+	expectedVersion := task.Version
+	task.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -69,11 +73,12 @@ func (r *PutawayTaskRepository) Save(ctx context.Context, task *domain.PutawayTa
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"taskId": task.TaskID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"taskId": task.TaskID}, expectedVersion)
 		update := bson.M{"$set": task}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "PutawayTask", task.TaskID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save putaway task: %w", err)
 		}
 
@@ -131,6 +136,7 @@ func (r *PutawayTaskRepository) Save(ctx context.Context, task *domain.PutawayTa
 	})
 
 	if err != nil {
+		task.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
@@ -675,8 +675,9 @@ func releaseUnitsHandler(service *application.UnitService, logger *logging.Logge
 
 		// Optional: accept request body with reason and handlerID
 		var req struct {
-			HandlerID string `json:"handlerId,omitempty"`
-			Reason    string `json:"reason,omitempty"`
+			HandlerID string   `json:"handlerId,omitempty"`
+			Reason    string   `json:"reason,omitempty"`
+			UnitIDs   []string `json:"unitIds,omitempty"`
 		}
 		// Ignore binding errors as these fields are optional
 		_ = c.ShouldBindJSON(&req)
@@ -685,6 +686,7 @@ func releaseUnitsHandler(service *application.UnitService, logger *logging.Logge
 			OrderID:   orderID,
 			HandlerID: req.HandlerID,
 			Reason:    req.Reason,
+			UnitIDs:   req.UnitIDs,
 		})
 		if err != nil {
 			logger.WithError(err).Error("Failed to release units", "orderId", orderID)
@@ -86,9 +86,10 @@ type ResolveExceptionCommand struct {
 
 // ReleaseUnitsCommand holds the input for releasing unit reservations
 type ReleaseUnitsCommand struct {
-	OrderID   string `json:"orderId"`
-	HandlerID string `json:"handlerId"`
-	Reason    string `json:"reason"`
+	OrderID   string   `json:"orderId"`
+	HandlerID string   `json:"handlerId"`
+	Reason    string   `json:"reason"`
+	UnitIDs   []string `json:"unitIds,omitempty"` // Optional: release only these units of the order
 }
 
 // CreateUnitsResult holds the result of creating units
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/wms-platform/services/unit-service/internal/domain"
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/resilience"
 	"github.com/wms-platform/shared/pkg/tenant"
 )
 
@@ -144,17 +146,14 @@ func (s *UnitService) GetUnit(ctx context.Context, unitID string) (*domain.Unit,
 
 // ConfirmPick confirms a unit has been picked
 func (s *UnitService) ConfirmPick(ctx context.Context, cmd ConfirmPickCommand) error {
-	unit, err := s.unitRepo.FindByUnitID(ctx, cmd.UnitID)
+	unit, err := s.updateUnit(ctx, cmd.UnitID, func(unit *domain.Unit) error {
+		if err := unit.Pick(cmd.ToteID, cmd.PickerID, cmd.StationID); err != nil {
+			return fmt.Errorf("failed to mark unit as picked: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("unit not found: %w", err)
-	}
-
-	if err := unit.Pick(cmd.ToteID, cmd.PickerID, cmd.StationID); err != nil {
-		return fmt.Errorf("failed to mark unit as picked: %w", err)
-	}
-
-	if err := s.unitRepo.Update(ctx, unit); err != nil {
-		return fmt.Errorf("failed to update unit: %w", err)
+		return err
 	}
 
 	if s.publisher != nil {
@@ -166,17 +165,14 @@ func (s *UnitService) ConfirmPick(ctx context.Context, cmd ConfirmPickCommand) e
 
 // ConfirmConsolidation confirms a unit has been consolidated
 func (s *UnitService) ConfirmConsolidation(ctx context.Context, cmd ConfirmConsolidationCommand) error {
-	unit, err := s.unitRepo.FindByUnitID(ctx, cmd.UnitID)
+	unit, err := s.updateUnit(ctx, cmd.UnitID, func(unit *domain.Unit) error {
+		if err := unit.Consolidate(cmd.DestinationBin, cmd.WorkerID, cmd.StationID); err != nil {
+			return fmt.Errorf("failed to mark unit as consolidated: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("unit not found: %w", err)
-	}
-
-	if err := unit.Consolidate(cmd.DestinationBin, cmd.WorkerID, cmd.StationID); err != nil {
-		return fmt.Errorf("failed to mark unit as consolidated: %w", err)
-	}
-
-	if err := s.unitRepo.Update(ctx, unit); err != nil {
-		return fmt.Errorf("failed to update unit: %w", err)
+		return err
 	}
 
 	if s.publisher != nil {
@@ -188,17 +184,14 @@ func (s *UnitService) ConfirmConsolidation(ctx context.Context, cmd ConfirmConso
 
 // ConfirmPacked confirms a unit has been packed
 func (s *UnitService) ConfirmPacked(ctx context.Context, cmd ConfirmPackedCommand) error {
-	unit, err := s.unitRepo.FindByUnitID(ctx, cmd.UnitID)
+	unit, err := s.updateUnit(ctx, cmd.UnitID, func(unit *domain.Unit) error {
+		if err := unit.Pack(cmd.PackageID, cmd.PackerID, cmd.StationID); err != nil {
+			return fmt.Errorf("failed to mark unit as packed: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("unit not found: %w", err)
-	}
-
-	if err := unit.Pack(cmd.PackageID, cmd.PackerID, cmd.StationID); err != nil {
-		return fmt.Errorf("failed to mark unit as packed: %w", err)
-	}
-
-	if err := s.unitRepo.Update(ctx, unit); err != nil {
-		return fmt.Errorf("failed to update unit: %w", err)
+		return err
 	}
 
 	if s.publisher != nil {
@@ -210,17 +203,14 @@ func (s *UnitService) ConfirmPacked(ctx context.Context, cmd ConfirmPackedComman
 
 // ConfirmShipped confirms a unit has been shipped
 func (s *UnitService) ConfirmShipped(ctx context.Context, cmd ConfirmShippedCommand) error {
-	unit, err := s.unitRepo.FindByUnitID(ctx, cmd.UnitID)
+	unit, err := s.updateUnit(ctx, cmd.UnitID, func(unit *domain.Unit) error {
+		if err := unit.Ship(cmd.ShipmentID, cmd.TrackingNumber, cmd.HandlerID); err != nil {
+			return fmt.Errorf("failed to mark unit as shipped: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("unit not found: %w", err)
-	}
-
-	if err := unit.Ship(cmd.ShipmentID, cmd.TrackingNumber, cmd.HandlerID); err != nil {
-		return fmt.Errorf("failed to mark unit as shipped: %w", err)
-	}
-
-	if err := s.unitRepo.Update(ctx, unit); err != nil {
-		return fmt.Errorf("failed to update unit: %w", err)
+		return err
 	}
 
 	if s.publisher != nil {
@@ -255,12 +245,14 @@ func (s *UnitService) CreateException(ctx context.Context, cmd CreateExceptionCo
 	}
 
 	// Mark the unit as having an exception
-	if err := unit.MarkException(exception.ExceptionID, cmd.Description, cmd.ReportedBy, cmd.StationID); err != nil {
-		return nil, fmt.Errorf("failed to mark unit exception: %w", err)
-	}
-
-	if err := s.unitRepo.Update(ctx, unit); err != nil {
-		return nil, fmt.Errorf("failed to update unit: %w", err)
+	unit, err = s.updateUnit(ctx, cmd.UnitID, func(unit *domain.Unit) error {
+		if err := unit.MarkException(exception.ExceptionID, cmd.Description, cmd.ReportedBy, cmd.StationID); err != nil {
+			return fmt.Errorf("failed to mark unit exception: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if s.publisher != nil {
@@ -306,6 +298,38 @@ func (s *UnitService) GetUnresolvedExceptions(ctx context.Context, limit int) ([
 	return s.exceptionRepo.FindUnresolved(ctx, limit)
 }
 
+// updateUnit loads a unit, applies change and updates it. An update that loses a race
+// with another writer reloads the unit and applies change again.
+func (s *UnitService) updateUnit(ctx context.Context, unitID string, change func(unit *domain.Unit) error) (*domain.Unit, error) {
+	var unit *domain.Unit
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.unitRepo.FindByUnitID(ctx, unitID)
+		if err != nil {
+			return fmt.Errorf("unit not found: %w", err)
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.unitRepo.Update(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				return err
+			}
+			return fmt.Errorf("failed to update unit: %w", err)
+		}
+		unit = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("unit %s was modified concurrently, please retry", unitID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return unit, nil
+}
+
 // firstNonEmpty returns the first non-empty string from the provided values
 func firstNonEmpty(values ...string) string {
 	for _, v := range values {
@@ -340,7 +364,20 @@ func (s *UnitService) ReleaseUnits(ctx context.Context, cmd ReleaseUnitsCommand)
 		reason = "Order processing failed - releasing unit reservation"
 	}
 
+	// Restrict to the requested units when a subset is given
+	var only map[string]bool
+	if len(cmd.UnitIDs) > 0 {
+		only = make(map[string]bool, len(cmd.UnitIDs))
+		for _, unitID := range cmd.UnitIDs {
+			only[unitID] = true
+		}
+	}
+
 	for _, unit := range units {
+		if only != nil && !only[unit.UnitID] {
+			continue
+		}
+
 		// Only release units that are still in reserved status
 		if unit.Status == domain.UnitStatusReserved {
 			if err := unit.Release(handlerID, reason); err != nil {
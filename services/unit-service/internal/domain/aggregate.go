@@ -93,6 +93,8 @@ type Unit struct {
 	CreatedAt      time.Time  `bson:"createdAt" json:"createdAt"`
 	UpdatedAt      time.Time  `bson:"updatedAt" json:"updatedAt"`
 
+	Version int `bson:"version" json:"version"` // Incremented on every update for optimistic concurrency
+
 	// Domain events (not persisted)
 	domainEvents []DomainEvent `bson:"-" json:"-"`
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/wms-platform/services/unit-service/internal/domain"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -198,14 +199,23 @@ func (r *UnitRepository) FindAvailableBySKU(ctx context.Context, sku string, lim
 	return units, nil
 }
 
-// Update updates a unit
+// Update updates a unit. The write only succeeds if the stored unit still has the version
+// that was loaded, otherwise it fails with ErrConcurrencyConflict.
 func (r *UnitRepository) Update(ctx context.Context, unit *domain.Unit) error {
 	unit.UpdatedAt = time.Now()
+	expectedVersion := unit.Version
+	unit.Version = expectedVersion + 1
+
 	filter := bson.M{"unitId": unit.UnitID}
 	filter = r.tenantHelper.WithTenantFilterOptional(ctx, filter)
+	filter = sharedMongo.VersionedFilter(filter, expectedVersion)
 
-	_, err := r.collection.ReplaceOne(ctx, filter, unit)
-	return err
+	result, err := r.collection.ReplaceOne(ctx, filter, unit)
+	if err := sharedMongo.CheckVersionedUpdate(result, err, "Unit", unit.UnitID, expectedVersion); err != nil {
+		unit.Version = expectedVersion
+		return err
+	}
+	return nil
 }
 
 // Delete removes a unit
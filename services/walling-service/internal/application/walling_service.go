@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/resilience"
 	"github.com/wms-platform/walling-service/internal/domain"
 )
 
@@ -150,20 +152,14 @@ func (s *WallingApplicationService) CreateWallingTask(ctx context.Context, cmd C
 func (s *WallingApplicationService) AssignWalliner(ctx context.Context, cmd AssignWallinerCommand) (*WallingTaskDTO, error) {
 	s.logger.Info("Assigning walliner", "taskId", cmd.TaskID, "wallinerId", cmd.WallinerID)
 
-	task, err := s.taskRepo.FindByTaskID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.WallingTask) error {
+		if err := task.Assign(cmd.WallinerID, cmd.Station); err != nil {
+			return fmt.Errorf("failed to assign walliner: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to find task: %w", err)
-	}
-	if task == nil {
-		return nil, fmt.Errorf("task not found: %s", cmd.TaskID)
-	}
-
-	if err := task.Assign(cmd.WallinerID, cmd.Station); err != nil {
-		return nil, fmt.Errorf("failed to assign walliner: %w", err)
-	}
-
-	if err := s.taskRepo.Update(ctx, task); err != nil {
-		return nil, fmt.Errorf("failed to update task: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Walliner assigned", "taskId", cmd.TaskID, "wallinerId", cmd.WallinerID)
@@ -175,20 +171,14 @@ func (s *WallingApplicationService) AssignWalliner(ctx context.Context, cmd Assi
 func (s *WallingApplicationService) SortItem(ctx context.Context, cmd SortItemCommand) (*WallingTaskDTO, error) {
 	s.logger.Info("Sorting item", "taskId", cmd.TaskID, "sku", cmd.SKU, "quantity", cmd.Quantity)
 
-	task, err := s.taskRepo.FindByTaskID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.WallingTask) error {
+		if err := task.SortItem(cmd.SKU, cmd.Quantity, cmd.FromToteID); err != nil {
+			return fmt.Errorf("failed to sort item: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to find task: %w", err)
-	}
-	if task == nil {
-		return nil, fmt.Errorf("task not found: %s", cmd.TaskID)
-	}
-
-	if err := task.SortItem(cmd.SKU, cmd.Quantity, cmd.FromToteID); err != nil {
-		return nil, fmt.Errorf("failed to sort item: %w", err)
-	}
-
-	if err := s.taskRepo.Update(ctx, task); err != nil {
-		return nil, fmt.Errorf("failed to update task: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Item sorted", "taskId", cmd.TaskID, "sku", cmd.SKU, "status", task.Status)
@@ -200,20 +190,14 @@ func (s *WallingApplicationService) SortItem(ctx context.Context, cmd SortItemCo
 func (s *WallingApplicationService) CompleteTask(ctx context.Context, cmd CompleteTaskCommand) (*WallingTaskDTO, error) {
 	s.logger.Info("Completing task", "taskId", cmd.TaskID)
 
-	task, err := s.taskRepo.FindByTaskID(ctx, cmd.TaskID)
+	task, err := s.updateTask(ctx, cmd.TaskID, func(task *domain.WallingTask) error {
+		if err := task.Complete(); err != nil {
+			return fmt.Errorf("failed to complete task: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to find task: %w", err)
-	}
-	if task == nil {
-		return nil, fmt.Errorf("task not found: %s", cmd.TaskID)
-	}
-
-	if err := task.Complete(); err != nil {
-		return nil, fmt.Errorf("failed to complete task: %w", err)
-	}
-
-	if err := s.taskRepo.Update(ctx, task); err != nil {
-		return nil, fmt.Errorf("failed to update task: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Task completed", "taskId", cmd.TaskID)
@@ -221,6 +205,42 @@ func (s *WallingApplicationService) CompleteTask(ctx context.Context, cmd Comple
 	return mapTaskToDTO(task), nil
 }
 
+// updateTask loads a walling task, applies change and updates it. An update that loses a
+// race with another writer reloads the task and applies change again.
+func (s *WallingApplicationService) updateTask(ctx context.Context, taskID string, change func(task *domain.WallingTask) error) (*domain.WallingTask, error) {
+	var task *domain.WallingTask
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.taskRepo.FindByTaskID(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to find task: %w", err)
+		}
+		if loaded == nil {
+			return fmt.Errorf("task not found: %s", taskID)
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.taskRepo.Update(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				s.logger.Debug("Walling task changed concurrently, retrying", "taskId", taskID)
+				return err
+			}
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+		task = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("walling task %s was modified concurrently, please retry", taskID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
 // GetTask gets a task by ID
 func (s *WallingApplicationService) GetTask(ctx context.Context, taskID string) (*WallingTaskDTO, error) {
 	task, err := s.taskRepo.FindByTaskID(ctx, taskID)
@@ -52,6 +52,7 @@ type WallingTask struct {
 	AssignedAt     *time.Time         `bson:"assignedAt,omitempty"`
 	StartedAt      *time.Time         `bson:"startedAt,omitempty"`
 	CompletedAt    *time.Time         `bson:"completedAt,omitempty"`
+	Version        int                `bson:"version"` // Incremented on every update for optimistic concurrency
 	DomainEvents   []DomainEvent      `bson:"-"`
 }
 
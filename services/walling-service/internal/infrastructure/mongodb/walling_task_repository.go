@@ -1,6 +1,7 @@
 package mongodb
 
 import (
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
 	"context"
 	"fmt"
@@ -184,22 +185,22 @@ func (r *WallingTaskRepository) FindByStatus(ctx context.Context, status domain.
 	return tasks, nil
 }
 
-// Update updates a walling task
+// Update updates a walling task. The write only succeeds if the stored task still has the
+// version that was loaded, otherwise it fails with ErrConcurrencyConflict.
 func (r *WallingTaskRepository) Update(ctx context.Context, task *domain.WallingTask) error {
 	task.UpdatedAt = time.Now()
+	expectedVersion := task.Version
+	task.Version = expectedVersion + 1
 
 	result, err := r.collection.ReplaceOne(
 		ctx,
-		bson.M{"taskId": task.TaskID},
+		sharedMongo.VersionedFilter(bson.M{"taskId": task.TaskID}, expectedVersion),
 		task,
 	)
-	if err != nil {
+	if err := sharedMongo.CheckVersionedUpdate(result, err, "WallingTask", task.TaskID, expectedVersion); err != nil {
+		task.Version = expectedVersion
 		return fmt.Errorf("failed to update walling task: %w", err)
 	}
 
-	if result.MatchedCount == 0 {
-		return fmt.Errorf("walling task not found: %s", task.TaskID)
-	}
-
 	return nil
 }
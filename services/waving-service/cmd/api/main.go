@@ -152,6 +152,11 @@ func main() {
 		temporalClient,
 	)
 
+	// Initialize Inventory Service client (implements domain.PickFaceReplenisher)
+	inventoryClient := clients.NewInventoryServiceClient(config.InventoryServiceURL)
+	wavingService.SetReplenisher(inventoryClient)
+	logger.Info("Inventory service client initialized", "url", config.InventoryServiceURL)
+
 	// Initialize Continuous Waving Service (scheduler)
 	var continuousWavingService *application.ContinuousWavingService
 	if config.ContinuousWaving.Enabled {
@@ -168,6 +173,7 @@ func main() {
 			eventPublisher,
 			cwConfig,
 		)
+		continuousWavingService.SetReplenisher(inventoryClient)
 		if err := continuousWavingService.Start(ctx); err != nil {
 			logger.WithError(err).Error("Failed to start continuous waving service")
 		} else {
@@ -370,12 +376,13 @@ func schedulerStopHandler(service *application.ContinuousWavingService, logger *
 
 // Config holds application configuration
 type Config struct {
-	ServerAddr       string
-	MongoDB          *mongodb.Config
-	Kafka            *kafka.Config
-	Temporal         *temporal.Config
-	OrderServiceURL  string
-	ContinuousWaving *ContinuousWavingConfig
+	ServerAddr          string
+	MongoDB             *mongodb.Config
+	Kafka               *kafka.Config
+	Temporal            *temporal.Config
+	OrderServiceURL     string
+	InventoryServiceURL string
+	ContinuousWaving    *ContinuousWavingConfig
 }
 
 // ContinuousWavingConfig holds configuration for continuous waving scheduler
@@ -411,7 +418,8 @@ func loadConfig() *Config {
 			Namespace: getEnv("TEMPORAL_NAMESPACE", "default"),
 			Identity:  serviceName,
 		},
-		OrderServiceURL: getEnv("ORDER_SERVICE_URL", "http://localhost:8001"),
+		OrderServiceURL:     getEnv("ORDER_SERVICE_URL", "http://localhost:8001"),
+		InventoryServiceURL: getEnv("INVENTORY_SERVICE_URL", "http://localhost:8008"),
 		ContinuousWaving: &ContinuousWavingConfig{
 			Enabled:             getEnv("CONTINUOUS_WAVING_ENABLED", "false") == "true",
 			ReleaseInterval:     parseDuration(getEnv("CONTINUOUS_WAVING_INTERVAL", "60s")),
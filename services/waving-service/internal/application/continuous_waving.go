@@ -14,6 +14,7 @@ type ContinuousWavingService struct {
 	waveRepo       domain.WaveRepository
 	orderService   domain.OrderService
 	eventPublisher domain.EventPublisher
+	replenisher    domain.PickFaceReplenisher
 	config         ContinuousWavingConfig
 	mu             sync.RWMutex
 	running        bool
@@ -69,6 +70,11 @@ func NewContinuousWavingService(
 	}
 }
 
+// SetReplenisher enables pick-face top-off requests for released waves
+func (s *ContinuousWavingService) SetReplenisher(replenisher domain.PickFaceReplenisher) {
+	s.replenisher = replenisher
+}
+
 // Start begins the continuous waving process
 func (s *ContinuousWavingService) Start(ctx context.Context) error {
 	s.mu.Lock()
@@ -218,6 +224,8 @@ func (s *ContinuousWavingService) releaseOrders(ctx context.Context, orders []do
 		}
 	}
 
+	s.requestTopOff(ctx, wave)
+
 	// Publish events
 	if err := s.eventPublisher.PublishAll(ctx, wave.GetDomainEvents()); err != nil {
 		return err
@@ -262,10 +270,23 @@ func (s *ContinuousWavingService) ProcessSingleOrder(ctx context.Context, order
 		return err
 	}
 
+	s.requestTopOff(ctx, wave)
+
 	// Publish events
 	return s.eventPublisher.PublishAll(ctx, wave.GetDomainEvents())
 }
 
+// requestTopOff asks inventory to replenish the pick faces a released wave will pick from
+func (s *ContinuousWavingService) requestTopOff(ctx context.Context, wave *domain.Wave) {
+	if s.replenisher == nil {
+		return
+	}
+	if err := s.replenisher.TopOffWave(ctx, waveTopOffRequest(wave)); err != nil {
+		// Log but continue
+		fmt.Printf("Failed to request pick-face top-off for wave %s: %v\n", wave.WaveID, err)
+	}
+}
+
 // getPriorityValue converts priority string to numeric value
 func getPriorityValue(priority string) int {
 	switch priority {
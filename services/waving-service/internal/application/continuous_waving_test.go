@@ -134,6 +134,15 @@ func (m *MockContinuousOrderService) NotifyWaveAssignment(ctx context.Context, o
 	return args.Error(0)
 }
 
+type MockPickFaceReplenisher struct {
+	mock.Mock
+}
+
+func (m *MockPickFaceReplenisher) TopOffWave(ctx context.Context, request domain.WaveTopOffRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
 func TestContinuousWavingService_Start(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -294,6 +303,29 @@ func TestContinuousWavingService_ProcessSingleOrder(t *testing.T) {
 
 		assert.NoError(t, err)
 	})
+
+	t.Run("Requests pick-face top-off for the released wave", func(t *testing.T) {
+		mockRepo := new(MockContinuousWaveRepo)
+		mockOrderService := new(MockContinuousOrderService)
+		mockEventPublisher := new(MockEventPublisher)
+		mockReplenisher := new(MockPickFaceReplenisher)
+
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*domain.Wave")).Return(nil)
+		mockOrderService.On("NotifyWaveAssignment", mock.Anything, "ORD-001", mock.Anything, mock.Anything).Return(nil)
+		mockEventPublisher.On("PublishAll", mock.Anything, mock.Anything).Return(nil)
+		mockReplenisher.On("TopOffWave", mock.Anything, mock.MatchedBy(func(request domain.WaveTopOffRequest) bool {
+			return request.WaveID != "" && len(request.OrderIDs) == 1 && request.OrderIDs[0] == "ORD-001"
+		})).Return(errors.New("inventory unavailable"))
+
+		config := DefaultContinuousWavingConfig()
+		service := NewContinuousWavingService(mockRepo, mockOrderService, mockEventPublisher, config)
+		service.SetReplenisher(mockReplenisher)
+
+		err := service.ProcessSingleOrder(context.Background(), order)
+
+		assert.NoError(t, err, "a failed top-off does not hold back the release")
+		mockReplenisher.AssertExpectations(t)
+	})
 }
 
 func TestContinuousWavingService_ReleaseOrders(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"github.com/wms-platform/shared/pkg/errors"
 	"github.com/wms-platform/shared/pkg/kafka"
 	"github.com/wms-platform/shared/pkg/logging"
+	"github.com/wms-platform/shared/pkg/resilience"
 	"github.com/wms-platform/shared/pkg/temporal"
 	"github.com/wms-platform/shared/pkg/tenant"
 
@@ -24,6 +25,7 @@ type WavingApplicationService struct {
 	logger         *logging.Logger
 	orderClient    *clients.OrderServiceClient
 	temporalClient *temporal.Client
+	replenisher    domain.PickFaceReplenisher
 }
 
 // NewWavingApplicationService creates a new WavingApplicationService
@@ -45,6 +47,11 @@ func NewWavingApplicationService(
 	}
 }
 
+// SetReplenisher enables pick-face top-off requests when waves are released
+func (s *WavingApplicationService) SetReplenisher(replenisher domain.PickFaceReplenisher) {
+	s.replenisher = replenisher
+}
+
 // CreateWave creates a new wave
 func (s *WavingApplicationService) CreateWave(ctx context.Context, cmd CreateWaveCommand) (*WaveDTO, error) {
 	waveType := domain.WaveType(cmd.WaveType)
@@ -119,26 +126,17 @@ func (s *WavingApplicationService) ListActiveWaves(ctx context.Context) ([]WaveL
 
 // UpdateWave updates a wave
 func (s *WavingApplicationService) UpdateWave(ctx context.Context, cmd UpdateWaveCommand) (*WaveDTO, error) {
-	wave, err := s.repo.FindByID(ctx, cmd.WaveID)
+	wave, err := s.updateWave(ctx, cmd.WaveID, func(wave *domain.Wave) error {
+		if cmd.Priority != nil && *cmd.Priority > 0 {
+			wave.SetPriority(*cmd.Priority)
+		}
+		if cmd.Zone != nil && *cmd.Zone != "" {
+			wave.SetZone(*cmd.Zone)
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to get wave: %w", err)
-	}
-
-	if wave == nil {
-		return nil, errors.ErrNotFound("wave")
-	}
-
-	if cmd.Priority != nil && *cmd.Priority > 0 {
-		wave.SetPriority(*cmd.Priority)
-	}
-	if cmd.Zone != nil && *cmd.Zone != "" {
-		wave.SetZone(*cmd.Zone)
-	}
-
-	if err := s.repo.Save(ctx, wave); err != nil {
-		s.logger.WithError(err).Error("Failed to update wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to update wave: %w", err)
+		return nil, err
 	}
 
 	s.logger.Info("Updated wave", "waveId", cmd.WaveID)
@@ -158,23 +156,14 @@ func (s *WavingApplicationService) DeleteWave(ctx context.Context, cmd DeleteWav
 
 // AddOrderToWave adds an order to a wave
 func (s *WavingApplicationService) AddOrderToWave(ctx context.Context, cmd AddOrderToWaveCommand) (*WaveDTO, error) {
-	wave, err := s.repo.FindByID(ctx, cmd.WaveID)
+	wave, err := s.updateWave(ctx, cmd.WaveID, func(wave *domain.Wave) error {
+		if err := wave.AddOrder(cmd.Order); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to get wave: %w", err)
-	}
-
-	if wave == nil {
-		return nil, errors.ErrNotFound("wave")
-	}
-
-	if err := wave.AddOrder(cmd.Order); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, wave); err != nil {
-		s.logger.WithError(err).Error("Failed to add order to wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to add order to wave: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -185,23 +174,14 @@ func (s *WavingApplicationService) AddOrderToWave(ctx context.Context, cmd AddOr
 
 // RemoveOrderFromWave removes an order from a wave
 func (s *WavingApplicationService) RemoveOrderFromWave(ctx context.Context, cmd RemoveOrderFromWaveCommand) (*WaveDTO, error) {
-	wave, err := s.repo.FindByID(ctx, cmd.WaveID)
+	wave, err := s.updateWave(ctx, cmd.WaveID, func(wave *domain.Wave) error {
+		if err := wave.RemoveOrder(cmd.OrderID); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to get wave: %w", err)
-	}
-
-	if wave == nil {
-		return nil, errors.ErrNotFound("wave")
-	}
-
-	if err := wave.RemoveOrder(cmd.OrderID); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, wave); err != nil {
-		s.logger.WithError(err).Error("Failed to remove order from wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to remove order from wave: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -212,23 +192,14 @@ func (s *WavingApplicationService) RemoveOrderFromWave(ctx context.Context, cmd
 
 // ScheduleWave schedules a wave
 func (s *WavingApplicationService) ScheduleWave(ctx context.Context, cmd ScheduleWaveCommand) (*WaveDTO, error) {
-	wave, err := s.repo.FindByID(ctx, cmd.WaveID)
+	wave, err := s.updateWave(ctx, cmd.WaveID, func(wave *domain.Wave) error {
+		if err := wave.Schedule(cmd.ScheduledStart, cmd.ScheduledEnd); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to get wave: %w", err)
-	}
-
-	if wave == nil {
-		return nil, errors.ErrNotFound("wave")
-	}
-
-	if err := wave.Schedule(cmd.ScheduledStart, cmd.ScheduledEnd); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, wave); err != nil {
-		s.logger.WithError(err).Error("Failed to schedule wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to schedule wave: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -239,27 +210,25 @@ func (s *WavingApplicationService) ScheduleWave(ctx context.Context, cmd Schedul
 
 // ReleaseWave releases a wave
 func (s *WavingApplicationService) ReleaseWave(ctx context.Context, cmd ReleaseWaveCommand) (*WaveDTO, error) {
-	wave, err := s.repo.FindByID(ctx, cmd.WaveID)
+	wave, err := s.updateWave(ctx, cmd.WaveID, func(wave *domain.Wave) error {
+		if err := wave.Release(); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to get wave: %w", err)
+		return nil, err
 	}
 
-	if wave == nil {
-		return nil, errors.ErrNotFound("wave")
-	}
-
-	if err := wave.Release(); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
+	// Events are saved to outbox by repository in transaction
 
-	if err := s.repo.Save(ctx, wave); err != nil {
-		s.logger.WithError(err).Error("Failed to release wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to release wave: %w", err)
+	// Top off pick faces so picking does not start against empty locations
+	if s.replenisher != nil {
+		if err := s.replenisher.TopOffWave(ctx, waveTopOffRequest(wave)); err != nil {
+			s.logger.WithError(err).Warn("Failed to request pick-face top-off", "waveId", wave.WaveID)
+		}
 	}
 
-	// Events are saved to outbox by repository in transaction
-
 	// Log business event: wave released
 	s.logger.LogBusinessEvent(ctx, logging.BusinessEvent{
 		EventType:  "wave.released",
@@ -276,23 +245,14 @@ func (s *WavingApplicationService) ReleaseWave(ctx context.Context, cmd ReleaseW
 
 // CancelWave cancels a wave
 func (s *WavingApplicationService) CancelWave(ctx context.Context, cmd CancelWaveCommand) (*WaveDTO, error) {
-	wave, err := s.repo.FindByID(ctx, cmd.WaveID)
+	wave, err := s.updateWave(ctx, cmd.WaveID, func(wave *domain.Wave) error {
+		if err := wave.Cancel(cmd.Reason); err != nil {
+			return errors.ErrValidation(err.Error())
+		}
+		return nil
+	})
 	if err != nil {
-		s.logger.WithError(err).Error("Failed to get wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to get wave: %w", err)
-	}
-
-	if wave == nil {
-		return nil, errors.ErrNotFound("wave")
-	}
-
-	if err := wave.Cancel(cmd.Reason); err != nil {
-		return nil, errors.ErrValidation(err.Error())
-	}
-
-	if err := s.repo.Save(ctx, wave); err != nil {
-		s.logger.WithError(err).Error("Failed to cancel wave", "waveId", cmd.WaveID)
-		return nil, fmt.Errorf("failed to cancel wave: %w", err)
+		return nil, err
 	}
 
 	// Events are saved to outbox by repository in transaction
@@ -311,6 +271,62 @@ func (s *WavingApplicationService) CancelWave(ctx context.Context, cmd CancelWav
 	return ToWaveDTO(wave), nil
 }
 
+// waveTopOffRequest builds the pick-face top-off request for a released wave
+func waveTopOffRequest(wave *domain.Wave) domain.WaveTopOffRequest {
+	orderIDs := make([]string, 0, len(wave.Orders))
+	for _, order := range wave.Orders {
+		orderIDs = append(orderIDs, order.OrderID)
+	}
+
+	return domain.WaveTopOffRequest{
+		TenantID:    wave.TenantID,
+		FacilityID:  wave.FacilityID,
+		WarehouseID: wave.WarehouseID,
+		WaveID:      wave.WaveID,
+		OrderIDs:    orderIDs,
+		Priority:    wave.Priority,
+	}
+}
+
+// updateWave loads a wave, applies change and saves it. A save that loses a race with
+// another writer reloads the wave and applies change again.
+func (s *WavingApplicationService) updateWave(ctx context.Context, waveID string, change func(wave *domain.Wave) error) (*domain.Wave, error) {
+	var wave *domain.Wave
+
+	err := resilience.RetryOnConflict(ctx, func() error {
+		loaded, err := s.repo.FindByID(ctx, waveID)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to get wave", "waveId", waveID)
+			return fmt.Errorf("failed to get wave: %w", err)
+		}
+		if loaded == nil {
+			return errors.ErrNotFound("wave")
+		}
+
+		if err := change(loaded); err != nil {
+			return err
+		}
+
+		if err := s.repo.Save(ctx, loaded); err != nil {
+			if errors.IsConcurrencyConflict(err) {
+				return err
+			}
+			s.logger.WithError(err).Error("Failed to save wave", "waveId", waveID)
+			return fmt.Errorf("failed to save wave: %w", err)
+		}
+
+		wave = loaded
+		return nil
+	})
+	if errors.IsConcurrencyConflict(err) {
+		return nil, errors.ErrConflict(fmt.Sprintf("wave %s was modified concurrently, please retry", waveID)).Wrap(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return wave, nil
+}
+
 // GetWavesByStatus retrieves waves by status
 func (s *WavingApplicationService) GetWavesByStatus(ctx context.Context, query GetWavesByStatusQuery) ([]WaveDTO, error) {
 	status := domain.WaveStatus(query.Status)
@@ -81,6 +81,7 @@ type Wave struct {
 	RequiresCertifiedLabor bool     `bson:"requiresCertifiedLabor"`         // Wave requires certified workers
 	CreatedAt         time.Time          `bson:"createdAt"`
 	UpdatedAt         time.Time          `bson:"updatedAt"`
+	Version           int                `bson:"version"`
 	ReleasedAt        *time.Time         `bson:"releasedAt,omitempty"`
 	CompletedAt       *time.Time         `bson:"completedAt,omitempty"`
 	DomainEvents      []DomainEvent      `bson:"-"` // Transient
@@ -92,6 +92,22 @@ type OrderService interface {
 	NotifyWaveAssignment(ctx context.Context, orderID, waveID string, scheduledStart time.Time) error
 }
 
+// PickFaceReplenisher tops off pick faces before a released wave is picked
+type PickFaceReplenisher interface {
+	// TopOffWave asks inventory to replenish the pick faces the wave's orders will be picked from
+	TopOffWave(ctx context.Context, request WaveTopOffRequest) error
+}
+
+// WaveTopOffRequest identifies a released wave's orders for pick-face replenishment
+type WaveTopOffRequest struct {
+	TenantID    string
+	FacilityID  string
+	WarehouseID string
+	WaveID      string
+	OrderIDs    []string
+	Priority    int // 1 = highest
+}
+
 // OrderFilter defines criteria for filtering orders
 type OrderFilter struct {
 	Priority                []string  `json:"priority,omitempty"`
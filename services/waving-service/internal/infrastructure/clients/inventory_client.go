@@ -0,0 +1,66 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/wms-platform/shared/pkg/middleware"
+
+	"github.com/wms-platform/waving-service/internal/domain"
+)
+
+// InventoryServiceClient handles communication with inventory-service
+// Implements domain.PickFaceReplenisher interface
+type InventoryServiceClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewInventoryServiceClient creates a new InventoryServiceClient
+func NewInventoryServiceClient(baseURL string) *InventoryServiceClient {
+	return &InventoryServiceClient{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// TopOffWave requests replenishment of the pick faces a released wave will pick from
+func (c *InventoryServiceClient) TopOffWave(ctx context.Context, request domain.WaveTopOffRequest) error {
+	url := fmt.Sprintf("%s/api/v1/inventory/replenishments/wave", c.baseURL)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"waveId":   request.WaveID,
+		"orderIds": request.OrderIDs,
+		"priority": request.Priority,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.HeaderWMSTenantID, request.TenantID)
+	req.Header.Set(middleware.HeaderWMSFacilityID, request.FacilityID)
+	req.Header.Set(middleware.HeaderWMSWarehouseID, request.WarehouseID)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request wave top-off: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("inventory service returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wms-platform/shared/pkg/middleware"
+	"github.com/wms-platform/waving-service/internal/domain"
+)
+
+func TestInventoryServiceClient_TopOffWave(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/inventory/replenishments/wave", r.URL.Path)
+		assert.Equal(t, "TENANT-1", r.Header.Get(middleware.HeaderWMSTenantID))
+		assert.Equal(t, "FAC-1", r.Header.Get(middleware.HeaderWMSFacilityID))
+		assert.Equal(t, "WH-1", r.Header.Get(middleware.HeaderWMSWarehouseID))
+
+		var body struct {
+			WaveID   string   `json:"waveId"`
+			OrderIDs []string `json:"orderIds"`
+			Priority int      `json:"priority"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "WAVE-001", body.WaveID)
+		assert.Equal(t, []string{"ORD-001", "ORD-002"}, body.OrderIDs)
+		assert.Equal(t, 2, body.Priority)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewInventoryServiceClient(server.URL)
+	err := client.TopOffWave(context.Background(), domain.WaveTopOffRequest{
+		TenantID:    "TENANT-1",
+		FacilityID:  "FAC-1",
+		WarehouseID: "WH-1",
+		WaveID:      "WAVE-001",
+		OrderIDs:    []string{"ORD-001", "ORD-002"},
+		Priority:    2,
+	})
+	require.NoError(t, err)
+}
+
+func TestInventoryServiceClient_TopOffWaveError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewInventoryServiceClient(server.URL)
+	err := client.TopOffWave(context.Background(), domain.WaveTopOffRequest{WaveID: "WAVE-001"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 500")
+}
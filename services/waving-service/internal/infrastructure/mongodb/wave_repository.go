@@ -7,6 +7,7 @@ import (
 
 	"github.com/wms-platform/shared/pkg/cloudevents"
 	"github.com/wms-platform/shared/pkg/kafka"
+	sharedMongo "github.com/wms-platform/shared/pkg/mongodb"
 	"github.com/wms-platform/shared/pkg/outbox"
 	outboxMongo "github.com/wms-platform/shared/pkg/outbox/mongodb"
 	"github.com/wms-platform/shared/pkg/tenant"
@@ -72,9 +73,12 @@ func (r *WaveRepository) ensureIndexes(ctx context.Context) {
 	r.collection.Indexes().CreateMany(ctx, indexes)
 }
 
-// Save persists a wave with its domain events in a single transaction
+// Save persists a wave with its domain events in a single transaction. The write only
+// succeeds if the stored wave still has the version that was loaded.
 func (r *WaveRepository) Save(ctx context.Context, wave *domain.Wave) error {
 	wave.UpdatedAt = time.Now()
+	expectedVersion := wave.Version
+	wave.Version = expectedVersion + 1
 
 	// Start a MongoDB session for transaction
 	session, err := r.db.Client().StartSession()
@@ -86,11 +90,12 @@ func (r *WaveRepository) Save(ctx context.Context, wave *domain.Wave) error {
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
 		// 1. Save the aggregate
-		opts := options.Update().SetUpsert(true)
-		filter := bson.M{"waveId": wave.WaveID}
+		opts := options.Update().SetUpsert(expectedVersion == 0)
+		filter := sharedMongo.VersionedFilter(bson.M{"waveId": wave.WaveID}, expectedVersion)
 		update := bson.M{"$set": wave}
 
-		if _, err := r.collection.UpdateOne(sessCtx, filter, update, opts); err != nil {
+		result, err := r.collection.UpdateOne(sessCtx, filter, update, opts)
+		if err := sharedMongo.CheckVersionedUpdate(result, err, "Wave", wave.WaveID, expectedVersion); err != nil {
 			return nil, fmt.Errorf("failed to save wave: %w", err)
 		}
 
@@ -152,6 +157,7 @@ func (r *WaveRepository) Save(ctx context.Context, wave *domain.Wave) error {
 	})
 
 	if err != nil {
+		wave.Version = expectedVersion
 		return fmt.Errorf("transaction failed: %w", err)
 	}
 
@@ -40,16 +40,19 @@ const (
 	PackTaskCompleted  = "wms.packing.task-completed"
 
 	// Shipping events
-	ShipmentCreated    = "wms.shipping.shipment-created"
-	ShipmentLabeled    = "wms.shipping.label-generated"
-	ShipmentManifested = "wms.shipping.manifested"
-	ShipConfirmed      = "wms.shipping.confirmed"
+	ShipmentCreated         = "wms.shipping.shipment-created"
+	ShipmentLabeled         = "wms.shipping.label-generated"
+	ShipmentManifested      = "wms.shipping.manifested"
+	ShipConfirmed           = "wms.shipping.confirmed"
+	ShipmentTrackingUpdated = "wms.shipping.tracking-updated"
 
 	// Inventory events
 	InventoryReceived      = "wms.inventory.received"
 	InventoryAdjusted      = "wms.inventory.adjusted"
 	LowStockAlert          = "wms.inventory.low-stock-alert"
 	CycleCountCompleted    = "wms.inventory.cycle-count-completed"
+	StockMoved             = "wms.inventory.stock-moved"
+	StockMoveStarted       = "wms.inventory.move-started"
 
 	// Labor events
 	ShiftStarted        = "wms.labor.shift-started"
@@ -82,6 +85,8 @@ const (
 	ChannelInventorySynced = "wms.channel.inventory-synced"
 	ChannelSyncCompleted = "wms.channel.sync-completed"
 	ChannelWebhookReceived = "wms.channel.webhook-received"
+	ChannelCredentialsRefreshed = "wms.channel.credentials-refreshed"
+	ChannelCredentialsExpired = "wms.channel.credentials-expired"
 )
 
 // Source constants for event sources
@@ -194,6 +199,36 @@ type ShipmentCreatedData struct {
 	TrackingNumber string `json:"trackingNumber,omitempty"`
 }
 
+// ShipConfirmedData represents the data payload for ShipConfirmed event
+type ShipConfirmedData struct {
+	ShipmentID        string     `json:"shipmentId"`
+	OrderID           string     `json:"orderId"`
+	TrackingNumber    string     `json:"trackingNumber"`
+	Carrier           string     `json:"carrier"`
+	EstimatedDelivery *time.Time `json:"estimatedDelivery,omitempty"`
+	ShippedAt         time.Time  `json:"shippedAt"`
+	BillableWeight    float64    `json:"billableWeight,omitempty"` // kg
+}
+
+// ShipmentTrackingUpdatedData represents the data payload for ShipmentTrackingUpdated event
+type ShipmentTrackingUpdatedData struct {
+	ShipmentID        string     `json:"shipmentId"`
+	OrderID           string     `json:"orderId"`
+	TenantID          string     `json:"tenantId,omitempty"`
+	SellerID          string     `json:"sellerId,omitempty"`
+	TrackingNumber    string     `json:"trackingNumber"`
+	Carrier           string     `json:"carrier"`
+	Milestone         string     `json:"milestone"` // "label_created", "in_transit", "out_for_delivery", "delivered", "exception"
+	ShipmentStatus    string     `json:"shipmentStatus"`
+	CarrierStatus     string     `json:"carrierStatus"`
+	StatusDetail      string     `json:"statusDetail,omitempty"`
+	Location          string     `json:"location,omitempty"`
+	EstimatedDelivery *time.Time `json:"estimatedDelivery,omitempty"`
+	DeliveredAt       *time.Time `json:"deliveredAt,omitempty"`
+	Source            string     `json:"source"` // "poll", "webhook"
+	EventAt           time.Time  `json:"eventAt"`
+}
+
 // InventoryAdjustedData represents the data payload for InventoryAdjusted event
 type InventoryAdjustedData struct {
 	SKU            string `json:"sku"`
@@ -204,6 +239,27 @@ type InventoryAdjustedData struct {
 	Reason         string `json:"reason,omitempty"`
 }
 
+// StockMovedData represents the data payload for StockMoved event
+type StockMovedData struct {
+	SKU            string  `json:"sku"`
+	FromLocationID string  `json:"fromLocationId"`
+	ToLocationID   string  `json:"toLocationId"`
+	Quantity       int     `json:"quantity"`
+	UnitWeight     float64 `json:"unitWeight,omitempty"`
+	MoveID         string  `json:"moveId,omitempty"`      // Set when dropped from a cart or tote; the source was emptied when the move started
+	ContainerID    string  `json:"containerId,omitempty"` // The cart or tote that carried the stock
+}
+
+// StockMoveStartedData represents the data payload for StockMoveStarted event
+type StockMoveStartedData struct {
+	SKU            string  `json:"sku"`
+	MoveID         string  `json:"moveId"`
+	ContainerID    string  `json:"containerId"`
+	FromLocationID string  `json:"fromLocationId"`
+	Quantity       int     `json:"quantity"`
+	UnitWeight     float64 `json:"unitWeight,omitempty"`
+}
+
 // LaborTaskAssignedData represents the data payload for LaborTaskAssigned event
 type LaborTaskAssignedData struct {
 	WorkerID string `json:"workerId"`
@@ -0,0 +1,114 @@
+package customs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Validation errors
+var (
+	ErrInvalidHSCode      = errors.New("invalid HS code")
+	ErrInvalidCountryCode = errors.New("invalid country code")
+	ErrInvalidIncoterm    = errors.New("invalid incoterm")
+)
+
+// Incoterm is the trade term that decides who pays duties and taxes on delivery
+type Incoterm string
+
+const (
+	// IncotermDAP (delivered at place): the recipient pays duties and taxes
+	IncotermDAP Incoterm = "DAP"
+	// IncotermDDP (delivered duty paid): the shipper pays duties and taxes
+	IncotermDDP Incoterm = "DDP"
+	// IncotermDDU (delivered duty unpaid) is the legacy name for DAP still used by carriers
+	IncotermDDU Incoterm = "DDU"
+	IncotermEXW Incoterm = "EXW"
+	IncotermFCA Incoterm = "FCA"
+	IncotermCPT Incoterm = "CPT"
+	IncotermCIP Incoterm = "CIP"
+)
+
+// IsValid checks if the incoterm is one parcel carriers accept
+func (i Incoterm) IsValid() bool {
+	switch i {
+	case IncotermDAP, IncotermDDP, IncotermDDU, IncotermEXW, IncotermFCA, IncotermCPT, IncotermCIP:
+		return true
+	default:
+		return false
+	}
+}
+
+// ShipperPaysDuties reports whether duties and taxes are billed to the shipper
+func (i Incoterm) ShipperPaysDuties() bool {
+	return i == IncotermDDP
+}
+
+// ParseIncoterm upper-cases and validates an incoterm
+func ParseIncoterm(value string) (Incoterm, error) {
+	incoterm := Incoterm(strings.ToUpper(strings.TrimSpace(value)))
+	if !incoterm.IsValid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidIncoterm, value)
+	}
+	return incoterm, nil
+}
+
+// NormalizeHSCode strips the dots and spaces sellers commonly write HS codes with
+// ("6109.10.00" becomes "61091000")
+func NormalizeHSCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(code))
+}
+
+// ValidateHSCode checks a normalized HS code has the 6-digit international heading
+// and at most a 10-digit national tariff line
+func ValidateHSCode(code string) error {
+	if len(code) < 6 || len(code) > 10 {
+		return fmt.Errorf("%w: %q must have 6 to 10 digits", ErrInvalidHSCode, code)
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("%w: %q must only contain digits", ErrInvalidHSCode, code)
+		}
+	}
+	return nil
+}
+
+// NormalizeCountryCode upper-cases an ISO 3166-1 alpha-2 country code
+func NormalizeCountryCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ValidateCountryCode checks a normalized country code is two letters
+func ValidateCountryCode(code string) error {
+	if len(code) != 2 || code[0] < 'A' || code[0] > 'Z' || code[1] < 'A' || code[1] > 'Z' {
+		return fmt.Errorf("%w: %q must be an ISO 3166-1 alpha-2 code", ErrInvalidCountryCode, code)
+	}
+	return nil
+}
+
+// DefaultRestrictedCountries are comprehensively embargoed destinations no parcel may ship to
+var DefaultRestrictedCountries = []string{"CU", "IR", "KP", "SY"}
+
+// IsRestricted reports whether a destination country is in the restricted list
+func IsRestricted(country string, restricted []string) bool {
+	country = NormalizeCountryCode(country)
+	for _, code := range restricted {
+		if NormalizeCountryCode(code) == country {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInternational reports whether a shipment between two countries crosses a border.
+// Unknown countries are treated as domestic.
+func IsInternational(originCountry, destinationCountry string) bool {
+	origin := NormalizeCountryCode(originCountry)
+	destination := NormalizeCountryCode(destinationCountry)
+	return origin != "" && destination != "" && origin != destination
+}
@@ -0,0 +1,63 @@
+package customs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateHSCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		code  string
+		valid bool
+	}{
+		{name: "6-digit heading", code: "610910", valid: true},
+		{name: "10-digit tariff line written with dots", code: "6109.10.0012", valid: true},
+		{name: "too short", code: "6109", valid: false},
+		{name: "too long", code: "61091000123", valid: false},
+		{name: "letters", code: "6109AB", valid: false},
+		{name: "empty", code: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHSCode(NormalizeHSCode(tt.code))
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidHSCode)
+			}
+		})
+	}
+}
+
+func TestValidateCountryCode(t *testing.T) {
+	assert.NoError(t, ValidateCountryCode(NormalizeCountryCode(" cn ")))
+	assert.ErrorIs(t, ValidateCountryCode("CHN"), ErrInvalidCountryCode)
+	assert.ErrorIs(t, ValidateCountryCode("C1"), ErrInvalidCountryCode)
+	assert.ErrorIs(t, ValidateCountryCode(""), ErrInvalidCountryCode)
+}
+
+func TestParseIncoterm(t *testing.T) {
+	incoterm, err := ParseIncoterm("ddp")
+	assert.NoError(t, err)
+	assert.Equal(t, IncotermDDP, incoterm)
+	assert.True(t, incoterm.ShipperPaysDuties())
+	assert.False(t, IncotermDAP.ShipperPaysDuties())
+
+	_, err = ParseIncoterm("FOB")
+	assert.ErrorIs(t, err, ErrInvalidIncoterm)
+}
+
+func TestIsRestricted(t *testing.T) {
+	assert.True(t, IsRestricted("kp", DefaultRestrictedCountries))
+	assert.False(t, IsRestricted("CA", DefaultRestrictedCountries))
+	assert.False(t, IsRestricted("KP", nil))
+}
+
+func TestIsInternational(t *testing.T) {
+	assert.True(t, IsInternational("US", "ca"))
+	assert.False(t, IsInternational("US", "us"))
+	assert.False(t, IsInternational("US", ""))
+}
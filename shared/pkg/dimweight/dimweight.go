@@ -0,0 +1,223 @@
+package dimweight
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// UnitSystem is the measurement system a carrier rates packages in
+type UnitSystem string
+
+const (
+	// Imperial rates in inches and pounds
+	Imperial UnitSystem = "imperial"
+	// Metric rates in centimetres and kilograms
+	Metric UnitSystem = "metric"
+)
+
+const (
+	cmPerInch = 2.54
+	kgPerLb   = 0.45359237
+)
+
+// Package is a physical package measured in kilograms and centimetres
+type Package struct {
+	WeightKg float64
+	LengthCm float64
+	WidthCm  float64
+	HeightCm float64
+}
+
+// SurchargeThresholds are the limits above which a carrier applies a surcharge.
+// Lengths and weights are in the rule's units; a zero value disables that check.
+type SurchargeThresholds struct {
+	LongestSide       float64
+	SecondLongestSide float64
+	LengthPlusGirth   float64
+	Weight            float64
+}
+
+// Rule describes how a carrier calculates dimensional and billable weight
+type Rule struct {
+	Units UnitSystem
+	// Divisor converts cubic size to weight (in³/lb or cm³/kg)
+	Divisor float64
+	// MinCubicSize is the cubic size at or below which DIM weight is not applied
+	MinCubicSize float64
+	// DimensionIncrement rounds each side to the nearest increment before cubing
+	DimensionIncrement float64
+	// WeightIncrement rounds actual and DIM weight up to the next increment
+	WeightIncrement    float64
+	AdditionalHandling SurchargeThresholds
+	Oversize           SurchargeThresholds
+}
+
+// Result is the outcome of a dimensional weight calculation. Weights are
+// reported in the rule's units and converted back to kilograms.
+type Result struct {
+	CarrierCode        string     `json:"carrierCode"`
+	Units              UnitSystem `json:"units"`
+	Divisor            float64    `json:"divisor"`
+	ActualWeight       float64    `json:"actualWeight"`
+	DimWeight          float64    `json:"dimWeight"`
+	BillableWeight     float64    `json:"billableWeight"`
+	ActualWeightKg     float64    `json:"actualWeightKg"`
+	DimWeightKg        float64    `json:"dimWeightKg"`
+	BillableWeightKg   float64    `json:"billableWeightKg"`
+	DimApplied         bool       `json:"dimApplied"`
+	AdditionalHandling bool       `json:"additionalHandling"`
+	Oversize           bool       `json:"oversize"`
+}
+
+// groundParcelRule is the 139 in³/lb rule used by the US ground parcel carriers
+var groundParcelRule = Rule{
+	Units:              Imperial,
+	Divisor:            139,
+	DimensionIncrement: 1,
+	WeightIncrement:    1,
+	AdditionalHandling: SurchargeThresholds{LongestSide: 48, SecondLongestSide: 30, LengthPlusGirth: 105, Weight: 50},
+	Oversize:           SurchargeThresholds{LongestSide: 96, LengthPlusGirth: 130, Weight: 110},
+}
+
+// DefaultRules returns the published dimensional weight rules for each supported carrier
+func DefaultRules() map[string]Rule {
+	return map[string]Rule{
+		"UPS":    groundParcelRule,
+		"FEDEX":  groundParcelRule,
+		"ONTRAC": groundParcelRule,
+		"USPS": {
+			Units:              Imperial,
+			Divisor:            166,
+			MinCubicSize:       1728,
+			DimensionIncrement: 1,
+			WeightIncrement:    1,
+			AdditionalHandling: SurchargeThresholds{LongestSide: 22},
+			Oversize:           SurchargeThresholds{LengthPlusGirth: 108, Weight: 70},
+		},
+		"DHL": {
+			Units:              Metric,
+			Divisor:            5000,
+			DimensionIncrement: 1,
+			WeightIncrement:    0.5,
+			AdditionalHandling: SurchargeThresholds{Weight: 25},
+			Oversize:           SurchargeThresholds{LongestSide: 120, Weight: 70},
+		},
+	}
+}
+
+// Calculator calculates dimensional and billable weight from per-carrier rules
+type Calculator struct {
+	rules    map[string]Rule
+	fallback Rule
+}
+
+// NewCalculator creates a Calculator from the default rules with any overrides applied.
+// Carriers without a rule use the ground parcel rule.
+func NewCalculator(overrides map[string]Rule) *Calculator {
+	rules := DefaultRules()
+	for code, rule := range overrides {
+		rules[normalizeCode(code)] = rule
+	}
+	return &Calculator{rules: rules, fallback: groundParcelRule}
+}
+
+var defaultCalculator = NewCalculator(nil)
+
+// Calculate calculates billable weight for a carrier using the default rules
+func Calculate(carrierCode string, pkg Package) Result {
+	return defaultCalculator.Calculate(carrierCode, pkg)
+}
+
+// RuleFor returns the rule used for a carrier
+func (c *Calculator) RuleFor(carrierCode string) Rule {
+	if rule, ok := c.rules[normalizeCode(carrierCode)]; ok {
+		return rule
+	}
+	return c.fallback
+}
+
+// Calculate calculates DIM weight, billable weight (the greater of actual and DIM)
+// and surcharge flags for a package shipped with the given carrier
+func (c *Calculator) Calculate(carrierCode string, pkg Package) Result {
+	rule := c.RuleFor(carrierCode)
+
+	sides := []float64{pkg.LengthCm, pkg.WidthCm, pkg.HeightCm}
+	weight := pkg.WeightKg
+	if rule.Units == Imperial {
+		for i := range sides {
+			sides[i] /= cmPerInch
+		}
+		weight /= kgPerLb
+	}
+	for i := range sides {
+		sides[i] = roundToNearest(sides[i], rule.DimensionIncrement)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(sides)))
+
+	result := Result{
+		CarrierCode:  normalizeCode(carrierCode),
+		Units:        rule.Units,
+		Divisor:      rule.Divisor,
+		ActualWeight: roundUp(weight, rule.WeightIncrement),
+	}
+
+	cubicSize := sides[0] * sides[1] * sides[2]
+	if rule.Divisor > 0 && cubicSize > rule.MinCubicSize {
+		result.DimWeight = roundUp(cubicSize/rule.Divisor, rule.WeightIncrement)
+	}
+
+	result.BillableWeight = result.ActualWeight
+	if result.DimWeight > result.ActualWeight {
+		result.BillableWeight = result.DimWeight
+		result.DimApplied = true
+	}
+
+	result.ActualWeightKg = toKg(result.ActualWeight, rule.Units)
+	result.DimWeightKg = toKg(result.DimWeight, rule.Units)
+	result.BillableWeightKg = toKg(result.BillableWeight, rule.Units)
+
+	result.AdditionalHandling = rule.AdditionalHandling.exceeded(sides, weight)
+	result.Oversize = rule.Oversize.exceeded(sides, weight)
+
+	return result
+}
+
+// exceeded reports whether sorted sides (longest first) or weight pass any threshold
+func (t SurchargeThresholds) exceeded(sides []float64, weight float64) bool {
+	lengthPlusGirth := sides[0] + 2*(sides[1]+sides[2])
+	return over(sides[0], t.LongestSide) ||
+		over(sides[1], t.SecondLongestSide) ||
+		over(lengthPlusGirth, t.LengthPlusGirth) ||
+		over(weight, t.Weight)
+}
+
+func over(value, threshold float64) bool {
+	return threshold > 0 && value > threshold
+}
+
+func roundToNearest(value, increment float64) float64 {
+	if increment <= 0 {
+		return value
+	}
+	return math.Round(value/increment) * increment
+}
+
+// roundUp rounds up to the next increment, tolerating floating point noise from unit conversion
+func roundUp(value, increment float64) float64 {
+	if increment <= 0 {
+		return value
+	}
+	return math.Ceil(value/increment-1e-9) * increment
+}
+
+func toKg(weight float64, units UnitSystem) float64 {
+	if units == Imperial {
+		return math.Round(weight*kgPerLb*1000) / 1000
+	}
+	return weight
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,120 @@
+package dimweight
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculate_BillableWeight(t *testing.T) {
+	tests := []struct {
+		name         string
+		carrier      string
+		pkg          Package
+		actual       float64
+		dim          float64
+		billable     float64
+		billableKg   float64
+		dimApplied   bool
+		expectedUnit UnitSystem
+	}{
+		{
+			name:    "ground parcel rounds sides to the inch and weight up to the pound",
+			carrier: "UPS",
+			pkg:     Package{WeightKg: 2, LengthCm: 40, WidthCm: 30, HeightCm: 30},
+			// 16 x 12 x 12 in = 2304 in³ / 139 = 16.6 lb
+			actual: 5, dim: 17, billable: 17, billableKg: 7.711, dimApplied: true,
+			expectedUnit: Imperial,
+		},
+		{
+			name:    "dense package bills actual weight",
+			carrier: "fedex",
+			pkg:     Package{WeightKg: 9, LengthCm: 30, WidthCm: 20, HeightCm: 15},
+			actual:  20, dim: 5, billable: 20, billableKg: 9.072,
+			expectedUnit: Imperial,
+		},
+		{
+			name:    "USPS ignores DIM weight at one cubic foot or less",
+			carrier: "USPS",
+			pkg:     Package{WeightKg: 1, LengthCm: 20, WidthCm: 15, HeightCm: 10},
+			actual:  3, dim: 0, billable: 3, billableKg: 1.361,
+			expectedUnit: Imperial,
+		},
+		{
+			name:    "USPS uses its own divisor above one cubic foot",
+			carrier: "USPS",
+			pkg:     Package{WeightKg: 2, LengthCm: 40, WidthCm: 30, HeightCm: 30},
+			actual:  5, dim: 14, billable: 14, billableKg: 6.35, dimApplied: true,
+			expectedUnit: Imperial,
+		},
+		{
+			name:    "DHL rates in metric and rounds up to the half kilogram",
+			carrier: "DHL",
+			pkg:     Package{WeightKg: 2, LengthCm: 40, WidthCm: 30, HeightCm: 30},
+			actual:  2, dim: 7.5, billable: 7.5, billableKg: 7.5, dimApplied: true,
+			expectedUnit: Metric,
+		},
+		{
+			name:    "unknown carrier falls back to the ground parcel rule",
+			carrier: "LOCAL",
+			pkg:     Package{WeightKg: 2, LengthCm: 40, WidthCm: 30, HeightCm: 30},
+			actual:  5, dim: 17, billable: 17, billableKg: 7.711, dimApplied: true,
+			expectedUnit: Imperial,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Calculate(tt.carrier, tt.pkg)
+			assert.Equal(t, tt.expectedUnit, result.Units)
+			assert.InDelta(t, tt.actual, result.ActualWeight, 0.001)
+			assert.InDelta(t, tt.dim, result.DimWeight, 0.001)
+			assert.InDelta(t, tt.billable, result.BillableWeight, 0.001)
+			assert.InDelta(t, tt.billableKg, result.BillableWeightKg, 0.001)
+			assert.Equal(t, tt.dimApplied, result.DimApplied)
+		})
+	}
+}
+
+func TestCalculate_Surcharges(t *testing.T) {
+	tests := []struct {
+		name               string
+		carrier            string
+		pkg                Package
+		additionalHandling bool
+		oversize           bool
+	}{
+		{"standard parcel", "UPS", Package{WeightKg: 2, LengthCm: 40, WidthCm: 30, HeightCm: 30}, false, false},
+		{"long side", "UPS", Package{WeightKg: 2, LengthCm: 130, WidthCm: 20, HeightCm: 20}, true, false},
+		{"second longest side", "FEDEX", Package{WeightKg: 2, LengthCm: 90, WidthCm: 80, HeightCm: 10}, true, false},
+		{"heavy parcel", "UPS", Package{WeightKg: 25, LengthCm: 40, WidthCm: 30, HeightCm: 30}, true, false},
+		{"oversize length", "UPS", Package{WeightKg: 5, LengthCm: 250, WidthCm: 40, HeightCm: 40}, true, true},
+		{"oversize girth", "ONTRAC", Package{WeightKg: 5, LengthCm: 150, WidthCm: 80, HeightCm: 70}, true, true},
+		{"USPS nonstandard length", "USPS", Package{WeightKg: 1, LengthCm: 60, WidthCm: 10, HeightCm: 10}, true, false},
+		{"DHL heavy piece", "DHL", Package{WeightKg: 30, LengthCm: 40, WidthCm: 30, HeightCm: 30}, true, false},
+		{"DHL oversize piece", "DHL", Package{WeightKg: 10, LengthCm: 130, WidthCm: 30, HeightCm: 30}, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Calculate(tt.carrier, tt.pkg)
+			assert.Equal(t, tt.additionalHandling, result.AdditionalHandling)
+			assert.Equal(t, tt.oversize, result.Oversize)
+		})
+	}
+}
+
+func TestNewCalculator_Overrides(t *testing.T) {
+	negotiated := groundParcelRule
+	negotiated.Divisor = 194
+	calculator := NewCalculator(map[string]Rule{"ups": negotiated})
+
+	pkg := Package{WeightKg: 2, LengthCm: 40, WidthCm: 30, HeightCm: 30}
+
+	result := calculator.Calculate("UPS", pkg)
+	assert.Equal(t, 194.0, result.Divisor)
+	assert.InDelta(t, 12, result.DimWeight, 0.001)
+
+	// Other carriers keep the published rules
+	assert.Equal(t, 166.0, calculator.Calculate("USPS", pkg).Divisor)
+}
@@ -19,26 +19,29 @@ type Carrier struct {
 
 // Valid carrier codes
 const (
-	carrierCodeUPS   = "UPS"
-	carrierCodeFedEx = "FEDEX"
-	carrierCodeUSPS  = "USPS"
-	carrierCodeDHL   = "DHL"
+	carrierCodeUPS    = "UPS"
+	carrierCodeFedEx  = "FEDEX"
+	carrierCodeUSPS   = "USPS"
+	carrierCodeDHL    = "DHL"
+	carrierCodeOnTrac = "ONTRAC"
 )
 
 // Carrier names
 const (
-	carrierNameUPS   = "United Parcel Service"
-	carrierNameFedEx = "Federal Express"
-	carrierNameUSPS  = "United States Postal Service"
-	carrierNameDHL   = "DHL Express"
+	carrierNameUPS    = "United Parcel Service"
+	carrierNameFedEx  = "Federal Express"
+	carrierNameUSPS   = "United States Postal Service"
+	carrierNameDHL    = "DHL Express"
+	carrierNameOnTrac = "OnTrac"
 )
 
 // Predefined Carrier instances (without account/service - use NewCarrier for those)
 var (
-	CarrierUPS   = Carrier{code: carrierCodeUPS, name: carrierNameUPS}
-	CarrierFedEx = Carrier{code: carrierCodeFedEx, name: carrierNameFedEx}
-	CarrierUSPS  = Carrier{code: carrierCodeUSPS, name: carrierNameUSPS}
-	CarrierDHL   = Carrier{code: carrierCodeDHL, name: carrierNameDHL}
+	CarrierUPS    = Carrier{code: carrierCodeUPS, name: carrierNameUPS}
+	CarrierFedEx  = Carrier{code: carrierCodeFedEx, name: carrierNameFedEx}
+	CarrierUSPS   = Carrier{code: carrierCodeUSPS, name: carrierNameUSPS}
+	CarrierDHL    = Carrier{code: carrierCodeDHL, name: carrierNameDHL}
+	CarrierOnTrac = Carrier{code: carrierCodeOnTrac, name: carrierNameOnTrac}
 )
 
 // NewCarrier creates a new Carrier value object with validation
@@ -55,6 +58,8 @@ func NewCarrier(code, accountID, serviceType string) (Carrier, error) {
 		name = carrierNameUSPS
 	case carrierCodeDHL:
 		name = carrierNameDHL
+	case carrierCodeOnTrac:
+		name = carrierNameOnTrac
 	default:
 		return Carrier{}, ErrInvalidCarrier
 	}
@@ -89,6 +94,8 @@ func NewCarrierWithDefaults(code string) (Carrier, error) {
 		name = carrierNameUSPS
 	case carrierCodeDHL:
 		name = carrierNameDHL
+	case carrierCodeOnTrac:
+		name = carrierNameOnTrac
 	default:
 		return Carrier{}, ErrInvalidCarrier
 	}
@@ -108,7 +115,7 @@ func MustNewCarrier(code, accountID, serviceType string) Carrier {
 	return carrier
 }
 
-// Code returns the carrier code (UPS, FEDEX, USPS, DHL)
+// Code returns the carrier code (UPS, FEDEX, USPS, DHL, ONTRAC)
 func (c Carrier) Code() string {
 	return c.code
 }
@@ -160,9 +167,15 @@ func (c Carrier) IsDHL() bool {
 	return c.code == carrierCodeDHL
 }
 
+// IsOnTrac returns true if the carrier is OnTrac
+func (c Carrier) IsOnTrac() bool {
+	return c.code == carrierCodeOnTrac
+}
+
 // SupportsInternationalShipping returns true if the carrier supports international shipping
 func (c Carrier) SupportsInternationalShipping() bool {
 	// All major carriers support international, but USPS has more restrictions
+	// and OnTrac is a US regional carrier
 	return c.code == carrierCodeUPS || c.code == carrierCodeFedEx || c.code == carrierCodeDHL
 }
 
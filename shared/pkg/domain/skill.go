@@ -0,0 +1,12 @@
+package domain
+
+// Worker skills needed for orders with special handling. Labor-service records them as
+// worker skill types and the orchestrator requests them when planning an order.
+const (
+	SkillHazmatCertification   = "hazmat_certification"
+	SkillColdChainHandling     = "cold_chain_handling"
+	SkillHighValueVerification = "high_value_verification"
+	SkillFragileHandling       = "fragile_handling"
+	SkillHeavyLifting          = "heavy_lifting"
+	SkillGiftWrapping          = "gift_wrapping"
+)
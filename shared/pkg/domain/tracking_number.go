@@ -28,6 +28,9 @@ var (
 
 	// DHL: 10 or 11 digits
 	dhlPattern = regexp.MustCompile(`^\d{10,11}$`)
+
+	// OnTrac: "C" or "D" followed by 14 digits
+	ontracPattern = regexp.MustCompile(`^[CD]\d{14}$`)
 )
 
 // NewTrackingNumber creates a new TrackingNumber value object with validation
@@ -75,6 +78,8 @@ func NewTrackingNumberForCarrier(trackingNumber, carrierCode string) (TrackingNu
 		valid = uspsPattern.MatchString(trackingNumber)
 	case "DHL":
 		valid = dhlPattern.MatchString(trackingNumber)
+	case "ONTRAC":
+		valid = ontracPattern.MatchString(trackingNumber)
 	default:
 		// Unknown carrier, use basic validation
 		valid = isValidBasicFormat(trackingNumber)
@@ -139,6 +144,11 @@ func (tn TrackingNumber) IsDHL() bool {
 	return tn.carrier == "DHL"
 }
 
+// IsOnTrac returns true if this is an OnTrac tracking number
+func (tn TrackingNumber) IsOnTrac() bool {
+	return tn.carrier == "ONTRAC"
+}
+
 // IsValid validates the tracking number format
 func (tn TrackingNumber) IsValid() bool {
 	switch tn.carrier {
@@ -150,6 +160,8 @@ func (tn TrackingNumber) IsValid() bool {
 		return uspsPattern.MatchString(tn.value)
 	case "DHL":
 		return dhlPattern.MatchString(tn.value)
+	case "ONTRAC":
+		return ontracPattern.MatchString(tn.value)
 	default:
 		return isValidBasicFormat(tn.value)
 	}
@@ -166,6 +178,8 @@ func (tn TrackingNumber) GetTrackingURL() string {
 		return "https://tools.usps.com/go/TrackConfirmAction?tLabels=" + tn.value
 	case "DHL":
 		return "https://www.dhl.com/en/express/tracking.html?AWB=" + tn.value
+	case "ONTRAC":
+		return "https://www.ontrac.com/tracking/?number=" + tn.value
 	default:
 		return ""
 	}
@@ -204,6 +218,9 @@ func detectCarrier(trackingNumber string) string {
 	if dhlPattern.MatchString(trackingNumber) {
 		return "DHL"
 	}
+	if ontracPattern.MatchString(trackingNumber) {
+		return "ONTRAC"
+	}
 	return ""
 }
 
@@ -101,6 +101,42 @@ func ErrConflict(message string) *AppError {
 	return NewAppError(CodeConflict, message, http.StatusConflict)
 }
 
+// ErrConcurrencyConflict is returned when an aggregate was saved by another writer
+// between being loaded and saved. The caller should reload the aggregate and retry.
+var ErrConcurrencyConflict = errors.New("concurrency conflict")
+
+// ConcurrencyConflictError identifies the aggregate a conditional write failed for.
+// It matches ErrConcurrencyConflict with errors.Is.
+type ConcurrencyConflictError struct {
+	AggregateType   string
+	AggregateID     string
+	ExpectedVersion int
+}
+
+// NewConcurrencyConflictError creates a concurrency conflict error for an aggregate
+func NewConcurrencyConflictError(aggregateType, aggregateID string, expectedVersion int) *ConcurrencyConflictError {
+	return &ConcurrencyConflictError{
+		AggregateType:   aggregateType,
+		AggregateID:     aggregateID,
+		ExpectedVersion: expectedVersion,
+	}
+}
+
+// Error implements the error interface
+func (e *ConcurrencyConflictError) Error() string {
+	return fmt.Sprintf("%s: %s %s was modified after version %d", ErrConcurrencyConflict, e.AggregateType, e.AggregateID, e.ExpectedVersion)
+}
+
+// Is reports whether target is ErrConcurrencyConflict
+func (e *ConcurrencyConflictError) Is(target error) bool {
+	return target == ErrConcurrencyConflict
+}
+
+// IsConcurrencyConflict checks if an error is caused by a concurrency conflict
+func IsConcurrencyConflict(err error) bool {
+	return errors.Is(err, ErrConcurrencyConflict)
+}
+
 // Authentication/Authorization errors
 
 // ErrUnauthorized creates an unauthorized error
@@ -192,6 +228,10 @@ func MapDomainError(err error) *AppError {
 		return appErr
 	}
 
+	if IsConcurrencyConflict(err) {
+		return ErrConflict("resource was modified concurrently, please retry").Wrap(err)
+	}
+
 	msg := err.Error()
 
 	// Map common domain error patterns
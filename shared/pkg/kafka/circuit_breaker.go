@@ -138,7 +138,7 @@ func NewCircuitBreakerConsumer(consumer *InstrumentedConsumer, logger *logging.L
 }
 
 // Subscribe subscribes to a topic with circuit breaker protected handler
-func (c *CircuitBreakerConsumer) Subscribe(topic string, eventType string, handler EventHandler) {
+func (c *CircuitBreakerConsumer) Subscribe(topic string, eventType string, handler EventHandler, opts ...SubscribeOption) {
 	// Wrap handler with circuit breaker
 	wrappedHandler := func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
 		_, err := c.circuitBreaker.Execute(ctx, func() (interface{}, error) {
@@ -147,11 +147,11 @@ func (c *CircuitBreakerConsumer) Subscribe(topic string, eventType string, handl
 		return err
 	}
 
-	c.consumer.Subscribe(topic, eventType, wrappedHandler)
+	c.consumer.Subscribe(topic, eventType, wrappedHandler, opts...)
 }
 
 // SubscribeAll subscribes to all event types with circuit breaker protected handler
-func (c *CircuitBreakerConsumer) SubscribeAll(topic string, handler EventHandler) {
+func (c *CircuitBreakerConsumer) SubscribeAll(topic string, handler EventHandler, opts ...SubscribeOption) {
 	// Wrap handler with circuit breaker
 	wrappedHandler := func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
 		_, err := c.circuitBreaker.Execute(ctx, func() (interface{}, error) {
@@ -160,7 +160,7 @@ func (c *CircuitBreakerConsumer) SubscribeAll(topic string, handler EventHandler
 		return err
 	}
 
-	c.consumer.SubscribeAll(topic, wrappedHandler)
+	c.consumer.SubscribeAll(topic, wrappedHandler, opts...)
 }
 
 // Start starts the circuit breaker protected consumer
@@ -21,6 +21,12 @@ type Config struct {
 	MaxWait       time.Duration
 	CommitTimeout time.Duration
 
+	// Consumer retry and dead-letter settings. Zero values fall back to DefaultRetryPolicy.
+	RetryMaxAttempts    int
+	RetryInitialBackoff time.Duration
+	RetryMaxBackoff     time.Duration
+	DisableDLQ          bool // drop messages after the last attempt instead of dead-lettering them
+
 	// TLS settings
 	TLSEnabled bool
 	TLSCert    string
@@ -50,11 +56,30 @@ func DefaultConfig() *Config {
 		MaxWait:       500 * time.Millisecond,
 		CommitTimeout: 5 * time.Second,
 
+		RetryMaxAttempts:    3,
+		RetryInitialBackoff: 200 * time.Millisecond,
+		RetryMaxBackoff:     5 * time.Second,
+
 		TLSEnabled:  false,
 		SASLEnabled: false,
 	}
 }
 
+// RetryPolicy returns the default consumer retry policy for this configuration
+func (c *Config) RetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	if c.RetryMaxAttempts > 0 {
+		policy.MaxAttempts = c.RetryMaxAttempts
+	}
+	if c.RetryInitialBackoff > 0 {
+		policy.InitialBackoff = c.RetryInitialBackoff
+	}
+	if c.RetryMaxBackoff > 0 {
+		policy.MaxBackoff = c.RetryMaxBackoff
+	}
+	return policy
+}
+
 // Topics contains all WMS Kafka topic names
 var Topics = struct {
 	// Inbound topics
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"github.com/wms-platform/shared/pkg/cloudevents"
@@ -14,12 +16,33 @@ import (
 // EventHandler is a function that handles a CloudEvent
 type EventHandler func(ctx context.Context, event *cloudevents.WMSCloudEvent) error
 
+// messageReader is the subset of kafka.Reader used by the consumer
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// messageWriter is the subset of kafka.Writer used for dead-lettering and replay
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// subscription is a handler together with its retry policy
+type subscription struct {
+	handler EventHandler
+	retry   RetryPolicy
+}
+
 // Consumer handles consuming messages from Kafka topics
 type Consumer struct {
-	config   *Config
-	readers  map[string]*kafka.Reader
-	handlers map[string]map[string]EventHandler // topic -> eventType -> handler
-	logger   *slog.Logger
+	config    *Config
+	readers   map[string]messageReader
+	handlers  map[string]map[string]subscription // topic -> eventType -> subscription
+	dlqWriter messageWriter
+	logger    *slog.Logger
+	mu        sync.Mutex
 }
 
 // NewConsumer creates a new Kafka consumer
@@ -29,27 +52,36 @@ func NewConsumer(config *Config, logger *slog.Logger) *Consumer {
 	}
 	return &Consumer{
 		config:   config,
-		readers:  make(map[string]*kafka.Reader),
-		handlers: make(map[string]map[string]EventHandler),
+		readers:  make(map[string]messageReader),
+		handlers: make(map[string]map[string]subscription),
 		logger:   logger,
 	}
 }
 
-// Subscribe subscribes to a topic with a handler for a specific event type
-func (c *Consumer) Subscribe(topic string, eventType string, handler EventHandler) {
+// Subscribe subscribes to a topic with a handler for a specific event type.
+// Failed handlers are retried per the consumer's retry policy unless overridden with WithRetryPolicy.
+func (c *Consumer) Subscribe(topic string, eventType string, handler EventHandler, opts ...SubscribeOption) {
+	sub := subscription{handler: handler, retry: c.config.RetryPolicy()}
+	for _, opt := range opts {
+		opt(&sub)
+	}
+
 	if _, exists := c.handlers[topic]; !exists {
-		c.handlers[topic] = make(map[string]EventHandler)
+		c.handlers[topic] = make(map[string]subscription)
 	}
-	c.handlers[topic][eventType] = handler
+	c.handlers[topic][eventType] = sub
 }
 
 // SubscribeAll subscribes to all event types on a topic with a single handler
-func (c *Consumer) SubscribeAll(topic string, handler EventHandler) {
-	c.Subscribe(topic, "*", handler)
+func (c *Consumer) SubscribeAll(topic string, handler EventHandler, opts ...SubscribeOption) {
+	c.Subscribe(topic, "*", handler, opts...)
 }
 
 // getReader returns a reader for the specified topic, creating one if necessary
-func (c *Consumer) getReader(topic string) *kafka.Reader {
+func (c *Consumer) getReader(topic string) messageReader {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if reader, exists := c.readers[topic]; exists {
 		return reader
 	}
@@ -68,6 +100,27 @@ func (c *Consumer) getReader(topic string) *kafka.Reader {
 	return reader
 }
 
+// getDLQWriter returns the writer used for all dead-letter topics, creating it if necessary
+func (c *Consumer) getDLQWriter() messageWriter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dlqWriter == nil {
+		c.dlqWriter = newTopicWriter(c.config)
+	}
+	return c.dlqWriter
+}
+
+// newTopicWriter creates a writer that routes each message by its Topic field
+func newTopicWriter(config *Config) *kafka.Writer {
+	return &kafka.Writer{
+		Addr:                   kafka.TCP(config.Brokers...),
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+	}
+}
+
 // Start starts consuming messages from all subscribed topics
 func (c *Consumer) Start(ctx context.Context) error {
 	for topic := range c.handlers {
@@ -78,7 +131,8 @@ func (c *Consumer) Start(ctx context.Context) error {
 	return ctx.Err()
 }
 
-// consumeTopic consumes messages from a single topic
+// consumeTopic consumes messages from a single topic.
+// A message is committed only once it was handled or safely dead-lettered.
 func (c *Consumer) consumeTopic(ctx context.Context, topic string) {
 	reader := c.getReader(topic)
 
@@ -99,25 +153,10 @@ func (c *Consumer) consumeTopic(ctx context.Context, topic string) {
 				continue
 			}
 
-			event, err := c.parseMessage(msg)
-			if err != nil {
-				c.logger.Error("Error parsing message", "topic", topic, "error", err)
-				// Commit the message anyway to avoid blocking
-				if commitErr := reader.CommitMessages(ctx, msg); commitErr != nil {
-					c.logger.Error("Error committing message", "topic", topic, "error", commitErr)
-				}
-				continue
-			}
-
-			if err := c.handleEvent(ctx, topic, event); err != nil {
-				c.logger.Error("Error handling event",
-					"topic", topic,
-					"eventType", event.Type,
-					"eventId", event.ID,
-					"error", err,
-				)
-				// Don't commit on handler error - this allows retry
-				continue
+			if err := c.processMessage(ctx, topic, msg); err != nil {
+				// Only cancellation gets here; the uncommitted message is redelivered on restart
+				c.logger.Info("Stopping consumer for topic", "topic", topic, "offset", msg.Offset)
+				return
 			}
 
 			if err := reader.CommitMessages(ctx, msg); err != nil {
@@ -127,6 +166,92 @@ func (c *Consumer) consumeTopic(ctx context.Context, topic string) {
 	}
 }
 
+// processMessage handles a message with bounded retry, dead-lettering it once attempts run out.
+// It returns an error only when ctx is cancelled before the message was settled.
+func (c *Consumer) processMessage(ctx context.Context, topic string, msg kafka.Message) error {
+	event, err := c.parseMessage(msg)
+	if err != nil {
+		c.logger.Error("Error parsing message", "topic", topic, "offset", msg.Offset, "error", err)
+		// Unparseable messages can never succeed, so skip retries
+		return c.deadLetter(ctx, topic, msg, 0, err)
+	}
+
+	sub, exists := c.findSubscription(topic, event.Type)
+	if !exists {
+		c.logger.Warn("No handler found for event type", "topic", topic, "eventType", event.Type)
+		return nil
+	}
+
+	var handlerErr error
+	for attempt := 1; attempt <= sub.retry.MaxAttempts; attempt++ {
+		handlerErr = c.handleEvent(ctx, event, sub.handler)
+		if handlerErr == nil {
+			return nil
+		}
+
+		c.logger.Error("Error handling event",
+			"topic", topic,
+			"eventType", event.Type,
+			"eventId", event.ID,
+			"attempt", attempt,
+			"maxAttempts", sub.retry.MaxAttempts,
+			"error", handlerErr,
+		)
+
+		if attempt < sub.retry.MaxAttempts {
+			if err := sleepContext(ctx, sub.retry.Backoff(attempt)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return c.deadLetter(ctx, topic, msg, sub.retry.MaxAttempts, handlerErr)
+}
+
+// deadLetter publishes a message to the topic's dead-letter topic.
+// Publishing is retried until it succeeds or ctx is cancelled so the message is never lost.
+func (c *Consumer) deadLetter(ctx context.Context, topic string, msg kafka.Message, attempts int, cause error) error {
+	if c.config.DisableDLQ {
+		c.logger.Error("Dropping message, dead-letter topic disabled",
+			"topic", topic,
+			"offset", msg.Offset,
+			"attempts", attempts,
+			"error", cause,
+		)
+		return nil
+	}
+
+	dlqMsg := newDeadLetterMessage(msg, topic, c.config.ConsumerGroup, attempts, cause, time.Now())
+	policy := c.config.RetryPolicy()
+
+	for publishAttempt := 1; ; publishAttempt++ {
+		err := c.getDLQWriter().WriteMessages(ctx, dlqMsg)
+		if err == nil {
+			c.logger.Warn("Message sent to dead-letter topic",
+				"topic", topic,
+				"dlqTopic", dlqMsg.Topic,
+				"partition", msg.Partition,
+				"offset", msg.Offset,
+				"attempts", attempts,
+				"error", cause,
+			)
+			return nil
+		}
+
+		c.logger.Error("Error publishing to dead-letter topic",
+			"topic", topic,
+			"dlqTopic", dlqMsg.Topic,
+			"offset", msg.Offset,
+			"publishAttempt", publishAttempt,
+			"error", err,
+		)
+
+		if err := sleepContext(ctx, policy.Backoff(publishAttempt)); err != nil {
+			return err
+		}
+	}
+}
+
 // parseMessage parses a Kafka message into a CloudEvent
 func (c *Consumer) parseMessage(msg kafka.Message) (*cloudevents.WMSCloudEvent, error) {
 	var event cloudevents.WMSCloudEvent
@@ -160,13 +285,25 @@ func (c *Consumer) parseMessage(msg kafka.Message) (*cloudevents.WMSCloudEvent,
 	return &event, nil
 }
 
-// handleEvent routes an event to the appropriate handler
-func (c *Consumer) handleEvent(ctx context.Context, topic string, event *cloudevents.WMSCloudEvent) error {
+// findSubscription returns the subscription for an event type, falling back to the wildcard
+func (c *Consumer) findSubscription(topic, eventType string) (subscription, bool) {
 	handlers, exists := c.handlers[topic]
 	if !exists {
-		return fmt.Errorf("no handlers registered for topic %s", topic)
+		return subscription{}, false
 	}
 
+	// Try specific handler first
+	if sub, exists := handlers[eventType]; exists {
+		return sub, true
+	}
+
+	// Fall back to wildcard handler
+	sub, exists := handlers["*"]
+	return sub, exists
+}
+
+// handleEvent invokes a handler with a context enriched by the event's WMS extensions
+func (c *Consumer) handleEvent(ctx context.Context, event *cloudevents.WMSCloudEvent, handler EventHandler) error {
 	// Enrich context with CloudEvents WMS extensions for logging
 	ctx = logging.ContextWithCloudEventExtensions(
 		ctx,
@@ -178,27 +315,24 @@ func (c *Consumer) handleEvent(ctx context.Context, topic string, event *cloudev
 		event.OrderID,
 	)
 
-	// Try specific handler first
-	if handler, exists := handlers[event.Type]; exists {
-		return handler(ctx, event)
-	}
-
-	// Fall back to wildcard handler
-	if handler, exists := handlers["*"]; exists {
-		return handler(ctx, event)
-	}
-
-	c.logger.Warn("No handler found for event type", "topic", topic, "eventType", event.Type)
-	return nil
+	return handler(ctx, event)
 }
 
-// Close closes all readers
+// Close closes all readers and the dead-letter writer
 func (c *Consumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	var lastErr error
 	for topic, reader := range c.readers {
 		if err := reader.Close(); err != nil {
 			lastErr = fmt.Errorf("failed to close reader for topic %s: %w", topic, err)
 		}
 	}
+	if c.dlqWriter != nil {
+		if err := c.dlqWriter.Close(); err != nil {
+			lastErr = fmt.Errorf("failed to close dead-letter writer: %w", err)
+		}
+	}
 	return lastErr
 }
@@ -0,0 +1,235 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/wms-platform/shared/pkg/cloudevents"
+)
+
+// mockWriter records written messages and fails the first failures writes
+type mockWriter struct {
+	mu       sync.Mutex
+	failures int
+	messages []kafka.Message
+}
+
+func (w *mockWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failures > 0 {
+		w.failures--
+		return errors.New("broker unavailable")
+	}
+	w.messages = append(w.messages, msgs...)
+	return nil
+}
+
+func (w *mockWriter) Close() error {
+	return nil
+}
+
+func testConfig() *Config {
+	config := DefaultConfig()
+	config.ConsumerGroup = "test-group"
+	config.RetryInitialBackoff = time.Millisecond
+	config.RetryMaxBackoff = 2 * time.Millisecond
+	return config
+}
+
+func testConsumer(config *Config) (*Consumer, *mockWriter) {
+	writer := &mockWriter{}
+	consumer := NewConsumer(config, nil)
+	consumer.dlqWriter = writer
+	return consumer, writer
+}
+
+func eventMessage(eventType string) kafka.Message {
+	return kafka.Message{
+		Topic:     "wms.orders.events",
+		Partition: 2,
+		Offset:    41,
+		Key:       []byte("order-1"),
+		Value:     []byte(`{"specversion":"1.0","type":"` + eventType + `","source":"/test","id":"evt-1"}`),
+		Headers: []kafka.Header{
+			{Key: "ce-type", Value: []byte(eventType)},
+			{Key: "ce-id", Value: []byte("evt-1")},
+		},
+	}
+}
+
+func headerValue(msg kafka.Message, key string) string {
+	for _, header := range msg.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func TestProcessMessage_RetriesThenSucceeds(t *testing.T) {
+	consumer, writer := testConsumer(testConfig())
+
+	calls := 0
+	consumer.Subscribe("wms.orders.events", "order.received", func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+
+	if err := consumer.processMessage(context.Background(), "wms.orders.events", eventMessage("order.received")); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 handler calls, got %d", calls)
+	}
+	if len(writer.messages) != 0 {
+		t.Errorf("Expected no dead-lettered messages, got %d", len(writer.messages))
+	}
+}
+
+func TestProcessMessage_DeadLettersAfterMaxAttempts(t *testing.T) {
+	consumer, writer := testConsumer(testConfig())
+
+	calls := 0
+	consumer.SubscribeAll("wms.orders.events", func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
+		calls++
+		return errors.New("inventory service unavailable")
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+
+	if err := consumer.processMessage(context.Background(), "wms.orders.events", eventMessage("order.received")); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 handler calls, got %d", calls)
+	}
+	if len(writer.messages) != 1 {
+		t.Fatalf("Expected 1 dead-lettered message, got %d", len(writer.messages))
+	}
+
+	dlqMsg := writer.messages[0]
+	if dlqMsg.Topic != "wms.orders.events.dlq" {
+		t.Errorf("Expected topic wms.orders.events.dlq, got %s", dlqMsg.Topic)
+	}
+	if string(dlqMsg.Key) != "order-1" {
+		t.Errorf("Expected original key to be kept, got %s", dlqMsg.Key)
+	}
+
+	expected := map[string]string{
+		"ce-type":                  "order.received",
+		HeaderDLQOriginalTopic:     "wms.orders.events",
+		HeaderDLQOriginalPartition: "2",
+		HeaderDLQOriginalOffset:    "41",
+		HeaderDLQConsumerGroup:     "test-group",
+		HeaderDLQError:             "inventory service unavailable",
+		HeaderDLQAttempts:          "2",
+	}
+	for key, want := range expected {
+		if got := headerValue(dlqMsg, key); got != want {
+			t.Errorf("Expected header %s=%q, got %q", key, want, got)
+		}
+	}
+}
+
+func TestProcessMessage_UnparseableMessageSkipsRetries(t *testing.T) {
+	consumer, writer := testConsumer(testConfig())
+
+	calls := 0
+	consumer.SubscribeAll("wms.orders.events", func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
+		calls++
+		return nil
+	})
+
+	msg := eventMessage("order.received")
+	msg.Value = []byte("not json")
+	if err := consumer.processMessage(context.Background(), "wms.orders.events", msg); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected handler not to be called, got %d calls", calls)
+	}
+	if len(writer.messages) != 1 || headerValue(writer.messages[0], HeaderDLQAttempts) != "0" {
+		t.Fatalf("Expected message dead-lettered with 0 attempts, got %+v", writer.messages)
+	}
+}
+
+func TestProcessMessage_RetriesDeadLetterPublish(t *testing.T) {
+	consumer, writer := testConsumer(testConfig())
+	writer.failures = 2
+
+	consumer.SubscribeAll("wms.orders.events", func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
+		return errors.New("permanent")
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	if err := consumer.processMessage(context.Background(), "wms.orders.events", eventMessage("order.received")); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if len(writer.messages) != 1 {
+		t.Errorf("Expected message dead-lettered after publish retries, got %d", len(writer.messages))
+	}
+}
+
+func TestProcessMessage_CancelledBeforeSettled(t *testing.T) {
+	consumer, writer := testConsumer(testConfig())
+	writer.failures = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumer.SubscribeAll("wms.orders.events", func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
+		cancel()
+		return errors.New("permanent")
+	}, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	if err := consumer.processMessage(ctx, "wms.orders.events", eventMessage("order.received")); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled so the message is not committed, got %v", err)
+	}
+}
+
+func TestProcessMessage_DisableDLQ(t *testing.T) {
+	config := testConfig()
+	config.DisableDLQ = true
+	consumer, writer := testConsumer(config)
+
+	consumer.SubscribeAll("wms.orders.events", func(ctx context.Context, event *cloudevents.WMSCloudEvent) error {
+		return errors.New("permanent")
+	})
+
+	if err := consumer.processMessage(context.Background(), "wms.orders.events", eventMessage("order.received")); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if len(writer.messages) != 0 {
+		t.Errorf("Expected no dead-lettered messages, got %d", len(writer.messages))
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 300 * time.Millisecond},
+		{4, 300 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Backoff(tt.attempt); got != tt.expected {
+			t.Errorf("Backoff(%d): expected %v, got %v", tt.attempt, tt.expected, got)
+		}
+	}
+}
+
+func TestConfig_RetryPolicyDefaults(t *testing.T) {
+	config := &Config{}
+	if got := config.RetryPolicy(); got != DefaultRetryPolicy() {
+		t.Errorf("Expected default retry policy, got %+v", got)
+	}
+}
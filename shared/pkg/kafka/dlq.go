@@ -0,0 +1,145 @@
+package kafka
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// DLQTopicSuffix is appended to a source topic to name its dead-letter topic
+const DLQTopicSuffix = ".dlq"
+
+// Dead-letter headers added alongside the original message headers
+const (
+	HeaderDLQOriginalTopic     = "dlq-original-topic"
+	HeaderDLQOriginalPartition = "dlq-original-partition"
+	HeaderDLQOriginalOffset    = "dlq-original-offset"
+	HeaderDLQConsumerGroup     = "dlq-consumer-group"
+	HeaderDLQError             = "dlq-error"
+	HeaderDLQAttempts          = "dlq-attempts"
+	HeaderDLQFailedAt          = "dlq-failed-at"
+	HeaderDLQReplayedAt        = "dlq-replayed-at"
+)
+
+// DLQTopic returns the dead-letter topic for a source topic
+func DLQTopic(topic string) string {
+	return topic + DLQTopicSuffix
+}
+
+// DLQMessage is a dead-lettered message as exposed by the admin API
+type DLQMessage struct {
+	Topic             string            `json:"topic"`
+	Partition         int               `json:"partition"`
+	Offset            int64             `json:"offset"`
+	Key               string            `json:"key,omitempty"`
+	Value             string            `json:"value"`
+	OriginalTopic     string            `json:"originalTopic"`
+	OriginalPartition int               `json:"originalPartition"`
+	OriginalOffset    int64             `json:"originalOffset"`
+	ConsumerGroup     string            `json:"consumerGroup,omitempty"`
+	EventType         string            `json:"eventType,omitempty"`
+	EventID           string            `json:"eventId,omitempty"`
+	Error             string            `json:"error"`
+	Attempts          int               `json:"attempts"`
+	FailedAt          time.Time         `json:"failedAt"`
+	Replayed          bool              `json:"replayed"` // Covered by a ReplayAll call
+	Headers           map[string]string `json:"headers"`
+}
+
+// newDeadLetterMessage builds the message written to the dead-letter topic.
+// Key, value and original headers are kept as-is so the message can be replayed unchanged.
+func newDeadLetterMessage(msg kafka.Message, topic, consumerGroup string, attempts int, cause error, failedAt time.Time) kafka.Message {
+	errText := ""
+	if cause != nil {
+		errText = cause.Error()
+	}
+
+	headers := make([]kafka.Header, 0, len(msg.Headers)+7)
+	for _, header := range msg.Headers {
+		if isDLQHeader(header.Key) {
+			continue
+		}
+		headers = append(headers, header)
+	}
+	headers = append(headers,
+		kafka.Header{Key: HeaderDLQOriginalTopic, Value: []byte(topic)},
+		kafka.Header{Key: HeaderDLQOriginalPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: HeaderDLQOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		kafka.Header{Key: HeaderDLQConsumerGroup, Value: []byte(consumerGroup)},
+		kafka.Header{Key: HeaderDLQError, Value: []byte(errText)},
+		kafka.Header{Key: HeaderDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderDLQFailedAt, Value: []byte(failedAt.UTC().Format(time.RFC3339Nano))},
+	)
+
+	return kafka.Message{
+		Topic:   DLQTopic(topic),
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+		Time:    failedAt,
+	}
+}
+
+// newReplayMessage rebuilds the original message from a dead-lettered one
+func newReplayMessage(msg kafka.Message, replayedAt time.Time) kafka.Message {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+1)
+	for _, header := range msg.Headers {
+		if isDLQHeader(header.Key) {
+			continue
+		}
+		headers = append(headers, header)
+	}
+	headers = append(headers, kafka.Header{Key: HeaderDLQReplayedAt, Value: []byte(replayedAt.UTC().Format(time.RFC3339Nano))})
+
+	return kafka.Message{
+		Topic:   originalTopic(msg),
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+		Time:    replayedAt,
+	}
+}
+
+// parseDLQMessage converts a message read from a dead-letter topic
+func parseDLQMessage(msg kafka.Message) DLQMessage {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, header := range msg.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+
+	dlq := DLQMessage{
+		Topic:         msg.Topic,
+		Partition:     msg.Partition,
+		Offset:        msg.Offset,
+		Key:           string(msg.Key),
+		Value:         string(msg.Value),
+		OriginalTopic: originalTopic(msg),
+		ConsumerGroup: headers[HeaderDLQConsumerGroup],
+		EventType:     headers["ce-type"],
+		EventID:       headers["ce-id"],
+		Error:         headers[HeaderDLQError],
+		Headers:       headers,
+	}
+	dlq.OriginalPartition, _ = strconv.Atoi(headers[HeaderDLQOriginalPartition])
+	dlq.OriginalOffset, _ = strconv.ParseInt(headers[HeaderDLQOriginalOffset], 10, 64)
+	dlq.Attempts, _ = strconv.Atoi(headers[HeaderDLQAttempts])
+	dlq.FailedAt, _ = time.Parse(time.RFC3339Nano, headers[HeaderDLQFailedAt])
+
+	return dlq
+}
+
+// originalTopic returns the source topic of a dead-lettered message
+func originalTopic(msg kafka.Message) string {
+	for _, header := range msg.Headers {
+		if header.Key == HeaderDLQOriginalTopic && len(header.Value) > 0 {
+			return string(header.Value)
+		}
+	}
+	return strings.TrimSuffix(msg.Topic, DLQTopicSuffix)
+}
+
+func isDLQHeader(key string) bool {
+	return strings.HasPrefix(key, "dlq-")
+}
@@ -0,0 +1,364 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// ErrDLQMessageNotFound is returned when no dead-lettered message exists at the requested position
+var ErrDLQMessageNotFound = errors.New("dead-letter message not found")
+
+// DLQReplayGroup is the consumer group under which ReplayAll commits how far each
+// dead-letter partition has been replayed
+const DLQReplayGroup = "wms-dlq-replay"
+
+// dlqReader reads messages back from dead-letter topics
+type dlqReader interface {
+	// ReadMessages reads up to limit messages, starting each partition at its offset in
+	// from, or at the first retained offset for partitions missing from from
+	ReadMessages(ctx context.Context, topic string, from map[int]int64, limit int) ([]kafka.Message, error)
+	ReadMessage(ctx context.Context, topic string, partition int, offset int64) (kafka.Message, error)
+}
+
+// replayOffsetStore tracks the next offset to replay for each dead-letter partition
+type replayOffsetStore interface {
+	ReplayedOffsets(ctx context.Context, topic string) (map[int]int64, error)
+	CommitReplayed(ctx context.Context, topic string, partition int, next int64) error
+}
+
+// DLQAdmin lists dead-lettered messages and replays them into their source topic.
+// Replaying does not remove the message from the dead-letter topic; the
+// dlq-replayed-at header on the replayed copy records that it was retried.
+// ReplayAll commits its progress under DLQReplayGroup, so repeated calls continue
+// after the last replayed message instead of replaying the topic from the start.
+type DLQAdmin struct {
+	reader  dlqReader
+	offsets replayOffsetStore
+	writer  messageWriter
+	logger  *slog.Logger
+}
+
+// NewDLQAdmin creates a DLQAdmin connected to the configured brokers
+func NewDLQAdmin(config *Config, logger *slog.Logger) *DLQAdmin {
+	return newDLQAdmin(&brokerDLQReader{config: config}, &brokerReplayOffsets{config: config}, newTopicWriter(config), logger)
+}
+
+func newDLQAdmin(reader dlqReader, offsets replayOffsetStore, writer messageWriter, logger *slog.Logger) *DLQAdmin {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &DLQAdmin{
+		reader:  reader,
+		offsets: offsets,
+		writer:  writer,
+		logger:  logger,
+	}
+}
+
+// List returns up to limit dead-lettered messages for a source topic, oldest first per partition.
+// Messages already covered by ReplayAll are marked as replayed.
+func (a *DLQAdmin) List(ctx context.Context, topic string, limit int) ([]DLQMessage, error) {
+	replayed, err := a.offsets.ReplayedOffsets(ctx, DLQTopic(topic))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replay offsets for %s: %w", DLQTopic(topic), err)
+	}
+
+	messages, err := a.reader.ReadMessages(ctx, DLQTopic(topic), nil, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dead-letter topic %s: %w", DLQTopic(topic), err)
+	}
+
+	result := make([]DLQMessage, 0, len(messages))
+	for _, msg := range messages {
+		dlq := parseDLQMessage(msg)
+		next, ok := replayed[msg.Partition]
+		dlq.Replayed = ok && msg.Offset < next
+		result = append(result, dlq)
+	}
+	return result, nil
+}
+
+// Replay republishes one dead-lettered message to its source topic with its original headers.
+// It does not move the ReplayAll position.
+func (a *DLQAdmin) Replay(ctx context.Context, topic string, partition int, offset int64) (*DLQMessage, error) {
+	msg, err := a.reader.ReadMessage(ctx, DLQTopic(topic), partition, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.replay(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	dlq := parseDLQMessage(msg)
+	return &dlq, nil
+}
+
+// ReplayAll republishes up to limit dead-lettered messages for a source topic that were not
+// replayed by an earlier call, and returns how many were replayed. The position of each
+// partition is committed after every replayed message, so a failure part way through
+// resumes at the first message that was not replayed.
+func (a *DLQAdmin) ReplayAll(ctx context.Context, topic string, limit int) (int, error) {
+	dlqTopic := DLQTopic(topic)
+	from, err := a.offsets.ReplayedOffsets(ctx, dlqTopic)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read replay offsets for %s: %w", dlqTopic, err)
+	}
+
+	messages, err := a.reader.ReadMessages(ctx, dlqTopic, from, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read dead-letter topic %s: %w", dlqTopic, err)
+	}
+
+	for i, msg := range messages {
+		if err := a.replay(ctx, msg); err != nil {
+			return i, err
+		}
+		if err := a.offsets.CommitReplayed(ctx, dlqTopic, msg.Partition, msg.Offset+1); err != nil {
+			return i + 1, fmt.Errorf("failed to commit replay offset for %s/%d: %w", dlqTopic, msg.Partition, err)
+		}
+	}
+	return len(messages), nil
+}
+
+func (a *DLQAdmin) replay(ctx context.Context, msg kafka.Message) error {
+	replayMsg := newReplayMessage(msg, time.Now())
+	if err := a.writer.WriteMessages(ctx, replayMsg); err != nil {
+		return fmt.Errorf("failed to replay message to topic %s: %w", replayMsg.Topic, err)
+	}
+
+	a.logger.Info("Replayed dead-letter message",
+		"dlqTopic", msg.Topic,
+		"partition", msg.Partition,
+		"offset", msg.Offset,
+		"topic", replayMsg.Topic,
+	)
+	return nil
+}
+
+// Close closes the replay writer
+func (a *DLQAdmin) Close() error {
+	return a.writer.Close()
+}
+
+// brokerDLQReader reads dead-letter topics directly from partition leaders, outside any consumer group
+type brokerDLQReader struct {
+	config *Config
+}
+
+// dlqReadTimeout bounds a single admin read so an idle partition cannot hang the request
+const dlqReadTimeout = 10 * time.Second
+
+func (r *brokerDLQReader) ReadMessages(ctx context.Context, topic string, from map[int]int64, limit int) ([]kafka.Message, error) {
+	partitions, err := r.partitions(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]kafka.Message, 0)
+	for _, partition := range partitions {
+		remaining := 0
+		if limit > 0 {
+			remaining = limit - len(messages)
+			if remaining <= 0 {
+				break
+			}
+		}
+
+		offset, ok := from[partition]
+		if !ok {
+			offset = -1
+		}
+		partitionMessages, err := r.readPartition(ctx, topic, partition, offset, remaining)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, partitionMessages...)
+	}
+	return messages, nil
+}
+
+func (r *brokerDLQReader) ReadMessage(ctx context.Context, topic string, partition int, offset int64) (kafka.Message, error) {
+	messages, err := r.readPartition(ctx, topic, partition, offset, 1)
+	if err != nil {
+		return kafka.Message{}, err
+	}
+	if len(messages) == 0 || messages[0].Offset != offset {
+		return kafka.Message{}, ErrDLQMessageNotFound
+	}
+	return messages[0], nil
+}
+
+func (r *brokerDLQReader) partitions(ctx context.Context, topic string) ([]int, error) {
+	if len(r.config.Brokers) == 0 {
+		return nil, errors.New("no kafka brokers configured")
+	}
+
+	conn, err := kafka.DialContext(ctx, "tcp", r.config.Brokers[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to kafka: %w", err)
+	}
+	defer conn.Close()
+
+	partitions, err := conn.ReadPartitions(topic)
+	if err != nil {
+		var kafkaErr kafka.Error
+		if errors.As(err, &kafkaErr) && kafkaErr == kafka.UnknownTopicOrPartition {
+			// Nothing has been dead-lettered yet
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read partitions: %w", err)
+	}
+
+	ids := make([]int, 0, len(partitions))
+	for _, partition := range partitions {
+		ids = append(ids, partition.ID)
+	}
+	return ids, nil
+}
+
+// brokerReplayOffsets keeps the ReplayAll position as committed offsets of DLQReplayGroup.
+// Offsets are committed without joining the group, as a standalone consumer would.
+type brokerReplayOffsets struct {
+	config *Config
+}
+
+func (o *brokerReplayOffsets) client() (*kafka.Client, error) {
+	if len(o.config.Brokers) == 0 {
+		return nil, errors.New("no kafka brokers configured")
+	}
+	return &kafka.Client{Addr: kafka.TCP(o.config.Brokers...), Timeout: dlqReadTimeout}, nil
+}
+
+func (o *brokerReplayOffsets) ReplayedOffsets(ctx context.Context, topic string) (map[int]int64, error) {
+	client, err := o.client()
+	if err != nil {
+		return nil, err
+	}
+
+	partitions, err := (&brokerDLQReader{config: o.config}).partitions(ctx, topic)
+	if err != nil || len(partitions) == 0 {
+		return nil, err
+	}
+
+	resp, err := client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+		GroupID: DLQReplayGroup,
+		Topics:  map[string][]int{topic: partitions},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch replay offsets: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to fetch replay offsets: %w", resp.Error)
+	}
+
+	offsets := make(map[int]int64)
+	for _, partition := range resp.Topics[topic] {
+		if partition.Error != nil {
+			return nil, fmt.Errorf("failed to fetch replay offset for partition %d: %w", partition.Partition, partition.Error)
+		}
+		// -1 means nothing was committed for the partition yet
+		if partition.CommittedOffset >= 0 {
+			offsets[partition.Partition] = partition.CommittedOffset
+		}
+	}
+	return offsets, nil
+}
+
+func (o *brokerReplayOffsets) CommitReplayed(ctx context.Context, topic string, partition int, next int64) error {
+	client, err := o.client()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.OffsetCommit(ctx, &kafka.OffsetCommitRequest{
+		GroupID:      DLQReplayGroup,
+		GenerationID: -1,
+		Topics: map[string][]kafka.OffsetCommit{
+			topic: {{Partition: partition, Offset: next}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, committed := range resp.Topics[topic] {
+		if committed.Error != nil {
+			return committed.Error
+		}
+	}
+	return nil
+}
+
+// readPartition reads up to limit messages (0 = all) from a partition starting at
+// offset, or from the first retained offset when offset is negative
+func (r *brokerDLQReader) readPartition(ctx context.Context, topic string, partition int, offset int64, limit int) ([]kafka.Message, error) {
+	if len(r.config.Brokers) == 0 {
+		return nil, errors.New("no kafka brokers configured")
+	}
+
+	conn, err := kafka.DialLeader(ctx, "tcp", r.config.Brokers[0], topic, partition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to partition %d leader: %w", partition, err)
+	}
+	defer conn.Close()
+
+	first, last, err := conn.ReadOffsets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offsets: %w", err)
+	}
+	if offset < 0 {
+		offset = first
+	}
+	if offset < first || offset >= last {
+		return nil, nil
+	}
+
+	maxBytes := r.config.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = 10e6
+	}
+
+	messages := make([]kafka.Message, 0)
+	for next := offset; next < last && (limit <= 0 || len(messages) < limit); {
+		if _, err := conn.Seek(next, kafka.SeekAbsolute); err != nil {
+			return nil, fmt.Errorf("failed to seek to offset %d: %w", next, err)
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(dlqReadTimeout)); err != nil {
+			return nil, fmt.Errorf("failed to set read deadline: %w", err)
+		}
+
+		batch := conn.ReadBatch(1, maxBytes)
+		read := 0
+		for limit <= 0 || len(messages) < limit {
+			msg, err := batch.ReadMessage()
+			if err != nil {
+				break
+			}
+			msg.Topic = topic
+			msg.Partition = partition
+			messages = append(messages, msg)
+			next = msg.Offset + 1
+			read++
+		}
+		if err := batch.Close(); err != nil && !errors.Is(err, io.EOF) && !isTimeout(err) {
+			return nil, fmt.Errorf("failed to read partition %d: %w", partition, err)
+		}
+		if read == 0 {
+			// Compacted or transactional gaps; stop rather than spin
+			break
+		}
+	}
+	return messages, nil
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,244 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/segmentio/kafka-go"
+)
+
+// mockDLQReader serves messages from an in-memory dead-letter topic
+type mockDLQReader struct {
+	messages []kafka.Message
+}
+
+func (r *mockDLQReader) ReadMessages(ctx context.Context, topic string, from map[int]int64, limit int) ([]kafka.Message, error) {
+	result := make([]kafka.Message, 0)
+	for _, msg := range r.messages {
+		if msg.Topic != topic {
+			continue
+		}
+		if next, ok := from[msg.Partition]; ok && msg.Offset < next {
+			continue
+		}
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+		result = append(result, msg)
+	}
+	return result, nil
+}
+
+func (r *mockDLQReader) ReadMessage(ctx context.Context, topic string, partition int, offset int64) (kafka.Message, error) {
+	for _, msg := range r.messages {
+		if msg.Topic == topic && msg.Partition == partition && msg.Offset == offset {
+			return msg, nil
+		}
+	}
+	return kafka.Message{}, ErrDLQMessageNotFound
+}
+
+// mockReplayOffsets keeps replay positions in memory
+type mockReplayOffsets struct {
+	offsets   map[string]map[int]int64
+	commitErr error
+}
+
+func newMockReplayOffsets() *mockReplayOffsets {
+	return &mockReplayOffsets{offsets: make(map[string]map[int]int64)}
+}
+
+func (o *mockReplayOffsets) ReplayedOffsets(ctx context.Context, topic string) (map[int]int64, error) {
+	offsets := make(map[int]int64)
+	for partition, next := range o.offsets[topic] {
+		offsets[partition] = next
+	}
+	return offsets, nil
+}
+
+func (o *mockReplayOffsets) CommitReplayed(ctx context.Context, topic string, partition int, next int64) error {
+	if o.commitErr != nil {
+		return o.commitErr
+	}
+	if o.offsets[topic] == nil {
+		o.offsets[topic] = make(map[int]int64)
+	}
+	o.offsets[topic][partition] = next
+	return nil
+}
+
+func deadLetteredMessages(count int) []kafka.Message {
+	failedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	messages := make([]kafka.Message, 0, count)
+	for i := 0; i < count; i++ {
+		msg := newDeadLetterMessage(eventMessage("order.received"), "wms.orders.events", "test-group", 3, context.DeadlineExceeded, failedAt)
+		msg.Partition = 0
+		msg.Offset = int64(i)
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+func TestDLQAdmin_List(t *testing.T) {
+	admin := newDLQAdmin(&mockDLQReader{messages: deadLetteredMessages(3)}, newMockReplayOffsets(), &mockWriter{}, nil)
+
+	messages, err := admin.List(context.Background(), "wms.orders.events", 2)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(messages))
+	}
+
+	msg := messages[0]
+	if msg.OriginalTopic != "wms.orders.events" || msg.OriginalPartition != 2 || msg.OriginalOffset != 41 {
+		t.Errorf("Unexpected original position: %+v", msg)
+	}
+	if msg.EventType != "order.received" || msg.EventID != "evt-1" || msg.Attempts != 3 || msg.ConsumerGroup != "test-group" {
+		t.Errorf("Unexpected metadata: %+v", msg)
+	}
+	if msg.Error != context.DeadlineExceeded.Error() || msg.FailedAt.IsZero() {
+		t.Errorf("Unexpected failure details: %+v", msg)
+	}
+}
+
+func TestDLQAdmin_Replay(t *testing.T) {
+	writer := &mockWriter{}
+	admin := newDLQAdmin(&mockDLQReader{messages: deadLetteredMessages(2)}, newMockReplayOffsets(), writer, nil)
+
+	if _, err := admin.Replay(context.Background(), "wms.orders.events", 0, 1); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if len(writer.messages) != 1 {
+		t.Fatalf("Expected 1 replayed message, got %d", len(writer.messages))
+	}
+
+	replayed := writer.messages[0]
+	if replayed.Topic != "wms.orders.events" || string(replayed.Key) != "order-1" {
+		t.Errorf("Expected replay to original topic and key, got %s/%s", replayed.Topic, replayed.Key)
+	}
+	if headerValue(replayed, "ce-type") != "order.received" {
+		t.Errorf("Expected original headers to be kept")
+	}
+	if headerValue(replayed, HeaderDLQError) != "" || headerValue(replayed, HeaderDLQOriginalTopic) != "" {
+		t.Errorf("Expected dead-letter headers to be stripped")
+	}
+	if headerValue(replayed, HeaderDLQReplayedAt) == "" {
+		t.Errorf("Expected %s header", HeaderDLQReplayedAt)
+	}
+
+	if _, err := admin.Replay(context.Background(), "wms.orders.events", 0, 9); err != ErrDLQMessageNotFound {
+		t.Errorf("Expected ErrDLQMessageNotFound, got %v", err)
+	}
+}
+
+func TestDLQAdmin_ReplayAll(t *testing.T) {
+	writer := &mockWriter{}
+	reader := &mockDLQReader{messages: deadLetteredMessages(3)}
+	offsets := newMockReplayOffsets()
+	admin := newDLQAdmin(reader, offsets, writer, nil)
+
+	replayed, err := admin.ReplayAll(context.Background(), "wms.orders.events", 2)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if replayed != 2 || len(writer.messages) != 2 {
+		t.Errorf("Expected 2 replayed messages, got %d (%d written)", replayed, len(writer.messages))
+	}
+	if offsets.offsets["wms.orders.events.dlq"][0] != 2 {
+		t.Errorf("Expected replay position 2, got %v", offsets.offsets)
+	}
+
+	// Listing marks what was replayed
+	messages, err := admin.List(context.Background(), "wms.orders.events", 0)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if !messages[0].Replayed || !messages[1].Replayed || messages[2].Replayed {
+		t.Errorf("Expected first two messages marked replayed: %+v", messages)
+	}
+
+	// The next call continues after the replayed messages
+	replayed, err = admin.ReplayAll(context.Background(), "wms.orders.events", 0)
+	if err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+	if replayed != 1 || len(writer.messages) != 3 {
+		t.Errorf("Expected 1 more replayed message, got %d (%d written)", replayed, len(writer.messages))
+	}
+
+	// Nothing left
+	replayed, _ = admin.ReplayAll(context.Background(), "wms.orders.events", 0)
+	if replayed != 0 || len(writer.messages) != 3 {
+		t.Errorf("Expected nothing replayed, got %d", replayed)
+	}
+}
+
+func TestDLQAdmin_ReplayAllStopsWhenCommitFails(t *testing.T) {
+	writer := &mockWriter{}
+	offsets := newMockReplayOffsets()
+	offsets.commitErr = errors.New("coordinator unavailable")
+	admin := newDLQAdmin(&mockDLQReader{messages: deadLetteredMessages(3)}, offsets, writer, nil)
+
+	replayed, err := admin.ReplayAll(context.Background(), "wms.orders.events", 0)
+	if err == nil {
+		t.Fatal("Expected commit error")
+	}
+	if replayed != 1 || len(writer.messages) != 1 {
+		t.Errorf("Expected replay to stop after the first message, got %d (%d written)", replayed, len(writer.messages))
+	}
+}
+
+func TestDLQAdminHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	writer := &mockWriter{}
+	admin := newDLQAdmin(&mockDLQReader{messages: deadLetteredMessages(2)}, newMockReplayOffsets(), writer, nil)
+	router := gin.New()
+	NewDLQAdminHandler(admin, nil).RegisterRoutes(router.Group("/api/v1/admin"))
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{"list", http.MethodGet, "/api/v1/admin/dlq/wms.orders.events/messages?limit=10", "", http.StatusOK},
+		{"list invalid limit", http.MethodGet, "/api/v1/admin/dlq/wms.orders.events/messages?limit=abc", "", http.StatusBadRequest},
+		{"replay", http.MethodPost, "/api/v1/admin/dlq/wms.orders.events/replay", `{"partition":0,"offset":0}`, http.StatusOK},
+		{"replay missing offset", http.MethodPost, "/api/v1/admin/dlq/wms.orders.events/replay", `{"partition":0}`, http.StatusBadRequest},
+		{"replay not found", http.MethodPost, "/api/v1/admin/dlq/wms.orders.events/replay", `{"partition":0,"offset":7}`, http.StatusNotFound},
+		{"replay all", http.MethodPost, "/api/v1/admin/dlq/wms.orders.events/replay-all", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	var listed struct {
+		Count int `json:"count"`
+	}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/dlq/wms.orders.events/messages", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if err := json.Unmarshal(w.Body.Bytes(), &listed); err != nil || listed.Count != 2 {
+		t.Errorf("Expected 2 listed messages, got %s", w.Body.String())
+	}
+}
@@ -0,0 +1,110 @@
+package kafka
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+	"github.com/wms-platform/shared/pkg/middleware"
+)
+
+// DLQAdminHandler exposes DLQAdmin over HTTP
+type DLQAdminHandler struct {
+	admin  *DLQAdmin
+	logger *slog.Logger
+}
+
+// NewDLQAdminHandler creates a new DLQAdminHandler
+func NewDLQAdminHandler(admin *DLQAdmin, logger *slog.Logger) *DLQAdminHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &DLQAdminHandler{admin: admin, logger: logger}
+}
+
+// RegisterRoutes registers the dead-letter admin routes. :topic is the source topic, not the .dlq topic.
+func (h *DLQAdminHandler) RegisterRoutes(router *gin.RouterGroup) {
+	dlq := router.Group("/dlq/:topic")
+	{
+		dlq.GET("/messages", h.ListMessages)
+		dlq.POST("/replay", h.Replay)
+		dlq.POST("/replay-all", h.ReplayAll)
+	}
+}
+
+// ListMessages handles GET /dlq/:topic/messages
+func (h *DLQAdminHandler) ListMessages(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger)
+
+	topic := c.Param("topic")
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 0 {
+		responder.RespondBadRequest("limit must be a non-negative integer")
+		return
+	}
+
+	messages, err := h.admin.List(c.Request.Context(), topic, limit)
+	if err != nil {
+		responder.RespondInternalError(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"topic":    DLQTopic(topic),
+		"messages": messages,
+		"count":    len(messages),
+	})
+}
+
+// Replay handles POST /dlq/:topic/replay
+func (h *DLQAdminHandler) Replay(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger)
+
+	var req struct {
+		Partition *int   `json:"partition" binding:"required"`
+		Offset    *int64 `json:"offset" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	message, err := h.admin.Replay(c.Request.Context(), c.Param("topic"), *req.Partition, *req.Offset)
+	if err != nil {
+		if errors.Is(err, ErrDLQMessageNotFound) {
+			responder.RespondWithAppError(sharedErrors.ErrNotFound("dead-letter message"))
+		} else {
+			responder.RespondInternalError(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// ReplayAll handles POST /dlq/:topic/replay-all
+func (h *DLQAdminHandler) ReplayAll(c *gin.Context) {
+	responder := middleware.NewErrorResponder(c, h.logger)
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit < 0 {
+		responder.RespondBadRequest("limit must be a non-negative integer")
+		return
+	}
+
+	replayed, err := h.admin.ReplayAll(c.Request.Context(), c.Param("topic"), limit)
+	if err != nil {
+		h.logger.Error("Dead-letter replay stopped early", "topic", c.Param("topic"), "replayed", replayed, "error", err)
+		responder.RespondInternalError(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"topic":    c.Param("topic"),
+		"replayed": replayed,
+	})
+}
@@ -236,15 +236,15 @@ func NewInstrumentedConsumer(consumer *Consumer, m *metrics.Metrics, logger *log
 }
 
 // Subscribe subscribes to a topic with instrumented handler
-func (c *InstrumentedConsumer) Subscribe(topic string, eventType string, handler EventHandler) {
+func (c *InstrumentedConsumer) Subscribe(topic string, eventType string, handler EventHandler, opts ...SubscribeOption) {
 	wrappedHandler := c.instrumentHandler(topic, eventType, handler)
-	c.consumer.Subscribe(topic, eventType, wrappedHandler)
+	c.consumer.Subscribe(topic, eventType, wrappedHandler, opts...)
 }
 
 // SubscribeAll subscribes to all event types with instrumented handler
-func (c *InstrumentedConsumer) SubscribeAll(topic string, handler EventHandler) {
+func (c *InstrumentedConsumer) SubscribeAll(topic string, handler EventHandler, opts ...SubscribeOption) {
 	wrappedHandler := c.instrumentHandler(topic, "*", handler)
-	c.consumer.SubscribeAll(topic, wrappedHandler)
+	c.consumer.SubscribeAll(topic, wrappedHandler, opts...)
 }
 
 // instrumentHandler wraps an event handler with metrics and tracing
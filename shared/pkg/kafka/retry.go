@@ -0,0 +1,71 @@
+package kafka
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy controls how often a failing handler is retried before its message is dead-lettered
+type RetryPolicy struct {
+	MaxAttempts    int           // total handler attempts, including the first
+	InitialBackoff time.Duration // wait before the second attempt
+	MaxBackoff     time.Duration // cap for the exponential backoff
+	Multiplier     float64       // backoff growth factor between attempts
+}
+
+// DefaultRetryPolicy returns the retry policy used when a subscription does not set one
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2.0,
+	}
+}
+
+// Backoff returns the wait after the given failed attempt (1-based)
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= multiplier
+		if p.MaxBackoff > 0 && backoff >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	return time.Duration(backoff)
+}
+
+// SubscribeOption customizes a single subscription
+type SubscribeOption func(*subscription)
+
+// WithRetryPolicy overrides the consumer's retry policy for one subscription
+func WithRetryPolicy(policy RetryPolicy) SubscribeOption {
+	return func(s *subscription) {
+		if policy.MaxAttempts <= 0 {
+			policy.MaxAttempts = 1
+		}
+		s.retry = policy
+	}
+}
+
+// sleepContext waits for d or until ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 )
 
@@ -41,6 +42,32 @@ func DefaultConfig(serviceName string) *Config {
 	}
 }
 
+// RedactedValue replaces the value of sensitive attributes
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeyFragments are matched against normalized attribute keys
+var sensitiveKeyFragments = []string{
+	"password",
+	"secret",
+	"token",
+	"apikey",
+	"credential",
+	"authorization",
+	"privatekey",
+}
+
+// IsSensitiveKey checks if an attribute key names a secret, such as "accessToken",
+// "client_secret" or "X-API-Key". Values of sensitive attributes are always redacted.
+func IsSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(key))
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(normalized, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
 // Logger wraps slog.Logger with additional functionality
 type Logger struct {
 	*slog.Logger
@@ -70,6 +97,10 @@ func New(config *Config) *Logger {
 		Level:     level,
 		AddSource: config.AddSource,
 		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			// Never write secrets, whatever the caller passed
+			if IsSensitiveKey(a.Key) {
+				return slog.String(a.Key, RedactedValue)
+			}
 			// Customize time format
 			if a.Key == slog.TimeKey {
 				if t, ok := a.Value.Any().(time.Time); ok {
@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "accessToken", want: true},
+		{key: "client_secret", want: true},
+		{key: "X-API-Key", want: true},
+		{key: "credentials", want: true},
+		{key: "Authorization", want: true},
+		{key: "password", want: true},
+		{key: "channelId", want: false},
+		{key: "operation", want: false},
+		{key: "error", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := IsSensitiveKey(tt.key); got != tt.want {
+				t.Errorf("IsSensitiveKey(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggerRedactsSensitiveAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&Config{Level: LevelInfo, ServiceName: "test", Output: &buf})
+
+	logger.WithFields(map[string]any{"refreshToken": "rt-plaintext"}).Info("Token refreshed",
+		"channelId", "CH-1",
+		"accessToken", "at-plaintext",
+		"credentials", map[string]string{"apiSecret": "as-plaintext"},
+	)
+
+	output := buf.String()
+	for _, plaintext := range []string{"rt-plaintext", "at-plaintext", "as-plaintext"} {
+		if strings.Contains(output, plaintext) {
+			t.Errorf("log output contains %q: %s", plaintext, output)
+		}
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to decode log entry: %v", err)
+	}
+	if entry["accessToken"] != RedactedValue {
+		t.Errorf("accessToken = %v, want %q", entry["accessToken"], RedactedValue)
+	}
+	if entry["channelId"] != "CH-1" {
+		t.Errorf("channelId = %v, want %q", entry["channelId"], "CH-1")
+	}
+}
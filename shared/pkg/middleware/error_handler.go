@@ -104,8 +104,13 @@ func (r *ErrorResponder) RespondValidationError(message string, fields map[strin
 	r.RespondWithAppError(errors.ErrValidationWithFields(message, fields))
 }
 
-// RespondInternalError sends a 500 response
+// RespondInternalError sends a 500 response. A save that lost an optimistic concurrency
+// race is not a server fault and is sent as 409 so the client retries.
 func (r *ErrorResponder) RespondInternalError(err error) {
+	if errors.IsConcurrencyConflict(err) {
+		r.RespondWithAppError(errors.ErrConflict("resource was modified concurrently, please retry").Wrap(err))
+		return
+	}
 	appErr := errors.ErrInternal("").Wrap(err)
 	r.RespondWithAppError(appErr)
 }
@@ -0,0 +1,39 @@
+package mongodb
+
+import (
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// VersionField is the document field holding an aggregate's optimistic concurrency version
+const VersionField = "version"
+
+// VersionedFilter restricts filter to the document version an aggregate was loaded at.
+// Documents written before versioning was introduced have no version field and match
+// version 0.
+func VersionedFilter(filter bson.M, expectedVersion int) bson.M {
+	if expectedVersion == 0 {
+		filter[VersionField] = bson.M{"$in": bson.A{0, nil}}
+	} else {
+		filter[VersionField] = expectedVersion
+	}
+	return filter
+}
+
+// CheckVersionedUpdate turns the outcome of a conditional update into
+// ErrConcurrencyConflict when no document matched the expected version. Upserts of new
+// aggregates conflict through the unique index on the aggregate ID, which rejects the
+// insert when another writer created the document first.
+func CheckVersionedUpdate(result *mongo.UpdateResult, err error, aggregateType, aggregateID string, expectedVersion int) error {
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return sharedErrors.NewConcurrencyConflictError(aggregateType, aggregateID, expectedVersion)
+		}
+		return err
+	}
+	if result.MatchedCount == 0 && result.UpsertedCount == 0 {
+		return sharedErrors.NewConcurrencyConflictError(aggregateType, aggregateID, expectedVersion)
+	}
+	return nil
+}
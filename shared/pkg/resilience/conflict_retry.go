@@ -0,0 +1,27 @@
+package resilience
+
+import (
+	"context"
+
+	sharedErrors "github.com/wms-platform/shared/pkg/errors"
+)
+
+// ConflictRetryConfig returns a retry configuration that retries only optimistic
+// concurrency conflicts. Each conflict means another writer succeeded, so a short
+// backoff is enough for the retry to see the latest state.
+func ConflictRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		MaxAttempts:     DefaultConflictRetryMaxAttempts,
+		InitialDelay:    DefaultConflictRetryInitialDelay,
+		MaxDelay:        DefaultConflictRetryMaxDelay,
+		BackoffFactor:   DefaultRetryBackoffFactor,
+		RetryableErrors: sharedErrors.IsConcurrencyConflict,
+	}
+}
+
+// RetryOnConflict runs fn until it succeeds, fails with an error other than a
+// concurrency conflict or runs out of attempts. fn must reload the aggregate it
+// changes on every call; re-saving a stale copy would conflict again.
+func RetryOnConflict(ctx context.Context, fn func() error) error {
+	return Retry(ctx, ConflictRetryConfig(), fn)
+}
@@ -20,3 +20,10 @@ const (
 	DefaultRetryMaxDelay      time.Duration = 5 * time.Second
 	DefaultRetryBackoffFactor float64       = 2.0
 )
+
+// Concurrency conflict retry configuration values
+const (
+	DefaultConflictRetryMaxAttempts  int           = 5
+	DefaultConflictRetryInitialDelay time.Duration = 10 * time.Millisecond
+	DefaultConflictRetryMaxDelay     time.Duration = 200 * time.Millisecond
+)
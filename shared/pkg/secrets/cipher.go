@@ -0,0 +1,166 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// encryptedPrefix marks a value produced by Cipher.Encrypt. The full format is
+//
+//	enc:v1:<key version>:<base64 wrapped data key>:<base64 nonce+ciphertext>
+const encryptedPrefix = "enc:v1:"
+
+var (
+	// ErrMalformedCiphertext indicates that an encrypted value could not be parsed
+	ErrMalformedCiphertext = errors.New("malformed ciphertext")
+
+	// ErrDecryptionFailed indicates that a value could not be authenticated with its key
+	ErrDecryptionFailed = errors.New("decryption failed")
+
+	// ErrUnknownKeyVersion indicates that a value was encrypted with a key the provider does not have
+	ErrUnknownKeyVersion = errors.New("unknown key version")
+)
+
+// Cipher encrypts individual string values with envelope encryption: every value
+// gets a fresh data key, which is wrapped by the KeyProvider's current KEK and
+// stored alongside the ciphertext together with the KEK version.
+type Cipher struct {
+	provider KeyProvider
+}
+
+// NewCipher creates a new Cipher
+func NewCipher(provider KeyProvider) *Cipher {
+	return &Cipher{provider: provider}
+}
+
+// Encrypt encrypts a value with the current key. Empty values are returned unchanged.
+func (c *Cipher) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	dataKey, err := GenerateKey()
+	if err != nil {
+		return "", err
+	}
+
+	version, wrappedKey, err := c.provider.WrapKey(ctx, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	ciphertext, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return encryptedPrefix + strconv.Itoa(version) + ":" +
+		base64.RawStdEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt decrypts a value produced by Encrypt. Values that are not encrypted are
+// returned unchanged so documents written before encryption was enabled stay readable
+// until they are rotated.
+func (c *Cipher) Decrypt(ctx context.Context, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	version, wrappedKey, ciphertext, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := c.provider.UnwrapKey(ctx, version, wrappedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	plaintext, err := open(dataKey, ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation checks if a value is stored in plaintext or encrypted with a key
+// other than the current one
+func (c *Cipher) NeedsRotation(ctx context.Context, value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	if !IsEncrypted(value) {
+		return true, nil
+	}
+
+	version, err := KeyVersion(value)
+	if err != nil {
+		return false, err
+	}
+	current, err := c.provider.CurrentKeyVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+	return version != current, nil
+}
+
+// Rotate re-encrypts a value with the current key if it needs rotation and reports
+// whether it changed
+func (c *Cipher) Rotate(ctx context.Context, value string) (string, bool, error) {
+	needsRotation, err := c.NeedsRotation(ctx, value)
+	if err != nil || !needsRotation {
+		return value, false, err
+	}
+
+	plaintext, err := c.Decrypt(ctx, value)
+	if err != nil {
+		return value, false, err
+	}
+	rotated, err := c.Encrypt(ctx, plaintext)
+	if err != nil {
+		return value, false, err
+	}
+	return rotated, true, nil
+}
+
+// IsEncrypted checks if a value was produced by Cipher.Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// KeyVersion returns the key version an encrypted value was encrypted with
+func KeyVersion(value string) (int, error) {
+	version, _, _, err := parse(value)
+	return version, err
+}
+
+func parse(value string) (int, []byte, []byte, error) {
+	if !IsEncrypted(value) {
+		return 0, nil, nil, ErrMalformedCiphertext
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, encryptedPrefix), ":")
+	if len(parts) != 3 {
+		return 0, nil, nil, ErrMalformedCiphertext
+	}
+
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, nil, nil, ErrMalformedCiphertext
+	}
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0, nil, nil, ErrMalformedCiphertext
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return 0, nil, nil, ErrMalformedCiphertext
+	}
+
+	return version, wrappedKey, ciphertext, nil
+}
@@ -0,0 +1,189 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func mustKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return key
+}
+
+func mustProvider(t *testing.T, keys map[int][]byte, current int) *LocalKeyProvider {
+	t.Helper()
+	provider, err := NewLocalKeyProvider(keys, current)
+	if err != nil {
+		t.Fatalf("NewLocalKeyProvider() error = %v", err)
+	}
+	return provider
+}
+
+func TestCipherRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	cipher := NewCipher(mustProvider(t, map[int][]byte{1: mustKey(t)}, 1))
+
+	encrypted, err := cipher.Encrypt(ctx, "shpat_secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !IsEncrypted(encrypted) {
+		t.Fatalf("Encrypt() = %q, want encrypted value", encrypted)
+	}
+	if strings.Contains(encrypted, "shpat_secret") {
+		t.Fatalf("Encrypt() leaked plaintext: %q", encrypted)
+	}
+	if version, err := KeyVersion(encrypted); err != nil || version != 1 {
+		t.Errorf("KeyVersion() = %d, %v; want 1", version, err)
+	}
+
+	again, err := cipher.Encrypt(ctx, "shpat_secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if again == encrypted {
+		t.Error("Encrypt() should use a fresh data key and nonce per value")
+	}
+
+	decrypted, err := cipher.Decrypt(ctx, encrypted)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if decrypted != "shpat_secret" {
+		t.Errorf("Decrypt() = %q, want %q", decrypted, "shpat_secret")
+	}
+}
+
+func TestCipherEmptyAndPlaintextValues(t *testing.T) {
+	ctx := context.Background()
+	cipher := NewCipher(mustProvider(t, map[int][]byte{1: mustKey(t)}, 1))
+
+	if encrypted, err := cipher.Encrypt(ctx, ""); err != nil || encrypted != "" {
+		t.Errorf("Encrypt(\"\") = %q, %v; want empty", encrypted, err)
+	}
+	if decrypted, err := cipher.Decrypt(ctx, "legacy-token"); err != nil || decrypted != "legacy-token" {
+		t.Errorf("Decrypt(plaintext) = %q, %v; want value unchanged", decrypted, err)
+	}
+}
+
+func TestCipherDecryptErrors(t *testing.T) {
+	ctx := context.Background()
+	cipher := NewCipher(mustProvider(t, map[int][]byte{1: mustKey(t)}, 1))
+	other := NewCipher(mustProvider(t, map[int][]byte{1: mustKey(t)}, 1))
+
+	encrypted, err := other.Encrypt(ctx, "secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr error
+	}{
+		{
+			name:    "wrong key",
+			value:   encrypted,
+			wantErr: ErrDecryptionFailed,
+		},
+		{
+			name:    "unknown key version",
+			value:   strings.Replace(encrypted, "enc:v1:1:", "enc:v1:7:", 1),
+			wantErr: ErrUnknownKeyVersion,
+		},
+		{
+			name:    "malformed",
+			value:   "enc:v1:1:not-base64",
+			wantErr: ErrMalformedCiphertext,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cipher.Decrypt(ctx, tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Decrypt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCipherRotate(t *testing.T) {
+	ctx := context.Background()
+	key1, key2 := mustKey(t), mustKey(t)
+	oldCipher := NewCipher(mustProvider(t, map[int][]byte{1: key1}, 1))
+	newCipher := NewCipher(mustProvider(t, map[int][]byte{1: key1, 2: key2}, 2))
+
+	encrypted, err := oldCipher.Encrypt(ctx, "secret")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	rotated, changed, err := newCipher.Rotate(ctx, encrypted)
+	if err != nil || !changed {
+		t.Fatalf("Rotate() = %v, %v; want changed", changed, err)
+	}
+	if version, _ := KeyVersion(rotated); version != 2 {
+		t.Errorf("KeyVersion() = %d, want 2", version)
+	}
+	if decrypted, err := newCipher.Decrypt(ctx, rotated); err != nil || decrypted != "secret" {
+		t.Errorf("Decrypt() = %q, %v; want %q", decrypted, err, "secret")
+	}
+
+	if _, changed, err := newCipher.Rotate(ctx, rotated); err != nil || changed {
+		t.Errorf("Rotate() of current value = %v, %v; want unchanged", changed, err)
+	}
+
+	plaintextRotated, changed, err := newCipher.Rotate(ctx, "legacy-token")
+	if err != nil || !changed || !IsEncrypted(plaintextRotated) {
+		t.Errorf("Rotate() of plaintext = %q, %v, %v; want encrypted", plaintextRotated, changed, err)
+	}
+}
+
+func TestLoadLocalKeyProvider(t *testing.T) {
+	key1, key2 := mustKey(t), mustKey(t)
+	path := filepath.Join(t.TempDir(), "keys.json")
+	content := `{"currentVersion": 2, "keys": {"1": "` + base64.StdEncoding.EncodeToString(key1) +
+		`", "2": "` + base64.StdEncoding.EncodeToString(key2) + `"}}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	provider, err := LoadLocalKeyProvider(path)
+	if err != nil {
+		t.Fatalf("LoadLocalKeyProvider() error = %v", err)
+	}
+	if version, _ := provider.CurrentKeyVersion(context.Background()); version != 2 {
+		t.Errorf("CurrentKeyVersion() = %d, want 2", version)
+	}
+}
+
+func TestNewLocalKeyProviderValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    map[int][]byte
+		current int
+	}{
+		{name: "no keys", keys: map[int][]byte{}, current: 1},
+		{name: "short key", keys: map[int][]byte{1: []byte("short")}, current: 1},
+		{name: "missing current", keys: map[int][]byte{1: make([]byte, KeySize)}, current: 2},
+		{name: "non-positive version", keys: map[int][]byte{0: make([]byte, KeySize)}, current: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewLocalKeyProvider(tt.keys, tt.current); err == nil {
+				t.Error("NewLocalKeyProvider() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// KeySize is the size in bytes of key-encryption and data keys (AES-256)
+const KeySize = 32
+
+// KeyProvider supplies the key-encryption keys (KEKs) that wrap the per-value
+// data keys used for envelope encryption. Implementations may keep KEKs locally
+// or delegate wrapping to an external KMS; KEKs never leave the provider.
+type KeyProvider interface {
+	// CurrentKeyVersion returns the KEK version new values are encrypted with
+	CurrentKeyVersion(ctx context.Context) (int, error)
+
+	// WrapKey encrypts a data key with the current KEK and returns the KEK version used
+	WrapKey(ctx context.Context, dataKey []byte) (version int, wrapped []byte, err error)
+
+	// UnwrapKey decrypts a data key that was wrapped with the given KEK version
+	UnwrapKey(ctx context.Context, version int, wrapped []byte) ([]byte, error)
+}
+
+// LocalKeyProvider keeps versioned KEKs in memory, typically loaded from a key file.
+// It is meant for local development and tests; production deployments should use a
+// KMS-backed provider.
+type LocalKeyProvider struct {
+	keys    map[int][]byte
+	current int
+}
+
+// localKeyFile is the on-disk format read by LoadLocalKeyProvider
+type localKeyFile struct {
+	CurrentVersion int               `json:"currentVersion"`
+	Keys           map[string]string `json:"keys"` // version -> base64 encoded key
+}
+
+// NewLocalKeyProvider creates a provider from versioned 32-byte keys
+func NewLocalKeyProvider(keys map[int][]byte, currentVersion int) (*LocalKeyProvider, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one key is required")
+	}
+	copied := make(map[int][]byte, len(keys))
+	for version, key := range keys {
+		if version <= 0 {
+			return nil, fmt.Errorf("key version must be positive, got %d", version)
+		}
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("key version %d must be %d bytes, got %d", version, KeySize, len(key))
+		}
+		copied[version] = append([]byte(nil), key...)
+	}
+	if _, ok := copied[currentVersion]; !ok {
+		return nil, fmt.Errorf("current key version %d not found", currentVersion)
+	}
+	return &LocalKeyProvider{keys: copied, current: currentVersion}, nil
+}
+
+// LoadLocalKeyProvider reads a key file of the form
+//
+//	{"currentVersion": 2, "keys": {"1": "<base64 key>", "2": "<base64 key>"}}
+//
+// Rotating keys means adding a new version, making it current and re-encrypting
+// existing values; old versions must stay in the file until nothing uses them.
+func LoadLocalKeyProvider(path string) (*LocalKeyProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	var file localKeyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse key file: %w", err)
+	}
+
+	keys := make(map[int][]byte, len(file.Keys))
+	for v, encoded := range file.Keys {
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key version %q", v)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key version %d: %w", version, err)
+		}
+		keys[version] = key
+	}
+
+	return NewLocalKeyProvider(keys, file.CurrentVersion)
+}
+
+// CurrentKeyVersion returns the version new values are encrypted with
+func (p *LocalKeyProvider) CurrentKeyVersion(ctx context.Context) (int, error) {
+	return p.current, nil
+}
+
+// WrapKey encrypts a data key with the current KEK
+func (p *LocalKeyProvider) WrapKey(ctx context.Context, dataKey []byte) (int, []byte, error) {
+	wrapped, err := seal(p.keys[p.current], dataKey)
+	if err != nil {
+		return 0, nil, err
+	}
+	return p.current, wrapped, nil
+}
+
+// UnwrapKey decrypts a data key wrapped with the given KEK version
+func (p *LocalKeyProvider) UnwrapKey(ctx context.Context, version int, wrapped []byte) ([]byte, error) {
+	key, ok := p.keys[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownKeyVersion, version)
+	}
+	return open(key, wrapped)
+}
+
+// GenerateKey returns a new random 32-byte key
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// seal encrypts plaintext with AES-256-GCM, prefixing the random nonce
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts a nonce-prefixed AES-256-GCM ciphertext
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrMalformedCiphertext
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -14,8 +14,9 @@ import (
 
 // MongoDBContainer wraps a testcontainers MongoDB instance
 type MongoDBContainer struct {
-	Container *mongodb.MongoDBContainer
-	URI       string
+	Container  *mongodb.MongoDBContainer
+	URI        string
+	ReplicaSet bool
 }
 
 // NewMongoDBContainer creates a new MongoDB testcontainer
@@ -40,6 +41,29 @@ func NewMongoDBContainer(ctx context.Context) (*MongoDBContainer, error) {
 	}, nil
 }
 
+// NewMongoDBReplicaSetContainer creates a single-node MongoDB replica set testcontainer.
+// Repositories that save aggregates and outbox events in one transaction need a replica set.
+func NewMongoDBReplicaSetContainer(ctx context.Context) (*MongoDBContainer, error) {
+	mongoContainer, err := mongodb.Run(ctx,
+		"mongo:6",
+		mongodb.WithReplicaSet("rs"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start mongodb replica set container: %w", err)
+	}
+
+	uri, err := mongoContainer.ConnectionString(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection string: %w", err)
+	}
+
+	return &MongoDBContainer{
+		Container:  mongoContainer,
+		URI:        uri,
+		ReplicaSet: true,
+	}, nil
+}
+
 // Close terminates the MongoDB container
 func (m *MongoDBContainer) Close(ctx context.Context) error {
 	if m.Container != nil {
@@ -51,6 +75,10 @@ func (m *MongoDBContainer) Close(ctx context.Context) error {
 // GetClient creates a MongoDB client connected to the test container
 func (m *MongoDBContainer) GetClient(ctx context.Context) (*mongo.Client, error) {
 	clientOptions := options.Client().ApplyURI(m.URI)
+	if m.ReplicaSet {
+		// The replica set advertises the container hostname, which is not reachable from the host
+		clientOptions.SetDirect(true)
+	}
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to mongodb: %w", err)